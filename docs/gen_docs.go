@@ -261,6 +261,18 @@ func generateConfigReference() error {
 	b.WriteString(configCompleteExampleProse)
 	b.WriteString("\n\n")
 
+	// Profiles section.
+	b.WriteString(configProfilesProse)
+	b.WriteString("\n\n")
+
+	// Shared base configuration section.
+	b.WriteString(configExtendsProse)
+	b.WriteString("\n\n")
+
+	// Effective configuration section.
+	b.WriteString(configViewSetProse)
+	b.WriteString("\n\n")
+
 	// Configuration Reference section — generated from types.
 	b.WriteString("## Configuration Reference\n\n")
 	generateConfigReferenceTables(&b, fieldDocs)
@@ -271,6 +283,10 @@ func generateConfigReference() error {
 
 	// Schema support section.
 	b.WriteString(configSchemaProse)
+	b.WriteString("\n\n")
+
+	// API version migration section.
+	b.WriteString(configMigrationProse)
 	b.WriteString("\n")
 
 	if err := os.MkdirAll(filepath.Dir(configDoc), dirPermissions); err != nil {
@@ -344,12 +360,84 @@ func generateConfigReferenceTables(b *strings.Builder, fieldDocs docs.FieldDocs)
 		reflect.TypeOf(v1alpha1.CertManager("")),
 		certManagerDetails,
 	)
+	generateEnumSection(
+		b,
+		"sealedSecrets",
+		reflect.TypeOf(v1alpha1.SealedSecrets("")),
+		sealedSecretsDetails,
+	)
+	generateEnumSection(
+		b,
+		"externalSecrets",
+		reflect.TypeOf(v1alpha1.ExternalSecrets("")),
+		externalSecretsDetails,
+	)
+	generateEnumSection(
+		b,
+		"backup",
+		reflect.TypeOf(v1alpha1.Backup("")),
+		backupDetails,
+	)
 	generateEnumSection(
 		b,
 		"policyEngine",
 		reflect.TypeOf(v1alpha1.PolicyEngine("")),
 		policyEngineDetails,
 	)
+	generateEnumSection(
+		b,
+		"ingressController",
+		reflect.TypeOf(v1alpha1.IngressController("")),
+		ingressControllerDetails,
+	)
+	generateEnumSection(
+		b,
+		"gatewayAPI",
+		reflect.TypeOf(v1alpha1.GatewayAPI("")),
+		gatewayAPIDetails,
+	)
+	generateEnumSection(
+		b,
+		"serviceMesh",
+		reflect.TypeOf(v1alpha1.ServiceMesh("")),
+		serviceMeshDetails,
+	)
+	generateEnumSection(
+		b,
+		"istio.mode",
+		reflect.TypeOf(v1alpha1.IstioMode("")),
+		istioModeDetails,
+	)
+	generateEnumSection(
+		b,
+		"knative",
+		reflect.TypeOf(v1alpha1.Knative("")),
+		knativeDetails,
+	)
+	generateEnumSection(
+		b,
+		"identityProvider",
+		reflect.TypeOf(v1alpha1.IdentityProvider("")),
+		identityProviderDetails,
+	)
+	generateEnumSection(
+		b,
+		"observability.logging",
+		reflect.TypeOf(v1alpha1.Logging("")),
+		loggingDetails,
+	)
+	generateEnumSection(
+		b,
+		"spot.reclaimPolicy",
+		reflect.TypeOf(v1alpha1.SpotReclaimPolicy("")),
+		spotReclaimPolicyDetails,
+	)
+	generateEnumSection(
+		b,
+		"network.ipFamily",
+		reflect.TypeOf(v1alpha1.IPFamily("")),
+		ipFamilyDetails,
+	)
 
 	b.WriteString(configLocalRegistryProse)
 	b.WriteString("\n\n")
@@ -379,10 +467,31 @@ func generateConfigReferenceTables(b *strings.Builder, fieldDocs docs.FieldDocs)
 	b.WriteString(docs.RenderFieldTable(reflect.TypeOf(v1alpha1.WorkloadSpec{}), "", fieldDocs))
 	b.WriteString("\n")
 
+	// spec.trust (TrustSpec).
+	b.WriteString("### spec.trust (TrustSpec)\n\n")
+	b.WriteString(docs.RenderFieldTable(reflect.TypeOf(v1alpha1.TrustSpec{}), "", fieldDocs))
+	b.WriteString("\n")
+	b.WriteString(configTrustProse)
+	b.WriteString("\n\n")
+
+	// spec.seed (SeedSpec) and its SeedResource entries.
+	b.WriteString(docs.RenderTypeSections(
+		"spec.seed", reflect.TypeOf(v1alpha1.SeedSpec{}), fieldDocs,
+	))
+	b.WriteString(configSeedProse)
+	b.WriteString("\n\n")
+
 	// spec.chat (ChatSpec).
 	b.WriteString("### spec.chat (ChatSpec)\n\n")
 	b.WriteString(docs.RenderFieldTable(reflect.TypeOf(v1alpha1.ChatSpec{}), "", fieldDocs))
 	b.WriteString("\n")
+
+	// spec.notify (NotifySpec).
+	b.WriteString("### spec.notify (NotifySpec)\n\n")
+	b.WriteString(docs.RenderFieldTable(reflect.TypeOf(v1alpha1.NotifySpec{}), "", fieldDocs))
+	b.WriteString("\n")
+	b.WriteString(configNotifyProse)
+	b.WriteString("\n\n")
 }
 
 // generateEnumSection writes a #### section documenting an enum field
@@ -68,6 +68,16 @@ spec:
 | ` + bt + `${VAR:-default}` + bt + ` | Uses value   | Uses default (no warning) |
 | ` + bt + `${VAR:-}` + bt + `        | Uses value   | Empty string (no warning) |
 
+### Strict Mode
+
+Set ` + bt + `KSAIL_STRICT_ENV=true` + bt + ` to turn the warning for an unset ` + bt + `${VAR}` + bt + ` into an error. KSail then refuses to load ` + bt + `ksail.yaml` + bt + `, ` + bt + `kind.yaml` + bt + `, ` + bt + `k3d.yaml` + bt + `, or a Talos patch that references an unset variable without a default, and names every missing variable in the error. Use it in CI, or when each developer supplies values such as registry hosts and versions from their own environment, so a forgotten export fails fast instead of producing a cluster with an empty value:
+
+` + cbt + `bash
+export KSAIL_STRICT_ENV=true
+export REGISTRY=registry.example.com:5000
+ksail cluster create
+` + cbt + `
+
 ### Scope
 
 Environment variables are expanded in all string fields of ` + bt + `ksail.yaml` + bt + `, distribution configs (` + bt + `kind.yaml` + bt + `, ` + bt + `k3d.yaml` + bt + `), and Talos patch files (` + bt + `talos/cluster/` + bt + `, ` + bt + `talos/control-planes/` + bt + `, ` + bt + `talos/workers/` + bt + `):
@@ -277,6 +287,7 @@ const configConnectionProse = `#### connection (Connection)
 | ` + bt + `kubeconfig` + bt + ` | string | ` + bt + `~/.kube/config` + bt + ` | Path to kubeconfig file |
 | ` + bt + `context` + bt + ` | string | (derived) | Kubeconfig context name |
 | ` + bt + `timeout` + bt + ` | duration | – | Timeout for cluster operations |
+| ` + bt + `apiServerPort` + bt + ` | integer | ` + bt + `0` + bt + ` (random) | Localhost port the Kubernetes API is published on |
 
 **Context defaults by distribution:**
 
@@ -289,7 +300,9 @@ const configConnectionProse = `#### connection (Connection)
 
 When using Talos with Omni, Omni generates the context name; set ` + bt + `spec.cluster.connection.context` + bt + ` to that generated name.
 
-**Timeout format:** Go duration string (e.g., ` + bt + `30s` + bt + `, ` + bt + `5m` + bt + `, ` + bt + `1h` + bt + `)`
+**Timeout format:** Go duration string (e.g., ` + bt + `30s` + bt + `, ` + bt + `5m` + bt + `, ` + bt + `1h` + bt + `)
+
+**Stable API server port:** by default the Docker provider publishes the Kubernetes API on a random localhost port, so the kubeconfig changes every time the cluster is recreated. Set ` + bt + `apiServerPort` + bt + ` (Vanilla, K3s, and Talos on Docker) to pin it, so kubeconfigs checked into dev tooling keep working. ` + bt + `ksail cluster create` + bt + ` refuses to start when the port is pinned by another KSail cluster or bound by another process.`
 
 // cniDetails provides prose after the CNI enum list.
 const cniDetails = `See [CNI](/concepts/#container-network-interface-cni) for more details.
@@ -327,6 +340,24 @@ const certManagerDetails = `Whether to install [cert-manager](/concepts/#cert-ma
 - ` + bt + `Enabled` + bt + ` – Install cert-manager
 - ` + bt + `Disabled` + bt + ` (default) – Skip installation`
 
+// sealedSecretsDetails provides prose after the SealedSecrets enum list.
+const sealedSecretsDetails = `Whether to install the [Sealed Secrets](https://github.com/bitnami-labs/sealed-secrets) controller as ` + bt + `sealed-secrets-controller` + bt + ` in ` + bt + `kube-system` + bt + `. Convert Secret manifests into SealedSecrets with ` + bt + `ksail workload cipher seal` + bt + `.
+
+- ` + bt + `Enabled` + bt + ` – Install the Sealed Secrets controller
+- ` + bt + `Disabled` + bt + ` (default) – Skip installation`
+
+// externalSecretsDetails provides prose after the ExternalSecrets enum list.
+const externalSecretsDetails = `Whether to install the [External Secrets Operator](https://external-secrets.io) as ` + bt + `external-secrets` + bt + ` in the ` + bt + `external-secrets` + bt + ` namespace. When enabled, ` + bt + `ksail project init` + bt + ` scaffolds a ` + bt + `ClusterSecretStore` + bt + ` backed by the operator's fake provider, so ` + bt + `ExternalSecret` + bt + ` manifests can be exercised locally before pointing them at Vault, AWS Secrets Manager, or another real backend.
+
+- ` + bt + `Enabled` + bt + ` – Install the External Secrets Operator
+- ` + bt + `Disabled` + bt + ` (default) – Skip installation`
+
+// backupDetails provides prose after the Backup enum list.
+const backupDetails = `In-cluster backup system. With ` + bt + `Velero` + bt + `, KSail installs [Velero](https://velero.io) in the ` + bt + `velero` + bt + ` namespace and starts a [MinIO](https://min.io) container on the cluster's Docker network as its S3 backup target. Take backups with ` + bt + `ksail cluster backup --velero` + bt + ` and restore them with ` + bt + `ksail cluster restore-backup` + bt + `. Only supported on the ` + bt + `Docker` + bt + ` provider. See [Backup & Restore](/guides/backup-restore/) for details.
+
+- ` + bt + `None` + bt + ` (default) – No backup system
+- ` + bt + `Velero` + bt + ` – Install Velero with a local MinIO target`
+
 // policyEngineDetails provides prose after the PolicyEngine enum list.
 const policyEngineDetails = `Policy engine to install for enforcing security, compliance, and best practices. See [Policy Engines](/concepts/#policy-engines) for details.
 
@@ -334,6 +365,66 @@ const policyEngineDetails = `Policy engine to install for enforcing security, co
 - ` + bt + `Kyverno` + bt + ` – Install [Kyverno](https://kyverno.io/)
 - ` + bt + `Gatekeeper` + bt + ` – Install [OPA Gatekeeper](https://open-policy-agent.github.io/gatekeeper/)`
 
+// ingressControllerDetails provides prose after the IngressController enum list.
+const ingressControllerDetails = `Ingress controller to install. On the Docker provider the controller is exposed on ` + bt + `localhost:80` + bt + ` and ` + bt + `localhost:443` + bt + ` through host port mappings that Kind, K3d, and Talos add at cluster creation; other providers get a ` + bt + `LoadBalancer` + bt + ` Service.
+
+- ` + bt + `None` + bt + ` (default) – No ingress controller
+- ` + bt + `Nginx` + bt + ` – Install [ingress-nginx](https://kubernetes.github.io/ingress-nginx/)
+- ` + bt + `Traefik` + bt + ` – Install [Traefik](https://traefik.io/traefik/)
+- ` + bt + `Contour` + bt + ` – Install [Contour](https://projectcontour.io/) with Envoy`
+
+// gatewayAPIDetails provides prose after the GatewayAPI enum list.
+const gatewayAPIDetails = `Gateway API support. When a controller is selected, ` + bt + `ksail project init` + bt + ` scaffolds an example ` + bt + `Gateway` + bt + ` and ` + bt + `HTTPRoute` + bt + ` (` + bt + `gateway.yaml` + bt + `) into the workload kustomization. See [Gateway API](/configuration/gateway-api/).
+
+- ` + bt + `None` + bt + ` (default) – Nothing beyond what the CNI installs (the Cilium CNI always ships its Gateway API controller)
+- ` + bt + `CRDs` + bt + ` – Install only the [Gateway API](https://gateway-api.sigs.k8s.io/) CRDs, for a controller you manage yourself
+- ` + bt + `Cilium` + bt + ` – Use the Cilium CNI's built-in controller (GatewayClass ` + bt + `cilium` + bt + `); requires ` + bt + `cni: Cilium` + bt + `
+- ` + bt + `Envoy` + bt + ` – Install the Gateway API CRDs and [Envoy Gateway](https://gateway.envoyproxy.io/) (GatewayClass ` + bt + `envoy-gateway` + bt + `)`
+
+// serviceMeshDetails provides prose after the ServiceMesh enum list.
+const serviceMeshDetails = `Service mesh to install. KSail waits for the control plane to become ready, and when a mesh is selected ` + bt + `ksail project init` + bt + ` scaffolds ` + bt + `namespace.yaml` + bt + ` for the ` + bt + `default` + bt + ` namespace with the mesh's injection label or annotation, so scaffolded workloads join the mesh. Pods that already run pick up the mesh on their next restart.
+
+- ` + bt + `None` + bt + ` (default) – No service mesh
+- ` + bt + `Istio` + bt + ` – Install [Istio](https://istio.io/) (` + bt + `base` + bt + ` and ` + bt + `istiod` + bt + `, plus ` + bt + `istio-cni` + bt + ` and ` + bt + `ztunnel` + bt + ` in ambient mode) into ` + bt + `istio-system` + bt + `; see ` + bt + `istio.mode` + bt + `
+- ` + bt + `Linkerd` + bt + ` – Install [Linkerd](https://linkerd.io/) (edge channel) into ` + bt + `linkerd` + bt + `, generating a trust anchor and issuer certificate on first install and reusing them on upgrades`
+
+// istioModeDetails provides prose after the IstioMode enum list.
+const istioModeDetails = `Istio data-plane mode, used when ` + bt + `serviceMesh` + bt + ` is ` + bt + `Istio` + bt + `.
+
+- ` + bt + `Sidecar` + bt + ` (default) – Inject an Envoy proxy into every pod; namespaces are labeled ` + bt + `istio-injection: enabled` + bt + `
+- ` + bt + `Ambient` + bt + ` – Run without sidecars through the Istio CNI node agent and the per-node ztunnel proxy; namespaces are labeled ` + bt + `istio.io/dataplane-mode: ambient` + bt
+
+// knativeDetails provides prose after the Knative enum list.
+const knativeDetails = `Whether to install [Knative Serving](https://knative.dev/docs/serving/) for local serverless development. KSail installs the Knative Operator into ` + bt + `knative-operator` + bt + ` and applies a ` + bt + `KnativeServing` + bt + ` resource that deploys Serving with the [Kourier](https://github.com/knative-extensions/net-kourier) networking layer into ` + bt + `knative-serving` + bt + `. On the Docker provider, Kourier listens on node port ` + bt + `31080` + bt + `, which Kind and K3d map to ` + bt + `localhost:8080` + bt + `, and services get [sslip.io](https://sslip.io) magic DNS names that resolve to ` + bt + `127.0.0.1` + bt + `, so a service ` + bt + `hello` + bt + ` in the ` + bt + `default` + bt + ` namespace answers on ` + bt + `http://hello.default.127.0.0.1.sslip.io:8080` + bt + `. The host port mapping is only added when the cluster is created.
+
+- ` + bt + `Enabled` + bt + ` – Install Knative Serving with Kourier
+- ` + bt + `Disabled` + bt + ` (default) – Skip installation`
+
+// identityProviderDetails provides prose after the IdentityProvider enum list.
+const identityProviderDetails = `Development identity provider for testing OIDC login and RBAC group mapping locally. Vanilla and K3s on the Docker provider only. With ` + bt + `Keycloak` + bt + `, KSail runs [Keycloak](https://www.keycloak.org) in dev mode in the ` + bt + `keycloak` + bt + ` namespace and imports the ` + bt + `ksail` + bt + ` realm: a public ` + bt + `kubernetes` + bt + ` client whose ID tokens carry a ` + bt + `groups` + bt + ` claim, and the test users ` + bt + `alice` + bt + ` (password ` + bt + `alice` + bt + `, group ` + bt + `developers` + bt + `) and ` + bt + `bob` + bt + ` (password ` + bt + `bob` + bt + `, group ` + bt + `viewers` + bt + `). Keycloak serves HTTPS on node port ` + bt + `31843` + bt + `, which Kind and K3d map to the same host port, with a certificate signed by the KSail CA in ` + bt + `~/.ksail/registry-tls` + bt + `. ` + bt + `ksail project init --identity-provider Keycloak` + bt + ` fills the empty ` + bt + `oidc` + bt + ` fields with issuer ` + bt + `https://127.0.0.1:31843/realms/ksail` + bt + `, client ID ` + bt + `kubernetes` + bt + ` and that CA, so the scaffolded distribution config points the API server at Keycloak. The admin console is at ` + bt + `https://127.0.0.1:31843/admin` + bt + ` (user and password ` + bt + `admin` + bt + `).
+
+- ` + bt + `None` + bt + ` (default) – No identity provider
+- ` + bt + `Keycloak` + bt + ` – Install Keycloak with the ` + bt + `ksail` + bt + ` realm, test users and groups`
+
+// loggingDetails provides prose after the Logging enum list.
+const loggingDetails = `Cluster-wide log aggregation stack, installed into the ` + bt + `logging` + bt + ` namespace after the cluster is created. Query logs with Grafana or ` + bt + `logcli` + bt + ` against ` + bt + `http://loki.logging.svc.cluster.local:3100` + bt + `.
+
+- ` + bt + `None` + bt + ` (default) – No log aggregation
+- ` + bt + `Loki` + bt + ` – Install [Grafana Loki](https://grafana.com/oss/loki/) in single-binary mode with filesystem storage, plus [Promtail](https://grafana.com/docs/loki/latest/send-data/promtail/) as a DaemonSet shipping every container's logs to it`
+
+// spotReclaimPolicyDetails provides prose after the SpotReclaimPolicy enum list.
+const spotReclaimPolicyDetails = `What happens when the cloud reclaims a spot instance. Spot capacity is requested with ` + bt + `spot.enabled: true` + bt + ` for the EKS, GKE, and AKS distributions: KSail marks every node pool in ` + bt + `gke.yaml` + bt + ` and every ` + bt + `User` + bt + ` agent pool in ` + bt + `aks.yaml` + bt + ` as spot at create time, and ` + bt + `ksail project init` + bt + ` scaffolds the ` + bt + `eks.yaml` + bt + ` managed node group as a spot group diversified across instance types. AKS system pools stay on-demand, and AKS taints spot pools with ` + bt + `kubernetes.azure.com/scalesetpriority=spot:NoSchedule` + bt + `. ` + bt + `ksail cluster info` + bt + ` marks spot node pools on GKE and AKS.
+
+- ` + bt + `Replace` + bt + ` (default) – Delete reclaimed instances so the node pool provisions replacements as capacity returns (EKS capacity rebalancing, GKE managed instance groups, AKS eviction policy ` + bt + `Delete` + bt + `)
+- ` + bt + `Deallocate` + bt + ` – Stop reclaimed VMs and keep their disks for a later restart (AKS only)`
+
+// ipFamilyDetails provides prose after the IPFamily enum list.
+const ipFamilyDetails = `IP family of the cluster's pods and services. IPv6 and dual-stack are supported by the Vanilla (Kind), K3s (K3d), and Talos distributions on the Docker provider; other combinations fail validation. KSail creates the cluster's Docker network with IPv6 enabled, so the Docker daemon needs an IPv6 address pool (Docker 27+ ships a default ULA pool). Kind sets ` + bt + `networking.ipFamily` + bt + `, K3d gets IPv6 ` + bt + `--cluster-cidr` + bt + ` and ` + bt + `--service-cidr` + bt + ` server args, and Talos gets an ` + bt + `ip-family.yaml` + bt + ` cluster patch with the pod and service subnets. Dual-stack lists the IPv4 subnet first.
+
+- ` + bt + `ipv4` + bt + ` (default) – IPv4-only pods and services
+- ` + bt + `ipv6` + bt + ` – IPv6-only pods and services
+- ` + bt + `dual` + bt + ` – Dual-stack pods and services with both IPv4 and IPv6 addresses`
+
 // configLocalRegistryProse describes the localRegistry sub-object.
 const configLocalRegistryProse = `#### localRegistry
 
@@ -348,7 +439,64 @@ Registry configuration for GitOps workflows. Supports local Docker registries or
 - ` + bt + `${USER}:${PASS}@ghcr.io:443/myorg` + bt + ` – With credentials from environment variables
 
 > [!NOTE]
-> Credentials support ` + bt + `${ENV_VAR}` + bt + ` placeholders for secure handling.`
+> Credentials support ` + bt + `${ENV_VAR}` + bt + ` placeholders for secure handling.
+
+**Retention:** ` + bt + `retention.keepLast` + bt + ` and ` + bt + `retention.maxAge` + bt + ` limit the artifact tags the local registry keeps per repository. After every ` + bt + `ksail workload push` + bt + `, KSail deletes the older tags of the pushed repository and garbage-collects the registry; ` + bt + `ksail registry prune-artifacts` + bt + ` applies the policy to every repository. The most recently pushed tag is always kept, and external registries are never pruned.
+
+**Host binding:** On the Docker provider the local registry is published on ` + bt + `127.0.0.1` + bt + ` and mirror registries are only reachable from the cluster's Docker network. Set ` + bt + `registryBinding.hostIP` + bt + ` (for example ` + bt + `0.0.0.0` + bt + `) to publish the registries on another host address, and ` + bt + `registryBinding.mirrorPorts` + bt + ` to publish mirrors on fixed host ports keyed by the registry they mirror (for example ` + bt + `docker.io: 5001` + bt + `), so other machines such as sibling VMs can pull from them. Mirror ports apply to the Vanilla, Talos, and VCluster distributions; K3d mirrors are created from ` + bt + `k3d.yaml` + bt + `.`
+
+// configTrustProse follows the spec.trust field table.
+const configTrustProse = `Use ` + bt + `caBundles` + bt + ` when the cluster runs behind a TLS-intercepting (MITM) proxy, so nodes can pull images and workloads can call webhooks and external APIs. Each entry is a path to a PEM file, resolved against the ` + bt + `ksail.yaml` + bt + ` directory, or inline PEM content. KSail rejects entries that are not X.509 certificates before creating the cluster.
+
+- **Vanilla (Kind):** after the nodes start, KSail writes the bundle to ` + bt + `/usr/local/share/ca-certificates/ksail-ca-bundle.crt` + bt + ` on every node, runs ` + bt + `update-ca-certificates` + bt + `, and restarts containerd.
+- **Talos:** KSail adds a ` + bt + `TrustedRootsConfig` + bt + ` document named ` + bt + `ksail-ca-bundle` + bt + ` to the machine configuration.
+- **Workloads:** KSail installs [trust-manager](https://cert-manager.io/docs/trust/trust-manager/) into ` + bt + `cert-manager` + bt + ` and applies a ` + bt + `Bundle` + bt + ` that combines the default public CAs with your certificates. trust-manager publishes the result as the ` + bt + `ksail-ca-bundle` + bt + ` ConfigMap in every namespace, under the ` + bt + `ca-certificates.crt` + bt + ` key, ready to mount at ` + bt + `/etc/ssl/certs` + bt + `.
+
+Other distributions only receive the ConfigMap. Certificates are installed when the cluster is created.
+
+` + cbt + `yaml
+spec:
+  trust:
+    caBundles:
+      - certs/corporate-proxy.pem
+` + cbt
+
+// configSeedProse follows the spec.seed field tables.
+const configSeedProse = `Use ` + bt + `seed` + bt + ` for the configuration a cluster needs before GitOps takes over, such as API tokens, feature flags, or a CA file, instead of a bootstrap kustomization. KSail applies the ConfigMaps and Secrets right after the cluster components are installed on ` + bt + `ksail cluster create` + bt + `, and again on every ` + bt + `ksail cluster update` + bt + `, so values edited in ` + bt + `ksail.yaml` + bt + ` or changed in the cluster converge back. Missing namespaces are created. Resources are server-side applied with the ` + bt + `ksail-seed` + bt + ` field manager and labelled ` + bt + `app.kubernetes.io/managed-by: ksail` + bt + `; removing an entry from ` + bt + `ksail.yaml` + bt + ` does not delete it from the cluster.
+
+Each key takes its value from ` + bt + `data` + bt + ` or from a file in ` + bt + `files` + bt + `. Values and file paths expand ` + bt + `${VAR}` + bt + ` placeholders from the environment KSail runs in, which makes ` + bt + `data` + bt + ` the place to hand CI or shell variables to workloads. A file that is a SOPS-encrypted YAML or JSON document is decrypted with your local SOPS keys (for example the age key imported with ` + bt + `ksail workload cipher import` + bt + `), so secrets can live in Git encrypted.
+
+` + cbt + `yaml
+spec:
+  seed:
+    configMaps:
+      - name: app-config
+        namespace: apps
+        data:
+          LOG_LEVEL: debug
+          API_URL: ${API_URL}
+    secrets:
+      - name: app-credentials
+        namespace: apps
+        files:
+          credentials.yaml: secrets/credentials.sops.yaml
+` + cbt
+
+// configNotifyProse follows the spec.notify field table.
+const configNotifyProse = `Use ` + bt + `notify` + bt + ` on shared automation hosts to keep a durable record of what KSail did beyond the terminal scrollback. Every message and stage title KSail prints is also sent to each configured sink, followed by the command error if the operation fails.
+
+- **` + bt + `logDir` + bt + `:** one plain-text log file per operation, named after its start time and command (for example ` + bt + `20261018-120000-cluster-create.log` + bt + `).
+- **` + bt + `syslog` + bt + `:** every message goes to the local syslog daemon under the ` + bt + `ksail` + bt + ` tag; errors and warnings keep their priority.
+- **` + bt + `webhook` + bt + `:** when the operation finishes, KSail POSTs one JSON document with the ` + bt + `operation` + bt + ` and its ` + bt + `records` + bt + ` (` + bt + `time` + bt + `, ` + bt + `type` + bt + `, ` + bt + `content` + bt + `). Reference the URL from an environment variable when it embeds a token.
+
+A sink that cannot be opened is reported as a warning and does not fail the command.
+
+` + cbt + `yaml
+spec:
+  notify:
+    logDir: .ksail/logs
+    webhook: ${KSAIL_NOTIFY_WEBHOOK}
+` + cbt
 
 // gitOpsEngineDetails provides prose after the GitOpsEngine enum list.
 const gitOpsEngineDetails = `GitOps engine for continuous deployment. See [GitOps](/concepts/#gitops). When set to ` + bt + `Flux` + bt + ` or ` + bt + `ArgoCD` + bt + `, KSail scaffolds a GitOps CR into your source directory.
@@ -380,7 +528,60 @@ The Kubernetes version is set at the top level (` + bt + `spec.cluster.kubernete
 
 **Vanilla options (` + bt + `spec.cluster.vanilla` + bt + `):**
 
-- ` + bt + `mirrorsDir` + bt + ` – Directory for containerd host mirror configuration`
+- ` + bt + `mirrorsDir` + bt + ` – Directory for containerd host mirror configuration
+- ` + bt + `nodeImageDockerfile` + bt + ` – Dockerfile snippet baked into a derived Kind node image (e.g. ` + bt + `RUN apt-get update && apt-get install -y nfs-common` + bt + `); the image is built before create, cached locally, and rebuilt only when the snippet or base image changes
+
+**Tenancy options (` + bt + `spec.cluster.tenancy` + bt + `):** share one Docker host between several users (Vanilla and K3s on the Docker provider).
+
+- ` + bt + `enabled` + bt + ` – Prefix the cluster name, node containers, registry containers, and kubeconfig context with the tenant; Kind clusters also use a per-tenant Docker network (` + bt + `kind-<tenant>` + bt + `). Can be set host-wide with ` + bt + `KSAIL_SPEC_CLUSTER_TENANCY_ENABLED=true` + bt + `
+- ` + bt + `prefix` + bt + ` – Tenant name (lowercase RFC 1123 label; default: the current OS user)
+
+` + bt + `ksail cluster list` + bt + ` only shows the current tenant's Kind and K3d clusters; pass ` + bt + `--all-users` + bt + ` to see every tenant's. The cloud-provider-kind controller is shared across the host and stays on the default ` + bt + `kind` + bt + ` network, so LoadBalancer services of tenant-scoped Kind clusters are not reachable through it.
+
+**Actions runner options (` + bt + `spec.cluster.actionsRunner` + bt + `):** install [Actions Runner Controller](https://github.com/actions/actions-runner-controller) with one runner scale set, so workflows can run jobs on the local cluster with ` + bt + `runs-on: <name>` + bt + `.
+
+- ` + bt + `enabled` + bt + ` – Install the controller in ` + bt + `arc-systems` + bt + ` and the scale set in ` + bt + `arc-runners` + bt + `
+- ` + bt + `githubConfigURL` + bt + ` – Repository (` + bt + `https://github.com/org/repo` + bt + `) or organization (` + bt + `https://github.com/org` + bt + `) the runners register with
+- ` + bt + `name` + bt + ` – Scale set name targeted by ` + bt + `runs-on` + bt + ` (default: ` + bt + `ksail` + bt + `)
+- ` + bt + `labels` + bt + ` – Additional ` + bt + `runs-on` + bt + ` labels
+- ` + bt + `minRunners` + bt + ` / ` + bt + `maxRunners` + bt + ` – Idle runners kept ready (default: ` + bt + `0` + bt + `) and the concurrency cap (default: unbounded)
+
+The registration token is never read from ` + bt + `ksail.yaml` + bt + `: KSail uses the ` + bt + `github.token` + bt + ` entry of its credential store, falling back to ` + bt + `GITHUB_TOKEN` + bt + `. The token needs the ` + bt + `repo` + bt + ` scope for a repository, or ` + bt + `admin:org` + bt + ` for an organization. The runner is installed by ` + bt + `ksail cluster create` + bt + ` and is skipped on KWOK.
+
+**Vulnerability policy options (` + bt + `spec.cluster.vulnerabilityPolicy` + bt + `):** scaffold a Kyverno policy (` + bt + `policies/block-vulnerable-images.yaml` + bt + `) that blocks images at admission unless they carry a cosign vulnerability attestation of a Trivy scan without blocked findings. Requires ` + bt + `policyEngine: Kyverno` + bt + `.
+
+- ` + bt + `severity` + bt + ` – Lowest severity that blocks an image: ` + bt + `None` + bt + ` (default, no policy), ` + bt + `Low` + bt + `, ` + bt + `Medium` + bt + `, ` + bt + `High` + bt + `, or ` + bt + `Critical` + bt + `
+- ` + bt + `allowlist` + bt + ` – Vulnerability IDs that never block an image (e.g. ` + bt + `CVE-2024-3094` + bt + `)
+- ` + bt + `images` + bt + ` – Image reference patterns the policy applies to (default: every image)
+
+See [Block vulnerable images](/concepts/#block-vulnerable-images) for producing the attestations.
+
+**Node options (` + bt + `spec.cluster.nodes` + bt + `):** customize nodes to mimic a heterogeneous production topology (Vanilla, K3s, and Talos on the Docker provider). Each entry selects nodes by role and optional index; later entries override earlier ones for the nodes they share.
+
+- ` + bt + `role` + bt + ` – ` + bt + `ControlPlane` + bt + ` or ` + bt + `Worker` + bt + `
+- ` + bt + `index` + bt + ` – Zero-based index of a single node of the role, in creation order (default: every node of the role)
+- ` + bt + `resources.cpu` + bt + ` / ` + bt + `resources.memory` + bt + ` – Container CPU and memory limits as Kubernetes quantities (e.g. ` + bt + `1500m` + bt + `, ` + bt + `4Gi` + bt + `); swap is capped at the memory limit
+- ` + bt + `labels` + bt + ` – Node labels registered by the kubelet
+- ` + bt + `taints` + bt + ` – Node taints (` + bt + `key` + bt + `, ` + bt + `value` + bt + `, ` + bt + `effect` + bt + `) registered by the kubelet; control-plane nodes keep their default control-plane taint
+- ` + bt + `gpus` + bt + ` – NVIDIA GPUs of the Docker host passed to the node containers: ` + bt + `all` + bt + ` or a count taken in device order (Vanilla and K3s only)
+
+Kind gets the labels on its nodes and the taints as kubeadm ` + bt + `nodeRegistration` + bt + ` patches, K3d gets ` + bt + `--node-label` + bt + ` and ` + bt + `--node-taint` + bt + ` args filtered to each node, and Talos writes them into each node's machine config. Talos nodes are created with their limits; Kind and K3d node containers are updated right after the cluster is created. Labels in the ` + bt + `node-role.kubernetes.io` + bt + ` namespace are rejected by the kubelet, so set roles with a different prefix.
+
+GPU passthrough needs the NVIDIA Container Toolkit on the Docker host. K3d passes the GPUs as a Docker device request and applies one value to every node, so all K3s entries must agree. Kind mounts one device per GPU under ` + bt + `/var/run/nvidia-container-devices` + bt + `, which requires the host's Docker daemon to use the ` + bt + `nvidia` + bt + ` default runtime with ` + bt + `accept-nvidia-visible-devices-as-volume-mounts = true` + bt + `. GPU nodes are labeled ` + bt + `nvidia.com/gpu.present=true` + bt + ` and KSail installs the NVIDIA device plugin so pods can request ` + bt + `nvidia.com/gpu` + bt + `.
+
+` + cbt + `yaml
+spec:
+  cluster:
+    workers: 2
+    nodes:
+      - role: Worker
+        resources: { cpu: "2", memory: 4Gi }
+      - role: Worker
+        index: 1
+        labels: { gpu: "true" }
+        taints:
+          - { key: gpu, value: "true", effect: NoSchedule }
+` + cbt
 
 // configDistributionConfigProse describes distribution configuration files.
 const configDistributionConfigProse = `## Distribution Configuration
@@ -472,6 +673,88 @@ spec:
 
 Access services at ` + bt + `http://localhost:8080` + bt + `. Ports are exposed on the first control-plane node; in multi-control-plane clusters, ` + bt + `extraPortMappings` + bt + ` apply only to that node.`
 
+// configProfilesProse describes the profiles section of ksail.yaml.
+const configProfilesProse = `## Profiles
+
+A ` + bt + `profiles` + bt + ` section keeps several variants of a cluster in one ` + bt + `ksail.yaml` + bt + `, such as a fast CI variant next to a full-featured local one. Each profile is an overlay of ` + bt + `spec` + bt + ` that is merged onto the base spec when selected with ` + bt + `--profile` + bt + ` or the ` + bt + `KSAIL_PROFILE` + bt + ` environment variable:
+
+` + cbt + `yaml
+apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Vanilla
+    cni: Cilium
+    workers: 2
+    metricsServer: Enabled
+profiles:
+  minimal:
+    cluster:
+      workers: 0
+      metricsServer: Disabled
+  ci:
+    cluster:
+      workers: 0
+    workload:
+      validateOnPush: true
+  full: {}
+` + cbt + `
+
+` + cbt + `bash
+ksail cluster create --profile ci
+KSAIL_PROFILE=minimal ksail cluster create
+` + cbt + `
+
+Mappings merge key by key, so a profile only lists the fields it changes; lists and scalar values in a profile replace the base value. Environment variables and flags still override the selected profile. Selecting a profile the file does not define is an error that lists the available profiles.`
+
+// configExtendsProse describes the extends key of ksail.yaml.
+const configExtendsProse = `## Shared Base Configuration
+
+Set ` + bt + `extends` + bt + ` to inherit defaults from a base ` + bt + `ksail.yaml` + bt + ` that a platform team publishes once, as an OCI artifact or at an HTTPS URL:
+
+` + cbt + `yaml
+apiVersion: ksail.io/v1alpha1
+kind: Cluster
+extends: oci://ghcr.io/org/ksail-base:v1
+spec:
+  cluster:
+    workers: 1
+` + cbt + `
+
+KSail fetches the base on every command and merges this file over it: mappings merge key by key, and lists and scalar values in the local file replace the base value, so local values always win. Profiles can come from either file. The base must not set ` + bt + `extends` + bt + ` itself, and an older ` + bt + `apiVersion` + bt + ` in the base is converted like a local file.
+
+Each fetched base is cached under ` + bt + `~/.ksail/bases` + bt + `, keyed by its reference. When a fetch fails, for example while offline, KSail prints a warning and uses the cached copy instead.
+
+An OCI artifact must contain the file as ` + bt + `ksail.yaml` + bt + `, either as a single-file layer or at the root of a tar layer. Both of these publish one:
+
+` + cbt + `bash
+oras push ghcr.io/org/ksail-base:v1 ksail.yaml
+flux push artifact oci://ghcr.io/org/ksail-base:v1 --path=./base --source=local --revision=v1
+` + cbt + `
+
+Registry credentials come from your Docker config (` + bt + `docker login` + bt + `). ` + bt + `${VAR}` + bt + ` placeholders in the reference are expanded from the environment.`
+
+// configViewSetProse describes the config view and config set commands.
+const configViewSetProse = `## Viewing and Editing the Effective Configuration
+
+` + bt + `ksail config view` + bt + ` prints the configuration exactly as other commands load it: ` + bt + `ksail.yaml` + bt + ` with its base and active profile, overridden by ` + bt + `KSAIL_*` + bt + ` environment variables and flags. Fields that have a flag are annotated with where their value came from, and listed even when they hold their default:
+
+` + cbt + `yaml
+spec:
+  cluster:
+    distribution: Vanilla # file: ksail.yaml
+    workers: 3 # env: KSAIL_SPEC_CLUSTER_WORKERS
+    gitOpsEngine: Flux # flag: --gitops-engine
+    controlPlanes: 1 # default
+` + cbt + `
+
+` + bt + `ksail config set` + bt + ` changes one of these fields in ` + bt + `ksail.yaml` + bt + `, named by its flag name or its path. The value is checked like the flag checks it, and the updated file is validated before it is written; comments and key order are preserved:
+
+` + cbt + `bash
+ksail config set workers 3
+ksail config set spec.cluster.gitOpsEngine Flux --dry-run
+` + cbt + ``
+
 // configSchemaProse describes JSON Schema support.
 const configSchemaProse = `## Schema Support
 
@@ -485,4 +768,29 @@ spec:
   # ...
 ` + cbt + `
 
-IDEs with YAML language support (e.g., VS Code + [Red Hat YAML extension](https://marketplace.visualstudio.com/items?itemName=redhat.vscode-yaml)) provide field autocompletion, inline docs, validation, and enum suggestions.`
+IDEs with YAML language support (e.g., VS Code + [Red Hat YAML extension](https://marketplace.visualstudio.com/items?itemName=redhat.vscode-yaml)) provide field autocompletion, inline docs, validation, and enum suggestions.
+
+` + bt + `ksail project init` + bt + ` always writes this header into ` + bt + `ksail.yaml` + bt + `. With ` + bt + `--schema` + bt + `, it also adds one to the scaffolded distribution configs, pointing at schemas generated alongside the KSail schema from the Kind, K3d, and Talos versions KSail is built with:
+
+| File | Schema |
+| ---- | ------ |
+| ` + bt + `kind.yaml` + bt + ` | ` + bt + `schemas/kind-config.schema.json` + bt + ` |
+| ` + bt + `k3d.yaml` + bt + ` | ` + bt + `schemas/k3d-config.schema.json` + bt + ` |
+| ` + bt + `talos/**/*.yaml` + bt + ` patches | ` + bt + `schemas/talos-config.schema.json` + bt + ` |`
+
+// configMigrationProse describes converting configs between API versions.
+const configMigrationProse = `## Migrating Between API Versions
+
+When KSail moves to a new API version (for example from ` + bt + `ksail.io/v1alpha1` + bt + ` to ` + bt + `v1alpha2` + bt + `), existing ` + bt + `ksail.yaml` + bt + ` files keep working: KSail converts them to the current version in memory on load and prints a warning listing every renamed and removed field.
+
+` + bt + `ksail config migrate` + bt + ` applies the same conversion to the file on disk. It also moves deprecated fields of the current version to their replacements (for example ` + bt + `spec.cluster.talos.workers` + bt + ` to ` + bt + `spec.cluster.workers` + bt + `), so the deprecation warnings go away. Comments and key order are preserved:
+
+` + cbt + `bash
+# Preview the migrated file
+ksail config migrate --dry-run
+
+# Rewrite ksail.yaml in place
+ksail config migrate
+` + cbt + `
+
+A deprecated field set alongside its replacement with a different value is reported as a conflict and the file is left unchanged.`
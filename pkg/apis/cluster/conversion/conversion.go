@@ -0,0 +1,237 @@
+package conversion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"gopkg.in/yaml.v3"
+)
+
+// LatestAPIVersion is the apiVersion documents are converted to.
+const LatestAPIVersion = v1alpha1.APIVersion
+
+// yamlIndent is the indentation used when a converted document is re-encoded.
+const yamlIndent = 2
+
+var (
+	// ErrUnsupportedVersion is returned for an apiVersion no conversion path starts from.
+	ErrUnsupportedVersion = errors.New("unsupported apiVersion")
+	// ErrNotClusterDocument is returned when the document is not a KSail Cluster.
+	ErrNotClusterDocument = errors.New("not a KSail Cluster document")
+	// ErrFieldConflict is returned when a renamed field and its replacement are
+	// both set to different values.
+	ErrFieldConflict = errors.New("field conflicts with its replacement")
+)
+
+// Rename moves the value of one field to another. Paths are dot-separated
+// ksail.yaml field paths such as "spec.cluster.workers".
+type Rename struct {
+	From string
+	To   string
+}
+
+// Removal drops a field that has no replacement.
+type Removal struct {
+	Field string
+	// Note explains what to do instead; it is shown in the report.
+	Note string
+}
+
+// Step upgrades documents of one apiVersion to the next.
+type Step struct {
+	From     string
+	To       string
+	Renames  []Rename
+	Removals []Removal
+}
+
+// steps is the chain of conversions from older API versions. v1alpha1 is the
+// first KSail API version, so there is nothing to convert from yet; the step
+// from v1alpha1 is added here when v1alpha2 lands.
+//
+//nolint:gochecknoglobals // Conversion table, replaced in tests.
+var steps []Step
+
+// deprecations are the fields of [LatestAPIVersion] that have been superseded
+// in place. The config manager still accepts them on load with a warning;
+// [Migrate] rewrites them so the warning goes away.
+//
+//nolint:gochecknoglobals // Conversion table.
+var deprecations = []Rename{
+	{From: "spec.cluster.talos.controlPlanes", To: "spec.cluster.controlPlanes"},
+	{From: "spec.cluster.talos.workers", To: "spec.cluster.workers"},
+	{From: "spec.cluster.nodeAutoscaling", To: "spec.cluster.autoscaler.node.enabled"},
+	{From: "spec.cluster.talos.imageVerification", To: "spec.cluster.imageVerification"},
+}
+
+// NeedsConversion reports whether apiVersion is an older version that
+// [Convert] can upgrade.
+func NeedsConversion(apiVersion string) bool {
+	return apiVersion != LatestAPIVersion && stepFrom(apiVersion) != nil
+}
+
+// Convert upgrades a ksail.yaml document to [LatestAPIVersion]. A document that
+// already has the latest version is returned unchanged.
+func Convert(data []byte) ([]byte, *Report, error) {
+	return convert(data, false)
+}
+
+// Migrate upgrades a ksail.yaml document to [LatestAPIVersion] like [Convert]
+// and also rewrites the fields the latest version deprecates.
+func Migrate(data []byte) ([]byte, *Report, error) {
+	return convert(data, true)
+}
+
+func convert(data []byte, rewriteDeprecated bool) ([]byte, *Report, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse ksail.yaml: %w", err)
+	}
+
+	root := documentRoot(&doc)
+	if root == nil || scalarValue(root, "kind") != v1alpha1.Kind {
+		return nil, nil, ErrNotClusterDocument
+	}
+
+	version := scalarValue(root, "apiVersion")
+	report := &Report{From: version, To: version}
+
+	for version != LatestAPIVersion {
+		step := stepFrom(version)
+		if step == nil {
+			return nil, nil, fmt.Errorf("%w %q (KSail converts from: %s)",
+				ErrUnsupportedVersion, version, strings.Join(supportedVersions(), ", "))
+		}
+
+		err = applyStep(root, *step, report)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		version = step.To
+	}
+
+	if rewriteDeprecated {
+		for _, rename := range deprecations {
+			err = applyRename(root, rename, report)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if !report.Changed() {
+		return data, report, nil
+	}
+
+	var out bytes.Buffer
+
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(yamlIndent)
+
+	err = encoder.Encode(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode ksail.yaml: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode ksail.yaml: %w", err)
+	}
+
+	return out.Bytes(), report, nil
+}
+
+// applyStep applies one version step to root and records it in report.
+func applyStep(root *yaml.Node, step Step, report *Report) error {
+	for _, rename := range step.Renames {
+		err := applyRename(root, rename, report)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, removal := range step.Removals {
+		if deleteField(root, splitPath(removal.Field)) {
+			report.Changes = append(report.Changes, Change{
+				Type:  ChangeRemoved,
+				Field: removal.Field,
+				Note:  removal.Note,
+			})
+		}
+	}
+
+	setScalar(root, "apiVersion", step.To)
+	report.To = step.To
+
+	return nil
+}
+
+// applyRename moves rename.From to rename.To. When both are set, equal values
+// collapse into the new field and different values are a conflict.
+func applyRename(root *yaml.Node, rename Rename, report *Report) error {
+	fromPath := splitPath(rename.From)
+
+	value := lookup(root, fromPath)
+	if value == nil {
+		return nil
+	}
+
+	existing := lookup(root, splitPath(rename.To))
+	if existing != nil && !sameValue(existing, value) {
+		return fmt.Errorf("%w: %s conflicts with %s (set only %s)",
+			ErrFieldConflict, rename.From, rename.To, rename.To)
+	}
+
+	if existing == nil {
+		setField(root, splitPath(rename.To), value)
+	}
+
+	deleteField(root, fromPath)
+
+	report.Changes = append(report.Changes, Change{
+		Type:     ChangeRenamed,
+		Field:    rename.From,
+		NewField: rename.To,
+	})
+
+	return nil
+}
+
+// stepFrom returns the step converting from version, or nil.
+func stepFrom(version string) *Step {
+	for i := range steps {
+		if steps[i].From == version {
+			return &steps[i]
+		}
+	}
+
+	return nil
+}
+
+// supportedVersions lists every apiVersion a conversion path starts from.
+func supportedVersions() []string {
+	versions := make([]string, 0, len(steps)+1)
+	for _, step := range steps {
+		versions = append(versions, step.From)
+	}
+
+	return append(versions, LatestAPIVersion)
+}
+
+// sameValue reports whether two nodes decode to the same value.
+func sameValue(a, b *yaml.Node) bool {
+	var left, right any
+
+	if a.Decode(&left) != nil || b.Decode(&right) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(left, right)
+}
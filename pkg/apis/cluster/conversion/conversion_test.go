@@ -0,0 +1,138 @@
+package conversion_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/conversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const latestDocument = `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Vanilla
+`
+
+func TestConvert_LatestVersionIsUnchanged(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, conversion.NeedsConversion(conversion.LatestAPIVersion))
+
+	converted, report, err := conversion.Convert([]byte(latestDocument))
+	require.NoError(t, err)
+
+	assert.Equal(t, latestDocument, string(converted))
+	assert.False(t, report.Changed())
+}
+
+func TestConvert_RejectsUnknownVersionsAndKinds(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := conversion.Convert([]byte("apiVersion: ksail.io/v0\nkind: Cluster\n"))
+	require.ErrorIs(t, err, conversion.ErrUnsupportedVersion)
+
+	_, _, err = conversion.Convert([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+	require.ErrorIs(t, err, conversion.ErrNotClusterDocument)
+}
+
+//nolint:paralleltest // replaces the package-level conversion chain
+func TestConvert_ChainsVersionSteps(t *testing.T) {
+	t.Cleanup(conversion.SetStepsForTests([]conversion.Step{
+		{
+			From:    "ksail.io/v1alpha0",
+			To:      "ksail.io/v1alpha00",
+			Renames: []conversion.Rename{{From: "spec.distribution", To: "spec.cluster.distribution"}},
+		},
+		{
+			From:     "ksail.io/v1alpha00",
+			To:       conversion.LatestAPIVersion,
+			Removals: []conversion.Removal{{Field: "spec.legacy", Note: "no longer needed"}},
+		},
+	}))
+
+	assert.True(t, conversion.NeedsConversion("ksail.io/v1alpha0"))
+
+	converted, report, err := conversion.Convert([]byte(`apiVersion: ksail.io/v1alpha0
+kind: Cluster
+# The cluster to create.
+spec:
+  distribution: K3s # lightweight
+  legacy: true
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+# The cluster to create.
+spec:
+  cluster:
+    distribution: K3s # lightweight
+`, string(converted))
+
+	assert.Equal(t, "ksail.io/v1alpha0", report.From)
+	assert.Equal(t, conversion.LatestAPIVersion, report.To)
+	require.Len(t, report.Changes, 2)
+	assert.Equal(t, "spec.distribution → spec.cluster.distribution", report.Changes[0].String())
+	assert.Equal(t, "spec.legacy removed (no longer needed)", report.Changes[1].String())
+}
+
+func TestMigrate_RewritesDeprecatedFields(t *testing.T) {
+	t.Parallel()
+
+	migrated, report, err := conversion.Migrate([]byte(`apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Talos
+    workers: 2
+    nodeAutoscaling: Enabled
+    talos:
+      workers: 2
+      imageVerification: Enforce
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Talos
+    workers: 2
+    autoscaler:
+      node:
+        enabled: Enabled
+    imageVerification: Enforce
+`, string(migrated))
+
+	assert.Equal(t, conversion.LatestAPIVersion, report.From)
+	assert.Equal(t, conversion.LatestAPIVersion, report.To)
+
+	fields := make([]string, 0, len(report.Changes))
+	for _, change := range report.Changes {
+		assert.Equal(t, conversion.ChangeRenamed, change.Type)
+		fields = append(fields, change.Field)
+	}
+
+	assert.Equal(t, []string{
+		"spec.cluster.talos.workers",
+		"spec.cluster.nodeAutoscaling",
+		"spec.cluster.talos.imageVerification",
+	}, fields)
+}
+
+func TestMigrate_ConflictingDeprecatedField(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := conversion.Migrate([]byte(`apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    workers: 3
+    talos:
+      workers: 1
+`))
+
+	require.ErrorIs(t, err, conversion.ErrFieldConflict)
+}
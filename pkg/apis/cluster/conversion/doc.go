@@ -0,0 +1,15 @@
+// Package conversion upgrades ksail.yaml documents written for an older KSail
+// API version to the current one, so existing projects keep loading when a new
+// version (such as v1alpha2) lands.
+//
+// Conversion works on the YAML document rather than on Go types: every older
+// version is described by a [Step] that renames and removes fields on its way to
+// the next version, and steps are chained until the document reaches
+// [LatestAPIVersion]. Working on the YAML node tree keeps comments and key
+// order intact when the result is written back to disk.
+//
+// The config manager applies [Convert] in memory when it loads an older file;
+// `ksail config migrate` applies [Migrate], which additionally rewrites fields
+// the current version deprecates, and writes the result back to the file. Both
+// return a [Report] listing every renamed and removed field.
+package conversion
@@ -0,0 +1,10 @@
+package conversion
+
+// SetStepsForTests replaces the version conversion chain and returns a
+// function restoring the previous one.
+func SetStepsForTests(chain []Step) func() {
+	previous := steps
+	steps = chain
+
+	return func() { steps = previous }
+}
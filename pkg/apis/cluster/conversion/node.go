@@ -0,0 +1,137 @@
+package conversion
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Keys are matched case-insensitively, as the config manager (Viper) does, so
+// "controlplanes" in a hand-written file is found as "controlPlanes".
+
+// documentRoot returns the top-level mapping of a parsed document, or nil.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	return root
+}
+
+// splitPath splits a dot-separated field path.
+func splitPath(path string) []string {
+	return strings.Split(path, ".")
+}
+
+// keyIndex returns the index of key's key node in mapping, or -1.
+func keyIndex(mapping *yaml.Node, key string) int {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return -1
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// lookup returns the value node at path below mapping, or nil.
+func lookup(mapping *yaml.Node, path []string) *yaml.Node {
+	node := mapping
+
+	for _, key := range path {
+		index := keyIndex(node, key)
+		if index < 0 {
+			return nil
+		}
+
+		node = node.Content[index+1]
+	}
+
+	return node
+}
+
+// scalarValue returns the scalar value of key in mapping, or "".
+func scalarValue(mapping *yaml.Node, key string) string {
+	value := lookup(mapping, []string{key})
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return value.Value
+}
+
+// setScalar sets key in mapping to a string scalar.
+func setScalar(mapping *yaml.Node, key, value string) {
+	setField(mapping, []string{key}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+}
+
+// setField sets the value at path below mapping, creating intermediate
+// mappings as needed.
+func setField(mapping *yaml.Node, path []string, value *yaml.Node) {
+	node := mapping
+
+	for i, key := range path {
+		index := keyIndex(node, key)
+
+		if i == len(path)-1 {
+			if index >= 0 {
+				node.Content[index+1] = value
+			} else {
+				node.Content = append(node.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+			}
+
+			return
+		}
+
+		if index < 0 || node.Content[index+1].Kind != yaml.MappingNode {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+			if index < 0 {
+				node.Content = append(node.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+			} else {
+				node.Content[index+1] = child
+			}
+
+			node = child
+
+			continue
+		}
+
+		node = node.Content[index+1]
+	}
+}
+
+// deleteField removes the field at path below mapping and any mappings the
+// removal leaves empty. It reports whether the field existed.
+func deleteField(mapping *yaml.Node, path []string) bool {
+	index := keyIndex(mapping, path[0])
+	if index < 0 {
+		return false
+	}
+
+	if len(path) > 1 {
+		child := mapping.Content[index+1]
+		if !deleteField(child, path[1:]) {
+			return false
+		}
+
+		if len(child.Content) > 0 {
+			return true
+		}
+	}
+
+	mapping.Content = append(mapping.Content[:index], mapping.Content[index+2:]...)
+
+	return true
+}
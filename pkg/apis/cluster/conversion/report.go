@@ -0,0 +1,52 @@
+package conversion
+
+import "fmt"
+
+// ChangeType classifies a field change made by a conversion.
+type ChangeType string
+
+const (
+	// ChangeRenamed records a field whose value moved to a new path.
+	ChangeRenamed ChangeType = "renamed"
+	// ChangeRemoved records a field that was dropped.
+	ChangeRemoved ChangeType = "removed"
+)
+
+// Change is one field a conversion renamed or removed.
+type Change struct {
+	Type ChangeType `json:"type"`
+	// Field is the path of the field in the original document.
+	Field string `json:"field"`
+	// NewField is the path the value moved to, for renames.
+	NewField string `json:"newField,omitempty"`
+	// Note explains a removal.
+	Note string `json:"note,omitempty"`
+}
+
+// String describes the change, e.g. "spec.cluster.talos.workers → spec.cluster.workers".
+func (c Change) String() string {
+	if c.Type == ChangeRenamed {
+		return fmt.Sprintf("%s → %s", c.Field, c.NewField)
+	}
+
+	if c.Note == "" {
+		return c.Field + " removed"
+	}
+
+	return fmt.Sprintf("%s removed (%s)", c.Field, c.Note)
+}
+
+// Report describes what a conversion did to a document.
+type Report struct {
+	// From is the apiVersion of the original document.
+	From string `json:"from"`
+	// To is the apiVersion of the converted document.
+	To string `json:"to"`
+	// Changes lists the renamed and removed fields in the order they were applied.
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// Changed reports whether the conversion modified the document.
+func (r *Report) Changed() bool {
+	return r.From != r.To || len(r.Changes) > 0
+}
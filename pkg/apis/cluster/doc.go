@@ -3,6 +3,7 @@
 // This package contains versioned API types for KSail cluster configuration:
 //
 //   - v1alpha1: Current API version for cluster configuration
+//   - conversion: Upgrades ksail.yaml documents from older API versions
 //
 // The cluster types define the declarative configuration format used
 // in ksail.yaml files.
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultActionsRunnerName is the runner scale set name used when spec.cluster.actionsRunner.name
+// is unset. Workflow jobs target the scale set with `runs-on: ksail`.
+const DefaultActionsRunnerName = "ksail"
+
+// ActionsRunnerSpec installs GitHub Actions Runner Controller (ARC) with one runner scale set that
+// registers with a repository or organization, so CI jobs that need in-cluster runners can be tested
+// against a local cluster. The registration token is resolved from the KSail credential store
+// (key github.token), falling back to the GITHUB_TOKEN environment variable; it is never read from
+// ksail.yaml.
+type ActionsRunnerSpec struct {
+	// Enabled installs ARC and the runner scale set.
+	Enabled bool `json:"enabled,omitzero" jsonschema_description:"Install GitHub Actions Runner Controller with a runner scale set registered to githubConfigURL. The token is read from the KSail credential store (github.token), falling back to GITHUB_TOKEN."` //nolint:lll
+
+	// GitHubConfigURL is the repository (https://github.com/org/repo) or organization
+	// (https://github.com/org) the runners register with.
+	GitHubConfigURL string `json:"githubConfigURL,omitzero" jsonschema_description:"Repository (https://github.com/org/repo) or organization (https://github.com/org) URL the runners register with."` //nolint:lll
+
+	// Name is the runner scale set name, which workflow jobs target with runs-on. Defaults to "ksail".
+	Name string `json:"name,omitzero" jsonschema_description:"Runner scale set name that workflow jobs target with runs-on (default: ksail). Must be a DNS-1123 label."` //nolint:lll
+
+	// Labels are additional runs-on labels of the scale set.
+	Labels []string `json:"labels,omitzero" jsonschema_description:"Additional runs-on labels of the runner scale set."` //nolint:lll
+
+	// MinRunners is the number of idle runners kept ready.
+	MinRunners int32 `json:"minRunners,omitzero" jsonschema:"minimum=0" jsonschema_description:"Number of idle runners kept ready (default: 0)."` //nolint:lll
+
+	// MaxRunners caps the number of concurrent runners; 0 leaves it unbounded.
+	MaxRunners int32 `json:"maxRunners,omitzero" jsonschema:"minimum=0" jsonschema_description:"Maximum number of concurrent runners (default: unbounded)."` //nolint:lll
+}
+
+// EffectiveName returns the configured scale set name, defaulting to DefaultActionsRunnerName.
+func (a ActionsRunnerSpec) EffectiveName() string {
+	if a.Name == "" {
+		return DefaultActionsRunnerName
+	}
+
+	return a.Name
+}
+
+// ValidateActionsRunner checks that an enabled runner scale set has a GitHub URL, a valid name,
+// and a consistent runner range, so a typo fails at config load rather than as a crash-looping
+// listener after the cluster is created.
+func ValidateActionsRunner(cluster *ClusterSpec) error {
+	if cluster == nil || !cluster.ActionsRunner.Enabled {
+		return nil
+	}
+
+	runner := cluster.ActionsRunner
+
+	err := validateGitHubConfigURL(runner.GitHubConfigURL)
+	if err != nil {
+		return err
+	}
+
+	if errs := validation.IsDNS1123Label(runner.EffectiveName()); len(errs) > 0 {
+		return fmt.Errorf(
+			"%w: name %q: %s",
+			ErrInvalidActionsRunner, runner.Name, strings.Join(errs, "; "),
+		)
+	}
+
+	for _, label := range runner.Labels {
+		if strings.TrimSpace(label) == "" {
+			return fmt.Errorf("%w: labels must not be empty", ErrInvalidActionsRunner)
+		}
+	}
+
+	if runner.MinRunners < 0 || runner.MaxRunners < 0 {
+		return fmt.Errorf("%w: minRunners and maxRunners must not be negative", ErrInvalidActionsRunner)
+	}
+
+	if runner.MaxRunners > 0 && runner.MaxRunners < runner.MinRunners {
+		return fmt.Errorf(
+			"%w: maxRunners (%d) is less than minRunners (%d)",
+			ErrInvalidActionsRunner, runner.MaxRunners, runner.MinRunners,
+		)
+	}
+
+	return nil
+}
+
+// validateGitHubConfigURL requires an absolute http(s) URL naming an organization or repository.
+func validateGitHubConfigURL(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("%w: githubConfigURL is required when enabled", ErrInvalidActionsRunner)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" ||
+		strings.Trim(parsed.Path, "/") == "" {
+		return fmt.Errorf(
+			"%w: githubConfigURL %q must be a repository or organization URL, e.g. https://github.com/org/repo",
+			ErrInvalidActionsRunner, raw,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package v1alpha1
+
+import "fmt"
+
+// maxPort is the highest valid TCP port.
+const maxPort = 65535
+
+// ValidateAPIServerPort checks that a pinned API server port is a valid TCP port and is
+// only set for distributions and providers that publish the API server on localhost.
+func ValidateAPIServerPort(cluster *ClusterSpec) error {
+	if cluster == nil || cluster.Connection.APIServerPort == 0 {
+		return nil
+	}
+
+	port := cluster.Connection.APIServerPort
+	if port < 0 || port > maxPort {
+		return fmt.Errorf("%w: %d is not between 1 and %d", ErrInvalidAPIServerPort, port, maxPort)
+	}
+
+	//nolint:exhaustive // every other distribution is rejected by the default case
+	switch cluster.Distribution {
+	case DistributionVanilla, DistributionK3s, DistributionTalos:
+	default:
+		return fmt.Errorf(
+			"%w: apiServerPort requires the Vanilla, K3s, or Talos distribution, got %s",
+			ErrInvalidAPIServerPort, cluster.Distribution,
+		)
+	}
+
+	if cluster.Provider != "" && cluster.Provider != ProviderDocker {
+		return fmt.Errorf(
+			"%w: apiServerPort requires the Docker provider, got %s",
+			ErrInvalidAPIServerPort, cluster.Provider,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// --- Argo CD Types ---
+
+// Argo CD sync window kinds.
+const (
+	// ArgoCDSyncWindowAllow permits syncs only while the window is active.
+	ArgoCDSyncWindowAllow = "allow"
+	// ArgoCDSyncWindowDeny blocks syncs while the window is active.
+	ArgoCDSyncWindowDeny = "deny"
+)
+
+// argoCDScheduleParser parses sync window schedules the same way Argo CD does:
+// standard five-field cron expressions plus descriptors such as @daily.
+var argoCDScheduleParser = cron.NewParser(
+	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// ArgoCDConfig holds the Argo CD-specific configuration KSail applies when it
+// bootstraps Argo CD (gitOpsEngine: ArgoCD). Has no effect for other GitOps
+// engines.
+type ArgoCDConfig struct {
+	// Project declares AppProject guardrails for the Application KSail generates.
+	Project ArgoCDProject `json:"project,omitzero" jsonschema_description:"AppProject guardrails (source repos, destinations, sync windows) KSail reconciles as the 'ksail' AppProject and assigns to its Application. Empty keeps the unrestricted default project."` //nolint:lll
+	// Layout selects a single Application or an app-of-apps layout where an
+	// ApplicationSet generates further Applications.
+	Layout ArgoCDLayout `json:"layout,omitzero" jsonschema_description:"Application (default) syncs the source directory with a single Application. ApplicationSet adds an ApplicationSet next to it that generates an Application per app directory or per registered cluster."` //nolint:lll
+	// ApplicationSet configures the generator used by the ApplicationSet layout.
+	ApplicationSet ArgoCDApplicationSet `json:"applicationSet,omitzero" jsonschema_description:"Generator of the ApplicationSet KSail creates when layout is ApplicationSet."` //nolint:lll
+}
+
+// ArgoCDProject declares the AppProject restrictions KSail reconciles for its
+// Application, so a local cluster can reproduce the guardrails that make syncs
+// fail in production. When any restriction is declared KSail owns an AppProject
+// named "ksail" and points its Application at it; an empty project keeps Argo
+// CD's unrestricted "default" project. Dimensions left empty stay unrestricted,
+// so only the declared constraints apply.
+type ArgoCDProject struct {
+	SourceRepos  []string            `json:"sourceRepos,omitzero"  jsonschema_description:"Repository URLs (glob patterns allowed) the Application may sync from. Empty allows any repository. Must cover the repository KSail syncs from, or Argo CD rejects the sync."` //nolint:lll
+	Destinations []ArgoCDDestination `json:"destinations,omitzero" jsonschema_description:"Clusters and namespaces the Application may deploy to. Empty allows any destination."`                                                                                         //nolint:lll
+	SyncWindows  []ArgoCDSyncWindow  `json:"syncWindows,omitzero"  jsonschema_description:"Time windows during which syncs are allowed or denied, evaluated by Argo CD exactly as in production."`                                                                        //nolint:lll
+}
+
+// ArgoCDDestination is a cluster/namespace pair an Application may deploy to.
+// Either Server or Name identifies the cluster; both accept glob patterns.
+type ArgoCDDestination struct {
+	Server    string `json:"server,omitzero"    jsonschema_description:"Cluster API server URL (glob patterns allowed, e.g. https://kubernetes.default.svc)."`  //nolint:lll
+	Name      string `json:"name,omitzero"      jsonschema_description:"Cluster name as registered in Argo CD (glob patterns allowed). Alternative to server."` //nolint:lll
+	Namespace string `json:"namespace,omitzero" jsonschema_description:"Target namespace (glob patterns allowed, e.g. team-*). Empty allows any namespace."`    //nolint:lll
+}
+
+// ArgoCDSyncWindow is an Argo CD AppProject sync window. Field names mirror
+// AppProject.spec.syncWindows so windows can be copied from production projects.
+type ArgoCDSyncWindow struct {
+	Kind         string   `json:"kind,omitzero"         jsonschema:"enum=allow,enum=deny"   jsonschema_description:"Whether syncs are allowed (allow) or blocked (deny) while the window is active."`                                                  //nolint:lll
+	Schedule     string   `json:"schedule,omitzero"                                         jsonschema_description:"Cron expression (five fields or a descriptor such as @daily) at which the window opens."`                                          //nolint:lll
+	Duration     string   `json:"duration,omitzero"                                         jsonschema_description:"How long the window stays open after each scheduled start (Go duration, e.g. 1h or 30m)."`                                         //nolint:lll
+	Applications []string `json:"applications,omitzero"                                     jsonschema_description:"Application names (glob patterns allowed) the window applies to. Empty with no namespaces or clusters matches every application."` //nolint:lll
+	Namespaces   []string `json:"namespaces,omitzero"                                       jsonschema_description:"Destination namespaces (glob patterns allowed) the window applies to."`                                                            //nolint:lll
+	Clusters     []string `json:"clusters,omitzero"                                         jsonschema_description:"Destination clusters (glob patterns allowed) the window applies to."`                                                              //nolint:lll
+	ManualSync   bool     `json:"manualSync,omitzero"                                       jsonschema_description:"Allow manual syncs while a deny window is active."`                                                                                //nolint:lll
+	TimeZone     string   `json:"timeZone,omitzero"                                         jsonschema_description:"IANA time zone the schedule is evaluated in (e.g. Europe/Copenhagen). Empty uses UTC."`                                            //nolint:lll
+}
+
+// IsZero reports whether no project restriction is declared.
+func (p ArgoCDProject) IsZero() bool {
+	return len(p.SourceRepos) == 0 && len(p.Destinations) == 0 && len(p.SyncWindows) == 0
+}
+
+// ValidateArgoCDProject checks the declared AppProject restrictions so a typo
+// fails at config load rather than silently leaving Argo CD with an unusable
+// window or destination.
+func ValidateArgoCDProject(project ArgoCDProject) error {
+	for idx, repo := range project.SourceRepos {
+		if strings.TrimSpace(repo) == "" {
+			return fmt.Errorf("%w: sourceRepos[%d] must not be empty", ErrInvalidArgoCDProject, idx)
+		}
+	}
+
+	for idx, dest := range project.Destinations {
+		if strings.TrimSpace(dest.Server) == "" && strings.TrimSpace(dest.Name) == "" {
+			return fmt.Errorf(
+				"%w: destinations[%d] must set server or name", ErrInvalidArgoCDProject, idx,
+			)
+		}
+	}
+
+	for idx, window := range project.SyncWindows {
+		err := validateArgoCDSyncWindow(idx, window)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateArgoCDSyncWindow applies the checks Argo CD performs when it evaluates
+// a sync window.
+func validateArgoCDSyncWindow(idx int, window ArgoCDSyncWindow) error {
+	if window.Kind != ArgoCDSyncWindowAllow && window.Kind != ArgoCDSyncWindowDeny {
+		return fmt.Errorf(
+			"%w: syncWindows[%d].kind %q must be %q or %q",
+			ErrInvalidArgoCDProject, idx, window.Kind, ArgoCDSyncWindowAllow, ArgoCDSyncWindowDeny,
+		)
+	}
+
+	_, err := argoCDScheduleParser.Parse(window.Schedule)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: syncWindows[%d].schedule %q: %w", ErrInvalidArgoCDProject, idx, window.Schedule, err,
+		)
+	}
+
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: syncWindows[%d].duration %q: %w", ErrInvalidArgoCDProject, idx, window.Duration, err,
+		)
+	}
+
+	if duration <= 0 {
+		return fmt.Errorf(
+			"%w: syncWindows[%d].duration %q must be positive",
+			ErrInvalidArgoCDProject, idx, window.Duration,
+		)
+	}
+
+	if window.TimeZone != "" {
+		_, err = time.LoadLocation(window.TimeZone)
+		if err != nil {
+			return fmt.Errorf(
+				"%w: syncWindows[%d].timeZone %q: %w", ErrInvalidArgoCDProject, idx, window.TimeZone, err,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateArgoCDProject(t *testing.T) {
+	t.Parallel()
+
+	nightlyFreeze := v1alpha1.ArgoCDSyncWindow{
+		Kind:     v1alpha1.ArgoCDSyncWindowDeny,
+		Schedule: "0 22 * * *",
+		Duration: "8h",
+		TimeZone: "Europe/Copenhagen",
+	}
+
+	tests := []struct {
+		name    string
+		project v1alpha1.ArgoCDProject
+		wantErr bool
+	}{
+		{name: "empty", project: v1alpha1.ArgoCDProject{}},
+		{
+			name: "valid restrictions",
+			project: v1alpha1.ArgoCDProject{
+				SourceRepos:  []string{"https://github.com/org/*"},
+				Destinations: []v1alpha1.ArgoCDDestination{{Server: "https://kubernetes.default.svc"}},
+				SyncWindows: []v1alpha1.ArgoCDSyncWindow{
+					nightlyFreeze,
+					{Kind: v1alpha1.ArgoCDSyncWindowAllow, Schedule: "@daily", Duration: "30m"},
+				},
+			},
+		},
+		{
+			name:    "empty source repo",
+			project: v1alpha1.ArgoCDProject{SourceRepos: []string{" "}},
+			wantErr: true,
+		},
+		{
+			name:    "destination without cluster",
+			project: v1alpha1.ArgoCDProject{Destinations: []v1alpha1.ArgoCDDestination{{Namespace: "apps"}}},
+			wantErr: true,
+		},
+		{
+			name: "unknown window kind",
+			project: v1alpha1.ArgoCDProject{SyncWindows: []v1alpha1.ArgoCDSyncWindow{
+				{Kind: "block", Schedule: "0 22 * * *", Duration: "1h"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid schedule",
+			project: v1alpha1.ArgoCDProject{SyncWindows: []v1alpha1.ArgoCDSyncWindow{
+				{Kind: v1alpha1.ArgoCDSyncWindowDeny, Schedule: "every night", Duration: "1h"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "non-positive duration",
+			project: v1alpha1.ArgoCDProject{SyncWindows: []v1alpha1.ArgoCDSyncWindow{
+				{Kind: v1alpha1.ArgoCDSyncWindowDeny, Schedule: "0 22 * * *", Duration: "0s"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "unknown time zone",
+			project: v1alpha1.ArgoCDProject{SyncWindows: []v1alpha1.ArgoCDSyncWindow{
+				{Kind: v1alpha1.ArgoCDSyncWindowDeny, Schedule: "0 22 * * *", Duration: "1h", TimeZone: "Mars/Olympus"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateArgoCDProject(testCase.project)
+			if testCase.wantErr {
+				require.ErrorIs(t, err, v1alpha1.ErrInvalidArgoCDProject)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestArgoCDProjectIsZero(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, v1alpha1.ArgoCDProject{}.IsZero())
+	assert.False(t, v1alpha1.ArgoCDProject{SourceRepos: []string{"*"}}.IsZero())
+}
+
+func TestValidateArgoCDLayout(t *testing.T) {
+	t.Parallel()
+
+	gitSource := v1alpha1.GitSource{URL: "https://github.com/org/platform.git"}
+	applicationSet := v1alpha1.ArgoCDConfig{Layout: v1alpha1.ArgoCDLayoutApplicationSet}
+
+	tests := []struct {
+		name     string
+		workload v1alpha1.WorkloadSpec
+		wantErr  error
+	}{
+		{name: "single Application", workload: v1alpha1.WorkloadSpec{}},
+		{
+			name:     "directory generator with Git source",
+			workload: v1alpha1.WorkloadSpec{ArgoCD: applicationSet, Git: gitSource},
+		},
+		{
+			name:     "directory generator without Git source",
+			workload: v1alpha1.WorkloadSpec{ArgoCD: applicationSet},
+			wantErr:  v1alpha1.ErrInvalidArgoCDLayout,
+		},
+		{
+			name: "cluster generator with selector",
+			workload: v1alpha1.WorkloadSpec{ArgoCD: v1alpha1.ArgoCDConfig{
+				Layout: v1alpha1.ArgoCDLayoutApplicationSet,
+				ApplicationSet: v1alpha1.ArgoCDApplicationSet{
+					Generator:       v1alpha1.ArgoCDGeneratorCluster,
+					ClusterSelector: map[string]string{"env": "staging"},
+				},
+			}},
+		},
+		{
+			name: "cluster generator without selector",
+			workload: v1alpha1.WorkloadSpec{ArgoCD: v1alpha1.ArgoCDConfig{
+				Layout:         v1alpha1.ArgoCDLayoutApplicationSet,
+				ApplicationSet: v1alpha1.ArgoCDApplicationSet{Generator: v1alpha1.ArgoCDGeneratorCluster},
+			}},
+			wantErr: v1alpha1.ErrInvalidArgoCDLayout,
+		},
+		{
+			name: "unknown generator",
+			workload: v1alpha1.WorkloadSpec{ArgoCD: v1alpha1.ArgoCDConfig{
+				Layout:         v1alpha1.ArgoCDLayoutApplicationSet,
+				ApplicationSet: v1alpha1.ArgoCDApplicationSet{Generator: "List"},
+			}},
+			wantErr: v1alpha1.ErrInvalidArgoCDGenerator,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateArgoCDLayout(testCase.workload)
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package v1alpha1
+
+import "fmt"
+
+// ArgoCDAppsDirectory is the directory under spec.workload.sourceDirectory whose
+// subdirectories the ApplicationSet directory generator turns into Applications.
+const ArgoCDAppsDirectory = "apps"
+
+// ArgoCDLayout defines how KSail structures the Argo CD resources it generates.
+type ArgoCDLayout string
+
+const (
+	// ArgoCDLayoutApplication syncs the source directory with a single Application.
+	ArgoCDLayoutApplication ArgoCDLayout = "Application"
+	// ArgoCDLayoutApplicationSet adds an ApplicationSet next to the root
+	// Application (app-of-apps), generating an Application per app directory or
+	// per registered cluster.
+	ArgoCDLayoutApplicationSet ArgoCDLayout = "ApplicationSet"
+)
+
+// ValidArgoCDLayouts returns supported ArgoCDLayout values.
+func ValidArgoCDLayouts() []ArgoCDLayout {
+	return []ArgoCDLayout{
+		ArgoCDLayoutApplication,
+		ArgoCDLayoutApplicationSet,
+	}
+}
+
+// Set for ArgoCDLayout (pflag.Value interface).
+func (l *ArgoCDLayout) Set(value string) error {
+	return setEnum(l, value, ValidArgoCDLayouts(), ErrInvalidArgoCDLayout)
+}
+
+// String returns the string representation of the ArgoCDLayout.
+func (l *ArgoCDLayout) String() string {
+	return string(*l)
+}
+
+// Type returns the type of the ArgoCDLayout.
+func (l *ArgoCDLayout) Type() string {
+	return "ArgoCDLayout"
+}
+
+// Default returns the default value for ArgoCDLayout (Application).
+func (l *ArgoCDLayout) Default() any {
+	return ArgoCDLayoutApplication
+}
+
+// ValidValues returns all valid ArgoCDLayout values as strings.
+func (l *ArgoCDLayout) ValidValues() []string {
+	return validValueStrings(ValidArgoCDLayouts())
+}
+
+// ArgoCDGenerator defines the generator of the ApplicationSet KSail generates.
+type ArgoCDGenerator string
+
+const (
+	// ArgoCDGeneratorDirectory generates an Application per subdirectory of
+	// ArgoCDAppsDirectory. Argo CD only discovers directories in Git
+	// repositories, so it requires spec.workload.git.
+	ArgoCDGeneratorDirectory ArgoCDGenerator = "Directory"
+	// ArgoCDGeneratorCluster generates an Application per cluster registered in
+	// Argo CD whose labels match the cluster selector, each syncing the source
+	// directory.
+	ArgoCDGeneratorCluster ArgoCDGenerator = "Cluster"
+)
+
+// ValidArgoCDGenerators returns supported ArgoCDGenerator values.
+func ValidArgoCDGenerators() []ArgoCDGenerator {
+	return []ArgoCDGenerator{
+		ArgoCDGeneratorDirectory,
+		ArgoCDGeneratorCluster,
+	}
+}
+
+// Set for ArgoCDGenerator (pflag.Value interface).
+func (g *ArgoCDGenerator) Set(value string) error {
+	return setEnum(g, value, ValidArgoCDGenerators(), ErrInvalidArgoCDGenerator)
+}
+
+// String returns the string representation of the ArgoCDGenerator.
+func (g *ArgoCDGenerator) String() string {
+	return string(*g)
+}
+
+// Type returns the type of the ArgoCDGenerator.
+func (g *ArgoCDGenerator) Type() string {
+	return "ArgoCDGenerator"
+}
+
+// Default returns the default value for ArgoCDGenerator (Directory).
+func (g *ArgoCDGenerator) Default() any {
+	return ArgoCDGeneratorDirectory
+}
+
+// ValidValues returns all valid ArgoCDGenerator values as strings.
+func (g *ArgoCDGenerator) ValidValues() []string {
+	return validValueStrings(ValidArgoCDGenerators())
+}
+
+// ArgoCDApplicationSet configures the ApplicationSet KSail generates when the
+// Argo CD layout is ApplicationSet.
+type ArgoCDApplicationSet struct {
+	Generator       ArgoCDGenerator   `json:"generator,omitzero"       jsonschema_description:"ApplicationSet generator. Directory (default) generates an Application per subdirectory of <sourceDirectory>/apps and requires spec.workload.git; Cluster generates an Application per Argo CD cluster matching clusterSelector."` //nolint:lll
+	ClusterSelector map[string]string `json:"clusterSelector,omitzero" jsonschema_description:"Labels of the Argo CD cluster secrets the Cluster generator deploys to. Required for the Cluster generator; the local cluster is synced by the root Application."`                                                                 //nolint:lll
+}
+
+// ResolvedGenerator returns the configured generator, or ArgoCDGeneratorDirectory
+// when unset.
+func (a ArgoCDApplicationSet) ResolvedGenerator() ArgoCDGenerator {
+	if a.Generator == "" {
+		return ArgoCDGeneratorDirectory
+	}
+
+	return a.Generator
+}
+
+// UsesApplicationSet reports whether the ApplicationSet layout is selected.
+func (c ArgoCDConfig) UsesApplicationSet() bool {
+	return c.Layout == ArgoCDLayoutApplicationSet
+}
+
+// ValidateArgoCDLayout checks that the ApplicationSet layout can be generated
+// for the configured source: the directory generator needs a Git repository,
+// and the cluster generator a selector so it does not target the local cluster
+// the root Application already syncs.
+func ValidateArgoCDLayout(workload WorkloadSpec) error {
+	if !workload.ArgoCD.UsesApplicationSet() {
+		return nil
+	}
+
+	switch workload.ArgoCD.ApplicationSet.ResolvedGenerator() {
+	case ArgoCDGeneratorDirectory:
+		if !workload.Git.IsEnabled() {
+			return fmt.Errorf(
+				"%w: the Directory generator requires spec.workload.git, "+
+					"Argo CD only discovers directories in Git repositories",
+				ErrInvalidArgoCDLayout,
+			)
+		}
+	case ArgoCDGeneratorCluster:
+		if len(workload.ArgoCD.ApplicationSet.ClusterSelector) == 0 {
+			return fmt.Errorf(
+				"%w: the Cluster generator requires applicationSet.clusterSelector",
+				ErrInvalidArgoCDLayout,
+			)
+		}
+	default:
+		return fmt.Errorf(
+			"%w: %q", ErrInvalidArgoCDGenerator, workload.ArgoCD.ApplicationSet.Generator,
+		)
+	}
+
+	return nil
+}
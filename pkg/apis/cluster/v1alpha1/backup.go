@@ -0,0 +1,44 @@
+package v1alpha1
+
+// Backup defines the in-cluster backup system options for a KSail cluster.
+type Backup string
+
+const (
+	// BackupNone is the default and disables backup system installation.
+	BackupNone Backup = "None"
+	// BackupVelero installs Velero with a local MinIO container as its S3 target.
+	BackupVelero Backup = "Velero"
+)
+
+// ValidBackups returns supported backup values.
+func ValidBackups() []Backup {
+	return []Backup{
+		BackupNone,
+		BackupVelero,
+	}
+}
+
+// Set for Backup (pflag.Value interface).
+func (b *Backup) Set(value string) error {
+	return setEnum(b, value, ValidBackups(), ErrInvalidBackup)
+}
+
+// String returns the string representation of the Backup.
+func (b *Backup) String() string {
+	return string(*b)
+}
+
+// Type returns the type of the Backup.
+func (b *Backup) Type() string {
+	return "Backup"
+}
+
+// Default returns the default value for Backup (None).
+func (b *Backup) Default() any {
+	return BackupNone
+}
+
+// ValidValues returns all valid Backup values as strings.
+func (b *Backup) ValidValues() []string {
+	return validValueStrings(ValidBackups())
+}
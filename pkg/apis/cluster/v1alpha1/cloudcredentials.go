@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// AWS STS bounds on an assumed-role session lifetime.
+const (
+	minAssumeRoleDuration = 15 * time.Minute
+	maxAssumeRoleDuration = 12 * time.Hour
+)
+
+// roleARNPattern matches IAM role ARNs in every AWS partition, e.g.
+// arn:aws:iam::123456789012:role/ksail or arn:aws-us-gov:iam::123456789012:role/ops/ksail.
+var roleARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:iam::\d{12}:role/[\w+=,.@/-]+$`)
+
+// EffectiveAssumeRoleDuration returns AssumeRoleDuration, or DefaultAWSAssumeRoleDuration when unset.
+func (o OptionsAWS) EffectiveAssumeRoleDuration() time.Duration {
+	if o.AssumeRoleDuration.Duration <= 0 {
+		return DefaultAWSAssumeRoleDuration
+	}
+
+	return o.AssumeRoleDuration.Duration
+}
+
+// EffectiveTokenRefreshInterval returns TokenRefreshInterval, or
+// DefaultHetznerTokenRefreshInterval when unset.
+func (o OptionsHetzner) EffectiveTokenRefreshInterval() time.Duration {
+	if o.TokenRefreshInterval.Duration <= 0 {
+		return DefaultHetznerTokenRefreshInterval
+	}
+
+	return o.TokenRefreshInterval.Duration
+}
+
+// ValidateCloudCredentials checks the short-lived credential settings of the
+// cloud providers: an assumed AWS role must be a role ARN with a session
+// lifetime STS accepts, and a Hetzner token command must name a program.
+func ValidateCloudCredentials(provider *ProviderSpec) error {
+	if provider == nil {
+		return nil
+	}
+
+	aws := provider.AWS
+	if aws.AssumeRoleARN == "" && aws.AssumeRoleDuration.Duration != 0 {
+		return fmt.Errorf("%w: assumeRoleDuration requires assumeRoleArn", ErrInvalidAssumeRole)
+	}
+
+	if aws.AssumeRoleARN != "" && !roleARNPattern.MatchString(aws.AssumeRoleARN) {
+		return fmt.Errorf(
+			"%w: %q is not an IAM role ARN (arn:aws:iam::<account>:role/<name>)",
+			ErrInvalidAssumeRole, aws.AssumeRoleARN,
+		)
+	}
+
+	if duration := aws.AssumeRoleDuration.Duration; duration != 0 &&
+		(duration < minAssumeRoleDuration || duration > maxAssumeRoleDuration) {
+		return fmt.Errorf(
+			"%w: assumeRoleDuration %s must be between %s and %s",
+			ErrInvalidAssumeRole, duration, minAssumeRoleDuration, maxAssumeRoleDuration,
+		)
+	}
+
+	hetzner := provider.Hetzner
+	if len(hetzner.TokenCommand) == 0 {
+		if hetzner.TokenRefreshInterval.Duration != 0 {
+			return fmt.Errorf("%w: tokenRefreshInterval requires tokenCommand", ErrInvalidTokenCommand)
+		}
+
+		return nil
+	}
+
+	if strings.TrimSpace(hetzner.TokenCommand[0]) == "" {
+		return fmt.Errorf("%w: tokenCommand must start with a program", ErrInvalidTokenCommand)
+	}
+
+	if hetzner.TokenRefreshInterval.Duration < 0 {
+		return fmt.Errorf("%w: tokenRefreshInterval must be positive", ErrInvalidTokenCommand)
+	}
+
+	return nil
+}
@@ -41,3 +41,20 @@ func (c *CNI) Default() any {
 func (c *CNI) ValidValues() []string {
 	return validValueStrings(ValidCNIs())
 }
+
+// OptionsCilium defines options for the Cilium CNI. They only take effect when
+// spec.cluster.cni is Cilium.
+type OptionsCilium struct {
+	// Hubble controls Hubble flow observability. Enabled also deploys Hubble
+	// Relay so `ksail workload network` can stream flows.
+	Hubble Hubble `json:"hubble,omitzero" jsonschema_description:"Hubble flow observability. Default keeps the Cilium chart defaults; Enabled deploys Hubble and Hubble Relay (used by 'ksail workload network'); Disabled turns Hubble off."` //nolint:lll
+	// KubeProxyReplacement controls Cilium's eBPF kube-proxy replacement.
+	// Default enables it on Talos (which runs without kube-proxy) and leaves
+	// the chart default elsewhere.
+	KubeProxyReplacement KubeProxyReplacement `json:"kubeProxyReplacement,omitzero" jsonschema_description:"Cilium eBPF kube-proxy replacement. Default enables it on Talos (which runs without kube-proxy) and keeps the chart default elsewhere; Enabled or Disabled override it."` //nolint:lll
+}
+
+// IsZero reports whether no Cilium option is set.
+func (o OptionsCilium) IsZero() bool {
+	return o == OptionsCilium{}
+}
@@ -1,5 +1,7 @@
 package v1alpha1
 
+import "time"
+
 const (
 	// DefaultVanillaDistributionConfig is the default Vanilla cluster distribution configuration filename (uses Kind).
 	DefaultVanillaDistributionConfig = "kind.yaml"
@@ -74,6 +76,16 @@ const (
 	// DefaultHetznerServerLimit is the default maximum number of Hetzner servers
 	// allowed for a cluster (matches `default:"10"` struct tag on ServerLimit).
 	DefaultHetznerServerLimit int32 = 10
+	// DefaultHetznerTokenRefreshInterval is how long a token printed by
+	// OptionsHetzner.TokenCommand is reused when TokenRefreshInterval is unset.
+	DefaultHetznerTokenRefreshInterval = 10 * time.Minute
+)
+
+// AWS default values.
+const (
+	// DefaultAWSAssumeRoleDuration is the assumed-role session lifetime used when
+	// OptionsAWS.AssumeRoleDuration is unset.
+	DefaultAWSAssumeRoleDuration = time.Hour
 )
 
 // DefaultHetznerFallbackLocations returns the default fallback datacenter
@@ -137,6 +137,20 @@ func componentEnumSpecs() []enumSpec {
 			defaultsTo: v1alpha1.LoadBalancerDefault,
 			invalidErr: v1alpha1.ErrInvalidLoadBalancer,
 		},
+		{
+			typeName:   "Hubble",
+			newValue:   func() enumValue { return new(v1alpha1.Hubble) },
+			values:     []string{valueDefault, valueEnabled, valueDisabled},
+			defaultsTo: v1alpha1.HubbleDefault,
+			invalidErr: v1alpha1.ErrInvalidHubble,
+		},
+		{
+			typeName:   "KubeProxyReplacement",
+			newValue:   func() enumValue { return new(v1alpha1.KubeProxyReplacement) },
+			values:     []string{valueDefault, valueEnabled, valueDisabled},
+			defaultsTo: v1alpha1.KubeProxyReplacementDefault,
+			invalidErr: v1alpha1.ErrInvalidKubeProxyReplacement,
+		},
 	}
 }
 
@@ -150,6 +164,34 @@ func policyEnumSpecs() []enumSpec {
 			defaultsTo: v1alpha1.CertManagerDisabled,
 			invalidErr: v1alpha1.ErrInvalidCertManager,
 		},
+		{
+			typeName:   "MirrorRegistryTLS",
+			newValue:   func() enumValue { return new(v1alpha1.MirrorRegistryTLS) },
+			values:     []string{valueEnabled, valueDisabled},
+			defaultsTo: v1alpha1.MirrorRegistryTLSDisabled,
+			invalidErr: v1alpha1.ErrInvalidMirrorRegistryTLS,
+		},
+		{
+			typeName:   "SealedSecrets",
+			newValue:   func() enumValue { return new(v1alpha1.SealedSecrets) },
+			values:     []string{valueEnabled, valueDisabled},
+			defaultsTo: v1alpha1.SealedSecretsDisabled,
+			invalidErr: v1alpha1.ErrInvalidSealedSecrets,
+		},
+		{
+			typeName:   "ExternalSecrets",
+			newValue:   func() enumValue { return new(v1alpha1.ExternalSecrets) },
+			values:     []string{valueEnabled, valueDisabled},
+			defaultsTo: v1alpha1.ExternalSecretsDisabled,
+			invalidErr: v1alpha1.ErrInvalidExternalSecrets,
+		},
+		{
+			typeName:   "Backup",
+			newValue:   func() enumValue { return new(v1alpha1.Backup) },
+			values:     []string{"None", "Velero"},
+			defaultsTo: v1alpha1.BackupNone,
+			invalidErr: v1alpha1.ErrInvalidBackup,
+		},
 		{
 			typeName:   "ImageVerification",
 			newValue:   func() enumValue { return new(v1alpha1.ImageVerification) },
@@ -157,6 +199,13 @@ func policyEnumSpecs() []enumSpec {
 			defaultsTo: v1alpha1.ImageVerificationDisabled,
 			invalidErr: v1alpha1.ErrInvalidImageVerification,
 		},
+		{
+			typeName:   "VulnerabilitySeverity",
+			newValue:   func() enumValue { return new(v1alpha1.VulnerabilitySeverity) },
+			values:     []string{valueNone, "Low", "Medium", "High", "Critical"},
+			defaultsTo: v1alpha1.VulnerabilitySeverityNone,
+			invalidErr: v1alpha1.ErrInvalidVulnerabilitySeverity,
+		},
 		{
 			typeName:   "PolicyEngine",
 			newValue:   func() enumValue { return new(v1alpha1.PolicyEngine) },
@@ -164,6 +213,76 @@ func policyEnumSpecs() []enumSpec {
 			defaultsTo: v1alpha1.PolicyEngineNone,
 			invalidErr: v1alpha1.ErrInvalidPolicyEngine,
 		},
+		{
+			typeName:   "IngressController",
+			newValue:   func() enumValue { return new(v1alpha1.IngressController) },
+			values:     []string{valueNone, "Nginx", "Traefik", "Contour"},
+			defaultsTo: v1alpha1.IngressControllerNone,
+			invalidErr: v1alpha1.ErrInvalidIngressController,
+		},
+		{
+			typeName:   "GatewayAPI",
+			newValue:   func() enumValue { return new(v1alpha1.GatewayAPI) },
+			values:     []string{valueNone, "CRDs", "Cilium", "Envoy"},
+			defaultsTo: v1alpha1.GatewayAPINone,
+			invalidErr: v1alpha1.ErrInvalidGatewayAPI,
+		},
+		{
+			typeName:   "ServiceMesh",
+			newValue:   func() enumValue { return new(v1alpha1.ServiceMesh) },
+			values:     []string{valueNone, "Istio", "Linkerd"},
+			defaultsTo: v1alpha1.ServiceMeshNone,
+			invalidErr: v1alpha1.ErrInvalidServiceMesh,
+		},
+		{
+			typeName:   "IstioMode",
+			newValue:   func() enumValue { return new(v1alpha1.IstioMode) },
+			values:     []string{"Sidecar", "Ambient"},
+			defaultsTo: v1alpha1.IstioModeSidecar,
+			invalidErr: v1alpha1.ErrInvalidIstioMode,
+		},
+		{
+			typeName:   "Knative",
+			newValue:   func() enumValue { return new(v1alpha1.Knative) },
+			values:     []string{valueEnabled, valueDisabled},
+			defaultsTo: v1alpha1.KnativeDisabled,
+			invalidErr: v1alpha1.ErrInvalidKnative,
+		},
+		{
+			typeName:   "ArgoCDLayout",
+			newValue:   func() enumValue { return new(v1alpha1.ArgoCDLayout) },
+			values:     []string{"Application", "ApplicationSet"},
+			defaultsTo: v1alpha1.ArgoCDLayoutApplication,
+			invalidErr: v1alpha1.ErrInvalidArgoCDLayout,
+		},
+		{
+			typeName:   "ArgoCDGenerator",
+			newValue:   func() enumValue { return new(v1alpha1.ArgoCDGenerator) },
+			values:     []string{"Directory", "Cluster"},
+			defaultsTo: v1alpha1.ArgoCDGeneratorDirectory,
+			invalidErr: v1alpha1.ErrInvalidArgoCDGenerator,
+		},
+		{
+			typeName:   "IdentityProvider",
+			newValue:   func() enumValue { return new(v1alpha1.IdentityProvider) },
+			values:     []string{valueNone, "Keycloak"},
+			defaultsTo: v1alpha1.IdentityProviderNone,
+			invalidErr: v1alpha1.ErrInvalidIdentityProvider,
+		},
+		{
+			typeName:   "Logging",
+			newValue:   func() enumValue { return new(v1alpha1.Logging) },
+			values:     []string{valueNone, "Loki"},
+			defaultsTo: v1alpha1.LoggingNone,
+			invalidErr: v1alpha1.ErrInvalidLogging,
+		},
+		{
+			typeName:   "SpotReclaimPolicy",
+			newValue:   func() enumValue { return new(v1alpha1.SpotReclaimPolicy) },
+			values:     []string{"Replace", "Deallocate"},
+			defaultsTo: v1alpha1.SpotReclaimPolicyReplace,
+			invalidErr: v1alpha1.ErrInvalidSpotReclaimPolicy,
+		},
 		{
 			typeName:   "IngressFirewall",
 			newValue:   func() enumValue { return new(v1alpha1.IngressFirewall) },
@@ -171,6 +290,20 @@ func policyEnumSpecs() []enumSpec {
 			defaultsTo: v1alpha1.IngressFirewallEnabled,
 			invalidErr: v1alpha1.ErrInvalidIngressFirewall,
 		},
+		{
+			typeName:   "IPFamily",
+			newValue:   func() enumValue { return new(v1alpha1.IPFamily) },
+			values:     []string{"ipv4", "ipv6", "dual"},
+			defaultsTo: v1alpha1.IPFamilyIPv4,
+			invalidErr: v1alpha1.ErrInvalidIPFamily,
+		},
+		{
+			typeName:   "NodeRole",
+			newValue:   func() enumValue { return new(v1alpha1.NodeRole) },
+			values:     []string{"ControlPlane", "Worker"},
+			defaultsTo: v1alpha1.NodeRoleWorker,
+			invalidErr: v1alpha1.ErrInvalidNodeRole,
+		},
 	}
 }
 
@@ -2,96 +2,146 @@ package v1alpha1
 
 import "github.com/devantler-tech/ksail/v7/pkg/envvar"
 
+// expandFunc expands the placeholders in one configuration value.
+type expandFunc func(string) string
+
 // ExpandEnvVars expands environment variable placeholders in all string fields
 // of the cluster configuration. This includes paths, credentials, contexts, and
 // other configuration values.
 //
-// Placeholders use the format ${VAR_NAME}. If a referenced environment variable
-// is not set, the placeholder is replaced with an empty string.
+// Placeholders use the format ${VAR_NAME} or ${VAR_NAME:-default}. If a referenced
+// environment variable is not set, the placeholder is replaced with its default,
+// or with an empty string and a warning when it has none.
 //
 // This method should be called after unmarshaling the configuration to ensure
 // all user-facing string values support environment variable expansion.
 func (c *Cluster) ExpandEnvVars() {
-	c.expandSpec()
+	c.expandSpec(envvar.Expand)
+}
+
+// ExpandEnvVarsStrict expands the same fields as ExpandEnvVars but returns
+// envvar.ErrUnsetVariable, naming every offending variable, when a placeholder
+// without a default references an unset environment variable.
+func (c *Cluster) ExpandEnvVarsStrict() error {
+	expander := envvar.NewStrictExpander(nil)
+	c.expandSpec(expander.Expand)
+
+	return expander.Err() //nolint:wrapcheck // the error already names the variables
 }
 
-func (c *Cluster) expandSpec() {
+func (c *Cluster) expandSpec(expand expandFunc) {
 	// Expand top-level Spec fields
-	c.Spec.Editor = envvar.Expand(c.Spec.Editor)
+	c.Spec.Editor = expand(c.Spec.Editor)
 
 	// Expand ClusterSpec fields
-	c.expandClusterSpec()
+	c.expandClusterSpec(expand)
 
 	// Expand ProviderSpec fields
-	c.expandProviderSpec()
+	c.expandProviderSpec(expand)
 
 	// Expand WorkloadSpec fields
-	c.expandWorkloadSpec()
+	c.expandWorkloadSpec(expand)
+
+	// Expand SeedSpec values and file paths
+	c.expandSeedSpec(expand)
 
 	// Expand ChatSpec fields
-	c.expandChatSpec()
+	c.expandChatSpec(expand)
+
+	// Expand NotifySpec fields
+	c.expandNotifySpec(expand)
 }
 
-func (c *Cluster) expandClusterSpec() {
+func (c *Cluster) expandClusterSpec(expand expandFunc) {
 	cluster := &c.Spec.Cluster
 
 	// Expand cluster-level fields
-	cluster.DistributionConfig = envvar.Expand(cluster.DistributionConfig)
+	cluster.DistributionConfig = expand(cluster.DistributionConfig)
 
 	// Expand Connection fields
-	cluster.Connection.Kubeconfig = envvar.Expand(cluster.Connection.Kubeconfig)
-	cluster.Connection.Context = envvar.Expand(cluster.Connection.Context)
+	cluster.Connection.Kubeconfig = expand(cluster.Connection.Kubeconfig)
+	cluster.Connection.Context = expand(cluster.Connection.Context)
 
 	// Expand entire registry string including credentials and host. When
-	// ResolveCredentials() is called later, the envvar.Expand() calls within it
+	// ResolveCredentials() is called later, the expand() calls within it
 	// become no-ops since expansion has already occurred.
-	cluster.LocalRegistry.Registry = envvar.Expand(cluster.LocalRegistry.Registry)
+	cluster.LocalRegistry.Registry = expand(cluster.LocalRegistry.Registry)
+	cluster.RegistryBinding.HostIP = expand(cluster.RegistryBinding.HostIP)
+
+	cluster.Tenancy.Prefix = expand(cluster.Tenancy.Prefix)
 
 	// Note: SOPS.AgeKeyEnvVar is the name of the env var itself, not a value to expand
 
 	// Expand distribution-specific options
-	c.expandVanillaOptions()
-	c.expandTalosOptions()
-	c.expandEKSOptions()
+	c.expandVanillaOptions(expand)
+	c.expandTalosOptions(expand)
+	c.expandEKSOptions(expand)
 }
 
-func (c *Cluster) expandVanillaOptions() {
+func (c *Cluster) expandVanillaOptions(expand expandFunc) {
 	vanilla := &c.Spec.Cluster.Vanilla
-	vanilla.MirrorsDir = envvar.Expand(vanilla.MirrorsDir)
+	vanilla.MirrorsDir = expand(vanilla.MirrorsDir)
 }
 
-func (c *Cluster) expandTalosOptions() {
+func (c *Cluster) expandTalosOptions(expand expandFunc) {
 	talos := &c.Spec.Cluster.Talos
-	talos.Config = envvar.Expand(talos.Config)
+	talos.Config = expand(talos.Config)
 }
 
-func (c *Cluster) expandEKSOptions() {
+func (c *Cluster) expandEKSOptions(expand expandFunc) {
 	eks := &c.Spec.Cluster.EKS
-	eks.AWSLoadBalancerControllerServiceAccount = envvar.Expand(
+	eks.AWSLoadBalancerControllerServiceAccount = expand(
 		eks.AWSLoadBalancerControllerServiceAccount,
 	)
 }
 
-func (c *Cluster) expandProviderSpec() {
-	c.expandHetznerOptions()
+func (c *Cluster) expandProviderSpec(expand expandFunc) {
+	c.expandHetznerOptions(expand)
 }
 
-func (c *Cluster) expandHetznerOptions() {
+func (c *Cluster) expandHetznerOptions(expand expandFunc) {
 	hetzner := &c.Spec.Provider.Hetzner
-	hetzner.SSHKeyName = envvar.Expand(hetzner.SSHKeyName)
-	hetzner.NetworkName = envvar.Expand(hetzner.NetworkName)
-	hetzner.PlacementGroup = envvar.Expand(hetzner.PlacementGroup)
+	hetzner.SSHKeyName = expand(hetzner.SSHKeyName)
+	hetzner.NetworkName = expand(hetzner.NetworkName)
+	hetzner.PlacementGroup = expand(hetzner.PlacementGroup)
 	// Note: TokenEnvVar is the name of the env var itself, not a value to expand
 	// So we don't expand it here
 }
 
-func (c *Cluster) expandWorkloadSpec() {
-	c.Spec.Workload.SourceDirectory = envvar.Expand(c.Spec.Workload.SourceDirectory)
-	c.Spec.Workload.Tag = envvar.Expand(c.Spec.Workload.Tag)
-	c.Spec.Workload.KustomizationFile = envvar.Expand(c.Spec.Workload.KustomizationFile)
-	c.Spec.Workload.Scan.Exceptions = envvar.Expand(c.Spec.Workload.Scan.Exceptions)
+func (c *Cluster) expandWorkloadSpec(expand expandFunc) {
+	c.Spec.Workload.SourceDirectory = expand(c.Spec.Workload.SourceDirectory)
+	c.Spec.Workload.Tag = expand(c.Spec.Workload.Tag)
+	c.Spec.Workload.KustomizationFile = expand(c.Spec.Workload.KustomizationFile)
+	c.Spec.Workload.Scan.Exceptions = expand(c.Spec.Workload.Scan.Exceptions)
+	c.Spec.Workload.Substitution.VarsFile = expand(c.Spec.Workload.Substitution.VarsFile)
+	c.Spec.Workload.Git.URL = expand(c.Spec.Workload.Git.URL)
+	c.Spec.Workload.Git.Branch = expand(c.Spec.Workload.Git.Branch)
+	// Note: Git.TokenEnvVar is the name of the env var itself, not a value to expand
+}
+
+func (c *Cluster) expandSeedSpec(expand expandFunc) {
+	// Seeded values are the main way to get host environment variables into the
+	// cluster, so both literal values and file paths are expanded. Names and
+	// namespaces identify resources and stay verbatim.
+	for _, resources := range [][]SeedResource{c.Spec.Seed.ConfigMaps, c.Spec.Seed.Secrets} {
+		for i := range resources {
+			for key, value := range resources[i].Data {
+				resources[i].Data[key] = expand(value)
+			}
+
+			for key, path := range resources[i].Files {
+				resources[i].Files[key] = expand(path)
+			}
+		}
+	}
+}
+
+func (c *Cluster) expandChatSpec(expand expandFunc) {
+	c.Spec.Chat.Model = expand(c.Spec.Chat.Model)
 }
 
-func (c *Cluster) expandChatSpec() {
-	c.Spec.Chat.Model = envvar.Expand(c.Spec.Chat.Model)
+func (c *Cluster) expandNotifySpec(expand expandFunc) {
+	c.Spec.Notify.LogDir = expand(c.Spec.Notify.LogDir)
+	// The webhook URL commonly embeds a token, so it is read from the environment.
+	c.Spec.Notify.Webhook = expand(c.Spec.Notify.Webhook)
 }
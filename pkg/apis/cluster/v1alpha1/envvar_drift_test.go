@@ -31,6 +31,8 @@ func expandedSpecStringFields() []string {
 		"Cluster.Connection.Kubeconfig",
 		"Cluster.Connection.Context",
 		"Cluster.LocalRegistry.Registry",
+		"Cluster.RegistryBinding.HostIP",
+		"Cluster.Tenancy.Prefix",
 		"Cluster.Vanilla.MirrorsDir",
 		"Cluster.Talos.Config",
 		"Cluster.EKS.AWSLoadBalancerControllerServiceAccount",
@@ -41,7 +43,24 @@ func expandedSpecStringFields() []string {
 		"Workload.Tag",
 		"Workload.KustomizationFile",
 		"Workload.Scan.Exceptions",
+		"Workload.Substitution.VarsFile",
+		"Workload.Git.URL",
+		"Workload.Git.Branch",
 		"Chat.Model",
+		"Notify.LogDir",
+		"Notify.Webhook",
+	}
+}
+
+// expandedSpecElementFields lists the map-valued Spec fields ExpandEnvVars
+// expands element by element. They cannot be probed through fieldByPath, so
+// TestCluster_ExpandEnvVars_SeedValues covers them behaviorally.
+func expandedSpecElementFields() []string {
+	return []string{
+		"Seed.ConfigMaps[].Data[]",
+		"Seed.ConfigMaps[].Files[]",
+		"Seed.Secrets[].Data[]",
+		"Seed.Secrets[].Files[]",
 	}
 }
 
@@ -53,9 +72,11 @@ func skippedSpecStringFields() []string {
 		skippedEnvVarNameFields(),
 		skippedVersionPinFields(),
 		skippedAutoscalerPoolFields(),
+		skippedNodeFields(),
 		skippedOIDCFields(),
 		skippedClusterWorkloadConfigFields(),
 		skippedProviderInfraFields(),
+		skippedArgoCDProjectFields(),
 	)
 }
 
@@ -82,6 +103,7 @@ func skippedEnvVarNameFields() []string {
 		"Provider.Kubernetes.KubeconfigEnvVar",
 		"Provider.Omni.EndpointEnvVar",
 		"Provider.Omni.ServiceAccountKeyEnvVar",
+		"Workload.Git.TokenEnvVar",
 	}
 }
 
@@ -123,6 +145,19 @@ func skippedAutoscalerPoolFields() []string {
 	}
 }
 
+// skippedNodeFields are per-node resource quantities, GPU requests, labels, and
+// taints passed verbatim to the node containers and kubelets.
+func skippedNodeFields() []string {
+	return []string{
+		"Cluster.Nodes[].GPUs",
+		"Cluster.Nodes[].Labels[]",
+		"Cluster.Nodes[].Resources.CPU",
+		"Cluster.Nodes[].Resources.Memory",
+		"Cluster.Nodes[].Taints[].Key",
+		"Cluster.Nodes[].Taints[].Value",
+	}
+}
+
 // skippedOIDCFields are OIDC issuer coordinates written into generated
 // kubeconfig/apiserver configuration without expansion.
 func skippedOIDCFields() []string {
@@ -139,21 +174,41 @@ func skippedOIDCFields() []string {
 }
 
 // skippedClusterWorkloadConfigFields are remaining cluster/workload settings
-// (enum-like strings, image lists, hooks, verification config) that the
-// expansion list has never covered.
+// (enum-like strings, image lists, hooks, verification config, resource
+// metadata validated against Kubernetes label syntax) that the expansion list
+// has never covered.
 func skippedClusterWorkloadConfigFields() []string {
 	return []string{
 		"Chat.ReasoningEffort",
+		"Cluster.ActionsRunner.GitHubConfigURL",
+		"Cluster.ActionsRunner.Labels[]",
+		"Cluster.ActionsRunner.Name",
 		"Cluster.ImportImages",
+		"Cluster.ResourceMetadata.Annotations[]",
+		"Cluster.ResourceMetadata.Labels[]",
 		"Cluster.SOPS.Extract.File",
 		"Cluster.SOPS.Extract.PublicKeys[]",
 		"Cluster.Talos.Extensions[]",
 		"Cluster.Talos.ExtraPortMappings[].Protocol",
+		// Dockerfile snippet: its $VAR references are build-time, not KSail's.
+		"Cluster.Vanilla.NodeImageDockerfile",
+		"Cluster.VulnerabilityPolicy.Allowlist[]",
+		"Cluster.VulnerabilityPolicy.Images[]",
+		// File paths or inline PEM; certificates are never templated.
+		"Trust.CABundles[]",
+		// Seeded resource identities; only their values are expanded.
+		"Seed.ConfigMaps[].Name",
+		"Seed.ConfigMaps[].Namespace",
+		"Seed.Secrets[].Name",
+		"Seed.Secrets[].Namespace",
 		"Workload.Flux.Verify.MatchOIDCIdentity[].Issuer",
 		"Workload.Flux.Verify.MatchOIDCIdentity[].Subject",
 		"Workload.Flux.Verify.Provider",
 		"Workload.Flux.Verify.SecretRef.Name",
 		"Workload.Scan.Frameworks[]",
+		// Substitution values are substituted into manifests verbatim; the
+		// environment is already one of their sources.
+		"Workload.Substitution.Vars[]",
 		"Workload.Validation.Rules",
 		"Workload.Validation.SchemaLocations[]",
 		"Workload.Validation.SkipKinds[]",
@@ -165,12 +220,16 @@ func skippedClusterWorkloadConfigFields() []string {
 // types, locations, CIDRs, endpoints) consumed verbatim by the providers.
 func skippedProviderInfraFields() []string {
 	return []string{
+		"Provider.AWS.AssumeRoleARN",
 		"Provider.Hetzner.AllowedCIDRs[]",
 		"Provider.Hetzner.ControlPlaneServerType",
 		"Provider.Hetzner.FallbackLocations[]",
 		"Provider.Hetzner.FloatingIPLocation",
 		"Provider.Hetzner.Location",
 		"Provider.Hetzner.NetworkCIDR",
+		"Provider.Hetzner.NodePortCIDRs[]",
+		// Command argv: it reads its own environment when it runs.
+		"Provider.Hetzner.TokenCommand[]",
 		"Provider.Hetzner.WorkerServerType",
 		"Provider.Kubernetes.Context",
 		"Provider.Kubernetes.GatewayClassName",
@@ -185,6 +244,26 @@ func skippedProviderInfraFields() []string {
 	}
 }
 
+// skippedArgoCDProjectFields are Argo CD AppProject guardrails (glob patterns,
+// cron schedules, durations) mirrored verbatim into the AppProject, and the
+// ApplicationSet cluster selector mirrored verbatim into its generator.
+func skippedArgoCDProjectFields() []string {
+	return []string{
+		"Workload.ArgoCD.ApplicationSet.ClusterSelector[]",
+		"Workload.ArgoCD.Project.Destinations[].Name",
+		"Workload.ArgoCD.Project.Destinations[].Namespace",
+		"Workload.ArgoCD.Project.Destinations[].Server",
+		"Workload.ArgoCD.Project.SourceRepos[]",
+		"Workload.ArgoCD.Project.SyncWindows[].Applications[]",
+		"Workload.ArgoCD.Project.SyncWindows[].Clusters[]",
+		"Workload.ArgoCD.Project.SyncWindows[].Duration",
+		"Workload.ArgoCD.Project.SyncWindows[].Kind",
+		"Workload.ArgoCD.Project.SyncWindows[].Namespaces[]",
+		"Workload.ArgoCD.Project.SyncWindows[].Schedule",
+		"Workload.ArgoCD.Project.SyncWindows[].TimeZone",
+	}
+}
+
 // TestExpandEnvVars_CoversEverySpecStringField asserts the expanded and skip
 // lists exactly partition the set of plain string fields reachable from Spec.
 func TestExpandEnvVars_CoversEverySpecStringField(t *testing.T) {
@@ -193,7 +272,9 @@ func TestExpandEnvVars_CoversEverySpecStringField(t *testing.T) {
 	discovered := collectPlainStringFieldPaths(reflect.TypeFor[v1alpha1.Spec](), "")
 	slices.Sort(discovered)
 
-	accounted := slices.Concat(expandedSpecStringFields(), skippedSpecStringFields())
+	accounted := slices.Concat(
+		expandedSpecStringFields(), expandedSpecElementFields(), skippedSpecStringFields(),
+	)
 	slices.Sort(accounted)
 
 	for _, path := range discovered {
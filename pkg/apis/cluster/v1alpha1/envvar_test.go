@@ -75,6 +75,27 @@ func TestCluster_ExpandEnvVars_ChatModel(t *testing.T) {
 	assert.Equal(t, "gpt-4", cluster.Spec.Chat.Model)
 }
 
+func TestCluster_ExpandEnvVars_SeedValues(t *testing.T) {
+	t.Setenv("TEST_SEED_TOKEN", "s3cret")
+	t.Setenv("TEST_SEED_DIR", "/secrets")
+
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Seed.ConfigMaps = []v1alpha1.SeedResource{{
+		Name: "${TEST_SEED_TOKEN}",
+		Data: map[string]string{"TOKEN": "token=${TEST_SEED_TOKEN}"},
+	}}
+	cluster.Spec.Seed.Secrets = []v1alpha1.SeedResource{{
+		Name:  "app",
+		Files: map[string]string{"token": "${TEST_SEED_DIR}/token.sops.yaml"},
+	}}
+
+	cluster.ExpandEnvVars()
+
+	assert.Equal(t, "${TEST_SEED_TOKEN}", cluster.Spec.Seed.ConfigMaps[0].Name)
+	assert.Equal(t, "token=s3cret", cluster.Spec.Seed.ConfigMaps[0].Data["TOKEN"])
+	assert.Equal(t, "/secrets/token.sops.yaml", cluster.Spec.Seed.Secrets[0].Files["token"])
+}
+
 func TestCluster_ExpandEnvVars_LocalRegistry(t *testing.T) {
 	t.Setenv("TEST_REGISTRY_USER", "testuser")
 	t.Setenv("TEST_REGISTRY_PASS", "testpass")
@@ -26,12 +26,95 @@ var ErrInvalidLoadBalancer = errors.New("invalid load balancer")
 // ErrInvalidCertManager is returned when an invalid cert-manager option is specified.
 var ErrInvalidCertManager = errors.New("invalid cert-manager")
 
+// ErrInvalidMirrorRegistryTLS is returned when an invalid mirror registry TLS option is specified.
+var ErrInvalidMirrorRegistryTLS = errors.New("invalid mirror registry TLS")
+
+// ErrInvalidSealedSecrets is returned when an invalid Sealed Secrets option is specified.
+var ErrInvalidSealedSecrets = errors.New("invalid sealed secrets")
+
+// ErrInvalidExternalSecrets is returned when an invalid External Secrets option is specified.
+var ErrInvalidExternalSecrets = errors.New("invalid external secrets")
+
+// ErrInvalidBackup is returned when an invalid backup option is specified.
+var ErrInvalidBackup = errors.New("invalid backup")
+
 // ErrInvalidPolicyEngine is returned when an invalid policy engine is specified.
 var ErrInvalidPolicyEngine = errors.New("invalid policy engine")
 
+// ErrInvalidIngressController is returned when an invalid ingress controller is specified.
+var ErrInvalidIngressController = errors.New("invalid ingress controller")
+
+// ErrInvalidServiceMesh is returned when an invalid service mesh is specified.
+var ErrInvalidServiceMesh = errors.New("invalid service mesh")
+
+// ErrInvalidIstioMode is returned when an invalid Istio mode is specified.
+var ErrInvalidIstioMode = errors.New("invalid istio mode")
+
+// ErrInvalidKnative is returned when an invalid Knative option is specified.
+var ErrInvalidKnative = errors.New("invalid knative")
+
+// ErrInvalidIdentityProvider is returned when an invalid identity provider option is specified
+// or the identity provider is not supported by the cluster's distribution and provider.
+var ErrInvalidIdentityProvider = errors.New("invalid identity provider")
+
+// ErrInvalidGatewayAPI is returned when an invalid Gateway API option is specified.
+var ErrInvalidGatewayAPI = errors.New("invalid gateway API")
+
+// ErrInvalidLogging is returned when an invalid logging stack is specified.
+var ErrInvalidLogging = errors.New("invalid logging stack")
+
+// ErrInvalidSpotReclaimPolicy is returned when an invalid spot reclaim policy is specified.
+var ErrInvalidSpotReclaimPolicy = errors.New("invalid spot reclaim policy")
+
+// ErrInvalidSpot is returned when spot capacity is requested for an unsupported
+// distribution or with a reclaim policy the distribution does not support.
+var ErrInvalidSpot = errors.New("invalid spot configuration")
+
+// ErrInvalidActionsRunner is returned when spec.cluster.actionsRunner is enabled with a missing or
+// malformed GitHub URL, an invalid scale set name, or an inconsistent runner range.
+var ErrInvalidActionsRunner = errors.New("invalid actions runner configuration")
+
+// ErrInvalidIPFamily is returned when an invalid IP family is specified.
+var ErrInvalidIPFamily = errors.New("invalid IP family")
+
+// ErrInvalidNetwork is returned when an IP family is requested for a distribution
+// or provider that does not support it.
+var ErrInvalidNetwork = errors.New("invalid network configuration")
+
+// ErrInvalidAPIServerPort is returned when spec.cluster.connection.apiServerPort is out
+// of range or set for a distribution or provider that cannot publish it.
+var ErrInvalidAPIServerPort = errors.New("invalid API server port")
+
+// ErrInvalidSeed is returned when a spec.seed ConfigMap or Secret has an invalid name,
+// namespace, or key, or is declared twice.
+var ErrInvalidSeed = errors.New("invalid seed resource")
+
+// ErrInvalidRegistryBinding is returned when spec.cluster.registryBinding has a malformed host
+// address or an invalid or duplicate mirror port.
+var ErrInvalidRegistryBinding = errors.New("invalid registry binding")
+
+// ErrInvalidNodeRole is returned when a spec.cluster.nodes entry has an invalid role.
+var ErrInvalidNodeRole = errors.New("invalid node role")
+
+// ErrInvalidNodeSpec is returned when a spec.cluster.nodes entry is malformed or is
+// set for a distribution or provider that does not support per-node customization.
+var ErrInvalidNodeSpec = errors.New("invalid node configuration")
+
 // ErrInvalidImageVerification is returned when an invalid image verification option is specified.
 var ErrInvalidImageVerification = errors.New("invalid image verification")
 
+// ErrInvalidVulnerabilitySeverity is returned when an invalid vulnerability severity is specified.
+var ErrInvalidVulnerabilitySeverity = errors.New("invalid vulnerability severity")
+
+// ErrInvalidVulnerabilityPolicy is returned when spec.cluster.vulnerabilityPolicy is invalid.
+var ErrInvalidVulnerabilityPolicy = errors.New("invalid vulnerability policy")
+
+// ErrInvalidAssumeRole is returned when spec.provider.aws assume-role settings are invalid.
+var ErrInvalidAssumeRole = errors.New("invalid AWS assume role")
+
+// ErrInvalidTokenCommand is returned when spec.provider.hetzner token command settings are invalid.
+var ErrInvalidTokenCommand = errors.New("invalid Hetzner token command")
+
 // ErrInvalidNodeAutoscaling is returned when an invalid node autoscaling option is specified.
 var ErrInvalidNodeAutoscaling = errors.New("invalid node autoscaling")
 
@@ -44,6 +127,12 @@ var ErrInvalidProvider = errors.New("invalid provider")
 // ErrInvalidIngressFirewall is returned when an invalid ingress firewall option is specified.
 var ErrInvalidIngressFirewall = errors.New("invalid ingress firewall")
 
+// ErrInvalidHubble is returned when an invalid Hubble option is specified.
+var ErrInvalidHubble = errors.New("invalid hubble")
+
+// ErrInvalidKubeProxyReplacement is returned when an invalid kube-proxy replacement option is specified.
+var ErrInvalidKubeProxyReplacement = errors.New("invalid kube-proxy replacement")
+
 // ErrInvalidPlacementGroupStrategy is returned when an invalid placement group strategy is specified.
 var ErrInvalidPlacementGroupStrategy = errors.New("invalid placement group strategy")
 
@@ -179,3 +268,18 @@ var ErrGatewayAPINotAvailable = errors.New(
 
 // ErrCIDROverlap is returned when nested cluster CIDRs overlap with host cluster CIDRs.
 var ErrCIDROverlap = errors.New("nested cluster CIDR overlaps with host cluster CIDR")
+
+// ErrInvalidResourceMetadata is returned when a spec.cluster.resourceMetadata label or annotation
+// does not satisfy Kubernetes metadata syntax.
+var ErrInvalidResourceMetadata = errors.New("invalid resource metadata")
+
+// ErrInvalidArgoCDProject is returned when spec.workload.argoCD.project declares an invalid
+// source repository, destination, or sync window.
+var ErrInvalidArgoCDProject = errors.New("invalid Argo CD project")
+
+// ErrInvalidArgoCDLayout is returned when spec.workload.argoCD.layout is invalid or the
+// ApplicationSet layout cannot be generated for the configured source.
+var ErrInvalidArgoCDLayout = errors.New("invalid Argo CD layout")
+
+// ErrInvalidArgoCDGenerator is returned when an invalid ApplicationSet generator is specified.
+var ErrInvalidArgoCDGenerator = errors.New("invalid Argo CD ApplicationSet generator")
@@ -0,0 +1,44 @@
+package v1alpha1
+
+// ExternalSecrets defines the External Secrets Operator options for a KSail cluster.
+type ExternalSecrets string
+
+const (
+	// ExternalSecretsEnabled ensures the External Secrets Operator is installed.
+	ExternalSecretsEnabled ExternalSecrets = "Enabled"
+	// ExternalSecretsDisabled ensures the External Secrets Operator is not installed.
+	ExternalSecretsDisabled ExternalSecrets = "Disabled"
+)
+
+// ValidExternalSecrets returns supported External Secrets values.
+func ValidExternalSecrets() []ExternalSecrets {
+	return []ExternalSecrets{
+		ExternalSecretsEnabled,
+		ExternalSecretsDisabled,
+	}
+}
+
+// Set for ExternalSecrets (pflag.Value interface).
+func (e *ExternalSecrets) Set(value string) error {
+	return setEnum(e, value, ValidExternalSecrets(), ErrInvalidExternalSecrets)
+}
+
+// String returns the string representation of the ExternalSecrets.
+func (e *ExternalSecrets) String() string {
+	return string(*e)
+}
+
+// Type returns the type of the ExternalSecrets.
+func (e *ExternalSecrets) Type() string {
+	return "ExternalSecrets"
+}
+
+// Default returns the default value for ExternalSecrets (Disabled).
+func (e *ExternalSecrets) Default() any {
+	return ExternalSecretsDisabled
+}
+
+// ValidValues returns all valid ExternalSecrets values as strings.
+func (e *ExternalSecrets) ValidValues() []string {
+	return validValueStrings(ValidExternalSecrets())
+}
@@ -0,0 +1,108 @@
+package v1alpha1
+
+// GatewayAPI defines the Gateway API options for a KSail cluster: whether the
+// Gateway API CRDs are installed and which controller implements them.
+type GatewayAPI string
+
+const (
+	// GatewayAPINone is the default and installs nothing beyond what the CNI
+	// brings (the Cilium CNI always ships its own Gateway API controller).
+	GatewayAPINone GatewayAPI = "None"
+	// GatewayAPICRDs installs only the Gateway API CRDs, for a controller
+	// managed outside KSail.
+	GatewayAPICRDs GatewayAPI = "CRDs"
+	// GatewayAPICilium uses the Gateway API controller built into the Cilium CNI.
+	// Requires spec.cluster.cni: Cilium.
+	GatewayAPICilium GatewayAPI = "Cilium"
+	// GatewayAPIEnvoy installs the Gateway API CRDs and Envoy Gateway.
+	GatewayAPIEnvoy GatewayAPI = "Envoy"
+)
+
+// GatewayClass names registered by the Gateway API controllers KSail supports.
+const (
+	// CiliumGatewayClassName is the GatewayClass the Cilium CNI creates.
+	CiliumGatewayClassName = "cilium"
+	// EnvoyGatewayClassName is the GatewayClass KSail creates for Envoy Gateway.
+	EnvoyGatewayClassName = "envoy-gateway"
+)
+
+// ValidGatewayAPIs returns supported Gateway API values.
+func ValidGatewayAPIs() []GatewayAPI {
+	return []GatewayAPI{
+		GatewayAPINone,
+		GatewayAPICRDs,
+		GatewayAPICilium,
+		GatewayAPIEnvoy,
+	}
+}
+
+// Set for GatewayAPI (pflag.Value interface).
+func (g *GatewayAPI) Set(value string) error {
+	return setEnum(g, value, ValidGatewayAPIs(), ErrInvalidGatewayAPI)
+}
+
+// String returns the string representation of the GatewayAPI.
+func (g *GatewayAPI) String() string {
+	return string(*g)
+}
+
+// Type returns the type of the GatewayAPI.
+func (g *GatewayAPI) Type() string {
+	return "GatewayAPI"
+}
+
+// Default returns the default value for GatewayAPI (None).
+func (g *GatewayAPI) Default() any {
+	return GatewayAPINone
+}
+
+// ValidValues returns all valid GatewayAPI values as strings.
+func (g *GatewayAPI) ValidValues() []string {
+	return validValueStrings(ValidGatewayAPIs())
+}
+
+// Enabled reports whether Gateway API support is selected (anything but None or empty).
+func (g GatewayAPI) Enabled() bool {
+	return g != "" && g != GatewayAPINone
+}
+
+// NeedsInstall reports whether KSail installs anything for this value. Cilium's
+// controller and CRDs are installed by the Cilium CNI installer, so only CRDs
+// and Envoy need a dedicated install step.
+func (g GatewayAPI) NeedsInstall() bool {
+	return g == GatewayAPICRDs || g == GatewayAPIEnvoy
+}
+
+// GatewayClassName returns the GatewayClass served by the selected controller,
+// or "" when no controller is selected (None or CRDs only).
+func (g GatewayAPI) GatewayClassName() string {
+	switch g {
+	case GatewayAPICilium:
+		return CiliumGatewayClassName
+	case GatewayAPIEnvoy:
+		return EnvoyGatewayClassName
+	case GatewayAPINone, GatewayAPICRDs:
+		return ""
+	}
+
+	return ""
+}
+
+// EffectiveValue resolves the value against the selected CNI for diffing. The
+// Cilium CNI always installs the Gateway API CRDs and runs its own gateway
+// controller, so CRDs and Cilium add nothing on top of it and resolve to None
+// there; every other combination passes through unchanged.
+func (g *GatewayAPI) EffectiveValue(cni CNI) GatewayAPI {
+	if cni != CNICilium {
+		return *g
+	}
+
+	switch *g {
+	case GatewayAPICRDs, GatewayAPICilium:
+		return GatewayAPINone
+	case "", GatewayAPINone, GatewayAPIEnvoy:
+		return *g
+	}
+
+	return *g
+}
@@ -0,0 +1,63 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGatewayAPI_Predicates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		in           v1alpha1.GatewayAPI
+		enabled      bool
+		needsInstall bool
+		className    string
+	}{
+		{"empty", v1alpha1.GatewayAPI(""), false, false, ""},
+		{"None", v1alpha1.GatewayAPINone, false, false, ""},
+		{"CRDs", v1alpha1.GatewayAPICRDs, true, true, ""},
+		{"Cilium", v1alpha1.GatewayAPICilium, true, false, "cilium"},
+		{"Envoy", v1alpha1.GatewayAPIEnvoy, true, true, "envoy-gateway"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.enabled, testCase.in.Enabled())
+			assert.Equal(t, testCase.needsInstall, testCase.in.NeedsInstall())
+			assert.Equal(t, testCase.className, testCase.in.GatewayClassName())
+		})
+	}
+}
+
+func TestGatewayAPI_EffectiveValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   v1alpha1.GatewayAPI
+		cni  v1alpha1.CNI
+		want v1alpha1.GatewayAPI
+	}{
+		{"None on Default CNI", v1alpha1.GatewayAPINone, v1alpha1.CNIDefault, v1alpha1.GatewayAPINone},
+		{"CRDs on Calico", v1alpha1.GatewayAPICRDs, v1alpha1.CNICalico, v1alpha1.GatewayAPICRDs},
+		{"Cilium on Default CNI", v1alpha1.GatewayAPICilium, v1alpha1.CNIDefault, v1alpha1.GatewayAPICilium},
+		{"None on Cilium", v1alpha1.GatewayAPINone, v1alpha1.CNICilium, v1alpha1.GatewayAPINone},
+		{"CRDs on Cilium", v1alpha1.GatewayAPICRDs, v1alpha1.CNICilium, v1alpha1.GatewayAPINone},
+		{"Cilium on Cilium", v1alpha1.GatewayAPICilium, v1alpha1.CNICilium, v1alpha1.GatewayAPINone},
+		{"Envoy on Cilium", v1alpha1.GatewayAPIEnvoy, v1alpha1.CNICilium, v1alpha1.GatewayAPIEnvoy},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, testCase.in.EffectiveValue(testCase.cni))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultGitSourceBranch is the branch GitOps engines track when spec.workload.git.branch is unset.
+const DefaultGitSourceBranch = "main"
+
+// GitSource points the GitOps engine at a Git repository instead of the OCI artifact KSail
+// pushes to its registry. When URL is set, Flux syncs from a GitRepository and ArgoCD from a
+// git repository, both tracking Branch and reading manifests from spec.workload.sourceDirectory.
+// `ksail init --push-to` records the repository it creates here.
+type GitSource struct {
+	// URL is the HTTPS clone URL of the repository (e.g. https://github.com/org/repo.git).
+	URL string `json:"url,omitzero" jsonschema_description:"HTTPS clone URL of the Git repository the GitOps engine tracks instead of the OCI artifact (e.g. https://github.com/org/repo.git). Set by 'ksail init --push-to'."` //nolint:lll
+	// Branch is the branch the GitOps engine tracks. Defaults to DefaultGitSourceBranch.
+	Branch string `json:"branch,omitzero" jsonschema_description:"Branch the GitOps engine tracks. Defaults to main."` //nolint:lll
+	// TokenEnvVar names the environment variable holding an access token for private
+	// repositories. The token is stored in-cluster as the engine's repository credentials.
+	TokenEnvVar string `json:"tokenEnvVar,omitzero" jsonschema_description:"Environment variable holding an access token for a private repository. When set and non-empty, KSail stores it in-cluster as the GitOps engine's repository credentials."` //nolint:lll
+	// DeployKey authenticates with an SSH deploy key instead of a token. KSail generates the key
+	// under ~/.ksail/deploy-keys, stores it in-cluster as the engine's repository credentials and
+	// has the engine clone SSHURL. Takes precedence over TokenEnvVar.
+	DeployKey bool `json:"deployKey,omitzero" jsonschema_description:"Authenticate with an SSH deploy key instead of a token. KSail generates the key on first use, stores it in-cluster as the GitOps engine's repository credentials, and registers it on GitHub when a token is available or prints the public key to register. Takes precedence over tokenEnvVar."` //nolint:lll
+}
+
+// IsEnabled reports whether a Git repository is configured as the GitOps source.
+func (g GitSource) IsEnabled() bool {
+	return strings.TrimSpace(g.URL) != ""
+}
+
+// ResolvedBranch returns the configured branch, or DefaultGitSourceBranch when unset.
+func (g GitSource) ResolvedBranch() string {
+	branch := strings.TrimSpace(g.Branch)
+	if branch == "" {
+		return DefaultGitSourceBranch
+	}
+
+	return branch
+}
+
+// SSHURL returns the SSH form of URL (ssh://git@host/owner/repo.git) the GitOps engine clones
+// when DeployKey is set. URLs that are not HTTP(S) are returned as configured.
+func (g GitSource) SSHURL() string {
+	url := strings.TrimSpace(g.URL)
+
+	for _, scheme := range []string{"https://", "http://"} {
+		if rest, found := strings.CutPrefix(url, scheme); found {
+			return "ssh://git@" + rest
+		}
+	}
+
+	return url
+}
+
+// CloneURL returns the URL the GitOps engine clones: SSHURL when DeployKey is set, otherwise URL.
+func (g GitSource) CloneURL() string {
+	if g.DeployKey {
+		return g.SSHURL()
+	}
+
+	return strings.TrimSpace(g.URL)
+}
+
+// ResolveToken returns the access token read from TokenEnvVar, or an empty string when no
+// variable is configured, it is unset, or DeployKey replaces token authentication.
+func (g GitSource) ResolveToken() string {
+	name := strings.TrimSpace(g.TokenEnvVar)
+	if name == "" || g.DeployKey {
+		return ""
+	}
+
+	return strings.TrimSpace(os.Getenv(name))
+}
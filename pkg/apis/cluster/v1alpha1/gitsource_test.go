@@ -0,0 +1,56 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitSource_CloneURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		source v1alpha1.GitSource
+		want   string
+	}{
+		{
+			name:   "token or public repository keeps the HTTPS URL",
+			source: v1alpha1.GitSource{URL: " https://github.com/org/repo.git "},
+			want:   "https://github.com/org/repo.git",
+		},
+		{
+			name:   "deploy key clones over SSH",
+			source: v1alpha1.GitSource{URL: "https://github.com/org/repo.git", DeployKey: true},
+			want:   "ssh://git@github.com/org/repo.git",
+		},
+		{
+			name:   "deploy key keeps an SSH URL as configured",
+			source: v1alpha1.GitSource{URL: "ssh://git@gitea.internal:2222/org/repo.git", DeployKey: true},
+			want:   "ssh://git@gitea.internal:2222/org/repo.git",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, test.source.CloneURL())
+		})
+	}
+}
+
+func TestGitSource_ResolveToken_DeployKeyTakesPrecedence(t *testing.T) {
+	// t.Setenv forbids t.Parallel().
+	t.Setenv("KSAIL_TEST_GIT_TOKEN", "s3cr3t")
+
+	source := v1alpha1.GitSource{
+		URL:         "https://github.com/org/repo.git",
+		TokenEnvVar: "KSAIL_TEST_GIT_TOKEN",
+	}
+	assert.Equal(t, "s3cr3t", source.ResolveToken())
+
+	source.DeployKey = true
+	assert.Empty(t, source.ResolveToken())
+}
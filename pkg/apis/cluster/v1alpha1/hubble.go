@@ -0,0 +1,44 @@
+package v1alpha1
+
+// Hubble defines whether Cilium's Hubble flow observability is deployed.
+type Hubble string
+
+const (
+	// HubbleDefault keeps the Cilium chart's Hubble defaults.
+	HubbleDefault Hubble = "Default"
+	// HubbleEnabled enables Hubble together with Hubble Relay, which
+	// `ksail workload network` connects to.
+	HubbleEnabled Hubble = "Enabled"
+	// HubbleDisabled disables Hubble entirely.
+	HubbleDisabled Hubble = "Disabled"
+)
+
+// ValidHubbles returns supported Hubble values.
+func ValidHubbles() []Hubble {
+	return []Hubble{HubbleDefault, HubbleEnabled, HubbleDisabled}
+}
+
+// Set for Hubble (pflag.Value interface).
+func (h *Hubble) Set(value string) error {
+	return setEnum(h, value, ValidHubbles(), ErrInvalidHubble)
+}
+
+// String returns the string representation of the Hubble.
+func (h *Hubble) String() string {
+	return string(*h)
+}
+
+// Type returns the type of the Hubble.
+func (h *Hubble) Type() string {
+	return "Hubble"
+}
+
+// Default returns the default value for Hubble (Default, which keeps the chart defaults).
+func (h *Hubble) Default() any {
+	return HubbleDefault
+}
+
+// ValidValues returns all valid Hubble values as strings.
+func (h *Hubble) ValidValues() []string {
+	return validValueStrings(ValidHubbles())
+}
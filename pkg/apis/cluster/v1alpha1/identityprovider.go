@@ -0,0 +1,124 @@
+package v1alpha1
+
+import "fmt"
+
+// IdentityProvider defines the development identity provider options for a KSail cluster.
+type IdentityProvider string
+
+const (
+	// IdentityProviderNone ensures no development identity provider is installed.
+	IdentityProviderNone IdentityProvider = "None"
+	// IdentityProviderKeycloak ensures a pre-configured Keycloak is installed.
+	IdentityProviderKeycloak IdentityProvider = "Keycloak"
+)
+
+// Fixed settings of the development Keycloak. Its HTTPS Service is a NodePort
+// pinned to KeycloakHTTPSNodePort, and the Kind and K3d provisioners map the same
+// host port onto it, so KeycloakIssuerURL resolves to Keycloak both on the host
+// (for `ksail cluster oidc get-token`) and on the nodes (for the API server).
+const (
+	// KeycloakHTTPSNodePort is the node and host port Keycloak serves HTTPS on.
+	KeycloakHTTPSNodePort int32 = 31843
+	// KeycloakRealm is the realm KSail imports with the test users and groups.
+	KeycloakRealm = "ksail"
+	// KeycloakClientID is the public OIDC client the API server trusts.
+	KeycloakClientID = "kubernetes"
+	// KeycloakIssuerURL is the issuer URL of the KeycloakRealm realm.
+	KeycloakIssuerURL = "https://127.0.0.1:31843/realms/" + KeycloakRealm
+)
+
+// ValidIdentityProviders returns supported IdentityProvider values.
+func ValidIdentityProviders() []IdentityProvider {
+	return []IdentityProvider{
+		IdentityProviderNone,
+		IdentityProviderKeycloak,
+	}
+}
+
+// Set for IdentityProvider (pflag.Value interface).
+func (i *IdentityProvider) Set(value string) error {
+	return setEnum(i, value, ValidIdentityProviders(), ErrInvalidIdentityProvider)
+}
+
+// String returns the string representation of the IdentityProvider.
+func (i *IdentityProvider) String() string {
+	return string(*i)
+}
+
+// Type returns the type of the IdentityProvider.
+func (i *IdentityProvider) Type() string {
+	return "IdentityProvider"
+}
+
+// Default returns the default value for IdentityProvider (None).
+func (i *IdentityProvider) Default() any {
+	return IdentityProviderNone
+}
+
+// ValidValues returns all valid IdentityProvider values as strings.
+func (i *IdentityProvider) ValidValues() []string {
+	return validValueStrings(ValidIdentityProviders())
+}
+
+// KeycloakPortMappings returns the container-to-host port mapping that exposes
+// Keycloak's fixed HTTPS node port on the same host port, or nil when Keycloak
+// is not selected.
+func KeycloakPortMappings(identityProvider IdentityProvider) []PortMapping {
+	if identityProvider != IdentityProviderKeycloak {
+		return nil
+	}
+
+	return []PortMapping{
+		{ContainerPort: KeycloakHTTPSNodePort, HostPort: KeycloakHTTPSNodePort, Protocol: "TCP"},
+	}
+}
+
+// ApplyIdentityProviderOIDCDefaults points the API server OIDC options at the
+// development identity provider, filling only the fields the user left empty.
+// caFile is the host path of the CA that signs the identity provider's serving
+// certificate. It is a no-op when no identity provider is selected.
+func ApplyIdentityProviderOIDCDefaults(cluster *ClusterSpec, caFile string) {
+	if cluster == nil || cluster.IdentityProvider != IdentityProviderKeycloak {
+		return
+	}
+
+	oidc := &cluster.OIDC
+
+	if oidc.IssuerURL == "" {
+		oidc.IssuerURL = KeycloakIssuerURL
+	}
+
+	if oidc.ClientID == "" {
+		oidc.ClientID = KeycloakClientID
+	}
+
+	if oidc.CAFile == "" {
+		oidc.CAFile = caFile
+	}
+}
+
+// ValidateIdentityProvider checks that the development identity provider runs
+// on a cluster whose API server KSail can point at it: Vanilla or K3s on the
+// Docker provider, where the issuer port is mapped to the host.
+func ValidateIdentityProvider(cluster *ClusterSpec) error {
+	if cluster == nil || cluster.IdentityProvider != IdentityProviderKeycloak {
+		return nil
+	}
+
+	if cluster.Provider != "" && cluster.Provider != ProviderDocker {
+		return fmt.Errorf(
+			"%w: Keycloak requires the Docker provider, got %s",
+			ErrInvalidIdentityProvider, cluster.Provider,
+		)
+	}
+
+	switch cluster.Distribution {
+	case DistributionVanilla, DistributionK3s, "":
+		return nil
+	default:
+		return fmt.Errorf(
+			"%w: Keycloak requires the Vanilla or K3s distribution, got %s",
+			ErrInvalidIdentityProvider, cluster.Distribution,
+		)
+	}
+}
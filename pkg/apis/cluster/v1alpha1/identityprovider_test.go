@@ -0,0 +1,59 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeycloakPortMappings(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, v1alpha1.KeycloakPortMappings(v1alpha1.IdentityProviderNone))
+	assert.Nil(t, v1alpha1.KeycloakPortMappings(v1alpha1.IdentityProvider("")))
+	assert.Equal(t, []v1alpha1.PortMapping{
+		{ContainerPort: 31843, HostPort: 31843, Protocol: "TCP"},
+	}, v1alpha1.KeycloakPortMappings(v1alpha1.IdentityProviderKeycloak))
+}
+
+func TestApplyIdentityProviderOIDCDefaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fills empty fields for Keycloak", func(t *testing.T) {
+		t.Parallel()
+
+		cluster := &v1alpha1.ClusterSpec{IdentityProvider: v1alpha1.IdentityProviderKeycloak}
+
+		v1alpha1.ApplyIdentityProviderOIDCDefaults(cluster, "/home/dev/.ksail/registry-tls/ca.crt")
+
+		assert.Equal(t, "https://127.0.0.1:31843/realms/ksail", cluster.OIDC.IssuerURL)
+		assert.Equal(t, "kubernetes", cluster.OIDC.ClientID)
+		assert.Equal(t, "/home/dev/.ksail/registry-tls/ca.crt", cluster.OIDC.CAFile)
+	})
+
+	t.Run("keeps fields set by the user", func(t *testing.T) {
+		t.Parallel()
+
+		cluster := &v1alpha1.ClusterSpec{
+			IdentityProvider: v1alpha1.IdentityProviderKeycloak,
+			OIDC:             v1alpha1.OIDCSpec{ClientID: "custom", CAFile: "/etc/ca.crt"},
+		}
+
+		v1alpha1.ApplyIdentityProviderOIDCDefaults(cluster, "/home/dev/.ksail/registry-tls/ca.crt")
+
+		assert.Equal(t, v1alpha1.KeycloakIssuerURL, cluster.OIDC.IssuerURL)
+		assert.Equal(t, "custom", cluster.OIDC.ClientID)
+		assert.Equal(t, "/etc/ca.crt", cluster.OIDC.CAFile)
+	})
+
+	t.Run("is a no-op without an identity provider", func(t *testing.T) {
+		t.Parallel()
+
+		cluster := &v1alpha1.ClusterSpec{IdentityProvider: v1alpha1.IdentityProviderNone}
+
+		v1alpha1.ApplyIdentityProviderOIDCDefaults(cluster, "/home/dev/.ksail/registry-tls/ca.crt")
+
+		assert.False(t, cluster.OIDC.Enabled())
+	})
+}
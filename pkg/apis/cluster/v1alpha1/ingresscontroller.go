@@ -0,0 +1,84 @@
+package v1alpha1
+
+// IngressController defines the ingress controller options for a KSail cluster.
+type IngressController string
+
+const (
+	// IngressControllerNone is the default and disables ingress controller installation.
+	IngressControllerNone IngressController = "None"
+	// IngressControllerNginx installs ingress-nginx.
+	IngressControllerNginx IngressController = "Nginx"
+	// IngressControllerTraefik installs Traefik.
+	IngressControllerTraefik IngressController = "Traefik"
+	// IngressControllerContour installs Contour (with Envoy as the data plane).
+	IngressControllerContour IngressController = "Contour"
+)
+
+// Fixed ports shared by every ingress controller KSail installs. On the Docker
+// provider the controller's Service is a NodePort pinned to the node ports below,
+// and the Kind, K3d, and Talos provisioners map the host ports onto them so the
+// controller answers on localhost:80 and localhost:443.
+const (
+	// IngressHTTPNodePort is the node port the controller's HTTP listener is pinned to.
+	IngressHTTPNodePort int32 = 30080
+	// IngressHTTPSNodePort is the node port the controller's HTTPS listener is pinned to.
+	IngressHTTPSNodePort int32 = 30443
+	// IngressHTTPHostPort is the host port mapped onto IngressHTTPNodePort.
+	IngressHTTPHostPort int32 = 80
+	// IngressHTTPSHostPort is the host port mapped onto IngressHTTPSNodePort.
+	IngressHTTPSHostPort int32 = 443
+)
+
+// ValidIngressControllers returns supported ingress controller values.
+func ValidIngressControllers() []IngressController {
+	return []IngressController{
+		IngressControllerNone,
+		IngressControllerNginx,
+		IngressControllerTraefik,
+		IngressControllerContour,
+	}
+}
+
+// Set for IngressController (pflag.Value interface).
+func (i *IngressController) Set(value string) error {
+	return setEnum(i, value, ValidIngressControllers(), ErrInvalidIngressController)
+}
+
+// String returns the string representation of the IngressController.
+func (i *IngressController) String() string {
+	return string(*i)
+}
+
+// Type returns the type of the IngressController.
+func (i *IngressController) Type() string {
+	return "IngressController"
+}
+
+// Default returns the default value for IngressController (None).
+func (i *IngressController) Default() any {
+	return IngressControllerNone
+}
+
+// ValidValues returns all valid IngressController values as strings.
+func (i *IngressController) ValidValues() []string {
+	return validValueStrings(ValidIngressControllers())
+}
+
+// Enabled reports whether an ingress controller is selected (anything but None or empty).
+func (i IngressController) Enabled() bool {
+	return i != "" && i != IngressControllerNone
+}
+
+// IngressPortMappings returns the container-to-host port mappings that expose an
+// ingress controller's fixed node ports on localhost:80 and localhost:443, or nil
+// when no ingress controller is selected.
+func IngressPortMappings(controller IngressController) []PortMapping {
+	if !controller.Enabled() {
+		return nil
+	}
+
+	return []PortMapping{
+		{ContainerPort: IngressHTTPNodePort, HostPort: IngressHTTPHostPort, Protocol: "TCP"},
+		{ContainerPort: IngressHTTPSNodePort, HostPort: IngressHTTPSHostPort, Protocol: "TCP"},
+	}
+}
@@ -0,0 +1,42 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngressController_Enabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   v1alpha1.IngressController
+		want bool
+	}{
+		{"empty is disabled", v1alpha1.IngressController(""), false},
+		{"None is disabled", v1alpha1.IngressControllerNone, false},
+		{"Nginx is enabled", v1alpha1.IngressControllerNginx, true},
+		{"Traefik is enabled", v1alpha1.IngressControllerTraefik, true},
+		{"Contour is enabled", v1alpha1.IngressControllerContour, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, testCase.in.Enabled())
+		})
+	}
+}
+
+func TestIngressPortMappings(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, v1alpha1.IngressPortMappings(v1alpha1.IngressControllerNone))
+	assert.Equal(t, []v1alpha1.PortMapping{
+		{ContainerPort: 30080, HostPort: 80, Protocol: "TCP"},
+		{ContainerPort: 30443, HostPort: 443, Protocol: "TCP"},
+	}, v1alpha1.IngressPortMappings(v1alpha1.IngressControllerTraefik))
+}
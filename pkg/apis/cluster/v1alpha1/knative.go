@@ -0,0 +1,75 @@
+package v1alpha1
+
+// Knative defines the Knative Serving options for a KSail cluster.
+type Knative string
+
+const (
+	// KnativeEnabled ensures Knative Serving (with the Kourier networking layer) is installed.
+	KnativeEnabled Knative = "Enabled"
+	// KnativeDisabled ensures Knative Serving is not installed.
+	KnativeDisabled Knative = "Disabled"
+)
+
+// Fixed ports used to expose Knative's Kourier gateway. They are separate from
+// the ingress controller ports so Knative and an ingress controller can run side
+// by side. On the Docker provider the Kourier Service is a NodePort pinned to
+// KnativeHTTPNodePort, and the Kind and K3d provisioners map KnativeHTTPHostPort
+// onto it, so Knative services answer on http://<name>.<namespace>.127.0.0.1.sslip.io:8080.
+const (
+	// KnativeHTTPNodePort is the node port the Kourier HTTP listener is pinned to.
+	KnativeHTTPNodePort int32 = 31080
+	// KnativeHTTPSNodePort is the node port the Kourier HTTPS listener is pinned to.
+	KnativeHTTPSNodePort int32 = 31443
+	// KnativeHTTPHostPort is the host port mapped onto KnativeHTTPNodePort.
+	KnativeHTTPHostPort int32 = 8080
+	// KnativeMagicDNSDomain is the sslip.io domain that resolves every host name
+	// to 127.0.0.1, so Knative service URLs reach the mapped host port without
+	// any DNS setup.
+	KnativeMagicDNSDomain = "127.0.0.1.sslip.io"
+)
+
+// ValidKnatives returns supported Knative values.
+func ValidKnatives() []Knative {
+	return []Knative{
+		KnativeEnabled,
+		KnativeDisabled,
+	}
+}
+
+// Set for Knative (pflag.Value interface).
+func (k *Knative) Set(value string) error {
+	return setEnum(k, value, ValidKnatives(), ErrInvalidKnative)
+}
+
+// String returns the string representation of the Knative.
+func (k *Knative) String() string {
+	return string(*k)
+}
+
+// Type returns the type of the Knative.
+func (k *Knative) Type() string {
+	return "Knative"
+}
+
+// Default returns the default value for Knative (Disabled).
+func (k *Knative) Default() any {
+	return KnativeDisabled
+}
+
+// ValidValues returns all valid Knative values as strings.
+func (k *Knative) ValidValues() []string {
+	return validValueStrings(ValidKnatives())
+}
+
+// KnativePortMappings returns the container-to-host port mapping that exposes the
+// Kourier gateway's fixed HTTP node port on localhost:8080, or nil when Knative
+// is not enabled.
+func KnativePortMappings(knative Knative) []PortMapping {
+	if knative != KnativeEnabled {
+		return nil
+	}
+
+	return []PortMapping{
+		{ContainerPort: KnativeHTTPNodePort, HostPort: KnativeHTTPHostPort, Protocol: "TCP"},
+	}
+}
@@ -0,0 +1,18 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnativePortMappings(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, v1alpha1.KnativePortMappings(v1alpha1.KnativeDisabled))
+	assert.Nil(t, v1alpha1.KnativePortMappings(v1alpha1.Knative("")))
+	assert.Equal(t, []v1alpha1.PortMapping{
+		{ContainerPort: 31080, HostPort: 8080, Protocol: "TCP"},
+	}, v1alpha1.KnativePortMappings(v1alpha1.KnativeEnabled))
+}
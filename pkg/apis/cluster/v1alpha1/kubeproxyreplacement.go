@@ -0,0 +1,49 @@
+package v1alpha1
+
+// KubeProxyReplacement defines whether Cilium replaces kube-proxy with its eBPF
+// service load-balancing.
+type KubeProxyReplacement string
+
+const (
+	// KubeProxyReplacementDefault relies on the distribution's default: enabled
+	// on Talos (which runs without kube-proxy), left to the chart elsewhere.
+	KubeProxyReplacementDefault KubeProxyReplacement = "Default"
+	// KubeProxyReplacementEnabled enables Cilium's kube-proxy replacement.
+	KubeProxyReplacementEnabled KubeProxyReplacement = "Enabled"
+	// KubeProxyReplacementDisabled disables Cilium's kube-proxy replacement.
+	KubeProxyReplacementDisabled KubeProxyReplacement = "Disabled"
+)
+
+// ValidKubeProxyReplacements returns supported kube-proxy replacement values.
+func ValidKubeProxyReplacements() []KubeProxyReplacement {
+	return []KubeProxyReplacement{
+		KubeProxyReplacementDefault,
+		KubeProxyReplacementEnabled,
+		KubeProxyReplacementDisabled,
+	}
+}
+
+// Set for KubeProxyReplacement (pflag.Value interface).
+func (k *KubeProxyReplacement) Set(value string) error {
+	return setEnum(k, value, ValidKubeProxyReplacements(), ErrInvalidKubeProxyReplacement)
+}
+
+// String returns the string representation of the KubeProxyReplacement.
+func (k *KubeProxyReplacement) String() string {
+	return string(*k)
+}
+
+// Type returns the type of the KubeProxyReplacement.
+func (k *KubeProxyReplacement) Type() string {
+	return "KubeProxyReplacement"
+}
+
+// Default returns the default value for KubeProxyReplacement (Default, which defers to the distribution).
+func (k *KubeProxyReplacement) Default() any {
+	return KubeProxyReplacementDefault
+}
+
+// ValidValues returns all valid KubeProxyReplacement values as strings.
+func (k *KubeProxyReplacement) ValidValues() []string {
+	return validValueStrings(ValidKubeProxyReplacements())
+}
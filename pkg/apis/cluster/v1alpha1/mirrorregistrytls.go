@@ -0,0 +1,45 @@
+package v1alpha1
+
+// MirrorRegistryTLS defines whether KSail's local mirror registries serve HTTPS.
+type MirrorRegistryTLS string
+
+const (
+	// MirrorRegistryTLSEnabled serves the mirror registries over HTTPS with
+	// certificates signed by a KSail-generated CA that the nodes trust.
+	MirrorRegistryTLSEnabled MirrorRegistryTLS = "Enabled"
+	// MirrorRegistryTLSDisabled serves the mirror registries over plain HTTP.
+	MirrorRegistryTLSDisabled MirrorRegistryTLS = "Disabled"
+)
+
+// ValidMirrorRegistryTLSs returns supported mirror registry TLS values.
+func ValidMirrorRegistryTLSs() []MirrorRegistryTLS {
+	return []MirrorRegistryTLS{
+		MirrorRegistryTLSEnabled,
+		MirrorRegistryTLSDisabled,
+	}
+}
+
+// Set for MirrorRegistryTLS (pflag.Value interface).
+func (m *MirrorRegistryTLS) Set(value string) error {
+	return setEnum(m, value, ValidMirrorRegistryTLSs(), ErrInvalidMirrorRegistryTLS)
+}
+
+// String returns the string representation of the MirrorRegistryTLS.
+func (m *MirrorRegistryTLS) String() string {
+	return string(*m)
+}
+
+// Type returns the type of the MirrorRegistryTLS.
+func (m *MirrorRegistryTLS) Type() string {
+	return "MirrorRegistryTLS"
+}
+
+// Default returns the default value for MirrorRegistryTLS (Disabled).
+func (m *MirrorRegistryTLS) Default() any {
+	return MirrorRegistryTLSDisabled
+}
+
+// ValidValues returns all valid MirrorRegistryTLS values as strings.
+func (m *MirrorRegistryTLS) ValidValues() []string {
+	return validValueStrings(ValidMirrorRegistryTLSs())
+}
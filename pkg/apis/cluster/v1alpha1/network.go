@@ -0,0 +1,96 @@
+package v1alpha1
+
+import "fmt"
+
+// NetworkSpec configures the cluster's pod and service networking.
+type NetworkSpec struct {
+	// IPFamily selects the IP family of pods and services: ipv4 (default), ipv6, or dual
+	// (dual-stack IPv4 and IPv6). ipv6 and dual are supported by the Vanilla (Kind), K3s
+	// (K3d), and Talos distributions on the Docker provider, where KSail also creates the
+	// cluster's Docker network with IPv6 enabled.
+	IPFamily IPFamily `json:"ipFamily,omitzero" jsonschema_description:"IP family of pods and services: ipv4 (default), ipv6, or dual (dual-stack). ipv6 and dual are supported by Vanilla (Kind), K3s (K3d), and Talos on the Docker provider, whose Docker network is then created with IPv6 enabled."` //nolint:lll
+}
+
+// IPFamily defines the IP family of a cluster's pod and service networks.
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 is the default and runs an IPv4-only cluster.
+	IPFamilyIPv4 IPFamily = "ipv4"
+	// IPFamilyIPv6 runs an IPv6-only cluster.
+	IPFamilyIPv6 IPFamily = "ipv6"
+	// IPFamilyDual runs a dual-stack cluster with both IPv4 and IPv6 addresses.
+	IPFamilyDual IPFamily = "dual"
+)
+
+// ValidIPFamilies returns supported IP family values.
+func ValidIPFamilies() []IPFamily {
+	return []IPFamily{
+		IPFamilyIPv4,
+		IPFamilyIPv6,
+		IPFamilyDual,
+	}
+}
+
+// Set for IPFamily (pflag.Value interface).
+func (f *IPFamily) Set(value string) error {
+	return setEnum(f, value, ValidIPFamilies(), ErrInvalidIPFamily)
+}
+
+// String returns the string representation of the IPFamily.
+func (f *IPFamily) String() string {
+	return string(*f)
+}
+
+// Type returns the type of the IPFamily.
+func (f *IPFamily) Type() string {
+	return "IPFamily"
+}
+
+// Default returns the default value for IPFamily (ipv4).
+func (f *IPFamily) Default() any {
+	return IPFamilyIPv4
+}
+
+// ValidValues returns all valid IPFamily values as strings.
+func (f *IPFamily) ValidValues() []string {
+	return validValueStrings(ValidIPFamilies())
+}
+
+// IPv4 reports whether the family includes IPv4 (ipv4, dual, or unset).
+func (f IPFamily) IPv4() bool {
+	return f != IPFamilyIPv6
+}
+
+// IPv6 reports whether the family includes IPv6 (ipv6 or dual).
+func (f IPFamily) IPv6() bool {
+	return f == IPFamilyIPv6 || f == IPFamilyDual
+}
+
+// ValidateNetwork checks that IPv6 and dual-stack networking are only requested for
+// distributions and providers KSail can configure them on, so the mismatch fails at
+// config load rather than after an IPv4-only cluster has been created.
+func ValidateNetwork(cluster *ClusterSpec) error {
+	if cluster == nil || !cluster.Network.IPFamily.IPv6() {
+		return nil
+	}
+
+	//nolint:exhaustive // every other distribution is rejected by the default case
+	switch cluster.Distribution {
+	case DistributionVanilla, DistributionK3s, DistributionTalos:
+	default:
+		return fmt.Errorf(
+			"%w: ipFamily %s requires the Vanilla, K3s, or Talos distribution, got %s",
+			ErrInvalidNetwork, cluster.Network.IPFamily, cluster.Distribution,
+		)
+	}
+
+	if cluster.Provider != "" && cluster.Provider != ProviderDocker {
+		return fmt.Errorf(
+			"%w: ipFamily %s requires the Docker provider, got %s",
+			ErrInvalidNetwork, cluster.Network.IPFamily, cluster.Provider,
+		)
+	}
+
+	return nil
+}
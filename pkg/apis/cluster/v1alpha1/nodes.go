@@ -0,0 +1,369 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// NodeSpec customizes the nodes of one role, or a single node of that role, so a
+// local cluster can mimic a heterogeneous production topology. The Docker provider
+// applies Resources as container limits; the distributions register Labels and
+// Taints through the kubelet when the node joins.
+type NodeSpec struct {
+	// Role selects the nodes this entry applies to: ControlPlane or Worker.
+	Role NodeRole `json:"role"`
+	// Index narrows the entry to a single node of Role, counted from 0 in creation
+	// order. When unset the entry applies to every node of Role.
+	Index *int32 `json:"index,omitzero" jsonschema:"minimum=0" jsonschema_description:"Zero-based index of the node of the given role this entry applies to, in creation order. When unset the entry applies to every node of the role."` //nolint:lll
+	// Resources limits the CPU and memory of the node container.
+	Resources NodeResources `json:"resources,omitzero"`
+	// Labels are Kubernetes node labels registered by the node's kubelet. Keys must
+	// be valid Kubernetes label keys.
+	Labels map[string]string `json:"labels,omitzero" jsonschema_description:"Kubernetes node labels registered by the kubelet of the selected nodes."` //nolint:lll
+	// Taints are Kubernetes node taints registered by the node's kubelet.
+	// Control-plane nodes keep their default control-plane taint.
+	Taints []NodePoolTaint `json:"taints,omitzero" jsonschema_description:"Kubernetes node taints registered by the kubelet of the selected nodes. Control-plane nodes keep their default control-plane taint."` //nolint:lll
+	// GPUs passes NVIDIA GPUs of the Docker host through to the node container:
+	// "all", or a count of GPUs taken in device order. Only the Vanilla (Kind) and
+	// K3s (K3d) distributions support it, and K3d applies one request to every node.
+	GPUs string `json:"gpus,omitzero" jsonschema_description:"NVIDIA GPUs of the Docker host passed through to the selected node containers: all, or a count of GPUs taken in device order. Requires the NVIDIA Container Toolkit on the host. Only Vanilla (Kind) and K3s (K3d) support it; K3d applies one request to every node."` //nolint:lll
+}
+
+// GPUsAll requests every NVIDIA GPU of the Docker host.
+const GPUsAll = "all"
+
+// GPUNodeLabel marks nodes that receive GPUs, matching the node affinity of the
+// NVIDIA device plugin chart so the plugin only runs where GPUs are present.
+const GPUNodeLabel = "nvidia.com/gpu.present"
+
+// NodeResources limits the CPU and memory available to a node. Both values are
+// Kubernetes quantities; an empty value leaves that resource unlimited.
+type NodeResources struct {
+	// CPU is the number of CPUs the node may use, e.g. "2" or "1500m".
+	CPU string `json:"cpu,omitzero" jsonschema_description:"Number of CPUs the node container may use, as a Kubernetes quantity (e.g. 2 or 1500m). Empty leaves CPU unlimited."` //nolint:lll
+	// Memory is the memory limit of the node, e.g. "4Gi".
+	Memory string `json:"memory,omitzero" jsonschema_description:"Memory limit of the node container, as a Kubernetes quantity (e.g. 4Gi). Empty leaves memory unlimited."` //nolint:lll
+}
+
+// NodeRole identifies the role of the nodes a NodeSpec applies to.
+type NodeRole string
+
+const (
+	// NodeRoleControlPlane selects control-plane nodes.
+	NodeRoleControlPlane NodeRole = "ControlPlane"
+	// NodeRoleWorker selects worker nodes.
+	NodeRoleWorker NodeRole = "Worker"
+)
+
+// ValidNodeRoles returns supported node role values.
+func ValidNodeRoles() []NodeRole {
+	return []NodeRole{
+		NodeRoleControlPlane,
+		NodeRoleWorker,
+	}
+}
+
+// Set for NodeRole (pflag.Value interface).
+func (r *NodeRole) Set(value string) error {
+	return setEnum(r, value, ValidNodeRoles(), ErrInvalidNodeRole)
+}
+
+// String returns the string representation of the NodeRole.
+func (r *NodeRole) String() string {
+	return string(*r)
+}
+
+// Type returns the type of the NodeRole.
+func (r *NodeRole) Type() string {
+	return "NodeRole"
+}
+
+// Default returns the default value for NodeRole (Worker).
+func (r *NodeRole) Default() any {
+	return NodeRoleWorker
+}
+
+// ValidValues returns all valid NodeRole values as strings.
+func (r *NodeRole) ValidValues() []string {
+	return validValueStrings(ValidNodeRoles())
+}
+
+// IsZero reports whether no resource limit is set.
+func (r NodeResources) IsZero() bool {
+	return r.CPU == "" && r.Memory == ""
+}
+
+// NanoCPUs returns the CPU limit in billionths of a CPU, the unit Docker uses, or 0
+// when CPU is unset.
+func (r NodeResources) NanoCPUs() (int64, error) {
+	if r.CPU == "" {
+		return 0, nil
+	}
+
+	quantity, err := parsePositiveQuantity("cpu", r.CPU)
+	if err != nil {
+		return 0, err
+	}
+
+	return quantity.ScaledValue(resource.Nano), nil
+}
+
+// MemoryBytes returns the memory limit in bytes, or 0 when Memory is unset.
+func (r NodeResources) MemoryBytes() (int64, error) {
+	if r.Memory == "" {
+		return 0, nil
+	}
+
+	quantity, err := parsePositiveQuantity("memory", r.Memory)
+	if err != nil {
+		return 0, err
+	}
+
+	return quantity.Value(), nil
+}
+
+// parsePositiveQuantity parses a resource limit, rejecting zero and negative values.
+func parsePositiveQuantity(name, value string) (resource.Quantity, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("%w: %s %q: %w", ErrInvalidNodeSpec, name, value, err)
+	}
+
+	if quantity.Sign() <= 0 {
+		return resource.Quantity{}, fmt.Errorf(
+			"%w: %s %q must be greater than zero", ErrInvalidNodeSpec, name, value,
+		)
+	}
+
+	return quantity, nil
+}
+
+// GPUDevices returns the NVIDIA device identifiers a gpus value selects: "all",
+// or the indexes 0..n-1 for a count n. An empty value selects no devices.
+func GPUDevices(gpus string) ([]string, error) {
+	if gpus == "" {
+		return nil, nil
+	}
+
+	if gpus == GPUsAll {
+		return []string{GPUsAll}, nil
+	}
+
+	count, err := strconv.Atoi(gpus)
+	if err != nil || count <= 0 {
+		return nil, fmt.Errorf(
+			"%w: gpus %q must be %q or a positive count", ErrInvalidNodeSpec, gpus, GPUsAll,
+		)
+	}
+
+	devices := make([]string, count)
+	for i := range devices {
+		devices[i] = strconv.Itoa(i)
+	}
+
+	return devices, nil
+}
+
+// RequestsGPUs reports whether any spec.cluster.nodes entry passes GPUs through.
+func (c ClusterSpec) RequestsGPUs() bool {
+	return slices.ContainsFunc(c.Nodes, func(node NodeSpec) bool { return node.GPUs != "" })
+}
+
+// NodeSettings is the customization of a single node after merging every
+// spec.cluster.nodes entry that selects it.
+type NodeSettings struct {
+	Resources NodeResources
+	Labels    map[string]string
+	Taints    []NodePoolTaint
+	GPUs      string
+}
+
+// IsZero reports whether the node has no customization.
+func (s NodeSettings) IsZero() bool {
+	return s.Resources.IsZero() && len(s.Labels) == 0 && len(s.Taints) == 0 && s.GPUs == ""
+}
+
+// NodeSettingsFor merges the spec.cluster.nodes entries that select the index-th
+// node of role, in declaration order: later entries override resources, labels,
+// and GPUs, and replace taints with the same key and effect. Nodes that receive
+// GPUs are labeled with GPUNodeLabel.
+func (c ClusterSpec) NodeSettingsFor(role NodeRole, index int) NodeSettings {
+	var settings NodeSettings
+
+	for _, node := range c.Nodes {
+		if node.Role != role || (node.Index != nil && int(*node.Index) != index) {
+			continue
+		}
+
+		if node.Resources.CPU != "" {
+			settings.Resources.CPU = node.Resources.CPU
+		}
+
+		if node.Resources.Memory != "" {
+			settings.Resources.Memory = node.Resources.Memory
+		}
+
+		if len(node.Labels) > 0 {
+			if settings.Labels == nil {
+				settings.Labels = make(map[string]string, len(node.Labels))
+			}
+
+			maps.Copy(settings.Labels, node.Labels)
+		}
+
+		for _, taint := range node.Taints {
+			settings.Taints = mergeTaint(settings.Taints, taint)
+		}
+
+		if node.GPUs != "" {
+			settings.GPUs = node.GPUs
+		}
+	}
+
+	if settings.GPUs != "" {
+		if settings.Labels == nil {
+			settings.Labels = make(map[string]string, 1)
+		}
+
+		settings.Labels[GPUNodeLabel] = "true"
+	}
+
+	return settings
+}
+
+// mergeTaint replaces the taint with the same key and effect, or appends it.
+func mergeTaint(taints []NodePoolTaint, taint NodePoolTaint) []NodePoolTaint {
+	for i, existing := range taints {
+		if existing.Key == taint.Key && existing.Effect == taint.Effect {
+			taints[i] = taint
+
+			return taints
+		}
+	}
+
+	return append(taints, taint)
+}
+
+// NodeCount returns the number of nodes of role the cluster is created with.
+func (c ClusterSpec) NodeCount(role NodeRole) int {
+	if role == NodeRoleWorker {
+		return int(max(c.Workers, 0))
+	}
+
+	return int(max(c.ControlPlanes, 1))
+}
+
+// ValidateNodes checks spec.cluster.nodes: roles, indexes within the node counts,
+// resource quantities, labels, and taints. Per-node customization is only supported
+// by the Vanilla (Kind), K3s (K3d), and Talos distributions on the Docker provider,
+// so other combinations fail at config load rather than silently ignoring it.
+func ValidateNodes(cluster *ClusterSpec) error {
+	if cluster == nil || len(cluster.Nodes) == 0 {
+		return nil
+	}
+
+	//nolint:exhaustive // every other distribution is rejected by the default case
+	switch cluster.Distribution {
+	case DistributionVanilla, DistributionK3s, DistributionTalos:
+	default:
+		return fmt.Errorf(
+			"%w: nodes require the Vanilla, K3s, or Talos distribution, got %s",
+			ErrInvalidNodeSpec, cluster.Distribution,
+		)
+	}
+
+	if cluster.Provider != "" && cluster.Provider != ProviderDocker {
+		return fmt.Errorf(
+			"%w: nodes require the Docker provider, got %s",
+			ErrInvalidNodeSpec, cluster.Provider,
+		)
+	}
+
+	for idx, node := range cluster.Nodes {
+		err := validateNode(cluster, idx, node)
+		if err != nil {
+			return err
+		}
+	}
+
+	return validateGPUs(cluster)
+}
+
+// validateGPUs checks that GPU passthrough is only requested where the node
+// containers can receive it: Kind mounts devices per node, while K3d takes a single
+// cluster-wide request, so every K3s entry must ask for the same GPUs.
+func validateGPUs(cluster *ClusterSpec) error {
+	var k3dRequest string
+
+	for idx, node := range cluster.Nodes {
+		if node.GPUs == "" {
+			continue
+		}
+
+		owner := fmt.Sprintf("nodes[%d]", idx)
+
+		//nolint:exhaustive // every other distribution is rejected by the default case
+		switch cluster.Distribution {
+		case DistributionVanilla:
+		case DistributionK3s:
+			if k3dRequest != "" && k3dRequest != node.GPUs {
+				return fmt.Errorf(
+					"%w: %s gpus %q differs from %q; K3d applies one GPU request to every node",
+					ErrInvalidNodeSpec, owner, node.GPUs, k3dRequest,
+				)
+			}
+
+			k3dRequest = node.GPUs
+		default:
+			return fmt.Errorf(
+				"%w: %s gpus require the Vanilla or K3s distribution, got %s",
+				ErrInvalidNodeSpec, owner, cluster.Distribution,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateNode validates a single spec.cluster.nodes entry.
+func validateNode(cluster *ClusterSpec, idx int, node NodeSpec) error {
+	owner := fmt.Sprintf("nodes[%d]", idx)
+
+	if !slices.Contains(ValidNodeRoles(), node.Role) {
+		return fmt.Errorf(
+			"%w: %s role %q (valid: %s, %s)",
+			ErrInvalidNodeRole, owner, node.Role, NodeRoleControlPlane, NodeRoleWorker,
+		)
+	}
+
+	if node.Index != nil {
+		count := cluster.NodeCount(node.Role)
+		if *node.Index < 0 || int(*node.Index) >= count {
+			return fmt.Errorf(
+				"%w: %s index %d is out of range for %d %s node(s)",
+				ErrInvalidNodeSpec, owner, *node.Index, count, node.Role,
+			)
+		}
+	}
+
+	_, err := node.Resources.NanoCPUs()
+	if err != nil {
+		return fmt.Errorf("%s: %w", owner, err)
+	}
+
+	_, err = node.Resources.MemoryBytes()
+	if err != nil {
+		return fmt.Errorf("%s: %w", owner, err)
+	}
+
+	_, err = GPUDevices(node.GPUs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", owner, err)
+	}
+
+	return validateLabelsAndTaints(owner, node.Labels, node.Taints, ErrInvalidNodeSpec, ErrInvalidNodeSpec)
+}
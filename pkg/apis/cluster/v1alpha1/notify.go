@@ -0,0 +1,20 @@
+package v1alpha1
+
+// NotifySpec configures extra sinks that receive a copy of every message KSail prints, so shared
+// automation hosts keep a durable record of each operation beyond the terminal scrollback.
+// CLI-only; ignored by the operator (the Cluster CRD shares this type but never reads it).
+type NotifySpec struct {
+	// LogDir writes the messages of each operation to a new timestamped log file in this
+	// directory. Relative paths resolve against the ksail.yaml directory.
+	LogDir string `json:"logDir,omitzero" jsonschema_description:"Directory receiving one timestamped log file per operation (relative to ksail.yaml)"` //nolint:lll
+	// Syslog sends every message to the local syslog daemon. Not supported on Windows.
+	Syslog bool `json:"syslog,omitzero" jsonschema_description:"Send every message to the local syslog daemon (not supported on Windows)"` //nolint:lll
+	// Webhook posts the messages of each operation as one JSON document to this URL when the
+	// operation finishes.
+	Webhook string `json:"webhook,omitzero" jsonschema_description:"URL receiving the messages of each operation as one JSON document when it finishes"` //nolint:lll
+}
+
+// Enabled reports whether any sink is configured.
+func (n NotifySpec) Enabled() bool {
+	return n.LogDir != "" || n.Syslog || n.Webhook != ""
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+// ObservabilitySpec groups the observability stacks KSail installs after
+// cluster creation.
+type ObservabilitySpec struct {
+	// Logging selects the cluster-wide log aggregation stack: None or Loki
+	// (Loki with Promtail shipping every container's logs to it).
+	Logging Logging `json:"logging,omitzero" jsonschema_description:"Cluster-wide log aggregation stack. None (default) installs nothing; Loki installs Grafana Loki in single-binary mode plus Promtail as a DaemonSet shipping every container's logs to it."` //nolint:lll
+}
+
+// IsZero reports whether no observability option is set.
+func (o ObservabilitySpec) IsZero() bool {
+	return o == ObservabilitySpec{}
+}
+
+// Logging defines the log aggregation stack options for a KSail cluster.
+type Logging string
+
+const (
+	// LoggingNone is the default and disables log aggregation.
+	LoggingNone Logging = "None"
+	// LoggingLoki installs Grafana Loki with Promtail.
+	LoggingLoki Logging = "Loki"
+)
+
+// ValidLoggings returns supported logging values.
+func ValidLoggings() []Logging {
+	return []Logging{
+		LoggingNone,
+		LoggingLoki,
+	}
+}
+
+// Set for Logging (pflag.Value interface).
+func (l *Logging) Set(value string) error {
+	return setEnum(l, value, ValidLoggings(), ErrInvalidLogging)
+}
+
+// String returns the string representation of the Logging.
+func (l *Logging) String() string {
+	return string(*l)
+}
+
+// Type returns the type of the Logging.
+func (l *Logging) Type() string {
+	return "Logging"
+}
+
+// Default returns the default value for Logging (None).
+func (l *Logging) Default() any {
+	return LoggingNone
+}
+
+// ValidValues returns all valid Logging values as strings.
+func (l *Logging) ValidValues() []string {
+	return validValueStrings(ValidLoggings())
+}
+
+// Enabled reports whether a logging stack is selected (anything but None or empty).
+func (l Logging) Enabled() bool {
+	return l != "" && l != LoggingNone
+}
@@ -10,6 +10,13 @@ type OptionsVanilla struct {
 	// MirrorsDir is the directory for containerd host mirror configuration.
 	// Defaults to "kind/mirrors" if not specified.
 	MirrorsDir string `json:"mirrorsDir,omitzero"`
+	// NodeImageDockerfile is a Dockerfile snippet applied on top of the Kind node
+	// image, e.g. "RUN apt-get update && apt-get install -y nfs-common".
+	// When set, KSail builds a derived node image before creating the cluster,
+	// caches it in the local Docker image store, and reuses it while the base
+	// image and snippet are unchanged. The build context is empty, so fetch extra
+	// binaries with RUN or ADD <url> rather than COPY.
+	NodeImageDockerfile string `json:"nodeImageDockerfile,omitzero"`
 }
 
 // OptionsEKS defines options specific to the EKS distribution.
@@ -149,6 +156,29 @@ type OptionsTalos struct {
 	// (naming the stuck volumes) rather than hanging. Example: "10m".
 	//+kubebuilder:validation:XValidation:rule="self.matches('^[0-9]+(ns|us|µs|ms|s|m|h)$')"
 	StorageHealthTimeout metav1.Duration `json:"storageHealthTimeout,omitzero"`
+	// API tunes how KSail retries and waits on the Talos API. Unset fields use
+	// adaptive defaults that allow more headroom on CI machines (CI env var set).
+	API TalosAPIOptions `json:"api,omitzero"`
+}
+
+// TalosAPIOptions configures retries and timeouts for Talos API interactions.
+type TalosAPIOptions struct {
+	// MaxAttempts is how many times a per-node Talos API call (config apply,
+	// reboot, upgrade, etcd operations) is attempted when it fails transiently,
+	// e.g. when a loaded machine is slow to accept its config. When unset, KSail
+	// uses 3, or 5 on CI machines.
+	//+kubebuilder:validation:Minimum=0
+	MaxAttempts int32 `json:"maxAttempts,omitzero"`
+	// CallTimeout bounds each attempt of a per-node Talos API call, so a hung
+	// call is retried instead of consuming the whole operation budget. When
+	// unset, attempts are bounded only by the operation. Example: "2m".
+	//+kubebuilder:validation:XValidation:rule="self.matches('^[0-9]+(ns|us|µs|ms|s|m|h)$')"
+	CallTimeout metav1.Duration `json:"callTimeout,omitzero"`
+	// HealthCheckGracePeriod is added to the cluster and node readiness timeouts
+	// (20m for Talos on cloud providers, 10m on Docker and per node during
+	// updates). When unset, KSail adds nothing, or 5m on CI machines. Example: "10m".
+	//+kubebuilder:validation:XValidation:rule="self.matches('^[0-9]+(ns|us|µs|ms|s|m|h)$')"
+	HealthCheckGracePeriod metav1.Duration `json:"healthCheckGracePeriod,omitzero"`
 }
 
 // PortMapping defines a mapping between a container port and a host port.
@@ -176,6 +206,22 @@ type LocalRegistry struct {
 	// each execution path. When set, it takes precedence over any password embedded
 	// in the Registry spec.
 	Credentials RegistryCredentials `json:"credentials,omitzero"`
+	// Retention limits the artifact tags the local registry keeps. It is applied to
+	// the pushed repository after every `ksail workload push` and by
+	// `ksail registry prune-artifacts`. External registries are never pruned.
+	Retention RegistryRetention `json:"retention,omitzero"`
+}
+
+// RegistryRetention limits the artifact tags a local registry keeps per
+// repository. The most recently pushed tag of a repository is always kept, and
+// a zero value keeps every tag.
+type RegistryRetention struct {
+	// KeepLast is the number of most recently pushed tags kept per repository.
+	//+kubebuilder:validation:Minimum=0
+	KeepLast int32 `json:"keepLast,omitzero"`
+	// MaxAge deletes tags pushed longer ago than this duration. Example: "168h".
+	//+kubebuilder:validation:XValidation:rule="self.matches('^[0-9]+(ns|us|µs|ms|s|m|h)$')"
+	MaxAge metav1.Duration `json:"maxAge,omitzero"`
 }
 
 // RegistryCredentials declares the environment variables that hold the registry
@@ -234,6 +280,16 @@ type OptionsHetzner struct {
 	// TokenEnvVar is the environment variable containing the Hetzner API token.
 	// Defaults to "HCLOUD_TOKEN".
 	TokenEnvVar string `default:"HCLOUD_TOKEN" json:"tokenEnvVar,omitzero"`
+	// TokenCommand is a program and its arguments, run without a shell, that prints a
+	// short-lived Hetzner API token on stdout (for example a token exchange against a
+	// secrets manager). When set, KSail's own Hetzner API calls use it instead of
+	// TokenEnvVar, re-running it every TokenRefreshInterval and whenever the API
+	// rejects the token. In-cluster components (CCM, CSI, floating IP VIP) still
+	// receive the token from TokenEnvVar, as they outlive any single KSail run.
+	TokenCommand []string `json:"tokenCommand,omitempty"`
+	// TokenRefreshInterval is how long a token printed by TokenCommand is reused
+	// before the command runs again. Defaults to 10m.
+	TokenRefreshInterval metav1.Duration `json:"tokenRefreshInterval,omitzero"`
 	// PlacementGroupStrategy controls whether and how placement groups are used.
 	// "Spread" (default) distributes servers across different physical hosts for HA.
 	// "None" disables placement groups, useful when Hetzner resources are constrained.
@@ -289,6 +345,12 @@ type OptionsHetzner struct {
 	// and the Talos OS-level ingress firewall for defense-in-depth.
 	// Examples: ["203.0.113.0/24", "198.51.100.0/24"]
 	AllowedCIDRs []string `json:"allowedCidrs,omitzero" jsonschema_description:"CIDR blocks allowed to access the Kubernetes API and Talos API on control-plane nodes. When empty defaults to 0.0.0.0/0 and ::/0 (open to all IPv4 and IPv6)."` //nolint:lll
+	// NodePortCIDRs opens the Kubernetes NodePort range (30000-32767, TCP and UDP) on the
+	// Hetzner Cloud Firewall to the specified CIDR blocks. When empty, NodePorts stay closed
+	// to public traffic; services remain reachable over the private network and through
+	// Hetzner load balancers.
+	// Examples: ["203.0.113.0/24"]
+	NodePortCIDRs []string `json:"nodePortCidrs,omitzero" jsonschema_description:"CIDR blocks allowed to reach the Kubernetes NodePort range (30000-32767, TCP and UDP) on every node. When empty NodePorts are not exposed publicly."` //nolint:lll
 	// WorkerPublicIPv4 controls whether worker nodes are assigned a public IPv4 address.
 	// nil (default) or true assigns a public IPv4 (billed by Hetzner). false provisions
 	// IPv4-less workers; ksail then reaches their Talos API over the private network — which
@@ -454,6 +516,15 @@ type OptionsAWS struct {
 	// (used with temporary credentials from STS).
 	// Defaults to "AWS_SESSION_TOKEN".
 	SessionTokenEnvVar string `default:"AWS_SESSION_TOKEN" json:"sessionTokenEnvVar,omitzero"`
+	// AssumeRoleARN is an IAM role KSail assumes through AWS STS with the resolved
+	// credentials, so eksctl and the AWS SDK only ever receive short-lived session
+	// credentials. SDK calls renew the session when it expires during long
+	// operations; each eksctl run starts with a current session but cannot renew it.
+	// Empty uses the resolved credentials directly.
+	AssumeRoleARN string `json:"assumeRoleArn,omitzero"`
+	// AssumeRoleDuration is the lifetime of each assumed-role session, between 15m
+	// and 12h and at most the role's maximum session duration. Defaults to 1h.
+	AssumeRoleDuration metav1.Duration `json:"assumeRoleDuration,omitzero"`
 }
 
 // OptionsGCP defines options specific to the Google Cloud provider used by the
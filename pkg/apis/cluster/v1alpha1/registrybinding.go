@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net"
+	"slices"
+)
+
+// maxRegistryPort is the highest TCP port a registry can be published on.
+const maxRegistryPort = 65535
+
+// RegistryBinding controls how the registry containers KSail runs on the Docker
+// host publish their ports. By default the local registry is published on
+// 127.0.0.1 only and mirror registries are reachable only from the cluster's
+// Docker network.
+type RegistryBinding struct {
+	// HostIP is the host address registry ports are published on.
+	HostIP string `json:"hostIP,omitzero" jsonschema_description:"Host address the local and mirror registry ports are published on. Defaults to 127.0.0.1; use 0.0.0.0 or the address of a host interface to reach the registries from other machines, such as sibling VMs."` //nolint:lll
+	// MirrorPorts publishes mirror registries on fixed host ports.
+	MirrorPorts map[string]int32 `json:"mirrorPorts,omitzero" jsonschema_description:"Host ports to publish mirror registries on, keyed by the registry host they mirror (e.g. docker.io: 5001). Mirrors not listed are only reachable from the cluster's Docker network."` //nolint:lll
+}
+
+// ValidateRegistryBinding checks that the registry host address is an IP
+// address and that mirror ports are valid and distinct, so a typo fails at
+// config load rather than when Docker publishes the registry ports.
+func ValidateRegistryBinding(cluster *ClusterSpec) error {
+	if cluster == nil {
+		return nil
+	}
+
+	binding := cluster.RegistryBinding
+
+	if binding.HostIP != "" && net.ParseIP(binding.HostIP) == nil {
+		return fmt.Errorf("%w: hostIP %q is not an IP address", ErrInvalidRegistryBinding, binding.HostIP)
+	}
+
+	hosts := make([]string, 0, len(binding.MirrorPorts))
+	for host := range binding.MirrorPorts {
+		hosts = append(hosts, host)
+	}
+
+	slices.Sort(hosts)
+
+	used := make(map[int32]string, len(hosts))
+
+	if cluster.LocalRegistry.Enabled() && !cluster.LocalRegistry.IsExternal() {
+		used[cluster.LocalRegistry.ResolvedPort()] = "the local registry"
+	}
+
+	for _, host := range hosts {
+		port := binding.MirrorPorts[host]
+
+		if host == "" {
+			return fmt.Errorf("%w: mirrorPorts has an empty registry host", ErrInvalidRegistryBinding)
+		}
+
+		if port < 1 || port > maxRegistryPort {
+			return fmt.Errorf(
+				"%w: mirror port %d of %s must be between 1 and %d",
+				ErrInvalidRegistryBinding, port, host, maxRegistryPort,
+			)
+		}
+
+		if owner, ok := used[port]; ok {
+			return fmt.Errorf(
+				"%w: mirror port %d of %s is already used by %s",
+				ErrInvalidRegistryBinding, port, host, owner,
+			)
+		}
+
+		used[port] = "the mirror of " + host
+	}
+
+	return nil
+}
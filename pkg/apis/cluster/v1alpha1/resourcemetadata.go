@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ResourceMetadata declares labels and annotations KSail stamps onto every resource it creates
+// for a cluster: Docker registry containers, namespaces created for component installs, Helm
+// release records, and scaffolded kustomizations. It enables downstream filtering, cleanup, and
+// cost attribution (e.g. team, environment, cost-center) without post-processing.
+//
+// KSail's own reserved keys (e.g. io.ksail.registry) always take precedence over user-declared
+// values, so a policy entry can never mask the identifiers KSail relies on to find its resources.
+type ResourceMetadata struct {
+	// Labels are applied as Kubernetes labels to namespaces and Helm release records, as
+	// Docker labels to registry containers and K3d and Talos node containers, and as node
+	// labels to Kind and Talos nodes. Keys and values must satisfy Kubernetes label syntax.
+	Labels map[string]string `json:"labels,omitempty" jsonschema_description:"Labels KSail stamps onto every resource it creates (Docker containers, namespaces, Helm releases, scaffolded kustomizations). Keys and values must satisfy Kubernetes label syntax."` //nolint:lll
+	// Annotations are applied to namespaces and scaffolded kustomizations. Keys must be
+	// Kubernetes qualified names.
+	Annotations map[string]string `json:"annotations,omitempty" jsonschema_description:"Annotations KSail stamps onto namespaces it creates and scaffolded kustomizations. Keys must be Kubernetes qualified names."` //nolint:lll
+}
+
+// IsZero reports whether no labels or annotations are declared.
+func (m ResourceMetadata) IsZero() bool {
+	return len(m.Labels) == 0 && len(m.Annotations) == 0
+}
+
+// MergeLabels returns a copy of base with the declared labels added. Keys already present in
+// base win, so callers pass KSail's reserved labels as base to keep them authoritative.
+func (m ResourceMetadata) MergeLabels(base map[string]string) map[string]string {
+	return mergeMissing(base, m.Labels)
+}
+
+// MergeAnnotations returns a copy of base with the declared annotations added. Keys already
+// present in base win.
+func (m ResourceMetadata) MergeAnnotations(base map[string]string) map[string]string {
+	return mergeMissing(base, m.Annotations)
+}
+
+// mergeMissing copies base and adds every extra entry whose key is not already set. It returns
+// nil when both inputs are empty so omitempty fields stay unset.
+func mergeMissing(base, extra map[string]string) map[string]string {
+	if len(base) == 0 && len(extra) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]string, len(base)+len(extra))
+	maps.Copy(merged, extra)
+	maps.Copy(merged, base)
+
+	return merged
+}
+
+// ValidateResourceMetadata checks that every declared label key/value and annotation key satisfies
+// Kubernetes metadata syntax, so a typo fails at config load rather than mid-provisioning. Keys are
+// checked in sorted order for deterministic error messages.
+func ValidateResourceMetadata(metadata ResourceMetadata) error {
+	for _, key := range slices.Sorted(maps.Keys(metadata.Labels)) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf(
+				"%w: label key %q: %s", ErrInvalidResourceMetadata, key, strings.Join(errs, "; "),
+			)
+		}
+
+		value := metadata.Labels[key]
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf(
+				"%w: label %q value %q: %s",
+				ErrInvalidResourceMetadata, key, value, strings.Join(errs, "; "),
+			)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(metadata.Annotations)) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf(
+				"%w: annotation key %q: %s",
+				ErrInvalidResourceMetadata, key, strings.Join(errs, "; "),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateResourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		metadata v1alpha1.ResourceMetadata
+		wantErr  bool
+	}{
+		{name: "empty", metadata: v1alpha1.ResourceMetadata{}},
+		{
+			name: "valid labels and annotations",
+			metadata: v1alpha1.ResourceMetadata{
+				Labels:      map[string]string{"team": "platform", "example.com/cost-center": "cc-42"},
+				Annotations: map[string]string{"example.com/owner": "Platform Team <platform@example.com>"},
+			},
+		},
+		{
+			name:     "invalid label key",
+			metadata: v1alpha1.ResourceMetadata{Labels: map[string]string{"bad key": "x"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid label value",
+			metadata: v1alpha1.ResourceMetadata{Labels: map[string]string{"team": "not valid!"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid annotation key",
+			metadata: v1alpha1.ResourceMetadata{Annotations: map[string]string{"-bad": "x"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateResourceMetadata(testCase.metadata)
+			if testCase.wantErr {
+				require.ErrorIs(t, err, v1alpha1.ErrInvalidResourceMetadata)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestResourceMetadataMergeKeepsBaseKeys(t *testing.T) {
+	t.Parallel()
+
+	metadata := v1alpha1.ResourceMetadata{
+		Labels:      map[string]string{"team": "platform", "io.ksail.registry": "spoofed"},
+		Annotations: map[string]string{"owner": "platform"},
+	}
+
+	base := map[string]string{"io.ksail.registry": "docker.io"}
+
+	labels := metadata.MergeLabels(base)
+	assert.Equal(t, map[string]string{"team": "platform", "io.ksail.registry": "docker.io"}, labels)
+	assert.Equal(t, map[string]string{"io.ksail.registry": "docker.io"}, base, "base must not be mutated")
+
+	assert.Equal(t, map[string]string{"owner": "platform"}, metadata.MergeAnnotations(nil))
+	assert.Nil(t, v1alpha1.ResourceMetadata{}.MergeLabels(nil))
+	assert.True(t, v1alpha1.ResourceMetadata{}.IsZero())
+	assert.False(t, metadata.IsZero())
+}
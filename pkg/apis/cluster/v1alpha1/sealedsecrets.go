@@ -0,0 +1,44 @@
+package v1alpha1
+
+// SealedSecrets defines the Sealed Secrets controller options for a KSail cluster.
+type SealedSecrets string
+
+const (
+	// SealedSecretsEnabled ensures the Sealed Secrets controller is installed.
+	SealedSecretsEnabled SealedSecrets = "Enabled"
+	// SealedSecretsDisabled ensures the Sealed Secrets controller is not installed.
+	SealedSecretsDisabled SealedSecrets = "Disabled"
+)
+
+// ValidSealedSecrets returns supported Sealed Secrets values.
+func ValidSealedSecrets() []SealedSecrets {
+	return []SealedSecrets{
+		SealedSecretsEnabled,
+		SealedSecretsDisabled,
+	}
+}
+
+// Set for SealedSecrets (pflag.Value interface).
+func (s *SealedSecrets) Set(value string) error {
+	return setEnum(s, value, ValidSealedSecrets(), ErrInvalidSealedSecrets)
+}
+
+// String returns the string representation of the SealedSecrets.
+func (s *SealedSecrets) String() string {
+	return string(*s)
+}
+
+// Type returns the type of the SealedSecrets.
+func (s *SealedSecrets) Type() string {
+	return "SealedSecrets"
+}
+
+// Default returns the default value for SealedSecrets (Disabled).
+func (s *SealedSecrets) Default() any {
+	return SealedSecretsDisabled
+}
+
+// ValidValues returns all valid SealedSecrets values as strings.
+func (s *SealedSecrets) ValidValues() []string {
+	return validValueStrings(ValidSealedSecrets())
+}
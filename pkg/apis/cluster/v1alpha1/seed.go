@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultSeedNamespace is the namespace a seed resource lands in when it declares none.
+const DefaultSeedNamespace = "default"
+
+// SeedSpec declares ConfigMaps and Secrets KSail creates right after the cluster is ready and
+// re-applies on every `ksail cluster update`. It replaces the bootstrap kustomization most
+// projects carry only to get initial configuration into the cluster before GitOps takes over.
+type SeedSpec struct {
+	// ConfigMaps lists the ConfigMaps to seed.
+	ConfigMaps []SeedResource `json:"configMaps,omitempty" jsonschema_description:"ConfigMaps KSail creates once the cluster is ready and keeps reconciled on update."` //nolint:lll
+	// Secrets lists the Secrets to seed. File references may be SOPS-encrypted.
+	Secrets []SeedResource `json:"secrets,omitempty" jsonschema_description:"Secrets KSail creates once the cluster is ready and keeps reconciled on update. File references may be SOPS-encrypted."` //nolint:lll
+}
+
+// SeedResource is one seeded ConfigMap or Secret. Its data is the union of the inline values and
+// the contents of the referenced files; a key declared in both is rejected.
+type SeedResource struct {
+	// Name is the metadata.name of the ConfigMap or Secret.
+	Name string `json:"name" jsonschema:"minLength=1" jsonschema_description:"Name of the ConfigMap or Secret."` //nolint:lll
+	// Namespace is the namespace the resource is created in, created itself when missing.
+	// Defaults to "default".
+	Namespace string `json:"namespace,omitzero" jsonschema_description:"Namespace of the resource, created when missing. Defaults to default."` //nolint:lll
+	// Data maps keys to values. ${VAR} placeholders are expanded from the environment.
+	Data map[string]string `json:"data,omitempty" jsonschema_description:"Keys with values. ${VAR} placeholders are expanded from the environment."` //nolint:lll
+	// Files maps keys to file paths whose content becomes the value. A SOPS-encrypted YAML or
+	// JSON file is decrypted with the local SOPS keys before it is stored.
+	Files map[string]string `json:"files,omitempty" jsonschema_description:"Keys with a path to a file whose content becomes the value (relative to the working directory). SOPS-encrypted YAML or JSON files are decrypted first."` //nolint:lll
+}
+
+// Enabled reports whether any ConfigMap or Secret is seeded.
+func (s SeedSpec) Enabled() bool {
+	return len(s.ConfigMaps) > 0 || len(s.Secrets) > 0
+}
+
+// EffectiveNamespace returns the namespace the resource is created in.
+func (r SeedResource) EffectiveNamespace() string {
+	if r.Namespace == "" {
+		return DefaultSeedNamespace
+	}
+
+	return r.Namespace
+}
+
+// ValidateSeed checks that every seeded ConfigMap and Secret has a valid name, namespace, and
+// keys, declares no key twice, and is not declared twice, so a typo fails at config load instead
+// of after the cluster is created.
+func ValidateSeed(seed SeedSpec) error {
+	err := validateSeedResources("ConfigMap", seed.ConfigMaps)
+	if err != nil {
+		return err
+	}
+
+	return validateSeedResources("Secret", seed.Secrets)
+}
+
+func validateSeedResources(kind string, resources []SeedResource) error {
+	seen := make(map[string]bool, len(resources))
+
+	for _, resource := range resources {
+		if errs := validation.IsDNS1123Subdomain(resource.Name); len(errs) > 0 {
+			return fmt.Errorf(
+				"%w: %s name %q: %s", ErrInvalidSeed, kind, resource.Name, strings.Join(errs, "; "),
+			)
+		}
+
+		namespace := resource.EffectiveNamespace()
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf(
+				"%w: %s %s namespace %q: %s",
+				ErrInvalidSeed, kind, resource.Name, namespace, strings.Join(errs, "; "),
+			)
+		}
+
+		id := namespace + "/" + resource.Name
+		if seen[id] {
+			return fmt.Errorf("%w: %s %s is declared more than once", ErrInvalidSeed, kind, id)
+		}
+
+		seen[id] = true
+
+		err := validateSeedKeys(kind, id, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateSeedKeys(kind, id string, resource SeedResource) error {
+	if len(resource.Data) == 0 && len(resource.Files) == 0 {
+		return fmt.Errorf("%w: %s %s declares no data or files", ErrInvalidSeed, kind, id)
+	}
+
+	keys := slices.Sorted(maps.Keys(resource.Data))
+	keys = append(keys, slices.Sorted(maps.Keys(resource.Files))...)
+
+	for _, key := range keys {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return fmt.Errorf(
+				"%w: %s %s key %q: %s", ErrInvalidSeed, kind, id, key, strings.Join(errs, "; "),
+			)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(resource.Files)) {
+		if _, ok := resource.Data[key]; ok {
+			return fmt.Errorf(
+				"%w: %s %s key %q is set in both data and files", ErrInvalidSeed, kind, id, key,
+			)
+		}
+
+		if resource.Files[key] == "" {
+			return fmt.Errorf("%w: %s %s file for key %q is empty", ErrInvalidSeed, kind, id, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package v1alpha1
+
+// ServiceMesh defines the service mesh options for a KSail cluster.
+type ServiceMesh string
+
+const (
+	// ServiceMeshNone is the default and disables service mesh installation.
+	ServiceMeshNone ServiceMesh = "None"
+	// ServiceMeshIstio installs Istio in the data-plane mode set by spec.cluster.istio.mode.
+	ServiceMeshIstio ServiceMesh = "Istio"
+	// ServiceMeshLinkerd installs Linkerd.
+	ServiceMeshLinkerd ServiceMesh = "Linkerd"
+)
+
+// ValidServiceMeshes returns supported service mesh values.
+func ValidServiceMeshes() []ServiceMesh {
+	return []ServiceMesh{
+		ServiceMeshNone,
+		ServiceMeshIstio,
+		ServiceMeshLinkerd,
+	}
+}
+
+// Set for ServiceMesh (pflag.Value interface).
+func (s *ServiceMesh) Set(value string) error {
+	return setEnum(s, value, ValidServiceMeshes(), ErrInvalidServiceMesh)
+}
+
+// String returns the string representation of the ServiceMesh.
+func (s *ServiceMesh) String() string {
+	return string(*s)
+}
+
+// Type returns the type of the ServiceMesh.
+func (s *ServiceMesh) Type() string {
+	return "ServiceMesh"
+}
+
+// Default returns the default value for ServiceMesh (None).
+func (s *ServiceMesh) Default() any {
+	return ServiceMeshNone
+}
+
+// ValidValues returns all valid ServiceMesh values as strings.
+func (s *ServiceMesh) ValidValues() []string {
+	return validValueStrings(ValidServiceMeshes())
+}
+
+// Enabled reports whether a service mesh is selected (anything but None or empty).
+func (s ServiceMesh) Enabled() bool {
+	return s != "" && s != ServiceMeshNone
+}
+
+// IstioMode defines the Istio data-plane mode.
+type IstioMode string
+
+const (
+	// IstioModeSidecar injects an Envoy sidecar into every pod of a labelled namespace.
+	IstioModeSidecar IstioMode = "Sidecar"
+	// IstioModeAmbient captures traffic with the per-node ztunnel instead of sidecars.
+	IstioModeAmbient IstioMode = "Ambient"
+)
+
+// ValidIstioModes returns supported Istio mode values.
+func ValidIstioModes() []IstioMode {
+	return []IstioMode{IstioModeSidecar, IstioModeAmbient}
+}
+
+// Set for IstioMode (pflag.Value interface).
+func (m *IstioMode) Set(value string) error {
+	return setEnum(m, value, ValidIstioModes(), ErrInvalidIstioMode)
+}
+
+// String returns the string representation of the IstioMode.
+func (m *IstioMode) String() string {
+	return string(*m)
+}
+
+// Type returns the type of the IstioMode.
+func (m *IstioMode) Type() string {
+	return "IstioMode"
+}
+
+// Default returns the default value for IstioMode (Sidecar).
+func (m *IstioMode) Default() any {
+	return IstioModeSidecar
+}
+
+// ValidValues returns all valid IstioMode values as strings.
+func (m *IstioMode) ValidValues() []string {
+	return validValueStrings(ValidIstioModes())
+}
+
+// OptionsIstio defines options for the Istio service mesh. They only take
+// effect when spec.cluster.serviceMesh is Istio.
+type OptionsIstio struct {
+	// Mode selects the Istio data-plane mode. Sidecar (the default) injects an
+	// Envoy proxy into each pod; Ambient adds the Istio CNI node agent and the
+	// per-node ztunnel proxy instead.
+	Mode IstioMode `json:"mode,omitzero" jsonschema_description:"Istio data-plane mode. Sidecar (default) injects an Envoy proxy into each pod; Ambient installs the Istio CNI node agent and ztunnel instead of sidecars."` //nolint:lll
+}
+
+// IsZero reports whether no Istio option is set.
+func (o OptionsIstio) IsZero() bool {
+	return o == OptionsIstio{}
+}
+
+// Ambient reports whether Istio runs in ambient mode.
+func (o OptionsIstio) Ambient() bool {
+	return o.Mode == IstioModeAmbient
+}
+
+// Service mesh namespace labels and annotations that opt a namespace's pods
+// into the mesh.
+const (
+	// IstioInjectionLabel enables Istio sidecar injection for a namespace.
+	IstioInjectionLabel = "istio-injection"
+	// IstioDataplaneModeLabel enrolls a namespace in the Istio ambient mesh.
+	IstioDataplaneModeLabel = "istio.io/dataplane-mode"
+	// LinkerdInjectAnnotation enables Linkerd proxy injection for a namespace.
+	LinkerdInjectAnnotation = "linkerd.io/inject"
+)
+
+// MeshNamespaceMetadata returns the labels and annotations that enroll a
+// namespace in the selected service mesh, or nil maps when none is selected.
+func MeshNamespaceMetadata(
+	mesh ServiceMesh,
+	istio OptionsIstio,
+) (map[string]string, map[string]string) {
+	switch mesh {
+	case ServiceMeshIstio:
+		if istio.Ambient() {
+			return map[string]string{IstioDataplaneModeLabel: "ambient"}, nil
+		}
+
+		return map[string]string{IstioInjectionLabel: "enabled"}, nil
+	case ServiceMeshLinkerd:
+		return nil, map[string]string{LinkerdInjectAnnotation: "enabled"}
+	case ServiceMeshNone:
+		return nil, nil
+	}
+
+	return nil, nil
+}
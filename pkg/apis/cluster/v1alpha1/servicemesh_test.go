@@ -0,0 +1,75 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceMesh_Enabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   v1alpha1.ServiceMesh
+		want bool
+	}{
+		{"empty is disabled", v1alpha1.ServiceMesh(""), false},
+		{"None is disabled", v1alpha1.ServiceMeshNone, false},
+		{"Istio is enabled", v1alpha1.ServiceMeshIstio, true},
+		{"Linkerd is enabled", v1alpha1.ServiceMeshLinkerd, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, testCase.in.Enabled())
+		})
+	}
+}
+
+func TestMeshNamespaceMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		mesh            v1alpha1.ServiceMesh
+		istio           v1alpha1.OptionsIstio
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name: "None",
+			mesh: v1alpha1.ServiceMeshNone,
+		},
+		{
+			name:       "IstioSidecarByDefault",
+			mesh:       v1alpha1.ServiceMeshIstio,
+			wantLabels: map[string]string{"istio-injection": "enabled"},
+		},
+		{
+			name:       "IstioAmbient",
+			mesh:       v1alpha1.ServiceMeshIstio,
+			istio:      v1alpha1.OptionsIstio{Mode: v1alpha1.IstioModeAmbient},
+			wantLabels: map[string]string{"istio.io/dataplane-mode": "ambient"},
+		},
+		{
+			name:            "Linkerd",
+			mesh:            v1alpha1.ServiceMeshLinkerd,
+			wantAnnotations: map[string]string{"linkerd.io/inject": "enabled"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			labels, annotations := v1alpha1.MeshNamespaceMetadata(testCase.mesh, testCase.istio)
+
+			assert.Equal(t, testCase.wantLabels, labels)
+			assert.Equal(t, testCase.wantAnnotations, annotations)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package v1alpha1
+
+import "fmt"
+
+// SpotSpec requests spot (preemptible) capacity for the worker node pools of
+// cloud distributions, trading availability for a large discount — a fit for
+// short-lived test clusters. KSail applies it to the GKE node pools in gke.yaml
+// and the AKS user agent pools in aks.yaml at create time, and scaffolds the
+// EKS managed node group in eks.yaml as a spot group. AKS system pools cannot
+// run on spot capacity and stay on-demand.
+type SpotSpec struct {
+	// Enabled requests spot capacity for the cluster's worker node pools.
+	Enabled bool `json:"enabled,omitzero" jsonschema_description:"Run the worker node pools of EKS, GKE, and AKS clusters on spot (preemptible) capacity. Applied to gke.yaml node pools and aks.yaml user agent pools at create time; scaffolded into the eks.yaml managed node group. AKS system pools stay on-demand."` //nolint:lll
+
+	// ReclaimPolicy controls what happens to an instance the cloud reclaims.
+	ReclaimPolicy SpotReclaimPolicy `json:"reclaimPolicy,omitzero" jsonschema_description:"What happens when the cloud reclaims a spot instance. Replace (default) deletes it so the node pool provisions a replacement as soon as capacity returns (EKS capacity rebalancing, GKE managed instance groups, AKS eviction policy Delete). Deallocate stops the VM and keeps its disk for a later restart; AKS only."` //nolint:lll
+}
+
+// SpotReclaimPolicy defines what happens to a reclaimed spot instance.
+type SpotReclaimPolicy string
+
+const (
+	// SpotReclaimPolicyReplace deletes reclaimed instances so the node pool
+	// provisions replacements automatically.
+	SpotReclaimPolicyReplace SpotReclaimPolicy = "Replace"
+	// SpotReclaimPolicyDeallocate stops reclaimed instances and keeps their
+	// disks (AKS only).
+	SpotReclaimPolicyDeallocate SpotReclaimPolicy = "Deallocate"
+)
+
+// ValidSpotReclaimPolicies returns supported spot reclaim policy values.
+func ValidSpotReclaimPolicies() []SpotReclaimPolicy {
+	return []SpotReclaimPolicy{
+		SpotReclaimPolicyReplace,
+		SpotReclaimPolicyDeallocate,
+	}
+}
+
+// Set for SpotReclaimPolicy (pflag.Value interface).
+func (p *SpotReclaimPolicy) Set(value string) error {
+	return setEnum(p, value, ValidSpotReclaimPolicies(), ErrInvalidSpotReclaimPolicy)
+}
+
+// String returns the string representation of the SpotReclaimPolicy.
+func (p *SpotReclaimPolicy) String() string {
+	return string(*p)
+}
+
+// Type returns the type of the SpotReclaimPolicy.
+func (p *SpotReclaimPolicy) Type() string {
+	return "SpotReclaimPolicy"
+}
+
+// Default returns the default value for SpotReclaimPolicy (Replace).
+func (p *SpotReclaimPolicy) Default() any {
+	return SpotReclaimPolicyReplace
+}
+
+// ValidValues returns all valid SpotReclaimPolicy values as strings.
+func (p *SpotReclaimPolicy) ValidValues() []string {
+	return validValueStrings(ValidSpotReclaimPolicies())
+}
+
+// EffectiveReclaimPolicy returns the configured reclaim policy, defaulting to
+// Replace when unset.
+func (s SpotSpec) EffectiveReclaimPolicy() SpotReclaimPolicy {
+	if s.ReclaimPolicy == "" {
+		return SpotReclaimPolicyReplace
+	}
+
+	return s.ReclaimPolicy
+}
+
+// ValidateSpot checks that spot capacity is only requested for cloud
+// distributions that support it and that the reclaim policy is supported by
+// the selected distribution, so a mismatch fails at config load rather than
+// after a billable cluster has been created on-demand.
+func ValidateSpot(cluster *ClusterSpec) error {
+	if cluster == nil || !cluster.Spot.Enabled {
+		return nil
+	}
+
+	//nolint:exhaustive // every non-cloud distribution is rejected by the default case
+	switch cluster.Distribution {
+	case DistributionEKS, DistributionGKE:
+		if cluster.Spot.EffectiveReclaimPolicy() != SpotReclaimPolicyReplace {
+			return fmt.Errorf(
+				"%w: reclaimPolicy %s is only supported by AKS; %s always replaces reclaimed instances",
+				ErrInvalidSpot, cluster.Spot.ReclaimPolicy, cluster.Distribution,
+			)
+		}
+	case DistributionAKS:
+		// AKS supports both reclaim policies (eviction policy Delete or Deallocate).
+	default:
+		return fmt.Errorf(
+			"%w: spot capacity requires the EKS, GKE, or AKS distribution, got %s",
+			ErrInvalidSpot, cluster.Distribution,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package v1alpha1
+
+// Tenancy scopes the host-local resources of a Docker-provider cluster to one tenant so several
+// users can share a Docker host without colliding. When enabled, KSail prefixes the cluster name
+// with the tenant, which carries through to the node container names, the K3d network, the
+// registry containers, and the kubeconfig context; Kind clusters also get a per-tenant Docker
+// network ("kind-<tenant>") instead of the shared "kind" network.
+type Tenancy struct {
+	// Enabled turns on tenant scoping. It can also be set host-wide through the
+	// KSAIL_SPEC_CLUSTER_TENANCY_ENABLED environment variable.
+	Enabled bool `json:"enabled,omitzero" jsonschema_description:"Prefix the cluster name, node containers, Docker network, registry containers, and kubeconfig context with the tenant so several users can share a Docker host."` //nolint:lll
+	// Prefix is the tenant name prepended to resource names. When empty, it is derived from the
+	// current OS user. It must be a lowercase RFC 1123 label.
+	Prefix string `json:"prefix,omitzero" jsonschema_description:"Tenant name prepended to resource names (lowercase RFC 1123 label). Defaults to the current OS user name."` //nolint:lll
+}
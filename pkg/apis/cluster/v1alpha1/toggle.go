@@ -36,16 +36,17 @@ func BoolToToggleValue(enabled bool) string {
 // ToggleEnumTypes returns the reflect.Type of every on/off enum that accepts a
 // boolean alias at config load (see BoolToToggleValue). It is the single source
 // of truth for which fields the bool-coercion decode hook applies to: both the
-// bi-state {Enabled,Disabled} family (CertManager, ImageVerification,
-// IngressFirewall, PodAutoscalerHorizontal, PodAutoscalerVertical,
-// NodeAutoscaling, NodeAutoscalerEnabled) and the tri-state
+// bi-state {Enabled,Disabled} family (CertManager, MirrorRegistryTLS,
+// ImageVerification, IngressFirewall, PodAutoscalerHorizontal,
+// PodAutoscalerVertical, NodeAutoscaling, NodeAutoscalerEnabled) and the tri-state
 // {Default,Enabled,Disabled} family (CSI, CDI, MetricsServer, LoadBalancer,
-// SOPSEnabled) accept booleans, since "Default" remains expressible as the
+// SOPSEnabled, Hubble, KubeProxyReplacement) accept booleans, since "Default" remains expressible as the
 // string value. Adding a new toggle enum requires adding it here; the drift
 // guard in toggle_test.go fails otherwise.
 func ToggleEnumTypes() []reflect.Type {
 	return []reflect.Type{
 		reflect.TypeFor[CertManager](),
+		reflect.TypeFor[MirrorRegistryTLS](),
 		reflect.TypeFor[ImageVerification](),
 		reflect.TypeFor[IngressFirewall](),
 		reflect.TypeFor[PodAutoscalerHorizontal](),
@@ -61,6 +62,8 @@ func ToggleEnumTypes() []reflect.Type {
 		reflect.TypeFor[MetricsServer](),
 		reflect.TypeFor[LoadBalancer](),
 		reflect.TypeFor[SOPSEnabled](),
+		reflect.TypeFor[Hubble](),
+		reflect.TypeFor[KubeProxyReplacement](),
 	}
 }
 
@@ -0,0 +1,29 @@
+package v1alpha1
+
+import "strings"
+
+// pemBlockPrefix marks a CABundles entry as inline PEM rather than a file path.
+const pemBlockPrefix = "-----BEGIN"
+
+// TrustSpec configures extra certificate authorities KSail distributes to the cluster. It exists
+// for networks where a TLS-intercepting (MITM) proxy re-signs traffic: without the proxy's CA,
+// nodes fail to pull images and workloads fail to call webhooks or external APIs.
+//
+// KSail installs the bundles into the node OS trust store (Kind via docker exec and
+// update-ca-certificates, Talos via a TrustedRootsConfig machine-config document) and publishes
+// them, together with the default public CAs, as a ConfigMap in every namespace via trust-manager.
+type TrustSpec struct {
+	// CABundles lists PEM-encoded CA certificates to trust. Each entry is either a path to a PEM
+	// file (relative paths resolve against the ksail.yaml directory) or inline PEM content.
+	CABundles []string `json:"caBundles,omitempty" jsonschema_description:"Extra CA certificates to trust on nodes and in workloads. Each entry is a path to a PEM file (relative to ksail.yaml) or inline PEM content."` //nolint:lll
+}
+
+// Enabled reports whether any CA bundle is configured.
+func (t TrustSpec) Enabled() bool {
+	return len(t.CABundles) > 0
+}
+
+// IsInlinePEM reports whether a CABundles entry holds inline PEM content rather than a file path.
+func IsInlinePEM(entry string) bool {
+	return strings.Contains(entry, pemBlockPrefix)
+}
@@ -61,9 +61,18 @@ type Spec struct {
 	// Workload configures workload management: the manifest source directory,
 	// OCI push and validation settings, and GitOps bootstrap options.
 	Workload WorkloadSpec `json:"workload,omitzero"`
+	// Trust configures extra CA certificates installed into node trust stores and published to
+	// workloads, for networks behind a TLS-intercepting proxy.
+	Trust TrustSpec `json:"trust,omitzero"`
+	// Seed declares ConfigMaps and Secrets KSail creates as soon as the cluster is ready and
+	// keeps reconciled on every update.
+	Seed SeedSpec `json:"seed,omitzero"`
 	// Chat configures the KSail AI chat assistant.
 	// CLI-only; ignored by the operator (the Cluster CRD shares this type but never reads it).
 	Chat ChatSpec `json:"chat,omitzero"`
+	// Notify configures extra sinks (log files, syslog, a webhook) that keep a record of KSail's output.
+	// CLI-only; ignored by the operator (the Cluster CRD shares this type but never reads it).
+	Notify NotifySpec `json:"notify,omitzero"`
 }
 
 // ProviderSpec defines provider-specific configuration for infrastructure providers.
@@ -108,6 +117,12 @@ type ClusterSpec struct {
 	// CNI selects the Container Network Interface plugin. Default keeps the
 	// distribution's built-in CNI; Cilium or Calico install that CNI instead.
 	CNI CNI `json:"cni,omitzero"`
+	// Cilium holds options for the Cilium CNI (Hubble observability and
+	// kube-proxy replacement). Ignored unless cni is Cilium.
+	Cilium OptionsCilium `json:"cilium,omitzero"`
+	// Network configures the pod and service networks, such as IPv6 or
+	// dual-stack addressing.
+	Network NetworkSpec `json:"network,omitzero"`
 	// CSI controls Container Storage Interface support. Default keeps the
 	// distribution's behavior; Enabled installs a CSI driver
 	// (local-path-provisioner, or Hetzner CSI on Hetzner); Disabled installs none.
@@ -135,14 +150,66 @@ type ClusterSpec struct {
 	ImageVerification ImageVerification `json:"imageVerification,omitzero" jsonschema_description:"Container-image signature verification scaffolding for all distributions: Talos scaffolds an ImageVerificationConfig document (1.13+); Vanilla/Kind injects a containerd verifier plugin patch; K3s/K3d scaffolds a containerd config template and mounts it into node containers. Requires verifier binaries (and typically policy) in the node image bin_dir. Disabled skips it."` //nolint:lll
 	// PolicyEngine selects the policy engine to install: None, Kyverno, or Gatekeeper.
 	PolicyEngine PolicyEngine `json:"policyEngine,omitzero"`
+	// VulnerabilityPolicy scaffolds a Kyverno policy that blocks images whose
+	// vulnerability scan attestation reports findings at or above a severity,
+	// except allowlisted IDs.
+	VulnerabilityPolicy VulnerabilityPolicy `json:"vulnerabilityPolicy,omitzero"`
+	// IngressController selects the ingress controller to install: None, Nginx
+	// (ingress-nginx), Traefik, or Contour. On the Docker provider KSail maps host
+	// ports 80 and 443 onto the controller so ingresses answer on localhost.
+	IngressController IngressController `json:"ingressController,omitzero"`
+	// GatewayAPI selects Gateway API support: None, CRDs (CRDs only), Cilium (the
+	// Cilium CNI's built-in controller), or Envoy (Envoy Gateway). When a
+	// controller is selected, the scaffolder emits an example Gateway and HTTPRoute.
+	GatewayAPI GatewayAPI `json:"gatewayAPI,omitzero"`
+	// ServiceMesh selects the service mesh to install: None, Istio, or Linkerd.
+	// KSail waits for the control plane to become ready, and the scaffolder
+	// enrolls the workload namespace in the mesh.
+	ServiceMesh ServiceMesh `json:"serviceMesh,omitzero"`
+	// Istio holds options for the Istio service mesh (when serviceMesh is Istio).
+	Istio OptionsIstio `json:"istio,omitzero"`
+	// Knative controls whether Knative Serving is installed (Enabled or Disabled),
+	// networked by Kourier. On the Docker provider Kourier is mapped to host port
+	// 8080 and services get sslip.io magic DNS names that resolve to localhost.
+	Knative Knative `json:"knative,omitzero"`
+	// Observability configures the observability stacks KSail installs, such as
+	// cluster-wide log aggregation.
+	Observability ObservabilitySpec `json:"observability,omitzero"`
 	// LocalRegistry configures the host-local OCI registry (or an external
 	// registry for cloud providers) used by GitOps workflows.
 	LocalRegistry LocalRegistry `json:"localRegistry,omitzero"`
+	// MirrorRegistryTLS controls whether the local mirror registries (pull-through
+	// caches created by --mirror-registry) serve HTTPS (Enabled or Disabled). When
+	// Enabled, KSail signs their certificates with a CA it generates under
+	// ~/.ksail/registry-tls and adds that CA to the nodes' containerd trust.
+	// Docker provider only.
+	MirrorRegistryTLS MirrorRegistryTLS `json:"mirrorRegistryTLS,omitzero"`
+	// RegistryBinding controls the host address and ports the local and mirror
+	// registry containers are published on, so registries can be reached from
+	// other machines than the Docker host. Docker provider only.
+	RegistryBinding RegistryBinding `json:"registryBinding,omitzero"`
 	// GitOpsEngine selects the GitOps engine KSail bootstraps: None, Flux, or ArgoCD.
 	GitOpsEngine GitOpsEngine `json:"gitOpsEngine,omitzero"`
 	// SOPS configures automatic creation of the SOPS Age secret used to decrypt
 	// encrypted manifests in the cluster.
 	SOPS SOPS `json:"sops,omitzero"`
+	// SealedSecrets controls whether the Bitnami Sealed Secrets controller is
+	// installed (Enabled or Disabled), so SealedSecret manifests sealed with
+	// `ksail workload cipher seal` can be committed and decrypted in-cluster.
+	SealedSecrets SealedSecrets `json:"sealedSecrets,omitzero"`
+	// ExternalSecrets controls whether the External Secrets Operator is installed
+	// (Enabled or Disabled). When enabled, the scaffolder emits a ClusterSecretStore
+	// backed by ESO's fake provider so ExternalSecret manifests work locally.
+	ExternalSecrets ExternalSecrets `json:"externalSecrets,omitzero"`
+	// Backup selects the in-cluster backup system: None or Velero. Velero is
+	// installed with a MinIO container on the cluster's Docker network as its
+	// S3 target and is driven by `ksail cluster backup --velero` and
+	// `ksail cluster restore-backup`.
+	Backup Backup `json:"backup,omitzero"`
+	// ActionsRunner installs GitHub Actions Runner Controller with a runner scale
+	// set registered to a repository or organization, for testing CI jobs that
+	// need in-cluster runners.
+	ActionsRunner ActionsRunnerSpec `json:"actionsRunner,omitzero"`
 	// NodeAutoscaling is a deprecated alias for spec.cluster.autoscaler.node.enabled
 	// and is migrated on load. Do not set both nodeAutoscaling and autoscaler.
 	NodeAutoscaling NodeAutoscaling `json:"nodeAutoscaling,omitzero" jsonschema_description:"Deprecated. Use autoscaler.node.enabled instead. Do not set both nodeAutoscaling and autoscaler."` //nolint:lll
@@ -159,6 +226,13 @@ type ClusterSpec struct {
 	// When 0 on Talos, scheduling is allowed on control-plane nodes.
 	// Supersedes spec.cluster.talos.workers (deprecated; aliased on load).
 	Workers int32 `json:"workers,omitzero" jsonschema:"minimum=0" jsonschema_description:"Number of worker nodes to create for the cluster (provider/distribution-agnostic)"` //nolint:lll
+	// Nodes customizes individual nodes or all nodes of a role: container CPU and
+	// memory limits, and kubelet-registered labels and taints. Supported by Vanilla
+	// (Kind), K3s (K3d), and Talos on the Docker provider.
+	Nodes []NodeSpec `json:"nodes,omitzero" jsonschema_description:"Per-node customization selected by role (ControlPlane or Worker) and optional index: container CPU and memory limits, and kubelet-registered labels and taints. Supported by Vanilla (Kind), K3s (K3d), and Talos on the Docker provider."` //nolint:lll
+	// Spot requests spot (preemptible) capacity for the worker node pools of
+	// cloud distributions (EKS, GKE, AKS), with a policy for reclaimed instances.
+	Spot SpotSpec `json:"spot,omitzero"`
 
 	// KubernetesVersion pins the Kubernetes version to deploy. Accepts values with
 	// or without the "v" prefix (e.g., "v1.32.0" or "1.32.0"). Honored by the Talos
@@ -182,6 +256,21 @@ type ClusterSpec struct {
 	// When issuerURL is set, KSail configures the API server with OIDC flags
 	// and sets up kubeconfig with exec-based OIDC credentials.
 	OIDC OIDCSpec `json:"oidc,omitzero" jsonschema_description:"OIDC authentication configuration for the API server and kubeconfig"` //nolint:lll
+	// IdentityProvider selects a development identity provider to install: None
+	// or Keycloak. Keycloak is imported with a realm of test users, groups and an
+	// OIDC client, and the oidc options default to it, so OIDC login and RBAC
+	// group mapping can be tested locally. Vanilla and K3s on Docker only.
+	IdentityProvider IdentityProvider `json:"identityProvider,omitzero"`
+
+	// ResourceMetadata declares labels and annotations KSail stamps onto every resource it
+	// creates for this cluster (registry containers, namespaces, Helm releases, scaffolded
+	// kustomizations), enabling downstream filtering, cleanup, and cost attribution.
+	ResourceMetadata ResourceMetadata `json:"resourceMetadata,omitzero" jsonschema_description:"Labels and annotations (e.g. team, environment, cost-center) KSail stamps onto every resource it creates: registry containers, namespaces, Helm releases, and scaffolded kustomizations."` //nolint:lll
+
+	// Tenancy scopes the cluster's host-local resources (node containers, Docker network,
+	// registry containers, kubeconfig context) to a tenant so several users can share a
+	// Docker host. CLI-only; ignored by the operator.
+	Tenancy Tenancy `json:"tenancy,omitzero"`
 
 	// Distribution-specific options
 
@@ -200,14 +289,35 @@ func (c ClusterSpec) TotalNodeCount() int32 {
 
 // WorkloadSpec defines workload-related configuration.
 type WorkloadSpec struct {
-	SourceDirectory   string           `default:"k8s"   json:"sourceDirectory,omitzero"   jsonschema_description:"Path to the directory containing Kubernetes manifests. Used as the default path by validate, watch, and push when no explicit path argument is given."`                                                                                                      //nolint:lll
-	ValidateOnPush    bool             `default:"false" json:"validateOnPush,omitzero"    jsonschema_description:"Validate manifests against schemas before pushing (validation disabled by default)"`                                                                                                                                                                         //nolint:lll
-	Tag               string           `default:"dev"   json:"tag,omitzero"               jsonschema_description:"OCI artifact tag used for workload push and GitOps reconciliation (Flux OCIRepository and ArgoCD Application). Push priority: CLI oci:// ref > this field > registry-embedded tag > dev. Reconciliation priority: this field > registry-embedded tag > dev"` //nolint:lll
-	KustomizationFile string           `default:""      json:"kustomizationFile,omitzero" jsonschema_description:"Path to the kustomization directory relative to sourceDirectory. When set, Flux Sync.Path is configured to this path so Flux uses the specified kustomization as the entry point instead of requiring a root kustomization.yaml."`                           //nolint:lll
-	Flux              FluxConfig       `                json:"flux,omitzero"              jsonschema_description:"Flux bootstrap configuration: operator/distribution version pins and signature verification for the generated OCIRepository. Empty values use KSail's pinned versions; a GitOps repo that declares these becomes the steady-state owner."`                   //nolint:lll
-	Watch             WatchConfig      `                json:"watch,omitzero"             jsonschema_description:"Configuration for the workload watch command (pre-apply hooks, etc.)"`                                                                                                                                                                                       //nolint:lll
-	Validation        ValidationConfig `                json:"validation,omitzero"        jsonschema_description:"Configuration for the workload validate command (additional kinds to skip, etc.)."`                                                                                                                                                                          //nolint:lll
-	Scan              ScanConfig       `                json:"scan,omitzero"              jsonschema_description:"Configuration for the workload scan command (Kubescape exceptions, frameworks, compliance threshold) so 'ksail workload scan' (no args) can act as a turnkey CI gate."`                                                                                      //nolint:lll
+	SourceDirectory   string             `default:"k8s"   json:"sourceDirectory,omitzero"   jsonschema_description:"Path to the directory containing Kubernetes manifests. Used as the default path by validate, watch, and push when no explicit path argument is given."`                                                                                                      //nolint:lll
+	ValidateOnPush    bool               `default:"false" json:"validateOnPush,omitzero"    jsonschema_description:"Validate manifests against schemas before pushing (validation disabled by default)"`                                                                                                                                                                         //nolint:lll
+	Tag               string             `default:"dev"   json:"tag,omitzero"               jsonschema_description:"OCI artifact tag used for workload push and GitOps reconciliation (Flux OCIRepository and ArgoCD Application). Push priority: CLI oci:// ref > this field > registry-embedded tag > dev. Reconciliation priority: this field > registry-embedded tag > dev"` //nolint:lll
+	KustomizationFile string             `default:""      json:"kustomizationFile,omitzero" jsonschema_description:"Path to the kustomization directory relative to sourceDirectory. When set, Flux Sync.Path is configured to this path so Flux uses the specified kustomization as the entry point instead of requiring a root kustomization.yaml."`                           //nolint:lll
+	Flux              FluxConfig         `                json:"flux,omitzero"              jsonschema_description:"Flux bootstrap configuration: operator/distribution version pins and signature verification for the generated OCIRepository. Empty values use KSail's pinned versions; a GitOps repo that declares these becomes the steady-state owner."`                   //nolint:lll
+	ArgoCD            ArgoCDConfig       `                json:"argoCD,omitzero"            jsonschema_description:"Argo CD bootstrap configuration: AppProject guardrails (source repos, destinations, sync windows) applied to the Application KSail generates. Empty keeps the unrestricted default project."`                                                                //nolint:lll
+	Watch             WatchConfig        `                json:"watch,omitzero"             jsonschema_description:"Configuration for the workload watch command (pre-apply hooks, etc.)"`                                                                                                                                                                                       //nolint:lll
+	Validation        ValidationConfig   `                json:"validation,omitzero"        jsonschema_description:"Configuration for the workload validate command (additional kinds to skip, etc.)."`                                                                                                                                                                          //nolint:lll
+	Scan              ScanConfig         `                json:"scan,omitzero"              jsonschema_description:"Configuration for the workload scan command (Kubescape exceptions, frameworks, compliance threshold) so 'ksail workload scan' (no args) can act as a turnkey CI gate."`                                                                                      //nolint:lll
+	Git               GitSource          `                json:"git,omitzero"               jsonschema_description:"Git repository the GitOps engine tracks instead of the OCI artifact KSail pushes. Set by 'ksail init --push-to'; empty keeps the OCI source."`                                                                                                               //nolint:lll
+	Substitution      SubstitutionConfig `                json:"substitution,omitzero"      jsonschema_description:"Variables substituted into manifests by 'ksail workload apply' and 'ksail workload render' (Flux postBuild-style ${VAR} references), so manifests can reference cluster-specific values without per-developer overlays."`                                    //nolint:lll
+}
+
+// SubstitutionConfig defines the variables 'ksail workload apply' and 'ksail
+// workload render' substitute into manifests, mirroring Flux
+// postBuild.substitute. The vars file overrides vars; --vars-file, environment
+// variables, and --var override both, in that order.
+type SubstitutionConfig struct {
+	// Vars maps variable names to the values substituted for ${NAME} references.
+	Vars map[string]string `json:"vars,omitzero" jsonschema_description:"Variables substituted for ${NAME} references. Overridden by the vars file, environment variables, and --var."` //nolint:lll
+
+	// VarsFile is the path to a dotenv-style file (KEY=VALUE per line) of
+	// variables. A relative path is resolved against the ksail.yaml directory.
+	VarsFile string `json:"varsFile,omitzero" jsonschema_description:"Path to a dotenv-style file (KEY=VALUE per line) of variables, overriding vars. A relative path resolves against the ksail.yaml directory."` //nolint:lll
+}
+
+// IsEnabled reports whether any substitution variables are configured.
+func (s SubstitutionConfig) IsEnabled() bool {
+	return len(s.Vars) > 0 || s.VarsFile != ""
 }
 
 // ValidationConfig defines configuration for the workload validate command.
@@ -306,4 +416,8 @@ type Connection struct {
 	// Timeout is the maximum time KSail waits for cluster operations to complete
 	// (e.g. "5m").
 	Timeout metav1.Duration `json:"timeout,omitzero"`
+	// APIServerPort pins the localhost port the Kubernetes API server is published on,
+	// so the kubeconfig endpoint stays the same across recreates. Zero picks a free
+	// port at random. Supported by Vanilla (Kind), K3s (K3d), and Talos on Docker.
+	APIServerPort int32 `json:"apiServerPort,omitzero" jsonschema:"minimum=0,maximum=65535" jsonschema_description:"Localhost port the Kubernetes API server is published on, so kubeconfigs stay valid across recreates. 0 (default) picks a random free port. Supported by Vanilla (Kind), K3s (K3d), and Talos on the Docker provider."` //nolint:lll
 }
@@ -291,38 +291,52 @@ func validateNodePoolFields(pool NodePool, idx int) error {
 // the Kubernetes label syntax, and taint effects must be one of the supported
 // effects.
 func validatePoolLabelsAndTaints(pool NodePool) error {
-	for key, value := range pool.Labels {
+	return validateLabelsAndTaints(
+		fmt.Sprintf("pool %q", pool.Name), pool.Labels, pool.Taints,
+		ErrInvalidPoolLabel, ErrInvalidPoolTaint,
+	)
+}
+
+// validateLabelsAndTaints checks the node labels and taints of owner (a pool or
+// a spec.cluster.nodes entry), wrapping failures in labelErr or taintErr.
+func validateLabelsAndTaints(
+	owner string,
+	labels map[string]string,
+	taints []NodePoolTaint,
+	labelErr, taintErr error,
+) error {
+	for key, value := range labels {
 		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
 			return fmt.Errorf(
-				"%w: pool %q label key %q: %s",
-				ErrInvalidPoolLabel, pool.Name, key, strings.Join(errs, "; "),
+				"%w: %s label key %q: %s",
+				labelErr, owner, key, strings.Join(errs, "; "),
 			)
 		}
 
 		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
 			return fmt.Errorf(
-				"%w: pool %q label %q value %q: %s",
-				ErrInvalidPoolLabel, pool.Name, key, value, strings.Join(errs, "; "),
+				"%w: %s label %q value %q: %s",
+				labelErr, owner, key, value, strings.Join(errs, "; "),
 			)
 		}
 	}
 
-	for idx, taint := range pool.Taints {
-		taintErr := validatePoolTaint(pool.Name, idx, taint)
-		if taintErr != nil {
-			return taintErr
+	for idx, taint := range taints {
+		err := validateTaint(owner, idx, taint, taintErr)
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// validatePoolTaint validates a single pool taint's key, value, and effect.
-func validatePoolTaint(poolName string, idx int, taint NodePoolTaint) error {
+// validateTaint validates a single taint's key, value, and effect.
+func validateTaint(owner string, idx int, taint NodePoolTaint, taintErr error) error {
 	if errs := validation.IsQualifiedName(taint.Key); len(errs) > 0 {
 		return fmt.Errorf(
-			"%w: pool %q taint[%d] key %q: %s",
-			ErrInvalidPoolTaint, poolName, idx, taint.Key, strings.Join(errs, "; "),
+			"%w: %s taint[%d] key %q: %s",
+			taintErr, owner, idx, taint.Key, strings.Join(errs, "; "),
 		)
 	}
 
@@ -330,15 +344,15 @@ func validatePoolTaint(poolName string, idx int, taint NodePoolTaint) error {
 	// unconditionally.
 	if errs := validation.IsValidLabelValue(taint.Value); len(errs) > 0 {
 		return fmt.Errorf(
-			"%w: pool %q taint[%d] %q value %q: %s",
-			ErrInvalidPoolTaint, poolName, idx, taint.Key, taint.Value, strings.Join(errs, "; "),
+			"%w: %s taint[%d] %q value %q: %s",
+			taintErr, owner, idx, taint.Key, taint.Value, strings.Join(errs, "; "),
 		)
 	}
 
 	if !slices.Contains(ValidTaintEffects(), taint.Effect) {
 		return fmt.Errorf(
-			"%w: pool %q taint[%d] %q has invalid effect %q (valid: %s, %s, %s)",
-			ErrInvalidPoolTaint, poolName, idx, taint.Key, taint.Effect,
+			"%w: %s taint[%d] %q has invalid effect %q (valid: %s, %s, %s)",
+			taintErr, owner, idx, taint.Key, taint.Effect,
 			TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute,
 		)
 	}
@@ -2,10 +2,12 @@ package v1alpha1_test
 
 import (
 	"testing"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestValidateLocalRegistryForProvider(t *testing.T) {
@@ -554,3 +556,905 @@ func TestValidateEKSConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSpot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.ClusterSpec
+		wantErr error
+	}{
+		{
+			name:    "nil cluster is valid",
+			cluster: nil,
+		},
+		{
+			name: "disabled spot ignores the distribution",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionVanilla,
+				Spot:         v1alpha1.SpotSpec{ReclaimPolicy: v1alpha1.SpotReclaimPolicyDeallocate},
+			},
+		},
+		{
+			name: "GKE with the default reclaim policy is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionGKE,
+				Spot:         v1alpha1.SpotSpec{Enabled: true},
+			},
+		},
+		{
+			name: "AKS with Deallocate is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionAKS,
+				Spot: v1alpha1.SpotSpec{
+					Enabled:       true,
+					ReclaimPolicy: v1alpha1.SpotReclaimPolicyDeallocate,
+				},
+			},
+		},
+		{
+			name: "EKS with Deallocate is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionEKS,
+				Spot: v1alpha1.SpotSpec{
+					Enabled:       true,
+					ReclaimPolicy: v1alpha1.SpotReclaimPolicyDeallocate,
+				},
+			},
+			wantErr: v1alpha1.ErrInvalidSpot,
+		},
+		{
+			name: "non-cloud distribution is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionTalos,
+				Spot:         v1alpha1.SpotSpec{Enabled: true},
+			},
+			wantErr: v1alpha1.ErrInvalidSpot,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateSpot(testCase.cluster)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateActionsRunner(t *testing.T) {
+	t.Parallel()
+
+	const repoURL = "https://github.com/devantler-tech/ksail"
+
+	tests := []struct {
+		name   string
+		runner v1alpha1.ActionsRunnerSpec
+		valid  bool
+	}{
+		{name: "disabled runner ignores missing URL", runner: v1alpha1.ActionsRunnerSpec{}, valid: true},
+		{
+			name:   "repository URL with defaults is valid",
+			runner: v1alpha1.ActionsRunnerSpec{Enabled: true, GitHubConfigURL: repoURL},
+			valid:  true,
+		},
+		{
+			name: "organization URL with labels and range is valid",
+			runner: v1alpha1.ActionsRunnerSpec{
+				Enabled:         true,
+				GitHubConfigURL: "https://github.com/devantler-tech",
+				Name:            "ci-runners",
+				Labels:          []string{"gpu"},
+				MinRunners:      1,
+				MaxRunners:      3,
+			},
+			valid: true,
+		},
+		{name: "missing URL is rejected", runner: v1alpha1.ActionsRunnerSpec{Enabled: true}},
+		{
+			name:   "URL without owner is rejected",
+			runner: v1alpha1.ActionsRunnerSpec{Enabled: true, GitHubConfigURL: "https://github.com"},
+		},
+		{
+			name: "invalid name is rejected",
+			runner: v1alpha1.ActionsRunnerSpec{
+				Enabled:         true,
+				GitHubConfigURL: repoURL,
+				Name:            "CI_Runners",
+			},
+		},
+		{
+			name: "empty label is rejected",
+			runner: v1alpha1.ActionsRunnerSpec{
+				Enabled:         true,
+				GitHubConfigURL: repoURL,
+				Labels:          []string{" "},
+			},
+		},
+		{
+			name: "maxRunners below minRunners is rejected",
+			runner: v1alpha1.ActionsRunnerSpec{
+				Enabled:         true,
+				GitHubConfigURL: repoURL,
+				MinRunners:      3,
+				MaxRunners:      1,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateActionsRunner(
+				&v1alpha1.ClusterSpec{ActionsRunner: testCase.runner},
+			)
+
+			if testCase.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, v1alpha1.ErrInvalidActionsRunner)
+			}
+		})
+	}
+}
+
+func TestValidateNetwork(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.ClusterSpec
+		wantErr error
+	}{
+		{
+			name:    "nil cluster is valid",
+			cluster: nil,
+		},
+		{
+			name: "ipv4 ignores the distribution",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionEKS,
+				Network:      v1alpha1.NetworkSpec{IPFamily: v1alpha1.IPFamilyIPv4},
+			},
+		},
+		{
+			name: "dual-stack Talos on Docker is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionTalos,
+				Provider:     v1alpha1.ProviderDocker,
+				Network:      v1alpha1.NetworkSpec{IPFamily: v1alpha1.IPFamilyDual},
+			},
+		},
+		{
+			name: "ipv6 K3s without a provider is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionK3s,
+				Network:      v1alpha1.NetworkSpec{IPFamily: v1alpha1.IPFamilyIPv6},
+			},
+		},
+		{
+			name: "ipv6 VCluster is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionVCluster,
+				Network:      v1alpha1.NetworkSpec{IPFamily: v1alpha1.IPFamilyIPv6},
+			},
+			wantErr: v1alpha1.ErrInvalidNetwork,
+		},
+		{
+			name: "dual-stack Talos on Hetzner is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionTalos,
+				Provider:     v1alpha1.ProviderHetzner,
+				Network:      v1alpha1.NetworkSpec{IPFamily: v1alpha1.IPFamilyDual},
+			},
+			wantErr: v1alpha1.ErrInvalidNetwork,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateNetwork(testCase.cluster)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.ClusterSpec
+		wantErr error
+	}{
+		{
+			name:    "nil cluster is valid",
+			cluster: nil,
+		},
+		{
+			name: "unset port ignores the distribution",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionEKS,
+			},
+		},
+		{
+			name: "Vanilla on Docker is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionVanilla,
+				Provider:     v1alpha1.ProviderDocker,
+				Connection:   v1alpha1.Connection{APIServerPort: 6443},
+			},
+		},
+		{
+			name: "K3s without a provider is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionK3s,
+				Connection:   v1alpha1.Connection{APIServerPort: 16443},
+			},
+		},
+		{
+			name: "out-of-range port is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionTalos,
+				Connection:   v1alpha1.Connection{APIServerPort: 70000},
+			},
+			wantErr: v1alpha1.ErrInvalidAPIServerPort,
+		},
+		{
+			name: "VCluster is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionVCluster,
+				Connection:   v1alpha1.Connection{APIServerPort: 6443},
+			},
+			wantErr: v1alpha1.ErrInvalidAPIServerPort,
+		},
+		{
+			name: "Talos on Hetzner is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionTalos,
+				Provider:     v1alpha1.ProviderHetzner,
+				Connection:   v1alpha1.Connection{APIServerPort: 6443},
+			},
+			wantErr: v1alpha1.ErrInvalidAPIServerPort,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateAPIServerPort(testCase.cluster)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRegistryBinding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.ClusterSpec
+		wantErr error
+	}{
+		{
+			name:    "nil cluster is valid",
+			cluster: nil,
+		},
+		{
+			name:    "unset binding is valid",
+			cluster: &v1alpha1.ClusterSpec{},
+		},
+		{
+			name: "all interfaces with mirror ports is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				LocalRegistry: v1alpha1.LocalRegistry{Registry: "localhost:5050"},
+				RegistryBinding: v1alpha1.RegistryBinding{
+					HostIP:      "0.0.0.0",
+					MirrorPorts: map[string]int32{"docker.io": 5001, "ghcr.io": 5002},
+				},
+			},
+		},
+		{
+			name: "hostname is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				RegistryBinding: v1alpha1.RegistryBinding{HostIP: "localhost"},
+			},
+			wantErr: v1alpha1.ErrInvalidRegistryBinding,
+		},
+		{
+			name: "out of range mirror port is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				RegistryBinding: v1alpha1.RegistryBinding{MirrorPorts: map[string]int32{"docker.io": 70000}},
+			},
+			wantErr: v1alpha1.ErrInvalidRegistryBinding,
+		},
+		{
+			name: "mirror port of the local registry is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				LocalRegistry:   v1alpha1.LocalRegistry{Registry: "localhost:5050"},
+				RegistryBinding: v1alpha1.RegistryBinding{MirrorPorts: map[string]int32{"docker.io": 5050}},
+			},
+			wantErr: v1alpha1.ErrInvalidRegistryBinding,
+		},
+		{
+			name: "shared mirror port is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				RegistryBinding: v1alpha1.RegistryBinding{
+					MirrorPorts: map[string]int32{"docker.io": 5001, "ghcr.io": 5001},
+				},
+			},
+			wantErr: v1alpha1.ErrInvalidRegistryBinding,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateRegistryBinding(testCase.cluster)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateIdentityProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.ClusterSpec
+		wantErr error
+	}{
+		{
+			name:    "nil cluster is valid",
+			cluster: nil,
+		},
+		{
+			name: "no identity provider on Talos is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution:     v1alpha1.DistributionTalos,
+				IdentityProvider: v1alpha1.IdentityProviderNone,
+			},
+		},
+		{
+			name: "Keycloak on Vanilla with Docker is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution:     v1alpha1.DistributionVanilla,
+				Provider:         v1alpha1.ProviderDocker,
+				IdentityProvider: v1alpha1.IdentityProviderKeycloak,
+			},
+		},
+		{
+			name: "Keycloak on K3s is valid",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution:     v1alpha1.DistributionK3s,
+				IdentityProvider: v1alpha1.IdentityProviderKeycloak,
+			},
+		},
+		{
+			name: "Keycloak on Talos is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution:     v1alpha1.DistributionTalos,
+				Provider:         v1alpha1.ProviderDocker,
+				IdentityProvider: v1alpha1.IdentityProviderKeycloak,
+			},
+			wantErr: v1alpha1.ErrInvalidIdentityProvider,
+		},
+		{
+			name: "Keycloak on Hetzner is rejected",
+			cluster: &v1alpha1.ClusterSpec{
+				Distribution:     v1alpha1.DistributionTalos,
+				Provider:         v1alpha1.ProviderHetzner,
+				IdentityProvider: v1alpha1.IdentityProviderKeycloak,
+			},
+			wantErr: v1alpha1.ErrInvalidIdentityProvider,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateIdentityProvider(testCase.cluster)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateNodes(t *testing.T) {
+	t.Parallel()
+
+	secondWorker := int32(1)
+	thirdWorker := int32(2)
+
+	tests := []struct {
+		name    string
+		nodes   []v1alpha1.NodeSpec
+		mutate  func(cluster *v1alpha1.ClusterSpec)
+		wantErr error
+	}{
+		{
+			name: "role-wide and indexed entries are valid",
+			nodes: []v1alpha1.NodeSpec{
+				{
+					Role:      v1alpha1.NodeRoleWorker,
+					Resources: v1alpha1.NodeResources{CPU: "1500m", Memory: "2Gi"},
+				},
+				{
+					Role:   v1alpha1.NodeRoleWorker,
+					Index:  &secondWorker,
+					Labels: map[string]string{"topology.kubernetes.io/zone": "b"},
+					Taints: []v1alpha1.NodePoolTaint{
+						{Key: "gpu", Value: "true", Effect: v1alpha1.TaintEffectNoSchedule},
+					},
+				},
+			},
+		},
+		{
+			name:    "unknown role is rejected",
+			nodes:   []v1alpha1.NodeSpec{{Role: "Master"}},
+			wantErr: v1alpha1.ErrInvalidNodeRole,
+		},
+		{
+			name:    "index beyond the worker count is rejected",
+			nodes:   []v1alpha1.NodeSpec{{Role: v1alpha1.NodeRoleWorker, Index: &thirdWorker}},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name: "malformed quantity is rejected",
+			nodes: []v1alpha1.NodeSpec{
+				{Role: v1alpha1.NodeRoleControlPlane, Resources: v1alpha1.NodeResources{Memory: "lots"}},
+			},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name: "zero CPU is rejected",
+			nodes: []v1alpha1.NodeSpec{
+				{Role: v1alpha1.NodeRoleControlPlane, Resources: v1alpha1.NodeResources{CPU: "0"}},
+			},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name: "invalid taint effect is rejected",
+			nodes: []v1alpha1.NodeSpec{
+				{
+					Role:   v1alpha1.NodeRoleWorker,
+					Taints: []v1alpha1.NodePoolTaint{{Key: "gpu", Effect: "Never"}},
+				},
+			},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name: "matching K3s GPU requests are valid",
+			nodes: []v1alpha1.NodeSpec{
+				{Role: v1alpha1.NodeRoleWorker, GPUs: v1alpha1.GPUsAll},
+				{Role: v1alpha1.NodeRoleControlPlane, GPUs: v1alpha1.GPUsAll},
+			},
+		},
+		{
+			name:    "malformed GPU request is rejected",
+			nodes:   []v1alpha1.NodeSpec{{Role: v1alpha1.NodeRoleWorker, GPUs: "0"}},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name: "differing K3s GPU requests are rejected",
+			nodes: []v1alpha1.NodeSpec{
+				{Role: v1alpha1.NodeRoleWorker, GPUs: "1"},
+				{Role: v1alpha1.NodeRoleWorker, Index: &secondWorker, GPUs: "2"},
+			},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name: "differing Vanilla GPU requests are valid",
+			nodes: []v1alpha1.NodeSpec{
+				{Role: v1alpha1.NodeRoleWorker, GPUs: "1"},
+				{Role: v1alpha1.NodeRoleWorker, Index: &secondWorker, GPUs: "2"},
+			},
+			mutate: func(cluster *v1alpha1.ClusterSpec) {
+				cluster.Distribution = v1alpha1.DistributionVanilla
+			},
+		},
+		{
+			name:  "Talos GPU request is rejected",
+			nodes: []v1alpha1.NodeSpec{{Role: v1alpha1.NodeRoleWorker, GPUs: v1alpha1.GPUsAll}},
+			mutate: func(cluster *v1alpha1.ClusterSpec) {
+				cluster.Distribution = v1alpha1.DistributionTalos
+			},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name:  "VCluster is rejected",
+			nodes: []v1alpha1.NodeSpec{{Role: v1alpha1.NodeRoleWorker}},
+			mutate: func(cluster *v1alpha1.ClusterSpec) {
+				cluster.Distribution = v1alpha1.DistributionVCluster
+			},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+		{
+			name:  "Hetzner is rejected",
+			nodes: []v1alpha1.NodeSpec{{Role: v1alpha1.NodeRoleWorker}},
+			mutate: func(cluster *v1alpha1.ClusterSpec) {
+				cluster.Provider = v1alpha1.ProviderHetzner
+			},
+			wantErr: v1alpha1.ErrInvalidNodeSpec,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := &v1alpha1.ClusterSpec{
+				Distribution:  v1alpha1.DistributionK3s,
+				Provider:      v1alpha1.ProviderDocker,
+				ControlPlanes: 1,
+				Workers:       2,
+				Nodes:         testCase.nodes,
+			}
+			if testCase.mutate != nil {
+				testCase.mutate(cluster)
+			}
+
+			err := v1alpha1.ValidateNodes(cluster)
+
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodeSettingsFor(t *testing.T) {
+	t.Parallel()
+
+	first := int32(0)
+	cluster := v1alpha1.ClusterSpec{
+		Workers: 2,
+		Nodes: []v1alpha1.NodeSpec{
+			{
+				Role:      v1alpha1.NodeRoleWorker,
+				Resources: v1alpha1.NodeResources{CPU: "1", Memory: "1Gi"},
+				Labels:    map[string]string{"tier": "general"},
+				Taints: []v1alpha1.NodePoolTaint{
+					{Key: "dedicated", Value: "shared", Effect: v1alpha1.TaintEffectNoSchedule},
+				},
+			},
+			{
+				Role:      v1alpha1.NodeRoleWorker,
+				Index:     &first,
+				Resources: v1alpha1.NodeResources{Memory: "4Gi"},
+				Labels:    map[string]string{"tier": "memory"},
+				Taints: []v1alpha1.NodePoolTaint{
+					{Key: "dedicated", Value: "memory", Effect: v1alpha1.TaintEffectNoSchedule},
+				},
+			},
+		},
+	}
+
+	firstWorker := cluster.NodeSettingsFor(v1alpha1.NodeRoleWorker, 0)
+	assert.Equal(t, v1alpha1.NodeResources{CPU: "1", Memory: "4Gi"}, firstWorker.Resources)
+	assert.Equal(t, map[string]string{"tier": "memory"}, firstWorker.Labels)
+	assert.Equal(t, []v1alpha1.NodePoolTaint{
+		{Key: "dedicated", Value: "memory", Effect: v1alpha1.TaintEffectNoSchedule},
+	}, firstWorker.Taints)
+
+	secondWorker := cluster.NodeSettingsFor(v1alpha1.NodeRoleWorker, 1)
+	assert.Equal(t, v1alpha1.NodeResources{CPU: "1", Memory: "1Gi"}, secondWorker.Resources)
+	assert.Equal(t, map[string]string{"tier": "general"}, secondWorker.Labels)
+
+	assert.True(t, cluster.NodeSettingsFor(v1alpha1.NodeRoleControlPlane, 0).IsZero())
+
+	nanoCPUs, err := firstWorker.Resources.NanoCPUs()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000_000), nanoCPUs)
+
+	memory, err := firstWorker.Resources.MemoryBytes()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4<<30), memory)
+}
+
+func TestGPUDevices(t *testing.T) {
+	t.Parallel()
+
+	devices, err := v1alpha1.GPUDevices("")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+
+	devices, err = v1alpha1.GPUDevices(v1alpha1.GPUsAll)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all"}, devices)
+
+	devices, err = v1alpha1.GPUDevices("3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "2"}, devices)
+
+	_, err = v1alpha1.GPUDevices("-1")
+	require.ErrorIs(t, err, v1alpha1.ErrInvalidNodeSpec)
+
+	_, err = v1alpha1.GPUDevices("some")
+	require.ErrorIs(t, err, v1alpha1.ErrInvalidNodeSpec)
+}
+
+func TestRequestsGPUs(t *testing.T) {
+	t.Parallel()
+
+	cluster := v1alpha1.ClusterSpec{Nodes: []v1alpha1.NodeSpec{{Role: v1alpha1.NodeRoleWorker}}}
+	assert.False(t, cluster.RequestsGPUs())
+
+	cluster.Nodes = append(cluster.Nodes, v1alpha1.NodeSpec{Role: v1alpha1.NodeRoleWorker, GPUs: "1"})
+	assert.True(t, cluster.RequestsGPUs())
+	settings := cluster.NodeSettingsFor(v1alpha1.NodeRoleWorker, 0)
+	assert.Equal(t, "1", settings.GPUs)
+	assert.Equal(t, map[string]string{v1alpha1.GPUNodeLabel: "true"}, settings.Labels)
+}
+
+func TestValidateVulnerabilityPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		engine v1alpha1.PolicyEngine
+		policy v1alpha1.VulnerabilityPolicy
+		valid  bool
+	}{
+		{name: "unset policy is valid without Kyverno", policy: v1alpha1.VulnerabilityPolicy{}, valid: true},
+		{
+			name:   "None ignores the allowlist",
+			policy: v1alpha1.VulnerabilityPolicy{Severity: v1alpha1.VulnerabilitySeverityNone, Allowlist: []string{"?"}},
+			valid:  true,
+		},
+		{
+			name:   "High with Kyverno and allowlist is valid",
+			engine: v1alpha1.PolicyEngineKyverno,
+			policy: v1alpha1.VulnerabilityPolicy{
+				Severity:  v1alpha1.VulnerabilitySeverityHigh,
+				Allowlist: []string{"CVE-2024-3094", "GHSA-jfh8-c2jp-5v3q"},
+			},
+			valid: true,
+		},
+		{
+			name:   "Gatekeeper is rejected",
+			engine: v1alpha1.PolicyEngineGatekeeper,
+			policy: v1alpha1.VulnerabilityPolicy{Severity: v1alpha1.VulnerabilitySeverityCritical},
+		},
+		{
+			name:   "malformed allowlist ID is rejected",
+			engine: v1alpha1.PolicyEngineKyverno,
+			policy: v1alpha1.VulnerabilityPolicy{
+				Severity:  v1alpha1.VulnerabilitySeverityLow,
+				Allowlist: []string{"CVE 2024 3094"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateVulnerabilityPolicy(&v1alpha1.ClusterSpec{
+				PolicyEngine:        test.engine,
+				VulnerabilityPolicy: test.policy,
+			})
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, v1alpha1.ErrInvalidVulnerabilityPolicy)
+			}
+		})
+	}
+}
+
+func TestVulnerabilitySeverity_Blocked(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, v1alpha1.VulnerabilitySeverity("").Blocked())
+	assert.Empty(t, v1alpha1.VulnerabilitySeverityNone.Blocked())
+	assert.Equal(t,
+		[]v1alpha1.VulnerabilitySeverity{v1alpha1.VulnerabilitySeverityHigh, v1alpha1.VulnerabilitySeverityCritical},
+		v1alpha1.VulnerabilitySeverityHigh.Blocked(),
+	)
+}
+
+func TestValidateCloudCredentials(t *testing.T) {
+	t.Parallel()
+
+	const roleARN = "arn:aws:iam::123456789012:role/ksail"
+
+	tests := []struct {
+		name     string
+		provider v1alpha1.ProviderSpec
+		wantErr  error
+	}{
+		{name: "unset credentials are valid", provider: v1alpha1.ProviderSpec{}},
+		{
+			name: "assumed role with duration is valid",
+			provider: v1alpha1.ProviderSpec{AWS: v1alpha1.OptionsAWS{
+				AssumeRoleARN:      "arn:aws-us-gov:iam::123456789012:role/ops/ksail",
+				AssumeRoleDuration: metav1.Duration{Duration: 2 * time.Hour},
+			}},
+		},
+		{
+			name: "duration without role is rejected",
+			provider: v1alpha1.ProviderSpec{AWS: v1alpha1.OptionsAWS{
+				AssumeRoleDuration: metav1.Duration{Duration: time.Hour},
+			}},
+			wantErr: v1alpha1.ErrInvalidAssumeRole,
+		},
+		{
+			name:     "user ARN is rejected",
+			provider: v1alpha1.ProviderSpec{AWS: v1alpha1.OptionsAWS{AssumeRoleARN: "arn:aws:iam::123456789012:user/ksail"}},
+			wantErr:  v1alpha1.ErrInvalidAssumeRole,
+		},
+		{
+			name: "duration below the STS minimum is rejected",
+			provider: v1alpha1.ProviderSpec{AWS: v1alpha1.OptionsAWS{
+				AssumeRoleARN:      roleARN,
+				AssumeRoleDuration: metav1.Duration{Duration: 5 * time.Minute},
+			}},
+			wantErr: v1alpha1.ErrInvalidAssumeRole,
+		},
+		{
+			name: "token command with interval is valid",
+			provider: v1alpha1.ProviderSpec{Hetzner: v1alpha1.OptionsHetzner{
+				TokenCommand:         []string{"vault", "read", "-field=token", "secret/hcloud"},
+				TokenRefreshInterval: metav1.Duration{Duration: 5 * time.Minute},
+			}},
+		},
+		{
+			name: "interval without command is rejected",
+			provider: v1alpha1.ProviderSpec{Hetzner: v1alpha1.OptionsHetzner{
+				TokenRefreshInterval: metav1.Duration{Duration: time.Minute},
+			}},
+			wantErr: v1alpha1.ErrInvalidTokenCommand,
+		},
+		{
+			name:     "blank program is rejected",
+			provider: v1alpha1.ProviderSpec{Hetzner: v1alpha1.OptionsHetzner{TokenCommand: []string{" ", "token"}}},
+			wantErr:  v1alpha1.ErrInvalidTokenCommand,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateCloudCredentials(&test.provider)
+			if test.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCloudCredentials_EffectiveDefaults(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, v1alpha1.DefaultAWSAssumeRoleDuration, v1alpha1.OptionsAWS{}.EffectiveAssumeRoleDuration())
+	assert.Equal(t, 3*time.Hour, v1alpha1.OptionsAWS{
+		AssumeRoleDuration: metav1.Duration{Duration: 3 * time.Hour},
+	}.EffectiveAssumeRoleDuration())
+	assert.Equal(t, v1alpha1.DefaultHetznerTokenRefreshInterval, v1alpha1.OptionsHetzner{}.EffectiveTokenRefreshInterval())
+}
+
+func TestValidateSeed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		seed    v1alpha1.SeedSpec
+		wantErr bool
+	}{
+		{name: "empty seed is valid"},
+		{
+			name: "ConfigMap and Secret with the same name are valid",
+			seed: v1alpha1.SeedSpec{
+				ConfigMaps: []v1alpha1.SeedResource{{Name: "app", Data: map[string]string{"LOG_LEVEL": "debug"}}},
+				Secrets:    []v1alpha1.SeedResource{{Name: "app", Files: map[string]string{"token": "token.sops.yaml"}}},
+			},
+		},
+		{
+			name: "invalid name is rejected",
+			seed: v1alpha1.SeedSpec{
+				ConfigMaps: []v1alpha1.SeedResource{{Name: "App_Config", Data: map[string]string{"a": "b"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid namespace is rejected",
+			seed: v1alpha1.SeedSpec{
+				Secrets: []v1alpha1.SeedResource{
+					{Name: "app", Namespace: "my.namespace", Data: map[string]string{"a": "b"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate resource in the default namespace is rejected",
+			seed: v1alpha1.SeedSpec{
+				ConfigMaps: []v1alpha1.SeedResource{
+					{Name: "app", Data: map[string]string{"a": "b"}},
+					{Name: "app", Namespace: v1alpha1.DefaultSeedNamespace, Data: map[string]string{"c": "d"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "resource without data is rejected",
+			seed:    v1alpha1.SeedSpec{ConfigMaps: []v1alpha1.SeedResource{{Name: "app"}}},
+			wantErr: true,
+		},
+		{
+			name: "invalid key is rejected",
+			seed: v1alpha1.SeedSpec{
+				ConfigMaps: []v1alpha1.SeedResource{{Name: "app", Data: map[string]string{"a key": "b"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "key in both data and files is rejected",
+			seed: v1alpha1.SeedSpec{
+				Secrets: []v1alpha1.SeedResource{{
+					Name:  "app",
+					Data:  map[string]string{"token": "x"},
+					Files: map[string]string{"token": "token.txt"},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty file path is rejected",
+			seed: v1alpha1.SeedSpec{
+				Secrets: []v1alpha1.SeedResource{{Name: "app", Files: map[string]string{"token": ""}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v1alpha1.ValidateSeed(test.seed)
+			if test.wantErr {
+				require.ErrorIs(t, err, v1alpha1.ErrInvalidSeed)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
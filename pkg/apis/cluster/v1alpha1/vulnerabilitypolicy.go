@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// VulnerabilitySeverity is the lowest vulnerability severity that blocks an image at admission.
+type VulnerabilitySeverity string
+
+const (
+	// VulnerabilitySeverityNone scaffolds no vulnerability admission policy.
+	VulnerabilitySeverityNone VulnerabilitySeverity = "None"
+	// VulnerabilitySeverityLow blocks images with Low or more severe vulnerabilities.
+	VulnerabilitySeverityLow VulnerabilitySeverity = "Low"
+	// VulnerabilitySeverityMedium blocks images with Medium or more severe vulnerabilities.
+	VulnerabilitySeverityMedium VulnerabilitySeverity = "Medium"
+	// VulnerabilitySeverityHigh blocks images with High or Critical vulnerabilities.
+	VulnerabilitySeverityHigh VulnerabilitySeverity = "High"
+	// VulnerabilitySeverityCritical blocks images with Critical vulnerabilities.
+	VulnerabilitySeverityCritical VulnerabilitySeverity = "Critical"
+)
+
+// ValidVulnerabilitySeverities returns supported vulnerability severity values,
+// from least to most severe after None.
+func ValidVulnerabilitySeverities() []VulnerabilitySeverity {
+	return []VulnerabilitySeverity{
+		VulnerabilitySeverityNone,
+		VulnerabilitySeverityLow,
+		VulnerabilitySeverityMedium,
+		VulnerabilitySeverityHigh,
+		VulnerabilitySeverityCritical,
+	}
+}
+
+// Set for VulnerabilitySeverity (pflag.Value interface).
+func (s *VulnerabilitySeverity) Set(value string) error {
+	return setEnum(s, value, ValidVulnerabilitySeverities(), ErrInvalidVulnerabilitySeverity)
+}
+
+// String returns the string representation of the VulnerabilitySeverity.
+func (s *VulnerabilitySeverity) String() string {
+	return string(*s)
+}
+
+// Type returns the type of the VulnerabilitySeverity.
+func (s *VulnerabilitySeverity) Type() string {
+	return "VulnerabilitySeverity"
+}
+
+// Default returns the default value for VulnerabilitySeverity (None).
+func (s *VulnerabilitySeverity) Default() any {
+	return VulnerabilitySeverityNone
+}
+
+// ValidValues returns all valid VulnerabilitySeverity values as strings.
+func (s *VulnerabilitySeverity) ValidValues() []string {
+	return validValueStrings(ValidVulnerabilitySeverities())
+}
+
+// Blocked returns the severities that block an image under this threshold: the
+// threshold itself and every more severe one. It is empty for None and unset.
+func (s VulnerabilitySeverity) Blocked() []VulnerabilitySeverity {
+	severities := ValidVulnerabilitySeverities()
+
+	index := slices.Index(severities, s)
+	if index <= 0 {
+		return nil
+	}
+
+	return severities[index:]
+}
+
+// VulnerabilityPolicy configures the admission policy that blocks images whose
+// vulnerability scan reports findings at or above a severity threshold.
+type VulnerabilityPolicy struct {
+	// Severity is the lowest severity that blocks an image: None (default, no
+	// policy), Low, Medium, High, or Critical. Requires policyEngine: Kyverno.
+	Severity VulnerabilitySeverity `json:"severity,omitzero"`
+	// Allowlist lists vulnerability IDs (e.g. CVE-2024-3094 or GHSA-xxxx-xxxx-xxxx)
+	// that never block an image, for findings that are accepted or not exploitable.
+	Allowlist []string `json:"allowlist,omitempty"`
+	// Images lists the image reference patterns the policy applies to, such as
+	// "ghcr.io/my-org/*". Defaults to every image.
+	Images []string `json:"images,omitempty"`
+}
+
+// EffectiveImages returns Images, or a wildcard matching every image when unset.
+func (p VulnerabilityPolicy) EffectiveImages() []string {
+	if len(p.Images) == 0 {
+		return []string{"*"}
+	}
+
+	return p.Images
+}
+
+// Enabled reports whether the policy blocks images at any severity.
+func (p VulnerabilityPolicy) Enabled() bool {
+	return len(p.Severity.Blocked()) > 0
+}
+
+// vulnerabilityIDPattern matches scanner vulnerability IDs such as CVE-2024-3094,
+// GHSA-jfh8-c2jp-5v3q, or DLA-3366-1.
+var vulnerabilityIDPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*-[A-Za-z0-9._:-]+$`)
+
+// ValidateVulnerabilityPolicy checks that an enabled vulnerability policy runs on
+// Kyverno and that every allowlisted ID is well-formed, so a typo fails at config
+// load rather than silently leaving a finding blocked.
+func ValidateVulnerabilityPolicy(cluster *ClusterSpec) error {
+	if cluster == nil || !cluster.VulnerabilityPolicy.Enabled() {
+		return nil
+	}
+
+	if cluster.PolicyEngine != PolicyEngineKyverno {
+		return fmt.Errorf(
+			"%w: severity %s requires policyEngine Kyverno, got %q",
+			ErrInvalidVulnerabilityPolicy, cluster.VulnerabilityPolicy.Severity, cluster.PolicyEngine,
+		)
+	}
+
+	for _, id := range cluster.VulnerabilityPolicy.Allowlist {
+		if !vulnerabilityIDPattern.MatchString(id) {
+			return fmt.Errorf("%w: invalid allowlist ID %q", ErrInvalidVulnerabilityPolicy, id)
+		}
+	}
+
+	return nil
+}
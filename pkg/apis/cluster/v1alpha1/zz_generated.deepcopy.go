@@ -12,6 +12,142 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionsRunnerSpec) DeepCopyInto(out *ActionsRunnerSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionsRunnerSpec.
+func (in *ActionsRunnerSpec) DeepCopy() *ActionsRunnerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionsRunnerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSet.
+func (in *ArgoCDApplicationSet) DeepCopy() *ArgoCDApplicationSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDConfig) DeepCopyInto(out *ArgoCDConfig) {
+	*out = *in
+	in.Project.DeepCopyInto(&out.Project)
+	in.ApplicationSet.DeepCopyInto(&out.ApplicationSet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDConfig.
+func (in *ArgoCDConfig) DeepCopy() *ArgoCDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDestination) DeepCopyInto(out *ArgoCDDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDestination.
+func (in *ArgoCDDestination) DeepCopy() *ArgoCDDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDProject) DeepCopyInto(out *ArgoCDProject) {
+	*out = *in
+	if in.SourceRepos != nil {
+		in, out := &in.SourceRepos, &out.SourceRepos
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]ArgoCDDestination, len(*in))
+		copy(*out, *in)
+	}
+	if in.SyncWindows != nil {
+		in, out := &in.SyncWindows, &out.SyncWindows
+		*out = make([]ArgoCDSyncWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDProject.
+func (in *ArgoCDProject) DeepCopy() *ArgoCDProject {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDProject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSyncWindow) DeepCopyInto(out *ArgoCDSyncWindow) {
+	*out = *in
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSyncWindow.
+func (in *ArgoCDSyncWindow) DeepCopy() *ArgoCDSyncWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSyncWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalerConfig) DeepCopyInto(out *AutoscalerConfig) {
 	*out = *in
@@ -126,13 +262,30 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
 	out.Connection = in.Connection
+	out.Cilium = in.Cilium
+	out.Network = in.Network
+	in.VulnerabilityPolicy.DeepCopyInto(&out.VulnerabilityPolicy)
+	out.Istio = in.Istio
+	out.Observability = in.Observability
 	out.LocalRegistry = in.LocalRegistry
+	in.RegistryBinding.DeepCopyInto(&out.RegistryBinding)
 	in.SOPS.DeepCopyInto(&out.SOPS)
 	in.Autoscaler.DeepCopyInto(&out.Autoscaler)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Spot = in.Spot
 	in.OIDC.DeepCopyInto(&out.OIDC)
+	in.ResourceMetadata.DeepCopyInto(&out.ResourceMetadata)
+	out.Tenancy = in.Tenancy
 	out.Vanilla = in.Vanilla
 	in.Talos.DeepCopyInto(&out.Talos)
 	out.EKS = in.EKS
+	in.ActionsRunner.DeepCopyInto(&out.ActionsRunner)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
@@ -466,6 +619,21 @@ func (in *GitOpsStatus) DeepCopy() *GitOpsStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSource) DeepCopyInto(out *GitSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSource.
+func (in *GitSource) DeepCopy() *GitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesPersistence) DeepCopyInto(out *KubernetesPersistence) {
 	*out = *in
@@ -485,6 +653,7 @@ func (in *KubernetesPersistence) DeepCopy() *KubernetesPersistence {
 func (in *LocalRegistry) DeepCopyInto(out *LocalRegistry) {
 	*out = *in
 	out.Credentials = in.Credentials
+	out.Retention = in.Retention
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalRegistry.
@@ -497,6 +666,21 @@ func (in *LocalRegistry) DeepCopy() *LocalRegistry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeAutoscalerConfig) DeepCopyInto(out *NodeAutoscalerConfig) {
 	*out = *in
@@ -576,6 +760,69 @@ func (in *NodePoolTaint) DeepCopy() *NodePoolTaint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeResources) DeepCopyInto(out *NodeResources) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeResources.
+func (in *NodeResources) DeepCopy() *NodeResources {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
+	*out = *in
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = new(int32)
+		**out = **in
+	}
+	out.Resources = in.Resources
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]NodePoolTaint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSpec.
+func (in *NodeSpec) DeepCopy() *NodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotifySpec) DeepCopyInto(out *NotifySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifySpec.
+func (in *NotifySpec) DeepCopy() *NotifySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotifySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
 	*out = *in
@@ -627,9 +874,25 @@ func (in *OIDCSpec) DeepCopy() *OIDCSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservabilitySpec.
+func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptionsAWS) DeepCopyInto(out *OptionsAWS) {
 	*out = *in
+	out.AssumeRoleDuration = in.AssumeRoleDuration
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionsAWS.
@@ -657,6 +920,21 @@ func (in *OptionsAzure) DeepCopy() *OptionsAzure {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionsCilium) DeepCopyInto(out *OptionsCilium) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionsCilium.
+func (in *OptionsCilium) DeepCopy() *OptionsCilium {
+	if in == nil {
+		return nil
+	}
+	out := new(OptionsCilium)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptionsEKS) DeepCopyInto(out *OptionsEKS) {
 	*out = *in
@@ -690,6 +968,12 @@ func (in *OptionsGCP) DeepCopy() *OptionsGCP {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptionsHetzner) DeepCopyInto(out *OptionsHetzner) {
 	*out = *in
+	if in.TokenCommand != nil {
+		in, out := &in.TokenCommand, &out.TokenCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.TokenRefreshInterval = in.TokenRefreshInterval
 	if in.FallbackLocations != nil {
 		in, out := &in.FallbackLocations, &out.FallbackLocations
 		*out = make([]string, len(*in))
@@ -700,6 +984,11 @@ func (in *OptionsHetzner) DeepCopyInto(out *OptionsHetzner) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodePortCIDRs != nil {
+		in, out := &in.NodePortCIDRs, &out.NodePortCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.WorkerPublicIPv4 != nil {
 		in, out := &in.WorkerPublicIPv4, &out.WorkerPublicIPv4
 		*out = new(bool)
@@ -744,6 +1033,21 @@ func (in *OptionsHetzner) DeepCopy() *OptionsHetzner {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionsIstio) DeepCopyInto(out *OptionsIstio) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionsIstio.
+func (in *OptionsIstio) DeepCopy() *OptionsIstio {
+	if in == nil {
+		return nil
+	}
+	out := new(OptionsIstio)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptionsKubernetes) DeepCopyInto(out *OptionsKubernetes) {
 	*out = *in
@@ -795,6 +1099,7 @@ func (in *OptionsTalos) DeepCopyInto(out *OptionsTalos) {
 	}
 	out.DrainTimeout = in.DrainTimeout
 	out.StorageHealthTimeout = in.StorageHealthTimeout
+	out.API = in.API
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionsTalos.
@@ -888,6 +1193,28 @@ func (in *ProviderSpec) DeepCopy() *ProviderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryBinding) DeepCopyInto(out *RegistryBinding) {
+	*out = *in
+	if in.MirrorPorts != nil {
+		in, out := &in.MirrorPorts, &out.MirrorPorts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryBinding.
+func (in *RegistryBinding) DeepCopy() *RegistryBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryCredentials) DeepCopyInto(out *RegistryCredentials) {
 	*out = *in
@@ -903,6 +1230,51 @@ func (in *RegistryCredentials) DeepCopy() *RegistryCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryRetention) DeepCopyInto(out *RegistryRetention) {
+	*out = *in
+	out.MaxAge = in.MaxAge
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryRetention.
+func (in *RegistryRetention) DeepCopy() *RegistryRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMetadata) DeepCopyInto(out *ResourceMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMetadata.
+func (in *ResourceMetadata) DeepCopy() *ResourceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SOPS) DeepCopyInto(out *SOPS) {
 	*out = *in
@@ -995,13 +1367,74 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedResource) DeepCopyInto(out *SeedResource) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedResource.
+func (in *SeedResource) DeepCopy() *SeedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]SeedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SeedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedSpec.
+func (in *SeedSpec) DeepCopy() *SeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Spec) DeepCopyInto(out *Spec) {
 	*out = *in
 	in.Cluster.DeepCopyInto(&out.Cluster)
 	in.Provider.DeepCopyInto(&out.Provider)
 	in.Workload.DeepCopyInto(&out.Workload)
+	in.Trust.DeepCopyInto(&out.Trust)
+	in.Seed.DeepCopyInto(&out.Seed)
 	out.Chat = in.Chat
+	out.Notify = in.Notify
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Spec.
@@ -1014,6 +1447,95 @@ func (in *Spec) DeepCopy() *Spec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotSpec) DeepCopyInto(out *SpotSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotSpec.
+func (in *SpotSpec) DeepCopy() *SpotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubstitutionConfig) DeepCopyInto(out *SubstitutionConfig) {
+	*out = *in
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubstitutionConfig.
+func (in *SubstitutionConfig) DeepCopy() *SubstitutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SubstitutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TalosAPIOptions) DeepCopyInto(out *TalosAPIOptions) {
+	*out = *in
+	out.CallTimeout = in.CallTimeout
+	out.HealthCheckGracePeriod = in.HealthCheckGracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TalosAPIOptions.
+func (in *TalosAPIOptions) DeepCopy() *TalosAPIOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TalosAPIOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tenancy) DeepCopyInto(out *Tenancy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tenancy.
+func (in *Tenancy) DeepCopy() *Tenancy {
+	if in == nil {
+		return nil
+	}
+	out := new(Tenancy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustSpec) DeepCopyInto(out *TrustSpec) {
+	*out = *in
+	if in.CABundles != nil {
+		in, out := &in.CABundles, &out.CABundles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustSpec.
+func (in *TrustSpec) DeepCopy() *TrustSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidationConfig) DeepCopyInto(out *ValidationConfig) {
 	*out = *in
@@ -1044,6 +1566,31 @@ func (in *ValidationConfig) DeepCopy() *ValidationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityPolicy) DeepCopyInto(out *VulnerabilityPolicy) {
+	*out = *in
+	if in.Allowlist != nil {
+		in, out := &in.Allowlist, &out.Allowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityPolicy.
+func (in *VulnerabilityPolicy) DeepCopy() *VulnerabilityPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WatchConfig) DeepCopyInto(out *WatchConfig) {
 	*out = *in
@@ -1068,9 +1615,12 @@ func (in *WatchConfig) DeepCopy() *WatchConfig {
 func (in *WorkloadSpec) DeepCopyInto(out *WorkloadSpec) {
 	*out = *in
 	in.Flux.DeepCopyInto(&out.Flux)
+	in.ArgoCD.DeepCopyInto(&out.ArgoCD)
 	in.Watch.DeepCopyInto(&out.Watch)
 	in.Validation.DeepCopyInto(&out.Validation)
 	in.Scan.DeepCopyInto(&out.Scan)
+	out.Git = in.Git
+	in.Substitution.DeepCopyInto(&out.Substitution)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpec.
@@ -0,0 +1,120 @@
+package clusterapi
+
+import (
+	"context"
+	"fmt"
+
+	specdiff "github.com/devantler-tech/ksail/v7/pkg/svc/diff"
+	"github.com/devantler-tech/ksail/v7/pkg/webui/api"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// Ensure the local backend can diff manifests.
+var _ api.DiffService = (*Service)(nil)
+
+const (
+	diffStatusCreated   = "created"
+	diffStatusChanged   = "changed"
+	diffStatusUnchanged = "unchanged"
+)
+
+// diffStatuses maps each previewed object action to the DiffResult status reported to the UI.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var diffStatuses = map[specdiff.ObjectAction]string{
+	specdiff.ObjectCreate:    diffStatusCreated,
+	specdiff.ObjectUpdate:    diffStatusChanged,
+	specdiff.ObjectUnchanged: diffStatusUnchanged,
+}
+
+// DiffManifests previews each document in the supplied multi-document YAML against the named
+// cluster: the live object is compared with the result of a dry-run server-side apply (see
+// specdiff.PreviewApply), so defaulting, admission, and field ownership are reflected exactly as a
+// real apply would. Nothing is persisted.
+// A per-document failure is recorded (not fatal), like ApplyManifests.
+func (s *Service) DiffManifests(
+	ctx context.Context,
+	_, name string,
+	manifests []byte,
+) ([]api.DiffResult, error) {
+	docs, err := splitManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%w: no manifests provided", api.ErrInvalid)
+	}
+
+	dynamicClient, mapper, err := s.newApplyClient(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]api.DiffResult, 0, len(docs))
+	for _, doc := range docs {
+		results = append(results, diffOne(ctx, dynamicClient, mapper, doc))
+	}
+
+	return results, nil
+}
+
+// diffOne parses a single manifest document, dry-run applies it, and diffs the outcome against the
+// live object.
+func diffOne(
+	ctx context.Context,
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
+	doc []byte,
+) api.DiffResult {
+	obj := &unstructured.Unstructured{}
+
+	err := yaml.Unmarshal(doc, &obj.Object)
+	if err != nil {
+		return api.DiffResult{
+			Status: applyStatusError,
+			Error:  fmt.Sprintf("parse manifest: %v", err),
+		}
+	}
+
+	gvk := obj.GroupVersionKind()
+	target := api.ApplyResult{Kind: gvk.Kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}
+
+	if gvk.Kind == "" || obj.GetName() == "" {
+		return diffError(target, "manifest missing apiVersion/kind or metadata.name")
+	}
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return diffError(target, fmt.Sprintf("resolve %s: %v", gvk.String(), err))
+	}
+
+	resource := resourceInterfaceFor(dynamicClient, mapping, obj, &target)
+
+	preview, err := specdiff.PreviewApply(ctx, resource, obj, applyFieldManager)
+	if err != nil {
+		return diffError(target, err.Error())
+	}
+
+	return api.DiffResult{
+		Kind:      target.Kind,
+		Name:      target.Name,
+		Namespace: target.Namespace,
+		Status:    diffStatuses[preview.Action],
+		Diff:      preview.Diff,
+	}
+}
+
+// diffError builds an error result for target.
+func diffError(target api.ApplyResult, message string) api.DiffResult {
+	return api.DiffResult{
+		Kind:      target.Kind,
+		Name:      target.Name,
+		Namespace: target.Namespace,
+		Status:    applyStatusError,
+		Error:     message,
+	}
+}
@@ -0,0 +1,117 @@
+package clusterapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/clusterapi"
+	"github.com/devantler-tech/ksail/v7/pkg/webui/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// injectFakeDiff wires a fake dynamic client holding a live ConfigMap x/cm1 (data k=old) whose
+// dry-run apply echoes the applied object back, as a real server would for a plain ConfigMap.
+func injectFakeDiff(t *testing.T, service *clusterapi.Service) {
+	t.Helper()
+
+	live := &unstructured.Unstructured{}
+	live.SetAPIVersion("v1")
+	live.SetKind("ConfigMap")
+	live.SetName("cm1")
+	live.SetNamespace("x")
+	live.SetResourceVersion("42")
+	live.Object["data"] = map[string]any{"k": "old"}
+
+	client := dynamicfake.NewSimpleDynamicClient(clientgoscheme.Scheme, live)
+
+	client.PrependReactor(
+		"patch",
+		"configmaps",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patch, _ := action.(k8stesting.PatchAction)
+			obj := &unstructured.Unstructured{}
+
+			err := json.Unmarshal(patch.GetPatch(), &obj.Object)
+			if err != nil {
+				return true, nil, err
+			}
+
+			obj.SetNamespace(patch.GetNamespace())
+
+			return true, obj, nil
+		},
+	)
+
+	mapper := applyTestMapper()
+
+	service.SetApplyClientForTest(
+		func(_ context.Context, _ string) (dynamic.Interface, meta.RESTMapper, error) {
+			return client, mapper, nil
+		},
+	)
+}
+
+func TestDiffManifestsReportsChangedCreatedAndUnchanged(t *testing.T) {
+	t.Parallel()
+
+	service := clusterapi.NewTestService(nil)
+	injectFakeDiff(t, service)
+
+	manifest := []byte(
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\n  namespace: x\ndata:\n  k: new\n" +
+			"---\n" +
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm2\n  namespace: x\ndata:\n  k: v\n" +
+			"---\n" +
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\n  namespace: x\ndata:\n  k: old\n",
+	)
+
+	results, err := service.DiffManifests(context.Background(), "default", "c1", manifest)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "changed", results[0].Status)
+	assert.Contains(t, results[0].Diff, "--- live/ConfigMap/x/cm1")
+	assert.Contains(t, results[0].Diff, "-  k: old")
+	assert.Contains(t, results[0].Diff, "+  k: new")
+	assert.NotContains(t, results[0].Diff, "resourceVersion")
+
+	assert.Equal(t, "created", results[1].Status)
+	assert.Contains(t, results[1].Diff, "+  name: cm2")
+
+	assert.Equal(t, "unchanged", results[2].Status)
+	assert.Empty(t, results[2].Diff)
+}
+
+func TestDiffManifestsRecordsPerDocumentErrors(t *testing.T) {
+	t.Parallel()
+
+	service := clusterapi.NewTestService(nil)
+	injectFakeDiff(t, service)
+
+	manifest := []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: s1\n")
+
+	results, err := service.DiffManifests(context.Background(), "default", "c1", manifest)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "error", results[0].Status)
+	assert.Contains(t, results[0].Error, "resolve")
+}
+
+func TestDiffManifestsRejectsEmptyInput(t *testing.T) {
+	t.Parallel()
+
+	service := clusterapi.NewTestService(nil)
+	injectFakeDiff(t, service)
+
+	_, err := service.DiffManifests(context.Background(), "default", "c1", []byte("  \n"))
+	require.ErrorIs(t, err, api.ErrInvalid)
+}
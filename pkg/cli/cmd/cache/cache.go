@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/spf13/cobra"
+)
+
+// NewCacheCmd creates the parent cache command and wires subcommands beneath it.
+func NewCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage data KSail stores locally",
+		Long: `Inspect and clean the data KSail stores on the local machine: downloaded ` +
+			`schemas, the offline schema store, session transcripts, chat sessions, ` +
+			`fetched base configurations, OIDC tokens, and cluster state.`,
+		Args:         cobra.NoArgs,
+		RunE:         handleCacheRunE,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationConsolidate: "cache_command",
+		},
+	}
+
+	cmd.AddCommand(NewInfoCmd())
+	cmd.AddCommand(NewCleanCmd())
+
+	return cmd
+}
+
+//nolint:gochecknoglobals // Injected for testability to simulate help failures.
+var helpRunner = func(cmd *cobra.Command) error {
+	return cmd.Help()
+}
+
+func handleCacheRunE(cmd *cobra.Command, _ []string) error {
+	err := helpRunner(cmd)
+	if err != nil {
+		return fmt.Errorf("displaying cache command help: %w", err)
+	}
+
+	return nil
+}
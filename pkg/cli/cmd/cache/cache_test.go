@@ -0,0 +1,43 @@
+package cache_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	snapshottest "github.com/devantler-tech/ksail/v7/internal/testutil/snapshottest"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	cachecmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cache"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(snapshottest.Run(m, snaps.CleanOpts{Sort: true}))
+}
+
+func TestCacheCmd_ShowsHelp(t *testing.T) {
+	t.Parallel()
+
+	cmd := cachecmd.NewCacheCmd()
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Contains(t, output, "clean")
+	require.Contains(t, output, "info")
+
+	snaps.MatchSnapshot(t, output)
+}
+
+func TestCacheCmd_HasConsolidateAnnotation(t *testing.T) {
+	t.Parallel()
+
+	cmd := cachecmd.NewCacheCmd()
+	require.Equal(t, "cache_command", cmd.Annotations[annotations.AnnotationConsolidate])
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/ui/confirm"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/strutil"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cache"
+	"github.com/spf13/cobra"
+)
+
+const cleanLongDesc = `Remove locally stored data.
+
+By default every unprotected category is garbage-collected by its retention
+policy (see 'ksail cache info'): expired entries are removed, then the least
+recently modified ones until the category fits its size limit. Use --all to
+empty the categories instead.
+
+Protected categories (schema-store, oidc, state) are only cleaned when named
+with --category.
+Removing cluster state makes the next 'ksail cluster update' of a Kind or K3d
+cluster fall back to the state kept in the cluster; removing OIDC tokens
+requires logging in again; removing the schema store breaks offline validation
+until 'ksail workload validate --update-schemas' refills it. All ask for confirmation unless --force is set.`
+
+const cleanExample = `  # Garbage-collect expired and over-size data
+  ksail cache clean
+
+  # Empty the schema cache
+  ksail cache clean --category schemas --all
+
+  # Preview what emptying every unprotected category would remove
+  ksail cache clean --all --dry-run`
+
+// NewCleanCmd creates the cache clean command.
+func NewCleanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "clean",
+		Short:        "Remove locally stored data",
+		Long:         cleanLongDesc,
+		Example:      cleanExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+	}
+
+	cmd.Flags().StringSlice("category", nil,
+		"Categories to clean: "+strings.Join(categoryNames(), ", ")+
+			" (default: every unprotected category)")
+	cmd.Flags().Bool("all", false, "Remove every entry instead of only expired and over-size ones")
+	cmd.Flags().Bool("dry-run", false, "Show what would be removed without removing it")
+	cmd.Flags().BoolP("force", "f", false, "Skip the confirmation prompt for protected categories")
+	_ = cmd.Flags().SetAnnotation(
+		"force", annotations.AnnotationConfirmFlag,
+		[]string{annotations.AnnotationValueTrue},
+	)
+
+	cmd.RunE = handleCleanRunE
+
+	return cmd
+}
+
+func handleCleanRunE(cmd *cobra.Command, _ []string) error {
+	names, _ := cmd.Flags().GetStringSlice("category")
+	all, _ := cmd.Flags().GetBool("all")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	categories, err := cache.DefaultCategories()
+	if err != nil {
+		return fmt.Errorf("resolve cache categories: %w", err)
+	}
+
+	selected, err := cache.SelectCategories(categories, names)
+	if err != nil {
+		return fmt.Errorf("select cache categories: %w", err)
+	}
+
+	protected := protectedNames(selected)
+	if len(protected) > 0 && !dryRun && !confirm.ShouldSkipPrompt(force) {
+		notify.Warningf(cmd.OutOrStdout(),
+			"This will remove protected data: %s", strings.Join(protected, ", "))
+
+		_, _ = fmt.Fprint(cmd.OutOrStdout(), `Type "yes" to confirm deletion: `)
+
+		if !confirm.PromptForConfirmation(cmd.OutOrStdout()) {
+			return confirm.ErrDeletionCancelled
+		}
+	}
+
+	results, err := cache.Clean(selected, cache.CleanOptions{
+		All:    all,
+		DryRun: dryRun,
+		Now:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("clean cache: %w", err)
+	}
+
+	writeCleanResults(cmd.OutOrStdout(), results, dryRun)
+
+	return nil
+}
+
+// writeCleanResults reports what was (or, on a dry run, would be) removed.
+func writeCleanResults(writer io.Writer, results []cache.CleanResult, dryRun bool) {
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+
+	var (
+		removed int
+		freed   int64
+	)
+
+	for _, result := range results {
+		removed += result.Removed
+		freed += result.Freed
+
+		if result.Removed > 0 {
+			_, _ = fmt.Fprintf(writer, "%s %d %s from %s (%s)\n",
+				verb, result.Removed, entriesNoun(result.Removed),
+				result.Category.Name, strutil.FormatBytes(result.Freed))
+		}
+	}
+
+	if removed == 0 {
+		notify.Infof(writer, "Nothing to clean")
+
+		return
+	}
+
+	if dryRun {
+		notify.Infof(writer, "%s %d %s, freeing %s", verb, removed, entriesNoun(removed), strutil.FormatBytes(freed))
+
+		return
+	}
+
+	notify.Successf(writer, "%s %d %s, freed %s", verb, removed, entriesNoun(removed), strutil.FormatBytes(freed))
+}
+
+// entriesNoun returns "entry" or "entries" for count.
+func entriesNoun(count int) string {
+	if count == 1 {
+		return "entry"
+	}
+
+	return "entries"
+}
+
+// protectedNames returns the names of the protected categories in categories.
+func protectedNames(categories []cache.Category) []string {
+	names := make([]string, 0, len(categories))
+
+	for _, category := range categories {
+		if category.Protected {
+			names = append(names, category.Name)
+		}
+	}
+
+	return names
+}
+
+// categoryNames lists every category name for the --category help text.
+func categoryNames() []string {
+	return []string{
+		cache.CategorySchemas,
+		cache.CategorySessions,
+		cache.CategoryChat,
+		cache.CategoryOIDC,
+		cache.CategoryState,
+	}
+}
@@ -0,0 +1,75 @@
+package cache_test
+
+import (
+	"bytes"
+	"testing"
+
+	cachecmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cache"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runClean(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := cachecmd.NewCleanCmd()
+	cmd.SetArgs(args)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	err := cmd.Execute()
+
+	return buf.String(), err
+}
+
+//nolint:paralleltest // Overrides HOME.
+func TestCleanCmd_AllSkipsProtectedByDefault(t *testing.T) {
+	home := useTempHome(t)
+	schema := writeFile(t, home, ".cache/ksail/kubeconform/deployment.json", 2048)
+	spec := writeFile(t, home, ".ksail/clusters/dev/spec.json", 100)
+
+	output, err := runClean(t, "--all")
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "Removed 1 entry from schemas (2 KiB)")
+	assert.NoFileExists(t, schema)
+	assert.FileExists(t, spec, "cluster state is protected")
+}
+
+//nolint:paralleltest // Overrides HOME.
+func TestCleanCmd_NamedProtectedCategory(t *testing.T) {
+	home := useTempHome(t)
+	spec := writeFile(t, home, ".ksail/clusters/dev/spec.json", 100)
+
+	output, err := runClean(t, "--category", cache.CategoryState, "--all", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Would remove 1 entry from state")
+	assert.FileExists(t, spec)
+
+	_, err = runClean(t, "--category", cache.CategoryState, "--all", "--force")
+	require.NoError(t, err)
+	assert.NoFileExists(t, spec)
+}
+
+//nolint:paralleltest // Overrides HOME.
+func TestCleanCmd_NothingToClean(t *testing.T) {
+	home := useTempHome(t)
+	fresh := writeFile(t, home, ".ksail/sessions/fresh.log", 10)
+
+	output, err := runClean(t)
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "Nothing to clean")
+	assert.FileExists(t, fresh)
+}
+
+func TestCleanCmd_UnknownCategory(t *testing.T) {
+	t.Parallel()
+
+	_, err := runClean(t, "--category", "charts")
+
+	require.ErrorIs(t, err, cache.ErrUnknownCategory)
+}
@@ -0,0 +1,3 @@
+// Package cache provides CLI commands for inspecting and cleaning the data
+// KSail stores on the local machine.
+package cache
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/strutil"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cache"
+	"github.com/spf13/cobra"
+)
+
+const infoLongDesc = `Show how much disk space each category of locally stored data uses and the
+retention policy KSail garbage-collects it by.
+
+Unprotected categories are garbage-collected automatically at most once a day:
+entries older than the TTL are removed, then the least recently modified ones
+until the category fits its size limit. Protected categories hold data KSail
+needs (the offline schema store, cluster state, OIDC tokens) and are only
+removed by 'ksail cache clean --category <name> --all'.
+
+Helm charts live in Helm's shared cache (see 'helm env') and image tarballs
+where they were exported to; KSail does not manage either.`
+
+// NewInfoCmd creates the cache info command.
+func NewInfoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "info",
+		Short:        "Show disk usage of locally stored data",
+		Long:         infoLongDesc,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			categories, err := cache.DefaultCategories()
+			if err != nil {
+				return fmt.Errorf("resolve cache categories: %w", err)
+			}
+
+			usages, err := cache.Inspect(categories)
+			if err != nil {
+				return fmt.Errorf("inspect cache: %w", err)
+			}
+
+			writeUsages(cmd.OutOrStdout(), usages)
+
+			return nil
+		},
+	}
+}
+
+// writeUsages writes one row per category with its usage and retention policy.
+func writeUsages(writer io.Writer, usages []cache.Usage) {
+	rows := make([]string, 0, len(usages)+1)
+	rows = append(rows, "CATEGORY\tSIZE\tENTRIES\tRETENTION\tPATH")
+
+	var total int64
+
+	for _, usage := range usages {
+		total += usage.Size
+		rows = append(rows, fmt.Sprintf("%s\t%s\t%d\t%s\t%s",
+			usage.Category.Name, strutil.FormatBytes(usage.Size), usage.Entries,
+			formatRetention(usage.Category), usage.Category.Dir))
+	}
+
+	writeTable(writer, rows)
+
+	_, _ = fmt.Fprintf(writer, "\nTotal: %s\n", strutil.FormatBytes(total))
+}
+
+// formatRetention describes the retention policy of category.
+func formatRetention(category cache.Category) string {
+	if category.Protected {
+		return "protected"
+	}
+
+	parts := make([]string, 0, 2)
+
+	if category.TTL > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", int(category.TTL/(24*time.Hour))))
+	}
+
+	if category.MaxSize > 0 {
+		parts = append(parts, "max "+strutil.FormatBytes(category.MaxSize))
+	}
+
+	if len(parts) == 0 {
+		return "unlimited"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// writeTable aligns tab-separated rows into columns without trailing spaces.
+func writeTable(writer io.Writer, rows []string) {
+	var buf bytes.Buffer
+
+	table := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(table, row)
+	}
+
+	_ = table.Flush()
+
+	for line := range strings.Lines(buf.String()) {
+		_, _ = fmt.Fprintln(writer, strings.TrimRight(line, " \n"))
+	}
+}
@@ -0,0 +1,55 @@
+package cache_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cachecmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempHome points HOME and the user cache directory at a fresh directory.
+func useTempHome(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+	return home
+}
+
+// writeFile creates a file of size bytes under home.
+func writeFile(t *testing.T, home, rel string, size int) string {
+	t.Helper()
+
+	path := filepath.Join(home, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o600))
+
+	return path
+}
+
+//nolint:paralleltest // Overrides HOME.
+func TestInfoCmd_ReportsUsagePerCategory(t *testing.T) {
+	home := useTempHome(t)
+	writeFile(t, home, ".cache/ksail/kubeconform/deployment.json", 2048)
+	writeFile(t, home, ".ksail/clusters/dev/spec.json", 100)
+
+	cmd := cachecmd.NewInfoCmd()
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	require.NoError(t, cmd.Execute())
+
+	output := buf.String()
+	assert.Regexp(t, `schemas\s+2 KiB\s+1\s+30d, max 512 MiB`, output)
+	assert.Regexp(t, `state\s+100 B\s+1\s+protected`, output)
+	assert.Contains(t, output, "Total: 2.1 KiB")
+}
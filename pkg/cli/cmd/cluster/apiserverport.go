@@ -0,0 +1,53 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+)
+
+// ErrAPIServerPortInUse is returned by create when the pinned API server port
+// (spec.cluster.connection.apiServerPort) is taken by another cluster or process.
+var ErrAPIServerPortInUse = errors.New("API server port is already in use")
+
+// runAPIServerPortPreflight refuses to create a cluster whose pinned API server port is
+// pinned by another KSail cluster or already bound on localhost, so the conflict fails
+// before any container is created instead of halfway through provisioning.
+func runAPIServerPortPreflight(clusterName string, spec v1alpha1.ClusterSpec) error {
+	port := spec.Connection.APIServerPort
+	if port == 0 || !spec.Provider.NeedsLocalDocker() {
+		return nil
+	}
+
+	entries, err := state.ListClusterStates()
+	if err != nil {
+		return fmt.Errorf("list cluster states: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Name != clusterName && entry.Spec.Connection.APIServerPort == port {
+			return fmt.Errorf(
+				"%w: port %d is pinned by cluster %q (change spec.cluster.connection.apiServerPort)",
+				ErrAPIServerPortInUse, port, entry.Name,
+			)
+		}
+	}
+
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(int(port)))
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: %s is bound by another process (free it or change spec.cluster.connection.apiServerPort)",
+			ErrAPIServerPortInUse, address,
+		)
+	}
+
+	_ = listener.Close()
+
+	return nil
+}
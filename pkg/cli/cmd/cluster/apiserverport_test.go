@@ -0,0 +1,76 @@
+package cluster_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pinnedSpec returns a local Vanilla cluster spec pinning the API server to port.
+func pinnedSpec(port int32) v1alpha1.ClusterSpec {
+	return v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionVanilla,
+		Provider:     v1alpha1.ProviderDocker,
+		Connection:   v1alpha1.Connection{APIServerPort: port},
+	}
+}
+
+// freePort returns a localhost port that is free at the time of the call.
+func freePort(t *testing.T) int32 {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	port := listener.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert // tcp listener
+
+	require.NoError(t, listener.Close())
+
+	return int32(port) //nolint:gosec // TCP ports fit in int32
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestAPIServerPortPreflight_UnpinnedIsNoop(t *testing.T) {
+	require.NoError(t, cluster.ExportRunAPIServerPortPreflight("dev", pinnedSpec(0)))
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestAPIServerPortPreflight_FreePortPasses(t *testing.T) {
+	require.NoError(t, cluster.ExportRunAPIServerPortPreflight("dev", pinnedSpec(freePort(t))))
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestAPIServerPortPreflight_RefusesBoundPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	port := int32(listener.Addr().(*net.TCPAddr).Port) //nolint:forcetypeassert,gosec // tcp listener
+
+	err = cluster.ExportRunAPIServerPortPreflight("dev", pinnedSpec(port))
+
+	require.ErrorIs(t, err, cluster.ErrAPIServerPortInUse)
+	assert.Contains(t, err.Error(), "bound by another process")
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestAPIServerPortPreflight_RefusesPortPinnedByOtherCluster(t *testing.T) {
+	port := freePort(t)
+	spec := pinnedSpec(port)
+
+	require.NoError(t, state.SaveClusterSpec("other", &spec))
+	t.Cleanup(func() { _ = state.DeleteClusterState("other") })
+
+	err := cluster.ExportRunAPIServerPortPreflight("dev", spec)
+
+	require.ErrorIs(t, err, cluster.ErrAPIServerPortInUse)
+	assert.Contains(t, err.Error(), `pinned by cluster "other"`)
+
+	require.NoError(t, cluster.ExportRunAPIServerPortPreflight("other", spec))
+}
@@ -0,0 +1,373 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	kubeconfigutil "github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// auditLongDesc describes the `ksail cluster audit` command.
+const auditLongDesc = `Show the audit log of mutating KSail operations on a cluster.
+
+Every cluster create, update (including recreations and version upgrades),
+start, stop, and restore is recorded with the user and host that ran it, the
+time, and the configuration fields it changed. Entries are stored in the
+cluster itself — the ksail-audit ConfigMap in kube-system, announced by a
+KSailOperation Event — so everyone sharing the cluster sees the same history,
+and in ~/.ksail/clusters/<name>/audit.jsonl on the machine that ran the
+operation.
+
+The in-cluster log is read by default. When the cluster cannot be reached
+(for example, while it is stopped), the local log is shown instead; pass
+--local to read the local log directly.
+
+The cluster is resolved in the following priority order:
+  1. From --name flag
+  2. From ksail.yaml config file (if present)
+  3. From current kubeconfig context`
+
+// auditRecordTimeout bounds the in-cluster audit write so an unreachable API
+// server cannot stall a command whose operation has already completed.
+const auditRecordTimeout = 15 * time.Second
+
+// auditTimeFormat renders entry timestamps in the text output.
+const auditTimeFormat = "2006-01-02 15:04:05Z"
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	auditClientFactoryMu sync.RWMutex
+	auditClientFactory   = func(kubeconfigPath, kubeContext string) (kubernetes.Interface, error) {
+		return k8s.NewClientset(kubeconfigPath, kubeContext)
+	}
+)
+
+// auditTarget identifies the cluster an in-cluster audit log is read from or
+// written to.
+type auditTarget struct {
+	kubeconfigPath string
+	kubeContext    string
+}
+
+// NewAuditCmd creates the cluster audit command.
+func NewAuditCmd() *cobra.Command {
+	var (
+		nameFlag     string
+		providerFlag v1alpha1.Provider
+		localFlag    bool
+		limitFlag    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show who changed a cluster, when, and what",
+		Long:  auditLongDesc,
+		Annotations: map[string]string{
+			annotations.AnnotationDescription: "Show the audit log of KSail operations on a cluster",
+		},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := validateOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			return runAuditCmd(cmd, nameFlag, providerFlag, localFlag, limitFlag)
+		},
+	}
+
+	lifecycle.BindNameAndProviderFlags(cmd, &nameFlag, &providerFlag)
+
+	cmd.Flags().BoolVar(&localFlag, "local", false,
+		"Read the local audit log instead of the in-cluster log")
+	cmd.Flags().IntVar(&limitFlag, "limit", 0,
+		"Show only the N most recent entries (0 shows all)")
+	cmd.Flags().String("output", outputFormatText,
+		"Output format: text or json. Use json for machine-readable structured output.")
+
+	return cmd
+}
+
+// runAuditCmd resolves the target cluster, reads its audit log, and writes
+// the entries in the requested format.
+func runAuditCmd(
+	cmd *cobra.Command,
+	nameFlag string,
+	providerFlag v1alpha1.Provider,
+	local bool,
+	limit int,
+) error {
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, nameFlag, providerFlag, "")
+	if err != nil {
+		return fmt.Errorf("resolve cluster info: %w", err)
+	}
+
+	entries, source, err := readAuditLog(cmd, resolved, local)
+	if err != nil {
+		return err
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	if getOutputFormat(cmd) == outputFormatJSON {
+		return writeAuditJSON(cmd.OutOrStdout(), entries)
+	}
+
+	writeAuditText(cmd.OutOrStdout(), resolved.ClusterName, source, entries)
+
+	return nil
+}
+
+// readAuditLog reads the in-cluster audit log of the resolved cluster, or its
+// local log when local is set or the cluster cannot be reached. It returns the
+// entries together with the name of the log they came from.
+func readAuditLog(
+	cmd *cobra.Command,
+	resolved *lifecycle.ResolvedClusterInfo,
+	local bool,
+) ([]audit.Entry, string, error) {
+	if !local {
+		entries, err := listClusterAudit(cmd, resolved)
+		if err == nil {
+			return entries, "in-cluster", nil
+		}
+
+		notify.Warningf(cmd.ErrOrStderr(),
+			"cannot read the in-cluster audit log, showing the local log: %v", err)
+	}
+
+	entries, err := audit.ListLocal(resolved.ClusterName)
+	if err != nil {
+		return nil, "", fmt.Errorf("read audit log: %w", err)
+	}
+
+	return entries, "local", nil
+}
+
+// listClusterAudit reads the in-cluster audit log of the resolved cluster.
+func listClusterAudit(
+	cmd *cobra.Command,
+	resolved *lifecycle.ResolvedClusterInfo,
+) ([]audit.Entry, error) {
+	kubeconfigPath, kubeContext, err := resolveAuditContext(
+		resolved.KubeconfigPath, resolved.ClusterName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newAuditClient(auditTarget{kubeconfigPath: kubeconfigPath, kubeContext: kubeContext})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := audit.ListCluster(cmd.Context(), client)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// writeAuditText renders entries as a table, one row per changed field.
+func writeAuditText(writer io.Writer, clusterName, source string, entries []audit.Entry) {
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintf(writer, "No audit entries recorded for cluster %q (%s log)\n",
+			clusterName, source)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(writer, "Audit log of cluster %q (%s, %d entries):\n",
+		clusterName, source, len(entries))
+
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "  TIME\tUSER\tOPERATION\tCHANGES")
+
+	for _, entry := range entries {
+		details := make([]string, 0, len(entry.Changes)+1)
+		for _, change := range entry.Changes {
+			details = append(details, change.String())
+		}
+
+		if entry.Error != "" {
+			details = append(details, "failed: "+entry.Error)
+		}
+
+		if len(details) == 0 {
+			details = append(details, "-")
+		}
+
+		_, _ = fmt.Fprintf(table, "  %s\t%s\t%s\t%s\n",
+			entry.Time.UTC().Format(auditTimeFormat), entry.Who(), entry.Operation, details[0])
+
+		for _, detail := range details[1:] {
+			_, _ = fmt.Fprintf(table, "  \t\t\t%s\n", detail)
+		}
+	}
+
+	_ = table.Flush()
+}
+
+// writeAuditJSON serialises entries as an indented JSON array.
+func writeAuditJSON(writer io.Writer, entries []audit.Entry) error {
+	if entries == nil {
+		entries = []audit.Entry{}
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal audit entries: %w", err)
+	}
+
+	_, err = fmt.Fprintln(writer, string(data))
+	if err != nil {
+		return fmt.Errorf("write audit entries: %w", err)
+	}
+
+	return nil
+}
+
+// recordAudit appends entry to the local audit log of entry.Cluster and to the
+// in-cluster audit log of target.
+// Recording is best-effort: the audited operation has already happened, so
+// failures are reported as warnings instead of failing the command.
+func recordAudit(cmd *cobra.Command, target auditTarget, entry audit.Entry) {
+	var client kubernetes.Interface
+
+	if target.kubeconfigPath != "" {
+		clientset, err := newAuditClient(target)
+		if err != nil {
+			notify.Warningf(cmd.OutOrStderr(),
+				"recording audit entry locally only: %v", err)
+		} else {
+			client = clientset
+		}
+	}
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(parent, auditRecordTimeout)
+	defer cancel()
+
+	err := audit.Record(ctx, client, entry)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "failed to record audit entry: %v", err)
+	}
+}
+
+// recordClusterAudit records entry for the cluster described by ctx, reaching
+// it through the same kubeconfig and context the update flow inspects.
+func recordClusterAudit(cmd *cobra.Command, ctx *localregistry.Context, entry audit.Entry) {
+	recordAudit(cmd, clusterAuditTarget(ctx), entry)
+}
+
+// clusterAuditTarget returns the kubeconfig and context the update flow uses
+// to reach the cluster described by ctx.
+func clusterAuditTarget(ctx *localregistry.Context) auditTarget {
+	target := auditTarget{kubeContext: resolveKubeContext(ctx)}
+
+	kubeconfigPath, err := kubeconfigutil.GetKubeconfigPathFromConfig(ctx.ClusterCfg)
+	if err == nil {
+		target.kubeconfigPath = kubeconfigPath
+	}
+
+	return target
+}
+
+// recordResolvedAudit returns a lifecycle hook that records operation for the
+// cluster resolved by a simple lifecycle command (start, stop). The context is
+// looked up by cluster name, so an entry never lands in whichever cluster the
+// kubeconfig's current-context happens to point at.
+func recordResolvedAudit(
+	operation audit.Operation,
+) func(*cobra.Command, *lifecycle.ResolvedClusterInfo) {
+	return func(cmd *cobra.Command, resolved *lifecycle.ResolvedClusterInfo) {
+		var target auditTarget
+
+		kubeconfigPath, kubeContext, err := resolveAuditContext(
+			resolved.KubeconfigPath, resolved.ClusterName,
+		)
+		if err != nil {
+			notify.Warningf(cmd.OutOrStderr(), "recording audit entry locally only: %v", err)
+		} else {
+			target.kubeconfigPath = kubeconfigPath
+			target.kubeContext = kubeContext
+		}
+
+		recordAudit(cmd, target, audit.NewEntry(operation, resolved.ClusterName))
+	}
+}
+
+// resolveAuditContext finds the kubeconfig context of clusterName in the
+// kubeconfig at kubeconfigPath.
+func resolveAuditContext(kubeconfigPath, clusterName string) (string, string, error) {
+	if kubeconfigPath == "" {
+		return "", "", ErrKubeconfigNotFound
+	}
+
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return "", "", fmt.Errorf("load kubeconfig %q: %w", kubeconfigPath, err)
+	}
+
+	kubeContext, err := resolveContextName(config, clusterName)
+	if err != nil {
+		return "", "", err
+	}
+
+	return kubeconfigPath, kubeContext, nil
+}
+
+// newAuditClient builds a Kubernetes client for target.
+func newAuditClient(target auditTarget) (kubernetes.Interface, error) {
+	auditClientFactoryMu.RLock()
+	factory := auditClientFactory
+	auditClientFactoryMu.RUnlock()
+
+	client, err := factory(target.kubeconfigPath, target.kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("build kubernetes client: %w", err)
+	}
+
+	return client, nil
+}
+
+// auditClusterName returns the cluster name restore records its entry under:
+// the --name flag, otherwise the cluster resolved from the config or the
+// current kubeconfig context. An unresolvable name yields "", which records
+// the entry in the cluster only.
+func auditClusterName(cmd *cobra.Command, nameFlag string) string {
+	if strings.TrimSpace(nameFlag) != "" {
+		return nameFlag
+	}
+
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, "", "", "")
+	if err != nil {
+		return ""
+	}
+
+	return resolved.ClusterName
+}
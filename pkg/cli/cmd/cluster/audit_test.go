@@ -0,0 +1,169 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewAuditCmd(t *testing.T) {
+	t.Parallel()
+
+	auditCmd := cluster.NewAuditCmd()
+	require.NotNil(t, auditCmd)
+
+	assert.Equal(t, "audit", auditCmd.Name())
+	assert.True(t, auditCmd.SilenceUsage)
+
+	for _, flagName := range []string{"name", "provider", "local", "limit", "output"} {
+		assert.NotNil(t, auditCmd.Flags().Lookup(flagName), "expected --%s flag", flagName)
+	}
+}
+
+func TestAuditCmd_InvalidFormatRejectsEarly(t *testing.T) {
+	t.Parallel()
+
+	auditCmd := cluster.NewAuditCmd()
+	auditCmd.SetOut(io.Discard)
+	auditCmd.SetErr(io.Discard)
+	auditCmd.SetArgs([]string{"--output", "xml"})
+
+	err := auditCmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrUnsupportedOutputFormat)
+}
+
+// useFakeAuditClient routes the audit command's Kubernetes client to client.
+func useFakeAuditClient(t *testing.T, client kubernetes.Interface) {
+	t.Helper()
+
+	restore := cluster.SetAuditClientFactoryForTests(
+		func(string, string) (kubernetes.Interface, error) { return client, nil },
+	)
+	t.Cleanup(restore)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the audit client factory
+func TestAuditCmd_ReadsInClusterLog(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	t.Setenv("KUBECONFIG", writeKubeconfigWithContext(t, workingDir, "kind-shared"))
+
+	client := fake.NewClientset()
+	useFakeAuditClient(t, client)
+
+	entry := audit.Entry{
+		Time:      time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+		User:      "alice",
+		Host:      "laptop",
+		Cluster:   "shared",
+		Operation: audit.OperationUpdate,
+	}.WithChanges([]clusterupdate.Change{
+		{Field: "cluster.cni", OldValue: "Default", NewValue: "Cilium"},
+		{Field: "cluster.workers", OldValue: "1", NewValue: "2"},
+	})
+	require.NoError(t, audit.AppendCluster(context.Background(), client, entry))
+
+	auditCmd := cluster.NewAuditCmd()
+
+	var out bytes.Buffer
+	auditCmd.SetOut(&out)
+	auditCmd.SetErr(&out)
+	auditCmd.SetContext(context.Background())
+	auditCmd.SetArgs([]string{"--name", "shared"})
+
+	err := auditCmd.Execute()
+	require.NoError(t, err, out.String())
+
+	assert.Contains(t, out.String(), `Audit log of cluster "shared" (in-cluster, 1 entries)`)
+	assert.Contains(t, out.String(), "2026-10-17 09:30:00Z")
+	assert.Contains(t, out.String(), "alice@laptop")
+	assert.Contains(t, out.String(), "cluster.cni Default → Cilium")
+	assert.Contains(t, out.String(), "cluster.workers 1 → 2")
+}
+
+//nolint:paralleltest // uses t.Chdir and t.Setenv
+func TestAuditCmd_FallsBackToLocalLog(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	t.Setenv("KUBECONFIG", "/nonexistent/kubeconfig")
+
+	clusterName := "audit-fallback"
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(clusterName)
+	})
+
+	first := audit.NewEntry(audit.OperationCreate, clusterName)
+	second := audit.NewEntry(audit.OperationStop, clusterName)
+	second.Time = first.Time.Add(time.Minute)
+
+	require.NoError(t, audit.AppendLocal(first))
+	require.NoError(t, audit.AppendLocal(second))
+
+	auditCmd := cluster.NewAuditCmd()
+
+	var out, errOut bytes.Buffer
+	auditCmd.SetOut(&out)
+	auditCmd.SetErr(&errOut)
+	auditCmd.SetContext(context.Background())
+	auditCmd.SetArgs([]string{"--name", clusterName, "--output", "json", "--limit", "1"})
+
+	err := auditCmd.Execute()
+	require.NoError(t, err, errOut.String())
+
+	assert.Contains(t, errOut.String(), "showing the local log")
+
+	var entries []audit.Entry
+
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries), out.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, audit.OperationStop, entries[0].Operation)
+}
+
+//nolint:paralleltest // mutates the audit client factory
+func TestRecordResolvedAudit_WritesLocalAndClusterLogs(t *testing.T) {
+	workingDir := t.TempDir()
+	clusterName := "audit-stop"
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(clusterName)
+	})
+
+	client := fake.NewClientset()
+	useFakeAuditClient(t, client)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetErr(io.Discard)
+
+	record := cluster.ExportRecordResolvedAudit(audit.OperationStop)
+	record(cmd, &lifecycle.ResolvedClusterInfo{
+		ClusterName:    clusterName,
+		KubeconfigPath: writeKubeconfigWithContext(t, workingDir, "kind-"+clusterName),
+	})
+
+	local, err := audit.ListLocal(clusterName)
+	require.NoError(t, err)
+	require.Len(t, local, 1)
+	assert.Equal(t, audit.OperationStop, local[0].Operation)
+
+	inCluster, err := audit.ListCluster(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, inCluster, 1)
+	assert.Equal(t, clusterName, inCluster[0].Cluster)
+}
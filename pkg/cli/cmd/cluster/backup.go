@@ -55,12 +55,15 @@ type backupFlags struct {
 	excludeTypes     []string
 	compressionLevel int
 	name             string
+	velero           bool
+	wait             bool
 }
 
 // NewBackupCmd creates the cluster backup command.
 func NewBackupCmd() *cobra.Command {
 	flags := &backupFlags{
 		compressionLevel: defaultCompressionLevel,
+		wait:             true,
 	}
 
 	cmd := &cobra.Command{
@@ -75,10 +78,16 @@ Metadata about the backup is included for restore operations.
 Note: This backs up resource manifests (YAML) only. Persistent volume
 contents are not included in the current implementation.
 
+With --velero, the backup is taken by Velero instead (spec.cluster.backup:
+Velero) and stored in the cluster's local MinIO bucket. The positional
+argument is then the backup name (default: ksail-<timestamp>); restore it
+with 'ksail cluster restore-backup <name>'.
+
 Example:
   ksail cluster backup ./my-backup.tar.gz
   ksail cluster backup ./backup.tar.gz --namespaces default,kube-system
-  ksail cluster backup ./backup.tar.gz --exclude-types events,pods`,
+  ksail cluster backup ./backup.tar.gz --exclude-types events,pods
+  ksail cluster backup --velero nightly --namespaces apps`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runBackup(cmd.Context(), cmd, flags, args)
@@ -133,6 +142,15 @@ func registerBackupFlags(cmd *cobra.Command, flags *backupFlags) {
 		"Name of the cluster to back up (resolves the kubeconfig like the other cluster "+
 			"commands; defaults to the current kubeconfig context when unset)",
 	)
+	cmd.Flags().BoolVar(
+		&flags.velero, "velero", false,
+		"Take the backup with Velero (requires spec.cluster.backup: Velero); "+
+			"the positional argument is the backup name",
+	)
+	cmd.Flags().BoolVar(
+		&flags.wait, "wait", true,
+		"With --velero, wait for Velero to finish the backup",
+	)
 }
 
 // prepareOutputPath creates the output directory if needed and canonicalizes
@@ -161,6 +179,10 @@ func runBackup(
 	flags *backupFlags,
 	args []string,
 ) error {
+	if flags.velero {
+		return runVeleroBackup(ctx, cmd, flags, args)
+	}
+
 	outputPath, err := resolveArchivePath(args, flags.outputPath, "--output")
 	if err != nil {
 		return err
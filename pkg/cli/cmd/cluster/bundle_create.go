@@ -0,0 +1,317 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/internal/buildmeta"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/bundle"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// fromBundleFlag names the bundle `ksail cluster create` materializes.
+	fromBundleFlag = "from-bundle"
+	// trustedKeyFlag names the public key a bundle must be signed with.
+	trustedKeyFlag = "trusted-key"
+	// distributionConfigFlagName names the flag overriding the distribution config path.
+	distributionConfigFlagName = "distribution-config"
+)
+
+// ErrBundleWithConfig is returned when --from-bundle is combined with
+// --config, as the bundle brings its own ksail.yaml.
+var ErrBundleWithConfig = errors.New("--from-bundle cannot be combined with --config")
+
+// registerBundleFlags adds the --from-bundle and --trusted-key flags of
+// `ksail cluster create`.
+func registerBundleFlags(cmd *cobra.Command) {
+	cmd.Flags().String(fromBundleFlag, "",
+		"Create the cluster from a bundle exported with 'ksail cluster export', "+
+			"extracting its project files into the current directory first")
+	cmd.Flags().String(trustedKeyFlag, "",
+		"PEM-encoded ed25519 public key the --from-bundle bundle must be signed with")
+}
+
+// withBundle wraps the create RunE so a --from-bundle bundle is verified and
+// extracted before the configuration is loaded from it, and the installed
+// component versions are compared with the bundled ones after creation. A dry
+// run stages the bundle in a temporary directory instead, so it leaves the
+// working directory untouched.
+func withBundle(
+	cfgManager *ksailconfigmanager.ConfigManager,
+	runE func(*cobra.Command, []string) error,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		bundlePath, _ := cmd.Flags().GetString(fromBundleFlag)
+		if bundlePath == "" {
+			return runE(cmd, args)
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		opened, err := openBundle(cmd, bundlePath)
+		if err != nil {
+			return err
+		}
+
+		if dryRun {
+			cleanup, err := stageBundle(cmd, cfgManager, opened, bundlePath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			return runE(cmd, args)
+		}
+
+		err = extractBundle(cmd, opened, bundlePath)
+		if err != nil {
+			return err
+		}
+
+		err = runE(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		warnBundleDrift(cmd, cfgManager, opened.Manifest)
+
+		return nil
+	}
+}
+
+// openBundle opens and verifies the bundle at bundlePath.
+func openBundle(cmd *cobra.Command, bundlePath string) (*bundle.Bundle, error) {
+	configPath, err := flags.GetConfigPath(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("resolve config path: %w", err)
+	}
+
+	if configPath != "" {
+		return nil, ErrBundleWithConfig
+	}
+
+	opened, err := bundle.Open(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle %s: %w", bundlePath, err)
+	}
+
+	err = verifyBundleSigner(cmd, opened)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := opened.Manifest
+	if manifest.KSailVersion != buildmeta.Version {
+		notify.Warningf(cmd.ErrOrStderr(),
+			"bundle was exported with KSail %s, this is KSail %s; defaults may differ",
+			manifest.KSailVersion, buildmeta.Version)
+	}
+
+	return opened, nil
+}
+
+// extractBundle extracts the project files of the bundle into the working
+// directory and points --config at its ksail.yaml.
+func extractBundle(cmd *cobra.Command, opened *bundle.Bundle, bundlePath string) error {
+	manifest := opened.Manifest
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	err = opened.Extract(workingDir)
+	if err != nil {
+		return fmt.Errorf("extract bundle %s: %w", bundlePath, err)
+	}
+
+	// Without a --config flag (a command run outside the root command), the
+	// extracted ksail.yaml is found by the discovery in the working directory.
+	if cmd.Flags().Lookup(flags.ConfigFlagName) != nil {
+		err = cmd.Flags().Set(flags.ConfigFlagName, filepath.Join(workingDir, filepath.FromSlash(manifest.ConfigFile)))
+		if err != nil {
+			return fmt.Errorf("use bundled config: %w", err)
+		}
+	}
+
+	notify.Infof(cmd.OutOrStdout(), "extracted %d files of cluster %q from %s",
+		len(manifest.Files), manifest.ClusterName, bundlePath)
+
+	return nil
+}
+
+// stageBundle extracts the project files of the bundle into a temporary
+// directory for a dry run and loads the configuration from there. It still
+// reports files the real run would refuse to overwrite. The returned cleanup
+// removes the temporary directory.
+func stageBundle(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	opened *bundle.Bundle,
+	bundlePath string,
+) (func(), error) {
+	manifest := opened.Manifest
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	err = opened.CheckExtract(workingDir)
+	if err != nil {
+		return nil, fmt.Errorf("extract bundle %s: %w", bundlePath, err)
+	}
+
+	stageDir, err := os.MkdirTemp("", "ksail-bundle-*")
+	if err != nil {
+		return nil, fmt.Errorf("create bundle staging directory: %w", err)
+	}
+
+	cleanup := func() { _ = os.RemoveAll(stageDir) }
+
+	err = useStagedBundle(cmd, cfgManager, opened, stageDir)
+	if err != nil {
+		cleanup()
+
+		return nil, fmt.Errorf("stage bundle %s: %w", bundlePath, err)
+	}
+
+	notify.Infof(cmd.OutOrStdout(), "would extract %d files of cluster %q from %s",
+		len(manifest.Files), manifest.ClusterName, bundlePath)
+
+	return cleanup, nil
+}
+
+// useStagedBundle extracts the bundle into stageDir and loads the configuration
+// from there. The distribution config is resolved relative to the working
+// directory, so --distribution-config is pointed at the staged copy unless it
+// was passed explicitly.
+func useStagedBundle(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	opened *bundle.Bundle,
+	stageDir string,
+) error {
+	err := opened.Extract(stageDir)
+	if err != nil {
+		return err
+	}
+
+	configPath := filepath.Join(stageDir, filepath.FromSlash(opened.Manifest.ConfigFile))
+
+	err = cfgManager.UseConfigFile(configPath)
+	if err != nil {
+		return fmt.Errorf("use bundled config: %w", err)
+	}
+
+	distributionConfigFlag := cmd.Flags().Lookup(distributionConfigFlagName)
+	if distributionConfigFlag == nil || distributionConfigFlag.Changed {
+		return nil
+	}
+
+	distributionConfig, err := bundledDistributionConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	if filepath.IsAbs(distributionConfig) {
+		return nil
+	}
+
+	err = cmd.Flags().Set(distributionConfigFlagName, filepath.Join(filepath.Dir(configPath), distributionConfig))
+	if err != nil {
+		return fmt.Errorf("use bundled distribution config: %w", err)
+	}
+
+	return nil
+}
+
+// bundledDistributionConfig returns the distribution config path the ksail.yaml
+// at configPath declares, or the default one of its distribution.
+func bundledDistributionConfig(configPath string) (string, error) {
+	data, err := os.ReadFile(configPath) //nolint:gosec // G304: path is inside the staging directory
+	if err != nil {
+		return "", fmt.Errorf("read bundled config: %w", err)
+	}
+
+	var config struct {
+		Spec struct {
+			Cluster struct {
+				Distribution       v1alpha1.Distribution `json:"distribution"`
+				DistributionConfig string                `json:"distributionConfig"`
+			} `json:"cluster"`
+		} `json:"spec"`
+	}
+
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return "", fmt.Errorf("parse bundled config: %w", err)
+	}
+
+	if config.Spec.Cluster.DistributionConfig != "" {
+		return config.Spec.Cluster.DistributionConfig, nil
+	}
+
+	return v1alpha1.ExpectedDistributionConfigName(config.Spec.Cluster.Distribution), nil
+}
+
+// verifyBundleSigner checks the bundle against --trusted-key. Without one, the
+// bundle is only known to be intact, so the signer is reported for the user to
+// check.
+func verifyBundleSigner(cmd *cobra.Command, opened *bundle.Bundle) error {
+	trustedKeyPath, _ := cmd.Flags().GetString(trustedKeyFlag)
+	if trustedKeyPath == "" {
+		notify.Warningf(cmd.ErrOrStderr(),
+			"bundle signer %s is not verified; pass --%s to require a trusted signer",
+			bundle.Fingerprint(opened.Signer), trustedKeyFlag)
+
+		return nil
+	}
+
+	trustedKey, err := bundle.LoadPublicKey(trustedKeyPath)
+	if err != nil {
+		return fmt.Errorf("load trusted key: %w", err)
+	}
+
+	err = opened.VerifySigner(trustedKey)
+	if err != nil {
+		return fmt.Errorf("verify bundle: %w", err)
+	}
+
+	return nil
+}
+
+// warnBundleDrift compares the component versions installed in the created
+// cluster with those recorded in the bundle and warns about each difference.
+// The comparison is best-effort: the cluster has already been created.
+func warnBundleDrift(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	manifest bundle.Manifest,
+) {
+	if len(manifest.Components) == 0 || cfgManager.Config == nil {
+		return
+	}
+
+	connection := cfgManager.Config.Spec.Cluster.Connection
+
+	installed, _, err := readClusterStateForBundle(
+		cmd.Context(), connection.Kubeconfig, connection.Context, manifest.ClusterName)
+	if err != nil {
+		notify.Warningf(cmd.ErrOrStderr(), "cannot compare component versions with the bundle: %v", err)
+
+		return
+	}
+
+	for _, drift := range bundle.ComponentDrift(manifest.Components, installed) {
+		notify.Warningf(cmd.ErrOrStderr(), "component version differs from the bundle: %s", drift)
+	}
+}
@@ -0,0 +1,287 @@
+package cluster
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/internal/buildmeta"
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/bundle"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	"github.com/spf13/cobra"
+)
+
+// exportLongDesc describes the `ksail cluster export` command.
+const exportLongDesc = `Export everything needed to reproduce a cluster elsewhere into a signed bundle.
+
+The bundle is a .tar.gz archive holding:
+  - the ksail.yaml
+  - the distribution configuration (spec.cluster.distributionConfig), e.g.
+    kind.yaml, k3d.yaml or the talos/ directory
+  - the workload source directory (spec.workload.sourceDirectory)
+  - the Kind mirror configuration (spec.cluster.vanilla.mirrorsDir)
+  - the chart versions of the KSail-managed components installed in the cluster
+  - the digests of the OCI artifacts Flux reconciled
+
+Project files must live in the directory of the ksail.yaml. When the cluster
+cannot be reached, the bundle is exported without component versions and
+artifact digests.
+
+The bundle is signed with an ed25519 key: --signing-key names a PEM-encoded
+PKCS #8 key, and by default a key is generated once in ~/.ksail/bundle-signing.
+Share the public key next to it (bundle.pub) with the machines that create
+clusters from your bundles, so they can pass it to --trusted-key.
+
+Materialize a bundle with 'ksail cluster create --from-bundle <bundle>'.
+
+Example:
+  ksail cluster export
+  ksail cluster export ./dev.tar.gz --signing-key ./keys/bundle.key`
+
+// ErrExportConfigNotFound is returned when `ksail cluster export` runs outside
+// a KSail project.
+var ErrExportConfigNotFound = errors.New("no ksail.yaml found to export")
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	bundleClusterStateMu sync.RWMutex
+	bundleClusterState   = readBundleClusterState
+)
+
+// readBundleClusterState reads the KSail-managed component versions and the
+// Flux OCI artifact revisions of the cluster scoped to kubeContext.
+func readBundleClusterState(
+	ctx context.Context,
+	kubeconfigPath, kubeContext string,
+) ([]bundle.Component, []bundle.Artifact, error) {
+	helmClient, err := helm.NewClient(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create helm client: %w", err)
+	}
+
+	versions, err := detector.DetectComponentVersions(ctx, helmClient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("detect component versions: %w", err)
+	}
+
+	dynamicClient, err := k8s.NewDynamicClient(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create dynamic client: %w", err)
+	}
+
+	artifacts, err := bundle.FluxArtifacts(ctx, dynamicClient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("collect OCI artifacts: %w", err)
+	}
+
+	return bundle.Components(versions), artifacts, nil
+}
+
+// NewExportCmd creates the cluster export command.
+func NewExportCmd() *cobra.Command {
+	var signingKeyFlag string
+
+	cmd := &cobra.Command{
+		Use:          "export [<output>]",
+		Short:        "Export a cluster as a signed, portable bundle",
+		Long:         exportLongDesc,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(cmd, args, signingKeyFlag)
+		},
+	}
+
+	cmd.Flags().StringVar(&signingKeyFlag, "signing-key", "",
+		"PEM-encoded ed25519 private key to sign the bundle with (default: ~/.ksail/bundle-signing/bundle.key)")
+
+	return cmd
+}
+
+// runExport collects the project files and cluster state described by the
+// ksail.yaml and writes them to a signed bundle.
+func runExport(cmd *cobra.Command, args []string, signingKeyFlag string) error {
+	configPath, err := flags.GetConfigPath(cmd)
+	if err != nil {
+		return fmt.Errorf("resolve config path: %w", err)
+	}
+
+	cfgManager := ksailconfigmanager.NewConfigManager(nil, configPath, append(
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+		ksailconfigmanager.StandardSourceDirectoryFieldSelector(),
+	)...)
+
+	clusterCfg, err := cfgManager.Load(configmanager.LoadOptions{Silent: true})
+	if err != nil {
+		return fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	configFile := cfgManager.Viper.ConfigFileUsed()
+	if !cfgManager.IsConfigFileFound() || configFile == "" {
+		return ErrExportConfigNotFound
+	}
+
+	configFile, err = filepath.Abs(configFile)
+	if err != nil {
+		return fmt.Errorf("resolve config path: %w", err)
+	}
+
+	clusterName, err := lifecycle.GetClusterNameFromConfig(clusterCfg,
+		clusterprovisioner.DefaultFactory{DistributionConfig: cfgManager.DistributionConfig})
+	if err != nil {
+		return fmt.Errorf("resolve cluster name: %w", err)
+	}
+
+	distributionConfig := clusterCfg.Spec.Cluster.DistributionConfig
+	if distributionConfig == "" {
+		distributionConfig = v1alpha1.ExpectedDistributionConfigName(clusterCfg.Spec.Cluster.Distribution)
+	}
+
+	files, err := exportProjectFiles(configFile, distributionConfig,
+		clusterCfg.Spec.Cluster.Vanilla.MirrorsDir, clusterCfg.Spec.Workload.SourceDirectory)
+	if err != nil {
+		return err
+	}
+
+	key, err := loadBundleSigningKey(signingKeyFlag)
+	if err != nil {
+		return err
+	}
+
+	outputPath := clusterName + "-bundle.tar.gz"
+	if len(args) > 0 {
+		outputPath = args[0]
+	}
+
+	outputPath, err = prepareOutputPath(outputPath)
+	if err != nil {
+		return err
+	}
+
+	manifest := bundle.Manifest{
+		KSailVersion: buildmeta.Version,
+		ClusterName:  clusterName,
+		Distribution: clusterCfg.Spec.Cluster.Distribution,
+		Provider:     clusterCfg.Spec.Cluster.Provider,
+		CreatedAt:    time.Now().UTC(),
+		ConfigFile:   filepath.Base(configFile),
+	}
+
+	connection := clusterCfg.Spec.Cluster.Connection
+
+	manifest.Components, manifest.Artifacts, err = readClusterStateForBundle(
+		cmd.Context(), connection.Kubeconfig, connection.Context, clusterName)
+	if err != nil {
+		notify.Warningf(cmd.ErrOrStderr(),
+			"cannot read cluster %q, exporting without component versions and artifact digests: %v",
+			clusterName, err)
+	}
+
+	err = bundle.Write(outputPath, manifest, filepath.Dir(configFile), files, key)
+	if err != nil {
+		return fmt.Errorf("export cluster %q: %w", clusterName, err)
+	}
+
+	notify.Successf(cmd.OutOrStdout(), "exported cluster %q to %s (%d files, %d components, %d artifacts)",
+		clusterName, outputPath, len(files), len(manifest.Components), len(manifest.Artifacts))
+	notify.Infof(cmd.OutOrStdout(), "signed with key %s",
+		bundle.Fingerprint(key.Public().(ed25519.PublicKey)))
+
+	return nil
+}
+
+// exportProjectFiles returns the project files to bundle, relative to the
+// directory of configFile. The distribution configuration is resolved against
+// the working directory, as cluster creation reads it from there.
+func exportProjectFiles(configFile, distributionConfig, mirrorsDir, sourceDirectory string) ([]string, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	paths := []string{configFile, sourceDirectory}
+
+	for _, path := range []string{distributionConfig, mirrorsDir} {
+		if path != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(workingDir, path)
+		}
+
+		paths = append(paths, path)
+	}
+
+	files, err := bundle.ProjectFiles(filepath.Dir(configFile), paths...)
+	if err != nil {
+		return nil, fmt.Errorf("collect project files: %w", err)
+	}
+
+	return files, nil
+}
+
+// readClusterStateForBundle reads the component versions and artifacts of the
+// cluster through the injectable bundleClusterState.
+func readClusterStateForBundle(
+	ctx context.Context,
+	kubeconfigPath, kubeContext, clusterName string,
+) ([]bundle.Component, []bundle.Artifact, error) {
+	if kubeContext == "" {
+		resolved, err := resolveClusterContext(kubeconfigPath, clusterName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if resolved == "" {
+			return nil, nil, fmt.Errorf("%w: %s", ErrKubeconfigNotFound, kubeconfigPath)
+		}
+
+		kubeContext = resolved
+	}
+
+	bundleClusterStateMu.RLock()
+	readState := bundleClusterState
+	bundleClusterStateMu.RUnlock()
+
+	return readState(ctx, kubeconfigPath, kubeContext)
+}
+
+// loadBundleSigningKey loads the key named by --signing-key, or the default
+// key in ~/.ksail/bundle-signing, generating it on first use.
+func loadBundleSigningKey(path string) (ed25519.PrivateKey, error) {
+	if path != "" {
+		key, err := bundle.LoadSigningKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("load signing key: %w", err)
+		}
+
+		return key, nil
+	}
+
+	dir, err := bundle.DefaultSigningDir()
+	if err != nil {
+		return nil, fmt.Errorf("locate signing key: %w", err)
+	}
+
+	key, err := bundle.LoadOrCreateSigningKey(dir)
+	if err != nil {
+		return nil, fmt.Errorf("load signing key: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,195 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/bundle"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errClusterUnreachable = errors.New("connection refused")
+
+// useBundleClusterState serves the component versions and OCI artifacts the
+// bundle commands read from the cluster, or fails with err.
+func useBundleClusterState(t *testing.T, err error) {
+	t.Helper()
+
+	t.Cleanup(cluster.SetBundleClusterStateForTests(
+		func(_ context.Context, _, kubeContext string) ([]bundle.Component, []bundle.Artifact, error) {
+			assert.Equal(t, "kind-test", kubeContext)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return []bundle.Component{{Name: "cilium", Chart: "cilium-1.16.0"}},
+				[]bundle.Artifact{{
+					Name: "apps", Namespace: "flux-system", URL: "oci://ghcr.io/org/apps",
+					Revision: "latest@sha256:abc", Digest: "sha256:abc",
+				}}, nil
+		},
+	))
+}
+
+// runBundleCmd executes cmd with args and returns its combined output.
+func runBundleCmd(cmd *cobra.Command, args ...string) (string, error) {
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+// exportTestBundle exports the test project in a fresh working directory and
+// returns the bundle path and the directory holding its signing key.
+func exportTestBundle(t *testing.T) (string, string) {
+	t.Helper()
+
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	writeTestConfigFiles(t, projectDir)
+	writeFile(t, filepath.Join(projectDir, "k8s"), "kustomization.yaml", "resources: []\n")
+
+	keyDir := t.TempDir()
+	_, err := bundle.LoadOrCreateSigningKey(keyDir)
+	require.NoError(t, err)
+
+	bundlePath := filepath.Join(t.TempDir(), "dev.tar.gz")
+
+	out, err := runBundleCmd(cluster.NewExportCmd(),
+		bundlePath, "--signing-key", filepath.Join(keyDir, bundle.SigningKeyFile))
+	require.NoError(t, err, out)
+
+	return bundlePath, keyDir
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates the bundle cluster state seam
+func TestExportCmd_WritesSignedBundle(t *testing.T) {
+	useBundleClusterState(t, nil)
+
+	bundlePath, keyDir := exportTestBundle(t)
+
+	opened, err := bundle.Open(bundlePath)
+	require.NoError(t, err)
+
+	trusted, err := bundle.LoadPublicKey(filepath.Join(keyDir, bundle.PublicKeyFile))
+	require.NoError(t, err)
+	require.NoError(t, opened.VerifySigner(trusted))
+
+	paths := make([]string, 0, len(opened.Manifest.Files))
+	for _, file := range opened.Manifest.Files {
+		paths = append(paths, file.Path)
+	}
+
+	assert.Equal(t, []string{"k8s/kustomization.yaml", "kind.yaml", "ksail.yaml"}, paths)
+	assert.Equal(t, "test", opened.Manifest.ClusterName)
+	assert.Equal(t, "ksail.yaml", opened.Manifest.ConfigFile)
+	assert.Equal(t, []bundle.Component{{Name: "cilium", Chart: "cilium-1.16.0"}}, opened.Manifest.Components)
+	require.Len(t, opened.Manifest.Artifacts, 1)
+	assert.Equal(t, "sha256:abc", opened.Manifest.Artifacts[0].Digest)
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates the bundle cluster state seam
+func TestExportCmd_UnreachableClusterExportsProjectFiles(t *testing.T) {
+	useBundleClusterState(t, errClusterUnreachable)
+
+	bundlePath, _ := exportTestBundle(t)
+
+	opened, err := bundle.Open(bundlePath)
+	require.NoError(t, err)
+
+	assert.Len(t, opened.Manifest.Files, 3)
+	assert.Empty(t, opened.Manifest.Components)
+	assert.Empty(t, opened.Manifest.Artifacts)
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestExportCmd_RequiresProject(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := runBundleCmd(cluster.NewExportCmd())
+	require.ErrorIs(t, err, cluster.ErrExportConfigNotFound)
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates shared test hooks
+func TestCreateCmd_FromBundleDryRun(t *testing.T) {
+	useBundleClusterState(t, nil)
+
+	bundlePath, keyDir := exportTestBundle(t)
+
+	restoreFactory := cluster.SetProvisionerFactoryForTests(fakeFactoryWithErrors{})
+	defer restoreFactory()
+
+	targetDir := t.TempDir()
+	t.Chdir(targetDir)
+
+	out, err := runBundleCmd(cluster.NewCreateCmd(), "--dry-run",
+		"--from-bundle", bundlePath, "--trusted-key", filepath.Join(keyDir, bundle.PublicKeyFile))
+	require.NoError(t, err, out)
+
+	assert.Contains(t, out, `would extract 3 files of cluster "test"`)
+	assert.Contains(t, out, `Would create cluster "test"`, "the bundled distribution config is used")
+	assert.Contains(t, out, "Dry run complete. No changes applied.")
+
+	entries, err := os.ReadDir(targetDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "a dry run extracts nothing into the working directory")
+
+	// A dry run leaves nothing behind that the real run would refuse to overwrite.
+	out, err = runBundleCmd(cluster.NewCreateCmd(), "--dry-run",
+		"--from-bundle", bundlePath, "--trusted-key", filepath.Join(keyDir, bundle.PublicKeyFile))
+	require.NoError(t, err, out)
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates shared test hooks
+func TestCreateCmd_FromBundleRejectsUntrustedSigner(t *testing.T) {
+	useBundleClusterState(t, nil)
+
+	bundlePath, _ := exportTestBundle(t)
+
+	otherKeyDir := t.TempDir()
+	_, err := bundle.LoadOrCreateSigningKey(otherKeyDir)
+	require.NoError(t, err)
+
+	targetDir := t.TempDir()
+	t.Chdir(targetDir)
+
+	_, err = runBundleCmd(cluster.NewCreateCmd(), "--dry-run",
+		"--from-bundle", bundlePath, "--trusted-key", filepath.Join(otherKeyDir, bundle.PublicKeyFile))
+	require.ErrorIs(t, err, bundle.ErrUntrustedSigner)
+
+	entries, err := os.ReadDir(targetDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "nothing is extracted from an untrusted bundle")
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates shared test hooks
+func TestCreateCmd_FromBundleKeepsExistingFiles(t *testing.T) {
+	useBundleClusterState(t, nil)
+
+	bundlePath, _ := exportTestBundle(t)
+
+	targetDir := t.TempDir()
+	t.Chdir(targetDir)
+	writeFile(t, targetDir, "kind.yaml", "mine\n")
+
+	_, err := runBundleCmd(cluster.NewCreateCmd(), "--dry-run", "--from-bundle", bundlePath)
+	require.ErrorIs(t, err, bundle.ErrFileExists)
+
+	content, err := os.ReadFile(filepath.Join(targetDir, "kind.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "mine\n", string(content))
+}
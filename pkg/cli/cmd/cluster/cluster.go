@@ -92,16 +92,28 @@ func NewClusterCmd() *cobra.Command {
 	cmd.AddCommand(newDeprecatedAddEnvironmentCmd())
 	cmd.AddCommand(NewCreateCmd())
 	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewUpgradeCmd())
 	cmd.AddCommand(NewDeleteCmd())
 	cmd.AddCommand(NewStartCmd())
 	cmd.AddCommand(NewStopCmd())
+	cmd.AddCommand(NewPoolCmd())
 	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(NewInfoCmd())
+	cmd.AddCommand(NewStatusCmd())
 	cmd.AddCommand(NewDiagnoseCmd())
+	cmd.AddCommand(NewDebugCmd())
+	cmd.AddCommand(NewConsoleCmd())
 	cmd.AddCommand(NewDiffCmd())
+	cmd.AddCommand(NewCostCmd())
+	cmd.AddCommand(NewAuditCmd())
+	cmd.AddCommand(NewTimelineCmd())
+	cmd.AddCommand(NewSBOMCmd())
 	cmd.AddCommand(NewConnectCmd())
 	cmd.AddCommand(NewBackupCmd())
 	cmd.AddCommand(NewRestoreCmd())
+	cmd.AddCommand(NewRestoreBackupCmd())
+	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewExportTerraformCmd())
 	cmd.AddCommand(NewSwitchCmd())
 	cmd.AddCommand(NewRepairCmd(nil))
 	cmd.AddCommand(NewRebindEKSOwnershipCmd())
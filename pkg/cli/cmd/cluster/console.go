@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/hostdebug"
+	"github.com/spf13/cobra"
+)
+
+// consoleLongDesc describes the `ksail cluster console` command.
+const consoleLongDesc = `Stream a Talos node's console over the Talos API.
+
+Talos nodes have no shell to exec into, so this is the node-level
+counterpart of 'ksail workload debug --host' on Kind, K3d, and other
+Docker-based distributions. The command prints the node's services with
+their state and health, then streams its kernel messages (dmesg) and the
+logs of the services named with --service, each line prefixed with its
+source. Output follows new messages until interrupted with Ctrl-C; pass
+--follow=false to print what is buffered and exit. For the full-screen
+Talos dashboard, use 'talosctl dashboard' with the same talosconfig.
+
+The node is reached with the talosconfig in $TALOSCONFIG or
+~/.talos/config. Node names resolve through the Docker network (Docker
+provider) or the Kubernetes Node addresses (Hetzner and Omni).
+
+The cluster is resolved in the following priority order:
+  1. From --name flag
+  2. From ksail.yaml config file (if present)
+  3. From current kubeconfig context`
+
+// defaultConsoleTailLines is how many lines of each service log are printed
+// before following.
+const defaultConsoleTailLines = 100
+
+type consoleFlags struct {
+	name      string
+	provider  v1alpha1.Provider
+	kernel    bool
+	services  []string
+	follow    bool
+	tailLines int32
+}
+
+// NewConsoleCmd creates the cluster console command.
+func NewConsoleCmd() *cobra.Command {
+	var flags consoleFlags
+
+	cmd := &cobra.Command{
+		Use:   "console <node>",
+		Short: "Stream a Talos node's kernel and service logs",
+		Long:  consoleLongDesc,
+		Example: `  # Stream kernel messages of a control-plane node
+  ksail cluster console dev-controlplane-1
+
+  # Add the kubelet and etcd logs
+  ksail cluster console dev-controlplane-1 --service kubelet --service etcd
+
+  # Print the last 500 kubelet log lines and exit
+  ksail cluster console dev-worker-1 --kernel=false --service kubelet --tail 500 --follow=false`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConsoleCmd(cmd, args[0], flags)
+		},
+	}
+
+	lifecycle.BindNameAndProviderFlags(cmd, &flags.name, &flags.provider)
+
+	cmd.Flags().BoolVar(&flags.kernel, "kernel", true, "Stream the node's kernel messages (dmesg)")
+	cmd.Flags().StringSliceVar(&flags.services, "service", nil,
+		"Talos service whose logs to stream, e.g. kubelet, etcd, containerd (repeatable)")
+	cmd.Flags().BoolVarP(&flags.follow, "follow", "f", true, "Keep streaming new output until interrupted")
+	cmd.Flags().Int32Var(&flags.tailLines, "tail", defaultConsoleTailLines,
+		"Lines of each service log to show before following (-1 for the whole log)")
+
+	return cmd
+}
+
+// runConsoleCmd resolves the cluster and its Talos node, then streams the console.
+func runConsoleCmd(cmd *cobra.Command, nodeName string, flags consoleFlags) error {
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, flags.name, flags.provider, "")
+	if err != nil {
+		return fmt.Errorf("resolve cluster info: %w", err)
+	}
+
+	kubeContext, err := resolveClusterContext(resolved.KubeconfigPath, resolved.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	info, err := clusterdetector.DetectInfo(cmd.Context(), resolved.KubeconfigPath, kubeContext)
+	if err != nil {
+		return fmt.Errorf("detect cluster info: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	err = hostdebug.RunConsole(ctx, hostdebug.ConsoleOptions{
+		Info:           info,
+		KubeconfigPath: resolved.KubeconfigPath,
+		ContextName:    kubeContext,
+		NodeName:       nodeName,
+		Kernel:         flags.kernel,
+		Services:       flags.services,
+		Follow:         flags.follow,
+		TailLines:      flags.tailLines,
+		Out:            cmd.OutOrStdout(),
+	})
+	if err != nil {
+		return fmt.Errorf("stream console of node %q: %w", nodeName, err)
+	}
+
+	return nil
+}
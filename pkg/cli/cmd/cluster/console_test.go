@@ -0,0 +1,43 @@
+package cluster_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsoleCmd(t *testing.T) {
+	t.Parallel()
+
+	cmd := cluster.NewConsoleCmd()
+
+	assert.Equal(t, "console", cmd.Name())
+
+	defaults := map[string]string{
+		"name":     "",
+		"provider": "",
+		"kernel":   "true",
+		"service":  "[]",
+		"follow":   "true",
+		"tail":     "100",
+	}
+	for flag, want := range defaults {
+		lookup := cmd.Flags().Lookup(flag)
+		require.NotNil(t, lookup, flag)
+		assert.Equal(t, want, lookup.DefValue, flag)
+	}
+}
+
+func TestConsoleCmd_RequiresNode(t *testing.T) {
+	t.Parallel()
+
+	cmd := cluster.NewConsoleCmd()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{})
+
+	require.Error(t, cmd.Execute())
+}
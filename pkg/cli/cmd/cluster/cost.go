@@ -0,0 +1,200 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cost"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/hetzner"
+	"github.com/spf13/cobra"
+)
+
+// costLongDesc describes the `ksail cluster cost` command.
+const costLongDesc = `Estimate the hourly and monthly cost of the cluster defined in ksail.yaml
+before creating it.
+
+The estimate is priced from the provider's price catalog for the resources
+KSail provisions: servers for every control-plane and worker node, their public
+IPv4 addresses, and the optional floating IP. Load balancers and volumes that
+workloads create later are usage-based; their unit prices are listed as notes.
+
+Supported providers:
+  - Hetzner: priced via the Hetzner Cloud pricing API (requires the API token
+    from spec.provider.hetzner.tokenEnvVar, default HCLOUD_TOKEN)
+  - Docker, Kubernetes: no cloud cost
+
+The same estimate is printed by 'ksail cluster create --dry-run'.
+No resources are created; this is a read-only operation.
+
+Use --control-planes and --workers to price a different node count without
+editing ksail.yaml, and --output json for machine-readable output.`
+
+// NewCostCmd creates the cluster cost command.
+func NewCostCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Estimate the cloud cost of a cluster",
+		Long:  costLongDesc,
+		Annotations: map[string]string{
+			annotations.AnnotationDescription: "Estimate the hourly and monthly cloud cost of the configured cluster",
+		},
+		SilenceUsage: true,
+	}
+
+	selectors := append(
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+		ksailconfigmanager.DefaultProviderFieldSelector(),
+		ksailconfigmanager.ControlPlanesFieldSelector(),
+		ksailconfigmanager.WorkersFieldSelector(),
+	)
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(cmd, selectors)
+
+	hideConfigOnlyFlags(cmd)
+
+	cmd.Flags().String("output", outputFormatText,
+		"Output format: text or json. Use json for machine-readable structured output.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		err := validateOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		return lifecycle.WrapHandler(cfgManager, handleCostRunE)(cmd, nil)
+	}
+
+	return cmd
+}
+
+// handleCostRunE estimates the cost of the loaded cluster configuration and
+// writes it in the requested format.
+func handleCostRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	_ lifecycle.Deps,
+) error {
+	clusterCfg := cfgManager.Config
+
+	err := clusterCfg.Spec.Cluster.Provider.ValidateForDistribution(clusterCfg.Spec.Cluster.Distribution)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	estimate, err := estimateClusterCost(cmd.Context(), clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	if getOutputFormat(cmd) == outputFormatJSON {
+		return writeCostJSON(cmd.OutOrStdout(), estimate)
+	}
+
+	writeCostText(cmd.OutOrStdout(), clusterCfg.Name, estimate)
+
+	return nil
+}
+
+// estimateClusterCost prices clusterCfg against its provider's price catalog.
+// Catalog clients are only constructed for the configured provider, so local
+// clusters are estimated without any cloud credentials.
+func estimateClusterCost(ctx context.Context, clusterCfg *v1alpha1.Cluster) (cost.Estimate, error) {
+	var estimator cost.Estimator
+
+	if clusterCfg.Spec.Cluster.Provider == v1alpha1.ProviderHetzner {
+		_, client, err := hetzner.NewProviderFromOptions(clusterCfg.Spec.Provider.Hetzner)
+		if err != nil {
+			return cost.Estimate{}, fmt.Errorf("estimate cluster cost: %w", err)
+		}
+
+		estimator.HetznerPricing = &client.Pricing
+	}
+
+	estimate, err := estimator.Estimate(ctx, clusterCfg)
+	if err != nil {
+		return cost.Estimate{}, fmt.Errorf("estimate cluster cost: %w", err)
+	}
+
+	return estimate, nil
+}
+
+// writeCostText renders the estimate as a table of line items followed by the
+// totals and any notes. Line-item prices share the estimate's currency.
+func writeCostText(writer io.Writer, clusterName string, estimate cost.Estimate) {
+	header := fmt.Sprintf("Estimated cost of cluster %q on %s", clusterName, estimate.Provider)
+	if estimate.Location != "" {
+		header += fmt.Sprintf(" (%s)", estimate.Location)
+	}
+
+	_, _ = fmt.Fprintln(writer, header+":")
+
+	if len(estimate.Items) > 0 {
+		table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+		_, _ = fmt.Fprintln(table, "  RESOURCE\tTYPE\tQTY\tHOURLY\tMONTHLY")
+
+		for _, item := range estimate.Items {
+			_, _ = fmt.Fprintf(table, "  %s\t%s\t%d\t%.4f\t%.2f\n",
+				item.Resource, item.Type, item.Quantity, item.Hourly, item.Monthly)
+		}
+
+		_ = table.Flush()
+	}
+
+	if estimate.Currency != "" {
+		_, _ = fmt.Fprintf(writer, "Total: %.4f %s/hour, %.2f %s/month\n",
+			estimate.Hourly, estimate.Currency, estimate.Monthly, estimate.Currency)
+	} else {
+		_, _ = fmt.Fprintln(writer, "Total: no cloud cost")
+	}
+
+	for _, note := range estimate.Notes {
+		_, _ = fmt.Fprintf(writer, "  • %s\n", note)
+	}
+}
+
+// writeCostJSON serialises the estimate as indented JSON.
+func writeCostJSON(writer io.Writer, estimate cost.Estimate) error {
+	data, err := json.MarshalIndent(estimate, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cost estimate: %w", err)
+	}
+
+	_, err = fmt.Fprintln(writer, string(data))
+	if err != nil {
+		return fmt.Errorf("write cost estimate: %w", err)
+	}
+
+	return nil
+}
+
+// reportCreateDryRun summarizes the cluster `cluster create --dry-run` would
+// provision together with its cost estimate. An estimate that cannot be
+// produced (no price catalog, missing credentials) is reported as a warning
+// rather than failing the dry run, since the configuration itself is valid.
+func reportCreateDryRun(cmd *cobra.Command, clusterName string, clusterCfg *v1alpha1.Cluster) {
+	spec := clusterCfg.Spec.Cluster
+
+	notify.Infof(cmd.OutOrStdout(),
+		"Would create cluster %q (%s on %s) with %d control-plane and %d worker node(s)",
+		clusterName, spec.Distribution, spec.Provider, spec.ControlPlanes, spec.Workers)
+
+	estimate, err := estimateClusterCost(cmd.Context(), clusterCfg)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "%v", err)
+	} else {
+		writeCostText(cmd.OutOrStdout(), clusterName, estimate)
+	}
+
+	reportSizingDryRun(cmd, spec)
+
+	notify.Infof(cmd.OutOrStdout(), "Dry run complete. No changes applied.")
+}
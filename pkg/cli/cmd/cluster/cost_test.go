@@ -0,0 +1,94 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCostCmd(t *testing.T) {
+	t.Parallel()
+
+	costCmd := cluster.NewCostCmd()
+	require.NotNil(t, costCmd)
+
+	assert.Equal(t, "cost", costCmd.Name())
+	assert.True(t, costCmd.SilenceUsage)
+
+	for _, flagName := range []string{"provider", "control-planes", "workers", "output"} {
+		assert.NotNil(t, costCmd.Flags().Lookup(flagName), "expected --%s flag", flagName)
+	}
+
+	assert.True(t, costCmd.Flags().Lookup("distribution").Hidden)
+}
+
+func TestCostCmd_InvalidFormatRejectsEarly(t *testing.T) {
+	t.Parallel()
+
+	costCmd := cluster.NewCostCmd()
+	costCmd.SetOut(io.Discard)
+	costCmd.SetErr(io.Discard)
+	costCmd.SetArgs([]string{"--output", "xml"})
+
+	err := costCmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrUnsupportedOutputFormat)
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestCostCmd_DockerJSON(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	writeTestConfigFiles(t, workingDir)
+
+	costCmd := cluster.NewCostCmd()
+
+	var out bytes.Buffer
+	costCmd.SetOut(&out)
+	costCmd.SetErr(&out)
+	costCmd.SetContext(context.Background())
+	costCmd.SetArgs([]string{"--output", "json"})
+
+	err := costCmd.Execute()
+	require.NoError(t, err, out.String())
+
+	var estimate cost.Estimate
+
+	require.NoError(t, json.Unmarshal(out.Bytes(), &estimate), out.String())
+	assert.Equal(t, "Docker", string(estimate.Provider))
+	assert.Empty(t, estimate.Items)
+	assert.Zero(t, estimate.Monthly)
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates shared test hooks
+func TestCreate_DryRun_DoesNotProvision(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	writeTestConfigFiles(t, workingDir)
+
+	// Any provisioner use fails the test run, proving --dry-run stops before it.
+	restoreFactory := cluster.SetProvisionerFactoryForTests(fakeFactoryWithErrors{})
+	defer restoreFactory()
+
+	createCmd := cluster.NewCreateCmd()
+
+	var out bytes.Buffer
+	createCmd.SetOut(&out)
+	createCmd.SetErr(&out)
+	createCmd.SetContext(context.Background())
+	createCmd.SetArgs([]string{"--dry-run", "--workers", "2"})
+
+	err := createCmd.Execute()
+	require.NoError(t, err, out.String())
+
+	assert.Contains(t, out.String(), "with 1 control-plane and 2 worker node(s)")
+	assert.Contains(t, out.String(), "Total: no cloud cost")
+	assert.Contains(t, out.String(), "Dry run complete. No changes applied.")
+}
@@ -16,15 +16,20 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/mirrorregistry"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
 	k3dconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/k3d"
 	kindconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/kind"
 	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	talosconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/talos"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
 	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	kindprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/kind"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/trust"
 	"github.com/devantler-tech/ksail/v7/pkg/timer"
 	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"github.com/spf13/cobra"
@@ -58,9 +63,33 @@ func newCreateLifecycleConfig() lifecycle.Config {
 // NewCreateCmd wires the cluster create command.
 func NewCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "create",
-		Short:        "Create a cluster",
-		Long:         `Create a Kubernetes cluster as defined by configuration.`,
+		Use:   "create",
+		Short: "Create a cluster",
+		Long: `Create a Kubernetes cluster as defined by configuration.
+
+Use --dry-run to validate the configuration and print the planned cluster
+with its estimated hourly and monthly cost (see 'ksail cluster cost')
+without provisioning anything.
+
+Before creating a local (Docker) cluster, a pre-flight sizing check estimates
+the memory and CPU it needs from the node count, distribution, and selected
+components, and compares that with what the container engine has available.
+A cluster that would not fit is refused with suggestions to shrink it (fewer
+nodes, a lighter CNI or distribution); one that fits tightly is created with a
+warning. Pass --skip-sizing-check to create it anyway.
+
+Use --from-bundle to create the cluster from a bundle exported with
+'ksail cluster export': its signature and file digests are verified, its
+project files are extracted into the current directory (existing files are
+never overwritten), and the cluster is created from its ksail.yaml. After
+creation, the installed component versions are compared with the bundled
+ones. Pass --trusted-key to require the bundle to be signed by a known key.
+
+Use --from-pool to claim a stopped spare kept by 'ksail cluster pool' whose
+configuration matches instead of creating a new cluster. The spare is started
+and keeps its pool name (<name>-pool-<n>), and <name> is recorded as an alias
+of it, so start, stop, delete and the other cluster commands resolving <name>
+act on the spare. When no spare matches, the cluster is created as usual.`,
 		SilenceUsage: true,
 		Annotations: map[string]string{
 			annotations.AnnotationPermission: permissionWrite,
@@ -72,7 +101,20 @@ func NewCreateCmd() *cobra.Command {
 	cmd.Flags().String("ttl", "",
 		"Auto-destroy cluster after duration (e.g. 1h, 30m, 2h30m). If not set, cluster persists indefinitely.")
 
-	cmd.RunE = lifecycle.WrapHandler(cfgManager, handleCreateRunE)
+	cmd.Flags().Bool("dry-run", false,
+		"Validate the configuration and print the planned cluster with its cost estimate without creating it")
+
+	cmd.Flags().Bool(skipSizingCheckFlag, false,
+		"Create a local cluster even when the pre-flight sizing check estimates it needs more memory than the container engine has")
+
+	cmd.Flags().Bool(fromPoolFlag, false,
+		"Claim and start a matching stopped spare from 'ksail cluster pool' instead of creating a new cluster")
+
+	registerBundleFlags(cmd)
+
+	lifecycle.AddClientModeFlag(cmd)
+
+	cmd.RunE = withBundle(cfgManager, lifecycle.WrapHandler(cfgManager, handleCreateRunE))
 
 	return cmd
 }
@@ -102,6 +144,38 @@ func handleCreateRunE(
 		return err
 	}
 
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		reportCreateDryRun(cmd, clusterName, ctx.ClusterCfg)
+
+		return nil
+	}
+
+	fromPool, _ := cmd.Flags().GetBool(fromPoolFlag)
+	if fromPool {
+		spareName, err := claimPoolSpare(cmd, ctx)
+		if err != nil {
+			return err
+		}
+
+		if spareName != "" {
+			return finishPoolClaim(cmd, ctx, clusterName, spareName)
+		}
+
+		notify.Warningf(cmd.OutOrStderr(),
+			"no pool spare matches this configuration; creating a new cluster")
+	}
+
+	err = runSizingPreflight(cmd, ctx.ClusterCfg.Spec.Cluster)
+	if err != nil {
+		return err
+	}
+
+	err = runAPIServerPortPreflight(clusterName, ctx.ClusterCfg.Spec.Cluster)
+	if err != nil {
+		return err
+	}
+
 	controllerReconciliationStarted, creationErr := runClusterCreationWorkflow(
 		cmd,
 		cfgManager,
@@ -127,6 +201,10 @@ func handleCreateRunE(
 		notify.Warningf(cmd.OutOrStderr(), "failed to save cluster state: %v", saveErr)
 	}
 
+	publishClusterState(cmd, ctx, clusterName)
+
+	recordClusterAudit(cmd, ctx, audit.NewEntry(audit.OperationCreate, clusterName))
+
 	return finishCreateWithTTL(
 		requiredStateErr,
 		func() error {
@@ -276,6 +354,83 @@ func maybeImportCachedImages(
 	}
 }
 
+// applyNodeResources sets the CPU and memory limits of spec.cluster.nodes on the
+// Kind and K3d node containers. Talos applies them when creating its nodes, and
+// other distributions reject spec.cluster.nodes during validation.
+func applyNodeResources(cmd *cobra.Command, ctx *localregistry.Context) error {
+	spec := ctx.ClusterCfg.Spec.Cluster
+	if len(spec.Nodes) == 0 {
+		return nil
+	}
+
+	var scheme dockerprovider.LabelScheme
+
+	//nolint:exhaustive // only Kind and K3d nodes are customized after creation
+	switch spec.Distribution {
+	case v1alpha1.DistributionVanilla:
+		scheme = dockerprovider.LabelSchemeKind
+	case v1alpha1.DistributionK3s:
+		scheme = dockerprovider.LabelSchemeK3d
+	default:
+		return nil
+	}
+
+	clusterName := resolveClusterNameFromContext(ctx)
+
+	return withDockerClient(cmd, func(dockerClient dockerclient.Client) error {
+		prov := dockerprovider.NewProvider(dockerClient, scheme)
+
+		err := prov.SetNodeResources(cmd.Context(), clusterName, spec)
+		if err != nil {
+			return fmt.Errorf("failed to apply node resources: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// installNodeTrustBundle adds the spec.trust.caBundles certificates to the OS
+// trust store of Kind nodes. Talos nodes get them through a TrustedRootsConfig
+// machine-config patch at create time instead; other distributions only
+// receive the trust-manager ConfigMap.
+func installNodeTrustBundle(cmd *cobra.Command, ctx *localregistry.Context) error {
+	bundle, err := trust.LoadBundle(ctx.ClusterCfg.Spec.Trust)
+	if err != nil {
+		return fmt.Errorf("failed to load trust CA bundles: %w", err)
+	}
+
+	if bundle == "" {
+		return nil
+	}
+
+	//nolint:exhaustive // only Kind and Talos nodes trust the bundle at the OS level
+	switch ctx.ClusterCfg.Spec.Cluster.Distribution {
+	case v1alpha1.DistributionVanilla:
+	case v1alpha1.DistributionTalos:
+		return nil
+	default:
+		notify.Warningf(cmd.OutOrStdout(),
+			"spec.trust.caBundles is not installed into %s node trust stores; "+
+				"only the trust-manager ConfigMap is published",
+			ctx.ClusterCfg.Spec.Cluster.Distribution)
+
+		return nil
+	}
+
+	clusterName := resolveClusterNameFromContext(ctx)
+
+	return withDockerClient(cmd, func(dockerClient dockerclient.Client) error {
+		err := kindprovisioner.InstallTrustBundle(
+			cmd.Context(), dockerClient, clusterName, trust.BundleName, bundle,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to install trust bundle on nodes: %w", err)
+		}
+
+		return nil
+	})
+}
+
 func loadClusterConfiguration(
 	cfgManager *ksailconfigmanager.ConfigManager,
 	tmr timer.Timer,
@@ -421,7 +576,8 @@ func configureRegistryMirrorsInClusterWithWarning(
 	}
 }
 
-// handlePostCreationSetup installs CNI, CSI, cert-manager, metrics-server, and GitOps engines.
+// handlePostCreationSetup installs CNI, CSI, cert-manager, metrics-server, and GitOps engines,
+// then creates the spec.seed ConfigMaps and Secrets.
 func handlePostCreationSetup(
 	cmd *cobra.Command,
 	clusterCfg *v1alpha1.Cluster,
@@ -447,6 +603,11 @@ func handlePostCreationSetup(
 		return true, fmt.Errorf("failed to install post-CNI components: %w", err)
 	}
 
+	err = applySeed(cmd, clusterCfg, clusterCfg.Spec.Cluster.Connection.Context)
+	if err != nil {
+		return true, err
+	}
+
 	// Configure OIDC kubeconfig entries when OIDC is enabled
 	if clusterCfg.Spec.Cluster.OIDC.Enabled() {
 		oidcErr := configureOIDCKubeconfig(cmd, clusterCfg)
@@ -597,6 +758,28 @@ func setupK3dLoadBalancer(clusterCfg *v1alpha1.Cluster, k3dConfig *v1alpha5.Simp
 	)
 }
 
+// setupK3dIngressController disables K3s's built-in Traefik when KSail installs an
+// ingress controller. The bundled Traefik would otherwise compete for the default
+// IngressClass and for the host ports mapped onto the controller's node ports.
+func setupK3dIngressController(clusterCfg *v1alpha1.Cluster, k3dConfig *v1alpha5.SimpleConfig) {
+	if clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionK3s || k3dConfig == nil {
+		return
+	}
+
+	if !clusterCfg.Spec.Cluster.IngressController.Enabled() ||
+		hasK3sArgForServers(k3dConfig, k3sDisableTraefikFlag) {
+		return
+	}
+
+	k3dConfig.Options.K3sOptions.ExtraArgs = append(
+		k3dConfig.Options.K3sOptions.ExtraArgs,
+		v1alpha5.K3sArgWithNodeFilters{
+			Arg:         k3sDisableTraefikFlag,
+			NodeFilters: []string{k3dAllServersNodeFilter},
+		},
+	)
+}
+
 // setupVClusterCNI configures the vCluster to disable flannel when a non-default
 // CNI (Cilium or Calico) is selected. Without this, the vCluster starts flannel,
 // causing conflicts when the custom CNI is installed post-creation.
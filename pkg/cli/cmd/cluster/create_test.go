@@ -412,7 +412,7 @@ func TestCreate_EKSProfileRegionFeedsIdentityCapture(t *testing.T) {
 	require.NoError(t, err)
 	//nolint:gosec // G101: these are environment-variable names, never credential values.
 	assert.Equal(t, v1alpha1.OptionsAWS{
-		ProfileEnvVar:         "KSAIL_PROFILE",
+		ProfileEnvVar:         "KSAIL_AWS_PROFILE",
 		RegionEnvVar:          "AWS_REGION",
 		AccessKeyIDEnvVar:     "AWS_ACCESS_KEY_ID",
 		SecretAccessKeyEnvVar: "AWS_SECRET_ACCESS_KEY",
@@ -439,7 +439,7 @@ spec:
       kubeconfig: ./kubeconfig
   provider:
     aws:
-      profileEnvVar: KSAIL_PROFILE
+      profileEnvVar: KSAIL_AWS_PROFILE
 `)
 	writeFile(t, workingDir, "eks.yaml", `apiVersion: eksctl.io/v1alpha5
 kind: ClusterConfig
@@ -481,7 +481,7 @@ region = eu-north-1
 	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
 	t.Setenv("AWS_SESSION_TOKEN", "")
 	t.Setenv("AWS_PROFILE", "")
-	t.Setenv("KSAIL_PROFILE", "selected-profile")
+	t.Setenv("KSAIL_AWS_PROFILE", "selected-profile")
 	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsFile)
 	t.Setenv("AWS_CONFIG_FILE", configFile)
 }
@@ -1099,6 +1099,34 @@ func TestSetupK3dCSI_DoesNothingWhenCSINotDisabled(t *testing.T) {
 	}
 }
 
+func TestSetupK3dIngressController_DisablesBuiltInTraefik(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Cluster: v1alpha1.ClusterSpec{
+				Distribution:      v1alpha1.DistributionK3s,
+				IngressController: v1alpha1.IngressControllerNginx,
+			},
+		},
+	}
+	k3dConfig := &v1alpha5.SimpleConfig{}
+
+	cluster.ExportSetupK3dIngressController(clusterCfg, k3dConfig)
+	cluster.ExportSetupK3dIngressController(clusterCfg, k3dConfig)
+
+	assert.Equal(t, []v1alpha5.K3sArgWithNodeFilters{
+		{Arg: "--disable=traefik", NodeFilters: []string{"server:*"}},
+	}, k3dConfig.Options.K3sOptions.ExtraArgs)
+
+	clusterCfg.Spec.Cluster.IngressController = v1alpha1.IngressControllerNone
+	k3dConfig = &v1alpha5.SimpleConfig{}
+
+	cluster.ExportSetupK3dIngressController(clusterCfg, k3dConfig)
+
+	assert.Empty(t, k3dConfig.Options.K3sOptions.ExtraArgs)
+}
+
 func TestSetupK3dCNI_CiliumDisablesFlannelNetworkPolicyAndTraefik(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,227 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/clusterflags"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/mirrorregistry"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/netdebug"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// debugNetworkLongDesc describes the `ksail cluster debug network` command.
+const debugNetworkLongDesc = `Check DNS and connectivity from inside the cluster.
+
+Starts a diagnostic pod (the Kubernetes e2e agnhost image, which ships
+nslookup, dig and curl) and a peer pod on every Ready node, then runs:
+
+  cluster-dns   resolve kubernetes.default through CoreDNS
+  external-dns  resolve a public name (--external-host) through CoreDNS
+  api-server    request /livez through the kubernetes Service
+  registry      request /v2/ on the upstream of each registry mirror
+  pod-network   request the peer pod on each node by its pod IP
+
+The results are printed as a pass/fail matrix, followed by the output and a
+remediation hint for every failed check. The pods are deleted afterwards,
+also when interrupted with Ctrl-C.
+
+Registry mirrors default to the ones KSail configures for local clusters;
+pass --mirror-registry with the specs used at creation to check others (only
+their upstreams are used), or --mirror-registry "" to skip the registry checks.
+
+The command exits non-zero when any check fails.`
+
+// newDebugNetworkClient builds the Kubernetes clientset the debug network command
+// uses; a package-level seam so tests can substitute a fake clientset.
+//
+//nolint:gochecknoglobals // Test seam: lets tests inject a fake clientset without a live cluster.
+var newDebugNetworkClient = func(kubeconfigPath string) (kubernetes.Interface, error) {
+	client, err := k8s.NewClientset(kubeconfigPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("build kubernetes client: %w", err)
+	}
+
+	return client, nil
+}
+
+// runNetworkDiagnosis is the in-cluster diagnosis the command drives; a
+// package-level seam so tests can skip scheduling real pods.
+//
+//nolint:gochecknoglobals // Test seam: lets tests stub the in-cluster checks.
+var runNetworkDiagnosis = netdebug.Run
+
+type debugNetworkFlags struct {
+	name         string
+	provider     v1alpha1.Provider
+	namespace    string
+	image        string
+	externalHost string
+	timeout      time.Duration
+	output       string
+}
+
+// NewDebugCmd creates the parent 'debug' command group.
+func NewDebugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Debug cluster subsystems from inside the cluster",
+		Long: `Debug cluster subsystems by running diagnostic workloads inside the cluster.
+
+Unlike 'ksail cluster diagnose', which reports failing resources from the
+Kubernetes API, debug commands exercise the cluster from a pod's point of view.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewDebugNetworkCmd())
+
+	return cmd
+}
+
+// NewDebugNetworkCmd creates the 'debug network' command.
+func NewDebugNetworkCmd() *cobra.Command {
+	flags := &debugNetworkFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Check DNS and connectivity from inside the cluster",
+		Long:  debugNetworkLongDesc,
+		Example: `  # Run all checks against the current cluster
+  ksail cluster debug network
+
+  # Check a custom mirror and emit the results as JSON
+  ksail cluster debug network --mirror-registry ghcr.io=https://ghcr.io --output json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDebugNetwork(cmd, flags)
+		},
+	}
+
+	lifecycle.BindNameAndProviderFlags(cmd, &flags.name, &flags.provider)
+
+	cmd.Flags().StringVar(&flags.namespace, "namespace", netdebug.DefaultNamespace,
+		"Namespace the diagnostic pods run in")
+	cmd.Flags().StringVar(&flags.image, "image", netdebug.DefaultImage(),
+		"agnhost image the diagnostic pods run")
+	cmd.Flags().StringVar(&flags.externalHost, "external-host", netdebug.DefaultExternalHost,
+		"Public host name resolved by the external-dns check")
+	clusterflags.RegisterMirrorRegistryFlag(cmd)
+	cmd.Flags().DurationVar(&flags.timeout, "timeout", netdebug.DefaultTimeout,
+		"How long to wait for the diagnostic pods to start and finish")
+	cmd.Flags().StringVar(&flags.output, "output", outputFormatText,
+		"Output format: text or json")
+
+	return cmd
+}
+
+// runDebugNetwork runs the network checks against the resolved cluster and
+// prints their results.
+func runDebugNetwork(cmd *cobra.Command, flags *debugNetworkFlags) error {
+	format := strings.ToLower(flags.output)
+	if format != outputFormatText && format != outputFormatJSON {
+		return fmt.Errorf("%w: %q (expected %q or %q)",
+			ErrUnsupportedOutputFormat, format, outputFormatText, outputFormatJSON)
+	}
+
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, flags.name, flags.provider, "")
+	if err != nil {
+		return fmt.Errorf("resolve cluster info: %w", err)
+	}
+
+	client, err := newDebugNetworkClient(resolved.KubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	// Cancel on Ctrl-C instead of exiting, so the pods are still cleaned up.
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := runNetworkDiagnosis(ctx, client, netdebug.Options{
+		Namespace:    flags.namespace,
+		Image:        flags.image,
+		ExternalHost: flags.externalHost,
+		Registries:   debugNetworkRegistries(cmd),
+		Timeout:      flags.timeout,
+	}, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("debug network of cluster %q: %w", resolved.ClusterName, err)
+	}
+
+	err = writeNetworkReport(cmd, format, resolved.ClusterName, report)
+	if err != nil {
+		return err
+	}
+
+	if failed := report.Failed(); failed > 0 {
+		return fmt.Errorf("%w: %d of %d", netdebug.ErrChecksFailed, failed, len(report.Results))
+	}
+
+	return nil
+}
+
+// debugNetworkRegistries returns the upstream URLs of the mirror specs to check:
+// the --mirror-registry values when set, otherwise KSail's default mirrors.
+func debugNetworkRegistries(cmd *cobra.Command) []string {
+	mirrors := mirrorregistry.DefaultMirrors
+	if cmd.Flags().Changed(mirrorregistry.MirrorRegistryFlag) {
+		mirrors, _ = cmd.Flags().GetStringSlice(mirrorregistry.MirrorRegistryFlag)
+	}
+
+	specs := registry.ParseMirrorSpecs(mirrors)
+	urls := make([]string, 0, len(specs))
+
+	for _, spec := range specs {
+		remote := spec.Remote
+		if remote == "" {
+			remote = registry.GenerateUpstreamURL(spec.Host)
+		}
+
+		urls = append(urls, strings.TrimSuffix(remote, "/"))
+	}
+
+	return urls
+}
+
+// writeNetworkReport writes report as a pass/fail matrix or as JSON.
+func writeNetworkReport(cmd *cobra.Command, format, clusterName string, report netdebug.Report) error {
+	writer := cmd.OutOrStdout()
+
+	if format == outputFormatJSON {
+		enc := json.NewEncoder(writer)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+
+		err := enc.Encode(report)
+		if err != nil {
+			return fmt.Errorf("encode network report: %w", err)
+		}
+
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(writer, "Network checks for cluster %q from node %s:\n\n", clusterName, report.Node)
+
+	err := netdebug.WriteMatrix(writer, report)
+	if err != nil {
+		return fmt.Errorf("write network report: %w", err)
+	}
+
+	return nil
+}
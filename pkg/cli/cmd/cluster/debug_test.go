@@ -0,0 +1,105 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/netdebug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewDebugCmd(t *testing.T) {
+	t.Parallel()
+
+	debugCmd := cluster.NewDebugCmd()
+
+	networkCmd, _, err := debugCmd.Find([]string{"network"})
+	require.NoError(t, err)
+	assert.Equal(t, "network", networkCmd.Name())
+
+	for _, flag := range []string{"name", "namespace", "image", "external-host", "mirror-registry", "timeout", "output"} {
+		assert.NotNil(t, networkCmd.Flags().Lookup(flag), flag)
+	}
+}
+
+// runDebugNetworkCmd executes `debug network` with args against a stubbed
+// diagnosis returning report, and returns its stdout, the options the
+// diagnosis received, and the command error.
+func runDebugNetworkCmd(
+	t *testing.T,
+	report netdebug.Report,
+	args ...string,
+) (string, netdebug.Options, error) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	var received netdebug.Options
+
+	restore := cluster.ExportSetDebugNetwork(fake.NewClientset(),
+		func(_ context.Context, _ kubernetes.Interface, opts netdebug.Options, _ io.Writer) (netdebug.Report, error) {
+			received = opts
+
+			return report, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := cluster.NewDebugNetworkCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(append([]string{"--name", "dev"}, args...))
+
+	err := cmd.ExecuteContext(context.Background())
+
+	return out.String(), received, err
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates the debug network seams
+func TestDebugNetworkCmd_PrintsMatrix(t *testing.T) {
+	out, opts, err := runDebugNetworkCmd(t, netdebug.Report{
+		Node: "dev-worker",
+		Results: []netdebug.Result{
+			{Check: netdebug.CheckClusterDNS, Target: "kubernetes.default", Passed: true},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, out, `Network checks for cluster "dev" from node dev-worker:`)
+	assert.Contains(t, out, "cluster-dns  kubernetes.default  pass")
+	assert.Contains(t, opts.Registries, "https://registry-1.docker.io", "defaults to KSail's mirrors")
+	assert.Equal(t, netdebug.DefaultExternalHost, opts.ExternalHost)
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates the debug network seams
+func TestDebugNetworkCmd_FailedChecksExitNonZero(t *testing.T) {
+	out, opts, err := runDebugNetworkCmd(t, netdebug.Report{
+		Results: []netdebug.Result{
+			{Check: netdebug.CheckRegistry, Target: "https://ghcr.io", Hint: "check egress"},
+			{Check: netdebug.CheckAPIServer, Target: "https://kubernetes.default.svc/livez", Passed: true},
+		},
+	}, "--mirror-registry", "ghcr.io", "--output", "json")
+
+	require.ErrorIs(t, err, netdebug.ErrChecksFailed)
+	assert.Contains(t, err.Error(), "1 of 2")
+	assert.Equal(t, []string{"https://ghcr.io"}, opts.Registries)
+
+	var report netdebug.Report
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, "check egress", report.Results[0].Hint)
+}
+
+//nolint:paralleltest // uses t.Chdir and mutates the debug network seams
+func TestDebugNetworkCmd_InvalidOutput(t *testing.T) {
+	_, _, err := runDebugNetworkCmd(t, netdebug.Report{}, "--output", "yaml")
+
+	require.ErrorIs(t, err, cluster.ErrUnsupportedOutputFormat)
+}
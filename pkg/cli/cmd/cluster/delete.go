@@ -17,6 +17,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/k8s"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	veleroinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/velero"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clustererr"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
@@ -32,8 +33,9 @@ import (
 type deleteFlags struct {
 	lifecycle.ClusterTargetFlags
 
-	storage bool
-	force   bool
+	storage       bool
+	force         bool
+	forceDataLoss bool
 }
 
 // NewDeleteCmd creates and returns the delete command.
@@ -51,7 +53,9 @@ func NewDeleteCmd() *cobra.Command {
 			annotations.AnnotationPermission: permissionWrite,
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runDeleteAction(cmd, flags)
+			return lifecycle.RunWithClientMode(cmd, func() error {
+				return runDeleteAction(cmd, flags)
+			})
 		},
 	}
 
@@ -71,6 +75,10 @@ func registerDeleteFlags(cmd *cobra.Command, flags *deleteFlags) {
 		"Delete storage volumes when cleaning up (registry volumes for Docker, block storage for Hetzner)")
 	cmd.Flags().BoolVarP(&flags.force, "force", "f", false,
 		"Skip confirmation prompt and delete immediately")
+	cmd.Flags().BoolVar(&flags.forceDataLoss, forceDataLossFlagName, false,
+		"Delete even when the cluster holds persistent volume claims, LoadBalancer services, "+
+			"or namespaces KSail did not create")
+	lifecycle.AddClientModeFlag(cmd)
 	_ = cmd.Flags().SetAnnotation(
 		forceFlagName, annotations.AnnotationConfirmFlag,
 		[]string{annotations.AnnotationValueTrue},
@@ -122,6 +130,18 @@ func runDeleteAction(
 	// This must happen before deletion while kubeconfig is still available
 	detectedInfo, isKindCluster, clusterInfo := detectDeleteClusterInfo(cmd, resolved)
 
+	// Refuse to silently destroy data: a cluster holding volumes, load balancers, or namespaces
+	// KSail did not create is only deleted with an explicit --force-data-loss, whatever the TTY.
+	// --force only skips the prompt: the chat assistant injects it after approval.
+	err = guardImportantState(
+		cmd, resolved.KubeconfigPath, deleteKubeContext(resolved, detectedInfo),
+		fmt.Sprintf("deleting cluster %q", resolved.ClusterName),
+		forceDataLossFlagName, flags.forceDataLoss,
+	)
+	if err != nil {
+		return err
+	}
+
 	// Create provisioner for the provider
 	options := minimalProvisionerOptions(resolved, flags.storage)
 
@@ -138,6 +158,8 @@ func runDeleteAction(
 		return err
 	}
 
+	removeVeleroMinIOBeforeDelete(cmd, resolved)
+
 	// Delete the cluster
 	err = executeDelete(cmd, tmr, provisioner, resolved)
 	if err != nil {
@@ -485,6 +507,23 @@ func disconnectRegistriesBeforeDelete(
 	)
 }
 
+// removeVeleroMinIOBeforeDelete removes the cluster's Velero MinIO container so
+// it does not keep the cluster network alive. The data volume is kept, so the
+// backups are available again when a cluster of the same name enables Velero.
+// This is best-effort: a failure is reported as a warning.
+func removeVeleroMinIOBeforeDelete(cmd *cobra.Command, resolved *lifecycle.ResolvedClusterInfo) {
+	if resolved.Provider != v1alpha1.ProviderDocker {
+		return
+	}
+
+	err := withDockerClient(cmd, func(dockerClient dockerclient.Client) error {
+		return veleroinstaller.RemoveMinIO(cmd.Context(), dockerClient, resolved.ClusterName)
+	})
+	if err != nil {
+		notify.Warningf(cmd.OutOrStdout(), "failed to remove velero minio container: %v", err)
+	}
+}
+
 // buildDeletionPreview builds a preview of resources that will be deleted.
 func buildDeletionPreview(
 	cmd *cobra.Command,
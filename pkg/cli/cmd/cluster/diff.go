@@ -38,7 +38,21 @@ Use --include-version-drift to also report the distribution/Kubernetes version
 reconciliation 'ksail cluster update' applies on every run (matching
 'update --dry-run'). It is off by default: resolving the latest available
 version performs OCI registry lookups, so enabling it makes diff
-network-dependent and may report drift whenever upstream cuts a release.`
+network-dependent and may report drift whenever upstream cuts a release.
+
+Pass two cluster names to compare those clusters with each other instead, to
+explain why something works on one cluster but not the other:
+
+  ksail cluster diff mine theirs
+
+Both clusters are looked up in the kubeconfig by name. The comparison covers
+the nodes (Kubernetes version, control-plane and worker counts, OS image,
+container runtime), the cluster configuration, and the chart and app versions
+of KSail-managed components. Each configuration is read from the state KSail
+keeps in the cluster, then from the local state of the machine that created
+it, and is otherwise detected from the live cluster (in which case only the
+detectable components are compared). --exit-code returns exit code 2 when the
+clusters differ.`
 
 // NewDiffCmd creates the cluster diff command.
 func NewDiffCmd() *cobra.Command {
@@ -48,11 +62,13 @@ func NewDiffCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "diff",
-		Short: "Show configuration drift between ksail.yaml and live cluster",
+		Use:   "diff [<cluster-a> <cluster-b>]",
+		Short: "Show configuration drift, or compare two clusters",
 		Long:  diffLongDesc,
+		Args:  validateDiffArgs,
 		Annotations: map[string]string{
-			annotations.AnnotationDescription: "Compare desired cluster configuration against live state and report drift",
+			annotations.AnnotationDescription: "Compare desired cluster configuration against live state and report drift, " +
+				"or compare two clusters",
 		},
 		SilenceUsage: true,
 	}
@@ -78,7 +94,7 @@ func NewDiffCmd() *cobra.Command {
 
 	clusterflags.RegisterNameFlag(cmd, cfgManager)
 
-	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		// Validate output format before entering WrapHandler to avoid unnecessary
 		// DI when the format is obviously invalid. Mirrors the diagnose pattern.
 		err := validateOutputFormat(cmd)
@@ -88,6 +104,13 @@ func NewDiffCmd() *cobra.Command {
 
 		format := getOutputFormat(cmd)
 
+		if len(args) == diffClusterArgs {
+			return runClusterCompare(cmd, args[0], args[1], diffOptions{
+				exitCode: exitCodeFlag,
+				format:   format,
+			})
+		}
+
 		handler := lifecycle.WrapHandler(
 			cfgManager,
 			func(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager, deps lifecycle.Deps) error {
@@ -0,0 +1,194 @@
+package cluster
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clustercompare"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/spf13/cobra"
+)
+
+// diffClusterArgs is the number of positional arguments that switches
+// `ksail cluster diff` from drift detection to comparing two clusters.
+const diffClusterArgs = 2
+
+// ErrInvalidDiffArgs is returned when `ksail cluster diff` is given a number
+// of cluster names other than zero or two.
+var ErrInvalidDiffArgs = errors.New("cluster diff takes no arguments or exactly two cluster names")
+
+// ErrDiffFlagWithClusters is returned when a drift-only flag is combined with
+// two cluster names.
+var ErrDiffFlagWithClusters = errors.New("flag cannot be combined with two cluster names")
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	clusterCompareSourcesFactoryMu sync.RWMutex
+	clusterCompareSourcesFactory   = newClusterCompareSources
+)
+
+// newClusterCompareSources builds the clients a cluster snapshot is captured
+// through, scoped to kubeContext.
+func newClusterCompareSources(kubeconfigPath, kubeContext string) (clustercompare.Sources, error) {
+	clientset, err := k8s.NewClientset(kubeconfigPath, kubeContext)
+	if err != nil {
+		return clustercompare.Sources{}, fmt.Errorf("build kubernetes client: %w", err)
+	}
+
+	sources := clustercompare.Sources{Clientset: clientset, LocalSpec: state.LoadClusterSpec}
+
+	// Without Helm the comparison still covers nodes and the saved configuration.
+	helmClient, err := helm.NewClient(kubeconfigPath, kubeContext)
+	if err == nil {
+		sources.Helm = helmClient
+	}
+
+	return sources, nil
+}
+
+// validateDiffArgs accepts no arguments (drift detection) or two cluster names
+// (cluster comparison).
+func validateDiffArgs(_ *cobra.Command, args []string) error {
+	if len(args) != 0 && len(args) != diffClusterArgs {
+		return fmt.Errorf("%w, got %d", ErrInvalidDiffArgs, len(args))
+	}
+
+	return nil
+}
+
+// runClusterCompare captures both clusters, compares them, and renders the
+// differences in the requested format.
+func runClusterCompare(cmd *cobra.Command, nameA, nameB string, opts diffOptions) error {
+	for _, flag := range []string{"name", "include-version-drift"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s: %w", flag, ErrDiffFlagWithClusters)
+		}
+	}
+
+	snapshotA, err := captureCluster(cmd, nameA)
+	if err != nil {
+		return err
+	}
+
+	snapshotB, err := captureCluster(cmd, nameB)
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range []*clustercompare.Snapshot{snapshotA, snapshotB} {
+		for _, warning := range snapshot.Warnings {
+			notify.Warningf(cmd.ErrOrStderr(), "could not read %s of cluster %q", warning, snapshot.Name)
+		}
+	}
+
+	result := clustercompare.Compare(snapshotA, snapshotB)
+
+	if opts.format == outputFormatJSON {
+		err = writeClusterCompareJSON(cmd.OutOrStdout(), result)
+		if err != nil {
+			return err
+		}
+	} else {
+		writeClusterCompareText(cmd.OutOrStdout(), result)
+	}
+
+	if opts.exitCode && len(result.Differences) > 0 {
+		return &DriftExitError{Changes: len(result.Differences)}
+	}
+
+	return nil
+}
+
+// captureCluster resolves the kubeconfig context of clusterName and captures
+// its snapshot.
+func captureCluster(cmd *cobra.Command, clusterName string) (*clustercompare.Snapshot, error) {
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, clusterName, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("resolve cluster info: %w", err)
+	}
+
+	kubeContext, err := resolveClusterContext(resolved.KubeconfigPath, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if kubeContext == "" {
+		return nil, fmt.Errorf("%w: %s", ErrKubeconfigNotFound, resolved.KubeconfigPath)
+	}
+
+	clusterCompareSourcesFactoryMu.RLock()
+	factory := clusterCompareSourcesFactory
+	clusterCompareSourcesFactoryMu.RUnlock()
+
+	sources, err := factory(resolved.KubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := clustercompare.Capture(cmd.Context(), clusterName, sources)
+	if err != nil {
+		return nil, fmt.Errorf("capture cluster %q: %w", clusterName, err)
+	}
+
+	return snapshot, nil
+}
+
+// writeClusterCompareJSON writes the comparison as one indented JSON document.
+func writeClusterCompareJSON(writer io.Writer, result clustercompare.Result) error {
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(result)
+	if err != nil {
+		return fmt.Errorf("encode cluster comparison: %w", err)
+	}
+
+	return nil
+}
+
+// writeClusterCompareText writes the differences as a table with one column
+// per cluster. An unset field or a missing component is shown as "-".
+func writeClusterCompareText(writer io.Writer, result clustercompare.Result) {
+	nameA, nameB := result.A.Name, result.B.Name
+
+	_, _ = fmt.Fprintf(writer, "Comparing cluster %q (configuration: %s) with %q (configuration: %s)\n",
+		nameA, result.A.SpecSource, nameB, result.B.SpecSource)
+
+	if len(result.Differences) == 0 {
+		notify.Infof(writer, "No differences between clusters %q and %q", nameA, nameB)
+
+		return
+	}
+
+	_, _ = fmt.Fprintln(writer)
+
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(table, "SECTION\tFIELD\t%s\t%s\n", nameA, nameB)
+
+	for _, difference := range result.Differences {
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n",
+			difference.Section, difference.Field, orDash(difference.A), orDash(difference.B))
+	}
+
+	_ = table.Flush()
+
+	_, _ = fmt.Fprintf(writer, "\n%d difference(s)\n", len(result.Differences))
+}
+
+// orDash returns value, or "-" when it is empty.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,185 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clustercompare"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// compareStateTime stamps the in-cluster state of the compared clusters.
+var compareStateTime = time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+
+// compareNode is a node of a compared cluster.
+func compareNode(name, version string, controlPlane bool) *corev1.Node {
+	labels := map[string]string{}
+	if controlPlane {
+		labels["node-role.kubernetes.io/control-plane"] = ""
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+			KubeletVersion:          version,
+			OSImage:                 "Debian GNU/Linux 12 (bookworm)",
+			ContainerRuntimeVersion: "containerd://2.1.0",
+		}},
+	}
+}
+
+// useClusterCompareSources targets a kubeconfig with kind-mine and kind-theirs
+// contexts and routes each context to its own sources.
+func useClusterCompareSources(t *testing.T, sources map[string]clustercompare.Sources) {
+	t.Helper()
+
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+
+	kubeconfigPath := filepath.Join(workingDir, "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(`apiVersion: v1
+kind: Config
+current-context: kind-mine
+clusters:
+- cluster: {server: "https://127.0.0.1:6443"}
+  name: kind-mine
+- cluster: {server: "https://127.0.0.1:6444"}
+  name: kind-theirs
+contexts:
+- context: {cluster: kind-mine, user: kind-mine}
+  name: kind-mine
+- context: {cluster: kind-theirs, user: kind-theirs}
+  name: kind-theirs
+users:
+- name: kind-mine
+  user: {}
+- name: kind-theirs
+  user: {}
+`), 0o600))
+	t.Setenv("KUBECONFIG", kubeconfigPath)
+
+	t.Cleanup(cluster.SetClusterCompareSourcesFactoryForTests(
+		func(_, kubeContext string) (clustercompare.Sources, error) {
+			source, ok := sources[kubeContext]
+			require.True(t, ok, "unexpected context %s", kubeContext)
+
+			return source, nil
+		},
+	))
+}
+
+// differingClusterSources returns two clusters that differ in Kubernetes
+// version, worker count, cert-manager, and the Cilium release.
+func differingClusterSources(t *testing.T) map[string]clustercompare.Sources {
+	t.Helper()
+
+	ctx := context.Background()
+
+	mine := fake.NewClientset(
+		compareNode("mine-control-plane", "v1.35.1", true),
+		compareNode("mine-worker", "v1.35.1", false),
+	)
+	require.NoError(t, state.SaveInClusterState(ctx, mine, "mine", &v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionVanilla,
+		CNI:          v1alpha1.CNICilium,
+		CertManager:  v1alpha1.CertManagerEnabled,
+	}, nil, compareStateTime))
+
+	theirs := fake.NewClientset(compareNode("theirs-control-plane", "v1.34.0", true))
+	require.NoError(t, state.SaveInClusterState(ctx, theirs, "theirs", &v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionVanilla,
+		CNI:          v1alpha1.CNICilium,
+		CertManager:  v1alpha1.CertManagerDisabled,
+	}, nil, compareStateTime))
+
+	return map[string]clustercompare.Sources{
+		"kind-mine": {Clientset: mine, Helm: statusHelm(t, helm.ReleaseInfo{
+			Name: detector.ReleaseCilium, Namespace: detector.NamespaceCilium, Revision: 1,
+			Status: "deployed", Chart: "cilium-1.16.3", AppVersion: "1.16.3",
+		})},
+		"kind-theirs": {Clientset: theirs, Helm: statusHelm(t, helm.ReleaseInfo{
+			Name: detector.ReleaseCilium, Namespace: detector.NamespaceCilium, Revision: 2,
+			Status: "failed", Chart: "cilium-1.15.0", AppVersion: "1.15.0",
+		})},
+	}
+}
+
+// runDiff executes `cluster diff` with args and returns stdout and the error.
+func runDiff(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+
+	diffCmd := cluster.NewDiffCmd()
+	diffCmd.SetOut(&out)
+	diffCmd.SetErr(io.Discard)
+	diffCmd.SetContext(context.Background())
+	diffCmd.SetArgs(args)
+
+	err := diffCmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the cluster compare sources factory
+func TestDiffCmd_ComparesTwoClusters(t *testing.T) {
+	useClusterCompareSources(t, differingClusterSources(t))
+
+	out, err := runDiff(t, "mine", "theirs")
+	require.NoError(t, err, out)
+
+	snaps.MatchSnapshot(t, out)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the cluster compare sources factory
+func TestDiffCmd_ComparesTwoClustersAsJSONWithExitCode(t *testing.T) {
+	useClusterCompareSources(t, differingClusterSources(t))
+
+	out, err := runDiff(t, "mine", "theirs", "--output", "json", "--exit-code")
+	require.ErrorIs(t, err, cluster.ErrDriftDetected)
+
+	var result clustercompare.Result
+
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "mine", result.A.Name)
+	assert.Equal(t, clustercompare.SpecSourceInCluster, result.B.SpecSource)
+	assert.Len(t, result.Differences, 4)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the cluster compare sources factory
+func TestDiffCmd_IdenticalClusters(t *testing.T) {
+	sources := differingClusterSources(t)
+	sources["kind-theirs"] = sources["kind-mine"]
+
+	useClusterCompareSources(t, sources)
+
+	out, err := runDiff(t, "mine", "theirs", "--exit-code")
+	require.NoError(t, err, out)
+	assert.Contains(t, out, `No differences between clusters "mine" and "theirs"`)
+}
+
+func TestDiffCmd_RejectsInvalidClusterArgs(t *testing.T) {
+	t.Parallel()
+
+	_, err := runDiff(t, "mine")
+	require.ErrorIs(t, err, cluster.ErrInvalidDiffArgs)
+
+	_, err = runDiff(t, "mine", "theirs", "--include-version-drift")
+	require.ErrorIs(t, err, cluster.ErrDiffFlagWithClusters)
+}
@@ -16,8 +16,7 @@ func TestNewDiffCmd(t *testing.T) {
 	require.NotNil(t, cmd)
 
 	assert.Equal(t, "diff", cmd.Name())
-	assert.Equal(t, "Show configuration drift between ksail.yaml and live cluster",
-		cmd.Short)
+	assert.Equal(t, "Show configuration drift, or compare two clusters", cmd.Short)
 	assert.True(t, cmd.SilenceUsage)
 
 	nameFlag := cmd.Flags().Lookup("name")
@@ -206,7 +206,7 @@ func TestStandaloneEKSLifecycleFreezesCustomAWSCredentialsForEveryConsumer(t *te
 		standaloneEKSLifecycleCases()[1].expectedCalls(clusterName),
 		readStandaloneEKSCalls(t, markerPath),
 	)
-	assert.Equal(t, "selected-profile", os.Getenv("KSAIL_PROFILE"))
+	assert.Equal(t, "selected-profile", os.Getenv("KSAIL_AWS_PROFILE"))
 	assert.Equal(t, "fixture-access", os.Getenv("KSAIL_ACCESS"))
 	assert.Equal(t, "fixture-secret", os.Getenv("KSAIL_SECRET"))
 	assert.Equal(t, "fixture-session", os.Getenv("KSAIL_SESSION"))
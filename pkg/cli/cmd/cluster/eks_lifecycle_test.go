@@ -15,11 +15,13 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const standaloneEKSClusterFixture = `apiVersion: ksail.io/v1alpha1
@@ -35,7 +37,7 @@ spec:
       kubeconfig: kubeconfig
   provider:
     aws:
-      profileEnvVar: KSAIL_PROFILE
+      profileEnvVar: KSAIL_AWS_PROFILE
       regionEnvVar: KSAIL_REGION
       accessKeyIdEnvVar: KSAIL_ACCESS
       secretAccessKeyEnvVar: KSAIL_SECRET
@@ -54,7 +56,7 @@ const standaloneEKSEksctlFixture = `#!/bin/sh
 [ "${AWS_ACCESS_KEY_ID-}" = "fixture-access" ] || exit 42
 [ "${AWS_SECRET_ACCESS_KEY-}" = "fixture-secret" ] || exit 43
 [ "${AWS_SESSION_TOKEN-}" = "fixture-session" ] || exit 44
-[ -z "${KSAIL_PROFILE+x}" ] || exit 45
+[ -z "${KSAIL_AWS_PROFILE+x}" ] || exit 45
 [ -z "${KSAIL_ACCESS+x}" ] || exit 46
 [ -z "${KSAIL_SECRET+x}" ] || exit 47
 [ -z "${KSAIL_SESSION+x}" ] || exit 48
@@ -687,7 +689,7 @@ func TestStandaloneEKSStartAcceptsPersistedOwnershipWithoutConfig(t *testing.T)
 func setCustomAWSMappingEnvironment(t *testing.T, region string) {
 	t.Helper()
 
-	t.Setenv("KSAIL_PROFILE", "selected-profile")
+	t.Setenv("KSAIL_AWS_PROFILE", "selected-profile")
 	t.Setenv("KSAIL_REGION", region)
 	t.Setenv("KSAIL_ACCESS", "fixture-access")
 	t.Setenv("KSAIL_SECRET", "fixture-secret")
@@ -733,7 +735,7 @@ func TestStandaloneEKSStartRestoresCustomAWSMappingsFromOwnershipState(t *testin
 	require.NoError(t, err)
 
 	ownership.AWSOptions = v1alpha1.OptionsAWS{
-		ProfileEnvVar:         "KSAIL_PROFILE",
+		ProfileEnvVar:         "KSAIL_AWS_PROFILE",
 		RegionEnvVar:          "KSAIL_REGION",
 		AccessKeyIDEnvVar:     "KSAIL_ACCESS",
 		SecretAccessKeyEnvVar: "KSAIL_SECRET",
@@ -1063,7 +1065,7 @@ metadata:
 	assert.Equal(t, clusterName, captured.ConfigClusterName)
 	assert.Equal(t, v1alpha1.ProviderAWS, captured.Provider)
 	assert.Equal(t, "ap-southeast-2", captured.AWSRegion)
-	assert.Equal(t, "KSAIL_PROFILE", captured.AWSOpts.ProfileEnvVar)
+	assert.Equal(t, "KSAIL_AWS_PROFILE", captured.AWSOpts.ProfileEnvVar)
 	assert.Equal(t, "KSAIL_ACCESS", captured.AWSOpts.AccessKeyIDEnvVar)
 	assert.Equal(t, "KSAIL_SECRET", captured.AWSOpts.SecretAccessKeyEnvVar)
 	assert.Equal(t, "KSAIL_SESSION", captured.AWSOpts.SessionTokenEnvVar)
@@ -1284,7 +1286,7 @@ func standaloneEKSTTLClusterConfig() *v1alpha1.Cluster {
 	clusterCfg.Spec.Cluster.Provider = v1alpha1.ProviderAWS
 	clusterCfg.Spec.Cluster.Connection.Kubeconfig = "kubeconfig"
 	clusterCfg.Spec.Provider.AWS = v1alpha1.OptionsAWS{
-		ProfileEnvVar:         "KSAIL_PROFILE",
+		ProfileEnvVar:         "KSAIL_AWS_PROFILE",
 		RegionEnvVar:          "KSAIL_REGION",
 		AccessKeyIDEnvVar:     "KSAIL_ACCESS",
 		SecretAccessKeyEnvVar: "KSAIL_SECRET",
@@ -1677,7 +1679,7 @@ func setupStandaloneEKSLifecycleFixture(
 	t.Setenv("KSAIL_EKSCTL_MARKER", markerPath)
 	t.Setenv("KSAIL_EKS_CLUSTER", clusterName)
 	t.Setenv("HOME", t.TempDir())
-	t.Setenv("KSAIL_PROFILE", "selected-profile")
+	t.Setenv("KSAIL_AWS_PROFILE", "selected-profile")
 	t.Setenv("KSAIL_REGION", "ap-southeast-2")
 	t.Setenv("KSAIL_ACCESS", "fixture-access")
 	t.Setenv("KSAIL_SECRET", "fixture-secret")
@@ -1813,3 +1815,30 @@ func readStandaloneEKSCalls(t *testing.T, markerPath string) []string {
 
 	return strings.Split(trimmed, "\n")
 }
+
+func TestWarnShortAssumeRoleSession(t *testing.T) {
+	t.Parallel()
+
+	warning := func(options v1alpha1.OptionsAWS) string {
+		var stderr bytes.Buffer
+
+		cmd := &cobra.Command{}
+		cmd.SetErr(&stderr)
+
+		resolution := credentials.ResolveAWS(credentials.NewAWSOptionsResolver(options))
+		cluster.ExportWarnShortAssumeRoleSession(cmd, &resolution)
+
+		return stderr.String()
+	}
+
+	const roleARN = "arn:aws:iam::123456789012:role/ksail"
+
+	assert.Contains(t,
+		warning(v1alpha1.OptionsAWS{
+			AssumeRoleARN:      roleARN,
+			AssumeRoleDuration: metav1.Duration{Duration: 20 * time.Minute},
+		}),
+		"assumeRoleDuration 20m0s is shorter than a typical EKS create (30m0s)")
+	assert.Empty(t, warning(v1alpha1.OptionsAWS{AssumeRoleARN: roleARN}), "the 1h default is long enough")
+	assert.Empty(t, warning(v1alpha1.OptionsAWS{}), "without a role there is no session to expire")
+}
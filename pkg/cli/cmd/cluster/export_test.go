@@ -10,12 +10,15 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
 	eksctlclient "github.com/devantler-tech/ksail/v7/pkg/client/eksctl"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
 	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterdiscovery"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
 	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/eksidentity"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/netdebug"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	awsprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/aws"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
@@ -25,6 +28,7 @@ import (
 	v1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -77,6 +81,11 @@ func ExportSetupK3dCSI(clusterCfg *v1alpha1.Cluster, k3dConfig *v1alpha5.SimpleC
 	setupK3dCSI(clusterCfg, k3dConfig)
 }
 
+// ExportSetupK3dIngressController exports setupK3dIngressController for testing.
+func ExportSetupK3dIngressController(clusterCfg *v1alpha1.Cluster, k3dConfig *v1alpha5.SimpleConfig) {
+	setupK3dIngressController(clusterCfg, k3dConfig)
+}
+
 // ExportSetupK3dCNI exports setupK3dCNI for testing.
 func ExportSetupK3dCNI(clusterCfg *v1alpha1.Cluster, k3dConfig *v1alpha5.SimpleConfig) {
 	setupK3dCNI(clusterCfg, k3dConfig)
@@ -162,6 +171,26 @@ func ExportEnsureConfiguredContextResolvable(clusterCfg *v1alpha1.Cluster) error
 	return ensureConfiguredContextResolvable(clusterCfg)
 }
 
+// ExportPublishClusterState exports publishClusterState for testing.
+func ExportPublishClusterState(cmd *cobra.Command, ctx *localregistry.Context, clusterName string) {
+	publishClusterState(cmd, ctx, clusterName)
+}
+
+// ExportReportNodeUpgradePlan exports the upgrade command's node plan output for testing.
+func ExportReportNodeUpgradePlan(cmd *cobra.Command, ctx *localregistry.Context, osTarget string) {
+	(&updateOrchestrator{cmd: cmd, ctx: ctx}).reportNodeUpgradePlan(osTarget)
+}
+
+// ExportRunUpgrade exports the upgrade command's run for testing.
+func ExportRunUpgrade(cmd *cobra.Command, ctx *localregistry.Context) error {
+	return (&updateOrchestrator{cmd: cmd, ctx: ctx}).runUpgrade()
+}
+
+// ExportWarnShortAssumeRoleSession exports warnShortAssumeRoleSession for testing.
+func ExportWarnShortAssumeRoleSession(cmd *cobra.Command, resolution *credentials.AWSResolution) {
+	warnShortAssumeRoleSession(cmd, resolution)
+}
+
 // ExportResolveKubeContext exports resolveKubeContext for testing.
 func ExportResolveKubeContext(ctx *localregistry.Context) string {
 	return resolveKubeContext(ctx)
@@ -283,7 +312,7 @@ func ExportExecuteRecreateFlow(
 		consent:     true,
 	}
 
-	return orchestrator.executeRecreateFlow()
+	return orchestrator.executeRecreateFlow(nil)
 }
 
 // ExportFinishRecreateFlow exposes successful recreation finalization for safety tests.
@@ -390,7 +419,7 @@ func ExportDisplayListResults(
 	providers []v1alpha1.Provider,
 	results []ExportListResult,
 ) {
-	displayListResults(writer, providers, results)
+	displayListResults(writer, providers, results, time.Now())
 }
 
 // ExportNewListResult creates a listResult with TTL info for testing.
@@ -526,6 +555,39 @@ func ExportReconcileCertManager(
 	return r.reconcileCertManager(context.Background(), change)
 }
 
+// ExportReconcileSealedSecrets exposes reconcileSealedSecrets for unit testing.
+func ExportReconcileSealedSecrets(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileSealedSecrets(context.Background(), change)
+}
+
+// ExportReconcileExternalSecrets exposes reconcileExternalSecrets for unit testing.
+func ExportReconcileExternalSecrets(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileExternalSecrets(context.Background(), change)
+}
+
+// ExportReconcileBackup exposes reconcileBackup for unit testing.
+func ExportReconcileBackup(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileBackup(context.Background(), change)
+}
+
 // ExportReconcilePolicyEngine exposes reconcilePolicyEngine for unit testing.
 func ExportReconcilePolicyEngine(
 	cmd *cobra.Command,
@@ -537,6 +599,83 @@ func ExportReconcilePolicyEngine(
 	return r.reconcilePolicyEngine(context.Background(), change)
 }
 
+// ExportReconcileIngressController exposes reconcileIngressController for unit testing.
+func ExportReconcileIngressController(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileIngressController(context.Background(), change)
+}
+
+// ExportReconcileGatewayAPI exposes reconcileGatewayAPI for unit testing.
+func ExportReconcileGatewayAPI(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileGatewayAPI(context.Background(), change)
+}
+
+// ExportReconcileServiceMesh exposes reconcileServiceMesh for unit testing.
+func ExportReconcileServiceMesh(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileServiceMesh(context.Background(), change)
+}
+
+// ExportReconcileKnative exposes reconcileKnative for unit testing.
+func ExportReconcileKnative(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileKnative(context.Background(), change)
+}
+
+// ExportReconcileIdentityProvider exposes reconcileIdentityProvider for unit testing.
+func ExportReconcileIdentityProvider(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileIdentityProvider(context.Background(), change)
+}
+
+// ExportReconcileIstioMode exposes reconcileIstioMode for unit testing.
+func ExportReconcileIstioMode(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileIstioMode(context.Background(), change)
+}
+
+// ExportReconcileLogging exposes reconcileLogging for unit testing.
+func ExportReconcileLogging(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	change clusterupdate.Change,
+) error {
+	r := newComponentReconciler(cmd, clusterCfg, "test-cluster")
+
+	return r.reconcileLogging(context.Background(), change)
+}
+
 // ExportReconcileGitOpsEngine exposes reconcileGitOpsEngine for unit testing.
 func ExportReconcileGitOpsEngine(
 	cmd *cobra.Command,
@@ -649,6 +788,11 @@ func ExportDisplayComponents(writer io.Writer, clusterName string) {
 	displayComponents(writer, clusterName)
 }
 
+// ExportDisplayFluxStatuses exports displayFluxStatuses for testing.
+func ExportDisplayFluxStatuses(writer io.Writer, statuses []fluxclient.ResourceStatus) {
+	displayFluxStatuses(writer, statuses)
+}
+
 // ExportStripDistributionPrefix exports stripDistributionPrefix for testing.
 func ExportStripDistributionPrefix(contextName string) string {
 	return stripDistributionPrefix(contextName)
@@ -793,3 +937,53 @@ func ExportGuardUpdateTargetManaged(
 
 	return err
 }
+
+// ExportRecordResolvedAudit exposes the start/stop audit hook for testing.
+func ExportRecordResolvedAudit(
+	operation audit.Operation,
+) func(*cobra.Command, *lifecycle.ResolvedClusterInfo) {
+	return recordResolvedAudit(operation)
+}
+
+// ExportRunSizingPreflight exposes runSizingPreflight for testing.
+func ExportRunSizingPreflight(cmd *cobra.Command, spec v1alpha1.ClusterSpec) error {
+	return runSizingPreflight(cmd, spec)
+}
+
+// ExportRunAPIServerPortPreflight exposes runAPIServerPortPreflight for testing.
+func ExportRunAPIServerPortPreflight(clusterName string, spec v1alpha1.ClusterSpec) error {
+	return runAPIServerPortPreflight(clusterName, spec)
+}
+
+// ExportBuildInfoJSON exports buildInfoJSON for testing.
+func ExportBuildInfoJSON(
+	resolved *lifecycle.ResolvedClusterInfo,
+	status *provider.ClusterStatus,
+	info *clusterdetector.Info,
+	apiReachable bool,
+) InfoJSON {
+	return buildInfoJSON(resolved, status, info, apiReachable)
+}
+
+// ExportSetDebugNetwork replaces the debug network command's clientset factory
+// and in-cluster diagnosis for testing. The returned function restores the originals.
+func ExportSetDebugNetwork(
+	client kubernetes.Interface,
+	run func(context.Context, kubernetes.Interface, netdebug.Options, io.Writer) (netdebug.Report, error),
+) func() {
+	origClient, origRun := newDebugNetworkClient, runNetworkDiagnosis
+	newDebugNetworkClient = func(string) (kubernetes.Interface, error) { return client, nil }
+	runNetworkDiagnosis = run
+
+	return func() { newDebugNetworkClient, runNetworkDiagnosis = origClient, origRun }
+}
+
+// ExportClaimPoolSpare exports claimPoolSpare for testing.
+func ExportClaimPoolSpare(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) (string, error) {
+	return claimPoolSpare(cmd, &localregistry.Context{ClusterCfg: clusterCfg})
+}
+
+// ExportNextPoolSpareName exports nextPoolSpareName for testing.
+func ExportNextPoolSpareName(poolName string) (string, error) {
+	return nextPoolSpareName(poolName)
+}
@@ -0,0 +1,114 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	componentInventoryMu sync.RWMutex
+	componentInventory   = detectComponentInventory
+)
+
+// detectComponentInventory lists the KSail-managed components deployed in the
+// cluster from their Helm releases.
+func detectComponentInventory(
+	ctx context.Context,
+	kubeconfigPath, kubeContext string,
+) ([]state.InventoryComponent, error) {
+	helmClient, err := helm.NewClient(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("create helm client: %w", err)
+	}
+
+	versions, err := detector.DetectComponentVersions(ctx, helmClient)
+	if err != nil {
+		return nil, fmt.Errorf("detect component versions: %w", err)
+	}
+
+	components := make([]state.InventoryComponent, 0, len(versions))
+
+	for _, version := range versions {
+		if !version.Deployed() {
+			continue
+		}
+
+		components = append(components, state.InventoryComponent{
+			Name:       version.Component,
+			Release:    version.Release,
+			Namespace:  version.Namespace,
+			Chart:      version.Chart,
+			AppVersion: version.AppVersion,
+		})
+	}
+
+	return components, nil
+}
+
+// keepsInClusterState reports whether KSail mirrors the state of a cluster
+// into the cluster itself. Only Kind and K3d clusters need it: their update
+// baseline otherwise lives solely in the creator's ~/.ksail.
+func keepsInClusterState(spec v1alpha1.ClusterSpec) bool {
+	if spec.Provider != v1alpha1.ProviderDocker {
+		return false
+	}
+
+	return spec.Distribution == v1alpha1.DistributionVanilla ||
+		spec.Distribution == v1alpha1.DistributionK3s
+}
+
+// publishClusterState writes the ClusterSpec and component inventory of the
+// cluster described by ctx to its kube-system/ksail-state ConfigMap, so
+// `ksail cluster update` from another machine can reconstruct the baseline.
+// Publishing is best-effort: the cluster operation has already succeeded, so
+// failures are reported as warnings instead of failing the command.
+func publishClusterState(cmd *cobra.Command, ctx *localregistry.Context, clusterName string) {
+	spec := ctx.ClusterCfg.Spec.Cluster
+	if !keepsInClusterState(spec) {
+		return
+	}
+
+	target := clusterAuditTarget(ctx)
+	if target.kubeconfigPath == "" {
+		return
+	}
+
+	client, err := newAuditClient(target)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "failed to save in-cluster state: %v", err)
+
+		return
+	}
+
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	goCtx, cancel := context.WithTimeout(parent, auditRecordTimeout)
+	defer cancel()
+
+	componentInventoryMu.RLock()
+	inventory := componentInventory
+	componentInventoryMu.RUnlock()
+
+	// The spec alone reconstructs the update baseline; an unreadable inventory
+	// only leaves the informational component list empty.
+	components, _ := inventory(goCtx, target.kubeconfigPath, target.kubeContext)
+
+	err = state.SaveInClusterState(goCtx, client, clusterName, &spec, components, time.Now())
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "failed to save in-cluster state: %v", err)
+	}
+}
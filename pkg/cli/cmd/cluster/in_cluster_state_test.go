@@ -0,0 +1,84 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// publishStateFixture routes the in-cluster state of a cluster with the given
+// distribution and provider to a fake API server and returns it.
+func publishStateFixture(
+	t *testing.T,
+	distribution v1alpha1.Distribution,
+	provider v1alpha1.Provider,
+) (*fake.Clientset, *localregistry.Context) {
+	t.Helper()
+
+	client := fake.NewClientset()
+	t.Cleanup(cluster.SetAuditClientFactoryForTests(
+		func(string, string) (kubernetes.Interface, error) { return client, nil },
+	))
+	t.Cleanup(cluster.SetComponentInventoryForTests(
+		func(context.Context, string, string) ([]state.InventoryComponent, error) {
+			return []state.InventoryComponent{{Name: "Cilium", Release: "cilium", Namespace: "kube-system"}}, nil
+		},
+	))
+
+	cfg := &v1alpha1.Cluster{}
+	cfg.Spec.Cluster.Distribution = distribution
+	cfg.Spec.Cluster.Provider = provider
+	cfg.Spec.Cluster.CNI = v1alpha1.CNICilium
+	cfg.Spec.Cluster.Connection.Kubeconfig = filepath.Join(t.TempDir(), "config")
+
+	return client, &localregistry.Context{ClusterCfg: cfg}
+}
+
+//nolint:paralleltest // mutates the audit client factory and component inventory
+func TestPublishClusterState_WritesKindState(t *testing.T) {
+	client, ctx := publishStateFixture(t, v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+
+	var out bytes.Buffer
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	cluster.ExportPublishClusterState(cmd, ctx, "dev")
+
+	assert.Empty(t, out.String())
+
+	saved, err := state.LoadInClusterState(context.Background(), client)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dev", saved.ClusterName)
+	assert.Equal(t, v1alpha1.CNICilium, saved.Spec.CNI)
+	assert.Equal(t, []state.InventoryComponent{
+		{Name: "Cilium", Release: "cilium", Namespace: "kube-system"},
+	}, saved.Components)
+}
+
+//nolint:paralleltest // mutates the audit client factory and component inventory
+func TestPublishClusterState_SkipsIntrospectableClusters(t *testing.T) {
+	client, ctx := publishStateFixture(t, v1alpha1.DistributionTalos, v1alpha1.ProviderDocker)
+
+	cluster.ExportPublishClusterState(&cobra.Command{}, ctx, "dev")
+
+	_, err := client.CoreV1().ConfigMaps(state.InClusterNamespace).Get(
+		context.Background(), state.InClusterConfigMapName, metav1.GetOptions{},
+	)
+	assert.True(t, apierrors.IsNotFound(err), "Talos clusters need no in-cluster state, got %v", err)
+}
@@ -12,8 +12,10 @@ import (
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	eksctlclient "github.com/devantler-tech/ksail/v7/pkg/client/eksctl"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
 	"github.com/devantler-tech/ksail/v7/pkg/client/kubectl"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
@@ -44,14 +46,23 @@ func NewInfoCmd() *cobra.Command {
 		Use:   "info",
 		Short: "Display cluster information",
 		Long: "Display cluster information from the infrastructure provider" +
-			" and Kubernetes API. Succeeds if information is available from any source.",
+			" and Kubernetes API. Succeeds if information is available from any source.\n\n" +
+			"Use --query to print part of the information as JSON instead: a JMESPath" +
+			" expression (e.g. --query status.phase), or a CEL expression over \"object\"" +
+			" with --query-language cel. Use --query @ to print the whole document.",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runInfoCmd(cmd, nameFlag, providerFlag)
+			query, err := outputquery.FromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			return runInfoCmd(cmd, nameFlag, providerFlag, query)
 		},
 	}
 
 	lifecycle.BindNameAndProviderFlags(cmd, &nameFlag, &providerFlag)
+	outputquery.BindFlags(cmd)
 
 	return cmd
 }
@@ -60,12 +71,13 @@ func NewInfoCmd() *cobra.Command {
 // 1. Resolve cluster identity (name, provider, kubeconfig)
 // 2. Query provider API for cluster status
 // 3. Attempt kubectl cluster-info
-// 4. Display combined results
+// 4. Display combined results, or only the --query result when query is set
 // 5. Return nil (exit 0) if any info available, error (exit 1) if nothing.
 func runInfoCmd(
 	cmd *cobra.Command,
 	nameFlag string,
 	providerFlag v1alpha1.Provider,
+	query *outputquery.Query,
 ) error {
 	resolved, err := lifecycle.ResolveClusterInfo(
 		cmd, nameFlag, providerFlag, "",
@@ -93,7 +105,7 @@ func runInfoCmd(
 	provErr = classifyProviderError(provErr)
 
 	hasProviderInfo := provErr == nil && status != nil
-	if hasProviderInfo {
+	if hasProviderInfo && query == nil {
 		displayProviderStatus(writer, resolved.Provider, resolved.ClusterName, status)
 	}
 
@@ -108,6 +120,10 @@ func runInfoCmd(
 		return ctxErr
 	}
 
+	if query != nil {
+		return renderInfoQuery(cmd, query, resolved, status, provErr, contextName)
+	}
+
 	// Phase 2: Attempt kubectl cluster-info, scoped to the resolved context.
 	hasKubeInfo := tryScopedKubeInfo(
 		cmd, writer, resolved.KubeconfigPath, contextName, hasProviderInfo,
@@ -138,7 +154,7 @@ func tryScopedKubeInfo(
 ) bool {
 	hasKubeInfo := false
 	if contextName != "" {
-		hasKubeInfo = tryKubeClusterInfo(cmd, kubeconfigPath, contextName) == nil
+		hasKubeInfo = tryKubeClusterInfo(cmd, writer, kubeconfigPath, contextName) == nil
 	}
 
 	if !hasKubeInfo && hasProviderInfo {
@@ -341,8 +357,36 @@ func getAWSProviderStatus(
 	awsOpts v1alpha1.OptionsAWS,
 	region string,
 ) (*provider.ClusterStatus, error) {
+	resolver := credentials.NewAWSOptionsResolver(awsOpts)
+
+	if awsOpts.AssumeRoleARN != "" {
+		// An assumed role only exists once frozen: query with the role's session.
+		auth, err := credentials.ResolveFrozenAWS(ctx, resolver, region)
+		if err != nil {
+			return nil, fmt.Errorf("resolve AWS credentials: %w", err)
+		}
+
+		return awsProviderStatus(
+			ctx,
+			eksctlclient.NewClient(credentials.OptionsForRefreshingAWSChildEnvironment(
+				auth,
+				os.Environ(),
+				eksctlclient.WithEnvironmentSource,
+				eksctlclient.RequireCredentialValues,
+			)...),
+			clusterName,
+			region,
+			credentials.OptionsForFrozenAWSConfig(
+				auth,
+				awsprovider.WithAWSConfig,
+				awsprovider.WithCredentialValues,
+				awsprovider.RequireCredentialValues,
+			)...,
+		)
+	}
+
 	_, eksctlOptions, providerOptions := credentials.ResolveAWSClientOptions(
-		credentials.NewAWSOptionsResolver(awsOpts),
+		resolver,
 		os.Environ(),
 		eksctlclient.WithEnvironment,
 		eksctlclient.RequireCredentialValues,
@@ -414,8 +458,13 @@ func displayProviderStatus(
 		_, _ = fmt.Fprintln(writer, "Nodes:")
 
 		for _, node := range status.Nodes {
+			state := node.State
+			if node.Spot {
+				state += " (spot)"
+			}
+
 			_, _ = fmt.Fprintf(writer, "  - %-40s %-15s %s\n",
-				node.Name, node.Role, node.State)
+				node.Name, node.Role, state)
 		}
 	}
 }
@@ -429,10 +478,14 @@ const (
 )
 
 // tryKubeClusterInfo attempts kubectl cluster-info with retries and writes
-// output to cmd's writer. Output is buffered during retries so that failed
+// output to writer. Output is buffered during retries so that failed
 // attempts do not leak partial output. Returns nil on success, an error if
 // the Kubernetes API is unreachable after all attempts.
-func tryKubeClusterInfo(cmd *cobra.Command, kubeconfigPath, contextName string) error {
+func tryKubeClusterInfo(
+	cmd *cobra.Command,
+	writer io.Writer,
+	kubeconfigPath, contextName string,
+) error {
 	var lastErr error
 
 	for attempt := 1; attempt <= clusterInfoMaxAttempts; attempt++ {
@@ -456,7 +509,7 @@ func tryKubeClusterInfo(cmd *cobra.Command, kubeconfigPath, contextName string)
 		_, lastErr = kubeCmd.ExecuteC()
 		if lastErr == nil {
 			// Success — flush buffered output to the real writer.
-			_, _ = io.Copy(cmd.OutOrStdout(), &buf)
+			_, _ = io.Copy(writer, &buf)
 
 			return nil
 		}
@@ -476,7 +529,8 @@ func tryKubeClusterInfo(cmd *cobra.Command, kubeconfigPath, contextName string)
 
 // displayKSailDetails appends KSail-specific cluster metadata after kubectl output.
 // This includes cluster identity (name, distribution, provider), TTL status,
-// and enabled component summary from persisted state. Each section fails gracefully.
+// enabled component summary from persisted state, and Flux reconciliation status.
+// Each section fails gracefully.
 //
 // It requires a resolved contextName: with an empty context, DetectInfo would
 // fall back to the kubeconfig current context and could report an unrelated
@@ -500,6 +554,7 @@ func displayKSailDetails(cmd *cobra.Command, kubeconfigPath, contextName string)
 	displayClusterIdentity(writer, info)
 	displayTTLInfo(writer, info.ClusterName)
 	displayComponents(writer, info.ClusterName)
+	displayFluxStatus(cmd.Context(), writer, kubeconfigPath, contextName)
 }
 
 // displayClusterIdentity prints the cluster name, distribution, provider, kubeconfig context,
@@ -553,23 +608,113 @@ func displayComponents(writer io.Writer, clusterName string) {
 		return
 	}
 
-	type row struct{ label, value string }
+	_, _ = fmt.Fprintln(writer)
+	_, _ = fmt.Fprintln(writer, "  Components:")
+
+	for _, component := range infoComponents(spec) {
+		_, _ = fmt.Fprintf(writer, "    %-16s%s\n", component.label, componentLabel(component.value))
+	}
+}
+
+// infoComponent is one entry of the components summary: its JSON key in the
+// --query document, its text label, and the persisted spec value.
+type infoComponent struct {
+	key, label, value string
+}
 
-	rows := []row{
-		{"GitOps Engine:", componentLabel(string(spec.GitOpsEngine))},
-		{"CNI:", componentLabel(string(spec.CNI))},
-		{"CSI:", componentLabel(string(spec.CSI))},
-		{"Metrics Server:", componentLabel(string(spec.MetricsServer))},
-		{"Load Balancer:", componentLabel(string(spec.LoadBalancer))},
-		{"Cert Manager:", componentLabel(string(spec.CertManager))},
-		{"Policy Engine:", componentLabel(string(spec.PolicyEngine))},
+// infoComponents lists the components summarised by `cluster info`, shared by
+// the text output and the --query document so the two cannot drift.
+func infoComponents(spec *v1alpha1.ClusterSpec) []infoComponent {
+	return []infoComponent{
+		{"gitOpsEngine", "GitOps Engine:", string(spec.GitOpsEngine)},
+		{"cni", "CNI:", string(spec.CNI)},
+		{"csi", "CSI:", string(spec.CSI)},
+		{"metricsServer", "Metrics Server:", string(spec.MetricsServer)},
+		{"loadBalancer", "Load Balancer:", string(spec.LoadBalancer)},
+		{"certManager", "Cert Manager:", string(spec.CertManager)},
+		{"sealedSecrets", "Sealed Secrets:", string(spec.SealedSecrets)},
+		{"externalSecrets", "External Secrets:", string(spec.ExternalSecrets)},
+		{"backup", "Backup:", string(spec.Backup)},
+		{"policyEngine", "Policy Engine:", string(spec.PolicyEngine)},
+		{"ingressController", "Ingress:", string(spec.IngressController)},
+		{"gatewayAPI", "Gateway API:", string(spec.GatewayAPI)},
+		{"serviceMesh", "Service Mesh:", string(spec.ServiceMesh)},
+		{"knative", "Knative:", string(spec.Knative)},
+		{"identityProvider", "Identity:", string(spec.IdentityProvider)},
+		{"logging", "Logging:", string(spec.Observability.Logging)},
+	}
+}
+
+// fluxStatusTimeout bounds the Flux status lookup so an unresponsive API server
+// does not stall 'cluster info' after everything else has been printed.
+const fluxStatusTimeout = 10 * time.Second
+
+// displayFluxStatus prints the reconciliation state of every Flux Kustomization
+// and HelmRelease in the cluster, including HelmRelease drift detection results.
+// It prints nothing when Flux is not installed or the lookup fails.
+func displayFluxStatus(ctx context.Context, writer io.Writer, kubeconfigPath, contextName string) {
+	fluxReconciler, err := fluxclient.NewReconcilerForContext(kubeconfigPath, contextName)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fluxStatusTimeout)
+	defer cancel()
+
+	statuses, err := fluxReconciler.ListResourceStatuses(ctx)
+	if err != nil {
+		return
+	}
+
+	displayFluxStatuses(writer, statuses)
+}
+
+// displayFluxStatuses prints one row per Flux resource followed by a warning when
+// any resource is not ready, stalled, or drifted. Healthy rows omit the reason.
+func displayFluxStatuses(writer io.Writer, statuses []fluxclient.ResourceStatus) {
+	if len(statuses) == 0 {
+		return
 	}
 
 	_, _ = fmt.Fprintln(writer)
-	_, _ = fmt.Fprintln(writer, "  Components:")
+	_, _ = fmt.Fprintln(writer, "  Flux:")
+
+	unhealthy := 0
 
-	for _, r := range rows {
-		_, _ = fmt.Fprintf(writer, "    %-16s%s\n", r.label, r.value)
+	for _, status := range statuses {
+		row := fmt.Sprintf("    %-15s%-40s%s",
+			status.Kind, status.Namespace+"/"+status.Name, status.State())
+
+		if !status.Healthy() {
+			unhealthy++
+
+			if detail := fluxStatusDetail(status); detail != "" {
+				row += "  " + detail
+			}
+		}
+
+		_, _ = fmt.Fprintln(writer, row)
+	}
+
+	if unhealthy > 0 {
+		_, _ = fmt.Fprintln(writer)
+		notify.Warningf(writer,
+			"%d of %d Flux resources are not ready, stalled, or drifted", unhealthy, len(statuses))
+	}
+}
+
+// fluxStatusDetail formats "Reason: message" using only the first line of the
+// condition message, which can span many lines for Helm errors.
+func fluxStatusDetail(status fluxclient.ResourceStatus) string {
+	message, _, _ := strings.Cut(strings.TrimSpace(status.Message), "\n")
+
+	switch {
+	case status.Reason != "" && message != "":
+		return status.Reason + ": " + message
+	case status.Reason != "":
+		return status.Reason
+	default:
+		return message
 	}
 }
 
@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"io"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/spf13/cobra"
+)
+
+// InfoJSON is the document `cluster info --query` evaluates its expression
+// against. Sections KSail could not determine are null.
+type InfoJSON struct {
+	Name         string `json:"name"`
+	Provider     string `json:"provider"`
+	Distribution string `json:"distribution"`
+	Context      string `json:"context"`
+	Server       string `json:"server"`
+	Kubeconfig   string `json:"kubeconfig"`
+	// APIReachable reports whether `kubectl cluster-info` succeeded against the
+	// cluster's kubeconfig context.
+	APIReachable bool              `json:"apiReachable"`
+	Status       *InfoStatusJSON   `json:"status"`
+	TTL          *InfoTTLJSON      `json:"ttl"`
+	Components   map[string]string `json:"components"`
+}
+
+// InfoStatusJSON is the infrastructure provider's view of the cluster.
+type InfoStatusJSON struct {
+	Phase      string         `json:"phase"`
+	Ready      bool           `json:"ready"`
+	NodesTotal int            `json:"nodesTotal"`
+	NodesReady int            `json:"nodesReady"`
+	Endpoint   string         `json:"endpoint,omitempty"`
+	Nodes      []InfoNodeJSON `json:"nodes"`
+}
+
+// InfoNodeJSON is a single node reported by the infrastructure provider.
+type InfoNodeJSON struct {
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+	State string `json:"state"`
+	Spot  bool   `json:"spot"`
+}
+
+// InfoTTLJSON is the cluster's auto-destroy TTL.
+type InfoTTLJSON struct {
+	Duration  string    `json:"duration"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Expired   bool      `json:"expired"`
+}
+
+// renderInfoQuery gathers the same information as the text output into an
+// InfoJSON document and prints the query result instead. kubectl cluster-info
+// is only probed for reachability. Like the text output it fails when neither
+// the provider nor the Kubernetes API returned anything.
+func renderInfoQuery(
+	cmd *cobra.Command,
+	query *outputquery.Query,
+	resolved *lifecycle.ResolvedClusterInfo,
+	status *provider.ClusterStatus,
+	provErr error,
+	contextName string,
+) error {
+	hasProviderInfo := provErr == nil && status != nil
+	apiReachable := contextName != "" &&
+		tryKubeClusterInfo(cmd, io.Discard, resolved.KubeconfigPath, contextName) == nil
+
+	if !hasProviderInfo && !apiReachable {
+		return buildNoInfoError(resolved.ClusterName, provErr)
+	}
+
+	if !hasProviderInfo {
+		status = nil
+	}
+
+	// Same guard as displayKSailDetails: without a resolved context DetectInfo
+	// would fall back to the current context and describe another cluster.
+	var info *clusterdetector.Info
+
+	if contextName != "" {
+		detected, err := clusterdetector.DetectInfo(cmd.Context(), resolved.KubeconfigPath, contextName)
+		if err == nil {
+			info = detected
+		}
+	}
+
+	return query.Render(cmd.OutOrStdout(), buildInfoJSON(resolved, status, info, apiReachable))
+}
+
+// buildInfoJSON assembles the --query document from the resolved identity,
+// the provider status and detected kubeconfig identity (either may be nil),
+// and the TTL and components persisted for the cluster.
+func buildInfoJSON(
+	resolved *lifecycle.ResolvedClusterInfo,
+	status *provider.ClusterStatus,
+	info *clusterdetector.Info,
+	apiReachable bool,
+) InfoJSON {
+	doc := InfoJSON{
+		Name:         resolved.ClusterName,
+		Provider:     string(resolved.Provider),
+		Kubeconfig:   resolved.KubeconfigPath,
+		APIReachable: apiReachable,
+	}
+
+	if status != nil {
+		doc.Status = infoStatusJSON(status)
+	}
+
+	if info != nil {
+		setInfoIdentity(&doc, info)
+	}
+
+	setInfoState(&doc)
+
+	return doc
+}
+
+// infoStatusJSON converts the provider status into its JSON representation.
+func infoStatusJSON(status *provider.ClusterStatus) *InfoStatusJSON {
+	nodes := make([]InfoNodeJSON, 0, len(status.Nodes))
+
+	for _, node := range status.Nodes {
+		nodes = append(nodes, InfoNodeJSON{
+			Name:  node.Name,
+			Role:  node.Role,
+			State: node.State,
+			Spot:  node.Spot,
+		})
+	}
+
+	return &InfoStatusJSON{
+		Phase:      status.Phase,
+		Ready:      status.Ready,
+		NodesTotal: status.NodesTotal,
+		NodesReady: status.NodesReady,
+		Endpoint:   status.Endpoint,
+		Nodes:      nodes,
+	}
+}
+
+// setInfoIdentity fills the identity fields detected from the kubeconfig.
+func setInfoIdentity(doc *InfoJSON, info *clusterdetector.Info) {
+	doc.Name = info.ClusterName
+	doc.Provider = string(info.Provider)
+	doc.Distribution = string(info.Distribution)
+	doc.Context = info.Context
+	doc.Server = info.ServerURL
+
+	if info.KubeconfigPath != "" {
+		doc.Kubeconfig = info.KubeconfigPath
+	}
+}
+
+// setInfoState fills the TTL and components persisted by `cluster create`,
+// leaving them null when the cluster has no local state.
+func setInfoState(doc *InfoJSON) {
+	ttlInfo, err := state.LoadClusterTTL(doc.Name)
+	if err == nil && ttlInfo != nil {
+		doc.TTL = &InfoTTLJSON{
+			Duration:  ttlInfo.Duration,
+			ExpiresAt: ttlInfo.ExpiresAt,
+			Expired:   ttlInfo.Remaining() <= 0,
+		}
+	}
+
+	spec, err := state.LoadClusterSpec(doc.Name)
+	if err != nil {
+		return
+	}
+
+	if doc.Distribution == "" {
+		doc.Distribution = string(spec.Distribution)
+	}
+
+	doc.Components = make(map[string]string)
+
+	for _, component := range infoComponents(spec) {
+		doc.Components[component.key] = component.value
+	}
+}
@@ -1,19 +1,27 @@
 package cluster_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
 	eksctlclient "github.com/devantler-tech/ksail/v7/pkg/client/eksctl"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	awsprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/aws"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,7 +39,7 @@ const mappedAWSEksctlFixture = `#!/bin/sh
 [ "${AWS_ACCESS_KEY_ID-}" = "fixture-access" ] || exit 42
 [ "${AWS_SECRET_ACCESS_KEY-}" = "fixture-secret" ] || exit 43
 [ "${AWS_SESSION_TOKEN-}" = "fixture-session" ] || exit 44
-[ -z "${KSAIL_PROFILE+x}" ] || exit 45
+[ -z "${KSAIL_AWS_PROFILE+x}" ] || exit 45
 [ -z "${KSAIL_ACCESS+x}" ] || exit 46
 [ -z "${KSAIL_SECRET+x}" ] || exit 47
 [ -z "${KSAIL_SESSION+x}" ] || exit 48
@@ -52,7 +60,7 @@ spec:
       kubeconfig: kubeconfig
   provider:
     aws:
-      profileEnvVar: KSAIL_PROFILE
+      profileEnvVar: KSAIL_AWS_PROFILE
       accessKeyIdEnvVar: KSAIL_ACCESS
       secretAccessKeyEnvVar: KSAIL_SECRET
       sessionTokenEnvVar: KSAIL_SESSION
@@ -257,7 +265,7 @@ func TestInfoCommandMapsCustomAWSCredentialsIntoEksctl(t *testing.T) {
 
 	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
 	t.Setenv("KSAIL_EKSCTL_MARKER", markerPath)
-	t.Setenv("KSAIL_PROFILE", "selected-profile")
+	t.Setenv("KSAIL_AWS_PROFILE", "selected-profile")
 	t.Setenv("KSAIL_ACCESS", "fixture-access")
 	t.Setenv("KSAIL_SECRET", "fixture-secret")
 	t.Setenv("KSAIL_SESSION", "fixture-session")
@@ -289,3 +297,116 @@ func writeExecutableFixture(t *testing.T, path, contents string) {
 		os.Chmod(path, 0o700),
 	)
 }
+
+func TestDisplayFluxStatuses_ReportsDriftAndStalls(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	cluster.ExportDisplayFluxStatuses(&buf, []fluxclient.ResourceStatus{
+		{
+			Kind: fluxclient.KindKustomization, Name: "flux-system", Namespace: "flux-system",
+			Ready: true, Reason: "ReconciliationSucceeded", Message: "Applied revision",
+		},
+		{
+			Kind: fluxclient.KindHelmRelease, Name: "podinfo", Namespace: "podinfo",
+			Ready: true, Drifted: true, Reason: "DriftDetected",
+			Message: "Deployment/podinfo/podinfo changed\nspec.replicas: 1 -> 3",
+		},
+		{
+			Kind: fluxclient.KindHelmRelease, Name: "broken", Namespace: "apps",
+			Stalled: true, Reason: "RetriesExceeded", Message: "install retries exhausted",
+		},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Flux:")
+	assert.Regexp(t, `Kustomization\s+flux-system/flux-system\s+Ready\n`, out)
+	assert.Contains(t, out, "Drifted  DriftDetected: Deployment/podinfo/podinfo changed\n")
+	assert.NotContains(t, out, "spec.replicas")
+	assert.Contains(t, out, "Stalled  RetriesExceeded: install retries exhausted")
+	assert.Contains(t, out, "2 of 3 Flux resources are not ready, stalled, or drifted")
+}
+
+func TestDisplayFluxStatuses_NoResources(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	cluster.ExportDisplayFluxStatuses(&buf, nil)
+	assert.Empty(t, buf.String())
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestBuildInfoJSON(t *testing.T) {
+	clusterName := "info-query-cluster"
+
+	require.NoError(t, state.SaveClusterSpec(clusterName, &v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionK3s,
+		CNI:          v1alpha1.CNICilium,
+		GitOpsEngine: v1alpha1.GitOpsEngineFlux,
+	}))
+	require.NoError(t, state.SaveClusterTTL(clusterName, time.Hour))
+
+	t.Cleanup(func() { _ = state.DeleteClusterState(clusterName) })
+
+	resolved := &lifecycle.ResolvedClusterInfo{
+		ClusterName:    clusterName,
+		Provider:       v1alpha1.ProviderDocker,
+		KubeconfigPath: "/tmp/kubeconfig",
+	}
+	status := &provider.ClusterStatus{
+		Phase:      "running",
+		Ready:      true,
+		NodesTotal: 1,
+		NodesReady: 1,
+		Nodes:      []provider.NodeInfo{{Name: "server-0", Role: "control-plane", State: "running"}},
+	}
+
+	doc := cluster.ExportBuildInfoJSON(resolved, status, nil, true)
+
+	assert.Equal(t, clusterName, doc.Name)
+	assert.Equal(t, "Docker", doc.Provider)
+	assert.Equal(t, "K3s", doc.Distribution, "distribution falls back to the persisted spec")
+	assert.True(t, doc.APIReachable)
+	require.NotNil(t, doc.Status)
+	assert.Equal(t, []cluster.InfoNodeJSON{
+		{Name: "server-0", Role: "control-plane", State: "running"},
+	}, doc.Status.Nodes)
+	require.NotNil(t, doc.TTL)
+	assert.False(t, doc.TTL.Expired)
+	assert.Equal(t, "Cilium", doc.Components["cni"])
+	assert.Equal(t, "Flux", doc.Components["gitOpsEngine"])
+
+	query, err := outputquery.New(outputquery.LanguageJMESPath, "components.cni")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	require.NoError(t, query.Render(&out, doc))
+	assert.Equal(t, "Cilium\n", out.String())
+}
+
+func TestBuildInfoJSON_UnknownSectionsAreNull(t *testing.T) {
+	t.Parallel()
+
+	doc := cluster.ExportBuildInfoJSON(
+		&lifecycle.ResolvedClusterInfo{ClusterName: "info-query-no-state"},
+		nil,
+		&clusterdetector.Info{
+			ClusterName:  "info-query-no-state",
+			Distribution: v1alpha1.DistributionVanilla,
+			Provider:     v1alpha1.ProviderDocker,
+			Context:      "kind-info-query-no-state",
+			ServerURL:    "https://127.0.0.1:6443",
+		},
+		true,
+	)
+
+	assert.Equal(t, "Vanilla", doc.Distribution)
+	assert.Equal(t, "kind-info-query-no-state", doc.Context)
+	assert.Equal(t, "https://127.0.0.1:6443", doc.Server)
+	assert.Nil(t, doc.Status)
+	assert.Nil(t, doc.TTL)
+	assert.Nil(t, doc.Components)
+}
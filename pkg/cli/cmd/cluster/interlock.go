@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterdiscovery"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrImportantState is returned when a delete or recreate would destroy persistent volumes, load
+// balancers, or namespaces KSail did not create, and the caller did not pass the force flag.
+var ErrImportantState = errors.New("cluster holds state that would be lost")
+
+// importantStateProbeTimeout bounds the pre-delete probe so an unreachable API server delays a
+// delete by seconds rather than the client's default timeout.
+const importantStateProbeTimeout = 10 * time.Second
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	importantStateClientFactoryMu sync.RWMutex
+	importantStateClientFactory   = func(kubeconfigPath, kubeContext string) (kubernetes.Interface, error) {
+		return k8s.NewClientset(kubeconfigPath, kubeContext)
+	}
+)
+
+// guardImportantState refuses a destructive action (e.g. `deleting cluster "dev"`) on the cluster
+// behind kubeContext when the detector finds state KSail cannot rebuild, listing what would be
+// lost and the flag that overrides the refusal. With force set it warns with the same list and
+// lets the action proceed. The guard fails open: an empty context or an unreachable cluster only
+// produces a warning, so a broken cluster can still be deleted.
+func guardImportantState(
+	cmd *cobra.Command,
+	kubeconfigPath, kubeContext string,
+	action, forceFlag string,
+	force bool,
+) error {
+	if kubeContext == "" {
+		return nil
+	}
+
+	importantStateClientFactoryMu.RLock()
+	factory := importantStateClientFactory
+	importantStateClientFactoryMu.RUnlock()
+
+	clientset, err := factory(kubeconfigPath, kubeContext)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(),
+			"could not check %s for state that would be lost: %v", kubeContext, err)
+
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), importantStateProbeTimeout)
+	defer cancel()
+
+	state, err := detector.DetectImportantState(ctx, clientset)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(),
+			"could not check %s for state that would be lost: %v", kubeContext, err)
+
+		return nil
+	}
+
+	if state.Empty() {
+		return nil
+	}
+
+	listing := formatImportantState(state)
+
+	if !force {
+		return fmt.Errorf("%w: %s would destroy:%s\nre-run with --%s to proceed anyway",
+			ErrImportantState, action, listing, forceFlag)
+	}
+
+	notify.Warningf(cmd.OutOrStderr(), "--%s is set; %s destroys:%s", forceFlag, action, listing)
+
+	return nil
+}
+
+// formatImportantState renders state as indented sections, one line per resource.
+func formatImportantState(state detector.ImportantState) string {
+	var listing strings.Builder
+
+	writeResourceSection(&listing, "Persistent volume claims:", state.PersistentVolumeClaims)
+	writeResourceSection(&listing, "LoadBalancer services:", state.LoadBalancers)
+	writeResourceSection(&listing, "Namespaces not created by KSail:", state.Namespaces)
+
+	return listing.String()
+}
+
+// writeResourceSection writes header followed by one list entry per item. Does nothing when
+// items is empty.
+func writeResourceSection(listing *strings.Builder, header string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	listing.WriteString("\n  " + header)
+
+	for _, item := range items {
+		listing.WriteString("\n    - " + item)
+	}
+}
+
+// deleteKubeContext returns the kubeconfig context of the cluster delete targets: the context
+// distribution detection resolved, otherwise the single context matching the cluster name. It
+// returns "" when the cluster cannot be pinned to exactly one context, rather than probing the
+// current context, which may belong to a different cluster.
+func deleteKubeContext(
+	resolved *lifecycle.ResolvedClusterInfo,
+	detectedInfo *clusterdetector.Info,
+) string {
+	if detectedInfo != nil && detectedInfo.Context != "" {
+		return detectedInfo.Context
+	}
+
+	matches := clusterdetector.MatchContexts(
+		clusterdiscovery.LoadKubeconfig(resolved.KubeconfigPath), resolved.ClusterName,
+	)
+	if len(matches) != 1 {
+		return ""
+	}
+
+	return matches[0]
+}
@@ -0,0 +1,137 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// useClusterWithData makes the delete interlock probe a fake cluster holding a
+// bound volume claim in a namespace KSail did not create, and records the
+// context it probed.
+func useClusterWithData(t *testing.T) *string {
+	t.Helper()
+
+	var probedContext string
+
+	clientset := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "postgres-0", Namespace: "shop"},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: corev1.ClaimBound,
+				Capacity: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("20Gi"),
+				},
+			},
+		},
+	)
+
+	restore := cluster.SetImportantStateClientFactoryForTests(
+		func(_, kubeContext string) (kubernetes.Interface, error) {
+			probedContext = kubeContext
+
+			return clientset, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	return &probedContext
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir() and t.Setenv() in helper
+func TestDelete_ImportantState_RefusedWithoutForce(t *testing.T) {
+	cleanup := setupContextBasedTest(t, "kind-my-cluster", true, nil)
+	defer cleanup()
+
+	probedContext := useClusterWithData(t)
+
+	cmd := cluster.NewDeleteCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetContext(context.Background())
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrImportantState)
+	assert.Equal(t, "kind-my-cluster", *probedContext)
+	assert.Contains(t, err.Error(), "shop/postgres-0 (20Gi)")
+	assert.Contains(t, err.Error(), "Namespaces not created by KSail:\n    - shop")
+	assert.Contains(t, err.Error(), "re-run with --force-data-loss")
+	assert.NotContains(t, out.String(), "cluster deleted")
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir() and t.Setenv() in helper
+func TestDelete_ImportantState_ForceListsLostState(t *testing.T) {
+	cleanup := setupContextBasedTest(t, "kind-my-cluster", true, nil)
+	defer cleanup()
+
+	useClusterWithData(t)
+
+	cmd := cluster.NewDeleteCmd()
+	cmd.SetArgs([]string{"--force", "--force-data-loss"})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetContext(context.Background())
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, `--force-data-loss is set; deleting cluster "my-cluster" destroys:`)
+	assert.Contains(t, output, "shop/postgres-0 (20Gi)")
+	assert.Contains(t, output, "cluster deleted")
+}
+
+// TestDelete_ImportantState_ChatConfirmFlagsDoNotOverride verifies that the flags the chat
+// assistant injects after permission approval (those annotated as confirm-flags) only skip the
+// prompt: they never authorize destroying the cluster's data.
+//
+//nolint:paralleltest // Cannot use t.Parallel() with t.Chdir() and t.Setenv() in helper
+func TestDelete_ImportantState_ChatConfirmFlagsDoNotOverride(t *testing.T) {
+	cleanup := setupContextBasedTest(t, "kind-my-cluster", true, nil)
+	defer cleanup()
+
+	useClusterWithData(t)
+
+	cmd := cluster.NewDeleteCmd()
+
+	var injected []string
+
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if slices.Contains(flag.Annotations[annotations.AnnotationConfirmFlag], annotations.AnnotationValueTrue) {
+			injected = append(injected, "--"+flag.Name)
+		}
+	})
+
+	require.Equal(t, []string{"--force"}, injected)
+	assert.Empty(t, cmd.Flags().Lookup("force-data-loss").Annotations[annotations.AnnotationConfirmFlag])
+
+	cmd.SetArgs(injected)
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetContext(context.Background())
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrImportantState)
+	assert.NotContains(t, out.String(), "cluster deleted")
+}
@@ -5,16 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	"github.com/devantler-tech/ksail/v7/pkg/k8s"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterdiscovery"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenancy"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 const listLongDesc = `List all Kubernetes clusters managed by KSail.
@@ -22,22 +30,36 @@ const listLongDesc = `List all Kubernetes clusters managed by KSail.
 By default, lists clusters from all distributions across all providers.
 Use --provider to filter results to a specific provider.
 
+Clusters are found by querying each provider live and by reading the local
+state registry (~/.ksail/clusters), which records every cluster KSail created
+on this machine.
+
 Output Format:
-  PROVIDER   DISTRIBUTION   CLUSTER       STATUS
-  docker     Vanilla        dev-cluster   Running
-  docker     K3s            test-cluster  Stopped
-  hetzner    Talos          prod-cluster  Unknown
+  PROVIDER   DISTRIBUTION   CLUSTER        STATUS    NODES   AGE
+  docker     Vanilla        dev-cluster    Running   3       2d4h
+  docker     K3s            test-cluster   Stopped   1       45m
+  docker     Talos          old-cluster    Missing   -       12d
+  hetzner    Talos          prod-cluster   Unknown   3       30d
 
 The STATUS column reports the cluster's run-state: "Running" when its nodes are
 up, "Stopped" when they exist but are not running (e.g. a stopped Docker
-cluster), and "Unknown" for providers that cannot report it (cloud providers).
+cluster), "Missing" when the state registry records a Docker cluster whose
+containers no longer exist, and "Unknown" for providers that cannot report it
+(cloud providers). NODES is the number of nodes and AGE the time since the
+oldest node was created; for clusters the provider cannot inspect both fall
+back to the saved spec, and "-" means unknown.
 Kubeconfig contexts KSail did not provision are also listed with STATUS
 "Unmanaged" (blank PROVIDER/DISTRIBUTION) so they are visible on the CLI just as
 in the web UI; KSail-only operations (delete/stop/update) do not act on them.
 
+On a shared host with tenancy enabled (spec.cluster.tenancy, or
+KSAIL_SPEC_CLUSTER_TENANCY_ENABLED=true), Vanilla and K3s clusters on Docker are
+limited to the current tenant's, i.e. those named "<tenant>-...". Use
+--all-users to list every tenant's clusters.
+
 When any cluster has a TTL set, a TTL column is appended:
-  PROVIDER   DISTRIBUTION   CLUSTER       STATUS    TTL
-  docker     K3s            dev-cluster   Running   2h 30m
+  PROVIDER   DISTRIBUTION   CLUSTER       STATUS    NODES   AGE   TTL
+  docker     K3s            dev-cluster   Running   1       3h    2h 30m
 
 The PROVIDER and CLUSTER values from the output can be used directly
 with other cluster commands:
@@ -46,11 +68,17 @@ with other cluster commands:
 
 Use --output json for machine-readable output. The JSON is an array of objects:
   [
-    {"name": "dev", "provider": "docker", "distribution": "Vanilla", "status": "Running", "ttl": "2h 30m"}
+    {"name": "dev", "provider": "docker", "distribution": "Vanilla", "status": "Running",
+     "nodes": 3, "age": "2d4h", "createdAt": "2026-01-02T15:04:05Z", "ttl": "2h 30m"}
   ]
-The "status" field is "Running", "Stopped", "Unknown", or "Unmanaged" (see the STATUS column).
+The "status" field is "Running", "Stopped", "Missing", "Unknown", or "Unmanaged" (see the STATUS
+column). The "nodes", "age", and "createdAt" (RFC 3339) fields are null when unknown.
 The "ttl" field is null when no TTL is set and "EXPIRED" once the TTL has elapsed.
 
+Use --query to print only part of that array: a JMESPath expression, or a CEL
+expression over "object" with --query-language cel. String results are printed
+without quotes, one per line.
+
 Examples:
   # List all clusters
   ksail cluster list
@@ -64,8 +92,17 @@ Examples:
   # List only Omni clusters
   ksail cluster list --provider Omni
 
-  # Machine-readable JSON (name/provider/distribution/ttl)
-  ksail cluster list --output json`
+  # List every tenant's clusters on a shared host
+  ksail cluster list --all-users
+
+  # Machine-readable JSON (name/provider/distribution/status/nodes/age/ttl)
+  ksail cluster list --output json
+
+  # Names of running clusters (JMESPath)
+  ksail cluster list --query "[?status=='Running'].name"
+
+  # Names of running clusters (CEL)
+  ksail cluster list --query-language cel --query "object.filter(c, c.status == 'Running').map(c, c.name)"`
 
 // NewListCmd creates the list command for clusters.
 func NewListCmd() *cobra.Command {
@@ -96,7 +133,10 @@ func NewListCmd() *cobra.Command {
 
 	cmd.Flags().String("output", outputFormatText,
 		"Output format: text or json. Use json for machine-readable structured output "+
-			"(array of {name, provider, distribution, status, ttl}).")
+			"(array of {name, provider, distribution, status, nodes, age, createdAt, ttl}).")
+	cmd.Flags().Bool("all-users", false,
+		"Include the clusters of every tenant on a shared host, not only the current tenant's")
+	outputquery.BindFlags(cmd)
 
 	return cmd
 }
@@ -117,10 +157,37 @@ type ListDeps struct {
 		name string,
 	) clusterdiscovery.RunState
 
+	// DockerNodesFunc optionally lists a Docker cluster's nodes, routed into the discoverer's
+	// DockerNodes seam. If nil, discovery queries the real Docker daemon. Primarily for testing, so
+	// the NODES and AGE columns are deterministic without a live Docker daemon.
+	DockerNodesFunc func(
+		ctx context.Context,
+		distribution v1alpha1.Distribution,
+		name string,
+	) ([]provider.NodeInfo, error)
+
+	// DockerPingFunc optionally probes the Docker daemon, routed into the discoverer's DockerPing
+	// seam. It decides whether a registry-only Docker cluster is Missing (daemon reachable) or
+	// Unknown. If nil, the real daemon is pinged. Primarily for testing.
+	DockerPingFunc func(ctx context.Context) error
+
+	// ClusterStatesFunc optionally returns the local state registry entries merged into the list.
+	// If nil, state.ListClusterStates reads ~/.ksail/clusters. Primarily for testing.
+	ClusterStatesFunc func() ([]state.ClusterStateEntry, error)
+
+	// NowFunc optionally returns the current time the AGE column is measured against. If nil,
+	// time.Now is used. Primarily for testing, so ages are deterministic.
+	NowFunc func() time.Time
+
 	// KubeconfigPathFunc optionally resolves the kubeconfig path scanned for unmanaged
 	// (kubeconfig-only) clusters. If nil, the user's default kubeconfig is used. Primarily for
 	// testing, so unmanaged discovery reads a temp kubeconfig instead of the real one.
 	KubeconfigPathFunc func() string
+
+	// TenantPrefixFunc optionally resolves the tenant prefix the list is scoped to ("" for none).
+	// If nil, it is resolved from ksail.yaml and the KSAIL_SPEC_CLUSTER_TENANCY_* environment.
+	// Primarily for testing, so the result does not depend on the local config or user.
+	TenantPrefixFunc func() (string, error)
 }
 
 // HandleListRunE handles the list command. It delegates cluster enumeration to the shared
@@ -131,9 +198,16 @@ func HandleListRunE(
 	providerFilter v1alpha1.Provider,
 	deps ListDeps,
 ) error {
+	query, err := outputquery.FromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	providers := resolveProviders(providerFilter)
 
-	clusters, failures := newDiscoverer(deps).Discover(cmd.Context(), providers)
+	discoverer := newDiscoverer(deps)
+
+	clusters, failures := discoverer.Discover(cmd.Context(), providers)
 
 	for _, failure := range failures {
 		_, _ = fmt.Fprintf(
@@ -144,6 +218,12 @@ func HandleListRunE(
 		)
 	}
 
+	clusters = mergeClusterStates(
+		cmd.Context(), cmd.ErrOrStderr(), discoverer, deps, providers, clusters,
+	)
+
+	clusters = filterTenantClusters(cmd, deps, clusters)
+
 	// When listing all providers (no --provider filter), also surface kubeconfig contexts ksail did
 	// not provision — flagged Unmanaged — so an unmanaged cluster visible in the web UI is visible on
 	// the CLI too (ksail#5654 surface parity). A provider filter narrows to that provider's managed
@@ -176,23 +256,34 @@ func HandleListRunE(
 			ClusterName:  cluster.Name,
 			TTL:          ttl,
 			RunState:     cluster.RunState,
+			Nodes:        cluster.Nodes,
+			Created:      cluster.Created,
 		})
 	}
 
+	now := time.Now
+	if deps.NowFunc != nil {
+		now = deps.NowFunc
+	}
+
+	if query != nil {
+		return query.Render(cmd.OutOrStdout(), buildListJSON(providers, allResults, now()))
+	}
+
 	if getOutputFormat(cmd) == outputFormatJSON {
-		return emitListJSON(cmd.OutOrStdout(), providers, allResults)
+		return emitListJSON(cmd.OutOrStdout(), providers, allResults, now())
 	}
 
-	displayListResults(cmd.OutOrStdout(), providers, allResults)
+	displayListResults(cmd.OutOrStdout(), providers, allResults, now())
 
 	return nil
 }
 
 // newDiscoverer builds the shared clusterdiscovery.Discoverer for the list command, routing the
-// optional test seams (Docker factory + run-state probe) from deps. With no seams it queries real
-// providers and probes the real Docker daemon for run-state.
+// optional test seams (Docker factory, run-state, node and ping probes) from deps. With no seams it
+// queries real providers and probes the real Docker daemon for run-state, node count, and age.
 func newDiscoverer(deps ListDeps) *clusterdiscovery.Discoverer {
-	discoverer := &clusterdiscovery.Discoverer{}
+	discoverer := &clusterdiscovery.Discoverer{ProbeRunState: true}
 	if deps.DistributionFactoryCreator != nil {
 		discoverer.DockerFactory = func(
 			distribution v1alpha1.Distribution,
@@ -205,6 +296,14 @@ func newDiscoverer(deps ListDeps) *clusterdiscovery.Discoverer {
 		discoverer.DockerStatus = deps.DockerStatusFunc
 	}
 
+	if deps.DockerNodesFunc != nil {
+		discoverer.DockerNodes = deps.DockerNodesFunc
+	}
+
+	if deps.DockerPingFunc != nil {
+		discoverer.DockerPing = deps.DockerPingFunc
+	}
+
 	return discoverer
 }
 
@@ -228,6 +327,76 @@ func discoverUnmanaged(
 	return clusterdiscovery.DiscoverUnmanaged(kubeconfigPath(), managed)
 }
 
+// filterTenantClusters drops the Vanilla and K3s Docker clusters of other tenants when tenancy is
+// enabled, unless --all-users is set. Those are the clusters tenancy scopes by name; clusters of
+// other distributions and providers are listed as before. A tenant that cannot be resolved is
+// reported as a warning and the list is left unfiltered.
+func filterTenantClusters(
+	cmd *cobra.Command,
+	deps ListDeps,
+	clusters []clusterdiscovery.Cluster,
+) []clusterdiscovery.Cluster {
+	allUsers, _ := cmd.Flags().GetBool("all-users")
+	if allUsers {
+		return clusters
+	}
+
+	resolvePrefix := deps.TenantPrefixFunc
+	if resolvePrefix == nil {
+		resolvePrefix = func() (string, error) { return resolveTenantPrefix(cmd) }
+	}
+
+	prefix, err := resolvePrefix()
+	if err != nil {
+		notify.Warningf(cmd.ErrOrStderr(), "listing all tenants' clusters: %v", err)
+
+		return clusters
+	}
+
+	if prefix == "" {
+		return clusters
+	}
+
+	filtered := make([]clusterdiscovery.Cluster, 0, len(clusters))
+
+	for _, cluster := range clusters {
+		scoped := cluster.Provider == v1alpha1.ProviderDocker &&
+			(cluster.Distribution == v1alpha1.DistributionVanilla ||
+				cluster.Distribution == v1alpha1.DistributionK3s)
+
+		if !scoped || tenancy.Owns(prefix, cluster.Name) {
+			filtered = append(filtered, cluster)
+		}
+	}
+
+	return filtered
+}
+
+// resolveTenantPrefix returns the tenant prefix from ksail.yaml (honoring --config) and the
+// environment, or "" when tenancy is disabled.
+func resolveTenantPrefix(cmd *cobra.Command) (string, error) {
+	configFile, err := flags.GetConfigPath(cmd)
+	if err != nil {
+		return "", fmt.Errorf("resolve config path: %w", err)
+	}
+
+	cfg, err := ksailconfigmanager.NewConfigManager(nil, configFile).Load(configmanager.LoadOptions{
+		Silent:                 true,
+		SkipValidation:         true,
+		SkipDistributionConfig: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("load cluster config: %w", err)
+	}
+
+	prefix, err := tenancy.Prefix(cfg.Spec.Cluster.Tenancy)
+	if err != nil {
+		return "", fmt.Errorf("resolve tenant: %w", err)
+	}
+
+	return prefix, nil
+}
+
 // resolveProviders returns the list of providers to query based on the filter.
 func resolveProviders(filter v1alpha1.Provider) []v1alpha1.Provider {
 	if filter == "" {
@@ -238,12 +407,13 @@ func resolveProviders(filter v1alpha1.Provider) []v1alpha1.Provider {
 }
 
 // displayListResults outputs the cluster list as an aligned table.
-// Columns: PROVIDER, DISTRIBUTION, CLUSTER, STATUS, and optionally TTL (when any cluster has one).
-// If no clusters exist, displays "No clusters found.".
+// Columns: PROVIDER, DISTRIBUTION, CLUSTER, STATUS, NODES, AGE, and optionally TTL (when any cluster
+// has one). Ages are measured against now. If no clusters exist, displays "No clusters found.".
 func displayListResults(
 	writer io.Writer,
 	providers []v1alpha1.Provider,
 	results []listResult,
+	now time.Time,
 ) {
 	if len(results) == 0 {
 		_, _ = fmt.Fprintln(writer, "No clusters found.")
@@ -251,12 +421,16 @@ func displayListResults(
 		return
 	}
 
-	printTable(writer, tableHeaders(results), buildTableRows(providers, results))
+	printTable(writer, tableHeaders(results), buildTableRows(providers, results, now))
 }
 
 // buildTableRows converts listResults into ordered table rows following provider order. Each row is
 // the ordered column values matching the header set built by tableHeaders for the same results.
-func buildTableRows(providers []v1alpha1.Provider, results []listResult) [][]string {
+func buildTableRows(
+	providers []v1alpha1.Provider,
+	results []listResult,
+	now time.Time,
+) [][]string {
 	hasTTL := anyTTL(results)
 
 	var rows [][]string
@@ -272,6 +446,8 @@ func buildTableRows(providers []v1alpha1.Provider, results []listResult) [][]str
 				string(result.Distribution),
 				result.ClusterName,
 				statusLabel(result.RunState),
+				formatNodesValue(result.Nodes),
+				formatAgeValue(result.Created, now),
 			}
 			if hasTTL {
 				row = append(row, formatTTLValue(result.TTL))
@@ -284,7 +460,10 @@ func buildTableRows(providers []v1alpha1.Provider, results []listResult) [][]str
 	// Unmanaged (kubeconfig-only) clusters have no provider, so the provider loop above skips them —
 	// append them last with blank PROVIDER/DISTRIBUTION and STATUS=Unmanaged.
 	for _, result := range unmanagedResults(results) {
-		row := []string{"", "", result.ClusterName, statusLabel(result.RunState)}
+		row := []string{
+			"", "", result.ClusterName, statusLabel(result.RunState),
+			formatNodesValue(result.Nodes), formatAgeValue(result.Created, now),
+		}
 		if hasTTL {
 			row = append(row, formatTTLValue(result.TTL))
 		}
@@ -311,9 +490,10 @@ func unmanagedResults(results []listResult) []listResult {
 }
 
 // tableHeaders returns the column headers for the results: the fixed PROVIDER/DISTRIBUTION/CLUSTER/
-// STATUS columns plus a trailing TTL column only when some cluster has a TTL (matching buildTableRows).
+// STATUS/NODES/AGE columns plus a trailing TTL column only when some cluster has a TTL (matching
+// buildTableRows).
 func tableHeaders(results []listResult) []string {
-	headers := []string{"PROVIDER", "DISTRIBUTION", "CLUSTER", "STATUS"}
+	headers := []string{"PROVIDER", "DISTRIBUTION", "CLUSTER", "STATUS", "NODES", "AGE"}
 	if anyTTL(results) {
 		headers = append(headers, "TTL")
 	}
@@ -346,6 +526,28 @@ func formatTTLValue(ttl *state.TTLInfo) string {
 	return formatRemainingDuration(remaining)
 }
 
+// unknownValue is the table cell shown for a NODES or AGE value that could not be determined.
+const unknownValue = "-"
+
+// formatNodesValue returns the node count for display, or "-" when it is unknown (zero).
+func formatNodesValue(nodes int) string {
+	if nodes <= 0 {
+		return unknownValue
+	}
+
+	return strconv.Itoa(nodes)
+}
+
+// formatAgeValue returns the time since created in kubectl's AGE format (e.g. "45m", "2d4h"), or "-"
+// when the creation time is unknown.
+func formatAgeValue(created, now time.Time) string {
+	if created.IsZero() {
+		return unknownValue
+	}
+
+	return duration.HumanDuration(now.Sub(created))
+}
+
 // printTable writes an aligned table with the given header and data rows. Column widths size to the
 // widest cell (header or value); the final column is not padded so trailing whitespace is avoided.
 func printTable(writer io.Writer, headers []string, rows [][]string) {
@@ -437,11 +639,17 @@ type listResult struct {
 	// only). It drives the STATUS column and the JSON "status" field. RunStateUnknown for providers
 	// that cannot report it (cloud providers today).
 	RunState clusterdiscovery.RunState
+	// Nodes is the cluster's node count, from discovery or the saved spec. Zero when unknown.
+	Nodes int
+	// Created is when the cluster was created (oldest node, or when its spec was saved). The zero
+	// time when unknown.
+	Created time.Time
 }
 
 // statusLabel maps a discovered run-state to the human STATUS column / JSON "status" value: "Running"
-// for a running cluster, "Stopped" for a stopped one, and "Unknown" when the provider cannot report
-// run-state (cloud providers today). It is the single source of the status vocabulary the CLI emits,
+// for a running cluster, "Stopped" for a stopped one, "Missing" for a registry-only cluster whose
+// containers are gone, and "Unknown" when the provider cannot report run-state (cloud providers
+// today). It is the single source of the status vocabulary the CLI emits,
 // kept aligned with the v1alpha1.ClusterPhase the web UI surfaces (Ready≈Running, Stopped).
 func statusLabel(runState clusterdiscovery.RunState) string {
 	switch runState {
@@ -451,6 +659,8 @@ func statusLabel(runState clusterdiscovery.RunState) string {
 		return "Stopped"
 	case clusterdiscovery.RunStateUnmanaged:
 		return "Unmanaged"
+	case clusterdiscovery.RunStateMissing:
+		return "Missing"
 	case clusterdiscovery.RunStateUnknown:
 		return "Unknown"
 	default:
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 )
@@ -18,6 +19,9 @@ import (
 // human STATUS column — it lets consumers (the VS Code extension) read cluster
 // status from this contract instead of sniffing `docker ps`.
 //
+// Nodes, Age, and CreatedAt mirror the NODES and AGE columns; they are pointers
+// so an unknown value serialises to null. CreatedAt is RFC 3339 in UTC.
+//
 // TTL is a pointer so it serialises to null when no TTL is set (and to the
 // human-readable remaining duration, or "EXPIRED", otherwise).
 type ListItemJSON struct {
@@ -25,12 +29,20 @@ type ListItemJSON struct {
 	Provider     string  `json:"provider"`
 	Distribution string  `json:"distribution"`
 	Status       string  `json:"status"`
+	Nodes        *int    `json:"nodes"`
+	Age          *string `json:"age"`
+	CreatedAt    *string `json:"createdAt"`
 	TTL          *string `json:"ttl"`
 }
 
 // buildListJSON converts the ordered list results into the JSON contract rows,
 // following the same provider ordering as the human table so both outputs agree.
-func buildListJSON(providers []v1alpha1.Provider, results []listResult) []ListItemJSON {
+// Ages are measured against now.
+func buildListJSON(
+	providers []v1alpha1.Provider,
+	results []listResult,
+	now time.Time,
+) []ListItemJSON {
 	rows := make([]ListItemJSON, 0, len(results))
 
 	for _, prov := range providers {
@@ -39,13 +51,16 @@ func buildListJSON(providers []v1alpha1.Provider, results []listResult) []ListIt
 				continue
 			}
 
-			rows = append(rows, ListItemJSON{
+			item := ListItemJSON{
 				Name:         result.ClusterName,
 				Provider:     strings.ToLower(string(result.Provider)),
 				Distribution: string(result.Distribution),
 				Status:       statusLabel(result.RunState),
 				TTL:          listTTLValue(result),
-			})
+			}
+			setListNodeFacts(&item, result, now)
+
+			rows = append(rows, item)
 		}
 	}
 
@@ -64,6 +79,24 @@ func buildListJSON(providers []v1alpha1.Provider, results []listResult) []ListIt
 	return rows
 }
 
+// setListNodeFacts fills the nodes/age/createdAt fields of a JSON row, leaving
+// each nil (null) when the corresponding fact is unknown.
+func setListNodeFacts(item *ListItemJSON, result listResult, now time.Time) {
+	if result.Nodes > 0 {
+		nodes := result.Nodes
+		item.Nodes = &nodes
+	}
+
+	if result.Created.IsZero() {
+		return
+	}
+
+	age := formatAgeValue(result.Created, now)
+	createdAt := result.Created.UTC().Format(time.RFC3339)
+	item.Age = &age
+	item.CreatedAt = &createdAt
+}
+
 // listTTLValue returns a pointer to the TTL display string, or nil when no TTL
 // is set so the JSON field serialises to null.
 func listTTLValue(result listResult) *string {
@@ -78,8 +111,13 @@ func listTTLValue(result listResult) *string {
 // emitListJSON serialises the cluster list as an indented JSON array and writes
 // it to the writer. An empty result set emits "[]" so consumers always parse a
 // valid array.
-func emitListJSON(writer io.Writer, providers []v1alpha1.Provider, results []listResult) error {
-	rows := buildListJSON(providers, results)
+func emitListJSON(
+	writer io.Writer,
+	providers []v1alpha1.Provider,
+	results []listResult,
+	now time.Time,
+) error {
+	rows := buildListJSON(providers, results, now)
 
 	var buf bytes.Buffer
 
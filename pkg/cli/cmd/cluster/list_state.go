@@ -0,0 +1,144 @@
+package cluster
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterdiscovery"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+)
+
+// clusterKey identifies a cluster across providers: the same name may exist on two providers.
+type clusterKey struct {
+	provider v1alpha1.Provider
+	name     string
+}
+
+// mergeClusterStates folds the local state registry (~/.ksail/clusters) into the discovered
+// clusters. A discovered cluster with a saved spec borrows its node count and age from the spec
+// when the provider did not report them (cloud providers). A saved spec with no discovered cluster
+// is listed too: for Docker it is flagged Missing when the daemon is reachable (its containers were
+// removed outside ksail), otherwise — and for every cloud provider, whose listing may have been
+// skipped for lack of credentials — its status is Unknown. Only providers being listed are merged,
+// so --provider keeps narrowing the output. A registry that cannot be read is a warning, not an
+// error: live discovery still lists what exists.
+func mergeClusterStates(
+	ctx context.Context,
+	errWriter io.Writer,
+	discoverer *clusterdiscovery.Discoverer,
+	deps ListDeps,
+	providers []v1alpha1.Provider,
+	clusters []clusterdiscovery.Cluster,
+) []clusterdiscovery.Cluster {
+	listStates := state.ListClusterStates
+	if deps.ClusterStatesFunc != nil {
+		listStates = deps.ClusterStatesFunc
+	}
+
+	entries, err := listStates()
+	if err != nil {
+		notify.Warningf(errWriter, "%v", err)
+
+		return clusters
+	}
+
+	index := make(map[clusterKey]int, len(clusters))
+	for i, cluster := range clusters {
+		index[clusterKey{provider: cluster.Provider, name: cluster.Name}] = i
+	}
+
+	dockerReachable := dockerReachability(ctx, discoverer)
+
+	for _, entry := range entries {
+		prov := entryProvider(entry.Spec)
+		if !slices.Contains(providers, prov) {
+			continue
+		}
+
+		if i, ok := index[clusterKey{provider: prov, name: entry.Name}]; ok {
+			fillFromState(&clusters[i], entry)
+
+			continue
+		}
+
+		clusters = append(clusters, stateOnlyCluster(entry, prov, dockerReachable))
+	}
+
+	return clusters
+}
+
+// dockerReachability returns a memoised Docker daemon check so the daemon is pinged at most once,
+// and only when a registry-only Docker cluster needs classifying.
+func dockerReachability(
+	ctx context.Context,
+	discoverer *clusterdiscovery.Discoverer,
+) func() bool {
+	var (
+		checked   bool
+		reachable bool
+	)
+
+	return func() bool {
+		if !checked {
+			checked = true
+			availability := discoverer.Availability(ctx, []v1alpha1.Provider{v1alpha1.ProviderDocker})
+			reachable = len(availability) == 1 && availability[0].Available
+		}
+
+		return reachable
+	}
+}
+
+// entryProvider is the provider a saved spec targets, resolving an empty provider to the
+// distribution's default the same way cluster create does.
+func entryProvider(spec *v1alpha1.ClusterSpec) v1alpha1.Provider {
+	if spec.Provider != "" {
+		return spec.Provider
+	}
+
+	return v1alpha1.DefaultProviderForDistribution(spec.Distribution)
+}
+
+// fillFromState completes a discovered cluster's unknown facts from its saved spec.
+func fillFromState(cluster *clusterdiscovery.Cluster, entry state.ClusterStateEntry) {
+	if cluster.Distribution == "" {
+		cluster.Distribution = entry.Spec.Distribution
+	}
+
+	if cluster.Nodes == 0 {
+		cluster.Nodes = int(entry.Spec.TotalNodeCount())
+	}
+
+	if cluster.Created.IsZero() {
+		cluster.Created = entry.SavedAt
+	}
+}
+
+// stateOnlyCluster builds the row for a saved spec that no provider reported. A Missing Docker
+// cluster has no nodes left, so only its age (when the spec was saved) is carried over.
+func stateOnlyCluster(
+	entry state.ClusterStateEntry,
+	prov v1alpha1.Provider,
+	dockerReachable func() bool,
+) clusterdiscovery.Cluster {
+	cluster := clusterdiscovery.Cluster{
+		Name:         entry.Name,
+		Distribution: entry.Spec.Distribution,
+		Provider:     prov,
+		RunState:     clusterdiscovery.RunStateUnknown,
+		Created:      entry.SavedAt,
+	}
+
+	if prov == v1alpha1.ProviderDocker && dockerReachable() {
+		cluster.RunState = clusterdiscovery.RunStateMissing
+
+		return cluster
+	}
+
+	cluster.Nodes = int(entry.Spec.TotalNodeCount())
+
+	return cluster
+}
@@ -14,8 +14,10 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterdiscovery"
 	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/gkampitakis/go-snaps/snaps"
@@ -88,6 +90,7 @@ func TestListCmd_NoClusterFound_DockerProvider(t *testing.T) {
 	cmd.SetContext(context.Background())
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{}}
 		},
@@ -111,6 +114,7 @@ func TestListCmd_SingleClusterFound_DockerProvider(t *testing.T) {
 	cmd.SetContext(context.Background())
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{"test-cluster"}}
 		},
@@ -135,6 +139,7 @@ func TestListCmd_MultipleClustersFound_DockerProvider(t *testing.T) {
 	cmd.SetContext(context.Background())
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{
 				clusters: []string{"cluster-1", "cluster-2", "cluster-3"},
@@ -164,6 +169,7 @@ func TestListCmd_AllProviders(t *testing.T) {
 
 	// Create a mock factory that returns test-cluster for all distributions
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{"test-cluster"}}
 		},
@@ -230,6 +236,7 @@ func TestListCmd_AllProviders_SurfacesUnmanaged(t *testing.T) {
 	cmd.SetContext(context.Background())
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{"test-cluster"}}
 		},
@@ -254,6 +261,7 @@ func TestListCmd_OutputJSON_SurfacesUnmanaged(t *testing.T) {
 	cmd, buf := newListCmdWithJSONOutput(t)
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{}}
 		},
@@ -283,6 +291,7 @@ func TestListCmd_ListError(t *testing.T) {
 	cmd.SetContext(context.Background())
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{
 				listErr: fmt.Errorf("test error: %w", errTestListClusters),
@@ -306,6 +315,7 @@ func TestHandleListRunE_Success(t *testing.T) {
 	cmd.SetContext(context.Background())
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{"test"}}
 		},
@@ -326,6 +336,7 @@ func TestListCmd_FactoryError(t *testing.T) {
 	cmd.SetContext(context.Background())
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithErrors{}
 		},
@@ -660,6 +671,9 @@ type jsonListRow struct {
 	Provider     string  `json:"provider"`
 	Distribution string  `json:"distribution"`
 	Status       string  `json:"status"`
+	Nodes        *int    `json:"nodes"`
+	Age          *string `json:"age"`
+	CreatedAt    *string `json:"createdAt"`
 	TTL          *string `json:"ttl"`
 }
 
@@ -686,6 +700,7 @@ func TestListCmd_OutputJSON_EmptyIsArray(t *testing.T) {
 	cmd, buf := newListCmdWithJSONOutput(t)
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{}}
 		},
@@ -705,6 +720,7 @@ func TestListCmd_OutputJSON_Contract(t *testing.T) {
 	cmd, buf := newListCmdWithJSONOutput(t)
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{"json-contract-cluster"}}
 		},
@@ -736,6 +752,7 @@ func TestListCmd_OutputJSON_TTLIsString(t *testing.T) {
 	cmd, buf := newListCmdWithJSONOutput(t)
 
 	deps := cluster.ListDeps{
+		ClusterStatesFunc: registryEntries(),
 		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
 			return fakeFactoryWithClusters{clusters: []string{clusterName}}
 		},
@@ -750,3 +767,273 @@ func TestListCmd_OutputJSON_TTLIsString(t *testing.T) {
 	require.NotNil(t, rows[0].TTL)
 	assert.NotEmpty(t, *rows[0].TTL)
 }
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestListCmd_Query(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "jmespath",
+			args: []string{"--query", "[?status=='Running'].name | [0]"},
+			want: "query-cluster\n",
+		},
+		{
+			name: "cel",
+			args: []string{
+				"--query-language", "cel",
+				"--query", "object.map(c, c.distribution)",
+			},
+			want: "[\n  \"Vanilla\"\n]\n",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: "list"}
+			outputquery.BindFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(testCase.args))
+
+			var buf bytes.Buffer
+
+			cmd.SetOut(&buf)
+			cmd.SetErr(io.Discard)
+			cmd.SetContext(context.Background())
+
+			deps := cluster.ListDeps{
+				ClusterStatesFunc: registryEntries(),
+				DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
+					return fakeFactoryWithClusters{clusters: []string{"query-cluster"}}
+				},
+				DockerStatusFunc: runningDockerStatus,
+			}
+
+			require.NoError(t, cluster.HandleListRunE(cmd, v1alpha1.ProviderDocker, deps))
+			assert.Equal(t, testCase.want, buf.String())
+		})
+	}
+}
+
+func TestListCmd_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{Use: "list"}
+	outputquery.BindFlags(cmd)
+	require.NoError(t, cmd.ParseFlags([]string{"--query", "[?status=="}))
+
+	err := cluster.HandleListRunE(cmd, v1alpha1.ProviderDocker, cluster.ListDeps{})
+	require.ErrorIs(t, err, outputquery.ErrInvalidQuery)
+}
+
+// listNow is the fixed clock the NODES/AGE tests measure ages against.
+var listNow = time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+
+// threeDockerNodes reports three running nodes, the oldest created two days and four hours before
+// listNow.
+func threeDockerNodes(
+	_ context.Context,
+	_ v1alpha1.Distribution,
+	_ string,
+) ([]provider.NodeInfo, error) {
+	created := listNow.Add(-52 * time.Hour)
+
+	return []provider.NodeInfo{
+		{State: "running", Created: created},
+		{State: "running", Created: created.Add(time.Minute)},
+		{State: "running", Created: created.Add(2 * time.Minute)},
+	}, nil
+}
+
+// registryEntries returns a ClusterStatesFunc seam serving the given entries.
+func registryEntries(
+	entries ...state.ClusterStateEntry,
+) func() ([]state.ClusterStateEntry, error) {
+	return func() ([]state.ClusterStateEntry, error) { return entries, nil }
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestListCmd_NodesAndAgeFromDocker(t *testing.T) {
+	cmd := &cobra.Command{Use: "list"}
+
+	var buf bytes.Buffer
+
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetContext(context.Background())
+
+	deps := cluster.ListDeps{
+		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
+			return fakeFactoryWithClusters{clusters: []string{"dev"}}
+		},
+		DockerNodesFunc:   threeDockerNodes,
+		ClusterStatesFunc: registryEntries(),
+		NowFunc:           func() time.Time { return listNow },
+	}
+
+	require.NoError(t, cluster.HandleListRunE(cmd, v1alpha1.ProviderDocker, deps))
+
+	snaps.MatchSnapshot(t, buf.String())
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestListCmd_MergesStateRegistry(t *testing.T) {
+	savedAt := listNow.Add(-12 * 24 * time.Hour)
+
+	entries := registryEntries(
+		state.ClusterStateEntry{
+			Name:    "dev",
+			Spec:    &v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionVanilla},
+			SavedAt: listNow.Add(-time.Hour),
+		},
+		state.ClusterStateEntry{
+			Name: "gone",
+			Spec: &v1alpha1.ClusterSpec{
+				Distribution:  v1alpha1.DistributionK3s,
+				ControlPlanes: 1,
+				Workers:       2,
+			},
+			SavedAt: savedAt,
+		},
+		state.ClusterStateEntry{
+			Name: "prod",
+			Spec: &v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionTalos,
+				Provider:     v1alpha1.ProviderHetzner,
+			},
+			SavedAt: savedAt,
+		},
+	)
+
+	tests := []struct {
+		name       string
+		pingErr    error
+		goneStatus string
+		goneNodes  *int
+	}{
+		{name: "docker reachable marks missing", goneStatus: "Missing"},
+		{
+			name:       "docker unreachable is unknown",
+			pingErr:    errTestListClusters,
+			goneStatus: "Unknown",
+			goneNodes:  new(3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd, buf := newListCmdWithJSONOutput(t)
+
+			deps := cluster.ListDeps{
+				DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
+					return fakeFactoryWithClusters{clusters: []string{"dev"}}
+				},
+				DockerNodesFunc:   threeDockerNodes,
+				DockerPingFunc:    func(context.Context) error { return test.pingErr },
+				ClusterStatesFunc: entries,
+				NowFunc:           func() time.Time { return listNow },
+			}
+
+			require.NoError(t, cluster.HandleListRunE(cmd, v1alpha1.ProviderDocker, deps))
+
+			var rows []jsonListRow
+
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+			require.Len(t, rows, 2, "the Hetzner entry is filtered out by --provider Docker")
+
+			assert.Equal(t, "dev", rows[0].Name)
+			assert.Equal(t, "Running", rows[0].Status)
+			assert.Equal(t, new(3), rows[0].Nodes)
+			assert.Equal(t, new("2d4h"), rows[0].Age)
+			assert.Equal(t, new("2026-05-08T08:00:00Z"), rows[0].CreatedAt)
+
+			assert.Equal(t, "gone", rows[1].Name)
+			assert.Equal(t, "docker", rows[1].Provider)
+			assert.Equal(t, string(v1alpha1.DistributionK3s), rows[1].Distribution)
+			assert.Equal(t, test.goneStatus, rows[1].Status)
+			assert.Equal(t, test.goneNodes, rows[1].Nodes)
+			assert.Equal(t, new("12d"), rows[1].Age)
+		})
+	}
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestListCmd_StateRegistryErrorIsWarning(t *testing.T) {
+	cmd := &cobra.Command{Use: "list"}
+
+	var out, errOut bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetContext(context.Background())
+
+	deps := cluster.ListDeps{
+		DistributionFactoryCreator: func(_ v1alpha1.Distribution) clusterprovisioner.Factory {
+			return fakeFactoryWithClusters{clusters: []string{"dev"}}
+		},
+		DockerStatusFunc: runningDockerStatus,
+		ClusterStatesFunc: func() ([]state.ClusterStateEntry, error) {
+			return nil, errTestListClusters
+		},
+	}
+
+	require.NoError(t, cluster.HandleListRunE(cmd, v1alpha1.ProviderDocker, deps))
+
+	assert.Contains(t, out.String(), "dev")
+	assert.Contains(t, errOut.String(), errTestListClusters.Error())
+}
+
+// listedNames returns "<distribution>/<name>" for every row of a JSON cluster list.
+func listedNames(t *testing.T, output []byte) []string {
+	t.Helper()
+
+	var rows []jsonListRow
+
+	require.NoError(t, json.Unmarshal(output, &rows))
+
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Distribution+"/"+row.Name)
+	}
+
+	return names
+}
+
+//nolint:paralleltest // uses isolated HOME state via TestMain
+func TestListCmd_TenancyHidesOtherTenantsClusters(t *testing.T) {
+	tenantDeps := func() cluster.ListDeps {
+		return cluster.ListDeps{
+			ClusterStatesFunc: registryEntries(),
+			DistributionFactoryCreator: func(distribution v1alpha1.Distribution) clusterprovisioner.Factory {
+				switch distribution {
+				case v1alpha1.DistributionVanilla:
+					return fakeFactoryWithClusters{clusters: []string{"alice-dev", "bob-dev"}}
+				case v1alpha1.DistributionTalos:
+					return fakeFactoryWithClusters{clusters: []string{"bob-talos"}}
+				default:
+					return fakeFactoryWithClusters{}
+				}
+			},
+			DockerStatusFunc: runningDockerStatus,
+			TenantPrefixFunc: func() (string, error) { return "alice", nil },
+		}
+	}
+
+	cmd, buf := newListCmdWithJSONOutput(t)
+	cmd.Flags().Bool("all-users", false, "")
+
+	require.NoError(t, cluster.HandleListRunE(cmd, v1alpha1.ProviderDocker, tenantDeps()))
+
+	names := listedNames(t, buf.Bytes())
+	assert.Equal(t, []string{"Vanilla/alice-dev", "Talos/bob-talos"}, names,
+		"other tenants' Kind clusters are hidden; tenancy does not scope Talos cluster names")
+
+	cmd, buf = newListCmdWithJSONOutput(t)
+	cmd.Flags().Bool("all-users", false, "")
+	require.NoError(t, cmd.Flags().Set("all-users", "true"))
+
+	require.NoError(t, cluster.HandleListRunE(cmd, v1alpha1.ProviderDocker, tenantDeps()))
+
+	assert.Contains(t, listedNames(t, buf.Bytes()), "Vanilla/bob-dev")
+}
@@ -9,19 +9,23 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	awsarn "github.com/aws/aws-sdk-go-v2/aws/arn"
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/clusterflags"
 	kubeconfigutil "github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
 	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/eksidentity"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenancy"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -44,6 +48,10 @@ const explicitEKSContextHint = "set spec.cluster.connection.context explicitly"
 
 const eksKubeconfigDirMode = 0o700
 
+// typicalEKSCreateDuration is how long one `eksctl create cluster` run commonly takes. Each eksctl
+// run receives one assumed-role session, so a shorter session can expire mid-run.
+const typicalEKSCreateDuration = 30 * time.Minute
+
 // defaultClusterMutationFieldSelectors returns the full set of field selectors
 // used by commands that modify cluster state (create, update).
 // This centralizes the selector list to avoid duplication between commands.
@@ -60,9 +68,20 @@ func defaultClusterMutationFieldSelectors() []ksailconfigmanager.FieldSelector[v
 		ksailconfigmanager.DefaultMetricsServerFieldSelector(),
 		ksailconfigmanager.DefaultLoadBalancerFieldSelector(),
 		ksailconfigmanager.DefaultCertManagerFieldSelector(),
+		ksailconfigmanager.DefaultSealedSecretsFieldSelector(),
+		ksailconfigmanager.DefaultExternalSecretsFieldSelector(),
+		ksailconfigmanager.DefaultBackupFieldSelector(),
 		ksailconfigmanager.DefaultPolicyEngineFieldSelector(),
+		ksailconfigmanager.DefaultIngressControllerFieldSelector(),
+		ksailconfigmanager.DefaultGatewayAPIFieldSelector(),
+		ksailconfigmanager.DefaultServiceMeshFieldSelector(),
+		ksailconfigmanager.DefaultIstioModeFieldSelector(),
+		ksailconfigmanager.DefaultKnativeFieldSelector(),
+		ksailconfigmanager.DefaultIdentityProviderFieldSelector(),
+		ksailconfigmanager.DefaultLoggingFieldSelector(),
 		ksailconfigmanager.DefaultCSIFieldSelector(),
 		ksailconfigmanager.DefaultCDIFieldSelector(),
+		ksailconfigmanager.DefaultMirrorRegistryTLSFieldSelector(),
 		ksailconfigmanager.DefaultImportImagesFieldSelector(),
 		ksailconfigmanager.KubernetesVersionFieldSelector(),
 		ksailconfigmanager.DistributionVersionFieldSelector(),
@@ -97,7 +116,8 @@ func hideConfigOnlyFlags(cmd *cobra.Command) {
 
 // validatePostMutationFlags re-validates the configuration fields that
 // clusterflags.ApplyClusterMutationFlags can change: OIDC extra scopes and Hetzner allowed
-// CIDRs. Shared by create and update so the two commands cannot drift.
+// CIDRs, alongside the config-only Hetzner NodePort CIDRs. Shared by create and update so
+// the two commands cannot drift.
 func validatePostMutationFlags(ctx *localregistry.Context) error {
 	// Re-validate OIDC after merging CLI scope flags which can change ExtraScopes
 	err := v1alpha1.ValidateOIDCConfig(&ctx.ClusterCfg.Spec.Cluster.OIDC)
@@ -111,6 +131,11 @@ func validatePostMutationFlags(ctx *localregistry.Context) error {
 		return fmt.Errorf("allowed CIDRs configuration: %w", err)
 	}
 
+	err = v1alpha1.ValidateAllowedCIDRs(ctx.ClusterCfg.Spec.Provider.Hetzner.NodePortCIDRs)
+	if err != nil {
+		return fmt.Errorf("NodePort CIDRs configuration: %w", err)
+	}
+
 	return nil
 }
 
@@ -212,7 +237,12 @@ func resolveMutationNameOverride(
 			return "", err
 		}
 
-		return explicitName, nil
+		scopedName, err := tenancy.ScopeClusterName(ctx.ClusterCfg, explicitName)
+		if err != nil {
+			return "", fmt.Errorf("resolve tenant: %w", err)
+		}
+
+		return scopedName, validateMutationClusterName(scopedName)
 	}
 
 	metadataName := ctx.ClusterCfg.Name
@@ -294,7 +324,9 @@ func validateEKSNameOverride(ctx *localregistry.Context, explicitName string) er
 
 // runClusterCreationWorkflow performs the full cluster creation workflow.
 // This is the shared implementation used by both the create handler and
-// the update command's recreate flow.
+// the update command's recreate flow. Node boots, image pulls and component
+// installs share one set of weighted slots for the whole workflow, so a small
+// host is not saturated by any of them (see setup.ResourceSlots).
 //
 //nolint:funlen // Sequential workflow steps are clearer kept together
 func runClusterCreationWorkflow(
@@ -303,6 +335,14 @@ func runClusterCreationWorkflow(
 	ctx *localregistry.Context,
 	deps lifecycle.Deps,
 ) (bool, error) {
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	cmd.SetContext(notify.ContextWithSlots(parent,
+		setup.ResourceSlots(parent, ctx.ClusterCfg, getInstallerFactories())))
+
 	localDeps := getLocalRegistryDeps()
 
 	err := ensureLocalRegistriesReady(
@@ -320,6 +360,7 @@ func runClusterCreationWorkflow(
 	setupK3dMetricsServer(ctx.ClusterCfg, ctx.K3dConfig)
 	setupK3dCSI(ctx.ClusterCfg, ctx.K3dConfig)
 	setupK3dLoadBalancer(ctx.ClusterCfg, ctx.K3dConfig)
+	setupK3dIngressController(ctx.ClusterCfg, ctx.K3dConfig)
 	setupVClusterCNI(ctx.ClusterCfg, ctx.VClusterConfig)
 
 	err = resolveNestedMirrorSpecs(cmd, cfgManager, ctx)
@@ -332,6 +373,8 @@ func runClusterCreationWorkflow(
 		return false, err
 	}
 
+	warnShortAssumeRoleSession(cmd, ctx.AWSResolution)
+
 	configureProvisionerFactory(&deps, ctx)
 
 	err = executeClusterCreationAndCapture(cmd, ctx, deps)
@@ -361,6 +404,16 @@ func runClusterCreationWorkflow(
 		if err != nil {
 			return false, fmt.Errorf("failed to connect local registry: %w", err)
 		}
+
+		err = applyNodeResources(cmd, ctx)
+		if err != nil {
+			return false, err
+		}
+
+		err = installNodeTrustBundle(cmd, ctx)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	err = localregistry.WaitForK3dLocalRegistryReady(
@@ -443,6 +496,24 @@ func prepareEKSCreateConfig(ctx *localregistry.Context) error {
 	return nil
 }
 
+// warnShortAssumeRoleSession warns when an assumed role's sessions are shorter than a typical EKS
+// create: eksctl receives a fresh session per run but cannot renew it while it runs.
+func warnShortAssumeRoleSession(cmd *cobra.Command, resolution *credentials.AWSResolution) {
+	if resolution == nil || resolution.AssumeRoleARN() == "" {
+		return
+	}
+
+	duration := resolution.AssumeRoleDuration()
+	if duration <= 0 || duration >= typicalEKSCreateDuration {
+		return
+	}
+
+	notify.Warningf(cmd.ErrOrStderr(),
+		"assumeRoleDuration %s is shorter than a typical EKS create (%s); "+
+			"eksctl fails if its session expires mid-run, so consider raising it",
+		duration, typicalEKSCreateDuration)
+}
+
 func freezeEKSCreateCredentials(
 	ctx context.Context,
 	clusterCtx *localregistry.Context,
@@ -19,6 +19,7 @@ import (
 	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	"github.com/devantler-tech/ksail/v7/pkg/k8s"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
 	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
 	specdiff "github.com/devantler-tech/ksail/v7/pkg/svc/diff"
@@ -105,7 +106,12 @@ func (o *updateOrchestrator) run(outputTimer timer.Timer) error {
 	// Check if provisioner supports updates
 	updater, supportsUpdate := provisioner.(clusterprovisioner.Updater)
 	if !supportsUpdate {
-		return o.runWithoutUpdater()
+		err = o.runWithoutUpdater()
+		if err != nil {
+			return err
+		}
+
+		return o.reconcileSeed()
 	}
 
 	// Compute full diff; return error if current config cannot be retrieved
@@ -118,7 +124,23 @@ func (o *updateOrchestrator) run(outputTimer timer.Timer) error {
 	// Display changes summary
 	displayChangesSummary(o.cmd, diff)
 
-	return o.applyOrReportChanges(updater, currentSpec, diff, outputTimer)
+	err = o.applyOrReportChanges(updater, currentSpec, diff, outputTimer)
+	if err != nil {
+		return err
+	}
+
+	return o.reconcileSeed()
+}
+
+// reconcileSeed re-applies the spec.seed ConfigMaps and Secrets after every
+// update, so values edited in ksail.yaml or drifted in the cluster converge
+// even when the cluster itself has no changes. Dry runs leave them untouched.
+func (o *updateOrchestrator) reconcileSeed() error {
+	if o.dryRun {
+		return nil
+	}
+
+	return applySeed(o.cmd, o.ctx.ClusterCfg, resolveKubeContext(o.ctx))
 }
 
 // runWithoutUpdater handles distributions whose provisioner does not implement
@@ -149,7 +171,7 @@ func (o *updateOrchestrator) runWithoutUpdater() error {
 		return nil
 	}
 
-	return o.executeRecreateFlow()
+	return o.executeRecreateFlow(specDiff.AllChanges())
 }
 
 // reconcileClusterVersions reconciles the cluster's distribution and Kubernetes
@@ -453,6 +475,8 @@ func (o *updateOrchestrator) applyRemainingUpgradeSteps(
 		Writer: o.cmd.OutOrStdout(),
 	})
 
+	o.recordVersionUpgrade(params.upgradeType, params.currentVersion, targetVersion)
+
 	return false, nil
 }
 
@@ -472,7 +496,27 @@ func (o *updateOrchestrator) handleRecreationUpgrade(
 		Writer: o.cmd.OutOrStdout(),
 	})
 
-	return o.executeRecreateFlow()
+	return o.executeRecreateFlow(
+		[]clusterupdate.Change{versionChange(upgradeType, currentVersion, targetVersion)},
+	)
+}
+
+// versionChange describes a version upgrade of one dimension ("Kubernetes" or
+// "distribution") as a change for the audit log.
+func versionChange(upgradeType, fromVersion, toVersion string) clusterupdate.Change {
+	return clusterupdate.Change{
+		Field:    strings.ToLower(upgradeType) + " version",
+		OldValue: fromVersion,
+		NewValue: toVersion,
+	}
+}
+
+// recordVersionUpgrade records an in-place version upgrade in the audit log.
+func (o *updateOrchestrator) recordVersionUpgrade(upgradeType, fromVersion, toVersion string) {
+	recordClusterAudit(o.cmd, o.ctx,
+		audit.NewEntry(audit.OperationUpgrade, o.clusterName).WithChanges(
+			[]clusterupdate.Change{versionChange(upgradeType, fromVersion, toVersion)},
+		))
 }
 
 // pinnedVersionSkipReason indicates why a pinned version upgrade was skipped.
@@ -563,6 +607,8 @@ func (o *updateOrchestrator) executePinnedUpgrade(
 		Writer:  o.cmd.OutOrStdout(),
 	})
 
+	o.recordVersionUpgrade(label, currentVersion, pinnedVersion)
+
 	return false, nil
 }
 
@@ -916,6 +962,9 @@ func (o *updateOrchestrator) computeUpdateDiff(
 	// Check for Flux distribution-version drift (spec.workload.flux.distributionVersion)
 	checkFluxDistributionVersionDrift(o.cmd, o.ctx, diffEngine, diff)
 
+	// Check for Argo CD AppProject drift (spec.workload.argoCD.project)
+	checkArgoCDProjectDrift(o.cmd, o.ctx, diffEngine, diff)
+
 	promoteUnsupportedInPlaceChanges(updater, diff)
 
 	return currentSpec, diff, nil
@@ -1003,6 +1052,9 @@ func computeSpecOnlyDiff(
 	// Check for Flux distribution-version drift (spec.workload.flux.distributionVersion)
 	checkFluxDistributionVersionDrift(cmd, ctx, diffEngine, diff)
 
+	// Check for Argo CD AppProject drift (spec.workload.argoCD.project)
+	checkArgoCDProjectDrift(cmd, ctx, diffEngine, diff)
+
 	return diff
 }
 
@@ -1200,6 +1252,62 @@ func checkFluxDistributionVersionDrift(
 	)
 }
 
+// checkArgoCDProjectDrift compares the restrictions of the running KSail
+// AppProject against spec.workload.argoCD.project. If they differ, an in-place
+// change is appended so cluster update re-asserts the Argo CD resources. ArgoCD
+// only. A missing Application means Argo CD was never bootstrapped, so there is
+// nothing to compare against yet. Errors during cluster queries are logged as
+// warnings and skipped — they should not block the rest of the update.
+func checkArgoCDProjectDrift(
+	cmd *cobra.Command,
+	ctx *localregistry.Context,
+	diffEngine *specdiff.Engine,
+	diff *clusterupdate.UpdateResult,
+) {
+	gitOpsEngine := ctx.ClusterCfg.Spec.Cluster.GitOpsEngine
+	if gitOpsEngine != v1alpha1.GitOpsEngineArgoCD {
+		return
+	}
+
+	kubeconfigPath, err := kubeconfig.GetKubeconfigPathFromConfig(ctx.ClusterCfg)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(),
+			"Cannot resolve kubeconfig path for Argo CD project drift detection: %v", err)
+
+		return
+	}
+
+	mgr, err := argocdclient.NewManagerFromKubeconfig(kubeconfigPath, resolveKubeContext(ctx))
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(),
+			"Cannot create Argo CD manager for project drift detection: %v", err)
+
+		return
+	}
+
+	// Query failures here are already reported by checkWorkloadTagDrift, which
+	// reads the same Application.
+	revision, err := mgr.GetCurrentTargetRevision(cmd.Context(), "")
+	if err != nil || revision == "" {
+		return
+	}
+
+	current, err := mgr.GetCurrentProject(cmd.Context())
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(),
+			"Cannot query current Argo CD project for drift detection: %v", err)
+
+		return
+	}
+
+	diffEngine.CheckArgoCDProject(
+		current.Summary(),
+		setup.ArgoCDProjectOptions(ctx.ClusterCfg).Summary(),
+		gitOpsEngine,
+		diff,
+	)
+}
+
 // getCurrentArgoCDTargetRevision queries the ArgoCD Application for its current
 // targetRevision. Returns empty string if the Application does not exist.
 func getCurrentArgoCDTargetRevision(
@@ -1308,7 +1416,7 @@ func (o *updateOrchestrator) handleRecreateRequired(diff *clusterupdate.UpdateRe
 
 	notify.Warningf(o.cmd.OutOrStderr(), "%s", strings.TrimRight(block.String(), "\n"))
 
-	return o.executeRecreateFlow()
+	return o.executeRecreateFlow(diff.AllChanges())
 }
 
 // errUpdateChangesFailed signals that one or more changes failed to apply during
@@ -1422,6 +1530,10 @@ func finalizeInPlaceApply(
 	// partial apply no longer matches the desired spec, so it must not become the
 	// saved baseline.
 	if result.HasFailedChanges() {
+		entry := audit.NewEntry(audit.OperationUpdate, clusterName).WithChanges(result.AppliedChanges)
+		entry.Error = failedChangesSummary(result.FailedChanges)
+		recordClusterAudit(cmd, ctx, entry)
+
 		return errors.Join(errUpdateChangesFailed, componentErr, componentStateErr)
 	}
 
@@ -1443,18 +1555,56 @@ func finalizeInPlaceApply(
 		notify.Warningf(cmd.OutOrStderr(), "failed to save cluster state: %v", saveErr)
 	}
 
+	publishClusterState(cmd, ctx, clusterName)
+
+	recordClusterAudit(cmd, ctx,
+		audit.NewEntry(audit.OperationUpdate, clusterName).WithChanges(result.AppliedChanges))
+
 	return nil
 }
 
+// failedChangesSummary names the fields an in-place update failed to apply,
+// for the audit entry of a partial update.
+func failedChangesSummary(failed []clusterupdate.Change) string {
+	fields := make([]string, 0, len(failed))
+	for _, change := range failed {
+		fields = append(fields, change.Field)
+	}
+
+	return fmt.Sprintf("%v: %s", errUpdateChangesFailed, strings.Join(fields, ", "))
+}
+
+// guardRecreateImportantState refuses to recreate a cluster that holds persistent volumes, load
+// balancers, or namespaces KSail did not create. --yes may be injected automatically, so the
+// override is the destructive --force-drain (or the deprecated --force).
+func (o *updateOrchestrator) guardRecreateImportantState() error {
+	kubeconfigPath, err := kubeconfig.GetKubeconfigPathFromConfig(o.ctx.ClusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	return guardImportantState(
+		o.cmd, kubeconfigPath, resolveKubeContext(o.ctx),
+		fmt.Sprintf("recreating cluster %q", o.clusterName), forceDrainFlagName, o.forceDrain,
+	)
+}
+
 // executeRecreateFlow performs the delete + create flow with confirmation.
-func (o *updateOrchestrator) executeRecreateFlow() error {
+// changes are the configuration changes that required the recreation; they
+// are recorded in the audit log once the cluster has been recreated.
+func (o *updateOrchestrator) executeRecreateFlow(changes []clusterupdate.Change) error {
 	outputTimer := flags.MaybeTimer(o.cmd, o.deps.Timer)
 
+	err := o.guardRecreateImportantState()
+	if err != nil {
+		return err
+	}
+
 	if !confirmRecreate(o.cmd, o.clusterName, o.consent) {
 		return nil
 	}
 
-	err := lifecycle.VerifyAWSOwnershipBeforeMutation(
+	err = lifecycle.VerifyAWSOwnershipBeforeMutation(
 		o.cmd.Context(),
 		o.ctx.AWSOwnershipVerifier,
 	)
@@ -1504,13 +1654,23 @@ func (o *updateOrchestrator) executeRecreateFlow() error {
 		o.deps,
 	)
 
-	return finishRecreateFlow(
+	err = finishRecreateFlow(
 		o.cmd.Context(),
 		o.ctx,
 		o.clusterName,
 		creationErr,
 		controllerReconciliationStarted,
 	)
+	if err != nil {
+		return err
+	}
+
+	publishClusterState(o.cmd, o.ctx, o.clusterName)
+
+	recordClusterAudit(o.cmd, o.ctx,
+		audit.NewEntry(audit.OperationRecreate, o.clusterName).WithChanges(changes))
+
+	return nil
 }
 
 // disconnectRegistriesBeforeRecreate releases Docker network attachments that
@@ -0,0 +1,453 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/clusterflags"
+	kubeconfigutil "github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// poolSizeFlag sets how many stopped spares the pool keeps.
+	poolSizeFlag = "size"
+	// fromPoolFlag makes cluster create claim a matching pool spare.
+	fromPoolFlag = "from-pool"
+	// poolSpareInfix separates the pool name from a spare's index.
+	poolSpareInfix = "-pool-"
+)
+
+var (
+	errNegativePoolSize     = errors.New("--size must not be negative")
+	errPoolRequiresDocker   = errors.New("cluster pools are only supported with the Docker provider")
+	errPoolSpareStartFailed = errors.New("failed to start pool cluster")
+)
+
+// NewPoolCmd creates the cluster pool command.
+func NewPoolCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pool",
+		Short: "Keep a pool of stopped, pre-provisioned clusters",
+		Long: `Keep a warm standby pool of stopped clusters built from the configuration.
+
+The pool is named after the cluster (metadata.name or --name) and its spares are
+named <name>-pool-1, <name>-pool-2, and so on. Each run brings the pool to
+exactly --size spares: spares created from a different configuration are
+deleted, missing spares are created in full (CNI, components, and GitOps
+setup) and then stopped, and spares beyond --size are deleted. --size 0
+drains the pool.
+
+'ksail cluster create --from-pool' claims the oldest stopped spare whose
+configuration matches and starts it, which takes seconds instead of a full
+create. The claimed cluster keeps its pool name: Kind, K3d, and Talos embed the
+cluster name in container names, node certificates, and the kubeconfig context,
+so it cannot be renamed without recreating the cluster.
+
+Pools are supported with the Docker provider only.`,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cfgManager := setupMutationCmdFlags(cmd)
+
+	cmd.Flags().Int(poolSizeFlag, 1, "Number of stopped spare clusters to keep in the pool")
+
+	cmd.Flags().Bool(skipSizingCheckFlag, false,
+		"Create spares even when the pre-flight sizing check estimates they need more memory than the container engine has")
+
+	lifecycle.AddClientModeFlag(cmd)
+
+	cmd.RunE = lifecycle.WrapHandler(cfgManager, handlePoolRunE)
+
+	return cmd
+}
+
+// handlePoolRunE reconciles the pool of the configured cluster to --size spares.
+func handlePoolRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps lifecycle.Deps,
+) error {
+	deps.Timer.Start()
+
+	size, _ := cmd.Flags().GetInt(poolSizeFlag)
+	if size < 0 {
+		return errNegativePoolSize
+	}
+
+	ctx, poolName, err := loadPoolClusterConfig(cmd, cfgManager, deps)
+	if err != nil {
+		return err
+	}
+
+	specHash, err := state.PoolSpecHash(&ctx.ClusterCfg.Spec.Cluster)
+	if err != nil {
+		return fmt.Errorf("hash cluster spec: %w", err)
+	}
+
+	members, err := poolSpares(poolName)
+	if err != nil {
+		return err
+	}
+
+	kept := 0
+
+	for _, member := range members {
+		if member.SpecHash == specHash && kept < size {
+			kept++
+
+			continue
+		}
+
+		err = retirePoolSpare(cmd, ctx.ClusterCfg, member.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for ; kept < size; kept++ {
+		spareName, err := nextPoolSpareName(poolName)
+		if err != nil {
+			return err
+		}
+
+		err = provisionPoolSpare(cmd, cfgManager, deps, poolName, spareName, specHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	notify.Successf(cmd.OutOrStdout(), "pool %q has %d stopped spare(s)", poolName, size)
+
+	return nil
+}
+
+// loadPoolClusterConfig loads the configuration a pool spare is built from,
+// with the cluster mutation flags applied, and returns it with the pool name.
+func loadPoolClusterConfig(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps lifecycle.Deps,
+) (*localregistry.Context, string, error) {
+	ctx, clusterName, err := loadAndValidateClusterConfig(cfgManager, deps)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = requirePoolProvider(ctx.ClusterCfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	clusterflags.ApplyClusterMutationFlags(cmd, ctx.ClusterCfg)
+
+	err = validatePostMutationFlags(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return ctx, clusterName, nil
+}
+
+// requirePoolProvider rejects providers whose clusters cannot be kept stopped locally.
+func requirePoolProvider(clusterCfg *v1alpha1.Cluster) error {
+	if clusterCfg.Spec.Cluster.Provider != v1alpha1.ProviderDocker {
+		return fmt.Errorf("%w, got %s", errPoolRequiresDocker, clusterCfg.Spec.Cluster.Provider)
+	}
+
+	return nil
+}
+
+// poolSpares returns the unclaimed spares of poolName, oldest first.
+func poolSpares(poolName string) ([]state.PoolMember, error) {
+	members, err := state.ListPoolMembers()
+	if err != nil {
+		return nil, fmt.Errorf("list pool spares: %w", err)
+	}
+
+	spares := make([]state.PoolMember, 0, len(members))
+
+	for _, member := range members {
+		if member.Pool == poolName {
+			spares = append(spares, member)
+		}
+	}
+
+	return spares, nil
+}
+
+// nextPoolSpareName returns the lowest-numbered spare name of poolName that no
+// known cluster uses yet. Claimed spares keep their name, so it is skipped too.
+func nextPoolSpareName(poolName string) (string, error) {
+	entries, err := state.ListClusterStates()
+	if err != nil {
+		return "", fmt.Errorf("list cluster state: %w", err)
+	}
+
+	members, err := state.ListPoolMembers()
+	if err != nil {
+		return "", fmt.Errorf("list pool spares: %w", err)
+	}
+
+	taken := make(map[string]bool, len(entries)+len(members))
+
+	for _, entry := range entries {
+		taken[entry.Name] = true
+	}
+
+	for _, member := range members {
+		taken[member.Name] = true
+	}
+
+	for index := 1; ; index++ {
+		name := poolName + poolSpareInfix + strconv.Itoa(index)
+		if !taken[name] {
+			return name, nil
+		}
+	}
+}
+
+// provisionPoolSpare creates spareName from a freshly loaded configuration,
+// stops it, and records it as a spare of poolName.
+func provisionPoolSpare(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps lifecycle.Deps,
+	poolName, spareName, specHash string,
+) error {
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Add pool spare %s...",
+		Args:    []any{spareName},
+		Emoji:   "🏊",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	// The creation workflow mutates the loaded configuration, so each spare
+	// starts from a fresh load.
+	cfgManager.Reset()
+
+	ctx, _, err := loadPoolClusterConfig(cmd, cfgManager, deps)
+	if err != nil {
+		return err
+	}
+
+	err = applyClusterNameOverride(ctx, spareName)
+	if err != nil {
+		return err
+	}
+
+	err = runSizingPreflight(cmd, ctx.ClusterCfg.Spec.Cluster)
+	if err != nil {
+		return err
+	}
+
+	_, err = runClusterCreationWorkflow(cmd, cfgManager, ctx, deps)
+	if err != nil {
+		return fmt.Errorf("create pool spare %q: %w", spareName, err)
+	}
+
+	provisioner, err := newPoolProvisioner(cmd, ctx.ClusterCfg, spareName)
+	if err != nil {
+		return err
+	}
+
+	err = provisioner.Stop(cmd.Context(), spareName)
+	if err != nil {
+		return fmt.Errorf("stop pool spare %q: %w", spareName, err)
+	}
+
+	err = state.SaveClusterSpec(spareName, &ctx.ClusterCfg.Spec.Cluster)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "failed to save cluster state: %v", err)
+	}
+
+	err = state.SavePoolMember(spareName, state.PoolMember{
+		Pool:      poolName,
+		SpecHash:  specHash,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("record pool spare %q: %w", spareName, err)
+	}
+
+	notify.Successf(cmd.OutOrStdout(), "pool spare %q created and stopped", spareName)
+
+	return nil
+}
+
+// retirePoolSpare claims spareName so no create can take it, then deletes it.
+func retirePoolSpare(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, spareName string) error {
+	err := state.ClaimPoolMember(spareName)
+	if err != nil {
+		if errors.Is(err, state.ErrPoolMemberClaimed) {
+			return nil
+		}
+
+		return fmt.Errorf("retire pool spare %q: %w", spareName, err)
+	}
+
+	provisioner, err := newPoolProvisioner(cmd, clusterCfg, spareName)
+	if err != nil {
+		return err
+	}
+
+	err = provisioner.Delete(cmd.Context(), spareName)
+	if err != nil {
+		return fmt.Errorf("delete pool spare %q: %w", spareName, err)
+	}
+
+	err = state.DeleteClusterState(spareName)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "failed to clean up cluster state: %v", err)
+	}
+
+	notify.Infof(cmd.OutOrStdout(), "deleted pool spare %q", spareName)
+
+	return nil
+}
+
+// newPoolProvisioner returns a provisioner that can start, stop, and delete the
+// pool spare spareName of clusterCfg's distribution.
+func newPoolProvisioner(
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	spareName string,
+) (clusterprovisioner.Provisioner, error) {
+	info := &clusterdetector.Info{
+		ClusterName:    spareName,
+		Distribution:   clusterCfg.Spec.Cluster.Distribution,
+		Provider:       clusterCfg.Spec.Cluster.Provider,
+		KubeconfigPath: clusterCfg.Spec.Cluster.Connection.Kubeconfig,
+	}
+
+	return createDeleteProvisioner(cmd.Context(), info, lifecycle.MinimalProvisionerOptions{})
+}
+
+// claimPoolSpare claims and starts the oldest spare whose spec matches ctx and
+// returns its name, or "" when no spare matches.
+func claimPoolSpare(cmd *cobra.Command, ctx *localregistry.Context) (string, error) {
+	err := requirePoolProvider(ctx.ClusterCfg)
+	if err != nil {
+		return "", err
+	}
+
+	specHash, err := state.PoolSpecHash(&ctx.ClusterCfg.Spec.Cluster)
+	if err != nil {
+		return "", fmt.Errorf("hash cluster spec: %w", err)
+	}
+
+	members, err := state.ListPoolMembers()
+	if err != nil {
+		return "", fmt.Errorf("list pool spares: %w", err)
+	}
+
+	for _, member := range members {
+		if member.SpecHash != specHash {
+			continue
+		}
+
+		err = state.ClaimPoolMember(member.Name)
+		if errors.Is(err, state.ErrPoolMemberClaimed) {
+			continue
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("claim pool spare %q: %w", member.Name, err)
+		}
+
+		err = startPoolSpare(cmd, ctx.ClusterCfg, member)
+		if err != nil {
+			return "", err
+		}
+
+		return member.Name, nil
+	}
+
+	return "", nil
+}
+
+// startPoolSpare starts a claimed spare. A spare that fails to start is
+// returned to the pool so the next run of 'ksail cluster pool' retires it.
+func startPoolSpare(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, member state.PoolMember) error {
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Claim pool cluster %s...",
+		Args:    []any{member.Name},
+		Emoji:   "🏊",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	provisioner, err := newPoolProvisioner(cmd, clusterCfg, member.Name)
+	if err == nil {
+		err = provisioner.Start(cmd.Context(), member.Name)
+	}
+
+	if err != nil {
+		member.SpecHash = ""
+
+		saveErr := state.SavePoolMember(member.Name, member)
+
+		return errors.Join(fmt.Errorf("%w %q: %w", errPoolSpareStartFailed, member.Name, err), saveErr)
+	}
+
+	return nil
+}
+
+// finishPoolClaim relabels a started spare as the created cluster: it records
+// the requested spec and an audit entry under the spare's name, aliases the
+// requested clusterName to it so lifecycle commands resolving clusterName act on
+// the spare, switches the kubeconfig to it, and honors --ttl.
+func finishPoolClaim(
+	cmd *cobra.Command,
+	ctx *localregistry.Context,
+	clusterName, spareName string,
+) error {
+	spec := &ctx.ClusterCfg.Spec.Cluster
+	spec.Connection.Context = resolveCreatedContextName(spec.Distribution, spec.Provider, spareName)
+
+	err := state.SaveClusterSpec(spareName, spec)
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "failed to save cluster state: %v", err)
+	}
+
+	if clusterName != "" && clusterName != spareName {
+		err = state.SaveClusterAlias(clusterName, spareName)
+		if err != nil {
+			notify.Warningf(cmd.OutOrStderr(), "failed to alias %q to the claimed spare: %v", clusterName, err)
+		}
+	}
+
+	kubeconfigPath, err := kubeconfigutil.GetKubeconfigPathFromConfig(ctx.ClusterCfg)
+	if err == nil {
+		_, err = switchContext(kubeconfigPath, spareName)
+	}
+
+	if err != nil {
+		notify.Warningf(cmd.OutOrStderr(), "failed to switch kubeconfig context: %v", err)
+	}
+
+	publishClusterState(cmd, ctx, spareName)
+
+	recordClusterAudit(cmd, ctx, audit.NewEntry(audit.OperationCreate, spareName))
+
+	notify.Successf(cmd.OutOrStdout(),
+		"claimed pool cluster %q as %q", spareName, clusterName)
+
+	return maybeWaitForTTL(cmd, spareName, ctx.ClusterCfg, nil)
+}
@@ -0,0 +1,87 @@
+package cluster_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPoolClusterConfig(distribution v1alpha1.Distribution, provider v1alpha1.Provider) *v1alpha1.Cluster {
+	clusterCfg := v1alpha1.NewCluster()
+	clusterCfg.Spec.Cluster.Distribution = distribution
+	clusterCfg.Spec.Cluster.Provider = provider
+
+	return clusterCfg
+}
+
+func savePoolSpare(t *testing.T, name string, spec *v1alpha1.ClusterSpec, createdAt time.Time) {
+	t.Helper()
+
+	specHash, err := state.PoolSpecHash(spec)
+	require.NoError(t, err)
+
+	require.NoError(t, state.SavePoolMember(name, state.PoolMember{
+		Pool:      "pool-test",
+		SpecHash:  specHash,
+		CreatedAt: createdAt,
+	}))
+
+	t.Cleanup(func() { _ = state.DeleteClusterState(name) })
+}
+
+//nolint:paralleltest // overrides the package-level provisioner factory and shares the test HOME
+func TestClaimPoolSpare_ClaimsOldestMatchingSpare(t *testing.T) {
+	t.Cleanup(cluster.SetProvisionerFactoryForTests(fakeFactory{}))
+
+	clusterCfg := newPoolClusterConfig(v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+	other := newPoolClusterConfig(v1alpha1.DistributionK3s, v1alpha1.ProviderDocker)
+	now := time.Now().UTC()
+
+	savePoolSpare(t, "pool-test-pool-1", &clusterCfg.Spec.Cluster, now)
+	savePoolSpare(t, "pool-test-pool-2", &clusterCfg.Spec.Cluster, now.Add(-time.Hour))
+	savePoolSpare(t, "pool-test-pool-3", &other.Spec.Cluster, now.Add(-2*time.Hour))
+
+	cmd := &cobra.Command{}
+
+	first, err := cluster.ExportClaimPoolSpare(cmd, clusterCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "pool-test-pool-2", first)
+
+	second, err := cluster.ExportClaimPoolSpare(cmd, clusterCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "pool-test-pool-1", second)
+
+	none, err := cluster.ExportClaimPoolSpare(cmd, clusterCfg)
+	require.NoError(t, err)
+	assert.Empty(t, none, "the remaining spare has a different spec")
+}
+
+func TestClaimPoolSpare_RequiresDockerProvider(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := newPoolClusterConfig(v1alpha1.DistributionTalos, v1alpha1.ProviderHetzner)
+
+	_, err := cluster.ExportClaimPoolSpare(&cobra.Command{}, clusterCfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Docker provider")
+}
+
+//nolint:paralleltest // shares the test HOME with the other pool tests
+func TestNextPoolSpareName_SkipsTakenNames(t *testing.T) {
+	clusterCfg := newPoolClusterConfig(v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+
+	require.NoError(t, state.SaveClusterSpec("next-test-pool-1", &clusterCfg.Spec.Cluster))
+	t.Cleanup(func() { _ = state.DeleteClusterState("next-test-pool-1") })
+
+	savePoolSpare(t, "next-test-pool-2", &clusterCfg.Spec.Cluster, time.Now().UTC())
+
+	name, err := cluster.ExportNextPoolSpareName("next-test")
+	require.NoError(t, err)
+	assert.Equal(t, "next-test-pool-3", name)
+}
@@ -95,7 +95,7 @@ func TestRebindEKSOwnershipPersistsOnlyAfterExplicitConfirmation(t *testing.T) {
 	assert.Equal(t, "123456789012", ownership.AccountID)
 	assert.Equal(t, immutableIdentityTime(), ownership.CreatedAt)
 	assert.Equal(t, v1alpha1.OptionsAWS{
-		ProfileEnvVar:         "KSAIL_PROFILE",
+		ProfileEnvVar:         "KSAIL_AWS_PROFILE",
 		RegionEnvVar:          "KSAIL_REGION",
 		AccessKeyIDEnvVar:     "KSAIL_ACCESS",
 		SecretAccessKeyEnvVar: "KSAIL_SECRET",
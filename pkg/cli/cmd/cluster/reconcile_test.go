@@ -12,6 +12,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,8 +40,17 @@ func TestHandlerForField_KnownFields(t *testing.T) {
 		"cluster.loadBalancer",
 		specdiff.EKSLoadBalancerControllerField,
 		"cluster.certManager",
+		"cluster.sealedSecrets",
+		"cluster.externalSecrets",
+		"cluster.backup",
 		"cluster.policyEngine",
+		"cluster.ingressController",
+		"cluster.gatewayAPI",
+		"cluster.knative",
+		"cluster.identityProvider",
+		"cluster.observability.logging",
 		"cluster.gitOpsEngine",
+		specdiff.ArgoCDProjectField,
 		"cluster.autoscaler.node.enabled",
 		"cluster.autoscaler.node.maxNodesTotal",
 		"cluster.autoscaler.node.expander",
@@ -243,6 +253,585 @@ func TestReconcilePolicyEngine_NoneFromEnabled_NilFactory(t *testing.T) {
 	assert.ErrorIs(t, err, setup.ErrPolicyEngineInstallerFactoryNil)
 }
 
+// TestReconcileIngressController_EnabledToNone_UninstallsOldController verifies
+// that disabling the ingress controller uninstalls the release of the previous
+// controller rather than resolving the installer from the desired (None) config.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileIngressController_EnabledToNone_UninstallsOldController(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	var resolved []v1alpha1.IngressController
+
+	restore := cluster.SetIngressControllerInstallerFactoryForTests(
+		func(cfg *v1alpha1.Cluster) (installer.Installer, error) {
+			resolved = append(resolved, cfg.Spec.Cluster.IngressController)
+
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	clusterCfg.Spec.Cluster.IngressController = v1alpha1.IngressControllerNone
+	change := clusterupdate.Change{
+		Field:    "cluster.ingressController",
+		OldValue: string(v1alpha1.IngressControllerTraefik),
+		NewValue: string(v1alpha1.IngressControllerNone),
+	}
+
+	err := cluster.ExportReconcileIngressController(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.IngressController{v1alpha1.IngressControllerTraefik}, resolved)
+	assert.Equal(t, v1alpha1.IngressControllerNone, clusterCfg.Spec.Cluster.IngressController,
+		"the caller's config must not be mutated")
+}
+
+// TestReconcileIngressController_NilFactory verifies that a nil factory returns
+// the factory-nil error.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileIngressController_NilFactory(t *testing.T) {
+	restore := cluster.SetIngressControllerInstallerFactoryForTests(nil)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.ingressController",
+		OldValue: string(v1alpha1.IngressControllerNone),
+		NewValue: string(v1alpha1.IngressControllerNginx),
+	}
+
+	err := cluster.ExportReconcileIngressController(cmd, clusterCfg, change)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, setup.ErrIngressControllerInstallerFactoryNil)
+}
+
+// TestReconcileServiceMesh_IstioToNone_UninstallsOldMesh verifies that
+// disabling the service mesh uninstalls the previously selected mesh.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileServiceMesh_IstioToNone_UninstallsOldMesh(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	var resolved []v1alpha1.ServiceMesh
+
+	restore := cluster.SetServiceMeshInstallerFactoryForTests(
+		func(cfg *v1alpha1.Cluster) (installer.Installer, error) {
+			resolved = append(resolved, cfg.Spec.Cluster.ServiceMesh)
+
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	clusterCfg.Spec.Cluster.ServiceMesh = v1alpha1.ServiceMeshNone
+	change := clusterupdate.Change{
+		Field:    "cluster.serviceMesh",
+		OldValue: string(v1alpha1.ServiceMeshIstio),
+		NewValue: string(v1alpha1.ServiceMeshNone),
+	}
+
+	err := cluster.ExportReconcileServiceMesh(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.ServiceMesh{v1alpha1.ServiceMeshIstio}, resolved)
+	assert.Equal(t, v1alpha1.ServiceMeshNone, clusterCfg.Spec.Cluster.ServiceMesh,
+		"the caller's config must not be mutated")
+}
+
+// TestReconcileServiceMesh_NilFactory verifies that a nil factory returns the
+// factory-nil error.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileServiceMesh_NilFactory(t *testing.T) {
+	restore := cluster.SetServiceMeshInstallerFactoryForTests(nil)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.serviceMesh",
+		OldValue: string(v1alpha1.ServiceMeshNone),
+		NewValue: string(v1alpha1.ServiceMeshLinkerd),
+	}
+
+	err := cluster.ExportReconcileServiceMesh(cmd, clusterCfg, change)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, setup.ErrServiceMeshInstallerFactoryNil)
+}
+
+// TestReconcileIstioMode_ReinstallsInNewMode verifies that switching the Istio
+// mode uninstalls the old mode before installing the new one.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileIstioMode_ReinstallsInNewMode(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+	mockInstaller.EXPECT().Install(mock.Anything).Return(nil).Once()
+
+	var resolved []v1alpha1.IstioMode
+
+	restore := cluster.SetServiceMeshInstallerFactoryForTests(
+		func(cfg *v1alpha1.Cluster) (installer.Installer, error) {
+			resolved = append(resolved, cfg.Spec.Cluster.Istio.Mode)
+
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	clusterCfg.Spec.Cluster.ServiceMesh = v1alpha1.ServiceMeshIstio
+	clusterCfg.Spec.Cluster.Istio.Mode = v1alpha1.IstioModeAmbient
+	change := clusterupdate.Change{
+		Field:    "cluster.istio.mode",
+		OldValue: string(v1alpha1.IstioModeSidecar),
+		NewValue: string(v1alpha1.IstioModeAmbient),
+	}
+
+	err := cluster.ExportReconcileIstioMode(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t, []v1alpha1.IstioMode{v1alpha1.IstioModeSidecar, v1alpha1.IstioModeAmbient}, resolved,
+	)
+}
+
+// TestReconcileIstioMode_NotIstio_NoOp verifies that a mode change is ignored
+// while another mesh is selected.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileIstioMode_NotIstio_NoOp(t *testing.T) {
+	restore := cluster.SetServiceMeshInstallerFactoryForTests(nil)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	clusterCfg.Spec.Cluster.ServiceMesh = v1alpha1.ServiceMeshLinkerd
+	change := clusterupdate.Change{
+		Field:    "cluster.istio.mode",
+		OldValue: string(v1alpha1.IstioModeSidecar),
+		NewValue: string(v1alpha1.IstioModeAmbient),
+	}
+
+	require.NoError(t, cluster.ExportReconcileIstioMode(cmd, clusterCfg, change))
+}
+
+// TestReconcileGatewayAPI_EnvoyToCRDs_UninstallsEnvoy verifies that switching
+// from Envoy to CRDs uninstalls Envoy (resolved from the old value) and then
+// installs the new option.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileGatewayAPI_EnvoyToCRDs_UninstallsEnvoy(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+	mockInstaller.EXPECT().Install(mock.Anything).Return(nil).Once()
+
+	var resolved []v1alpha1.GatewayAPI
+
+	restore := cluster.SetGatewayAPIInstallerFactoryForTests(
+		func(cfg *v1alpha1.Cluster) (installer.Installer, error) {
+			resolved = append(resolved, cfg.Spec.Cluster.GatewayAPI)
+
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	clusterCfg.Spec.Cluster.GatewayAPI = v1alpha1.GatewayAPICRDs
+	change := clusterupdate.Change{
+		Field:    "cluster.gatewayAPI",
+		OldValue: string(v1alpha1.GatewayAPIEnvoy),
+		NewValue: string(v1alpha1.GatewayAPICRDs),
+	}
+
+	err := cluster.ExportReconcileGatewayAPI(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.GatewayAPI{v1alpha1.GatewayAPIEnvoy, v1alpha1.GatewayAPICRDs}, resolved)
+}
+
+// TestReconcileGatewayAPI_ToCilium_Noop verifies that switching to Cilium from
+// an option without an install step neither installs nor uninstalls anything.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileGatewayAPI_ToCilium_Noop(t *testing.T) {
+	restore := cluster.SetGatewayAPIInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			t.Fatal("installer factory must not be called")
+
+			return nil, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.gatewayAPI",
+		OldValue: string(v1alpha1.GatewayAPINone),
+		NewValue: string(v1alpha1.GatewayAPICilium),
+	}
+
+	require.NoError(t, cluster.ExportReconcileGatewayAPI(cmd, clusterCfg, change))
+}
+
+// TestReconcileGatewayAPI_NilFactory verifies that a nil factory returns the
+// factory-nil error.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileGatewayAPI_NilFactory(t *testing.T) {
+	restore := cluster.SetGatewayAPIInstallerFactoryForTests(nil)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.gatewayAPI",
+		OldValue: string(v1alpha1.GatewayAPINone),
+		NewValue: string(v1alpha1.GatewayAPIEnvoy),
+	}
+
+	err := cluster.ExportReconcileGatewayAPI(cmd, clusterCfg, change)
+
+	require.ErrorIs(t, err, setup.ErrGatewayAPIInstallerFactoryNil)
+}
+
+// TestReconcileSealedSecrets_EnabledToDisabled_Uninstalls verifies that
+// disabling Sealed Secrets uninstalls the controller.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileSealedSecrets_EnabledToDisabled_Uninstalls(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetSealedSecretsInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.sealedSecrets",
+		OldValue: string(v1alpha1.SealedSecretsEnabled),
+		NewValue: string(v1alpha1.SealedSecretsDisabled),
+	}
+
+	err := cluster.ExportReconcileSealedSecrets(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileSealedSecrets_NilFactory verifies that a nil factory returns
+// the factory-nil error.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileSealedSecrets_NilFactory(t *testing.T) {
+	restore := cluster.SetSealedSecretsInstallerFactoryForTests(nil)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.sealedSecrets",
+		OldValue: string(v1alpha1.SealedSecretsDisabled),
+		NewValue: string(v1alpha1.SealedSecretsEnabled),
+	}
+
+	err := cluster.ExportReconcileSealedSecrets(cmd, clusterCfg, change)
+
+	require.ErrorIs(t, err, setup.ErrSealedSecretsInstallerFactoryNil)
+}
+
+// TestReconcileExternalSecrets_DisabledToEnabled_Installs verifies that
+// enabling External Secrets installs the operator.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileExternalSecrets_DisabledToEnabled_Installs(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Install(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetExternalSecretsInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.externalSecrets",
+		OldValue: string(v1alpha1.ExternalSecretsDisabled),
+		NewValue: string(v1alpha1.ExternalSecretsEnabled),
+	}
+
+	err := cluster.ExportReconcileExternalSecrets(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileExternalSecrets_EnabledToDisabled_Uninstalls verifies that
+// disabling External Secrets uninstalls the operator.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileExternalSecrets_EnabledToDisabled_Uninstalls(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetExternalSecretsInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.externalSecrets",
+		OldValue: string(v1alpha1.ExternalSecretsEnabled),
+		NewValue: string(v1alpha1.ExternalSecretsDisabled),
+	}
+
+	err := cluster.ExportReconcileExternalSecrets(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileBackup_NoneToVelero_Installs verifies that enabling Velero
+// backups installs Velero.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileBackup_NoneToVelero_Installs(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Install(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetVeleroInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.backup",
+		OldValue: string(v1alpha1.BackupNone),
+		NewValue: string(v1alpha1.BackupVelero),
+	}
+
+	err := cluster.ExportReconcileBackup(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileBackup_VeleroToNone_Uninstalls verifies that disabling Velero
+// backups uninstalls Velero.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileBackup_VeleroToNone_Uninstalls(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetVeleroInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.backup",
+		OldValue: string(v1alpha1.BackupVelero),
+		NewValue: string(v1alpha1.BackupNone),
+	}
+
+	err := cluster.ExportReconcileBackup(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileKnative_DisabledToEnabled_Installs verifies that enabling
+// Knative installs Knative Serving.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileKnative_DisabledToEnabled_Installs(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Install(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetKnativeInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.knative",
+		OldValue: string(v1alpha1.KnativeDisabled),
+		NewValue: string(v1alpha1.KnativeEnabled),
+	}
+
+	err := cluster.ExportReconcileKnative(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileKnative_EnabledToDisabled_Uninstalls verifies that disabling
+// Knative uninstalls Knative Serving.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileKnative_EnabledToDisabled_Uninstalls(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetKnativeInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.knative",
+		OldValue: string(v1alpha1.KnativeEnabled),
+		NewValue: string(v1alpha1.KnativeDisabled),
+	}
+
+	err := cluster.ExportReconcileKnative(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileIdentityProvider_NoneToKeycloak_Installs verifies that selecting
+// Keycloak installs the development identity provider.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileIdentityProvider_NoneToKeycloak_Installs(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Install(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetIdentityProviderInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.identityProvider",
+		OldValue: string(v1alpha1.IdentityProviderNone),
+		NewValue: string(v1alpha1.IdentityProviderKeycloak),
+	}
+
+	err := cluster.ExportReconcileIdentityProvider(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileIdentityProvider_KeycloakToNone_Uninstalls verifies that
+// deselecting Keycloak uninstalls it.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileIdentityProvider_KeycloakToNone_Uninstalls(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetIdentityProviderInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.identityProvider",
+		OldValue: string(v1alpha1.IdentityProviderKeycloak),
+		NewValue: string(v1alpha1.IdentityProviderNone),
+	}
+
+	err := cluster.ExportReconcileIdentityProvider(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileLogging_LokiToNone_Uninstalls verifies that disabling logging
+// uninstalls the Loki stack.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileLogging_LokiToNone_Uninstalls(t *testing.T) {
+	mockInstaller := installer.NewMockInstaller(t)
+	mockInstaller.EXPECT().Uninstall(mock.Anything).Return(nil).Once()
+
+	restore := cluster.SetLoggingInstallerFactoryForTests(
+		func(*v1alpha1.Cluster) (installer.Installer, error) {
+			return mockInstaller, nil
+		},
+	)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.observability.logging",
+		OldValue: string(v1alpha1.LoggingLoki),
+		NewValue: string(v1alpha1.LoggingNone),
+	}
+
+	err := cluster.ExportReconcileLogging(cmd, clusterCfg, change)
+
+	require.NoError(t, err)
+}
+
+// TestReconcileLogging_NilFactory verifies that a nil factory returns the
+// factory-nil error.
+//
+//nolint:paralleltest // mutates global installerFactoriesOverride; cannot run in parallel
+func TestReconcileLogging_NilFactory(t *testing.T) {
+	restore := cluster.SetLoggingInstallerFactoryForTests(nil)
+	t.Cleanup(restore)
+
+	cmd := newReconcileTestCmd()
+	clusterCfg := newReconcileTestClusterCfg()
+	change := clusterupdate.Change{
+		Field:    "cluster.observability.logging",
+		OldValue: string(v1alpha1.LoggingNone),
+		NewValue: string(v1alpha1.LoggingLoki),
+	}
+
+	err := cluster.ExportReconcileLogging(cmd, clusterCfg, change)
+
+	require.ErrorIs(t, err, setup.ErrLoggingInstallerFactoryNil)
+}
+
 // TestReconcileGitOpsEngine_NoneToNone_Noop verifies that None→None is a no-op.
 func TestReconcileGitOpsEngine_NoneToNone_Noop(t *testing.T) {
 	t.Parallel()
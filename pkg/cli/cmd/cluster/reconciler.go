@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -140,12 +141,23 @@ func (r *componentReconciler) handlerForField(
 		"cluster.metricsServer":                     r.reconcileMetricsServer,
 		"cluster.loadBalancer":                      r.reconcileLoadBalancer,
 		"cluster.certManager":                       r.reconcileCertManager,
+		"cluster.sealedSecrets":                     r.reconcileSealedSecrets,
+		"cluster.externalSecrets":                   r.reconcileExternalSecrets,
+		"cluster.backup":                            r.reconcileBackup,
 		"cluster.policyEngine":                      r.reconcilePolicyEngine,
+		"cluster.ingressController":                 r.reconcileIngressController,
+		"cluster.gatewayAPI":                        r.reconcileGatewayAPI,
+		"cluster.serviceMesh":                       r.reconcileServiceMesh,
+		"cluster.istio.mode":                        r.reconcileIstioMode,
+		"cluster.knative":                           r.reconcileKnative,
+		"cluster.identityProvider":                  r.reconcileIdentityProvider,
+		"cluster.observability.logging":             r.reconcileLogging,
 		"cluster.gitOpsEngine":                      r.reconcileGitOpsEngine,
 		"cluster.workload.tag":                      r.reconcileWorkloadTag,
 		"cluster.workload.flux.distributionVersion": r.reconcileFluxVersion,
 	}
 	handlers[specdiff.EKSLoadBalancerControllerField] = r.reconcileLoadBalancer
+	handlers[specdiff.ArgoCDProjectField] = r.reconcileArgoCDProject
 
 	if handler, ok := handlers[field]; ok {
 		return handler, true
@@ -169,10 +181,21 @@ func isComponentReconcileField(field string) bool {
 		"cluster.metricsServer",
 		"cluster.loadBalancer",
 		"cluster.certManager",
+		"cluster.sealedSecrets",
+		"cluster.externalSecrets",
+		"cluster.backup",
 		"cluster.policyEngine",
+		"cluster.ingressController",
+		"cluster.gatewayAPI",
+		"cluster.serviceMesh",
+		"cluster.istio.mode",
+		"cluster.knative",
+		"cluster.identityProvider",
+		"cluster.observability.logging",
 		"cluster.gitOpsEngine",
 		"cluster.workload.tag",
 		"cluster.workload.flux.distributionVersion",
+		specdiff.ArgoCDProjectField,
 		specdiff.EKSLoadBalancerControllerField:
 		return true
 	default:
@@ -480,6 +503,107 @@ func (r *componentReconciler) reconcileCertManager(
 	return nil
 }
 
+// reconcileSealedSecrets installs or uninstalls the Sealed Secrets controller.
+func (r *componentReconciler) reconcileSealedSecrets(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.SealedSecrets == nil {
+		return setup.ErrSealedSecretsInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.SealedSecrets(change.NewValue)
+	oldValue := v1alpha1.SealedSecrets(change.OldValue)
+
+	if newValue == v1alpha1.SealedSecretsDisabled {
+		if oldValue == v1alpha1.SealedSecretsDisabled || oldValue == "" {
+			return nil
+		}
+
+		err := r.uninstallWithFactory(ctx, r.factories.SealedSecrets)
+		if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+			return err
+		}
+
+		return nil
+	}
+
+	err := setup.InstallSealedSecretsSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install sealed secrets: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileExternalSecrets installs or uninstalls the External Secrets Operator.
+func (r *componentReconciler) reconcileExternalSecrets(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.ExternalSecrets == nil {
+		return setup.ErrExternalSecretsInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.ExternalSecrets(change.NewValue)
+	oldValue := v1alpha1.ExternalSecrets(change.OldValue)
+
+	if newValue == v1alpha1.ExternalSecretsDisabled {
+		if oldValue == v1alpha1.ExternalSecretsDisabled || oldValue == "" {
+			return nil
+		}
+
+		err := r.uninstallWithFactory(ctx, r.factories.ExternalSecrets)
+		if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+			return err
+		}
+
+		return nil
+	}
+
+	err := setup.InstallExternalSecretsSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install external secrets: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileBackup installs or uninstalls Velero and its MinIO backup target.
+// Uninstalling keeps the MinIO data volume, so earlier backups come back when
+// Velero is enabled again.
+func (r *componentReconciler) reconcileBackup(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.Velero == nil {
+		return setup.ErrVeleroInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.Backup(change.NewValue)
+	oldValue := v1alpha1.Backup(change.OldValue)
+
+	if newValue == v1alpha1.BackupNone {
+		if oldValue == v1alpha1.BackupNone || oldValue == "" {
+			return nil
+		}
+
+		err := r.uninstallWithFactory(ctx, r.factories.Velero)
+		if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+			return err
+		}
+
+		return nil
+	}
+
+	err := setup.InstallVeleroSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install velero: %w", err)
+	}
+
+	return nil
+}
+
 // reconcilePolicyEngine installs or uninstalls the policy engine.
 func (r *componentReconciler) reconcilePolicyEngine(
 	ctx context.Context,
@@ -513,6 +637,275 @@ func (r *componentReconciler) reconcilePolicyEngine(
 	return nil
 }
 
+// reconcileIngressController switches the ingress controller. Controllers live
+// in different releases and namespaces, so the previous controller is
+// uninstalled (resolved from the old value, not the desired config) before the
+// new one is installed. An unknown baseline value is not uninstalled because the
+// release it would target cannot be determined.
+func (r *componentReconciler) reconcileIngressController(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.IngressController == nil {
+		return setup.ErrIngressControllerInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.IngressController(change.NewValue)
+	oldValue := v1alpha1.IngressController(change.OldValue)
+
+	if oldValue.Enabled() && slices.Contains(v1alpha1.ValidIngressControllers(), oldValue) {
+		oldCfg := *r.clusterCfg
+		oldCfg.Spec.Cluster.IngressController = oldValue
+
+		inst, err := r.factories.IngressController(&oldCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create installer for uninstall: %w", err)
+		}
+
+		err = inst.Uninstall(ctx)
+		if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+			return fmt.Errorf("failed to uninstall ingress controller %s: %w", oldValue, err)
+		}
+	}
+
+	if !newValue.Enabled() {
+		return nil
+	}
+
+	err := setup.InstallIngressControllerSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install ingress controller: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileGatewayAPI switches the Gateway API option. The previous option is
+// uninstalled first (resolved from the old value, not the desired config), which
+// removes Envoy Gateway but keeps the Gateway API CRDs so existing Gateways and
+// routes survive. Cilium needs no install step: its controller ships with the CNI.
+func (r *componentReconciler) reconcileGatewayAPI(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.GatewayAPI == nil {
+		return setup.ErrGatewayAPIInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.GatewayAPI(change.NewValue)
+	oldValue := v1alpha1.GatewayAPI(change.OldValue)
+
+	if oldValue.NeedsInstall() {
+		oldCfg := *r.clusterCfg
+		oldCfg.Spec.Cluster.GatewayAPI = oldValue
+
+		inst, err := r.factories.GatewayAPI(&oldCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create installer for uninstall: %w", err)
+		}
+
+		err = inst.Uninstall(ctx)
+		if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+			return fmt.Errorf("failed to uninstall gateway API %s: %w", oldValue, err)
+		}
+	}
+
+	if !newValue.NeedsInstall() {
+		return nil
+	}
+
+	err := setup.InstallGatewayAPISilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install gateway API: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileServiceMesh switches the service mesh. Meshes live in different
+// releases and namespaces, so the previous mesh is uninstalled (resolved from the
+// old value, not the desired config) before the new one is installed. Workloads
+// keep their injected proxies until they are restarted.
+func (r *componentReconciler) reconcileServiceMesh(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.ServiceMesh == nil {
+		return setup.ErrServiceMeshInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.ServiceMesh(change.NewValue)
+	oldValue := v1alpha1.ServiceMesh(change.OldValue)
+
+	if oldValue.Enabled() && slices.Contains(v1alpha1.ValidServiceMeshes(), oldValue) {
+		oldCfg := *r.clusterCfg
+		oldCfg.Spec.Cluster.ServiceMesh = oldValue
+
+		err := r.uninstallServiceMesh(ctx, &oldCfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !newValue.Enabled() {
+		return nil
+	}
+
+	err := setup.InstallServiceMeshSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install service mesh: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileIstioMode switches Istio between sidecar and ambient mode. The
+// ambient-only CNI node agent and ztunnel are removed with the old mode, then
+// Istio is reinstalled in the new one. Nothing happens unless Istio is the
+// selected mesh.
+func (r *componentReconciler) reconcileIstioMode(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.clusterCfg.Spec.Cluster.ServiceMesh != v1alpha1.ServiceMeshIstio {
+		return nil
+	}
+
+	if r.factories.ServiceMesh == nil {
+		return setup.ErrServiceMeshInstallerFactoryNil
+	}
+
+	oldCfg := *r.clusterCfg
+	oldCfg.Spec.Cluster.Istio.Mode = v1alpha1.IstioMode(change.OldValue)
+
+	err := r.uninstallServiceMesh(ctx, &oldCfg)
+	if err != nil {
+		return err
+	}
+
+	err = setup.InstallServiceMeshSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install service mesh: %w", err)
+	}
+
+	return nil
+}
+
+// uninstallServiceMesh removes the service mesh described by cfg, tolerating
+// releases that are already gone.
+func (r *componentReconciler) uninstallServiceMesh(
+	ctx context.Context,
+	cfg *v1alpha1.Cluster,
+) error {
+	inst, err := r.factories.ServiceMesh(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create installer for uninstall: %w", err)
+	}
+
+	err = inst.Uninstall(ctx)
+	if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+		return fmt.Errorf(
+			"failed to uninstall service mesh %s: %w", cfg.Spec.Cluster.ServiceMesh, err,
+		)
+	}
+
+	return nil
+}
+
+// reconcileKnative installs or uninstalls Knative Serving.
+func (r *componentReconciler) reconcileKnative(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.Knative == nil {
+		return setup.ErrKnativeInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.Knative(change.NewValue)
+	oldValue := v1alpha1.Knative(change.OldValue)
+
+	if newValue == v1alpha1.KnativeDisabled {
+		if oldValue == v1alpha1.KnativeDisabled || oldValue == "" {
+			return nil
+		}
+
+		err := r.uninstallWithFactory(ctx, r.factories.Knative)
+		if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+			return err
+		}
+
+		return nil
+	}
+
+	err := setup.InstallKnativeSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install knative: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileIdentityProvider installs or uninstalls the development identity provider.
+func (r *componentReconciler) reconcileIdentityProvider(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.IdentityProvider == nil {
+		return setup.ErrIdentityProviderInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.IdentityProvider(change.NewValue)
+	oldValue := v1alpha1.IdentityProvider(change.OldValue)
+
+	if newValue == v1alpha1.IdentityProviderNone {
+		if oldValue == v1alpha1.IdentityProviderNone || oldValue == "" {
+			return nil
+		}
+
+		return r.uninstallWithFactory(ctx, r.factories.IdentityProvider)
+	}
+
+	err := setup.InstallIdentityProviderSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install identity provider: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileLogging installs or uninstalls the log aggregation stack.
+func (r *componentReconciler) reconcileLogging(
+	ctx context.Context,
+	change clusterupdate.Change,
+) error {
+	if r.factories.Logging == nil {
+		return setup.ErrLoggingInstallerFactoryNil
+	}
+
+	newValue := v1alpha1.Logging(change.NewValue)
+	oldValue := v1alpha1.Logging(change.OldValue)
+
+	if !newValue.Enabled() {
+		if !oldValue.Enabled() {
+			return nil
+		}
+
+		err := r.uninstallWithFactory(ctx, r.factories.Logging)
+		if err != nil && !errors.Is(err, helm.ErrReleaseNotFound) {
+			return err
+		}
+
+		return nil
+	}
+
+	err := setup.InstallLoggingSilent(ctx, r.clusterCfg, r.factories)
+	if err != nil {
+		return fmt.Errorf("failed to install logging stack: %w", err)
+	}
+
+	return nil
+}
+
 // reconcileGitOpsEngine installs or uninstalls the GitOps engine.
 //
 //nolint:exhaustive // Only Flux and ArgoCD are installable; None is handled above
@@ -630,6 +1023,31 @@ func (r *componentReconciler) reconcileFluxVersion(
 	return nil
 }
 
+// reconcileArgoCDProject re-asserts the Argo CD resources so a changed
+// spec.workload.argoCD.project takes effect in-place on cluster update: the
+// KSail AppProject is created, updated, or removed and the Application is moved
+// onto the matching project. ArgoCD only.
+func (r *componentReconciler) reconcileArgoCDProject(
+	ctx context.Context,
+	_ clusterupdate.Change,
+) error {
+	if r.clusterCfg.Spec.Cluster.GitOpsEngine != v1alpha1.GitOpsEngineArgoCD {
+		return nil
+	}
+
+	kubeconfigPath, err := kubeconfig.GetKubeconfigPathFromConfig(r.clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	err = setup.EnsureArgoCDResources(ctx, kubeconfigPath, r.clusterCfg, r.clusterName)
+	if err != nil {
+		return fmt.Errorf("ensure argocd resources: %w", err)
+	}
+
+	return nil
+}
+
 // reconcileWorkloadTag updates the GitOps sync resource (FluxInstance or ArgoCD
 // Application) to match the desired workload tag from configuration.
 //
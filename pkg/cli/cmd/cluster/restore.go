@@ -7,6 +7,7 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
 	backupsvc "github.com/devantler-tech/ksail/v7/pkg/svc/backup"
 	"github.com/spf13/cobra"
 )
@@ -110,7 +111,7 @@ func runRestore(
 	flags.inputPath = inputPath
 
 	//nolint:contextcheck // buildRestorer→resolveTargetKubeconfig derives ctx from cmd (cluster-cmd convention)
-	restorer, err := buildRestorer(cmd, flags)
+	restorer, target, err := buildRestorer(cmd, flags)
 	if err != nil {
 		return err
 	}
@@ -137,18 +138,24 @@ func runRestore(
 
 	printRestoreFooter(writer, flags)
 
+	if !flags.dryRun {
+		recordAudit(cmd, target,
+			audit.NewEntry(audit.OperationRestore, auditClusterName(cmd, flags.name)))
+	}
+
 	return nil
 }
 
 // buildRestorer validates the restore flags, canonicalizes the input path,
-// resolves the kubeconfig, and constructs the restore engine.
+// resolves the kubeconfig, and constructs the restore engine. The resolved
+// target is returned so the restore can be recorded in the cluster's audit log.
 func buildRestorer(
 	cmd *cobra.Command,
 	flags *restoreFlags,
-) (*backupsvc.Restorer, error) {
+) (*backupsvc.Restorer, auditTarget, error) {
 	if flags.existingResourcePolicy != resourcePolicyNone &&
 		flags.existingResourcePolicy != resourcePolicyUpdate {
-		return nil, ErrInvalidResourcePolicy
+		return nil, auditTarget{}, ErrInvalidResourcePolicy
 	}
 
 	// Canonicalize user-supplied input path (resolve symlinks + absolute)
@@ -156,14 +163,14 @@ func buildRestorer(
 	// attacks are prevented in CI pipelines.
 	canonInput, err := fsutil.EvalCanonicalPath(flags.inputPath)
 	if err != nil {
-		return nil, fmt.Errorf("resolve input path %q: %w", flags.inputPath, err)
+		return nil, auditTarget{}, fmt.Errorf("resolve input path %q: %w", flags.inputPath, err)
 	}
 
 	flags.inputPath = canonInput
 
 	kubeconfigPath, kubeContext, err := resolveTarget(cmd, flags.name)
 	if err != nil {
-		return nil, err
+		return nil, auditTarget{}, err
 	}
 
 	return backupsvc.NewRestorer(backupsvc.RestoreOptions{
@@ -172,7 +179,7 @@ func buildRestorer(
 		InputPath:              flags.inputPath,
 		ExistingResourcePolicy: flags.existingResourcePolicy,
 		DryRun:                 flags.dryRun,
-	}), nil
+	}), auditTarget{kubeconfigPath: kubeconfigPath, kubeContext: kubeContext}, nil
 }
 
 // printRestoreHeader writes the initial restore status lines to the writer.
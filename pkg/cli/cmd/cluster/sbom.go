@@ -0,0 +1,194 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/internal/buildmeta"
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterdiscovery"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sbom"
+	"github.com/spf13/cobra"
+)
+
+// sbomLongDesc describes the `ksail cluster sbom` command.
+const sbomLongDesc = `Produce a software bill of materials for a running cluster.
+
+The inventory covers everything KSail put into the cluster:
+  - the node images the cluster runs on (for Docker-based clusters, the node
+    container images; elsewhere, the nodes' operating system)
+  - the Helm charts of KSail-managed components, with chart and app versions
+  - the container images run by those components and by the distribution in
+    kube-system, with the digest the container runtime resolved
+
+Workloads you deployed yourself are not included. The document is written to
+stdout as SPDX 2.3 JSON (--output spdx, the default) or CycloneDX 1.6 JSON
+(--output cyclonedx), ready to attach to compliance attestations.
+
+The cluster is resolved in the following priority order:
+  1. From --name flag
+  2. From ksail.yaml config file (if present)
+  3. From current kubeconfig context`
+
+// ErrInvalidSBOMFormat is returned when --output names an unsupported SBOM format.
+var ErrInvalidSBOMFormat = errors.New("invalid SBOM format")
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers and Docker daemons.
+var (
+	sbomSourcesFactoryMu sync.RWMutex
+	sbomSourcesFactory   = newSBOMSources
+	sbomDockerNodes      = clusterdiscovery.ListDockerNodes
+)
+
+// newSBOMSources builds the Kubernetes and Helm clients the inventory is read
+// through, scoped to kubeContext.
+func newSBOMSources(kubeconfigPath, kubeContext string) (sbom.Sources, error) {
+	clientset, err := k8s.NewClientset(kubeconfigPath, kubeContext)
+	if err != nil {
+		return sbom.Sources{}, fmt.Errorf("build kubernetes client: %w", err)
+	}
+
+	helmClient, err := helm.NewClient(kubeconfigPath, kubeContext)
+	if err != nil {
+		return sbom.Sources{}, fmt.Errorf("create helm client: %w", err)
+	}
+
+	return sbom.Sources{Clientset: clientset, Helm: helmClient}, nil
+}
+
+// NewSBOMCmd creates the cluster sbom command.
+func NewSBOMCmd() *cobra.Command {
+	var (
+		nameFlag     string
+		providerFlag v1alpha1.Provider
+		outputFlag   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sbom",
+		Short: "Produce an SBOM of what KSail put into a cluster",
+		Long:  sbomLongDesc,
+		Annotations: map[string]string{
+			annotations.AnnotationDescription: "Produce an SPDX or CycloneDX SBOM of a cluster's node images, " +
+				"charts, and component images",
+		},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format := sbom.Format(outputFlag)
+			if !slices.Contains(sbom.Formats(), format) {
+				return fmt.Errorf("%w: %q (supported: spdx, cyclonedx)", ErrInvalidSBOMFormat, outputFlag)
+			}
+
+			return runSBOMCmd(cmd, nameFlag, providerFlag, format)
+		},
+	}
+
+	lifecycle.BindNameAndProviderFlags(cmd, &nameFlag, &providerFlag)
+
+	cmd.Flags().StringVar(&outputFlag, "output", string(sbom.FormatSPDX),
+		"SBOM format: spdx or cyclonedx")
+
+	return cmd
+}
+
+// runSBOMCmd resolves the cluster, collects its inventory, and writes it to
+// stdout in format.
+func runSBOMCmd(
+	cmd *cobra.Command,
+	nameFlag string,
+	providerFlag v1alpha1.Provider,
+	format sbom.Format,
+) error {
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, nameFlag, providerFlag, "")
+	if err != nil {
+		return fmt.Errorf("resolve cluster info: %w", err)
+	}
+
+	kubeContext, err := resolveClusterContext(resolved.KubeconfigPath, resolved.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if kubeContext == "" {
+		return fmt.Errorf("%w: %s", ErrKubeconfigNotFound, resolved.KubeconfigPath)
+	}
+
+	sbomSourcesFactoryMu.RLock()
+	factory := sbomSourcesFactory
+	listDockerNodes := sbomDockerNodes
+	sbomSourcesFactoryMu.RUnlock()
+
+	sources, err := factory(resolved.KubeconfigPath, kubeContext)
+	if err != nil {
+		return err
+	}
+
+	metadata := sbom.Metadata{
+		Cluster:  resolved.ClusterName,
+		Provider: resolved.Provider,
+		Tool:     buildmeta.Version,
+		Created:  time.Now(),
+	}
+
+	if resolved.Provider == v1alpha1.ProviderDocker {
+		distribution, nodes, err := findDockerNodes(cmd.Context(), listDockerNodes, resolved.ClusterName)
+		if err != nil {
+			notify.Warningf(cmd.ErrOrStderr(),
+				"cannot list node containers, recording the nodes' operating system instead: %v", err)
+		}
+
+		metadata.Distribution = distribution
+		sources.Nodes = nodes
+	}
+
+	inventory, err := sbom.Collect(cmd.Context(), metadata, sources)
+	if err != nil {
+		return fmt.Errorf("collect inventory of cluster %q: %w", resolved.ClusterName, err)
+	}
+
+	err = sbom.Write(cmd.OutOrStdout(), inventory, format)
+	if err != nil {
+		return fmt.Errorf("write SBOM: %w", err)
+	}
+
+	return nil
+}
+
+// findDockerNodes returns the node containers of the Docker-based cluster name
+// together with its distribution, which is the first local distribution that
+// has nodes under that name (the same rule `ksail cluster list` applies). The
+// first listing error is returned only when no distribution reported nodes.
+func findDockerNodes(
+	ctx context.Context,
+	listDockerNodes func(context.Context, v1alpha1.Distribution, string) ([]provider.NodeInfo, error),
+	name string,
+) (v1alpha1.Distribution, []provider.NodeInfo, error) {
+	var firstErr error
+
+	for _, distribution := range clusterdiscovery.LocalDistributions() {
+		nodes, err := listDockerNodes(ctx, distribution, name)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
+		}
+
+		if len(nodes) > 0 {
+			return distribution, nodes, nil
+		}
+	}
+
+	return "", nil, firstErr
+}
@@ -0,0 +1,155 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var errDockerUnavailable = errors.New("cannot connect to the Docker daemon")
+
+// useSBOMSources routes the sbom command's clients to a fake API server with
+// one kind node, serves Docker node containers from dockerNodes, and targets a
+// kubeconfig with a kind-shared context.
+func useSBOMSources(
+	t *testing.T,
+	dockerNodes func(context.Context, v1alpha1.Distribution, string) ([]provider.NodeInfo, error),
+) {
+	t.Helper()
+
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	t.Setenv("KUBECONFIG", writeKubeconfigWithContext(t, workingDir, "kind-shared"))
+
+	clientset := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-control-plane"},
+		Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+			KubeletVersion: "v1.35.1",
+			OSImage:        "Debian GNU/Linux 12 (bookworm)",
+		}},
+	})
+
+	t.Cleanup(cluster.SetSBOMSourcesForTests(
+		func(_, kubeContext string) (sbom.Sources, error) {
+			assert.Equal(t, "kind-shared", kubeContext)
+
+			return sbom.Sources{Clientset: clientset}, nil
+		},
+		dockerNodes,
+	))
+}
+
+// runSBOM executes `cluster sbom` with args and returns stdout and stderr.
+func runSBOM(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	sbomCmd := cluster.NewSBOMCmd()
+	sbomCmd.SetOut(&stdout)
+	sbomCmd.SetErr(&stderr)
+	sbomCmd.SetContext(context.Background())
+	sbomCmd.SetArgs(append([]string{"--name", "shared"}, args...))
+
+	err := sbomCmd.Execute()
+
+	return stdout.String(), stderr.String(), err
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the sbom sources factory
+func TestSBOMCmd_CycloneDXWithNodeImage(t *testing.T) {
+	useSBOMSources(t, func(_ context.Context, distribution v1alpha1.Distribution, name string) (
+		[]provider.NodeInfo, error,
+	) {
+		assert.Equal(t, "shared", name)
+
+		if distribution != v1alpha1.DistributionVanilla {
+			return nil, nil
+		}
+
+		return []provider.NodeInfo{{Name: "shared-control-plane", Image: "kindest/node:v1.35.1"}}, nil
+	})
+
+	stdout, stderr, err := runSBOM(t, "--output", "cyclonedx")
+	require.NoError(t, err, stderr)
+	assert.Empty(t, stderr)
+
+	var bom struct {
+		BOMFormat string `json:"bomFormat"`
+		Metadata  struct {
+			Component struct {
+				Name       string `json:"name"`
+				Version    string `json:"version"`
+				Properties []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"properties"`
+			} `json:"component"`
+		} `json:"metadata"`
+		Components []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"components"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(stdout), &bom))
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "shared", bom.Metadata.Component.Name)
+	assert.Equal(t, "v1.35.1", bom.Metadata.Component.Version)
+	assert.Contains(t, bom.Metadata.Component.Properties, struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}{Name: "ksail:distribution", Value: "Vanilla"})
+	require.Len(t, bom.Components, 1)
+	assert.Equal(t, "index.docker.io/kindest/node", bom.Components[0].Name)
+	assert.Equal(t, "v1.35.1", bom.Components[0].Version)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the sbom sources factory
+func TestSBOMCmd_SPDXFallsBackWhenDockerIsUnavailable(t *testing.T) {
+	useSBOMSources(t, func(context.Context, v1alpha1.Distribution, string) ([]provider.NodeInfo, error) {
+		return nil, errDockerUnavailable
+	})
+
+	stdout, stderr, err := runSBOM(t)
+	require.NoError(t, err, stderr)
+	assert.Contains(t, stderr, "cannot list node containers")
+	assert.Contains(t, stderr, errDockerUnavailable.Error())
+
+	var document struct {
+		SPDXVersion string `json:"spdxVersion"`
+		Packages    []struct {
+			Name string `json:"name"`
+		} `json:"packages"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(stdout), &document))
+	assert.Equal(t, "SPDX-2.3", document.SPDXVersion)
+	require.Len(t, document.Packages, 2)
+	assert.Equal(t, "Debian GNU/Linux 12 (bookworm)", document.Packages[1].Name)
+}
+
+func TestSBOMCmd_RejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	sbomCmd := cluster.NewSBOMCmd()
+	sbomCmd.SetOut(&bytes.Buffer{})
+	sbomCmd.SetErr(&bytes.Buffer{})
+	sbomCmd.SetArgs([]string{"--output", "swid"})
+
+	err := sbomCmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrInvalidSBOMFormat)
+}
@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	kubeconfigutil "github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/seed"
+	"github.com/spf13/cobra"
+)
+
+// applySeed creates or reconciles the spec.seed ConfigMaps and Secrets in the
+// cluster reachable through kubeContext. It is a no-op when nothing is seeded.
+func applySeed(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, kubeContext string) error {
+	if !clusterCfg.Spec.Seed.Enabled() {
+		return nil
+	}
+
+	resources, err := seed.Build(clusterCfg.Spec.Seed)
+	if err != nil {
+		return fmt.Errorf("failed to build seed resources: %w", err)
+	}
+
+	kubeconfigPath, err := kubeconfigutil.GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig path: %w", err)
+	}
+
+	clientset, err := k8s.NewClientset(kubeconfigPath, kubeContext)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	err = seed.Apply(cmd.Context(), clientset, resources)
+	if err != nil {
+		return fmt.Errorf("failed to apply seed resources: %w", err)
+	}
+
+	notify.Successf(cmd.OutOrStdout(), "seeded %d ConfigMaps and Secrets", resources.Count())
+
+	return nil
+}
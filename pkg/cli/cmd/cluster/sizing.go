@@ -0,0 +1,136 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/recommend"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sizing"
+	"github.com/spf13/cobra"
+)
+
+// skipSizingCheckFlag is the create flag that turns a refused sizing pre-flight into a warning.
+const skipSizingCheckFlag = "skip-sizing-check"
+
+// ErrInsufficientHostResources is returned by create when the sizing pre-flight estimates the
+// cluster needs more memory than the container engine has.
+var ErrInsufficientHostResources = errors.New("insufficient host resources for the cluster")
+
+//nolint:gochecknoglobals // Injected for testability to avoid a real container engine.
+var (
+	hostResourcesProbeMu sync.RWMutex
+	hostResourcesProbe   = probeHostResources
+)
+
+// probeHostResources reads the CPUs and memory available to containers. The container engine's
+// figures win over the host's, since on Docker Desktop and Podman machines the engine runs in a VM
+// with its own allocation.
+func probeHostResources(ctx context.Context) sizing.Host {
+	var info recommend.HostInfo
+
+	engine, err := dockerclient.GetConcreteDockerClient()
+	if err != nil {
+		info = recommend.ProbeHost(ctx, nil)
+	} else {
+		defer func() { _ = engine.Close() }()
+
+		info = recommend.ProbeHost(ctx, engine)
+	}
+
+	return sizing.Host{CPUs: info.CPUs, MemoryBytes: info.MemoryBytes}
+}
+
+// checkClusterSizing estimates the cluster's footprint against the local host. Remote providers
+// are not checked.
+func checkClusterSizing(ctx context.Context, spec v1alpha1.ClusterSpec) (sizing.Result, bool) {
+	if !spec.Provider.NeedsLocalDocker() {
+		return sizing.Result{}, false
+	}
+
+	hostResourcesProbeMu.RLock()
+	probe := hostResourcesProbe
+	hostResourcesProbeMu.RUnlock()
+
+	return sizing.Check(spec, probe(ctx)), true
+}
+
+// runSizingPreflight refuses to create a local cluster the host cannot hold, and warns about one
+// that would leave it little headroom, listing changes that shrink the cluster. With
+// --skip-sizing-check a refusal is downgraded to a warning.
+func runSizingPreflight(cmd *cobra.Command, spec v1alpha1.ClusterSpec) error {
+	result, checked := checkClusterSizing(cmd.Context(), spec)
+	if !checked {
+		return nil
+	}
+
+	//nolint:exhaustive // fitting and unknown-host results need no output
+	switch result.Verdict {
+	case sizing.VerdictTight:
+		writeSizingWarning(cmd.ErrOrStderr(), result)
+	case sizing.VerdictInsufficient:
+		skip, _ := cmd.Flags().GetBool(skipSizingCheckFlag)
+
+		writeSizingWarning(cmd.ErrOrStderr(), result)
+
+		if !skip {
+			return fmt.Errorf(
+				"%w: needs about %s of memory, %s available (pass --%s to create it anyway)",
+				ErrInsufficientHostResources,
+				sizing.FormatMemory(result.Requirement.Total.MemoryBytes),
+				sizing.FormatMemory(result.Host.MemoryBytes),
+				skipSizingCheckFlag,
+			)
+		}
+	}
+
+	return nil
+}
+
+// writeSizingWarning prints the verdict's reasons followed by the suggestions.
+func writeSizingWarning(writer io.Writer, result sizing.Result) {
+	for _, reason := range result.Reasons {
+		notify.Warningf(writer, "%s", reason)
+	}
+
+	if len(result.Suggestions) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(writer, "To shrink the cluster:")
+
+	for _, suggestion := range result.Suggestions {
+		_, _ = fmt.Fprintf(writer, "  - %s\n", suggestion)
+	}
+}
+
+// reportSizingDryRun prints the estimated footprint and how it compares with the host as part of
+// `cluster create --dry-run`.
+func reportSizingDryRun(cmd *cobra.Command, spec v1alpha1.ClusterSpec) {
+	result, checked := checkClusterSizing(cmd.Context(), spec)
+	if !checked {
+		return
+	}
+
+	total := result.Requirement.Total
+
+	if result.Verdict == sizing.VerdictUnknown {
+		notify.Infof(cmd.OutOrStdout(), "Estimated footprint: %s of memory, %s (host resources unknown)",
+			sizing.FormatMemory(total.MemoryBytes), sizing.FormatCPU(total.MilliCPU))
+
+		return
+	}
+
+	notify.Infof(cmd.OutOrStdout(), "Estimated footprint: %s of memory, %s (host: %s, %d CPUs; %s)",
+		sizing.FormatMemory(total.MemoryBytes), sizing.FormatCPU(total.MilliCPU),
+		sizing.FormatMemory(result.Host.MemoryBytes), result.Host.CPUs, result.Verdict)
+
+	if result.Verdict != sizing.VerdictFits {
+		writeSizingWarning(cmd.OutOrStdout(), result)
+	}
+}
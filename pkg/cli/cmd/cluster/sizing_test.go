@@ -0,0 +1,101 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sizing"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSizingCmd returns a command carrying the create sizing flag, writing all output to one buffer.
+func newSizingCmd(t *testing.T, skip bool) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "create"}
+	cmd.Flags().Bool("skip-sizing-check", skip, "")
+
+	var buf bytes.Buffer
+
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetContext(context.Background())
+
+	return cmd, &buf
+}
+
+// withHost makes the sizing pre-flight see the given host for the rest of the test.
+func withHost(t *testing.T, host sizing.Host) {
+	t.Helper()
+
+	t.Cleanup(cluster.SetHostResourcesProbeForTests(
+		func(context.Context) sizing.Host { return host },
+	))
+}
+
+// heavySpec needs about 2.9 GiB of memory and 1.6 CPUs.
+var heavySpec = v1alpha1.ClusterSpec{
+	Distribution: v1alpha1.DistributionVanilla,
+	Provider:     v1alpha1.ProviderDocker,
+	Workers:      2,
+	CNI:          v1alpha1.CNICilium,
+	GitOpsEngine: v1alpha1.GitOpsEngineFlux,
+}
+
+//nolint:paralleltest // overrides the package-level host probe
+func TestSizingPreflight_RefusesInsufficientHost(t *testing.T) {
+	withHost(t, sizing.Host{CPUs: 4, MemoryBytes: 2 << 30})
+
+	cmd, buf := newSizingCmd(t, false)
+
+	err := cluster.ExportRunSizingPreflight(cmd, heavySpec)
+
+	require.ErrorIs(t, err, cluster.ErrInsufficientHostResources)
+	assert.Contains(t, err.Error(), "--skip-sizing-check")
+	assert.Contains(t, buf.String(), "--workers 0")
+	assert.Contains(t, buf.String(), "--cni Default")
+}
+
+//nolint:paralleltest // overrides the package-level host probe
+func TestSizingPreflight_SkipDowngradesToWarning(t *testing.T) {
+	withHost(t, sizing.Host{CPUs: 4, MemoryBytes: 2 << 30})
+
+	cmd, buf := newSizingCmd(t, true)
+
+	require.NoError(t, cluster.ExportRunSizingPreflight(cmd, heavySpec))
+	assert.Contains(t, buf.String(), "only 2.0 GiB is available")
+}
+
+//nolint:paralleltest // overrides the package-level host probe
+func TestSizingPreflight_WarnsWhenTight(t *testing.T) {
+	withHost(t, sizing.Host{CPUs: 4, MemoryBytes: 3 << 30})
+
+	cmd, buf := newSizingCmd(t, false)
+
+	require.NoError(t, cluster.ExportRunSizingPreflight(cmd, heavySpec))
+	assert.Contains(t, buf.String(), "leaving little for workloads")
+}
+
+//nolint:paralleltest // overrides the package-level host probe
+func TestSizingPreflight_QuietWhenItFitsOrIsRemote(t *testing.T) {
+	withHost(t, sizing.Host{CPUs: 1, MemoryBytes: 1 << 30})
+
+	remote := heavySpec
+	remote.Distribution = v1alpha1.DistributionTalos
+	remote.Provider = v1alpha1.ProviderHetzner
+
+	cmd, buf := newSizingCmd(t, false)
+
+	require.NoError(t, cluster.ExportRunSizingPreflight(cmd, remote))
+	assert.Empty(t, buf.String())
+
+	withHost(t, sizing.Host{CPUs: 16, MemoryBytes: 32 << 30})
+
+	require.NoError(t, cluster.ExportRunSizingPreflight(cmd, heavySpec))
+	assert.Empty(t, buf.String())
+}
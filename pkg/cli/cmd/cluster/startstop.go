@@ -5,6 +5,7 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/spf13/cobra"
 )
@@ -43,13 +44,16 @@ func NewStartCmd() *cobra.Command {
 		) error {
 			return provisioner.Start(ctx, clusterName)
 		},
-		Guard: unmanagedClusterGuard,
+		Guard:       unmanagedClusterGuard,
+		AfterAction: recordResolvedAudit(audit.OperationStart),
 	})
 
 	cmd.Annotations = map[string]string{
 		annotations.AnnotationPermission: permissionWrite,
 	}
 
+	lifecycle.AddClientModeFlag(cmd)
+
 	return cmd
 }
 
@@ -73,11 +77,16 @@ func NewStopCmd() *cobra.Command {
 			return provisioner.Stop(ctx, clusterName)
 		},
 		Guard: unmanagedClusterGuard,
+		// The API server is unreachable once the cluster is stopped, so the stop
+		// is recorded when it is issued.
+		BeforeAction: recordResolvedAudit(audit.OperationStop),
 	})
 
 	cmd.Annotations = map[string]string{
 		annotations.AnnotationPermission: permissionWrite,
 	}
 
+	lifecycle.AddClientModeFlag(cmd)
+
 	return cmd
 }
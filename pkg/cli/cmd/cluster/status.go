@@ -0,0 +1,537 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterstatus"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/opmetrics"
+	fcolor "github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// statusLongDesc describes the `ksail cluster status` command.
+const statusLongDesc = `Show a health report for a running cluster.
+
+The report combines, in one view:
+  - node readiness and kubelet versions
+  - control-plane component health (static control-plane pods in kube-system,
+    or the embedded control plane of K3s, vCluster, and KWOK) and cluster DNS
+  - the reconciliation state of the installed GitOps engine (Flux
+    Kustomizations and HelmReleases, or Argo CD Applications)
+  - the installed versions of KSail-managed components, read from their Helm
+    releases
+
+The overall verdict is Healthy, Degraded (a node is NotReady, a GitOps
+resource is not reconciled, or a component release is not deployed), or
+Unhealthy (no node is Ready or a control-plane component is down).
+
+Use --watch to refresh the report every --interval until interrupted. Add
+--metrics-address to serve the health verdict, node and control-plane
+readiness, and check counters as Prometheus metrics on /metrics while watching.
+
+Use --query to print only part of the JSON report, e.g. --query health or
+--query "nodes[?!ready].name" (JMESPath), or a CEL expression over "object"
+with --query-language cel.
+
+The cluster is resolved in the following priority order:
+  1. From --name flag
+  2. From ksail.yaml config file (if present)
+  3. From current kubeconfig context
+
+Exit code 0 is returned whatever the verdict. A non-zero exit code indicates
+the cluster's nodes could not be listed (e.g., the cluster is unreachable).`
+
+// defaultStatusInterval is the refresh interval of `cluster status --watch`.
+const defaultStatusInterval = 5 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal before a --watch refresh.
+const clearScreen = "\033[H\033[2J"
+
+// ErrInvalidStatusInterval is returned when --interval is not positive.
+var ErrInvalidStatusInterval = errors.New("--interval must be positive")
+
+// ErrMetricsRequireWatch is returned when --metrics-address is set without --watch.
+var ErrMetricsRequireWatch = errors.New("--metrics-address requires --watch")
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	statusSourcesFactoryMu sync.RWMutex
+	statusSourcesFactory   = newStatusSources
+)
+
+// newStatusSources builds the clients the status report reads from, all scoped to
+// kubeContext. Flux and Argo CD reconcilers are created lazily by the collector
+// only when their engine is detected.
+func newStatusSources(kubeconfigPath, kubeContext string) (clusterstatus.Sources, error) {
+	clientset, err := k8s.NewClientset(kubeconfigPath, kubeContext)
+	if err != nil {
+		return clusterstatus.Sources{}, fmt.Errorf("build kubernetes client: %w", err)
+	}
+
+	sources := clusterstatus.Sources{
+		Clientset: clientset,
+		FluxStatuses: func(ctx context.Context) ([]fluxclient.ResourceStatus, error) {
+			reconciler, err := fluxclient.NewReconcilerForContext(kubeconfigPath, kubeContext)
+			if err != nil {
+				return nil, fmt.Errorf("create flux client: %w", err)
+			}
+
+			return reconciler.ListResourceStatuses(ctx)
+		},
+		ArgoCDStatuses: func(ctx context.Context) ([]argocd.ApplicationStatus, error) {
+			reconciler, err := argocd.NewReconcilerForContext(kubeconfigPath, kubeContext)
+			if err != nil {
+				return nil, fmt.Errorf("create argocd client: %w", err)
+			}
+
+			return reconciler.ListApplicationStatuses(ctx)
+		},
+	}
+
+	// Without Helm the report still covers nodes and the control plane.
+	helmClient, err := helm.NewClient(kubeconfigPath, kubeContext)
+	if err == nil {
+		sources.Helm = helmClient
+	}
+
+	return sources, nil
+}
+
+// NewStatusCmd creates the cluster status command.
+func NewStatusCmd() *cobra.Command {
+	var (
+		nameFlag     string
+		providerFlag v1alpha1.Provider
+		outputFlag   string
+		watchFlag    bool
+		intervalFlag time.Duration
+		metricsAddr  string
+	)
+
+	cmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Show a health report for a cluster",
+		Long:         statusLongDesc,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := validateOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			if intervalFlag <= 0 {
+				return fmt.Errorf("%w: %s", ErrInvalidStatusInterval, intervalFlag)
+			}
+
+			if metricsAddr != "" && !watchFlag {
+				return ErrMetricsRequireWatch
+			}
+
+			return runStatusCmd(cmd, nameFlag, providerFlag, watchFlag, intervalFlag, metricsAddr)
+		},
+	}
+
+	lifecycle.BindNameAndProviderFlags(cmd, &nameFlag, &providerFlag)
+
+	cmd.Flags().StringVar(
+		&outputFlag,
+		"output",
+		"text",
+		"Output format: text or json. Use json for machine-readable structured output.",
+	)
+	cmd.Flags().BoolVarP(&watchFlag, "watch", "w", false,
+		"Refresh the report every --interval until interrupted")
+	cmd.Flags().DurationVar(&intervalFlag, "interval", defaultStatusInterval,
+		"Refresh interval for --watch")
+	cmd.Flags().StringVar(&metricsAddr, "metrics-address", "",
+		"Serve Prometheus metrics on this address (e.g. 127.0.0.1:9293) while --watch runs")
+	outputquery.BindFlags(cmd)
+
+	return cmd
+}
+
+// runStatusCmd resolves the cluster's kubeconfig context, then collects and
+// renders the report once, or repeatedly with --watch.
+func runStatusCmd(
+	cmd *cobra.Command,
+	nameFlag string,
+	providerFlag v1alpha1.Provider,
+	watch bool,
+	interval time.Duration,
+	metricsAddr string,
+) error {
+	query, err := outputquery.FromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, nameFlag, providerFlag, "")
+	if err != nil {
+		return fmt.Errorf("resolve cluster info: %w", err)
+	}
+
+	kubeContext, err := resolveClusterContext(resolved.KubeconfigPath, resolved.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if kubeContext == "" {
+		return fmt.Errorf("%w: %s", ErrKubeconfigNotFound, resolved.KubeconfigPath)
+	}
+
+	statusSourcesFactoryMu.RLock()
+	factory := statusSourcesFactory
+	statusSourcesFactoryMu.RUnlock()
+
+	sources, err := factory(resolved.KubeconfigPath, kubeContext)
+	if err != nil {
+		return err
+	}
+
+	render := renderStatusText
+
+	switch {
+	case query != nil:
+		render = func(writer io.Writer, report clusterstatus.Report) error {
+			return query.Render(writer, report)
+		}
+	case getOutputFormat(cmd) == outputFormatJSON:
+		render = renderStatusJSON
+	}
+
+	collect := func(ctx context.Context) (clusterstatus.Report, error) {
+		report, err := clusterstatus.Collect(ctx, resolved.ClusterName, sources)
+		if err != nil {
+			return report, fmt.Errorf("collect status of cluster %q: %w", resolved.ClusterName, err)
+		}
+
+		return report, nil
+	}
+
+	if !watch {
+		report, err := collect(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		return render(cmd.OutOrStdout(), report)
+	}
+
+	if metricsAddr == "" {
+		return watchStatus(cmd, collect, render, interval)
+	}
+
+	return watchStatusWithMetrics(cmd, collect, render, interval, metricsAddr)
+}
+
+// watchStatusWithMetrics runs watchStatus while serving the metrics of every
+// collection on metricsAddr. The listener is bound before the first collection
+// so an address already in use is reported immediately.
+func watchStatusWithMetrics(
+	cmd *cobra.Command,
+	collect func(context.Context) (clusterstatus.Report, error),
+	render func(io.Writer, clusterstatus.Report) error,
+	interval time.Duration,
+	metricsAddr string,
+) error {
+	listener, err := opmetrics.Listen(cmd.Context(), metricsAddr)
+	if err != nil {
+		return fmt.Errorf("start metrics listener: %w", err)
+	}
+
+	recorder := opmetrics.NewRecorder()
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	serveErr := make(chan error, 1)
+
+	go func() { serveErr <- recorder.Serve(ctx, listener) }()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "serving metrics on http://%s%s",
+		Args:    []any{listener.Addr(), opmetrics.MetricsPath},
+		Writer:  cmd.ErrOrStderr(),
+	})
+
+	observed := func(ctx context.Context) (clusterstatus.Report, error) {
+		report, err := collect(ctx)
+		if err != nil {
+			recorder.ObserveStatusError()
+		} else {
+			recorder.ObserveStatus(report)
+		}
+
+		return report, err
+	}
+
+	err = watchStatus(cmd, observed, render, interval)
+
+	cancel()
+
+	return errors.Join(err, <-serveErr)
+}
+
+// watchStatus re-renders the report every interval until the command's context
+// is cancelled or the process is interrupted. A failed collection is reported and
+// retried on the next tick, since a restarting cluster is what --watch is for.
+func watchStatus(
+	cmd *cobra.Command,
+	collect func(context.Context) (clusterstatus.Report, error),
+	render func(io.Writer, clusterstatus.Report) error,
+	interval time.Duration,
+) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	writer := cmd.OutOrStdout()
+	interactive := isTerminal(writer)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for first := true; ; first = false {
+		report, err := collect(ctx)
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case interactive:
+			_, _ = fmt.Fprint(writer, clearScreen)
+		case !first:
+			_, _ = fmt.Fprintln(writer)
+		}
+
+		if err != nil {
+			notify.Errorf(writer, "%v", err)
+		} else {
+			err = render(writer, report)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTerminal reports whether writer is an interactive terminal.
+func isTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+
+	//nolint:gosec // uintptr to int conversion is safe for term.IsTerminal usage
+	return term.IsTerminal(int(file.Fd()))
+}
+
+// renderStatusJSON writes the report as one indented JSON document.
+func renderStatusJSON(writer io.Writer, report clusterstatus.Report) error {
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(report)
+	if err != nil {
+		return fmt.Errorf("encode status report: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:gochecknoglobals // cached color objects to avoid per-call allocations
+var (
+	statusColorOK   = fcolor.New(fcolor.FgGreen)
+	statusColorWarn = fcolor.New(fcolor.FgYellow)
+	statusColorFail = fcolor.New(fcolor.FgRed)
+)
+
+// statusMark returns a colored ✔ or ✗.
+func statusMark(ok bool) string {
+	if ok {
+		return statusColorOK.Sprint("✔")
+	}
+
+	return statusColorFail.Sprint("✗")
+}
+
+// healthLabel colors the overall verdict.
+func healthLabel(health clusterstatus.Health) string {
+	switch health {
+	case clusterstatus.HealthHealthy:
+		return statusColorOK.Sprint(health)
+	case clusterstatus.HealthDegraded:
+		return statusColorWarn.Sprint(health)
+	case clusterstatus.HealthUnhealthy:
+		return statusColorFail.Sprint(health)
+	default:
+		return string(health)
+	}
+}
+
+// renderStatusText writes the report as colored sections. Colors are dropped
+// automatically when stdout is not a terminal or NO_COLOR is set.
+func renderStatusText(writer io.Writer, report clusterstatus.Report) error {
+	_, _ = fmt.Fprintf(writer, "Cluster %q: %s (checked %s)\n",
+		report.ClusterName, healthLabel(report.Health), report.CheckedAt.Format(time.TimeOnly))
+
+	writeStatusNodes(writer, report.Nodes)
+	writeStatusControlPlane(writer, report.ControlPlane)
+	writeStatusGitOps(writer, report.GitOps)
+	writeStatusComponents(writer, report)
+
+	if len(report.Warnings) > 0 {
+		_, _ = fmt.Fprintln(writer)
+
+		for _, warning := range report.Warnings {
+			notify.Warningf(writer, "could not read %s", warning)
+		}
+	}
+
+	return nil
+}
+
+// writeStatusTable aligns tab-separated rows into columns, trimming the padding
+// an empty last cell (a healthy row's message) would leave behind.
+func writeStatusTable(writer io.Writer, rows []string) {
+	var buf bytes.Buffer
+
+	table := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(table, row)
+	}
+
+	_ = table.Flush()
+
+	for line := range strings.Lines(buf.String()) {
+		_, _ = fmt.Fprintln(writer, strings.TrimRight(line, " \n"))
+	}
+}
+
+// writeStatusNodes writes one row per node with its roles, kubelet version, and
+// the reason it is not Ready.
+func writeStatusNodes(writer io.Writer, nodes []clusterstatus.NodeStatus) {
+	ready := 0
+
+	for _, node := range nodes {
+		if node.Ready {
+			ready++
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nNodes (%d/%d ready):\n", ready, len(nodes))
+
+	rows := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		roles := strings.Join(node.Roles, ",")
+		if roles == "" {
+			roles = "<none>"
+		}
+
+		rows = append(rows, fmt.Sprintf("  %s %s\t%s\t%s\t%s",
+			statusMark(node.Ready), node.Name, roles, node.Version, node.Message))
+	}
+
+	writeStatusTable(writer, rows)
+}
+
+// writeStatusControlPlane writes one row per control-plane component with its
+// ready pod count.
+func writeStatusControlPlane(writer io.Writer, components []clusterstatus.ComponentHealth) {
+	if len(components) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(writer, "\nControl plane:")
+
+	rows := make([]string, 0, len(components))
+
+	for _, component := range components {
+		pods := fmt.Sprintf("%d/%d ready", component.ReadyPods, component.TotalPods)
+		if component.Embedded {
+			pods = "embedded"
+		}
+
+		rows = append(rows, fmt.Sprintf("  %s %s\t%s", statusMark(component.Ready()), component.Name, pods))
+	}
+
+	writeStatusTable(writer, rows)
+}
+
+// writeStatusGitOps writes the engine followed by one row per resource.
+func writeStatusGitOps(writer io.Writer, gitops clusterstatus.GitOpsStatus) {
+	if gitops.Engine == "" || gitops.Engine == v1alpha1.GitOpsEngineNone {
+		_, _ = fmt.Fprintln(writer, "\nGitOps: (none)")
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nGitOps (%s):\n", gitops.Engine)
+
+	if len(gitops.Resources) == 0 {
+		_, _ = fmt.Fprintln(writer, "  no resources")
+
+		return
+	}
+
+	rows := make([]string, 0, len(gitops.Resources))
+
+	for _, resource := range gitops.Resources {
+		rows = append(rows, fmt.Sprintf("  %s %s\t%s\t%s\t%s",
+			statusMark(resource.Healthy), resource.Kind, resource.Namespace+"/"+resource.Name,
+			resource.State, resource.Message))
+	}
+
+	writeStatusTable(writer, rows)
+}
+
+// writeStatusComponents writes one row per KSail-managed component with its
+// chart, app version, and release status.
+func writeStatusComponents(writer io.Writer, report clusterstatus.Report) {
+	if len(report.Components) == 0 {
+		_, _ = fmt.Fprintln(writer, "\nComponents: (none detected)")
+
+		return
+	}
+
+	_, _ = fmt.Fprintln(writer, "\nComponents:")
+
+	rows := make([]string, 0, len(report.Components))
+
+	for _, component := range report.Components {
+		rows = append(rows, fmt.Sprintf("  %s %s\t%s\t%s\t%s",
+			statusMark(component.Deployed()), component.Component, component.Chart,
+			component.AppVersion, component.Status))
+	}
+
+	writeStatusTable(writer, rows)
+}
@@ -0,0 +1,325 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterstatus"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var errStatusAPIDown = errors.New("connection refused")
+
+func TestNewStatusCmd(t *testing.T) {
+	t.Parallel()
+
+	statusCmd := cluster.NewStatusCmd()
+	require.NotNil(t, statusCmd)
+
+	assert.Equal(t, "status", statusCmd.Name())
+	assert.True(t, statusCmd.SilenceUsage)
+
+	for _, flagName := range []string{
+		"name", "provider", "output", "watch", "interval", "query", "query-language",
+	} {
+		assert.NotNil(t, statusCmd.Flags().Lookup(flagName), "expected --%s flag", flagName)
+	}
+
+	assert.Equal(t, "5s", statusCmd.Flags().Lookup("interval").DefValue)
+}
+
+func TestStatusCmd_RejectsInvalidFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{name: "unknown output", args: []string{"--output", "xml"}, wantErr: cluster.ErrUnsupportedOutputFormat},
+		{name: "zero interval", args: []string{"--interval", "0s"}, wantErr: cluster.ErrInvalidStatusInterval},
+		{name: "invalid query", args: []string{"--query", "nodes[?"}, wantErr: outputquery.ErrInvalidQuery},
+		{
+			name:    "metrics without watch",
+			args:    []string{"--metrics-address", "127.0.0.1:0"},
+			wantErr: cluster.ErrMetricsRequireWatch,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			statusCmd := cluster.NewStatusCmd()
+			statusCmd.SetOut(io.Discard)
+			statusCmd.SetErr(io.Discard)
+			statusCmd.SetArgs(testCase.args)
+
+			err := statusCmd.Execute()
+
+			require.ErrorIs(t, err, testCase.wantErr)
+		})
+	}
+}
+
+func statusNode(name string, ready bool, role string) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"node-role.kubernetes.io/" + role: ""},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.33.1"},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: status, Message: "container runtime network not ready"},
+			},
+		},
+	}
+}
+
+func statusPod(name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem, Labels: labels},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+// statusHelm serves releases from a mock Helm client, both for GitOps engine
+// detection and component versions.
+func statusHelm(t *testing.T, releases ...helm.ReleaseInfo) *helm.MockInterface {
+	t.Helper()
+
+	helmClient := helm.NewMockInterface(t)
+	helmClient.On("ReleaseExists", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, name, namespace string) bool {
+			for _, release := range releases {
+				if release.Name == name && release.Namespace == namespace {
+					return true
+				}
+			}
+
+			return false
+		},
+		nil,
+	).Maybe()
+	helmClient.On("ListReleases", mock.Anything).Return(releases, nil).Maybe()
+
+	return helmClient
+}
+
+// useStatusSources routes the status command's clients to sources and targets a
+// kubeconfig with a kind-shared context.
+func useStatusSources(t *testing.T, sources clusterstatus.Sources) {
+	t.Helper()
+
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	t.Setenv("KUBECONFIG", writeKubeconfigWithContext(t, workingDir, "kind-shared"))
+
+	sources.Now = func() time.Time { return time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC) }
+
+	restore := cluster.SetStatusSourcesFactoryForTests(
+		func(_, kubeContext string) (clusterstatus.Sources, error) {
+			assert.Equal(t, "kind-shared", kubeContext)
+
+			return sources, nil
+		},
+	)
+	t.Cleanup(restore)
+}
+
+func degradedClusterSources(t *testing.T) clusterstatus.Sources {
+	t.Helper()
+
+	return clusterstatus.Sources{
+		Clientset: fake.NewClientset(
+			statusNode("shared-control-plane", true, "control-plane"),
+			statusNode("shared-worker", false, "worker"),
+			statusPod("etcd-shared-control-plane", map[string]string{"tier": "control-plane", "component": "etcd"}),
+			statusPod("kube-apiserver-shared-control-plane", map[string]string{
+				"tier": "control-plane", "component": "kube-apiserver",
+			}),
+			statusPod("coredns-abc", map[string]string{"k8s-app": "kube-dns"}),
+		),
+		Helm: statusHelm(t,
+			helm.ReleaseInfo{
+				Name: detector.ReleaseCilium, Namespace: detector.NamespaceCilium, Revision: 1,
+				Status: "deployed", Chart: "cilium-1.16.3", AppVersion: "1.16.3",
+			},
+			helm.ReleaseInfo{
+				Name: detector.ReleaseFluxOperator, Namespace: detector.NamespaceFluxOperator, Revision: 1,
+				Status: "deployed", Chart: "flux-operator-0.9.0", AppVersion: "v0.9.0",
+			},
+		),
+		FluxStatuses: func(context.Context) ([]fluxclient.ResourceStatus, error) {
+			return []fluxclient.ResourceStatus{
+				{Kind: fluxclient.KindKustomization, Name: "flux-system", Namespace: "flux-system", Ready: true},
+				{
+					Kind: fluxclient.KindHelmRelease, Name: "podinfo", Namespace: "apps",
+					Reason: "InstallFailed", Message: "install retries exhausted",
+				},
+			}, nil
+		},
+	}
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the status sources factory
+func TestStatusCmd_TextReport(t *testing.T) {
+	useStatusSources(t, degradedClusterSources(t))
+
+	statusCmd := cluster.NewStatusCmd()
+
+	var out bytes.Buffer
+	statusCmd.SetOut(&out)
+	statusCmd.SetErr(&out)
+	statusCmd.SetContext(context.Background())
+	statusCmd.SetArgs([]string{"--name", "shared"})
+
+	err := statusCmd.Execute()
+	require.NoError(t, err, out.String())
+
+	snaps.MatchSnapshot(t, out.String())
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the status sources factory
+func TestStatusCmd_JSONReport(t *testing.T) {
+	useStatusSources(t, degradedClusterSources(t))
+
+	statusCmd := cluster.NewStatusCmd()
+
+	var out bytes.Buffer
+	statusCmd.SetOut(&out)
+	statusCmd.SetErr(io.Discard)
+	statusCmd.SetContext(context.Background())
+	statusCmd.SetArgs([]string{"--name", "shared", "--output", "json"})
+
+	err := statusCmd.Execute()
+	require.NoError(t, err)
+
+	var report clusterstatus.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+
+	assert.Equal(t, "shared", report.ClusterName)
+	assert.Equal(t, clusterstatus.HealthDegraded, report.Health)
+	assert.Len(t, report.Nodes, 2)
+	assert.Len(t, report.GitOps.Resources, 2)
+	assert.Len(t, report.Components, 2)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the status sources factory
+func TestStatusCmd_QueryReport(t *testing.T) {
+	useStatusSources(t, degradedClusterSources(t))
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "jmespath", args: []string{"--query", "health"}, want: "Degraded\n"},
+		{
+			name: "cel",
+			args: []string{"--query-language", "cel", "--query", "size(object.nodes)"},
+			want: "2\n",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			statusCmd := cluster.NewStatusCmd()
+
+			var out bytes.Buffer
+			statusCmd.SetOut(&out)
+			statusCmd.SetErr(io.Discard)
+			statusCmd.SetContext(context.Background())
+			statusCmd.SetArgs(append([]string{"--name", "shared"}, testCase.args...))
+
+			require.NoError(t, statusCmd.Execute())
+			assert.Equal(t, testCase.want, out.String())
+		})
+	}
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the status sources factory
+func TestStatusCmd_UnreachableClusterFails(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errStatusAPIDown
+	})
+
+	useStatusSources(t, clusterstatus.Sources{Clientset: clientset})
+
+	statusCmd := cluster.NewStatusCmd()
+	statusCmd.SetOut(io.Discard)
+	statusCmd.SetErr(io.Discard)
+	statusCmd.SetContext(context.Background())
+	statusCmd.SetArgs([]string{"--name", "shared"})
+
+	err := statusCmd.Execute()
+
+	require.ErrorIs(t, err, errStatusAPIDown)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the status sources factory
+func TestStatusCmd_WatchRefreshesUntilCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var listed atomic.Int32
+
+	clientset := fake.NewClientset(statusNode("shared-control-plane", true, "control-plane"))
+	clientset.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		// The first refresh fails as if the API server were restarting; the watch
+		// must keep going. The third refresh ends the watch.
+		switch listed.Add(1) {
+		case 1:
+			return true, nil, errStatusAPIDown
+		case 3:
+			cancel()
+		}
+
+		return false, nil, nil
+	})
+
+	useStatusSources(t, clusterstatus.Sources{Clientset: clientset})
+
+	statusCmd := cluster.NewStatusCmd()
+
+	var out bytes.Buffer
+	statusCmd.SetOut(&out)
+	statusCmd.SetErr(&out)
+	statusCmd.SetContext(ctx)
+	statusCmd.SetArgs([]string{"--name", "shared", "--watch", "--interval", "10ms"})
+
+	err := statusCmd.Execute()
+	require.NoError(t, err, out.String())
+
+	assert.Equal(t, int32(3), listed.Load())
+	assert.Contains(t, out.String(), errStatusAPIDown.Error())
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte(`Cluster "shared": Healthy`)))
+}
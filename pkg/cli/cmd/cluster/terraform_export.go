@@ -0,0 +1,194 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/clusterflags"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/hetzner"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tfexport"
+	"github.com/spf13/cobra"
+)
+
+// exportTerraformLongDesc describes the `ksail cluster export-terraform` command.
+const exportTerraformLongDesc = `Export the cloud infrastructure of a cluster as a Terraform/OpenTofu module,
+so it can be handed over to an infrastructure-as-code pipeline without being
+recreated.
+
+The module holds a versions.tf with the provider requirements and an
+imports.tf with one import block per cloud resource:
+  - Hetzner: every server, the private network and its subnets, the firewall,
+    the placement group, the floating IP, and the load balancers attached to
+    the cluster network
+  - AWS: the EKS cluster (node groups, VPC, and IAM roles stay in the
+    CloudFormation stacks eksctl manages)
+  - GCP: the GKE cluster
+  - Azure: the AKS cluster
+
+Complete the module with 'terraform plan -generate-config-out=generated.tf',
+or pass --import to run init, plan, and apply with tofu (or terraform, or
+--binary). The import plan is only applied when it changes nothing besides
+importing; otherwise it is kept for review. Credentials are read by the
+Terraform provider from its usual environment variables (e.g. HCLOUD_TOKEN).
+
+Local providers (Docker, Kubernetes) and Omni have no cloud resources to export.`
+
+// ErrTerraformExportUnsupported is returned for providers without cloud resources to export.
+var ErrTerraformExportUnsupported = errors.New("terraform export is not supported for provider")
+
+// ErrNoCloudResources is returned when no cloud resources of the cluster were found.
+var ErrNoCloudResources = errors.New("no cloud resources found for cluster")
+
+// ErrTerraformExportMissingValue is returned when an import ID cannot be built
+// because a provider setting is not configured.
+var ErrTerraformExportMissingValue = errors.New("missing provider setting for terraform export")
+
+// NewExportTerraformCmd creates the cluster export-terraform command.
+func NewExportTerraformCmd() *cobra.Command {
+	var (
+		dirFlag    string
+		importFlag bool
+		binaryFlag string
+		forceFlag  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-terraform",
+		Short: "Export cloud resources as Terraform/OpenTofu import blocks",
+		Long:  exportTerraformLongDesc,
+		Annotations: map[string]string{
+			annotations.AnnotationDescription: "Export a cloud cluster's infrastructure as a Terraform/OpenTofu " +
+				"module of import blocks, optionally importing it into state",
+			annotations.AnnotationPermission: permissionWrite,
+		},
+		SilenceUsage: true,
+	}
+
+	cfgManager := ksailconfigmanager.NewCommandConfigManager(cmd, append(
+		ksailconfigmanager.DefaultClusterFieldSelectors(),
+		ksailconfigmanager.DefaultProviderFieldSelector(),
+	))
+
+	hideConfigOnlyFlags(cmd)
+	clusterflags.RegisterNameFlag(cmd, cfgManager)
+
+	cmd.Flags().StringVar(&dirFlag, "dir", "terraform",
+		"Directory to write the Terraform module to")
+	cmd.Flags().BoolVar(&importFlag, "import", false,
+		"Run init, plan, and apply to import the resources into Terraform state")
+	cmd.Flags().StringVar(&binaryFlag, "binary", "",
+		"Terraform or OpenTofu executable used by --import (default: tofu, then terraform from PATH)")
+	cmd.Flags().BoolVar(&forceFlag, "force", false,
+		"Overwrite existing versions.tf and imports.tf files")
+
+	cmd.RunE = lifecycle.WrapHandler(cfgManager,
+		func(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager, _ lifecycle.Deps) error {
+			module, err := buildTerraformModule(cmd, cfgManager)
+			if err != nil {
+				return err
+			}
+
+			err = tfexport.Write(dirFlag, module, forceFlag)
+			if err != nil {
+				return fmt.Errorf("write terraform module: %w", err)
+			}
+
+			notify.Successf(cmd.OutOrStdout(), "wrote %d import block(s) for cluster %q to %s",
+				len(module.Resources), module.Cluster, dirFlag)
+
+			if !importFlag {
+				return nil
+			}
+
+			return importTerraformModule(cmd, dirFlag, binaryFlag)
+		})
+
+	return cmd
+}
+
+// buildTerraformModule returns the module importing the cloud resources of
+// the cluster configured in cfgManager.
+func buildTerraformModule(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+) (tfexport.Module, error) {
+	clusterCfg := cfgManager.Config
+	distCfg := cfgManager.DistributionConfig
+	clusterName := resolveClusterNameFromContext(localregistry.NewContextFromConfigManager(cfgManager))
+
+	switch clusterCfg.Spec.Cluster.Provider {
+	case v1alpha1.ProviderHetzner:
+		prov, _, err := hetzner.NewProviderFromOptions(clusterCfg.Spec.Provider.Hetzner)
+		if err != nil {
+			return tfexport.Module{}, fmt.Errorf("create hetzner provider: %w", err)
+		}
+
+		resources, err := prov.ListClusterResources(cmd.Context(), clusterName)
+		if err != nil {
+			return tfexport.Module{}, fmt.Errorf("list cluster resources: %w", err)
+		}
+
+		if len(resources) == 0 {
+			return tfexport.Module{}, fmt.Errorf("%w: %s", ErrNoCloudResources, clusterName)
+		}
+
+		return tfexport.HetznerModule(clusterName, resources), nil
+	case v1alpha1.ProviderAWS:
+		region := lifecycle.ResolveAWSRegion(clusterCfg.Spec.Provider.AWS, distCfg)
+		if region == "" {
+			return tfexport.Module{}, fmt.Errorf("%w: AWS region (set AWS_REGION or metadata.region in eks.yaml)",
+				ErrTerraformExportMissingValue)
+		}
+
+		return tfexport.EKSModule(clusterName, region), nil
+	case v1alpha1.ProviderGCP:
+		if distCfg == nil || distCfg.GKE == nil || distCfg.GKE.Project == "" || distCfg.GKE.Location == "" {
+			return tfexport.Module{}, fmt.Errorf("%w: GCP project and location (set GOOGLE_CLOUD_PROJECT "+
+				"and GOOGLE_CLOUD_LOCATION, or the variables named in spec.provider.gcp)",
+				ErrTerraformExportMissingValue)
+		}
+
+		return tfexport.GKEModule(clusterName, distCfg.GKE.Project, distCfg.GKE.Location), nil
+	case v1alpha1.ProviderAzure:
+		if distCfg == nil || distCfg.AKS == nil ||
+			distCfg.AKS.SubscriptionID == "" || distCfg.AKS.ResourceGroup == "" {
+			return tfexport.Module{}, fmt.Errorf("%w: Azure subscription and resource group (set "+
+				"AZURE_SUBSCRIPTION_ID and AZURE_RESOURCE_GROUP, or the variables named in spec.provider.azure)",
+				ErrTerraformExportMissingValue)
+		}
+
+		return tfexport.AKSModule(clusterName, distCfg.AKS.SubscriptionID, distCfg.AKS.ResourceGroup), nil
+	case v1alpha1.ProviderDocker, v1alpha1.ProviderOmni, v1alpha1.ProviderKubernetes:
+		return tfexport.Module{}, fmt.Errorf("%w %s", ErrTerraformExportUnsupported,
+			clusterCfg.Spec.Cluster.Provider)
+	default:
+		return tfexport.Module{}, fmt.Errorf("%w %s", ErrTerraformExportUnsupported,
+			clusterCfg.Spec.Cluster.Provider)
+	}
+}
+
+// importTerraformModule imports the module written to dir into Terraform state.
+func importTerraformModule(cmd *cobra.Command, dir, binary string) error {
+	resolved, err := tfexport.ResolveBinary(binary)
+	if err != nil {
+		return fmt.Errorf("import terraform module: %w", err)
+	}
+
+	notify.Infof(cmd.OutOrStdout(), "importing resources with %s (this may take a few minutes)", resolved)
+
+	err = tfexport.NewImporter(resolved, dir).Import(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("import terraform module: %w", err)
+	}
+
+	notify.Successf(cmd.OutOrStdout(), "imported resources into terraform state in %s; configuration written to %s",
+		dir, tfexport.GeneratedConfigFile)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExportTerraformCmd(t *testing.T) {
+	t.Parallel()
+
+	exportCmd := cluster.NewExportTerraformCmd()
+	require.NotNil(t, exportCmd)
+
+	assert.Equal(t, "export-terraform", exportCmd.Name())
+	assert.True(t, exportCmd.SilenceUsage)
+
+	for _, flagName := range []string{"name", "provider", "dir", "import", "binary", "force"} {
+		assert.NotNil(t, exportCmd.Flags().Lookup(flagName), "expected --%s flag", flagName)
+	}
+
+	assert.Equal(t, "terraform", exportCmd.Flags().Lookup("dir").DefValue)
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestExportTerraformCmd_DockerUnsupported(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	writeTestConfigFiles(t, workingDir)
+
+	exportCmd := cluster.NewExportTerraformCmd()
+
+	var out bytes.Buffer
+	exportCmd.SetOut(&out)
+	exportCmd.SetErr(&out)
+	exportCmd.SetContext(context.Background())
+	exportCmd.SetArgs([]string{})
+
+	err := exportCmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrTerraformExportUnsupported)
+	assert.NoDirExists(t, "terraform")
+}
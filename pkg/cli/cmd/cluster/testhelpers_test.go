@@ -17,6 +17,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/svc/installer"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sizing"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
@@ -24,6 +25,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 )
 
@@ -249,9 +252,33 @@ var (
 )
 
 func TestMain(m *testing.M) {
-	os.Exit(homeenv.RunFunc(func() int {
+	// Audit entries of the commands under test go to a fake API server instead
+	// of whatever cluster a test kubeconfig points at.
+	restoreAudit := cluster.SetAuditClientFactoryForTests(
+		func(string, string) (kubernetes.Interface, error) { return fake.NewClientset(), nil },
+	)
+	// Delete and recreate find no important state in an empty fake cluster.
+	restoreImportantState := cluster.SetImportantStateClientFactoryForTests(
+		func(string, string) (kubernetes.Interface, error) { return fake.NewClientset(), nil },
+	)
+	// The in-cluster state skips the Helm lookup of the component inventory.
+	restoreInventory := cluster.SetComponentInventoryForTests(
+		func(context.Context, string, string) ([]state.InventoryComponent, error) { return nil, nil },
+	)
+	// Create's sizing pre-flight sees a roomy host instead of the machine running the tests.
+	restoreHostProbe := cluster.SetHostResourcesProbeForTests(
+		func(context.Context) sizing.Host { return sizing.Host{CPUs: 64, MemoryBytes: 256 << 30} },
+	)
+
+	code := homeenv.RunFunc(func() int {
 		return snapshottest.Run(m, snaps.CleanOpts{Sort: true})
-	}))
+	})
+
+	restoreHostProbe()
+	restoreInventory()
+	restoreImportantState()
+	restoreAudit()
+	os.Exit(code)
 }
 
 var errClusterPureTalosConfigEmpty = errors.New("talos config file is empty")
@@ -11,9 +11,19 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/client/velero"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/bundle"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clustercompare"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterstatus"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/installer"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sbom"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sizing"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 )
 
 // overrideInstallerFactory is a helper that applies a factory override and returns a restore function.
@@ -50,6 +60,33 @@ func SetCertManagerInstallerFactoryForTests(
 	})
 }
 
+// SetSealedSecretsInstallerFactoryForTests overrides the Sealed Secrets installer factory.
+func SetSealedSecretsInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.SealedSecrets = factory
+	})
+}
+
+// SetExternalSecretsInstallerFactoryForTests overrides the External Secrets installer factory.
+func SetExternalSecretsInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.ExternalSecrets = factory
+	})
+}
+
+// SetVeleroInstallerFactoryForTests overrides the Velero installer factory.
+func SetVeleroInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.Velero = factory
+	})
+}
+
 // SetCSIInstallerFactoryForTests overrides the CSI installer factory.
 func SetCSIInstallerFactoryForTests(
 	factory func(*v1alpha1.Cluster) (installer.Installer, error),
@@ -77,6 +114,60 @@ func SetPolicyEngineInstallerFactoryForTests(
 	})
 }
 
+// SetIngressControllerInstallerFactoryForTests overrides the ingress controller installer factory.
+func SetIngressControllerInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.IngressController = factory
+	})
+}
+
+// SetServiceMeshInstallerFactoryForTests overrides the service mesh installer factory.
+func SetServiceMeshInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.ServiceMesh = factory
+	})
+}
+
+// SetKnativeInstallerFactoryForTests overrides the Knative installer factory.
+func SetKnativeInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.Knative = factory
+	})
+}
+
+// SetIdentityProviderInstallerFactoryForTests overrides the identity provider installer factory.
+func SetIdentityProviderInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.IdentityProvider = factory
+	})
+}
+
+// SetGatewayAPIInstallerFactoryForTests overrides the Gateway API installer factory.
+func SetGatewayAPIInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.GatewayAPI = factory
+	})
+}
+
+// SetLoggingInstallerFactoryForTests overrides the logging installer factory.
+func SetLoggingInstallerFactoryForTests(
+	factory func(*v1alpha1.Cluster) (installer.Installer, error),
+) func() {
+	return overrideInstallerFactory(func(f *setup.InstallerFactories) {
+		f.Logging = factory
+	})
+}
+
 // SetClusterAutoscalerInstallerFactoryForTests overrides the cluster-autoscaler installer factory.
 func SetClusterAutoscalerInstallerFactoryForTests(
 	factory func(*v1alpha1.Cluster) (installer.Installer, error),
@@ -165,6 +256,69 @@ func SetProvisionerFactoryForTests(factory clusterprovisioner.Factory) func() {
 	}
 }
 
+// SetAuditClientFactoryForTests overrides the Kubernetes client factory used to
+// read and write the in-cluster audit log.
+func SetAuditClientFactoryForTests(
+	factory func(kubeconfigPath, kubeContext string) (kubernetes.Interface, error),
+) func() {
+	auditClientFactoryMu.Lock()
+
+	previous := auditClientFactory
+	auditClientFactory = factory
+
+	auditClientFactoryMu.Unlock()
+
+	return func() {
+		auditClientFactoryMu.Lock()
+
+		auditClientFactory = previous
+
+		auditClientFactoryMu.Unlock()
+	}
+}
+
+// SetImportantStateClientFactoryForTests overrides the Kubernetes client factory
+// delete and recreate use to check for state that would be lost.
+func SetImportantStateClientFactoryForTests(
+	factory func(kubeconfigPath, kubeContext string) (kubernetes.Interface, error),
+) func() {
+	importantStateClientFactoryMu.Lock()
+
+	previous := importantStateClientFactory
+	importantStateClientFactory = factory
+
+	importantStateClientFactoryMu.Unlock()
+
+	return func() {
+		importantStateClientFactoryMu.Lock()
+
+		importantStateClientFactory = previous
+
+		importantStateClientFactoryMu.Unlock()
+	}
+}
+
+// SetComponentInventoryForTests overrides how the component inventory stored in
+// the in-cluster state is detected.
+func SetComponentInventoryForTests(
+	inventory func(ctx context.Context, kubeconfigPath, kubeContext string) ([]state.InventoryComponent, error),
+) func() {
+	componentInventoryMu.Lock()
+
+	previous := componentInventory
+	componentInventory = inventory
+
+	componentInventoryMu.Unlock()
+
+	return func() {
+		componentInventoryMu.Lock()
+
+		componentInventory = previous
+
+		componentInventoryMu.Unlock()
+	}
+}
+
 // SetLocalRegistryServiceFactoryForTests overrides the local registry service factory for testing.
 func SetLocalRegistryServiceFactoryForTests(factory localregistry.ServiceFactoryFunc) func() {
 	localRegistryServiceFactoryMu.Lock()
@@ -216,3 +370,151 @@ func SetClusterStabilityCheckForTests(
 		f.ClusterStabilityCheck = fn
 	})
 }
+
+// SetVeleroClientFactoryForTests overrides the Velero client factory used by
+// backup --velero and restore-backup.
+func SetVeleroClientFactoryForTests(
+	factory func(kubeconfigPath, kubeContext string) (*velero.Client, error),
+) func() {
+	veleroClientFactoryMu.Lock()
+
+	previous := veleroClientFactory
+	veleroClientFactory = factory
+
+	veleroClientFactoryMu.Unlock()
+
+	return func() {
+		veleroClientFactoryMu.Lock()
+
+		veleroClientFactory = previous
+
+		veleroClientFactoryMu.Unlock()
+	}
+}
+
+// SetHostResourcesProbeForTests overrides the probe the create sizing pre-flight uses to read the
+// CPUs and memory available to containers.
+func SetHostResourcesProbeForTests(probe func(context.Context) sizing.Host) func() {
+	hostResourcesProbeMu.Lock()
+
+	previous := hostResourcesProbe
+	hostResourcesProbe = probe
+
+	hostResourcesProbeMu.Unlock()
+
+	return func() {
+		hostResourcesProbeMu.Lock()
+
+		hostResourcesProbe = previous
+
+		hostResourcesProbeMu.Unlock()
+	}
+}
+
+// SetStatusSourcesFactoryForTests overrides the factory `cluster status` uses to build the clients
+// its report reads from.
+func SetStatusSourcesFactoryForTests(
+	factory func(kubeconfigPath, kubeContext string) (clusterstatus.Sources, error),
+) func() {
+	statusSourcesFactoryMu.Lock()
+
+	previous := statusSourcesFactory
+	statusSourcesFactory = factory
+
+	statusSourcesFactoryMu.Unlock()
+
+	return func() {
+		statusSourcesFactoryMu.Lock()
+
+		statusSourcesFactory = previous
+
+		statusSourcesFactoryMu.Unlock()
+	}
+}
+
+// SetSBOMSourcesForTests overrides the factory `cluster sbom` uses to build its
+// Kubernetes and Helm clients, and the lister of Docker node containers.
+func SetSBOMSourcesForTests(
+	factory func(kubeconfigPath, kubeContext string) (sbom.Sources, error),
+	dockerNodes func(ctx context.Context, distribution v1alpha1.Distribution, name string) ([]provider.NodeInfo, error),
+) func() {
+	sbomSourcesFactoryMu.Lock()
+
+	previousFactory, previousNodes := sbomSourcesFactory, sbomDockerNodes
+	sbomSourcesFactory, sbomDockerNodes = factory, dockerNodes
+
+	sbomSourcesFactoryMu.Unlock()
+
+	return func() {
+		sbomSourcesFactoryMu.Lock()
+
+		sbomSourcesFactory, sbomDockerNodes = previousFactory, previousNodes
+
+		sbomSourcesFactoryMu.Unlock()
+	}
+}
+
+// SetClusterCompareSourcesFactoryForTests overrides the factory `cluster diff <a> <b>` uses to build
+// the clients each cluster is captured through.
+func SetClusterCompareSourcesFactoryForTests(
+	factory func(kubeconfigPath, kubeContext string) (clustercompare.Sources, error),
+) func() {
+	clusterCompareSourcesFactoryMu.Lock()
+
+	previous := clusterCompareSourcesFactory
+	clusterCompareSourcesFactory = factory
+
+	clusterCompareSourcesFactoryMu.Unlock()
+
+	return func() {
+		clusterCompareSourcesFactoryMu.Lock()
+
+		clusterCompareSourcesFactory = previous
+
+		clusterCompareSourcesFactoryMu.Unlock()
+	}
+}
+
+// SetBundleClusterStateForTests overrides how `cluster export` and
+// `cluster create --from-bundle` read the component versions and OCI artifacts
+// of a cluster.
+func SetBundleClusterStateForTests(
+	readState func(ctx context.Context, kubeconfigPath, kubeContext string) (
+		[]bundle.Component, []bundle.Artifact, error),
+) func() {
+	bundleClusterStateMu.Lock()
+
+	previous := bundleClusterState
+	bundleClusterState = readState
+
+	bundleClusterStateMu.Unlock()
+
+	return func() {
+		bundleClusterStateMu.Lock()
+
+		bundleClusterState = previous
+
+		bundleClusterStateMu.Unlock()
+	}
+}
+
+// SetTimelineDynamicClientFactoryForTests overrides the dynamic client factory
+// used to read GitOps reconciliation history for the timeline.
+func SetTimelineDynamicClientFactoryForTests(
+	factory func(kubeconfigPath, kubeContext string) (dynamic.Interface, error),
+) func() {
+	timelineDynamicClientFactoryMu.Lock()
+
+	previous := timelineDynamicClientFactory
+	timelineDynamicClientFactory = factory
+
+	timelineDynamicClientFactoryMu.Unlock()
+
+	return func() {
+		timelineDynamicClientFactoryMu.Lock()
+
+		timelineDynamicClientFactory = previous
+
+		timelineDynamicClientFactoryMu.Unlock()
+	}
+}
@@ -0,0 +1,300 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/timeline"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+)
+
+// timelineLongDesc describes the `ksail cluster timeline` command.
+const timelineLongDesc = `Show what happened to a cluster as one chronological timeline.
+
+The timeline merges three sources:
+  ksail   KSail operations from the audit log (create, update, start, stop, ...)
+  event   Kubernetes Events
+  gitops  Flux Ready transitions and Helm releases, and Argo CD syncs
+
+By default the last hour is shown. Use --since to widen or narrow the window
+(0 shows everything still recorded) and --source, --namespace, --object, and
+--warnings to focus on what matters. Kubernetes Events expire after the API
+server's event TTL (one hour by default), so older entries come from the
+audit log and GitOps status only.
+
+When the cluster cannot be reached, the local audit log is shown on its own.
+
+The cluster is resolved in the following priority order:
+  1. From --name flag
+  2. From ksail.yaml config file (if present)
+  3. From current kubeconfig context`
+
+// defaultTimelineWindow is how far back the timeline looks by default.
+const defaultTimelineWindow = time.Hour
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	timelineDynamicClientFactoryMu sync.RWMutex
+	timelineDynamicClientFactory   = func(kubeconfigPath, kubeContext string) (dynamic.Interface, error) {
+		return k8s.NewDynamicClient(kubeconfigPath, kubeContext)
+	}
+)
+
+// timelineFlags holds the flag values of `ksail cluster timeline`.
+type timelineFlags struct {
+	name      string
+	provider  v1alpha1.Provider
+	since     time.Duration
+	sources   []string
+	namespace string
+	object    string
+	warnings  bool
+	limit     int
+}
+
+// NewTimelineCmd creates the cluster timeline command.
+func NewTimelineCmd() *cobra.Command {
+	var flags timelineFlags
+
+	cmd := &cobra.Command{
+		Use:   "timeline",
+		Short: "Show a chronological timeline of cluster activity",
+		Long:  timelineLongDesc,
+		Example: `  # What happened in the last hour
+  ksail cluster timeline
+
+  # Only warnings from the last day
+  ksail cluster timeline --since 24h --warnings
+
+  # GitOps reconciliation and events for one namespace
+  ksail cluster timeline --source gitops,event --namespace apps`,
+		Annotations: map[string]string{
+			annotations.AnnotationDescription: "Show a chronological timeline of KSail operations, " +
+				"Kubernetes events, and GitOps reconciliations on a cluster",
+		},
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := validateOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			return runTimelineCmd(cmd, flags)
+		},
+	}
+
+	lifecycle.BindNameAndProviderFlags(cmd, &flags.name, &flags.provider)
+
+	cmd.Flags().DurationVar(&flags.since, "since", defaultTimelineWindow,
+		"Show entries newer than this duration (0 shows all)")
+	cmd.Flags().StringSliceVar(&flags.sources, "source", nil,
+		"Sources to include: ksail, event, gitops (default all)")
+	cmd.Flags().StringVar(&flags.namespace, "namespace", "",
+		"Show only entries in this namespace")
+	cmd.Flags().StringVar(&flags.object, "object", "",
+		"Show only entries whose object (Kind/name) contains this text")
+	cmd.Flags().BoolVar(&flags.warnings, "warnings", false,
+		"Show only warnings and failures")
+	cmd.Flags().IntVar(&flags.limit, "limit", 0,
+		"Show only the N most recent entries (0 shows all)")
+	cmd.Flags().String("output", outputFormatText,
+		"Output format: text or json. Use json for machine-readable structured output.")
+
+	return cmd
+}
+
+// runTimelineCmd resolves the target cluster, collects the selected sources,
+// and writes the merged timeline in the requested format.
+func runTimelineCmd(cmd *cobra.Command, flags timelineFlags) error {
+	sources, err := timeline.ParseSources(flags.sources)
+	if err != nil {
+		return fmt.Errorf("parse --source: %w", err)
+	}
+
+	resolved, err := lifecycle.ResolveClusterInfo(cmd, flags.name, flags.provider, "")
+	if err != nil {
+		return fmt.Errorf("resolve cluster info: %w", err)
+	}
+
+	filter := timeline.Filter{
+		Sources:      sources,
+		Namespace:    flags.namespace,
+		Object:       flags.object,
+		WarningsOnly: flags.warnings,
+	}
+	if flags.since > 0 {
+		filter.Since = time.Now().Add(-flags.since)
+	}
+
+	entries := timeline.Merge(filter, collectTimeline(cmd, resolved, filter)...)
+
+	if flags.limit > 0 && len(entries) > flags.limit {
+		entries = entries[len(entries)-flags.limit:]
+	}
+
+	if getOutputFormat(cmd) == outputFormatJSON {
+		return writeTimelineJSON(cmd.OutOrStdout(), entries)
+	}
+
+	writeTimelineText(cmd.OutOrStdout(), resolved.ClusterName, flags.since, entries)
+
+	return nil
+}
+
+// collectTimeline gathers the entries of every source selected by filter.
+// Sources are best-effort: one that cannot be read is reported as a warning
+// so the others are still shown.
+func collectTimeline(
+	cmd *cobra.Command,
+	resolved *lifecycle.ResolvedClusterInfo,
+	filter timeline.Filter,
+) [][]timeline.Entry {
+	var groups [][]timeline.Entry
+
+	selected := func(source timeline.Source) bool {
+		return slices.Contains(filter.Sources, source)
+	}
+
+	// KSail operations are cluster-wide, so a namespace filter excludes them.
+	if selected(timeline.SourceKSail) && filter.Namespace == "" {
+		journal, _, err := readAuditLog(cmd, resolved, false)
+		if err != nil {
+			notify.Warningf(cmd.ErrOrStderr(), "skipping KSail operations: %v", err)
+		} else {
+			groups = append(groups, timeline.FromAudit(journal))
+		}
+	}
+
+	if !selected(timeline.SourceEvent) && !selected(timeline.SourceGitOps) {
+		return groups
+	}
+
+	kubeconfigPath, kubeContext, err := resolveAuditContext(
+		resolved.KubeconfigPath, resolved.ClusterName,
+	)
+	if err != nil {
+		notify.Warningf(cmd.ErrOrStderr(), "skipping cluster events and GitOps history: %v", err)
+
+		return groups
+	}
+
+	if selected(timeline.SourceEvent) {
+		groups = append(groups, collectTimelineEvents(cmd, kubeconfigPath, kubeContext, filter.Namespace))
+	}
+
+	if selected(timeline.SourceGitOps) {
+		groups = append(groups, collectTimelineGitOps(cmd, kubeconfigPath, kubeContext, filter.Namespace))
+	}
+
+	return groups
+}
+
+// collectTimelineEvents lists the Kubernetes Events of the cluster.
+func collectTimelineEvents(
+	cmd *cobra.Command,
+	kubeconfigPath, kubeContext, namespace string,
+) []timeline.Entry {
+	client, err := newAuditClient(auditTarget{kubeconfigPath: kubeconfigPath, kubeContext: kubeContext})
+	if err == nil {
+		var entries []timeline.Entry
+
+		entries, err = timeline.ListEvents(cmd.Context(), client, namespace)
+		if err == nil {
+			return entries
+		}
+	}
+
+	notify.Warningf(cmd.ErrOrStderr(), "skipping Kubernetes events: %v", err)
+
+	return nil
+}
+
+// collectTimelineGitOps lists the Flux and Argo CD reconciliation history of
+// the cluster. Entries that could be read are kept when some kinds fail.
+func collectTimelineGitOps(
+	cmd *cobra.Command,
+	kubeconfigPath, kubeContext, namespace string,
+) []timeline.Entry {
+	timelineDynamicClientFactoryMu.RLock()
+	factory := timelineDynamicClientFactory
+	timelineDynamicClientFactoryMu.RUnlock()
+
+	client, err := factory(kubeconfigPath, kubeContext)
+	if err != nil {
+		notify.Warningf(cmd.ErrOrStderr(), "skipping GitOps history: build dynamic client: %v", err)
+
+		return nil
+	}
+
+	entries, err := timeline.ListGitOps(cmd.Context(), client, namespace)
+	if err != nil {
+		notify.Warningf(cmd.ErrOrStderr(), "GitOps history is incomplete: %v", err)
+	}
+
+	return entries
+}
+
+// writeTimelineText renders entries as a table, oldest first.
+func writeTimelineText(writer io.Writer, clusterName string, since time.Duration, entries []timeline.Entry) {
+	window := "all recorded history"
+	if since > 0 {
+		window = "last " + since.String()
+	}
+
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintf(writer, "No activity recorded for cluster %q (%s)\n", clusterName, window)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(writer, "Timeline of cluster %q (%s, %d entries):\n",
+		clusterName, window, len(entries))
+
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "  TIME\tSOURCE\tTYPE\tNAMESPACE\tOBJECT\tREASON\tMESSAGE")
+
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(table, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Time.UTC().Format(auditTimeFormat),
+			entry.Source,
+			entry.Type,
+			orDash(entry.Namespace),
+			entry.Object,
+			orDash(entry.Reason),
+			orDash(entry.Message),
+		)
+	}
+
+	_ = table.Flush()
+}
+
+// writeTimelineJSON serialises entries as an indented JSON array.
+func writeTimelineJSON(writer io.Writer, entries []timeline.Entry) error {
+	if entries == nil {
+		entries = []timeline.Entry{}
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal timeline entries: %w", err)
+	}
+
+	_, err = fmt.Fprintln(writer, string(data))
+	if err != nil {
+		return fmt.Errorf("write timeline entries: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/timeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewTimelineCmd(t *testing.T) {
+	t.Parallel()
+
+	timelineCmd := cluster.NewTimelineCmd()
+	require.NotNil(t, timelineCmd)
+
+	assert.Equal(t, "timeline", timelineCmd.Name())
+	assert.True(t, timelineCmd.SilenceUsage)
+
+	for _, flagName := range []string{
+		"name", "provider", "since", "source", "namespace", "object", "warnings", "limit", "output",
+	} {
+		assert.NotNil(t, timelineCmd.Flags().Lookup(flagName), "expected --%s flag", flagName)
+	}
+}
+
+func TestTimelineCmd_InvalidFormatRejectsEarly(t *testing.T) {
+	t.Parallel()
+
+	timelineCmd := cluster.NewTimelineCmd()
+	timelineCmd.SetOut(io.Discard)
+	timelineCmd.SetErr(io.Discard)
+	timelineCmd.SetArgs([]string{"--output", "xml"})
+
+	err := timelineCmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrUnsupportedOutputFormat)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the client factories
+func TestTimelineCmd_MergesSources(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	t.Setenv("KUBECONFIG", writeKubeconfigWithContext(t, workingDir, "kind-shared"))
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	client := fake.NewClientset(&corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "web.1", Namespace: "apps"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web", Namespace: "apps"},
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Type:           corev1.EventTypeWarning,
+		LastTimestamp:  metav1.NewTime(now.Add(-10 * time.Minute)),
+	})
+	useFakeAuditClient(t, client)
+
+	require.NoError(t, audit.AppendCluster(context.Background(), client, audit.Entry{
+		Time:      now.Add(-20 * time.Minute),
+		User:      "alice",
+		Cluster:   "shared",
+		Operation: audit.OperationStart,
+	}))
+
+	kustomization := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]any{"name": "apps", "namespace": "flux-system"},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{
+				"type":               "Ready",
+				"status":             "True",
+				"reason":             "ReconciliationSucceeded",
+				"message":            "Applied revision: latest@sha256:abc",
+				"lastTransitionTime": now.Add(-5 * time.Minute).Format(time.RFC3339),
+			},
+		}},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			flux.GitRepositoryGVR(): "GitRepositoryList",
+			flux.OCIRepositoryGVR(): "OCIRepositoryList",
+			flux.KustomizationGVR(): "KustomizationList",
+			flux.HelmReleaseGVR():   "HelmReleaseList",
+			argocd.ApplicationGVR(): "ApplicationList",
+		},
+		kustomization,
+	)
+	t.Cleanup(cluster.SetTimelineDynamicClientFactoryForTests(
+		func(string, string) (dynamic.Interface, error) { return dynamicClient, nil },
+	))
+
+	timelineCmd := cluster.NewTimelineCmd()
+
+	var out bytes.Buffer
+	timelineCmd.SetOut(&out)
+	timelineCmd.SetErr(io.Discard)
+	timelineCmd.SetContext(context.Background())
+	timelineCmd.SetArgs([]string{"--name", "shared", "--output", "json"})
+
+	require.NoError(t, timelineCmd.Execute())
+
+	var entries []timeline.Entry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries), out.String())
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, timeline.SourceKSail, entries[0].Source)
+	assert.Equal(t, "start by alice", entries[0].Message)
+	assert.Equal(t, timeline.SourceEvent, entries[1].Source)
+	assert.Equal(t, "Pod/web", entries[1].Object)
+	assert.Equal(t, timeline.SourceGitOps, entries[2].Source)
+	assert.Equal(t, "Kustomization/apps", entries[2].Object)
+
+	out.Reset()
+	timelineCmd = cluster.NewTimelineCmd()
+	timelineCmd.SetOut(&out)
+	timelineCmd.SetErr(io.Discard)
+	timelineCmd.SetContext(context.Background())
+	timelineCmd.SetArgs([]string{"--name", "shared", "--warnings"})
+
+	require.NoError(t, timelineCmd.Execute())
+
+	assert.Contains(t, out.String(), `Timeline of cluster "shared" (last 1h0m0s, 1 entries)`)
+	assert.Contains(t, out.String(), "BackOff")
+	assert.NotContains(t, out.String(), "Kustomization/apps")
+}
@@ -424,6 +424,11 @@ func mergeAWSOptions(current, persisted v1alpha1.OptionsAWS) v1alpha1.OptionsAWS
 		current.SessionTokenEnvVar = persisted.SessionTokenEnvVar
 	}
 
+	if current.AssumeRoleARN == "" {
+		current.AssumeRoleARN = persisted.AssumeRoleARN
+		current.AssumeRoleDuration = persisted.AssumeRoleDuration
+	}
+
 	return current
 }
 
@@ -486,10 +491,10 @@ func queryFrozenAWSOwnership(
 		resolved.AWSRegion = strings.TrimSpace(auth.Region)
 	}
 
-	eksctlOptions := credentials.OptionsForAWSChildEnvironment(
+	eksctlOptions := credentials.OptionsForRefreshingAWSChildEnvironment(
 		auth,
 		os.Environ(),
-		eksctlclient.WithEnvironment,
+		eksctlclient.WithEnvironmentSource,
 		eksctlclient.RequireCredentialValues,
 	)
 
@@ -25,6 +25,12 @@ const yesFlagName = "yes"
 // is deliberately NOT a confirm-flag, so the chat assistant never injects it.
 const forceDrainFlagName = "force-drain"
 
+// forceDataLossFlagName is the name of the delete --force-data-loss flag. It lets
+// delete destroy a cluster holding persistent volume claims, LoadBalancer services,
+// or namespaces KSail did not create. Like --force-drain it is deliberately NOT a
+// confirm-flag, so the chat assistant never injects it.
+const forceDataLossFlagName = "force-data-loss"
+
 // NewUpdateCmd creates the cluster update command.
 // The update command applies configuration changes to a running cluster.
 // It supports in-place updates where possible and falls back to recreation when necessary.
@@ -56,7 +62,9 @@ Confirmation vs. disruption are two separate flags:
   - --force-drain additionally lets node drains delete pods directly (bypassing
     PodDisruptionBudgets) and authorizes partition wipes — use it when a budget
     would otherwise block a rolling reboot/recreate (may cause workload
-    disruption or data loss).
+    disruption or data loss). It is also required to recreate a cluster that
+    holds persistent volume claims, LoadBalancer services, or namespaces KSail
+    did not create.
 
 Use --dry-run to preview changes without applying them.
 Use --output json to emit a machine-readable diff for CI/MCP consumption.`,
@@ -77,6 +85,8 @@ Use --output json to emit a machine-readable diff for CI/MCP consumption.`,
 	cmd.Flags().String("output", outputFormatText,
 		"Output format: text (default) or json (machine-readable, for CI/MCP)")
 
+	lifecycle.AddClientModeFlag(cmd)
+
 	cmd.RunE = lifecycle.WrapHandler(cfgManager, handleUpdateRunE)
 
 	return cmd
@@ -103,8 +113,9 @@ func registerUpdateConsentFlags(
 	cmd.Flags().Bool(forceDrainFlagName, false,
 		"Make node drains delete pods directly, bypassing PodDisruptionBudgets, so a "+
 			"rolling reboot/recreate completes even when a budget would block graceful "+
-			"eviction; also authorizes partition wipes (may cause workload disruption "+
-			"or data loss). This is the destructive behavior the old --force implied.")
+			"eviction; also authorizes partition wipes and recreating a cluster that holds "+
+			"persistent volumes, load balancers, or non-KSail namespaces (may cause workload "+
+			"disruption or data loss). This is the destructive behavior the old --force implied.")
 
 	// --force is a deprecated combined flag retained for one release: it keeps its
 	// full pre-split behavior (skip prompts AND the PDB-bypassing drain + partition
@@ -136,15 +147,31 @@ func handleUpdateRunE(
 
 	outputTimer := flags.MaybeTimer(cmd, deps.Timer)
 
+	orchestrator, err := prepareUpdateOrchestrator(cmd, cfgManager, deps)
+	if err != nil {
+		return err
+	}
+
+	return orchestrator.run(outputTimer)
+}
+
+// prepareUpdateOrchestrator loads and validates the configuration, refuses
+// clusters KSail does not own, applies the mutation flags, and resolves the
+// consent flags into an updateOrchestrator. It is shared by update and upgrade.
+func prepareUpdateOrchestrator(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps lifecycle.Deps,
+) (*updateOrchestrator, error) {
 	// Load and validate configuration using shared helper
 	ctx, clusterName, err := loadAndValidateClusterConfig(cfgManager, deps)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = validateEKSMutationConfigSource(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Refuse to reconcile configuration to a cluster ksail did not provision. When the target is an
@@ -155,7 +182,7 @@ func handleUpdateRunE(
 		cmd.Context(), ctx.ClusterCfg, clusterName, ctx.EKSConfig,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctx.AWSResolution = awsResolution
@@ -165,7 +192,7 @@ func handleUpdateRunE(
 
 	err = validatePostMutationFlags(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// The deprecated --force retains its FULL pre-split behavior for one release: it
@@ -182,11 +209,9 @@ func handleUpdateRunE(
 	forceDrainFlag, _ := cmd.Flags().GetBool(forceDrainFlagName)
 	forceDrain := forceDrainFlag || forceDeprecated
 
-	orchestrator := newUpdateOrchestrator(
+	return newUpdateOrchestrator(
 		cmd, cfgManager, ctx, deps, clusterName, consent, forceDrain,
-	)
-
-	return orchestrator.run(outputTimer)
+	), nil
 }
 
 // resolveConsent reports whether the user consented to skip KSail's interactive
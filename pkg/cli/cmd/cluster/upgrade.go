@@ -0,0 +1,230 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clustererr"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrUpgradeNotInPlace is returned when upgrade targets a distribution whose
+// nodes cannot be upgraded in place.
+var ErrUpgradeNotInPlace = errors.New("cluster nodes cannot be upgraded in place")
+
+const upgradeLongDesc = `Upgrade the Kubernetes (and distribution) version of a running cluster.
+
+The target versions come from ksail.yaml: spec.cluster.kubernetesVersion and,
+for Talos, spec.cluster.talos.version (overridable with --kubernetes-version and
+--distribution-version). When a version is unset, the cluster follows the latest
+supported version published for its node image.
+
+Unlike 'ksail cluster update', only versions are reconciled; other configuration
+changes in ksail.yaml are left for update. Before upgrading, the command prints
+the upgrade plan: each node is upgraded in place one at a time through the Talos
+upgrade API, OS first and then Kubernetes, control-plane nodes first, with each
+node cordoned and drained.
+
+Only Talos nodes can be upgraded in place. Kind and K3d node images carry the
+cluster state, so these clusters reach a new version only by being recreated;
+upgrade refuses them and 'ksail cluster update' recreates them instead.
+
+Use --dry-run to print the plan and upgrade path without applying them.`
+
+// NewUpgradeCmd creates the cluster upgrade command.
+func NewUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "upgrade",
+		Short:        "Upgrade the Kubernetes version of a cluster",
+		Long:         upgradeLongDesc,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cfgManager := setupMutationCmdFlags(cmd)
+
+	registerUpdateConsentFlags(cmd, cfgManager)
+
+	cmd.Flags().Bool("dry-run", false,
+		"Print the upgrade plan without applying it")
+	_ = cfgManager.Viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run"))
+
+	lifecycle.AddClientModeFlag(cmd)
+
+	cmd.RunE = lifecycle.WrapHandler(cfgManager, handleUpgradeRunE)
+
+	return cmd
+}
+
+// handleUpgradeRunE resolves the run state shared with update and reconciles
+// only the cluster versions.
+func handleUpgradeRunE(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	deps lifecycle.Deps,
+) error {
+	deps.Timer.Start()
+
+	orchestrator, err := prepareUpdateOrchestrator(cmd, cfgManager, deps)
+	if err != nil {
+		return err
+	}
+
+	return orchestrator.runUpgrade()
+}
+
+// runUpgrade prints the node-by-node upgrade plan and reconciles the cluster's
+// distribution and Kubernetes versions, skipping the configuration diff that
+// update performs afterwards. Distributions whose nodes cannot be upgraded in
+// place are refused rather than recreated.
+func (o *updateOrchestrator) runUpgrade() error {
+	distribution := o.ctx.ClusterCfg.Spec.Cluster.Distribution
+	if !clusterupdate.SupportsInPlaceNodeUpgrade(distribution) {
+		return fmt.Errorf("%w: %s nodes cannot be replaced one at a time; "+
+			"run 'ksail cluster update' to recreate the cluster at the target version",
+			ErrUpgradeNotInPlace, distribution)
+	}
+
+	provisioner, err := createAndVerifyProvisioner(o.cmd, o.ctx, o.clusterName)
+	if err != nil {
+		return err
+	}
+
+	err = ensureConfiguredContextResolvable(o.ctx.ClusterCfg)
+	if err != nil {
+		return err
+	}
+
+	upgrader, ok := provisioner.(clusterupdate.Upgrader)
+	if !ok {
+		return fmt.Errorf("%w: %s", clustererr.ErrUpgraderNotSupported, distribution)
+	}
+
+	o.reportNodeUpgradePlan(upgrader.PinnedDistributionVersion())
+
+	// Each version dimension reports its own outcome: already current, the
+	// upgrade steps applied, or the dry-run path.
+	_, err = o.reconcileClusterVersions(provisioner)
+
+	return err
+}
+
+// reportNodeUpgradePlan prints the nodes the upgrade takes through, in order, as
+// a diff table built by the diff engine: the OS moves to osTarget ("" when the
+// OS version is not pinned) and Kubernetes to spec.cluster.kubernetesVersion.
+// The plan is informational: a cluster whose nodes cannot be listed is still
+// upgraded.
+func (o *updateOrchestrator) reportNodeUpgradePlan(osTarget string) {
+	nodes, err := o.listNodeVersions()
+	if err != nil {
+		notify.Warningf(o.cmd.OutOrStderr(), "cannot list nodes for the upgrade plan: %v", err)
+
+		return
+	}
+
+	target := clusterupdate.NodeUpgradeTarget{
+		KubernetesVersion: strings.TrimSpace(o.ctx.ClusterCfg.Spec.Cluster.KubernetesVersion),
+		OSVersion:         strings.TrimSpace(osTarget),
+	}
+	plan := clusterupdate.PlanNodeUpgrades(nodes, target)
+
+	writer := o.cmd.OutOrStdout()
+
+	targetLabel := "Kubernetes target: " + target.KubernetesVersion
+	if target.KubernetesVersion == "" {
+		targetLabel = "Kubernetes target: latest supported"
+	}
+
+	if target.OSVersion != "" {
+		targetLabel += ", OS target: " + target.OSVersion
+	}
+
+	notify.Titlef(writer, "🔍", "Upgrade plan (%s)", targetLabel)
+
+	if plan.TotalChanges() == 0 {
+		notify.Infof(writer, "every node already runs the target versions")
+
+		return
+	}
+
+	notify.Infof(writer, formatDiffTable(plan))
+	notify.Infof(writer, "nodes are upgraded one at a time, in the order listed: %s",
+		clusterupdate.RollingUpgradeReason)
+}
+
+// listNodeVersions reads the running version and role of every node.
+func (o *updateOrchestrator) listNodeVersions() ([]clusterupdate.NodeVersion, error) {
+	target := clusterAuditTarget(o.ctx)
+	if target.kubeconfigPath == "" {
+		return nil, ErrKubeconfigNotFound
+	}
+
+	client, err := newAuditClient(target)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := o.cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	nodeList, err := client.CoreV1().Nodes().List(parent, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	nodes := make([]clusterupdate.NodeVersion, 0, len(nodeList.Items))
+
+	for _, node := range nodeList.Items {
+		nodes = append(nodes, clusterupdate.NodeVersion{
+			Name:           node.Name,
+			ControlPlane:   isControlPlaneNode(node),
+			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+			OSVersion:      nodeOSVersion(node.Status.NodeInfo.OSImage),
+		})
+	}
+
+	return nodes, nil
+}
+
+// isControlPlaneNode reports whether node carries a control-plane role label.
+func isControlPlaneNode(node corev1.Node) bool {
+	for _, label := range []string{
+		"node-role.kubernetes.io/control-plane",
+		"node-role.kubernetes.io/master",
+	} {
+		if _, ok := node.Labels[label]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nodeOSVersion extracts the OS version from a node's OS image, which Talos
+// reports as "Talos (v1.11.2)". Returns "" for images without a version.
+func nodeOSVersion(osImage string) string {
+	_, rest, found := strings.Cut(osImage, "(")
+	if !found {
+		return ""
+	}
+
+	version, _, found := strings.Cut(rest, ")")
+	if !found {
+		return ""
+	}
+
+	return strings.TrimSpace(version)
+}
@@ -0,0 +1,99 @@
+package cluster_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/setup/localregistry"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewUpgradeCmd(t *testing.T) {
+	t.Parallel()
+
+	upgradeCmd := cluster.NewUpgradeCmd()
+	require.NotNil(t, upgradeCmd)
+
+	assert.Equal(t, "upgrade", upgradeCmd.Name())
+	assert.True(t, upgradeCmd.SilenceUsage)
+
+	for _, flagName := range []string{"kubernetes-version", "distribution-version", "dry-run", "yes", "force-drain"} {
+		assert.NotNil(t, upgradeCmd.Flags().Lookup(flagName), "expected --%s flag", flagName)
+	}
+
+	assert.Nil(t, upgradeCmd.Flags().Lookup("output"), "upgrade has no machine-readable diff to emit")
+}
+
+func TestClusterCmd_RegistersUpgrade(t *testing.T) {
+	t.Parallel()
+
+	upgradeCmd, _, err := cluster.NewClusterCmd().Find([]string{"upgrade"})
+	require.NoError(t, err)
+	assert.Equal(t, "upgrade", upgradeCmd.Name())
+}
+
+//nolint:paralleltest // mutates the audit client factory
+func TestReportNodeUpgradePlan(t *testing.T) {
+	controlPlane := statusNode("dev-control-plane", true, "control-plane")
+	controlPlane.Status.NodeInfo.OSImage = "Talos (v1.12.0)"
+	worker := statusNode("dev-worker", true, "worker")
+	worker.Status.NodeInfo.OSImage = "Talos (v1.11.2)"
+
+	client := fake.NewClientset(worker, controlPlane)
+	t.Cleanup(cluster.SetAuditClientFactoryForTests(
+		func(string, string) (kubernetes.Interface, error) { return client, nil },
+	))
+
+	for _, testCase := range []struct {
+		name     string
+		osTarget string
+	}{
+		{name: "KubernetesOnly"},
+		{name: "WithOSTarget", osTarget: "v1.12.0"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := &v1alpha1.Cluster{}
+			cfg.Spec.Cluster.Distribution = v1alpha1.DistributionTalos
+			cfg.Spec.Cluster.KubernetesVersion = "v1.34.0"
+			cfg.Spec.Cluster.Connection.Kubeconfig = filepath.Join(t.TempDir(), "config")
+
+			var out bytes.Buffer
+
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+
+			cluster.ExportReportNodeUpgradePlan(cmd, &localregistry.Context{ClusterCfg: cfg}, testCase.osTarget)
+
+			snaps.MatchSnapshot(t, out.String())
+		})
+	}
+}
+
+func TestRunUpgrade_RefusesRecreateDistributions(t *testing.T) {
+	t.Parallel()
+
+	for _, distribution := range []v1alpha1.Distribution{
+		v1alpha1.DistributionVanilla,
+		v1alpha1.DistributionK3s,
+	} {
+		t.Run(string(distribution), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &v1alpha1.Cluster{}
+			cfg.Spec.Cluster.Distribution = distribution
+
+			err := cluster.ExportRunUpgrade(&cobra.Command{}, &localregistry.Context{ClusterCfg: cfg})
+			require.ErrorIs(t, err, cluster.ErrUpgradeNotInPlace)
+			assert.Contains(t, err.Error(), "ksail cluster update")
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/client/velero"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	"github.com/spf13/cobra"
+)
+
+// ErrVeleroArchiveFlag is returned when an archive-only backup flag is combined
+// with --velero.
+var ErrVeleroArchiveFlag = errors.New("flag is not supported with --velero")
+
+// veleroWaitTimeout bounds how long backup and restore-backup wait for Velero
+// to finish.
+const veleroWaitTimeout = 30 * time.Minute
+
+// veleroNameTimeFormat is the timestamp suffix of generated backup and restore
+// names. It only contains characters valid in Kubernetes resource names.
+const veleroNameTimeFormat = "20060102-150405"
+
+// restoreBackupLongDesc describes the `ksail cluster restore-backup` command.
+const restoreBackupLongDesc = `Restore a Velero backup into the cluster.
+
+Creates a Velero Restore from the named backup, which was taken with
+'ksail cluster backup --velero'. Velero must be installed in the cluster
+(spec.cluster.backup: Velero). By default the command waits until Velero
+reports the restore as finished; pass --wait=false to return right after the
+restore is submitted.
+
+Example:
+  ksail cluster restore-backup ksail-20261017-093000
+  ksail cluster restore-backup nightly --namespaces apps`
+
+//nolint:gochecknoglobals // Injected for testability to avoid real API servers.
+var (
+	veleroClientFactoryMu sync.RWMutex
+	veleroClientFactory   = velero.NewClientFromKubeconfig
+)
+
+type restoreBackupFlags struct {
+	namespaces []string
+	wait       bool
+	name       string
+}
+
+// NewRestoreBackupCmd creates the cluster restore-backup command.
+func NewRestoreBackupCmd() *cobra.Command {
+	flags := &restoreBackupFlags{wait: true}
+
+	cmd := &cobra.Command{
+		Use:   "restore-backup <backup-name>",
+		Short: "Restore a Velero backup",
+		Long:  restoreBackupLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRestoreBackup(cmd.Context(), cmd, flags, args[0])
+		},
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cmd.Flags().StringSliceVar(
+		&flags.namespaces, "namespaces", []string{},
+		"Namespaces to restore from the backup (default: all)",
+	)
+	cmd.Flags().BoolVar(
+		&flags.wait, "wait", true,
+		"Wait for Velero to finish the restore",
+	)
+	cmd.Flags().StringVar(
+		&flags.name, "name", "",
+		"Name of the cluster to restore into (resolves the kubeconfig like the other "+
+			"cluster commands; defaults to the current kubeconfig context when unset)",
+	)
+
+	return cmd
+}
+
+// runVeleroBackup creates a Velero Backup named after the positional argument
+// (or a generated name) and optionally waits for it to finish.
+func runVeleroBackup(
+	ctx context.Context,
+	cmd *cobra.Command,
+	flags *backupFlags,
+	args []string,
+) error {
+	for _, name := range []string{"output", "exclude-types", "compression"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("%w: --%s", ErrVeleroArchiveFlag, name)
+		}
+	}
+
+	backupName := veleroResourceName("ksail", args)
+
+	//nolint:contextcheck // resolveTarget→ResolveClusterInfo derives ctx from cmd (cluster-cmd convention)
+	client, _, err := newVeleroClient(cmd, flags.name)
+	if err != nil {
+		return err
+	}
+
+	writer := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(writer, "Starting Velero backup...\n")
+	_, _ = fmt.Fprintf(writer, "   Backup: %s\n", backupName)
+	printVeleroNamespaces(writer, flags.namespaces)
+
+	err = client.CreateBackup(ctx, velero.BackupOptions{
+		Name:               backupName,
+		IncludedNamespaces: flags.namespaces,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create velero backup: %w", err)
+	}
+
+	if !flags.wait {
+		_, _ = fmt.Fprintf(writer, "Backup submitted to Velero\n")
+
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, veleroWaitTimeout)
+	defer cancel()
+
+	status, err := client.WaitForBackup(waitCtx, backupName)
+	if err != nil {
+		return fmt.Errorf("velero backup %q: %w", backupName, err)
+	}
+
+	_, _ = fmt.Fprintf(writer, "Backup completed successfully\n")
+	printVeleroStatus(writer, status)
+
+	return nil
+}
+
+func runRestoreBackup(
+	ctx context.Context,
+	cmd *cobra.Command,
+	flags *restoreBackupFlags,
+	backupName string,
+) error {
+	restoreName := veleroResourceName(backupName, nil)
+
+	//nolint:contextcheck // resolveTarget→ResolveClusterInfo derives ctx from cmd (cluster-cmd convention)
+	client, target, err := newVeleroClient(cmd, flags.name)
+	if err != nil {
+		return err
+	}
+
+	writer := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(writer, "Starting Velero restore...\n")
+	_, _ = fmt.Fprintf(writer, "   Backup: %s\n", backupName)
+	_, _ = fmt.Fprintf(writer, "   Restore: %s\n", restoreName)
+	printVeleroNamespaces(writer, flags.namespaces)
+
+	err = client.CreateRestore(ctx, velero.RestoreOptions{
+		Name:               restoreName,
+		BackupName:         backupName,
+		IncludedNamespaces: flags.namespaces,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create velero restore: %w", err)
+	}
+
+	recordAudit(cmd, target,
+		audit.NewEntry(audit.OperationRestore, auditClusterName(cmd, flags.name)))
+
+	if !flags.wait {
+		_, _ = fmt.Fprintf(writer, "Restore submitted to Velero\n")
+
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, veleroWaitTimeout)
+	defer cancel()
+
+	status, err := client.WaitForRestore(waitCtx, restoreName)
+	if err != nil {
+		return fmt.Errorf("velero restore %q: %w", restoreName, err)
+	}
+
+	_, _ = fmt.Fprintf(writer, "Restore completed successfully\n")
+	printVeleroStatus(writer, status)
+
+	return nil
+}
+
+// newVeleroClient resolves the target cluster and builds a Velero client for
+// it. The resolved target is returned so restores can be audited.
+func newVeleroClient(cmd *cobra.Command, nameFlag string) (*velero.Client, auditTarget, error) {
+	kubeconfigPath, kubeContext, err := resolveTarget(cmd, nameFlag)
+	if err != nil {
+		return nil, auditTarget{}, err
+	}
+
+	veleroClientFactoryMu.RLock()
+	factory := veleroClientFactory
+	veleroClientFactoryMu.RUnlock()
+
+	client, err := factory(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, auditTarget{}, fmt.Errorf("build velero client: %w", err)
+	}
+
+	return client, auditTarget{kubeconfigPath: kubeconfigPath, kubeContext: kubeContext}, nil
+}
+
+// veleroResourceName returns the first positional argument when one was given,
+// otherwise prefix followed by a UTC timestamp.
+func veleroResourceName(prefix string, args []string) string {
+	if len(args) > 0 && args[0] != "" {
+		return args[0]
+	}
+
+	return prefix + "-" + time.Now().UTC().Format(veleroNameTimeFormat)
+}
+
+func printVeleroNamespaces(writer io.Writer, namespaces []string) {
+	if len(namespaces) > 0 {
+		_, _ = fmt.Fprintf(writer, "   Namespaces: %v\n", namespaces)
+	} else {
+		_, _ = fmt.Fprintf(writer, "   Namespaces: all\n")
+	}
+}
+
+func printVeleroStatus(writer io.Writer, status velero.Status) {
+	if status.Warnings > 0 {
+		_, _ = fmt.Fprintf(writer, "   Warnings: %d\n", status.Warnings)
+	}
+
+	if status.Errors > 0 {
+		_, _ = fmt.Fprintf(writer, "   Errors: %d\n", status.Errors)
+	}
+}
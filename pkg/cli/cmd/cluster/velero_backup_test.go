@@ -0,0 +1,132 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/client/velero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// useFakeVeleroClient routes backup --velero and restore-backup to a fake
+// dynamic client and returns it.
+func useFakeVeleroClient(t *testing.T) *dynamicfake.FakeDynamicClient {
+	t.Helper()
+
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			velero.BackupGVR:  "BackupList",
+			velero.RestoreGVR: "RestoreList",
+		},
+	)
+
+	restore := cluster.SetVeleroClientFactoryForTests(
+		func(string, string) (*velero.Client, error) { return velero.NewClient(dyn), nil },
+	)
+	t.Cleanup(restore)
+
+	return dyn
+}
+
+func TestNewRestoreBackupCmd(t *testing.T) {
+	t.Parallel()
+
+	restoreBackupCmd := cluster.NewRestoreBackupCmd()
+	require.NotNil(t, restoreBackupCmd)
+
+	assert.Equal(t, "restore-backup", restoreBackupCmd.Name())
+
+	for _, flagName := range []string{"namespaces", "wait", "name"} {
+		assert.NotNil(t, restoreBackupCmd.Flags().Lookup(flagName), "expected --%s flag", flagName)
+	}
+
+	restoreBackupCmd.SetOut(io.Discard)
+	restoreBackupCmd.SetErr(io.Discard)
+	restoreBackupCmd.SetArgs([]string{})
+
+	require.Error(t, restoreBackupCmd.Execute(), "a backup name is required")
+}
+
+func TestBackupCmd_VeleroRejectsArchiveFlags(t *testing.T) {
+	t.Parallel()
+
+	backupCmd := cluster.NewBackupCmd()
+	backupCmd.SetOut(io.Discard)
+	backupCmd.SetErr(io.Discard)
+	backupCmd.SetArgs([]string{"--velero", "--compression", "9"})
+
+	err := backupCmd.Execute()
+
+	require.ErrorIs(t, err, cluster.ErrVeleroArchiveFlag)
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the Velero client factory
+func TestBackupCmd_VeleroCreatesBackup(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	t.Setenv("KUBECONFIG", writeKubeconfigWithContext(t, workingDir, "kind-dev"))
+
+	dyn := useFakeVeleroClient(t)
+
+	backupCmd := cluster.NewBackupCmd()
+
+	var out bytes.Buffer
+	backupCmd.SetOut(&out)
+	backupCmd.SetErr(&out)
+	backupCmd.SetContext(context.Background())
+	backupCmd.SetArgs([]string{"--velero", "nightly", "--namespaces", "apps", "--wait=false"})
+
+	require.NoError(t, backupCmd.Execute(), out.String())
+
+	backup, err := dyn.Resource(velero.BackupGVR).
+		Namespace(velero.DefaultNamespace).
+		Get(context.Background(), "nightly", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	namespaces, _, _ := unstructured.NestedStringSlice(backup.Object, "spec", "includedNamespaces")
+	assert.Equal(t, []string{"apps"}, namespaces)
+	assert.Contains(t, out.String(), "Backup submitted to Velero")
+}
+
+//nolint:paralleltest // uses t.Chdir, t.Setenv, and mutates the client factories
+func TestRestoreBackupCmd_CreatesRestore(t *testing.T) {
+	workingDir := t.TempDir()
+	t.Chdir(workingDir)
+	t.Setenv("HOME", workingDir)
+	t.Setenv("KUBECONFIG", writeKubeconfigWithContext(t, workingDir, "kind-dev"))
+
+	dyn := useFakeVeleroClient(t)
+	useFakeAuditClient(t, fake.NewClientset())
+
+	restoreBackupCmd := cluster.NewRestoreBackupCmd()
+
+	var out bytes.Buffer
+	restoreBackupCmd.SetOut(&out)
+	restoreBackupCmd.SetErr(&out)
+	restoreBackupCmd.SetContext(context.Background())
+	restoreBackupCmd.SetArgs([]string{"nightly", "--wait=false"})
+
+	require.NoError(t, restoreBackupCmd.Execute(), out.String())
+
+	restores, err := dyn.Resource(velero.RestoreGVR).
+		Namespace(velero.DefaultNamespace).
+		List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, restores.Items, 1)
+
+	backupName, _, _ := unstructured.NestedString(restores.Items[0].Object, "spec", "backupName")
+	assert.Equal(t, "nightly", backupName)
+	assert.Contains(t, restores.Items[0].GetName(), "nightly-")
+	assert.Contains(t, out.String(), "Restore submitted to Velero")
+}
@@ -12,8 +12,11 @@
 package clusterflags
 
 import (
+	"strings"
+
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
 	"github.com/spf13/cobra"
 )
 
@@ -22,9 +25,12 @@ import (
 // via getMirrorRegistriesWithDefaults() in setup/mirrorregistry.
 func RegisterMirrorRegistryFlag(cmd *cobra.Command) {
 	cmd.Flags().StringSlice("mirror-registry", []string{},
-		"Configure mirror registries with optional authentication. Format: [user:pass@]host[=upstream]. "+
+		"Configure mirror registries with optional authentication. Format: [credentials@]host[=upstream], "+
+			"where credentials is user:pass, <token>:token, or <dockerconfig>[:path]. "+
+			"host may be a preset ("+strings.Join(registry.MirrorPresetNames(), ", ")+") that sets host and upstream. "+
 			"Credentials support environment variables using ${VAR} syntax (quote placeholders so KSail can expand them). "+
-			"Examples: docker.io=https://registry-1.docker.io, '${USER}:${TOKEN}@ghcr.io=https://ghcr.io'")
+			"Examples: dockerhub,ghcr, docker.io=https://registry-1.docker.io, '${USER}:${TOKEN}@ghcr.io=https://ghcr.io', "+
+			"'<dockerconfig>@ghcr.io'")
 }
 
 // RegisterNameFlag adds the --name flag to a command and binds it to Viper.
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd creates the parent config command and wires subcommands beneath it.
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Maintain the ksail.yaml configuration file",
+		Long: `Maintain the ksail.yaml configuration file: view the effective configuration,
+set individual fields, or migrate it to the current API version.`,
+		Args:         cobra.NoArgs,
+		RunE:         handleConfigRunE,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationConsolidate: "config_command",
+		},
+	}
+
+	cmd.AddCommand(NewMigrateCmd())
+	cmd.AddCommand(NewSetCmd())
+	cmd.AddCommand(NewViewCmd())
+
+	return cmd
+}
+
+//nolint:gochecknoglobals // Injected for testability to simulate help failures.
+var helpRunner = func(cmd *cobra.Command) error {
+	return cmd.Help()
+}
+
+func handleConfigRunE(cmd *cobra.Command, _ []string) error {
+	err := helpRunner(cmd)
+	if err != nil {
+		return fmt.Errorf("displaying config command help: %w", err)
+	}
+
+	return nil
+}
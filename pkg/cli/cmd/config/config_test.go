@@ -0,0 +1,42 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	snapshottest "github.com/devantler-tech/ksail/v7/internal/testutil/snapshottest"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	configcmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/config"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(snapshottest.Run(m, snaps.CleanOpts{Sort: true}))
+}
+
+func TestConfigCmd_ShowsHelp(t *testing.T) {
+	t.Parallel()
+
+	cmd := configcmd.NewConfigCmd()
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Contains(t, output, "migrate")
+
+	snaps.MatchSnapshot(t, output)
+}
+
+func TestConfigCmd_HasConsolidateAnnotation(t *testing.T) {
+	t.Parallel()
+
+	cmd := configcmd.NewConfigCmd()
+	require.Equal(t, "config_command", cmd.Annotations[annotations.AnnotationConsolidate])
+}
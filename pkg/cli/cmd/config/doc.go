@@ -0,0 +1,4 @@
+// Package config provides CLI commands for maintaining the ksail.yaml
+// configuration file itself: viewing the effective configuration, setting
+// individual fields, and migrating it to the current API version.
+package config
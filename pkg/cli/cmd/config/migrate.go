@@ -0,0 +1,145 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/conversion"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ErrConfigNotFound is returned when no ksail.yaml can be found to migrate.
+var ErrConfigNotFound = errors.New("no ksail.yaml found")
+
+const migrateLongDesc = `Migrate ksail.yaml to the current KSail API version and rewrite deprecated fields.
+
+When a new API version is released, files written for an older version keep
+loading: KSail converts them in memory and prints a warning. This command
+applies the same conversion to the file on disk, and additionally moves
+deprecated fields of the current version to their replacements, so the
+warnings go away. Every renamed and removed field is reported.
+
+Comments and key order are preserved. The file is found like every other
+command finds it: --config, otherwise ksail.yaml in the current or a parent
+directory.`
+
+const migrateExample = `  # Migrate ksail.yaml in place
+  ksail config migrate
+
+  # Show the migrated file without writing it
+  ksail config migrate --dry-run`
+
+// NewMigrateCmd creates the config migrate command.
+func NewMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "migrate",
+		Short:        "Migrate ksail.yaml to the current API version",
+		Long:         migrateLongDesc,
+		Example:      migrateExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+		RunE: handleMigrateRunE,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Print the migrated file instead of writing it")
+
+	return cmd
+}
+
+func handleMigrateRunE(cmd *cobra.Command, _ []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	configFile, err := resolveConfigFile(cmd)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(configFile)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", configFile, err)
+	}
+
+	data, err := os.ReadFile(configFile) //nolint:gosec // path resolved from --config or discovery
+	if err != nil {
+		return fmt.Errorf("read %s: %w", configFile, err)
+	}
+
+	migrated, report, err := conversion.Migrate(data)
+	if err != nil {
+		return fmt.Errorf("migrate %s: %w", configFile, err)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if !report.Changed() {
+		notify.Successf(out, "'%s' is up to date (%s)", configFile, report.To)
+
+		return nil
+	}
+
+	writeReport(out, configFile, report)
+
+	if dryRun {
+		_, _ = fmt.Fprintf(out, "\n%s", migrated)
+
+		return nil
+	}
+
+	err = os.WriteFile(configFile, migrated, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("write %s: %w", configFile, err)
+	}
+
+	notify.Successf(out, "migrated '%s' to %s", configFile, report.To)
+
+	return nil
+}
+
+// resolveConfigFile returns the --config file, or the ksail.yaml found by the
+// same discovery the config manager uses.
+func resolveConfigFile(cmd *cobra.Command) (string, error) {
+	configPath, err := flags.GetConfigPath(cmd)
+	if err != nil {
+		return "", fmt.Errorf("resolving --config flag: %w", err)
+	}
+
+	viperInstance, err := configmanager.InitializeViper(configPath)
+	if err != nil {
+		return "", fmt.Errorf("resolve config file: %w", err)
+	}
+
+	err = viperInstance.ReadInConfig()
+	if err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			return "", ErrConfigNotFound
+		}
+
+		return "", fmt.Errorf("read config file: %w", err)
+	}
+
+	return viperInstance.ConfigFileUsed(), nil
+}
+
+// writeReport lists the changes a migration makes.
+func writeReport(writer io.Writer, configFile string, report *conversion.Report) {
+	if report.From != report.To {
+		notify.Activityf(writer, "converting '%s' from %s to %s", configFile, report.From, report.To)
+	} else {
+		notify.Activityf(writer, "rewriting deprecated fields in '%s'", configFile)
+	}
+
+	for _, change := range report.Changes {
+		notify.Activityf(writer, "%s", change)
+	}
+}
@@ -0,0 +1,105 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configcmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deprecatedConfig = `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Talos
+    # Two workers for the demo.
+    talos:
+      workers: 2
+`
+
+const migratedConfig = `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Talos
+    workers: 2
+`
+
+// writeConfig writes content as ksail.yaml in a temporary working directory.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	configFile := filepath.Join(dir, "ksail.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(content), 0o600))
+
+	return configFile
+}
+
+func runMigrate(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := configcmd.NewMigrateCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestMigrateCmd_RewritesDeprecatedFields(t *testing.T) {
+	configFile := writeConfig(t, deprecatedConfig)
+
+	output, err := runMigrate(t)
+	require.NoError(t, err, output)
+
+	assert.Contains(t, output, "spec.cluster.talos.workers → spec.cluster.workers")
+	assert.Contains(t, output, "migrated")
+
+	content, err := os.ReadFile(configFile) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.Equal(t, migratedConfig, string(content))
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestMigrateCmd_DryRunLeavesFileUntouched(t *testing.T) {
+	configFile := writeConfig(t, deprecatedConfig)
+
+	output, err := runMigrate(t, "--dry-run")
+	require.NoError(t, err, output)
+
+	assert.Contains(t, output, migratedConfig)
+
+	content, err := os.ReadFile(configFile) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.Equal(t, deprecatedConfig, string(content))
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestMigrateCmd_UpToDate(t *testing.T) {
+	writeConfig(t, migratedConfig)
+
+	output, err := runMigrate(t)
+	require.NoError(t, err, output)
+
+	assert.Contains(t, output, "is up to date (ksail.io/v1alpha1)")
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestMigrateCmd_NoConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := runMigrate(t)
+	require.ErrorIs(t, err, configcmd.ErrConfigNotFound)
+}
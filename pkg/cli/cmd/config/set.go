@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/project"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/spf13/cobra"
+)
+
+const setLongDesc = `Set one field in ksail.yaml.
+
+The field is named by its flag name (as accepted by 'ksail project init',
+e.g. workers or gitops-engine) or by its ksail.yaml path (e.g.
+spec.cluster.workers). The value is parsed like the flag parses it, so enum
+values and numbers are checked, and the resulting file is validated the same
+way every command validates it before it is written. Nothing is written when
+validation fails.
+
+Comments and key order are preserved. The file is found like every other
+command finds it: --config, otherwise ksail.yaml in the current or a parent
+directory. Run 'ksail config view' to list the fields and their values.`
+
+const setExample = `  # Add two worker nodes
+  ksail config set workers 2
+
+  # Same field, by its ksail.yaml path
+  ksail config set spec.cluster.workers 2
+
+  # Show the updated file without writing it
+  ksail config set gitops-engine Flux --dry-run`
+
+const setArgCount = 2
+
+// NewSetCmd creates the config set command.
+func NewSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "set <field> <value>",
+		Short:        "Set a field in ksail.yaml",
+		Long:         setLongDesc,
+		Example:      setExample,
+		Args:         cobra.ExactArgs(setArgCount),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+		RunE: handleSetRunE,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Print the updated file instead of writing it")
+
+	return cmd
+}
+
+func handleSetRunE(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	selectors := project.InitFieldSelectors()
+
+	selector, path, err := configmanager.LookupFieldSelector(selectors, args[0])
+	if err != nil {
+		return fmt.Errorf("%w (run 'ksail config view' to list fields)", err)
+	}
+
+	value, err := configmanager.ParseFieldValue(selector, args[1])
+	if err != nil {
+		return err
+	}
+
+	configFile, err := resolveConfigFile(cmd)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(configFile)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", configFile, err)
+	}
+
+	data, err := os.ReadFile(configFile) //nolint:gosec // path resolved from --config or discovery
+	if err != nil {
+		return fmt.Errorf("read %s: %w", configFile, err)
+	}
+
+	updated, err := setField(data, path, value)
+	if err != nil {
+		return fmt.Errorf("update %s: %w", configFile, err)
+	}
+
+	err = validateUpdatedConfig(cmd, configFile, updated, selectors)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	if dryRun {
+		_, _ = fmt.Fprintf(out, "%s", updated)
+
+		return nil
+	}
+
+	err = fsutil.AtomicWriteFile(configFile, updated, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("write %s: %w", configFile, err)
+	}
+
+	notify.Successf(out, "set %s to %v in '%s'", path, value, configFile)
+
+	return nil
+}
+
+// setField returns the ksail.yaml document data with the field at path set to value.
+func setField(data []byte, path string, value any) ([]byte, error) {
+	doc, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := scalarNode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	setNode(doc.Content[0], path, node)
+
+	return encodeDocument(doc)
+}
+
+// validateUpdatedConfig loads the updated document like any command would, from a
+// temporary file next to configFile so relative paths in it resolve the same way.
+func validateUpdatedConfig(
+	cmd *cobra.Command,
+	configFile string,
+	updated []byte,
+	selectors []configmanager.FieldSelector[v1alpha1.Cluster],
+) error {
+	tmp, err := os.CreateTemp(filepath.Dir(configFile), ".ksail-*.yaml")
+	if err != nil {
+		return fmt.Errorf("create temporary config: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(updated)
+	closeErr := tmp.Close()
+
+	if err != nil || closeErr != nil {
+		return fmt.Errorf("write temporary config: %w", errors.Join(err, closeErr))
+	}
+
+	manager := configmanager.NewConfigManager(cmd.ErrOrStderr(), tmp.Name(), selectors...)
+
+	_, err = manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	if err != nil {
+		return fmt.Errorf("%s would be invalid: %w", configFile, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	configcmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/config"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const setConfig = `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Vanilla
+    # Scale up for load tests.
+    workers: 1
+`
+
+func runSet(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := configcmd.NewSetCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestSetCmd_UpdatesFieldAndKeepsComments(t *testing.T) {
+	configFile := writeConfig(t, setConfig)
+	writeKindConfig(t)
+
+	output, err := runSet(t, "spec.cluster.workers", "3")
+	require.NoError(t, err, output)
+
+	output, err = runSet(t, "gitops-engine", "Flux")
+	require.NoError(t, err, output)
+	assert.Contains(t, output, "set spec.cluster.gitOpsEngine to Flux")
+
+	content, err := os.ReadFile(configFile) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Vanilla
+    # Scale up for load tests.
+    workers: 3
+    gitOpsEngine: Flux
+`, string(content))
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestSetCmd_DryRunLeavesFileUntouched(t *testing.T) {
+	configFile := writeConfig(t, setConfig)
+	writeKindConfig(t)
+
+	output, err := runSet(t, "workers", "2", "--dry-run")
+	require.NoError(t, err, output)
+	assert.Contains(t, output, "workers: 2")
+
+	content, err := os.ReadFile(configFile) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.Equal(t, setConfig, string(content))
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestSetCmd_RejectsInvalidInput(t *testing.T) {
+	configFile := writeConfig(t, setConfig)
+	writeKindConfig(t)
+
+	_, err := runSet(t, "no-such-field", "1")
+	require.ErrorIs(t, err, configmanager.ErrUnknownField)
+
+	_, err = runSet(t, "distribution", "Minikube")
+	require.ErrorContains(t, err, "invalid distribution")
+
+	_, err = runSet(t, "workers", "many")
+	require.Error(t, err)
+
+	content, err := os.ReadFile(configFile) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.Equal(t, setConfig, string(content), "a rejected value is never written")
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestSetCmd_NoConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := runSet(t, "workers", "2")
+	require.ErrorIs(t, err, configcmd.ErrConfigNotFound)
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/project"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+const viewLongDesc = `Print the effective configuration: ksail.yaml (including any extends base
+and the active profile) merged with KSAIL_* environment variables and flags,
+exactly as other commands load it.
+
+Every field that can also be set by a flag is annotated with where its value
+came from: the flag, the environment variable, the config file, or the
+default. Fields left at their default are listed too, so the output shows
+every such setting at a glance. Other fields are printed when they differ from
+their defaults.
+
+The flags are those of 'ksail project init'; pass them to preview how an
+override changes the configuration.`
+
+const viewExample = `  # Show the effective configuration and where each value comes from
+  ksail config view
+
+  # Preview the effect of an override
+  ksail config view --distribution K3s --workers 2`
+
+// NewViewCmd creates the config view command.
+func NewViewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "view",
+		Short:        "Print the effective configuration with the source of each value",
+		Long:         viewLongDesc,
+		Example:      viewExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cfgManager := configmanager.NewCommandConfigManager(cmd, project.InitFieldSelectors())
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return handleViewRunE(cmd, cfgManager)
+	}
+
+	return cmd
+}
+
+func handleViewRunE(cmd *cobra.Command, cfgManager *configmanager.ConfigManager) error {
+	cfg, err := cfgManager.Load(configmanagerinterface.LoadOptions{
+		Silent:                 true,
+		SkipValidation:         true,
+		SkipDistributionConfig: true,
+	})
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+
+	doc, err := parseDocument(data)
+	if err != nil {
+		return err
+	}
+
+	err = annotateProvenance(doc.Content[0], cfgManager.Provenance())
+	if err != nil {
+		return err
+	}
+
+	out, err := encodeDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.OutOrStdout().Write(out)
+	if err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	return nil
+}
+
+// annotateProvenance adds each field's source as a line comment, inserting the
+// fields the marshalled config omits because they hold their default value.
+func annotateProvenance(root *yamlv3.Node, provenance []configmanager.FieldProvenance) error {
+	for _, field := range provenance {
+		node := lookupNode(root, field.Path)
+		if node == nil {
+			value, err := scalarNode(field.Value)
+			if err != nil {
+				return err
+			}
+
+			node = setNode(root, field.Path, value)
+		}
+
+		node.LineComment = provenanceComment(field)
+	}
+
+	return nil
+}
+
+// provenanceComment describes where a field's value came from.
+func provenanceComment(field configmanager.FieldProvenance) string {
+	switch field.Source {
+	case configmanager.SourceFlag, configmanager.SourceEnv:
+		return fmt.Sprintf("%s: %s", field.Source, field.Origin)
+	case configmanager.SourceFile:
+		return fmt.Sprintf("%s: %s", field.Source, filepath.Base(field.Origin))
+	case configmanager.SourceDefault:
+		return string(field.Source)
+	default:
+		return string(field.Source)
+	}
+}
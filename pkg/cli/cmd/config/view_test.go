@@ -0,0 +1,55 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configcmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKindConfig writes the kind.yaml the Vanilla distribution loads next to ksail.yaml.
+func writeKindConfig(t *testing.T) {
+	t.Helper()
+
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "kind.yaml"),
+		[]byte("kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\nname: kind\n"),
+		0o600,
+	))
+}
+
+func runView(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := configcmd.NewViewCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // uses t.Chdir and t.Setenv
+func TestViewCmd_AnnotatesProvenance(t *testing.T) {
+	writeConfig(t, setConfig)
+	t.Setenv("KSAIL_SPEC_CLUSTER_WORKERS", "4")
+
+	output, err := runView(t, "--gitops-engine", "Flux")
+	require.NoError(t, err, output)
+
+	assert.Contains(t, output, "distribution: Vanilla # file: ksail.yaml")
+	assert.Contains(t, output, "workers: 4 # env: KSAIL_SPEC_CLUSTER_WORKERS")
+	assert.Contains(t, output, "gitOpsEngine: Flux # flag: --gitops-engine")
+	assert.Contains(t, output, "controlPlanes: 1 # default", "defaulted fields are listed")
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlIndent is the indentation of documents this package writes, matching
+// `ksail config migrate`.
+const yamlIndent = 2
+
+// Keys are matched case-insensitively, as the config manager (Viper) does, so
+// "controlplanes" in a hand-written file is found as "controlPlanes".
+
+// parseDocument parses data into a document node whose root is a mapping.
+func parseDocument(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("parse YAML: %w", err)
+	}
+
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	return &doc, nil
+}
+
+// encodeDocument encodes doc with the package's indentation.
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	var out bytes.Buffer
+
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(yamlIndent)
+
+	err := encoder.Encode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encode YAML: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, fmt.Errorf("encode YAML: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// keyIndex returns the index of key's key node in mapping, or -1.
+func keyIndex(mapping *yaml.Node, key string) int {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return -1
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// setNode sets the value at the dot-separated path below mapping, creating
+// intermediate mappings as needed, and returns the value node now at path. An
+// existing scalar keeps its comments.
+func setNode(mapping *yaml.Node, path string, value *yaml.Node) *yaml.Node {
+	node := mapping
+	keys := strings.Split(path, ".")
+
+	for i, key := range keys {
+		index := keyIndex(node, key)
+
+		if i == len(keys)-1 {
+			if index < 0 {
+				node.Content = append(node.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+
+				return value
+			}
+
+			existing := node.Content[index+1]
+			value.HeadComment, value.LineComment, value.FootComment =
+				existing.HeadComment, existing.LineComment, existing.FootComment
+			node.Content[index+1] = value
+
+			return value
+		}
+
+		if index < 0 || node.Content[index+1].Kind != yaml.MappingNode {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+			if index < 0 {
+				node.Content = append(node.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+			} else {
+				node.Content[index+1] = child
+			}
+
+			node = child
+
+			continue
+		}
+
+		node = node.Content[index+1]
+	}
+
+	return value
+}
+
+// lookupNode returns the value node at the dot-separated path below mapping, or nil.
+func lookupNode(mapping *yaml.Node, path string) *yaml.Node {
+	node := mapping
+
+	for key := range strings.SplitSeq(path, ".") {
+		index := keyIndex(node, key)
+		if index < 0 {
+			return nil
+		}
+
+		node = node.Content[index+1]
+	}
+
+	return node
+}
+
+// scalarNode encodes value (a bool, integer, or string) as a scalar node.
+func scalarNode(value any) (*yaml.Node, error) {
+	var node yaml.Node
+
+	err := node.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode %v: %w", value, err)
+	}
+
+	return &node, nil
+}
@@ -5,5 +5,6 @@
 //   - cipher: Secret encryption and decryption with SOPS
 //   - cluster: Cluster lifecycle management (create, delete, start, stop, etc.)
 //   - mcp: Model Context Protocol server for AI assistants
+//   - registry: OCI registry operations (copy)
 //   - workload: Workload management (apply, push, gen, etc.)
 package cmd
@@ -0,0 +1,388 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/dockerutil"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/registryresolver"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/workloadwatch"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+// ErrLoadUnsupported is returned when building without --push for a cluster
+// whose nodes cannot load images directly.
+var ErrLoadUnsupported = errors.New(
+	"loading images into nodes is only supported for Vanilla and K3s on Docker; " +
+		"use --push to deliver the image through the local registry")
+
+// ErrExternalRegistry is returned when --push resolves to a registry that is
+// not the cluster's local registry.
+var ErrExternalRegistry = errors.New("--push only pushes to the cluster's local registry")
+
+const buildCmdLong = `Build an image from a Dockerfile and make it available to the cluster in one step.
+
+The image is built by the BuildKit builder of the Docker daemon, honouring the
+.dockerignore of the build context and pulling base images with the
+credentials of your Docker config. It is then loaded straight into the
+containerd runtime of every cluster node, so pods can run it with
+imagePullPolicy IfNotPresent or Never without any registry.
+
+With --push, the image is instead pushed to the cluster's local registry and
+can be referenced as <registry>/<name>:<tag>; use this for Talos clusters,
+whose nodes cannot load images directly.
+
+The image is built for the platforms of the cluster nodes, so nodes of
+another architecture than the Docker host (such as arm64 nodes built on an
+amd64 laptop) can run it. QEMU emulators are installed on the Docker host
+first when a platform needs them. Nodes of mixed architectures get a
+multi-platform image, which needs the containerd image store of Docker.
+
+With --watch, the image is rebuilt and delivered again whenever a file in the
+build context changes, until interrupted.`
+
+const buildCmdExample = `  # Build ./Dockerfile and load it into every node
+  ksail image build -t my-app:dev
+
+  # Build another context with a build argument and push it to the local registry
+  ksail image build ./services/api -t api:dev --build-arg VERSION=dev --push
+
+  # Rebuild and reload on every change
+  ksail image build -t my-app:dev --watch`
+
+// Builder builds images and delivers them to a cluster.
+type Builder interface {
+	// Build builds spec, delivers the image to the cluster and returns the
+	// references the cluster can run it by.
+	Build(ctx context.Context, spec dockerclient.ImageBuildSpec, output io.Writer) ([]string, error)
+}
+
+type buildFlags struct {
+	spec      dockerclient.ImageBuildSpec
+	buildArgs map[string]string
+	push      bool
+	watch     bool
+}
+
+// NewBuildCmd creates the image build command.
+func NewBuildCmd() *cobra.Command {
+	var flags buildFlags
+
+	cmd := &cobra.Command{
+		Use:          "build [CONTEXT]",
+		Short:        "Build an image and load it into cluster nodes",
+		Long:         buildCmdLong,
+		Example:      buildCmdExample,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+	}
+
+	cfgManager := newImageConfigManager(cmd)
+
+	cmd.Flags().StringSliceVarP(&flags.spec.Tags, "tag", "t", nil,
+		"Name and tag of the image, e.g. my-app:dev (repeatable)")
+	cmd.Flags().StringVarP(&flags.spec.Dockerfile, "file", "f", "",
+		"Dockerfile path relative to the build context (default: Dockerfile)")
+	cmd.Flags().StringToStringVar(&flags.buildArgs, "build-arg", nil,
+		"Build-time variable, e.g. VERSION=dev (repeatable)")
+	cmd.Flags().StringVar(&flags.spec.Target, "target", "", "Build stage to build")
+	cmd.Flags().StringVar(&flags.spec.Platform, "platform", "",
+		"Platforms to build for, e.g. linux/arm64 (default: the platforms of the cluster nodes)")
+	cmd.Flags().BoolVar(&flags.spec.NoCache, "no-cache", false, "Do not use the build cache")
+	cmd.Flags().BoolVar(&flags.push, "push", false,
+		"Push the image to the cluster's local registry instead of loading it into the nodes")
+	cmd.Flags().BoolVarP(&flags.watch, "watch", "w", false,
+		"Rebuild and deliver the image whenever the build context changes")
+
+	_ = cmd.MarkFlagRequired("tag")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		flags.spec.ContextDir = "."
+		if len(args) > 0 {
+			flags.spec.ContextDir = args[0]
+		}
+
+		flags.spec.BuildArgs = flags.buildArgs
+
+		return withBuilder(cmd, cfgManager, flags.push, func(builder Builder) error {
+			err := buildAndReport(cmd.Context(), cmd, builder, flags.spec)
+			if err != nil || !flags.watch {
+				return err
+			}
+
+			return watchAndRebuild(cmd, builder, flags.spec)
+		})
+	}
+
+	return cmd
+}
+
+// buildAndReport builds spec and reports the references the cluster can run it by.
+func buildAndReport(
+	ctx context.Context,
+	cmd *cobra.Command,
+	builder Builder,
+	spec dockerclient.ImageBuildSpec,
+) error {
+	start := time.Now()
+
+	refs, err := builder.Build(ctx, spec, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("build image: %w", err)
+	}
+
+	for _, ref := range refs {
+		notify.Successf(cmd.OutOrStdout(), "%s is available in the cluster (%s)",
+			ref, workloadwatch.FormatElapsed(time.Since(start)))
+	}
+
+	return nil
+}
+
+// watchAndRebuild rebuilds spec whenever a file of its build context changes,
+// until the command is interrupted. Failed builds are reported and the watch
+// continues.
+func watchAndRebuild(cmd *cobra.Command, builder Builder, spec dockerclient.ImageBuildSpec) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create file watcher: %w", err)
+	}
+
+	defer func() { _ = watcher.Close() }()
+
+	err = workloadwatch.AddRecursive(watcher, spec.ContextDir)
+	if err != nil {
+		return fmt.Errorf("watch build context: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	state := &workloadwatch.DebounceState{}
+	defer workloadwatch.CancelPendingDebounce(state)
+
+	rebuildCh := make(chan string, 1)
+
+	notify.Infof(cmd.OutOrStdout(), "watching %s for changes, press Ctrl+C to stop", spec.ContextDir)
+
+	for {
+		select {
+		case <-ctx.Done():
+			cmd.PrintErrln("\n✋ watcher stopped")
+
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if !workloadwatch.IsRelevantEvent(event) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				workloadwatch.TryAddDirectory(watcher, event.Name, cmd.ErrOrStderr())
+			}
+
+			workloadwatch.ScheduleApply(state, event.Name, rebuildCh)
+
+		case file := <-rebuildCh:
+			notify.Infof(cmd.OutOrStdout(), "%s changed, rebuilding", file)
+
+			err := buildAndReport(ctx, cmd, builder, spec)
+			if err != nil {
+				notify.Errorf(cmd.ErrOrStderr(), "%v", err)
+			}
+
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			return fmt.Errorf("file watcher: %w", watchErr)
+		}
+	}
+}
+
+// WithBuilder runs operation with a Builder delivering images to the cluster of
+// cfgManager's configuration: loaded into its nodes, or pushed to its local
+// registry when push is set. 'ksail workload dev --operator' rebuilds the
+// manager image through it.
+func WithBuilder(
+	cmd *cobra.Command,
+	cfgManager *configmanager.ConfigManager,
+	push bool,
+	operation func(Builder) error,
+) error {
+	return withBuilder(cmd, cfgManager, push, operation)
+}
+
+//nolint:gochecknoglobals // Test seam: replaced to build images without Docker or a cluster.
+var withBuilder = func(
+	cmd *cobra.Command,
+	cfgManager *configmanager.ConfigManager,
+	push bool,
+	operation func(Builder) error,
+) error {
+	clusterCfg, err := cfgManager.Load(
+		configmanagerinterface.LoadOptions{Silent: true, SkipValidation: true},
+	)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	connection := clusterCfg.Spec.Cluster.Connection
+
+	info, err := clusterdetector.DetectInfo(cmd.Context(), connection.Kubeconfig, connection.Context)
+	if err != nil {
+		return fmt.Errorf("detect cluster info: %w", err)
+	}
+
+	builder := &clusterBuilder{info: info, nodePlatforms: detectNodePlatforms(cmd, info)}
+
+	if push {
+		registryInfo, err := registryresolver.ResolveRegistry(cmd.Context(), registryresolver.ResolveRegistryOptions{
+			ClusterConfig: clusterCfg,
+			ClusterName:   info.ClusterName,
+		})
+		if err != nil {
+			return fmt.Errorf("resolve local registry: %w", err)
+		}
+
+		if registryInfo.IsExternal {
+			return fmt.Errorf("%w: %s is external", ErrExternalRegistry, registryInfo.Host)
+		}
+
+		builder.registryHost = fmt.Sprintf("%s:%d", registryInfo.Host, registryInfo.Port)
+	} else if !canLoadImages(info) {
+		return fmt.Errorf("%w: %s on %s", ErrLoadUnsupported, info.Distribution, info.Provider)
+	}
+
+	return dockerutil.WithDockerClient(cmd, func(dockerClient dockerclient.Client) error {
+		builder.dockerClient = dockerClient
+		builder.importer = imagesvc.NewImporter(dockerClient)
+
+		return operation(builder)
+	})
+}
+
+// detectNodePlatforms returns the platforms of the cluster nodes, or an empty
+// string to build for the Docker daemon's platform when they cannot be listed.
+func detectNodePlatforms(cmd *cobra.Command, info *clusterdetector.Info) string {
+	clientset, err := k8s.NewClientset(info.KubeconfigPath, info.Context)
+	if err == nil {
+		var platforms string
+
+		platforms, err = imagesvc.NodePlatforms(cmd.Context(), clientset)
+		if err == nil {
+			return platforms
+		}
+	}
+
+	notify.Warningf(cmd.ErrOrStderr(), "building for the Docker host platform: detect node platforms: %v", err)
+
+	return ""
+}
+
+// canLoadImages reports whether the nodes of the cluster are Docker containers
+// images can be loaded into.
+func canLoadImages(info *clusterdetector.Info) bool {
+	if info.Provider != v1alpha1.ProviderDocker && info.Provider != "" {
+		return false
+	}
+
+	return info.Distribution == v1alpha1.DistributionVanilla || info.Distribution == v1alpha1.DistributionK3s
+}
+
+// clusterBuilder builds images with the Docker daemon and loads them into the
+// nodes of a cluster, or pushes them to its local registry when registryHost is set.
+type clusterBuilder struct {
+	dockerClient  dockerclient.Client
+	importer      *imagesvc.Importer
+	info          *clusterdetector.Info
+	registryHost  string
+	nodePlatforms string
+	// emulated is the platform emulation was last set up for, so rebuilds of
+	// --watch skip setting it up again.
+	emulated string
+}
+
+// Build builds spec and delivers the image to the cluster.
+func (b *clusterBuilder) Build(
+	ctx context.Context,
+	spec dockerclient.ImageBuildSpec,
+	output io.Writer,
+) ([]string, error) {
+	if spec.Platform == "" {
+		spec.Platform = b.nodePlatforms
+	}
+
+	if spec.Platform != "" && spec.Platform != b.emulated {
+		err := dockerclient.EnsureEmulation(ctx, b.dockerClient, spec.Platform)
+		if err != nil {
+			return nil, fmt.Errorf("set up cross-platform build: %w", err)
+		}
+
+		b.emulated = spec.Platform
+	}
+
+	if b.registryHost == "" {
+		err := dockerclient.BuildImageFromContext(ctx, b.dockerClient, spec, output)
+		if err != nil {
+			return nil, fmt.Errorf("build: %w", err)
+		}
+
+		err = b.importer.LoadImages(ctx, b.info.ClusterName, b.info.Distribution, b.info.Provider, spec.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("load into nodes: %w", err)
+		}
+
+		return spec.Tags, nil
+	}
+
+	registryRefs := make([]string, 0, len(spec.Tags))
+
+	for _, tag := range spec.Tags {
+		ref, err := imagesvc.RegistryRef(tag, b.registryHost)
+		if err != nil {
+			return nil, fmt.Errorf("tag for local registry: %w", err)
+		}
+
+		registryRefs = append(registryRefs, ref)
+	}
+
+	spec.Tags = slices.Concat(spec.Tags, registryRefs)
+
+	err := dockerclient.BuildImageFromContext(ctx, b.dockerClient, spec, output)
+	if err != nil {
+		return nil, fmt.Errorf("build: %w", err)
+	}
+
+	err = b.importer.PushImages(ctx, registryRefs, output)
+	if err != nil {
+		return nil, fmt.Errorf("push to local registry: %w", err)
+	}
+
+	return registryRefs, nil
+}
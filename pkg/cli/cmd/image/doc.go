@@ -0,0 +1,3 @@
+// Package image provides CLI commands for inspecting and pruning the images
+// held by the containerd runtimes of a cluster's nodes.
+package image
@@ -0,0 +1,68 @@
+package image
+
+import (
+	"context"
+	"io"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/spf13/cobra"
+)
+
+// SetRuntimeForTests replaces the cluster-backed image runtime and returns a
+// function restoring the original.
+func SetRuntimeForTests(runtime imagesvc.Runtime) func() {
+	original := withRuntime
+	withRuntime = func(
+		_ *cobra.Command,
+		_ *configmanager.ConfigManager,
+		operation func(imagesvc.Runtime) error,
+	) error {
+		return operation(runtime)
+	}
+
+	return func() { withRuntime = original }
+}
+
+// BuildFunc builds an image for tests, returning the references it is available by.
+type BuildFunc func(ctx context.Context, spec dockerclient.ImageBuildSpec, output io.Writer) ([]string, error)
+
+func (f BuildFunc) Build(ctx context.Context, spec dockerclient.ImageBuildSpec, output io.Writer) ([]string, error) {
+	return f(ctx, spec, output)
+}
+
+// SetBuilderForTests replaces the Docker-backed image builder with build and
+// returns a function restoring the original. The push flag is passed to build
+// through pushed.
+func SetBuilderForTests(build BuildFunc, pushed *bool) func() {
+	original := withBuilder
+	withBuilder = func(
+		_ *cobra.Command,
+		_ *configmanager.ConfigManager,
+		push bool,
+		operation func(Builder) error,
+	) error {
+		*pushed = push
+
+		return operation(build)
+	}
+
+	return func() { withBuilder = original }
+}
+
+// SetNodeImageExporterForTests replaces the export of node images scanned by
+// image scan and returns a function restoring the original.
+func SetNodeImageExporterForTests(export func(image, archivePath string) error) func() {
+	original := exportNodeImage
+	exportNodeImage = func(
+		_ *cobra.Command,
+		_ *configmanager.ConfigManager,
+		image string,
+		archivePath string,
+	) error {
+		return export(image, archivePath)
+	}
+
+	return func() { exportNodeImage = original }
+}
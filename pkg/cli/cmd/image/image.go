@@ -0,0 +1,47 @@
+package image
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/spf13/cobra"
+)
+
+// NewImageCmd creates the parent image command and wires subcommands beneath it.
+func NewImageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image",
+		Short: "Manage images in cluster nodes",
+		Long: `Manage the container images held by the containerd runtimes of the ` +
+			`cluster's nodes, such as building images straight into them, listing them ` +
+			`with their sizes, pruning the images no container uses and scanning images ` +
+			`for known vulnerabilities.`,
+		Args:         cobra.NoArgs,
+		RunE:         handleImageRunE,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationConsolidate: "image_command",
+		},
+	}
+
+	cmd.AddCommand(NewBuildCmd())
+	cmd.AddCommand(NewListCmd())
+	cmd.AddCommand(NewPruneCmd())
+	cmd.AddCommand(NewScanCmd())
+
+	return cmd
+}
+
+//nolint:gochecknoglobals // Injected for testability to simulate help failures.
+var helpRunner = func(cmd *cobra.Command) error {
+	return cmd.Help()
+}
+
+func handleImageRunE(cmd *cobra.Command, _ []string) error {
+	err := helpRunner(cmd)
+	if err != nil {
+		return fmt.Errorf("displaying image command help: %w", err)
+	}
+
+	return nil
+}
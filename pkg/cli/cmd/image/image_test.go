@@ -0,0 +1,248 @@
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	snapshottest "github.com/devantler-tech/ksail/v7/internal/testutil/snapshottest"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	imagepkg "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/image"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	grypeclient "github.com/devantler-tech/ksail/v7/pkg/client/grype"
+	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRemoveFailed = errors.New("image is being pulled")
+
+func TestMain(m *testing.M) {
+	os.Exit(snapshottest.Run(m, snaps.CleanOpts{Sort: true}))
+}
+
+// stubRuntime serves a fixed image list and records removals.
+type stubRuntime struct {
+	images  []imagesvc.NodeImage
+	removed []string
+	failOn  string
+}
+
+func (s *stubRuntime) ListImages(_ context.Context) ([]imagesvc.NodeImage, error) {
+	return s.images, nil
+}
+
+func (s *stubRuntime) RemoveImage(_ context.Context, image imagesvc.NodeImage) error {
+	if image.Name() == s.failOn {
+		return errRemoveFailed
+	}
+
+	s.removed = append(s.removed, image.Node+"/"+image.Name())
+
+	return nil
+}
+
+func newStubRuntime() *stubRuntime {
+	return &stubRuntime{images: []imagesvc.NodeImage{
+		{
+			Node: "dev-control-plane", Digest: "sha256:0123456789abcdef0123",
+			Refs: []string{"docker.io/library/nginx:latest"}, Size: 70 << 20, InUse: true,
+		},
+		{
+			Node: "dev-control-plane", Digest: "sha256:fedcba9876543210fedc",
+			Refs: []string{"docker.io/library/redis:7", "sha256:2222"}, Size: 40 << 20,
+		},
+		{
+			Node: "dev-worker", Digest: "sha256:aaaa",
+			Refs: []string{"sha256:aaaa"}, Size: 512 << 10,
+		},
+	}}
+}
+
+func executeImageCmd(t *testing.T, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	cmd.SetContext(context.Background())
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+func TestImageCmd_ShowsHelp(t *testing.T) {
+	t.Parallel()
+
+	output, err := executeImageCmd(t, imagepkg.NewImageCmd())
+	require.NoError(t, err)
+
+	require.Contains(t, output, "list")
+	require.Contains(t, output, "prune")
+	require.Contains(t, output, "scan")
+
+	snaps.MatchSnapshot(t, output)
+}
+
+func TestImageCmd_HasConsolidateAnnotation(t *testing.T) {
+	t.Parallel()
+
+	cmd := imagepkg.NewImageCmd()
+	require.Equal(t, "image_command", cmd.Annotations[annotations.AnnotationConsolidate])
+}
+
+//nolint:paralleltest // mutates the shared runtime seam
+func TestListCmd_ListsImagesPerNode(t *testing.T) {
+	defer imagepkg.SetRuntimeForTests(newStubRuntime())()
+
+	output, err := executeImageCmd(t, imagepkg.NewListCmd())
+	require.NoError(t, err)
+
+	assert.Equal(t, `NODE               IMAGE                           DIGEST               SIZE     STATUS
+dev-control-plane  docker.io/library/nginx:latest  sha256:0123456789ab  70 MiB   in use
+dev-control-plane  docker.io/library/redis:7       sha256:fedcba987654  40 MiB   unused
+dev-worker         sha256:aaaa                     sha256:aaaa          512 KiB  unused
+
+Total: 110.5 MiB in 3 images on 2 nodes
+`, output)
+}
+
+//nolint:paralleltest // mutates the shared runtime seam
+func TestPruneCmd_RemovesUnusedImages(t *testing.T) {
+	runtime := newStubRuntime()
+	defer imagepkg.SetRuntimeForTests(runtime)()
+
+	output, err := executeImageCmd(t, imagepkg.NewPruneCmd())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dev-control-plane/docker.io/library/redis:7", "dev-worker/sha256:aaaa"}, runtime.removed)
+	assert.Contains(t, output, "removed docker.io/library/redis:7 from dev-control-plane (40 MiB)")
+	assert.Contains(t, output, "pruned 2 images, freed 40.5 MiB")
+}
+
+//nolint:paralleltest // mutates the shared runtime seam
+func TestPruneCmd_DryRunRemovesNothing(t *testing.T) {
+	runtime := newStubRuntime()
+	defer imagepkg.SetRuntimeForTests(runtime)()
+
+	output, err := executeImageCmd(t, imagepkg.NewPruneCmd(), "--dry-run")
+	require.NoError(t, err)
+
+	assert.Empty(t, runtime.removed)
+	assert.Contains(t, output, "would remove sha256:aaaa from dev-worker (512 KiB)")
+	assert.Contains(t, output, "would prune 2 images, freeing 40.5 MiB")
+}
+
+//nolint:paralleltest // mutates the shared runtime seam
+func TestPruneCmd_ReportsFailures(t *testing.T) {
+	runtime := newStubRuntime()
+	runtime.failOn = "docker.io/library/redis:7"
+	defer imagepkg.SetRuntimeForTests(runtime)()
+
+	output, err := executeImageCmd(t, imagepkg.NewPruneCmd())
+	require.ErrorIs(t, err, errRemoveFailed)
+
+	assert.Equal(t, []string{"dev-worker/sha256:aaaa"}, runtime.removed)
+	assert.Contains(t, output, "pruned 1 images, freed 512 KiB")
+}
+
+//nolint:paralleltest // mutates the shared image builder seam
+func TestBuildCmd_LoadsBuiltImage(t *testing.T) {
+	var (
+		built  dockerclient.ImageBuildSpec
+		pushed bool
+	)
+
+	defer imagepkg.SetBuilderForTests(func(
+		_ context.Context,
+		spec dockerclient.ImageBuildSpec,
+		_ io.Writer,
+	) ([]string, error) {
+		built = spec
+
+		return spec.Tags, nil
+	}, &pushed)()
+
+	output, err := executeImageCmd(t, imagepkg.NewBuildCmd(),
+		"./app", "-t", "my-app:dev", "-f", "build/Dockerfile", "--build-arg", "VERSION=dev")
+	require.NoError(t, err)
+
+	assert.False(t, pushed)
+	assert.Equal(t, dockerclient.ImageBuildSpec{
+		ContextDir: "./app",
+		Dockerfile: "build/Dockerfile",
+		Tags:       []string{"my-app:dev"},
+		BuildArgs:  map[string]string{"VERSION": "dev"},
+	}, built)
+	assert.Contains(t, output, "my-app:dev is available in the cluster")
+}
+
+//nolint:paralleltest // mutates the shared image builder seam
+func TestBuildCmd_PushesToLocalRegistry(t *testing.T) {
+	var pushed bool
+
+	defer imagepkg.SetBuilderForTests(func(
+		_ context.Context,
+		_ dockerclient.ImageBuildSpec,
+		_ io.Writer,
+	) ([]string, error) {
+		return []string{"localhost:5050/my-app:dev"}, nil
+	}, &pushed)()
+
+	output, err := executeImageCmd(t, imagepkg.NewBuildCmd(), "-t", "my-app:dev", "--push")
+	require.NoError(t, err)
+
+	assert.True(t, pushed)
+	assert.Contains(t, output, "localhost:5050/my-app:dev is available in the cluster")
+}
+
+func TestBuildCmd_RequiresTag(t *testing.T) {
+	t.Parallel()
+
+	_, err := executeImageCmd(t, imagepkg.NewBuildCmd())
+	require.ErrorContains(t, err, `required flag(s) "tag" not set`)
+}
+
+func TestScanCmd_RejectsInvalidFlags(t *testing.T) {
+	t.Parallel()
+
+	_, err := executeImageCmd(t, imagepkg.NewScanCmd(), "my-app:dev", "--format", "xml")
+	require.ErrorIs(t, err, grypeclient.ErrUnsupportedFormat)
+
+	_, err = executeImageCmd(t, imagepkg.NewScanCmd(), "my-app:dev", "--fail-on", "severe")
+	require.ErrorIs(t, err, grypeclient.ErrUnknownSeverity)
+}
+
+//nolint:paralleltest // mutates the shared node image exporter seam
+func TestScanCmd_ExportsNodeImages(t *testing.T) {
+	var exported string
+
+	defer imagepkg.SetNodeImageExporterForTests(func(image, archivePath string) error {
+		exported = image
+
+		// An archive that is no image stops the scan before the vulnerability
+		// database is loaded.
+		return os.WriteFile(archivePath, nil, 0o600)
+	})()
+
+	_, err := executeImageCmd(t, imagepkg.NewScanCmd(), "my-app:dev")
+	require.ErrorIs(t, err, grypeclient.ErrScanFailed)
+	assert.Equal(t, "my-app:dev", exported)
+}
+
+func TestVulnerabilitiesExitError(t *testing.T) {
+	t.Parallel()
+
+	err := &imagepkg.VulnerabilitiesExitError{Count: 3, Severity: "high"}
+
+	require.ErrorIs(t, err, imagepkg.ErrVulnerabilitiesFound)
+	assert.Equal(t, 2, err.KSailExitCode())
+	assert.Equal(t, "vulnerabilities found: 3 at or above severity high", err.Error())
+}
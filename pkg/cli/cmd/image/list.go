@@ -0,0 +1,111 @@
+package image
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/devantler-tech/ksail/v7/pkg/strutil"
+	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/spf13/cobra"
+)
+
+// tabwriter geometry for the image table (minwidth 0, tab/padding 2, space pad).
+const (
+	listTabPadding = 2
+	listTabSize    = 2
+)
+
+// shortDigestLength is the number of digest hex characters shown in tables.
+const shortDigestLength = 12
+
+const listCmdLong = `List the container images held by the containerd runtime of each cluster node.
+
+Every image is listed once per node with its size and whether a container on
+the node uses it. Images no container uses are removed by 'ksail image prune'.
+
+Kind and K3d nodes are inspected with ctr through Docker exec; Talos nodes
+through the image service of the Talos API, using the talosconfig of
+spec.cluster.talos.config (default: ~/.talos/config).`
+
+const listCmdExample = `  # List the images of the current cluster
+  ksail image list
+
+  # List the images of another cluster
+  ksail image list --context=kind-dev`
+
+// NewListCmd creates the image list command.
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List images in cluster nodes",
+		Long:         listCmdLong,
+		Example:      listCmdExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+
+	cfgManager := newImageConfigManager(cmd)
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return withRuntime(cmd, cfgManager, func(runtime imagesvc.Runtime) error {
+			images, err := runtime.ListImages(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("list images: %w", err)
+			}
+
+			writeImages(cmd.OutOrStdout(), images)
+
+			return nil
+		})
+	}
+
+	return cmd
+}
+
+// writeImages writes one row per node image and the total size of all images.
+func writeImages(out io.Writer, images []imagesvc.NodeImage) {
+	if len(images) == 0 {
+		_, _ = fmt.Fprintln(out, "No images found.")
+
+		return
+	}
+
+	writer := tabwriter.NewWriter(out, 0, listTabSize, listTabPadding, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "NODE\tIMAGE\tDIGEST\tSIZE\tSTATUS")
+
+	var total int64
+
+	nodes := make(map[string]struct{})
+
+	for _, image := range images {
+		status := "unused"
+		if image.InUse {
+			status = "in use"
+		}
+
+		total += image.Size
+		nodes[image.Node] = struct{}{}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			image.Node, image.Name(), shortDigest(image.Digest), strutil.FormatBytes(image.Size), status)
+	}
+
+	_ = writer.Flush()
+
+	_, _ = fmt.Fprintf(out, "\nTotal: %s in %d images on %d nodes\n",
+		strutil.FormatBytes(total), len(images), len(nodes))
+}
+
+// shortDigest abbreviates a digest such as sha256:<hex> to its algorithm and
+// the first hex characters.
+func shortDigest(digest string) string {
+	algorithm, hex, found := strings.Cut(digest, ":")
+	if !found || len(hex) <= shortDigestLength {
+		return digest
+	}
+
+	return algorithm + ":" + hex[:shortDigestLength]
+}
@@ -0,0 +1,94 @@
+package image
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/strutil"
+	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/spf13/cobra"
+)
+
+const pruneCmdLong = `Free disk space on cluster nodes by removing the images no container uses.
+
+An image is removed from a node when no container or pod sandbox on that node
+references it, including images another node still uses. Removed images are
+pulled again when a workload needs them, so pruning never breaks a cluster,
+but the next start of such a workload is slower. Use --dry-run to see what
+would be removed.`
+
+const pruneCmdExample = `  # Show the images that would be removed
+  ksail image prune --dry-run
+
+  # Remove every unused image from every node
+  ksail image prune`
+
+// NewPruneCmd creates the image prune command.
+func NewPruneCmd() *cobra.Command {
+	var opts imagesvc.PruneOptions
+
+	cmd := &cobra.Command{
+		Use:          "prune",
+		Short:        "Remove unused images from cluster nodes",
+		Long:         pruneCmdLong,
+		Example:      pruneCmdExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+	}
+
+	cfgManager := newImageConfigManager(cmd)
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false,
+		"Report the images that would be removed without removing them")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return withRuntime(cmd, cfgManager, func(runtime imagesvc.Runtime) error {
+			removed, err := imagesvc.Prune(cmd.Context(), runtime, opts)
+
+			writePruneResults(cmd.OutOrStdout(), removed, opts.DryRun)
+
+			if err != nil {
+				return fmt.Errorf("prune images: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	return cmd
+}
+
+// writePruneResults reports each removed image and the space freed in total.
+func writePruneResults(writer io.Writer, removed []imagesvc.NodeImage, dryRun bool) {
+	if len(removed) == 0 {
+		notify.Infof(writer, "no unused images to prune")
+
+		return
+	}
+
+	verb := "removed"
+	if dryRun {
+		verb = "would remove"
+	}
+
+	var freed int64
+
+	for _, image := range removed {
+		freed += image.Size
+
+		notify.Infof(writer, "%s %s from %s (%s)", verb, image.Name(), image.Node, strutil.FormatBytes(image.Size))
+	}
+
+	if dryRun {
+		notify.Infof(writer, "would prune %d images, freeing %s", len(removed), strutil.FormatBytes(freed))
+
+		return
+	}
+
+	notify.Successf(writer, "pruned %d images, freed %s", len(removed), strutil.FormatBytes(freed))
+}
@@ -0,0 +1,105 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/dockerutil"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/spf13/cobra"
+)
+
+// defaultTalosconfigPath is used when spec.cluster.talos.config is not set.
+const defaultTalosconfigPath = "~/.talos/config"
+
+// ErrUnsupportedDistribution is returned for clusters whose node images
+// cannot be reached.
+var ErrUnsupportedDistribution = errors.New(
+	"listing and pruning node images is only supported for Vanilla and K3s on Docker, and for Talos")
+
+// newImageConfigManager creates a config manager for the image commands.
+// Only --context and --kubeconfig are registered, since the distribution is
+// detected from the running cluster.
+func newImageConfigManager(cmd *cobra.Command) *configmanager.ConfigManager {
+	fieldSelectors := []configmanager.FieldSelector[v1alpha1.Cluster]{
+		configmanager.DefaultContextFieldSelector(),
+		configmanager.DefaultKubeconfigFieldSelector(),
+	}
+
+	return configmanager.NewCommandConfigManager(cmd, fieldSelectors)
+}
+
+//nolint:gochecknoglobals // Test seam: replaced to work with node images without a cluster.
+var withRuntime = func(
+	cmd *cobra.Command,
+	cfgManager *configmanager.ConfigManager,
+	operation func(imagesvc.Runtime) error,
+) error {
+	clusterCfg, err := cfgManager.Load(
+		configmanagerinterface.LoadOptions{Silent: true, SkipValidation: true},
+	)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	connection := clusterCfg.Spec.Cluster.Connection
+
+	info, err := clusterdetector.DetectInfo(cmd.Context(), connection.Kubeconfig, connection.Context)
+	if err != nil {
+		return fmt.Errorf("detect cluster info: %w", err)
+	}
+
+	switch info.Distribution {
+	case v1alpha1.DistributionVanilla, v1alpha1.DistributionK3s:
+		return dockerutil.WithDockerClient(cmd, func(dockerClient dockerclient.Client) error {
+			runtime, err := imagesvc.NewDockerRuntime(dockerClient, info.ClusterName, info.Distribution, info.Provider)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrUnsupportedDistribution, err)
+			}
+
+			return operation(runtime)
+		})
+	case v1alpha1.DistributionTalos:
+		return withTalosRuntime(cmd, info, clusterCfg.Spec.Cluster.Talos.Config, operation)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedDistribution, info.Distribution)
+	}
+}
+
+// withTalosRuntime runs operation against the nodes of a Talos cluster, reached
+// through the talosconfig at talosconfigPath.
+func withTalosRuntime(
+	cmd *cobra.Command,
+	info *clusterdetector.Info,
+	talosconfigPath string,
+	operation func(imagesvc.Runtime) error,
+) error {
+	if talosconfigPath == "" {
+		talosconfigPath = defaultTalosconfigPath
+	}
+
+	clientset, err := k8s.NewClientset(info.KubeconfigPath, info.Context)
+	if err != nil {
+		return fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	nodes, err := imagesvc.TalosNodes(cmd.Context(), clientset)
+	if err != nil {
+		return fmt.Errorf("resolve Talos nodes: %w", err)
+	}
+
+	runtime, err := imagesvc.NewTalosRuntime(cmd.Context(), talosconfigPath, nodes)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = runtime.Close() }()
+
+	return operation(runtime)
+}
@@ -0,0 +1,266 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/dockerutil"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	grypeclient "github.com/devantler-tech/ksail/v7/pkg/client/grype"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	imagesvc "github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/spf13/cobra"
+)
+
+// vulnerabilitiesExitCode is the exit code of a scan that finds vulnerabilities
+// at or above --fail-on, set apart from the exit code 1 of failed scans.
+const vulnerabilitiesExitCode = 2
+
+// ErrVulnerabilitiesFound is wrapped by VulnerabilitiesExitError.
+var ErrVulnerabilitiesFound = errors.New("vulnerabilities found")
+
+// ErrScanUnsupported is returned when scanning a node image of a cluster whose
+// node images cannot be exported.
+var ErrScanUnsupported = errors.New(
+	"scanning node images is only supported for Vanilla and K3s on Docker; " +
+		"scan an image archive instead")
+
+// VulnerabilitiesExitError is returned when a scan finds vulnerabilities at or
+// above the --fail-on severity. It wraps ErrVulnerabilitiesFound and exposes
+// KSailExitCode() so main.go exits with code 2 instead of the generic 1.
+type VulnerabilitiesExitError struct {
+	Count    int
+	Severity string
+}
+
+// Error implements the error interface.
+func (e *VulnerabilitiesExitError) Error() string {
+	return fmt.Sprintf("%v: %d at or above severity %s", ErrVulnerabilitiesFound, e.Count, e.Severity)
+}
+
+// Unwrap returns ErrVulnerabilitiesFound so errors.Is(err, ErrVulnerabilitiesFound) works.
+func (e *VulnerabilitiesExitError) Unwrap() error {
+	return ErrVulnerabilitiesFound
+}
+
+// KSailExitCode returns the exit code that main.go should use for this error.
+func (e *VulnerabilitiesExitError) KSailExitCode() int {
+	return vulnerabilitiesExitCode
+}
+
+const scanCmdLong = `Scan a container image for known vulnerabilities with the embedded Grype engine.
+
+The image is either a tar archive on disk, as written by docker save, ctr
+export or 'ksail workload export', or the reference of an image already
+loaded into the cluster nodes, such as one built by 'ksail image build'.
+Node images are exported from a node of the cluster for the platform of the
+nodes, which is supported for Vanilla and K3s clusters on Docker.
+
+The Grype vulnerability database is downloaded to the user cache directory on
+first use and updated when a newer one is published. Use --skip-db-update to
+scan with the cached database, for example in air-gapped CI.
+
+Findings are written as a table, a Grype JSON document (--format json) or a
+SARIF log for code scanning tools (--format sarif), to stdout or to the file
+of --output. With --fail-on, the command exits with code 2 when it finds a
+vulnerability of that severity or higher, so CI can gate on the scan; failed
+scans exit with code 1.`
+
+const scanCmdExample = `  # Scan an image loaded into the cluster nodes
+  ksail image scan my-app:dev
+
+  # Scan an exported archive and fail CI on high or critical findings
+  ksail image scan images.tar --fail-on high
+
+  # Write a SARIF log for code scanning
+  ksail image scan my-app:dev --format sarif --output grype.sarif`
+
+type scanFlags struct {
+	format       string
+	output       string
+	failOn       string
+	skipDBUpdate bool
+}
+
+// NewScanCmd creates the image scan command.
+func NewScanCmd() *cobra.Command {
+	var flags scanFlags
+
+	cmd := &cobra.Command{
+		Use:          "scan <IMAGE | ARCHIVE>",
+		Short:        "Scan an image for vulnerabilities",
+		Long:         scanCmdLong,
+		Example:      scanCmdExample,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+
+	cfgManager := newImageConfigManager(cmd)
+
+	cmd.Flags().StringVar(&flags.format, "format", grypeclient.FormatTable,
+		"Output format: "+strings.Join(grypeclient.Formats(), ", "))
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "", "File to write the report to (default: stdout)")
+	cmd.Flags().StringVar(&flags.failOn, "fail-on", "",
+		"Exit with code 2 when a vulnerability of this severity or higher is found: "+
+			strings.Join(grypeclient.Severities(), ", "))
+	cmd.Flags().BoolVar(&flags.skipDBUpdate, "skip-db-update", false,
+		"Scan with the cached vulnerability database without checking for updates")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runScan(cmd, cfgManager, args[0], flags)
+	}
+
+	return cmd
+}
+
+// runScan scans target and writes the report in the requested format.
+func runScan(cmd *cobra.Command, cfgManager *configmanager.ConfigManager, target string, flags scanFlags) error {
+	err := grypeclient.ValidateFormat(flags.format)
+	if err != nil {
+		return fmt.Errorf("invalid --format flag: %w", err)
+	}
+
+	if flags.failOn != "" {
+		err = grypeclient.ValidateSeverity(flags.failOn)
+		if err != nil {
+			return fmt.Errorf("invalid --fail-on flag: %w", err)
+		}
+	}
+
+	archivePath := target
+
+	if !isArchive(target) {
+		tmpDir, err := os.MkdirTemp("", "ksail-image-scan-")
+		if err != nil {
+			return fmt.Errorf("create temporary directory: %w", err)
+		}
+
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+
+		archivePath = filepath.Join(tmpDir, "image.tar")
+
+		err = exportNodeImage(cmd, cfgManager, target, archivePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	source, err := grypeclient.ArchiveSource(archivePath)
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", target, err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "scanning %s for vulnerabilities",
+		Args:    []any{target},
+		Writer:  cmd.ErrOrStderr(),
+	})
+
+	client := grypeclient.NewClient(grypeclient.ScanOptions{SkipDBUpdate: flags.skipDBUpdate})
+
+	defer func() { _ = client.Close() }()
+
+	report, err := client.Scan(cmd.Context(), source)
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", target, err)
+	}
+
+	err = writeScanReport(cmd.OutOrStdout(), report, flags)
+	if err != nil {
+		return err
+	}
+
+	if flags.failOn != "" {
+		count := report.CountAtOrAbove(flags.failOn)
+		if count > 0 {
+			return &VulnerabilitiesExitError{Count: count, Severity: strings.ToLower(flags.failOn)}
+		}
+	}
+
+	return nil
+}
+
+// isArchive reports whether target names a file on disk rather than an image
+// in the cluster nodes.
+func isArchive(target string) bool {
+	info, err := os.Stat(target)
+
+	return err == nil && info.Mode().IsRegular()
+}
+
+// writeScanReport writes report to the --output file, or to stdout without one.
+func writeScanReport(stdout io.Writer, report *grypeclient.Report, flags scanFlags) error {
+	if flags.output == "" {
+		return report.Write(stdout, flags.format)
+	}
+
+	file, err := os.Create(flags.output) //nolint:gosec // Path is provided by the user.
+	if err != nil {
+		return fmt.Errorf("create report file: %w", err)
+	}
+
+	err = report.Write(file, flags.format)
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("close report file: %w", err)
+	}
+
+	notify.Infof(stdout, "wrote %d vulnerabilities to %s", report.Count(), flags.output)
+
+	return nil
+}
+
+//nolint:gochecknoglobals // Test seam: replaced to scan node images without a cluster.
+var exportNodeImage = func(
+	cmd *cobra.Command,
+	cfgManager *configmanager.ConfigManager,
+	image string,
+	archivePath string,
+) error {
+	clusterCfg, err := cfgManager.Load(
+		configmanagerinterface.LoadOptions{Silent: true, SkipValidation: true},
+	)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	connection := clusterCfg.Spec.Cluster.Connection
+
+	info, err := clusterdetector.DetectInfo(cmd.Context(), connection.Kubeconfig, connection.Context)
+	if err != nil {
+		return fmt.Errorf("detect cluster info: %w", err)
+	}
+
+	if !canLoadImages(info) {
+		return fmt.Errorf("%w: %s on %s", ErrScanUnsupported, info.Distribution, info.Provider)
+	}
+
+	return dockerutil.WithDockerClient(cmd, func(dockerClient dockerclient.Client) error {
+		err := imagesvc.NewExporter(dockerClient).Export(
+			cmd.Context(),
+			info.ClusterName,
+			info.Distribution,
+			info.Provider,
+			imagesvc.ExportOptions{OutputPath: archivePath, Images: []string{image}},
+		)
+		if err != nil {
+			return fmt.Errorf("export %s from cluster %s: %w", image, info.ClusterName, err)
+		}
+
+		return nil
+	})
+}
@@ -86,7 +86,8 @@ func TestModeToolExecution(t *testing.T) {
 	}
 }
 
-// TestModeToggle verifies that mode toggling cycles correctly: Interactive → Plan → Autopilot → Interactive.
+// TestModeToggle verifies that mode toggling cycles correctly:
+// Interactive → Plan → Sandbox → Autopilot → Interactive.
 func TestModeToggle(t *testing.T) {
 	t.Parallel()
 
@@ -104,14 +105,20 @@ func TestModeToggle(t *testing.T) {
 
 	chatModeRef.SetMode(chatModeRef.Mode().Next())
 
+	if chatModeRef.Mode() != chatui.SandboxMode {
+		t.Errorf("Expected SandboxMode after second toggle, got %v", chatModeRef.Mode())
+	}
+
+	chatModeRef.SetMode(chatModeRef.Mode().Next())
+
 	if chatModeRef.Mode() != chatui.AutopilotMode {
-		t.Errorf("Expected AutopilotMode after second toggle, got %v", chatModeRef.Mode())
+		t.Errorf("Expected AutopilotMode after third toggle, got %v", chatModeRef.Mode())
 	}
 
 	chatModeRef.SetMode(chatModeRef.Mode().Next())
 
 	if chatModeRef.Mode() != chatui.InteractiveMode {
-		t.Errorf("Expected InteractiveMode after third toggle, got %v", chatModeRef.Mode())
+		t.Errorf("Expected InteractiveMode after fourth toggle, got %v", chatModeRef.Mode())
 	}
 }
 
@@ -1008,3 +1015,89 @@ func (m *cancelOnCallMock) GetAuthStatus(
 
 	return resp, err //nolint:wrapcheck // Mock function, wrapping not needed
 }
+
+// newSandboxTestTool returns a write tool that records the arguments it ran with.
+func newSandboxTestTool(toolName string, received *map[string]any) copilot.Tool {
+	return copilot.Tool{
+		Name: toolName,
+		Handler: func(inv copilot.ToolInvocation) (copilot.ToolResult, error) {
+			*received, _ = inv.Arguments.(map[string]any)
+
+			return copilot.ToolResult{TextResultForLLM: "Done", ResultType: successResult}, nil
+		},
+	}
+}
+
+// TestSandboxWrapper verifies that sandboxed write tools run with their
+// dry-run flag set, that write tools without one are not executed, and that
+// nothing changes outside sandbox mode.
+func TestSandboxWrapper(t *testing.T) {
+	t.Parallel()
+
+	metadata := map[string]toolgen.ToolDefinition{
+		clusterWriteTool: {
+			Name:               clusterWriteTool,
+			CommandPath:        "ksail cluster",
+			RequiresPermission: true,
+			IsConsolidated:     true,
+			SubcommandParam:    clusterCommandParam,
+			Subcommands: map[string]*toolgen.SubcommandDef{
+				"update": {Flags: map[string]*toolgen.FlagDef{
+					"dry-run": {Name: "dry-run", Type: booleanTypeName},
+				}},
+				deleteSubcommand: {Flags: map[string]*toolgen.FlagDef{}},
+			},
+		},
+	}
+
+	var (
+		received  map[string]any
+		sandboxed atomic.Bool
+	)
+
+	wrapped := chat.WrapToolsWithSandbox(
+		[]copilot.Tool{newSandboxTestTool(clusterWriteTool, &received)},
+		metadata,
+		sandboxed.Load,
+	)
+
+	invoke := func(subcommand string) copilot.ToolResult {
+		received = nil
+
+		result, err := wrapped[0].Handler(copilot.ToolInvocation{
+			ToolName:  clusterWriteTool,
+			Arguments: map[string]any{clusterCommandParam: subcommand},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return result
+	}
+
+	invoke(deleteSubcommand)
+
+	if received == nil || received["dry-run"] != nil {
+		t.Errorf("expected delete to run unchanged outside sandbox mode, got %v", received)
+	}
+
+	sandboxed.Store(true)
+
+	result := invoke("update")
+	if received["dry-run"] != true {
+		t.Errorf("expected update to run with --dry-run in sandbox mode, got %v", received)
+	}
+
+	if !strings.HasPrefix(result.TextResultForLLM, "SANDBOX MODE") {
+		t.Errorf("expected sandbox notice in result, got %q", result.TextResultForLLM)
+	}
+
+	result = invoke(deleteSubcommand)
+	if received != nil {
+		t.Errorf("expected delete not to run in sandbox mode, got %v", received)
+	}
+
+	if result.ResultType != failureResult || !strings.Contains(result.TextResultForLLM, "ksail cluster delete") {
+		t.Errorf("expected delete to be refused in sandbox mode, got %+v", result)
+	}
+}
@@ -153,3 +153,83 @@ func invokeWithOptionalForce(
 
 	return handler(injectConfirmFlags(invocation, confirmFlags))
 }
+
+// sandboxNotice prefixes the result of a sandboxed tool call so the model
+// reports it as a preview rather than a change.
+const sandboxNotice = "SANDBOX MODE: ran as a dry run; nothing was changed.\n"
+
+// WrapToolsWithSandbox wraps write tools so that, while sandboxed reports
+// true, they run as a dry run instead of mutating anything: the invoked
+// command's --dry-run flag is set (server-side for kubectl-backed commands, so
+// admission and validation still run), which surfaces e.g. cluster update's
+// change classification without applying it. Write tools whose command has no
+// dry-run flag are not executed at all. Read tools are left untouched.
+func WrapToolsWithSandbox(
+	tools []copilot.Tool,
+	toolMetadata map[string]toolgen.ToolDefinition,
+	sandboxed func() bool,
+) []copilot.Tool {
+	wrappedTools := make([]copilot.Tool, len(tools))
+
+	for toolIdx, tool := range tools {
+		wrappedTools[toolIdx] = tool
+
+		meta, metaExists := toolMetadata[tool.Name]
+		if !metaExists || !meta.RequiresPermission {
+			continue
+		}
+
+		originalHandler := tool.Handler
+
+		wrappedTools[toolIdx].Handler = func(invocation copilot.ToolInvocation) (copilot.ToolResult, error) {
+			if !sandboxed() {
+				return originalHandler(invocation)
+			}
+
+			return invokeSandboxed(invocation, meta, originalHandler)
+		}
+	}
+
+	return wrappedTools
+}
+
+// invokeSandboxed runs the invocation as a dry run, or refuses it when the
+// invoked command cannot dry-run.
+func invokeSandboxed(
+	invocation copilot.ToolInvocation,
+	meta toolgen.ToolDefinition,
+	handler func(copilot.ToolInvocation) (copilot.ToolResult, error),
+) (copilot.ToolResult, error) {
+	args, _ := invocation.Arguments.(map[string]any)
+
+	dryRunArgs, canDryRun := meta.DryRunParams(args)
+	if !canDryRun {
+		command := meta.CommandPath
+		if subcommand, ok := args[meta.SubcommandParam].(string); ok && meta.IsConsolidated {
+			command += " " + subcommand
+		}
+
+		return copilot.ToolResult{
+			TextResultForLLM: fmt.Sprintf(
+				"SANDBOX MODE: %q has no dry-run mode, so it was not executed. "+
+					"Describe the change instead; the user can leave sandbox mode to run it.",
+				command,
+			),
+			ResultType:    "failure",
+			SessionLog:    "[SANDBOX] skipped " + command,
+			ToolTelemetry: map[string]any{},
+		}, nil
+	}
+
+	invocation.Arguments = dryRunArgs
+
+	result, err := handler(invocation)
+	if err != nil {
+		return result, err
+	}
+
+	result.TextResultForLLM = sandboxNotice + result.TextResultForLLM
+	result.SessionLog = "[SANDBOX] " + result.SessionLog
+
+	return result, nil
+}
@@ -47,6 +47,9 @@ func setupChatTools(
 	tools, toolMetadata := chatsvc.GetKSailToolMetadata(rootCmd, outputChan, sessionLog)
 	chatModeRef := chatui.NewChatModeRef(chatui.InteractiveMode)
 	tools = WrapToolsWithForceInjection(tools, toolMetadata)
+	tools = WrapToolsWithSandbox(tools, toolMetadata, func() bool {
+		return chatModeRef.Mode() == chatui.SandboxMode
+	})
 	sessionConfig.Tools = tools
 	sessionConfig.OnPermissionRequest = chatui.CreateTUIPermissionHandler(eventChan, chatModeRef)
 
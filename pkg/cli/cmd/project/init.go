@@ -15,6 +15,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clustererr"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
 	"github.com/devantler-tech/ksail/v7/pkg/timer"
 	"github.com/spf13/cobra"
 )
@@ -53,13 +54,25 @@ func InitFieldSelectors() []ksailconfigmanager.FieldSelector[v1alpha1.Cluster] {
 	selectors = append(selectors, ksailconfigmanager.DefaultProviderFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.StandardSourceDirectoryFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.StandardKustomizationFileFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultArgoCDLayoutFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultArgoCDGeneratorFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultCNIFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultCSIFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultCDIFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultMirrorRegistryTLSFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultMetricsServerFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultLoadBalancerFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultCertManagerFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultSealedSecretsFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultExternalSecretsFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultBackupFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultPolicyEngineFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultIngressControllerFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultGatewayAPIFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultServiceMeshFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultIstioModeFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultKnativeFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultLoggingFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.DefaultImportImagesFieldSelector())
 	// Declarative version selectors (unset = follow latest, set = pin)
 	selectors = append(selectors, ksailconfigmanager.KubernetesVersionFieldSelector())
@@ -83,6 +96,7 @@ func InitFieldSelectors() []ksailconfigmanager.FieldSelector[v1alpha1.Cluster] {
 	selectors = append(selectors, ksailconfigmanager.OIDCGroupsClaimFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.OIDCGroupsPrefixFieldSelector())
 	selectors = append(selectors, ksailconfigmanager.OIDCCAFileFieldSelector())
+	selectors = append(selectors, ksailconfigmanager.DefaultIdentityProviderFieldSelector())
 
 	return selectors
 }
@@ -96,6 +110,19 @@ func bindInitLocalFlags(cmd *cobra.Command, cfgManager *ksailconfigmanager.Confi
 	_ = cfgManager.Viper.BindPFlag("force", cmd.Flags().Lookup("force"))
 	cmd.Flags().Bool("no-devcontainer", false, "Skip scaffolding .devcontainer/devcontainer.json")
 	_ = cfgManager.Viper.BindPFlag("no-devcontainer", cmd.Flags().Lookup("no-devcontainer"))
+	cmd.Flags().Bool(
+		"pre-commit",
+		false,
+		"Scaffold a .pre-commit-config.yaml that runs 'ksail verify' before every commit",
+	)
+	_ = cfgManager.Viper.BindPFlag("pre-commit", cmd.Flags().Lookup("pre-commit"))
+	cmd.Flags().String(
+		"task-runner",
+		"",
+		"Scaffold developer entrypoints (up, down, validate, test, push) that call ksail: "+
+			"'taskfile' for a Taskfile.yml or 'makefile' for a Makefile",
+	)
+	_ = cfgManager.Viper.BindPFlag("task-runner", cmd.Flags().Lookup("task-runner"))
 	cmd.Flags().String(
 		"multi-cluster",
 		"",
@@ -104,6 +131,28 @@ func bindInitLocalFlags(cmd *cobra.Command, cfgManager *ksailconfigmanager.Confi
 			"kustomizationFile at the environment overlay",
 	)
 	_ = cfgManager.Viper.BindPFlag("multi-cluster", cmd.Flags().Lookup("multi-cluster"))
+	cmd.Flags().String(
+		"layout",
+		string(scaffolder.LayoutFlat),
+		"Source directory layout: 'flat' for a single kustomization, 'overlays' for a "+
+			"kustomize base/ plus overlays/dev|staging|production/ selected with --env by "+
+			"'ksail workload apply' and 'ksail workload push', 'helm' for a chart with "+
+			"per-environment values installed by a Flux HelmRelease or Argo CD Application "+
+			"(requires --gitops-engine and --push-to), or 'monorepo' for apps/<name> with "+
+			"per-app namespaces and RBAC, infrastructure/ and clusters/<cluster>",
+	)
+	_ = cfgManager.Viper.BindPFlag("layout", cmd.Flags().Lookup("layout"))
+	cmd.Flags().Bool(
+		"schema",
+		false,
+		"Add yaml-language-server schema headers to the scaffolded distribution configs "+
+			"(kind.yaml, k3d.yaml, Talos patches) for editor completion and validation; "+
+			"ksail.yaml always has one",
+	)
+	_ = cfgManager.Viper.BindPFlag("schema", cmd.Flags().Lookup("schema"))
+
+	bindInitPushFlags(cmd, cfgManager)
+	bindInitRecommendFlags(cmd, cfgManager)
 
 	clusterflags.RegisterMirrorRegistryFlag(cmd)
 	clusterflags.RegisterNameFlag(cmd, cfgManager)
@@ -114,6 +163,11 @@ func bindInitLocalFlags(cmd *cobra.Command, cfgManager *ksailconfigmanager.Confi
 // InitDeps holds dependencies injected into HandleInitRunE.
 type InitDeps struct {
 	Timer timer.Timer
+	// NewGitProvider creates the Git provider used by --push-to. Defaults to gitprovider.New.
+	NewGitProvider GitProviderFactory
+	// ProbeHost inspects the host for --recommend. Defaults to probing the live host
+	// and container engine.
+	ProbeHost HostProber
 }
 
 // validateInitConfig validates the cluster configuration for the init command.
@@ -156,6 +210,35 @@ func validatePostFlagInitConfig(clusterCfg *v1alpha1.Cluster) error {
 		return fmt.Errorf("allowed CIDRs configuration: %w", err)
 	}
 
+	err = v1alpha1.ValidateAllowedCIDRs(clusterCfg.Spec.Provider.Hetzner.NodePortCIDRs)
+	if err != nil {
+		return fmt.Errorf("NodePort CIDRs configuration: %w", err)
+	}
+
+	return nil
+}
+
+// applyIdentityProviderDefaults points the OIDC options at the development
+// identity provider before scaffolding, so the distribution config configures
+// the API server to trust it. Keycloak serves a certificate signed by the KSail
+// CA, which is generated here when missing so its path can be mounted.
+func applyIdentityProviderDefaults(clusterCfg *v1alpha1.Cluster) error {
+	if clusterCfg.Spec.Cluster.IdentityProvider != v1alpha1.IdentityProviderKeycloak {
+		return nil
+	}
+
+	err := v1alpha1.ValidateIdentityProvider(&clusterCfg.Spec.Cluster)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	authority, err := registry.LoadDefaultTLSAuthority()
+	if err != nil {
+		return fmt.Errorf("failed to load the KSail CA for Keycloak: %w", err)
+	}
+
+	v1alpha1.ApplyIdentityProviderOIDCDefaults(&clusterCfg.Spec.Cluster, authority.CACertPath())
+
 	return nil
 }
 
@@ -169,6 +252,10 @@ func HandleInitRunE(
 		deps.Timer.Start()
 	}
 
+	if cfgManager.Viper.GetBool("recommend") {
+		return handleInitRecommend(cmd, cfgManager, deps.ProbeHost)
+	}
+
 	clusterCfg, err := cfgManager.Load(
 		configmanager.LoadOptions{Silent: true, IgnoreConfigFile: true},
 	)
@@ -183,11 +270,21 @@ func HandleInitRunE(
 
 	clusterflags.ApplyClusterMutationFlags(cmd, clusterCfg)
 
+	err = applyIdentityProviderDefaults(clusterCfg)
+	if err != nil {
+		return err
+	}
+
 	err = validatePostFlagInitConfig(clusterCfg)
 	if err != nil {
 		return err
 	}
 
+	pushTarget, err := prepareInitPush(cmd.Context(), cmd, cfgManager, clusterCfg, deps.NewGitProvider)
+	if err != nil {
+		return err
+	}
+
 	scaffolderInstance, targetPath, force, err := prepareScaffolder(cmd, cfgManager, clusterCfg)
 	if err != nil {
 		return err
@@ -204,11 +301,25 @@ func HandleInitRunE(
 		Writer:  cmd.OutOrStdout(),
 	})
 
+	if pushTarget != nil {
+		err = pushTarget.snapshot(targetPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = scaffolderInstance.Scaffold(targetPath, force)
 	if err != nil {
 		return fmt.Errorf("failed to scaffold project files: %w", err)
 	}
 
+	if pushTarget != nil {
+		err = pushTarget.push(cmd.Context(), cmd, targetPath)
+		if err != nil {
+			return err
+		}
+	}
+
 	notify.WriteMessage(notify.Message{
 		Type:    notify.SuccessType,
 		Content: "initialized project",
@@ -245,6 +356,16 @@ func prepareScaffolder(
 		return nil, "", false, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	taskRunner, err := scaffolder.ParseTaskRunner(cfgManager.Viper.GetString("task-runner"))
+	if err != nil {
+		return nil, "", false, fmt.Errorf("invalid --task-runner flag: %w", err)
+	}
+
+	layout, err := scaffolder.ParseLayout(cfgManager.Viper.GetString("layout"))
+	if err != nil {
+		return nil, "", false, fmt.Errorf("invalid --layout flag: %w", err)
+	}
+
 	// Validate cluster name is DNS-1123 compliant
 	if clusterName != "" {
 		validationErr := v1alpha1.ValidateClusterName(clusterName)
@@ -267,6 +388,20 @@ func prepareScaffolder(
 	// Dev Container scaffolding is on by default; --no-devcontainer opts out.
 	scaffolderInstance.WithDevcontainer(!cfgManager.Viper.GetBool("no-devcontainer"))
 
+	// The pre-commit hook is opt-in via --pre-commit.
+	scaffolderInstance.WithPreCommit(cfgManager.Viper.GetBool("pre-commit"))
+
+	// Distribution config schema headers are opt-in via --schema.
+	scaffolderInstance.WithSchema(cfgManager.Viper.GetBool("schema"))
+
+	// Task runner entrypoints are opt-in via --task-runner.
+	scaffolderInstance.WithTaskRunner(taskRunner)
+
+	// The source layout defaults to a single flat kustomization; the other layouts
+	// conflict with --multi-cluster, and helm needs a GitOps engine and a Git
+	// source, which Scaffold checks before writing.
+	scaffolderInstance.WithLayout(layout)
+
 	// Multi-cluster layout is opt-in; the environment name is validated when the
 	// layout is derived, before any file is written.
 	multiClusterEnv := strings.TrimSpace(cfgManager.Viper.GetString("multi-cluster"))
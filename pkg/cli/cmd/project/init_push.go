@@ -0,0 +1,313 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+	"github.com/spf13/cobra"
+)
+
+// initPushCommitMessage is the commit message used for the scaffolded content.
+const initPushCommitMessage = "feat: initial ksail project scaffold"
+
+// GitProviderFactory creates a Git provider client for a provider name and API token.
+type GitProviderFactory func(providerName, token string) (gitprovider.Provider, error)
+
+// initPushTarget is the remote repository `ksail init --push-to` publishes the project to.
+type initPushTarget struct {
+	ref          gitprovider.RepoRef
+	provider     gitprovider.Provider
+	visibility   gitprovider.RepoVisibility
+	branch       string
+	exists       bool
+	pushExisting bool
+	// before stamps the files under the project directory before scaffolding, so push
+	// only publishes what the scaffolder wrote.
+	before map[string]fileStamp
+}
+
+// fileStamp identifies the version of a file on disk.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// bindInitPushFlags adds the flags that publish the scaffolded project to a new remote repository.
+func bindInitPushFlags(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager) {
+	cmd.Flags().String(
+		"push-to",
+		"",
+		"Create the repository (e.g. github.com/org/repo), push the scaffolded project to it, "+
+			"and configure the GitOps engine to track it",
+	)
+	_ = cfgManager.Viper.BindPFlag("push-to", cmd.Flags().Lookup("push-to"))
+	cmd.Flags().String(
+		"repo-visibility",
+		string(gitprovider.VisibilityPrivate),
+		"Visibility of the repository created by --push-to: Private, Internal, or Public",
+	)
+	_ = cfgManager.Viper.BindPFlag("repo-visibility", cmd.Flags().Lookup("repo-visibility"))
+	cmd.Flags().String(
+		"git-token",
+		"",
+		"API token used by --push-to (defaults to the provider's token, e.g. GITHUB_TOKEN or gh auth)",
+	)
+	_ = cfgManager.Viper.BindPFlag("git-token", cmd.Flags().Lookup("git-token"))
+	cmd.Flags().Bool(
+		"push-existing",
+		false,
+		"Let --push-to commit the scaffolded files on top of a repository that already exists",
+	)
+	_ = cfgManager.Viper.BindPFlag("push-existing", cmd.Flags().Lookup("push-existing"))
+}
+
+// prepareInitPush validates --push-to and records the repository as the GitOps source in
+// clusterCfg, so the scaffolded ksail.yaml tracks it. It returns nil when --push-to is unset.
+// Nothing is created yet, so a missing token or an unusable repository aborts before any file
+// is written; push creates the repository once scaffolding succeeded. A repository that already
+// exists is refused unless --push-existing is set.
+func prepareInitPush(
+	ctx context.Context,
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	clusterCfg *v1alpha1.Cluster,
+	newProvider GitProviderFactory,
+) (*initPushTarget, error) {
+	pushTo := strings.TrimSpace(cfgManager.Viper.GetString("push-to"))
+	if pushTo == "" {
+		return nil, nil //nolint:nilnil // nil target signals --push-to is unset
+	}
+
+	ref, err := gitprovider.ParseRepoRef(pushTo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --push-to: %w", err)
+	}
+
+	visibility, err := gitprovider.ParseVisibility(
+		strings.TrimSpace(cfgManager.Viper.GetString("repo-visibility")),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --repo-visibility: %w", err)
+	}
+
+	token := gitprovider.ResolveToken(ref.Provider, cfgManager.Viper.GetString("git-token"))
+	if token == "" {
+		return nil, fmt.Errorf(
+			"--push-to %s: %w (set --git-token or %s)",
+			ref, gitprovider.ErrTokenRequired, gitprovider.TokenEnvVar(ref.Provider),
+		)
+	}
+
+	if newProvider == nil {
+		newProvider = gitprovider.New
+	}
+
+	provider, err := newProvider(ref.Provider, token)
+	if err != nil {
+		return nil, fmt.Errorf("creating git provider: %w", err)
+	}
+
+	target := &initPushTarget{
+		ref:          ref,
+		provider:     provider,
+		visibility:   visibility,
+		branch:       v1alpha1.DefaultGitSourceBranch,
+		pushExisting: cfgManager.Viper.GetBool("push-existing"),
+	}
+
+	branch, err := provider.GetDefaultBranch(ctx, ref.Owner, ref.Name)
+
+	switch {
+	case errors.Is(err, gitprovider.ErrRepoNotFound):
+		// push creates the repository, which starts on the branch ksail.yaml tracks.
+	case err != nil:
+		return nil, fmt.Errorf("resolving default branch of %s: %w", ref, err)
+	case !target.pushExisting:
+		return nil, fmt.Errorf(
+			"--push-to %s: %w (pass --push-existing to commit the scaffolded files on top of it)",
+			ref, gitprovider.ErrRepoAlreadyExists,
+		)
+	default:
+		notify.Warningf(
+			cmd.OutOrStdout(),
+			"repository %s already exists, pushing scaffolded files to it", ref,
+		)
+
+		target.exists = true
+
+		if branch != "" {
+			target.branch = branch
+		}
+	}
+
+	clusterCfg.Spec.Workload.Git = buildInitGitSource(ref, target.branch, visibility)
+
+	engine := clusterCfg.Spec.Cluster.GitOpsEngine
+	if engine != v1alpha1.GitOpsEngineFlux && engine != v1alpha1.GitOpsEngineArgoCD {
+		notify.Warningf(
+			cmd.OutOrStdout(),
+			"no GitOps engine is configured; set --gitops-engine for the cluster to track %s", ref,
+		)
+	}
+
+	return target, nil
+}
+
+// buildInitGitSource returns the spec.workload.git source tracking the pushed repository.
+// Non-public repositories reference the provider's token variable so the GitOps engine can
+// clone them; the branch is omitted when it matches the default.
+func buildInitGitSource(
+	ref gitprovider.RepoRef,
+	branch string,
+	visibility gitprovider.RepoVisibility,
+) v1alpha1.GitSource {
+	source := v1alpha1.GitSource{URL: ref.CloneURL()}
+
+	if branch != "" && branch != v1alpha1.DefaultGitSourceBranch {
+		source.Branch = branch
+	}
+
+	if visibility != gitprovider.VisibilityPublic {
+		source.TokenEnvVar = gitprovider.TokenEnvVar(ref.Provider)
+	}
+
+	return source
+}
+
+// snapshot stamps the files under the project directory before scaffolding.
+func (t *initPushTarget) snapshot(targetPath string) error {
+	before := map[string]fileStamp{}
+
+	err := walkProjectFiles(targetPath, func(rel, _ string, info fs.FileInfo) error {
+		before[rel] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	t.before = before
+
+	return nil
+}
+
+// push creates the target repository unless it already exists and publishes the files the
+// scaffolder wrote to the tracked branch.
+func (t *initPushTarget) push(ctx context.Context, cmd *cobra.Command, targetPath string) error {
+	files, err := t.collectScaffoldedFiles(targetPath)
+	if err != nil {
+		return err
+	}
+
+	if !t.exists {
+		err = t.provider.CreateRepo(ctx, t.ref.Owner, t.ref.Name, t.visibility)
+
+		switch {
+		case err == nil:
+		case errors.Is(err, gitprovider.ErrRepoAlreadyExists) && t.pushExisting:
+			notify.Warningf(
+				cmd.OutOrStdout(),
+				"repository %s already exists, pushing scaffolded files to it", t.ref,
+			)
+		case errors.Is(err, gitprovider.ErrRepoAlreadyExists):
+			return fmt.Errorf(
+				"creating repository %s: %w (pass --push-existing to commit the scaffolded files on top of it)",
+				t.ref, err,
+			)
+		default:
+			return fmt.Errorf("creating repository %s: %w", t.ref, err)
+		}
+	}
+
+	err = t.provider.PushFilesToBranch(
+		ctx, t.ref.Owner, t.ref.Name, t.branch, files, initPushCommitMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("pushing scaffolded files to %s: %w", t.ref, err)
+	}
+
+	notify.Successf(cmd.OutOrStdout(), "pushed %d files to %s", len(files), t.ref)
+
+	return nil
+}
+
+// collectScaffoldedFiles reads the files under root that are new or changed since snapshot,
+// keyed by their slash-separated relative path.
+func (t *initPushTarget) collectScaffoldedFiles(root string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	err := walkProjectFiles(root, func(rel, path string, info fs.FileInfo) error {
+		stamp, ok := t.before[rel]
+		if ok && stamp.modTime.Equal(info.ModTime()) && stamp.size == info.Size() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path) //nolint:gosec // path comes from walking the project dir
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+
+		files[rel] = content
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// walkProjectFiles calls visit for every regular file under root with its slash-separated
+// relative path, skipping the .git directory. A missing root has no files.
+func walkProjectFiles(root string, visit func(rel, path string, info fs.FileInfo) error) error {
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if path == root && errors.Is(walkErr, fs.ErrNotExist) {
+				return filepath.SkipAll
+			}
+
+			return walkErr
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("resolve relative path of %s: %w", path, err)
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+
+		return visit(filepath.ToSlash(rel), path, info)
+	})
+	if err != nil {
+		return fmt.Errorf("collecting project files: %w", err)
+	}
+
+	return nil
+}
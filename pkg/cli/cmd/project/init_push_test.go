@@ -0,0 +1,216 @@
+package project_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/project"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+	"github.com/devantler-tech/ksail/v7/pkg/timer"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitProvider records the calls made by init --push-to. Methods init does
+// not use are left to the embedded (nil) interface.
+type fakeGitProvider struct {
+	gitprovider.Provider
+
+	// dir is the project directory, checked for ksail.yaml when the repository is created.
+	dir           string
+	exists        bool
+	createErr     error
+	defaultBranch string
+	created       []string
+	scaffolded    bool
+	visibility    gitprovider.RepoVisibility
+	branch        string
+	pushed        map[string][]byte
+}
+
+func (f *fakeGitProvider) CreateRepo(
+	_ context.Context,
+	owner, name string,
+	visibility gitprovider.RepoVisibility,
+) error {
+	f.created = append(f.created, owner+"/"+name)
+	f.visibility = visibility
+
+	_, err := os.Stat(filepath.Join(f.dir, "ksail.yaml"))
+	f.scaffolded = err == nil
+
+	return f.createErr
+}
+
+func (f *fakeGitProvider) GetDefaultBranch(context.Context, string, string) (string, error) {
+	if !f.exists {
+		return "", gitprovider.ErrRepoNotFound
+	}
+
+	return f.defaultBranch, nil
+}
+
+func (f *fakeGitProvider) PushFilesToBranch(
+	_ context.Context,
+	_, _, branch string,
+	files map[string][]byte,
+	_ string,
+) error {
+	f.branch = branch
+	f.pushed = files
+
+	return nil
+}
+
+func setupInitPushTest(
+	t *testing.T,
+	outDir string,
+	values map[string]string,
+) (*cobra.Command, *ksailconfigmanager.ConfigManager) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	cmd, cfgManager := setupInitTest(t, outDir, false, &buffer)
+
+	for _, name := range []string{"push-to", "repo-visibility", "git-token"} {
+		cmd.Flags().String(name, "", "")
+		_ = cfgManager.Viper.BindPFlag(name, cmd.Flags().Lookup(name))
+	}
+
+	cmd.Flags().Bool("push-existing", false, "")
+	_ = cfgManager.Viper.BindPFlag("push-existing", cmd.Flags().Lookup("push-existing"))
+
+	setFlags(t, cmd, values)
+
+	return cmd, cfgManager
+}
+
+func TestHandleInitRunE_PushToCreatesRepoAndTracksIt(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	cmd, cfgManager := setupInitPushTest(t, outDir, map[string]string{
+		"gitops-engine":   "Flux",
+		"push-to":         "github.com/org/platform",
+		"repo-visibility": "Private",
+		"git-token":       "token",
+	})
+
+	err := os.WriteFile(filepath.Join(outDir, "notes.txt"), []byte("local notes"), 0o600)
+	require.NoError(t, err)
+
+	provider := &fakeGitProvider{dir: outDir}
+	deps := newInitDeps(t)
+	deps.NewGitProvider = func(providerName, token string) (gitprovider.Provider, error) {
+		assert.Equal(t, "github", providerName)
+		assert.Equal(t, "token", token)
+
+		return provider, nil
+	}
+
+	err = project.HandleInitRunE(cmd, cfgManager, deps)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"org/platform"}, provider.created)
+	assert.True(t, provider.scaffolded, "the repository is created after scaffolding")
+	assert.Equal(t, gitprovider.VisibilityPrivate, provider.visibility)
+
+	ksailContent, err := os.ReadFile(filepath.Join(outDir, "ksail.yaml")) //nolint:gosec // t.TempDir()
+	require.NoError(t, err)
+	assert.Contains(t, string(ksailContent), "url: https://github.com/org/platform.git")
+	assert.NotContains(t, string(ksailContent), "branch:")
+	assert.Contains(t, string(ksailContent), "tokenEnvVar: GITHUB_TOKEN")
+
+	assert.Equal(t, "main", provider.branch)
+	require.Contains(t, provider.pushed, "ksail.yaml")
+	assert.Equal(t, ksailContent, provider.pushed["ksail.yaml"])
+	assert.Contains(t, provider.pushed, "k8s/kustomization.yaml")
+	assert.NotContains(t, provider.pushed, "notes.txt", "only scaffolded files are pushed")
+}
+
+func TestHandleInitRunE_PushToRefusesExistingRepo(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	cmd, cfgManager := setupInitPushTest(t, outDir, map[string]string{
+		"push-to":         "github.com/org/platform",
+		"repo-visibility": "Private",
+		"git-token":       "token",
+	})
+
+	provider := &fakeGitProvider{dir: outDir, exists: true, defaultBranch: "main"}
+
+	tmr := timer.NewMockTimer(t)
+	tmr.EXPECT().Start().Return()
+
+	err := project.HandleInitRunE(cmd, cfgManager, project.InitDeps{
+		Timer: tmr,
+		NewGitProvider: func(string, string) (gitprovider.Provider, error) {
+			return provider, nil
+		},
+	})
+	require.ErrorIs(t, err, gitprovider.ErrRepoAlreadyExists)
+	require.ErrorContains(t, err, "--push-existing")
+
+	assert.Empty(t, provider.created)
+	assert.Nil(t, provider.pushed)
+
+	_, statErr := os.Stat(filepath.Join(outDir, "ksail.yaml"))
+	require.True(t, os.IsNotExist(statErr))
+}
+
+func TestHandleInitRunE_PushExistingCommitsOnTopOfRepo(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	cmd, cfgManager := setupInitPushTest(t, outDir, map[string]string{
+		"push-to":         "github.com/org/platform",
+		"repo-visibility": "Public",
+		"git-token":       "token",
+		"push-existing":   "true",
+	})
+
+	provider := &fakeGitProvider{dir: outDir, exists: true, defaultBranch: "trunk"}
+	deps := newInitDeps(t)
+	deps.NewGitProvider = func(string, string) (gitprovider.Provider, error) {
+		return provider, nil
+	}
+
+	err := project.HandleInitRunE(cmd, cfgManager, deps)
+	require.NoError(t, err)
+	assert.Empty(t, provider.created)
+	assert.Equal(t, "trunk", provider.branch)
+	require.NotEmpty(t, provider.pushed)
+
+	ksailContent, err := os.ReadFile(filepath.Join(outDir, "ksail.yaml")) //nolint:gosec // t.TempDir()
+	require.NoError(t, err)
+	assert.Contains(t, string(ksailContent), "branch: trunk")
+	assert.NotContains(t, string(ksailContent), "tokenEnvVar:")
+}
+
+func TestHandleInitRunE_PushToRejectsUnsupportedHost(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	cmd, cfgManager := setupInitPushTest(t, outDir, map[string]string{
+		"push-to":         "git.example.com/org/platform",
+		"repo-visibility": "Private",
+		"git-token":       "token",
+	})
+
+	tmr := timer.NewMockTimer(t)
+	tmr.EXPECT().Start().Return()
+
+	err := project.HandleInitRunE(cmd, cfgManager, project.InitDeps{Timer: tmr})
+	require.ErrorIs(t, err, gitprovider.ErrUnsupportedProvider)
+
+	// Fail-fast: nothing was scaffolded.
+	_, statErr := os.Stat(filepath.Join(outDir, "ksail.yaml"))
+	require.True(t, os.IsNotExist(statErr))
+}
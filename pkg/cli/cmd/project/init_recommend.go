@@ -0,0 +1,124 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/recommend"
+	"github.com/spf13/cobra"
+)
+
+// HostProber inspects the host for `ksail project init --recommend`.
+type HostProber func(ctx context.Context) recommend.HostInfo
+
+// bindInitRecommendFlags adds the flag that prints a recommended configuration instead of
+// scaffolding.
+func bindInitRecommendFlags(cmd *cobra.Command, cfgManager *ksailconfigmanager.ConfigManager) {
+	cmd.Flags().Bool(
+		"recommend",
+		false,
+		"Inspect the host and the project's manifests and print a recommended distribution, "+
+			"node topology, and components with reasons instead of scaffolding",
+	)
+	_ = cfgManager.Viper.BindPFlag("recommend", cmd.Flags().Lookup("recommend"))
+}
+
+// probeLiveHost inspects the live host, asking the container engine for the resources
+// it can give cluster nodes. An unreachable engine is not an error: the recommendation
+// falls back to the host's own resources and says so.
+func probeLiveHost(ctx context.Context) recommend.HostInfo {
+	engine, err := dockerclient.GetConcreteDockerClient()
+	if err != nil {
+		return recommend.ProbeHost(ctx, nil)
+	}
+
+	defer func() { _ = engine.Close() }()
+
+	return recommend.ProbeHost(ctx, engine)
+}
+
+// handleInitRecommend prints the recommended init configuration for the host and the
+// manifests under the target directory. No files are written.
+func handleInitRecommend(
+	cmd *cobra.Command,
+	cfgManager *ksailconfigmanager.ConfigManager,
+	probe HostProber,
+) error {
+	targetPath, err := resolveInitTargetPath(cfgManager)
+	if err != nil {
+		return err
+	}
+
+	if probe == nil {
+		probe = probeLiveHost
+	}
+
+	host := probe(cmd.Context())
+
+	repo, err := recommend.ScanRepo(targetPath)
+	if err != nil {
+		return fmt.Errorf("inspecting project manifests: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Recommend project configuration...",
+		Emoji:   "🧭",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	writeRecommendation(cmd.OutOrStdout(), host, repo, recommend.Recommend(host, repo))
+
+	return nil
+}
+
+// writeRecommendation renders the inspected facts, the recommendation with its reasons,
+// and the init command that applies it.
+func writeRecommendation(
+	writer io.Writer,
+	host recommend.HostInfo,
+	repo recommend.RepoInfo,
+	rec recommend.Recommendation,
+) {
+	engine := host.ContainerEngine
+	if engine == "" {
+		engine = "no container engine"
+	}
+
+	_, _ = fmt.Fprintf(writer, "Host: %s/%s, %d CPUs, %.1f GiB memory, %s\n",
+		host.OS, host.Arch, host.CPUs, float64(host.MemoryBytes)/(1<<30), engine)
+	_, _ = fmt.Fprintf(writer,
+		"Manifests: %d resources, %d Helm charts, %d HelmReleases, %d kustomizations\n",
+		repo.Manifests, repo.HelmCharts, repo.HelmReleases, repo.Kustomizations)
+
+	_, _ = fmt.Fprintf(writer, "\nDistribution: %s (%d control-plane, %d worker node(s))\n",
+		rec.Distribution, rec.ControlPlanes, rec.Workers)
+
+	for _, reason := range rec.Reasons {
+		_, _ = fmt.Fprintf(writer, "  • %s\n", reason)
+	}
+
+	if len(rec.Components) > 0 {
+		_, _ = fmt.Fprintln(writer, "\nComponents:")
+
+		for _, component := range rec.Components {
+			_, _ = fmt.Fprintf(writer, "  --%s %s: %s\n", component.Flag, component.Value, component.Reason)
+		}
+	}
+
+	if len(rec.Notes) > 0 {
+		_, _ = fmt.Fprintln(writer, "\nNotes:")
+
+		for _, note := range rec.Notes {
+			_, _ = fmt.Fprintf(writer, "  • %s\n", note)
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nTo scaffold it, run:\n  ksail project init %s\n",
+		strings.Join(rec.Args(), " "))
+}
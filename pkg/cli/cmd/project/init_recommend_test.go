@@ -0,0 +1,57 @@
+package project_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/project"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/recommend"
+	"github.com/devantler-tech/ksail/v7/pkg/timer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleInitRunE_RecommendPrintsSuggestionWithoutScaffolding(t *testing.T) {
+	t.Parallel()
+
+	outDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, "certificate.yaml"), []byte(
+		"apiVersion: cert-manager.io/v1\nkind: Certificate\nmetadata:\n  name: web\n",
+	), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, "kustomization.yaml"), []byte(
+		"resources:\n  - certificate.yaml\n",
+	), 0o600))
+
+	var buffer bytes.Buffer
+
+	cmd, cfgManager := setupInitTest(t, outDir, false, &buffer)
+	cmd.Flags().Bool("recommend", false, "")
+	_ = cfgManager.Viper.BindPFlag("recommend", cmd.Flags().Lookup("recommend"))
+	setFlags(t, cmd, map[string]string{"recommend": "true"})
+
+	tmr := timer.NewMockTimer(t)
+	tmr.EXPECT().Start().Return()
+
+	deps := project.InitDeps{
+		Timer: tmr,
+		ProbeHost: func(context.Context) recommend.HostInfo {
+			return recommend.HostInfo{
+				OS: "linux", Arch: "amd64", CPUs: 4, MemoryBytes: 8 << 30,
+				ContainerEngine: recommend.EngineDocker,
+			}
+		},
+	}
+
+	require.NoError(t, project.HandleInitRunE(cmd, cfgManager, deps))
+
+	output := buffer.String()
+	assert.Contains(t, output, "Distribution: Vanilla")
+	assert.Contains(t, output, "--cert-manager Enabled")
+	assert.Contains(t, output,
+		"ksail project init --distribution Vanilla --control-planes 1 --workers 0 "+
+			"--gitops-engine Flux --cert-manager Enabled")
+	assert.NoFileExists(t, filepath.Join(outDir, "ksail.yaml"))
+}
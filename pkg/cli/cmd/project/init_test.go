@@ -9,11 +9,13 @@ import (
 	"strings"
 	"testing"
 
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/project"
 	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	"github.com/devantler-tech/ksail/v7/pkg/timer"
 	"github.com/gkampitakis/go-snaps/snaps"
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -335,6 +337,38 @@ func TestHandleInitRunE_RespectsCertManagerFlag(t *testing.T) {
 	}
 }
 
+//nolint:paralleltest // Sets HOME so the KSail CA is generated in a temporary directory.
+func TestHandleInitRunE_IdentityProviderConfiguresOIDC(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	outDir := t.TempDir()
+
+	cmd := newInitCommand(t)
+	cfgManager := newConfigManager(t, cmd, io.Discard)
+
+	setFlags(t, cmd, map[string]string{
+		"output":            outDir,
+		"force":             "true",
+		"identity-provider": "Keycloak",
+	})
+
+	err := project.HandleInitRunE(cmd, cfgManager, newInitDeps(t))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(outDir, "ksail.yaml")) //nolint:gosec // test file path is safe
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "identityProvider: Keycloak")
+	assert.Contains(t, string(content), "issuerURL: "+v1alpha1.KeycloakIssuerURL)
+	assert.Contains(t, string(content), "clientID: kubernetes")
+
+	kindConfig, err := os.ReadFile(filepath.Join(outDir, "kind.yaml")) //nolint:gosec // test file path is safe
+	require.NoError(t, err)
+	assert.Contains(t, string(kindConfig), v1alpha1.KeycloakIssuerURL)
+	assert.Contains(t, string(kindConfig), v1alpha1.OIDCCAContainerPath)
+	assert.FileExists(t, filepath.Join(home, ".ksail", "registry-tls", "ca.crt"))
+}
+
 func TestHandleInitRunE_IgnoresExistingConfigFile(t *testing.T) {
 	t.Parallel()
 
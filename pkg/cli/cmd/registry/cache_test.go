@@ -0,0 +1,176 @@
+package registry_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	registrypkg "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/registry"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCacheBackend struct {
+	caches  []dockerclient.RegistryCache
+	blobs   []dockerclient.RegistryBlob
+	deleted []string
+}
+
+func (s *stubCacheBackend) ListRegistryCaches(
+	_ context.Context,
+) ([]dockerclient.RegistryCache, error) {
+	return s.caches, nil
+}
+
+func (s *stubCacheBackend) ListRegistryBlobs(
+	_ context.Context,
+	_ string,
+) ([]dockerclient.RegistryBlob, error) {
+	return s.blobs, nil
+}
+
+func (s *stubCacheBackend) DeleteRegistryBlobs(
+	_ context.Context,
+	_ string,
+	digests []string,
+) error {
+	s.deleted = append(s.deleted, digests...)
+
+	return nil
+}
+
+func (s *stubCacheBackend) GarbageCollectRegistry(_ context.Context, _ string, _ bool) error {
+	return nil
+}
+
+func executeCacheCmd(t *testing.T, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // mutates the shared cache backend seam
+func TestStatsCmd_ListsRegistries(t *testing.T) {
+	defer registrypkg.SetCacheBackendForTests(&stubCacheBackend{
+		caches: []dockerclient.RegistryCache{
+			{Name: "local-registry", Running: true, Size: 2048},
+			{Name: "docker.io", Upstream: "https://registry-1.docker.io", Running: true, Size: 3 << 30},
+			{Name: "ghcr.io", Upstream: "https://ghcr.io"},
+		},
+	})()
+
+	output, err := executeCacheCmd(t, registrypkg.NewStatsCmd())
+	require.NoError(t, err)
+
+	assert.Equal(t, `NAME            UPSTREAM                      STATUS   SIZE
+docker.io       https://registry-1.docker.io  running  3 GiB
+ghcr.io         https://ghcr.io               stopped  -
+local-registry  (local)                       running  2 KiB
+
+Total: 3 GiB
+`, output)
+}
+
+//nolint:paralleltest // mutates the shared cache backend seam
+func TestPruneCmd_EvictsDownToMaxSize(t *testing.T) {
+	backend := &stubCacheBackend{
+		caches: []dockerclient.RegistryCache{
+			{Name: "docker.io", Upstream: "https://registry-1.docker.io", Running: true, Size: 2 << 20},
+		},
+		blobs: []dockerclient.RegistryBlob{
+			{Digest: "sha256:old", Size: 1 << 20, LastUsed: time.Unix(100, 0)},
+			{Digest: "sha256:new", Size: 1 << 20, LastUsed: time.Unix(200, 0)},
+		},
+	}
+	defer registrypkg.SetCacheBackendForTests(backend)()
+
+	output, err := executeCacheCmd(t, registrypkg.NewPruneCmd(), "--max-size", "1Mi")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"sha256:old"}, backend.deleted)
+	assert.Contains(t, output, "evicted 1 blobs")
+	assert.Contains(t, output, "pruned 1 mirror registries")
+}
+
+func TestPruneCmd_RejectsInvalidMaxSize(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"lots", "0", "-1Gi"} {
+		_, err := executeCacheCmd(t, registrypkg.NewPruneCmd(), "--max-size", value)
+		require.ErrorIs(t, err, registrypkg.ErrInvalidMaxSize, value)
+	}
+}
+
+type stubArtifactBackend struct {
+	stubCacheBackend
+
+	tags        []dockerclient.RegistryTag
+	deletedTags []dockerclient.RegistryTag
+}
+
+func (s *stubArtifactBackend) ListRegistryTags(
+	_ context.Context,
+	_ string,
+) ([]dockerclient.RegistryTag, error) {
+	return s.tags, nil
+}
+
+func (s *stubArtifactBackend) DeleteRegistryTags(
+	_ context.Context,
+	_ string,
+	tags []dockerclient.RegistryTag,
+) error {
+	s.deletedTags = append(s.deletedTags, tags...)
+
+	return nil
+}
+
+func newStubArtifactBackend() *stubArtifactBackend {
+	return &stubArtifactBackend{
+		stubCacheBackend: stubCacheBackend{
+			caches: []dockerclient.RegistryCache{{Name: "local-registry", Running: true, Size: 2048}},
+		},
+		tags: []dockerclient.RegistryTag{
+			{Repository: "k8s", Tag: "v1", PushedAt: time.Unix(100, 0)},
+			{Repository: "k8s", Tag: "v2", PushedAt: time.Unix(200, 0)},
+			{Repository: "k8s", Tag: "v3", PushedAt: time.Unix(300, 0)},
+		},
+	}
+}
+
+//nolint:paralleltest // mutates the shared artifact backend seam
+func TestPruneArtifactsCmd_KeepsLastTags(t *testing.T) {
+	backend := newStubArtifactBackend()
+	defer registrypkg.SetArtifactBackendForTests(backend)()
+
+	output, err := executeCacheCmd(t, registrypkg.NewPruneArtifactsCmd(), "--keep-last", "2")
+	require.NoError(t, err)
+
+	require.Len(t, backend.deletedTags, 1)
+	assert.Equal(t, "v1", backend.deletedTags[0].Tag)
+	assert.Contains(t, output, "deleted 1 tags from 1 local registries")
+}
+
+//nolint:paralleltest // mutates the shared artifact backend seam
+func TestPruneArtifactsCmd_DryRunListsTags(t *testing.T) {
+	backend := newStubArtifactBackend()
+	defer registrypkg.SetArtifactBackendForTests(backend)()
+
+	output, err := executeCacheCmd(t, registrypkg.NewPruneArtifactsCmd(), "--keep-last", "1", "--dry-run")
+	require.NoError(t, err)
+
+	assert.Empty(t, backend.deletedTags)
+	assert.Contains(t, output, "would delete k8s:v1")
+	assert.Contains(t, output, "would delete k8s:v2")
+	assert.Contains(t, output, "would delete 2 tags from 1 local registries")
+}
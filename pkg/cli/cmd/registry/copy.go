@@ -0,0 +1,168 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/registryresolver"
+	"github.com/spf13/cobra"
+)
+
+// Static errors for the copy command.
+var (
+	// ErrCopyArgs is returned when neither a <src> <dst> pair nor --from-file is given.
+	ErrCopyArgs = errors.New("provide <src> <dst> or --from-file, but not both")
+	// ErrCopyFailed is returned when at least one copy in a bulk run fails.
+	ErrCopyFailed = errors.New("one or more copies failed")
+)
+
+const copyCmdLong = `Copy container images, multi-arch image indexes, and OCI artifacts between
+registries without pulling them through the local Docker daemon.
+
+References use the same form as --local-registry: [user:pass@]host[:port]/repo[:tag|@digest].
+Credentials may reference environment variables (${USER}:${TOKEN}@ghcr.io/org/app:1.0)
+and an oci:// prefix is accepted. References without inline credentials on the
+host of the cluster's local registry reuse its configured credentials; all other
+references fall back to the Docker credential helpers in ~/.docker/config.json.
+
+Use --from-file to copy many references at once. Each line holds a
+"<src> <dst>" pair; blank lines and lines starting with # are ignored. Pass "-"
+to read the list from stdin. Transient registry errors are retried.`
+
+const copyCmdExample = `  # Mirror an image into the cluster's local registry
+  ksail registry copy docker.io/library/nginx:1.27 localhost:5050/library/nginx:1.27
+
+  # Copy into a private registry with credentials from environment variables
+  ksail registry copy ghcr.io/org/app:1.0 '${REG_USER}:${REG_TOKEN}@registry.example.com/org/app:1.0'
+
+  # Mirror every pair listed in a file
+  ksail registry copy --from-file images.txt`
+
+// NewCopyCmd creates the registry copy command.
+func NewCopyCmd() *cobra.Command {
+	var fromFile string
+
+	cmd := &cobra.Command{
+		Use:          "copy [<src> <dst>]",
+		Short:        "Copy images and artifacts between registries",
+		Long:         copyCmdLong,
+		Example:      copyCmdExample,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+	}
+
+	cmd.Flags().StringVarP(&fromFile, "from-file", "f", "",
+		`File with one "<src> <dst>" pair per line ("-" reads stdin)`)
+
+	cfgManager := configmanager.NewCommandConfigManager(
+		cmd,
+		[]configmanager.FieldSelector[v1alpha1.Cluster]{
+			configmanager.DefaultLocalRegistryFieldSelector(),
+		},
+	)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		pairs, err := resolveCopyPairs(cmd, args, fromFile)
+		if err != nil {
+			return err
+		}
+
+		clusterCfg, err := cfgManager.Load(configmanagerinterface.LoadOptions{
+			Silent:         true,
+			SkipValidation: true,
+		})
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		return runCopy(cmd, pairs, registryresolver.CopyOptions{
+			LocalRegistry: clusterCfg.Spec.Cluster.LocalRegistry,
+		})
+	}
+
+	return cmd
+}
+
+// resolveCopyPairs returns the pairs to copy from either the positional
+// arguments or the --from-file list.
+func resolveCopyPairs(cmd *cobra.Command, args []string, fromFile string) ([]registryresolver.CopyPair, error) {
+	switch {
+	case fromFile == "" && len(args) == 2:
+		return []registryresolver.CopyPair{{Source: args[0], Destination: args[1]}}, nil
+	case fromFile != "" && len(args) == 0:
+		return readCopyList(cmd.InOrStdin(), fromFile)
+	default:
+		return nil, ErrCopyArgs
+	}
+}
+
+func readCopyList(stdin io.Reader, path string) ([]registryresolver.CopyPair, error) {
+	if path == "-" {
+		return registryresolver.ParseCopyList(stdin)
+	}
+
+	file, err := os.Open(path) //nolint:gosec // path is user-supplied by design
+	if err != nil {
+		return nil, fmt.Errorf("open copy list: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	pairs, err := registryresolver.ParseCopyList(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return pairs, nil
+}
+
+// runCopy copies each pair in turn, reporting every result and continuing past
+// failures so a bulk run mirrors as much as it can.
+func runCopy(cmd *cobra.Command, pairs []registryresolver.CopyPair, opts registryresolver.CopyOptions) error {
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Content: "Copy artifacts...",
+		Emoji:   "📦",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	failed := 0
+
+	for _, pair := range pairs {
+		result, err := registryresolver.CopyArtifact(cmd.Context(), pair.Source, pair.Destination, opts)
+		if err != nil {
+			failed++
+
+			notify.WriteMessage(notify.Message{
+				Type:    notify.ErrorType,
+				Content: "%v",
+				Args:    []any{err},
+				Writer:  cmd.ErrOrStderr(),
+			})
+
+			continue
+		}
+
+		notify.WriteMessage(notify.Message{
+			Type:    notify.SuccessType,
+			Content: "copied %s to %s (%s)",
+			Args:    []any{result.Source, result.Destination, result.Digest},
+			Writer:  cmd.OutOrStdout(),
+		})
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", ErrCopyFailed, failed, len(pairs))
+	}
+
+	return nil
+}
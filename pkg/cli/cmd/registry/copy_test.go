@@ -0,0 +1,137 @@
+package registry_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	registrypkg "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/registry"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+)
+
+// seedImage starts an in-memory registry and pushes a random image to it,
+// returning the registry host.
+func seedImage(t *testing.T, repo string) string {
+	t.Helper()
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	image, err := random.Image(256, 1)
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(host + "/" + repo)
+	require.NoError(t, err)
+
+	require.NoError(t, remote.Write(ref, image))
+
+	return host
+}
+
+// requireImage asserts that ref resolves in the registry.
+func requireImage(t *testing.T, ref string) {
+	t.Helper()
+
+	parsed, err := name.ParseReference(ref)
+	require.NoError(t, err)
+
+	_, err = remote.Head(parsed)
+	require.NoError(t, err, ref)
+}
+
+func executeCopy(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := registrypkg.NewCopyCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+func TestCopyCmd_HasWritePermission(t *testing.T) {
+	t.Parallel()
+
+	cmd := registrypkg.NewCopyCmd()
+	require.Equal(t, "write", cmd.Annotations[annotations.AnnotationPermission])
+}
+
+func TestCopyCmd_CopiesPair(t *testing.T) {
+	t.Parallel()
+
+	host := seedImage(t, "src/app:1.0")
+
+	output, err := executeCopy(t, "", host+"/src/app:1.0", host+"/dst/app:1.0")
+	require.NoError(t, err)
+	require.Contains(t, output, "copied")
+	require.Contains(t, output, "sha256:")
+
+	requireImage(t, host+"/dst/app:1.0")
+}
+
+func TestCopyCmd_CopiesListFromStdin(t *testing.T) {
+	t.Parallel()
+
+	host := seedImage(t, "src/app:1.0")
+
+	list := "# mirror list\n" +
+		host + "/src/app:1.0 " + host + "/mirror/one:1.0\n\n" +
+		host + "/src/app:1.0 " + host + "/mirror/two:1.0\n"
+
+	_, err := executeCopy(t, list, "--from-file", "-")
+	require.NoError(t, err)
+
+	requireImage(t, host+"/mirror/one:1.0")
+	requireImage(t, host+"/mirror/two:1.0")
+}
+
+func TestCopyCmd_ReportsFailedCopies(t *testing.T) {
+	t.Parallel()
+
+	host := seedImage(t, "src/app:1.0")
+
+	list := host + "/src/app:1.0 " + host + "/mirror/ok:1.0\n" +
+		host + "/src/missing:1.0 " + host + "/mirror/missing:1.0\n"
+
+	output, err := executeCopy(t, list, "-f", "-")
+	require.ErrorIs(t, err, registrypkg.ErrCopyFailed)
+	require.Contains(t, output, "1 of 2")
+
+	requireImage(t, host+"/mirror/ok:1.0")
+}
+
+func TestCopyCmd_RejectsInvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no arguments", args: nil},
+		{name: "single argument", args: []string{"ghcr.io/org/app:1.0"}},
+		{name: "pair and file", args: []string{"a:1", "b:1", "--from-file", "-"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := executeCopy(t, "", testCase.args...)
+			require.ErrorIs(t, err, registrypkg.ErrCopyArgs)
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package registry provides CLI commands for working with OCI registries.
+package registry
@@ -0,0 +1,34 @@
+package registry
+
+import (
+	registryprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/spf13/cobra"
+)
+
+// SetCacheBackendForTests replaces the Docker-backed registry cache backend and
+// returns a function restoring the original.
+func SetCacheBackendForTests(backend registryprovisioner.CacheBackend) func() {
+	original := withCacheBackend
+	withCacheBackend = func(
+		_ *cobra.Command,
+		operation func(registryprovisioner.CacheBackend) error,
+	) error {
+		return operation(backend)
+	}
+
+	return func() { withCacheBackend = original }
+}
+
+// SetArtifactBackendForTests replaces the Docker-backed registry artifact
+// backend and returns a function restoring the original.
+func SetArtifactBackendForTests(backend registryprovisioner.ArtifactBackend) func() {
+	original := withArtifactBackend
+	withArtifactBackend = func(
+		_ *cobra.Command,
+		operation func(registryprovisioner.ArtifactBackend) error,
+	) error {
+		return operation(backend)
+	}
+
+	return func() { withArtifactBackend = original }
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/strutil"
+	registryprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ErrInvalidMaxSize is returned when --max-size is not a positive quantity.
+var ErrInvalidMaxSize = errors.New("invalid --max-size")
+
+const pruneCmdLong = `Free disk space held by pull-through mirror registries.
+
+Each mirror is garbage-collected first, deleting blobs no cached manifest
+references. With --max-size, the least recently used blobs are then deleted
+until each mirror fits the limit. Evicted content is fetched from upstream again
+on the next pull, so pruning never breaks a cluster, but the next pull of an
+evicted image is slower.
+
+The local registry is never pruned: it holds artifacts that exist nowhere else.
+Only running mirrors are pruned. Avoid pruning while clusters pull images, as
+garbage collection may delete a blob that is being uploaded.`
+
+const pruneCmdExample = `  # Garbage-collect every running mirror
+  ksail registry prune
+
+  # Keep each mirror below 10 GiB
+  ksail registry prune --max-size 10Gi
+
+  # Prune one mirror, also dropping manifests no tag points to
+  ksail registry prune --name docker.io --delete-untagged`
+
+// NewPruneCmd creates the registry prune command.
+func NewPruneCmd() *cobra.Command {
+	var (
+		maxSize string
+		opts    registryprovisioner.PruneOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:          "prune",
+		Short:        "Shrink mirror registry caches",
+		Long:         pruneCmdLong,
+		Example:      pruneCmdExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			size, err := parseMaxSize(maxSize)
+			if err != nil {
+				return err
+			}
+
+			opts.MaxSize = size
+
+			return withCacheBackend(cmd, func(backend registryprovisioner.CacheBackend) error {
+				results, err := registryprovisioner.PruneCaches(cmd.Context(), backend, opts)
+				if err != nil {
+					return fmt.Errorf("prune mirror registries: %w", err)
+				}
+
+				writePruneResults(cmd.OutOrStdout(), results)
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&maxSize, "max-size", "",
+		"Largest size each mirror may keep, e.g. 10Gi or 500Mi (default: no limit)")
+	cmd.Flags().StringSliceVar(&opts.Names, "name", nil,
+		"Mirror registry container to prune (repeatable; default: all running mirrors)")
+	cmd.Flags().BoolVar(&opts.DeleteUntagged, "delete-untagged", false,
+		"Also delete manifests no tag points to, such as images pulled by digest")
+
+	return cmd
+}
+
+// parseMaxSize parses a Kubernetes quantity such as 10Gi into bytes. An empty
+// value disables the size limit.
+func parseMaxSize(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q: %w", ErrInvalidMaxSize, value, err)
+	}
+
+	if quantity.Sign() <= 0 {
+		return 0, fmt.Errorf("%w %q: must be positive", ErrInvalidMaxSize, value)
+	}
+
+	return quantity.Value(), nil
+}
+
+// writePruneResults reports the space freed per mirror and in total.
+func writePruneResults(writer io.Writer, results []registryprovisioner.PruneResult) {
+	if len(results) == 0 {
+		notify.Infof(writer, "no running mirror registries to prune")
+
+		return
+	}
+
+	var freed int64
+
+	for _, result := range results {
+		saved := max(result.SizeBefore-result.SizeAfter, 0)
+		freed += saved
+
+		notify.Infof(writer, "%s: %s → %s (freed %s, evicted %d blobs)",
+			result.Name,
+			strutil.FormatBytes(result.SizeBefore),
+			strutil.FormatBytes(result.SizeAfter),
+			strutil.FormatBytes(saved),
+			result.EvictedBlobs)
+	}
+
+	notify.Successf(writer, "pruned %d mirror registries, freed %s", len(results), strutil.FormatBytes(freed))
+}
@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/strutil"
+	registryprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/spf13/cobra"
+)
+
+const pruneArtifactsCmdLong = `Delete old artifact tags from local registries.
+
+Every 'ksail workload push' adds a tag to the local registry, so iterating on
+manifests slowly fills its volume. This command deletes, per repository, every
+tag but the most recently pushed --keep-last ones and every tag pushed longer
+ago than --max-age, then garbage-collects the registry so the manifests and
+blobs only those tags referenced are freed. The most recently pushed tag of each
+repository is always kept.
+
+Without --keep-last and --max-age, the retention configured in
+spec.cluster.localRegistry.retention of ksail.yaml is used. The same retention
+is applied to the pushed repository after every 'ksail workload push'.
+
+Only running local registries are pruned; mirror registries hold no pushed
+artifacts and are shrunk with 'ksail registry prune'.`
+
+const pruneArtifactsCmdExample = `  # Keep the 10 most recent tags of every repository
+  ksail registry prune-artifacts --keep-last 10
+
+  # Delete tags older than a week
+  ksail registry prune-artifacts --max-age 168h
+
+  # Preview what the retention in ksail.yaml would delete
+  ksail registry prune-artifacts --dry-run`
+
+// NewPruneArtifactsCmd creates the registry prune-artifacts command.
+func NewPruneArtifactsCmd() *cobra.Command {
+	var opts registryprovisioner.ArtifactPruneOptions
+
+	cmd := &cobra.Command{
+		Use:          "prune-artifacts",
+		Short:        "Delete old artifact tags from local registries",
+		Long:         pruneArtifactsCmdLong,
+		Example:      pruneArtifactsCmdExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: "write",
+		},
+	}
+
+	cfgManager := configmanager.NewCommandConfigManager(cmd, nil)
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		if !cmd.Flags().Changed("keep-last") && !cmd.Flags().Changed("max-age") {
+			clusterCfg, err := cfgManager.Load(
+				configmanagerinterface.LoadOptions{Silent: true, SkipValidation: true},
+			)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			opts.Policy = registryprovisioner.RetentionPolicyFromSpec(
+				clusterCfg.Spec.Cluster.LocalRegistry.Retention,
+			)
+		}
+
+		return withArtifactBackend(cmd, func(backend registryprovisioner.ArtifactBackend) error {
+			results, err := registryprovisioner.PruneArtifacts(cmd.Context(), backend, opts, time.Now())
+			if err != nil {
+				return fmt.Errorf("prune local registry artifacts: %w", err)
+			}
+
+			writeArtifactPruneResults(cmd.OutOrStdout(), results, opts.DryRun)
+
+			return nil
+		})
+	}
+
+	cmd.Flags().IntVar(&opts.Policy.KeepLast, "keep-last", 0,
+		"Number of most recently pushed tags kept per repository (default: from ksail.yaml)")
+	cmd.Flags().DurationVar(&opts.Policy.MaxAge, "max-age", 0,
+		"Delete tags pushed longer ago than this, e.g. 168h (default: from ksail.yaml)")
+	cmd.Flags().StringSliceVar(&opts.Names, "name", nil,
+		"Local registry container to prune (repeatable; default: all running local registries)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false,
+		"List the tags that would be deleted without deleting them")
+
+	return cmd
+}
+
+// writeArtifactPruneResults reports the tags deleted and space freed per local registry.
+func writeArtifactPruneResults(
+	writer io.Writer,
+	results []registryprovisioner.ArtifactPruneResult,
+	dryRun bool,
+) {
+	if len(results) == 0 {
+		notify.Infof(writer, "no running local registries to prune")
+
+		return
+	}
+
+	var (
+		deleted int
+		freed   int64
+	)
+
+	for _, result := range results {
+		deleted += len(result.DeletedTags)
+
+		if dryRun {
+			for _, tag := range result.DeletedTags {
+				notify.Infof(writer, "%s: would delete %s:%s (pushed %s)",
+					result.Name, tag.Repository, tag.Tag, tag.PushedAt.Format(time.DateTime))
+			}
+
+			continue
+		}
+
+		saved := max(result.SizeBefore-result.SizeAfter, 0)
+		freed += saved
+
+		notify.Infof(writer, "%s: deleted %d tags, %s → %s (freed %s)",
+			result.Name,
+			len(result.DeletedTags),
+			strutil.FormatBytes(result.SizeBefore),
+			strutil.FormatBytes(result.SizeAfter),
+			strutil.FormatBytes(saved))
+	}
+
+	if dryRun {
+		notify.Infof(writer, "would delete %d tags from %d local registries", deleted, len(results))
+
+		return
+	}
+
+	notify.Successf(writer, "deleted %d tags from %d local registries, freed %s",
+		deleted, len(results), strutil.FormatBytes(freed))
+}
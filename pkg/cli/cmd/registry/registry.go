@@ -0,0 +1,46 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/spf13/cobra"
+)
+
+// NewRegistryCmd creates the parent registry command and wires subcommands beneath it.
+func NewRegistryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Work with OCI registries",
+		Long: `Work with OCI registries, such as mirroring images and artifacts between ` +
+			`public registries and the cluster's local registry, and inspecting and pruning ` +
+			`the pull-through mirror caches and old local registry artifacts.`,
+		Args:         cobra.NoArgs,
+		RunE:         handleRegistryRunE,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationConsolidate: "registry_command",
+		},
+	}
+
+	cmd.AddCommand(NewCopyCmd())
+	cmd.AddCommand(NewPruneCmd())
+	cmd.AddCommand(NewPruneArtifactsCmd())
+	cmd.AddCommand(NewStatsCmd())
+
+	return cmd
+}
+
+//nolint:gochecknoglobals // Injected for testability to simulate help failures.
+var helpRunner = func(cmd *cobra.Command) error {
+	return cmd.Help()
+}
+
+func handleRegistryRunE(cmd *cobra.Command, _ []string) error {
+	err := helpRunner(cmd)
+	if err != nil {
+		return fmt.Errorf("displaying registry command help: %w", err)
+	}
+
+	return nil
+}
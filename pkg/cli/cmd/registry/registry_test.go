@@ -0,0 +1,57 @@
+package registry_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	snapshottest "github.com/devantler-tech/ksail/v7/internal/testutil/snapshottest"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	registrypkg "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/registry"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(snapshottest.Run(m, snaps.CleanOpts{Sort: true}))
+}
+
+func TestRegistryCmd_ShowsHelp(t *testing.T) {
+	t.Parallel()
+
+	cmd := registrypkg.NewRegistryCmd()
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Contains(t, output, "Work with OCI registries")
+	require.Contains(t, output, "copy")
+
+	snaps.MatchSnapshot(t, output)
+}
+
+func TestRegistryCmd_HasConsolidateAnnotation(t *testing.T) {
+	t.Parallel()
+
+	cmd := registrypkg.NewRegistryCmd()
+	require.Equal(t, "registry_command", cmd.Annotations[annotations.AnnotationConsolidate])
+}
+
+func TestRegistryCmd_RejectsArgs(t *testing.T) {
+	t.Parallel()
+
+	cmd := registrypkg.NewRegistryCmd()
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"unexpected-arg"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+}
@@ -2,18 +2,27 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	cachecmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cache"
 	cluster "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/cluster"
+	configcmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/config"
+	imagecmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/image"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/open"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/operator"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/project"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/registry"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/serve"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/steeragent"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/tenant"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/verify"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfighook"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/notifyhook"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/ui/asciiart"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/ui/errorhandler"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cache"
 	"github.com/spf13/cobra"
 )
 
@@ -43,19 +52,31 @@ func NewRootCmd(version, commit, date string) *cobra.Command {
 		"Path to config file (default: ksail.yaml found via directory traversal)",
 	)
 
+	cmd.PersistentFlags().String(
+		flags.ProfileFlagName,
+		"",
+		"Profile from the profiles section of ksail.yaml to overlay onto the spec (env: KSAIL_PROFILE)",
+	)
+
 	cmd.PersistentFlags().Bool(
 		flags.ExperimentalFlagName,
 		false,
 		"Enable experimental (unstable) commands and features",
 	)
 
-	// Transparently refresh expired Omni kubeconfig tokens before any command.
+	// Transparently refresh expired Omni kubeconfig tokens and register the
+	// spec.notify sinks before any command.
 	// Cobra does not chain PersistentPreRunE: when a child command defines its own
 	// (e.g. workload via wrapWithKubeconfigResolution), the child's hook replaces
-	// this one. Workload commands wire the hook separately in their own hook.
+	// this one. Workload commands wire the hooks separately in their own hook.
 	cmd.PersistentPreRunE = func(child *cobra.Command, _ []string) error {
+		notifyhook.MaybeInstallSinks(child)
 		kubeconfighook.MaybeRefreshOmniKubeconfig(child)
 
+		// Garbage-collect expired local data at most once a day. Best-effort: a
+		// failure only postpones the collection to the next command.
+		_ = cache.MaybeCollect(time.Now())
+
 		return nil
 	}
 
@@ -66,7 +87,13 @@ func NewRootCmd(version, commit, date string) *cobra.Command {
 	cmd.AddCommand(steeragent.NewSteerAgentCmd())
 	cmd.AddCommand(project.NewProjectCmd())
 	cmd.AddCommand(tenant.NewTenantCmd())
+	cmd.AddCommand(registry.NewRegistryCmd())
+	cmd.AddCommand(imagecmd.NewImageCmd())
 	cmd.AddCommand(open.NewOpenCmd())
+	cmd.AddCommand(serve.NewServeCmd())
+	cmd.AddCommand(verify.NewVerifyCmd())
+	cmd.AddCommand(cachecmd.NewCacheCmd())
+	cmd.AddCommand(configcmd.NewConfigCmd())
 
 	return cmd
 }
@@ -76,6 +103,10 @@ func Execute(cmd *cobra.Command) error {
 	executor := errorhandler.NewExecutor()
 
 	err := executor.Execute(cmd)
+
+	// Record the outcome in the spec.notify sinks and flush them.
+	notifyhook.Finish(cmd.ErrOrStderr(), err)
+
 	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
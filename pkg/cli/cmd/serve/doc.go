@@ -0,0 +1,5 @@
+// Package serve implements the `ksail serve` command, which runs KSail as a long-lived daemon that
+// exposes its core operations (cluster create, delete, and status, plus manifest apply and diff) over
+// a token-authenticated local REST API, with progress streamed as server-sent events. IDE extensions
+// and internal platforms drive it programmatically instead of spawning a process per command.
+package serve
@@ -0,0 +1,138 @@
+package serve
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/uiserver"
+	"github.com/spf13/cobra"
+)
+
+// TokenEnvVar names the environment variable that supplies the API token. It is read from the
+// environment rather than a flag so the token does not show up in process listings.
+const TokenEnvVar = "KSAIL_SERVE_TOKEN"
+
+// tokenBytes is the entropy of a generated token (hex-encoded to twice as many characters).
+const tokenBytes = 32
+
+const serveLongDesc = `Run KSail as a daemon exposing its core operations over a local REST API.
+
+IDE extensions and internal platforms can drive KSail through the API instead of spawning one
+process per command. The server binds to 127.0.0.1 by default and runs until you press Ctrl+C.
+
+Every API request must carry a bearer token (Authorization: Bearer <token>). The token is read from
+` + TokenEnvVar + `; when unset a random token is generated and printed once at startup. The URL and
+token are printed as machine-parseable KSAIL_SERVE_URL=... and KSAIL_SERVE_TOKEN=... lines.
+
+Endpoints:
+  GET    /api/v1/clusters                              list clusters
+  POST   /api/v1/clusters                              create a cluster
+  GET    /api/v1/clusters/{namespace}/{name}           cluster status
+  DELETE /api/v1/clusters/{namespace}/{name}           delete a cluster
+  POST   /api/v1/clusters/{namespace}/{name}/apply     server-side apply manifests (?dryRun=true)
+  POST   /api/v1/clusters/{namespace}/{name}/diff      preview how manifests would change the cluster
+  GET    /api/v1/events                                stream cluster progress (server-sent events)
+
+Examples:
+  ksail serve
+  ksail serve --port 7373
+  KSAIL_SERVE_TOKEN=secret ksail serve --host 0.0.0.0 --read-only`
+
+// NewServeCmd creates the `ksail serve` command.
+func NewServeCmd() *cobra.Command {
+	var (
+		host     string
+		port     int
+		readOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "serve",
+		Short:        "Expose KSail operations over a local REST API",
+		Long:         serveLongDesc,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		// Exclude from AI tool generation: this is a long-running, blocking server command, like
+		// `open web` and `open mcp`.
+		Annotations: map[string]string{
+			annotations.AnnotationExclude: annotations.AnnotationValueTrue,
+		},
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return runServeCmd(cmd, host, port, readOnly)
+	}
+
+	cmd.Flags().StringVar(&host, "host", uiserver.Host, "Address to bind the API server to")
+	cmd.Flags().IntVar(&port, "port", 0, "Port to serve the API on (0 picks a free port)")
+	cmd.Flags().BoolVar(&readOnly, "read-only", false, "Reject mutating requests (create, delete, apply)")
+
+	return cmd
+}
+
+func runServeCmd(cmd *cobra.Command, host string, port int, readOnly bool) error {
+	token, generated, err := resolveToken()
+	if err != nil {
+		return err
+	}
+
+	// Cancel the context on Ctrl+C / SIGTERM so the server shuts down gracefully.
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, url, err := uiserver.ListenOn(ctx, host, port)
+	if err != nil {
+		return fmt.Errorf("start api server: %w", err)
+	}
+
+	// Reuse the local web UI backend headless: the same cluster lifecycle and manifest operations,
+	// without the embedded SPA, and guarded by the bearer token.
+	server := uiserver.NewServer()
+	server.StaticFS = nil
+	server.ReadOnly = readOnly
+	server.Token = token
+
+	// Print machine-parseable lines first so wrappers can discover the URL (and a generated token),
+	// then a friendly message.
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "KSAIL_SERVE_URL=%s\n", url)
+
+	if generated {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s=%s\n", TokenEnvVar, token)
+	}
+
+	_, _ = fmt.Fprintf(
+		cmd.OutOrStdout(),
+		"KSail API available at %s (press Ctrl+C to stop)\n",
+		url,
+	)
+
+	serveErr := server.Serve(ctx, listener)
+	if serveErr != nil {
+		return fmt.Errorf("serve api: %w", serveErr)
+	}
+
+	return nil
+}
+
+// resolveToken returns the token from TokenEnvVar, or a freshly generated one (reporting true) when
+// the variable is unset.
+func resolveToken() (string, bool, error) {
+	token := os.Getenv(TokenEnvVar)
+	if token != "" {
+		return token, false, nil
+	}
+
+	buf := make([]byte, tokenBytes)
+
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", false, fmt.Errorf("generate api token: %w", err)
+	}
+
+	return hex.EncodeToString(buf), true, nil
+}
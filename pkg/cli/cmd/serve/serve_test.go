@@ -0,0 +1,149 @@
+package serve_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/serve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serveTimeout = 5 * time.Second
+
+// syncBuffer is a goroutine-safe buffer so a test can read command output while the command writes
+// it from another goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	//nolint:wrapcheck // bytes.Buffer.Write never returns an error.
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestNewServeCmdFlagsAndAnnotations(t *testing.T) {
+	t.Parallel()
+
+	cmd := serve.NewServeCmd()
+
+	assert.Equal(t, "serve", cmd.Name())
+	assert.Equal(t, "true", cmd.Annotations[annotations.AnnotationExclude])
+
+	hostFlag := cmd.Flags().Lookup("host")
+	require.NotNil(t, hostFlag)
+	assert.Equal(t, "127.0.0.1", hostFlag.DefValue)
+
+	portFlag := cmd.Flags().Lookup("port")
+	require.NotNil(t, portFlag)
+	assert.Equal(t, "0", portFlag.DefValue)
+
+	readOnlyFlag := cmd.Flags().Lookup("read-only")
+	require.NotNil(t, readOnlyFlag)
+	assert.Equal(t, "false", readOnlyFlag.DefValue)
+}
+
+// startServe runs `ksail serve --port 0` until the test ends and returns its URL and printed output.
+func startServe(t *testing.T) (string, *syncBuffer) {
+	t.Helper()
+
+	cmd := serve.NewServeCmd()
+
+	output := &syncBuffer{}
+	cmd.SetOut(output)
+	cmd.SetErr(output)
+	cmd.SetArgs([]string{"--port", "0"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd.SetContext(ctx)
+
+	done := make(chan error, 1)
+
+	go func() { done <- cmd.Execute() }()
+
+	t.Cleanup(func() {
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(serveTimeout):
+			t.Error("command did not shut down after context cancellation")
+		}
+	})
+
+	urlPattern := regexp.MustCompile(`KSAIL_SERVE_URL=(\S+)`)
+
+	var url string
+
+	require.Eventually(t, func() bool {
+		match := urlPattern.FindStringSubmatch(output.String())
+		if match == nil {
+			return false
+		}
+
+		url = match[1]
+
+		return true
+	}, serveTimeout, 10*time.Millisecond)
+
+	return url, output
+}
+
+func get(t *testing.T, url, token string) int {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	_ = response.Body.Close()
+
+	return response.StatusCode
+}
+
+//nolint:paralleltest // t.Setenv forbids t.Parallel.
+func TestServeCmdRequiresConfiguredToken(t *testing.T) {
+	t.Setenv(serve.TokenEnvVar, "s3cret")
+
+	url, output := startServe(t)
+
+	assert.NotContains(t, output.String(), serve.TokenEnvVar+"=", "a supplied token must not be echoed")
+	assert.Equal(t, http.StatusOK, get(t, url+"healthz", ""))
+	assert.Equal(t, http.StatusUnauthorized, get(t, url+"api/v1/clusters", ""))
+	assert.Equal(t, http.StatusUnauthorized, get(t, url+"api/v1/clusters", "wrong"))
+	// The SPA is not served: unknown routes are not found rather than falling back to index.html.
+	assert.Equal(t, http.StatusNotFound, get(t, url, "s3cret"))
+}
+
+//nolint:paralleltest // t.Setenv forbids t.Parallel.
+func TestServeCmdPrintsGeneratedToken(t *testing.T) {
+	t.Setenv(serve.TokenEnvVar, "")
+
+	_, output := startServe(t)
+
+	assert.Regexp(t, serve.TokenEnvVar+`=[0-9a-f]{64}\n`, output.String())
+}
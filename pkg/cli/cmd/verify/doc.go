@@ -0,0 +1,6 @@
+// Package verify implements the `ksail verify` command, which runs config
+// validation, manifest validation, secret scanning, and policy checks in one
+// pass and prints a summarized report. It is the entry point of the pre-commit
+// hook scaffolded by `ksail project init --pre-commit`, so broken manifests and
+// plain-text secrets are caught before they reach a shared branch.
+package verify
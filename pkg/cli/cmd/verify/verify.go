@@ -0,0 +1,355 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/secretscan"
+	"github.com/spf13/cobra"
+)
+
+// Check names, usable with --skip.
+const (
+	CheckConfig    = "config"
+	CheckManifests = "manifests"
+	CheckSecrets   = "secrets"
+	CheckPolicy    = "policy"
+)
+
+var (
+	// ErrVerifyFailed is returned when at least one check fails.
+	ErrVerifyFailed = errors.New("verification failed")
+
+	// ErrSecretsFound is returned by the secrets check when plain-text secrets are found.
+	ErrSecretsFound = errors.New("plain-text secrets found")
+
+	// ErrUnknownCheck is returned when --skip names a check that does not exist.
+	ErrUnknownCheck = errors.New("unknown check")
+)
+
+// verifyLongDescription is the long help text for the verify command.
+const verifyLongDescription = `Run every pre-merge check on the project in one pass and print a summary.
+
+The checks are:
+  config     Load and validate ksail.yaml and the distribution config
+  manifests  Validate the workload manifests (same as 'ksail workload validate')
+  secrets    Scan the workload manifests and ksail.yaml for plain-text secrets:
+             Secrets without SOPS encryption, private keys, and cloud/Git tokens
+  policy     Run the Kubescape policy gate (same as 'ksail workload scan') when
+             spec.workload.scan is configured in ksail.yaml
+
+Every check runs even when an earlier one fails, and the command exits non-zero
+when any check fails. Checks that do not apply (no ksail.yaml, no scan policy) are
+reported as skipped. Add '# ksail:allow-secret' to a line to suppress a secret
+finding on it.
+
+'ksail project init --pre-commit' scaffolds a .pre-commit-config.yaml that runs this
+command before every commit.`
+
+// Check is a single verification step.
+type Check struct {
+	// Name identifies the check in the report and in --skip.
+	Name string
+	// Run executes the check. A non-empty skipReason marks the check as skipped
+	// (not applicable); a non-nil error marks it as failed.
+	Run func(ctx context.Context, cmd *cobra.Command) (skipReason string, err error)
+}
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusPassed means the check ran and found no problems.
+	StatusPassed Status = "passed"
+	// StatusFailed means the check ran and found problems.
+	StatusFailed Status = "failed"
+	// StatusSkipped means the check did not apply or was skipped via --skip.
+	StatusSkipped Status = "skipped"
+)
+
+// Result is the outcome of running one check.
+type Result struct {
+	Name   string
+	Status Status
+	// Detail is the skip reason or failure message.
+	Detail string
+}
+
+// NewVerifyCmd creates the verify command with the default checks.
+func NewVerifyCmd() *cobra.Command {
+	return NewVerifyCmdWithChecks(DefaultChecks())
+}
+
+// NewVerifyCmdWithChecks creates the verify command running the given checks in order.
+func NewVerifyCmdWithChecks(checks []Check) *cobra.Command {
+	var skip []string
+
+	cmd := &cobra.Command{
+		Use:          "verify",
+		Short:        "Run config, manifest, secret, and policy checks in one pass",
+		Long:         verifyLongDescription,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runVerify(cmd.Context(), cmd, checks, skip)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(
+		&skip,
+		"skip",
+		nil,
+		"Checks to skip: "+strings.Join(checkNames(checks), ", "),
+	)
+
+	return cmd
+}
+
+// DefaultChecks returns the checks `ksail verify` runs, in order.
+func DefaultChecks() []Check {
+	return []Check{
+		{Name: CheckConfig, Run: checkConfig},
+		{Name: CheckManifests, Run: checkManifests},
+		{Name: CheckSecrets, Run: checkSecrets},
+		{Name: CheckPolicy, Run: checkPolicy},
+	}
+}
+
+// runVerify runs every check not listed in skip, prints the summary, and returns
+// ErrVerifyFailed when any check failed.
+func runVerify(ctx context.Context, cmd *cobra.Command, checks []Check, skip []string) error {
+	names := checkNames(checks)
+	for _, name := range skip {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("%w %q (valid checks: %s)", ErrUnknownCheck, name, strings.Join(names, ", "))
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	results := make([]Result, 0, len(checks))
+
+	for _, check := range checks {
+		if slices.Contains(skip, check.Name) {
+			results = append(results, Result{Name: check.Name, Status: StatusSkipped, Detail: "--skip"})
+
+			continue
+		}
+
+		notify.Titlef(out, "🔎", "Verifying %s...", check.Name)
+
+		skipReason, err := check.Run(ctx, cmd)
+
+		switch {
+		case err != nil:
+			results = append(results, Result{Name: check.Name, Status: StatusFailed, Detail: err.Error()})
+		case skipReason != "":
+			results = append(results, Result{Name: check.Name, Status: StatusSkipped, Detail: skipReason})
+		default:
+			results = append(results, Result{Name: check.Name, Status: StatusPassed})
+		}
+	}
+
+	return reportResults(out, results)
+}
+
+// reportResults prints one summary line per check and returns ErrVerifyFailed
+// when any check failed.
+func reportResults(out io.Writer, results []Result) error {
+	_, _ = fmt.Fprintln(out)
+	notify.Titlef(out, "📋", "Verification summary")
+
+	failed := 0
+
+	for _, result := range results {
+		switch result.Status {
+		case StatusFailed:
+			failed++
+
+			notify.Errorf(out, "%s: %s", result.Name, result.Detail)
+		case StatusSkipped:
+			notify.Infof(out, "%s: skipped (%s)", result.Name, result.Detail)
+		case StatusPassed:
+			notify.Successf(out, "%s: passed", result.Name)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d checks failed", ErrVerifyFailed, failed, len(results))
+	}
+
+	return nil
+}
+
+// checkNames returns the names of checks, in order.
+func checkNames(checks []Check) []string {
+	names := make([]string, 0, len(checks))
+	for _, check := range checks {
+		names = append(names, check.Name)
+	}
+
+	return names
+}
+
+// loadConfig loads ksail.yaml (honoring --config), returning the config manager
+// so callers can inspect whether and where a config file was found.
+func loadConfig(
+	cmd *cobra.Command,
+	opts configmanagerinterface.LoadOptions,
+) (*ksailconfigmanager.ConfigManager, error) {
+	configPath, _ := flags.GetConfigPath(cmd)
+
+	cfgManager := ksailconfigmanager.NewConfigManager(io.Discard, configPath)
+
+	_, err := cfgManager.Load(opts)
+	if err != nil {
+		return cfgManager, fmt.Errorf("load config: %w", err)
+	}
+
+	return cfgManager, nil
+}
+
+// checkConfig loads and fully validates ksail.yaml and its distribution config.
+func checkConfig(_ context.Context, cmd *cobra.Command) (string, error) {
+	cfgManager, err := loadConfig(cmd, configmanagerinterface.LoadOptions{Silent: true})
+	if !cfgManager.IsConfigFileFound() {
+		return "no ksail.yaml found", nil
+	}
+
+	return "", err
+}
+
+// checkManifests validates the workload source directory.
+func checkManifests(ctx context.Context, cmd *cobra.Command) (string, error) {
+	err := workload.ValidateManifests(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("validate manifests: %w", err)
+	}
+
+	return "", nil
+}
+
+// checkSecrets scans the workload source directory and ksail.yaml for
+// plain-text secrets, printing each finding.
+func checkSecrets(_ context.Context, cmd *cobra.Command) (string, error) {
+	targets, err := secretScanTargets(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	var findings []secretscan.Finding
+
+	for _, target := range targets {
+		targetFindings, err := secretscan.Scan(target)
+		if err != nil {
+			return "", fmt.Errorf("scan for secrets: %w", err)
+		}
+
+		// Findings are relative to the scanned directory (or the bare file name
+		// for a file); report them relative to the working directory instead.
+		targetIsDir := isDir(target)
+
+		for _, finding := range targetFindings {
+			if targetIsDir {
+				finding.Path = filepath.ToSlash(filepath.Join(target, finding.Path))
+			} else {
+				finding.Path = filepath.ToSlash(target)
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	for _, finding := range findings {
+		notify.Errorf(cmd.OutOrStdout(), "%s", finding)
+	}
+
+	if len(findings) > 0 {
+		return "", fmt.Errorf("%w: %d finding(s)", ErrSecretsFound, len(findings))
+	}
+
+	return "", nil
+}
+
+// secretScanTargets returns the workload source directory plus ksail.yaml when
+// it lies outside that directory.
+func secretScanTargets(cmd *cobra.Command) ([]string, error) {
+	sourcePath, err := workload.ResolveSourcePath(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("resolve source directory: %w", err)
+	}
+
+	var targets []string
+
+	_, statErr := os.Stat(sourcePath)
+	if statErr == nil {
+		targets = append(targets, sourcePath)
+	}
+
+	cfgManager, _ := loadConfig(cmd, configmanagerinterface.LoadOptions{
+		Silent:                 true,
+		SkipValidation:         true,
+		SkipDistributionConfig: true,
+	})
+	if !cfgManager.IsConfigFileFound() {
+		return targets, nil
+	}
+
+	configFile := cfgManager.Viper.ConfigFileUsed()
+	if configFile == "" || isWithin(configFile, sourcePath) {
+		return targets, nil
+	}
+
+	return append(targets, configFile), nil
+}
+
+// isWithin reports whether path lies inside dir.
+func isWithin(path, dir string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// isDir reports whether path is an existing directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
+
+// checkPolicy runs the Kubescape policy gate when spec.workload.scan is configured.
+func checkPolicy(ctx context.Context, cmd *cobra.Command) (string, error) {
+	ran, err := workload.ScanManifests(ctx, cmd)
+	if err != nil {
+		return "", fmt.Errorf("policy scan: %w", err)
+	}
+
+	if !ran {
+		return "spec.workload.scan is not configured", nil
+	}
+
+	return "", nil
+}
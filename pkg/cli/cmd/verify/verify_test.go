@@ -0,0 +1,135 @@
+package verify_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/verify"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCheckFailed = errors.New("boom")
+
+// recordingCheck returns a check that records that it ran and returns the given outcome.
+func recordingCheck(name, skipReason string, err error, ran *[]string) verify.Check {
+	return verify.Check{
+		Name: name,
+		Run: func(context.Context, *cobra.Command) (string, error) {
+			*ran = append(*ran, name)
+
+			return skipReason, err
+		},
+	}
+}
+
+func runVerifyCmd(t *testing.T, cmd *cobra.Command, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+
+	err := cmd.ExecuteContext(context.Background())
+
+	return out.String(), err
+}
+
+func TestVerify_AllChecksPass(t *testing.T) {
+	t.Parallel()
+
+	var ran []string
+
+	cmd := verify.NewVerifyCmdWithChecks([]verify.Check{
+		recordingCheck("config", "", nil, &ran),
+		recordingCheck("policy", "not configured", nil, &ran),
+	})
+
+	out, err := runVerifyCmd(t, cmd)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"config", "policy"}, ran)
+	assert.Contains(t, out, "config: passed")
+	assert.Contains(t, out, "policy: skipped (not configured)")
+}
+
+func TestVerify_RunsEveryCheckAndFailsWhenOneFails(t *testing.T) {
+	t.Parallel()
+
+	var ran []string
+
+	cmd := verify.NewVerifyCmdWithChecks([]verify.Check{
+		recordingCheck("config", "", errCheckFailed, &ran),
+		recordingCheck("manifests", "", nil, &ran),
+	})
+
+	out, err := runVerifyCmd(t, cmd)
+	require.ErrorIs(t, err, verify.ErrVerifyFailed)
+	assert.Contains(t, err.Error(), "1 of 2 checks failed")
+
+	assert.Equal(t, []string{"config", "manifests"}, ran)
+	assert.Contains(t, out, "config: boom")
+	assert.Contains(t, out, "manifests: passed")
+}
+
+func TestVerify_SkipFlag(t *testing.T) {
+	t.Parallel()
+
+	var ran []string
+
+	cmd := verify.NewVerifyCmdWithChecks([]verify.Check{
+		recordingCheck("config", "", nil, &ran),
+		recordingCheck("secrets", "", errCheckFailed, &ran),
+	})
+
+	out, err := runVerifyCmd(t, cmd, "--skip", "secrets")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"config"}, ran)
+	assert.Contains(t, out, "secrets: skipped (--skip)")
+}
+
+func TestVerify_SkipFlagRejectsUnknownCheck(t *testing.T) {
+	t.Parallel()
+
+	var ran []string
+
+	cmd := verify.NewVerifyCmdWithChecks([]verify.Check{recordingCheck("config", "", nil, &ran)})
+
+	_, err := runVerifyCmd(t, cmd, "--skip", "lint")
+	require.ErrorIs(t, err, verify.ErrUnknownCheck)
+	assert.Empty(t, ran)
+}
+
+func TestVerify_DefaultSecretsCheckReportsUnencryptedSecret(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	secret := strings.Join([]string{
+		"apiVersion: v1",
+		"kind: Secret",
+		"metadata:",
+		"  name: db",
+		"stringData:",
+		"  password: hunter2",
+		"",
+	}, "\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "k8s"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "k8s", "secret.yaml"), []byte(secret), 0o600))
+
+	cmd := verify.NewVerifyCmd()
+
+	out, err := runVerifyCmd(t, cmd, "--skip", "config,manifests,policy")
+	require.ErrorIs(t, err, verify.ErrVerifyFailed)
+
+	assert.Contains(t, out, `k8s/secret.yaml:1: Secret "db" is not SOPS-encrypted`)
+	assert.Contains(t, out, "secrets: plain-text secrets found: 1 finding(s)")
+}
@@ -0,0 +1,529 @@
+package workload
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/kustomize"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	specdiff "github.com/devantler-tech/ksail/v7/pkg/svc/diff"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// interactiveFlag enables the per-resource review on workload apply.
+	interactiveFlag = "interactive"
+	// interactiveFieldManager is the field manager used for the dry-run
+	// previews when the apply command has no --field-manager flag.
+	interactiveFieldManager = "kubectl"
+	// defaultNamespace is used for namespaced objects without a namespace when
+	// --namespace is not set.
+	defaultNamespace = "default"
+)
+
+// errInteractivePrune is returned when --interactive is combined with --prune,
+// since skipping a change would make kubectl prune the skipped object.
+var errInteractivePrune = errors.New("--interactive cannot be combined with --prune")
+
+// errApplyCancelled is returned when the user quits the interactive review.
+var errApplyCancelled = errors.New("apply cancelled; no changes were applied")
+
+// interactiveHelp documents the --interactive review for apply.
+const interactiveHelp = `Interactive review: with --interactive every manifest is previewed with a
+server-side dry-run apply and its diff against the live object is shown. Answer
+y to apply the change, n to skip it, a to apply it and every remaining change,
+or q to cancel without applying anything. Unchanged objects are skipped
+without prompting. Only approved manifests are handed to kubectl apply, and
+answers are read from stdin, so manifests cannot also be piped in with -f -.`
+
+// reviewDecision is the answer to a single interactive review prompt.
+type reviewDecision int
+
+const (
+	decisionApply reviewDecision = iota
+	decisionSkip
+	decisionApplyAll
+	decisionQuit
+)
+
+// reviewedDocument is a manifest document together with its previewed change.
+// previewErr is set when the change could not be previewed, e.g. because its
+// namespace or CRD is created by an earlier document of the same apply.
+type reviewedDocument struct {
+	doc        []byte
+	diff       specdiff.ObjectDiff
+	previewErr error
+}
+
+// objectPreviewer previews applying a single manifest document.
+type objectPreviewer func(ctx context.Context, doc []byte) (specdiff.ObjectDiff, error)
+
+// withInteractiveApproval adds --interactive to the apply command. When set,
+// each manifest from -f and -k is previewed and the user approves or skips it;
+// the -f values are then pointed at a temporary file holding only the approved
+// manifests before kubectl runs. It must wrap the kubectl command directly so
+// it sees the inputs already resolved and substituted by the outer wrappers.
+func withInteractiveApproval(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Bool(
+		interactiveFlag, false,
+		"Show the diff of each resource against the live cluster and approve or skip it before applying",
+	)
+
+	origRunE := cmd.RunE
+	origRun := cmd.Run
+
+	cmd.RunE = func(child *cobra.Command, args []string) error {
+		interactive, _ := child.Flags().GetBool(interactiveFlag)
+		if interactive {
+			proceed, cleanup, err := reviewApply(child)
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+
+			if !proceed {
+				return nil
+			}
+		}
+
+		if origRunE != nil {
+			return origRunE(child, args)
+		}
+
+		origRun(child, args)
+
+		return nil
+	}
+	cmd.Run = nil
+
+	return cmd
+}
+
+// reviewApply runs the interactive review for cmd's manifest inputs. It reports
+// whether kubectl should run and returns a cleanup func for the temporary file
+// holding the approved manifests.
+func reviewApply(cmd *cobra.Command) (bool, func(), error) {
+	noop := func() {}
+
+	if prune, _ := cmd.Flags().GetBool("prune"); prune {
+		return false, noop, errInteractivePrune
+	}
+
+	docs, err := collectApplyDocuments(cmd)
+	if err != nil {
+		return false, noop, err
+	}
+
+	previewer, err := newObjectPreviewer(cmd)
+	if err != nil {
+		return false, noop, err
+	}
+
+	reviewed := previewDocuments(cmd.Context(), previewer, docs)
+
+	approved, err := reviewDocuments(reviewed, cmd.InOrStdin(), cmd.OutOrStdout())
+	if err != nil {
+		return false, noop, err
+	}
+
+	if len(approved) == 0 {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.InfoType,
+			Content: "no changes approved; nothing to apply",
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return false, noop, nil
+	}
+
+	return pointApplyAtDocuments(cmd, approved)
+}
+
+// collectApplyDocuments reads every manifest document from cmd's -f files and
+// directories and its -k kustomization.
+func collectApplyDocuments(cmd *cobra.Command) ([][]byte, error) {
+	var docs [][]byte
+
+	recursive, _ := cmd.Flags().GetBool("recursive")
+
+	if flag := cmd.Flags().Lookup(filenameFlag); flag != nil {
+		if filenames, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, source := range filenames.GetSlice() {
+				sourceDocs, err := readManifestSource(source, recursive)
+				if err != nil {
+					return nil, err
+				}
+
+				docs = append(docs, sourceDocs...)
+			}
+		}
+	}
+
+	if flag := cmd.Flags().Lookup(kustomizeFlag); flag != nil && flag.Value.String() != "" {
+		output, err := kustomize.NewClient().Build(cmd.Context(), flag.Value.String())
+		if err != nil {
+			return nil, fmt.Errorf("build kustomization %q: %w", flag.Value.String(), err)
+		}
+
+		kustomizeDocs, err := splitManifestDocuments(output.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("split kustomization %q: %w", flag.Value.String(), err)
+		}
+
+		docs = append(docs, kustomizeDocs...)
+	}
+
+	return docs, nil
+}
+
+// readManifestSource reads the manifest documents of a -f file or directory.
+// Like kubectl, subdirectories are only read when recursive is set.
+func readManifestSource(source string, recursive bool) ([][]byte, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("stat %q: %w", source, err)
+	}
+
+	if !info.IsDir() {
+		return readManifestFile(source)
+	}
+
+	var docs [][]byte
+
+	err = filepath.WalkDir(source, func(path string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if entry.IsDir() {
+			if path != source && !recursive {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !isManifestFile(path) {
+			return nil
+		}
+
+		fileDocs, err := readManifestFile(path)
+		if err != nil {
+			return err
+		}
+
+		docs = append(docs, fileDocs...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", source, err)
+	}
+
+	return docs, nil
+}
+
+// readManifestFile reads the manifest documents of a single file.
+func readManifestFile(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-supplied manifest path
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", path, err)
+	}
+
+	docs, err := splitManifestDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("split %q: %w", path, err)
+	}
+
+	return docs, nil
+}
+
+// splitManifestDocuments splits multi-document YAML into trimmed, non-empty
+// documents.
+func splitManifestDocuments(data []byte) ([][]byte, error) {
+	reader := yamlutil.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var docs [][]byte
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read document: %w", err)
+		}
+
+		doc = bytes.TrimSpace(doc)
+		if len(doc) > 0 {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// newObjectPreviewer builds a previewer against the cluster selected by cmd's
+// --kubeconfig and --context flags. Objects without a namespace use
+// --namespace, or "default" when it is unset.
+func newObjectPreviewer(cmd *cobra.Command) (objectPreviewer, error) {
+	restConfig, err := k8s.BuildRESTConfig(flagString(cmd, "kubeconfig"), flagString(cmd, "context"))
+	if err != nil {
+		return nil, fmt.Errorf("build rest config: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("build dynamic client: %w", err)
+	}
+
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("build http client: %w", err)
+	}
+
+	mapper, err := apiutil.NewDynamicRESTMapper(restConfig, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("build rest mapper: %w", err)
+	}
+
+	namespace := flagString(cmd, "namespace")
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	fieldManager := flagString(cmd, "field-manager")
+	if fieldManager == "" {
+		fieldManager = interactiveFieldManager
+	}
+
+	return newDynamicPreviewer(dynamicClient, mapper, namespace, fieldManager), nil
+}
+
+// newDynamicPreviewer returns a previewer that dry-run applies each document
+// through dynamicClient, resolving its resource with mapper.
+func newDynamicPreviewer(
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
+	namespace, fieldManager string,
+) objectPreviewer {
+	return func(ctx context.Context, doc []byte) (specdiff.ObjectDiff, error) {
+		obj := &unstructured.Unstructured{}
+
+		err := yaml.Unmarshal(doc, &obj.Object)
+		if err != nil {
+			return specdiff.ObjectDiff{}, fmt.Errorf("parse manifest: %w", err)
+		}
+
+		gvk := obj.GroupVersionKind()
+
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return specdiff.ObjectDiff{}, fmt.Errorf("resolve %s: %w", gvk.String(), err)
+		}
+
+		resource := dynamicClient.Resource(mapping.Resource)
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			return specdiff.PreviewApply(ctx, resource, obj, fieldManager)
+		}
+
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+
+		return specdiff.PreviewApply(ctx, resource.Namespace(obj.GetNamespace()), obj, fieldManager)
+	}
+}
+
+// previewDocuments previews every document. A preview failure is recorded on
+// the document rather than aborting, so the user can still decide on it.
+func previewDocuments(ctx context.Context, previewer objectPreviewer, docs [][]byte) []reviewedDocument {
+	reviewed := make([]reviewedDocument, 0, len(docs))
+
+	for _, doc := range docs {
+		diff, err := previewer(ctx, doc)
+		reviewed = append(reviewed, reviewedDocument{doc: doc, diff: diff, previewErr: err})
+	}
+
+	return reviewed
+}
+
+// reviewDocuments shows each previewed change on out and reads the user's
+// decision from in. It returns the approved documents in their original order,
+// or errApplyCancelled when the user quits. End of input counts as quitting.
+func reviewDocuments(reviewed []reviewedDocument, in io.Reader, out io.Writer) ([][]byte, error) {
+	reader := bufio.NewReader(in)
+	approved := make([][]byte, 0, len(reviewed))
+	applyAll := false
+
+	for _, document := range reviewed {
+		if document.previewErr == nil && document.diff.Action == specdiff.ObjectUnchanged {
+			_, _ = fmt.Fprintf(out, "= %s unchanged\n", document.diff.Label())
+
+			continue
+		}
+
+		writeDocumentChange(out, document)
+
+		if applyAll {
+			approved = append(approved, document.doc)
+
+			continue
+		}
+
+		switch promptDecision(reader, out) {
+		case decisionApply:
+			approved = append(approved, document.doc)
+		case decisionApplyAll:
+			applyAll = true
+
+			approved = append(approved, document.doc)
+		case decisionSkip:
+		case decisionQuit:
+			return nil, errApplyCancelled
+		}
+	}
+
+	return approved, nil
+}
+
+// writeDocumentChange prints the header and diff of a previewed change.
+func writeDocumentChange(out io.Writer, document reviewedDocument) {
+	if document.previewErr != nil {
+		_, _ = fmt.Fprintf(out, "! %s cannot be previewed: %v\n", documentLabel(document.doc), document.previewErr)
+
+		return
+	}
+
+	symbol, verb := "~", "updated"
+	if document.diff.Action == specdiff.ObjectCreate {
+		symbol, verb = "+", "created"
+	}
+
+	_, _ = fmt.Fprintf(out, "%s %s will be %s\n%s", symbol, document.diff.Label(), verb, document.diff.Diff)
+}
+
+// documentLabel returns a Kind/Namespace/Name label for a document that could
+// not be previewed, falling back to "manifest" when it cannot be parsed.
+func documentLabel(doc []byte) string {
+	obj := &unstructured.Unstructured{}
+
+	err := yaml.Unmarshal(doc, &obj.Object)
+	if err != nil || obj.GetKind() == "" {
+		return "manifest"
+	}
+
+	return specdiff.ObjectDiff{Kind: obj.GetKind(), Name: obj.GetName(), Namespace: obj.GetNamespace()}.Label()
+}
+
+// promptDecision asks for a decision until a valid answer is given.
+func promptDecision(reader *bufio.Reader, out io.Writer) reviewDecision {
+	for {
+		_, _ = fmt.Fprint(out, "Apply this change? [y]es, [n]o, [a]ll, [q]uit: ")
+
+		line, err := reader.ReadString('\n')
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return decisionApply
+		case "n", "no":
+			return decisionSkip
+		case "a", "all":
+			return decisionApplyAll
+		case "q", "quit":
+			return decisionQuit
+		}
+
+		if err != nil {
+			_, _ = fmt.Fprintln(out)
+
+			return decisionQuit
+		}
+	}
+}
+
+// pointApplyAtDocuments writes docs to a temporary file and points cmd's -f at
+// it, clearing -k since the kustomization output is already included.
+func pointApplyAtDocuments(cmd *cobra.Command, docs [][]byte) (bool, func(), error) {
+	noop := func() {}
+
+	tmpFile, err := os.CreateTemp("", "ksail-apply-*.yaml")
+	if err != nil {
+		return false, noop, fmt.Errorf("create approved manifest file: %w", err)
+	}
+
+	cleanup := func() { _ = os.Remove(tmpFile.Name()) }
+
+	_, err = tmpFile.Write(bytes.Join(docs, []byte("\n---\n")))
+	closeErr := tmpFile.Close()
+
+	err = errors.Join(err, closeErr)
+	if err != nil {
+		cleanup()
+
+		return false, noop, fmt.Errorf("write approved manifest file: %w", err)
+	}
+
+	err = setApplyInputs(cmd, tmpFile.Name())
+	if err != nil {
+		cleanup()
+
+		return false, noop, err
+	}
+
+	return true, cleanup, nil
+}
+
+// setApplyInputs replaces cmd's -f values with path and clears -k.
+func setApplyInputs(cmd *cobra.Command, path string) error {
+	if flag := cmd.Flags().Lookup(kustomizeFlag); flag != nil && flag.Value.String() != "" {
+		err := flag.Value.Set("")
+		if err != nil {
+			return fmt.Errorf("clear --%s: %w", kustomizeFlag, err)
+		}
+	}
+
+	flag := cmd.Flags().Lookup(filenameFlag)
+	if flag == nil {
+		return nil
+	}
+
+	filenames, ok := flag.Value.(pflag.SliceValue)
+	if !ok {
+		return nil
+	}
+
+	err := filenames.Replace([]string{path})
+	if err != nil {
+		return fmt.Errorf("set --%s: %w", filenameFlag, err)
+	}
+
+	return nil
+}
+
+// flagString returns the string value of cmd's flag name, or "" when the flag
+// does not exist.
+func flagString(cmd *cobra.Command, name string) string {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		return ""
+	}
+
+	return flag.Value.String()
+}
@@ -0,0 +1,180 @@
+package workload_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	specdiff "github.com/devantler-tech/ksail/v7/pkg/svc/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNamespaceNotFound = errors.New(`namespaces "apps" not found`)
+
+// stubPreviewer previews documents by their metadata.name: "new-*" objects
+// are created, "same-*" objects are unchanged, "broken-*" objects fail, and
+// everything else is updated.
+func stubPreviewer(_ context.Context, doc []byte) (specdiff.ObjectDiff, error) {
+	var name string
+
+	for line := range strings.SplitSeq(string(doc), "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "name: "); found {
+			name = value
+		}
+	}
+
+	result := specdiff.ObjectDiff{Kind: "ConfigMap", Name: name, Namespace: "apps", Diff: "@@ " + name + " @@\n"}
+
+	switch {
+	case strings.HasPrefix(name, "new-"):
+		result.Action = specdiff.ObjectCreate
+	case strings.HasPrefix(name, "same-"):
+		return specdiff.ObjectDiff{Kind: "ConfigMap", Name: name, Namespace: "apps", Action: specdiff.ObjectUnchanged}, nil
+	case strings.HasPrefix(name, "broken-"):
+		return specdiff.ObjectDiff{}, errNamespaceNotFound
+	default:
+		result.Action = specdiff.ObjectUpdate
+	}
+
+	return result, nil
+}
+
+func configMapDoc(name string) []byte {
+	return []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n  namespace: apps")
+}
+
+func TestReviewApplyDocuments(t *testing.T) {
+	t.Parallel()
+
+	docs := [][]byte{
+		configMapDoc("new-a"),
+		configMapDoc("same-b"),
+		configMapDoc("changed-c"),
+		configMapDoc("broken-d"),
+		configMapDoc("changed-e"),
+	}
+
+	tests := []struct {
+		name         string
+		answers      string
+		wantApproved []string
+		wantErr      error
+	}{
+		{
+			name:         "approve and skip individually",
+			answers:      "y\nn\nmaybe\ny\nn\n",
+			wantApproved: []string{"new-a", "broken-d"},
+		},
+		{
+			name:         "all approves the remaining changes",
+			answers:      "n\na\n",
+			wantApproved: []string{"changed-c", "broken-d", "changed-e"},
+		},
+		{
+			name:    "quit cancels everything",
+			answers: "y\nq\n",
+			wantErr: workload.ExportErrApplyCancelled(),
+		},
+		{
+			name:    "end of input cancels everything",
+			answers: "y\n",
+			wantErr: workload.ExportErrApplyCancelled(),
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			approved, err := workload.ExportReviewApplyDocuments(
+				context.Background(), docs, stubPreviewer, strings.NewReader(testCase.answers), &out,
+			)
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(approved))
+			for _, doc := range approved {
+				_, name, _ := strings.Cut(string(doc), "name: ")
+				name, _, _ = strings.Cut(name, "\n")
+				names = append(names, name)
+			}
+
+			assert.Equal(t, testCase.wantApproved, names)
+		})
+	}
+}
+
+func TestReviewApplyDocuments_Output(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	_, err := workload.ExportReviewApplyDocuments(
+		context.Background(),
+		[][]byte{configMapDoc("new-a"), configMapDoc("same-b"), configMapDoc("changed-c"), configMapDoc("broken-d")},
+		stubPreviewer,
+		strings.NewReader("a\n"),
+		&out,
+	)
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, "+ ConfigMap/apps/new-a will be created\n@@ new-a @@\n")
+	assert.Contains(t, output, "= ConfigMap/apps/same-b unchanged\n")
+	assert.Contains(t, output, "~ ConfigMap/apps/changed-c will be updated\n@@ changed-c @@\n")
+	assert.Contains(t, output, `! ConfigMap/apps/broken-d cannot be previewed: namespaces "apps" not found`)
+	assert.Equal(t, 1, strings.Count(output, "Apply this change?"), "a answers every remaining prompt")
+}
+
+func TestCollectApplyDocuments(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	require.NoError(t, os.Mkdir(nested, 0o700))
+
+	file := filepath.Join(dir, "multi.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("---\n"+string(configMapDoc("a"))+"\n---\n"+
+		string(configMapDoc("b"))+"\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(nested, "c.yaml"), configMapDoc("c"), 0o600))
+
+	tests := []struct {
+		name      string
+		recursive bool
+		wantDocs  int
+	}{
+		{name: "top-level directory only", wantDocs: 2},
+		{name: "recursive", recursive: true, wantDocs: 3},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := newManifestFlagsCommand(t, []string{dir}, "")
+			cmd.Flags().BoolP("recursive", "R", false, "")
+
+			if testCase.recursive {
+				require.NoError(t, cmd.Flags().Set("recursive", "true"))
+			}
+
+			docs, err := workload.ExportCollectApplyDocuments(cmd)
+			require.NoError(t, err)
+			assert.Len(t, docs, testCase.wantDocs)
+		})
+	}
+}
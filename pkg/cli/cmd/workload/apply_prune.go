@@ -0,0 +1,307 @@
+package workload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// applySetFlag names the ownership set --prune tracks.
+	applySetFlag = "apply-set"
+	// ApplySetLabel is the label recording which ownership set an object
+	// applied by `ksail workload apply` belongs to.
+	ApplySetLabel = "ksail.io/apply-set"
+	// managedByLabel is the well-known label naming the tool managing an object.
+	managedByLabel = "app.kubernetes.io/managed-by"
+	// managedByValue is the managed-by value of objects in an ownership set.
+	managedByValue = "ksail"
+	// applySetNameMaxPrefix bounds the readable part of a derived set name so
+	// the name stays within the 63-character label value limit.
+	applySetNameMaxPrefix = 40
+	// applySetHashLength is the number of hex characters of the input hash in
+	// a derived set name.
+	applySetHashLength = 8
+)
+
+// errApplySetFromStdin is returned when --prune cannot derive an ownership
+// set name because the manifests come from stdin.
+var errApplySetFromStdin = errors.New("--prune with manifests from stdin requires --apply-set")
+
+// errInvalidApplySet is returned when --apply-set is not a valid label value.
+var errInvalidApplySet = errors.New("invalid --apply-set")
+
+// errNoPruneInputs is returned when --prune is used without -f or -k.
+var errNoPruneInputs = errors.New("--prune requires manifests from -f or -k")
+
+// applySetNameInvalidChars matches the characters not allowed in a derived
+// ownership set name.
+var applySetNameInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// pruneHelp documents ownership tracking for --prune.
+const pruneHelp = `Pruning: --prune without -l or --all tracks ownership with labels, like the
+GitOps engines do. Every applied object is labeled ` + ApplySetLabel + `=<set> and
+` + managedByLabel + `=` + managedByValue + `, and objects of the set that are no longer in
+the manifests are deleted. The set defaults to a name derived from the -f and -k
+inputs, so applying the same directory again prunes what was removed from it;
+pass --apply-set to name it explicitly, e.g. when the manifests move. --prune
+with -l or --all keeps kubectl's selector-based behavior.`
+
+// withPruneOwnership adds --apply-set to the apply command and, when --prune is
+// set without -l or --all, labels every manifest with its ownership set and
+// selects the set for kubectl's prune. The set name is derived from the raw -f
+// and -k values in PreRunE, before the outer wrappers rewrite them to
+// temporary files, while labeling happens in RunE on the resolved, substituted
+// manifests.
+func withPruneOwnership(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(
+		applySetFlag, "",
+		"Ownership set tracked by --prune (default: derived from the -f and -k inputs)",
+	)
+
+	if pruneFlag := cmd.Flags().Lookup("prune"); pruneFlag != nil {
+		pruneFlag.Usage = "Delete objects that are no longer in the manifests: those matching -l or --all, " +
+			"or otherwise those of the --apply-set ownership set."
+	}
+
+	origPreRunE := cmd.PreRunE
+	origPreRun := cmd.PreRun
+
+	cmd.PreRunE = func(child *cobra.Command, args []string) error {
+		if ownedPruneActive(child) {
+			err := defaultApplySet(child)
+			if err != nil {
+				return err
+			}
+		}
+
+		if origPreRunE != nil {
+			return origPreRunE(child, args)
+		}
+
+		if origPreRun != nil {
+			origPreRun(child, args)
+		}
+
+		return nil
+	}
+	cmd.PreRun = nil
+
+	origRunE := cmd.RunE
+	origRun := cmd.Run
+
+	cmd.RunE = func(child *cobra.Command, args []string) error {
+		if ownedPruneActive(child) {
+			cleanup, err := labelApplySet(child, flagString(child, applySetFlag))
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+		}
+
+		if origRunE != nil {
+			return origRunE(child, args)
+		}
+
+		origRun(child, args)
+
+		return nil
+	}
+	cmd.Run = nil
+
+	return cmd
+}
+
+// ownedPruneActive reports whether --prune should track ownership with labels:
+// --prune is set and neither -l nor --all selects the objects to prune.
+func ownedPruneActive(cmd *cobra.Command) bool {
+	prune, _ := cmd.Flags().GetBool("prune")
+	all, _ := cmd.Flags().GetBool("all")
+
+	return prune && !all && flagString(cmd, "selector") == ""
+}
+
+// defaultApplySet validates --apply-set, or derives it from the raw -f and -k
+// values when it was not given.
+func defaultApplySet(cmd *cobra.Command) error {
+	if name := flagString(cmd, applySetFlag); name != "" {
+		errs := validation.IsValidLabelValue(name)
+		if len(errs) > 0 {
+			return fmt.Errorf("%w %q: %s", errInvalidApplySet, name, strings.Join(errs, "; "))
+		}
+
+		return nil
+	}
+
+	var inputs []string
+
+	if flag := cmd.Flags().Lookup(filenameFlag); flag != nil {
+		if filenames, ok := flag.Value.(pflag.SliceValue); ok {
+			inputs = append(inputs, filenames.GetSlice()...)
+		}
+	}
+
+	if kustomizeDir := flagString(cmd, kustomizeFlag); kustomizeDir != "" {
+		inputs = append(inputs, kustomizeDir)
+	}
+
+	name, err := deriveApplySetName(inputs)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.Flags().Set(applySetFlag, name)
+	if err != nil {
+		return fmt.Errorf("set --%s: %w", applySetFlag, err)
+	}
+
+	return nil
+}
+
+// deriveApplySetName returns a stable ownership set name for the manifest
+// inputs: a readable prefix from the first input's base name and a short hash
+// of all inputs, with local paths made absolute so the working directory does
+// not matter.
+func deriveApplySetName(inputs []string) (string, error) {
+	if len(inputs) == 0 {
+		return "", errNoPruneInputs
+	}
+
+	normalized := make([]string, 0, len(inputs))
+
+	for _, input := range inputs {
+		switch {
+		case input == "-":
+			return "", errApplySetFromStdin
+		case strings.HasPrefix(input, "http://"), strings.HasPrefix(input, "https://"):
+			normalized = append(normalized, input)
+		default:
+			abs, err := filepath.Abs(input)
+			if err != nil {
+				return "", fmt.Errorf("resolve %q: %w", input, err)
+			}
+
+			normalized = append(normalized, filepath.ToSlash(abs))
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(normalized, "\n")))
+	hash := hex.EncodeToString(sum[:])[:applySetHashLength]
+
+	prefix := strings.TrimSuffix(path.Base(normalized[0]), path.Ext(normalized[0]))
+	prefix = strings.Trim(applySetNameInvalidChars.ReplaceAllString(strings.ToLower(prefix), "-"), "-")
+
+	if len(prefix) > applySetNameMaxPrefix {
+		prefix = strings.TrimRight(prefix[:applySetNameMaxPrefix], "-")
+	}
+
+	if prefix == "" {
+		return hash, nil
+	}
+
+	return prefix + "-" + hash, nil
+}
+
+// labelApplySet labels every manifest from cmd's -f and -k inputs with the
+// ownership set, points -f at the labeled copy, and selects the set for
+// kubectl's prune. It returns a cleanup func removing the copy.
+func labelApplySet(cmd *cobra.Command, applySet string) (func(), error) {
+	docs, err := collectApplyDocuments(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	labeled := make([][]byte, 0, len(docs))
+
+	for _, doc := range docs {
+		out, err := labelApplySetDocument(doc, applySet)
+		if err != nil {
+			return nil, err
+		}
+
+		labeled = append(labeled, out)
+	}
+
+	_, cleanup, err := pointApplyAtDocuments(cmd, labeled)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Flags().Set("selector", ApplySetLabel+"="+applySet)
+	if err != nil {
+		cleanup()
+
+		return nil, fmt.Errorf("set --selector: %w", err)
+	}
+
+	return cleanup, nil
+}
+
+// labelApplySetDocument adds the ownership labels to the object in doc, or to
+// every item when doc is a List.
+func labelApplySetDocument(doc []byte, applySet string) ([]byte, error) {
+	obj := &unstructured.Unstructured{}
+
+	err := yaml.Unmarshal(doc, &obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+
+	if len(obj.Object) == 0 {
+		return doc, nil
+	}
+
+	setLabels := func(item *unstructured.Unstructured) {
+		labels := item.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		labels[ApplySetLabel] = applySet
+		labels[managedByLabel] = managedByValue
+		item.SetLabels(labels)
+	}
+
+	if obj.IsList() {
+		list, err := obj.ToList()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", obj.GetKind(), err)
+		}
+
+		err = list.EachListItem(func(item runtime.Object) error {
+			itemObj, ok := item.(*unstructured.Unstructured)
+			if ok {
+				setLabels(itemObj)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("label %s items: %w", obj.GetKind(), err)
+		}
+
+		obj.Object = list.UnstructuredContent()
+	} else {
+		setLabels(obj)
+	}
+
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", obj.GetName(), err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,180 @@
+package workload_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pruneManifests = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  labels:
+    app: web
+---
+# only a comment
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: Secret
+    metadata:
+      name: token
+`
+
+// pruneRun records what the stub kubectl apply saw.
+type pruneRun struct {
+	selector  string
+	manifests string
+}
+
+// newPruneCommand returns a stub apply command wrapped with prune ownership
+// tracking. Its RunE records the selector and the manifests it would apply.
+func newPruneCommand(t *testing.T, run *pruneRun) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{
+		Use: "apply",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			run.selector, _ = cmd.Flags().GetString("selector")
+
+			filenames, _ := cmd.Flags().GetStringSlice("filename")
+			for _, name := range filenames {
+				data, err := os.ReadFile(name) //nolint:gosec // test-owned temp file
+				if err != nil {
+					return err
+				}
+
+				run.manifests += string(data)
+			}
+
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceP("filename", "f", nil, "")
+	cmd.Flags().StringP("kustomize", "k", "", "")
+	cmd.Flags().BoolP("recursive", "R", false, "")
+	cmd.Flags().Bool("prune", false, "")
+	cmd.Flags().Bool("all", false, "")
+	cmd.Flags().StringP("selector", "l", "", "")
+
+	return workload.ExportWithPruneOwnership(cmd)
+}
+
+func TestPruneOwnership_LabelsManifestsAndSelectsSet(t *testing.T) {
+	t.Parallel()
+
+	manifest := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(pruneManifests), 0o600))
+
+	var run pruneRun
+
+	cmd := newPruneCommand(t, &run)
+	cmd.SetArgs([]string{"-f", manifest, "--prune", "--apply-set", "web"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "ksail.io/apply-set=web", run.selector)
+	assert.Equal(t, 2, strings.Count(run.manifests, "ksail.io/apply-set: web"), "ConfigMap and List item")
+	assert.Equal(t, 2, strings.Count(run.manifests, "app.kubernetes.io/managed-by: ksail"))
+	assert.Contains(t, run.manifests, "app: web", "existing labels are kept")
+	assert.Contains(t, run.manifests, "# only a comment", "documents without an object pass through")
+}
+
+func TestPruneOwnership_KeepsKubectlSelectorBehavior(t *testing.T) {
+	t.Parallel()
+
+	manifest := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(pruneManifests), 0o600))
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantSelector string
+	}{
+		{name: "no prune", args: []string{"-f", manifest}},
+		{
+			name:         "prune with selector",
+			args:         []string{"-f", manifest, "--prune", "-l", "app=web"},
+			wantSelector: "app=web",
+		},
+		{name: "prune all", args: []string{"-f", manifest, "--prune", "--all"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var run pruneRun
+
+			cmd := newPruneCommand(t, &run)
+			cmd.SetArgs(testCase.args)
+			require.NoError(t, cmd.Execute())
+
+			assert.Equal(t, testCase.wantSelector, run.selector)
+			assert.Equal(t, pruneManifests, run.manifests)
+		})
+	}
+}
+
+func TestPruneOwnership_Rejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "stdin without set", args: []string{"-f", "-", "--prune"}, wantErr: "requires --apply-set"},
+		{
+			name:    "invalid set",
+			args:    []string{"-f", "app.yaml", "--prune", "--apply-set", "not valid"},
+			wantErr: "invalid --apply-set",
+		},
+		{name: "no inputs", args: []string{"--prune"}, wantErr: "requires manifests from -f or -k"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var run pruneRun
+
+			cmd := newPruneCommand(t, &run)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			cmd.SetArgs(testCase.args)
+
+			require.ErrorContains(t, cmd.Execute(), testCase.wantErr)
+		})
+	}
+}
+
+func TestDeriveApplySetName(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	name, err := workload.ExportDeriveApplySetName([]string{filepath.Join(dir, "My_Apps.yaml")})
+	require.NoError(t, err)
+	assert.Regexp(t, `^my-apps-[0-9a-f]{8}$`, name)
+
+	again, err := workload.ExportDeriveApplySetName([]string{filepath.Join(dir, "My_Apps.yaml")})
+	require.NoError(t, err)
+	assert.Equal(t, name, again, "the same inputs derive the same set")
+
+	other, err := workload.ExportDeriveApplySetName([]string{filepath.Join(t.TempDir(), "My_Apps.yaml")})
+	require.NoError(t, err)
+	assert.NotEqual(t, name, other, "the same base name in another directory is another set")
+
+	long, err := workload.ExportDeriveApplySetName([]string{strings.Repeat("a", 80)})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(long), 63)
+}
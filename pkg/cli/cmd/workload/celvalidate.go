@@ -18,26 +18,42 @@ import (
 // violated by the validated manifests.
 var ErrCELRuleViolation = errors.New("CEL rule violation")
 
-// buildCELEngine loads and compiles the CEL rules file named by rulesPath. It
-// returns (nil, nil) when rulesPath is empty (the --rules flag was not given),
+// buildCELEngine loads and compiles the CEL rules file named by rulesPath
+// together with the CEL rules of the Kyverno policies at policiesPath (a file
+// or directory, from --policies). It returns (nil, nil) when both are empty,
 // so callers can treat a nil engine as "CEL validation disabled". A malformed
-// or non-compiling rules file is surfaced as an error so validate fails fast,
-// before any manifest is processed, rather than silently skipping the rules.
-func buildCELEngine(rulesPath string) (*celrules.Engine, error) {
-	if rulesPath == "" {
+// or non-compiling rules file or policy is surfaced as an error so validate
+// fails fast, before any manifest is processed, rather than silently skipping
+// the rules. Kyverno rules that cannot be evaluated offline are reported as
+// warnings on cmd's stderr.
+func buildCELEngine(cmd *cobra.Command, rulesPath, policiesPath string) (*celrules.Engine, error) {
+	if rulesPath == "" && policiesPath == "" {
 		return nil, nil //nolint:nilnil // nil engine + nil error means "CEL disabled" by design.
 	}
 
-	// Canonicalize like the validate target path and --config so a relative or
-	// symlinked rules path resolves to the intended file.
-	canonical, err := fsutil.EvalCanonicalPath(rulesPath)
-	if err != nil {
-		return nil, fmt.Errorf("resolve rules file %q: %w", rulesPath, err)
+	var rules []celrules.Rule
+
+	if rulesPath != "" {
+		// Canonicalize like the validate target path and --config so a relative or
+		// symlinked rules path resolves to the intended file.
+		canonical, err := fsutil.EvalCanonicalPath(rulesPath)
+		if err != nil {
+			return nil, fmt.Errorf("resolve rules file %q: %w", rulesPath, err)
+		}
+
+		rules, err = celrules.LoadRules(canonical)
+		if err != nil {
+			return nil, fmt.Errorf("load CEL rules: %w", err)
+		}
 	}
 
-	rules, err := celrules.LoadRules(canonical)
-	if err != nil {
-		return nil, fmt.Errorf("load CEL rules: %w", err)
+	if policiesPath != "" {
+		policyRules, err := loadPolicyRules(cmd, policiesPath)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, policyRules...)
 	}
 
 	engine, err := celrules.NewEngine(rules)
@@ -48,6 +64,31 @@ func buildCELEngine(rulesPath string) (*celrules.Engine, error) {
 	return engine, nil
 }
 
+// loadPolicyRules converts the Kyverno policies at policiesPath into CEL rules,
+// warning about every rule that is skipped because it is not a CEL validation.
+func loadPolicyRules(cmd *cobra.Command, policiesPath string) ([]celrules.Rule, error) {
+	canonical, err := fsutil.EvalCanonicalPath(policiesPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve policies path %q: %w", policiesPath, err)
+	}
+
+	rules, skipped, err := celrules.LoadKyvernoPolicies(canonical)
+	if err != nil {
+		return nil, fmt.Errorf("load Kyverno policies: %w", err)
+	}
+
+	for _, name := range skipped {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "policy rule %s skipped: only validate.cel rules are evaluated offline",
+			Args:    []any{name},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	return rules, nil
+}
+
 // evaluateCELDocuments runs the CEL engine over every document in a rendered or
 // built manifest stream. Error-severity violations are aggregated and returned
 // as an ErrCELRuleViolation (failing validation); warning-severity violations
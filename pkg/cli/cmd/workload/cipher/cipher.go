@@ -36,9 +36,10 @@ const permissionWrite = "write"
 func NewCipherCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cipher",
-		Short: "Manage encrypted files with SOPS",
+		Short: "Manage encrypted files with SOPS and Sealed Secrets",
 		Long: `Cipher command provides access to SOPS (Secrets OPerationS) functionality
-for encrypting and decrypting files.
+for encrypting and decrypting files, and seals Secret manifests into Bitnami
+SealedSecrets for clusters running the Sealed Secrets controller.
 
 SOPS supports multiple key management systems:
   - age recipients
@@ -56,6 +57,7 @@ SOPS supports multiple key management systems:
 	cmd.AddCommand(NewDecryptCmd())
 	cmd.AddCommand(NewImportCmd())
 	cmd.AddCommand(NewRotateCmd())
+	cmd.AddCommand(NewSealCmd())
 
 	return cmd
 }
@@ -3,5 +3,6 @@
 // This package contains commands for managing encrypted files using the SOPS
 // (Secrets OPerationS) Go library, supporting multiple key management systems
 // including age recipients, PGP fingerprints, AWS KMS, GCP KMS, Azure Key Vault,
-// and HashiCorp Vault.
+// and HashiCorp Vault. The seal subcommand converts Secret manifests into Bitnami
+// SealedSecrets using the public certificate of the in-cluster controller.
 package cipher
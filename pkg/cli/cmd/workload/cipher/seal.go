@@ -0,0 +1,191 @@
+package cipher
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"os"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sealedsecrets"
+	"github.com/spf13/cobra"
+)
+
+// sealOptions holds the flag values of the seal command.
+type sealOptions struct {
+	certFile            string
+	controllerName      string
+	controllerNamespace string
+	scope               string
+	namespace           string
+	context             string
+}
+
+// NewSealCmd creates and returns the seal command.
+func NewSealCmd() *cobra.Command {
+	opts := sealOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "seal <file>...",
+		Short: "Convert Secret manifests into SealedSecrets",
+		Long: `Convert Kubernetes Secret manifests into Bitnami SealedSecrets in place.
+
+Every v1 Secret in the given files is replaced by a SealedSecret whose values
+only the Sealed Secrets controller in the cluster can decrypt, so the files can
+be committed safely. Other documents in the files are kept as they are.
+
+The controller's public certificate is fetched from the cluster in the current
+kubeconfig context. Pass --cert to seal offline with a certificate saved earlier.
+Install the controller with 'ksail cluster create --sealed-secrets Enabled' or
+spec.cluster.sealedSecrets: Enabled in ksail.yaml.
+
+Scopes:
+  - strict: values are bound to the Secret's name and namespace (default)
+  - namespace-wide: the Secret may be renamed within its namespace
+  - cluster-wide: the Secret may be renamed and moved to any namespace
+
+SOPS-encrypted Secrets are rejected; decrypt them first with
+'ksail workload cipher decrypt'.
+
+Example:
+  ksail workload cipher seal k8s/apps/db/secret.yaml`,
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return handleSealRunE(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.certFile, "cert", "",
+		"PEM certificate of the controller; skips fetching it from the cluster")
+	cmd.Flags().StringVar(&opts.controllerName, "controller-name", sealedsecrets.ControllerName,
+		"Name of the Sealed Secrets controller Service")
+	cmd.Flags().StringVar(&opts.controllerNamespace, "controller-namespace",
+		sealedsecrets.ControllerNamespace,
+		"Namespace of the Sealed Secrets controller")
+	cmd.Flags().StringVar(&opts.scope, "scope", string(sealedsecrets.ScopeStrict),
+		"Sealing scope: strict, namespace-wide, or cluster-wide")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default",
+		"Namespace assumed for Secrets that do not declare one")
+	cmd.Flags().StringVar(&opts.context, "context", "",
+		"Kubeconfig context of the cluster to fetch the certificate from")
+
+	return cmd
+}
+
+// handleSealRunE seals every Secret in the given files.
+func handleSealRunE(cmd *cobra.Command, args []string, opts sealOptions) error {
+	scope, err := sealedsecrets.ParseScope(opts.scope)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := loadSealingKey(cmd, opts)
+	if err != nil {
+		return err
+	}
+
+	sealer := &sealedsecrets.Sealer{
+		PublicKey:        publicKey,
+		Scope:            scope,
+		DefaultNamespace: opts.namespace,
+	}
+
+	for _, path := range args {
+		err = sealFile(cmd, sealer, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadSealingKey reads the controller's public key from --cert, or fetches it
+// from the controller running in the cluster.
+func loadSealingKey(cmd *cobra.Command, opts sealOptions) (*rsa.PublicKey, error) {
+	var (
+		certPEM []byte
+		err     error
+	)
+
+	if opts.certFile != "" {
+		certPEM, err = os.ReadFile(opts.certFile) //nolint:gosec // path is a user-supplied certificate
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate: %w", err)
+		}
+	} else {
+		clientset, clientErr := k8s.NewClientset(
+			kubeconfig.GetKubeconfigPathSilently(cmd),
+			opts.context,
+		)
+		if clientErr != nil {
+			return nil, fmt.Errorf("failed to create kubernetes client: %w", clientErr)
+		}
+
+		certPEM, err = sealedsecrets.FetchCertificate(
+			cmd.Context(),
+			clientset,
+			opts.controllerNamespace,
+			opts.controllerName,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	publicKey, err := sealedsecrets.ParsePublicKey(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sealing certificate: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+// sealFile rewrites path with its Secrets sealed, keeping the file mode.
+func sealFile(cmd *cobra.Command, sealer *sealedsecrets.Sealer, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is a user-supplied manifest
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	sealed, count, err := sealer.SealManifest(data)
+	if err != nil {
+		return fmt.Errorf("failed to seal %s: %w", path, err)
+	}
+
+	if count == 0 {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "no Secrets found in %s",
+			Args:    []any{path},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return nil
+	}
+
+	err = os.WriteFile(path, sealed, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "sealed %d secret(s) in %s",
+		Args:    []any{count, path},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package cipher_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload/cipher"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sealedsecrets"
+	"github.com/gkampitakis/go-snaps/snaps"
+)
+
+// writeSealingCert writes a self-signed controller certificate into dir.
+func writeSealingCert(t *testing.T, dir string) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sealed-secret"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	certPath := filepath.Join(dir, "cert.pem")
+
+	err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	return certPath
+}
+
+func TestSealCommandHelp(t *testing.T) {
+	t.Parallel()
+
+	cmd := cipher.NewSealCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--help"})
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Errorf("expected no error executing --help, got: %v", err)
+	}
+
+	snaps.MatchSnapshot(t, out.String())
+}
+
+func TestCipherCommandHasSealSubcommand(t *testing.T) {
+	t.Parallel()
+
+	if findSubcommand(cipher.NewCipherCmd(), "seal") == nil {
+		t.Error("expected seal subcommand to exist")
+	}
+}
+
+func TestSealCommandRequiresFile(t *testing.T) {
+	t.Parallel()
+
+	cmd := cipher.NewSealCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{})
+
+	if cmd.Execute() == nil {
+		t.Error("expected error when no file is given")
+	}
+}
+
+func TestSealCommandInvalidScope(t *testing.T) {
+	t.Parallel()
+
+	cmd := cipher.NewSealCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--scope", "global", "secret.yaml"})
+
+	err := cmd.Execute()
+	if !errors.Is(err, sealedsecrets.ErrInvalidScope) {
+		t.Errorf("expected ErrInvalidScope, got: %v", err)
+	}
+}
+
+func TestSealCommandSealsFileInPlace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certPath := writeSealingCert(t, dir)
+	manifestPath := filepath.Join(dir, "secret.yaml")
+
+	manifest := `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+stringData:
+  password: hunter2
+`
+
+	err := os.WriteFile(manifestPath, []byte(manifest), 0o640)
+	if err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	cmd := cipher.NewSealCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--cert", certPath, manifestPath})
+
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v\n%s", err, out.String())
+	}
+
+	if !strings.Contains(out.String(), "sealed 1 secret(s)") {
+		t.Errorf("expected success message, got: %q", out.String())
+	}
+
+	sealed, err := os.ReadFile(manifestPath) //nolint:gosec // test path
+	if err != nil {
+		t.Fatalf("failed to read sealed manifest: %v", err)
+	}
+
+	if !strings.Contains(string(sealed), "kind: SealedSecret") ||
+		strings.Contains(string(sealed), "hunter2") {
+		t.Errorf("expected a SealedSecret without plaintext, got:\n%s", sealed)
+	}
+
+	info, err := os.Stat(manifestPath)
+	if err != nil {
+		t.Fatalf("failed to stat sealed manifest: %v", err)
+	}
+
+	if info.Mode().Perm() != 0o640 {
+		t.Errorf("expected file mode to be kept, got %v", info.Mode().Perm())
+	}
+}
+
+func TestSealCommandWarnsWithoutSecrets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certPath := writeSealingCert(t, dir)
+	manifestPath := filepath.Join(dir, "configmap.yaml")
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n"
+
+	err := os.WriteFile(manifestPath, []byte(manifest), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	cmd := cipher.NewSealCmd()
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--cert", certPath, manifestPath})
+
+	err = cmd.Execute()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "no Secrets found") {
+		t.Errorf("expected warning, got: %q", out.String())
+	}
+
+	unchanged, _ := os.ReadFile(manifestPath) //nolint:gosec // test path
+	if string(unchanged) != manifest {
+		t.Errorf("expected file to be unchanged, got:\n%s", unchanged)
+	}
+}
@@ -0,0 +1,147 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator/openapi"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/discovery"
+)
+
+// ErrClusterSchemaViolation is returned when validated manifests violate the
+// OpenAPI v3 schemas served by the cluster (--cluster-openapi).
+var ErrClusterSchemaViolation = errors.New("cluster schema violation")
+
+// buildClusterSchemaValidator loads the OpenAPI v3 schemas of the cluster in
+// the kubeconfig, targeting spec.cluster.connection.context when configured.
+// It returns (nil, nil) when --cluster-openapi is not set, so callers can treat
+// a nil validator as "cluster schema validation disabled". Unlike
+// --update-schemas, an unreachable cluster fails the run: the flag asks for
+// the cluster to be the source of truth.
+func buildClusterSchemaValidator(
+	cmd *cobra.Command,
+	cfg *v1alpha1.Cluster,
+	enabled bool,
+) (*openapi.Validator, error) {
+	if !enabled {
+		return nil, nil //nolint:nilnil // nil validator + nil error means "disabled" by design.
+	}
+
+	var contextName string
+	if cfg != nil {
+		contextName = cfg.Spec.Cluster.Connection.Context
+	}
+
+	schemaValidator, err := loadClusterSchemas(kubeconfig.GetKubeconfigPathSilently(cmd), contextName)
+	if err != nil {
+		return nil, fmt.Errorf("load cluster OpenAPI schemas: %w", err)
+	}
+
+	return schemaValidator, nil
+}
+
+// loadClusterSchemas fetches every OpenAPI v3 group-version document of the
+// cluster selected by kubeconfigPath and contextName.
+//
+//nolint:gochecknoglobals // test seam replacing the cluster the schemas are fetched from
+var loadClusterSchemas = func(kubeconfigPath, contextName string) (*openapi.Validator, error) {
+	restConfig, err := k8s.BuildRESTConfig(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("build rest config: %w", err)
+	}
+
+	restConfig.Timeout = clusterCRDListTimeout
+
+	client, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create discovery client: %w", err)
+	}
+
+	schemaValidator, err := openapi.NewValidatorFromClient(client.OpenAPIV3())
+	if err != nil {
+		return nil, fmt.Errorf("load OpenAPI v3 schemas: %w", err)
+	}
+
+	return schemaValidator, nil
+}
+
+// validateClusterSchemaDocuments validates every document of a rendered or
+// built manifest stream against the cluster's OpenAPI v3 schemas, honoring the
+// same kind exclusions and attribution as evaluateCELDocuments. Kinds the
+// cluster does not serve are left to kubeconform. A nil validator
+// (--cluster-openapi not set) is a no-op.
+func validateClusterSchemaDocuments(
+	schemaValidator *openapi.Validator,
+	data []byte,
+	source string,
+	skipKinds []string,
+	attribution map[string]string,
+) error {
+	if schemaValidator == nil {
+		return nil
+	}
+
+	var violations []string
+
+	for _, docBytes := range fsutil.SplitYAMLDocuments(data) {
+		obj, ok := decodeDocumentObject(docBytes)
+		if !ok {
+			continue
+		}
+
+		if kind, _ := obj["kind"].(string); kind != "" && slices.Contains(skipKinds, kind) {
+			continue
+		}
+
+		for _, validationErr := range schemaValidator.Validate(obj).Errors {
+			violations = append(
+				violations,
+				describeClusterSchemaError(validationErr, obj, source, attribution),
+			)
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("%w:\n  %s", ErrClusterSchemaViolation, strings.Join(violations, "\n  "))
+	}
+
+	return nil
+}
+
+// describeClusterSchemaError renders a field-level error with the offending
+// document's identity, source, render layer and fix suggestion, reading like
+// describeCELViolation.
+func describeClusterSchemaError(
+	validationErr validator.ValidationError,
+	obj map[string]any,
+	source string,
+	attribution map[string]string,
+) string {
+	identity := documentIdentityFromObject(obj)
+	if identity == "" {
+		identity, _ = obj["kind"].(string)
+	}
+
+	described := fmt.Sprintf(
+		"%s (in %s): %s: %s",
+		identity, source, validationErr.Field, validationErr.Message,
+	)
+
+	if layer := attribution[identity]; layer != "" {
+		described += " (from " + layer + ")"
+	}
+
+	if validationErr.FixSuggestion != "" {
+		described += " — " + validationErr.FixSuggestion
+	}
+
+	return described
+}
@@ -7,12 +7,36 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
 	"github.com/devantler-tech/ksail/v7/pkg/client/kubectl"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/hostdebug"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/session"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/spf13/cobra"
 )
 
-const defaultDebugImage = "docker.io/library/alpine:latest"
+// defaultToolboxImage is the debug container image used for pod, node, and
+// --host debugging when --image is not given. netshoot carries the usual
+// network and process troubleshooting tools; it is fully qualified on docker.io
+// and pinned so clusters with a docker.io mirror pull it through the local
+// pull-through cache instead of the internet.
+const defaultToolboxImage = "docker.io/nicolaka/netshoot:v0.16"
+
+// nodeDebugProfile is the kubectl debug profile used by --node: a privileged
+// container in the node's host namespaces.
+const nodeDebugProfile = "sysadmin"
+
+// debugToolboxHelp documents the toolbox image default and --node mode.
+const debugToolboxHelp = `Without --image, debug containers use the ` + defaultToolboxImage + `
+toolbox image, which a cluster with a docker.io mirror pulls through the local
+pull-through cache. --copy-to and --set-image keep kubectl's behavior and add no
+debug container unless --image is given.
+
+With --node <node-name> a privileged debug pod (the sysadmin profile unless
+--profile is set) is scheduled on the node, running in its host namespaces with
+the host filesystem mounted at /host, e.g.:
+
+  ksail workload debug --node dev-worker -it -- chroot /host`
 
 // ErrHostModePositionalArgs is returned when kubectl-style positional args are
 // used with --host mode.
@@ -21,15 +45,43 @@ var ErrHostModePositionalArgs = errors.New(
 		"place the container command after '--' (e.g., debug --host <node> -- /bin/sh)",
 )
 
+// ErrNodeModePositionalArgs is returned when a debug target is passed together
+// with --node, which selects the target itself.
+var ErrNodeModePositionalArgs = errors.New(
+	"--node mode does not accept a debug target; " +
+		"place the container command after '--' (e.g., debug --node <node> -it -- sh)",
+)
+
+// ErrNodeWithHost is returned when --node and --host are combined.
+var ErrNodeWithHost = errors.New("--node and --host cannot be combined")
+
+// ErrRecordWithoutHost is returned when --record or --redact is used without
+// --host: only host-level sessions are recorded.
+var ErrRecordWithoutHost = errors.New("--record and --redact require --host")
+
+// hostDebugFlags holds the flags that only apply in --host mode, plus the
+// --node target of Kubernetes node debugging.
+type hostDebugFlags struct {
+	node        string
+	record      bool
+	redactRules []string
+	debugNode   string
+}
+
 // NewDebugCmd creates the workload debug command.
 //
-// Without --host it wraps kubectl debug (ephemeral containers, node debugging).
-// With --host <node-name> it performs host-level debugging routed per distribution
+// Without --host it wraps kubectl debug (ephemeral containers, node debugging),
+// defaulting the debug image to a toolbox image and adding --node as a shortcut
+// for privileged node debugging. With --host <node-name> it performs host-level debugging routed per distribution
 // by the pkg/svc/hostdebug service:
 //   - Vanilla/K3s/VCluster (Docker): interactive docker exec into the node container
 //   - Talos (all providers): Talos SDK DebugClient.ContainerRun()
+//
+// With --record the host-level session transcript is saved under
+// ~/.ksail/sessions, redacted by the pkg/svc/session default rules plus any
+// --redact patterns.
 func NewDebugCmd() *cobra.Command {
-	var hostNode string
+	var hostFlags hostDebugFlags
 
 	kubectlDebugCmd := newKubectlCommand(
 		func(client *kubectl.Client, kubeconfigPath string) *cobra.Command {
@@ -42,7 +94,11 @@ func NewDebugCmd() *cobra.Command {
 	originalRun := kubectlDebugCmd.Run
 
 	kubectlDebugCmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if hostNode != "" {
+		if hostFlags.node != "" && hostFlags.debugNode != "" {
+			return ErrNodeWithHost
+		}
+
+		if hostFlags.node != "" {
 			// In --host mode, only accept args after '--' as the container command.
 			// Positional args without '--' or before '--' are likely kubectl-style
 			// targets (e.g., node/<name>) that would be misinterpreted.
@@ -51,7 +107,25 @@ func NewDebugCmd() *cobra.Command {
 				return ErrHostModePositionalArgs
 			}
 
-			return runHostDebug(cmd, hostNode, args)
+			return runHostDebug(cmd, hostFlags, args)
+		}
+
+		if hostFlags.record || len(hostFlags.redactRules) > 0 {
+			return ErrRecordWithoutHost
+		}
+
+		if hostFlags.debugNode != "" {
+			var err error
+
+			args, err = targetDebugNode(cmd, hostFlags.debugNode, args)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := applyDebugImageDefault(cmd)
+		if err != nil {
+			return err
 		}
 
 		// Fall through to kubectl debug.
@@ -70,11 +144,38 @@ func NewDebugCmd() *cobra.Command {
 	kubectlDebugCmd.Run = nil
 
 	kubectlDebugCmd.Flags().StringVar(
-		&hostNode,
+		&hostFlags.node,
 		"host",
 		"",
 		"Node name for host-level debugging (bypasses Kubernetes, targets the infrastructure node directly)",
 	)
+	kubectlDebugCmd.Flags().BoolVar(
+		&hostFlags.record,
+		"record",
+		false,
+		"Record the --host session transcript under ~/.ksail/sessions",
+	)
+	kubectlDebugCmd.Flags().StringArrayVar(
+		&hostFlags.redactRules,
+		"redact",
+		nil,
+		"Additional regular expression to redact from recorded transcripts "+
+			"(the first capture group, or the whole match; repeatable)",
+	)
+
+	kubectlDebugCmd.Flags().StringVar(
+		&hostFlags.debugNode,
+		"node",
+		"",
+		"Node name to schedule a privileged debug pod on (shortcut for node/<name> --profile="+nodeDebugProfile+")",
+	)
+
+	if imageFlag := kubectlDebugCmd.Flags().Lookup("image"); imageFlag != nil {
+		imageFlag.Usage = "Container image to use for debug container (default " + defaultToolboxImage +
+			" unless --copy-to or --set-image is set)"
+	}
+
+	kubectlDebugCmd.Long += "\n\n" + debugToolboxHelp
 
 	kubectlDebugCmd.Annotations = map[string]string{
 		annotations.AnnotationPermission: permissionWrite,
@@ -83,9 +184,58 @@ func NewDebugCmd() *cobra.Command {
 	return kubectlDebugCmd
 }
 
+// targetDebugNode turns --node into kubectl's node/<name> debug target and
+// selects the privileged profile unless --profile was set. kubectl splits the
+// target from the container command with ArgsLenAtDash, so the positional args
+// are re-parsed with the target prepended; flags already parsed keep their
+// values.
+func targetDebugNode(cmd *cobra.Command, node string, args []string) ([]string, error) {
+	dashIdx := cmd.ArgsLenAtDash()
+	if dashIdx > 0 || (dashIdx == -1 && len(args) > 0) {
+		return nil, ErrNodeModePositionalArgs
+	}
+
+	if profileFlag := cmd.Flags().Lookup("profile"); profileFlag != nil && !profileFlag.Changed {
+		err := profileFlag.Value.Set(nodeDebugProfile)
+		if err != nil {
+			return nil, fmt.Errorf("set --profile: %w", err)
+		}
+	}
+
+	nodeArgs := []string{"node/" + node}
+	if dashIdx == 0 {
+		nodeArgs = append(append(nodeArgs, "--"), args...)
+	}
+
+	err := cmd.Flags().Parse(nodeArgs)
+	if err != nil {
+		return nil, fmt.Errorf("target node %s: %w", node, err)
+	}
+
+	return cmd.Flags().Args(), nil
+}
+
+// applyDebugImageDefault sets --image to the toolbox image when it was not
+// given. Copies made with --copy-to or --set-image are left alone, since an
+// image there adds a debug container the user did not ask for.
+func applyDebugImageDefault(cmd *cobra.Command) error {
+	for _, name := range []string{"image", "copy-to", "set-image"} {
+		if flag := cmd.Flags().Lookup(name); flag == nil || flag.Changed {
+			return nil
+		}
+	}
+
+	err := cmd.Flags().Set("image", defaultToolboxImage)
+	if err != nil {
+		return fmt.Errorf("set --image: %w", err)
+	}
+
+	return nil
+}
+
 // runHostDebug resolves the cluster and flag inputs from the cobra command and
 // delegates to the hostdebug service for distribution/provider routing.
-func runHostDebug(cmd *cobra.Command, nodeName string, args []string) error {
+func runHostDebug(cmd *cobra.Command, flags hostDebugFlags, args []string) error {
 	kubeconfigPath := kubeconfig.GetKubeconfigPathSilently(cmd)
 
 	contextName := ""
@@ -93,23 +243,89 @@ func runHostDebug(cmd *cobra.Command, nodeName string, args []string) error {
 		contextName, _ = cmd.Flags().GetString("context")
 	}
 
-	info, err := clusterdetector.DetectInfo(cmd.Context(), kubeconfigPath, contextName)
+	target, err := clusterdetector.DetectTarget(cmd.Context(), kubeconfigPath, contextName)
 	if err != nil {
 		return fmt.Errorf("detect cluster info: %w", err)
 	}
 
+	// Pod debugging works against any context; node debugging needs a cluster
+	// whose nodes KSail can reach.
+	err = target.Require(clusterdetector.CapabilityNodeDebug)
+	if err != nil {
+		return fmt.Errorf("debug node: %w", err)
+	}
+
 	debugImage, _ := cmd.Flags().GetString("image")
 	if debugImage == "" {
-		debugImage = defaultDebugImage
+		debugImage = defaultToolboxImage
 	}
 
-	//nolint:wrapcheck // hostdebug sentinels (ErrNodeNotFound, …) must stay unwrapped for tests
-	return hostdebug.Run(cmd.Context(), hostdebug.Options{
-		Info:           info,
+	opts := hostdebug.Options{
+		Info:           target.Info,
 		KubeconfigPath: kubeconfigPath,
 		ContextName:    contextName,
-		NodeName:       nodeName,
+		NodeName:       flags.node,
 		Image:          debugImage,
 		Args:           args,
+	}
+
+	if !flags.record {
+		//nolint:wrapcheck // hostdebug sentinels (ErrNodeNotFound, …) must stay unwrapped for tests
+		return hostdebug.Run(cmd.Context(), opts)
+	}
+
+	return runRecordedHostDebug(cmd, opts, flags.redactRules)
+}
+
+// runRecordedHostDebug runs a host-level debug session while recording its
+// redacted transcript to a new log under ~/.ksail/sessions.
+func runRecordedHostDebug(
+	cmd *cobra.Command,
+	opts hostdebug.Options,
+	redactRules []string,
+) error {
+	redactor, err := session.NewRedactor(redactRules)
+	if err != nil {
+		return fmt.Errorf("parse --redact: %w", err)
+	}
+
+	command := opts.Args
+	if len(command) == 0 {
+		command = []string{"/bin/sh"}
+	}
+
+	header := session.NewHeader(opts.Info.ClusterName, opts.NodeName, command)
+
+	logFile, err := state.CreateSessionLog(opts.Info.ClusterName, opts.NodeName, header.Started)
+	if err != nil {
+		return fmt.Errorf("create session recording: %w", err)
+	}
+
+	recorder, err := session.NewRecorder(logFile, header, redactor)
+	if err != nil {
+		return fmt.Errorf("start session recording: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "recording session to %s",
+		Args:    []any{logFile.Name()},
+		Writer:  cmd.ErrOrStderr(),
 	})
+
+	opts.Transcript = recorder
+	runErr := hostdebug.Run(cmd.Context(), opts)
+
+	finishErr := recorder.Finish(runErr)
+	if finishErr != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "session recording incomplete: %v",
+			Args:    []any{finishErr},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	//nolint:wrapcheck // hostdebug sentinels (ErrNodeNotFound, …) must stay unwrapped for tests
+	return runErr
 }
@@ -0,0 +1,169 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+	"github.com/spf13/cobra"
+)
+
+// ErrDeployKeyRequiresGitSource is returned when no spec.workload.git source is
+// configured: deploy keys authenticate the engine's clone of a Git repository.
+var ErrDeployKeyRequiresGitSource = errors.New(
+	"deploy key requires a Git source (spec.workload.git.url, see 'ksail init --push-to')",
+)
+
+const deployKeyCmdLong = `Generate the SSH deploy key the GitOps engine clones a private
+spec.workload.git repository with, and print its public key.
+
+The key is an ed25519 key pair kept under ~/.ksail/deploy-keys, one per
+repository. It is generated on first use; later runs print the same key. With
+spec.workload.git.deployKey set, 'ksail cluster create' stores it in-cluster as
+the Flux clone secret (with the host's known_hosts) or the Argo CD repository
+credential, and the engine clones the repository over SSH.
+
+When the repository is hosted on GitHub and a token is available (--git-token,
+GH_TOKEN/GITHUB_TOKEN, or the GitHub CLI login), the key is registered as a
+read-only deploy key; --read-write grants it push access. Otherwise, or with
+--register=false, add the printed public key in the repository's deploy key
+settings.
+
+The public key is written to stdout and progress messages to stderr, so the
+output can be redirected to a file.`
+
+const deployKeyCmdExample = `  # Generate the deploy key and register it on GitHub
+  ksail workload deploy-key
+
+  # Print the public key to register it by hand
+  ksail workload deploy-key --register=false > deploy-key.pub`
+
+// deployKeyOptions carries the deploy-key command's flag values into the run function.
+type deployKeyOptions struct {
+	gitToken  string
+	register  bool
+	readWrite bool
+}
+
+// NewDeployKeyCmd creates the workload deploy-key command.
+func NewDeployKeyCmd() *cobra.Command {
+	opts := deployKeyOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "deploy-key",
+		Short:        "Generate and register the SSH deploy key for a private Git source",
+		Long:         deployKeyCmdLong,
+		Example:      deployKeyCmdExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.gitToken, "git-token", "",
+		"Git provider API token used to register the deploy key (default: provider SDK lookup)")
+	cmd.Flags().BoolVar(&opts.register, "register", true,
+		"Register the deploy key on the Git provider when a token is available")
+	cmd.Flags().BoolVar(&opts.readWrite, "read-write", false,
+		"Grant the registered deploy key push access")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return runDeployKey(cmd, opts)
+	}
+
+	return cmd
+}
+
+func runDeployKey(cmd *cobra.Command, opts deployKeyOptions) error {
+	// Load silently: stdout carries only the public key.
+	cfgManager := configmanager.NewCommandConfigManager(
+		cmd, configmanager.DefaultClusterFieldSelectors(),
+	)
+
+	clusterCfg, err := cfgManager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	git := clusterCfg.Spec.Workload.Git
+	if !git.IsEnabled() {
+		return ErrDeployKeyRequiresGitSource
+	}
+
+	dir, err := deploykey.DefaultDir()
+	if err != nil {
+		return err
+	}
+
+	key, created, err := deploykey.LoadOrCreate(dir, git.URL)
+	if err != nil {
+		return fmt.Errorf("load deploy key: %w", err)
+	}
+
+	if created {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.GenerateType,
+			Content: "generated deploy key %s",
+			Args:    []any{key.Path},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	if opts.register {
+		registerDeployKey(cmd, git.URL, key, opts)
+	}
+
+	if !git.DeployKey {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "set spec.workload.git.deployKey to true to have the GitOps engine clone with this key",
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), key.AuthorizedKey)
+	if err != nil {
+		return fmt.Errorf("write public key: %w", err)
+	}
+
+	return nil
+}
+
+// registerDeployKey registers key on the repository through the Git provider API,
+// reporting a failure as a warning: the printed public key can still be added by hand.
+func registerDeployKey(
+	cmd *cobra.Command,
+	repositoryURL string,
+	key deploykey.Key,
+	opts deployKeyOptions,
+) {
+	token := opts.gitToken
+	if ref, err := gitprovider.ParseRepoRef(repositoryURL); err == nil {
+		token = gitprovider.ResolveToken(ref.Provider, opts.gitToken)
+	}
+
+	err := deploykey.Register(cmd.Context(), repositoryURL, token, key, opts.readWrite)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "could not register the deploy key automatically: %v",
+			Args:    []any{err},
+			Writer:  cmd.ErrOrStderr(),
+		})
+
+		return
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "registered deploy key on %s",
+		Args:    []any{repositoryURL},
+		Writer:  cmd.ErrOrStderr(),
+	})
+}
@@ -0,0 +1,87 @@
+package workload_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeployKeyCmdHasCorrectDefaults(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewDeployKeyCmd()
+
+	assert.Equal(t, "deploy-key", cmd.Name())
+	assert.Equal(t, "true", cmd.Flags().Lookup("register").DefValue)
+	assert.Equal(t, "false", cmd.Flags().Lookup("read-write").DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("git-token"))
+}
+
+//nolint:paralleltest // t.Chdir is incompatible with t.Parallel.
+func TestDeployKeyCmdRequiresGitSource(t *testing.T) {
+	// An empty directory has no ksail.yaml, so no spec.workload.git source.
+	t.Chdir(t.TempDir())
+
+	cmd := workload.NewDeployKeyCmd()
+	cmd.SetArgs([]string{})
+
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, workload.ErrDeployKeyRequiresGitSource)
+}
+
+//nolint:paralleltest // Changes the working directory and HOME.
+func TestDeployKeyCmdPrintsTheSamePublicKeyOnEveryRun(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := t.TempDir()
+	ksailYAML := `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  workload:
+    git:
+      url: https://github.com/org/platform.git
+      deployKey: true
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ksail.yaml"), []byte(ksailYAML), 0o600))
+	t.Chdir(dir)
+
+	run := func() (string, string) {
+		cmd := workload.NewDeployKeyCmd()
+		cmd.SetArgs([]string{"--register=false"})
+
+		var stdout, stderr bytes.Buffer
+
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+
+		require.NoError(t, cmd.Execute())
+
+		return stdout.String(), stderr.String()
+	}
+
+	publicKey, messages := run()
+	assert.True(t, strings.HasPrefix(publicKey, "ssh-ed25519 "), publicKey)
+	assert.Contains(t, messages, "generated deploy key")
+
+	stored, err := os.ReadFile(
+		filepath.Join(home, ".ksail", "deploy-keys", "github.com_org_platform.pub"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, string(stored), publicKey)
+
+	again, messages := run()
+	assert.Equal(t, publicKey, again)
+	assert.NotContains(t, messages, "generated deploy key")
+}
@@ -0,0 +1,483 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	imagecmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/image"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s/readiness"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/operatordev"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/workloadwatch"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrDevModeRequired is returned when workload dev runs without a dev mode.
+var ErrDevModeRequired = errors.New("select a development mode: --operator or --webhook")
+
+// defaultOperatorDevTimeout bounds the wait for cert-manager to inject CA
+// bundles and for the restarted manager to become ready.
+const defaultOperatorDevTimeout = 2 * time.Minute
+
+const devCmdLong = `Run a development loop that keeps the cluster running your latest code.
+
+With --operator, watch a controller project scaffolded by kubebuilder or the
+Operator SDK (PROJECT_DIR, default the current directory) and, on every change:
+
+  - Go sources and anything else outside config/: run the --hook commands
+    (e.g. "make manifests generate"), re-apply the CRDs, rebuild the manager
+    image from the project's Dockerfile, load it into the cluster nodes (or push
+    it to the local registry with --push), then point the manager Deployment at
+    it and restart it.
+  - CRDs below config/crd: re-apply them with server-side apply. The config/crd
+    kustomization is built when there is one, so conversion webhook and
+    cert-manager CA injection patches are kept. For CRDs converting through a
+    webhook whose CA cert-manager injects (cert-manager.io/inject-ca-from), the
+    loop waits until cainjector has (re)injected the CA bundle, so conversion
+    requests do not fail TLS verification after a re-apply.
+
+Test files, other manifests below config/ and bin/ are ignored.
+
+The manager Deployment defaults to the kubebuilder convention,
+<projectName>-controller-manager in the <projectName>-system namespace; deploy
+it once (e.g. 'make deploy IMG=controller:dev') before starting the loop. The
+image is rebuilt under the tag the manager container runs unless --image is
+set; that tag must not use imagePullPolicy Always, or the node ignores the
+loaded image. Use --initial-sync to apply the CRDs and roll out the current code
+before watching. Press Ctrl+C to stop.
+
+With --webhook, serve the admission webhooks of a controller from a process on
+your host instead, e.g. under a debugger. Every webhook of the Validating- and
+MutatingWebhookConfigurations that calls the controller's webhook Service
+(--webhook-service, default <projectName>-webhook-service in the
+<projectName>-system namespace of the project in PROJECT_DIR) is pointed at
+https://<host>:<webhook-port> with the path it calls, where <host> is the address
+the cluster reaches your host at: the gateway of the cluster's Docker network
+(host.docker.internal on Docker Desktop), or --webhook-host. A serving
+certificate for that address is written to --cert-dir, which defaults to where
+controller-runtime's webhook server looks for one, and the webhooks trust the
+CA that signed it. cert-manager's CA injection is suspended meanwhile. Start the
+controller locally with its webhook server on --webhook-port, listening on all
+interfaces. Press Ctrl+C to point the webhooks back at the Service; a session
+that did not exit cleanly is undone by the next one.`
+
+const devCmdExample = `  # Iterate on the controller project in the current directory
+  ksail workload dev --operator
+
+  # Regenerate manifests and code before each rebuild and sync once on startup
+  ksail workload dev --operator ./guestbook --hook "make manifests generate" --initial-sync
+
+  # Deliver the manager image through the local registry (Talos clusters)
+  ksail workload dev --operator --push --image guestbook:dev
+
+  # Serve the webhooks of the controller project in the current directory locally
+  ksail workload dev --webhook
+
+  # Serve a webhook Service's webhooks from a local process on port 8443
+  ksail workload dev --webhook --webhook-service my-system/my-webhook --webhook-port 8443 --cert-dir ./certs`
+
+// devOptions carries the dev command's flag values into the run function.
+type devOptions struct {
+	operator       bool
+	webhook        bool
+	namespace      string
+	deployment     string
+	container      string
+	image          string
+	push           bool
+	hooks          []string
+	initialSync    bool
+	timeout        time.Duration
+	webhookService string
+	webhookPort    int
+	certDir        string
+	webhookHost    string
+}
+
+// NewDevCmd creates the workload dev command.
+func NewDevCmd() *cobra.Command {
+	opts := devOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "dev [PROJECT_DIR]",
+		Short:        "Develop a local controller project against the cluster",
+		Long:         devCmdLong,
+		Example:      devCmdExample,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cfgManager := createImageConfigManager(cmd)
+
+	cmd.Flags().BoolVar(&opts.operator, "operator", false,
+		"Develop a kubebuilder or Operator SDK controller: rebuild its image, re-apply CRDs and restart the manager")
+	cmd.Flags().BoolVar(&opts.webhook, "webhook", false,
+		"Serve a controller's admission webhooks from a process on the host, e.g. under a debugger")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "",
+		"Namespace of the manager Deployment and webhook Service (default: <projectName>-system)")
+	cmd.Flags().StringVar(&opts.deployment, "deployment", "",
+		"Name of the manager Deployment (default: <projectName>-controller-manager)")
+	cmd.Flags().StringVar(&opts.container, "container", operatordev.DefaultContainer,
+		"Container of the manager Deployment that runs the controller")
+	cmd.Flags().StringVar(&opts.image, "image", "",
+		"Name and tag to build the manager image as (default: the image the manager container runs)")
+	cmd.Flags().BoolVar(&opts.push, "push", false,
+		"Push the manager image to the cluster's local registry instead of loading it into the nodes")
+	cmd.Flags().StringArrayVar(&opts.hooks, "hook", nil,
+		"Shell command to run before each rebuild, e.g. \"make manifests generate\" (repeatable)")
+	cmd.Flags().BoolVar(&opts.initialSync, "initial-sync", false,
+		"Apply the CRDs and roll out the current code once before watching")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", defaultOperatorDevTimeout,
+		"How long to wait for CA bundle injection and for the restarted manager to become ready")
+	cmd.Flags().StringVar(&opts.webhookService, "webhook-service", "",
+		"Webhook Service as <namespace>/<name> whose webhooks to serve locally (default: <projectName>-webhook-service)")
+	cmd.Flags().IntVar(&opts.webhookPort, "webhook-port", defaultWebhookPort,
+		"Port the local webhook server listens on")
+	cmd.Flags().StringVar(&opts.certDir, "cert-dir", "",
+		"Directory to write the local webhook server's tls.crt and tls.key to (default: $TMPDIR/k8s-webhook-server/serving-certs)")
+	cmd.Flags().StringVar(&opts.webhookHost, "webhook-host", "",
+		"Address the cluster reaches the host at (default: the gateway of the cluster's Docker network)")
+	cmd.MarkFlagsMutuallyExclusive("operator", "webhook")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !opts.operator && !opts.webhook {
+			return ErrDevModeRequired
+		}
+
+		projectDir := "."
+		if len(args) > 0 {
+			projectDir = args[0]
+		}
+
+		if opts.webhook {
+			return runWebhookDev(cmd, cfgManager, projectDir, opts)
+		}
+
+		return runOperatorDev(cmd, cfgManager, projectDir, opts)
+	}
+
+	return cmd
+}
+
+// newOperatorDevClients builds the clients the operator loop talks to the
+// cluster with. It is a package-level seam so tests can substitute fakes.
+//
+//nolint:gochecknoglobals // Test seam: lets tests inject fake clientsets without a live cluster.
+var newOperatorDevClients = func(
+	clusterCfg *v1alpha1.Cluster,
+) (kubernetes.Interface, apiextensionsclient.Interface, error) {
+	kubeconfigPath, err := getKubeconfigPath(clusterCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	restConfig, err := k8s.BuildRESTConfig(kubeconfigPath, clusterCfg.Spec.Cluster.Connection.Context)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build REST config: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create Kubernetes client: %w", err)
+	}
+
+	crdClient, err := apiextensionsclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create apiextensions client: %w", err)
+	}
+
+	return client, crdClient, nil
+}
+
+// withOperatorBuilder runs an operation with the builder that delivers the
+// manager image to the cluster; a seam so tests can build without Docker.
+//
+//nolint:gochecknoglobals // Test seam: lets tests build images without Docker or a cluster.
+var withOperatorBuilder = imagecmd.WithBuilder
+
+// operatorLoop holds the state of one `workload dev --operator` session.
+type operatorLoop struct {
+	cmd       *cobra.Command
+	project   *operatordev.Project
+	client    kubernetes.Interface
+	crdClient apiextensionsclient.Interface
+	builder   imagecmd.Builder
+	opts      devOptions
+}
+
+// runOperatorDev resolves the project and its manager Deployment, then runs the
+// operator loop until interrupted.
+func runOperatorDev(
+	cmd *cobra.Command,
+	cfgManager *configmanager.ConfigManager,
+	projectDir string,
+	opts devOptions,
+) error {
+	// As for watch, feedback of a long-running loop must reach the terminal
+	// rather than the root command's buffered stderr.
+	cmd.SetErr(os.Stderr)
+
+	project, err := operatordev.DetectProject(projectDir)
+	if err != nil {
+		return fmt.Errorf("detect controller project: %w", err)
+	}
+
+	if opts.namespace != "" {
+		project.Namespace = opts.namespace
+	}
+
+	if opts.deployment != "" {
+		project.Deployment = opts.deployment
+	}
+
+	clusterCfg, err := cfgManager.Load(
+		configmanagerinterface.LoadOptions{Silent: true, SkipValidation: true},
+	)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	client, crdClient, err := newOperatorDevClients(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts.image, err = resolveManagerImage(ctx, cmd, client, project, opts)
+	if err != nil {
+		return err
+	}
+
+	return withOperatorBuilder(cmd, cfgManager, opts.push, func(builder imagecmd.Builder) error {
+		loop := &operatorLoop{
+			cmd:       cmd,
+			project:   project,
+			client:    client,
+			crdClient: crdClient,
+			builder:   builder,
+			opts:      opts,
+		}
+
+		if opts.initialSync {
+			loop.syncAndReport(ctx, operatordev.ChangeCRDs|operatordev.ChangeCode, project.Dir)
+		}
+
+		return loop.watch(ctx)
+	})
+}
+
+// resolveManagerImage returns the image to build: --image, or the image the
+// manager container runs. It warns when the container always pulls, since the
+// node then ignores an image loaded into it.
+func resolveManagerImage(
+	ctx context.Context,
+	cmd *cobra.Command,
+	client kubernetes.Interface,
+	project *operatordev.Project,
+	opts devOptions,
+) (string, error) {
+	container, err := operatordev.ManagerContainer(
+		ctx, client, project.Namespace, project.Deployment, opts.container,
+	)
+	if err != nil {
+		return "", fmt.Errorf("resolve manager (deploy it first, e.g. 'make deploy'): %w", err)
+	}
+
+	if container.ImagePullPolicy == corev1.PullAlways && !opts.push {
+		notify.Warningf(cmd.ErrOrStderr(),
+			"container %q uses imagePullPolicy Always; set IfNotPresent so it runs the loaded image",
+			opts.container)
+	}
+
+	if opts.image != "" {
+		return opts.image, nil
+	}
+
+	return container.Image, nil
+}
+
+// watch runs sync for the changes of the project until ctx is cancelled. Events
+// are debounced, and the kinds of change seen within one debounce window are
+// combined into a single sync. Failed syncs are reported and the watch
+// continues.
+func (l *operatorLoop) watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create file watcher: %w", err)
+	}
+
+	defer func() { _ = watcher.Close() }()
+
+	err = workloadwatch.AddRecursive(watcher, l.project.Dir)
+	if err != nil {
+		return fmt.Errorf("watch controller project: %w", err)
+	}
+
+	state := &workloadwatch.DebounceState{}
+	defer workloadwatch.CancelPendingDebounce(state)
+
+	syncCh := make(chan string, 1)
+	pending := operatordev.ChangeNone
+
+	notify.Infof(l.cmd.OutOrStdout(), "watching %s for changes to %s/%s, press Ctrl+C to stop",
+		l.project.Dir, l.project.Namespace, l.project.Deployment)
+
+	for {
+		select {
+		case <-ctx.Done():
+			l.cmd.PrintErrln("\n✋ watcher stopped")
+
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if !workloadwatch.IsRelevantEvent(event) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				workloadwatch.TryAddDirectory(watcher, event.Name, l.cmd.ErrOrStderr())
+			}
+
+			change := l.project.Classify(event.Name)
+			if change == operatordev.ChangeNone {
+				continue
+			}
+
+			pending |= change
+
+			workloadwatch.ScheduleApply(state, event.Name, syncCh)
+
+		case file := <-syncCh:
+			change := pending
+			pending = operatordev.ChangeNone
+
+			l.syncAndReport(ctx, change, file)
+
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			return fmt.Errorf("file watcher: %w", watchErr)
+		}
+	}
+}
+
+// syncAndReport runs sync and reports its outcome.
+func (l *operatorLoop) syncAndReport(ctx context.Context, change operatordev.Change, file string) {
+	start := time.Now()
+
+	notify.Infof(l.cmd.OutOrStdout(), "%s changed, syncing", file)
+
+	err := l.sync(ctx, change)
+	if err != nil {
+		notify.Errorf(l.cmd.ErrOrStderr(), "%v", err)
+
+		return
+	}
+
+	notify.Successf(l.cmd.OutOrStdout(), "%s/%s is up to date (%s)",
+		l.project.Namespace, l.project.Deployment, workloadwatch.FormatElapsed(time.Since(start)))
+}
+
+// sync brings the cluster up to date with a change: code changes run the hooks
+// and re-apply the CRDs the hooks may have regenerated before rebuilding and
+// restarting the manager, so new code never starts against old CRDs.
+func (l *operatorLoop) sync(ctx context.Context, change operatordev.Change) error {
+	if change.Has(operatordev.ChangeCode) {
+		err := runHooks(ctx, l.cmd, l.opts.hooks)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := l.applyCRDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !change.Has(operatordev.ChangeCode) {
+		return nil
+	}
+
+	return l.rebuildAndRestart(ctx)
+}
+
+// applyCRDs re-applies the project's CRDs and waits for cert-manager to inject
+// the CA bundles of their conversion webhooks.
+func (l *operatorLoop) applyCRDs(ctx context.Context) error {
+	if l.project.CRDPath == "" {
+		return nil
+	}
+
+	crds, err := operatordev.LoadCRDs(ctx, l.project.CRDPath)
+	if err != nil {
+		return fmt.Errorf("load CRDs: %w", err)
+	}
+
+	err = operatordev.ApplyCRDs(ctx, l.crdClient, crds)
+	if err != nil {
+		return fmt.Errorf("apply CRDs: %w", err)
+	}
+
+	err = operatordev.WaitForCABundles(ctx, l.crdClient, crds, l.opts.timeout)
+	if err != nil {
+		return fmt.Errorf("%w (is cert-manager installed and the serving Certificate ready?)", err)
+	}
+
+	notify.Infof(l.cmd.OutOrStdout(), "applied %d CRDs", len(crds))
+
+	return nil
+}
+
+// rebuildAndRestart rebuilds the manager image, delivers it to the cluster and
+// rolls the manager Deployment onto it.
+func (l *operatorLoop) rebuildAndRestart(ctx context.Context) error {
+	refs, err := l.builder.Build(ctx, dockerclient.ImageBuildSpec{
+		ContextDir: l.project.Dir,
+		Tags:       []string{l.opts.image},
+	}, l.cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("build manager image: %w", err)
+	}
+
+	err = operatordev.RestartManager(
+		ctx, l.client, l.project.Namespace, l.project.Deployment, l.opts.container, refs[0],
+	)
+	if err != nil {
+		return err
+	}
+
+	err = readiness.WaitForDeploymentReady(
+		ctx, l.client, l.project.Namespace, l.project.Deployment, l.opts.timeout,
+	)
+	if err != nil {
+		return fmt.Errorf("wait for manager rollout: %w", err)
+	}
+
+	return nil
+}
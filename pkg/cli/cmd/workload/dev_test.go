@@ -0,0 +1,299 @@
+package workload_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/operatordev"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhookdev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+// errDevBuildFailed is the static build failure the dev tests inject.
+var errDevBuildFailed = errors.New("build failed")
+
+const devTestCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: guestbooks.webapp.example.com
+spec:
+  group: webapp.example.com
+  names:
+    kind: Guestbook
+    plural: guestbooks
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+`
+
+// recordingBuilder records the specs it is asked to build.
+type recordingBuilder struct {
+	specs []dockerclient.ImageBuildSpec
+	err   error
+}
+
+func (b *recordingBuilder) Build(
+	_ context.Context,
+	spec dockerclient.ImageBuildSpec,
+	_ io.Writer,
+) ([]string, error) {
+	b.specs = append(b.specs, spec)
+
+	return spec.Tags, b.err
+}
+
+// newDevProject writes a kubebuilder-style project with one CRD.
+func newDevProject(t *testing.T) *operatordev.Project {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "config", "crd", "bases"), 0o750))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "config", "crd", "bases", "webapp.example.com_guestbooks.yaml"),
+		[]byte(devTestCRD), 0o600,
+	))
+
+	project, err := operatordev.DetectProject(dir)
+	require.NoError(t, err)
+
+	project.Namespace = "guestbook-system"
+	project.Deployment = "guestbook-controller-manager"
+
+	return project
+}
+
+// newReadyManager returns a manager Deployment whose rollout has completed.
+func newReadyManager() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook-controller-manager", Namespace: "guestbook-system"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager", Image: "controller:dev"}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+// newRecordingCRDClient returns a fake apiextensions client that accepts
+// server-side applies and records the applied CRD names.
+func newRecordingCRDClient(applied *[]string) *apiextensionsfake.Clientset {
+	client := apiextensionsfake.NewClientset()
+	client.PrependReactor("patch", "customresourcedefinitions",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patchAction, ok := action.(k8stesting.PatchAction)
+			if ok {
+				*applied = append(*applied, patchAction.GetName())
+			}
+
+			return true, &apiextensionsv1.CustomResourceDefinition{}, nil
+		})
+
+	return client
+}
+
+func TestDevCmdRequiresMode(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewDevCmd()
+	cmd.SetArgs([]string{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, workload.ErrDevModeRequired)
+}
+
+func TestSyncOperatorCodeChangeRebuildsAndRestarts(t *testing.T) {
+	t.Parallel()
+
+	project := newDevProject(t)
+	client := k8sfake.NewClientset(newReadyManager())
+
+	var applied []string
+
+	builder := &recordingBuilder{}
+	marker := filepath.Join(t.TempDir(), "hook-ran")
+
+	cmd := workload.NewDevCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := workload.ExportSyncOperator(
+		context.Background(), cmd, project, client, newRecordingCRDClient(&applied), builder,
+		"controller:dev", []string{"echo ran > " + marker}, operatordev.ChangeCode,
+	)
+	require.NoError(t, err)
+
+	assert.FileExists(t, marker, "hooks run before the rebuild")
+	assert.Equal(t, []string{"guestbooks.webapp.example.com"}, applied, "CRDs are re-applied with new code")
+
+	require.Len(t, builder.specs, 1)
+	assert.Equal(t, project.Dir, builder.specs[0].ContextDir)
+	assert.Equal(t, []string{"controller:dev"}, builder.specs[0].Tags)
+
+	manager, err := client.AppsV1().Deployments("guestbook-system").Get(
+		context.Background(), "guestbook-controller-manager", metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.NotEmpty(t, manager.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"])
+}
+
+func TestSyncOperatorCRDChangeOnlyAppliesCRDs(t *testing.T) {
+	t.Parallel()
+
+	project := newDevProject(t)
+
+	var applied []string
+
+	builder := &recordingBuilder{}
+
+	cmd := workload.NewDevCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := workload.ExportSyncOperator(
+		context.Background(), cmd, project, k8sfake.NewClientset(newReadyManager()),
+		newRecordingCRDClient(&applied), builder, "controller:dev", nil, operatordev.ChangeCRDs,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"guestbooks.webapp.example.com"}, applied)
+	assert.Empty(t, builder.specs, "a CRD change does not rebuild the manager")
+}
+
+func TestSyncOperatorBuildFailureSkipsRestart(t *testing.T) {
+	t.Parallel()
+
+	project := newDevProject(t)
+	client := k8sfake.NewClientset(newReadyManager())
+
+	var applied []string
+
+	cmd := workload.NewDevCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := workload.ExportSyncOperator(
+		context.Background(), cmd, project, client, newRecordingCRDClient(&applied),
+		&recordingBuilder{err: errDevBuildFailed}, "controller:dev", nil, operatordev.ChangeCode,
+	)
+	require.ErrorIs(t, err, errDevBuildFailed)
+
+	manager, err := client.AppsV1().Deployments("guestbook-system").Get(
+		context.Background(), "guestbook-controller-manager", metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Empty(t, manager.Spec.Template.Annotations, "the manager is not restarted onto a failed build")
+}
+
+func TestDevCmdModesAreExclusive(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewDevCmd()
+	cmd.SetArgs([]string{"--operator", "--webhook"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.ErrorContains(t, err, "none of the others can be")
+}
+
+func TestServeWebhooksLocallyRedirectsUntilStopped(t *testing.T) {
+	t.Parallel()
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook-validating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name: "vguestbook.kb.io",
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{
+					Namespace: "guestbook-system",
+					Name:      "guestbook-webhook-service",
+					Path:      ptr.To("/validate-guestbook"),
+				},
+			},
+		}},
+	}
+	client := k8sfake.NewClientset(validating)
+	service := webhookdev.Service{Namespace: "guestbook-system", Name: "guestbook-webhook-service"}
+
+	certs, err := webhookdev.GenerateCertificates("172.18.0.1")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var out bytes.Buffer
+
+	cmd := workload.NewDevCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- workload.ExportServeWebhooksLocally(ctx, cmd, client, service, "172.18.0.1", 9443, certs)
+	}()
+
+	require.Eventually(t, func() bool {
+		live, getErr := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(
+			context.Background(), validating.Name, metav1.GetOptions{},
+		)
+
+		return getErr == nil &&
+			live.Webhooks[0].ClientConfig.URL != nil &&
+			*live.Webhooks[0].ClientConfig.URL == "https://172.18.0.1:9443/validate-guestbook"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	live, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(
+		context.Background(), validating.Name, metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, validating.Webhooks, live.Webhooks)
+	assert.Contains(t, out.String(), "restored 1 webhooks")
+}
+
+func TestServeWebhooksLocallyFailsWithoutWebhooks(t *testing.T) {
+	t.Parallel()
+
+	certs, err := webhookdev.GenerateCertificates("172.18.0.1")
+	require.NoError(t, err)
+
+	cmd := workload.NewDevCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err = workload.ExportServeWebhooksLocally(
+		context.Background(), cmd, k8sfake.NewClientset(),
+		webhookdev.Service{Namespace: "guestbook-system", Name: "guestbook-webhook-service"},
+		"172.18.0.1", 9443, certs,
+	)
+	require.ErrorIs(t, err, workload.ErrNoWebhooksRedirected)
+}
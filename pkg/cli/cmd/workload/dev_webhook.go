@@ -0,0 +1,276 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"syscall"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	veleroinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/velero"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/operatordev"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhookdev"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// ErrNoWebhooksRedirected is returned when no webhook calls the webhook
+	// Service, so there is nothing to serve locally.
+	ErrNoWebhooksRedirected = errors.New("no webhook calls the webhook service")
+	// ErrInvalidWebhookPort rejects --webhook-port values outside the valid
+	// TCP port range.
+	ErrInvalidWebhookPort = errors.New("--webhook-port must be between 1 and 65535")
+	// ErrNoClusterNetwork is returned when the cluster has no local Docker
+	// network to reach the host through.
+	ErrNoClusterNetwork = errors.New("cluster has no local Docker network")
+)
+
+const (
+	// defaultWebhookPort is the port controller-runtime's webhook server
+	// listens on.
+	defaultWebhookPort = 9443
+	// maxWebhookPort is the highest valid TCP port.
+	maxWebhookPort = 65535
+	// dockerDesktopHost is the name Docker Desktop resolves to the host inside
+	// containers, where the network gateway is not the host.
+	dockerDesktopHost = "host.docker.internal"
+)
+
+// defaultWebhookCertDir returns the directory controller-runtime's webhook
+// server reads its serving certificate from when none is configured.
+func defaultWebhookCertDir() string {
+	return filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+}
+
+// newWebhookDevClient builds the client the webhook session talks to the
+// cluster with. It is a package-level seam so tests can substitute a fake.
+//
+//nolint:gochecknoglobals // Test seam: lets tests inject a fake clientset without a live cluster.
+var newWebhookDevClient = func(clusterCfg *v1alpha1.Cluster) (kubernetes.Interface, error) {
+	kubeconfigPath, err := getKubeconfigPath(clusterCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := k8s.BuildRESTConfig(kubeconfigPath, clusterCfg.Spec.Cluster.Connection.Context)
+	if err != nil {
+		return nil, fmt.Errorf("build REST config: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create Kubernetes client: %w", err)
+	}
+
+	return client, nil
+}
+
+// resolveWebhookHost returns the address the cluster reaches processes on the
+// host at. It is a package-level seam so tests can run without Docker.
+//
+//nolint:gochecknoglobals // Test seam: lets tests resolve the host without Docker or a cluster.
+var resolveWebhookHost = defaultResolveWebhookHost
+
+// defaultResolveWebhookHost returns the gateway of the cluster's Docker network,
+// the host's address on it. Docker Desktop runs containers in a VM, where the
+// gateway is the VM's, so there the name it resolves to the host is used.
+func defaultResolveWebhookHost(ctx context.Context, clusterCfg *v1alpha1.Cluster) (string, error) {
+	kubeconfigPath, err := getKubeconfigPath(clusterCfg)
+	if err != nil {
+		return "", err
+	}
+
+	target, err := clusterdetector.DetectTarget(
+		ctx, kubeconfigPath, clusterCfg.Spec.Cluster.Connection.Context,
+	)
+	if err != nil {
+		return "", fmt.Errorf("detect cluster info: %w", err)
+	}
+
+	err = target.Require(clusterdetector.CapabilityHostAccess)
+	if err != nil {
+		return "", fmt.Errorf("%w (pass --webhook-host with an address the cluster reaches you at)", err)
+	}
+
+	if runtime.GOOS != "linux" {
+		return dockerDesktopHost, nil
+	}
+
+	networkName := veleroinstaller.DockerNetworkName(target.Info.Distribution, target.Info.ClusterName)
+	if networkName == "" {
+		return "", fmt.Errorf("%w: %s", ErrNoClusterNetwork, target.Info.Distribution)
+	}
+
+	dockerClient, err := dockerclient.GetDockerClient()
+	if err != nil {
+		return "", fmt.Errorf("get docker client: %w", err)
+	}
+
+	defer func() { _ = dockerClient.Close() }()
+
+	gateway, err := dockerclient.ResolveNetworkGateway(ctx, dockerClient, networkName)
+	if err != nil {
+		return "", fmt.Errorf("resolve host address: %w", err)
+	}
+
+	return gateway, nil
+}
+
+// resolveWebhookService returns --webhook-service, or the kubebuilder webhook
+// Service of the controller project in projectDir: <projectName>-webhook-service
+// in --namespace or <projectName>-system.
+func resolveWebhookService(projectDir string, opts devOptions) (webhookdev.Service, error) {
+	if opts.webhookService != "" {
+		//nolint:wrapcheck // ParseService errors already name the flag value
+		return webhookdev.ParseService(opts.webhookService)
+	}
+
+	project, err := operatordev.DetectProject(projectDir)
+	if err != nil {
+		return webhookdev.Service{}, fmt.Errorf(
+			"detect controller project (or pass --webhook-service): %w", err,
+		)
+	}
+
+	service := webhookdev.Service{Namespace: project.Namespace, Name: project.Name + "-webhook-service"}
+	if opts.namespace != "" {
+		service.Namespace = opts.namespace
+	}
+
+	return service, nil
+}
+
+// runWebhookDev points the webhooks of the controller's webhook Service at a
+// server on the host, until interrupted, then points them back.
+func runWebhookDev(
+	cmd *cobra.Command,
+	cfgManager *configmanager.ConfigManager,
+	projectDir string,
+	opts devOptions,
+) error {
+	cmd.SetErr(os.Stderr)
+
+	if opts.webhookPort < 1 || opts.webhookPort > maxWebhookPort {
+		return fmt.Errorf("%w: got %d", ErrInvalidWebhookPort, opts.webhookPort)
+	}
+
+	if opts.certDir == "" {
+		opts.certDir = defaultWebhookCertDir()
+	}
+
+	service, err := resolveWebhookService(projectDir, opts)
+	if err != nil {
+		return err
+	}
+
+	clusterCfg, err := cfgManager.Load(
+		configmanagerinterface.LoadOptions{Silent: true, SkipValidation: true},
+	)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newWebhookDevClient(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	host := opts.webhookHost
+	if host == "" {
+		host, err = resolveWebhookHost(ctx, clusterCfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	certs, err := webhookdev.GenerateCertificates(host)
+	if err != nil {
+		return fmt.Errorf("generate webhook certificates: %w", err)
+	}
+
+	err = certs.Write(opts.certDir)
+	if err != nil {
+		return fmt.Errorf("write webhook certificates: %w", err)
+	}
+
+	return serveWebhooksLocally(ctx, cmd, client, service, host, certs, opts)
+}
+
+// serveWebhooksLocally redirects the webhooks of service to host and restores
+// them when ctx is done. Webhooks left redirected by an earlier session that did
+// not exit cleanly are restored first.
+func serveWebhooksLocally(
+	ctx context.Context,
+	cmd *cobra.Command,
+	client kubernetes.Interface,
+	service webhookdev.Service,
+	host string,
+	certs *webhookdev.Certificates,
+	opts devOptions,
+) error {
+	_, err := webhookdev.Restore(ctx, client)
+	if err != nil {
+		return fmt.Errorf("restore webhooks of an earlier session: %w", err)
+	}
+
+	baseURL := "https://" + net.JoinHostPort(host, strconv.Itoa(opts.webhookPort))
+
+	redirected, err := webhookdev.Redirect(ctx, client, service, baseURL, certs.CACertPEM)
+
+	// Restore even after a failed or interrupted redirect, which may have
+	// changed some configurations already.
+	defer restoreWebhooks(ctx, cmd, client)
+
+	if err != nil {
+		return fmt.Errorf("redirect webhooks: %w", err)
+	}
+
+	if redirected == 0 {
+		return fmt.Errorf("%w %s", ErrNoWebhooksRedirected, service)
+	}
+
+	notify.Infof(cmd.OutOrStdout(),
+		"%d webhooks of %s now call %s; serve them with the certificate in %s, press Ctrl+C to stop",
+		redirected, service, baseURL, opts.certDir)
+
+	<-ctx.Done()
+
+	cmd.PrintErrln("\n✋ webhook passthrough stopped")
+
+	return nil
+}
+
+// restoreWebhooks points the redirected webhooks back at their Service. It runs
+// after ctx is cancelled, so it uses a context that outlives it.
+func restoreWebhooks(ctx context.Context, cmd *cobra.Command, client kubernetes.Interface) {
+	restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultOperatorDevTimeout)
+	defer cancel()
+
+	restored, err := webhookdev.Restore(restoreCtx, client)
+	if err != nil {
+		notify.Errorf(cmd.ErrOrStderr(),
+			"%v; rerun 'ksail workload dev --webhook' and stop it to retry", err)
+
+		return
+	}
+
+	if restored > 0 {
+		notify.Successf(cmd.OutOrStdout(), "restored %d webhooks", restored)
+	}
+}
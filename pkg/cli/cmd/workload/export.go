@@ -12,6 +12,9 @@ import (
 
 const exportCmdLong = `Export container images from the cluster's containerd runtime to a tar archive.
 
+Images are exported for the platform of the cluster node they are exported
+from, unless --platform selects another one.
+
 The exported archive can be used to:
   - Share image sets between development machines
   - Pre-load images for offline development
@@ -27,12 +30,18 @@ Examples:
   # Export specific images from cluster
   ksail workload export --image=nginx:latest --image=redis:7
 
+  # Export the arm64 variant of an image, e.g. for a cluster on an arm64 Mac
+  ksail workload export --image=nginx:latest --platform=linux/arm64
+
   # Export from a specific kubeconfig context
   ksail workload export --context=kind-dev --kubeconfig=~/.kube/config`
 
 // NewExportCmd creates the image export command.
 func NewExportCmd() *cobra.Command {
-	var images []string
+	var (
+		images   []string
+		platform string
+	)
 
 	cmd := &cobra.Command{
 		Use:          "export [<output>]",
@@ -49,10 +58,13 @@ func NewExportCmd() *cobra.Command {
 	cmd.Flags().StringArrayVar(&images, "image", nil,
 		"Image(s) to export (repeatable); if not specified, all images are exported")
 
+	cmd.Flags().StringVar(&platform, "platform", "",
+		"Platform to export, e.g. linux/arm64 (default: the platform of the cluster nodes)")
+
 	_ = cfgManager.Viper.BindPFlag("image", cmd.Flags().Lookup("image"))
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		return runExportCommand(cmd, args, cfgManager, images)
+		return runExportCommand(cmd, args, cfgManager, images, platform)
 	}
 
 	return cmd
@@ -63,6 +75,7 @@ func runExportCommand(
 	args []string,
 	cfgManager *configmanager.ConfigManager,
 	images []string,
+	platform string,
 ) error {
 	ctx, err := initImageCommandContext(cmd, cfgManager)
 	if err != nil {
@@ -90,14 +103,17 @@ func runExportCommand(
 		return err
 	}
 
-	return executeExport(cmd, ctx, images, outputPath)
+	return executeExport(cmd, ctx, imagesvc.ExportOptions{
+		OutputPath: outputPath,
+		Images:     images,
+		Platform:   platform,
+	})
 }
 
 func executeExport(
 	cmd *cobra.Command,
 	ctx *imageCommandContext,
-	images []string,
-	outputPath string,
+	opts imagesvc.ExportOptions,
 ) error {
 	dockerClient, err := docker.GetDockerClient()
 	if err != nil {
@@ -120,10 +136,7 @@ func executeExport(
 		ctx.ClusterInfo.ClusterName,
 		ctx.ClusterInfo.Distribution,
 		ctx.ClusterInfo.Provider,
-		imagesvc.ExportOptions{
-			OutputPath: outputPath,
-			Images:     images,
-		},
+		opts,
 	)
 	if err != nil {
 		return fmt.Errorf("export images: %w", err)
@@ -132,7 +145,7 @@ func executeExport(
 	notify.WriteMessage(notify.Message{
 		Type:    notify.SuccessType,
 		Content: "images exported to %s",
-		Args:    []any{outputPath},
+		Args:    []any{opts.OutputPath},
 		Timer:   ctx.OutputTimer,
 		Writer:  cmd.OutOrStdout(),
 	})
@@ -6,17 +6,25 @@ import (
 	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	imagecmd "github.com/devantler-tech/ksail/v7/pkg/cli/cmd/image"
 	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
 	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
 	"github.com/devantler-tech/ksail/v7/pkg/client/hubble"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator/openapi"
+	specdiff "github.com/devantler-tech/ksail/v7/pkg/svc/diff"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/fluxsubst"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/hostdebug"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/loadtest"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/mirror"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/operatordev"
 	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhookdev"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/workloadwatch"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -374,7 +382,7 @@ func ExportRetryOnTransientError(
 // ExportRunWatch exposes runWatch for unit testing the early-exit path
 // validation that runs before config load.
 func ExportRunWatch(cmd *cobra.Command, pathFlag string, initialApply bool, debug bool) error {
-	return runWatch(cmd, pathFlag, initialApply, debug, nil)
+	return runWatch(cmd, pathFlag, initialApply, debug, nil, "", "")
 }
 
 // ExportRunHooks exposes runHooks for unit testing hook execution.
@@ -398,6 +406,19 @@ func ExportSetFlowObserverFactory(factory func(string) hubble.FlowObserver) func
 	return func() { newFlowObserver = original }
 }
 
+// ExportSetLoadTest swaps the loadtest command's client factory and blocking
+// run so tests can drive the command against a fake clientset. It returns a
+// restore function that reinstates the originals.
+func ExportSetLoadTest(
+	factory func(string, string) (kubernetes.Interface, error),
+	run func(context.Context, kubernetes.Interface, loadtest.Options, io.Writer) (loadtest.Summary, error),
+) func() {
+	originalFactory, originalRun := newLoadTestClient, runLoadTest
+	newLoadTestClient, runLoadTest = factory, run
+
+	return func() { newLoadTestClient, runLoadTest = originalFactory, originalRun }
+}
+
 // ExportSetMirrorClients swaps the mirror command's client factory so tests
 // can inject a fake clientset and REST config without a live cluster. It
 // returns a restore function that reinstates the original factory.
@@ -554,3 +575,148 @@ func ExportInstallDeclaredCharts(
 ) error {
 	return installDeclaredCharts(ctx, cmd, cluster, sourcePath)
 }
+
+// ExportWithOutputQuery wraps cmd with the --query handling of `workload get`.
+// cmd's Run must write its output to the returned writer, which forwards to
+// target unless --query captures it.
+func ExportWithOutputQuery(cmd *cobra.Command, target io.Writer) (*cobra.Command, io.Writer) {
+	out := &redirectableWriter{target: target}
+
+	return withOutputQuery(cmd, out), out
+}
+
+// ExportSubstituteManifestFlags exposes substituteManifestFlags so external-package
+// tests can assert how -f and -k inputs are rewritten to substituted copies
+// without running kubectl against a cluster.
+var ExportSubstituteManifestFlags = substituteManifestFlags //nolint:gochecknoglobals // test export
+
+// ExportWebhookWorker exposes webhookWorker so tests can drive `watch --serve`
+// requests through a fake reconcile trigger.
+func ExportWebhookWorker(
+	ctx context.Context,
+	cmd *cobra.Command,
+	requests <-chan struct{},
+	trigger func(context.Context) (string, error),
+) {
+	webhookWorker(ctx, cmd, requests, trigger)
+}
+
+// ExportReviewApplyDocuments previews docs with previewer and runs the
+// `apply --interactive` review against in and out, returning the approved
+// documents. It lets tests drive the prompt flow without a cluster.
+func ExportReviewApplyDocuments(
+	ctx context.Context,
+	docs [][]byte,
+	previewer func(context.Context, []byte) (specdiff.ObjectDiff, error),
+	in io.Reader,
+	out io.Writer,
+) ([][]byte, error) {
+	return reviewDocuments(previewDocuments(ctx, previewer, docs), in, out)
+}
+
+// ExportCollectApplyDocuments exposes collectApplyDocuments for testing.
+var ExportCollectApplyDocuments = collectApplyDocuments //nolint:gochecknoglobals // test export
+
+// ExportErrApplyCancelled exposes the interactive-apply cancellation sentinel for testing.
+func ExportErrApplyCancelled() error { return errApplyCancelled }
+
+// ExportTargetDebugNode exposes targetDebugNode for testing.
+var ExportTargetDebugNode = targetDebugNode //nolint:gochecknoglobals // test export
+
+// ExportApplyDebugImageDefault exposes applyDebugImageDefault for testing.
+var ExportApplyDebugImageDefault = applyDebugImageDefault //nolint:gochecknoglobals // test export
+
+// ExportWithPruneOwnership exposes withPruneOwnership so tests can drive the
+// --prune ownership labeling against a stub kubectl command.
+var ExportWithPruneOwnership = withPruneOwnership //nolint:gochecknoglobals // test export
+
+// ExportWithOverlaySelection exposes withOverlaySelection so tests can drive
+// --env against a stub kubectl command.
+var ExportWithOverlaySelection = withOverlaySelection //nolint:gochecknoglobals // test export
+
+// ErrEnvWithManifestInputs exposes the --env conflict sentinel for test assertions.
+var ErrEnvWithManifestInputs = errEnvWithManifestInputs
+
+// ExportDeriveApplySetName exposes deriveApplySetName for testing.
+var ExportDeriveApplySetName = deriveApplySetName //nolint:gochecknoglobals // test export
+
+// ExportBuildChartSpec exposes buildChartSpec for testing.
+var ExportBuildChartSpec = buildChartSpec //nolint:gochecknoglobals // test export
+
+// ExportSetListClusterCRDs replaces the function listing the cluster's CRDs for
+// --update-schemas and returns a restore function.
+func ExportSetListClusterCRDs(
+	list func(
+		ctx context.Context,
+		kubeconfigPath, contextName string,
+	) ([]apiextensionsv1.CustomResourceDefinition, error),
+) func() {
+	original := listClusterCRDs
+	listClusterCRDs = list
+
+	return func() { listClusterCRDs = original }
+}
+
+// ExportSetLoadClusterSchemas replaces the function fetching the cluster's
+// OpenAPI v3 schemas for --cluster-openapi and returns a restore function.
+func ExportSetLoadClusterSchemas(
+	load func(kubeconfigPath, contextName string) (*openapi.Validator, error),
+) func() {
+	original := loadClusterSchemas
+	loadClusterSchemas = load
+
+	return func() { loadClusterSchemas = original }
+}
+
+// ExportApplyConfiguredContext exposes applyConfiguredContext for testing.
+func ExportApplyConfiguredContext(cmd *cobra.Command, configuredContext string) error {
+	return applyConfiguredContext(cmd, configuredContext)
+}
+
+// ExportSyncOperator runs one operator dev-loop sync of change against the
+// given clients and builder, with the manager container and rollout timeout of
+// the dev command's defaults.
+func ExportSyncOperator(
+	ctx context.Context,
+	cmd *cobra.Command,
+	project *operatordev.Project,
+	client kubernetes.Interface,
+	crdClient apiextensionsclient.Interface,
+	builder imagecmd.Builder,
+	image string,
+	hooks []string,
+	change operatordev.Change,
+) error {
+	loop := &operatorLoop{
+		cmd:       cmd,
+		project:   project,
+		client:    client,
+		crdClient: crdClient,
+		builder:   builder,
+		opts: devOptions{
+			container: operatordev.DefaultContainer,
+			image:     image,
+			hooks:     hooks,
+			timeout:   time.Second,
+		},
+	}
+
+	return loop.sync(ctx, change)
+}
+
+// ExportServeWebhooksLocally runs a webhook dev session against client until
+// ctx is done, redirecting the webhooks of service to host:port.
+func ExportServeWebhooksLocally(
+	ctx context.Context,
+	cmd *cobra.Command,
+	client kubernetes.Interface,
+	service webhookdev.Service,
+	host string,
+	port int,
+	certs *webhookdev.Certificates,
+) error {
+	return serveWebhooksLocally(ctx, cmd, client, service, host, certs, devOptions{
+		webhookPort: port,
+		certDir:     "certs",
+	})
+}
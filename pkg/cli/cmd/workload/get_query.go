@@ -0,0 +1,122 @@
+package workload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
+	"github.com/spf13/cobra"
+)
+
+// getOutputJSON is the kubectl output format --query evaluates against.
+const getOutputJSON = "json"
+
+var (
+	// errQueryWithWatch is returned when --query is combined with a watch, whose
+	// stream of events is not a single JSON document.
+	errQueryWithWatch = errors.New("--query cannot be combined with --watch or --watch-only")
+	// errQueryOutputFormat is returned when --query is combined with a non-JSON --output.
+	errQueryOutputFormat = errors.New("--query evaluates JSON output")
+)
+
+// redirectableWriter forwards writes to target, letting a command capture the
+// output of a kubectl command whose streams were bound at construction time.
+type redirectableWriter struct {
+	target io.Writer
+}
+
+// Write implements io.Writer.
+func (w *redirectableWriter) Write(p []byte) (int, error) {
+	n, err := w.target.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("write output: %w", err)
+	}
+
+	return n, nil
+}
+
+// withOutputQuery adds --query and --query-language to a kubectl command that
+// writes through out. With --query set, kubectl runs with -o json into a buffer
+// and only the query result is written to the command's output.
+// kubectl's Run (not RunE) is promoted to RunE so query errors surface through
+// cobra.
+func withOutputQuery(cmd *cobra.Command, out *redirectableWriter) *cobra.Command {
+	outputquery.BindFlags(cmd)
+
+	origRunE := cmd.RunE
+	origRun := cmd.Run
+
+	run := func(child *cobra.Command, args []string) error {
+		if origRunE != nil {
+			return origRunE(child, args)
+		}
+
+		origRun(child, args)
+
+		return nil
+	}
+
+	cmd.RunE = func(child *cobra.Command, args []string) error {
+		query, err := outputquery.FromFlags(child)
+		if err != nil {
+			return err
+		}
+
+		if query == nil {
+			return run(child, args)
+		}
+
+		err = forceJSONOutput(child)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+
+		previous := out.target
+		out.target = &buf
+
+		err = run(child, args)
+
+		out.target = previous
+
+		if err != nil {
+			return err
+		}
+
+		return query.RenderJSON(child.OutOrStdout(), buf.Bytes())
+	}
+	cmd.Run = nil
+
+	return cmd
+}
+
+// forceJSONOutput switches kubectl to -o json, rejecting flags whose output
+// cannot be queried as one JSON document.
+func forceJSONOutput(cmd *cobra.Command) error {
+	for _, name := range []string{"watch", "watch-only"} {
+		flag := cmd.Flags().Lookup(name)
+		if flag != nil && flag.Value.String() == "true" {
+			return errQueryWithWatch
+		}
+	}
+
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil {
+		return nil
+	}
+
+	if cmd.Flags().Changed("output") && flag.Value.String() != getOutputJSON {
+		return fmt.Errorf("%w; drop --output %s or use --output json",
+			errQueryOutputFormat, flag.Value.String())
+	}
+
+	err := flag.Value.Set(getOutputJSON)
+	if err != nil {
+		return fmt.Errorf("set --output: %w", err)
+	}
+
+	return nil
+}
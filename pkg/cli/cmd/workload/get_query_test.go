@@ -0,0 +1,133 @@
+package workload_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const getQueryPodList = `{"kind":"List","items":[` +
+	`{"metadata":{"name":"web"},"status":{"phase":"Running"}},` +
+	`{"metadata":{"name":"job"},"status":{"phase":"Succeeded"}}]}`
+
+// newFakeGetCmd builds a command shaped like kubectl get: it has --output and
+// --watch flags and prints a pod list as JSON, or a table otherwise.
+func newFakeGetCmd(t *testing.T, args ...string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	var stdout, cmdOut bytes.Buffer
+
+	var out io.Writer
+
+	cmd := &cobra.Command{
+		Use: "get",
+		Run: func(cmd *cobra.Command, _ []string) {
+			format, _ := cmd.Flags().GetString("output")
+			if format == "json" {
+				_, _ = fmt.Fprintln(out, getQueryPodList)
+
+				return
+			}
+
+			_, _ = fmt.Fprintln(out, "NAME  STATUS")
+		},
+	}
+	cmd.Flags().StringP("output", "o", "", "Output format")
+	cmd.Flags().BoolP("watch", "w", false, "Watch for changes")
+
+	cmd, out = workload.ExportWithOutputQuery(cmd, &stdout)
+	cmd.SetOut(&cmdOut)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(args)
+
+	return cmd, &stdout, &cmdOut
+}
+
+func TestWorkloadGetQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "jmespath",
+			args: []string{"--query", "items[?status.phase=='Running'].metadata.name | [0]"},
+			want: "web\n",
+		},
+		{
+			name: "cel",
+			args: []string{
+				"--query-language", "cel",
+				"--query", "object.items.map(p, p.metadata.name)",
+			},
+			want: "[\n  \"web\",\n  \"job\"\n]\n",
+		},
+		{
+			name: "explicit json output",
+			args: []string{"-o", "json", "--query", "length(items)"},
+			want: "2\n",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, stdout, cmdOut := newFakeGetCmd(t, testCase.args...)
+
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, testCase.want, cmdOut.String())
+			assert.Empty(t, stdout.String(), "kubectl output is captured, not printed")
+		})
+	}
+}
+
+func TestWorkloadGetQuery_WithoutQueryPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	cmd, stdout, cmdOut := newFakeGetCmd(t)
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "NAME  STATUS\n", stdout.String())
+	assert.Empty(t, cmdOut.String())
+}
+
+func TestWorkloadGetQuery_Rejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		err  error
+	}{
+		{name: "invalid expression", args: []string{"--query", "items[?"}, err: outputquery.ErrInvalidQuery},
+		{name: "watch", args: []string{"--query", "items", "--watch"}},
+		{name: "non-json output", args: []string{"--query", "items", "-o", "yaml"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, stdout, _ := newFakeGetCmd(t, testCase.args...)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+
+			if testCase.err != nil {
+				require.ErrorIs(t, err, testCase.err)
+			}
+
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
@@ -83,7 +83,17 @@ func createImagesConfigManager(cmd *cobra.Command) *configmanager.ConfigManager
 		configmanager.DefaultMetricsServerFieldSelector(),
 		configmanager.DefaultLoadBalancerFieldSelector(),
 		configmanager.DefaultCertManagerFieldSelector(),
+		configmanager.DefaultSealedSecretsFieldSelector(),
+		configmanager.DefaultExternalSecretsFieldSelector(),
+		configmanager.DefaultBackupFieldSelector(),
 		configmanager.DefaultPolicyEngineFieldSelector(),
+		configmanager.DefaultIngressControllerFieldSelector(),
+		configmanager.DefaultGatewayAPIFieldSelector(),
+		configmanager.DefaultServiceMeshFieldSelector(),
+		configmanager.DefaultIstioModeFieldSelector(),
+		configmanager.DefaultKnativeFieldSelector(),
+		configmanager.DefaultIdentityProviderFieldSelector(),
+		configmanager.DefaultLoggingFieldSelector(),
 		configmanager.DefaultGitOpsEngineFieldSelector(),
 	}
 
@@ -15,6 +15,11 @@ const importCmdLong = `Import container images from a tar archive to the cluster
 Images are imported to all nodes in the cluster, making them available for
 pod scheduling without requiring registry pulls.
 
+Multi-platform images are imported for the platform of the nodes, selected
+from the image indexes of the archive. The import fails before touching the
+nodes when an image of the archive has no content for that platform, instead
+of leaving pods to crash with exec format errors.
+
 Examples:
   # Import images from images.tar (default)
   ksail workload import
@@ -2,29 +2,64 @@ package workload
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
 	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	"github.com/spf13/cobra"
+	helmv4strvals "helm.sh/helm/v4/pkg/strvals"
+	"sigs.k8s.io/yaml"
 )
 
 const requiredInstallArgs = 2
 
+// installValueOverrideFlags lists the value override flags in the order Helm
+// merges them, so later flags win over earlier ones.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var installValueOverrideFlags = []string{"set-json", "set", "set-string", "set-file"}
+
 // NewInstallCmd creates the workload install command.
 func NewInstallCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install [NAME] [CHART]",
 		Short: "Install Helm charts",
 		Long: "Install Helm charts to provision workloads through KSail. " +
-			"This command provides native Helm chart installation capabilities.",
-		Args: cobra.ExactArgs(requiredInstallArgs),
-		RunE: runInstall,
+			"This command provides native Helm chart installation capabilities.\n\n" +
+			"CHART is a local chart directory or archive, an oci:// reference, or a chart name " +
+			"in the repository given by --repo. Values are layered like Helm: each -f file " +
+			"overrides the previous one, so a base values file can be followed by a " +
+			"per-environment one, and --set-json, --set, --set-string, and --set-file " +
+			"override the files in that order. The target cluster is resolved from " +
+			"--kubeconfig and --context, falling back to the connection in ksail.yaml.",
+		Example: `  # Install a local chart with a per-environment values overlay
+  ksail workload install podinfo ./charts/podinfo -f values.yaml -f values-prod.yaml --set replicaCount=3
+
+  # Fetch missing chart dependencies before installing
+  ksail workload install app ./charts/app --dependency-update
+
+  # Install from an OCI registry or a chart repository
+  ksail workload install podinfo oci://ghcr.io/stefanprodan/charts/podinfo --version 6.x
+  ksail workload install podinfo podinfo --repo https://stefanprodan.github.io/podinfo`,
+		Args:         cobra.ExactArgs(requiredInstallArgs),
+		SilenceUsage: true,
 		Annotations: map[string]string{
 			annotations.AnnotationPermission: permissionWrite,
 		},
 	}
 
+	// Registers --context and --kubeconfig so the release targets the same
+	// cluster as the other workload commands.
+	cfgManager := createImageConfigManager(cmd)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runInstall(cmd, args, cfgManager)
+	}
+
 	flags := cmd.Flags()
 	flags.StringP("namespace", "n", "default", "namespace scope for the request")
 	flags.String("version", "", "chart version constraint (default: latest)")
@@ -36,22 +71,48 @@ func NewInstallCmd() *cobra.Command {
 	flags.Bool("create-namespace", false, "create the release namespace if not present")
 	flags.Bool("wait", false, "wait until resources are ready")
 	flags.Bool("atomic", false, "if set, the installation deletes on failure")
+	flags.StringArrayP("values", "f", nil, "values file to merge; later files override earlier ones (can be repeated)")
+	flags.StringArray("set", nil, "set values on the command line (can be repeated: key1=val1,key2=val2)")
+	flags.StringArray("set-string", nil, "set STRING values on the command line (can be repeated)")
+	flags.StringArray("set-json", nil, "set JSON values on the command line (can be repeated)")
+	flags.StringArray("set-file", nil, "set values from files on the command line (can be repeated: key=path)")
+	flags.Bool("dependency-update", false, "fetch missing dependencies of a local chart before installing")
+	flags.String("repo", "", "chart repository URL to locate CHART in")
+	flags.String("username", "", "chart repository or OCI registry username")
+	flags.String("password", "", "chart repository or OCI registry password")
 
 	return cmd
 }
 
-func runInstall(cmd *cobra.Command, args []string) error {
+func runInstall(
+	cmd *cobra.Command,
+	args []string,
+	cfgManager *configmanager.ConfigManager,
+) error {
 	releaseName := args[0]
 	chartName := args[1]
 
-	kubeconfigPath := kubeconfig.GetKubeconfigPathSilently(cmd)
+	clusterCfg, err := cfgManager.Load(
+		configmanagerinterface.LoadOptions{Silent: true, SkipValidation: true},
+	)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
 
-	client, err := helm.NewClient(kubeconfigPath, "")
+	kubeconfigPath, err := kubeconfig.GetKubeconfigPathFromConfig(clusterCfg)
 	if err != nil {
-		return fmt.Errorf("create helm client: %w", err)
+		return fmt.Errorf("resolve kubeconfig: %w", err)
+	}
+
+	spec, err := buildChartSpec(cmd, releaseName, chartName)
+	if err != nil {
+		return err
 	}
 
-	spec := buildChartSpec(cmd, releaseName, chartName)
+	client, err := helm.NewClient(kubeconfigPath, clusterCfg.Spec.Cluster.Connection.Context)
+	if err != nil {
+		return fmt.Errorf("create helm client: %w", err)
+	}
 
 	_, err = client.InstallChart(cmd.Context(), spec)
 	if err != nil {
@@ -61,7 +122,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func buildChartSpec(cmd *cobra.Command, releaseName, chartName string) *helm.ChartSpec {
+func buildChartSpec(cmd *cobra.Command, releaseName, chartName string) (*helm.ChartSpec, error) {
 	namespace, _ := cmd.Flags().GetString("namespace")
 	if namespace == "" {
 		namespace = "default"
@@ -72,15 +133,101 @@ func buildChartSpec(cmd *cobra.Command, releaseName, chartName string) *helm.Cha
 	createNamespace, _ := cmd.Flags().GetBool("create-namespace")
 	wait, _ := cmd.Flags().GetBool("wait")
 	atomic, _ := cmd.Flags().GetBool("atomic")
+	dependencyUpdate, _ := cmd.Flags().GetBool("dependency-update")
+	repoURL, _ := cmd.Flags().GetString("repo")
+	username, _ := cmd.Flags().GetString("username")
+	password, _ := cmd.Flags().GetString("password")
+
+	valueFiles, err := installValueFiles(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := installValueOverrides(cmd)
+	if err != nil {
+		return nil, err
+	}
 
 	return &helm.ChartSpec{
-		ReleaseName:     releaseName,
-		ChartName:       chartName,
-		Namespace:       namespace,
-		Timeout:         timeout,
-		Version:         version,
-		CreateNamespace: createNamespace,
-		Wait:            wait,
-		Atomic:          atomic,
+		ReleaseName:      releaseName,
+		ChartName:        chartName,
+		Namespace:        namespace,
+		Timeout:          timeout,
+		Version:          version,
+		CreateNamespace:  createNamespace,
+		Wait:             wait,
+		Atomic:           atomic,
+		DependencyUpdate: dependencyUpdate,
+		ValueFiles:       valueFiles,
+		ValuesYaml:       overrides,
+		RepoURL:          repoURL,
+		Username:         username,
+		Password:         password,
+	}, nil
+}
+
+// installValueFiles returns the -f values files as absolute paths. The Helm
+// client resolves relative values files against the chart directory, while
+// users pass them relative to the working directory.
+func installValueFiles(cmd *cobra.Command) ([]string, error) {
+	files, _ := cmd.Flags().GetStringArray("values")
+
+	valueFiles := make([]string, 0, len(files))
+
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, fmt.Errorf("resolve values file %q: %w", file, err)
+		}
+
+		valueFiles = append(valueFiles, abs)
+	}
+
+	return valueFiles, nil
+}
+
+// installValueOverrides merges the --set-json, --set, --set-string, and
+// --set-file flags in Helm's precedence order and returns the result as YAML,
+// or "" when no override is given. The Helm client merges it after the -f
+// values files, so the overrides win like they do with raw helm.
+func installValueOverrides(cmd *cobra.Command) (string, error) {
+	overrides := map[string]any{}
+
+	readFile := func(path []rune) (any, error) {
+		data, err := os.ReadFile(string(path)) //nolint:gosec // path is given by the user via --set-file
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", string(path), err)
+		}
+
+		return string(data), nil
 	}
+
+	parsers := map[string]func(string) error{
+		"set-json":   func(value string) error { return helmv4strvals.ParseJSON(value, overrides) },
+		"set":        func(value string) error { return helmv4strvals.ParseInto(value, overrides) },
+		"set-string": func(value string) error { return helmv4strvals.ParseIntoString(value, overrides) },
+		"set-file":   func(value string) error { return helmv4strvals.ParseIntoFile(value, overrides, readFile) },
+	}
+
+	for _, flag := range installValueOverrideFlags {
+		values, _ := cmd.Flags().GetStringArray(flag)
+
+		for _, value := range values {
+			err := parsers[flag](value)
+			if err != nil {
+				return "", fmt.Errorf("parse --%s %q: %w", flag, value, err)
+			}
+		}
+	}
+
+	if len(overrides) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(overrides)
+	if err != nil {
+		return "", fmt.Errorf("marshal value overrides: %w", err)
+	}
+
+	return string(out), nil
 }
@@ -0,0 +1,76 @@
+package workload_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildChartSpec_LayersValues(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	motd := filepath.Join(dir, "motd.txt")
+	require.NoError(t, os.WriteFile(motd, []byte("hello"), 0o600))
+
+	cmd := workload.NewInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{
+		"-f", "values.yaml",
+		"-f", filepath.Join(dir, "values-prod.yaml"),
+		"--set", "replicaCount=3,image.tag=v2",
+		"--set-string", "image.tag=3",
+		"--set-json", `resources={"limits":{"cpu":"1"}}`,
+		"--set", "resources.limits.cpu=2",
+		"--set-file", "motd=" + motd,
+		"--dependency-update",
+		"--repo", "https://charts.example.com",
+	}))
+
+	spec, err := workload.ExportBuildChartSpec(cmd, "app", "app")
+	require.NoError(t, err)
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(cwd, "values.yaml"), filepath.Join(dir, "values-prod.yaml")}, spec.ValueFiles)
+	assert.True(t, spec.DependencyUpdate)
+	assert.Equal(t, "https://charts.example.com", spec.RepoURL)
+
+	var overrides map[string]any
+
+	require.NoError(t, yaml.Unmarshal([]byte(spec.ValuesYaml), &overrides))
+	assert.Equal(t, map[string]any{
+		"replicaCount": float64(3),
+		"image":        map[string]any{"tag": "3"},
+		"resources":    map[string]any{"limits": map[string]any{"cpu": float64(2)}},
+		"motd":         "hello",
+	}, overrides, "--set overrides --set-json and --set-string overrides --set")
+}
+
+func TestBuildChartSpec_WithoutOverrides(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewInstallCmd()
+
+	spec, err := workload.ExportBuildChartSpec(cmd, "app", "oci://ghcr.io/example/charts/app")
+	require.NoError(t, err)
+
+	assert.Empty(t, spec.ValuesYaml)
+	assert.Empty(t, spec.ValueFiles)
+	assert.Equal(t, "default", spec.Namespace)
+}
+
+func TestBuildChartSpec_InvalidOverride(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--set-file", "motd=/does/not/exist"}))
+
+	_, err := workload.ExportBuildChartSpec(cmd, "app", "app")
+	require.ErrorContains(t, err, "parse --set-file")
+}
@@ -1,11 +1,20 @@
 package workload
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/manifestinput"
 	"github.com/devantler-tech/ksail/v7/pkg/client/kubectl"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// filenameFlag is the kubectl -f/--filename flag that manifest-consuming
+// commands share.
+const filenameFlag = "filename"
+
 // newKubectlWrapperCommand builds a kubectl wrapper command from a kubectl.Client
 // method expression, applying the write-permission annotation uniformly when the
 // command mutates cluster state. Command names, flags, and help text come from
@@ -25,14 +34,93 @@ func newKubectlWrapperCommand(creator kubectlCommandCreator, write bool) *cobra.
 	return cmd
 }
 
-// NewApplyCmd creates the workload apply command.
+// withManifestInputResolution resolves every -f value through the shared
+// manifestinput helper before the kubectl command runs, so "-" reads from the
+// command's input stream and http(s) URLs are fetched the same way `workload
+// validate` handles them. Temporary files are removed once the command returns.
+// kubectl's Run (not RunE) is promoted to RunE so resolution errors surface
+// through cobra instead of kubectl's fatal handler.
+func withManifestInputResolution(cmd *cobra.Command) *cobra.Command {
+	origRunE := cmd.RunE
+	origRun := cmd.Run
+
+	cmd.RunE = func(child *cobra.Command, args []string) error {
+		resolver := manifestinput.NewResolver(child.InOrStdin())
+		defer resolver.Cleanup()
+
+		err := resolveFilenameFlag(child, resolver)
+		if err != nil {
+			return err
+		}
+
+		if origRunE != nil {
+			return origRunE(child, args)
+		}
+
+		origRun(child, args)
+
+		return nil
+	}
+	cmd.Run = nil
+
+	return cmd
+}
+
+// resolveFilenameFlag replaces the -f values on cmd with local paths produced
+// by resolver. Commands without a filename flag are left untouched.
+func resolveFilenameFlag(cmd *cobra.Command, resolver *manifestinput.Resolver) error {
+	flag := cmd.Flags().Lookup(filenameFlag)
+	if flag == nil {
+		return nil
+	}
+
+	sliceValue, ok := flag.Value.(pflag.SliceValue)
+	if !ok {
+		return nil
+	}
+
+	resolved, err := resolver.ResolveAll(cmd.Context(), sliceValue.GetSlice())
+	if err != nil {
+		return fmt.Errorf("resolve --%s: %w", filenameFlag, err)
+	}
+
+	err = sliceValue.Replace(resolved)
+	if err != nil {
+		return fmt.Errorf("set --%s: %w", filenameFlag, err)
+	}
+
+	return nil
+}
+
+// NewApplyCmd creates the workload apply command. An --env overlay is selected
+// first, then manifest inputs are resolved, so stdin and remote manifests are
+// substituted like local files, and prune ownership labels and the interactive
+// review apply to the substituted manifests.
 func NewApplyCmd() *cobra.Command {
-	return newKubectlWrapperCommand((*kubectl.Client).CreateApplyCommand, true)
+	cmd := withOverlaySelection(withManifestInputResolution(withVariableSubstitution(
+		withPruneOwnership(withInteractiveApproval(
+			newKubectlWrapperCommand((*kubectl.Client).CreateApplyCommand, true),
+		)),
+	)))
+	cmd.Long += "\n\n" + substitutionHelp + "\n\n" + pruneHelp + "\n\n" + interactiveHelp +
+		"\n\n" + overlayHelp
+
+	return cmd
 }
 
 // NewDeleteCmd creates the workload delete command.
 func NewDeleteCmd() *cobra.Command {
-	return newKubectlWrapperCommand((*kubectl.Client).CreateDeleteCommand, true)
+	return withManifestInputResolution(
+		newKubectlWrapperCommand((*kubectl.Client).CreateDeleteCommand, true),
+	)
+}
+
+// NewDiffCmd creates the workload diff command. Diff only reads cluster state,
+// so it is not marked as a write operation.
+func NewDiffCmd() *cobra.Command {
+	return withManifestInputResolution(
+		newKubectlWrapperCommand((*kubectl.Client).CreateDiffCommand, false),
+	)
 }
 
 // NewExecCmd creates the workload exec command.
@@ -55,9 +143,15 @@ func NewRolloutCmd() *cobra.Command {
 	return newKubectlWrapperCommand((*kubectl.Client).CreateRolloutCommand, true)
 }
 
-// NewGetCmd creates the workload get command.
+// NewGetCmd creates the workload get command. kubectl writes through a
+// redirectable writer so --query can evaluate its JSON output.
 func NewGetCmd() *cobra.Command {
-	return newKubectlWrapperCommand((*kubectl.Client).CreateGetCommand, false)
+	out := &redirectableWriter{target: os.Stdout}
+
+	return withOutputQuery(
+		newKubectlCommandWithOutput((*kubectl.Client).CreateGetCommand, out),
+		out,
+	)
 }
 
 // NewDescribeCmd creates the workload describe command.
@@ -0,0 +1,187 @@
+package workload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	k8sutil "github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/loadtest"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Output formats of the loadtest command.
+const (
+	loadTestOutputPlain = "plain"
+	loadTestOutputJSON  = "json"
+)
+
+// ErrInvalidLoadTestOutput rejects --output values other than plain and json.
+var ErrInvalidLoadTestOutput = errors.New("invalid --output: must be plain or json")
+
+const loadTestCmdLong = `Load test a URL or Service from inside the cluster.
+
+Runs a k6 load generator as a one-off Job that sends requests at a constant
+rate for the given duration, then prints the request count, error rate, and
+latency percentiles. Because the load originates inside the cluster, Services
+are reached by their cluster DNS name without port-forwarding or exposing them
+— useful for watching a HorizontalPodAutoscaler scale out, or for checking that
+resource limits hold under load, on a local cluster.
+
+The target is either an http(s) URL or a Service reference of the form
+service[.namespace][:port][/path]. A Service is looked up before the Job is
+created; the port (a name or number) defaults to the Service's first port.
+
+Progress is reported while the test runs. The Job is deleted when the test ends
+or is interrupted with Ctrl-C, unless --keep is set.
+
+"Dropped" in the summary counts requests that could not be sent on schedule
+because every virtual user was waiting on a response: raise --vus, or treat it
+as a sign the target is saturated.`
+
+const loadTestCmdExample = `  # 10 req/s for 30s against the first port of the "web" Service
+  ksail workload loadtest web
+
+  # 200 req/s for 2 minutes against a path on a named port in another namespace
+  ksail workload loadtest web.shop:http/api/products --rps 200 --duration 2m
+
+  # Load test a URL and emit the summary as JSON
+  ksail workload loadtest https://example.com --rps 5 --output json`
+
+// newLoadTestClient builds the Kubernetes clientset the loadtest command uses;
+// a package-level seam so tests can substitute a fake clientset.
+//
+//nolint:gochecknoglobals // Test seam: lets tests inject a fake clientset without a live cluster.
+var newLoadTestClient = func(kubeconfigPath, contextName string) (kubernetes.Interface, error) {
+	client, err := k8sutil.NewClientset(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("create Kubernetes client: %w", err)
+	}
+
+	return client, nil
+}
+
+// runLoadTest is the blocking load test the command drives; a package-level
+// seam so tests can skip waiting on a real Job.
+//
+//nolint:gochecknoglobals // Test seam: lets tests stub the in-cluster load test.
+var runLoadTest = loadtest.Run
+
+// loadTestOptions carries the loadtest command's flag values into the run function.
+type loadTestOptions struct {
+	namespace string
+	context   string
+	method    string
+	rps       int
+	duration  time.Duration
+	vus       int
+	image     string
+	output    string
+	keep      bool
+}
+
+// NewLoadTestCmd creates the workload loadtest command.
+func NewLoadTestCmd() *cobra.Command {
+	opts := loadTestOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "loadtest <url|service>",
+		Short:        "Load test a URL or Service from inside the cluster",
+		Long:         loadTestCmdLong,
+		Example:      loadTestCmdExample,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default",
+		"Namespace the load generator Job runs in and Service targets default to")
+	cmd.Flags().StringVar(&opts.context, "context", "",
+		"Kubeconfig context of the target cluster")
+	cmd.Flags().StringVarP(&opts.method, "method", "X", loadtest.DefaultMethod,
+		"HTTP method of each request")
+	cmd.Flags().IntVar(&opts.rps, "rps", loadtest.DefaultRPS,
+		"Constant request rate per second")
+	cmd.Flags().DurationVarP(&opts.duration, "duration", "d", loadtest.DefaultDuration,
+		"How long to apply the load (at least 1s)")
+	cmd.Flags().IntVar(&opts.vus, "vus", 0,
+		"Maximum concurrent virtual users (default: one per request per second)")
+	cmd.Flags().StringVar(&opts.image, "image", loadtest.DefaultImage(),
+		"k6 image the load generator Job runs")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", loadTestOutputPlain,
+		"Summary format: plain, json")
+	cmd.Flags().BoolVar(&opts.keep, "keep", false,
+		"Keep the finished load generator Job instead of deleting it")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if opts.output != loadTestOutputPlain && opts.output != loadTestOutputJSON {
+			return fmt.Errorf("%w: got %q", ErrInvalidLoadTestOutput, opts.output)
+		}
+
+		return runLoadTestCommand(cmd, args[0], opts)
+	}
+
+	return cmd
+}
+
+// runLoadTestCommand resolves the target, runs the load test Job to completion
+// and prints its summary.
+func runLoadTestCommand(cmd *cobra.Command, target string, opts loadTestOptions) error {
+	client, err := newLoadTestClient(kubeconfig.GetKubeconfigPathSilently(cmd), opts.context)
+	if err != nil {
+		return err
+	}
+
+	// Cancel on Ctrl-C instead of exiting, so the Job is still cleaned up.
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	url, err := loadtest.ResolveTarget(ctx, client, opts.namespace, target)
+	if err != nil {
+		return fmt.Errorf("resolve load test target: %w", err)
+	}
+
+	summary, err := runLoadTest(ctx, client, loadtest.Options{
+		Namespace: opts.namespace,
+		Target:    url,
+		Method:    opts.method,
+		RPS:       opts.rps,
+		Duration:  opts.duration,
+		VUs:       opts.vus,
+		Image:     opts.image,
+		Keep:      opts.keep,
+	}, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("load test: %w", err)
+	}
+
+	if opts.output == loadTestOutputJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+
+		err = encoder.Encode(summary)
+		if err != nil {
+			return fmt.Errorf("encode load test summary: %w", err)
+		}
+
+		return nil
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "load test finished",
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return loadtest.WriteSummary(cmd.OutOrStdout(), summary)
+}
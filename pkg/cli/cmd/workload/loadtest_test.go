@@ -0,0 +1,81 @@
+package workload_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/loadtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewLoadTestCmdHasCorrectDefaults(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewLoadTestCmd()
+
+	assert.Equal(t, "loadtest", cmd.Name())
+	assert.Equal(t, "10", cmd.Flags().Lookup("rps").DefValue)
+	assert.Equal(t, "30s", cmd.Flags().Lookup("duration").DefValue)
+	assert.Equal(t, "GET", cmd.Flags().Lookup("method").DefValue)
+	assert.Equal(t, "plain", cmd.Flags().Lookup("output").DefValue)
+	assert.Equal(t, loadtest.DefaultImage(), cmd.Flags().Lookup("image").DefValue)
+}
+
+func TestLoadTestCmd_InvalidOutput(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewLoadTestCmd()
+	cmd.SetArgs([]string{"web", "--output", "yaml"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, workload.ErrInvalidLoadTestOutput)
+}
+
+//nolint:paralleltest // Swaps package-level client and run seams.
+func TestLoadTestCmd_ResolvesServiceAndPrintsSummary(t *testing.T) {
+	client := fake.NewClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+	})
+
+	var got loadtest.Options
+
+	restore := workload.ExportSetLoadTest(
+		func(string, string) (kubernetes.Interface, error) { return client, nil },
+		func(
+			_ context.Context,
+			_ kubernetes.Interface,
+			opts loadtest.Options,
+			_ io.Writer,
+		) (loadtest.Summary, error) {
+			got = opts
+
+			return loadtest.Summary{Target: opts.Target, Requests: 600, Rate: 20}, nil
+		},
+	)
+	defer restore()
+
+	var out bytes.Buffer
+
+	cmd := workload.NewLoadTestCmd()
+	cmd.SetArgs([]string{"web/api", "-n", "shop", "--rps", "20", "--output", "json"})
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "http://web.shop.svc:8080/api", got.Target)
+	assert.Equal(t, "shop", got.Namespace)
+	assert.Equal(t, 20, got.RPS)
+	assert.Contains(t, out.String(), `"requests": 600`)
+}
@@ -0,0 +1,105 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// envFlag selects an environment overlay of the overlays project layout.
+const envFlag = "env"
+
+// errEnvWithManifestInputs is returned when --env is combined with -f or -k,
+// which would make it ambiguous what to apply.
+var errEnvWithManifestInputs = errors.New("--env cannot be combined with -f or -k")
+
+// overlayHelp documents --env for apply.
+const overlayHelp = `Environment overlays: in a project scaffolded with 'ksail project init --layout overlays',
+--env <name> applies the <sourceDirectory>/overlays/<name> kustomization as if it were
+passed with -k.`
+
+// withOverlaySelection adds --env to a kubectl manifest command and, when it is
+// set, points -k at the environment's overlay below the configured source
+// directory. It runs in PreRunE, ahead of every other wrapper, so the ownership
+// set of --prune is derived from the overlay and substitution builds it like any
+// other -k input.
+func withOverlaySelection(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(
+		envFlag, "",
+		"Environment overlay to apply from <sourceDirectory>/overlays/<env> (overlays project layout)",
+	)
+
+	origPreRunE := cmd.PreRunE
+	origPreRun := cmd.PreRun
+
+	cmd.PreRunE = func(child *cobra.Command, args []string) error {
+		err := selectOverlay(child)
+		if err != nil {
+			return err
+		}
+
+		if origPreRunE != nil {
+			return origPreRunE(child, args)
+		}
+
+		if origPreRun != nil {
+			origPreRun(child, args)
+		}
+
+		return nil
+	}
+	cmd.PreRun = nil
+
+	return cmd
+}
+
+// selectOverlay sets -k to the overlay --env names. Commands without --env set
+// are left untouched.
+func selectOverlay(cmd *cobra.Command) error {
+	envName := flagString(cmd, envFlag)
+	if envName == "" {
+		return nil
+	}
+
+	if flagString(cmd, kustomizeFlag) != "" || hasFilenames(cmd) {
+		return errEnvWithManifestInputs
+	}
+
+	cfg, configFound, loadErr := loadValidateConfigSilently(cmd)
+	if loadErr != nil {
+		return fmt.Errorf("load ksail.yaml to resolve --%s: %w", envFlag, loadErr)
+	}
+
+	sourceDir := v1alpha1.DefaultSourceDirectory
+	if configFound && cfg != nil {
+		sourceDir = resolveSourceDir(cfg, "")
+	}
+
+	overlayDir, err := environment.ResolveOverlayDir(sourceDir, envName)
+	if err != nil {
+		return fmt.Errorf("resolve --%s: %w", envFlag, err)
+	}
+
+	err = cmd.Flags().Set(kustomizeFlag, overlayDir)
+	if err != nil {
+		return fmt.Errorf("set --%s: %w", kustomizeFlag, err)
+	}
+
+	return nil
+}
+
+// hasFilenames reports whether cmd has any -f values.
+func hasFilenames(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup(filenameFlag)
+	if flag == nil {
+		return false
+	}
+
+	filenames, ok := flag.Value.(pflag.SliceValue)
+
+	return ok && len(filenames.GetSlice()) > 0
+}
@@ -0,0 +1,96 @@
+package workload_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOverlayCommand returns a stub apply command wrapped with --env selection.
+// Its RunE records the -k value it would apply.
+func newOverlayCommand(kustomize *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "apply",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			*kustomize, _ = cmd.Flags().GetString("kustomize")
+
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceP("filename", "f", nil, "")
+	cmd.Flags().StringP("kustomize", "k", "", "")
+
+	return workload.ExportWithOverlaySelection(cmd)
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestOverlaySelection_SetsKustomizeToOverlay(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ksail.yaml"), []byte(
+		"apiVersion: ksail.io/v1alpha1\nkind: Cluster\nspec:\n  workload:\n    sourceDirectory: deploy\n",
+	), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "deploy", "overlays", "staging"), 0o750))
+
+	var kustomize string
+
+	cmd := newOverlayCommand(&kustomize)
+	cmd.SetArgs([]string{"--env", "staging"})
+	require.NoError(t, cmd.Execute())
+
+	// The source directory resolves relative to ksail.yaml, so the overlay is
+	// compared by identity rather than by how its path is spelled.
+	want, err := os.Stat(filepath.Join(dir, "deploy", "overlays", "staging"))
+	require.NoError(t, err)
+
+	got, err := os.Stat(kustomize)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(want, got), "kustomize = %q", kustomize)
+}
+
+//nolint:paralleltest // uses t.Chdir
+func TestOverlaySelection_Rejects(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join("k8s", "overlays", "dev"), 0o750))
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name:    "combined with -k",
+			args:    []string{"--env", "dev", "-k", "k8s"},
+			wantErr: workload.ErrEnvWithManifestInputs,
+		},
+		{
+			name:    "combined with -f",
+			args:    []string{"--env", "dev", "-f", "app.yaml"},
+			wantErr: workload.ErrEnvWithManifestInputs,
+		},
+		{
+			name:    "missing overlay",
+			args:    []string{"--env", "production"},
+			wantErr: environment.ErrOverlayNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var kustomize string
+
+			cmd := newOverlayCommand(&kustomize)
+			cmd.SetArgs(testCase.args)
+
+			err := cmd.Execute()
+			require.ErrorIs(t, err, testCase.wantErr)
+			assert.Empty(t, kustomize, "kubectl does not run")
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
 	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
 	registryhelpers "github.com/devantler-tech/ksail/v7/pkg/svc/registryresolver"
 	"github.com/devantler-tech/ksail/v7/pkg/timer"
@@ -73,10 +74,17 @@ Examples:
   # Push with variant (subdirectory in repository)
   ksail workload push oci://localhost:5050/my-app/base:v1.0.0 --path=./k8s
 
+  # Push for the staging overlay of the overlays project layout
+  ksail workload push --env staging
+
 All parts of the OCI reference are optional and will be inferred:
   - host:port: Auto-detected from running local-registry container
   - repository: Derived from source directory name
-  - ref: Defaults to "dev"`
+  - ref: Defaults to "dev"
+
+With --env <name> (overlays project layout), the <source>/overlays/<name> overlay must
+exist, --validate checks it, and the ref defaults to the environment name, so each
+environment's cluster can follow its own tag of the same source tree.`
 }
 
 // configurePushFlags configures flags for the push command.
@@ -88,6 +96,11 @@ func configurePushFlags(
 ) {
 	cmd.Flags().BoolVar(validate, "validate", false, "Validate manifests before pushing")
 	cmd.Flags().StringVar(pathFlag, "path", "", "Source directory containing manifests to push")
+	cmd.Flags().String(
+		envFlag,
+		"",
+		"Environment overlay (<source>/overlays/<env>) to validate and tag the artifact for",
+	)
 	cmd.Flags().String(
 		"registry",
 		"",
@@ -115,6 +128,20 @@ func runPushCommand(
 	outputTimer := cmdCtx.OutputTimer
 	tmr := cmdCtx.Timer
 
+	// Resolve the --env overlay before any registry detection, so a missing
+	// overlay fails fast. Without --env the whole source directory is validated.
+	envName, _ := cmd.Flags().GetString(envFlag)
+	validateDir := ""
+
+	if envName != "" {
+		validateDir, err = environment.ResolveOverlayDir(
+			resolveSourceDir(clusterCfg, pathFlag), envName,
+		)
+		if err != nil {
+			return fmt.Errorf("resolve --%s: %w", envFlag, err)
+		}
+	}
+
 	// Parse OCI reference if provided
 	var ociRef *oci.Reference
 	if len(args) > 0 {
@@ -132,6 +159,14 @@ func runPushCommand(
 		return err
 	}
 
+	if envName != "" && (ociRef == nil || ociRef.Ref == "") {
+		params.Ref = envName
+	}
+
+	if validateDir == "" {
+		validateDir = params.SourceDir
+	}
+
 	cmd.Println()
 	notify.WriteMessage(notify.Message{
 		Type:    notify.TitleType,
@@ -144,19 +179,71 @@ func runPushCommand(
 
 	// Validate if flag is set or config option is enabled
 	if validate || clusterCfg.Spec.Workload.ValidateOnPush {
-		validateErr := validateManifests(cmd, params.SourceDir, outputTimer)
+		validateErr := validateManifests(cmd, validateDir, outputTimer)
 		if validateErr != nil {
 			return validateErr
 		}
 	}
 
-	return retryOnTransientError(
+	err = retryOnTransientError(
 		cmd.Context(), cmd,
 		pushMaxRetryAttempts, pushRetryBaseWait, pushRetryMaxWait,
 		func() error {
 			return buildAndPushArtifact(cmd, params, outputTimer)
 		},
 	)
+	if err != nil {
+		return err
+	}
+
+	applyPushRetention(cmd, params, clusterCfg.Spec.Cluster.LocalRegistry.Retention, outputTimer)
+
+	return nil
+}
+
+// applyPushRetention deletes the older tags of the pushed repository that the
+// local registry retention no longer keeps. Pruning is best effort: the push
+// already succeeded, so failures are reported as warnings.
+func applyPushRetention(
+	cmd *cobra.Command,
+	params *pushParams,
+	retention v1alpha1.RegistryRetention,
+	outputTimer timer.Timer,
+) {
+	if registry.RetentionPolicyFromSpec(retention).IsZero() {
+		return
+	}
+
+	deleted, err := registryhelpers.ApplyRetention(cmd.Context(), registryhelpers.ApplyRetentionOptions{
+		Host:       params.Host,
+		Port:       params.Port,
+		IsExternal: params.IsExternal,
+		Repository: params.Repository,
+		Tag:        params.Ref,
+		Retention:  retention,
+	})
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "failed to apply registry retention: %v",
+			Args:    []any{err},
+			Writer:  cmd.OutOrStdout(),
+		})
+
+		return
+	}
+
+	if len(deleted) == 0 {
+		return
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "pruned %d old tags of %s",
+		Args:    []any{len(deleted), params.Repository},
+		Timer:   outputTimer,
+		Writer:  cmd.OutOrStdout(),
+	})
 }
 
 // validateManifests runs manifest validation and reports progress.
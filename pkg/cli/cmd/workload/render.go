@@ -54,14 +54,18 @@ func (g *gitopsRenderer) expand(ctx context.Context, kustDir string) (render.Res
 		return render.Result{}, err //nolint:wrapcheck // caller strips the kustomize prefix
 	}
 
+	return g.expandStream(ctx, fluxsubst.ExpandFluxSubstitutions(output.Bytes()))
+}
+
+// expandStream Helm-renders the HelmReleases in an already built and
+// substituted manifest stream.
+func (g *gitopsRenderer) expandStream(ctx context.Context, stream []byte) (render.Result, error) {
 	helmClient, err := helm.NewTemplateOnlyClient()
 	if err != nil {
 		return render.Result{}, fmt.Errorf("create helm template client: %w", err)
 	}
 
-	expanded := fluxsubst.ExpandFluxSubstitutions(output.Bytes())
-
-	result, err := render.Expand(ctx, expanded, render.Options{
+	result, err := render.Expand(ctx, stream, render.Options{
 		Resolver: render.NewHelmChartResolver(
 			helmClient,
 			render.WithChartCache(g.chartCache),
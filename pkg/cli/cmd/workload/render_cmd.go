@@ -0,0 +1,269 @@
+package workload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/kustomize"
+	sopsclient "github.com/devantler-tech/ksail/v7/pkg/client/sops"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/fluxsubst"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/gitops/render"
+	"github.com/spf13/cobra"
+)
+
+// ErrRenderOutputNotEmpty is returned when --output points at a directory that
+// already has entries, which would mix stale files into the rendered set.
+var ErrRenderOutputNotEmpty = errors.New("output directory is not empty")
+
+// renderCmdLong is the long help for the render command.
+const renderCmdLong = `Render the kustomization in PATH into the fully hydrated manifests the GitOps
+engine applies.
+
+The kustomization is built with Kustomize, substitution variables are applied,
+SOPS-encrypted resources are decrypted, and HelmReleases are templated
+in-process with Helm and replaced by the resources they produce. Documents are
+sorted by kind, namespace, and name so the output is stable across runs.
+
+By default the manifests are printed to stdout, so they can be reviewed,
+diffed, or piped into other tools. With --output DIR, each resource is written
+to its own file (<kind>_<namespace>_<name>.yaml) in DIR, which suits
+golden-file tests in CI. DIR must be empty or not exist yet.
+
+SOPS decryption uses the local age key (SOPS_AGE_KEY, SOPS_AGE_KEY_FILE, or
+the default keys.txt) and any configured KMS. Pass --skip-decrypt to keep the
+encrypted resources as-is, e.g. when the key is not available. HelmReleases
+whose chart cannot be rendered offline are kept as-is with a warning; pass
+--skip-helm-render to keep every HelmRelease unrendered.
+
+` + sourcePathResolutionHelp + `
+
+` + substitutionHelp
+
+// renderFlags holds the render command's flag values.
+type renderFlags struct {
+	output         string
+	skipDecrypt    bool
+	skipHelmRender bool
+}
+
+// NewRenderCmd creates the workload render command.
+func NewRenderCmd() *cobra.Command {
+	var flags renderFlags
+
+	cmd := &cobra.Command{
+		Use:   "render [PATH]",
+		Short: "Render the fully hydrated manifests the GitOps engine applies",
+		Long:  renderCmdLong,
+		Example: `  # Print the hydrated manifests of the configured source directory
+  ksail workload render
+
+  # Write one file per resource for golden-file testing
+  ksail workload render ./k8s/clusters/dev --output ./testdata/golden
+
+  # Render without SOPS keys or chart access
+  ksail workload render --skip-decrypt --skip-helm-render`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRenderCmd(cmd, args, flags)
+		},
+		SilenceUsage: true,
+	}
+
+	addSubstitutionFlags(cmd)
+
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "",
+		"Directory to write one file per rendered resource to (default: stdout)")
+	cmd.Flags().BoolVar(&flags.skipDecrypt, "skip-decrypt", false,
+		"Keep SOPS-encrypted resources encrypted instead of decrypting them")
+	cmd.Flags().BoolVar(&flags.skipHelmRender, "skip-helm-render", false,
+		"Keep HelmReleases as-is instead of templating their charts")
+
+	return cmd
+}
+
+// runRenderCmd builds the kustomization at the resolved path, substitutes
+// variables when substitution is active, decrypts SOPS-encrypted resources,
+// renders HelmReleases, and writes the manifests to stdout or --output.
+func runRenderCmd(cmd *cobra.Command, args []string, flags renderFlags) error {
+	cfg, configFound, loadErr := loadValidateConfigSilently(cmd)
+
+	path, err := resolveValidatePath(args, cfg, configFound, loadErr)
+	if err != nil {
+		return err
+	}
+
+	lookup, active, err := resolveSubstitutionLookup(
+		cmd,
+		configuredSubstitution(cmd, cfg, configFound, loadErr),
+	)
+	if err != nil {
+		return err
+	}
+
+	output, err := kustomize.NewClient().Build(cmd.Context(), path)
+	if err != nil {
+		return fmt.Errorf("build kustomization %q: %w", path, err)
+	}
+
+	manifests := output.Bytes()
+	if active {
+		manifests = fluxsubst.Substitute(manifests, lookup)
+	}
+
+	if !flags.skipDecrypt {
+		manifests, err = decryptManifests(manifests)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := hydrateManifests(cmd.Context(), manifests, flags.skipHelmRender)
+	if err != nil {
+		return err
+	}
+
+	warnDegradations(cmd, result.Degradations)
+
+	if flags.output != "" {
+		return writeRenderedDir(cmd, flags.output, result.Documents)
+	}
+
+	_, err = cmd.OutOrStdout().Write(result.Bytes())
+	if err != nil {
+		return fmt.Errorf("write rendered manifests: %w", err)
+	}
+
+	return nil
+}
+
+// hydrateManifests renders the HelmReleases in manifests, or only parses and
+// sorts the stream when Helm rendering is skipped.
+func hydrateManifests(ctx context.Context, manifests []byte, skipHelmRender bool) (render.Result, error) {
+	if skipHelmRender {
+		return render.Result{Documents: render.Parse(manifests)}, nil
+	}
+
+	return newGitOpsRenderer().expandStream(ctx, manifests)
+}
+
+// decryptManifests decrypts every SOPS-encrypted document in the stream, as
+// Flux's kustomize-controller does before applying. It runs before Helm
+// rendering so HelmRelease valuesFrom Secrets resolve to their plaintext.
+func decryptManifests(manifests []byte) ([]byte, error) {
+	docs := fsutil.SplitYAMLDocuments(manifests)
+
+	var buf bytes.Buffer
+
+	for index, doc := range docs {
+		if sopsclient.IsEncryptedDocument(doc) {
+			decrypted, err := sopsclient.DecryptDocument(doc)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"decrypt %s (pass --skip-decrypt to keep it encrypted): %w",
+					describeDocument(doc), err,
+				)
+			}
+
+			doc = decrypted
+		}
+
+		if index > 0 {
+			buf.WriteString("---\n")
+		}
+
+		buf.Write(doc)
+
+		if len(doc) > 0 && doc[len(doc)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// describeDocument names a document as Kind/namespace/name for error messages.
+func describeDocument(doc []byte) string {
+	docs := render.Parse(doc)
+	if len(docs) == 0 || documentIdentity(docs[0]) == "" {
+		return "document"
+	}
+
+	return documentIdentity(docs[0])
+}
+
+// writeRenderedDir writes each document to its own file in dir. The directory
+// must be empty or not exist, so a golden-file set never keeps stale files.
+func writeRenderedDir(cmd *cobra.Command, dir string, docs []render.Document) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read output directory %q: %w", dir, err)
+	}
+
+	if len(entries) > 0 {
+		return fmt.Errorf("%w: %s", ErrRenderOutputNotEmpty, dir)
+	}
+
+	err = os.MkdirAll(dir, dirPerm)
+	if err != nil {
+		return fmt.Errorf("create output directory %q: %w", dir, err)
+	}
+
+	used := make(map[string]int, len(docs))
+
+	for _, doc := range docs {
+		name := renderedFileName(doc, used)
+
+		content := doc.Bytes
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			content = append(bytes.Clone(content), '\n')
+		}
+
+		err = os.WriteFile(filepath.Join(dir, name), content, renderedManifestPerm)
+		if err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "rendered %d resources to %s",
+		Args:    []any{len(docs), dir},
+		Writer:  cmd.ErrOrStderr(),
+	})
+
+	return nil
+}
+
+// renderedFileName returns the file name for doc: <kind>_<namespace>_<name>.yaml,
+// dropping the namespace for cluster-scoped resources. Repeated names (the same
+// identity in different API groups, or unnamed documents) get a numeric suffix.
+func renderedFileName(doc render.Document, used map[string]int) string {
+	parts := make([]string, 0, 3) //nolint:mnd // kind, namespace, name
+
+	for _, part := range []string{doc.Kind, doc.Namespace, doc.Name} {
+		if part != "" {
+			parts = append(parts, strings.ToLower(part))
+		}
+	}
+
+	base := strings.Join(parts, "_")
+	if base == "" {
+		base = "document"
+	}
+
+	used[base]++
+
+	if count := used[base]; count > 1 {
+		base += "-" + strconv.Itoa(count)
+	}
+
+	return base + ".yaml"
+}
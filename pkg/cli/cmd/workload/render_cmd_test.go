@@ -0,0 +1,176 @@
+package workload_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	sopsclient "github.com/devantler-tech/ksail/v7/pkg/client/sops"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/stores/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runRender(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := workload.NewRenderCmd()
+	cmd.SetArgs(args)
+
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+// TestRenderCmd_RendersHelmRelease verifies the HelmRelease is replaced by the
+// chart's rendered resources while its source object is kept.
+func TestRenderCmd_RendersHelmRelease(t *testing.T) {
+	t.Parallel()
+
+	dir := writeHelmReleaseKustomization(t, localChartURL(t, "validchart"))
+
+	out, err := runRender(t, dir)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "name: app-config")
+	assert.Contains(t, out, "kind: OCIRepository")
+	assert.NotContains(t, out, "kind: HelmRelease")
+}
+
+func TestRenderCmd_SkipHelmRenderKeepsHelmRelease(t *testing.T) {
+	t.Parallel()
+
+	dir := writeHelmReleaseKustomization(t, localChartURL(t, "validchart"))
+
+	out, err := runRender(t, dir, "--skip-helm-render")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "kind: HelmRelease")
+	assert.NotContains(t, out, "name: app-config")
+}
+
+func TestRenderCmd_WritesOneFilePerResource(t *testing.T) {
+	t.Parallel()
+
+	dir := writeHelmReleaseKustomization(t, localChartURL(t, "validchart"))
+	outDir := filepath.Join(t.TempDir(), "golden")
+
+	out, err := runRender(t, dir, "--output", outDir)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	assert.ElementsMatch(t, []string{
+		"configmap_default_app-config.yaml",
+		"ocirepository_flux-system_chart.yaml",
+	}, names)
+
+	content, err := os.ReadFile(filepath.Join(outDir, "configmap_default_app-config.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "name: app-config")
+}
+
+func TestRenderCmd_RejectsNonEmptyOutputDir(t *testing.T) {
+	t.Parallel()
+
+	dir := writeHelmReleaseKustomization(t, localChartURL(t, "validchart"))
+	outDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, "stale.yaml"), []byte("{}\n"), 0o600))
+
+	_, err := runRender(t, dir, "--output", outDir)
+	require.ErrorIs(t, err, workload.ErrRenderOutputNotEmpty)
+}
+
+// writeEncryptedSecretKustomization writes a kustomization with a Secret
+// encrypted for a fresh age identity and returns the directory and identity.
+func writeEncryptedSecretKustomization(t *testing.T) (string, *age.X25519Identity) {
+	t.Helper()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	ageKey, err := sopsage.MasterKeyFromRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	plainPath := filepath.Join(t.TempDir(), "secret.yaml")
+	require.NoError(t, os.WriteFile(plainPath, []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: default
+stringData:
+  password: hunter2
+`), 0o600))
+
+	encrypted, err := sopsclient.Encrypt(sopsclient.EncryptOpts{
+		EncryptConfig: sopsclient.EncryptConfig{
+			EncryptedRegex: "^(data|stringData)$",
+			KeyGroups:      []sops.KeyGroup{{ageKey}},
+		},
+		Cipher:      aes.NewCipher(),
+		InputStore:  &yaml.Store{},
+		OutputStore: &yaml.Store{},
+		InputPath:   plainPath,
+		KeyServices: []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.yaml"), encrypted, 0o600))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "kustomization.yaml"), []byte("resources:\n  - secret.yaml\n"), 0o600,
+	))
+
+	return dir, identity
+}
+
+//nolint:paralleltest // Sets SOPS_AGE_KEY.
+func TestRenderCmd_DecryptsSOPSResources(t *testing.T) {
+	dir, identity := writeEncryptedSecretKustomization(t)
+	t.Setenv("SOPS_AGE_KEY", identity.String())
+
+	out, err := runRender(t, dir)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "password: hunter2")
+	assert.NotContains(t, out, "sops:")
+
+	out, err = runRender(t, dir, "--skip-decrypt")
+	require.NoError(t, err)
+
+	assert.NotContains(t, out, "hunter2")
+	assert.Contains(t, out, "sops:")
+}
+
+//nolint:paralleltest // Sets SOPS_AGE_KEY.
+func TestRenderCmd_DecryptFailureNamesResource(t *testing.T) {
+	dir, _ := writeEncryptedSecretKustomization(t)
+
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Setenv("SOPS_AGE_KEY", other.String())
+	t.Setenv("SOPS_AGE_KEY_FILE", filepath.Join(t.TempDir(), "missing.txt"))
+
+	_, err = runRender(t, dir)
+	require.ErrorContains(t, err, "decrypt Secret/default/db")
+}
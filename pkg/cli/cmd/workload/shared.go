@@ -13,6 +13,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfighook"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/notifyhook"
 	"github.com/devantler-tech/ksail/v7/pkg/client/kubectl"
 	"github.com/devantler-tech/ksail/v7/pkg/client/netretry"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
@@ -123,10 +124,12 @@ func initImageCommandContext(
 
 // detectClusterInfo detects the cluster info after printing the header.
 // This should be called after initImageCommandContext and after printing the title.
+// Image commands reach into the node container runtimes, so a context that is
+// not a KSail cluster on the Docker provider is rejected with the reason.
 func (ctx *imageCommandContext) detectClusterInfo(cmdCtx context.Context) error {
 	ctx.Timer.NewStage()
 
-	clusterInfo, err := clusterdetector.DetectInfo(
+	target, err := clusterdetector.DetectTarget(
 		cmdCtx,
 		ctx.ClusterCfg.Spec.Cluster.Connection.Kubeconfig,
 		ctx.ClusterCfg.Spec.Cluster.Connection.Context,
@@ -135,7 +138,12 @@ func (ctx *imageCommandContext) detectClusterInfo(cmdCtx context.Context) error
 		return fmt.Errorf("detect cluster info: %w", err)
 	}
 
-	ctx.ClusterInfo = clusterInfo
+	err = target.Require(clusterdetector.CapabilityImageTransfer)
+	if err != nil {
+		return fmt.Errorf("detect cluster info: %w", err)
+	}
+
+	ctx.ClusterInfo = target.Info
 
 	return nil
 }
@@ -147,12 +155,18 @@ type kubectlCommandCreator func(client *kubectl.Client, kubeconfigPath string) *
 // The kubeconfig path is resolved lazily via a PersistentPreRunE hook so that the
 // --config persistent flag is honored after cobra has parsed all flags.
 func newKubectlCommand(creator kubectlCommandCreator) *cobra.Command {
+	return newKubectlCommandWithOutput(creator, os.Stdout)
+}
+
+// newKubectlCommandWithOutput is newKubectlCommand with kubectl's output stream
+// bound to out instead of stdout.
+func newKubectlCommandWithOutput(creator kubectlCommandCreator, out io.Writer) *cobra.Command {
 	// Use a placeholder during command construction so cobra can build the
 	// command tree.  The actual kubeconfig path will be resolved in
 	// PersistentPreRunE before the command runs.
 	client := kubectl.NewClient(genericiooptions.IOStreams{
 		In:     os.Stdin,
-		Out:    os.Stdout,
+		Out:    out,
 		ErrOut: os.Stderr,
 	})
 
@@ -171,11 +185,14 @@ func wrapWithKubeconfigResolution(cmd *cobra.Command) {
 	origPersistentPreRun := cmd.PersistentPreRun
 
 	cmd.PersistentPreRunE = func(child *cobra.Command, args []string) error {
+		// The root hook registering the spec.notify sinks is replaced by this one.
+		notifyhook.MaybeInstallSinks(child)
+
 		// Refresh expired Omni kubeconfig tokens before resolving the path,
 		// so path resolution picks up the freshly written kubeconfig.
 		kubeconfighook.MaybeRefreshOmniKubeconfig(child)
 
-		resolvedPath := kubeconfig.GetKubeconfigPathSilently(child)
+		resolvedPath, configuredContext := kubeconfig.GetConnectionSilently(child)
 
 		kubeconfigFlag := child.Flags().Lookup("kubeconfig")
 		if kubeconfigFlag != nil && !child.Flags().Changed("kubeconfig") {
@@ -187,6 +204,11 @@ func wrapWithKubeconfigResolution(cmd *cobra.Command) {
 			kubeconfigFlag.DefValue = resolvedPath
 		}
 
+		err := applyConfiguredContext(child, configuredContext)
+		if err != nil {
+			return err
+		}
+
 		if origPersistentPreRunE != nil {
 			return origPersistentPreRunE(child, args)
 		}
@@ -201,6 +223,25 @@ func wrapWithKubeconfigResolution(cmd *cobra.Command) {
 	cmd.PersistentPreRun = nil
 }
 
+// applyConfiguredContext targets the context in ksail.yaml
+// (spec.cluster.connection.context) when the command has a --context flag that
+// was not set explicitly. An explicit --context always wins, so any kubeconfig
+// context, KSail-managed or not, can be targeted; without a configured context
+// the kubeconfig's current context is used as before.
+func applyConfiguredContext(cmd *cobra.Command, configuredContext string) error {
+	contextFlag := cmd.Flags().Lookup("context")
+	if contextFlag == nil || configuredContext == "" || cmd.Flags().Changed("context") {
+		return nil
+	}
+
+	err := contextFlag.Value.Set(configuredContext)
+	if err != nil {
+		return fmt.Errorf("failed to set context flag: %w", err)
+	}
+
+	return nil
+}
+
 // resolveGitOpsEngine determines the GitOps engine from config, normalizing an
 // unset ("") value to GitOpsEngineNone so callers (e.g. push's BuildOptions)
 // never see an empty engine. This matches how reconcile.go treats "" as None.
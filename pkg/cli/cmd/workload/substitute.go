@@ -0,0 +1,364 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/kustomize"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/fluxsubst"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// varFlag sets a single substitution variable as KEY=VALUE.
+	varFlag = "var"
+	// varsFileFlag points at a dotenv-style file of substitution variables.
+	varsFileFlag = "vars-file"
+	// kustomizeFlag is kubectl's -k/--kustomize flag.
+	kustomizeFlag = "kustomize"
+	// substitutedManifestPerm is the permission for substituted manifest copies.
+	substitutedManifestPerm = 0o600
+	// substitutedDirPerm is the permission for directories holding substituted copies.
+	substitutedDirPerm = 0o700
+)
+
+// errInvalidVarFlag is returned when a --var value is not a KEY=VALUE pair.
+var errInvalidVarFlag = errors.New("--var must be KEY=VALUE")
+
+// substitutionHelp documents variable substitution for apply and render.
+const substitutionHelp = `Variable substitution: Flux postBuild-style references (${VAR}, ${VAR:-default},
+${VAR:=default}) are replaced with values from spec.workload.substitution.vars,
+spec.workload.substitution.varsFile, --vars-file, the environment, and --var (later
+sources win). Substitution is active when ksail.yaml declares variables or --var or
+--vars-file is passed; references without a value or default are left as-is and
+$${VAR} escapes a literal ${VAR}.`
+
+// addSubstitutionFlags registers the --var and --vars-file flags on cmd.
+func addSubstitutionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray(
+		varFlag, nil,
+		"Substitution variable as KEY=VALUE for ${KEY} references (repeatable; overrides all other sources)",
+	)
+	cmd.Flags().String(
+		varsFileFlag, "",
+		"Dotenv-style file (KEY=VALUE per line) of substitution variables",
+	)
+}
+
+// resolveSubstitutionLookup returns the variable lookup for cmd's --var and
+// --vars-file flags and spec.workload.substitution, and whether substitution is
+// active at all. Sources are consulted from highest to lowest precedence: --var,
+// the environment, --vars-file, spec.workload.substitution.varsFile, and
+// spec.workload.substitution.vars.
+func resolveSubstitutionLookup(
+	cmd *cobra.Command,
+	config v1alpha1.SubstitutionConfig,
+) (func(string) (string, bool), bool, error) {
+	flagVars, err := parseVarFlags(cmd)
+	if err != nil {
+		return nil, false, err
+	}
+
+	varsFile, _ := cmd.Flags().GetString(varsFileFlag)
+	if len(flagVars) == 0 && varsFile == "" && !config.IsEnabled() {
+		return nil, false, nil
+	}
+
+	var layers []map[string]string
+
+	for _, path := range []string{varsFile, config.VarsFile} {
+		if path == "" {
+			continue
+		}
+
+		fileVars, err := fluxsubst.LoadVarsFile(path)
+		if err != nil {
+			return nil, false, fmt.Errorf("load substitution variables: %w", err)
+		}
+
+		layers = append(layers, fileVars)
+	}
+
+	layers = append(layers, config.Vars)
+
+	lookup := func(name string) (string, bool) {
+		if value, ok := flagVars[name]; ok {
+			return value, true
+		}
+
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+
+		for _, layer := range layers {
+			if value, ok := layer[name]; ok {
+				return value, true
+			}
+		}
+
+		return "", false
+	}
+
+	return lookup, true, nil
+}
+
+// parseVarFlags parses the repeatable --var KEY=VALUE flag.
+func parseVarFlags(cmd *cobra.Command) (map[string]string, error) {
+	values, _ := cmd.Flags().GetStringArray(varFlag)
+	vars := make(map[string]string, len(values))
+
+	for _, value := range values {
+		name, val, found := strings.Cut(value, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("%w, got %q", errInvalidVarFlag, value)
+		}
+
+		vars[name] = val
+	}
+
+	return vars, nil
+}
+
+// configuredSubstitution returns spec.workload.substitution from the single
+// config load, or a zero config when no config file is found. A config file that
+// exists but failed to load is warned about and otherwise ignored so it cannot
+// block flag-driven substitution (mirrors configuredSkipKinds).
+func configuredSubstitution(
+	cmd *cobra.Command,
+	cfg *v1alpha1.Cluster,
+	configFound bool,
+	loadErr error,
+) v1alpha1.SubstitutionConfig {
+	if loadErr != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "could not read spec.workload.substitution from ksail.yaml: %v",
+			Args:    []any{loadErr},
+			Writer:  cmd.ErrOrStderr(),
+		})
+
+		return v1alpha1.SubstitutionConfig{}
+	}
+
+	if !configFound || cfg == nil {
+		return v1alpha1.SubstitutionConfig{}
+	}
+
+	return cfg.Spec.Workload.Substitution
+}
+
+// withVariableSubstitution adds the substitution flags to a kubectl manifest
+// command and, when substitution is active, points its -f and -k inputs at
+// substituted copies in a temporary directory before kubectl runs: files and
+// directories are copied with their manifests substituted, and a -k
+// kustomization is built and substituted into a single -f file. The copies are
+// removed once the command returns. Like withManifestInputResolution, kubectl's
+// Run is promoted to RunE so errors surface through cobra.
+func withVariableSubstitution(cmd *cobra.Command) *cobra.Command {
+	addSubstitutionFlags(cmd)
+
+	origRunE := cmd.RunE
+	origRun := cmd.Run
+
+	cmd.RunE = func(child *cobra.Command, args []string) error {
+		cfg, configFound, loadErr := loadValidateConfigSilently(child)
+
+		lookup, active, err := resolveSubstitutionLookup(
+			child,
+			configuredSubstitution(child, cfg, configFound, loadErr),
+		)
+		if err != nil {
+			return err
+		}
+
+		if active {
+			cleanup, err := substituteManifestFlags(child, lookup)
+			if err != nil {
+				return err
+			}
+
+			defer cleanup()
+		}
+
+		if origRunE != nil {
+			return origRunE(child, args)
+		}
+
+		origRun(child, args)
+
+		return nil
+	}
+	cmd.Run = nil
+
+	return cmd
+}
+
+// substituteManifestFlags rewrites cmd's -f and -k values to substituted copies
+// and returns a cleanup func removing them.
+func substituteManifestFlags(
+	cmd *cobra.Command,
+	lookup func(string) (string, bool),
+) (func(), error) {
+	flag := cmd.Flags().Lookup(filenameFlag)
+	if flag == nil {
+		return func() {}, nil
+	}
+
+	filenames, ok := flag.Value.(pflag.SliceValue)
+	if !ok {
+		return func() {}, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ksail-substitute-*")
+	if err != nil {
+		return nil, fmt.Errorf("create substitution temp dir: %w", err)
+	}
+
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+
+	sources := filenames.GetSlice()
+	substituted := make([]string, 0, len(sources)+1)
+
+	for index, source := range sources {
+		target := filepath.Join(tmpDir, strconv.Itoa(index)+"-"+filepath.Base(source))
+
+		err = substitutePath(source, target, lookup)
+		if err != nil {
+			cleanup()
+
+			return nil, err
+		}
+
+		substituted = append(substituted, target)
+	}
+
+	kustomizeValue := cmd.Flags().Lookup(kustomizeFlag)
+	if kustomizeValue != nil && kustomizeValue.Value.String() != "" {
+		target := filepath.Join(tmpDir, "kustomize.yaml")
+
+		err = substituteKustomization(cmd, kustomizeValue.Value.String(), target, lookup)
+		if err != nil {
+			cleanup()
+
+			return nil, err
+		}
+
+		err = kustomizeValue.Value.Set("")
+		if err != nil {
+			cleanup()
+
+			return nil, fmt.Errorf("clear --%s: %w", kustomizeFlag, err)
+		}
+
+		substituted = append(substituted, target)
+	}
+
+	err = filenames.Replace(substituted)
+	if err != nil {
+		cleanup()
+
+		return nil, fmt.Errorf("set --%s: %w", filenameFlag, err)
+	}
+
+	return cleanup, nil
+}
+
+// substituteKustomization builds the kustomization at dir and writes the
+// substituted output to target.
+func substituteKustomization(
+	cmd *cobra.Command,
+	dir, target string,
+	lookup func(string) (string, bool),
+) error {
+	output, err := kustomize.NewClient().Build(cmd.Context(), dir)
+	if err != nil {
+		return fmt.Errorf("build kustomization %q: %w", dir, err)
+	}
+
+	err = os.WriteFile(target, fluxsubst.Substitute(output.Bytes(), lookup), substitutedManifestPerm)
+	if err != nil {
+		return fmt.Errorf("write substituted kustomization: %w", err)
+	}
+
+	return nil
+}
+
+// substitutePath writes a substituted copy of the manifest file or directory
+// tree at source to target. Only the YAML and JSON files kubectl reads are
+// copied.
+func substitutePath(source, target string, lookup func(string) (string, bool)) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("stat %q: %w", source, err)
+	}
+
+	if !info.IsDir() {
+		return substituteFile(source, target, lookup)
+	}
+
+	err = filepath.WalkDir(source, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return fmt.Errorf("relativize %q: %w", path, err)
+		}
+
+		dest := filepath.Join(target, rel)
+
+		if entry.IsDir() {
+			err = os.MkdirAll(dest, substitutedDirPerm)
+			if err != nil {
+				return fmt.Errorf("create %q: %w", dest, err)
+			}
+
+			return nil
+		}
+
+		if !isManifestFile(path) {
+			return nil
+		}
+
+		return substituteFile(path, dest, lookup)
+	})
+	if err != nil {
+		return fmt.Errorf("substitute %q: %w", source, err)
+	}
+
+	return nil
+}
+
+// substituteFile writes a substituted copy of the file at source to target.
+func substituteFile(source, target string, lookup func(string) (string, bool)) error {
+	data, err := os.ReadFile(source) //nolint:gosec // user-supplied manifest path
+	if err != nil {
+		return fmt.Errorf("read %q: %w", source, err)
+	}
+
+	err = os.WriteFile(target, fluxsubst.Substitute(data, lookup), substitutedManifestPerm)
+	if err != nil {
+		return fmt.Errorf("write substituted %q: %w", source, err)
+	}
+
+	return nil
+}
+
+// isManifestFile reports whether path has an extension kubectl reads manifests from.
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
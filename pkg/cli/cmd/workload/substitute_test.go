@@ -0,0 +1,153 @@
+package workload_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const substitutionConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  registry: ${REGISTRY}
+  domain: ${DOMAIN}
+  port: "${PORT:-8080}"
+  unset: ${UNSET}
+`
+
+func writeSubstitutionFixture(t *testing.T, dir string) {
+	t.Helper()
+
+	files := map[string]string{
+		"kustomization.yaml": "resources:\n  - configmap.yaml\n",
+		"configmap.yaml":     substitutionConfigMap,
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+}
+
+//nolint:paralleltest // Changes the working directory and environment.
+func TestRenderCmd_SubstitutesVariables(t *testing.T) {
+	dir := t.TempDir()
+	writeSubstitutionFixture(t, dir)
+
+	ksailYAML := `apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  workload:
+    sourceDirectory: .
+    substitution:
+      vars:
+        REGISTRY: config-registry
+        DOMAIN: config.local
+      varsFile: dev.env
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ksail.yaml"), []byte(ksailYAML), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dev.env"), []byte("DOMAIN=file.local\n"), 0o600))
+
+	t.Chdir(dir)
+	t.Setenv("REGISTRY", "env-registry")
+
+	cmd := workload.NewRenderCmd()
+
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--var", "PORT=9090"})
+
+	require.NoError(t, cmd.Execute())
+
+	rendered := out.String()
+	assert.Contains(t, rendered, "registry: env-registry")
+	assert.Contains(t, rendered, "domain: file.local")
+	assert.Contains(t, rendered, "port: 9090")
+	assert.Contains(t, rendered, "unset: ${UNSET}")
+}
+
+//nolint:paralleltest // Changes the working directory.
+func TestRenderCmd_RejectsMalformedVar(t *testing.T) {
+	dir := t.TempDir()
+	writeSubstitutionFixture(t, dir)
+	t.Chdir(dir)
+
+	cmd := workload.NewRenderCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{dir, "--var", "REGISTRY"})
+
+	require.ErrorContains(t, cmd.Execute(), "--var must be KEY=VALUE")
+}
+
+func newManifestFlagsCommand(t *testing.T, filenames []string, kustomizeDir string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSliceP("filename", "f", nil, "")
+	cmd.Flags().StringP("kustomize", "k", "", "")
+
+	for _, name := range filenames {
+		require.NoError(t, cmd.Flags().Set("filename", name))
+	}
+
+	if kustomizeDir != "" {
+		require.NoError(t, cmd.Flags().Set("kustomize", kustomizeDir))
+	}
+
+	return cmd
+}
+
+func TestSubstituteManifestFlags(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeSubstitutionFixture(t, dir)
+
+	lookup := func(name string) (string, bool) {
+		if name == "REGISTRY" {
+			return "localhost:5000", true
+		}
+
+		return "", false
+	}
+
+	cmd := newManifestFlagsCommand(t, []string{filepath.Join(dir, "configmap.yaml"), dir}, dir)
+
+	cleanup, err := workload.ExportSubstituteManifestFlags(cmd, lookup)
+	require.NoError(t, err)
+
+	kustomizeDir, err := cmd.Flags().GetString("kustomize")
+	require.NoError(t, err)
+	assert.Empty(t, kustomizeDir, "-k is replaced by the built, substituted output")
+
+	filenames, err := cmd.Flags().GetStringSlice("filename")
+	require.NoError(t, err)
+	require.Len(t, filenames, 3)
+
+	fileCopy, err := os.ReadFile(filenames[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(fileCopy), "registry: localhost:5000")
+
+	dirCopy, err := os.ReadFile(filepath.Join(filenames[1], "configmap.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(dirCopy), "registry: localhost:5000")
+
+	built, err := os.ReadFile(filenames[2])
+	require.NoError(t, err)
+	assert.Contains(t, string(built), "registry: localhost:5000")
+	assert.Contains(t, string(built), "port: 8080")
+
+	cleanup()
+
+	_, err = os.Stat(filenames[0])
+	assert.True(t, os.IsNotExist(err), "cleanup removes the substituted copies")
+}
@@ -0,0 +1,273 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/spf13/cobra"
+)
+
+// ErrInvalidSuspendKind is returned when --kind is not a Flux Kustomization or HelmRelease.
+var ErrInvalidSuspendKind = errors.New("invalid --kind: must be kustomization or helmrelease")
+
+// ErrSuspendFluxOnlyFlag is returned when --kind or --namespace is used with Argo CD.
+var ErrSuspendFluxOnlyFlag = errors.New(
+	"--kind and --namespace only apply to Flux; Argo CD Applications are selected by name",
+)
+
+// suspendAction describes one direction of the suspend/resume pair.
+type suspendAction struct {
+	suspend bool
+	title   string
+	emoji   string
+	verb    string
+	noop    string
+}
+
+//nolint:gochecknoglobals // fixed descriptors for the suspend and resume commands
+var (
+	suspendReconciliation = suspendAction{
+		suspend: true,
+		title:   "Suspend Reconciliation...",
+		emoji:   "⏸️",
+		verb:    "suspended",
+		noop:    "reconciliation is already suspended",
+	}
+	resumeReconciliation = suspendAction{
+		suspend: false,
+		title:   "Resume Reconciliation...",
+		emoji:   "▶️",
+		verb:    "resumed",
+		noop:    "no suspended reconciliation to resume",
+	}
+)
+
+// NewSuspendCmd creates the workload suspend command.
+func NewSuspendCmd() *cobra.Command {
+	return newSuspendResumeCmd(
+		"suspend [name]",
+		"Pause GitOps reconciliation",
+		"Pause GitOps reconciliation so workloads can be debugged or patched by hand without "+
+			"the GitOps engine reverting the changes.\n\n"+
+			"For Flux, sets spec.suspend on Kustomizations and HelmReleases. "+
+			"For Argo CD, removes the automated sync policy from Applications and keeps it in the "+
+			"ksail.devantler.tech/suspended-automated-sync annotation so 'ksail workload resume' can restore it. "+
+			"Applications generated by an ApplicationSet get their sync policy back from the "+
+			"ApplicationSet controller.\n\n"+
+			"Without a name, every Kustomization and HelmRelease (Flux) or Application (Argo CD) is suspended.",
+		`  # Suspend all GitOps reconciliation
+  ksail workload suspend
+
+  # Suspend a single Flux HelmRelease
+  ksail workload suspend podinfo --kind helmrelease --namespace podinfo
+
+  # Suspend auto-sync of a single Argo CD Application
+  ksail workload suspend my-app`,
+		suspendReconciliation,
+	)
+}
+
+// NewResumeCmd creates the workload resume command.
+func NewResumeCmd() *cobra.Command {
+	return newSuspendResumeCmd(
+		"resume [name]",
+		"Resume GitOps reconciliation",
+		"Resume GitOps reconciliation paused by 'ksail workload suspend'.\n\n"+
+			"For Flux, clears spec.suspend on Kustomizations and HelmReleases and requests an immediate "+
+			"reconciliation. For Argo CD, restores the automated sync policy saved when the Application "+
+			"was suspended.\n\n"+
+			"Without a name, every suspended Kustomization and HelmRelease (Flux) or Application (Argo CD) is resumed.",
+		`  # Resume all GitOps reconciliation
+  ksail workload resume
+
+  # Resume a single Flux Kustomization
+  ksail workload resume apps --kind kustomization`,
+		resumeReconciliation,
+	)
+}
+
+// newSuspendResumeCmd builds the suspend or resume command for action.
+func newSuspendResumeCmd(use, short, long, example string, action suspendAction) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          use,
+		Short:        short,
+		Long:         long,
+		Example:      example,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cmd.Flags().String("kind", "", "Flux resource kind to select: kustomization or helmrelease (default both)")
+	cmd.Flags().String("namespace", "", "Flux resource namespace to select (default all namespaces)")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		return runSuspendResume(cmd, name, action)
+	}
+
+	return cmd
+}
+
+// runSuspendResume executes the suspend or resume command logic.
+func runSuspendResume(cmd *cobra.Command, name string, action suspendAction) error {
+	kind, _ := cmd.Flags().GetString("kind")
+	namespace, _ := cmd.Flags().GetString("namespace")
+
+	err := validateSuspendKind(kind)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := initCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	clusterCfg := ctx.ClusterCfg
+
+	gitOpsEngine := clusterCfg.Spec.Cluster.GitOpsEngine
+	if gitOpsEngine.IsNone() {
+		detected, detectErr := autoDetectGitOpsEngine(cmd, clusterCfg, ctx.Timer, ctx.OutputTimer)
+		if detectErr != nil {
+			return detectErr
+		}
+
+		gitOpsEngine = detected
+	}
+
+	cmd.Println()
+	notify.WriteMessage(notify.Message{
+		Type:    notify.TitleType,
+		Emoji:   action.emoji,
+		Content: action.title,
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	ctx.Timer.NewStage()
+
+	kubeconfigPath, err := getCanonicalKubeconfigPath(clusterCfg)
+	if err != nil {
+		return err
+	}
+
+	var changed []string
+
+	switch gitOpsEngine {
+	case v1alpha1.GitOpsEngineFlux:
+		changed, err = setFluxSuspended(cmd, kubeconfigPath, flux.SuspendSelector{
+			Kind:      kind,
+			Name:      name,
+			Namespace: namespace,
+		}, action.suspend)
+	case v1alpha1.GitOpsEngineArgoCD:
+		if kind != "" || namespace != "" {
+			return ErrSuspendFluxOnlyFlag
+		}
+
+		changed, err = setArgoCDAutoSync(cmd, kubeconfigPath, name, action.suspend)
+	case v1alpha1.GitOpsEngineNone:
+		return errGitOpsEngineRequired
+	default:
+		return errGitOpsEngineRequired
+	}
+
+	if err != nil {
+		return err
+	}
+
+	writer := cmd.OutOrStdout()
+
+	if len(changed) == 0 {
+		notify.Infof(writer, "%s", action.noop)
+
+		return nil
+	}
+
+	for _, ref := range changed {
+		writeActivityNotification(action.verb+" "+ref, writer)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.SuccessType,
+		Content: "reconciliation %s for %d resource(s)",
+		Args:    []any{action.verb, len(changed)},
+		Timer:   ctx.OutputTimer,
+		Writer:  writer,
+	})
+
+	return nil
+}
+
+// validateSuspendKind rejects a --kind that is neither a Kustomization nor a HelmRelease.
+func validateSuspendKind(kind string) error {
+	if kind == "" ||
+		strings.EqualFold(kind, flux.KindKustomization) ||
+		strings.EqualFold(kind, flux.KindHelmRelease) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: got %q", ErrInvalidSuspendKind, kind)
+}
+
+// setFluxSuspended toggles spec.suspend on the selected Flux resources.
+func setFluxSuspended(
+	cmd *cobra.Command,
+	kubeconfigPath string,
+	selector flux.SuspendSelector,
+	suspend bool,
+) ([]string, error) {
+	fluxReconciler, err := flux.NewReconciler(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("create flux reconciler: %w", err)
+	}
+
+	refs, err := fluxReconciler.SetSuspended(cmd.Context(), selector, suspend)
+	if err != nil {
+		return nil, fmt.Errorf("set flux suspension: %w", err)
+	}
+
+	changed := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		changed = append(changed, ref.String())
+	}
+
+	return changed, nil
+}
+
+// setArgoCDAutoSync suspends or resumes automated sync on Argo CD Applications.
+func setArgoCDAutoSync(cmd *cobra.Command, kubeconfigPath, name string, suspend bool) ([]string, error) {
+	argoReconciler, err := argocd.NewReconciler(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("create argocd reconciler: %w", err)
+	}
+
+	toggle := argoReconciler.ResumeAutoSync
+	if suspend {
+		toggle = argoReconciler.SuspendAutoSync
+	}
+
+	names, err := toggle(cmd.Context(), name)
+	if err != nil {
+		return nil, fmt.Errorf("set argocd auto-sync: %w", err)
+	}
+
+	changed := make([]string, 0, len(names))
+	for _, appName := range names {
+		changed = append(changed, "Application/"+argocd.DefaultNamespace+"/"+appName)
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,33 @@
+package workload_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuspendResumeCmdFlags(t *testing.T) {
+	t.Parallel()
+
+	for _, cmd := range []*cobra.Command{workload.NewSuspendCmd(), workload.NewResumeCmd()} {
+		assert.NotNil(t, cmd.Flags().Lookup("kind"), cmd.Name())
+		assert.NotNil(t, cmd.Flags().Lookup("namespace"), cmd.Name())
+		require.Error(t, cmd.Args(cmd, []string{"a", "b"}), cmd.Name())
+	}
+}
+
+func TestSuspendCmdRejectsUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewSuspendCmd()
+	cmd.SetArgs([]string{"--kind", "gitrepository"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, workload.ErrInvalidSuspendKind)
+}
@@ -9,27 +9,38 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/manifestinput"
 	"github.com/devantler-tech/ksail/v7/pkg/client/kubeconform"
 	"github.com/devantler-tech/ksail/v7/pkg/client/kustomize"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
 	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
 	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator/openapi"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/crdschema"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/fluxsubst"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/gitops/celrules"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/gitops/render"
 	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kustomizeTypes "sigs.k8s.io/kustomize/api/types"
 )
 
 const (
 	kustomizationFileName = "kustomization.yaml"
+	helmChartFileName     = "Chart.yaml"
 	validationConcurrency = 5
 	dirPerm               = 0o750
+	// clusterCRDListTimeout bounds listing the cluster's CRDs for --update-schemas.
+	clusterCRDListTimeout = 30 * time.Second
 )
 
 // kustomizationFileNames lists all kustomization filenames recognized by kubectl.
@@ -48,6 +59,9 @@ const validateLongDescription = `Validate Kubernetes manifest files and kustomiz
 This command validates individual YAML files and kustomizations in the specified path.
 ` + sourcePathResolutionHelp + `
 
+PATH may also be "-" to validate a manifest stream read from stdin, or an http(s)
+URL to validate a remote manifest, e.g. 'helm template ... | ksail workload validate -'.
+
 The validation process:
 1. Validates individual YAML files (patch files referenced in a kustomization file via patches,
    patchesStrategicMerge, or patchesJson6902 are excluded — they are not valid standalone
@@ -67,6 +81,15 @@ The validation process:
 Schema lookups use a local disk cache and require no network access. When no cached
 JSON schema is available, placeholders fall back to strings with YAML-native parsing.
 
+Kubernetes and CRD schemas are looked up in the schema store at ~/.ksail/schemas before
+the network. --update-schemas downloads the schema of every validated kind into the store
+and adds the CRD schemas of the cluster in the kubeconfig; --offline then validates with
+the store and local --schema-location directories only, without any network access.
+
+--cluster-openapi additionally validates every resource against the OpenAPI v3 schemas served
+by the cluster in the kubeconfig, so custom resources of installed CRDs are checked field by
+field (unknown fields, types, required fields and enum values), not just the built-in kinds.
+
 By default, Kubernetes Secrets are skipped to avoid validation failures due to SOPS fields.`
 
 // NewValidateCmd creates the workload validate command.
@@ -74,7 +97,7 @@ func NewValidateCmd() *cobra.Command {
 	flags := &validateFlags{}
 
 	cmd := &cobra.Command{
-		Use:   "validate [PATH]",
+		Use:   "validate [PATH | - | URL]",
 		Short: "Validate Kubernetes manifests and kustomizations",
 		Long:  validateLongDescription,
 		Args:  cobra.MaximumNArgs(1),
@@ -96,10 +119,14 @@ type validateFlags struct {
 	ignoreMissingSchemas bool
 	skipHelmRender       bool
 	includeCRDSchemas    bool
+	updateSchemas        bool
+	offline              bool
+	clusterOpenAPI       bool
 	ephemeral            bool
 	skipKinds            []string
 	schemaLocations      []string
 	rules                string
+	policies             string
 }
 
 // addValidateFlags registers the flags for the validate command, binding each to a
@@ -144,6 +171,29 @@ func addValidateFlags(cmd *cobra.Command, flags *validateFlags) {
 			"custom resources whose CRD ships in the repo are validated instead of skipped "+
 			"(off by default; a CRD that cannot be converted is warned and skipped)",
 	)
+	cmd.Flags().BoolVar(
+		&flags.updateSchemas,
+		"update-schemas",
+		false,
+		"Download the schema of every validated kind, plus the CRD schemas of the cluster in the "+
+			"kubeconfig, into the schema store at ~/.ksail/schemas so later runs can validate offline",
+	)
+	cmd.Flags().BoolVar(
+		&flags.offline,
+		"offline",
+		false,
+		"Validate without network access: only the schema store at ~/.ksail/schemas and local "+
+			"schema locations are used, and HelmReleases are validated without rendering their charts",
+	)
+	cmd.MarkFlagsMutuallyExclusive("offline", "update-schemas")
+	cmd.Flags().BoolVar(
+		&flags.clusterOpenAPI,
+		"cluster-openapi",
+		false,
+		"Also validate every resource structurally against the OpenAPI v3 schemas served by the "+
+			"cluster in the kubeconfig, including installed CRDs, reporting field-level errors",
+	)
+	cmd.MarkFlagsMutuallyExclusive("offline", "cluster-openapi")
 	cmd.Flags().StringVar(
 		&flags.rules,
 		"rules",
@@ -153,6 +203,14 @@ func addValidateFlags(cmd *cobra.Command, flags *validateFlags) {
 			"validation, a warning-severity violation is reported without failing. "+
 			"Overrides spec.workload.validation.rules from ksail.yaml.",
 	)
+	cmd.Flags().StringVar(
+		&flags.policies,
+		"policies",
+		"",
+		"Path to a Kyverno policy file or directory (e.g. the scaffolded policies/ directory). "+
+			"The policies' CEL validation rules are evaluated against every rendered document "+
+			"before apply: Enforce rules fail validation, Audit rules are reported as warnings.",
+	)
 	cmd.Flags().BoolVar(
 		&flags.ephemeral,
 		"ephemeral",
@@ -177,6 +235,17 @@ func runValidateCmd(
 	args []string,
 	flags validateFlags,
 ) error {
+	// Materialize "-" (stdin) and URL arguments into a local file up front so
+	// every downstream step — including ephemeral source resolution — sees an
+	// ordinary file path.
+	resolver := manifestinput.NewResolver(cmd.InOrStdin())
+	defer resolver.Cleanup()
+
+	args, err := resolver.ResolveAll(ctx, args)
+	if err != nil {
+		return fmt.Errorf("resolve manifest input: %w", err)
+	}
+
 	if flags.ephemeral {
 		return withPreparedEphemeralCluster(ctx, cmd, args, func(ctx context.Context) error {
 			return runValidateCmdInner(ctx, cmd, args, flags)
@@ -226,7 +295,9 @@ func runValidateCmdInner(
 	// walk). Reusing this same renderer for the real validation pass below lets
 	// its chart cache (pkg/svc/gitops/render.ChartCache) serve the second render
 	// of an already-seen chart from memory instead of re-templating it.
-	renderer := buildValidateRenderer(cfg, configFound, flags.skipHelmRender)
+	// Offline runs cannot pull the charts HelmReleases reference, so they are
+	// validated as-is.
+	renderer := buildValidateRenderer(cfg, configFound, flags.skipHelmRender || flags.offline)
 
 	// Assemble validation options (skip-kinds, schema locations, and — when
 	// --include-crd-schemas is set — schemas derived from in-tree CRDs plus, when
@@ -251,16 +322,26 @@ func runValidateCmdInner(
 	// Compile the CEL rules once up front so a malformed rules file fails fast
 	// (before any manifest is processed) rather than silently skipping the rules.
 	// The path comes from --rules or, when the flag is empty, from
-	// spec.workload.validation.rules in ksail.yaml. A nil engine means neither was
-	// set (CEL validation disabled).
+	// spec.workload.validation.rules in ksail.yaml; --policies adds the CEL rules
+	// of Kyverno policies. A nil engine means none was set (CEL validation disabled).
 	rulesPath := resolveCELRulesPath(cmd, cfg, configFound, loadErr, flags.rules)
 
-	engine, err := buildCELEngine(rulesPath)
+	engine, err := buildCELEngine(cmd, rulesPath, flags.policies)
 	if err != nil {
 		return err
 	}
 
-	return validatePath(ctx, cmd, path, kubeconformClient, validationOpts, renderer, engine)
+	// Fetch the cluster's OpenAPI v3 schemas once up front, like the CEL rules,
+	// so an unreachable cluster fails before any manifest is processed. A nil
+	// validator means --cluster-openapi was not set.
+	schemas, err := buildClusterSchemaValidator(cmd, cfg, flags.clusterOpenAPI)
+	if err != nil {
+		return err
+	}
+
+	checks := documentChecks{engine: engine, schemas: schemas}
+
+	return validatePath(ctx, cmd, path, kubeconformClient, validationOpts, renderer, checks)
 }
 
 // buildValidationOptions assembles the kubeconform validation options from the
@@ -285,6 +366,22 @@ func buildValidationOptions(
 	validationOpts := &kubeconform.ValidationOptions{
 		Strict:               flags.strict,
 		IgnoreMissingSchemas: flags.ignoreMissingSchemas,
+		UpdateSchemas:        flags.updateSchemas,
+		Offline:              flags.offline,
+	}
+
+	storeDir, err := kubeconform.DefaultSchemaStoreDir()
+	if err != nil && (flags.offline || flags.updateSchemas) {
+		return nil, nil, fmt.Errorf("resolve schema store: %w", err)
+	}
+
+	validationOpts.SchemaStoreDir = storeDir
+
+	if flags.updateSchemas {
+		err = storeClusterCRDSchemas(ctx, cmd, cfg, storeDir)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	if flags.skipSecrets {
@@ -381,6 +478,87 @@ func addCRDSchemas(
 	return cleanup, nil
 }
 
+// storeClusterCRDSchemas converts the CustomResourceDefinitions of the cluster
+// in the kubeconfig into schemas in the schema store at storeDir, so custom
+// resources of operators installed in the cluster validate offline too. A
+// cluster that cannot be reached is warned about rather than failing the run:
+// --update-schemas still stores the schemas of the validated kinds.
+func storeClusterCRDSchemas(
+	ctx context.Context,
+	cmd *cobra.Command,
+	cfg *v1alpha1.Cluster,
+	storeDir string,
+) error {
+	var contextName string
+	if cfg != nil {
+		contextName = cfg.Spec.Cluster.Connection.Context
+	}
+
+	crds, err := listClusterCRDs(ctx, kubeconfig.GetKubeconfigPathSilently(cmd), contextName)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "skipped cluster CRD schemas: %v",
+			Args:    []any{err},
+			Writer:  cmd.ErrOrStderr(),
+		})
+
+		return nil
+	}
+
+	result, err := crdschema.MaterializeCRDs(crds, "cluster", kubeconform.SchemaStoreCRDDir(storeDir))
+	if err != nil {
+		return fmt.Errorf("store cluster CRD schemas: %w", err)
+	}
+
+	for _, warning := range result.Warnings {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "skipped CRD schema: %s",
+			Args:    []any{warning.String()},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.InfoType,
+		Content: "stored %d CRD schemas from the cluster in %s",
+		Args:    []any{result.Written, storeDir},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// listClusterCRDs lists the CustomResourceDefinitions of the cluster selected
+// by kubeconfigPath and contextName.
+//
+//nolint:gochecknoglobals // test seam replacing the cluster the CRDs are listed from
+var listClusterCRDs = func(
+	ctx context.Context,
+	kubeconfigPath, contextName string,
+) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	restConfig, err := k8s.BuildRESTConfig(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("build rest config: %w", err)
+	}
+
+	client, err := apiextensionsclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create apiextensions client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusterCRDListTimeout)
+	defer cancel()
+
+	list, err := client.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list CRDs: %w", err)
+	}
+
+	return list.Items, nil
+}
+
 // addRenderedCRDSchemas renders every kustomization under path and extracts CRD
 // schemas from each rendered manifest stream into destDir, discovering CRDs that
 // only exist after Helm/Kustomize rendering (invisible to a raw source-tree
@@ -679,7 +857,7 @@ func validatePath(
 	kubeconformClient *kubeconform.Client,
 	opts *kubeconform.ValidationOptions,
 	renderer *gitopsRenderer,
-	engine *celrules.Engine,
+	checks documentChecks,
 ) error {
 	// Check if path exists
 	info, err := os.Stat(path)
@@ -691,11 +869,11 @@ func validatePath(
 	if !info.IsDir() {
 		rootDir := filepath.Dir(path)
 
-		return validateFile(ctx, cmd, rootDir, path, kubeconformClient, opts, engine)
+		return validateFile(ctx, cmd, rootDir, path, kubeconformClient, opts, checks)
 	}
 
 	// If it's a directory, walk it to find YAML files and kustomizations
-	return validateDirectory(ctx, cmd, path, kubeconformClient, opts, renderer, engine)
+	return validateDirectory(ctx, cmd, path, kubeconformClient, opts, renderer, checks)
 }
 
 // validateFile validates a single YAML file, then applies any CEL rules. A
@@ -708,7 +886,7 @@ func validateFile(
 	filePath string,
 	kubeconformClient *kubeconform.Client,
 	opts *kubeconform.ValidationOptions,
-	engine *celrules.Engine,
+	checks documentChecks,
 ) error {
 	// Only validate YAML files
 	if !isYAMLFile(filePath) {
@@ -724,7 +902,7 @@ func validateFile(
 
 	sink := &celViolationSink{}
 
-	err := validateFileSilent(ctx, rootDir, filePath, kubeconformClient, opts, engine, sink)
+	err := validateFileSilent(ctx, rootDir, filePath, kubeconformClient, opts, checks, sink)
 
 	sink.report(cmd)
 
@@ -744,7 +922,7 @@ func validateDirectory(
 	kubeconformClient *kubeconform.Client,
 	opts *kubeconform.ValidationOptions,
 	renderer *gitopsRenderer,
-	engine *celrules.Engine,
+	checks documentChecks,
 ) error {
 	// Find all kustomizations
 	kustomizations, err := findKustomizations(dirPath)
@@ -782,7 +960,7 @@ func validateDirectory(
 			renderer:    renderer,
 			sink:        &degradationSink{},
 			opts:        opts,
-			engine:      engine,
+			checks:      checks,
 			celSink:     celSink,
 		}
 
@@ -794,7 +972,7 @@ func validateDirectory(
 
 	// Validate individual YAML files in parallel with progress display
 	err = validateYAMLFiles(
-		ctx, cmd, dirPath, yamlFiles, kubeconformClient, opts, engine, celSink, progressOpts,
+		ctx, cmd, dirPath, yamlFiles, kubeconformClient, opts, checks, celSink, progressOpts,
 	)
 	if err != nil {
 		return err
@@ -813,7 +991,7 @@ func validateYAMLFiles(
 	yamlFiles []string,
 	kubeconformClient *kubeconform.Client,
 	opts *kubeconform.ValidationOptions,
-	engine *celrules.Engine,
+	checks documentChecks,
 	celSink *celViolationSink,
 	progressOpts []notify.ProgressOption,
 ) error {
@@ -825,7 +1003,7 @@ func validateYAMLFiles(
 		ctx, cmd, yamlFiles, dirPath, "Validating YAML files", "📄",
 		func(taskCtx context.Context, file string) error {
 			return validateFileSilent(
-				taskCtx, dirPath, file, kubeconformClient, opts, engine, celSink,
+				taskCtx, dirPath, file, kubeconformClient, opts, checks, celSink,
 			)
 		},
 		append(progressOpts, notify.WithCountLabel("files"))...,
@@ -880,6 +1058,31 @@ func runParallelValidation(
 	return nil
 }
 
+// documentChecks are the per-document checks applied after kubeconform: CEL
+// rules and, with --cluster-openapi, the cluster's OpenAPI v3 schemas. A nil
+// member disables that check.
+type documentChecks struct {
+	engine  *celrules.Engine
+	schemas *openapi.Validator
+}
+
+// run applies the checks to every document of a manifest stream; see
+// evaluateCELDocuments and validateClusterSchemaDocuments.
+func (c documentChecks) run(
+	data []byte,
+	source string,
+	skipKinds []string,
+	celSink *celViolationSink,
+	attribution map[string]string,
+) error {
+	err := evaluateCELDocuments(c.engine, data, source, skipKinds, celSink, attribution)
+	if err != nil {
+		return err
+	}
+
+	return validateClusterSchemaDocuments(c.schemas, data, source, skipKinds, attribution)
+}
+
 // kustomizationValidator validates one kustomization directory, optionally
 // Helm-rendering it first. Its validate method is the task function passed to
 // the parallel progress group.
@@ -890,7 +1093,7 @@ type kustomizationValidator struct {
 	renderer    *gitopsRenderer // nil → Helm rendering disabled
 	sink        *degradationSink
 	opts        *kubeconform.ValidationOptions
-	engine      *celrules.Engine  // nil → CEL rule validation disabled
+	checks      documentChecks
 	celSink     *celViolationSink // collects warning-severity CEL violations
 }
 
@@ -959,11 +1162,12 @@ func (v *kustomizationValidator) validateSilent(ctx context.Context, kustDir str
 		return fmt.Errorf("validate manifests: %w", err)
 	}
 
-	// Apply CEL rules to the same rendered/built manifests kubeconform validated,
-	// honoring the same kind exclusions (--skip-kinds / --skip-secrets) and the
-	// same render-provenance attribution, so a skipped kind cannot surface a CEL
-	// failure and a rendered document's violation is traced to its HelmRelease.
-	err = evaluateCELDocuments(v.engine, data, kustDir, opts.SkipKinds, v.celSink, opts.Attribution)
+	// Apply CEL rules and cluster schemas to the same rendered/built manifests
+	// kubeconform validated, honoring the same kind exclusions (--skip-kinds /
+	// --skip-secrets) and the same render-provenance attribution, so a skipped
+	// kind cannot surface a failure and a rendered document's violation is
+	// traced to its HelmRelease.
+	err = v.checks.run(data, kustDir, opts.SkipKinds, v.celSink, opts.Attribution)
 	if err != nil {
 		return err
 	}
@@ -1066,7 +1270,7 @@ func validateFileSilent(
 	filePath string,
 	kubeconformClient *kubeconform.Client,
 	opts *kubeconform.ValidationOptions,
-	engine *celrules.Engine,
+	checks documentChecks,
 	celSink *celViolationSink,
 ) error {
 	// Only validate YAML files
@@ -1086,13 +1290,12 @@ func validateFileSilent(
 		return fmt.Errorf("validate file %s: %w", filePath, err)
 	}
 
-	// Apply CEL rules to the same content kubeconform validated, honoring the same
-	// kind exclusions (--skip-kinds / --skip-secrets) so a skipped kind cannot
-	// surface a CEL failure. Loose files carry no render provenance, so
-	// opts.Attribution is nil here and descriptions are unchanged.
-	err = evaluateCELDocuments(
-		engine, expanded, filePath, opts.SkipKinds, celSink, opts.Attribution,
-	)
+	// Apply CEL rules and cluster schemas to the same content kubeconform
+	// validated, honoring the same kind exclusions (--skip-kinds /
+	// --skip-secrets) so a skipped kind cannot surface a failure. Loose files
+	// carry no render provenance, so opts.Attribution is nil here and
+	// descriptions are unchanged.
+	err = checks.run(expanded, filePath, opts.SkipKinds, celSink, opts.Attribution)
 	if err != nil {
 		return err
 	}
@@ -1139,8 +1342,13 @@ func simplifyBuildError(err error, basePath string) error {
 
 // walkFiles collects file paths under rootPath that satisfy match.
 // match receives the full path and os.FileInfo for each non-directory entry
-// and returns the value to collect (empty string means skip).
-func walkFiles(rootPath string, match func(string, os.FileInfo) string) ([]string, error) {
+// and returns the value to collect (empty string means skip). Directories
+// below rootPath for which skipDir (when non-nil) returns true are not entered.
+func walkFiles(
+	rootPath string,
+	skipDir func(string) bool,
+	match func(string, os.FileInfo) string,
+) ([]string, error) {
 	var results []string
 
 	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
@@ -1148,6 +1356,10 @@ func walkFiles(rootPath string, match func(string, os.FileInfo) string) ([]strin
 			return err
 		}
 
+		if info.IsDir() && path != rootPath && skipDir != nil && skipDir(path) {
+			return filepath.SkipDir
+		}
+
 		if !info.IsDir() {
 			if v := match(path, info); v != "" {
 				results = append(results, v)
@@ -1166,7 +1378,7 @@ func walkFiles(rootPath string, match func(string, os.FileInfo) string) ([]strin
 // findKustomizations finds all directories containing a kustomization file
 // recognized by kubectl (kustomization.yaml, kustomization.yml, or Kustomization).
 func findKustomizations(rootPath string) ([]string, error) {
-	return walkFiles(rootPath, func(path string, info os.FileInfo) string {
+	return walkFiles(rootPath, nil, func(path string, info os.FileInfo) string {
 		if slices.Contains(kustomizationFileNames, info.Name()) {
 			return filepath.Dir(path)
 		}
@@ -1176,9 +1388,11 @@ func findKustomizations(rootPath string) ([]string, error) {
 }
 
 // findYAMLFiles finds all YAML files in a directory, excluding kustomization files
-// recognized by kubectl (kustomization.yaml, kustomization.yml, or Kustomization).
+// recognized by kubectl (kustomization.yaml, kustomization.yml, or Kustomization)
+// and Helm chart directories, whose templates and values are not manifests on
+// their own.
 func findYAMLFiles(rootPath string) ([]string, error) {
-	return walkFiles(rootPath, func(path string, _ os.FileInfo) string {
+	return walkFiles(rootPath, isHelmChartDir, func(path string, _ os.FileInfo) string {
 		base := filepath.Base(path)
 
 		if slices.Contains(kustomizationFileNames, base) {
@@ -1193,6 +1407,13 @@ func findYAMLFiles(rootPath string) ([]string, error) {
 	})
 }
 
+// isHelmChartDir reports whether dir is the root of a Helm chart.
+func isHelmChartDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, helmChartFileName))
+
+	return err == nil
+}
+
 // isYAMLFile checks if a file has a YAML extension.
 func isYAMLFile(filePath string) bool {
 	ext := strings.ToLower(filepath.Ext(filePath))
@@ -328,3 +328,75 @@ func TestDecodeDocumentObjectSkipsNonMappings(t *testing.T) {
 	require.True(t, mapOK, "a mapping document should decode")
 	assert.Equal(t, "ConfigMap", obj["kind"])
 }
+
+// writeHostNetworkPod writes a kubeconform-valid Pod sharing the host network
+// into dir, violating the Kyverno policy written by writeHostNetworkPolicy.
+func writeHostNetworkPod(t *testing.T, dir string) {
+	t.Helper()
+
+	content := `apiVersion: v1
+kind: Pod
+metadata:
+  name: host-pod
+  namespace: default
+spec:
+  hostNetwork: true
+  containers:
+    - name: app
+      image: nginx
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pod.yaml"), []byte(content), 0o600))
+}
+
+// writeHostNetworkPolicy writes a Kyverno ClusterPolicy with the given failure
+// action into its own temp directory and returns that directory.
+func writeHostNetworkPolicy(t *testing.T, failureAction string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	content := `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-host-network
+spec:
+  rules:
+    - name: host-network
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      validate:
+        failureAction: ` + failureAction + `
+        cel:
+          expressions:
+            - expression: "!has(object.spec.hostNetwork) || object.spec.hostNetwork == false"
+              message: hostNetwork is disallowed
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(content), 0o600))
+
+	return dir
+}
+
+func TestValidatePoliciesEnforceViolationFails(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeHostNetworkPod(t, dir)
+
+	_, err := runValidate(t, dir, "--policies", writeHostNetworkPolicy(t, "Enforce"))
+	require.Error(t, err, "an Enforce policy violation should fail validation")
+	require.ErrorContains(t, err, "disallow-host-network/host-network")
+	require.ErrorContains(t, err, "Pod/default/host-pod")
+}
+
+func TestValidatePoliciesAuditViolationWarns(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeHostNetworkPod(t, dir)
+
+	out, err := runValidate(t, dir, "--policies", writeHostNetworkPolicy(t, "Audit"))
+	require.NoError(t, err, "an Audit policy violation must not fail validation")
+	assert.Contains(t, out, "disallow-host-network/host-network")
+}
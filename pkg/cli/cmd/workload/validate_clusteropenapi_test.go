@@ -0,0 +1,98 @@
+package workload_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// widgetOpenAPIDocument is the OpenAPI v3 document a cluster with the widget
+// CRD installed serves for example.com/v1.
+const widgetOpenAPIDocument = `{"components": {"schemas": {"com.example.v1.Widget": {
+  "type": "object",
+  "required": ["spec"],
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {"type": "object"},
+    "spec": {"type": "object", "required": ["size"], "properties": {"size": {"type": "integer"}}}
+  },
+  "x-kubernetes-group-version-kind": [{"group": "example.com", "version": "v1", "kind": "Widget"}]
+}}}}`
+
+// storeStaleWidgetSchema stores a Widget schema that accepts any object, like a
+// stale catalog copy, in a fresh schema store so kubeconform finds it without
+// network access and lets invalid widgets through.
+func storeStaleWidgetSchema(t *testing.T) {
+	t.Helper()
+
+	dir := filepath.Join(setSchemaStoreHome(t), "crds", "example.com")
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "widget_v1.json"), []byte(`{"type":"object"}`), 0o600,
+	))
+}
+
+// loadWidgetSchemas stands in for a cluster that has the widget CRD installed.
+func loadWidgetSchemas(_, _ string) (*openapi.Validator, error) {
+	return openapi.NewValidator([]byte(widgetOpenAPIDocument))
+}
+
+// TestValidateClusterOpenAPI_ReportsFieldLevelErrors proves --cluster-openapi
+// catches a custom resource its stored schema lets through, naming the field.
+//
+//nolint:paralleltest // mutates HOME and the package-level cluster schema seam
+func TestValidateClusterOpenAPI_ReportsFieldLevelErrors(t *testing.T) {
+	storeStaleWidgetSchema(t)
+
+	restore := workload.ExportSetLoadClusterSchemas(loadWidgetSchemas)
+	defer restore()
+
+	dir := writeManifestDir(t, invalidWidget)
+
+	_, err := runValidate(t, dir)
+	require.NoError(t, err, "without --cluster-openapi only the stale schema is checked")
+
+	_, err = runValidate(t, dir, "--cluster-openapi")
+	require.ErrorIs(t, err, workload.ErrClusterSchemaViolation)
+	require.ErrorContains(t, err, "Widget/widget")
+	require.ErrorContains(t, err, "spec.bogus: unknown field")
+}
+
+//nolint:paralleltest // mutates HOME and the package-level cluster schema seam
+func TestValidateClusterOpenAPI_ValidAndSkippedResourcesPass(t *testing.T) {
+	storeStaleWidgetSchema(t)
+
+	restore := workload.ExportSetLoadClusterSchemas(loadWidgetSchemas)
+	defer restore()
+
+	_, err := runValidate(t, writeManifestDir(t, validWidget), "--cluster-openapi")
+	require.NoError(t, err)
+
+	_, err = runValidate(
+		t, writeManifestDir(t, invalidWidget), "--cluster-openapi", "--skip-kinds", "Widget",
+	)
+	require.NoError(t, err, "a skipped kind must not surface a cluster schema violation")
+}
+
+//nolint:paralleltest // mutates the package-level cluster schema seam
+func TestValidateClusterOpenAPI_UnreachableClusterFails(t *testing.T) {
+	restore := workload.ExportSetLoadClusterSchemas(func(_, _ string) (*openapi.Validator, error) {
+		return nil, errClusterUnreachable
+	})
+	defer restore()
+
+	_, err := runValidate(t, writeManifestDir(t, validWidget), "--cluster-openapi")
+	require.ErrorIs(t, err, errClusterUnreachable)
+}
+
+func TestValidateClusterOpenAPI_ExclusiveWithOffline(t *testing.T) {
+	t.Parallel()
+
+	_, err := runValidate(t, writeManifestDir(t, validWidget), "--offline", "--cluster-openapi")
+	require.ErrorContains(t, err, "none of the others can be")
+}
@@ -377,3 +377,31 @@ func TestValidateWithoutSchemaLocationIgnoresCatalogAbsentCRD(t *testing.T) {
 	_, err := runValidate(t, dir)
 	require.NoError(t, err, "a catalog-absent CRD is ignored without a supplied schema")
 }
+
+// TestValidateSkipsHelmChartDirectories verifies the files of a chart kept in the
+// source tree (the helm layout's charts/) are not validated as loose manifests:
+// its templates are not YAML until rendered and its values have no kind.
+func TestValidateSkipsHelmChartDirectories(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	chartDir := filepath.Join(dir, "charts", "app")
+	require.NoError(t, os.MkdirAll(filepath.Join(chartDir, "templates"), 0o750))
+
+	files := map[string]string{
+		filepath.Join(chartDir, "Chart.yaml"):  "apiVersion: v2\nname: app\nversion: 0.1.0\n",
+		filepath.Join(chartDir, "values.yaml"): "replicaCount: 1\n",
+		filepath.Join(chartDir, "templates", "deployment.yaml"): `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    {{- include "app.labels" . | nindent 4 }}
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	_, err := runValidate(t, dir, "--offline")
+	require.NoError(t, err, "chart templates and values are not validated as manifests")
+}
@@ -0,0 +1,118 @@
+package workload_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var errClusterUnreachable = errors.New("cluster unreachable")
+
+// setSchemaStoreHome points the home directory, and with it the schema store at
+// ~/.ksail/schemas, at a fresh temp directory and returns the store path.
+func setSchemaStoreHome(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	return filepath.Join(home, ".ksail", "schemas")
+}
+
+// writeManifestDir writes resource into a fresh temp directory and returns it.
+func writeManifestDir(t *testing.T, resource string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "resource.yaml"), []byte(resource), 0o600))
+
+	return dir
+}
+
+// listWidgetCRD stands in for a cluster that has the widget CRD installed.
+func listWidgetCRD(
+	_ context.Context,
+	_, _ string,
+) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	var crd apiextensionsv1.CustomResourceDefinition
+
+	err := yaml.Unmarshal([]byte(widgetCRDManifest), &crd)
+	if err != nil {
+		return nil, err
+	}
+
+	return []apiextensionsv1.CustomResourceDefinition{crd}, nil
+}
+
+// TestValidateUpdateSchemas_StoresClusterCRDSchemasForOffline proves the
+// offline round trip: --update-schemas stores the CRD schemas of the cluster,
+// and a later --offline run validates custom resources against them.
+//
+//nolint:paralleltest // mutates HOME and the package-level CRD listing seam
+func TestValidateUpdateSchemas_StoresClusterCRDSchemasForOffline(t *testing.T) {
+	storeDir := setSchemaStoreHome(t)
+
+	restore := workload.ExportSetListClusterCRDs(listWidgetCRD)
+	defer restore()
+
+	// Widget is skipped so no schema is downloaded; only the cluster CRD is stored.
+	output, err := runValidate(t, writeManifestDir(t, validWidget), "--update-schemas", "--skip-kinds", "Widget")
+	require.NoError(t, err)
+	require.Contains(t, output, "stored 1 CRD schemas from the cluster")
+	require.FileExists(t, filepath.Join(storeDir, "crds", "example.com", "widget_v1.json"))
+
+	_, err = runValidate(t, writeManifestDir(t, invalidWidget), "--offline")
+	require.Error(t, err, "an invalid CR should be caught against the stored cluster CRD schema")
+
+	_, err = runValidate(t, writeManifestDir(t, validWidget), "--offline")
+	require.NoError(t, err, "a valid CR should pass against the stored cluster CRD schema")
+}
+
+// TestValidateUpdateSchemas_UnreachableClusterWarns proves an unreachable
+// cluster does not fail --update-schemas; its CRD schemas are skipped with a
+// warning.
+//
+//nolint:paralleltest // mutates HOME and the package-level CRD listing seam
+func TestValidateUpdateSchemas_UnreachableClusterWarns(t *testing.T) {
+	setSchemaStoreHome(t)
+
+	restore := workload.ExportSetListClusterCRDs(func(
+		context.Context,
+		string,
+		string,
+	) ([]apiextensionsv1.CustomResourceDefinition, error) {
+		return nil, errClusterUnreachable
+	})
+	defer restore()
+
+	output, err := runValidate(t, writeManifestDir(t, validWidget), "--update-schemas", "--skip-kinds", "Widget")
+	require.NoError(t, err)
+	require.Contains(t, output, "skipped cluster CRD schemas")
+}
+
+// TestValidateOffline_IgnoresKindsMissingFromStore proves --offline never
+// reaches for the network: a kind without a stored schema is ignored like any
+// missing schema.
+//
+//nolint:paralleltest // mutates HOME
+func TestValidateOffline_IgnoresKindsMissingFromStore(t *testing.T) {
+	setSchemaStoreHome(t)
+
+	_, err := runValidate(t, writeManifestDir(t, invalidWidget), "--offline")
+	require.NoError(t, err)
+}
+
+func TestValidateOffline_ExclusiveWithUpdateSchemas(t *testing.T) {
+	t.Parallel()
+
+	_, err := runValidate(t, writeManifestDir(t, validWidget), "--offline", "--update-schemas")
+	require.ErrorContains(t, err, "none of the others can be")
+}
@@ -0,0 +1,47 @@
+package workload
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// The helpers below expose the validate and scan passes to `ksail verify`,
+// which runs them on the workload source directory with their default flag
+// values (flags that verify does not define read as unchanged, so
+// spec.workload settings from ksail.yaml still apply).
+
+// ResolveSourcePath returns the workload source directory configured in
+// ksail.yaml (spec.workload.sourceDirectory), or "." when no config file is found.
+func ResolveSourcePath(cmd *cobra.Command) (string, error) {
+	cfg, configFound, loadErr := loadValidateConfigSilently(cmd)
+
+	return resolveValidatePath(nil, cfg, configFound, loadErr)
+}
+
+// ValidateManifests validates the workload source directory exactly as
+// `ksail workload validate` does with no arguments and default flags.
+func ValidateManifests(ctx context.Context, cmd *cobra.Command) error {
+	return runValidateCmdInner(ctx, cmd, nil, validateFlags{
+		skipSecrets:          true,
+		ignoreMissingSchemas: true,
+	})
+}
+
+// ScanManifests runs the `ksail workload scan` policy gate on the workload
+// source directory when spec.workload.scan is configured in ksail.yaml. It
+// reports ran=false, without scanning, when no scan policy is configured.
+func ScanManifests(ctx context.Context, cmd *cobra.Command) (bool, error) {
+	cfg, configFound, _ := loadValidateConfigSilently(cmd)
+
+	scanCfg := workloadScanConfig(cfg, configFound)
+	if scanCfg.ComplianceThreshold == nil && scanCfg.Exceptions == "" &&
+		len(scanCfg.Frameworks) == 0 {
+		return false, nil
+	}
+
+	return true, runScanCmdInner(ctx, cmd, nil, scanFlags{
+		frameworks: []string{"nsa"},
+		format:     "pretty-printer",
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/client/kubectl"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/opmetrics"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/workloadwatch"
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
@@ -51,6 +52,21 @@ Hooks execute sequentially; if any hook fails the apply is skipped for
 that cycle. Hooks can also be configured in ksail.yaml under
 spec.workload.watch.hooks. CLI --hook flags are appended after config hooks.
 
+Use --serve to also start a lightweight webhook listener. A POST to
+/reconcile triggers an immediate GitOps reconciliation: for Flux, the root
+Kustomization's source (OCIRepository, GitRepository, or Bucket) and the
+root Kustomization; for Argo CD, a hard refresh of the root Application.
+Call it after 'ksail workload push' or from a Git host webhook to skip the
+poll interval wait. Requests arriving while one is pending are coalesced.
+The bare flag listens on 127.0.0.1:9292; pass an address to change it. The
+endpoint is unauthenticated, so only bind non-loopback addresses on trusted
+networks.
+
+Use --metrics-address to serve Prometheus metrics on /metrics: apply and
+webhook reconcile durations (ksail_reconcile_duration_seconds) and counters
+by result (ksail_operations_total), so a long-lived watcher on a shared dev
+cluster can be scraped by an existing Prometheus stack.
+
 Examples:
   # Watch the default k8s/ directory
   ksail workload watch
@@ -65,7 +81,14 @@ Examples:
   ksail workload watch --hook "docker build -t myapp:latest ."
 
   # Chain multiple hooks
-  ksail workload watch --hook "make generate" --hook "docker build -t myapp ."`
+  ksail workload watch --hook "make generate" --hook "docker build -t myapp ."
+
+  # Reconcile Flux right after each push instead of waiting for the interval
+  ksail workload watch --serve
+  ksail workload push && curl -X POST http://127.0.0.1:9292/reconcile
+
+  # Expose apply metrics for Prometheus
+  ksail workload watch --metrics-address 127.0.0.1:9293`
 
 // NewWatchCmd creates the workload watch command.
 func NewWatchCmd() *cobra.Command {
@@ -74,6 +97,8 @@ func NewWatchCmd() *cobra.Command {
 		initialApply bool
 		debugFlag    bool
 		hookFlags    []string
+		serveAddr    string
+		metricsAddr  string
 	)
 
 	cmd := &cobra.Command{
@@ -107,8 +132,19 @@ func NewWatchCmd() *cobra.Command {
 		"Shell command to run before each apply (repeatable; appended after spec.workload.watch.hooks)",
 	)
 
+	cmd.Flags().StringVar(
+		&serveAddr, "serve", "",
+		"Listen on this address for POST /reconcile webhook calls that trigger an immediate GitOps reconciliation",
+	)
+	cmd.Flags().Lookup("serve").NoOptDefVal = workloadwatch.DefaultServeAddress
+
+	cmd.Flags().StringVar(
+		&metricsAddr, "metrics-address", "",
+		"Serve Prometheus metrics for applies and webhook reconciles on this address (e.g. 127.0.0.1:9293)",
+	)
+
 	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
-		return runWatch(cmd, pathFlag, initialApply, debugFlag, hookFlags)
+		return runWatch(cmd, pathFlag, initialApply, debugFlag, hookFlags, serveAddr, metricsAddr)
 	}
 
 	return cmd
@@ -135,6 +171,8 @@ func runWatch(
 	initialApply bool,
 	debug bool,
 	hookFlags []string,
+	serveAddr string,
+	metricsAddr string,
 ) error {
 	// The root-level error executor captures stderr into a buffer for error
 	// aggregation.  For long-running commands like watch the buffer is never
@@ -186,6 +224,32 @@ func runWatch(
 	// the reconciler is nil and selective reconciliation is silently skipped.
 	fluxReconciler := tryCreateFluxReconciler()
 
+	var webhook *webhookListener
+
+	if serveAddr != "" {
+		webhook, err = startWebhookListener(cmd, cmdCtx, serveAddr)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = webhook.listener.Close() }()
+	}
+
+	var metrics *metricsListener
+
+	if metricsAddr != "" {
+		metrics, err = startMetricsListener(cmd, metricsAddr)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = metrics.listener.Close() }()
+
+		if webhook != nil {
+			webhook.recorder = metrics.recorder
+		}
+	}
+
 	cmd.Println()
 	notify.WriteMessage(notify.Message{
 		Type:    notify.TitleType,
@@ -200,9 +264,19 @@ func runWatch(
 		cmd.PrintErrf("  hooks:    %d configured\n", len(hooks))
 	}
 
+	if webhook != nil {
+		cmd.PrintErrf("  webhook:  POST http://%s%s\n", webhook.listener.Addr(), workloadwatch.ReconcilePath)
+	}
+
+	if metrics != nil {
+		cmd.PrintErrf("  metrics:  GET http://%s%s\n", metrics.listener.Addr(), opmetrics.MetricsPath)
+	}
+
 	cmd.PrintErrf("  press Ctrl+C to stop\n\n")
 
-	return watchLoop(cmd.Context(), cmd, absDir, initialApply, fluxReconciler, debug, hooks)
+	return watchLoop(
+		cmd.Context(), cmd, absDir, initialApply, fluxReconciler, debug, hooks, webhook, metrics,
+	)
 }
 
 // watchLoop sets up the fsnotify watcher and runs the debounced apply loop.
@@ -210,6 +284,8 @@ func runWatch(
 // after the event loop goroutine is started, so watcher events are consumed
 // immediately and not dropped or buffered during the initial apply. Ctrl+C
 // cancels both the initial apply and the event loop via the shared sigCtx.
+// When webhook or metrics is non-nil, its listener is served on the same
+// context.
 func watchLoop(
 	ctx context.Context,
 	cmd *cobra.Command,
@@ -218,6 +294,8 @@ func watchLoop(
 	fluxReconciler *flux.Reconciler,
 	debug bool,
 	hooks []string,
+	webhook *webhookListener,
+	metrics *metricsListener,
 ) error {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -241,11 +319,29 @@ func watchLoop(
 	errCh := make(chan error, 1)
 
 	go func() {
-		errCh <- eventLoop(sigCtx, cmd, watcher, dir, fluxReconciler, debug, hooks)
+		errCh <- eventLoop(sigCtx, cmd, watcher, dir, fluxReconciler, debug, hooks, recorderOf(metrics))
 	}()
 
+	if webhook != nil {
+		go func() {
+			serveErr := serveWebhook(sigCtx, cmd, webhook)
+			if serveErr != nil {
+				cmd.PrintErrf("⚠ %v\n", serveErr)
+			}
+		}()
+	}
+
+	if metrics != nil {
+		go func() {
+			serveErr := serveMetrics(sigCtx, metrics)
+			if serveErr != nil {
+				cmd.PrintErrf("⚠ %v\n", serveErr)
+			}
+		}()
+	}
+
 	if initialApply {
-		executeAndReportApply(sigCtx, cmd, dir, "initial apply", hooks)
+		executeAndReportApply(sigCtx, cmd, dir, "initial apply", hooks, recorderOf(metrics))
 	}
 
 	// Wait for the event loop to complete and propagate its error.
@@ -266,6 +362,7 @@ func eventLoop(
 	fluxReconciler *flux.Reconciler,
 	debug bool,
 	hooks []string,
+	recorder *opmetrics.Recorder,
 ) error {
 	state := &workloadwatch.DebounceState{}
 
@@ -274,7 +371,7 @@ func eventLoop(
 	applyCh := make(chan string, 1)
 
 	// Single worker: runs applies one at a time, stops when ctx is cancelled.
-	go applyWorker(ctx, cmd, dir, applyCh, fluxReconciler, hooks)
+	go applyWorker(ctx, cmd, dir, applyCh, fluxReconciler, hooks, recorder)
 
 	// Polling fallback: periodically scan for modification time changes to
 	// catch events missed by fsnotify (CI runners, atomic-save editors).
@@ -306,6 +403,7 @@ func applyWorker(
 	applyCh <-chan string,
 	fluxReconciler *flux.Reconciler,
 	hooks []string,
+	recorder *opmetrics.Recorder,
 ) {
 	var cachedKustomizations []flux.KustomizationInfo
 
@@ -318,7 +416,7 @@ func applyWorker(
 				return
 			}
 
-			applyAndReport(ctx, cmd, dir, file, fluxReconciler, &cachedKustomizations, hooks)
+			applyAndReport(ctx, cmd, dir, file, fluxReconciler, &cachedKustomizations, hooks, recorder)
 		}
 	}
 }
@@ -394,6 +492,7 @@ func executeAndReportApply(
 	cmd *cobra.Command,
 	dir, label string,
 	hooks []string,
+	recorder *opmetrics.Recorder,
 ) {
 	if ctx.Err() != nil {
 		return
@@ -419,6 +518,8 @@ func executeAndReportApply(
 				hookErr,
 			)
 
+			recorder.ObserveReconcile(applyOperation, elapsed, hookErr)
+
 			return
 		}
 	}
@@ -426,6 +527,8 @@ func executeAndReportApply(
 	applyErr := runKubectlApply(ctx, cmd, dir)
 	elapsed := time.Since(start)
 
+	recorder.ObserveReconcile(applyOperation, elapsed, applyErr)
+
 	timestamp = time.Now().Format("15:04:05")
 
 	if applyErr != nil {
@@ -461,6 +564,7 @@ func applyAndReport(
 	fluxReconciler *flux.Reconciler,
 	cachedKustomizations *[]flux.KustomizationInfo,
 	hooks []string,
+	recorder *opmetrics.Recorder,
 ) {
 	if ctx.Err() != nil {
 		return
@@ -488,7 +592,7 @@ func applyAndReport(
 		label = "→ reconciling subtree: " + relDir
 	}
 
-	executeAndReportApply(ctx, cmd, applyDir, label, hooks)
+	executeAndReportApply(ctx, cmd, applyDir, label, hooks, recorder)
 
 	reconcileFluxSelectively(ctx, cmd, fluxReconciler, applyDir, dir, cachedKustomizations)
 }
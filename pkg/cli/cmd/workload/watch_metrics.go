@@ -0,0 +1,55 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/opmetrics"
+	"github.com/spf13/cobra"
+)
+
+// Operations recorded in the metrics of `workload watch`.
+const (
+	applyOperation            = "apply"
+	webhookReconcileOperation = "webhook_reconcile"
+)
+
+// metricsListener is the bound `--metrics-address` listener and the recorder
+// whose metrics it serves.
+type metricsListener struct {
+	listener net.Listener
+	recorder *opmetrics.Recorder
+}
+
+// startMetricsListener binds the metrics listener to addr. Binding happens
+// before the watch loop starts so an address already in use is reported
+// immediately.
+func startMetricsListener(cmd *cobra.Command, addr string) (*metricsListener, error) {
+	listener, err := opmetrics.Listen(cmd.Context(), addr)
+	if err != nil {
+		return nil, fmt.Errorf("start metrics listener: %w", err)
+	}
+
+	return &metricsListener{listener: listener, recorder: opmetrics.NewRecorder()}, nil
+}
+
+// recorderOf returns the recorder of metrics, or nil when metrics are disabled.
+// A nil recorder records nothing.
+func recorderOf(metrics *metricsListener) *opmetrics.Recorder {
+	if metrics == nil {
+		return nil
+	}
+
+	return metrics.recorder
+}
+
+// serveMetrics serves the metrics listener until ctx is cancelled.
+func serveMetrics(ctx context.Context, metrics *metricsListener) error {
+	err := metrics.recorder.Serve(ctx, metrics.listener)
+	if err != nil {
+		return fmt.Errorf("serve metrics: %w", err)
+	}
+
+	return nil
+}
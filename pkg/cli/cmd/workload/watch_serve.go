@@ -0,0 +1,169 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/opmetrics"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/workloadwatch"
+	"github.com/spf13/cobra"
+)
+
+// webhookReconcileTimeout bounds a single webhook-triggered reconcile request.
+const webhookReconcileTimeout = 30 * time.Second
+
+// reconcileTrigger requests an immediate GitOps reconciliation without waiting
+// for it to finish and returns a description of what it triggered.
+type reconcileTrigger func(ctx context.Context) (string, error)
+
+// webhookListener is the bound `--serve` listener and the reconciliation it
+// triggers per request. A non-nil recorder records each reconcile.
+type webhookListener struct {
+	listener net.Listener
+	trigger  reconcileTrigger
+	recorder *opmetrics.Recorder
+}
+
+// startWebhookListener resolves the GitOps engine, auto-detecting it when
+// ksail.yaml leaves it unset, and binds the webhook listener to addr. Binding
+// happens before the watch loop starts so an address already in use is
+// reported immediately.
+func startWebhookListener(cmd *cobra.Command, cmdCtx *commandContext, addr string) (*webhookListener, error) {
+	clusterCfg := cmdCtx.ClusterCfg
+
+	gitOpsEngine := clusterCfg.Spec.Cluster.GitOpsEngine
+	if gitOpsEngine.IsNone() {
+		detected, err := autoDetectGitOpsEngine(cmd, clusterCfg, cmdCtx.Timer, cmdCtx.OutputTimer)
+		if err != nil {
+			return nil, err
+		}
+
+		gitOpsEngine = detected
+	}
+
+	kubeconfigPath, err := getCanonicalKubeconfigPath(clusterCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger, err := newReconcileTrigger(gitOpsEngine, kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := workloadwatch.Listen(cmd.Context(), addr)
+	if err != nil {
+		return nil, fmt.Errorf("start webhook listener: %w", err)
+	}
+
+	return &webhookListener{listener: listener, trigger: trigger}, nil
+}
+
+// newReconcileTrigger returns the reconcile trigger for gitOpsEngine. Flux
+// reconciles the root Kustomization's source and then the root Kustomization;
+// Argo CD hard-refreshes the root Application so its sync policy applies the
+// new revision.
+func newReconcileTrigger(gitOpsEngine v1alpha1.GitOpsEngine, kubeconfigPath string) (reconcileTrigger, error) {
+	switch gitOpsEngine {
+	case v1alpha1.GitOpsEngineFlux:
+		fluxReconciler, err := flux.NewReconciler(kubeconfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("create flux reconciler: %w", err)
+		}
+
+		return func(ctx context.Context) (string, error) {
+			source, err := fluxReconciler.TriggerSourceReconciliation(ctx)
+			if err != nil {
+				return "", fmt.Errorf("trigger flux source reconciliation: %w", err)
+			}
+
+			err = fluxReconciler.TriggerKustomizationReconciliation(ctx)
+			if err != nil {
+				return "", fmt.Errorf("trigger flux kustomization reconciliation: %w", err)
+			}
+
+			return "flux: reconciled " + source + " and root kustomization", nil
+		}, nil
+	case v1alpha1.GitOpsEngineArgoCD:
+		argoReconciler, err := argocd.NewReconciler(kubeconfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("create argocd reconciler: %w", err)
+		}
+
+		return func(ctx context.Context) (string, error) {
+			err := argoReconciler.TriggerRefresh(ctx, true)
+			if err != nil {
+				return "", fmt.Errorf("trigger argocd refresh: %w", err)
+			}
+
+			return "argocd: refreshed root application", nil
+		}, nil
+	case v1alpha1.GitOpsEngineNone:
+		return nil, errGitOpsEngineRequired
+	default:
+		return nil, errGitOpsEngineRequired
+	}
+}
+
+// serveWebhook serves the webhook listener until ctx is cancelled, running
+// each (coalesced) request through webhookWorker.
+func serveWebhook(ctx context.Context, cmd *cobra.Command, webhook *webhookListener) error {
+	requests := make(chan struct{}, 1)
+
+	go webhookWorker(ctx, cmd, requests, recordedTrigger(webhook.trigger, webhook.recorder))
+
+	err := workloadwatch.Serve(ctx, webhook.listener, workloadwatch.NewReconcileHandler(requests))
+	if err != nil {
+		return fmt.Errorf("serve webhook: %w", err)
+	}
+
+	return nil
+}
+
+// recordedTrigger wraps trigger so each call's duration and result are recorded.
+func recordedTrigger(trigger reconcileTrigger, recorder *opmetrics.Recorder) reconcileTrigger {
+	return func(ctx context.Context) (string, error) {
+		start := time.Now()
+		result, err := trigger(ctx)
+
+		recorder.ObserveReconcile(webhookReconcileOperation, time.Since(start), err)
+
+		return result, err
+	}
+}
+
+// webhookWorker runs one reconcile per request and prints a timestamped status
+// line for each, stopping when ctx is cancelled.
+func webhookWorker(
+	ctx context.Context,
+	cmd *cobra.Command,
+	requests <-chan struct{},
+	trigger reconcileTrigger,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-requests:
+			cmd.PrintErrf("[%s] webhook: reconcile requested\n", time.Now().Format("15:04:05"))
+
+			reqCtx, cancel := context.WithTimeout(ctx, webhookReconcileTimeout)
+			result, err := trigger(reqCtx)
+
+			cancel()
+
+			timestamp := time.Now().Format("15:04:05")
+
+			if err != nil {
+				cmd.PrintErrf("[%s] ⚠ webhook reconcile: %v\n\n", timestamp, err)
+			} else {
+				cmd.PrintErrf("[%s] ↻ %s\n\n", timestamp, result)
+			}
+		}
+	}
+}
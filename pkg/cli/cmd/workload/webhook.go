@@ -0,0 +1,294 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/annotations"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhooktunnel"
+	"github.com/spf13/cobra"
+)
+
+// ErrWebhookRequiresGitSource is returned when no spec.workload.git source is
+// configured: push events only reach the engine when it tracks a Git repository.
+var ErrWebhookRequiresGitSource = errors.New(
+	"webhook tunnel requires a Git source (spec.workload.git.url, see 'ksail init --push-to')",
+)
+
+// defaultWebhookWaitTimeout bounds how long the command waits for cloudflared
+// to report its public URL.
+const defaultWebhookWaitTimeout = 2 * time.Minute
+
+// webhookTeardownTimeout bounds the cleanup that runs after Ctrl-C, when the
+// command context is already cancelled.
+const webhookTeardownTimeout = 30 * time.Second
+
+const webhookCmdLong = `Expose the GitOps engine's webhook receiver on a public URL so that pushing
+to the tracked Git repository triggers immediate reconciliation.
+
+For Flux, KSail creates a github-type Receiver for the flux-system GitRepository;
+for ArgoCD, it sets the GitHub webhook secret argocd-server verifies /api/webhook
+payloads with. It then runs a cloudflared quick tunnel as a Deployment next to
+the engine and prints the public payload URL.
+
+When the repository is hosted on GitHub and a token is available (--git-token,
+GH_TOKEN/GITHUB_TOKEN, or the GitHub CLI login), the webhook is registered on the
+repository automatically and removed again on exit. Otherwise the payload URL and
+secret are printed for manual registration.
+
+The tunnel runs until interrupted. Ctrl-C removes the tunnel Deployment and the
+registered webhook; the Receiver and its secret are kept so a later run reuses
+them. Requires spec.workload.git (see 'ksail init --push-to').`
+
+const webhookCmdExample = `  # Tunnel push events to the cluster's GitOps engine for a demo
+  ksail workload webhook
+
+  # Print the payload URL and secret instead of registering the webhook
+  ksail workload webhook --register=false`
+
+// webhookOptions carries the webhook command's flag values into the run function.
+type webhookOptions struct {
+	image       string
+	gitToken    string
+	register    bool
+	waitTimeout time.Duration
+}
+
+// NewWebhookCmd creates the workload webhook command.
+func NewWebhookCmd() *cobra.Command {
+	opts := webhookOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "webhook",
+		Short:        "Tunnel Git push webhooks to the GitOps engine for instant reconciliation",
+		Long:         webhookCmdLong,
+		Example:      webhookCmdExample,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			annotations.AnnotationPermission: permissionWrite,
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.image, "image", webhooktunnel.DefaultImage,
+		"cloudflared image the in-cluster tunnel runs")
+	cmd.Flags().StringVar(&opts.gitToken, "git-token", "",
+		"Git provider API token used to register the webhook (default: provider SDK lookup)")
+	cmd.Flags().BoolVar(&opts.register, "register", true,
+		"Register the webhook on the Git provider when a token is available")
+	cmd.Flags().DurationVar(&opts.waitTimeout, "wait-timeout", defaultWebhookWaitTimeout,
+		"How long to wait for the tunnel to report its public URL")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return runWebhook(cmd, opts)
+	}
+
+	return cmd
+}
+
+func runWebhook(cmd *cobra.Command, opts webhookOptions) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cmdCtx, err := initCommandContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	clusterCfg := cmdCtx.ClusterCfg
+
+	git := clusterCfg.Spec.Workload.Git
+	if !git.IsEnabled() {
+		return ErrWebhookRequiresGitSource
+	}
+
+	engine := clusterCfg.Spec.Cluster.GitOpsEngine
+	if engine.IsNone() {
+		engine, err = autoDetectGitOpsEngine(cmd, clusterCfg, cmdCtx.Timer, cmdCtx.OutputTimer)
+		if err != nil {
+			return err
+		}
+	}
+
+	tunnel, target, err := startWebhookTunnel(ctx, cmd, clusterCfg, engine, opts.image)
+	if err != nil {
+		return cleanInterceptCancellation(ctx, err)
+	}
+
+	defer teardownWebhook(cmd, "remove tunnel", tunnel.Stop)
+
+	publicURL, err := tunnel.PublicURL(ctx, opts.waitTimeout)
+	if err != nil {
+		return cleanInterceptCancellation(ctx, fmt.Errorf("wait for tunnel: %w", err))
+	}
+
+	payloadURL := target.PayloadURL(publicURL)
+
+	unregister := announceWebhook(ctx, cmd, git, target, payloadURL, opts)
+	if unregister != nil {
+		defer teardownWebhook(cmd, "remove webhook", unregister)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "forwarding push events to %s — press Ctrl-C to stop",
+		Args:    []any{engine},
+		Writer:  cmd.ErrOrStderr(),
+	})
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// startWebhookTunnel prepares the engine's receiver and starts the in-cluster
+// tunnel pointed at it.
+func startWebhookTunnel(
+	ctx context.Context,
+	cmd *cobra.Command,
+	clusterCfg *v1alpha1.Cluster,
+	engine v1alpha1.GitOpsEngine,
+	image string,
+) (*webhooktunnel.Tunnel, webhooktunnel.Target, error) {
+	kubeconfigPath, err := getCanonicalKubeconfigPath(clusterCfg)
+	if err != nil {
+		return nil, webhooktunnel.Target{}, err
+	}
+
+	kubeContext := clusterCfg.Spec.Cluster.Connection.Context
+
+	client, err := k8s.NewClientset(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, webhooktunnel.Target{}, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	dyn, err := k8s.NewDynamicClient(kubeconfigPath, kubeContext)
+	if err != nil {
+		return nil, webhooktunnel.Target{}, fmt.Errorf("create dynamic client: %w", err)
+	}
+
+	target, err := webhooktunnel.EnsureReceiver(ctx, engine, client, dyn)
+	if err != nil {
+		return nil, webhooktunnel.Target{}, fmt.Errorf("prepare webhook receiver: %w", err)
+	}
+
+	tunnel := webhooktunnel.NewTunnel(client, target.Namespace, image)
+
+	err = tunnel.Start(ctx, target)
+	if err != nil {
+		return nil, webhooktunnel.Target{}, fmt.Errorf("start tunnel: %w", err)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "started webhook tunnel in namespace %s",
+		Args:    []any{target.Namespace},
+		Writer:  cmd.OutOrStdout(),
+	})
+
+	return tunnel, target, nil
+}
+
+// announceWebhook registers the webhook on the Git provider when possible and
+// returns the function that removes it again. When registration is disabled or
+// not possible, it prints the payload URL and secret for manual setup and
+// returns nil.
+func announceWebhook(
+	ctx context.Context,
+	cmd *cobra.Command,
+	git v1alpha1.GitSource,
+	target webhooktunnel.Target,
+	payloadURL string,
+	opts webhookOptions,
+) func(context.Context) error {
+	if opts.register {
+		unregister, err := registerWebhook(ctx, git, target, payloadURL, opts.gitToken)
+		if err == nil {
+			notify.WriteMessage(notify.Message{
+				Type:    notify.SuccessType,
+				Content: "registered webhook %s on %s",
+				Args:    []any{payloadURL, git.URL},
+				Writer:  cmd.OutOrStdout(),
+			})
+
+			return unregister
+		}
+
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "could not register the webhook automatically: %v",
+			Args:    []any{err},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type: notify.InfoType,
+		Content: "add a webhook to %s with payload URL %s, content type application/json, " +
+			"secret %s, and the push event",
+		Args:   []any{git.URL, payloadURL, target.Secret},
+		Writer: cmd.OutOrStdout(),
+	})
+
+	return nil
+}
+
+// registerWebhook creates the repository webhook through the Git provider API.
+func registerWebhook(
+	ctx context.Context,
+	git v1alpha1.GitSource,
+	target webhooktunnel.Target,
+	payloadURL, explicitToken string,
+) (func(context.Context) error, error) {
+	ref, err := gitprovider.ParseRepoRef(git.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse git source: %w", err)
+	}
+
+	token := gitprovider.ResolveToken(ref.Provider, explicitToken)
+
+	provider, err := gitprovider.New(ref.Provider, token)
+	if err != nil {
+		return nil, fmt.Errorf("create git provider client: %w", err)
+	}
+
+	hookID, err := provider.CreateWebhook(ctx, ref.Owner, ref.Name, gitprovider.WebhookOptions{
+		URL:    payloadURL,
+		Secret: target.Secret,
+		Events: []string{"push"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		return provider.DeleteWebhook(ctx, ref.Owner, ref.Name, hookID)
+	}, nil
+}
+
+// teardownWebhook runs a cleanup step on a fresh context, since the command
+// context is already cancelled by the time deferred cleanup runs, and reports
+// failures as warnings rather than masking the command's own result.
+func teardownWebhook(cmd *cobra.Command, verb string, cleanup func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(cmd.Context()), webhookTeardownTimeout)
+	defer cancel()
+
+	err := cleanup(ctx)
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "failed to %s: %v",
+			Args:    []any{verb, err},
+			Writer:  cmd.ErrOrStderr(),
+		})
+	}
+}
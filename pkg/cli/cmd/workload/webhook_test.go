@@ -0,0 +1,40 @@
+package workload_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/cmd/workload"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhooktunnel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookCmdHasCorrectDefaults(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewWebhookCmd()
+
+	assert.Equal(t, "webhook", cmd.Name())
+	assert.Equal(t, webhooktunnel.DefaultImage, cmd.Flags().Lookup("image").DefValue)
+	assert.Equal(t, "true", cmd.Flags().Lookup("register").DefValue)
+	assert.Equal(t, "2m0s", cmd.Flags().Lookup("wait-timeout").DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("git-token"))
+}
+
+//nolint:paralleltest // t.Chdir is incompatible with t.Parallel.
+func TestWebhookCmdRequiresGitSource(t *testing.T) {
+	// An empty directory has no ksail.yaml, so no spec.workload.git source.
+	t.Chdir(t.TempDir())
+
+	cmd := workload.NewWebhookCmd()
+	cmd.SetArgs([]string{})
+
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, workload.ErrWebhookRequiresGitSource)
+}
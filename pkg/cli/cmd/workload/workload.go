@@ -32,6 +32,7 @@ func NewWorkloadCmd() *cobra.Command {
 			"Read operations:\n" +
 			"  get       - List resources with optional -o json for structured output including status/conditions\n" +
 			"  describe  - Show detailed resource info including events, conditions, and error details\n" +
+			"  diff      - Diff manifests (files, stdin via -f -, or URLs) against the live cluster\n" +
 			"  logs      - Print container logs (use --tail=N, --previous for crash diagnostics)\n" +
 			"  explain   - Show API documentation for a resource kind\n" +
 			"  forward   - Forward one or more local ports to a pod\n" +
@@ -39,8 +40,8 @@ func NewWorkloadCmd() *cobra.Command {
 			"  scan      - Run security scans on Kubernetes manifests using Kubescape\n" +
 			"  wait      - Wait for a specific condition on resources\n\n" +
 			"Write operations:\n" +
-			"  apply, create, debug, delete, edit, exec, export, expose, import, install, push, " +
-			"reconcile, rollout, scale, watch\n" +
+			"  apply, create, debug, delete, deploy-key, dev, edit, exec, export, expose, import, install, loadtest, push, " +
+			"reconcile, resume, rollout, scale, suspend, watch, webhook\n" +
 			"  cipher    - Manage SOPS-encrypted secret files (encrypt, decrypt, edit, import, rotate)\n\n" +
 			"GitOps diagnostics: Use 'get' with Flux resources (kustomization, helmrelease, " +
 			"ocirepository -A -o json) or ArgoCD resources (application -A -o json) to check " +
@@ -81,6 +82,7 @@ func addWorkloadSubcommands(cmd *cobra.Command) {
 	addGroupedCommand(cmd, NewApplyCmd(), groupResources)
 	addGroupedCommand(cmd, NewCreateCmd(), groupResources)
 	addGroupedCommand(cmd, NewDeleteCmd(), groupResources)
+	addGroupedCommand(cmd, NewDiffCmd(), groupResources)
 	addGroupedCommand(cmd, NewEditCmd(), groupResources)
 	addGroupedCommand(cmd, NewExposeCmd(), groupResources)
 	addGroupedCommand(cmd, NewScaleCmd(), groupResources)
@@ -92,12 +94,18 @@ func addWorkloadSubcommands(cmd *cobra.Command) {
 	addGroupedCommand(cmd, NewImportCmd(), groupImages)
 
 	addGroupedCommand(cmd, NewReconcileCmd(), groupGitOps)
+	addGroupedCommand(cmd, NewSuspendCmd(), groupGitOps)
+	addGroupedCommand(cmd, NewResumeCmd(), groupGitOps)
 	addGroupedCommand(cmd, NewPushCmd(), groupGitOps)
 	addGroupedCommand(cmd, NewInstallCmd(), groupGitOps)
+	addGroupedCommand(cmd, NewRenderCmd(), groupGitOps)
 	addGroupedCommand(cmd, NewValidateCmd(), groupGitOps)
 	addGroupedCommand(cmd, NewScanCmd(), groupGitOps)
+	addGroupedCommand(cmd, NewWebhookCmd(), groupGitOps)
+	addGroupedCommand(cmd, NewDeployKeyCmd(), groupGitOps)
 
 	addGroupedCommand(cmd, NewWatchCmd(), groupDevLoop)
+	addGroupedCommand(cmd, NewDevCmd(), groupDevLoop)
 	addGroupedCommand(cmd, NewDebugCmd(), groupDevLoop)
 	addGroupedCommand(cmd, NewExecCmd(), groupDevLoop)
 	addGroupedCommand(cmd, NewForwardCmd(), groupDevLoop)
@@ -105,6 +113,7 @@ func addWorkloadSubcommands(cmd *cobra.Command) {
 	addGroupedCommand(cmd, NewMirrorCmd(), groupDevLoop)
 	addGroupedCommand(cmd, NewInterceptCmd(), groupDevLoop)
 	addGroupedCommand(cmd, NewNetworkCmd(), groupDevLoop)
+	addGroupedCommand(cmd, NewLoadTestCmd(), groupDevLoop)
 	addGroupedCommand(cmd, NewRolloutCmd(), groupDevLoop)
 
 	addGroupedCommand(cmd, cipher.NewCipherCmd(), groupSecrets)
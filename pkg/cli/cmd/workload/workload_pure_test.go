@@ -1,6 +1,7 @@
 package workload_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -1010,6 +1011,66 @@ func TestRetryOnTransientError_ZeroMaxAttemptsClampedToOne(t *testing.T) {
 	assert.Equal(t, 1, calls, "maxAttempts=0 should be clamped to 1 so operation is always called")
 }
 
+// ===========================================================================
+// webhookWorker — `watch --serve` request handling
+// ===========================================================================
+
+// errTestReconcile is the sentinel returned by the fake webhook reconcile trigger.
+var errTestReconcile = errors.New("root kustomization not found")
+
+func TestWebhookWorkerReportsTriggerResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		result     string
+		err        error
+		wantOutput string
+	}{
+		{
+			name:       "success prints the triggered resources",
+			result:     "flux: reconciled OCIRepository/flux-system/flux-system and root kustomization",
+			wantOutput: "↻ flux: reconciled OCIRepository/flux-system/flux-system",
+		},
+		{
+			name:       "failure prints a warning",
+			err:        errTestReconcile,
+			wantOutput: "⚠ webhook reconcile: " + errTestReconcile.Error(),
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var output bytes.Buffer
+
+			cmd := &cobra.Command{}
+			cmd.SetErr(&output)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			requests := make(chan struct{}, 1)
+			requests <- struct{}{}
+
+			done := make(chan struct{})
+
+			go func() {
+				workload.ExportWebhookWorker(ctx, cmd, requests, func(context.Context) (string, error) {
+					cancel()
+
+					return testCase.result, testCase.err
+				})
+				close(done)
+			}()
+
+			<-done
+
+			assert.Contains(t, output.String(), "webhook: reconcile requested")
+			assert.Contains(t, output.String(), testCase.wantOutput)
+		})
+	}
+}
+
 // ===========================================================================
 // runWatch — early-exit path validation (pre-config-load)
 // ===========================================================================
@@ -1228,3 +1289,55 @@ func TestRunHooks_StdoutAndStderrForwarded(t *testing.T) {
 	assert.Contains(t, outBuf.String(), "hello-stdout")
 	assert.Contains(t, errBuf.String(), "hello-stderr")
 }
+
+// ===========================================================================
+// applyConfiguredContext — ksail.yaml context as the default --context
+// ===========================================================================
+
+func TestApplyConfiguredContext(t *testing.T) {
+	t.Parallel()
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "get"}
+		cmd.Flags().String("context", "", "")
+
+		return cmd
+	}
+
+	t.Run("defaults --context to the configured context", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := newCmd()
+		require.NoError(t, workload.ExportApplyConfiguredContext(cmd, "kind-dev"))
+
+		got, _ := cmd.Flags().GetString("context")
+		assert.Equal(t, "kind-dev", got)
+	})
+
+	t.Run("keeps an explicit --context", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := newCmd()
+		require.NoError(t, cmd.Flags().Set("context", "staging"))
+		require.NoError(t, workload.ExportApplyConfiguredContext(cmd, "kind-dev"))
+
+		got, _ := cmd.Flags().GetString("context")
+		assert.Equal(t, "staging", got)
+	})
+
+	t.Run("keeps the current context when none is configured", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := newCmd()
+		require.NoError(t, workload.ExportApplyConfiguredContext(cmd, ""))
+
+		got, _ := cmd.Flags().GetString("context")
+		assert.Empty(t, got)
+	})
+
+	t.Run("ignores commands without --context", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, workload.ExportApplyConfiguredContext(&cobra.Command{Use: "push"}, "kind-dev"))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -250,8 +251,10 @@ func TestWriteWorkloadCommandsHaveWritePermission(t *testing.T) {
 		{name: "install", cmd: workload.NewInstallCmd()},
 		{name: pushCommandName, cmd: workload.NewPushCmd()},
 		{name: reconcileCommandName, cmd: workload.NewReconcileCmd()},
+		{name: "resume", cmd: workload.NewResumeCmd()},
 		{name: "rollout", cmd: workload.NewRolloutCmd()},
 		{name: "scale", cmd: workload.NewScaleCmd()},
+		{name: "suspend", cmd: workload.NewSuspendCmd()},
 		{name: "watch", cmd: workload.NewWatchCmd()},
 	}
 
@@ -291,6 +294,7 @@ func TestReadWorkloadCommandsDoNotHaveWritePermission(t *testing.T) {
 		cmd  *cobra.Command
 	}{
 		{name: "describe", cmd: workload.NewDescribeCmd()},
+		{name: "diff", cmd: workload.NewDiffCmd()},
 		{name: "explain", cmd: workload.NewExplainCmd()},
 		{name: "export", cmd: workload.NewExportCmd()},
 		{name: "forward", cmd: workload.NewForwardCmd()},
@@ -678,8 +682,8 @@ func TestNewValidateCmdHasCorrectDefaults(t *testing.T) {
 
 	cmd := workload.NewValidateCmd()
 
-	if cmd.Use != "validate [PATH]" {
-		t.Fatalf("expected Use to be 'validate [PATH]', got %q", cmd.Use)
+	if cmd.Use != "validate [PATH | - | URL]" {
+		t.Fatalf("expected Use to be 'validate [PATH | - | URL]', got %q", cmd.Use)
 	}
 
 	if cmd.Short != "Validate Kubernetes manifests and kustomizations" {
@@ -846,6 +850,52 @@ data: "invalid structure"
 	}
 }
 
+func TestValidateCmdReadsManifestFromStdin(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewValidateCmd()
+	cmd.SetArgs([]string{"-"})
+	cmd.SetIn(strings.NewReader(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-config
+  namespace: default
+data:
+  key: value
+`))
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatalf("expected stdin validation to succeed, got error: %v", err)
+	}
+}
+
+func TestValidateCmdRejectsInvalidManifestFromStdin(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewValidateCmd()
+	cmd.SetArgs([]string{"-"})
+	cmd.SetIn(strings.NewReader(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-config
+data: "invalid structure"
+`))
+
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected stdin validation to fail for invalid manifest")
+	}
+}
+
 func TestValidateCmdWithKustomization(t *testing.T) {
 	t.Parallel()
 
@@ -1805,6 +1855,11 @@ func TestNewWatchCmdHasCorrectDefaults(t *testing.T) {
 	initialApplyFlag := cmd.Flags().Lookup("initial-apply")
 	require.NotNil(t, initialApplyFlag, "expected --initial-apply flag to exist")
 	require.Equal(t, "false", initialApplyFlag.DefValue)
+
+	serveFlag := cmd.Flags().Lookup("serve")
+	require.NotNil(t, serveFlag, "expected --serve flag to exist")
+	require.Empty(t, serveFlag.DefValue)
+	require.Equal(t, "127.0.0.1:9292", serveFlag.NoOptDefVal)
 }
 
 func TestWatchCmdShowsHelp(t *testing.T) {
@@ -3486,3 +3541,137 @@ func TestIsAggregatedReconcileError(t *testing.T) {
 	assert.True(t, workload.ExportIsAggregatedReconcileError(retried))
 	assert.False(t, workload.ExportIsAggregatedReconcileError(errGenericFailed))
 }
+
+func TestDebugCmdRecordRequiresHost(t *testing.T) {
+	t.Parallel()
+
+	cmd := workload.NewDebugCmd()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--record", "node/dev-control-plane"})
+
+	err := cmd.Execute()
+	if !errors.Is(err, workload.ErrRecordWithoutHost) {
+		t.Fatalf("expected ErrRecordWithoutHost, got %v", err)
+	}
+}
+
+func TestDebugCmdNodeModeRejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name:    "combined with host",
+			args:    []string{"--node", "dev-worker", "--host", "dev-worker"},
+			wantErr: workload.ErrNodeWithHost,
+		},
+		{
+			name:    "debug target",
+			args:    []string{"--node", "dev-worker", "mypod"},
+			wantErr: workload.ErrNodeModePositionalArgs,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := workload.NewDebugCmd()
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(testCase.args)
+
+			require.ErrorIs(t, cmd.Execute(), testCase.wantErr)
+		})
+	}
+}
+
+func TestTargetDebugNode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantArgs    []string
+		wantDash    int
+		wantProfile string
+	}{
+		{
+			name:        "no command",
+			args:        []string{"--node", "dev-worker"},
+			wantArgs:    []string{"node/dev-worker"},
+			wantDash:    -1,
+			wantProfile: "sysadmin",
+		},
+		{
+			name:        "command after dash",
+			args:        []string{"--node", "dev-worker", "--", "chroot", "/host"},
+			wantArgs:    []string{"node/dev-worker", "chroot", "/host"},
+			wantDash:    1,
+			wantProfile: "sysadmin",
+		},
+		{
+			name:        "explicit profile",
+			args:        []string{"--node", "dev-worker", "--profile", "netadmin"},
+			wantArgs:    []string{"node/dev-worker"},
+			wantDash:    -1,
+			wantProfile: "netadmin",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("node", "", "")
+			cmd.Flags().String("profile", "general", "")
+			require.NoError(t, cmd.ParseFlags(testCase.args))
+
+			args, err := workload.ExportTargetDebugNode(cmd, "dev-worker", cmd.Flags().Args())
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantArgs, args)
+			assert.Equal(t, testCase.wantDash, cmd.ArgsLenAtDash())
+
+			profile, _ := cmd.Flags().GetString("profile")
+			assert.Equal(t, testCase.wantProfile, profile)
+		})
+	}
+}
+
+func TestApplyDebugImageDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantImage string
+	}{
+		{name: "defaults to toolbox", wantImage: "docker.io/nicolaka/netshoot:v0.16"},
+		{name: "explicit image", args: []string{"--image", "busybox"}, wantImage: "busybox"},
+		{name: "copy keeps kubectl behavior", args: []string{"--copy-to", "copy"}},
+		{name: "set-image keeps kubectl behavior", args: []string{"--set-image", "*=busybox"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("image", "", "")
+			cmd.Flags().String("copy-to", "", "")
+			cmd.Flags().StringToString("set-image", nil, "")
+			require.NoError(t, cmd.ParseFlags(testCase.args))
+
+			require.NoError(t, workload.ExportApplyDebugImageDefault(cmd))
+
+			image, _ := cmd.Flags().GetString("image")
+			assert.Equal(t, testCase.wantImage, image)
+		})
+	}
+}
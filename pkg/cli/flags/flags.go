@@ -16,6 +16,8 @@ const (
 	ConfigFlagName = "config"
 	// ExperimentalFlagName is the global/root persistent flag that opts into experimental features.
 	ExperimentalFlagName = "experimental"
+	// ProfileFlagName is the global/root persistent flag that selects a ksail.yaml profile.
+	ProfileFlagName = "profile"
 )
 
 var (
@@ -115,21 +117,33 @@ func getStringFlag(flagSet *pflag.FlagSet, name string) (string, bool, error) {
 // GetConfigPath returns the config file path from the --config persistent flag.
 // Returns an empty string if the flag is not set or not found.
 func GetConfigPath(cmd *cobra.Command) (string, error) {
+	return lookupStringFlagTiered(cmd, ConfigFlagName)
+}
+
+// GetProfile returns the profile name from the --profile persistent flag.
+// Returns an empty string if the flag is not set or not found.
+func GetProfile(cmd *cobra.Command) (string, error) {
+	return lookupStringFlagTiered(cmd, ProfileFlagName)
+}
+
+// lookupStringFlagTiered resolves a string flag across the command's own, inherited, and
+// persistent flag sets (in that order). A flag no tier defines yields an empty string.
+func lookupStringFlagTiered(cmd *cobra.Command, name string) (string, error) {
 	if cmd == nil {
 		return "", errNilCommand
 	}
 
-	value, found, err := getStringFlag(cmd.Flags(), ConfigFlagName)
+	value, found, err := getStringFlag(cmd.Flags(), name)
 	if found || err != nil {
 		return value, err
 	}
 
-	value, found, err = getStringFlag(cmd.InheritedFlags(), ConfigFlagName)
+	value, found, err = getStringFlag(cmd.InheritedFlags(), name)
 	if found || err != nil {
 		return value, err
 	}
 
-	value, _, err = getStringFlag(cmd.PersistentFlags(), ConfigFlagName)
+	value, _, err = getStringFlag(cmd.PersistentFlags(), name)
 
 	return value, err
 }
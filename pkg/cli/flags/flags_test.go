@@ -245,3 +245,26 @@ func TestGetConfigPath_FlagNotFound(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, path)
 }
+
+func TestGetProfile_InheritedFromParent(t *testing.T) {
+	t.Parallel()
+
+	parent := &cobra.Command{}
+	parent.PersistentFlags().String(flags.ProfileFlagName, "", "")
+	require.NoError(t, parent.PersistentFlags().Set(flags.ProfileFlagName, "ci"))
+
+	child := &cobra.Command{Use: "child"}
+	parent.AddCommand(child)
+
+	profile, err := flags.GetProfile(child)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", profile)
+}
+
+func TestGetProfile_FlagNotFound(t *testing.T) {
+	t.Parallel()
+
+	profile, err := flags.GetProfile(&cobra.Command{})
+	require.NoError(t, err)
+	assert.Empty(t, profile)
+}
@@ -84,13 +84,41 @@ func NewSilentConfigManager(cmd *cobra.Command) *ksailconfigmanager.ConfigManage
 	return ksailconfigmanager.NewConfigManager(io.Discard, configFile)
 }
 
+// GetConnectionSilently is GetKubeconfigPathSilently that also returns the
+// configured context (spec.cluster.connection.context). The context is "" when
+// none is configured or the config cannot be loaded, so callers fall back to
+// the kubeconfig's current context.
+func GetConnectionSilently(cmd *cobra.Command) (string, string) {
+	clusterCfg, err := loadClusterConfig(NewSilentConfigManager(cmd))
+	if err != nil {
+		return k8s.DefaultKubeconfigPath(), ""
+	}
+
+	kubeconfigPath, err := GetKubeconfigPathFromConfig(clusterCfg)
+	if err != nil {
+		return k8s.DefaultKubeconfigPath(), ""
+	}
+
+	return kubeconfigPath, clusterCfg.Spec.Cluster.Connection.Context
+}
+
 // getKubeconfigPath loads the KSail configuration using the provided manager
 // and extracts the kubeconfig path from the loaded cluster configuration.
 //
 // This is an internal helper function used by GetKubeconfigPathSilently.
-// It creates a minimal timer for config loading and delegates to GetKubeconfigPathFromConfig
-// for path extraction and expansion.
+// It delegates to GetKubeconfigPathFromConfig for path extraction and expansion.
 func getKubeconfigPath(cfgManager *ksailconfigmanager.ConfigManager) (string, error) {
+	clusterCfg, err := loadClusterConfig(cfgManager)
+	if err != nil {
+		return "", err
+	}
+
+	return GetKubeconfigPathFromConfig(clusterCfg)
+}
+
+// loadClusterConfig loads the KSail configuration silently with a minimal
+// timer, skipping the distribution config.
+func loadClusterConfig(cfgManager *ksailconfigmanager.ConfigManager) (*v1alpha1.Cluster, error) {
 	// Create a minimal timer for config loading
 	tmr := timer.New()
 	tmr.Start()
@@ -101,8 +129,8 @@ func getKubeconfigPath(cfgManager *ksailconfigmanager.ConfigManager) (string, er
 		SkipDistributionConfig: true,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to load cluster configuration: %w", err)
+		return nil, fmt.Errorf("failed to load cluster configuration: %w", err)
 	}
 
-	return GetKubeconfigPathFromConfig(clusterCfg)
+	return clusterCfg, nil
 }
@@ -0,0 +1,65 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ClientModeFlagName is the flag that selects how long-running commands report progress.
+	ClientModeFlagName = "client-mode"
+	// ClientModeJSONRPC reports progress as LSP-style JSON-RPC notifications on stdout.
+	ClientModeJSONRPC = "jsonrpc"
+)
+
+// ErrUnsupportedClientMode is returned when --client-mode names an unknown mode.
+var ErrUnsupportedClientMode = errors.New("unsupported client mode")
+
+// AddClientModeFlag registers the --client-mode flag on a long-running command. The default
+// (empty) mode keeps the human-readable output.
+func AddClientModeFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		ClientModeFlagName,
+		"",
+		"Report progress for an IDE client instead of human output. "+
+			"jsonrpc emits LSP-style $/progress and window/logMessage notifications on stdout",
+	)
+}
+
+// RunWithClientMode runs the command body with its output adapted to --client-mode. In jsonrpc
+// mode stdout is replaced by a notify.JSONRPCWriter, whose open progress is ended (as failed when
+// the body returns an error) once the body returns. Commands without the flag run unchanged.
+func RunWithClientMode(cmd *cobra.Command, run func() error) error {
+	flag := cmd.Flags().Lookup(ClientModeFlagName)
+	if flag == nil || flag.Value.String() == "" {
+		return run()
+	}
+
+	if flag.Value.String() != ClientModeJSONRPC {
+		return fmt.Errorf(
+			"%w %q: must be %q",
+			ErrUnsupportedClientMode,
+			flag.Value.String(),
+			ClientModeJSONRPC,
+		)
+	}
+
+	writer := notify.NewJSONRPCWriter(cmd.OutOrStdout())
+	cmd.SetOut(writer)
+
+	err := run()
+
+	finishErr := writer.Finish(err)
+	if err != nil {
+		return err
+	}
+
+	if finishErr != nil {
+		return fmt.Errorf("finish client progress: %w", finishErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package lifecycle_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errClientModeRun = errors.New("run failed")
+
+func newClientModeCmd(t *testing.T, mode string) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "test"}
+	lifecycle.AddClientModeFlag(cmd)
+
+	if mode != "" {
+		require.NoError(t, cmd.Flags().Set(lifecycle.ClientModeFlagName, mode))
+	}
+
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+
+	return cmd, &out
+}
+
+func TestRunWithClientMode_DefaultKeepsHumanOutput(t *testing.T) {
+	t.Parallel()
+
+	cmd, out := newClientModeCmd(t, "")
+
+	err := lifecycle.RunWithClientMode(cmd, func() error {
+		notify.Titlef(cmd.OutOrStdout(), "🚀", "Create cluster...")
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "🚀 Create cluster...\n", out.String())
+}
+
+func TestRunWithClientMode_JSONRPCEmitsNotifications(t *testing.T) {
+	t.Parallel()
+
+	cmd, out := newClientModeCmd(t, lifecycle.ClientModeJSONRPC)
+
+	err := lifecycle.RunWithClientMode(cmd, func() error {
+		notify.Titlef(cmd.OutOrStdout(), "🚀", "Create cluster...")
+
+		return errClientModeRun
+	})
+
+	require.ErrorIs(t, err, errClientModeRun)
+	assert.Contains(t, out.String(), "Content-Length: ")
+	assert.Contains(t, out.String(), `"method":"$/progress"`)
+	assert.Contains(t, out.String(), `"kind":"begin","title":"Create cluster..."`)
+	assert.Contains(t, out.String(), `{"type":1,"message":"run failed"}`)
+	assert.Contains(t, out.String(), `"kind":"end","message":"failed"`)
+	assert.NotContains(t, out.String(), "🚀")
+}
+
+func TestRunWithClientMode_RejectsUnknownMode(t *testing.T) {
+	t.Parallel()
+
+	cmd, _ := newClientModeCmd(t, "xml")
+
+	called := false
+
+	err := lifecycle.RunWithClientMode(cmd, func() error {
+		called = true
+
+		return nil
+	})
+
+	require.ErrorIs(t, err, lifecycle.ErrUnsupportedClientMode)
+	assert.False(t, called)
+}
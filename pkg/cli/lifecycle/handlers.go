@@ -75,7 +75,8 @@ func NewStandardRunE(
 // the factory has the proper distribution-specific configuration.
 //
 // The output is wrapped with StageSeparatingWriter to automatically add
-// blank lines between CLI stages for better readability.
+// blank lines between CLI stages for better readability, and adapted to
+// --client-mode when the command registers it (see RunWithClientMode).
 //
 // This function is used internally by NewStandardRunE but can also be used
 // directly for custom lifecycle handlers that require custom logic beyond the
@@ -85,29 +86,31 @@ func WrapHandler(
 	handler func(*cobra.Command, *ksailconfigmanager.ConfigManager, Deps) error,
 ) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
-		// Wrap output with StageSeparatingWriter for automatic stage separation
-		stageWriter := notify.NewStageSeparatingWriter(cmd.OutOrStdout())
-		cmd.SetOut(stageWriter)
+		return RunWithClientMode(cmd, func() error {
+			// Wrap output with StageSeparatingWriter for automatic stage separation
+			stageWriter := notify.NewStageSeparatingWriter(cmd.OutOrStdout())
+			cmd.SetOut(stageWriter)
 
-		// Start timer and load config first to get distribution config
-		tmr := timer.New()
-		tmr.Start()
+			// Start timer and load config first to get distribution config
+			tmr := timer.New()
+			tmr.Start()
 
-		outputTimer := flags.MaybeTimer(cmd, tmr)
+			outputTimer := flags.MaybeTimer(cmd, tmr)
 
-		_, err := cfgManager.Load(configmanager.LoadOptions{Timer: outputTimer})
-		if err != nil {
-			return fmt.Errorf("failed to load cluster configuration: %w", err)
-		}
+			_, err := cfgManager.Load(configmanager.LoadOptions{Timer: outputTimer})
+			if err != nil {
+				return fmt.Errorf("failed to load cluster configuration: %w", err)
+			}
 
-		// Create factory with the cached distribution config
-		factory := clusterprovisioner.DefaultFactory{
-			DistributionConfig: cfgManager.DistributionConfig,
-		}
+			// Create factory with the cached distribution config
+			factory := clusterprovisioner.DefaultFactory{
+				DistributionConfig: cfgManager.DistributionConfig,
+			}
 
-		deps := Deps{Timer: tmr, Factory: factory}
+			deps := Deps{Timer: tmr, Factory: factory}
 
-		return handler(cmd, cfgManager, deps)
+			return handler(cmd, cfgManager, deps)
+		})
 	}
 }
 
@@ -23,6 +23,8 @@ import (
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clustererr"
 	talosprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/talos"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenancy"
 	"github.com/spf13/cobra"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -66,6 +68,11 @@ type SimpleLifecycleConfig struct {
 	// lets a caller refuse the action for a resolved cluster — e.g. an unmanaged, ksail-unprovisioned
 	// cluster — with a clear error. A nil Guard is a no-op.
 	Guard func(ctx context.Context, resolved *ResolvedClusterInfo) error
+	// BeforeAction and AfterAction, when non-nil, run immediately before Action and after it
+	// succeeds. They let a caller observe the mutation while the cluster API is reachable — e.g.
+	// record a stop before the API server goes down, or a start once it is back up.
+	BeforeAction func(cmd *cobra.Command, resolved *ResolvedClusterInfo)
+	AfterAction  func(cmd *cobra.Command, resolved *ResolvedClusterInfo)
 }
 
 // NewSimpleLifecycleCmd creates a simple lifecycle command (start/stop) with --name and --provider flags.
@@ -90,7 +97,9 @@ func NewSimpleLifecycleCmd(config SimpleLifecycleConfig) *cobra.Command {
 		Long:         config.Long,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runSimpleLifecycleAction(cmd, nameFlag, providerFlag, config)
+			return RunWithClientMode(cmd, func() error {
+				return runSimpleLifecycleAction(cmd, nameFlag, providerFlag, config)
+			})
 		},
 	}
 
@@ -266,6 +275,9 @@ func resolveClusterInfo(
 		return nil, ErrClusterNameRequired
 	}
 
+	// A pool spare claimed for the requested name keeps its pool name; act on it.
+	resolved.ClusterName = state.ResolveClusterAlias(resolved.ClusterName)
+
 	if resolved.Provider == "" {
 		resolved.Provider = v1alpha1.ProviderDocker
 	}
@@ -300,7 +312,11 @@ func loadConfig(
 
 	cfgManager := ksailconfigmanager.NewConfigManager(nil, configFile)
 
-	cfg, err := cfgManager.Load(configmanager.LoadOptions{Silent: true, SkipValidation: true})
+	cfg, err := cfgManager.Load(configmanager.LoadOptions{
+		Context:        commandContext(cmd),
+		Silent:         true,
+		SkipValidation: true,
+	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("load cluster config: %w", err)
 	}
@@ -347,6 +363,12 @@ func resolveClusterIdentityFromConfig(
 	cfg *v1alpha1.Cluster,
 	distCfg *clusterprovisioner.DistributionConfig,
 ) {
+	// A --name value is the tenant's own name for the cluster; scope it like the
+	// config-derived names the loader already scoped.
+	if scoped, err := tenancy.ScopeClusterName(cfg, resolved.ClusterName); err == nil {
+		resolved.ClusterName = scoped
+	}
+
 	resolved.ConfigClusterName = resolveConfigClusterName(cfg, distCfg)
 	resolved.EKSConfigSource = hasLoadedEKSConfigSource(cfg, distCfg, resolved.ConfigClusterName)
 	resolved.ClusterName = resolveClusterNameFromConfig(
@@ -570,11 +592,19 @@ func provisionAndAct(
 		return err
 	}
 
+	if config.BeforeAction != nil {
+		config.BeforeAction(cmd, resolved)
+	}
+
 	err = config.Action(cmd.Context(), provisioner, resolved.ClusterName)
 	if err != nil {
 		return fmt.Errorf("failed to %s cluster: %w", config.Activity, err)
 	}
 
+	if config.AfterAction != nil {
+		config.AfterAction(cmd, resolved)
+	}
+
 	notify.WriteMessage(notify.Message{
 		Type:    notify.SuccessType,
 		Content: config.Success,
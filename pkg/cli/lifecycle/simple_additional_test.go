@@ -10,6 +10,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/lifecycle"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -68,6 +69,29 @@ func TestResolveClusterInfo(t *testing.T) {
 	})
 }
 
+// TestResolveClusterInfoFollowsPoolClaimAlias verifies a name whose pool spare was
+// claimed by 'ksail cluster create --from-pool' resolves to the spare.
+func TestResolveClusterInfoFollowsPoolClaimAlias(t *testing.T) {
+	t.Parallel()
+
+	spareName := "aliased-cluster-pool-1"
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState("aliased-cluster")
+		_ = state.DeleteClusterState(spareName)
+	})
+
+	require.NoError(t, state.SaveClusterSpec(spareName, &v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionVanilla,
+	}))
+	require.NoError(t, state.SaveClusterAlias("aliased-cluster", spareName))
+
+	resolved, err := lifecycle.ResolveClusterInfo(nil, "aliased-cluster", v1alpha1.ProviderDocker, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, spareName, resolved.ClusterName)
+}
+
 // TestResolveClusterInfoToleratesUnreadableConfigForReadOnlyCallers pins the split between
 // read-only and mutating resolution. A broken ksail.yaml must not lock the user out of read-only
 // commands (cluster info, diagnose, connect) when they have supplied an explicit --name, but it must
@@ -0,0 +1,5 @@
+// Package manifestinput resolves manifest sources given on the command line —
+// local paths, "-" for stdin, and http(s) URLs — into local files, so workload
+// commands (apply, delete, diff, validate) accept the same inputs kubectl does
+// and compose with other tools in shell pipelines.
+package manifestinput
@@ -0,0 +1,177 @@
+package manifestinput
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Stdin is the conventional source name (as in `kubectl apply -f -`) that
+// selects the standard input stream instead of a file.
+const Stdin = "-"
+
+const (
+	// tempFilePattern names the temporary files stdin and remote manifests are
+	// materialized into.
+	tempFilePattern = "ksail-manifest-*.yaml"
+	// maxManifestBytes bounds how much is read from stdin or a URL so a runaway
+	// pipe or a wrong URL cannot exhaust memory or disk.
+	maxManifestBytes = 64 << 20
+	// fetchTimeout bounds a single remote manifest download.
+	fetchTimeout = 60 * time.Second
+)
+
+var (
+	// ErrStdinReused is returned when stdin is referenced more than once in a
+	// single invocation; the stream can only be consumed once.
+	ErrStdinReused = errors.New("stdin (-) can only be used once")
+	// ErrStdinUnavailable is returned when "-" is requested but no input stream
+	// was supplied.
+	ErrStdinUnavailable = errors.New("stdin (-) requested but no input stream is available")
+	// ErrManifestTooLarge is returned when stdin or a remote manifest exceeds
+	// maxManifestBytes.
+	ErrManifestTooLarge = errors.New("manifest input exceeds size limit")
+	// ErrFetchFailed is returned when a remote manifest responds with a non-2xx
+	// status code.
+	ErrFetchFailed = errors.New("fetch manifest")
+)
+
+// IsStdin reports whether source selects standard input.
+func IsStdin(source string) bool {
+	return source == Stdin
+}
+
+// IsURL reports whether source is an http(s) URL.
+func IsURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// NeedsResolution reports whether source must be materialized into a local file
+// before file-based tooling can consume it.
+func NeedsResolution(source string) bool {
+	return IsStdin(source) || IsURL(source)
+}
+
+// Resolver materializes manifest sources (local paths, "-" for stdin, and
+// http(s) URLs) into local file paths. A single Resolver should be used per
+// command invocation: it enforces that stdin is consumed at most once and
+// tracks every temporary file it creates so Cleanup can remove them.
+type Resolver struct {
+	stdin      io.Reader
+	httpClient *http.Client
+	stdinUsed  bool
+	tempFiles  []string
+}
+
+// NewResolver returns a Resolver that reads "-" from stdin.
+func NewResolver(stdin io.Reader) *Resolver {
+	return &Resolver{
+		stdin:      stdin,
+		httpClient: &http.Client{Timeout: fetchTimeout},
+	}
+}
+
+// Resolve returns a local path for source. Local paths are returned unchanged;
+// stdin and URLs are written to a temporary file that is removed by Cleanup.
+func (r *Resolver) Resolve(ctx context.Context, source string) (string, error) {
+	switch {
+	case IsStdin(source):
+		return r.resolveStdin()
+	case IsURL(source):
+		return r.resolveURL(ctx, source)
+	default:
+		return source, nil
+	}
+}
+
+// ResolveAll resolves every source in order, preserving positions so the
+// result can replace a repeated -f flag value in place.
+func (r *Resolver) ResolveAll(ctx context.Context, sources []string) ([]string, error) {
+	resolved := make([]string, 0, len(sources))
+
+	for _, source := range sources {
+		path, err := r.Resolve(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved = append(resolved, path)
+	}
+
+	return resolved, nil
+}
+
+// Cleanup removes every temporary file created by this Resolver. It is safe to
+// call more than once and is intended to be deferred.
+func (r *Resolver) Cleanup() {
+	for _, path := range r.tempFiles {
+		_ = os.Remove(path)
+	}
+
+	r.tempFiles = nil
+}
+
+func (r *Resolver) resolveStdin() (string, error) {
+	if r.stdinUsed {
+		return "", ErrStdinReused
+	}
+
+	if r.stdin == nil {
+		return "", ErrStdinUnavailable
+	}
+
+	r.stdinUsed = true
+
+	return r.writeTemp(r.stdin, "stdin")
+}
+
+func (r *Resolver) resolveURL(ctx context.Context, rawURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("build request for %s: %w", rawURL, err)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch %s: %w", rawURL, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", fmt.Errorf("%w %s: unexpected status %s", ErrFetchFailed, rawURL, resp.Status)
+	}
+
+	return r.writeTemp(resp.Body, rawURL)
+}
+
+// writeTemp copies at most maxManifestBytes from src into a new temporary file
+// and records it for Cleanup. label identifies the source in error messages.
+func (r *Resolver) writeTemp(src io.Reader, label string) (string, error) {
+	file, err := os.CreateTemp("", tempFilePattern)
+	if err != nil {
+		return "", fmt.Errorf("create temp file for %s: %w", label, err)
+	}
+
+	r.tempFiles = append(r.tempFiles, file.Name())
+
+	written, copyErr := io.Copy(file, io.LimitReader(src, maxManifestBytes+1))
+
+	closeErr := file.Close()
+
+	switch {
+	case copyErr != nil:
+		return "", fmt.Errorf("read %s: %w", label, copyErr)
+	case closeErr != nil:
+		return "", fmt.Errorf("write temp file for %s: %w", label, closeErr)
+	case written > maxManifestBytes:
+		return "", fmt.Errorf("%w: %s is larger than %d bytes", ErrManifestTooLarge, label, maxManifestBytes)
+	}
+
+	return file.Name(), nil
+}
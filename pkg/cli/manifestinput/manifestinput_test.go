@@ -0,0 +1,117 @@
+package manifestinput_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/manifestinput"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: demo\n"
+
+func TestNeedsResolution(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{source: "-", want: true},
+		{source: "https://example.com/app.yaml", want: true},
+		{source: "http://example.com/app.yaml", want: true},
+		{source: "k8s/app.yaml", want: false},
+		{source: "./-", want: false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.source, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, manifestinput.NeedsResolution(testCase.source))
+		})
+	}
+}
+
+func TestResolveLocalPathIsUnchanged(t *testing.T) {
+	t.Parallel()
+
+	resolver := manifestinput.NewResolver(nil)
+	defer resolver.Cleanup()
+
+	path, err := resolver.Resolve(t.Context(), "k8s/app.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "k8s/app.yaml", path)
+}
+
+func TestResolveStdin(t *testing.T) {
+	t.Parallel()
+
+	resolver := manifestinput.NewResolver(strings.NewReader(testManifest))
+
+	path, err := resolver.Resolve(t.Context(), manifestinput.Stdin)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path) //nolint:gosec // path is a temp file created by the resolver
+	require.NoError(t, err)
+	assert.Equal(t, testManifest, string(content))
+
+	resolver.Cleanup()
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist, "cleanup should remove the temp file")
+}
+
+func TestResolveStdinTwiceFails(t *testing.T) {
+	t.Parallel()
+
+	resolver := manifestinput.NewResolver(strings.NewReader(testManifest))
+	defer resolver.Cleanup()
+
+	_, err := resolver.ResolveAll(t.Context(), []string{"-", "-"})
+	require.ErrorIs(t, err, manifestinput.ErrStdinReused)
+}
+
+func TestResolveStdinWithoutStream(t *testing.T) {
+	t.Parallel()
+
+	resolver := manifestinput.NewResolver(nil)
+	defer resolver.Cleanup()
+
+	_, err := resolver.Resolve(t.Context(), manifestinput.Stdin)
+	require.ErrorIs(t, err, manifestinput.ErrStdinUnavailable)
+}
+
+func TestResolveURL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app.yaml" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(testManifest))
+	}))
+	defer server.Close()
+
+	resolver := manifestinput.NewResolver(nil)
+	defer resolver.Cleanup()
+
+	paths, err := resolver.ResolveAll(t.Context(), []string{"local.yaml", server.URL + "/app.yaml"})
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+	assert.Equal(t, "local.yaml", paths[0])
+
+	content, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	assert.Equal(t, testManifest, string(content))
+
+	_, err = resolver.Resolve(t.Context(), server.URL+"/missing.yaml")
+	require.ErrorIs(t, err, manifestinput.ErrFetchFailed)
+}
@@ -0,0 +1,5 @@
+// Package notifyhook registers the notification sinks configured under
+// spec.notify in ksail.yaml before a CLI command runs, so every message of the
+// operation is also recorded in a log file, syslog, or a webhook, and closes
+// them once the command finishes.
+package notifyhook
@@ -0,0 +1,118 @@
+package notifyhook
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/kubeconfig"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/timer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// syslogTag tags the messages KSail sends to syslog.
+	syslogTag = "ksail"
+	// logFileTimeLayout prefixes log file names so they sort chronologically.
+	logFileTimeLayout = "20060102-150405"
+)
+
+// MaybeInstallSinks registers the sinks configured under spec.notify for the
+// operation cmd runs. It is a no-op when no KSail config is found or no sink is
+// configured. A sink that cannot be created is reported as a warning on cmd's
+// stderr and skipped, so the command still runs.
+//
+// This function is designed to be called from Cobra PersistentPreRunE hooks;
+// [Finish] closes the sinks once the command returns.
+func MaybeInstallSinks(cmd *cobra.Command) {
+	spec, ok := loadNotifySpec(cmd)
+	if !ok || !spec.Enabled() {
+		return
+	}
+
+	InstallSinks(cmd, spec, time.Now())
+}
+
+// InstallSinks registers the sinks described by spec for the operation cmd
+// runs, naming its log file after now.
+func InstallSinks(cmd *cobra.Command, spec v1alpha1.NotifySpec, now time.Time) {
+	warn := cmd.ErrOrStderr()
+	operation := cmd.CommandPath()
+
+	if spec.LogDir != "" {
+		logDir, err := fsutil.ExpandHomePath(spec.LogDir)
+		if err == nil {
+			var sink *notify.FileSink
+
+			sink, err = notify.NewFileSink(filepath.Join(logDir, LogFileName(operation, now)))
+			if err == nil {
+				notify.AddSink(sink)
+			}
+		}
+
+		if err != nil {
+			notify.Warningf(warn, "notify log file disabled: %v", err)
+		}
+	}
+
+	if spec.Syslog {
+		sink, err := notify.NewSyslogSink(syslogTag)
+		if err != nil {
+			notify.Warningf(warn, "notify syslog disabled: %v", err)
+		} else {
+			notify.AddSink(sink)
+		}
+	}
+
+	if spec.Webhook != "" {
+		notify.AddSink(notify.NewWebhookSink(spec.Webhook, operation))
+	}
+}
+
+// Finish records the command's error, if any, in the sinks and closes them.
+// Failures to close a sink are reported as a warning on errOut.
+func Finish(errOut io.Writer, cmdErr error) {
+	if cmdErr != nil {
+		notify.RecordToSinks(notify.ErrorType, cmdErr.Error())
+	}
+
+	err := notify.CloseSinks()
+	if err != nil {
+		notify.Warningf(errOut, "notify sinks: %v", err)
+	}
+}
+
+// LogFileName returns the log file name of an operation started at now, e.g.
+// "20261018-120000-cluster-create.log" for "ksail cluster create".
+func LogFileName(operation string, now time.Time) string {
+	parts := strings.Fields(operation)
+	if len(parts) > 1 {
+		parts = parts[1:]
+	}
+
+	return now.UTC().Format(logFileTimeLayout) + "-" + strings.Join(parts, "-") + ".log"
+}
+
+// loadNotifySpec silently loads spec.notify from the KSail config, honoring
+// cmd's --config flag.
+func loadNotifySpec(cmd *cobra.Command) (v1alpha1.NotifySpec, bool) {
+	tmr := timer.New()
+	tmr.Start()
+
+	clusterCfg, err := kubeconfig.NewSilentConfigManager(cmd).Load(configmanager.LoadOptions{
+		Timer:                  tmr,
+		Silent:                 true,
+		SkipDistributionConfig: true,
+		SkipValidation:         true,
+	})
+	if err != nil || clusterCfg == nil {
+		return v1alpha1.NotifySpec{}, false
+	}
+
+	return clusterCfg.Spec.Notify, true
+}
@@ -0,0 +1,70 @@
+package notifyhook_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/cli/notifyhook"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCreateFailed = errors.New("create failed")
+
+func TestLogFileName(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 30, 5, 0, time.UTC)
+
+	assert.Equal(t, "20261018-123005-cluster-create.log", notifyhook.LogFileName("ksail cluster create", now))
+	assert.Equal(t, "20261018-123005-ksail.log", notifyhook.LogFileName("ksail", now))
+}
+
+//nolint:paralleltest // mutates the process-wide notify sink registry
+func TestInstallSinks_LogDir(t *testing.T) {
+	logDir := filepath.Join(t.TempDir(), "logs")
+	now := time.Date(2026, 10, 18, 12, 30, 5, 0, time.UTC)
+
+	root := &cobra.Command{Use: "ksail"}
+	create := &cobra.Command{Use: "create"}
+	root.AddCommand(create)
+
+	var stderr bytes.Buffer
+
+	create.SetErr(&stderr)
+
+	notifyhook.InstallSinks(create, v1alpha1.NotifySpec{LogDir: logDir}, now)
+	notify.Activityf(io.Discard, "creating %s", "kind")
+	notifyhook.Finish(&stderr, errCreateFailed)
+
+	data, err := os.ReadFile(filepath.Join(logDir, "20261018-123005-create.log")) //nolint:gosec // test path
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "activity creating kind\n")
+	assert.Contains(t, string(data), "error    create failed\n")
+	assert.Empty(t, stderr.String())
+}
+
+//nolint:paralleltest // mutates the process-wide notify sink registry
+func TestInstallSinks_UnusableLogDirWarns(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(blocker, nil, 0o600))
+
+	cmd := &cobra.Command{Use: "ksail"}
+
+	var stderr bytes.Buffer
+
+	cmd.SetErr(&stderr)
+
+	notifyhook.InstallSinks(cmd, v1alpha1.NotifySpec{LogDir: blocker}, time.Now())
+	notifyhook.Finish(&stderr, nil)
+
+	assert.Contains(t, stderr.String(), "notify log file disabled")
+}
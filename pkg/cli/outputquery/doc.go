@@ -0,0 +1,5 @@
+// Package outputquery evaluates a --query expression (JMESPath or CEL) over the
+// structured output of read-only commands such as `cluster list`, `cluster
+// info`, `cluster status`, and `workload get`, so scripts can extract fields
+// without piping through jq or depending on the human-readable layout.
+package outputquery
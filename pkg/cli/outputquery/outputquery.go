@@ -0,0 +1,250 @@
+package outputquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/jmespath/go-jmespath"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// FlagQuery is the flag holding the query expression.
+	FlagQuery = "query"
+	// FlagQueryLanguage is the flag selecting the language of FlagQuery.
+	FlagQueryLanguage = "query-language"
+)
+
+// objectVarName is the CEL variable the document is bound to, matching the
+// CEL rules of `workload validate` and Kubernetes ValidatingAdmissionPolicy.
+const objectVarName = "object"
+
+var (
+	// ErrUnsupportedLanguage is returned when --query-language is neither jmespath nor cel.
+	ErrUnsupportedLanguage = errors.New("unsupported query language")
+	// ErrInvalidQuery is returned when the --query expression does not compile.
+	ErrInvalidQuery = errors.New("invalid query")
+)
+
+// Language selects how a query expression is interpreted.
+type Language string
+
+const (
+	// LanguageJMESPath evaluates the expression as JMESPath (https://jmespath.org).
+	LanguageJMESPath Language = "jmespath"
+	// LanguageCEL evaluates the expression as CEL with the document bound to `object`.
+	LanguageCEL Language = "cel"
+)
+
+// ValidLanguages returns the supported query languages.
+func ValidLanguages() []Language {
+	return []Language{LanguageJMESPath, LanguageCEL}
+}
+
+// Set for Language (pflag.Value interface). Matching is case-insensitive.
+func (l *Language) Set(value string) error {
+	for _, language := range ValidLanguages() {
+		if strings.EqualFold(value, string(language)) {
+			*l = language
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q (expected %q or %q)",
+		ErrUnsupportedLanguage, value, LanguageJMESPath, LanguageCEL)
+}
+
+// String returns the string representation of the Language.
+func (l *Language) String() string {
+	return string(*l)
+}
+
+// Type returns the type of the Language.
+func (l *Language) Type() string {
+	return "Language"
+}
+
+// Default returns the default value for Language (jmespath).
+func (l *Language) Default() any {
+	return LanguageJMESPath
+}
+
+// ValidValues returns all valid Language values as strings.
+func (l *Language) ValidValues() []string {
+	values := make([]string, 0, len(ValidLanguages()))
+
+	for _, language := range ValidLanguages() {
+		values = append(values, string(language))
+	}
+
+	return values
+}
+
+// Query is a compiled expression ready to be evaluated against a document.
+type Query struct {
+	search func(document any) (any, error)
+}
+
+// BindFlags registers --query and --query-language on cmd.
+func BindFlags(cmd *cobra.Command) {
+	language := LanguageJMESPath
+
+	cmd.Flags().String(FlagQuery, "",
+		"Expression evaluated over the JSON output, printing only its result "+
+			"(strings unquoted). Implies JSON output.")
+	cmd.Flags().Var(&language, FlagQueryLanguage,
+		"Language of --query: jmespath or cel (CEL binds the document to 'object')")
+}
+
+// FromFlags compiles the --query expression registered by BindFlags. It
+// returns nil when --query is unset or the flags are not registered on cmd.
+func FromFlags(cmd *cobra.Command) (*Query, error) {
+	flag := cmd.Flags().Lookup(FlagQuery)
+	if flag == nil || strings.TrimSpace(flag.Value.String()) == "" {
+		return nil, nil //nolint:nilnil // nil query means render the command's own output
+	}
+
+	language := LanguageJMESPath
+
+	languageFlag := cmd.Flags().Lookup(FlagQueryLanguage)
+	if languageFlag != nil {
+		language = Language(languageFlag.Value.String())
+	}
+
+	return New(language, flag.Value.String())
+}
+
+// New compiles expression in the given language.
+func New(language Language, expression string) (*Query, error) {
+	switch language {
+	case LanguageJMESPath, "":
+		compiled, err := jmespath.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+		}
+
+		return &Query{search: compiled.Search}, nil
+	case LanguageCEL:
+		return newCELQuery(expression)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedLanguage, language)
+	}
+}
+
+// newCELQuery compiles expression against an environment that binds the
+// document as `object` (dynamic type) with the strings extension library.
+func newCELQuery(expression string) (*Query, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(objectVarName, cel.DynType),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, issues.Err())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidQuery, err)
+	}
+
+	search := func(document any) (any, error) {
+		out, _, err := program.Eval(map[string]any{objectVarName: document})
+		if err != nil {
+			return nil, fmt.Errorf("evaluate CEL query: %w", err)
+		}
+
+		// Converting through structpb yields plain JSON values (maps, slices,
+		// strings, float64, bool, nil) however the result was built.
+		native, err := out.ConvertToNative(reflect.TypeFor[*structpb.Value]())
+		if err != nil {
+			return nil, fmt.Errorf("convert CEL result to JSON: %w", err)
+		}
+
+		value, ok := native.(*structpb.Value)
+		if !ok {
+			return nil, fmt.Errorf("convert CEL result to JSON: unexpected %T", native)
+		}
+
+		return value.AsInterface(), nil
+	}
+
+	return &Query{search: search}, nil
+}
+
+// Evaluate runs the query against a document decoded from JSON.
+func (q *Query) Evaluate(document any) (any, error) {
+	result, err := q.search(document)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate query: %w", err)
+	}
+
+	return result, nil
+}
+
+// Render encodes value as JSON, evaluates the query against it, and writes the
+// result, so the query sees exactly the document --output json would print.
+func (q *Query) Render(writer io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal query document: %w", err)
+	}
+
+	return q.RenderJSON(writer, data)
+}
+
+// RenderJSON evaluates the query against a JSON document and writes the result.
+func (q *Query) RenderJSON(writer io.Writer, data []byte) error {
+	var document any
+
+	err := json.Unmarshal(data, &document)
+	if err != nil {
+		return fmt.Errorf("decode query document: %w", err)
+	}
+
+	result, err := q.Evaluate(document)
+	if err != nil {
+		return err
+	}
+
+	return Write(writer, result)
+}
+
+// Write prints a query result: strings on their own line without quotes so they
+// can be used directly in shell scripts, everything else as indented JSON.
+func Write(writer io.Writer, result any) error {
+	text, ok := result.(string)
+	if ok {
+		_, err := fmt.Fprintln(writer, text)
+		if err != nil {
+			return fmt.Errorf("write query result: %w", err)
+		}
+
+		return nil
+	}
+
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	// Keep '<', '>', '&' literal instead of \u-escaping them; this is CLI
+	// output, not HTML.
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(result)
+	if err != nil {
+		return fmt.Errorf("write query result: %w", err)
+	}
+
+	return nil
+}
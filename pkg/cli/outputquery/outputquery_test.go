@@ -0,0 +1,163 @@
+package outputquery_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/outputquery"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCluster struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Nodes  int    `json:"nodes"`
+}
+
+//nolint:gochecknoglobals // shared read-only fixture
+var testClusters = []testCluster{
+	{Name: "dev", Status: "Running", Nodes: 3},
+	{Name: "ci", Status: "Stopped", Nodes: 1},
+}
+
+func TestQueryRender(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		language   outputquery.Language
+		expression string
+		want       string
+	}{
+		{
+			name:       "jmespath projection",
+			language:   outputquery.LanguageJMESPath,
+			expression: "[?status=='Running'].name",
+			want:       "[\n  \"dev\"\n]\n",
+		},
+		{
+			name:       "jmespath string result is unquoted",
+			language:   outputquery.LanguageJMESPath,
+			expression: "[0].name",
+			want:       "dev\n",
+		},
+		{
+			name:       "jmespath no match prints null",
+			language:   outputquery.LanguageJMESPath,
+			expression: "[0].missing",
+			want:       "null\n",
+		},
+		{
+			name:       "empty language defaults to jmespath",
+			expression: "length(@)",
+			want:       "2\n",
+		},
+		{
+			name:       "cel filter and map",
+			language:   outputquery.LanguageCEL,
+			expression: "object.filter(c, c.nodes > 1).map(c, c.name)",
+			want:       "[\n  \"dev\"\n]\n",
+		},
+		{
+			name:       "cel string result is unquoted",
+			language:   outputquery.LanguageCEL,
+			expression: "object[1].name.upperAscii()",
+			want:       "CI\n",
+		},
+		{
+			name:       "cel boolean",
+			language:   outputquery.LanguageCEL,
+			expression: "object.all(c, c.nodes > 0)",
+			want:       "true\n",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := outputquery.New(testCase.language, testCase.expression)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+
+			require.NoError(t, query.Render(&out, testClusters))
+			assert.Equal(t, testCase.want, out.String())
+		})
+	}
+}
+
+func TestNew_InvalidExpression(t *testing.T) {
+	t.Parallel()
+
+	_, err := outputquery.New(outputquery.LanguageJMESPath, "[?status==")
+	require.ErrorIs(t, err, outputquery.ErrInvalidQuery)
+
+	_, err = outputquery.New(outputquery.LanguageCEL, "object.")
+	require.ErrorIs(t, err, outputquery.ErrInvalidQuery)
+}
+
+func TestNew_UnsupportedLanguage(t *testing.T) {
+	t.Parallel()
+
+	_, err := outputquery.New("jq", ".name")
+	require.ErrorIs(t, err, outputquery.ErrUnsupportedLanguage)
+}
+
+func TestQueryEvaluate_CELRuntimeError(t *testing.T) {
+	t.Parallel()
+
+	query, err := outputquery.New(outputquery.LanguageCEL, "object.missing")
+	require.NoError(t, err)
+
+	_, err = query.Evaluate(map[string]any{"name": "dev"})
+	require.Error(t, err)
+}
+
+func TestLanguageSet(t *testing.T) {
+	t.Parallel()
+
+	var language outputquery.Language
+
+	require.NoError(t, language.Set("CEL"))
+	assert.Equal(t, outputquery.LanguageCEL, language)
+
+	require.ErrorIs(t, language.Set("jq"), outputquery.ErrUnsupportedLanguage)
+}
+
+func TestFromFlags(t *testing.T) {
+	t.Parallel()
+
+	newCmd := func(args ...string) *cobra.Command {
+		t.Helper()
+
+		cmd := &cobra.Command{Use: "test"}
+		outputquery.BindFlags(cmd)
+		require.NoError(t, cmd.ParseFlags(args))
+
+		return cmd
+	}
+
+	query, err := outputquery.FromFlags(newCmd())
+	require.NoError(t, err)
+	assert.Nil(t, query, "no query without --query")
+
+	query, err = outputquery.FromFlags(&cobra.Command{Use: "unbound"})
+	require.NoError(t, err)
+	assert.Nil(t, query, "no query when the flags are not registered")
+
+	query, err = outputquery.FromFlags(newCmd("--query", "size(object)", "--query-language", "cel"))
+	require.NoError(t, err)
+	require.NotNil(t, query)
+
+	var out bytes.Buffer
+
+	require.NoError(t, query.Render(&out, testClusters))
+	assert.Equal(t, "2\n", out.String())
+
+	cmd := &cobra.Command{Use: "test"}
+	outputquery.BindFlags(cmd)
+	require.Error(t, cmd.ParseFlags([]string{"--query-language", "jq"}))
+}
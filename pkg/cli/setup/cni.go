@@ -137,7 +137,7 @@ func installCiliumCNI(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster, tmr time
 		clusterCfg.Spec.Cluster.Provider,
 		clusterCfg.Spec.Cluster.LoadBalancer,
 		installer.IsHAEnabled(clusterCfg.Spec.Cluster.TotalNodeCount()),
-	)
+	).WithOptions(clusterCfg.Spec.Cluster.Cilium)
 
 	return runCNIInstallation(
 		cmd, ciliumInst, "cilium", tmr, setup, clusterCfg, []string{"kube-system"},
@@ -9,17 +9,29 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/installer"
+	actionsrunnercontrollerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/actionsrunnercontroller"
 	argocdinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/argocd"
 	certmanagerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/certmanager"
 	clusterautoscalerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/clusterautoscaler"
+	externalsecretsinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/externalsecrets"
 	fluxinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/flux"
 	gatekeeperinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/gatekeeper"
 	hcloudccminstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/hcloudccm"
 	hetznercsiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/hetznercsi"
+	keycloakinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/keycloak"
+	knativeinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/knative"
 	kubeletcsrapproverinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/kubeletcsrapprover"
 	kyvernoinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/kyverno"
 	localpathstorageinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/localpathstorage"
+	lokiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/loki"
+	nvidiadeviceplugininstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/nvidiadeviceplugin"
+	sealedsecretsinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/sealedsecrets"
+	trustmanagerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/trustmanager"
+	veleroinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/velero"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/trust"
 	"github.com/spf13/cobra"
 )
 
@@ -33,6 +45,27 @@ var (
 	ErrCSIInstallerFactoryNil               = errors.New("CSI installer factory is nil")
 	ErrPolicyEngineInstallerFactoryNil      = errors.New("policy engine installer factory is nil")
 	ErrPolicyEngineDisabled                 = errors.New("policy engine is disabled")
+	ErrIngressControllerInstallerFactoryNil = errors.New(
+		"ingress controller installer factory is nil",
+	)
+	ErrIngressControllerDisabled             = errors.New("ingress controller is disabled")
+	ErrLoggingInstallerFactoryNil            = errors.New("logging installer factory is nil")
+	ErrSealedSecretsInstallerFactoryNil      = errors.New("sealed secrets installer factory is nil")
+	ErrTrustManagerInstallerFactoryNil       = errors.New("trust-manager installer factory is nil")
+	ErrExternalSecretsInstallerFactoryNil    = errors.New("external secrets installer factory is nil")
+	ErrVeleroInstallerFactoryNil             = errors.New("velero installer factory is nil")
+	ErrNVIDIADevicePluginInstallerFactoryNil = errors.New(
+		"nvidia device plugin installer factory is nil",
+	)
+	ErrActionsRunnerInstallerFactoryNil = errors.New(
+		"actions runner controller installer factory is nil",
+	)
+	ErrGatewayAPIInstallerFactoryNil        = errors.New("gateway API installer factory is nil")
+	ErrServiceMeshInstallerFactoryNil       = errors.New("service mesh installer factory is nil")
+	ErrServiceMeshDisabled                  = errors.New("service mesh is disabled")
+	ErrKnativeInstallerFactoryNil           = errors.New("knative installer factory is nil")
+	ErrIdentityProviderInstallerFactoryNil  = errors.New("identity provider installer factory is nil")
+	ErrGatewayAPIDisabled                   = errors.New("gateway API install is disabled")
 	ErrClusterConfigNil                     = errors.New("cluster config is nil")
 	ErrClusterAutoscalerInstallerFactoryNil = errors.New(
 		"cluster-autoscaler installer factory is nil",
@@ -66,8 +99,20 @@ type InstallerFactories struct {
 		operatorVersion string,
 	) installer.Installer
 	CertManager               func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	TrustManager              func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	SealedSecrets             func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	ExternalSecrets           func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	Velero                    func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	NVIDIADevicePlugin        func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	ActionsRunner             func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
 	CSI                       func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
 	PolicyEngine              func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	IngressController         func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	GatewayAPI                func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	ServiceMesh               func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	Knative                   func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	IdentityProvider          func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
+	Logging                   func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
 	ArgoCD                    func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
 	KubeletCSRApprover        func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
 	ClusterAutoscaler         func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error)
@@ -121,6 +166,10 @@ type InstallerFactories struct {
 	// nested containerd repeatedly reserves a pod sandbox name. Set to nil to
 	// use the default typed-event monitor.
 	ReservedSandboxMonitor func(ctx context.Context, clusterCfg *v1alpha1.Cluster) error
+	// ResourceLimits bounds how many network- and CPU-heavy component installs
+	// run at once on local Docker providers (see ResourceSlots). Set to nil to
+	// derive the limits from the host's CPUs and memory (detectResourceLimits).
+	ResourceLimits func(ctx context.Context) notify.ResourceLimits
 }
 
 // policyEngineFactory creates the policy engine factory function.
@@ -170,6 +219,152 @@ func policyEngineFactory(
 	}
 }
 
+// ingressControllerFactory creates the ingress controller factory function.
+func ingressControllerFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		controller := clusterCfg.Spec.Cluster.IngressController
+
+		if !controller.Enabled() {
+			return nil, ErrIngressControllerDisabled
+		}
+
+		helmClient, _, err := factories.HelmClientFactory(clusterCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return installer.NewIngressControllerInstaller(
+			helmClient,
+			installer.GetInstallTimeout(clusterCfg),
+			controller,
+			clusterCfg.Spec.Cluster.Provider.NeedsLocalDocker(),
+			installer.IsHAEnabled(clusterCfg.Spec.Cluster.TotalNodeCount()),
+		), nil
+	}
+}
+
+// serviceMeshFactory creates the service mesh factory function.
+func serviceMeshFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		if !clusterCfg.Spec.Cluster.ServiceMesh.Enabled() {
+			return nil, ErrServiceMeshDisabled
+		}
+
+		helmClient, kubeconfig, err := factories.HelmClientFactory(clusterCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return installer.NewServiceMeshInstaller(
+			helmClient,
+			installer.GetInstallTimeout(clusterCfg),
+			clusterCfg.Spec.Cluster,
+			kubeconfig,
+			clusterCfg.Spec.Cluster.Connection.Context,
+		), nil
+	}
+}
+
+// knativeFactory creates the Knative Serving factory function. The installer
+// needs the kubeconfig to apply the KnativeServing resource after the operator
+// chart is installed.
+func knativeFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		helmClient, kubeconfig, err := factories.HelmClientFactory(clusterCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return knativeinstaller.NewInstaller(
+			helmClient,
+			kubeconfig,
+			clusterCfg.Spec.Cluster.Connection.Context,
+			installer.GetInstallTimeout(clusterCfg),
+			clusterCfg.Spec.Cluster.Provider.NeedsLocalDocker(),
+		), nil
+	}
+}
+
+// trustManagerFactory creates the trust-manager factory function. The installer
+// needs the kubeconfig to apply the Bundle after the chart is installed, and the
+// loaded spec.trust.caBundles certificates to put in it.
+func trustManagerFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		bundle, err := trust.LoadBundle(clusterCfg.Spec.Trust)
+		if err != nil {
+			return nil, fmt.Errorf("load trust CA bundles: %w", err)
+		}
+
+		helmClient, kubeconfig, err := factories.HelmClientFactory(clusterCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return trustmanagerinstaller.NewInstaller(
+			helmClient,
+			kubeconfig,
+			clusterCfg.Spec.Cluster.Connection.Context,
+			installer.GetInstallTimeout(clusterCfg),
+			bundle,
+		), nil
+	}
+}
+
+// identityProviderFactory creates the development identity provider factory
+// function. Keycloak is applied as plain manifests, so only the kubeconfig of
+// the Helm client factory is used.
+func identityProviderFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		_, kubeconfig, err := factories.HelmClientFactory(clusterCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return keycloakinstaller.NewInstaller(
+			kubeconfig,
+			clusterCfg.Spec.Cluster.Connection.Context,
+			installer.GetInstallTimeout(clusterCfg),
+		), nil
+	}
+}
+
+// gatewayAPIFactory creates the Gateway API factory function. Only the CRDs and
+// Envoy options install anything; Cilium's controller ships with the CNI.
+func gatewayAPIFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		gatewayAPI := clusterCfg.Spec.Cluster.GatewayAPI
+
+		if !gatewayAPI.NeedsInstall() {
+			return nil, ErrGatewayAPIDisabled
+		}
+
+		helmClient, kubeconfig, err := factories.HelmClientFactory(clusterCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return installer.NewGatewayAPIInstaller(
+			helmClient,
+			kubeconfig,
+			clusterCfg.Spec.Cluster.Connection.Context,
+			installer.GetInstallTimeout(clusterCfg),
+			gatewayAPI,
+		), nil
+	}
+}
+
 // csiFactory creates the CSI factory function.
 func csiFactory(
 	factories *InstallerFactories,
@@ -231,6 +426,76 @@ func clusterAutoscalerFactory(
 	}
 }
 
+// veleroFactory creates the Velero factory function. The MinIO container that
+// stores the backups joins the cluster's Docker network, which is derived from
+// the distribution and the cluster name.
+func veleroFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		helmClient, timeout, err := resolveHelmClientAndTimeout(factories, clusterCfg, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		clusterName := resolveClusterNameFromContext(clusterCfg)
+
+		return veleroinstaller.NewInstaller(
+			helmClient,
+			nil,
+			timeout,
+			clusterName,
+			veleroinstaller.DockerNetworkName(clusterCfg.Spec.Cluster.Distribution, clusterName),
+		), nil
+	}
+}
+
+// actionsRunnerFactory creates the actions-runner-controller factory function. The
+// registration token is resolved from the KSail credential store, falling back to
+// GITHUB_TOKEN, so it never has to be written into ksail.yaml.
+func actionsRunnerFactory(
+	factories *InstallerFactories,
+) func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+	return func(clusterCfg *v1alpha1.Cluster) (installer.Installer, error) {
+		token, err := resolveGitHubToken()
+		if err != nil {
+			return nil, err
+		}
+
+		helmClient, timeout, err := resolveHelmClientAndTimeout(factories, clusterCfg, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		runner := clusterCfg.Spec.Cluster.ActionsRunner
+
+		return actionsrunnercontrollerinstaller.NewInstaller(
+			helmClient,
+			timeout,
+			actionsrunnercontrollerinstaller.Options{
+				GitHubConfigURL: runner.GitHubConfigURL,
+				Name:            runner.EffectiveName(),
+				Labels:          runner.Labels,
+				MinRunners:      runner.MinRunners,
+				MaxRunners:      runner.MaxRunners,
+				Token:           token,
+			},
+		), nil
+	}
+}
+
+// resolveGitHubToken returns the GitHub token from the OS keyring, falling back to the
+// configured environment variable (GITHUB_TOKEN by default). An empty token is reported
+// by the installer when it runs.
+func resolveGitHubToken() (string, error) {
+	manager, err := credentials.NewManager(credentials.KeyringStore{})
+	if err != nil {
+		return "", fmt.Errorf("load credential settings: %w", err)
+	}
+
+	return manager.Value(credentials.GitHubToken), nil
+}
+
 // resolveHelmClientAndTimeout creates a Helm client and computes the
 // effective install timeout for the given cluster configuration.
 func resolveHelmClientAndTimeout(
@@ -321,6 +586,7 @@ func DefaultInstallerFactories() *InstallerFactories {
 		},
 		installer.CertManagerInstallTimeout,
 	)
+	factories.TrustManager = trustManagerFactory(factories)
 	factories.ArgoCD = argoCDInstallerFactory(factories)
 	factories.KubeletCSRApprover = haHelmInstallerFactory(
 		factories,
@@ -331,6 +597,41 @@ func DefaultInstallerFactories() *InstallerFactories {
 	)
 	factories.CSI = csiFactory(factories)
 	factories.PolicyEngine = policyEngineFactory(factories)
+	factories.IngressController = ingressControllerFactory(factories)
+	factories.GatewayAPI = gatewayAPIFactory(factories)
+	factories.ServiceMesh = serviceMeshFactory(factories)
+	factories.Knative = knativeFactory(factories)
+	factories.IdentityProvider = identityProviderFactory(factories)
+	factories.Logging = haHelmInstallerFactory(
+		factories,
+		func(c helm.Interface, t time.Duration, _ bool) installer.Installer {
+			return lokiinstaller.NewInstaller(c, t)
+		},
+		0,
+	)
+	factories.SealedSecrets = haHelmInstallerFactory(
+		factories,
+		func(c helm.Interface, t time.Duration, _ bool) installer.Installer {
+			return sealedsecretsinstaller.NewInstaller(c, t)
+		},
+		0,
+	)
+	factories.ExternalSecrets = haHelmInstallerFactory(
+		factories,
+		func(c helm.Interface, t time.Duration, _ bool) installer.Installer {
+			return externalsecretsinstaller.NewInstaller(c, t)
+		},
+		0,
+	)
+	factories.Velero = veleroFactory(factories)
+	factories.NVIDIADevicePlugin = haHelmInstallerFactory(
+		factories,
+		func(c helm.Interface, t time.Duration, _ bool) installer.Installer {
+			return nvidiadeviceplugininstaller.NewInstaller(c, t)
+		},
+		0,
+	)
+	factories.ActionsRunner = actionsRunnerFactory(factories)
 	factories.ClusterAutoscaler = clusterAutoscalerFactory(factories)
 	factories.AWSLoadBalancerController = func(
 		clusterCfg *v1alpha1.Cluster,
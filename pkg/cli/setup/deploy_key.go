@@ -0,0 +1,70 @@
+package setup
+
+import (
+	"context"
+	"io"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+)
+
+// ensureDeployKey generates the spec.workload.git deploy key on first use, so the GitOps
+// engines can store it in-cluster, and registers it on the repository when a Git provider
+// token is available. Otherwise it prints the public key to add by hand; the engine keeps
+// retrying the clone until the key is registered.
+func ensureDeployKey(ctx context.Context, clusterCfg *v1alpha1.Cluster, writer io.Writer) error {
+	git := clusterCfg.Spec.Workload.Git
+	if !git.IsEnabled() || !git.DeployKey {
+		return nil
+	}
+
+	dir, err := deploykey.DefaultDir()
+	if err != nil {
+		return err
+	}
+
+	key, created, err := deploykey.LoadOrCreate(dir, git.URL)
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		return nil
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.GenerateType,
+		Content: "generated deploy key %s",
+		Args:    []any{key.Path},
+		Writer:  writer,
+	})
+
+	var token string
+	if ref, parseErr := gitprovider.ParseRepoRef(git.URL); parseErr == nil {
+		token = gitprovider.ResolveToken(ref.Provider, "")
+	}
+
+	err = deploykey.Register(ctx, git.URL, token, key, false)
+	if err == nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.SuccessType,
+			Content: "registered deploy key on %s",
+			Args:    []any{git.URL},
+			Writer:  writer,
+		})
+
+		return nil
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type: notify.WarningType,
+		Content: "could not register the deploy key automatically (%v); add this public key " +
+			"as a read-only deploy key of %s:\n%s",
+		Args:   []any{err, git.URL, key.AuthorizedKey},
+		Writer: writer,
+	})
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package setup
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // mutates HOME and the Git provider token environment
+func TestEnsureDeployKey_GeneratesKeyAndPrintsItWithoutToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GH_CONFIG_DIR", home)
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Workload: v1alpha1.WorkloadSpec{
+				Git: v1alpha1.GitSource{URL: "https://github.com/org/repo.git", DeployKey: true},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+
+	require.NoError(t, ensureDeployKey(context.Background(), clusterCfg, &out))
+
+	keyPath := filepath.Join(home, ".ksail", "deploy-keys", "github.com_org_repo")
+	public, err := os.ReadFile(keyPath + ".pub")
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "generated deploy key "+keyPath)
+	assert.Contains(t, out.String(), "could not register the deploy key automatically")
+	assert.Contains(t, out.String(), string(bytes.TrimSpace(public)))
+
+	out.Reset()
+	require.NoError(t, ensureDeployKey(context.Background(), clusterCfg, &out))
+	assert.Empty(t, out.String(), "an existing key is reused silently")
+}
+
+func TestEnsureDeployKey_NoopWithoutDeployKey(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Workload: v1alpha1.WorkloadSpec{
+				Git: v1alpha1.GitSource{URL: "https://github.com/org/repo.git"},
+			},
+		},
+	}
+
+	require.NoError(t, ensureDeployKey(context.Background(), clusterCfg, &out))
+	assert.Empty(t, out.String())
+}
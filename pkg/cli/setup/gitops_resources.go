@@ -24,7 +24,8 @@ const (
 )
 
 // ShouldPushOCIArtifact determines if OCI artifact push should happen for GitOps engines.
-// Returns true if Flux or ArgoCD is enabled and a local registry is configured.
+// Returns true if Flux or ArgoCD is enabled, no Git source is configured, and a local
+// registry is configured.
 func ShouldPushOCIArtifact(clusterCfg *v1alpha1.Cluster) bool {
 	// Only push for GitOps engines that consume OCI artifacts
 	engine := clusterCfg.Spec.Cluster.GitOpsEngine
@@ -32,6 +33,11 @@ func ShouldPushOCIArtifact(clusterCfg *v1alpha1.Cluster) bool {
 		return false
 	}
 
+	// A Git source (spec.workload.git) is reconciled from the repository, not an artifact
+	if clusterCfg.Spec.Workload.Git.IsEnabled() {
+		return false
+	}
+
 	// Only push if local registry is enabled
 	return clusterCfg.Spec.Cluster.LocalRegistry.Enabled()
 }
@@ -231,6 +237,8 @@ func (f *InstallerFactories) callEnsureOCIArtifact(
 
 // ensureOCIArtifact checks if an OCI artifact exists and pushes one if needed.
 // Returns true if an artifact exists or was pushed, false if no artifact needed.
+// A Git source (spec.workload.git) needs no artifact, and its repository already
+// holds the content, so it reports true to let the engine be awaited.
 func ensureOCIArtifact(
 	ctx context.Context,
 	_ *cobra.Command,
@@ -238,6 +246,10 @@ func ensureOCIArtifact(
 	clusterName string,
 	writer io.Writer,
 ) (bool, error) {
+	if clusterCfg.Spec.Workload.Git.IsEnabled() {
+		return true, nil
+	}
+
 	// Only check/push for local registries
 	if !clusterCfg.Spec.Cluster.LocalRegistry.Enabled() {
 		return false, nil
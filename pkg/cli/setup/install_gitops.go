@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"path"
 	"strconv"
 	"strings"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	argocdgitops "github.com/devantler-tech/ksail/v7/pkg/client/argocd"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/installer"
 	argocdinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/argocd"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
@@ -87,6 +89,11 @@ func EnsureArgoCDResources(
 	// Build repository URL and credentials based on registry configuration
 	opts := buildArgoCDEnsureOptions(clusterCfg, clusterName, registryHost)
 
+	err = applyDeployKeyOptions(&opts, clusterCfg.Spec.Workload.Git)
+	if err != nil {
+		return err
+	}
+
 	err = mgr.Ensure(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("ensure argocd resources: %w", err)
@@ -119,17 +126,132 @@ func buildArgoCDEnsureOptions(
 		SourcePath:      ".",
 		ApplicationName: "ksail",
 		TargetRevision:  tag,
+		Project:         ArgoCDProjectOptions(clusterCfg),
 	}
 
-	if localRegistry.IsExternal() {
+	switch {
+	case clusterCfg.Spec.Workload.Git.IsEnabled():
+		applyGitSourceOptions(&opts, clusterCfg)
+	case localRegistry.IsExternal():
 		applyExternalRegistryOptions(&opts, localRegistry)
-	} else {
+	default:
 		applyLocalRegistryOptions(&opts, clusterCfg, clusterName, registryHost)
 	}
 
+	opts.ApplicationSet = argoCDApplicationSetOptions(clusterCfg, opts.SourcePath)
+
+	return opts
+}
+
+// argoCDApplicationSetOptions converts spec.workload.argoCD into the ApplicationSet
+// the Argo CD manager reconciles next to the Application. It returns nil for the
+// single Application layout, which removes a previously created ApplicationSet.
+// The directory generator discovers the app directories under sourcePath.
+func argoCDApplicationSetOptions(
+	clusterCfg *v1alpha1.Cluster,
+	sourcePath string,
+) *argocdgitops.ApplicationSetOptions {
+	argoCD := clusterCfg.Spec.Workload.ArgoCD
+	if !argoCD.UsesApplicationSet() {
+		return nil
+	}
+
+	if argoCD.ApplicationSet.ResolvedGenerator() == v1alpha1.ArgoCDGeneratorCluster {
+		return &argocdgitops.ApplicationSetOptions{
+			Generator:       argocdgitops.GeneratorCluster,
+			ClusterSelector: argoCD.ApplicationSet.ClusterSelector,
+		}
+	}
+
+	return &argocdgitops.ApplicationSetOptions{
+		Generator:     argocdgitops.GeneratorDirectory,
+		DirectoryPath: path.Join(sourcePath, v1alpha1.ArgoCDAppsDirectory, "*"),
+	}
+}
+
+// ArgoCDProjectOptions converts spec.workload.argoCD.project into the AppProject
+// restrictions the Argo CD manager reconciles. It returns nil when no restriction
+// is declared, which keeps the Application on the default project.
+func ArgoCDProjectOptions(clusterCfg *v1alpha1.Cluster) *argocdgitops.ProjectOptions {
+	project := clusterCfg.Spec.Workload.ArgoCD.Project
+	if project.IsZero() {
+		return nil
+	}
+
+	opts := &argocdgitops.ProjectOptions{SourceRepos: project.SourceRepos}
+
+	for _, dest := range project.Destinations {
+		opts.Destinations = append(opts.Destinations, argocdgitops.ProjectDestination{
+			Server:    dest.Server,
+			Name:      dest.Name,
+			Namespace: dest.Namespace,
+		})
+	}
+
+	for _, window := range project.SyncWindows {
+		opts.SyncWindows = append(opts.SyncWindows, argocdgitops.SyncWindow{
+			Kind:         window.Kind,
+			Schedule:     window.Schedule,
+			Duration:     window.Duration,
+			Applications: window.Applications,
+			Namespaces:   window.Namespaces,
+			Clusters:     window.Clusters,
+			ManualSync:   window.ManualSync,
+			TimeZone:     window.TimeZone,
+		})
+	}
+
 	return opts
 }
 
+// applyGitSourceOptions configures options for a spec.workload.git repository. A Git
+// checkout holds the whole repository, so the source path is the source directory
+// and the Application tracks the configured branch rather than an artifact tag.
+func applyGitSourceOptions(opts *argocdgitops.EnsureOptions, clusterCfg *v1alpha1.Cluster) {
+	git := clusterCfg.Spec.Workload.Git
+
+	sourceDir := strings.TrimSpace(clusterCfg.Spec.Workload.SourceDirectory)
+	if sourceDir == "" {
+		sourceDir = v1alpha1.DefaultSourceDirectory
+	}
+
+	opts.RepositoryType = argocdgitops.RepositoryTypeGit
+	opts.RepositoryURL = git.CloneURL()
+	opts.SourcePath = sourceDir
+	opts.TargetRevision = git.ResolvedBranch()
+
+	if token := git.ResolveToken(); token != "" {
+		opts.Username = "git"
+		opts.Password = token
+	}
+}
+
+// applyDeployKeyOptions adds the repository's deploy key from the KSail deploy key store
+// when spec.workload.git.deployKey is set. Argo CD trusts the SSH host keys of GitHub,
+// GitLab and Bitbucket out of the box.
+func applyDeployKeyOptions(opts *argocdgitops.EnsureOptions, git v1alpha1.GitSource) error {
+	if !git.IsEnabled() || !git.DeployKey {
+		return nil
+	}
+
+	dir, err := deploykey.DefaultDir()
+	if err != nil {
+		return err
+	}
+
+	key, err := deploykey.Load(dir, git.URL)
+	if err != nil {
+		return fmt.Errorf(
+			"load argocd deploy key: %w (generate and register it with 'ksail workload deploy-key')",
+			err,
+		)
+	}
+
+	opts.SSHPrivateKey = string(key.PrivateKeyPEM)
+
+	return nil
+}
+
 // applyExternalRegistryOptions configures options for external OCI registries.
 func applyExternalRegistryOptions(
 	opts *argocdgitops.EnsureOptions,
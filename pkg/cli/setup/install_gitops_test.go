@@ -5,6 +5,7 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/setup"
+	argocdgitops "github.com/devantler-tech/ksail/v7/pkg/client/argocd"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -121,6 +122,132 @@ func TestBuildArgoCDEnsureOptions(t *testing.T) {
 	}
 }
 
+func TestBuildArgoCDEnsureOptions_GitSource(t *testing.T) {
+	t.Setenv("KSAIL_TEST_GIT_TOKEN", "git-token")
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Cluster: v1alpha1.ClusterSpec{
+				LocalRegistry: v1alpha1.LocalRegistry{Registry: "localhost:5000"},
+			},
+			Workload: v1alpha1.WorkloadSpec{
+				SourceDirectory: "manifests",
+				Tag:             "v1",
+				Git: v1alpha1.GitSource{
+					URL:         "https://github.com/org/platform.git",
+					TokenEnvVar: "KSAIL_TEST_GIT_TOKEN",
+				},
+			},
+		},
+	}
+
+	opts := setup.BuildArgoCDEnsureOptions(clusterCfg, "test-cluster", "")
+
+	assert.Equal(t, argocdgitops.RepositoryTypeGit, opts.RepositoryType)
+	assert.Equal(t, "https://github.com/org/platform.git", opts.RepositoryURL)
+	assert.Equal(t, "manifests", opts.SourcePath)
+	assert.Equal(t, "main", opts.TargetRevision)
+	assert.Equal(t, "git", opts.Username)
+	assert.Equal(t, "git-token", opts.Password)
+	assert.False(t, opts.Insecure)
+}
+
+func TestBuildArgoCDEnsureOptions_GitSourceDeployKey(t *testing.T) {
+	t.Setenv("KSAIL_TEST_GIT_TOKEN", "git-token")
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Workload: v1alpha1.WorkloadSpec{
+				Git: v1alpha1.GitSource{
+					URL:         "https://github.com/org/platform.git",
+					TokenEnvVar: "KSAIL_TEST_GIT_TOKEN",
+					DeployKey:   true,
+				},
+			},
+		},
+	}
+
+	opts := setup.BuildArgoCDEnsureOptions(clusterCfg, "test-cluster", "")
+
+	assert.Equal(t, "ssh://git@github.com/org/platform.git", opts.RepositoryURL)
+	assert.Empty(t, opts.Username, "the deploy key replaces token authentication")
+	assert.Empty(t, opts.Password)
+}
+
+func TestBuildArgoCDEnsureOptions_Project(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Cluster: v1alpha1.ClusterSpec{
+				LocalRegistry: v1alpha1.LocalRegistry{Registry: "localhost:5000"},
+			},
+		},
+	}
+
+	opts := setup.BuildArgoCDEnsureOptions(clusterCfg, "test-cluster", "")
+	assert.Nil(t, opts.Project, "no restrictions must keep the default project")
+
+	clusterCfg.Spec.Workload.ArgoCD.Project = v1alpha1.ArgoCDProject{
+		SourceRepos:  []string{"oci://ghcr.io/org/*"},
+		Destinations: []v1alpha1.ArgoCDDestination{{Name: "in-cluster", Namespace: "apps"}},
+		SyncWindows: []v1alpha1.ArgoCDSyncWindow{{
+			Kind:         v1alpha1.ArgoCDSyncWindowDeny,
+			Schedule:     "0 22 * * *",
+			Duration:     "8h",
+			Applications: []string{"ksail"},
+		}},
+	}
+
+	opts = setup.BuildArgoCDEnsureOptions(clusterCfg, "test-cluster", "")
+
+	assert.Equal(t, &argocdgitops.ProjectOptions{
+		SourceRepos:  []string{"oci://ghcr.io/org/*"},
+		Destinations: []argocdgitops.ProjectDestination{{Name: "in-cluster", Namespace: "apps"}},
+		SyncWindows: []argocdgitops.SyncWindow{{
+			Kind:         "deny",
+			Schedule:     "0 22 * * *",
+			Duration:     "8h",
+			Applications: []string{"ksail"},
+		}},
+	}, opts.Project)
+}
+
+func TestBuildArgoCDEnsureOptions_ApplicationSet(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Workload: v1alpha1.WorkloadSpec{
+				SourceDirectory: "manifests",
+				Git:             v1alpha1.GitSource{URL: "https://github.com/org/platform.git"},
+			},
+		},
+	}
+
+	opts := setup.BuildArgoCDEnsureOptions(clusterCfg, "test-cluster", "")
+	assert.Nil(t, opts.ApplicationSet, "the Application layout must not create an ApplicationSet")
+
+	clusterCfg.Spec.Workload.ArgoCD.Layout = v1alpha1.ArgoCDLayoutApplicationSet
+
+	opts = setup.BuildArgoCDEnsureOptions(clusterCfg, "test-cluster", "")
+	assert.Equal(t, &argocdgitops.ApplicationSetOptions{
+		Generator:     argocdgitops.GeneratorDirectory,
+		DirectoryPath: "manifests/apps/*",
+	}, opts.ApplicationSet)
+
+	clusterCfg.Spec.Workload.ArgoCD.ApplicationSet = v1alpha1.ArgoCDApplicationSet{
+		Generator:       v1alpha1.ArgoCDGeneratorCluster,
+		ClusterSelector: map[string]string{"env": "staging"},
+	}
+
+	opts = setup.BuildArgoCDEnsureOptions(clusterCfg, "test-cluster", "")
+	assert.Equal(t, &argocdgitops.ApplicationSetOptions{
+		Generator:       argocdgitops.GeneratorCluster,
+		ClusterSelector: map[string]string{"env": "staging"},
+	}, opts.ApplicationSet)
+}
+
 func TestBuildArgoCDEnsureOptions_UsesConfiguredClusterTokenEnvVar(t *testing.T) {
 	t.Setenv("GHCR_PULL_TOKEN", "pull-token")
 
@@ -56,6 +56,9 @@ func HelmClientForCluster(clusterCfg *v1alpha1.Cluster) (*helm.Client, string, e
 		return nil, "", fmt.Errorf("failed to create Helm client: %w", err)
 	}
 
+	metadata := clusterCfg.Spec.Cluster.ResourceMetadata
+	helmClient.WithResourceMetadata(metadata.Labels, metadata.Annotations)
+
 	return helmClient, kubeconfigPath, nil
 }
 
@@ -746,6 +749,153 @@ func InstallPolicyEngineSilent(
 	)
 }
 
+// InstallIngressControllerSilent installs the ingress controller silently for parallel execution.
+func InstallIngressControllerSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.IngressController,
+		ErrIngressControllerInstallerFactoryNil, "ingress-controller",
+	)
+}
+
+// InstallGatewayAPISilent installs the Gateway API CRDs and controller silently for parallel execution.
+func InstallGatewayAPISilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.GatewayAPI,
+		ErrGatewayAPIInstallerFactoryNil, "gateway-api",
+	)
+}
+
+// InstallServiceMeshSilent installs the service mesh control plane silently for parallel execution.
+func InstallServiceMeshSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.ServiceMesh,
+		ErrServiceMeshInstallerFactoryNil, "service-mesh",
+	)
+}
+
+// InstallKnativeSilent installs Knative Serving silently for parallel execution.
+func InstallKnativeSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.Knative,
+		ErrKnativeInstallerFactoryNil, "knative",
+	)
+}
+
+// InstallIdentityProviderSilent installs the development identity provider
+// silently for parallel execution.
+func InstallIdentityProviderSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.IdentityProvider,
+		ErrIdentityProviderInstallerFactoryNil, "identity-provider",
+	)
+}
+
+// InstallLoggingSilent installs the log aggregation stack silently for parallel execution.
+func InstallLoggingSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.Logging,
+		ErrLoggingInstallerFactoryNil, "logging",
+	)
+}
+
+// InstallTrustManagerSilent installs trust-manager and publishes the
+// spec.trust.caBundles certificates silently for parallel execution.
+func InstallTrustManagerSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.TrustManager,
+		ErrTrustManagerInstallerFactoryNil, "trust-manager",
+	)
+}
+
+// InstallSealedSecretsSilent installs the Sealed Secrets controller silently for parallel execution.
+func InstallSealedSecretsSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.SealedSecrets,
+		ErrSealedSecretsInstallerFactoryNil, "sealed-secrets",
+	)
+}
+
+// InstallExternalSecretsSilent installs the External Secrets Operator silently for parallel execution.
+func InstallExternalSecretsSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.ExternalSecrets,
+		ErrExternalSecretsInstallerFactoryNil, "external-secrets",
+	)
+}
+
+// InstallVeleroSilent installs Velero and its MinIO backup target silently for parallel execution.
+func InstallVeleroSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.Velero,
+		ErrVeleroInstallerFactoryNil, "velero",
+	)
+}
+
+// InstallNVIDIADevicePluginSilent installs the NVIDIA device plugin silently for parallel execution.
+func InstallNVIDIADevicePluginSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.NVIDIADevicePlugin,
+		ErrNVIDIADevicePluginInstallerFactoryNil, "nvidia-device-plugin",
+	)
+}
+
+// InstallActionsRunnerSilent installs actions-runner-controller and its runner scale set
+// silently for parallel execution.
+func InstallActionsRunnerSilent(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) error {
+	return installFromFactory(
+		ctx, clusterCfg, factories.ActionsRunner,
+		ErrActionsRunnerInstallerFactoryNil, "actions-runner-controller",
+	)
+}
+
 // InstallClusterAutoscalerSilent installs the Cluster Autoscaler silently for parallel execution.
 func InstallClusterAutoscalerSilent(
 	ctx context.Context,
@@ -226,6 +226,8 @@ func newCreateOptions(
 		ClusterName: ctx.clusterName,
 		// Use base name for volume to share across clusters
 		VolumeName: registry.LocalRegistryBaseName,
+		Labels:     clusterCfg.Spec.Cluster.ResourceMetadata.Labels,
+		HostIP:     clusterCfg.Spec.Cluster.RegistryBinding.HostIP,
 	}
 }
 
@@ -446,6 +446,27 @@ func TestNewCreateOptions(t *testing.T) {
 	assert.Equal(t, registry.LocalRegistryBaseName, opts.VolumeName)
 }
 
+// TestNewCreateOptions_RegistryBinding verifies the local registry is published
+// on the configured host address.
+func TestNewCreateOptions_RegistryBinding(t *testing.T) {
+	t.Parallel()
+
+	clusterCfg := &v1alpha1.Cluster{
+		Spec: v1alpha1.Spec{
+			Cluster: v1alpha1.ClusterSpec{
+				Distribution:    v1alpha1.DistributionVanilla,
+				LocalRegistry:   v1alpha1.LocalRegistry{Registry: "localhost:5050"},
+				RegistryBinding: v1alpha1.RegistryBinding{HostIP: "0.0.0.0"},
+			},
+		},
+	}
+
+	opts := localregistry.NewCreateOptionsForTest(clusterCfg, "my-cluster", "kind")
+
+	assert.Equal(t, "0.0.0.0", opts.HostIP)
+	assert.Equal(t, 5050, opts.Port)
+}
+
 // TestBuildVerifyOptions verifies verify options are built correctly.
 func TestBuildVerifyOptions(t *testing.T) {
 	t.Parallel()
@@ -65,7 +65,11 @@ func runK3dNetworkAction(
 	writer := ctx.Cmd.OutOrStdout()
 
 	// For K3d, we don't need to specify a CIDR as K3d manages its own network settings.
-	return EnsureDockerNetworkExists(execCtx, dockerClient, networkName, "", writer)
+	// K3d cannot create IPv6 networks itself, so IPv6 and dual-stack clusters rely on
+	// this pre-created network, which K3d reuses by name.
+	return EnsureDockerNetworkExists(
+		execCtx, dockerClient, networkName, "", ctx.ClusterCfg.Spec.Cluster.Network.IPFamily.IPv6(), writer,
+	)
 }
 
 // runK3dConnectAction connects registries to the Docker network.
@@ -141,7 +145,19 @@ func PrepareK3dConfigWithMirrors(
 		return false
 	}
 
-	rendered := registry.RenderK3dMirrorConfig(updatedMap)
+	// Merge into the original document so imported rewrites and auth/TLS
+	// configs survive, adding the mirrors' upstream credentials and, for mirrors
+	// served over HTTPS, the CA the nodes verify them with. The config is
+	// only handed to K3d, never written back. An unparsable original is left untouched.
+	rendered, err := registry.RenderK3dMirrorRuntimeConfig(
+		original,
+		updatedMap,
+		registry.BuildK3dMirrorAuths(mirrorSpecs),
+		registry.BuildK3dMirrorCAFiles(mirrorSpecs, clusterName),
+	)
+	if err != nil {
+		return strings.TrimSpace(original) != ""
+	}
 
 	if strings.TrimSpace(rendered) == strings.TrimSpace(original) {
 		return strings.TrimSpace(original) != ""
@@ -149,9 +165,32 @@ func PrepareK3dConfigWithMirrors(
 
 	k3dConfig.Registries.Config = rendered
 
+	if authority := registry.MirrorTLSAuthority(mirrorSpecs); authority != nil {
+		applyK3dRegistryCAVolume(k3dConfig, authority.CACertPath())
+	}
+
 	return true
 }
 
+// applyK3dRegistryCAVolume mounts the mirror registry CA into every K3d node at
+// the path the rendered registries.yaml references. It is idempotent.
+func applyK3dRegistryCAVolume(k3dConfig *v1alpha5.SimpleConfig, hostCAPath string) {
+	volumeSpec := hostCAPath + ":" + registry.K3dRegistryCAPath + ":ro"
+
+	for i, vol := range k3dConfig.Volumes {
+		if strings.Contains(vol.Volume, registry.K3dRegistryCAPath) {
+			k3dConfig.Volumes[i].Volume = volumeSpec
+
+			return
+		}
+	}
+
+	k3dConfig.Volumes = append(k3dConfig.Volumes, v1alpha5.VolumeWithNodeFilters{
+		Volume:      volumeSpec,
+		NodeFilters: []string{"all"},
+	})
+}
+
 // filterOutLocalRegistry removes entries for the local registry from the registry list.
 // The local registry is managed separately by K3d's native registry management.
 // Checks for both cluster-prefixed names (e.g., k3d-default-local-registry) and
@@ -203,11 +242,16 @@ func configureK3dNativeLocalRegistry(
 		hostPort = int(clusterCfg.Spec.Cluster.LocalRegistry.ResolvedPort())
 	}
 
+	hostIP := dockerclient.RegistryHostIP
+	if bindingIP := clusterCfg.Spec.Cluster.RegistryBinding.HostIP; bindingIP != "" {
+		hostIP = bindingIP
+	}
+
 	// Configure K3d to create and manage the local registry.
 	// Container is named with cluster prefix (e.g., "k3d-default-local-registry").
 	k3dConfig.Registries.Create = &v1alpha5.SimpleConfigRegistryCreateConfig{
 		Name:     registryName,
-		Host:     dockerclient.RegistryHostIP,
+		Host:     hostIP,
 		HostPort: strconv.Itoa(hostPort),
 		Image:    dockerclient.RegistryImageName,
 	}
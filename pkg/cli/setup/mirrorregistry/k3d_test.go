@@ -44,6 +44,35 @@ func TestPrepareK3dConfigWithMirrors(t *testing.T) {
 				assert.NotEmpty(t, cfg.Registries.Config, "registries config should be set")
 			},
 		},
+		{
+			name: "keeps imported auth, TLS and rewrites",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionK3s,
+					},
+				},
+			},
+			k3dConfig: &v1alpha5.SimpleConfig{
+				ObjectMeta: types.ObjectMeta{Name: "test-cluster"},
+				Registries: v1alpha5.SimpleConfigRegistries{
+					Config: "mirrors:\n  quay.io:\n    endpoint:\n      - https://quay.example.com\n" +
+						"    rewrite:\n      \"^(.*)\": \"cache/$1\"\n" +
+						"configs:\n  quay.example.com:\n    auth:\n      username: ci\n",
+				},
+			},
+			mirrorSpecs: []registry.MirrorSpec{
+				{Host: "docker.io", Remote: "https://registry-1.docker.io"},
+			},
+			expected: true,
+			checkConfig: func(t *testing.T, cfg *v1alpha5.SimpleConfig) {
+				t.Helper()
+				assert.Contains(t, cfg.Registries.Config, "\"docker.io\":")
+				assert.Contains(t, cfg.Registries.Config, "      - https://quay.example.com\n")
+				assert.Contains(t, cfg.Registries.Config, "\"^(.*)\": \"cache/$1\"")
+				assert.Contains(t, cfg.Registries.Config, "      username: ci\n")
+			},
+		},
 		{
 			name: "returns false for non-K3s distribution",
 			clusterCfg: &v1alpha1.Cluster{
@@ -53,12 +53,14 @@ func runKindNetworkAction(
 	writer := ctx.Cmd.OutOrStdout()
 
 	// Create the Docker network. For Kind, we don't need to specify a CIDR
-	// as Kind manages its own network settings.
+	// as Kind manages its own network settings. Kind reuses an existing network
+	// as-is, so IPv6 must be enabled here for IPv6 and dual-stack clusters.
 	return EnsureDockerNetworkExists(
 		execCtx,
 		dockerClient,
-		kindconfigmanager.DefaultNetworkName,
+		kindconfigmanager.NetworkName(),
 		"",
+		ctx.ClusterCfg.Spec.Cluster.Network.IPFamily.IPv6(),
 		writer,
 	)
 }
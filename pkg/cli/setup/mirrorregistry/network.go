@@ -17,17 +17,19 @@ const DefaultNetworkMTU = registry.DefaultNetworkMTU
 // EnsureDockerNetworkExists creates a Docker network if it doesn't already exist.
 // It delegates to registry.EnsureNetwork (shared with the nested Kubernetes-provider
 // mirror setup) so the cluster network can be pre-created with Talos-compatible labels
-// and CIDR before registry containers are connected.
+// and CIDR before registry containers are connected. enableIPv6 adds an IPv6 subnet for
+// IPv6 and dual-stack clusters.
 func EnsureDockerNetworkExists(
 	ctx context.Context,
 	dockerClient dockerclient.Client,
 	networkName string,
 	networkCIDR string,
+	enableIPv6 bool,
 	writer io.Writer,
 ) error {
 	// Host (non-DinD) networks use the standard MTU.
 	err := registry.EnsureNetwork(
-		ctx, dockerClient, networkName, networkCIDR, registry.DefaultNetworkMTU, writer,
+		ctx, dockerClient, networkName, networkCIDR, enableIPv6, registry.DefaultNetworkMTU, writer,
 	)
 	if err != nil {
 		return fmt.Errorf("ensure docker network: %w", err)
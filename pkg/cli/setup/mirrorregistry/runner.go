@@ -12,6 +12,7 @@ import (
 	ksailconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
 	talosconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/talos"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	kindprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/kind"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
 	"github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"github.com/spf13/cobra"
@@ -88,6 +89,11 @@ func RunStage(params StageParams, role Role) error {
 		return err
 	}
 
+	mirrorSpecs, err = applyMirrorRegistryTLS(clusterCfg, params.KindConfig, mirrorSpecs)
+	if err != nil {
+		return err
+	}
+
 	definition, definitionExists := StageDefinitions[role]
 	if !definitionExists {
 		return nil
@@ -149,7 +155,66 @@ func resolveMirrorSpecs(
 		return nil, err
 	}
 
-	return registry.MergeSpecs(existingSpecs, flagSpecs), nil
+	// Credentials are resolved here, at cluster operation time, so tokens and Docker
+	// config references reach the nodes without ever being written to scaffolded files.
+	specs, err := registry.ResolveMirrorAuth(registry.MergeSpecs(existingSpecs, flagSpecs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mirror registry credentials: %w", err)
+	}
+
+	// Stamp the cluster's resource metadata onto every mirror so the registry
+	// containers KSail creates carry the configured labels.
+	if labels := clusterCfg.Spec.Cluster.ResourceMetadata.Labels; len(labels) > 0 {
+		for i := range specs {
+			specs[i].Labels = labels
+		}
+	}
+
+	// Publish the mirrors listed in spec.cluster.registryBinding on the host, so
+	// they can be reached from other machines than the Docker host.
+	binding := clusterCfg.Spec.Cluster.RegistryBinding
+	if len(binding.MirrorPorts) > 0 {
+		specs = registry.ApplyMirrorBinding(specs, binding.HostIP, binding.MirrorPorts)
+	}
+
+	return specs, nil
+}
+
+// applyMirrorRegistryTLS serves the mirrors over HTTPS when spec.cluster.mirrorRegistryTLS
+// is enabled on the Docker provider, signing their certificates with the KSail CA.
+// Kind hosts whose scaffolded hosts.toml is mounted into the nodes keep plain HTTP,
+// since those files cannot be rewritten to trust the CA.
+func applyMirrorRegistryTLS(
+	clusterCfg *v1alpha1.Cluster,
+	kindConfig *v1alpha4.Cluster,
+	specs []registry.MirrorSpec,
+) ([]registry.MirrorSpec, error) {
+	cluster := clusterCfg.Spec.Cluster
+	if cluster.MirrorRegistryTLS != v1alpha1.MirrorRegistryTLSEnabled || len(specs) == 0 {
+		return specs, nil
+	}
+
+	if cluster.Provider != "" && cluster.Provider != v1alpha1.ProviderDocker {
+		return specs, nil
+	}
+
+	authority, err := registry.LoadDefaultTLSAuthority()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mirror registry CA: %w", err)
+	}
+
+	specs = registry.ApplyMirrorTLS(specs, authority)
+
+	if cluster.Distribution == v1alpha1.DistributionVanilla {
+		mountedHosts := kindprovisioner.MountedMirrorHosts(kindConfig)
+		for i := range specs {
+			if mountedHosts[specs[i].Host] {
+				specs[i].TLS = nil
+			}
+		}
+	}
+
+	return specs, nil
 }
 
 // collectExistingMirrorSpecs reads existing mirror specs from hosts.toml files
@@ -199,6 +264,10 @@ func newRegistryHandlers(
 	clusterCfg := stageCtx.ClusterCfg
 	mirrorSpecs := stageCtx.MirrorSpecs
 
+	// Neither K3d nor the Talos SDK creates IPv6 networks, so IPv6 and dual-stack
+	// clusters need the network stage even without mirrors.
+	needsIPv6Network := role == RoleNetwork && clusterCfg.Spec.Cluster.Network.IPFamily.IPv6()
+
 	bind := func(action ActionFunc) func(context.Context, dockerclient.Client) error {
 		if action == nil {
 			return nil
@@ -224,7 +293,8 @@ func newRegistryHandlers(
 					return false
 				}
 
-				return PrepareK3dConfigWithMirrors(clusterCfg, stageCtx.K3dConfig, mirrorSpecs)
+				return PrepareK3dConfigWithMirrors(clusterCfg, stageCtx.K3dConfig, mirrorSpecs) ||
+					needsIPv6Network
 			},
 			Action: bind(actions[v1alpha1.DistributionK3s]),
 		},
@@ -235,7 +305,7 @@ func newRegistryHandlers(
 					stageCtx.TalosConfig,
 					mirrorSpecs,
 					talosconfigmanager.ResolveClusterName(clusterCfg, stageCtx.TalosConfig),
-				)
+				) || needsIPv6Network
 			},
 			Action: bind(actions[v1alpha1.DistributionTalos]),
 		},
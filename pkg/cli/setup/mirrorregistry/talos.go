@@ -91,7 +91,10 @@ func runTalosNetworkAction(
 	ctx *Context,
 	dockerClient dockerclient.Client,
 ) error {
-	if len(ctx.MirrorSpecs) == 0 {
+	// The Talos SDK creates IPv4-only networks, so IPv6 and dual-stack clusters
+	// need the network pre-created even without mirrors.
+	enableIPv6 := ctx.ClusterCfg.Spec.Cluster.Network.IPFamily.IPv6()
+	if len(ctx.MirrorSpecs) == 0 && !enableIPv6 {
 		return nil
 	}
 
@@ -102,7 +105,9 @@ func runTalosNetworkAction(
 	networkCIDR := talosconfigmanager.DefaultNetworkCIDR
 	writer := ctx.Cmd.OutOrStdout()
 
-	return EnsureDockerNetworkExists(execCtx, dockerClient, networkName, networkCIDR, writer)
+	return EnsureDockerNetworkExists(
+		execCtx, dockerClient, networkName, networkCIDR, enableIPv6, writer,
+	)
 }
 
 // runTalosConnectAction connects registries to the Docker network with static IPs.
@@ -30,7 +30,7 @@ func runVClusterNetworkAction(
 	networkName := vclusterNetworkPrefix + clusterName
 	writer := ctx.Cmd.OutOrStdout()
 
-	return EnsureDockerNetworkExists(execCtx, dockerClient, networkName, "", writer)
+	return EnsureDockerNetworkExists(execCtx, dockerClient, networkName, "", false, writer)
 }
 
 // resolveVClusterClusterName determines the VCluster cluster name from config.
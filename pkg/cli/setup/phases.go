@@ -7,6 +7,7 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/recommend"
 	"github.com/devantler-tech/ksail/v7/pkg/timer"
 	"github.com/spf13/cobra"
 )
@@ -47,6 +48,48 @@ func (f *InstallerFactories) waitForCSRApprover() func(
 	return waitForKubeletCSRApprover
 }
 
+// resourceLimits returns the function bounding concurrent heavy installs,
+// falling back to the default detectResourceLimits when the factory field is
+// unset.
+func (f *InstallerFactories) resourceLimits() func(context.Context) notify.ResourceLimits {
+	if f != nil && f.ResourceLimits != nil {
+		return f.ResourceLimits
+	}
+
+	return detectResourceLimits
+}
+
+// detectResourceLimits derives the heavy-install limits from the CPUs and
+// memory of the host KSail runs on, so a small CI runner installs fewer
+// components at once instead of timing out on saturated image pulls.
+func detectResourceLimits(ctx context.Context) notify.ResourceLimits {
+	host := recommend.ProbeHost(ctx, nil)
+
+	return notify.ResourceLimitsForHost(host.CPUs, host.MemoryBytes)
+}
+
+// ResourceSlots returns the weighted slots bounding the heavy work of a
+// cluster operation on clusterCfg: the Slots already carried by ctx, or new
+// ones from the factories' resource limits. Only providers that run nodes in
+// the local Docker engine load this host, so other providers (Hetzner, Omni,
+// AWS, Kubernetes) get nil, which bounds nothing.
+func ResourceSlots(
+	ctx context.Context,
+	clusterCfg *v1alpha1.Cluster,
+	factories *InstallerFactories,
+) *notify.Slots {
+	slots := notify.SlotsFromContext(ctx)
+	if slots != nil {
+		return slots
+	}
+
+	if !clusterCfg.Spec.Cluster.Provider.NeedsLocalDocker() {
+		return nil
+	}
+
+	return notify.NewSlots(factories.resourceLimits()(ctx))
+}
+
 // cloudProviderInitInstall returns the load-balancer install function used by
 // the cloud-provider init pre-phase, falling back to the default
 // InstallLoadBalancerSilent when the factory field is unset. The override only
@@ -140,6 +183,7 @@ func InstallPostCNIComponents(
 	reqs := GetComponentRequirements(clusterCfg)
 
 	emitKWOKUnsupportedComponentWarnings(cmd, clusterCfg)
+	emitVeleroUnsupportedProviderWarning(cmd, clusterCfg)
 
 	if reqs.Count() == 0 {
 		return nil
@@ -327,6 +371,7 @@ func runInfraPhase(
 		writer,
 		notify.WithLabels(labels),
 		notify.WithTimer(tmr),
+		notify.WithSlots(ResourceSlots(ctx, clusterCfg, factories)),
 	)
 
 	err := infraGroup.Run(ctx, infraTasks...)
@@ -367,12 +412,18 @@ func runGitOpsPhase(
 		return fmt.Errorf("cluster not stable before GitOps installation: %w", err)
 	}
 
+	err = ensureDeployKey(ctx, clusterCfg, writer)
+	if err != nil {
+		return fmt.Errorf("failed to prepare git deploy key: %w", err)
+	}
+
 	gitopsGroup := notify.NewProgressGroup(
 		"Installing GitOps engines",
 		"📦",
 		writer,
 		notify.WithLabels(labels),
 		notify.WithTimer(tmr),
+		notify.WithSlots(ResourceSlots(ctx, clusterCfg, factories)),
 	)
 
 	err = gitopsGroup.Run(ctx, gitopsTasks...)
@@ -401,7 +452,50 @@ func buildInfrastructureTasks(
 		},
 		{needed: reqs.NeedsCSI, name: "csi", fn: InstallCSISilent},
 		{needed: reqs.NeedsCertManager, name: "cert-manager", fn: InstallCertManagerSilent},
-		{needed: reqs.NeedsPolicyEngine, name: "policy-engine", fn: InstallPolicyEngineSilent},
+		{needed: reqs.NeedsTrustManager, name: "trust-manager", fn: InstallTrustManagerSilent},
+		{
+			needed:   reqs.NeedsPolicyEngine,
+			name:     "policy-engine",
+			fn:       InstallPolicyEngineSilent,
+			cpuHeavy: true,
+		},
+		{
+			needed: reqs.NeedsIngressController,
+			name:   "ingress-controller",
+			fn:     InstallIngressControllerSilent,
+		},
+		{needed: reqs.NeedsGatewayAPI, name: "gateway-api", fn: InstallGatewayAPISilent},
+		{
+			needed:   reqs.NeedsServiceMesh,
+			name:     "service-mesh",
+			fn:       InstallServiceMeshSilent,
+			cpuHeavy: true,
+		},
+		{needed: reqs.NeedsKnative, name: "knative", fn: InstallKnativeSilent, cpuHeavy: true},
+		{
+			needed:   reqs.NeedsIdentityProvider,
+			name:     "identity-provider",
+			fn:       InstallIdentityProviderSilent,
+			cpuHeavy: true,
+		},
+		{needed: reqs.NeedsLogging, name: "logging", fn: InstallLoggingSilent, cpuHeavy: true},
+		{needed: reqs.NeedsSealedSecrets, name: "sealed-secrets", fn: InstallSealedSecretsSilent},
+		{
+			needed: reqs.NeedsExternalSecrets,
+			name:   "external-secrets",
+			fn:     InstallExternalSecretsSilent,
+		},
+		{needed: reqs.NeedsVelero, name: "velero", fn: InstallVeleroSilent},
+		{
+			needed: reqs.NeedsNVIDIADevicePlugin,
+			name:   "nvidia-device-plugin",
+			fn:     InstallNVIDIADevicePluginSilent,
+		},
+		{
+			needed: reqs.NeedsActionsRunner,
+			name:   "actions-runner-controller",
+			fn:     InstallActionsRunnerSilent,
+		},
 		{
 			needed: reqs.NeedsClusterAutoscaler,
 			name:   "cluster-autoscaler",
@@ -418,7 +512,7 @@ func buildGitOpsTasks(
 	reqs ComponentRequirements,
 ) []notify.ProgressTask {
 	return tasksFromEntries(clusterCfg, factories, []componentTask{
-		{needed: reqs.NeedsArgoCD, name: "argocd", fn: InstallArgoCDSilent},
+		{needed: reqs.NeedsArgoCD, name: "argocd", fn: InstallArgoCDSilent, cpuHeavy: true},
 		{needed: reqs.NeedsFlux, name: "flux", fn: InstallFluxSilent},
 	})
 }
@@ -427,10 +521,13 @@ type silentInstallFunc func(ctx context.Context, cfg *v1alpha1.Cluster, f *Insta
 
 // componentTask pairs a "needed" gate with a component name and its install function.
 // It is used to build the install-task lists in buildInfrastructureTasks and buildGitOpsTasks.
+// Every install pulls images and is scheduled as network-heavy; cpuHeavy marks the
+// components whose many controllers load the host's CPUs while they start instead.
 type componentTask struct {
-	needed bool
-	name   string
-	fn     silentInstallFunc
+	needed   bool
+	name     string
+	fn       silentInstallFunc
+	cpuHeavy bool
 }
 
 func newTask(
@@ -440,8 +537,9 @@ func newTask(
 	fn silentInstallFunc,
 ) notify.ProgressTask {
 	return notify.ProgressTask{
-		Name: name,
-		Fn:   func(ctx context.Context) error { return fn(ctx, cfg, factories) },
+		Name:   name,
+		Fn:     func(ctx context.Context) error { return fn(ctx, cfg, factories) },
+		Weight: notify.WeightNetwork,
 	}
 }
 
@@ -456,7 +554,12 @@ func tasksFromEntries(
 
 	for _, e := range entries {
 		if e.needed {
-			tasks = append(tasks, newTask(e.name, cfg, factories, e.fn))
+			task := newTask(e.name, cfg, factories, e.fn)
+			if e.cpuHeavy {
+				task.Weight = notify.WeightCPU
+			}
+
+			tasks = append(tasks, task)
 		}
 	}
 
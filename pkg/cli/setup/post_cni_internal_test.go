@@ -6,6 +6,7 @@ import (
 	"io"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
@@ -672,3 +673,102 @@ func TestInstallComponentsInPhases_HetznerCCMPrePhaseExcludesFromParallelPhase(t
 		"load-balancer installer must run exactly once (pre-phase only, not again in parallel phase)",
 	)
 }
+
+func TestBuildInfrastructureTasks_WeighsHeavyComponents(t *testing.T) {
+	t.Parallel()
+
+	tasks := buildInfrastructureTasks(&v1alpha1.Cluster{}, nil, ComponentRequirements{
+		NeedsMetricsServer: true,
+		NeedsPolicyEngine:  true,
+		NeedsLogging:       true,
+	})
+
+	weights := make(map[string]notify.TaskWeight, len(tasks))
+	for _, task := range tasks {
+		weights[task.Name] = task.Weight
+	}
+
+	assert.Equal(t, map[string]notify.TaskWeight{
+		"metrics-server": notify.WeightNetwork,
+		"policy-engine":  notify.WeightCPU,
+		"logging":        notify.WeightCPU,
+	}, weights)
+}
+
+func TestRunInfraPhase_BoundsHeavyTasksByResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu           sync.Mutex
+		active, peak int
+	)
+
+	heavyTask := func(name string) notify.ProgressTask {
+		return notify.ProgressTask{
+			Name:   name,
+			Weight: notify.WeightCPU,
+			Fn: func(context.Context) error {
+				mu.Lock()
+				active++
+				peak = max(peak, active)
+				mu.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mu.Lock()
+				active--
+				mu.Unlock()
+
+				return nil
+			},
+		}
+	}
+
+	factories := &InstallerFactories{
+		ClusterStabilityCheck: func(context.Context, *v1alpha1.Cluster, bool) error {
+			return nil
+		},
+		ResourceLimits: func(context.Context) notify.ResourceLimits {
+			return notify.ResourceLimits{CPU: 1}
+		},
+	}
+
+	err := runInfraPhase(
+		context.Background(),
+		&v1alpha1.Cluster{},
+		io.Discard,
+		notify.InstallingLabels(),
+		timer.New(),
+		factories,
+		[]notify.ProgressTask{heavyTask("policy-engine"), heavyTask("logging")},
+		false,
+		false,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, peak, "CPU-heavy installs must not overlap with a CPU limit of 1")
+}
+
+func TestResourceSlots(t *testing.T) {
+	t.Parallel()
+
+	factories := &InstallerFactories{
+		ResourceLimits: func(context.Context) notify.ResourceLimits {
+			return notify.ResourceLimits{CPU: 1}
+		},
+	}
+
+	local := &v1alpha1.Cluster{}
+	local.Spec.Cluster.Provider = v1alpha1.ProviderDocker
+
+	remote := &v1alpha1.Cluster{}
+	remote.Spec.Cluster.Provider = v1alpha1.ProviderHetzner
+
+	assert.NotNil(t, ResourceSlots(context.Background(), local, factories))
+	assert.Nil(t, ResourceSlots(context.Background(), remote, factories),
+		"remote nodes do not load this host, so their work is not bounded by it")
+
+	shared := notify.NewSlots(notify.ResourceLimits{Network: 1})
+	ctx := notify.ContextWithSlots(context.Background(), shared)
+
+	assert.Same(t, shared, ResourceSlots(ctx, local, factories), "slots carried by the context are reused")
+}
@@ -25,12 +25,17 @@ func assertComponentRequirements(
 	)
 	assert.Equal(t, expected.NeedsCSI, result.NeedsCSI, "CSI")
 	assert.Equal(t, expected.NeedsCertManager, result.NeedsCertManager, "CertManager")
+	assert.Equal(t, expected.NeedsTrustManager, result.NeedsTrustManager, "TrustManager")
 	assert.Equal(t, expected.NeedsPolicyEngine, result.NeedsPolicyEngine, "PolicyEngine")
 	assert.Equal(
 		t, expected.NeedsClusterAutoscaler, result.NeedsClusterAutoscaler, "ClusterAutoscaler",
 	)
 	assert.Equal(t, expected.NeedsArgoCD, result.NeedsArgoCD, "ArgoCD")
 	assert.Equal(t, expected.NeedsFlux, result.NeedsFlux, "Flux")
+	assert.Equal(t, expected.NeedsVelero, result.NeedsVelero, "Velero")
+	assert.Equal(t, expected.NeedsServiceMesh, result.NeedsServiceMesh, "ServiceMesh")
+	assert.Equal(t, expected.NeedsKnative, result.NeedsKnative, "Knative")
+	assert.Equal(t, expected.NeedsIdentityProvider, result.NeedsIdentityProvider, "IdentityProvider")
 }
 
 //nolint:funlen,maintidx // Table-driven test with comprehensive test cases
@@ -397,6 +402,186 @@ func TestGetComponentRequirements(t *testing.T) {
 			expectedCount: 0, // cert-manager webhook pod is simulated; admission calls always time out on KWOK
 			expected:      setup.ComponentRequirements{},
 		},
+		{
+			name: "trust CA bundles set NeedsTrustManager",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionVanilla,
+						Provider:     v1alpha1.ProviderDocker,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+					Trust: v1alpha1.TrustSpec{CABundles: []string{"proxy-ca.pem"}},
+				},
+			},
+			expectedCount: 1,
+			expected:      setup.ComponentRequirements{NeedsTrustManager: true},
+		},
+		{
+			name: "KWOK with trust CA bundles sets NeedsTrustManager to false",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionKWOK,
+					},
+					Trust: v1alpha1.TrustSpec{CABundles: []string{"proxy-ca.pem"}},
+				},
+			},
+			expectedCount: 0, // trust-manager pod is simulated and never publishes the bundle on KWOK
+			expected:      setup.ComponentRequirements{},
+		},
+		{
+			name: "Vanilla × Docker with Velero backup sets NeedsVelero",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionVanilla,
+						Provider:     v1alpha1.ProviderDocker,
+						Backup:       v1alpha1.BackupVelero,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 1,
+			expected:      setup.ComponentRequirements{NeedsVelero: true},
+		},
+		{
+			name: "Vanilla × Docker with GPU nodes sets NeedsNVIDIADevicePlugin",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionVanilla,
+						Provider:     v1alpha1.ProviderDocker,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+						Nodes: []v1alpha1.NodeSpec{
+							{Role: v1alpha1.NodeRoleWorker, GPUs: v1alpha1.GPUsAll},
+						},
+					},
+				},
+			},
+			expectedCount: 1,
+			expected:      setup.ComponentRequirements{NeedsNVIDIADevicePlugin: true},
+		},
+		{
+			name: "Vanilla × Docker with actions runner sets NeedsActionsRunner",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution:  v1alpha1.DistributionVanilla,
+						Provider:      v1alpha1.ProviderDocker,
+						PolicyEngine:  v1alpha1.PolicyEngineNone,
+						ActionsRunner: v1alpha1.ActionsRunnerSpec{Enabled: true},
+					},
+				},
+			},
+			expectedCount: 1,
+			expected:      setup.ComponentRequirements{NeedsActionsRunner: true},
+		},
+		{
+			name: "Talos × Hetzner with Velero backup sets NeedsVelero to false",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionTalos,
+						Provider:     v1alpha1.ProviderHetzner,
+						Backup:       v1alpha1.BackupVelero,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 0, // the MinIO backup target only runs on the local Docker network
+			expected:      setup.ComponentRequirements{},
+		},
+		{
+			name: "KWOK with Velero backup sets NeedsVelero to false",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionKWOK,
+						Provider:     v1alpha1.ProviderDocker,
+						Backup:       v1alpha1.BackupVelero,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 0, // the Velero server pod is simulated on KWOK
+			expected:      setup.ComponentRequirements{},
+		},
+		{
+			name: "Vanilla × Docker with Istio sets NeedsServiceMesh",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionVanilla,
+						Provider:     v1alpha1.ProviderDocker,
+						ServiceMesh:  v1alpha1.ServiceMeshIstio,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 1,
+			expected:      setup.ComponentRequirements{NeedsServiceMesh: true},
+		},
+		{
+			name: "KWOK with Linkerd sets NeedsServiceMesh to false",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionKWOK,
+						Provider:     v1alpha1.ProviderDocker,
+						ServiceMesh:  v1alpha1.ServiceMeshLinkerd,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 0, // simulated pods have no network dataplane to proxy
+			expected:      setup.ComponentRequirements{},
+		},
+		{
+			name: "Vanilla × Docker with Knative sets NeedsKnative",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionK3s,
+						Provider:     v1alpha1.ProviderDocker,
+						Knative:      v1alpha1.KnativeEnabled,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 1,
+			expected:      setup.ComponentRequirements{NeedsKnative: true},
+		},
+		{
+			name: "KWOK with Knative sets NeedsKnative to false",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution: v1alpha1.DistributionKWOK,
+						Provider:     v1alpha1.ProviderDocker,
+						Knative:      v1alpha1.KnativeEnabled,
+						PolicyEngine: v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 0, // revisions would never serve traffic on KWOK
+			expected:      setup.ComponentRequirements{},
+		},
+		{
+			name: "K3s × Docker with Keycloak sets NeedsIdentityProvider",
+			clusterCfg: &v1alpha1.Cluster{
+				Spec: v1alpha1.Spec{
+					Cluster: v1alpha1.ClusterSpec{
+						Distribution:     v1alpha1.DistributionK3s,
+						Provider:         v1alpha1.ProviderDocker,
+						IdentityProvider: v1alpha1.IdentityProviderKeycloak,
+						PolicyEngine:     v1alpha1.PolicyEngineNone,
+					},
+				},
+			},
+			expectedCount: 1,
+			expected:      setup.ComponentRequirements{NeedsIdentityProvider: true},
+		},
 	}
 
 	for _, testCase := range tests {
@@ -31,6 +31,71 @@ const (
 	// never runs real TLS logic; calls to the admission webhook always time out.
 	kwokCertManagerWarning = "cert-manager is not installed on KWOK: " +
 		"webhook pod is simulated and admission webhook calls always time out — skipping"
+
+	// kwokTrustManagerWarning is emitted when spec.trust.caBundles is configured
+	// but trust-manager cannot be installed on KWOK. The controller pod is
+	// simulated, so the CA bundle ConfigMaps would never be published.
+	kwokTrustManagerWarning = "trust-manager is not installed on KWOK: " +
+		"controller pod is simulated and never publishes the CA bundle — skipping"
+
+	// kwokIngressControllerWarning is emitted when an ingress controller is
+	// configured but cannot be installed on KWOK. The controller pods are
+	// simulated and never serve traffic, so there is nothing to route to.
+	kwokIngressControllerWarning = "ingress controller %q is not installed on KWOK: " +
+		"controller pods are simulated and never serve traffic — skipping"
+
+	// kwokServiceMeshWarning is emitted when a service mesh is configured but
+	// cannot be installed on KWOK. Simulated pods have no network dataplane to
+	// proxy, and injected sidecars would never start.
+	kwokServiceMeshWarning = "service mesh %q is not installed on KWOK: " +
+		"pods are simulated and have no network dataplane — skipping"
+
+	// kwokKnativeWarning is emitted when Knative Serving is configured but
+	// cannot be installed on KWOK. Revisions would never start, and the Kourier
+	// gateway pods are simulated and never serve traffic.
+	kwokKnativeWarning = "Knative Serving is not installed on KWOK: " +
+		"pods are simulated and revisions never serve traffic — skipping"
+
+	// kwokGatewayAPIWarning is emitted when a Gateway API option is configured
+	// but cannot be installed on KWOK, for the same reason as ingress controllers.
+	kwokGatewayAPIWarning = "gateway API %q is not installed on KWOK: " +
+		"controller pods are simulated and never serve traffic — skipping"
+
+	// kwokLoggingWarning is emitted when a logging stack is configured but
+	// cannot be installed on KWOK. No container runs, so there are no logs to
+	// collect and the Promtail DaemonSet would never tail anything.
+	kwokLoggingWarning = "logging stack %q is not installed on KWOK: " +
+		"containers are simulated and produce no logs — skipping"
+
+	// kwokSealedSecretsWarning is emitted when the Sealed Secrets controller is
+	// configured but cannot be installed on KWOK. The controller pod is
+	// simulated, so SealedSecrets would never be decrypted into Secrets.
+	kwokSealedSecretsWarning = "Sealed Secrets is not installed on KWOK: " +
+		"controller pod is simulated and never decrypts SealedSecrets — skipping"
+
+	// kwokExternalSecretsWarning is emitted when the External Secrets Operator
+	// is configured but cannot be installed on KWOK. The operator pod is
+	// simulated, so ExternalSecrets would never be synced into Secrets.
+	kwokExternalSecretsWarning = "External Secrets Operator is not installed on KWOK: " +
+		"operator pod is simulated and never syncs ExternalSecrets — skipping"
+
+	// kwokVeleroWarning is emitted when Velero is configured but cannot be
+	// installed on KWOK. The Velero server pod is simulated and never takes or
+	// restores backups.
+	kwokVeleroWarning = "Velero is not installed on KWOK: " +
+		"server pod is simulated and never takes backups — skipping"
+
+	// kwokActionsRunnerWarning is emitted when the actions runner is configured
+	// but cannot be installed on KWOK. Runner pods are simulated, so jobs routed
+	// to the scale set would never run.
+	kwokActionsRunnerWarning = "actions runner is not installed on KWOK: " +
+		"runner pods are simulated and never run jobs — skipping"
+
+	// veleroProviderWarning is emitted when Velero is configured for a cluster
+	// that is not on the Docker provider. Its MinIO backup target runs as a
+	// container on the cluster's local Docker network.
+	veleroProviderWarning = "Velero is not installed on provider %q: " +
+		"its MinIO backup target runs on the local Docker network — skipping"
 )
 
 // ComponentRequirements represents which components need to be installed.
@@ -40,7 +105,19 @@ type ComponentRequirements struct {
 	NeedsKubeletCSRApprover bool
 	NeedsCSI                bool
 	NeedsCertManager        bool
+	NeedsTrustManager       bool
 	NeedsPolicyEngine       bool
+	NeedsIngressController  bool
+	NeedsGatewayAPI         bool
+	NeedsServiceMesh        bool
+	NeedsKnative            bool
+	NeedsIdentityProvider   bool
+	NeedsLogging            bool
+	NeedsSealedSecrets      bool
+	NeedsExternalSecrets    bool
+	NeedsVelero             bool
+	NeedsNVIDIADevicePlugin bool
+	NeedsActionsRunner      bool
 	NeedsClusterAutoscaler  bool
 	NeedsArgoCD             bool
 	NeedsFlux               bool
@@ -54,7 +131,19 @@ func (r ComponentRequirements) Count() int {
 		r.NeedsKubeletCSRApprover,
 		r.NeedsCSI,
 		r.NeedsCertManager,
+		r.NeedsTrustManager,
 		r.NeedsPolicyEngine,
+		r.NeedsIngressController,
+		r.NeedsGatewayAPI,
+		r.NeedsServiceMesh,
+		r.NeedsKnative,
+		r.NeedsIdentityProvider,
+		r.NeedsLogging,
+		r.NeedsSealedSecrets,
+		r.NeedsExternalSecrets,
+		r.NeedsVelero,
+		r.NeedsNVIDIADevicePlugin,
+		r.NeedsActionsRunner,
 		r.NeedsClusterAutoscaler,
 		r.NeedsArgoCD,
 		r.NeedsFlux,
@@ -110,13 +199,64 @@ func GetComponentRequirements(clusterCfg *v1alpha1.Cluster) ComponentRequirement
 	needsCertManager := clusterCfg.Spec.Cluster.CertManager == v1alpha1.CertManagerEnabled &&
 		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
 
+	// KWOK runs no controller logic, so the CA bundle would never be published.
+	needsTrustManager := clusterCfg.Spec.Trust.Enabled() &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// KWOK simulates pod status only; an ingress controller would never serve
+	// traffic. Skip it for KWOK entirely.
+	needsIngressController := clusterCfg.Spec.Cluster.IngressController.Enabled() &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// Cilium's gateway controller ships with the CNI, so only the CRDs and
+	// Envoy options need their own install step.
+	needsGatewayAPI := clusterCfg.Spec.Cluster.GatewayAPI.NeedsInstall() &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// KWOK has no network dataplane for a mesh to proxy.
+	needsServiceMesh := clusterCfg.Spec.Cluster.ServiceMesh.Enabled() &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// KWOK runs no containers, so Knative revisions would never serve traffic.
+	needsKnative := clusterCfg.Spec.Cluster.Knative == v1alpha1.KnativeEnabled &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// KWOK runs no containers, so there are no logs to aggregate.
+	needsLogging := clusterCfg.Spec.Cluster.Observability.Logging.Enabled() &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// KWOK runs no controller logic, so SealedSecrets would never be unsealed.
+	needsSealedSecrets := clusterCfg.Spec.Cluster.SealedSecrets == v1alpha1.SealedSecretsEnabled &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// KWOK runs no controller logic, so ExternalSecrets would never be synced.
+	needsExternalSecrets := clusterCfg.Spec.Cluster.ExternalSecrets ==
+		v1alpha1.ExternalSecretsEnabled &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
+	// KWOK runs no containers, so runner pods would never pick up jobs.
+	needsActionsRunner := clusterCfg.Spec.Cluster.ActionsRunner.Enabled &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+
 	return ComponentRequirements{
 		NeedsMetricsServer:      needsMetricsServer,
 		NeedsLoadBalancer:       NeedsLoadBalancerInstall(clusterCfg),
 		NeedsKubeletCSRApprover: needsKubeletCSRApprover,
 		NeedsCSI:                needsCSI,
 		NeedsCertManager:        needsCertManager,
+		NeedsTrustManager:       needsTrustManager,
 		NeedsPolicyEngine:       needsPolicyEngine,
+		NeedsIngressController:  needsIngressController,
+		NeedsGatewayAPI:         needsGatewayAPI,
+		NeedsServiceMesh:        needsServiceMesh,
+		NeedsKnative:            needsKnative,
+		NeedsIdentityProvider:   clusterCfg.Spec.Cluster.IdentityProvider == v1alpha1.IdentityProviderKeycloak,
+		NeedsLogging:            needsLogging,
+		NeedsSealedSecrets:      needsSealedSecrets,
+		NeedsExternalSecrets:    needsExternalSecrets,
+		NeedsVelero:             needsVeleroInstall(clusterCfg),
+		NeedsNVIDIADevicePlugin: clusterCfg.Spec.Cluster.RequestsGPUs(),
+		NeedsActionsRunner:      needsActionsRunner,
 		NeedsClusterAutoscaler:  NeedsClusterAutoscalerInstall(clusterCfg),
 		NeedsArgoCD:             clusterCfg.Spec.Cluster.GitOpsEngine == v1alpha1.GitOpsEngineArgoCD,
 		NeedsFlux:               needsFlux,
@@ -152,6 +292,27 @@ func needsCSIInstall(clusterCfg *v1alpha1.Cluster) bool {
 	return !dist.ProvidesCSIByDefault(provider)
 }
 
+// needsVeleroInstall determines if Velero needs to be installed. Its MinIO
+// backup target joins the cluster's Docker network, so Velero is only
+// installed on the Docker provider; KWOK is skipped because its simulated
+// server pod never takes backups.
+func needsVeleroInstall(clusterCfg *v1alpha1.Cluster) bool {
+	return clusterCfg.Spec.Cluster.Backup == v1alpha1.BackupVelero &&
+		clusterCfg.Spec.Cluster.Provider == v1alpha1.ProviderDocker &&
+		clusterCfg.Spec.Cluster.Distribution != v1alpha1.DistributionKWOK
+}
+
+// emitVeleroUnsupportedProviderWarning warns when Velero is configured for a
+// provider its local MinIO backup target cannot run on.
+func emitVeleroUnsupportedProviderWarning(cmd *cobra.Command, clusterCfg *v1alpha1.Cluster) {
+	if clusterCfg.Spec.Cluster.Backup != v1alpha1.BackupVelero ||
+		clusterCfg.Spec.Cluster.Provider == v1alpha1.ProviderDocker {
+		return
+	}
+
+	notify.Warningf(cmd.OutOrStdout(), veleroProviderWarning, clusterCfg.Spec.Cluster.Provider)
+}
+
 // emitKWOKUnsupportedComponentWarnings emits user-visible warnings for components
 // that are configured but cannot be installed on KWOK (simulated pods never run real
 // controller logic). Called at the start of InstallPostCNIComponents to notify the
@@ -167,6 +328,34 @@ func emitKWOKUnsupportedComponentWarnings(cmd *cobra.Command, clusterCfg *v1alph
 		)
 	}
 
+	if clusterCfg.Spec.Cluster.IngressController.Enabled() {
+		notify.Warningf(cmd.OutOrStdout(), kwokIngressControllerWarning,
+			clusterCfg.Spec.Cluster.IngressController,
+		)
+	}
+
+	if clusterCfg.Spec.Cluster.GatewayAPI.NeedsInstall() {
+		notify.Warningf(cmd.OutOrStdout(), kwokGatewayAPIWarning,
+			clusterCfg.Spec.Cluster.GatewayAPI,
+		)
+	}
+
+	if clusterCfg.Spec.Cluster.ServiceMesh.Enabled() {
+		notify.Warningf(cmd.OutOrStdout(), kwokServiceMeshWarning,
+			clusterCfg.Spec.Cluster.ServiceMesh,
+		)
+	}
+
+	if clusterCfg.Spec.Cluster.Knative == v1alpha1.KnativeEnabled {
+		notify.Warningf(cmd.OutOrStdout(), kwokKnativeWarning)
+	}
+
+	if clusterCfg.Spec.Cluster.Observability.Logging.Enabled() {
+		notify.Warningf(cmd.OutOrStdout(), kwokLoggingWarning,
+			clusterCfg.Spec.Cluster.Observability.Logging,
+		)
+	}
+
 	if clusterCfg.Spec.Cluster.GitOpsEngine == v1alpha1.GitOpsEngineFlux {
 		notify.Warningf(cmd.OutOrStdout(), kwokFluxWarning)
 	}
@@ -178,6 +367,26 @@ func emitKWOKUnsupportedComponentWarnings(cmd *cobra.Command, clusterCfg *v1alph
 	if clusterCfg.Spec.Cluster.CertManager == v1alpha1.CertManagerEnabled {
 		notify.Warningf(cmd.OutOrStdout(), kwokCertManagerWarning)
 	}
+
+	if clusterCfg.Spec.Trust.Enabled() {
+		notify.Warningf(cmd.OutOrStdout(), kwokTrustManagerWarning)
+	}
+
+	if clusterCfg.Spec.Cluster.SealedSecrets == v1alpha1.SealedSecretsEnabled {
+		notify.Warningf(cmd.OutOrStdout(), kwokSealedSecretsWarning)
+	}
+
+	if clusterCfg.Spec.Cluster.ExternalSecrets == v1alpha1.ExternalSecretsEnabled {
+		notify.Warningf(cmd.OutOrStdout(), kwokExternalSecretsWarning)
+	}
+
+	if clusterCfg.Spec.Cluster.Backup == v1alpha1.BackupVelero {
+		notify.Warningf(cmd.OutOrStdout(), kwokVeleroWarning)
+	}
+
+	if clusterCfg.Spec.Cluster.ActionsRunner.Enabled {
+		notify.Warningf(cmd.OutOrStdout(), kwokActionsRunnerWarning)
+	}
 }
 
 // needsCloudProviderInitPhase returns true when the cluster uses an external
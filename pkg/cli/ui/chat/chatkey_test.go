@@ -218,7 +218,8 @@ func TestHandleToggleMode_CyclesThroughModes(t *testing.T) {
 	// Tab cycles: Interactive -> Plan (but may fail due to session RPC)
 	// We test the mode cycling logic through the exported ChatMode type
 	assert.Equal(t, chat.PlanMode, chat.InteractiveMode.Next())
-	assert.Equal(t, chat.AutopilotMode, chat.PlanMode.Next())
+	assert.Equal(t, chat.SandboxMode, chat.PlanMode.Next())
+	assert.Equal(t, chat.AutopilotMode, chat.SandboxMode.Next())
 	assert.Equal(t, chat.InteractiveMode, chat.AutopilotMode.Next())
 }
 
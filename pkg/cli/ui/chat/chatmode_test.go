@@ -19,6 +19,7 @@ func TestChatMode_String(t *testing.T) {
 		{name: "interactive mode string", mode: chat.InteractiveMode, expected: "interactive"},
 		{name: "plan mode string", mode: chat.PlanMode, expected: "plan"},
 		{name: "autopilot mode string", mode: chat.AutopilotMode, expected: "autopilot"},
+		{name: "sandbox mode string", mode: chat.SandboxMode, expected: "sandbox"},
 		{
 			name:     "unknown mode defaults to interactive",
 			mode:     chat.ChatMode(99),
@@ -54,6 +55,7 @@ func TestChatMode_Icon(t *testing.T) {
 		{name: "interactive mode icon", mode: chat.InteractiveMode, expected: "</>"},
 		{name: "plan mode icon", mode: chat.PlanMode, expected: "≡"},
 		{name: "autopilot mode icon", mode: chat.AutopilotMode, expected: "⚡"},
+		{name: "sandbox mode icon", mode: chat.SandboxMode, expected: "⚗"},
 		{
 			name:     "unknown mode defaults to interactive icon",
 			mode:     chat.ChatMode(99),
@@ -84,6 +86,7 @@ func TestChatMode_Label(t *testing.T) {
 		{name: "interactive mode label", mode: chat.InteractiveMode, expected: "</> interactive"},
 		{name: "plan mode label", mode: chat.PlanMode, expected: "≡ plan"},
 		{name: "autopilot mode label", mode: chat.AutopilotMode, expected: "⚡ autopilot"},
+		{name: "sandbox mode label", mode: chat.SandboxMode, expected: "⚗ sandbox"},
 	}
 
 	for _, testCase := range tests {
@@ -112,7 +115,8 @@ func TestChatMode_Next(t *testing.T) {
 		expected chat.ChatMode
 	}{
 		{name: "interactive cycles to plan", mode: chat.InteractiveMode, expected: chat.PlanMode},
-		{name: "plan cycles to autopilot", mode: chat.PlanMode, expected: chat.AutopilotMode},
+		{name: "plan cycles to sandbox", mode: chat.PlanMode, expected: chat.SandboxMode},
+		{name: "sandbox cycles to autopilot", mode: chat.SandboxMode, expected: chat.AutopilotMode},
 		{
 			name:     "autopilot cycles to interactive",
 			mode:     chat.AutopilotMode,
@@ -136,7 +140,7 @@ func TestChatMode_Next(t *testing.T) {
 	}
 }
 
-// TestChatMode_NextFullCycle verifies a complete cycle through all three modes.
+// TestChatMode_NextFullCycle verifies a complete cycle through all four modes.
 func TestChatMode_NextFullCycle(t *testing.T) {
 	t.Parallel()
 
@@ -147,14 +151,19 @@ func TestChatMode_NextFullCycle(t *testing.T) {
 		t.Fatalf("Expected PlanMode after first Next(), got %v", mode)
 	}
 
+	mode = mode.Next()
+	if mode != chat.SandboxMode {
+		t.Fatalf("Expected SandboxMode after second Next(), got %v", mode)
+	}
+
 	mode = mode.Next()
 	if mode != chat.AutopilotMode {
-		t.Fatalf("Expected AutopilotMode after second Next(), got %v", mode)
+		t.Fatalf("Expected AutopilotMode after third Next(), got %v", mode)
 	}
 
 	mode = mode.Next()
 	if mode != chat.InteractiveMode {
-		t.Fatalf("Expected InteractiveMode after third Next(), got %v", mode)
+		t.Fatalf("Expected InteractiveMode after fourth Next(), got %v", mode)
 	}
 }
 
@@ -178,6 +187,11 @@ func TestChatMode_ToSDKMode(t *testing.T) {
 			mode:     chat.AutopilotMode,
 			expected: rpc.SessionModeAutopilot,
 		},
+		{
+			name:     "sandbox maps to interactive",
+			mode:     chat.SandboxMode,
+			expected: rpc.SessionModeInteractive,
+		},
 		{
 			name:     "unknown defaults to interactive",
 			mode:     chat.ChatMode(99),
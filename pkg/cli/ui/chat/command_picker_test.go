@@ -309,7 +309,7 @@ func TestOptionPicker_ShowsOnCommandWithSpace(t *testing.T) {
 	assert.False(t, chat.ExportShowCommandPicker(model))
 	assert.True(t, chat.ExportShowOptionPicker(model))
 	assert.Equal(t, "mode", chat.ExportActiveCommandName(model))
-	assert.Len(t, chat.ExportFilteredOptions(model), 3) // interactive, plan, autopilot
+	assert.Len(t, chat.ExportFilteredOptions(model), 4) // interactive, plan, sandbox, autopilot
 }
 
 func TestOptionPicker_FiltersOnPartialArg(t *testing.T) {
@@ -386,6 +386,6 @@ func TestPickerExtraHeight_OptionPicker(t *testing.T) {
 	chat.ExportSetTextareaValue(model, "/mode ")
 	chat.ExportUpdateCommandPicker(model)
 
-	// 3 options + 2 for border
-	assert.Equal(t, 5, chat.ExportPickerExtraHeight(model))
+	// 4 options + 2 for border
+	assert.Equal(t, 6, chat.ExportPickerExtraHeight(model))
 }
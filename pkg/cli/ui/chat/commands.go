@@ -12,7 +12,7 @@ import (
 
 // Sentinel errors for slash command validation.
 var (
-	errModeUsage   = errors.New("usage: /mode <interactive|plan|autopilot>")
+	errModeUsage   = errors.New("usage: /mode <interactive|plan|sandbox|autopilot>")
 	errUnknownMode = errors.New("invalid mode")
 )
 
@@ -73,6 +73,8 @@ func ParseChatMode(name string) (ChatMode, bool) {
 		return PlanMode, true
 	case modeNameAutopilot:
 		return AutopilotMode, true
+	case modeNameSandbox:
+		return SandboxMode, true
 	default:
 		return InteractiveMode, false
 	}
@@ -84,7 +86,7 @@ func BuildTUISlashCommands(eventChan chan<- tea.Msg) []copilot.CommandDefinition
 	return []copilot.CommandDefinition{
 		{
 			Name:        cmdMode,
-			Description: "Switch chat mode (interactive, plan, autopilot)",
+			Description: "Switch chat mode (interactive, plan, sandbox, autopilot)",
 			Handler: func(ctx copilot.CommandContext) error {
 				return handleModeCommand(ctx.Args, eventChan)
 			},
@@ -147,7 +149,7 @@ func BuildNonTUISlashCommands(writer io.Writer) []copilot.CommandDefinition {
 				_, _ = fmt.Fprintln(writer, "  /help              Show this help")
 				_, _ = fmt.Fprintln(
 					writer,
-					"  /mode <mode>       Switch mode (interactive, plan, autopilot)",
+					"  /mode <mode>       Switch mode (interactive, plan, sandbox, autopilot)",
 				)
 				_, _ = fmt.Fprintln(writer, "  exit, quit, q      Exit the chat")
 				_, _ = fmt.Fprintln(writer, "")
@@ -157,11 +159,11 @@ func BuildNonTUISlashCommands(writer io.Writer) []copilot.CommandDefinition {
 		},
 		{
 			Name:        cmdMode,
-			Description: "Switch chat mode (interactive, plan, autopilot)",
+			Description: "Switch chat mode (interactive, plan, sandbox, autopilot)",
 			Handler: func(ctx copilot.CommandContext) error {
 				args := strings.TrimSpace(ctx.Args)
 				if args == "" {
-					_, _ = fmt.Fprintln(writer, "Usage: /mode <interactive|plan|autopilot>")
+					_, _ = fmt.Fprintln(writer, "Usage: /mode <interactive|plan|sandbox|autopilot>")
 
 					return nil
 				}
@@ -170,7 +172,7 @@ func BuildNonTUISlashCommands(writer io.Writer) []copilot.CommandDefinition {
 				if !ok {
 					_, _ = fmt.Fprintf(
 						writer,
-						"Invalid mode %q: use interactive, plan, or autopilot\n",
+						"Invalid mode %q: use interactive, plan, sandbox, or autopilot\n",
 						args,
 					)
 
@@ -194,7 +196,7 @@ func handleModeCommand(args string, eventChan chan<- tea.Msg) error {
 
 	mode, ok := ParseChatMode(args)
 	if !ok {
-		return fmt.Errorf("%w %q: use interactive, plan, or autopilot", errUnknownMode, args)
+		return fmt.Errorf("%w %q: use interactive, plan, sandbox, or autopilot", errUnknownMode, args)
 	}
 
 	eventChan <- modeChangeRequestMsg{Mode: mode}
@@ -224,6 +226,7 @@ func BuildTUICommandOptions() map[string]CommandOptionProvider {
 			return []CommandOption{
 				{Name: "interactive", Description: "Confirm each action"},
 				{Name: "plan", Description: "Create plans before acting"},
+				{Name: "sandbox", Description: "Dry-run changes before applying"},
 				{Name: "autopilot", Description: "Act autonomously"},
 			}
 		},
@@ -24,6 +24,7 @@ func TestParseChatMode_ValidModes(t *testing.T) {
 		{"interactive", chat.InteractiveMode},
 		{"plan", chat.PlanMode},
 		{"autopilot", chat.AutopilotMode},
+		{"sandbox", chat.SandboxMode},
 		{"INTERACTIVE", chat.InteractiveMode},
 		{"Plan", chat.PlanMode},
 		{"AUTOPILOT", chat.AutopilotMode},
@@ -359,7 +359,7 @@ func (m *Model) handleNewChat() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleToggleMode cycles through chat modes: Interactive -> Plan -> Autopilot -> Interactive.
+// handleToggleMode cycles through chat modes: Interactive -> Plan -> Sandbox -> Autopilot -> Interactive.
 func (m *Model) handleToggleMode() (tea.Model, tea.Cmd) {
 	// Prevent mode toggling while streaming to avoid mode mismatch between
 	// message submission and tool execution time
@@ -40,6 +40,12 @@ const (
 		`Do not execute tools or make changes - only describe what you would do:
 
 
+`
+	sandboxModePrefix = `[SANDBOX MODE] KSail write tools run as dry runs and change nothing; ` +
+		`write tools without a dry-run mode are not executed. Try the changes, then summarize ` +
+		`what would change and whether the dry runs succeeded so the user can approve a real apply:
+
+
 `
 )
 
@@ -51,6 +57,7 @@ const (
 	modeNameInteractive = "interactive"
 	modeNamePlan        = "plan"
 	modeNameAutopilot   = "autopilot"
+	modeNameSandbox     = "sandbox"
 )
 
 const (
@@ -60,6 +67,9 @@ const (
 	PlanMode
 	// AutopilotMode allows end-to-end execution without interruption (auto-approves all operations).
 	AutopilotMode
+	// SandboxMode runs KSail write tools as dry runs (server-side for kubectl-backed
+	// commands) so the model can try changes without mutating anything.
+	SandboxMode
 )
 
 // String returns a human-readable label for the chat mode.
@@ -71,6 +81,8 @@ func (m ChatMode) String() string {
 		return modeNamePlan
 	case AutopilotMode:
 		return modeNameAutopilot
+	case SandboxMode:
+		return modeNameSandbox
 	default:
 		return modeNameInteractive
 	}
@@ -85,6 +97,8 @@ func (m ChatMode) Icon() string {
 		return "\u2261" // ≡
 	case AutopilotMode:
 		return "\u26a1" // ⚡
+	case SandboxMode:
+		return "\u2697" // ⚗
 	default:
 		return "</>"
 	}
@@ -95,12 +109,14 @@ func (m ChatMode) Label() string {
 	return m.Icon() + " " + m.String()
 }
 
-// Next cycles to the next chat mode: Interactive -> Plan -> Autopilot -> Interactive.
+// Next cycles to the next chat mode: Interactive -> Plan -> Sandbox -> Autopilot -> Interactive.
 func (m ChatMode) Next() ChatMode {
 	switch m {
 	case InteractiveMode:
 		return PlanMode
 	case PlanMode:
+		return SandboxMode
+	case SandboxMode:
 		return AutopilotMode
 	case AutopilotMode:
 		return InteractiveMode
@@ -110,9 +126,11 @@ func (m ChatMode) Next() ChatMode {
 }
 
 // ToSDKMode maps the KSail chat mode to the corresponding Copilot SDK RPC mode.
+// Sandbox mode runs tools like interactive mode; KSail's tool wrapper turns
+// write tools into dry runs.
 func (m ChatMode) ToSDKMode() rpc.SessionMode {
 	switch m {
-	case InteractiveMode:
+	case InteractiveMode, SandboxMode:
 		return rpc.SessionModeInteractive
 	case PlanMode:
 		return rpc.SessionModePlan
@@ -202,7 +220,7 @@ type message struct {
 // after queuing don't affect the prompt's execution.
 type pendingPrompt struct {
 	content         string   // the prompt text
-	chatMode        ChatMode // chat mode when queued (interactive/plan/autopilot)
+	chatMode        ChatMode // chat mode when queued (interactive/plan/sandbox/autopilot)
 	model           string   // model ID when queued
 	reasoningEffort string   // reasoning effort when queued (if applicable)
 	seq             uint64   // insertion sequence number for global ordering
@@ -318,7 +336,8 @@ type Model struct {
 	// Markdown renderer (cached to avoid terminal queries)
 	renderer *glamour.TermRenderer
 
-	// Mode selection (interactive executes tools with prompts, plan describes only, autopilot auto-approves)
+	// Mode selection (interactive executes tools with prompts, plan describes only,
+	// sandbox dry-runs write tools, autopilot auto-approves)
 	chatMode    ChatMode     // current chat mode
 	chatModeRef *ChatModeRef // shared reference for tool handlers to check current mode
 
@@ -871,6 +890,8 @@ func (m *Model) streamResponseCmd(userMessage string) tea.Cmd {
 		switch chatMode {
 		case PlanMode:
 			prompt = planModePrefix + userMessage
+		case SandboxMode:
+			prompt = sandboxModePrefix + userMessage
 		case InteractiveMode, AutopilotMode:
 			// No prefix needed
 		}
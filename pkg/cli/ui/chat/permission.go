@@ -289,7 +289,8 @@ func (d *permissionDeduplicator) markApproved(toolCallID string) {
 // It sends permission requests to the provided event channel and waits for a response.
 // This allows the TUI to display permission prompts and collect user input.
 // Read and URL operations are auto-approved to avoid excessive prompting.
-// When chatModeRef is provided and Autopilot mode is enabled, permissions are auto-approved.
+// When chatModeRef is provided and Autopilot mode is enabled, permissions are auto-approved;
+// in Sandbox mode, KSail tool requests are auto-approved because they only dry-run.
 func CreateTUIPermissionHandler(
 	eventChan chan<- tea.Msg,
 	chatModeRef *ChatModeRef,
@@ -305,6 +306,12 @@ func CreateTUIPermissionHandler(
 			return &rpc.PermissionDecisionApproveOnce{}, nil
 		}
 
+		// In Sandbox mode KSail tools run as dry runs, so only SDK-native
+		// operations (shell commands, file writes, ...) still need approval.
+		if chatModeRef != nil && chatModeRef.Mode() == SandboxMode && isCustomToolRequest(request) {
+			return &rpc.PermissionDecisionApproveOnce{}, nil
+		}
+
 		// Auto-approve read operations to avoid excessive prompting.
 		if chatsvc.IsReadOperation(request.Kind()) {
 			return &rpc.PermissionDecisionApproveOnce{}, nil
@@ -346,6 +353,13 @@ func CreateTUIPermissionHandler(
 	}
 }
 
+// isCustomToolRequest reports whether the request is for a KSail (custom) tool.
+func isCustomToolRequest(request copilot.PermissionRequest) bool {
+	_, ok := request.(*copilot.PermissionRequestCustomTool)
+
+	return ok
+}
+
 // rejectDecision builds a rejection decision, attaching the optional feedback reason when
 // non-empty (nil otherwise).
 func rejectDecision(feedback string) rpc.PermissionDecision {
@@ -274,6 +274,67 @@ func TestPermissionKey_DenyWithEsc(t *testing.T) {
 	}
 }
 
+// TestPermissionHandler_SandboxApprovesOnlyKSailTools tests that Sandbox mode
+// auto-approves KSail tools (which only dry-run) but still prompts for shell commands.
+func TestPermissionHandler_SandboxApprovesOnlyKSailTools(t *testing.T) {
+	t.Parallel()
+
+	chatModeRef := chat.NewChatModeRef(chat.SandboxMode)
+	eventChan := make(chan tea.Msg, 10)
+
+	handler := chat.CreateTUIPermissionHandler(eventChan, chatModeRef)
+
+	result, err := handler(
+		&copilot.PermissionRequestCustomTool{ToolName: "workload_write"},
+		copilot.PermissionInvocation{},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.Kind() != rpc.PermissionDecisionKindApproveOnce {
+		t.Errorf("expected 'approve-once' for a KSail tool in Sandbox mode, got %q", result.Kind())
+	}
+
+	resultChan := make(chan rpc.PermissionDecision, 1)
+
+	go func() {
+		shellResult, _ := handler(
+			&copilot.PermissionRequestShell{FullCommandText: "kubectl delete ns prod"},
+			copilot.PermissionInvocation{},
+		)
+		resultChan <- shellResult
+	}()
+
+	var msg tea.Msg
+
+	select {
+	case msg = <-eventChan:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a permission prompt for a shell command in Sandbox mode")
+	}
+
+	if _, ok := msg.(chat.PermissionRequestMsgForTest); !ok {
+		t.Fatalf("expected a permission request message, got %T", msg)
+	}
+
+	// Deny the prompt so the handler goroutine returns.
+	var updatedModel tea.Model = chat.NewModel(newTestParams())
+
+	updatedModel, _ = updatedModel.Update(msg)
+	updatedModel, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	_, _ = updatedModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	select {
+	case shellResult := <-resultChan:
+		if shellResult.Kind() != rpc.PermissionDecisionKindReject {
+			t.Errorf("expected %q, got %q", rpc.PermissionDecisionKindReject, shellResult.Kind())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for permission handler result")
+	}
+}
+
 // TestPermissionHandler_AutopilotAutoApproves tests that Autopilot mode auto-approves permissions.
 func TestPermissionHandler_AutopilotAutoApproves(t *testing.T) {
 	t.Parallel()
@@ -41,7 +41,7 @@ var (
 // MessageMetadata represents metadata for a single message in a session.
 type MessageMetadata struct {
 	// ChatMode stores the chat mode when the message was sent.
-	// 0 = interactive, 1 = plan, 2 = autopilot.
+	// 0 = interactive, 1 = plan, 2 = autopilot, 3 = sandbox.
 	ChatMode ChatMode `json:"chatMode"`
 }
 
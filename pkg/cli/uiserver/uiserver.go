@@ -17,7 +17,8 @@ import (
 )
 
 // Host is the loopback address the UI binds to. Binding only to localhost is the security boundary:
-// the local API is unauthenticated and must not be reachable from the network.
+// the local API is unauthenticated and must not be reachable from the network. `ksail serve` may bind
+// elsewhere (ListenOn) because it always requires a bearer token.
 const Host = "127.0.0.1"
 
 // NewServer returns the API server that serves the web UI plus a REST API backed by the local
@@ -77,7 +78,13 @@ func toProviderInfos(availabilities []clusterdiscovery.Availability) []api.Provi
 // together with the URL it serves. Callers bind first so they can learn the chosen port before
 // serving and opening a browser or window.
 func Listen(ctx context.Context, port int) (net.Listener, string, error) {
-	bindAddr := net.JoinHostPort(Host, strconv.Itoa(port))
+	return ListenOn(ctx, Host, port)
+}
+
+// ListenOn binds a listener on host and port (0 picks a free port) and returns it together with the
+// URL it serves. Only callers that set api.Server.Token may pass a non-loopback host.
+func ListenOn(ctx context.Context, host string, port int) (net.Listener, string, error) {
+	bindAddr := net.JoinHostPort(host, strconv.Itoa(port))
 
 	var listenConfig net.ListenConfig
 
@@ -97,7 +104,7 @@ func Listen(ctx context.Context, port int) (net.Listener, string, error) {
 		)
 	}
 
-	url := fmt.Sprintf("http://%s/", net.JoinHostPort(Host, boundPort))
+	url := fmt.Sprintf("http://%s/", net.JoinHostPort(host, boundPort))
 
 	return listener, url, nil
 }
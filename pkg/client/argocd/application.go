@@ -53,7 +53,7 @@ func buildApplication(opts EnsureOptions) *unstructured.Unstructured {
 			"namespace": DefaultNamespace,
 		},
 		"spec": map[string]any{
-			"project": defaultProject,
+			"project": applicationProject(opts),
 			"source": map[string]any{
 				"repoURL":        opts.RepositoryURL,
 				"targetRevision": opts.TargetRevision,
@@ -63,12 +63,27 @@ func buildApplication(opts EnsureOptions) *unstructured.Unstructured {
 				"server":    defaultDestinationServer,
 				"namespace": defaultDestinationNamespace,
 			},
-			"syncPolicy": map[string]any{
-				"automated":   map[string]any{"prune": true, "selfHeal": true},
-				"syncOptions": []any{"CreateNamespace=true"},
-			},
+			"syncPolicy": automatedSyncPolicy(),
 		},
 	}
 
 	return &unstructured.Unstructured{Object: obj}
 }
+
+// applicationProject returns the AppProject the generated Applications belong to.
+func applicationProject(opts EnsureOptions) string {
+	if opts.Project != nil {
+		return ProjectName
+	}
+
+	return defaultProject
+}
+
+// automatedSyncPolicy returns the sync policy of the generated Applications:
+// automated sync with pruning and self-healing, creating missing namespaces.
+func automatedSyncPolicy() map[string]any {
+	return map[string]any{
+		"automated":   map[string]any{"prune": true, "selfHeal": true},
+		"syncOptions": []any{"CreateNamespace=true"},
+	}
+}
@@ -0,0 +1,263 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ApplicationSet generators KSail can render.
+const (
+	// GeneratorDirectory generates an Application per repository directory
+	// matching ApplicationSetOptions.DirectoryPath. Git repositories only.
+	GeneratorDirectory = "Directory"
+	// GeneratorCluster generates an Application per Argo CD cluster whose
+	// labels match ApplicationSetOptions.ClusterSelector.
+	GeneratorCluster = "Cluster"
+)
+
+var (
+	errDirectoryGeneratorRequiresGit = errors.New(
+		"the directory generator requires a git repository",
+	)
+	errDirectoryPathRequired   = errors.New("the directory generator requires a directory path")
+	errClusterSelectorRequired = errors.New("the cluster generator requires a cluster selector")
+	errUnknownGenerator        = errors.New("unknown ApplicationSet generator")
+)
+
+// ApplicationSetOptions configures the ApplicationSet KSail reconciles next to
+// its Application. The generated Applications share the repository, target
+// revision, project, and sync policy of the Application.
+type ApplicationSetOptions struct {
+	// Generator is GeneratorDirectory or GeneratorCluster.
+	Generator string
+
+	// DirectoryPath is the repository path glob whose matching directories the
+	// directory generator turns into Applications (e.g. k8s/apps/*). Each
+	// Application is named after its directory and deploys to the namespace of
+	// the same name.
+	DirectoryPath string
+
+	// ClusterSelector matches the labels of the Argo CD cluster secrets the
+	// cluster generator deploys the source path to.
+	ClusterSelector map[string]string
+}
+
+// ApplicationSetGVR returns the GroupVersionResource for Argo CD ApplicationSets.
+func ApplicationSetGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "argoproj.io",
+		Version:  "v1alpha1",
+		Resource: "applicationsets",
+	}
+}
+
+// buildApplicationSet renders the ApplicationSet for the non-nil
+// opts.ApplicationSet. It is named after the Application and uses Go templates,
+// so the generator parameters are referenced as {{.path.basename}} and {{.name}}.
+func buildApplicationSet(opts EnsureOptions) (*unstructured.Unstructured, error) {
+	name := opts.ApplicationName
+	if name == "" {
+		name = defaultApplicationName
+	}
+
+	sourcePath := opts.SourcePath
+	if sourcePath == "" {
+		sourcePath = DefaultSourcePath
+	}
+
+	generator, template, err := buildGenerator(name, sourcePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "ApplicationSet",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": DefaultNamespace,
+		},
+		"spec": map[string]any{
+			"goTemplate":        true,
+			"goTemplateOptions": []any{"missingkey=error"},
+			"generators":        []any{generator},
+			"template":          template,
+		},
+	}
+
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// buildGenerator returns the generator and the Application template for the
+// configured generator.
+func buildGenerator(
+	name, sourcePath string,
+	opts EnsureOptions,
+) (map[string]any, map[string]any, error) {
+	appSet := opts.ApplicationSet
+
+	switch appSet.Generator {
+	case GeneratorDirectory:
+		if opts.RepositoryType != RepositoryTypeGit {
+			return nil, nil, errDirectoryGeneratorRequiresGit
+		}
+
+		if appSet.DirectoryPath == "" {
+			return nil, nil, errDirectoryPathRequired
+		}
+
+		generator := map[string]any{
+			"git": map[string]any{
+				"repoURL":     opts.RepositoryURL,
+				"revision":    opts.TargetRevision,
+				"directories": []any{map[string]any{"path": appSet.DirectoryPath}},
+			},
+		}
+
+		return generator, applicationTemplate(
+			"{{.path.basename}}",
+			opts, "{{.path.path}}",
+			map[string]any{"server": defaultDestinationServer, "namespace": "{{.path.basename}}"},
+		), nil
+	case GeneratorCluster:
+		if len(appSet.ClusterSelector) == 0 {
+			return nil, nil, errClusterSelectorRequired
+		}
+
+		matchLabels := make(map[string]any, len(appSet.ClusterSelector))
+		for key, value := range appSet.ClusterSelector {
+			matchLabels[key] = value
+		}
+
+		generator := map[string]any{
+			"clusters": map[string]any{
+				"selector": map[string]any{"matchLabels": matchLabels},
+			},
+		}
+
+		return generator, applicationTemplate(
+			name+"-{{.name}}",
+			opts, sourcePath,
+			map[string]any{"server": "{{.server}}", "namespace": defaultDestinationNamespace},
+		), nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", errUnknownGenerator, appSet.Generator)
+	}
+}
+
+// applicationTemplate returns the template of the generated Applications. They
+// share the project and sync policy of the root Application.
+func applicationTemplate(
+	name string,
+	opts EnsureOptions,
+	path string,
+	destination map[string]any,
+) map[string]any {
+	return map[string]any{
+		"metadata": map[string]any{"name": name},
+		"spec": map[string]any{
+			"source": map[string]any{
+				"repoURL":        opts.RepositoryURL,
+				"targetRevision": opts.TargetRevision,
+				"path":           path,
+			},
+			"destination": destination,
+			"project":     applicationProject(opts),
+			"syncPolicy":  automatedSyncPolicy(),
+		},
+	}
+}
+
+// ensureApplicationSet creates or updates the ApplicationSet when one is
+// configured, and deletes it otherwise so switching back to the single
+// Application layout removes the generated Applications.
+func (m *ManagerImpl) ensureApplicationSet(ctx context.Context, opts EnsureOptions) error {
+	appSets := m.dynamic.Resource(ApplicationSetGVR()).Namespace(DefaultNamespace)
+
+	name := opts.ApplicationName
+	if name == "" {
+		name = defaultApplicationName
+	}
+
+	if opts.ApplicationSet == nil {
+		err := appSets.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete Argo CD ApplicationSet %s: %w", name, err)
+		}
+
+		return nil
+	}
+
+	desired, err := buildApplicationSet(opts)
+	if err != nil {
+		return fmt.Errorf("build Argo CD ApplicationSet: %w", err)
+	}
+
+	existing, err := appSets.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			_, err = appSets.Create(ctx, desired, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("create Argo CD ApplicationSet: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("get Argo CD ApplicationSet: %w", err)
+	}
+
+	desired.SetResourceVersion(existing.GetResourceVersion())
+
+	_, err = appSets.Update(ctx, desired, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update Argo CD ApplicationSet: %w", err)
+	}
+
+	return nil
+}
+
+// updateApplicationSetTargetRevision moves the template of the ApplicationSet
+// named name to revision, so generated Applications follow the root
+// Application. A missing ApplicationSet is not an error.
+func (m *ManagerImpl) updateApplicationSetTargetRevision(
+	ctx context.Context,
+	name, revision string,
+) error {
+	appSets := m.dynamic.Resource(ApplicationSetGVR()).Namespace(DefaultNamespace)
+
+	obj, err := appSets.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("get Argo CD ApplicationSet %s: %w", name, err)
+	}
+
+	err = unstructured.SetNestedField(
+		obj.Object,
+		revision,
+		"spec",
+		"template",
+		"spec",
+		"source",
+		"targetRevision",
+	)
+	if err != nil {
+		return fmt.Errorf("set ApplicationSet.spec.template.spec.source.targetRevision: %w", err)
+	}
+
+	_, err = appSets.Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update Argo CD ApplicationSet %s: %w", name, err)
+	}
+
+	return nil
+}
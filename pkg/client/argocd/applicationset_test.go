@@ -0,0 +1,175 @@
+package argocd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newGitApplicationSetOptions(appSet *argocd.ApplicationSetOptions) argocd.EnsureOptions {
+	return argocd.EnsureOptions{
+		RepositoryURL:   "https://github.com/org/platform.git",
+		RepositoryType:  argocd.RepositoryTypeGit,
+		SourcePath:      "k8s",
+		ApplicationName: "ksail",
+		TargetRevision:  "main",
+		ApplicationSet:  appSet,
+	}
+}
+
+func getApplicationSet(t *testing.T, testMgr testManager) (*unstructured.Unstructured, error) {
+	t.Helper()
+
+	obj, err := testMgr.dyn.Resource(argocd.ApplicationSetGVR()).
+		Namespace("argocd").
+		Get(context.Background(), "ksail", metav1.GetOptions{})
+	if err != nil {
+		return nil, err //nolint:wrapcheck // test helper surfaces the raw API error
+	}
+
+	return obj, nil
+}
+
+func TestManagerEnsure_CreatesDirectoryApplicationSet(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+
+	err := testMgr.mgr.Ensure(context.Background(), newGitApplicationSetOptions(&argocd.ApplicationSetOptions{
+		Generator:     argocd.GeneratorDirectory,
+		DirectoryPath: "k8s/apps/*",
+	}))
+	require.NoError(t, err)
+
+	appSet, err := getApplicationSet(t, testMgr)
+	require.NoError(t, err)
+
+	generators, _, _ := unstructured.NestedSlice(appSet.Object, "spec", "generators")
+	require.Len(t, generators, 1)
+	assert.Equal(t, map[string]any{
+		"git": map[string]any{
+			"repoURL":     "https://github.com/org/platform.git",
+			"revision":    "main",
+			"directories": []any{map[string]any{"path": "k8s/apps/*"}},
+		},
+	}, generators[0])
+
+	assert.Equal(t, "{{.path.basename}}", getNestedString(t, appSet, "spec", "template", "metadata", "name"))
+	assert.Equal(t, "{{.path.path}}", getNestedString(t, appSet, "spec", "template", "spec", "source", "path"))
+	assert.Equal(t, "{{.path.basename}}",
+		getNestedString(t, appSet, "spec", "template", "spec", "destination", "namespace"))
+	assert.Equal(t, "default", getNestedString(t, appSet, "spec", "template", "spec", "project"))
+
+	// The root Application still syncs the source directory.
+	assert.Equal(t, "k8s", applicationSourcePath(t, testMgr))
+}
+
+func TestManagerEnsure_CreatesClusterApplicationSet(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+
+	opts := newProjectEnsureOptions(newRestrictedProject())
+	opts.ApplicationSet = &argocd.ApplicationSetOptions{
+		Generator:       argocd.GeneratorCluster,
+		ClusterSelector: map[string]string{"env": "staging"},
+	}
+
+	err := testMgr.mgr.Ensure(context.Background(), opts)
+	require.NoError(t, err)
+
+	appSet, err := getApplicationSet(t, testMgr)
+	require.NoError(t, err)
+
+	generators, _, _ := unstructured.NestedSlice(appSet.Object, "spec", "generators")
+	require.Len(t, generators, 1)
+
+	cluster, ok := generators[0].(map[string]any)
+	require.True(t, ok)
+
+	matchLabels, _, _ := unstructured.NestedStringMap(cluster, "clusters", "selector", "matchLabels")
+	assert.Equal(t, map[string]string{"env": "staging"}, matchLabels)
+
+	assert.Equal(t, "ksail-{{.name}}", getNestedString(t, appSet, "spec", "template", "metadata", "name"))
+	assert.Equal(t, "{{.server}}",
+		getNestedString(t, appSet, "spec", "template", "spec", "destination", "server"))
+	assert.Equal(t, "v1", getNestedString(t, appSet, "spec", "template", "spec", "source", "targetRevision"))
+	assert.Equal(t, argocd.ProjectName, getNestedString(t, appSet, "spec", "template", "spec", "project"))
+}
+
+func TestManagerEnsure_DirectoryApplicationSetRequiresGit(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+
+	opts := newProjectEnsureOptions(nil)
+	opts.ApplicationSet = &argocd.ApplicationSetOptions{
+		Generator:     argocd.GeneratorDirectory,
+		DirectoryPath: "apps/*",
+	}
+
+	err := testMgr.mgr.Ensure(context.Background(), opts)
+	require.ErrorContains(t, err, "requires a git repository")
+}
+
+func TestManagerEnsure_RemovesApplicationSetWhenLayoutDropped(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+	ctx := context.Background()
+
+	err := testMgr.mgr.Ensure(ctx, newGitApplicationSetOptions(&argocd.ApplicationSetOptions{
+		Generator:     argocd.GeneratorDirectory,
+		DirectoryPath: "k8s/apps/*",
+	}))
+	require.NoError(t, err)
+
+	err = testMgr.mgr.Ensure(ctx, newGitApplicationSetOptions(nil))
+	require.NoError(t, err)
+
+	_, err = getApplicationSet(t, testMgr)
+	assert.True(t, apierrors.IsNotFound(err), "expected ApplicationSet to be deleted, got %v", err)
+}
+
+func TestManagerUpdateTargetRevision_UpdatesApplicationSetTemplate(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+	ctx := context.Background()
+
+	opts := newProjectEnsureOptions(nil)
+	opts.ApplicationSet = &argocd.ApplicationSetOptions{
+		Generator:       argocd.GeneratorCluster,
+		ClusterSelector: map[string]string{"env": "staging"},
+	}
+
+	err := testMgr.mgr.Ensure(ctx, opts)
+	require.NoError(t, err)
+
+	err = testMgr.mgr.UpdateTargetRevision(ctx, argocd.UpdateTargetRevisionOptions{
+		ApplicationName: "ksail",
+		TargetRevision:  "v2",
+	})
+	require.NoError(t, err)
+
+	appSet, err := getApplicationSet(t, testMgr)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", getNestedString(t, appSet, "spec", "template", "spec", "source", "targetRevision"))
+}
+
+func applicationSourcePath(t *testing.T, testMgr testManager) string {
+	t.Helper()
+
+	app, err := testMgr.dyn.Resource(testMgr.gvr).
+		Namespace("argocd").
+		Get(context.Background(), "ksail", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	return getNestedString(t, app, "spec", "source", "path")
+}
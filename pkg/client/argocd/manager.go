@@ -54,7 +54,8 @@ func NewManagerFromKubeconfig(kubeconfig, kubeContext string) (*ManagerImpl, err
 	return NewManager(clientset, dyn), nil
 }
 
-// Ensure creates or updates the Argo CD repository secret and Application.
+// Ensure creates or updates the Argo CD repository secret, AppProject, Application,
+// and ApplicationSet.
 func (m *ManagerImpl) Ensure(ctx context.Context, opts EnsureOptions) error {
 	if ctx == nil {
 		return errNilContext
@@ -74,9 +75,11 @@ func (m *ManagerImpl) Ensure(ctx context.Context, opts EnsureOptions) error {
 	}
 
 	err = m.upsertRepositorySecret(ctx, repositorySecretOptions{
+		repositoryType:      opts.RepositoryType,
 		repositoryURL:       opts.RepositoryURL,
 		username:            opts.Username,
 		password:            opts.Password,
+		sshPrivateKey:       opts.SSHPrivateKey,
 		pullOnlyCredentials: opts.PullOnlyCredentials,
 		insecure:            opts.Insecure,
 	})
@@ -84,10 +87,34 @@ func (m *ManagerImpl) Ensure(ctx context.Context, opts EnsureOptions) error {
 		return err
 	}
 
-	return m.upsertApplication(ctx, opts)
+	// The project must exist before the Application references it, and must
+	// only be removed after the Application has moved back to the default one.
+	if opts.Project != nil {
+		err = m.ensureProject(ctx, opts.Project)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.upsertApplication(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	err = m.ensureApplicationSet(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Project == nil {
+		return m.ensureProject(ctx, nil)
+	}
+
+	return nil
 }
 
 // UpdateTargetRevision updates the Application target revision and optionally requests a hard refresh.
+// The template of an ApplicationSet of the same name follows the new revision.
 func (m *ManagerImpl) UpdateTargetRevision(
 	ctx context.Context,
 	opts UpdateTargetRevisionOptions,
@@ -138,7 +165,11 @@ func (m *ManagerImpl) UpdateTargetRevision(
 		return fmt.Errorf("update Argo CD Application %s: %w", name, err)
 	}
 
-	return nil
+	if opts.TargetRevision == "" {
+		return nil
+	}
+
+	return m.updateApplicationSetTargetRevision(ctx, name, opts.TargetRevision)
 }
 
 // GetCurrentTargetRevision returns the Application's current targetRevision.
@@ -6,6 +6,10 @@ type EnsureOptions struct {
 	// Example: oci://local-registry:5000/<repository>
 	RepositoryURL string
 
+	// RepositoryType is the Argo CD repository type, RepositoryTypeOCI or
+	// RepositoryTypeGit. If empty, defaults to RepositoryTypeOCI.
+	RepositoryType string
+
 	// SourcePath is the path inside the OCI artifact to the kustomization root,
 	// resolved by Argo CD relative to the root of the expanded archive.
 	//
@@ -25,12 +29,27 @@ type EnsureOptions struct {
 
 	// Password for OCI registry authentication (optional, for external registries).
 	Password string
+
+	// SSHPrivateKey authenticates a Git repository cloned over SSH with a deploy key
+	// (optional, PEM encoded).
+	SSHPrivateKey string
 	// PullOnlyCredentials marks Username and Password as cluster-resident pull
 	// credentials so push auto-detection cannot reuse them.
 	PullOnlyCredentials bool
 
 	// Insecure allows HTTP connections (for local registries). Default is false.
 	Insecure bool
+
+	// Project declares AppProject restrictions for the Application. When set,
+	// KSail reconciles the ProjectName AppProject and assigns the Application to
+	// it; when nil, the Application uses the default project and a previously
+	// created ProjectName AppProject is removed.
+	Project *ProjectOptions
+
+	// ApplicationSet declares an ApplicationSet KSail reconciles next to the
+	// Application (app-of-apps layout). When nil, a previously created
+	// ApplicationSet is removed.
+	ApplicationSet *ApplicationSetOptions
 }
 
 // UpdateTargetRevisionOptions configures how KSail updates an Application to a new OCI revision.
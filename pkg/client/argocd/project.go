@@ -0,0 +1,341 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ProjectName is the AppProject KSail owns when project restrictions are
+// declared. Without restrictions the Application uses Argo CD's "default"
+// project and no AppProject is created.
+const ProjectName = "ksail"
+
+// wildcard allows any value for an AppProject dimension left unrestricted.
+const wildcard = "*"
+
+// ProjectOptions declares the AppProject restrictions KSail reconciles for its
+// Application. Empty dimensions stay unrestricted.
+type ProjectOptions struct {
+	// SourceRepos lists the repository URLs (glob patterns allowed) the
+	// Application may sync from.
+	SourceRepos []string
+	// Destinations lists the clusters and namespaces the Application may deploy to.
+	Destinations []ProjectDestination
+	// SyncWindows lists the windows during which syncs are allowed or denied.
+	SyncWindows []SyncWindow
+}
+
+// ProjectDestination is a cluster/namespace pair an Application may deploy to.
+type ProjectDestination struct {
+	Server    string
+	Name      string
+	Namespace string
+}
+
+// SyncWindow mirrors an AppProject spec.syncWindows entry.
+type SyncWindow struct {
+	Kind         string
+	Schedule     string
+	Duration     string
+	Applications []string
+	Namespaces   []string
+	Clusters     []string
+	ManualSync   bool
+	TimeZone     string
+}
+
+// AppProjectGVR returns the GroupVersionResource for Argo CD AppProjects.
+func AppProjectGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "argoproj.io",
+		Version:  "v1alpha1",
+		Resource: "appprojects",
+	}
+}
+
+// Summary renders the restrictions as a compact, deterministic string for
+// drift reporting. A nil project renders as "None".
+func (p *ProjectOptions) Summary() string {
+	if p == nil {
+		return "None"
+	}
+
+	parts := make([]string, 0, len(p.Destinations)+len(p.SyncWindows)+1)
+
+	// Wildcard entries are what KSail renders for an unrestricted dimension, so
+	// they are skipped to keep a declared and a live project comparable.
+	if len(p.SourceRepos) > 0 && !slices.Equal(p.SourceRepos, []string{wildcard}) {
+		parts = append(parts, "sourceRepos="+strings.Join(p.SourceRepos, ","))
+	}
+
+	for _, dest := range p.Destinations {
+		if dest.Name == "" && dest.Server == wildcard && namespaceOrWildcard(dest) == wildcard {
+			continue
+		}
+
+		cluster := dest.Server
+		if cluster == "" {
+			cluster = dest.Name
+		}
+
+		parts = append(parts, fmt.Sprintf("destination=%s/%s", cluster, namespaceOrWildcard(dest)))
+	}
+
+	for _, window := range p.SyncWindows {
+		parts = append(parts, window.summary())
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// summary renders a sync window with only the selectors and flags it sets.
+func (w SyncWindow) summary() string {
+	out := fmt.Sprintf("%sWindow=%q/%s", w.Kind, w.Schedule, w.Duration)
+
+	selectors := []struct {
+		name   string
+		values []string
+	}{
+		{"applications", w.Applications},
+		{"namespaces", w.Namespaces},
+		{"clusters", w.Clusters},
+	}
+	for _, selector := range selectors {
+		if len(selector.values) > 0 {
+			out += fmt.Sprintf(" %s=%s", selector.name, strings.Join(selector.values, ","))
+		}
+	}
+
+	if w.ManualSync {
+		out += " manualSync"
+	}
+
+	if w.TimeZone != "" {
+		out += " timeZone=" + w.TimeZone
+	}
+
+	return out
+}
+
+func namespaceOrWildcard(dest ProjectDestination) string {
+	if dest.Namespace == "" {
+		return wildcard
+	}
+
+	return dest.Namespace
+}
+
+func buildAppProject(opts ProjectOptions) *unstructured.Unstructured {
+	sourceRepos := []any{wildcard}
+	if len(opts.SourceRepos) > 0 {
+		sourceRepos = toAnySlice(opts.SourceRepos)
+	}
+
+	destinations := []any{map[string]any{"server": wildcard, "namespace": wildcard}}
+	if len(opts.Destinations) > 0 {
+		destinations = make([]any, 0, len(opts.Destinations))
+
+		for _, dest := range opts.Destinations {
+			entry := map[string]any{"namespace": namespaceOrWildcard(dest)}
+			if dest.Server != "" {
+				entry["server"] = dest.Server
+			}
+
+			if dest.Name != "" {
+				entry["name"] = dest.Name
+			}
+
+			destinations = append(destinations, entry)
+		}
+	}
+
+	spec := map[string]any{
+		"description":  "Guardrails declared in ksail.yaml (spec.workload.argoCD.project).",
+		"sourceRepos":  sourceRepos,
+		"destinations": destinations,
+		// Cluster-scoped resources stay unrestricted so only the declared
+		// constraints apply, matching the "default" project's behavior.
+		"clusterResourceWhitelist": []any{map[string]any{"group": wildcard, "kind": wildcard}},
+	}
+
+	if len(opts.SyncWindows) > 0 {
+		spec["syncWindows"] = buildSyncWindows(opts.SyncWindows)
+	}
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "AppProject",
+		"metadata": map[string]any{
+			"name":      ProjectName,
+			"namespace": DefaultNamespace,
+		},
+		"spec": spec,
+	}}
+}
+
+func buildSyncWindows(windows []SyncWindow) []any {
+	entries := make([]any, 0, len(windows))
+
+	for _, window := range windows {
+		entry := map[string]any{
+			"kind":     window.Kind,
+			"schedule": window.Schedule,
+			"duration": window.Duration,
+		}
+
+		if len(window.Applications) > 0 {
+			entry["applications"] = toAnySlice(window.Applications)
+		}
+
+		if len(window.Namespaces) > 0 {
+			entry["namespaces"] = toAnySlice(window.Namespaces)
+		}
+
+		if len(window.Clusters) > 0 {
+			entry["clusters"] = toAnySlice(window.Clusters)
+		}
+
+		if window.ManualSync {
+			entry["manualSync"] = true
+		}
+
+		if window.TimeZone != "" {
+			entry["timeZone"] = window.TimeZone
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func toAnySlice(values []string) []any {
+	out := make([]any, 0, len(values))
+	for _, value := range values {
+		out = append(out, value)
+	}
+
+	return out
+}
+
+// parseAppProject reads the restrictions back from a live AppProject.
+func parseAppProject(obj *unstructured.Unstructured) *ProjectOptions {
+	opts := &ProjectOptions{}
+
+	opts.SourceRepos, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "sourceRepos")
+
+	destinations, _, _ := unstructured.NestedSlice(obj.Object, "spec", "destinations")
+	for _, raw := range destinations {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		opts.Destinations = append(opts.Destinations, ProjectDestination{
+			Server:    nestedString(entry, "server"),
+			Name:      nestedString(entry, "name"),
+			Namespace: nestedString(entry, "namespace"),
+		})
+	}
+
+	windows, _, _ := unstructured.NestedSlice(obj.Object, "spec", "syncWindows")
+	for _, raw := range windows {
+		entry, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		manualSync, _, _ := unstructured.NestedBool(entry, "manualSync")
+		applications, _, _ := unstructured.NestedStringSlice(entry, "applications")
+		namespaces, _, _ := unstructured.NestedStringSlice(entry, "namespaces")
+		clusters, _, _ := unstructured.NestedStringSlice(entry, "clusters")
+
+		opts.SyncWindows = append(opts.SyncWindows, SyncWindow{
+			Kind:         nestedString(entry, "kind"),
+			Schedule:     nestedString(entry, "schedule"),
+			Duration:     nestedString(entry, "duration"),
+			Applications: applications,
+			Namespaces:   namespaces,
+			Clusters:     clusters,
+			ManualSync:   manualSync,
+			TimeZone:     nestedString(entry, "timeZone"),
+		})
+	}
+
+	return opts
+}
+
+func nestedString(obj map[string]any, field string) string {
+	value, _, _ := unstructured.NestedString(obj, field)
+
+	return value
+}
+
+// GetCurrentProject returns the restrictions of the KSail-owned AppProject, or
+// nil when it does not exist (the Application uses the default project).
+func (m *ManagerImpl) GetCurrentProject(ctx context.Context) (*ProjectOptions, error) {
+	if ctx == nil {
+		return nil, errNilContext
+	}
+
+	obj, err := m.dynamic.Resource(AppProjectGVR()).
+		Namespace(DefaultNamespace).
+		Get(ctx, ProjectName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get Argo CD AppProject %s: %w", ProjectName, err)
+	}
+
+	return parseAppProject(obj), nil
+}
+
+// ensureProject creates or updates the KSail-owned AppProject when restrictions
+// are declared, and deletes it when they are removed so a stale project never
+// lingers after the Application moves back to the default project.
+func (m *ManagerImpl) ensureProject(ctx context.Context, opts *ProjectOptions) error {
+	projects := m.dynamic.Resource(AppProjectGVR()).Namespace(DefaultNamespace)
+
+	if opts == nil {
+		err := projects.Delete(ctx, ProjectName, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete Argo CD AppProject %s: %w", ProjectName, err)
+		}
+
+		return nil
+	}
+
+	desired := buildAppProject(*opts)
+
+	existing, err := projects.Get(ctx, ProjectName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			_, err = projects.Create(ctx, desired, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("create Argo CD AppProject: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("get Argo CD AppProject: %w", err)
+	}
+
+	desired.SetResourceVersion(existing.GetResourceVersion())
+
+	_, err = projects.Update(ctx, desired, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update Argo CD AppProject: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package argocd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newRestrictedProject() *argocd.ProjectOptions {
+	return &argocd.ProjectOptions{
+		SourceRepos: []string{"https://github.com/org/*"},
+		Destinations: []argocd.ProjectDestination{
+			{Server: "https://kubernetes.default.svc", Namespace: "apps"},
+		},
+		SyncWindows: []argocd.SyncWindow{{
+			Kind:       "deny",
+			Schedule:   "0 22 * * *",
+			Duration:   "8h",
+			ManualSync: true,
+			TimeZone:   "Europe/Copenhagen",
+		}},
+	}
+}
+
+func newProjectEnsureOptions(project *argocd.ProjectOptions) argocd.EnsureOptions {
+	return argocd.EnsureOptions{
+		RepositoryURL:   "oci://local-registry:5000/demo",
+		ApplicationName: "ksail",
+		TargetRevision:  "v1",
+		Project:         project,
+	}
+}
+
+func getAppProject(t *testing.T, testMgr testManager) (*unstructured.Unstructured, error) {
+	t.Helper()
+
+	obj, err := testMgr.dyn.Resource(argocd.AppProjectGVR()).
+		Namespace("argocd").
+		Get(context.Background(), argocd.ProjectName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err //nolint:wrapcheck // test helper surfaces the raw API error
+	}
+
+	return obj, nil
+}
+
+func applicationProject(t *testing.T, testMgr testManager) string {
+	t.Helper()
+
+	app, err := testMgr.dyn.Resource(testMgr.gvr).
+		Namespace("argocd").
+		Get(context.Background(), "ksail", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	project, _, err := unstructured.NestedString(app.Object, "spec", "project")
+	require.NoError(t, err)
+
+	return project
+}
+
+func TestManagerEnsure_CreatesRestrictedAppProject(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+
+	err := testMgr.mgr.Ensure(context.Background(), newProjectEnsureOptions(newRestrictedProject()))
+	require.NoError(t, err)
+
+	project, err := getAppProject(t, testMgr)
+	require.NoError(t, err)
+
+	repos, _, _ := unstructured.NestedStringSlice(project.Object, "spec", "sourceRepos")
+	assert.Equal(t, []string{"https://github.com/org/*"}, repos)
+
+	windows, _, _ := unstructured.NestedSlice(project.Object, "spec", "syncWindows")
+	require.Len(t, windows, 1)
+	assert.Equal(t, map[string]any{
+		"kind":       "deny",
+		"schedule":   "0 22 * * *",
+		"duration":   "8h",
+		"manualSync": true,
+		"timeZone":   "Europe/Copenhagen",
+	}, windows[0])
+
+	assert.Equal(t, argocd.ProjectName, applicationProject(t, testMgr))
+}
+
+func TestManagerEnsure_UnrestrictedDimensionsUseWildcards(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+	project := &argocd.ProjectOptions{
+		SyncWindows: []argocd.SyncWindow{{Kind: "allow", Schedule: "@daily", Duration: "1h"}},
+	}
+
+	err := testMgr.mgr.Ensure(context.Background(), newProjectEnsureOptions(project))
+	require.NoError(t, err)
+
+	obj, err := getAppProject(t, testMgr)
+	require.NoError(t, err)
+
+	repos, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "sourceRepos")
+	assert.Equal(t, []string{"*"}, repos)
+
+	destinations, _, _ := unstructured.NestedSlice(obj.Object, "spec", "destinations")
+	assert.Equal(t, []any{map[string]any{"server": "*", "namespace": "*"}}, destinations)
+}
+
+func TestManagerEnsure_RemovesAppProjectWhenRestrictionsDropped(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+	ctx := context.Background()
+
+	err := testMgr.mgr.Ensure(ctx, newProjectEnsureOptions(newRestrictedProject()))
+	require.NoError(t, err)
+
+	err = testMgr.mgr.Ensure(ctx, newProjectEnsureOptions(nil))
+	require.NoError(t, err)
+
+	_, err = getAppProject(t, testMgr)
+	assert.True(t, apierrors.IsNotFound(err), "expected AppProject to be deleted, got %v", err)
+	assert.Equal(t, "default", applicationProject(t, testMgr))
+}
+
+func TestManagerGetCurrentProject_RoundTripsSummary(t *testing.T) {
+	t.Parallel()
+
+	testMgr := newTestManager(t)
+	ctx := context.Background()
+
+	current, err := testMgr.mgr.GetCurrentProject(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, current)
+	assert.Equal(t, "None", current.Summary())
+
+	desired := newRestrictedProject()
+
+	err = testMgr.mgr.Ensure(ctx, newProjectEnsureOptions(desired))
+	require.NoError(t, err)
+
+	current, err = testMgr.mgr.GetCurrentProject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, desired.Summary(), current.Summary())
+	assert.Equal(t,
+		`sourceRepos=https://github.com/org/*; destination=https://kubernetes.default.svc/apps; `+
+			`denyWindow="0 22 * * *"/8h manualSync timeZone=Europe/Copenhagen`,
+		current.Summary(),
+	)
+}
+
+func TestProjectOptionsSummary_IgnoresWildcardDefaults(t *testing.T) {
+	t.Parallel()
+
+	window := argocd.SyncWindow{Kind: "allow", Schedule: "@daily", Duration: "1h"}
+	declared := &argocd.ProjectOptions{SyncWindows: []argocd.SyncWindow{window}}
+	live := &argocd.ProjectOptions{
+		SourceRepos:  []string{"*"},
+		Destinations: []argocd.ProjectDestination{{Server: "*", Namespace: "*"}},
+		SyncWindows:  []argocd.SyncWindow{window},
+	}
+
+	assert.Equal(t, declared.Summary(), live.Summary())
+}
@@ -7,22 +7,34 @@ import (
 )
 
 const (
+	// RepositoryTypeOCI registers an OCI artifact repository (the KSail default).
+	RepositoryTypeOCI = "oci"
+	// RepositoryTypeGit registers a Git repository (spec.workload.git).
+	RepositoryTypeGit = "git"
+
 	//nolint:gosec // G101: false positive - this is a Kubernetes secret name, not a credential
 	repositorySecretName = "ksail-local-registry-repo"
 )
 
 // repositorySecretOptions contains options for building the ArgoCD repository secret.
 type repositorySecretOptions struct {
+	repositoryType      string
 	repositoryURL       string
 	username            string
 	password            string
+	sshPrivateKey       string
 	pullOnlyCredentials bool
 	insecure            bool
 }
 
 func buildRepositorySecret(opts repositorySecretOptions) *corev1.Secret {
+	repositoryType := opts.repositoryType
+	if repositoryType == "" {
+		repositoryType = RepositoryTypeOCI
+	}
+
 	data := map[string]string{
-		"type": "oci",
+		"type": repositoryType,
 		"url":  opts.repositoryURL,
 	}
 
@@ -35,6 +47,10 @@ func buildRepositorySecret(opts repositorySecretOptions) *corev1.Secret {
 		data["password"] = opts.password
 	}
 
+	if opts.sshPrivateKey != "" {
+		data["sshPrivateKey"] = opts.sshPrivateKey
+	}
+
 	// Only set insecureOCIForceHttp for local registries (insecure mode)
 	if opts.insecure {
 		data["insecureOCIForceHttp"] = "true"
@@ -0,0 +1,112 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/reconciler"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// ApplicationStatus summarizes the sync and health state of an ArgoCD Application.
+type ApplicationStatus struct {
+	Name string `json:"name"`
+	// Sync is the sync status (Synced, OutOfSync, Unknown); empty when not yet reported.
+	Sync string `json:"sync"`
+	// Health is the health status (Healthy, Progressing, Degraded, Suspended,
+	// Missing, Unknown); empty when not yet reported.
+	Health string `json:"health"`
+	// Message explains a failed operation or an error condition, when present.
+	Message string `json:"message,omitempty"`
+}
+
+// Healthy reports whether the application is synced and healthy.
+func (s ApplicationStatus) Healthy() bool {
+	return s.Sync == "Synced" && s.Health == "Healthy"
+}
+
+// State returns a one-word summary: the health status when the application is
+// synced, otherwise the sync status, or Unknown when neither was reported.
+func (s ApplicationStatus) State() string {
+	switch {
+	case s.Sync == "Synced" && s.Health != "":
+		return s.Health
+	case s.Sync != "":
+		return s.Sync
+	default:
+		return "Unknown"
+	}
+}
+
+// NewReconcilerForContext creates an ArgoCD reconciler for a specific kubeconfig
+// context. An empty contextName uses the kubeconfig's current context.
+func NewReconcilerForContext(kubeconfigPath, contextName string) (*Reconciler, error) {
+	restConfig, err := k8s.BuildRESTConfig(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("build rest config: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create dynamic client: %w", err)
+	}
+
+	base := reconciler.NewBaseWithClient(dynamicClient)
+	base.KubeconfigPath = kubeconfigPath
+
+	return newFromBase(base), nil
+}
+
+// ListApplicationStatuses returns the sync and health status of every ArgoCD
+// Application in the argocd namespace, sorted by name.
+func (r *Reconciler) ListApplicationStatuses(ctx context.Context) ([]ApplicationStatus, error) {
+	list, err := r.applicationClient().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list argocd applications: %w", err)
+	}
+
+	statuses := make([]ApplicationStatus, 0, len(list.Items))
+	for i := range list.Items {
+		statuses = append(statuses, ApplicationStatusFromObject(&list.Items[i]))
+	}
+
+	slices.SortFunc(statuses, func(a, b ApplicationStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return statuses, nil
+}
+
+// ApplicationStatusFromObject derives an ApplicationStatus from an ArgoCD
+// Application. The message comes from a failed operation first, then from the
+// first error condition.
+func ApplicationStatusFromObject(app *unstructured.Unstructured) ApplicationStatus {
+	syncStatus, _, _ := unstructured.NestedString(app.Object, "status", "sync", "status")
+	healthStatus, _, _ := unstructured.NestedString(app.Object, "status", "health", "status")
+
+	status := ApplicationStatus{Name: app.GetName(), Sync: syncStatus, Health: healthStatus}
+
+	phase, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "phase")
+	if phase == "Error" || phase == "Failed" {
+		status.Message, _, _ = unstructured.NestedString(
+			app.Object, "status", "operationState", "message",
+		)
+
+		return status
+	}
+
+	for _, cond := range reconciler.ParseConditions(app) {
+		if strings.HasSuffix(cond.Type, "Error") {
+			status.Message = cond.Message
+
+			break
+		}
+	}
+
+	return status
+}
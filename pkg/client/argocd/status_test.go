@@ -0,0 +1,58 @@
+package argocd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListApplicationStatuses(t *testing.T) {
+	t.Parallel()
+
+	r := newTestArgoCDReconciler(
+		newFakeApplication("workloads", "OutOfSync", "Degraded"),
+		newFakeApplication("ksail", "Synced", "Healthy"),
+		newFakeApplicationWithOperation("broken", "Failed", "sync failed: manifest invalid"),
+		newFakeApplicationWithConditions("erroring", []map[string]any{
+			{"type": "ComparisonError", "message": "repository not found"},
+		}),
+	)
+
+	statuses, err := r.ListApplicationStatuses(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []argocd.ApplicationStatus{
+		{Name: "broken", Message: "sync failed: manifest invalid"},
+		{Name: "erroring", Sync: "Synced", Health: "Healthy", Message: "repository not found"},
+		{Name: "ksail", Sync: "Synced", Health: "Healthy"},
+		{Name: "workloads", Sync: "OutOfSync", Health: "Degraded"},
+	}, statuses)
+}
+
+func TestApplicationStatus_HealthyAndState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      argocd.ApplicationStatus
+		wantHealthy bool
+		wantState   string
+	}{
+		{"synced and healthy", argocd.ApplicationStatus{Sync: "Synced", Health: "Healthy"}, true, "Healthy"},
+		{"synced but progressing", argocd.ApplicationStatus{Sync: "Synced", Health: "Progressing"}, false, "Progressing"},
+		{"out of sync", argocd.ApplicationStatus{Sync: "OutOfSync", Health: "Healthy"}, false, "OutOfSync"},
+		{"not reported", argocd.ApplicationStatus{}, false, "Unknown"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.wantHealthy, testCase.status.Healthy())
+			assert.Equal(t, testCase.wantState, testCase.status.State())
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SuspendedAutoSyncAnnotation records the automated sync policy an Application
+// had before SuspendAutoSync removed it, so ResumeAutoSync can restore it.
+const SuspendedAutoSyncAnnotation = "ksail.devantler.tech/suspended-automated-sync"
+
+// ErrApplicationNotFound is returned when the named Application does not exist.
+var ErrApplicationNotFound = errors.New("argocd application not found")
+
+// SuspendAutoSync turns off automated sync on the named Application, or on
+// every Application when name is empty, and returns the names of those it
+// changed. The removed policy (prune, selfHeal, ...) is kept in the
+// SuspendedAutoSyncAnnotation. Applications that sync manually are left
+// untouched.
+func (r *Reconciler) SuspendAutoSync(ctx context.Context, name string) ([]string, error) {
+	apps, err := r.selectApplications(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+
+	for i := range apps {
+		automated, found, _ := unstructured.NestedMap(apps[i].Object, "spec", "syncPolicy", "automated")
+		if !found {
+			continue
+		}
+
+		policy, err := json.Marshal(automated)
+		if err != nil {
+			return changed, fmt.Errorf("encode sync policy of %s: %w", apps[i].GetName(), err)
+		}
+
+		patch := fmt.Appendf(nil,
+			`{"metadata":{"annotations":{%q:%q}},"spec":{"syncPolicy":{"automated":null}}}`,
+			SuspendedAutoSyncAnnotation, policy,
+		)
+
+		err = r.patchApplication(ctx, apps[i].GetName(), patch)
+		if err != nil {
+			return changed, err
+		}
+
+		changed = append(changed, apps[i].GetName())
+	}
+
+	return changed, nil
+}
+
+// ResumeAutoSync restores the automated sync policy SuspendAutoSync removed
+// from the named Application, or from every suspended Application when name
+// is empty, and returns the names of those it changed. Applications without
+// the SuspendedAutoSyncAnnotation are left untouched.
+func (r *Reconciler) ResumeAutoSync(ctx context.Context, name string) ([]string, error) {
+	apps, err := r.selectApplications(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+
+	for i := range apps {
+		policy, ok := apps[i].GetAnnotations()[SuspendedAutoSyncAnnotation]
+		if !ok {
+			continue
+		}
+
+		if !json.Valid([]byte(policy)) {
+			policy = "{}"
+		}
+
+		patch := fmt.Appendf(nil,
+			`{"metadata":{"annotations":{%q:null}},"spec":{"syncPolicy":{"automated":%s}}}`,
+			SuspendedAutoSyncAnnotation, policy,
+		)
+
+		err = r.patchApplication(ctx, apps[i].GetName(), patch)
+		if err != nil {
+			return changed, err
+		}
+
+		changed = append(changed, apps[i].GetName())
+	}
+
+	return changed, nil
+}
+
+// selectApplications returns the named Application, or every Application
+// sorted by name when name is empty.
+func (r *Reconciler) selectApplications(ctx context.Context, name string) ([]unstructured.Unstructured, error) {
+	if name != "" {
+		app, err := r.applicationClient().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("%w: %s", ErrApplicationNotFound, name)
+			}
+
+			return nil, fmt.Errorf("get argocd application %s: %w", name, err)
+		}
+
+		return []unstructured.Unstructured{*app}, nil
+	}
+
+	list, err := r.applicationClient().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list argocd applications: %w", err)
+	}
+
+	slices.SortFunc(list.Items, func(a, b unstructured.Unstructured) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	return list.Items, nil
+}
+
+// patchApplication applies a JSON merge patch to the named Application.
+func (r *Reconciler) patchApplication(ctx context.Context, name string, patch []byte) error {
+	_, err := r.applicationClient().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("patch argocd application %s: %w", name, err)
+	}
+
+	return nil
+}
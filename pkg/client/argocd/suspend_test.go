@@ -0,0 +1,69 @@
+package argocd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newFakeAutoSyncApplication(name string) *unstructured.Unstructured {
+	app := newFakeApplication(name, "Synced", "Healthy")
+	app.Object["spec"] = map[string]any{
+		"syncPolicy": map[string]any{
+			"automated": map[string]any{"prune": true, "selfHeal": false},
+		},
+	}
+
+	return app
+}
+
+func TestSuspendAndResumeAutoSync(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rec := newTestArgoCDReconciler(
+		newFakeAutoSyncApplication("apps"),
+		newFakeApplication("manual", "Synced", "Healthy"),
+	)
+
+	suspended, err := rec.SuspendAutoSync(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps"}, suspended)
+
+	app, err := rec.Dynamic.Resource(applicationGVR).Namespace("argocd").Get(ctx, "apps", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	_, found, _ := unstructured.NestedMap(app.Object, "spec", "syncPolicy", "automated")
+	assert.False(t, found)
+	assert.JSONEq(t, `{"prune":true,"selfHeal":false}`, app.GetAnnotations()[argocd.SuspendedAutoSyncAnnotation])
+
+	again, err := rec.SuspendAutoSync(ctx, "apps")
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	resumed, err := rec.ResumeAutoSync(ctx, "apps")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps"}, resumed)
+
+	app, err = rec.Dynamic.Resource(applicationGVR).Namespace("argocd").Get(ctx, "apps", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	automated, found, _ := unstructured.NestedMap(app.Object, "spec", "syncPolicy", "automated")
+	require.True(t, found)
+	assert.Equal(t, map[string]any{"prune": true, "selfHeal": false}, automated)
+	assert.NotContains(t, app.GetAnnotations(), argocd.SuspendedAutoSyncAnnotation)
+}
+
+func TestSuspendAutoSync_ApplicationNotFound(t *testing.T) {
+	t.Parallel()
+
+	rec := newTestArgoCDReconciler()
+
+	_, err := rec.SuspendAutoSync(context.Background(), "missing")
+	require.ErrorIs(t, err, argocd.ErrApplicationNotFound)
+}
@@ -8,6 +8,7 @@
 //   - eksctl: eksctl CLI shim for EKS cluster management (shells out to an
 //     external eksctl binary)
 //   - flux: Flux CD GitOps controller client
+//   - grype: Grype container image vulnerability scanning
 //   - helm: Helm chart installation and management
 //   - k9s: Terminal UI for Kubernetes cluster interaction
 //   - klogutil: klog output redirection and suppression utilities
@@ -3,8 +3,10 @@ package docker
 import (
 	"context"
 	"io"
+	"net"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
@@ -71,6 +73,18 @@ type Client interface {
 	ContainerStart(ctx context.Context, container string, options container.StartOptions) error
 	// ContainerStop stops a container.
 	ContainerStop(ctx context.Context, container string, options container.StopOptions) error
+	// ContainerUpdate updates the resource constraints of a container.
+	ContainerUpdate(
+		ctx context.Context,
+		container string,
+		updateConfig container.UpdateConfig,
+	) (container.UpdateResponse, error)
+	// ContainerWait waits until a container meets the given condition.
+	ContainerWait(
+		ctx context.Context,
+		container string,
+		condition container.WaitCondition,
+	) (<-chan container.WaitResponse, <-chan error)
 	// CopyFromContainer copies a resource out of a container.
 	CopyFromContainer(
 		ctx context.Context,
@@ -84,6 +98,16 @@ type Client interface {
 		options container.CopyToContainerOptions,
 	) error
 
+	// DialHijack opens a hijacked connection to the Docker API, used to attach a
+	// BuildKit session to an image build.
+	DialHijack(ctx context.Context, url, proto string, meta map[string][]string) (net.Conn, error)
+
+	// ImageBuild builds an image from a tar build context.
+	ImageBuild(
+		ctx context.Context,
+		buildContext io.Reader,
+		options build.ImageBuildOptions,
+	) (build.ImageBuildResponse, error)
 	// ImageInspect returns the image information for the given image reference.
 	ImageInspect(
 		ctx context.Context,
@@ -92,6 +116,14 @@ type Client interface {
 	) (image.InspectResponse, error)
 	// ImagePull pulls an image from a registry.
 	ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error)
+	// ImagePush pushes an image to a registry.
+	ImagePush(ctx context.Context, ref string, options image.PushOptions) (io.ReadCloser, error)
+	// ImageSave streams the given images as a docker save tar archive.
+	ImageSave(
+		ctx context.Context,
+		imageIDs []string,
+		saveOpts ...client.ImageSaveOption,
+	) (io.ReadCloser, error)
 
 	// NetworkConnect connects a container to an existing network.
 	NetworkConnect(
@@ -120,6 +152,8 @@ type Client interface {
 
 	// Ping pings the Docker server and returns its capabilities.
 	Ping(ctx context.Context) (types.Ping, error)
+	// ServerVersion returns the version and platform of the Docker daemon.
+	ServerVersion(ctx context.Context) (types.Version, error)
 
 	// VolumeCreate creates a new volume.
 	VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error)
@@ -0,0 +1,154 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ErrEmulationSetup is returned when the QEMU emulators cannot be installed on
+// the Docker host.
+var ErrEmulationSetup = errors.New("install QEMU emulators")
+
+// BinfmtImageName is the image that registers QEMU emulators as binfmt_misc
+// handlers of the Docker host, the same one docker/setup-qemu-action uses.
+const BinfmtImageName = "tonistiigi/binfmt:latest"
+
+// DaemonPlatform returns the os/arch platform of the Docker daemon, such as
+// linux/amd64.
+func DaemonPlatform(ctx context.Context, dockerClient Client) (string, error) {
+	version, err := dockerClient.ServerVersion(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get docker daemon version: %w", err)
+	}
+
+	return version.Os + "/" + version.Arch, nil
+}
+
+// ForeignArchitectures returns the architectures of the comma-separated
+// platforms, such as linux/amd64,linux/arm64, that differ from the architecture
+// of daemonPlatform, sorted and without duplicates.
+func ForeignArchitectures(platforms, daemonPlatform string) []string {
+	daemonArch := platformArchitecture(daemonPlatform)
+
+	var foreign []string
+
+	for platform := range strings.SplitSeq(platforms, ",") {
+		arch := platformArchitecture(platform)
+		if arch != "" && arch != daemonArch && !slices.Contains(foreign, arch) {
+			foreign = append(foreign, arch)
+		}
+	}
+
+	slices.Sort(foreign)
+
+	return foreign
+}
+
+// EnsureEmulation installs QEMU emulators on the Docker host for every
+// architecture of the comma-separated platforms the daemon does not run
+// natively, so BuildKit can build images for them. It does nothing when every
+// platform is native. Installing is idempotent: registered emulators are kept.
+func EnsureEmulation(ctx context.Context, dockerClient Client, platforms string) error {
+	daemonPlatform, err := DaemonPlatform(ctx, dockerClient)
+	if err != nil {
+		return err
+	}
+
+	architectures := ForeignArchitectures(platforms, daemonPlatform)
+	if len(architectures) == 0 {
+		return nil
+	}
+
+	_, err = dockerClient.ImageInspect(ctx, BinfmtImageName)
+	if err != nil {
+		err = PullImage(ctx, dockerClient, BinfmtImageName)
+		if err != nil {
+			return fmt.Errorf("%w: pull %s: %w", ErrEmulationSetup, BinfmtImageName, err)
+		}
+	}
+
+	return runBinfmt(ctx, dockerClient, architectures)
+}
+
+// runBinfmt runs the binfmt image privileged to register the emulators of
+// architectures, and removes its container afterwards.
+func runBinfmt(ctx context.Context, dockerClient Client, architectures []string) error {
+	created, err := dockerClient.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: BinfmtImageName,
+			Cmd:   []string{"--install", strings.Join(architectures, ",")},
+		},
+		&container.HostConfig{Privileged: true},
+		nil,
+		nil,
+		"",
+	)
+	if err != nil {
+		return fmt.Errorf("%w: create container: %w", ErrEmulationSetup, err)
+	}
+
+	defer func() {
+		_ = dockerClient.ContainerRemove(context.WithoutCancel(ctx), created.ID,
+			container.RemoveOptions{Force: true})
+	}()
+
+	err = dockerClient.ContainerStart(ctx, created.ID, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("%w: start container: %w", ErrEmulationSetup, err)
+	}
+
+	statusCh, errCh := dockerClient.ContainerWait(ctx, created.ID, container.WaitConditionNotRunning)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("%w: wait for container: %w", ErrEmulationSetup, err)
+	case status := <-statusCh:
+		if status.StatusCode == 0 {
+			return nil
+		}
+
+		return fmt.Errorf("%w for %s: exit code %d: %s", ErrEmulationSetup,
+			strings.Join(architectures, ", "), status.StatusCode, containerOutput(ctx, dockerClient, created.ID))
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrEmulationSetup, ctx.Err())
+	}
+}
+
+// containerOutput returns the trimmed logs of a stopped container, or an empty
+// string when they cannot be read.
+func containerOutput(ctx context.Context, dockerClient Client, containerID string) string {
+	logs, err := dockerClient.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return ""
+	}
+
+	defer func() { _ = logs.Close() }()
+
+	var output bytes.Buffer
+
+	_, _ = stdcopy.StdCopy(&output, &output, logs)
+
+	return strings.TrimSpace(output.String())
+}
+
+// platformArchitecture returns the architecture of an os/arch[/variant]
+// platform, or an empty string when platform has none.
+func platformArchitecture(platform string) string {
+	parts := strings.Split(strings.TrimSpace(platform), "/")
+	if len(parts) < 2 { //nolint:mnd // os/arch has two parts.
+		return ""
+	}
+
+	return parts[1]
+}
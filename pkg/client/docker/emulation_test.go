@@ -0,0 +1,110 @@
+package docker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignArchitectures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		platforms string
+		want      []string
+	}{
+		{name: "native platform", platforms: "linux/amd64", want: nil},
+		{name: "foreign platform", platforms: "linux/arm64", want: []string{"arm64"}},
+		{name: "mixed platforms", platforms: "linux/arm64,linux/amd64", want: []string{"arm64"}},
+		{name: "variants share an architecture", platforms: "linux/arm/v7,linux/arm/v6", want: []string{"arm"}},
+		{name: "sorted", platforms: "linux/s390x, linux/arm64", want: []string{"arm64", "s390x"}},
+		{name: "no platform", platforms: "", want: nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, docker.ForeignArchitectures(testCase.platforms, "linux/amd64"))
+		})
+	}
+}
+
+func TestEnsureEmulation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("does nothing for native platforms", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := docker.NewMockAPIClient(t)
+		mockClient.EXPECT().ServerVersion(mock.Anything).Return(types.Version{Os: "linux", Arch: "arm64"}, nil)
+
+		require.NoError(t, docker.EnsureEmulation(context.Background(), mockClient, "linux/arm64"))
+	})
+
+	t.Run("installs emulators for foreign architectures", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := expectBinfmtRun(t, 0)
+
+		require.NoError(t, docker.EnsureEmulation(context.Background(), mockClient, "linux/amd64,linux/arm64"))
+	})
+
+	t.Run("returns error when the installer fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := expectBinfmtRun(t, 1)
+		mockClient.EXPECT().
+			ContainerLogs(mock.Anything, "binfmt", mock.Anything).
+			Return(nil, assert.AnError)
+
+		err := docker.EnsureEmulation(context.Background(), mockClient, "linux/arm64")
+
+		require.ErrorIs(t, err, docker.ErrEmulationSetup)
+		assert.Contains(t, err.Error(), "exit code 1")
+	})
+}
+
+// expectBinfmtRun expects the binfmt installer to run for arm64 on an amd64
+// daemon and exit with exitCode.
+func expectBinfmtRun(t *testing.T, exitCode int64) *docker.MockAPIClient {
+	t.Helper()
+
+	mockClient := docker.NewMockAPIClient(t)
+	mockClient.EXPECT().ServerVersion(mock.Anything).Return(types.Version{Os: "linux", Arch: "amd64"}, nil)
+	mockClient.EXPECT().
+		ImageInspect(mock.Anything, docker.BinfmtImageName).
+		Return(image.InspectResponse{}, nil)
+	mockClient.EXPECT().
+		ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").
+		RunAndReturn(func(
+			_ context.Context, config *container.Config, hostConfig *container.HostConfig,
+			_ *network.NetworkingConfig, _ *ocispec.Platform, _ string,
+		) (container.CreateResponse, error) {
+			assert.Equal(t, []string{"--install", "arm64"}, []string(config.Cmd))
+			assert.True(t, hostConfig.Privileged)
+
+			return container.CreateResponse{ID: "binfmt"}, nil
+		})
+	mockClient.EXPECT().ContainerStart(mock.Anything, "binfmt", mock.Anything).Return(nil)
+
+	statusCh := make(chan container.WaitResponse, 1)
+	statusCh <- container.WaitResponse{StatusCode: exitCode}
+
+	mockClient.EXPECT().
+		ContainerWait(mock.Anything, "binfmt", container.WaitConditionNotRunning).
+		Return(statusCh, make(chan error))
+	mockClient.EXPECT().ContainerRemove(mock.Anything, "binfmt", mock.Anything).Return(nil)
+
+	return mockClient
+}
@@ -74,5 +74,5 @@ func (rm *RegistryManager) ExportBuildProxyCredentialsEnv(
 
 // ExportBuildHealthcheck exports buildHealthcheck for testing.
 func ExportBuildHealthcheck() *dockertypes.HealthConfig {
-	return buildHealthcheck()
+	return buildHealthcheck(false)
 }
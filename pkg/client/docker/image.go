@@ -1,16 +1,29 @@
 package docker
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/docker/docker/api/types/build"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
 )
 
 // PullImage performs a single Docker image pull and consumes the output stream.
 // This is the shared implementation used by both the registry manager and the Talos provisioner.
+// The pull holds a network slot of the notify.Slots carried by ctx, if any.
 func PullImage(ctx context.Context, dockerClient Client, imageName string) error {
+	release, err := notify.AcquireSlot(ctx, notify.WeightNetwork)
+	if err != nil {
+		return fmt.Errorf("image pull request: %w", err)
+	}
+
+	defer release()
+
 	reader, err := dockerClient.ImagePull(ctx, imageName, image.PullOptions{})
 	if err != nil {
 		return fmt.Errorf("image pull request: %w", err)
@@ -30,3 +43,69 @@ func PullImage(ctx context.Context, dockerClient Client, imageName string) error
 
 	return nil
 }
+
+// BuildImage builds dockerfile into an image tagged tag. The build context holds
+// only the Dockerfile, so instructions must not COPY local files. Build output
+// is streamed to output (io.Discard to drop it); a failing build step is
+// returned as an error.
+func BuildImage(
+	ctx context.Context,
+	dockerClient Client,
+	tag, dockerfile string,
+	output io.Writer,
+) error {
+	buildContext, err := dockerfileBuildContext(dockerfile)
+	if err != nil {
+		return err
+	}
+
+	response, err := dockerClient.ImageBuild(ctx, buildContext, build.ImageBuildOptions{
+		Tags:        []string{tag},
+		Dockerfile:  "Dockerfile",
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return fmt.Errorf("image build request: %w", err)
+	}
+
+	defer func() { _ = response.Body.Close() }()
+
+	err = jsonmessage.DisplayJSONMessagesStream(response.Body, output, 0, false, nil)
+	if err != nil {
+		return fmt.Errorf("build image %s: %w", tag, err)
+	}
+
+	return nil
+}
+
+// dockerfileBuildContext returns a tar archive holding dockerfile as the only
+// file of a build context.
+func dockerfileBuildContext(dockerfile string) (io.Reader, error) {
+	var buffer bytes.Buffer
+
+	writer := tar.NewWriter(&buffer)
+
+	const dockerfileMode = 0o644
+
+	err := writer.WriteHeader(&tar.Header{
+		Name: "Dockerfile",
+		Mode: dockerfileMode,
+		Size: int64(len(dockerfile)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("write build context header: %w", err)
+	}
+
+	_, err = writer.Write([]byte(dockerfile))
+	if err != nil {
+		return nil, fmt.Errorf("write build context Dockerfile: %w", err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close build context: %w", err)
+	}
+
+	return &buffer, nil
+}
@@ -0,0 +1,244 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dockerconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/pkg/jsonmessage"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/auth/authprovider"
+	archive "github.com/moby/go-archive"
+	"github.com/moby/patternmatcher/ignorefile"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidBuildContext is returned when the build context or Dockerfile of an
+// image build cannot be used.
+var ErrInvalidBuildContext = errors.New("invalid build context")
+
+const (
+	// defaultDockerfile is the Dockerfile name used when ImageBuildSpec.Dockerfile is empty.
+	defaultDockerfile = "Dockerfile"
+	// dockerIgnoreFile lists the build context paths excluded from the upload.
+	dockerIgnoreFile = ".dockerignore"
+	// buildkitTraceID identifies the aux messages carrying BuildKit progress.
+	buildkitTraceID = "moby.buildkit.trace"
+	// sessionEndpoint is the Docker API endpoint BuildKit sessions are attached on.
+	sessionEndpoint = "/session"
+)
+
+// ImageBuildSpec describes an image build from a local build context.
+type ImageBuildSpec struct {
+	// ContextDir is the directory sent to the builder as build context.
+	ContextDir string
+	// Dockerfile is the Dockerfile path relative to ContextDir. Defaults to "Dockerfile".
+	Dockerfile string
+	// Tags are the references the built image is tagged with.
+	Tags []string
+	// BuildArgs are passed to ARG instructions.
+	BuildArgs map[string]string
+	// Target is the build stage to build. Empty builds the last stage.
+	Target string
+	// Platform is the platform to build for, such as linux/arm64. Empty builds for the daemon's platform.
+	Platform string
+	// NoCache disables the build cache.
+	NoCache bool
+}
+
+// BuildImageFromContext builds spec with the BuildKit builder of the Docker
+// daemon. The build context is uploaded without the paths matched by its
+// .dockerignore, and base images are pulled with the credentials of the local
+// Docker config. Build progress is streamed to output (io.Discard to drop it);
+// a failing build step is returned as an error.
+func BuildImageFromContext(
+	ctx context.Context,
+	dockerClient Client,
+	spec ImageBuildSpec,
+	output io.Writer,
+) error {
+	dockerfile := spec.Dockerfile
+	if dockerfile == "" {
+		dockerfile = defaultDockerfile
+	}
+
+	buildContext, err := tarBuildContext(spec.ContextDir, dockerfile)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = buildContext.Close() }()
+
+	buildSession, err := session.NewSession(ctx, "")
+	if err != nil {
+		return fmt.Errorf("create build session: %w", err)
+	}
+
+	defer func() { _ = buildSession.Close() }()
+
+	buildSession.Allow(authprovider.NewDockerAuthProvider(authprovider.DockerAuthProviderConfig{
+		AuthConfigProvider: authprovider.LoadAuthConfig(dockerconfig.LoadDefaultConfigFile(io.Discard)),
+	}))
+
+	go func() {
+		_ = buildSession.Run(ctx, func(
+			ctx context.Context,
+			proto string,
+			meta map[string][]string,
+		) (net.Conn, error) {
+			return dockerClient.DialHijack(ctx, sessionEndpoint, proto, meta)
+		})
+	}()
+
+	response, err := dockerClient.ImageBuild(ctx, buildContext, build.ImageBuildOptions{
+		Version:    build.BuilderBuildKit,
+		SessionID:  buildSession.ID(),
+		Tags:       spec.Tags,
+		Dockerfile: filepath.ToSlash(dockerfile),
+		BuildArgs:  buildArgs(spec.BuildArgs),
+		Target:     spec.Target,
+		Platform:   spec.Platform,
+		NoCache:    spec.NoCache,
+		Remove:     true,
+	})
+	if err != nil {
+		return fmt.Errorf("image build request: %w", err)
+	}
+
+	defer func() { _ = response.Body.Close() }()
+
+	progress := newBuildProgress(output)
+
+	err = jsonmessage.DisplayJSONMessagesStream(response.Body, output, 0, false, progress.handleAux)
+	if err != nil {
+		return fmt.Errorf("build image %s: %w", strings.Join(spec.Tags, ", "), err)
+	}
+
+	return nil
+}
+
+// tarBuildContext archives contextDir without the paths its .dockerignore
+// excludes. The Dockerfile and .dockerignore are always sent, as the builder
+// needs them even when they are ignored.
+func tarBuildContext(contextDir, dockerfile string) (io.ReadCloser, error) {
+	info, err := os.Stat(contextDir)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s is not a directory", ErrInvalidBuildContext, contextDir)
+	}
+
+	if !filepath.IsLocal(dockerfile) {
+		return nil, fmt.Errorf("%w: Dockerfile %s must be inside %s", ErrInvalidBuildContext, dockerfile, contextDir)
+	}
+
+	_, err = os.Stat(filepath.Join(contextDir, dockerfile))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBuildContext, err)
+	}
+
+	excludes, err := readDockerIgnore(contextDir)
+	if err != nil {
+		return nil, err
+	}
+
+	excludes = append(excludes, "!"+filepath.ToSlash(dockerfile), "!"+dockerIgnoreFile)
+
+	buildContext, err := archive.TarWithOptions(contextDir, &archive.TarOptions{ExcludePatterns: excludes})
+	if err != nil {
+		return nil, fmt.Errorf("archive build context: %w", err)
+	}
+
+	return buildContext, nil
+}
+
+// readDockerIgnore returns the exclude patterns of the .dockerignore in
+// contextDir, or none when it has no .dockerignore.
+func readDockerIgnore(contextDir string) ([]string, error) {
+	file, err := os.Open(filepath.Join(contextDir, dockerIgnoreFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", dockerIgnoreFile, err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	patterns, err := ignorefile.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", dockerIgnoreFile, err)
+	}
+
+	return patterns, nil
+}
+
+// buildArgs converts build args into the pointer map the Docker API expects.
+func buildArgs(args map[string]string) map[string]*string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	converted := make(map[string]*string, len(args))
+
+	for name, value := range args {
+		converted[name] = &value
+	}
+
+	return converted
+}
+
+// buildProgress prints the steps and logs of a BuildKit build from the trace
+// aux messages of the build response.
+type buildProgress struct {
+	output  io.Writer
+	started map[string]bool
+}
+
+func newBuildProgress(output io.Writer) *buildProgress {
+	return &buildProgress{output: output, started: map[string]bool{}}
+}
+
+// handleAux prints the vertices a BuildKit trace message starts and the logs
+// it carries. Messages that are not BuildKit traces are ignored.
+func (p *buildProgress) handleAux(message jsonmessage.JSONMessage) {
+	if message.ID != buildkitTraceID || message.Aux == nil {
+		return
+	}
+
+	var encoded []byte
+
+	err := json.Unmarshal(*message.Aux, &encoded)
+	if err != nil {
+		return
+	}
+
+	var status controlapi.StatusResponse
+
+	err = proto.Unmarshal(encoded, &status)
+	if err != nil {
+		return
+	}
+
+	for _, vertex := range status.GetVertexes() {
+		if vertex.GetStarted() == nil || p.started[vertex.GetDigest()] {
+			continue
+		}
+
+		p.started[vertex.GetDigest()] = true
+
+		_, _ = fmt.Fprintf(p.output, "=> %s\n", vertex.GetName())
+	}
+
+	for _, log := range status.GetLogs() {
+		_, _ = p.output.Write(log.GetMsg())
+	}
+}
@@ -2,13 +2,17 @@
 package docker_test
 
 import (
+	"archive/tar"
 	"context"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	docker "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/docker/docker/api/types/build"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -110,6 +114,101 @@ func TestPullImage(t *testing.T) {
 	})
 }
 
+func TestBuildImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("builds the Dockerfile as the only file of the context", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := docker.NewMockAPIClient(t)
+		dockerfile := "FROM kindest/node:v1.35.0\nRUN apt-get install -y nfs-common\n"
+
+		var contextFiles map[string]string
+
+		mockClient.EXPECT().
+			ImageBuild(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(
+				_ context.Context, buildContext io.Reader, options build.ImageBuildOptions,
+			) (build.ImageBuildResponse, error) {
+				assert.Equal(t, []string{"ksail/kind-node:test"}, options.Tags)
+				assert.Equal(t, "Dockerfile", options.Dockerfile)
+
+				contextFiles = readTarFiles(t, buildContext)
+
+				return build.ImageBuildResponse{
+					Body: io.NopCloser(strings.NewReader(`{"stream":"Step 1/2 : FROM kindest/node:v1.35.0\n"}`)),
+				}, nil
+			}).
+			Once()
+
+		var output strings.Builder
+
+		err := docker.BuildImage(context.Background(), mockClient, "ksail/kind-node:test", dockerfile, &output)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"Dockerfile": dockerfile}, contextFiles)
+		assert.Contains(t, output.String(), "Step 1/2")
+	})
+
+	t.Run("returns error when a build step fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := docker.NewMockAPIClient(t)
+
+		mockClient.EXPECT().
+			ImageBuild(mock.Anything, mock.Anything, mock.Anything).
+			Return(build.ImageBuildResponse{
+				Body: io.NopCloser(strings.NewReader(
+					`{"errorDetail":{"message":"exit code 100"},"error":"exit code 100"}`,
+				)),
+			}, nil).
+			Once()
+
+		err := docker.BuildImage(context.Background(), mockClient, "ksail/kind-node:test", "FROM scratch", io.Discard)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exit code 100")
+	})
+
+	t.Run("returns error when the build request fails", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := docker.NewMockAPIClient(t)
+
+		mockClient.EXPECT().
+			ImageBuild(mock.Anything, mock.Anything, mock.Anything).
+			Return(build.ImageBuildResponse{}, errors.New("daemon unavailable")).
+			Once()
+
+		err := docker.BuildImage(context.Background(), mockClient, "ksail/kind-node:test", "FROM scratch", io.Discard)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "image build request")
+	})
+}
+
+// readTarFiles returns the name and content of every file in a tar archive.
+func readTarFiles(t *testing.T, archive io.Reader) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+	reader := tar.NewReader(archive)
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		files[header.Name] = string(content)
+	}
+}
+
 // failingReader implements io.Reader that always returns an error.
 type failingReader struct {
 	err error
@@ -129,3 +228,76 @@ type failingCloser struct {
 func (c *failingCloser) Close() error {
 	return c.err
 }
+
+func TestBuildImageFromContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uploads the context without ignored files through BuildKit", func(t *testing.T) {
+		t.Parallel()
+
+		contextDir := t.TempDir()
+		writeContextFile(t, contextDir, "Dockerfile", "FROM scratch\nCOPY app /app\n")
+		writeContextFile(t, contextDir, "app", "binary")
+		writeContextFile(t, contextDir, "secret.env", "TOKEN=x")
+		writeContextFile(t, contextDir, ".dockerignore", "*.env\nDockerfile\n")
+
+		mockClient := docker.NewMockAPIClient(t)
+		mockClient.EXPECT().
+			DialHijack(mock.Anything, "/session", mock.Anything, mock.Anything).
+			Return(nil, errors.New("no session support")).
+			Maybe()
+
+		var contextFiles map[string]string
+
+		mockClient.EXPECT().
+			ImageBuild(mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(
+				_ context.Context, buildContext io.Reader, options build.ImageBuildOptions,
+			) (build.ImageBuildResponse, error) {
+				assert.Equal(t, build.BuilderBuildKit, options.Version)
+				assert.NotEmpty(t, options.SessionID)
+				assert.Equal(t, []string{"my-app:dev"}, options.Tags)
+				assert.Equal(t, "dev", *options.BuildArgs["VERSION"])
+
+				contextFiles = readTarFiles(t, buildContext)
+
+				return build.ImageBuildResponse{Body: io.NopCloser(strings.NewReader(""))}, nil
+			}).
+			Once()
+
+		err := docker.BuildImageFromContext(context.Background(), mockClient, docker.ImageBuildSpec{
+			ContextDir: contextDir,
+			Tags:       []string{"my-app:dev"},
+			BuildArgs:  map[string]string{"VERSION": "dev"},
+		}, io.Discard)
+
+		require.NoError(t, err)
+		assert.Contains(t, contextFiles, "Dockerfile")
+		assert.Contains(t, contextFiles, "app")
+		assert.NotContains(t, contextFiles, "secret.env")
+	})
+
+	t.Run("rejects a Dockerfile outside the context", func(t *testing.T) {
+		t.Parallel()
+
+		err := docker.BuildImageFromContext(context.Background(), docker.NewMockAPIClient(t),
+			docker.ImageBuildSpec{ContextDir: t.TempDir(), Dockerfile: "../Dockerfile"}, io.Discard)
+
+		require.ErrorIs(t, err, docker.ErrInvalidBuildContext)
+	})
+
+	t.Run("rejects a missing Dockerfile", func(t *testing.T) {
+		t.Parallel()
+
+		err := docker.BuildImageFromContext(context.Background(), docker.NewMockAPIClient(t),
+			docker.ImageBuildSpec{ContextDir: t.TempDir()}, io.Discard)
+
+		require.ErrorIs(t, err, docker.ErrInvalidBuildContext)
+	})
+}
+
+func writeContextFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
@@ -7,8 +7,10 @@ package docker
 import (
 	"context"
 	"io"
+	"net"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
@@ -786,6 +788,154 @@ func (_c *MockAPIClient_ContainerStop_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// ContainerUpdate provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) ContainerUpdate(ctx context.Context, container1 string, updateConfig container.UpdateConfig) (container.UpdateResponse, error) {
+	ret := _mock.Called(ctx, container1, updateConfig)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerUpdate")
+	}
+
+	var r0 container.UpdateResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, container.UpdateConfig) (container.UpdateResponse, error)); ok {
+		return returnFunc(ctx, container1, updateConfig)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, container.UpdateConfig) container.UpdateResponse); ok {
+		r0 = returnFunc(ctx, container1, updateConfig)
+	} else {
+		r0 = ret.Get(0).(container.UpdateResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, container.UpdateConfig) error); ok {
+		r1 = returnFunc(ctx, container1, updateConfig)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_ContainerUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContainerUpdate'
+type MockAPIClient_ContainerUpdate_Call struct {
+	*mock.Call
+}
+
+// ContainerUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - container1 string
+//   - updateConfig container.UpdateConfig
+func (_e *MockAPIClient_Expecter) ContainerUpdate(ctx interface{}, container1 interface{}, updateConfig interface{}) *MockAPIClient_ContainerUpdate_Call {
+	return &MockAPIClient_ContainerUpdate_Call{Call: _e.mock.On("ContainerUpdate", ctx, container1, updateConfig)}
+}
+
+func (_c *MockAPIClient_ContainerUpdate_Call) Run(run func(ctx context.Context, container1 string, updateConfig container.UpdateConfig)) *MockAPIClient_ContainerUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 container.UpdateConfig
+		if args[2] != nil {
+			arg2 = args[2].(container.UpdateConfig)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_ContainerUpdate_Call) Return(updateResponse container.UpdateResponse, err error) *MockAPIClient_ContainerUpdate_Call {
+	_c.Call.Return(updateResponse, err)
+	return _c
+}
+
+func (_c *MockAPIClient_ContainerUpdate_Call) RunAndReturn(run func(ctx context.Context, container1 string, updateConfig container.UpdateConfig) (container.UpdateResponse, error)) *MockAPIClient_ContainerUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ContainerWait provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) ContainerWait(ctx context.Context, container1 string, condition container.WaitCondition) (<-chan container.WaitResponse, <-chan error) {
+	ret := _mock.Called(ctx, container1, condition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerWait")
+	}
+
+	var r0 <-chan container.WaitResponse
+	var r1 <-chan error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, container.WaitCondition) (<-chan container.WaitResponse, <-chan error)); ok {
+		return returnFunc(ctx, container1, condition)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, container.WaitCondition) <-chan container.WaitResponse); ok {
+		r0 = returnFunc(ctx, container1, condition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan container.WaitResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, container.WaitCondition) <-chan error); ok {
+		r1 = returnFunc(ctx, container1, condition)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+	return r0, r1
+}
+
+// MockAPIClient_ContainerWait_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContainerWait'
+type MockAPIClient_ContainerWait_Call struct {
+	*mock.Call
+}
+
+// ContainerWait is a helper method to define mock.On call
+//   - ctx context.Context
+//   - container1 string
+//   - condition container.WaitCondition
+func (_e *MockAPIClient_Expecter) ContainerWait(ctx interface{}, container1 interface{}, condition interface{}) *MockAPIClient_ContainerWait_Call {
+	return &MockAPIClient_ContainerWait_Call{Call: _e.mock.On("ContainerWait", ctx, container1, condition)}
+}
+
+func (_c *MockAPIClient_ContainerWait_Call) Run(run func(ctx context.Context, container1 string, condition container.WaitCondition)) *MockAPIClient_ContainerWait_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 container.WaitCondition
+		if args[2] != nil {
+			arg2 = args[2].(container.WaitCondition)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_ContainerWait_Call) Return(waitResponseCh <-chan container.WaitResponse, errCh <-chan error) *MockAPIClient_ContainerWait_Call {
+	_c.Call.Return(waitResponseCh, errCh)
+	return _c
+}
+
+func (_c *MockAPIClient_ContainerWait_Call) RunAndReturn(run func(ctx context.Context, container1 string, condition container.WaitCondition) (<-chan container.WaitResponse, <-chan error)) *MockAPIClient_ContainerWait_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CopyFromContainer provides a mock function for the type MockAPIClient
 func (_mock *MockAPIClient) CopyFromContainer(ctx context.Context, container1 string, srcPath string) (io.ReadCloser, container.PathStat, error) {
 	ret := _mock.Called(ctx, container1, srcPath)
@@ -941,6 +1091,158 @@ func (_c *MockAPIClient_CopyToContainer_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// DialHijack provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) DialHijack(ctx context.Context, url string, proto string, meta map[string][]string) (net.Conn, error) {
+	ret := _mock.Called(ctx, url, proto, meta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DialHijack")
+	}
+
+	var r0 net.Conn
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, map[string][]string) (net.Conn, error)); ok {
+		return returnFunc(ctx, url, proto, meta)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, map[string][]string) net.Conn); ok {
+		r0 = returnFunc(ctx, url, proto, meta)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(net.Conn)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, map[string][]string) error); ok {
+		r1 = returnFunc(ctx, url, proto, meta)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_DialHijack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DialHijack'
+type MockAPIClient_DialHijack_Call struct {
+	*mock.Call
+}
+
+// DialHijack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - proto string
+//   - meta map[string][]string
+func (_e *MockAPIClient_Expecter) DialHijack(ctx interface{}, url interface{}, proto interface{}, meta interface{}) *MockAPIClient_DialHijack_Call {
+	return &MockAPIClient_DialHijack_Call{Call: _e.mock.On("DialHijack", ctx, url, proto, meta)}
+}
+
+func (_c *MockAPIClient_DialHijack_Call) Run(run func(ctx context.Context, url string, proto string, meta map[string][]string)) *MockAPIClient_DialHijack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 map[string][]string
+		if args[3] != nil {
+			arg3 = args[3].(map[string][]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_DialHijack_Call) Return(conn net.Conn, err error) *MockAPIClient_DialHijack_Call {
+	_c.Call.Return(conn, err)
+	return _c
+}
+
+func (_c *MockAPIClient_DialHijack_Call) RunAndReturn(run func(ctx context.Context, url string, proto string, meta map[string][]string) (net.Conn, error)) *MockAPIClient_DialHijack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImageBuild provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) ImageBuild(ctx context.Context, buildContext io.Reader, options build.ImageBuildOptions) (build.ImageBuildResponse, error) {
+	ret := _mock.Called(ctx, buildContext, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImageBuild")
+	}
+
+	var r0 build.ImageBuildResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader, build.ImageBuildOptions) (build.ImageBuildResponse, error)); ok {
+		return returnFunc(ctx, buildContext, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader, build.ImageBuildOptions) build.ImageBuildResponse); ok {
+		r0 = returnFunc(ctx, buildContext, options)
+	} else {
+		r0 = ret.Get(0).(build.ImageBuildResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, io.Reader, build.ImageBuildOptions) error); ok {
+		r1 = returnFunc(ctx, buildContext, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_ImageBuild_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImageBuild'
+type MockAPIClient_ImageBuild_Call struct {
+	*mock.Call
+}
+
+// ImageBuild is a helper method to define mock.On call
+//   - ctx context.Context
+//   - buildContext io.Reader
+//   - options build.ImageBuildOptions
+func (_e *MockAPIClient_Expecter) ImageBuild(ctx interface{}, buildContext interface{}, options interface{}) *MockAPIClient_ImageBuild_Call {
+	return &MockAPIClient_ImageBuild_Call{Call: _e.mock.On("ImageBuild", ctx, buildContext, options)}
+}
+
+func (_c *MockAPIClient_ImageBuild_Call) Run(run func(ctx context.Context, buildContext io.Reader, options build.ImageBuildOptions)) *MockAPIClient_ImageBuild_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Reader
+		if args[1] != nil {
+			arg1 = args[1].(io.Reader)
+		}
+		var arg2 build.ImageBuildOptions
+		if args[2] != nil {
+			arg2 = args[2].(build.ImageBuildOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_ImageBuild_Call) Return(imageBuildResponse build.ImageBuildResponse, err error) *MockAPIClient_ImageBuild_Call {
+	_c.Call.Return(imageBuildResponse, err)
+	return _c
+}
+
+func (_c *MockAPIClient_ImageBuild_Call) RunAndReturn(run func(ctx context.Context, buildContext io.Reader, options build.ImageBuildOptions) (build.ImageBuildResponse, error)) *MockAPIClient_ImageBuild_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ImageInspect provides a mock function for the type MockAPIClient
 func (_mock *MockAPIClient) ImageInspect(ctx context.Context, image1 string, inspectOpts ...client.ImageInspectOption) (image.InspectResponse, error) {
 	var tmpRet mock.Arguments
@@ -1096,6 +1398,163 @@ func (_c *MockAPIClient_ImagePull_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// ImagePush provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) ImagePush(ctx context.Context, ref string, options image.PushOptions) (io.ReadCloser, error) {
+	ret := _mock.Called(ctx, ref, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImagePush")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, image.PushOptions) (io.ReadCloser, error)); ok {
+		return returnFunc(ctx, ref, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, image.PushOptions) io.ReadCloser); ok {
+		r0 = returnFunc(ctx, ref, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, image.PushOptions) error); ok {
+		r1 = returnFunc(ctx, ref, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_ImagePush_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImagePush'
+type MockAPIClient_ImagePush_Call struct {
+	*mock.Call
+}
+
+// ImagePush is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ref string
+//   - options image.PushOptions
+func (_e *MockAPIClient_Expecter) ImagePush(ctx interface{}, ref interface{}, options interface{}) *MockAPIClient_ImagePush_Call {
+	return &MockAPIClient_ImagePush_Call{Call: _e.mock.On("ImagePush", ctx, ref, options)}
+}
+
+func (_c *MockAPIClient_ImagePush_Call) Run(run func(ctx context.Context, ref string, options image.PushOptions)) *MockAPIClient_ImagePush_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 image.PushOptions
+		if args[2] != nil {
+			arg2 = args[2].(image.PushOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_ImagePush_Call) Return(readCloser io.ReadCloser, err error) *MockAPIClient_ImagePush_Call {
+	_c.Call.Return(readCloser, err)
+	return _c
+}
+
+func (_c *MockAPIClient_ImagePush_Call) RunAndReturn(run func(ctx context.Context, ref string, options image.PushOptions) (io.ReadCloser, error)) *MockAPIClient_ImagePush_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImageSave provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) ImageSave(ctx context.Context, imageIDs []string, saveOpts ...client.ImageSaveOption) (io.ReadCloser, error) {
+	var tmpRet mock.Arguments
+	if len(saveOpts) > 0 {
+		tmpRet = _mock.Called(ctx, imageIDs, saveOpts)
+	} else {
+		tmpRet = _mock.Called(ctx, imageIDs)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImageSave")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ...client.ImageSaveOption) (io.ReadCloser, error)); ok {
+		return returnFunc(ctx, imageIDs, saveOpts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ...client.ImageSaveOption) io.ReadCloser); ok {
+		r0 = returnFunc(ctx, imageIDs, saveOpts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, ...client.ImageSaveOption) error); ok {
+		r1 = returnFunc(ctx, imageIDs, saveOpts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_ImageSave_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImageSave'
+type MockAPIClient_ImageSave_Call struct {
+	*mock.Call
+}
+
+// ImageSave is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imageIDs []string
+//   - saveOpts ...client.ImageSaveOption
+func (_e *MockAPIClient_Expecter) ImageSave(ctx interface{}, imageIDs interface{}, saveOpts ...interface{}) *MockAPIClient_ImageSave_Call {
+	return &MockAPIClient_ImageSave_Call{Call: _e.mock.On("ImageSave",
+		append([]interface{}{ctx, imageIDs}, saveOpts...)...)}
+}
+
+func (_c *MockAPIClient_ImageSave_Call) Run(run func(ctx context.Context, imageIDs []string, saveOpts ...client.ImageSaveOption)) *MockAPIClient_ImageSave_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 []client.ImageSaveOption
+		var variadicArgs []client.ImageSaveOption
+		if len(args) > 2 {
+			variadicArgs = args[2].([]client.ImageSaveOption)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_ImageSave_Call) Return(readCloser io.ReadCloser, err error) *MockAPIClient_ImageSave_Call {
+	_c.Call.Return(readCloser, err)
+	return _c
+}
+
+func (_c *MockAPIClient_ImageSave_Call) RunAndReturn(run func(ctx context.Context, imageIDs []string, saveOpts ...client.ImageSaveOption) (io.ReadCloser, error)) *MockAPIClient_ImageSave_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NetworkConnect provides a mock function for the type MockAPIClient
 func (_mock *MockAPIClient) NetworkConnect(ctx context.Context, network1 string, container1 string, config *network.EndpointSettings) error {
 	ret := _mock.Called(ctx, network1, container1, config)
@@ -1563,6 +2022,66 @@ func (_c *MockAPIClient_Ping_Call) RunAndReturn(run func(ctx context.Context) (t
 	return _c
 }
 
+// ServerVersion provides a mock function for the type MockAPIClient
+func (_mock *MockAPIClient) ServerVersion(ctx context.Context) (types.Version, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ServerVersion")
+	}
+
+	var r0 types.Version
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (types.Version, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) types.Version); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(types.Version)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIClient_ServerVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ServerVersion'
+type MockAPIClient_ServerVersion_Call struct {
+	*mock.Call
+}
+
+// ServerVersion is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAPIClient_Expecter) ServerVersion(ctx interface{}) *MockAPIClient_ServerVersion_Call {
+	return &MockAPIClient_ServerVersion_Call{Call: _e.mock.On("ServerVersion", ctx)}
+}
+
+func (_c *MockAPIClient_ServerVersion_Call) Run(run func(ctx context.Context)) *MockAPIClient_ServerVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIClient_ServerVersion_Call) Return(version types.Version, err error) *MockAPIClient_ServerVersion_Call {
+	_c.Call.Return(version, err)
+	return _c
+}
+
+func (_c *MockAPIClient_ServerVersion_Call) RunAndReturn(run func(ctx context.Context) (types.Version, error)) *MockAPIClient_ServerVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // VolumeCreate provides a mock function for the type MockAPIClient
 func (_mock *MockAPIClient) VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error) {
 	ret := _mock.Called(ctx, options)
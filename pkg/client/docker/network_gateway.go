@@ -0,0 +1,36 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// ErrNoNetworkGateway is returned when a Docker network has no IPv4 gateway.
+var ErrNoNetworkGateway = errors.New("network has no IPv4 gateway")
+
+// ResolveNetworkGateway returns the IPv4 gateway of a Docker network. On Linux
+// the gateway is the host's address on the bridge, so containers attached to
+// the network — such as cluster nodes — reach processes on the host through it.
+func ResolveNetworkGateway(
+	ctx context.Context,
+	dockerClient Client,
+	networkName string,
+) (string, error) {
+	inspect, err := dockerClient.NetworkInspect(ctx, networkName, network.InspectOptions{})
+	if err != nil {
+		return "", fmt.Errorf("inspect network %s: %w", networkName, err)
+	}
+
+	for _, config := range inspect.IPAM.Config {
+		gateway := net.ParseIP(config.Gateway)
+		if gateway != nil && gateway.To4() != nil {
+			return gateway.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrNoNetworkGateway, networkName)
+}
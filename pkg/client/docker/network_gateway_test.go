@@ -0,0 +1,74 @@
+package docker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	docker "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/docker/docker/api/types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveNetworkGateway(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		inspect     network.Inspect
+		inspectErr  error
+		expectedIP  string
+		expectedErr error
+	}{
+		{
+			name: "returns the IPv4 gateway",
+			inspect: network.Inspect{IPAM: network.IPAM{Config: []network.IPAMConfig{
+				{Subnet: "fc00:f853:ccd:e793::/64", Gateway: "fc00:f853:ccd:e793::1"},
+				{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"},
+			}}},
+			expectedIP: "172.18.0.1",
+		},
+		{
+			name: "returns ErrNoNetworkGateway without an IPv4 gateway",
+			inspect: network.Inspect{IPAM: network.IPAM{Config: []network.IPAMConfig{
+				{Subnet: "fc00:f853:ccd:e793::/64", Gateway: "fc00:f853:ccd:e793::1"},
+			}}},
+			expectedErr: docker.ErrNoNetworkGateway,
+		},
+		{
+			name: "returns the inspect error",
+			//nolint:err113 // Tests use controlled mock errors.
+			inspectErr: errors.New("network kind not found"),
+		},
+	}
+
+	for i := range tests {
+		testCase := tests[i]
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := docker.NewMockAPIClient(t)
+			ctx := context.Background()
+			mockClient.EXPECT().
+				NetworkInspect(ctx, "kind", network.InspectOptions{}).
+				Return(testCase.inspect, testCase.inspectErr).
+				Once()
+
+			gateway, err := docker.ResolveNetworkGateway(ctx, mockClient, "kind")
+
+			switch {
+			case testCase.expectedErr != nil:
+				require.ErrorIs(t, err, testCase.expectedErr)
+				assert.Empty(t, gateway)
+			case testCase.inspectErr != nil:
+				require.ErrorContains(t, err, "inspect network kind")
+				assert.Empty(t, gateway)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedIP, gateway)
+			}
+		})
+	}
+}
@@ -53,6 +53,9 @@ const (
 
 	// RegistryDataPath is the path inside the container where registry data is stored.
 	RegistryDataPath = "/var/lib/registry"
+	// RegistryCertsPath is the path inside the container the serving certificate
+	// directory is mounted at when the registry serves HTTPS.
+	RegistryCertsPath = "/certs"
 	// RegistryRestartPolicy defines the container restart policy.
 	RegistryRestartPolicy = "unless-stopped"
 
@@ -116,4 +119,16 @@ type RegistryConfig struct {
 	Username    string // Optional: username for upstream registry authentication (supports ${ENV_VAR} placeholders)
 
 	Password string
+	// Labels are extra Docker labels applied to the registry container (from
+	// spec.cluster.resourceMetadata). KSail's reserved registry label always wins.
+	Labels map[string]string
+	// TLSCertDir, when set, is a host directory holding tls.crt and tls.key; the
+	// registry then serves HTTPS with them instead of plain HTTP.
+	TLSCertDir string
+	// HostIP is the host address the registry port is published on. Defaults to
+	// RegistryHostIP; 0.0.0.0 makes the registry reachable from other machines.
+	HostIP string
+	// Publish publishes the Port of a mirror registry (one with UpstreamURL set)
+	// on the host. Mirrors are otherwise only reachable from the Docker network.
+	Publish bool
 }
@@ -0,0 +1,313 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ErrRegistryExecFailed is returned when a command run inside a registry container fails.
+var ErrRegistryExecFailed = errors.New("registry container command failed")
+
+// ErrInvalidBlobDigest is returned when a blob digest is not a sha256 digest.
+var ErrInvalidBlobDigest = errors.New("invalid blob digest")
+
+const (
+	// registryConfigPath is the distribution configuration file inside the registry image.
+	registryConfigPath = "/etc/distribution/config.yml"
+	// registryBlobsPath is where the filesystem storage driver keeps blob data.
+	registryBlobsPath = RegistryDataPath + "/docker/registry/v2/blobs/sha256"
+	// registryReposPath is where the filesystem storage driver keeps repository links.
+	registryReposPath = RegistryDataPath + "/docker/registry/v2/repositories"
+	// registryProxyRemoteURLEnv configures the upstream of a pull-through cache.
+	registryProxyRemoteURLEnv = "REGISTRY_PROXY_REMOTEURL"
+	// kibibyte is the unit du -k reports sizes in.
+	kibibyte = 1024
+	// blobStatFields is the number of fields listRegistryBlobsScript prints per blob.
+	blobStatFields = 4
+)
+
+// listRegistryBlobsScript prints "<size> <atime> <mtime> <path>" for every blob,
+// and nothing when the registry has not stored any blob yet.
+const listRegistryBlobsScript = `[ -d "$1" ] || exit 0
+find "$1" -type f -name data -exec stat -c '%s %X %Y %n' {} +`
+
+// deleteRegistryBlobsScript removes each blob and the manifest revisions that
+// point at it, so garbage collection never trips over a missing manifest.
+const deleteRegistryBlobsScript = `blobs=$1 repos=$2
+shift 2
+for d in "$@"; do
+  rm -rf "$blobs/$(echo "$d" | cut -c1-2)/$d"
+  [ -d "$repos" ] && find "$repos" -type d -path "*/_manifests/revisions/sha256/$d" -prune -exec rm -rf {} +
+done
+exit 0`
+
+// sha256HexPattern matches the hex part of a sha256 digest.
+var sha256HexPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// RegistryCache describes the storage of a KSail-managed registry container.
+type RegistryCache struct {
+	// Name is the registry container name.
+	Name string
+	// Upstream is the remote a pull-through cache fetches from, empty for a local registry.
+	Upstream string
+	// Running reports whether the container is running. Sizes of stopped registries are unknown.
+	Running bool
+	// Size is the number of bytes stored in the registry, zero when not running.
+	Size int64
+}
+
+// RegistryBlob is a blob stored by a registry.
+type RegistryBlob struct {
+	// Digest is the blob digest, such as sha256:<hex>.
+	Digest string
+	// Size is the blob size in bytes.
+	Size int64
+	// LastUsed is the later of the blob's access and modification time.
+	LastUsed time.Time
+}
+
+// ListRegistryCaches returns the storage of every KSail-managed registry container.
+func (rm *RegistryManager) ListRegistryCaches(ctx context.Context) ([]RegistryCache, error) {
+	containers, err := rm.listAllRegistryContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	caches := make([]RegistryCache, 0, len(containers))
+
+	for _, summary := range containers {
+		name := containerName(summary)
+		if name == "" {
+			continue
+		}
+
+		inspect, err := inspectContainer(ctx, rm.client, summary.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		cache := RegistryCache{
+			Name:     name,
+			Upstream: upstreamFromEnv(inspect),
+			Running:  inspect.State != nil && inspect.State.Running,
+		}
+
+		if cache.Running {
+			cache.Size, err = rm.registryDiskUsage(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		caches = append(caches, cache)
+	}
+
+	return caches, nil
+}
+
+// ListRegistryBlobs returns the blobs stored by a running registry container.
+func (rm *RegistryManager) ListRegistryBlobs(
+	ctx context.Context,
+	name string,
+) ([]RegistryBlob, error) {
+	output, err := rm.execInRegistry(
+		ctx,
+		name,
+		[]string{"sh", "-c", listRegistryBlobsScript, "sh", registryBlobsPath},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRegistryBlobs(output)
+}
+
+// DeleteRegistryBlobs deletes blobs from a running registry container. Deleting
+// blobs from a pull-through cache is safe: the next pull fetches them again.
+func (rm *RegistryManager) DeleteRegistryBlobs(
+	ctx context.Context,
+	name string,
+	digests []string,
+) error {
+	if len(digests) == 0 {
+		return nil
+	}
+
+	cmd := []string{"sh", "-c", deleteRegistryBlobsScript, "sh", registryBlobsPath, registryReposPath}
+
+	for _, digest := range digests {
+		hex := strings.TrimPrefix(digest, "sha256:")
+		if !sha256HexPattern.MatchString(hex) {
+			return fmt.Errorf("%w: %q", ErrInvalidBlobDigest, digest)
+		}
+
+		cmd = append(cmd, hex)
+	}
+
+	_, err := rm.execInRegistry(ctx, name, cmd)
+
+	return err
+}
+
+// GarbageCollectRegistry runs the distribution garbage collector inside a running
+// registry container, deleting blobs no manifest references. With deleteUntagged,
+// manifests no tag points to are deleted first.
+func (rm *RegistryManager) GarbageCollectRegistry(
+	ctx context.Context,
+	name string,
+	deleteUntagged bool,
+) error {
+	cmd := []string{"registry", "garbage-collect"}
+	if deleteUntagged {
+		cmd = append(cmd, "--delete-untagged")
+	}
+
+	_, err := rm.execInRegistry(ctx, name, append(cmd, registryConfigPath))
+
+	return err
+}
+
+// ParseRegistryBlobs parses the "<size> <atime> <mtime> <path>" lines printed by
+// stat for each blob data file.
+func ParseRegistryBlobs(output string) ([]RegistryBlob, error) {
+	var blobs []RegistryBlob
+
+	for line := range strings.Lines(output) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != blobStatFields {
+			return nil, fmt.Errorf("%w: unexpected blob listing %q", ErrRegistryExecFailed, line)
+		}
+
+		values := make([]int64, blobStatFields-1)
+
+		for i := range values {
+			value, err := strconv.ParseInt(fields[i], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: unexpected blob listing %q", ErrRegistryExecFailed, line)
+			}
+
+			values[i] = value
+		}
+
+		// <blobs>/sha256/<prefix>/<hex>/data
+		hex := path.Base(path.Dir(fields[3]))
+		if !sha256HexPattern.MatchString(hex) {
+			continue
+		}
+
+		blobs = append(blobs, RegistryBlob{
+			Digest:   "sha256:" + hex,
+			Size:     values[0],
+			LastUsed: time.Unix(max(values[1], values[2]), 0),
+		})
+	}
+
+	return blobs, nil
+}
+
+// registryDiskUsage returns the bytes stored under the registry data path.
+func (rm *RegistryManager) registryDiskUsage(ctx context.Context, name string) (int64, error) {
+	output, err := rm.execInRegistry(ctx, name, []string{"du", "-sk", RegistryDataPath})
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("%w: empty disk usage for %s", ErrRegistryExecFailed, name)
+	}
+
+	kib, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: unexpected disk usage %q", ErrRegistryExecFailed, fields[0])
+	}
+
+	return kib * kibibyte, nil
+}
+
+// execInRegistry runs a command inside a registry container and returns its stdout.
+func (rm *RegistryManager) execInRegistry(
+	ctx context.Context,
+	name string,
+	cmd []string,
+) (string, error) {
+	execID, err := rm.client.ContainerExecCreate(ctx, name, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create exec in %s: %w", name, err)
+	}
+
+	resp, err := rm.client.ContainerExecAttach(ctx, execID.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to attach to exec in %s: %w", name, err)
+	}
+	defer resp.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	_, err = stdcopy.StdCopy(&stdout, &stderr, resp.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read exec output from %s: %w", name, err)
+	}
+
+	inspect, err := rm.client.ContainerExecInspect(ctx, execID.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect exec in %s: %w", name, err)
+	}
+
+	if inspect.ExitCode != 0 {
+		return "", fmt.Errorf(
+			"%w: %s in %s exited with code %d: %s",
+			ErrRegistryExecFailed,
+			cmd[0],
+			name,
+			inspect.ExitCode,
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	return stdout.String(), nil
+}
+
+// containerName returns the first name of a container without its leading slash.
+func containerName(summary container.Summary) string {
+	for _, rawName := range summary.Names {
+		if trimmed := strings.TrimPrefix(rawName, "/"); trimmed != "" {
+			return trimmed
+		}
+	}
+
+	return ""
+}
+
+// upstreamFromEnv returns the pull-through cache upstream configured on a registry container.
+func upstreamFromEnv(inspect container.InspectResponse) string {
+	if inspect.Config == nil {
+		return ""
+	}
+
+	for _, env := range inspect.Config.Env {
+		if value, ok := strings.CutPrefix(env, registryProxyRemoteURLEnv+"="); ok {
+			return value
+		}
+	}
+
+	return ""
+}
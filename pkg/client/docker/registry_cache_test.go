@@ -0,0 +1,45 @@
+package docker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegistryBlobs(t *testing.T) {
+	t.Parallel()
+
+	hex := "4f4fb700ef54461cfa02571ae0db9a0dc1e0cdb5577484a6d75e68dc38e8acc1"
+	output := "32 1700000200 1700000100 /var/lib/registry/docker/registry/v2/blobs/sha256/4f/" +
+		hex + "/data\n\n"
+
+	blobs, err := docker.ParseRegistryBlobs(output)
+	require.NoError(t, err)
+	assert.Equal(t, []docker.RegistryBlob{
+		{Digest: "sha256:" + hex, Size: 32, LastUsed: time.Unix(1700000200, 0)},
+	}, blobs)
+
+	_, err = docker.ParseRegistryBlobs("garbage\n")
+	require.ErrorIs(t, err, docker.ErrRegistryExecFailed)
+}
+
+func TestParseRegistryTags(t *testing.T) {
+	t.Parallel()
+
+	output := "1700000100 /var/lib/registry/docker/registry/v2/repositories/team/apps/_manifests/tags/" +
+		"v1/current/link sha256:abc\n\n" +
+		"1700000200 /var/lib/registry/docker/registry/v2/repositories/k8s/_manifests/tags/dev/current/link sha256:def\n"
+
+	tags, err := docker.ParseRegistryTags(output)
+	require.NoError(t, err)
+	assert.Equal(t, []docker.RegistryTag{
+		{Repository: "team/apps", Tag: "v1", Digest: "sha256:abc", PushedAt: time.Unix(1700000100, 0)},
+		{Repository: "k8s", Tag: "dev", Digest: "sha256:def", PushedAt: time.Unix(1700000200, 0)},
+	}, tags)
+
+	_, err = docker.ParseRegistryTags("garbage\n")
+	require.ErrorIs(t, err, docker.ErrRegistryExecFailed)
+}
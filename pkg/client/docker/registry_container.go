@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
 
@@ -37,23 +38,30 @@ func (rm *RegistryManager) IsRegistryInUse(ctx context.Context, name string) (bo
 // GetRegistryPort returns the host port for a registry.
 // Returns ErrRegistryPortNotFound if the registry has no host port binding (e.g., mirror registries).
 func (rm *RegistryManager) GetRegistryPort(ctx context.Context, name string) (int, error) {
+	_, port, err := rm.registryHostBinding(ctx, name)
+
+	return port, err
+}
+
+// registryHostBinding returns the host address and port a registry is published on.
+func (rm *RegistryManager) registryHostBinding(ctx context.Context, name string) (string, int, error) {
 	containers, err := rm.listRegistryContainers(ctx, name)
 	if err != nil {
-		return 0, fmt.Errorf("failed to list registry containers: %w", err)
+		return "", 0, fmt.Errorf("failed to list registry containers: %w", err)
 	}
 
 	if len(containers) == 0 {
-		return 0, ErrRegistryNotFound
+		return "", 0, ErrRegistryNotFound
 	}
 
 	// Get port from container ports - look for a valid host port binding
 	for _, port := range containers[0].Ports {
 		if port.PrivatePort == DefaultRegistryPort && port.PublicPort > 0 {
-			return int(port.PublicPort), nil
+			return port.IP, int(port.PublicPort), nil
 		}
 	}
 
-	return 0, ErrRegistryPortNotFound
+	return "", 0, ErrRegistryPortNotFound
 }
 
 // IsContainerRunning checks if a container with the given exact name is running.
@@ -375,7 +383,11 @@ func (rm *RegistryManager) buildProxyCredentialsEnv(
 func (rm *RegistryManager) buildContainerConfig(
 	config RegistryConfig,
 ) (*container.Config, error) {
-	labels := map[string]string{}
+	labels := maps.Clone(config.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
 	if config.Name != "" {
 		labels[RegistryLabelKey] = config.Name
 	}
@@ -396,6 +408,14 @@ func (rm *RegistryManager) buildContainerConfig(
 		}
 	}
 
+	tlsEnabled := config.TLSCertDir != ""
+	if tlsEnabled {
+		env = append(env,
+			"REGISTRY_HTTP_TLS_CERTIFICATE="+RegistryCertsPath+"/tls.crt",
+			"REGISTRY_HTTP_TLS_KEY="+RegistryCertsPath+"/tls.key",
+		)
+	}
+
 	return &container.Config{
 		Image: RegistryImageName,
 		ExposedPorts: nat.PortSet{
@@ -403,7 +423,7 @@ func (rm *RegistryManager) buildContainerConfig(
 		},
 		Labels:      labels,
 		Env:         env,
-		Healthcheck: buildHealthcheck(),
+		Healthcheck: buildHealthcheck(tlsEnabled),
 	}, nil
 }
 
@@ -411,15 +431,21 @@ func (rm *RegistryManager) buildContainerConfig(
 // Docker periodically runs this check and marks the container as unhealthy after consecutive
 // failures, providing visibility into registry mirror issues via `docker ps` and `docker inspect`.
 // Uses wget (available in the Alpine-based registry image via BusyBox) to check the /v2/ endpoint,
-// which is the standard Docker Distribution health check endpoint.
-func buildHealthcheck() *container.HealthConfig {
+// which is the standard Docker Distribution health check endpoint. Registries serving HTTPS
+// are checked over HTTPS; BusyBox wget cannot be given a CA, so the certificate is not verified.
+func buildHealthcheck(tlsEnabled bool) *container.HealthConfig {
+	check := fmt.Sprintf("wget -q --spider http://localhost:%d/v2/", DefaultRegistryPort)
+	if tlsEnabled {
+		check = fmt.Sprintf(
+			"wget -q --spider --no-check-certificate https://localhost:%d/v2/",
+			DefaultRegistryPort,
+		)
+	}
+
 	return &container.HealthConfig{
 		Test: []string{
 			"CMD-SHELL",
-			fmt.Sprintf(
-				"wget -q --spider http://localhost:%d/v2/",
-				DefaultRegistryPort,
-			),
+			check,
 		},
 		Interval:    registryHealthcheckInterval,
 		Timeout:     registryHealthcheckTimeout,
@@ -430,18 +456,24 @@ func buildHealthcheck() *container.HealthConfig {
 
 // buildHostConfig builds the host configuration including port bindings and mounts.
 // Mirror registries (those with UpstreamURL set) do not need host port bindings
-// since they are accessed via Docker network by cluster nodes, not from the host.
+// since they are accessed via Docker network by cluster nodes, not from the host,
+// unless Publish asks for them to be reachable from the host as well.
 func (rm *RegistryManager) buildHostConfig(
 	config RegistryConfig,
 	volumeName string,
 ) *container.HostConfig {
 	portBindings := nat.PortMap{}
-	// Only bind to host port for local registries (not mirrors)
-	// Mirrors are accessed via Docker network, not from the host
-	if config.Port > 0 && config.UpstreamURL == "" {
+	// Only bind to host port for local registries and published mirrors
+	// Other mirrors are accessed via Docker network, not from the host
+	if config.Port > 0 && (config.UpstreamURL == "" || config.Publish) {
+		hostIP := config.HostIP
+		if hostIP == "" {
+			hostIP = RegistryHostIP
+		}
+
 		portBindings[RegistryContainerPort] = []nat.PortBinding{
 			{
-				HostIP:   RegistryHostIP,
+				HostIP:   hostIP,
 				HostPort: strconv.Itoa(config.Port),
 			},
 		}
@@ -455,6 +487,15 @@ func (rm *RegistryManager) buildHostConfig(
 		},
 	}
 
+	if config.TLSCertDir != "" {
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   config.TLSCertDir,
+			Target:   RegistryCertsPath,
+			ReadOnly: true,
+		})
+	}
+
 	return &container.HostConfig{
 		PortBindings: portBindings,
 		RestartPolicy: container.RestartPolicy{
@@ -6,6 +6,7 @@ import (
 
 	docker "github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -466,6 +467,28 @@ func TestBuildContainerConfig(t *testing.T) {
 		assert.False(t, hasLabel)
 	})
 
+	t.Run("config labels are applied without masking the registry label", func(t *testing.T) {
+		t.Parallel()
+
+		_, manager, _ := setupTestRegistryManager(t)
+
+		config := docker.RegistryConfig{
+			Name: "my-registry",
+			Port: 5000,
+			Labels: map[string]string{
+				"team":                  "platform",
+				docker.RegistryLabelKey: "spoofed",
+			},
+		}
+
+		cfg, err := manager.ExportBuildContainerConfig(config)
+
+		require.NoError(t, err)
+		assert.Equal(t, "platform", cfg.Labels["team"])
+		assert.Equal(t, "my-registry", cfg.Labels[docker.RegistryLabelKey])
+		assert.Equal(t, "spoofed", config.Labels[docker.RegistryLabelKey], "input must not be mutated")
+	})
+
 	t.Run("exposed ports always include registry port", func(t *testing.T) {
 		t.Parallel()
 
@@ -526,6 +549,27 @@ func TestBuildHostConfig(t *testing.T) {
 		assert.Empty(t, bindings)
 	})
 
+	t.Run("published mirror registry binds configured host IP", func(t *testing.T) {
+		t.Parallel()
+
+		_, manager, _ := setupTestRegistryManager(t)
+
+		config := docker.RegistryConfig{
+			Name:        "docker.io",
+			Port:        5001,
+			UpstreamURL: "https://registry-1.docker.io",
+			HostIP:      "0.0.0.0",
+			Publish:     true,
+		}
+
+		hostCfg := manager.ExportBuildHostConfig(config, "vol")
+
+		bindings := hostCfg.PortBindings[docker.RegistryContainerPort]
+		require.Len(t, bindings, 1)
+		assert.Equal(t, "0.0.0.0", bindings[0].HostIP)
+		assert.Equal(t, "5001", bindings[0].HostPort)
+	})
+
 	t.Run("zero port gets no binding", func(t *testing.T) {
 		t.Parallel()
 
@@ -762,3 +806,30 @@ func TestBuildContainerConfig_PartialCredentialsError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "proxy credentials")
 }
+
+func TestBuildContainerConfig_TLS(t *testing.T) {
+	t.Parallel()
+
+	_, manager, _ := setupTestRegistryManager(t)
+
+	config := docker.RegistryConfig{
+		Name:        "kind-docker.io",
+		UpstreamURL: "https://registry-1.docker.io",
+		TLSCertDir:  "/home/user/.ksail/registry-tls/kind-docker.io",
+	}
+
+	cfg, err := manager.ExportBuildContainerConfig(config)
+
+	require.NoError(t, err)
+	assert.Contains(t, cfg.Env, "REGISTRY_HTTP_TLS_CERTIFICATE=/certs/tls.crt")
+	assert.Contains(t, cfg.Env, "REGISTRY_HTTP_TLS_KEY=/certs/tls.key")
+	assert.Contains(t, cfg.Healthcheck.Test[1], "https://localhost:5000/v2/")
+
+	hostCfg := manager.ExportBuildHostConfig(config, "vol")
+
+	require.Len(t, hostCfg.Mounts, 2)
+	assert.Equal(t, mount.TypeBind, hostCfg.Mounts[1].Type)
+	assert.Equal(t, config.TLSCertDir, hostCfg.Mounts[1].Source)
+	assert.Equal(t, docker.RegistryCertsPath, hostCfg.Mounts[1].Target)
+	assert.True(t, hostCfg.Mounts[1].ReadOnly)
+}
@@ -134,17 +134,27 @@ func (rm *RegistryManager) prepareHealthCheck(
 		return "", fmt.Errorf("registry %s is not running: %w", name, ErrRegistryNotFound)
 	}
 
-	port, portErr := rm.GetRegistryPort(ctx, name)
+	hostIP, port, portErr := rm.registryHostBinding(ctx, name)
 	if portErr != nil {
 		return "", fmt.Errorf("failed to get registry port: %w", portErr)
 	}
 
-	return buildHealthCheckURL(port), nil
+	return buildHealthCheckURLForHost(hostIP, port), nil
 }
 
 // buildHealthCheckURL constructs the health check URL for a registry on the given port.
 func buildHealthCheckURL(port int) string {
-	checkAddr := net.JoinHostPort(RegistryHostIP, strconv.Itoa(port))
+	return buildHealthCheckURLForHost(RegistryHostIP, port)
+}
+
+// buildHealthCheckURLForHost constructs the health check URL for a registry published
+// on hostIP. Registries published on all interfaces are checked over loopback.
+func buildHealthCheckURLForHost(hostIP string, port int) string {
+	if ip := net.ParseIP(hostIP); ip == nil || ip.IsUnspecified() {
+		hostIP = RegistryHostIP
+	}
+
+	checkAddr := net.JoinHostPort(hostIP, strconv.Itoa(port))
 
 	return fmt.Sprintf("http://%s/v2/", checkAddr)
 }
@@ -0,0 +1,136 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRegistryTag is returned when a tag to delete has an invalid
+// repository or tag name.
+var ErrInvalidRegistryTag = errors.New("invalid registry tag")
+
+const (
+	// registryTagsMarker separates the repository from the tag in the path of a tag link.
+	registryTagsMarker = "/_manifests/tags/"
+	// tagStatFields is the number of fields listRegistryTagsScript prints per tag.
+	tagStatFields = 3
+)
+
+// listRegistryTagsScript prints "<mtime> <path> <digest>" for the current link
+// of every tag, and nothing when the registry has not stored any repository yet.
+// The link is rewritten on every push of the tag, so its mtime is the push time.
+const listRegistryTagsScript = `[ -d "$1" ] || exit 0
+find "$1" -type f -path '*/_manifests/tags/*/current/link' | while read -r f; do
+  printf '%s %s %s\n' "$(stat -c %Y "$f")" "$f" "$(cat "$f")"
+done`
+
+// deleteRegistryTagsScript removes the directory of each "<repository>/<tag>"
+// argument, untagging its manifest for garbage collection.
+const deleteRegistryTagsScript = `repos=$1
+shift
+for t in "$@"; do
+  rm -rf "$repos/${t%/*}/_manifests/tags/${t##*/}"
+done`
+
+var (
+	// repositoryNamePattern matches OCI distribution repository names.
+	repositoryNamePattern = regexp.MustCompile(
+		`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`,
+	)
+	// tagNamePattern matches OCI distribution tag names.
+	tagNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+)
+
+// RegistryTag is an artifact tag stored by a registry.
+type RegistryTag struct {
+	// Repository is the repository holding the tag, such as k8s or team/apps.
+	Repository string
+	// Tag is the tag name.
+	Tag string
+	// Digest is the digest of the manifest the tag points at.
+	Digest string
+	// PushedAt is when the tag was last pushed.
+	PushedAt time.Time
+}
+
+// ListRegistryTags returns the tags stored by a running registry container.
+func (rm *RegistryManager) ListRegistryTags(ctx context.Context, name string) ([]RegistryTag, error) {
+	output, err := rm.execInRegistry(
+		ctx,
+		name,
+		[]string{"sh", "-c", listRegistryTagsScript, "sh", registryReposPath},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRegistryTags(output)
+}
+
+// DeleteRegistryTags deletes tags from a running registry container. The
+// manifests and blobs of deleted tags stay on disk until the registry is
+// garbage-collected with untagged manifests deleted.
+func (rm *RegistryManager) DeleteRegistryTags(ctx context.Context, name string, tags []RegistryTag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	cmd := []string{"sh", "-c", deleteRegistryTagsScript, "sh", registryReposPath}
+
+	for _, tag := range tags {
+		if !repositoryNamePattern.MatchString(tag.Repository) || !tagNamePattern.MatchString(tag.Tag) {
+			return fmt.Errorf("%w: %s:%s", ErrInvalidRegistryTag, tag.Repository, tag.Tag)
+		}
+
+		cmd = append(cmd, tag.Repository+"/"+tag.Tag)
+	}
+
+	_, err := rm.execInRegistry(ctx, name, cmd)
+
+	return err
+}
+
+// ParseRegistryTags parses the "<mtime> <path> <digest>" lines printed for the
+// current link of each tag.
+func ParseRegistryTags(output string) ([]RegistryTag, error) {
+	var tags []RegistryTag
+
+	for line := range strings.Lines(output) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != tagStatFields {
+			return nil, fmt.Errorf("%w: unexpected tag listing %q", ErrRegistryExecFailed, line)
+		}
+
+		pushedAt, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unexpected tag listing %q", ErrRegistryExecFailed, line)
+		}
+
+		// <repos>/<repository>/_manifests/tags/<tag>/current/link
+		relative := strings.TrimPrefix(fields[1], registryReposPath+"/")
+		repository, tagPath, found := strings.Cut(relative, registryTagsMarker)
+		tag, _, _ := strings.Cut(tagPath, "/")
+
+		if !found || repository == "" || tag == "" {
+			continue
+		}
+
+		tags = append(tags, RegistryTag{
+			Repository: repository,
+			Tag:        tag,
+			Digest:     fields[2],
+			PushedAt:   time.Unix(pushedAt, 0),
+		})
+	}
+
+	return tags, nil
+}
@@ -88,9 +88,10 @@ func (ExecRunner) RunWithEnvironment(
 
 // Client is the eksctl CLI wrapper.
 type Client struct {
-	binary      string
-	runner      Runner
-	environment []string
+	binary            string
+	runner            Runner
+	environment       []string
+	environmentSource func(context.Context) ([]string, error)
 
 	requireCredentialValues bool
 }
@@ -127,6 +128,17 @@ func WithEnvironment(environment []string) Option {
 	}
 }
 
+// WithEnvironmentSource sets a function that returns the complete environment
+// for each eksctl child process. It is called once per invocation, so
+// credentials that expire (such as an assumed-role session) can be renewed
+// between the commands of a long operation. It takes precedence over
+// WithEnvironment.
+func WithEnvironmentSource(source func(context.Context) ([]string, error)) Option {
+	return func(c *Client) {
+		c.environmentSource = source
+	}
+}
+
 // RequireCredentialValues makes execution fail closed unless the explicit
 // child environment contains either a profile or a complete static credential
 // pair. Use it when custom source names were configured so an unset alias
@@ -144,6 +156,7 @@ func NewClient(opts ...Option) *Client {
 		binary:                  DefaultBinary,
 		runner:                  ExecRunner{},
 		environment:             nil,
+		environmentSource:       nil,
 		requireCredentialValues: false,
 	}
 
@@ -180,8 +193,6 @@ func (c *Client) CheckAvailable() error {
 // used directly when a helper has not been written yet.
 func (c *Client) Exec(ctx context.Context, args ...string) ([]byte, []byte, error) {
 	stdout, stderr, err := c.run(ctx, args, nil)
-
-	stderr = c.redactCredentialValues(stderr)
 	if err != nil {
 		return stdout, stderr, wrapExecErr(args, stderr, err)
 	}
@@ -197,8 +208,6 @@ func (c *Client) ExecWithStdin(
 	args ...string,
 ) ([]byte, []byte, error) {
 	stdout, stderr, err := c.run(ctx, args, stdin)
-
-	stderr = c.redactCredentialValues(stderr)
 	if err != nil {
 		return stdout, stderr, wrapExecErr(args, stderr, err)
 	}
@@ -206,18 +215,55 @@ func (c *Client) ExecWithStdin(
 	return stdout, stderr, nil
 }
 
-// run validates the credential boundary before invoking the runner with a defensive environment snapshot.
+// run validates the credential boundary before invoking the runner with a defensive environment
+// snapshot, and redacts the credential values of that environment from stderr.
 func (c *Client) run(
 	ctx context.Context,
 	args []string,
 	stdin io.Reader,
 ) ([]byte, []byte, error) {
-	err := c.validateCredentialValues()
+	environment, err := c.childEnvironment(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = c.validateCredentialValues(environment)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if c.environment == nil {
+	stdout, stderr, err := c.runWithEnvironment(ctx, args, stdin, environment)
+
+	return stdout, redactCredentialValues(environment, stderr), err
+}
+
+// childEnvironment returns the environment for one invocation: the source's
+// current environment when one is set, otherwise a copy of the fixed one.
+func (c *Client) childEnvironment(ctx context.Context) ([]string, error) {
+	if c.environmentSource == nil {
+		return cloneStrings(c.environment), nil
+	}
+
+	environment, err := c.environmentSource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("resolve eksctl environment: %w", err)
+	}
+
+	if environment == nil {
+		environment = []string{}
+	}
+
+	return environment, nil
+}
+
+// runWithEnvironment invokes the runner, inheriting the parent environment when environment is nil.
+func (c *Client) runWithEnvironment(
+	ctx context.Context,
+	args []string,
+	stdin io.Reader,
+	environment []string,
+) ([]byte, []byte, error) {
+	if environment == nil {
 		stdout, stderr, err := c.runner.Run(ctx, c.binary, args, stdin)
 		if err != nil {
 			return stdout, stderr, fmt.Errorf("run eksctl: %w", err)
@@ -236,7 +282,7 @@ func (c *Client) run(
 		c.binary,
 		args,
 		stdin,
-		cloneStrings(c.environment),
+		environment,
 	)
 	if err != nil {
 		return stdout, stderr, fmt.Errorf("run eksctl with explicit environment: %w", err)
@@ -246,12 +292,12 @@ func (c *Client) run(
 }
 
 // validateCredentialValues rejects incomplete or required-but-empty explicit selections before process execution.
-func (c *Client) validateCredentialValues() error {
+func (c *Client) validateCredentialValues(environment []string) error {
 	if !c.requireCredentialValues {
 		return nil
 	}
 
-	values := environmentValues(c.environment)
+	values := environmentValues(environment)
 	profile := values["AWS_PROFILE"]
 	accessKeyID := values["AWS_ACCESS_KEY_ID"]
 	secretAccessKey := values["AWS_SECRET_ACCESS_KEY"]
@@ -280,14 +326,14 @@ func cloneStrings(values []string) []string {
 }
 
 // redactCredentialValues removes resolved secret values from stderr before it reaches callers or wrapped errors.
-func (c *Client) redactCredentialValues(stderr []byte) []byte {
-	if len(stderr) == 0 || len(c.environment) == 0 {
+func redactCredentialValues(environment []string, stderr []byte) []byte {
+	if len(stderr) == 0 || len(environment) == 0 {
 		return stderr
 	}
 
 	uniqueValues := make(map[string]struct{})
 
-	for _, entry := range c.environment {
+	for _, entry := range environment {
 		name, value, found := strings.Cut(entry, "=")
 		if !found || value == "" {
 			continue
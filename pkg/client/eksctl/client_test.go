@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strconv"
 	"testing"
 
 	"github.com/devantler-tech/ksail/v7/pkg/client/eksctl"
@@ -173,6 +174,56 @@ func TestWithEnvironment_FailsClosedForLegacyRunner(t *testing.T) {
 	require.ErrorIs(t, err, eksctl.ErrRunnerEnvironmentUnsupported)
 }
 
+// TestWithEnvironmentSource_ResolvesEachInvocation verifies renewed credentials
+// reach the next command and the current ones are redacted from its stderr.
+func TestWithEnvironmentSource_ResolvesEachInvocation(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	runner := &fakeRunner{stderr: []byte("using session-2"), err: errExitStatus1}
+	client := eksctl.NewClient(
+		eksctl.WithRunner(runner),
+		eksctl.WithEnvironment([]string{"AWS_PROFILE=ignored"}),
+		eksctl.WithEnvironmentSource(func(context.Context) ([]string, error) {
+			calls++
+
+			return []string{
+				"AWS_ACCESS_KEY_ID=access-" + strconv.Itoa(calls),
+				"AWS_SECRET_ACCESS_KEY=session-" + strconv.Itoa(calls),
+			}, nil
+		}),
+	)
+
+	_, _, err := client.Exec(t.Context(), "get", "cluster")
+	require.Error(t, err)
+	assert.Equal(t, []string{"AWS_ACCESS_KEY_ID=access-1", "AWS_SECRET_ACCESS_KEY=session-1"}, runner.lastEnv)
+	assert.NotContains(t, err.Error(), "[REDACTED]", "only the invocation's own values are redacted")
+
+	_, stderr, err := client.Exec(t.Context(), "get", "cluster")
+	require.Error(t, err)
+	assert.Equal(t, []string{"AWS_ACCESS_KEY_ID=access-2", "AWS_SECRET_ACCESS_KEY=session-2"}, runner.lastEnv)
+	assert.Equal(t, "using [REDACTED]", string(stderr))
+}
+
+// TestWithEnvironmentSource_ReportsSourceErrors verifies a failed renewal never
+// falls back to the parent environment.
+func TestWithEnvironmentSource_ReportsSourceErrors(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{}
+	client := eksctl.NewClient(
+		eksctl.WithRunner(runner),
+		eksctl.WithEnvironmentSource(func(context.Context) ([]string, error) {
+			return nil, errExitStatus1
+		}),
+	)
+
+	_, _, err := client.Exec(t.Context(), "get", "cluster")
+	require.ErrorIs(t, err, errExitStatus1)
+	assert.Empty(t, runner.lastName, "eksctl must not run without its environment")
+}
+
 // TestExec_RedactsCredentialValuesFromStderrErrors verifies secret values are
 // absent from returned stderr and wrapped errors.
 func TestExec_RedactsCredentialValuesFromStderrErrors(t *testing.T) {
@@ -2,12 +2,19 @@ package flux
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// ErrNoReconcilableSource is returned when the root Kustomization has no
+// spec.sourceRef backed by an OCIRepository, GitRepository, or Bucket.
+var ErrNoReconcilableSource = errors.New("root flux kustomization has no reconcilable source")
+
 // sourceRef identifies the Flux source (OCIRepository / GitRepository / Bucket)
 // referenced by a Kustomization's spec.sourceRef.
 type sourceRef struct {
@@ -90,3 +97,37 @@ func (r *Reconciler) resolveSourceRevision(
 
 	return revision
 }
+
+// TriggerSourceReconciliation requests an immediate reconciliation of the
+// source referenced by the root Kustomization (OCIRepository, GitRepository, or
+// Bucket) without waiting, so a freshly pushed artifact or commit is fetched
+// without waiting for the source's poll interval. It returns the source as
+// Kind/namespace/name.
+func (r *Reconciler) TriggerSourceReconciliation(ctx context.Context) (string, error) {
+	kustomization, err := r.kustomizationClient().Get(ctx, rootKustomizationName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("get root flux kustomization: %w", err)
+	}
+
+	ref, found := parseKustomizationSourceRef(kustomization)
+	if !found {
+		return "", ErrNoReconcilableSource
+	}
+
+	gvr, known := sourceGVRForKind(ref.Kind)
+	if !known {
+		return "", fmt.Errorf("%w: unsupported kind %q", ErrNoReconcilableSource, ref.Kind)
+	}
+
+	_, err = triggerReconciliationWithRetry(
+		ctx,
+		r.Dynamic.Resource(gvr).Namespace(ref.Namespace),
+		ref.Name,
+		"flux "+strings.ToLower(ref.Kind)+" "+ref.Name,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/client/reconciler"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -324,3 +325,38 @@ func kustomizationNameForTest(objects []runtime.Object) string {
 
 	return ""
 }
+
+func TestTriggerSourceReconciliation(t *testing.T) {
+	t.Parallel()
+
+	rec := newTestFluxReconcilerWithSources(
+		newFakeSource("GitRepository", pushedRevision),
+		newFakeKustomizationWithSource(
+			"flux-system", "GitRepository",
+			statusTrue, reasonSucceeded, "Applied revision: pushed",
+			pushedRevision, pushedRevision,
+		),
+	)
+
+	source, err := rec.TriggerSourceReconciliation(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "GitRepository/flux-system/flux-system", source)
+
+	obj, err := rec.Dynamic.Resource(gitRepositoryGVR).Namespace(namespaceFluxSystem).
+		Get(context.Background(), ociRepoName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, obj.GetAnnotations(), "reconcile.fluxcd.io/requestedAt")
+}
+
+func TestTriggerSourceReconciliation_NoSourceRef(t *testing.T) {
+	t.Parallel()
+
+	kust := newFakeKustomizationWithSource(
+		"flux-system", "OCIRepository",
+		statusTrue, reasonSucceeded, "", "", "",
+	)
+	unstructured.RemoveNestedField(kust.Object, "spec", "sourceRef")
+
+	_, err := newTestFluxReconcilerWithSources(kust).TriggerSourceReconciliation(context.Background())
+	require.ErrorIs(t, err, flux.ErrNoReconcilableSource)
+}
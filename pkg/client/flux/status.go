@@ -0,0 +1,173 @@
+package flux
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/reconciler"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// conditionTypeDrifted is the HelmRelease condition the helm-controller sets when
+// drift detection (spec.driftDetection.mode: enabled|warn) finds that the cluster
+// state no longer matches the Helm release manifest.
+const conditionTypeDrifted = "Drifted"
+
+// Resource kinds reported by ListResourceStatuses.
+const (
+	KindKustomization = "Kustomization"
+	KindHelmRelease   = "HelmRelease"
+)
+
+// ResourceStatus summarizes the reconciliation state of a Flux Kustomization or
+// HelmRelease from its status conditions.
+type ResourceStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Ready is true when the Ready condition is True.
+	Ready bool `json:"ready"`
+	// Stalled is true when the Stalled condition is True (the controller has
+	// stopped retrying until the spec changes).
+	Stalled bool `json:"stalled,omitempty"`
+	// Drifted is true when HelmRelease drift detection reported the in-cluster
+	// resources as diverged from the release manifest (Drifted condition True).
+	Drifted bool `json:"drifted,omitempty"`
+	// Suspended is true when spec.suspend is set.
+	Suspended bool `json:"suspended,omitempty"`
+	// Reason and Message come from the most significant condition: Stalled, then
+	// Drifted, then Ready.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Healthy reports whether the resource is ready and neither stalled nor drifted.
+// Suspended resources are healthy by definition (reconciliation is paused on purpose).
+func (s ResourceStatus) Healthy() bool {
+	if s.Suspended {
+		return true
+	}
+
+	return s.Ready && !s.Stalled && !s.Drifted
+}
+
+// State returns a one-word summary: Suspended, Stalled, Drifted, Ready, or NotReady.
+func (s ResourceStatus) State() string {
+	switch {
+	case s.Suspended:
+		return "Suspended"
+	case s.Stalled:
+		return "Stalled"
+	case s.Drifted:
+		return "Drifted"
+	case s.Ready:
+		return "Ready"
+	default:
+		return "NotReady"
+	}
+}
+
+// NewReconcilerForContext creates a Flux reconciler for a specific kubeconfig
+// context. An empty contextName uses the kubeconfig's current context.
+func NewReconcilerForContext(kubeconfigPath, contextName string) (*Reconciler, error) {
+	restConfig, err := k8s.BuildRESTConfig(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("build rest config: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create dynamic client: %w", err)
+	}
+
+	base := reconciler.NewBaseWithClient(dynamicClient)
+	base.KubeconfigPath = kubeconfigPath
+
+	return newFromBase(base), nil
+}
+
+// ListResourceStatuses returns the reconciliation status of every Flux
+// Kustomization and HelmRelease across all namespaces, Kustomizations first, each
+// kind sorted by namespace and name. A kind whose CRD is not installed is skipped.
+func (r *Reconciler) ListResourceStatuses(ctx context.Context) ([]ResourceStatus, error) {
+	kinds := []struct {
+		kind string
+		gvr  schema.GroupVersionResource
+	}{
+		{KindKustomization, KustomizationGVR()},
+		{KindHelmRelease, HelmReleaseGVR()},
+	}
+
+	var statuses []ResourceStatus
+
+	for _, entry := range kinds {
+		list, err := r.Dynamic.Resource(entry.gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if isAPIDiscoveryError(err.Error()) {
+				continue
+			}
+
+			return nil, fmt.Errorf("list flux %ss: %w", strings.ToLower(entry.kind), err)
+		}
+
+		kindStatuses := make([]ResourceStatus, 0, len(list.Items))
+		for i := range list.Items {
+			kindStatuses = append(kindStatuses, ResourceStatusFromObject(entry.kind, &list.Items[i]))
+		}
+
+		slices.SortFunc(kindStatuses, func(a, b ResourceStatus) int {
+			return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+		})
+
+		statuses = append(statuses, kindStatuses...)
+	}
+
+	return statuses, nil
+}
+
+// ResourceStatusFromObject derives a ResourceStatus from a Flux custom resource.
+func ResourceStatusFromObject(kind string, obj *unstructured.Unstructured) ResourceStatus {
+	suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+
+	status := ResourceStatus{
+		Kind:      kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Suspended: suspended,
+	}
+
+	var ready, stalled, drifted *reconciler.Condition
+
+	conditions := reconciler.ParseConditions(obj)
+	for i := range conditions {
+		switch conditions[i].Type {
+		case conditionTypeReady:
+			ready = &conditions[i]
+		case conditionTypeStalled:
+			stalled = &conditions[i]
+		case conditionTypeDrifted:
+			drifted = &conditions[i]
+		}
+	}
+
+	status.Ready = ready != nil && ready.Status == conditionStatusTrue
+	status.Stalled = stalled != nil && stalled.Status == conditionStatusTrue
+	status.Drifted = drifted != nil && drifted.Status == conditionStatusTrue
+
+	switch {
+	case status.Stalled:
+		status.Reason, status.Message = stalled.Reason, stalled.Message
+	case status.Drifted:
+		status.Reason, status.Message = drifted.Reason, drifted.Message
+	case ready != nil:
+		status.Reason, status.Message = ready.Reason, ready.Message
+	}
+
+	return status
+}
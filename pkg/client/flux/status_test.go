@@ -0,0 +1,122 @@
+package flux_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/client/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newFluxStatusObject builds an unstructured Flux resource with the given conditions.
+func newFluxStatusObject(
+	gvk schema.GroupVersionKind,
+	namespace, name string,
+	suspend bool,
+	conditions ...map[string]any,
+) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	rawConditions := make([]any, 0, len(conditions))
+	for _, condition := range conditions {
+		rawConditions = append(rawConditions, condition)
+	}
+
+	obj.Object["spec"] = map[string]any{"suspend": suspend}
+	obj.Object["status"] = map[string]any{statusConditions: rawConditions}
+
+	return obj
+}
+
+func condition(condType, status, reason, message string) map[string]any {
+	return map[string]any{
+		"type":    condType,
+		"status":  status,
+		"reason":  reason,
+		"message": message,
+	}
+}
+
+func helmReleaseGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}
+}
+
+func TestListResourceStatuses(t *testing.T) {
+	t.Parallel()
+
+	kustomizationGVK := schema.GroupVersionKind{
+		Group:   "kustomize.toolkit.fluxcd.io",
+		Version: "v1",
+		Kind:    kustomizationKind,
+	}
+
+	objects := []runtime.Object{
+		newFluxStatusObject(kustomizationGVK, namespaceFluxSystem, statusInfra, false,
+			condition(conditionTypeReady, statusTrue, reasonSucceeded, "Applied revision")),
+		newFluxStatusObject(helmReleaseGVK(), "podinfo", "podinfo", false,
+			condition(conditionTypeReady, statusTrue, "UpgradeSucceeded", "Helm upgrade succeeded"),
+			condition("Drifted", statusTrue, "DriftDetected", "Deployment/podinfo changed")),
+		newFluxStatusObject(helmReleaseGVK(), "apps", "broken", false,
+			condition(conditionTypeReady, statusFalse, reasonInstallFailed, "install failed"),
+			condition(conditionTypeStalled, statusTrue, reasonRetryExhausted, "retries exhausted")),
+		newFluxStatusObject(helmReleaseGVK(), "apps", "paused", true),
+	}
+
+	fakeClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			flux.KustomizationGVR(): kustomizationListKind,
+			flux.HelmReleaseGVR():   "HelmReleaseList",
+		},
+		objects...,
+	)
+	rec := &flux.Reconciler{Base: reconciler.NewBaseWithClient(fakeClient)}
+
+	statuses, err := rec.ListResourceStatuses(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 4)
+
+	assert.Equal(t, flux.KindKustomization, statuses[0].Kind)
+	assert.Equal(t, "Ready", statuses[0].State())
+	assert.True(t, statuses[0].Healthy())
+
+	broken := statuses[1]
+	assert.Equal(t, "apps/broken", broken.Namespace+"/"+broken.Name)
+	assert.Equal(t, "Stalled", broken.State())
+	assert.Equal(t, reasonRetryExhausted, broken.Reason)
+	assert.False(t, broken.Healthy())
+
+	paused := statuses[2]
+	assert.Equal(t, "Suspended", paused.State())
+	assert.True(t, paused.Healthy())
+
+	drifted := statuses[3]
+	assert.Equal(t, "podinfo", drifted.Name)
+	assert.True(t, drifted.Ready)
+	assert.True(t, drifted.Drifted)
+	assert.Equal(t, "Drifted", drifted.State())
+	assert.Equal(t, "Deployment/podinfo changed", drifted.Message)
+	assert.False(t, drifted.Healthy())
+}
+
+func TestResourceStatusFromObject_NoConditions(t *testing.T) {
+	t.Parallel()
+
+	status := flux.ResourceStatusFromObject(
+		flux.KindHelmRelease,
+		newFluxStatusObject(helmReleaseGVK(), "apps", "new", false),
+	)
+
+	assert.Equal(t, "NotReady", status.State())
+	assert.Empty(t, status.Reason)
+	assert.False(t, status.Healthy())
+}
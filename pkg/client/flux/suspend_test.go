@@ -0,0 +1,96 @@
+package flux_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newSuspendTestReconciler() *flux.Reconciler {
+	kustomizationGVK := schema.GroupVersionKind{
+		Group:   "kustomize.toolkit.fluxcd.io",
+		Version: "v1",
+		Kind:    kustomizationKind,
+	}
+
+	return newTestFluxReconcilerWithHelmReleases(
+		newFluxStatusObject(kustomizationGVK, namespaceFluxSystem, "apps", false),
+		newFluxStatusObject(kustomizationGVK, namespaceFluxSystem, "infra", true),
+		newFluxStatusObject(helmReleaseGVK(), "podinfo", "podinfo", false),
+	)
+}
+
+func isSuspended(t *testing.T, rec *flux.Reconciler, gvr schema.GroupVersionResource, namespace, name string) bool {
+	t.Helper()
+
+	obj, err := rec.Dynamic.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend")
+
+	return suspended
+}
+
+func TestSetSuspended_SuspendsAllUnsuspended(t *testing.T) {
+	t.Parallel()
+
+	rec := newSuspendTestReconciler()
+
+	changed, err := rec.SetSuspended(context.Background(), flux.SuspendSelector{}, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []flux.ResourceRef{
+		{Kind: flux.KindKustomization, Name: "apps", Namespace: namespaceFluxSystem},
+		{Kind: flux.KindHelmRelease, Name: "podinfo", Namespace: "podinfo"},
+	}, changed)
+	assert.True(t, isSuspended(t, rec, flux.KustomizationGVR(), namespaceFluxSystem, "apps"))
+	assert.True(t, isSuspended(t, rec, flux.HelmReleaseGVR(), "podinfo", "podinfo"))
+}
+
+func TestSetSuspended_ResumeRequestsReconciliation(t *testing.T) {
+	t.Parallel()
+
+	rec := newSuspendTestReconciler()
+
+	changed, err := rec.SetSuspended(context.Background(), flux.SuspendSelector{
+		Kind: "kustomization",
+		Name: "infra",
+	}, false)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, "Kustomization/flux-system/infra", changed[0].String())
+
+	obj, err := rec.Dynamic.Resource(flux.KustomizationGVR()).Namespace(namespaceFluxSystem).
+		Get(context.Background(), "infra", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, isSuspended(t, rec, flux.KustomizationGVR(), namespaceFluxSystem, "infra"))
+	assert.Contains(t, obj.GetAnnotations(), "reconcile.fluxcd.io/requestedAt")
+}
+
+func TestSetSuspended_AlreadyInState(t *testing.T) {
+	t.Parallel()
+
+	rec := newSuspendTestReconciler()
+
+	changed, err := rec.SetSuspended(context.Background(), flux.SuspendSelector{Name: "infra"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestSetSuspended_NoMatch(t *testing.T) {
+	t.Parallel()
+
+	rec := newSuspendTestReconciler()
+
+	_, err := rec.SetSuspended(context.Background(), flux.SuspendSelector{
+		Kind: flux.KindHelmRelease,
+		Name: "apps",
+	}, true)
+	require.ErrorIs(t, err, flux.ErrNoMatchingResources)
+}
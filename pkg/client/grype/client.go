@@ -0,0 +1,343 @@
+package grype
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/anchore/clio"
+	anchoregrype "github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/grypeerr"
+	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/grype/matcher/dotnet"
+	"github.com/anchore/grype/grype/matcher/golang"
+	"github.com/anchore/grype/grype/matcher/java"
+	"github.com/anchore/grype/grype/matcher/javascript"
+	"github.com/anchore/grype/grype/matcher/python"
+	"github.com/anchore/grype/grype/matcher/ruby"
+	"github.com/anchore/grype/grype/matcher/stock"
+	"github.com/anchore/grype/grype/pkg"
+	jsonpresenter "github.com/anchore/grype/grype/presenter/json"
+	"github.com/anchore/grype/grype/presenter/models"
+	sarifpresenter "github.com/anchore/grype/grype/presenter/sarif"
+	tablepresenter "github.com/anchore/grype/grype/presenter/table"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// Output formats of a Report.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// mavenSearchURL is the Maven Central search API the Java matcher resolves
+// unidentified JARs with.
+const mavenSearchURL = "https://search.maven.org/solrsearch/select"
+
+var (
+	// ErrScanFailed indicates that the vulnerability scan encountered an error.
+	ErrScanFailed = errors.New("vulnerability scan failed")
+	// ErrUnsupportedFormat is returned for an output format other than table, json or sarif.
+	ErrUnsupportedFormat = errors.New("unsupported output format")
+	// ErrUnknownSeverity is returned for a severity Grype does not rank.
+	ErrUnknownSeverity = errors.New("unknown severity")
+)
+
+// Formats returns the output formats a Report can be written in.
+func Formats() []string {
+	return []string{FormatTable, FormatJSON, FormatSARIF}
+}
+
+// Severities returns the severities findings are ranked by, lowest first.
+func Severities() []string {
+	return []string{"negligible", "low", "medium", "high", "critical"}
+}
+
+// ValidateFormat returns ErrUnsupportedFormat when format is not one of Formats.
+func ValidateFormat(format string) error {
+	if !slices.Contains(Formats(), format) {
+		return fmt.Errorf("%w: %q (valid: %s)", ErrUnsupportedFormat, format, strings.Join(Formats(), ", "))
+	}
+
+	return nil
+}
+
+// ValidateSeverity returns ErrUnknownSeverity when severity is not one of
+// Severities. Severities are matched case-insensitively.
+func ValidateSeverity(severity string) error {
+	if !slices.Contains(Severities(), strings.ToLower(severity)) {
+		return fmt.Errorf("%w: %q (valid: %s)", ErrUnknownSeverity, severity, strings.Join(Severities(), ", "))
+	}
+
+	return nil
+}
+
+// ScanOptions configures the vulnerability database of a Client.
+type ScanOptions struct {
+	// DBDir is the directory the vulnerability database is kept in.
+	// Defaults to DefaultDBDir.
+	DBDir string
+	// SkipDBUpdate scans with the database already in DBDir instead of
+	// checking for a newer one, for air-gapped runs.
+	SkipDBUpdate bool
+}
+
+// Client scans image archives with an embedded Grype engine. The vulnerability
+// database is loaded on the first scan and shared by the following ones.
+type Client struct {
+	opts     ScanOptions
+	provider vulnerability.Provider
+}
+
+// NewClient creates a new Grype client.
+func NewClient(opts ScanOptions) *Client {
+	if opts.DBDir == "" {
+		opts.DBDir = DefaultDBDir()
+	}
+
+	return &Client{opts: opts}
+}
+
+// DefaultDBDir returns the directory the vulnerability database is cached in.
+func DefaultDBDir() string {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "ksail", "grype")
+	}
+
+	return filepath.Join(userCacheDir, "ksail", "grype")
+}
+
+// Report is the result of scanning one image.
+type Report struct {
+	// Source is the scanned image source, such as docker-archive:images.tar.
+	Source string
+
+	document models.Document
+	sbom     *sbom.SBOM
+}
+
+// Scan catalogs the packages of the image at source and matches them against
+// the vulnerability database. source is any source Grype accepts, such as
+// docker-archive:<path> or oci-archive:<path>; see ArchiveSource.
+func (c *Client) Scan(ctx context.Context, source string) (*Report, error) {
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("%w", ctx.Err())
+	}
+
+	err := c.loadDB()
+	if err != nil {
+		return nil, err
+	}
+
+	packages, pkgContext, packageSBOM, err := pkg.Provide(source, pkg.ProviderConfig{
+		SyftProviderConfig: pkg.SyftProviderConfig{SBOMOptions: syft.DefaultCreateSBOMConfig()},
+		SynthesisConfig:    pkg.SynthesisConfig{GenerateMissingCPEs: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: catalog packages of %s: %w", ErrScanFailed, source, err)
+	}
+
+	vulnerabilityMatcher := anchoregrype.VulnerabilityMatcher{
+		VulnerabilityProvider: c.provider,
+		Matchers:              matcher.NewDefaultMatchers(matcherConfig()),
+	}
+
+	matches, ignoredMatches, err := vulnerabilityMatcher.FindMatches(packages, pkgContext)
+	if err != nil && !errors.Is(err, grypeerr.ErrAboveSeverityThreshold) {
+		return nil, fmt.Errorf("%w: match vulnerabilities of %s: %w", ErrScanFailed, source, err)
+	}
+
+	document, err := models.NewDocument(
+		clio.Identification{Name: "ksail"},
+		packages,
+		pkgContext,
+		*matches,
+		ignoredMatches,
+		c.provider,
+		nil,
+		nil,
+		models.DefaultSortStrategy,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: build report of %s: %w", ErrScanFailed, source, err)
+	}
+
+	return &Report{Source: source, document: document, sbom: packageSBOM}, nil
+}
+
+// Close releases the vulnerability database.
+func (c *Client) Close() error {
+	if c.provider == nil {
+		return nil
+	}
+
+	err := c.provider.Close()
+	if err != nil {
+		return fmt.Errorf("close vulnerability database: %w", err)
+	}
+
+	return nil
+}
+
+// loadDB loads the vulnerability database, downloading or updating it unless
+// SkipDBUpdate is set.
+func (c *Client) loadDB() error {
+	if c.provider != nil {
+		return nil
+	}
+
+	identification := clio.Identification{Name: "ksail"}
+
+	installConfig := installation.DefaultConfig(identification)
+	installConfig.DBRootDir = c.opts.DBDir
+
+	distributionConfig := distribution.DefaultConfig()
+	distributionConfig.ID = identification
+
+	provider, status, err := anchoregrype.LoadVulnerabilityDB(
+		distributionConfig,
+		installConfig,
+		!c.opts.SkipDBUpdate,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: load vulnerability database: %w", ErrScanFailed, err)
+	}
+
+	if status != nil && status.Error != nil {
+		_ = provider.Close()
+
+		return fmt.Errorf("%w: load vulnerability database: %w", ErrScanFailed, status.Error)
+	}
+
+	c.provider = provider
+
+	return nil
+}
+
+// matcherConfig returns Grype's default matcher configuration: packages of
+// language ecosystems are matched by their package URLs, and CPEs are only used
+// for OS packages and the Go standard library.
+func matcherConfig() matcher.Config {
+	return matcher.Config{
+		Java: java.MatcherConfig{
+			ExternalSearchConfig: java.ExternalSearchConfig{MavenBaseURL: mavenSearchURL},
+		},
+		Ruby:       ruby.MatcherConfig{},
+		Python:     python.MatcherConfig{},
+		Dotnet:     dotnet.MatcherConfig{},
+		Javascript: javascript.MatcherConfig{},
+		Golang:     golang.MatcherConfig{AlwaysUseCPEForStdlib: true},
+		Stock:      stock.MatcherConfig{UseCPEs: true},
+	}
+}
+
+// Count returns the number of vulnerabilities found.
+func (r *Report) Count() int {
+	return len(r.document.Matches)
+}
+
+// CountAtOrAbove returns the number of vulnerabilities found whose severity is
+// severity or higher. severity must be one of Severities.
+func (r *Report) CountAtOrAbove(severity string) int {
+	threshold := vulnerability.ParseSeverity(severity)
+	if threshold == vulnerability.UnknownSeverity {
+		return 0
+	}
+
+	count := 0
+
+	for _, match := range r.document.Matches {
+		if vulnerability.ParseSeverity(match.Vulnerability.Severity) >= threshold {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Write renders the report to writer in format: a table, a Grype JSON document
+// or a SARIF log for code scanning tools.
+func (r *Report) Write(writer io.Writer, format string) error {
+	config := models.PresenterConfig{
+		ID:       clio.Identification{Name: "ksail"},
+		Document: r.document,
+		SBOM:     r.sbom,
+		Pretty:   true,
+	}
+
+	// The SARIF presenter reads the scanned source from the SBOM.
+	if config.SBOM == nil {
+		config.SBOM = &sbom.SBOM{}
+	}
+
+	var err error
+
+	switch format {
+	case FormatTable:
+		err = tablepresenter.NewPresenter(config, false).Present(writer)
+	case FormatJSON:
+		err = jsonpresenter.NewPresenter(config).Present(writer)
+	case FormatSARIF:
+		err = sarifpresenter.NewPresenter(config).Present(writer)
+	default:
+		return ValidateFormat(format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("write %s report: %w", format, err)
+	}
+
+	return nil
+}
+
+// ArchiveSource returns the Grype source of the image tar archive at path:
+// docker-archive for archives with a manifest.json, as written by docker save
+// and ctr export, and oci-archive for plain OCI image layouts.
+func ArchiveSource(path string) (string, error) {
+	archive, err := os.Open(path) //nolint:gosec // Path is provided by the user.
+	if err != nil {
+		return "", fmt.Errorf("open image archive: %w", err)
+	}
+
+	defer func() { _ = archive.Close() }()
+
+	tarReader := tar.NewReader(archive)
+	isOCILayout := false
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("read image archive %s: %w", path, err)
+		}
+
+		switch strings.TrimPrefix(header.Name, "./") {
+		case "manifest.json":
+			return "docker-archive:" + path, nil
+		case "index.json":
+			isOCILayout = true
+		}
+	}
+
+	if !isOCILayout {
+		return "", fmt.Errorf("%w: %s is not a docker save or OCI image archive", ErrScanFailed, path)
+	}
+
+	return "oci-archive:" + path, nil
+}
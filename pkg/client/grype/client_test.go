@@ -0,0 +1,170 @@
+package grype_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/devantler-tech/ksail/v7/pkg/client/grype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReport() *grype.Report {
+	match := func(id, severity, name string) models.Match {
+		return models.Match{
+			Vulnerability: models.Vulnerability{
+				VulnerabilityMetadata: models.VulnerabilityMetadata{ID: id, Severity: severity},
+			},
+			Artifact: models.Package{Name: name, Version: "1.0.0"},
+		}
+	}
+
+	return grype.NewReportForTests("docker-archive:images.tar", models.Document{
+		Matches: []models.Match{
+			match("CVE-2026-0001", "Critical", "openssl"),
+			match("CVE-2026-0002", "Medium", "zlib"),
+			match("CVE-2026-0003", "Low", "busybox"),
+		},
+	})
+}
+
+func TestValidateFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range grype.Formats() {
+		require.NoError(t, grype.ValidateFormat(format))
+	}
+
+	require.ErrorIs(t, grype.ValidateFormat("xml"), grype.ErrUnsupportedFormat)
+}
+
+func TestValidateSeverity(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, grype.ValidateSeverity("high"))
+	require.NoError(t, grype.ValidateSeverity("Critical"))
+	require.ErrorIs(t, grype.ValidateSeverity("severe"), grype.ErrUnknownSeverity)
+}
+
+func TestReportCountAtOrAbove(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+
+	assert.Equal(t, 3, report.Count())
+	assert.Equal(t, 3, report.CountAtOrAbove("negligible"))
+	assert.Equal(t, 2, report.CountAtOrAbove("medium"))
+	assert.Equal(t, 1, report.CountAtOrAbove("high"))
+	assert.Equal(t, 1, report.CountAtOrAbove("critical"))
+}
+
+func TestReportWrite(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+
+		require.NoError(t, report.Write(&out, grype.FormatTable))
+		assert.Contains(t, out.String(), "CVE-2026-0001")
+		assert.Contains(t, out.String(), "openssl")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+
+		require.NoError(t, report.Write(&out, grype.FormatJSON))
+
+		var document models.Document
+		require.NoError(t, json.Unmarshal(out.Bytes(), &document))
+		assert.Len(t, document.Matches, 3)
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+
+		require.NoError(t, report.Write(&out, grype.FormatSARIF))
+
+		var log struct {
+			Version string `json:"version"`
+			Runs    []struct {
+				Results []json.RawMessage `json:"results"`
+			} `json:"runs"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		assert.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+		assert.Len(t, log.Runs[0].Results, 3)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, report.Write(&bytes.Buffer{}, "xml"), grype.ErrUnsupportedFormat)
+	})
+}
+
+func writeTestArchive(t *testing.T, names ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "images.tar")
+
+	file, err := os.Create(path) //nolint:gosec // Path is a test temp file.
+	require.NoError(t, err)
+
+	tarWriter := tar.NewWriter(file)
+
+	for _, name := range names {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: 2}))
+		_, err = tarWriter.Write([]byte("{}"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, file.Close())
+
+	return path
+}
+
+func TestArchiveSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("docker save archive", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeTestArchive(t, "index.json", "manifest.json")
+
+		source, err := grype.ArchiveSource(path)
+		require.NoError(t, err)
+		assert.Equal(t, "docker-archive:"+path, source)
+	})
+
+	t.Run("OCI image layout", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeTestArchive(t, "oci-layout", "./index.json")
+
+		source, err := grype.ArchiveSource(path)
+		require.NoError(t, err)
+		assert.Equal(t, "oci-archive:"+path, source)
+	})
+
+	t.Run("not an image archive", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := grype.ArchiveSource(writeTestArchive(t, "notes.txt"))
+		require.ErrorIs(t, err, grype.ErrScanFailed)
+	})
+}
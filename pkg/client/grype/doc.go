@@ -0,0 +1,9 @@
+// Package grype scans container images for known vulnerabilities using the
+// Grype engine.
+//
+// It wraps the Grype Go library (github.com/anchore/grype/grype) to catalog the
+// packages of an image archive, match them against the Grype vulnerability
+// database and render the findings as a table, Grype JSON or SARIF. The
+// database is downloaded to the user cache directory on first use and updated
+// when a newer one is published.
+package grype
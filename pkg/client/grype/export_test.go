@@ -0,0 +1,9 @@
+package grype
+
+import "github.com/anchore/grype/grype/presenter/models"
+
+// NewReportForTests builds a Report from a rendered Grype document, so reports
+// can be tested without a vulnerability database.
+func NewReportForTests(source string, document models.Document) *Report {
+	return &Report{Source: source, document: document}
+}
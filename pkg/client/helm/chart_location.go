@@ -3,6 +3,7 @@ package helm
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -10,9 +11,12 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/client/netretry"
 	helmv4action "helm.sh/helm/v4/pkg/action"
+	helmv4chart "helm.sh/helm/v4/pkg/chart"
 	helmv4loader "helm.sh/helm/v4/pkg/chart/loader"
 	chartv2 "helm.sh/helm/v4/pkg/chart/v2"
 	helmv4cli "helm.sh/helm/v4/pkg/cli"
+	helmv4downloader "helm.sh/helm/v4/pkg/downloader"
+	helmv4getter "helm.sh/helm/v4/pkg/getter"
 	helmv4registry "helm.sh/helm/v4/pkg/registry"
 )
 
@@ -151,18 +155,80 @@ func (c *Client) locateAndLoadChart(
 		return "", nil, err
 	}
 
+	chart, err := loadChart(chartPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if spec.DependencyUpdate && isChartDirectory(chartPath) {
+		chart, err = c.updateChartDependencies(chartPath, chart)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return chartPath, chart, nil
+}
+
+// loadChart loads the chart at chartPath, which may be a directory or an
+// archive.
+func loadChart(chartPath string) (*chartv2.Chart, error) {
 	chartInterface, err := helmv4loader.Load(chartPath)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to load chart: %w", err)
+		return nil, fmt.Errorf("failed to load chart: %w", err)
 	}
 
 	// Type assert to *chartv2.Chart
 	chart, ok := chartInterface.(*chartv2.Chart)
 	if !ok {
-		return "", nil, fmt.Errorf("%w: %T", errUnexpectedChartType, chartInterface)
+		return nil, fmt.Errorf("%w: %T", errUnexpectedChartType, chartInterface)
 	}
 
-	return chartPath, chart, nil
+	return chart, nil
+}
+
+// isChartDirectory reports whether chartPath is an unpacked chart directory,
+// the only chart form whose dependencies can be updated in place.
+func isChartDirectory(chartPath string) bool {
+	info, err := os.Stat(chartPath)
+
+	return err == nil && info.IsDir()
+}
+
+// updateChartDependencies fetches the dependencies declared in the Chart.yaml
+// of the chart directory at chartPath that are missing from its charts/
+// directory, then reloads the chart. Charts whose dependencies are all present
+// are returned unchanged, so repeated installs do not hit the network.
+func (c *Client) updateChartDependencies(
+	chartPath string,
+	chart *chartv2.Chart,
+) (*chartv2.Chart, error) {
+	accessor, err := helmv4chart.NewAccessor(chart)
+	if err != nil {
+		return nil, fmt.Errorf("read chart dependencies: %w", err)
+	}
+
+	required := accessor.MetaDependencies()
+	if len(required) == 0 || helmv4action.CheckDependencies(chart, required) == nil {
+		return chart, nil
+	}
+
+	manager := &helmv4downloader.Manager{
+		Out:              io.Discard,
+		ChartPath:        chartPath,
+		Getters:          helmv4getter.All(c.settings),
+		RegistryClient:   c.actionConfig.RegistryClient,
+		RepositoryConfig: c.settings.RepositoryConfig,
+		RepositoryCache:  c.settings.RepositoryCache,
+		ContentCache:     c.settings.ContentCache,
+	}
+
+	err = manager.Update()
+	if err != nil {
+		return nil, fmt.Errorf("update chart dependencies: %w", err)
+	}
+
+	return loadChart(chartPath)
 }
 
 // buildChartPathOptions creates common ChartPathOptions from a ChartSpec.
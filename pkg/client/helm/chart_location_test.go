@@ -1,6 +1,9 @@
 package helm_test
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
@@ -176,3 +179,40 @@ func TestApplyChartPathOptions(t *testing.T) {
 		})
 	})
 }
+
+func writeChartFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestTemplateChart_DependencyUpdate(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	parent := filepath.Join(root, "parent")
+
+	writeChartFile(t, root, "child/Chart.yaml", "apiVersion: v2\nname: child\nversion: 0.1.0\n")
+	writeChartFile(t, root, "child/templates/configmap.yaml",
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: from-child\n")
+	writeChartFile(t, parent, "Chart.yaml", "apiVersion: v2\nname: parent\nversion: 0.1.0\n"+
+		"dependencies:\n  - name: child\n    version: 0.1.0\n    repository: file://../child\n")
+
+	client, err := helm.NewTemplateOnlyClient()
+	require.NoError(t, err)
+
+	spec := &helm.ChartSpec{ReleaseName: "deps", ChartName: parent, Namespace: "default"}
+
+	manifest, err := client.TemplateChart(context.Background(), spec)
+	require.NoError(t, err)
+	assert.NotContains(t, manifest, "from-child", "dependencies are not fetched by default")
+
+	spec.DependencyUpdate = true
+
+	manifest, err = client.TemplateChart(context.Background(), spec)
+	require.NoError(t, err)
+	assert.Contains(t, manifest, "name: from-child")
+	assert.FileExists(t, filepath.Join(parent, "Chart.lock"))
+}
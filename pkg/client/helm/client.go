@@ -54,6 +54,11 @@ type Client struct {
 	kubeConfig   string
 	kubeContext  string
 	debugLog     func(string, ...any)
+
+	// resourceLabels and resourceAnnotations are stamped onto release records and
+	// created namespaces; see WithResourceMetadata.
+	resourceLabels      map[string]string
+	resourceAnnotations map[string]string
 }
 
 var _ Interface = (*Client)(nil)
@@ -594,6 +599,8 @@ func (c *Client) installRelease(
 
 	defer cleanup()
 
+	spec = c.withResourceLabels(spec)
+
 	// Check if release exists when doing upgrade
 	var rel *v1.Release
 
@@ -617,6 +624,13 @@ func (c *Client) installRelease(
 		return nil, err
 	}
 
+	if spec.CreateNamespace {
+		err = c.stampNamespaceMetadata(ctx, spec.Namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return releaseToInfo(rel), nil
 }
 
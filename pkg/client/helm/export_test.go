@@ -28,6 +28,8 @@ var (
 	ExecuteAndExtractRelease = executeAndExtractRelease
 	ApplyCommonActionConfig  = applyCommonActionConfig
 	LocateChartWithRetry     = locateChartWithRetry
+	MergeReleaseLabels       = mergeReleaseLabels
+	StampNamespace           = stampNamespace
 )
 
 // Expose unexported error sentinels and repository helpers for test assertions.
@@ -0,0 +1,126 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	helmv4driver "helm.sh/helm/v4/pkg/storage/driver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WithResourceMetadata configures labels and annotations the client stamps onto
+// everything it creates: labels are added to Helm release records, and both are
+// added to namespaces created via ChartSpec.CreateNamespace. Labels set on a
+// ChartSpec win over these, and Helm's reserved storage labels (name, owner,
+// status, version, ...) are never overridden. It returns the client for chaining.
+func (c *Client) WithResourceMetadata(labels, annotations map[string]string) *Client {
+	c.resourceLabels = maps.Clone(labels)
+	c.resourceAnnotations = maps.Clone(annotations)
+
+	return c
+}
+
+// withResourceLabels returns spec unchanged when no resource labels are
+// configured, otherwise a shallow copy whose Labels include them.
+func (c *Client) withResourceLabels(spec *ChartSpec) *ChartSpec {
+	labels := mergeReleaseLabels(spec.Labels, c.resourceLabels)
+	if len(labels) == len(spec.Labels) {
+		return spec
+	}
+
+	stamped := *spec
+	stamped.Labels = labels
+
+	return &stamped
+}
+
+// mergeReleaseLabels adds every extra label that is neither already set in
+// base nor reserved by Helm's release storage driver.
+func mergeReleaseLabels(base, extra map[string]string) map[string]string {
+	systemLabels := helmv4driver.GetSystemLabels()
+	merged := maps.Clone(base)
+
+	for key, value := range extra {
+		if _, exists := base[key]; exists || slices.Contains(systemLabels, key) {
+			continue
+		}
+
+		if merged == nil {
+			merged = make(map[string]string, len(extra))
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// stampNamespaceMetadata adds the configured resource labels and annotations to
+// namespace without overriding keys that are already set.
+func (c *Client) stampNamespaceMetadata(ctx context.Context, namespace string) error {
+	if namespace == "" || (len(c.resourceLabels) == 0 && len(c.resourceAnnotations) == 0) {
+		return nil
+	}
+
+	restConfig, err := c.settings.RESTClientGetter().ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("get REST config for namespace metadata: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("create kubernetes clientset for namespace metadata: %w", err)
+	}
+
+	return stampNamespace(ctx, clientset, namespace, c.resourceLabels, c.resourceAnnotations)
+}
+
+func stampNamespace(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	name string,
+	labels, annotations map[string]string,
+) error {
+	namespace, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get namespace %q: %w", name, err)
+	}
+
+	labelsChanged := addMissing(&namespace.Labels, labels)
+	annotationsChanged := addMissing(&namespace.Annotations, annotations)
+
+	if !labelsChanged && !annotationsChanged {
+		return nil
+	}
+
+	_, err = clientset.CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update namespace %q metadata: %w", name, err)
+	}
+
+	return nil
+}
+
+// addMissing copies every entry of extra whose key is absent from *target and
+// reports whether anything was added.
+func addMissing(target *map[string]string, extra map[string]string) bool {
+	changed := false
+
+	for key, value := range extra {
+		if _, exists := (*target)[key]; exists {
+			continue
+		}
+
+		if *target == nil {
+			*target = make(map[string]string, len(extra))
+		}
+
+		(*target)[key] = value
+		changed = true
+	}
+
+	return changed
+}
@@ -0,0 +1,50 @@
+package helm_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMergeReleaseLabelsSkipsReservedAndExistingKeys(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]string{"app": "cilium"}
+	extra := map[string]string{"app": "ignored", "team": "platform", "owner": "someone"}
+
+	merged := helm.MergeReleaseLabels(base, extra)
+
+	assert.Equal(t, map[string]string{"app": "cilium", "team": "platform"}, merged)
+	assert.Equal(t, map[string]string{"app": "cilium"}, base, "base must not be mutated")
+	assert.Nil(t, helm.MergeReleaseLabels(nil, nil))
+}
+
+func TestStampNamespaceAddsMissingMetadata(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "cilium",
+			Labels: map[string]string{"team": "network"},
+		},
+	})
+
+	err := helm.StampNamespace(
+		t.Context(),
+		clientset,
+		"cilium",
+		map[string]string{"team": "platform", "env": "dev"},
+		map[string]string{"example.com/owner": "platform"},
+	)
+	require.NoError(t, err)
+
+	namespace, err := clientset.CoreV1().Namespaces().Get(t.Context(), "cilium", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "network", "env": "dev"}, namespace.Labels)
+	assert.Equal(t, map[string]string{"example.com/owner": "platform"}, namespace.Annotations)
+}
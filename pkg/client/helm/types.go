@@ -56,6 +56,10 @@ type ChartSpec struct {
 	Timeout     time.Duration
 	Silent      bool
 	UpgradeCRDs bool
+	// DependencyUpdate fetches the dependencies declared in a local chart's
+	// Chart.yaml that are missing from its charts/ directory before loading it,
+	// like `helm install --dependency-update`.
+	DependencyUpdate bool
 
 	ValuesYaml  string
 	ValueFiles  []string
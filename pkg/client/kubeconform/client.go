@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -20,6 +22,10 @@ import (
 // ErrValidationFailed indicates that validation failed.
 var ErrValidationFailed = errors.New("validation failed")
 
+// ErrNoOfflineSchemaLocations indicates that offline validation was requested
+// without a schema store or local schema location to validate against.
+var ErrNoOfflineSchemaLocations = errors.New("offline validation requires a schema store or local schema location")
+
 const (
 	// schemaCacheDirPerm is the permission for the schema cache directory.
 	schemaCacheDirPerm = 0o700
@@ -36,6 +42,8 @@ const (
 	defaultValidateRetryBaseWait = 500 * time.Millisecond
 	// defaultValidateRetryMaxWait caps the backoff between retries.
 	defaultValidateRetryMaxWait = 5 * time.Second
+	// schemaDownloadTimeout bounds a single schema download into the store.
+	schemaDownloadTimeout = 30 * time.Second
 )
 
 // Client provides kubeconform validation functionality.
@@ -46,6 +54,12 @@ type Client struct {
 	retryBaseWait time.Duration
 	// retryMaxWait caps the exponential-backoff delay between retries.
 	retryMaxWait time.Duration
+	// httpClient downloads schemas into the schema store.
+	httpClient *http.Client
+	// kubernetesSchemaLocation and crdsCatalogSchemaLocation are the remote
+	// locations the schema store is updated from.
+	kubernetesSchemaLocation  string
+	crdsCatalogSchemaLocation string
 }
 
 // NewClient creates a new kubeconform client.
@@ -54,6 +68,10 @@ func NewClient() *Client {
 		maxRetryAttempts: defaultValidateMaxRetryAttempts,
 		retryBaseWait:    defaultValidateRetryBaseWait,
 		retryMaxWait:     defaultValidateRetryMaxWait,
+		httpClient:       &http.Client{Timeout: schemaDownloadTimeout},
+
+		kubernetesSchemaLocation:  kubernetesSchemaLocation,
+		crdsCatalogSchemaLocation: crdsCatalogSchemaLocation,
 	}
 }
 
@@ -125,6 +143,13 @@ func (c *Client) validateData(
 		return fmt.Errorf("split manifests: %w", err)
 	}
 
+	if opts.UpdateSchemas && !opts.Offline && opts.SchemaStoreDir != "" {
+		err = c.updateSchemaStore(ctx, documents, opts)
+		if err != nil {
+			return fmt.Errorf("update schema store: %w", err)
+		}
+	}
+
 	kubeValidator, err := c.createValidator(opts)
 	if err != nil {
 		return fmt.Errorf("create validator: %w", err)
@@ -277,23 +302,59 @@ type ValidationOptions struct {
 	// a multi-layer render points at the originating layer. A nil map (the default)
 	// disables attribution and leaves failure messages unchanged.
 	Attribution map[string]string
+	// SchemaStoreDir is the schema store (see DefaultSchemaStoreDir) consulted
+	// before any other location, so schemas saved there win over the network.
+	// Empty disables the store.
+	SchemaStoreDir string
+	// UpdateSchemas downloads the schema of every validated kind into
+	// SchemaStoreDir before validating, refreshing the store for offline use.
+	UpdateSchemas bool
+	// Offline validates without network access: only the schema store and local
+	// SchemaLocations are consulted, and UpdateSchemas is ignored. Kinds without
+	// a local schema are handled according to IgnoreMissingSchemas.
+	Offline bool
 }
 
-// createValidator creates a kubeconform validator with the given options.
-func (c *Client) createValidator(opts *ValidationOptions) (validator.Validator, error) {
-	// Create schema locations. Caller-supplied locations are appended last so they
-	// act as a fallback for CRDs absent from the catalog (the catalog URL 404s and
-	// kubeconform falls through to these) without changing precedence for kinds
-	// already covered.
-	schemaLocations := make([]string, 0, builtinSchemaLocationCount+len(opts.SchemaLocations))
-	schemaLocations = append(schemaLocations,
+// schemaLocations returns the schema locations for opts, in lookup order: the
+// schema store, the default Kubernetes schemas, the CRDs-catalog, and then the
+// caller-supplied locations. Caller-supplied locations are appended last so
+// they act as a fallback for CRDs absent from the catalog (the catalog URL 404s
+// and kubeconform falls through to these) without changing precedence for
+// kinds already covered. In offline mode every remote location is dropped.
+func schemaLocations(opts *ValidationOptions) []string {
+	locations := make(
+		[]string,
+		0,
+		schemaStoreLocationCount+builtinSchemaLocationCount+len(opts.SchemaLocations),
+	)
+
+	if opts.SchemaStoreDir != "" {
+		locations = append(locations, SchemaStoreLocations(opts.SchemaStoreDir)...)
+	}
+
+	locations = append(locations,
 		// Default Kubernetes schemas
 		"default",
 		// Add Datree CRDs catalog for additional CRD schemas
-		"https://raw.githubusercontent.com/datreeio/CRDs-catalog/main/"+
-			"{{.Group}}/{{.ResourceKind}}_{{.ResourceAPIVersion}}.json",
+		crdsCatalogSchemaLocation,
 	)
-	schemaLocations = append(schemaLocations, opts.SchemaLocations...)
+	locations = append(locations, opts.SchemaLocations...)
+
+	if opts.Offline {
+		locations = slices.DeleteFunc(locations, isRemoteSchemaLocation)
+	}
+
+	return locations
+}
+
+// createValidator creates a kubeconform validator with the given options.
+func (c *Client) createValidator(opts *ValidationOptions) (validator.Validator, error) {
+	schemaLocations := schemaLocations(opts)
+
+	// kubeconform falls back to the network when given no location at all.
+	if opts.Offline && len(schemaLocations) == 0 {
+		return nil, ErrNoOfflineSchemaLocations
+	}
 
 	// Convert skip kinds to map
 	skipKinds := make(map[string]struct{})
@@ -327,7 +388,7 @@ func (c *Client) createValidator(opts *ValidationOptions) (validator.Validator,
 	validatorOpts := validator.Opts{
 		SkipKinds:            skipKinds,
 		RejectKinds:          make(map[string]struct{}),
-		KubernetesVersion:    "master",
+		KubernetesVersion:    kubernetesVersion,
 		Strict:               opts.Strict,
 		IgnoreMissingSchemas: opts.IgnoreMissingSchemas,
 		SkipTLS:              false,
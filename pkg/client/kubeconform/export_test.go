@@ -25,3 +25,10 @@ func (c *Client) SetRetryConfig(maxAttempts int, baseWait, maxWait time.Duration
 func (c *Client) MaxRetryAttempts() int {
 	return c.maxRetryAttempts
 }
+
+// SetSchemaSources overrides the remote locations the schema store is updated
+// from, so black-box tests can serve schemas from an httptest server.
+func (c *Client) SetSchemaSources(kubernetesLocation, crdsCatalogLocation string) {
+	c.kubernetesSchemaLocation = kubernetesLocation
+	c.crdsCatalogSchemaLocation = crdsCatalogLocation
+}
@@ -0,0 +1,280 @@
+package kubeconform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/netretry"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// kubernetesSchemaLocation is the URL template of the built-in Kubernetes
+	// schemas (what kubeconform calls the "default" location).
+	kubernetesSchemaLocation = "https://raw.githubusercontent.com/yannh/kubernetes-json-schema/master/" +
+		"{{ .NormalizedKubernetesVersion }}-standalone{{ .StrictSuffix }}/" +
+		"{{ .ResourceKind }}{{ .KindSuffix }}.json"
+	// crdsCatalogSchemaLocation is the URL template of the Datree CRDs-catalog.
+	crdsCatalogSchemaLocation = "https://raw.githubusercontent.com/datreeio/CRDs-catalog/main/" +
+		"{{.Group}}/{{.ResourceKind}}_{{.ResourceAPIVersion}}.json"
+
+	// kubernetesVersion is the Kubernetes version schemas are resolved for.
+	kubernetesVersion = "master"
+
+	// schemaStoreKubernetesDir holds the built-in Kubernetes schemas in the store.
+	schemaStoreKubernetesDir = "kubernetes"
+	// schemaStoreCRDsDir holds the CRD schemas in the store.
+	schemaStoreCRDsDir = "crds"
+	// schemaStoreLocationCount is the number of locations SchemaStoreLocations
+	// returns.
+	schemaStoreLocationCount = 2
+	// schemaStoreFilePerm is the permission for a schema file in the store.
+	schemaStoreFilePerm = 0o600
+	// maxSchemaSize caps a downloaded schema so a misbehaving server cannot
+	// exhaust memory.
+	maxSchemaSize = 10 << 20
+)
+
+// errSchemaNotFound is returned when a schema location has no schema for a kind.
+var errSchemaNotFound = errors.New("schema not found")
+
+// errUnexpectedSchemaStatus is returned when a schema download receives a
+// non-200, non-404 response.
+var errUnexpectedSchemaStatus = errors.New("unexpected HTTP status")
+
+// DefaultSchemaStoreDir returns the schema store directory, ~/.ksail/schemas.
+//
+// Unlike the download cache kubeconform keeps under the user cache directory,
+// the store is only written by `ksail workload validate --update-schemas`, is
+// never garbage collected, and is the only source of schemas in offline mode.
+func DefaultSchemaStoreDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".ksail", "schemas"), nil
+}
+
+// SchemaStoreCRDDir returns the directory of the store at storeDir that holds
+// CRD schemas, laid out as {group}/{kind}_{version}.json like the CRDs-catalog.
+func SchemaStoreCRDDir(storeDir string) string {
+	return filepath.Join(storeDir, schemaStoreCRDsDir)
+}
+
+// SchemaStoreLocations returns the kubeconform schema locations that resolve
+// schemas in the store at storeDir: the built-in Kubernetes schemas under
+// kubernetes/ (mirroring the upstream layout) and the CRD schemas under crds/.
+func SchemaStoreLocations(storeDir string) []string {
+	return []string{
+		filepath.Join(
+			storeDir,
+			schemaStoreKubernetesDir,
+			"{{ .NormalizedKubernetesVersion }}-standalone{{ .StrictSuffix }}",
+			"{{ .ResourceKind }}{{ .KindSuffix }}.json",
+		),
+		filepath.Join(
+			SchemaStoreCRDDir(storeDir),
+			"{{.Group}}",
+			"{{.ResourceKind}}_{{.ResourceAPIVersion}}.json",
+		),
+	}
+}
+
+// schemaSource pairs a remote schema location with the store location its
+// schemas are saved to.
+type schemaSource struct {
+	remote string
+	local  string
+}
+
+// updateSchemaStore downloads the schema of every kind in documents into the
+// store at opts.SchemaStoreDir, trying the built-in Kubernetes schemas before
+// the CRDs-catalog. A kind neither location knows is left to the validator's
+// missing-schema handling. Existing schemas are overwritten so the store is
+// refreshed on every update.
+func (c *Client) updateSchemaStore(ctx context.Context, documents [][]byte, opts *ValidationOptions) error {
+	locations := SchemaStoreLocations(opts.SchemaStoreDir)
+	sources := []schemaSource{
+		{remote: c.kubernetesSchemaLocation, local: locations[0]},
+		{remote: c.crdsCatalogSchemaLocation, local: locations[1]},
+	}
+
+	seen := make(map[resourceType]struct{}, len(documents))
+
+	for _, resource := range resourceTypes(documents) {
+		if _, skip := seen[resource]; skip || slices.Contains(opts.SkipKinds, resource.Kind) {
+			continue
+		}
+
+		seen[resource] = struct{}{}
+
+		err := c.storeSchema(ctx, sources, resource, opts.Strict)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeSchema saves the first schema found for resource across sources.
+func (c *Client) storeSchema(
+	ctx context.Context,
+	sources []schemaSource,
+	resource resourceType,
+	strict bool,
+) error {
+	for _, source := range sources {
+		url, err := schemaPath(source.remote, resource, strict)
+		if err != nil {
+			return err
+		}
+
+		var schema []byte
+
+		err = netretry.Do(ctx, max(c.maxRetryAttempts, 1), c.retryBaseWait, c.retryMaxWait, func() error {
+			var downloadErr error
+
+			schema, downloadErr = c.downloadSchema(ctx, url)
+
+			return downloadErr
+		})
+		if errors.Is(err, errSchemaNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("download schema for %s %s: %w", resource.APIVersion, resource.Kind, err)
+		}
+
+		path, err := schemaPath(source.local, resource, strict)
+		if err != nil {
+			return err
+		}
+
+		err = os.MkdirAll(filepath.Dir(path), schemaCacheDirPerm)
+		if err != nil {
+			return fmt.Errorf("create schema store directory: %w", err)
+		}
+
+		// Written atomically: concurrent validations of the same kind must not
+		// observe a partially written schema.
+		err = fsutil.AtomicWriteFile(path, schema, schemaStoreFilePerm)
+		if err != nil {
+			return fmt.Errorf("write schema %s: %w", path, err)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// downloadSchema fetches the schema at url, returning errSchemaNotFound on 404.
+func (c *Client) downloadSchema(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", url, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errSchemaNotFound
+	default:
+		return nil, fmt.Errorf("%w: %s returned %d", errUnexpectedSchemaStatus, url, resp.StatusCode)
+	}
+
+	schema, err := io.ReadAll(io.LimitReader(resp.Body, maxSchemaSize))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", url, err)
+	}
+
+	return schema, nil
+}
+
+// resourceType is the apiVersion and kind of a manifest.
+type resourceType struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// resourceTypes returns the type of every document that declares one.
+func resourceTypes(documents [][]byte) []resourceType {
+	types := make([]resourceType, 0, len(documents))
+
+	for _, document := range documents {
+		var resource resourceType
+
+		err := yaml.Unmarshal(document, &resource)
+		if err != nil || resource.APIVersion == "" || resource.Kind == "" {
+			continue
+		}
+
+		types = append(types, resource)
+	}
+
+	return types
+}
+
+// schemaPath renders a kubeconform schema-location template for resource,
+// exposing the same variables kubeconform does.
+func schemaPath(location string, resource resourceType, strict bool) (string, error) {
+	strictSuffix := ""
+	if strict {
+		strictSuffix = "-strict"
+	}
+
+	groupParts := strings.Split(resource.APIVersion, "/")
+	versionParts := strings.Split(groupParts[0], ".")
+
+	kindSuffix := "-" + strings.ToLower(versionParts[0])
+	if len(groupParts) > 1 {
+		kindSuffix += "-" + strings.ToLower(groupParts[1])
+	}
+
+	tmpl, err := template.New("location").Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("parse schema location %q: %w", location, err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, map[string]string{
+		"NormalizedKubernetesVersion": kubernetesVersion,
+		"StrictSuffix":                strictSuffix,
+		"ResourceKind":                strings.ToLower(resource.Kind),
+		"ResourceAPIVersion":          groupParts[len(groupParts)-1],
+		"Group":                       groupParts[0],
+		"KindSuffix":                  kindSuffix,
+	})
+	if err != nil {
+		return "", fmt.Errorf("render schema location %q: %w", location, err)
+	}
+
+	return buf.String(), nil
+}
+
+// isRemoteSchemaLocation reports whether a schema location is fetched over
+// the network: kubeconform's "default" alias or an http(s) URL.
+func isRemoteSchemaLocation(location string) bool {
+	return location == "default" || strings.HasPrefix(location, "http")
+}
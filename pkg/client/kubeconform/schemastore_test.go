@@ -0,0 +1,163 @@
+package kubeconform_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/kubeconform"
+)
+
+const configMapSchema = `{"type":"object","properties":{"data":{"type":"object"}}}`
+
+const widgetSchema = `{"type":"object","properties":{"spec":{"type":"object",` +
+	`"properties":{"size":{"type":"integer"}}}}}`
+
+const validConfigMapYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  key: value
+`
+
+const invalidConfigMapYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data: not-an-object
+`
+
+const widgetYAML = `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gear
+spec:
+  size: 3
+`
+
+// newSchemaServer serves the ConfigMap schema as a built-in Kubernetes schema
+// and the Widget schema from the CRDs-catalog, and 404s everything else.
+func newSchemaServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/kubernetes/master-standalone/configmap-v1.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(configMapSchema))
+	})
+	mux.HandleFunc("/catalog/example.com/widget_v1.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(widgetSchema))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newSchemaStoreClient(t *testing.T) *kubeconform.Client {
+	t.Helper()
+
+	server := newSchemaServer(t)
+
+	client := kubeconform.NewClient()
+	client.SetSchemaSources(
+		server.URL+"/kubernetes/{{ .NormalizedKubernetesVersion }}-standalone{{ .StrictSuffix }}/"+
+			"{{ .ResourceKind }}{{ .KindSuffix }}.json",
+		server.URL+"/catalog/{{.Group}}/{{.ResourceKind}}_{{.ResourceAPIVersion}}.json",
+	)
+
+	return client
+}
+
+func TestUpdateSchemasPopulatesStoreForOfflineValidation(t *testing.T) {
+	t.Parallel()
+
+	client := newSchemaStoreClient(t)
+	storeDir := t.TempDir()
+
+	err := client.ValidateBytes(context.Background(), "update", []byte(validConfigMapYAML+"---\n"+widgetYAML),
+		&kubeconform.ValidationOptions{SchemaStoreDir: storeDir, UpdateSchemas: true, Offline: false})
+	if err != nil {
+		t.Fatalf("update schemas: %v", err)
+	}
+
+	for _, path := range []string{
+		filepath.Join(storeDir, "kubernetes", "master-standalone", "configmap-v1.json"),
+		filepath.Join(kubeconform.SchemaStoreCRDDir(storeDir), "example.com", "widget_v1.json"),
+	} {
+		_, statErr := os.Stat(path)
+		if statErr != nil {
+			t.Fatalf("expected schema %s in store: %v", path, statErr)
+		}
+	}
+
+	offline := &kubeconform.ValidationOptions{SchemaStoreDir: storeDir, Offline: true}
+
+	err = client.ValidateBytes(context.Background(), "valid", []byte(validConfigMapYAML+"---\n"+widgetYAML), offline)
+	if err != nil {
+		t.Fatalf("expected offline validation to pass, got %v", err)
+	}
+
+	err = client.ValidateBytes(context.Background(), "invalid", []byte(invalidConfigMapYAML), offline)
+	if !errors.Is(err, kubeconform.ErrValidationFailed) {
+		t.Fatalf("expected offline validation to fail against the stored schema, got %v", err)
+	}
+}
+
+func TestUpdateSchemasSkipsUnknownAndSkippedKinds(t *testing.T) {
+	t.Parallel()
+
+	client := newSchemaStoreClient(t)
+	storeDir := t.TempDir()
+
+	manifests := validNamespaceYAML + "---\n" + validConfigMapYAML
+
+	err := client.ValidateBytes(context.Background(), "update", []byte(manifests),
+		&kubeconform.ValidationOptions{
+			SchemaStoreDir:       storeDir,
+			UpdateSchemas:        true,
+			Offline:              false,
+			SkipKinds:            []string{"ConfigMap", "Namespace"},
+			IgnoreMissingSchemas: true,
+		})
+	if err != nil {
+		t.Fatalf("update schemas: %v", err)
+	}
+
+	entries, err := os.ReadDir(storeDir)
+	if err != nil {
+		t.Fatalf("read store: %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("expected no schema to be stored for skipped kinds, got %d entries", len(entries))
+	}
+}
+
+func TestOfflineValidationIgnoresMissingSchemas(t *testing.T) {
+	t.Parallel()
+
+	err := kubeconform.NewClient().ValidateBytes(context.Background(), "offline", []byte(widgetYAML),
+		&kubeconform.ValidationOptions{SchemaStoreDir: t.TempDir(), Offline: true, IgnoreMissingSchemas: true})
+	if err != nil {
+		t.Fatalf("expected a kind missing from the store to be ignored, got %v", err)
+	}
+}
+
+func TestOfflineValidationRequiresLocalSchemaLocation(t *testing.T) {
+	t.Parallel()
+
+	err := kubeconform.NewClient().ValidateBytes(context.Background(), "offline", []byte(widgetYAML),
+		&kubeconform.ValidationOptions{
+			SchemaLocations: []string{"https://example.com/{{.ResourceKind}}.json"},
+			Offline:         true,
+		})
+	if !errors.Is(err, kubeconform.ErrNoOfflineSchemaLocations) {
+		t.Fatalf("expected ErrNoOfflineSchemaLocations, got %v", err)
+	}
+}
@@ -113,6 +113,19 @@ func TestCreateApplyCommand(t *testing.T) {
 	)
 }
 
+func TestCreateDiffCommand(t *testing.T) {
+	t.Parallel()
+
+	testCommandCreation(
+		t,
+		func(c *kubectl.Client, path string) *cobra.Command { return c.CreateDiffCommand(path) },
+		"diff",
+		"Diff manifests against the live cluster",
+		"Diff the live version of Kubernetes resources against the version that would be "+
+			"applied from local files, stdin, or URLs.",
+	)
+}
+
 func TestCreateApplyCommandHasFlags(t *testing.T) {
 	t.Parallel()
 
@@ -14,6 +14,7 @@ import (
 	"k8s.io/kubectl/pkg/cmd/debug"
 	"k8s.io/kubectl/pkg/cmd/delete"
 	"k8s.io/kubectl/pkg/cmd/describe"
+	"k8s.io/kubectl/pkg/cmd/diff"
 	"k8s.io/kubectl/pkg/cmd/edit"
 	"k8s.io/kubectl/pkg/cmd/exec"
 	"k8s.io/kubectl/pkg/cmd/explain"
@@ -72,6 +73,17 @@ func (c *Client) CreateApplyCommand(kubeConfigPath string) *cobra.Command {
 	}, kubeConfigPath)
 }
 
+// CreateDiffCommand creates a kubectl diff command with all its flags and behavior.
+func (c *Client) CreateDiffCommand(kubeConfigPath string) *cobra.Command {
+	return c.newWrappedCommand(commandSpec{
+		use:   "diff",
+		short: "Diff manifests against the live cluster",
+		long: "Diff the live version of Kubernetes resources against the version that would be " +
+			"applied from local files, stdin, or URLs.",
+		build: diff.NewCmdDiff,
+	}, kubeConfigPath)
+}
+
 // CreateCreateCommand creates a kubectl create command with all its flags and behavior.
 func (c *Client) CreateCreateCommand(kubeConfigPath string) *cobra.Command {
 	return c.newWrappedCommand(commandSpec{
@@ -0,0 +1,58 @@
+package sops
+
+import (
+	"fmt"
+
+	"github.com/getsops/sops/v3/aes"
+	"github.com/getsops/sops/v3/cmd/sops/common"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/stores/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// sopsMetadataProbe is the minimal shape used to detect SOPS metadata in a document.
+type sopsMetadataProbe struct {
+	Sops *struct {
+		MAC string `json:"mac"`
+	} `json:"sops"`
+}
+
+// IsEncryptedDocument reports whether a single YAML document carries SOPS
+// metadata (a top-level sops key with a MAC), the same check Flux's
+// kustomize-controller uses to decide which resources to decrypt.
+func IsEncryptedDocument(doc []byte) bool {
+	var probe sopsMetadataProbe
+
+	err := sigsyaml.Unmarshal(doc, &probe)
+	if err != nil {
+		return false
+	}
+
+	return probe.Sops != nil && probe.Sops.MAC != ""
+}
+
+// DecryptDocument decrypts a single SOPS-encrypted YAML document held in
+// memory, using the local key service (age keys from SOPS_AGE_KEY,
+// SOPS_AGE_KEY_FILE or the default keys.txt, plus any configured KMS). The
+// returned document has the sops metadata removed.
+func DecryptDocument(doc []byte) ([]byte, error) {
+	store := &yaml.Store{}
+
+	tree, err := store.LoadEncryptedFile(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encrypted document: %w", err)
+	}
+
+	_, err = common.DecryptTree(common.DecryptTreeOpts{
+		Cipher:      aes.NewCipher(),
+		Tree:        &tree,
+		KeyServices: []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt tree: %w", err)
+	}
+
+	decrypted, err := store.EmitPlainFile(tree.Branches)
+
+	return handleEmitError(err, decrypted)
+}
@@ -0,0 +1,76 @@
+package sops_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	sopsclient "github.com/devantler-tech/ksail/v7/pkg/client/sops"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/stores/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const plainSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+stringData:
+  password: hunter2
+`
+
+func TestIsEncryptedDocument(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, sopsclient.IsEncryptedDocument([]byte(plainSecret)))
+	assert.False(t, sopsclient.IsEncryptedDocument([]byte("sops: {}\n")))
+	assert.False(t, sopsclient.IsEncryptedDocument([]byte("not: [valid")))
+	assert.True(t, sopsclient.IsEncryptedDocument([]byte("data: x\nsops:\n  mac: ENC[abc]\n")))
+}
+
+//nolint:paralleltest // Sets SOPS_AGE_KEY.
+func TestDecryptDocumentRoundTrip(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Setenv("SOPS_AGE_KEY", identity.String())
+
+	ageKey, err := sopsage.MasterKeyFromRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	inputPath := filepath.Join(t.TempDir(), "secret.yaml")
+	require.NoError(t, os.WriteFile(inputPath, []byte(plainSecret), 0o600))
+
+	encrypted, err := sopsclient.Encrypt(sopsclient.EncryptOpts{
+		EncryptConfig: sopsclient.EncryptConfig{
+			EncryptedRegex: "^(data|stringData)$",
+			KeyGroups:      []sops.KeyGroup{{ageKey}},
+		},
+		Cipher:      aes.NewCipher(),
+		InputStore:  &yaml.Store{},
+		OutputStore: &yaml.Store{},
+		InputPath:   inputPath,
+		KeyServices: []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+	})
+	require.NoError(t, err)
+	require.True(t, sopsclient.IsEncryptedDocument(encrypted))
+	require.NotContains(t, string(encrypted), "hunter2")
+
+	decrypted, err := sopsclient.DecryptDocument(encrypted)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(decrypted), "password: hunter2")
+	assert.NotContains(t, string(decrypted), "sops:")
+}
+
+func TestDecryptDocumentRejectsPlainDocument(t *testing.T) {
+	t.Parallel()
+
+	_, err := sopsclient.DecryptDocument([]byte(plainSecret))
+	require.Error(t, err)
+}
@@ -0,0 +1,244 @@
+package velero
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// DefaultNamespace is the namespace the Velero server watches for Backup and
+// Restore resources.
+const DefaultNamespace = "velero"
+
+// defaultPollInterval is the interval between status checks while waiting
+// for a backup or restore to finish.
+const defaultPollInterval = 2 * time.Second
+
+// Phase is the lifecycle phase Velero reports for a Backup or Restore.
+type Phase string
+
+const (
+	// PhaseCompleted means the operation finished without errors.
+	PhaseCompleted Phase = "Completed"
+	// PhasePartiallyFailed means the operation finished but some items failed.
+	PhasePartiallyFailed Phase = "PartiallyFailed"
+	// PhaseFailed means the operation failed.
+	PhaseFailed Phase = "Failed"
+	// PhaseFailedValidation means Velero rejected the operation's spec.
+	PhaseFailedValidation Phase = "FailedValidation"
+)
+
+// ErrOperationFailed is returned when a backup or restore finishes in a phase
+// other than Completed.
+var ErrOperationFailed = errors.New("velero operation did not complete")
+
+//nolint:gochecknoglobals // GroupVersionResources are immutable lookup keys
+var (
+	// BackupGVR identifies Velero Backup resources.
+	BackupGVR = schema.GroupVersionResource{
+		Group:    "velero.io",
+		Version:  "v1",
+		Resource: "backups",
+	}
+	// RestoreGVR identifies Velero Restore resources.
+	RestoreGVR = schema.GroupVersionResource{
+		Group:    "velero.io",
+		Version:  "v1",
+		Resource: "restores",
+	}
+)
+
+// Status is the outcome Velero reports for a Backup or Restore.
+type Status struct {
+	Phase         Phase
+	Errors        int64
+	Warnings      int64
+	FailureReason string
+}
+
+// BackupOptions describes a backup to create.
+type BackupOptions struct {
+	// Name is the name of the Backup resource.
+	Name string
+	// IncludedNamespaces limits the backup to these namespaces (all when empty).
+	IncludedNamespaces []string
+	// TTL is how long Velero keeps the backup (Velero's default when zero).
+	TTL time.Duration
+}
+
+// RestoreOptions describes a restore to create.
+type RestoreOptions struct {
+	// Name is the name of the Restore resource.
+	Name string
+	// BackupName is the backup to restore from.
+	BackupName string
+	// IncludedNamespaces limits the restore to these namespaces (all when empty).
+	IncludedNamespaces []string
+}
+
+// Client creates and watches Velero Backup and Restore resources.
+type Client struct {
+	dynamic      dynamic.Interface
+	namespace    string
+	pollInterval time.Duration
+}
+
+// NewClient creates a client using the provided dynamic client.
+//
+// This is the primary constructor for unit tests.
+func NewClient(dyn dynamic.Interface) *Client {
+	return &Client{
+		dynamic:      dyn,
+		namespace:    DefaultNamespace,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// NewClientFromKubeconfig creates a client for the cluster of the given
+// kubeconfig and context.
+func NewClientFromKubeconfig(kubeconfig, kubeContext string) (*Client, error) {
+	dyn, err := k8s.NewDynamicClient(kubeconfig, kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("create velero client: %w", err)
+	}
+
+	return NewClient(dyn), nil
+}
+
+// CreateBackup asks Velero to take a backup.
+func (c *Client) CreateBackup(ctx context.Context, opts BackupOptions) error {
+	spec := map[string]any{}
+
+	if len(opts.IncludedNamespaces) > 0 {
+		spec["includedNamespaces"] = toAnySlice(opts.IncludedNamespaces)
+	}
+
+	if opts.TTL > 0 {
+		spec["ttl"] = opts.TTL.String()
+	}
+
+	return c.create(ctx, BackupGVR, "Backup", opts.Name, spec)
+}
+
+// CreateRestore asks Velero to restore a backup.
+func (c *Client) CreateRestore(ctx context.Context, opts RestoreOptions) error {
+	spec := map[string]any{
+		"backupName": opts.BackupName,
+	}
+
+	if len(opts.IncludedNamespaces) > 0 {
+		spec["includedNamespaces"] = toAnySlice(opts.IncludedNamespaces)
+	}
+
+	return c.create(ctx, RestoreGVR, "Restore", opts.Name, spec)
+}
+
+// WaitForBackup polls the named Backup until Velero reports a terminal phase.
+// It returns ErrOperationFailed when that phase is not Completed; ctx bounds
+// how long it waits.
+func (c *Client) WaitForBackup(ctx context.Context, name string) (Status, error) {
+	return c.wait(ctx, BackupGVR, name)
+}
+
+// WaitForRestore polls the named Restore until Velero reports a terminal
+// phase. It returns ErrOperationFailed when that phase is not Completed; ctx
+// bounds how long it waits.
+func (c *Client) WaitForRestore(ctx context.Context, name string) (Status, error) {
+	return c.wait(ctx, RestoreGVR, name)
+}
+
+func (c *Client) create(
+	ctx context.Context,
+	gvr schema.GroupVersionResource,
+	kind string,
+	name string,
+	spec map[string]any,
+) error {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": gvr.GroupVersion().String(),
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": c.namespace,
+			"labels": map[string]any{
+				"app.kubernetes.io/managed-by": "ksail",
+			},
+		},
+		"spec": spec,
+	}}
+
+	_, err := c.dynamic.Resource(gvr).
+		Namespace(c.namespace).
+		Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("create velero %s %q: %w", kind, name, err)
+	}
+
+	return nil
+}
+
+func (c *Client) wait(
+	ctx context.Context,
+	gvr schema.GroupVersionResource,
+	name string,
+) (Status, error) {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		obj, err := c.dynamic.Resource(gvr).
+			Namespace(c.namespace).
+			Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return Status{}, fmt.Errorf("get velero %s %q: %w", gvr.Resource, name, err)
+		}
+
+		status := statusOf(obj)
+
+		switch status.Phase {
+		case PhaseCompleted:
+			return status, nil
+		case PhasePartiallyFailed, PhaseFailed, PhaseFailedValidation:
+			return status, fmt.Errorf(
+				"%w: %s %q is %s", ErrOperationFailed, gvr.Resource, name, status.Phase,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("wait for velero %s %q: %w", gvr.Resource, name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// statusOf reads the status fields Velero sets on Backups and Restores.
+func statusOf(obj *unstructured.Unstructured) Status {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	errorCount, _, _ := unstructured.NestedInt64(obj.Object, "status", "errors")
+	warningCount, _, _ := unstructured.NestedInt64(obj.Object, "status", "warnings")
+	reason, _, _ := unstructured.NestedString(obj.Object, "status", "failureReason")
+
+	return Status{
+		Phase:         Phase(phase),
+		Errors:        errorCount,
+		Warnings:      warningCount,
+		FailureReason: reason,
+	}
+}
+
+func toAnySlice(values []string) []any {
+	out := make([]any, 0, len(values))
+	for _, value := range values {
+		out = append(out, value)
+	}
+
+	return out
+}
@@ -0,0 +1,140 @@
+package velero_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/velero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeClient(objects ...runtime.Object) (*velero.Client, *dynamicfake.FakeDynamicClient) {
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			velero.BackupGVR:  "BackupList",
+			velero.RestoreGVR: "RestoreList",
+		},
+		objects...,
+	)
+
+	client := velero.NewClient(dyn)
+	client.SetPollInterval(time.Millisecond)
+
+	return client, dyn
+}
+
+func veleroObject(kind, name, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "velero.io/v1",
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": velero.DefaultNamespace,
+		},
+		"status": map[string]any{
+			"phase":    phase,
+			"warnings": int64(2),
+		},
+	}}
+}
+
+func TestCreateBackup(t *testing.T) {
+	t.Parallel()
+
+	client, dyn := newFakeClient()
+
+	err := client.CreateBackup(context.Background(), velero.BackupOptions{
+		Name:               "nightly",
+		IncludedNamespaces: []string{"apps", "data"},
+		TTL:                24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	backup, err := dyn.Resource(velero.BackupGVR).
+		Namespace(velero.DefaultNamespace).
+		Get(context.Background(), "nightly", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	namespaces, _, _ := unstructured.NestedStringSlice(backup.Object, "spec", "includedNamespaces")
+	ttl, _, _ := unstructured.NestedString(backup.Object, "spec", "ttl")
+
+	assert.Equal(t, []string{"apps", "data"}, namespaces)
+	assert.Equal(t, "24h0m0s", ttl)
+}
+
+func TestCreateRestore(t *testing.T) {
+	t.Parallel()
+
+	client, dyn := newFakeClient()
+
+	err := client.CreateRestore(context.Background(), velero.RestoreOptions{
+		Name:       "nightly-restore",
+		BackupName: "nightly",
+	})
+	require.NoError(t, err)
+
+	restore, err := dyn.Resource(velero.RestoreGVR).
+		Namespace(velero.DefaultNamespace).
+		Get(context.Background(), "nightly-restore", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	backupName, _, _ := unstructured.NestedString(restore.Object, "spec", "backupName")
+	_, hasNamespaces, _ := unstructured.NestedStringSlice(
+		restore.Object, "spec", "includedNamespaces",
+	)
+
+	assert.Equal(t, "nightly", backupName)
+	assert.False(t, hasNamespaces)
+}
+
+func TestWaitForBackup_Completed(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient(veleroObject("Backup", "nightly", "Completed"))
+
+	status, err := client.WaitForBackup(context.Background(), "nightly")
+	require.NoError(t, err)
+
+	assert.Equal(t, velero.PhaseCompleted, status.Phase)
+	assert.Equal(t, int64(2), status.Warnings)
+}
+
+func TestWaitForRestore_PartiallyFailed(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient(veleroObject("Restore", "nightly-restore", "PartiallyFailed"))
+
+	status, err := client.WaitForRestore(context.Background(), "nightly-restore")
+	require.ErrorIs(t, err, velero.ErrOperationFailed)
+
+	assert.Equal(t, velero.PhasePartiallyFailed, status.Phase)
+}
+
+func TestWaitForBackup_RespectsContext(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient(veleroObject("Backup", "nightly", "InProgress"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitForBackup(ctx, "nightly")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitForBackup_Missing(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newFakeClient()
+
+	_, err := client.WaitForBackup(context.Background(), "missing")
+	require.Error(t, err)
+}
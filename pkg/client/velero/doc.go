@@ -0,0 +1,9 @@
+// Package velero drives Velero backups and restores for KSail.
+//
+// Velero is controlled entirely through its custom resources: creating a
+// velero.io/v1 Backup asks the Velero server to take a backup, creating a
+// Restore asks it to restore one. This package creates those resources with
+// the Kubernetes dynamic client and polls their status until Velero reports a
+// terminal phase, which keeps KSail free of a dependency on Velero's Go
+// module.
+package velero
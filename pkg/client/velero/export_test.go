@@ -0,0 +1,8 @@
+package velero
+
+import "time"
+
+// SetPollInterval overrides the status poll interval for tests.
+func (c *Client) SetPollInterval(interval time.Duration) {
+	c.pollInterval = interval
+}
@@ -0,0 +1,108 @@
+package envvar
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// StrictEnvVar turns on strict expansion of configuration files when set to a
+// true value (1, t, true). In strict mode a ${VAR} placeholder without a
+// default that references an unset variable is an error instead of a warning.
+const StrictEnvVar = "KSAIL_STRICT_ENV"
+
+// ErrUnsetVariable is returned by strict expansion when a placeholder without
+// a default references an unset environment variable.
+var ErrUnsetVariable = errors.New("environment variable not set")
+
+// StrictEnabled reports whether KSAIL_STRICT_ENV enables strict expansion.
+// Unparseable values leave strict mode off.
+func StrictEnabled() bool {
+	enabled, err := strconv.ParseBool(strings.TrimSpace(os.Getenv(StrictEnvVar)))
+
+	return err == nil && enabled
+}
+
+// StrictExpander expands placeholders like ExpandWithLookup but records every
+// unset variable referenced without a default, so one error can name all of
+// them across many expanded values.
+type StrictExpander struct {
+	lookup  func(string) (string, bool)
+	missing []string
+}
+
+// NewStrictExpander returns a StrictExpander using lookup, or os.LookupEnv
+// when lookup is nil.
+func NewStrictExpander(lookup func(string) (string, bool)) *StrictExpander {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	return &StrictExpander{lookup: lookup}
+}
+
+// Expand replaces the placeholders in value. Unset variables without a
+// default expand to an empty string and are reported by Err.
+func (e *StrictExpander) Expand(value string) string {
+	if value == "" {
+		return value
+	}
+
+	return pattern.ReplaceAllStringFunc(value, func(match string) string {
+		groups := pattern.FindStringSubmatch(match)
+		if len(groups) < minGroupsForVarName {
+			return match
+		}
+
+		envValue, exists := e.lookup(groups[1])
+		if exists {
+			return envValue
+		}
+
+		if groups[2] != "" {
+			return groups[3]
+		}
+
+		if !slices.Contains(e.missing, groups[1]) {
+			e.missing = append(e.missing, groups[1])
+		}
+
+		return ""
+	})
+}
+
+// Err returns ErrUnsetVariable naming every unset variable seen by Expand, in
+// first-seen order, or nil when there were none.
+func (e *StrictExpander) Err() error {
+	if len(e.missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: %s (set it, or give the placeholder a default with ${VAR:-default})",
+		ErrUnsetVariable, strings.Join(e.missing, ", "),
+	)
+}
+
+// ExpandConfig expands the placeholders in configuration file content with
+// lookup. When strict mode is enabled (see StrictEnabled) it fails with
+// ErrUnsetVariable on unset variables without a default; otherwise it behaves
+// like ExpandWithLookup and only warns.
+func ExpandConfig(content []byte, lookup func(string) (string, bool)) ([]byte, error) {
+	if !StrictEnabled() {
+		return []byte(ExpandWithLookup(string(content), lookup)), nil
+	}
+
+	expander := NewStrictExpander(lookup)
+	expanded := expander.Expand(string(content))
+
+	err := expander.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(expanded), nil
+}
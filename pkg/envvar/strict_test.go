@@ -0,0 +1,62 @@
+package envvar_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mapLookup(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+
+		return value, ok
+	}
+}
+
+func TestStrictExpander(t *testing.T) {
+	t.Parallel()
+
+	expander := envvar.NewStrictExpander(mapLookup(map[string]string{"HOST": "ghcr.io", "EMPTY": ""}))
+
+	assert.Equal(t, "ghcr.io/org", expander.Expand("${HOST}/org"))
+	assert.Empty(t, expander.Expand("${EMPTY}"))
+	assert.Equal(t, "v1.36.0", expander.Expand("${VERSION:-v1.36.0}"))
+	assert.Empty(t, expander.Expand("${OPTIONAL:-}"))
+	require.NoError(t, expander.Err())
+
+	assert.Equal(t, "/", expander.Expand("${MISSING_A}/${MISSING_B}"))
+	expander.Expand("${MISSING_A}")
+
+	err := expander.Err()
+	require.ErrorIs(t, err, envvar.ErrUnsetVariable)
+	assert.Contains(t, err.Error(), "MISSING_A, MISSING_B")
+}
+
+//nolint:paralleltest // Uses t.Setenv
+func TestStrictEnabled(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "true": true, "1": true, "false": false, "yes": false} {
+		t.Setenv(envvar.StrictEnvVar, value)
+		assert.Equal(t, want, envvar.StrictEnabled(), "KSAIL_STRICT_ENV=%q", value)
+	}
+}
+
+//nolint:paralleltest // Uses t.Setenv
+func TestExpandConfig(t *testing.T) {
+	lookup := mapLookup(map[string]string{"HOST": "ghcr.io"})
+	content := []byte("registry: ${HOST}\nmirror: ${MIRROR}\n")
+
+	t.Setenv(envvar.StrictEnvVar, "")
+
+	expanded, err := envvar.ExpandConfig(content, lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "registry: ghcr.io\nmirror: \n", string(expanded))
+
+	t.Setenv(envvar.StrictEnvVar, "true")
+
+	_, err = envvar.ExpandConfig(content, lookup)
+	require.ErrorIs(t, err, envvar.ErrUnsetVariable)
+	assert.Contains(t, err.Error(), "MIRROR")
+}
@@ -2,6 +2,9 @@ package k3d
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
@@ -252,6 +255,202 @@ func ApplyAPIServerFeatureGatesArgs(k3dConfig *v1alpha5.SimpleConfig) {
 	}
 }
 
+// Default K3s pod and service subnets, and the IPv6 subnets KSail pairs with them for
+// IPv6 and dual-stack clusters.
+const (
+	DefaultClusterCIDR     = "10.42.0.0/16"
+	DefaultServiceCIDR     = "10.43.0.0/16"
+	DefaultClusterCIDRIPv6 = "fd00:42::/56"
+	DefaultServiceCIDRIPv6 = "fd00:43::/112"
+)
+
+// IPFamilyArgs returns the K3s server args that give pods and services addresses of
+// the given IP family, or nil for IPv4 (the K3s default). Dual-stack lists the IPv4
+// subnet first so it stays the primary family.
+func IPFamilyArgs(family v1alpha1.IPFamily) []string {
+	if !family.IPv6() {
+		return nil
+	}
+
+	clusterCIDR, serviceCIDR := DefaultClusterCIDRIPv6, DefaultServiceCIDRIPv6
+	if family == v1alpha1.IPFamilyDual {
+		clusterCIDR = DefaultClusterCIDR + "," + clusterCIDR
+		serviceCIDR = DefaultServiceCIDR + "," + serviceCIDR
+	}
+
+	return []string{
+		"--cluster-cidr=" + clusterCIDR,
+		"--service-cidr=" + serviceCIDR,
+		"--flannel-ipv6-masq",
+	}
+}
+
+// ApplyIPFamilyArgs appends the IPFamilyArgs of family to the server nodes. A flag
+// already set in k3d.yaml, with any value, is left untouched.
+func ApplyIPFamilyArgs(k3dConfig *v1alpha5.SimpleConfig, family v1alpha1.IPFamily) {
+	for _, arg := range IPFamilyArgs(family) {
+		flag, _, _ := strings.Cut(arg, "=")
+		if k3sFlagPresent(k3dConfig.Options.K3sOptions.ExtraArgs, flag) {
+			continue
+		}
+
+		k3dConfig.Options.K3sOptions.ExtraArgs = append(
+			k3dConfig.Options.K3sOptions.ExtraArgs,
+			v1alpha5.K3sArgWithNodeFilters{
+				Arg:         arg,
+				NodeFilters: []string{"server:*"},
+			},
+		)
+	}
+}
+
+// ApplyAPIServerPort publishes the K3d API server on 127.0.0.1:port, so the kubeconfig
+// endpoint survives a recreate. A zero port leaves k3d.yaml untouched.
+func ApplyAPIServerPort(k3dConfig *v1alpha5.SimpleConfig, port int32) {
+	if port == 0 {
+		return
+	}
+
+	k3dConfig.ExposeAPI.HostPort = strconv.Itoa(int(port))
+
+	if k3dConfig.ExposeAPI.HostIP == "" {
+		k3dConfig.ExposeAPI.HostIP = "127.0.0.1"
+	}
+}
+
+// resourceLabelNodeFilter selects every K3d node container, the load balancer included.
+const resourceLabelNodeFilter = "all"
+
+// ApplyResourceLabels stamps the spec.cluster.resourceMetadata labels onto every K3d node
+// container through the runtime labels of k3d.yaml, sorted by key so the config is stable.
+// Labels k3d.yaml already sets keep their value.
+func ApplyResourceLabels(k3dConfig *v1alpha5.SimpleConfig, labels map[string]string) {
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if slices.ContainsFunc(
+			k3dConfig.Options.Runtime.Labels,
+			func(existing v1alpha5.LabelWithNodeFilters) bool {
+				existingKey, _, _ := strings.Cut(existing.Label, "=")
+
+				return existingKey == key
+			},
+		) {
+			continue
+		}
+
+		k3dConfig.Options.Runtime.Labels = append(
+			k3dConfig.Options.Runtime.Labels,
+			v1alpha5.LabelWithNodeFilters{
+				Label:       key + "=" + labels[key],
+				NodeFilters: []string{resourceLabelNodeFilter},
+			},
+		)
+	}
+}
+
+// ApplyNodeSettings registers the labels and taints of cluster.Nodes through the
+// kubelet of each matching K3d node, using "server:N" and "agent:N" node filters.
+// Args already present in k3d.yaml are left untouched. A GPU request becomes the
+// cluster-wide runtime.gpuRequest, which K3d turns into Docker device requests.
+func ApplyNodeSettings(k3dConfig *v1alpha5.SimpleConfig, cluster v1alpha1.ClusterSpec) {
+	if len(cluster.Nodes) == 0 {
+		return
+	}
+
+	roles := []struct {
+		role   v1alpha1.NodeRole
+		filter string
+		count  int
+	}{
+		{v1alpha1.NodeRoleControlPlane, "server", k3dConfig.Servers},
+		{v1alpha1.NodeRoleWorker, "agent", k3dConfig.Agents},
+	}
+
+	for _, group := range roles {
+		for index := range group.count {
+			settings := cluster.NodeSettingsFor(group.role, index)
+			filter := fmt.Sprintf("%s:%d", group.filter, index)
+
+			for _, arg := range nodeSettingsArgs(settings) {
+				entry := v1alpha5.K3sArgWithNodeFilters{Arg: arg, NodeFilters: []string{filter}}
+				if slices.ContainsFunc(
+					k3dConfig.Options.K3sOptions.ExtraArgs,
+					func(existing v1alpha5.K3sArgWithNodeFilters) bool {
+						return existing.Arg == arg && slices.Equal(existing.NodeFilters, entry.NodeFilters)
+					},
+				) {
+					continue
+				}
+
+				k3dConfig.Options.K3sOptions.ExtraArgs = append(
+					k3dConfig.Options.K3sOptions.ExtraArgs, entry,
+				)
+			}
+		}
+	}
+
+	// K3d takes a single GPU request for every node; validation ensures all
+	// entries that request GPUs agree on it.
+	for _, node := range cluster.Nodes {
+		if node.GPUs != "" {
+			k3dConfig.Options.Runtime.GPURequest = node.GPUs
+
+			break
+		}
+	}
+}
+
+// nodeSettingsArgs returns the K3s --node-label and --node-taint flags for settings,
+// with labels sorted by key so the generated config is stable.
+func nodeSettingsArgs(settings v1alpha1.NodeSettings) []string {
+	args := make([]string, 0, len(settings.Labels)+len(settings.Taints))
+
+	for _, key := range slices.Sorted(maps.Keys(settings.Labels)) {
+		args = append(args, "--node-label="+key+"="+settings.Labels[key])
+	}
+
+	for _, taint := range settings.Taints {
+		arg := "--node-taint=" + taint.Key
+		if taint.Value != "" {
+			arg += "=" + taint.Value
+		}
+
+		args = append(args, arg+":"+string(taint.Effect))
+	}
+
+	return args
+}
+
+// ApplyIngressPorts publishes the given host port mappings through the K3d
+// load balancer so an ingress controller pinned to fixed node ports answers on
+// the host. Mappings whose host port is already published are skipped, which
+// keeps the function idempotent and leaves user-defined ports untouched.
+func ApplyIngressPorts(k3dConfig *v1alpha5.SimpleConfig, mappings []v1alpha1.PortMapping) {
+	for _, mapping := range mappings {
+		hostPort := strconv.Itoa(int(mapping.HostPort))
+		if hostPortPublished(k3dConfig.Ports, hostPort) {
+			continue
+		}
+
+		k3dConfig.Ports = append(k3dConfig.Ports, v1alpha5.PortWithNodeFilters{
+			Port:        hostPort + ":" + strconv.Itoa(int(mapping.ContainerPort)),
+			NodeFilters: []string{"loadbalancer"},
+		})
+	}
+}
+
+// hostPortPublished reports whether a K3d port spec ("[ip:]hostPort:containerPort[/proto]")
+// already publishes the given host port.
+func hostPortPublished(ports []v1alpha5.PortWithNodeFilters, hostPort string) bool {
+	for _, port := range ports {
+		parts := strings.Split(port.Port, ":")
+		if len(parts) >= 2 && parts[len(parts)-2] == hostPort {
+			return true
+		}
+	}
+
+	return false
+}
+
 // k3sArgPresent reports whether the K3s extra args already include the given arg.
 func k3sArgPresent(existing []v1alpha5.K3sArgWithNodeFilters, arg string) bool {
 	for _, entry := range existing {
@@ -262,3 +461,14 @@ func k3sArgPresent(existing []v1alpha5.K3sArgWithNodeFilters, arg string) bool {
 
 	return false
 }
+
+// k3sFlagPresent reports whether the K3s extra args already set the given flag.
+func k3sFlagPresent(existing []v1alpha5.K3sArgWithNodeFilters, flag string) bool {
+	for _, entry := range existing {
+		if entry.Arg == flag || strings.HasPrefix(entry.Arg, flag+"=") {
+			return true
+		}
+	}
+
+	return false
+}
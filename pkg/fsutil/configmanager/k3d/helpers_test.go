@@ -638,3 +638,168 @@ func TestAPIServerFeatureGatesArgsForCNI(t *testing.T) {
 		assert.Nil(t, k3d.APIServerFeatureGatesArgsForCNI(v1alpha1.CNIDefault))
 	})
 }
+
+func TestApplyIngressPorts(t *testing.T) {
+	t.Parallel()
+
+	k3dConfig := &v1alpha5.SimpleConfig{
+		Ports: []v1alpha5.PortWithNodeFilters{
+			{Port: "127.0.0.1:443:8443", NodeFilters: []string{"loadbalancer"}},
+		},
+	}
+	mappings := v1alpha1.IngressPortMappings(v1alpha1.IngressControllerContour)
+
+	k3d.ApplyIngressPorts(k3dConfig, mappings)
+	k3d.ApplyIngressPorts(k3dConfig, mappings)
+
+	assert.Equal(t, []v1alpha5.PortWithNodeFilters{
+		{Port: "127.0.0.1:443:8443", NodeFilters: []string{"loadbalancer"}},
+		{Port: "80:30080", NodeFilters: []string{"loadbalancer"}},
+	}, k3dConfig.Ports)
+}
+
+func TestIPFamilyArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, k3d.IPFamilyArgs(v1alpha1.IPFamilyIPv4))
+	assert.Nil(t, k3d.IPFamilyArgs(""))
+	assert.Equal(t, []string{
+		"--cluster-cidr=fd00:42::/56",
+		"--service-cidr=fd00:43::/112",
+		"--flannel-ipv6-masq",
+	}, k3d.IPFamilyArgs(v1alpha1.IPFamilyIPv6))
+	assert.Equal(t, []string{
+		"--cluster-cidr=10.42.0.0/16,fd00:42::/56",
+		"--service-cidr=10.43.0.0/16,fd00:43::/112",
+		"--flannel-ipv6-masq",
+	}, k3d.IPFamilyArgs(v1alpha1.IPFamilyDual))
+}
+
+func TestApplyAPIServerPort(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero_port_is_a_noop", func(t *testing.T) {
+		t.Parallel()
+
+		k3dConfig := &v1alpha5.SimpleConfig{}
+		k3d.ApplyAPIServerPort(k3dConfig, 0)
+
+		assert.Empty(t, k3dConfig.ExposeAPI.HostPort)
+		assert.Empty(t, k3dConfig.ExposeAPI.HostIP)
+	})
+
+	t.Run("publishes_on_localhost", func(t *testing.T) {
+		t.Parallel()
+
+		k3dConfig := &v1alpha5.SimpleConfig{}
+		k3d.ApplyAPIServerPort(k3dConfig, 16443)
+
+		assert.Equal(t, "16443", k3dConfig.ExposeAPI.HostPort)
+		assert.Equal(t, "127.0.0.1", k3dConfig.ExposeAPI.HostIP)
+	})
+
+	t.Run("keeps_user_defined_host_ip", func(t *testing.T) {
+		t.Parallel()
+
+		k3dConfig := &v1alpha5.SimpleConfig{}
+		k3dConfig.ExposeAPI.HostIP = "0.0.0.0"
+		k3d.ApplyAPIServerPort(k3dConfig, 16443)
+
+		assert.Equal(t, "0.0.0.0", k3dConfig.ExposeAPI.HostIP)
+	})
+}
+
+func TestApplyIPFamilyArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds_args_to_server_nodes", func(t *testing.T) {
+		t.Parallel()
+
+		k3dConfig := &v1alpha5.SimpleConfig{}
+		k3d.ApplyIPFamilyArgs(k3dConfig, v1alpha1.IPFamilyDual)
+		k3d.ApplyIPFamilyArgs(k3dConfig, v1alpha1.IPFamilyDual)
+
+		args := k3dConfig.Options.K3sOptions.ExtraArgs
+		require.Len(t, args, 3)
+
+		for _, arg := range args {
+			assert.Equal(t, []string{"server:*"}, arg.NodeFilters)
+		}
+	})
+
+	t.Run("keeps_user_defined_subnets", func(t *testing.T) {
+		t.Parallel()
+
+		k3dConfig := &v1alpha5.SimpleConfig{}
+		k3dConfig.Options.K3sOptions.ExtraArgs = []v1alpha5.K3sArgWithNodeFilters{
+			{Arg: "--cluster-cidr=10.1.0.0/16,fd01::/56", NodeFilters: []string{"server:*"}},
+		}
+
+		k3d.ApplyIPFamilyArgs(k3dConfig, v1alpha1.IPFamilyDual)
+
+		args := k3dConfig.Options.K3sOptions.ExtraArgs
+		require.Len(t, args, 3)
+		assert.Equal(t, "--cluster-cidr=10.1.0.0/16,fd01::/56", args[0].Arg)
+		assert.Equal(t, "--service-cidr=10.43.0.0/16,fd00:43::/112", args[1].Arg)
+	})
+}
+
+func TestApplyNodeSettings(t *testing.T) {
+	t.Parallel()
+
+	first := int32(0)
+	k3dConfig := &v1alpha5.SimpleConfig{Servers: 1, Agents: 2}
+	cluster := v1alpha1.ClusterSpec{
+		Nodes: []v1alpha1.NodeSpec{
+			{Role: v1alpha1.NodeRoleWorker, Labels: map[string]string{"tier": "app", "zone": "a"}},
+			{
+				Role:   v1alpha1.NodeRoleWorker,
+				Index:  &first,
+				Taints: []v1alpha1.NodePoolTaint{{Key: "gpu", Value: "true", Effect: v1alpha1.TaintEffectNoSchedule}},
+			},
+		},
+	}
+
+	k3d.ApplyNodeSettings(k3dConfig, cluster)
+	k3d.ApplyNodeSettings(k3dConfig, cluster)
+
+	assert.Equal(t, []v1alpha5.K3sArgWithNodeFilters{
+		{Arg: "--node-label=tier=app", NodeFilters: []string{"agent:0"}},
+		{Arg: "--node-label=zone=a", NodeFilters: []string{"agent:0"}},
+		{Arg: "--node-taint=gpu=true:NoSchedule", NodeFilters: []string{"agent:0"}},
+		{Arg: "--node-label=tier=app", NodeFilters: []string{"agent:1"}},
+		{Arg: "--node-label=zone=a", NodeFilters: []string{"agent:1"}},
+	}, k3dConfig.Options.K3sOptions.ExtraArgs)
+}
+
+func TestApplyNodeSettings_GPURequest(t *testing.T) {
+	t.Parallel()
+
+	k3dConfig := &v1alpha5.SimpleConfig{Servers: 1, Agents: 1}
+
+	k3d.ApplyNodeSettings(k3dConfig, v1alpha1.ClusterSpec{
+		Nodes: []v1alpha1.NodeSpec{
+			{Role: v1alpha1.NodeRoleControlPlane, Labels: map[string]string{"tier": "system"}},
+			{Role: v1alpha1.NodeRoleWorker, GPUs: v1alpha1.GPUsAll},
+		},
+	})
+
+	assert.Equal(t, "all", k3dConfig.Options.Runtime.GPURequest)
+}
+
+func TestApplyResourceLabels(t *testing.T) {
+	t.Parallel()
+
+	k3dConfig := &v1alpha5.SimpleConfig{}
+	k3dConfig.Options.Runtime.Labels = []v1alpha5.LabelWithNodeFilters{
+		{Label: "team=k3d", NodeFilters: []string{"server:0"}},
+	}
+
+	k3d.ApplyResourceLabels(k3dConfig, map[string]string{"team": "platform", "env": "dev"})
+	k3d.ApplyResourceLabels(k3dConfig, map[string]string{"env": "dev"})
+
+	assert.Equal(t, []v1alpha5.LabelWithNodeFilters{
+		{Label: "team=k3d", NodeFilters: []string{"server:0"}},
+		{Label: "env=dev", NodeFilters: []string{"all"}},
+	}, k3dConfig.Options.Runtime.Labels)
+}
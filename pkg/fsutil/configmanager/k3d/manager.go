@@ -2,7 +2,9 @@ package k3d
 
 import (
 	"fmt"
+	"path/filepath"
 
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
 	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/loader"
 	k3dvalidator "github.com/devantler-tech/ksail/v7/pkg/fsutil/validator/k3d"
@@ -74,6 +76,8 @@ func NewConfigManager(configPath string) *ConfigManager {
 // Load loads the K3d configuration from the specified file.
 // Returns the loaded config, either freshly loaded or previously cached.
 // If the file doesn't exist, returns a default K3d cluster configuration.
+// A registries.config that references a registries.yaml file is inlined (see
+// ImportRegistriesConfig).
 // Validates the configuration after loading and returns an error if validation fails.
 // The opts parameter is accepted for interface compliance but not currently used.
 func (m *ConfigManager) Load(_ configmanager.LoadOptions) (*v1alpha5.SimpleConfig, error) {
@@ -82,7 +86,10 @@ func (m *ConfigManager) Load(_ configmanager.LoadOptions) (*v1alpha5.SimpleConfi
 		return m.config, nil
 	}
 
-	config, err := loader.LoadAndValidateConfig(
+	// Loading and validation are split so a registries.yaml referenced by path
+	// is inlined first: k3d's own validation would otherwise resolve the path
+	// against the working directory instead of k3d.yaml's directory.
+	config, err := loader.LoadConfigFromFile(
 		m.configPath,
 		func() *v1alpha5.SimpleConfig {
 			// Create default with proper APIVersion and Kind
@@ -90,14 +97,34 @@ func (m *ConfigManager) Load(_ configmanager.LoadOptions) (*v1alpha5.SimpleConfi
 
 			return config
 		},
-		k3dvalidator.NewValidator(),
 	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load K3d config: failed to load config: %w", err)
+	}
+
+	err = ImportRegistriesConfig(config, m.configDir())
 	if err != nil {
 		return nil, fmt.Errorf("failed to load K3d config: %w", err)
 	}
 
+	err = loader.ValidateConfig(config, k3dvalidator.NewValidator())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load K3d config: failed to validate config: %w", err)
+	}
+
 	m.config = config
 	m.configLoaded = true
 
 	return m.config, nil
 }
+
+// configDir returns the directory of the resolved k3d.yaml, which relative
+// registries.yaml references are resolved against.
+func (m *ConfigManager) configDir() string {
+	resolvedPath, err := fsutil.FindFile(m.configPath)
+	if err != nil {
+		return filepath.Dir(m.configPath)
+	}
+
+	return filepath.Dir(resolvedPath)
+}
@@ -0,0 +1,54 @@
+package k3d
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrRegistriesConfigImport is returned when a registries.yaml file referenced
+// from k3d.yaml cannot be read or parsed.
+var ErrRegistriesConfigImport = errors.New("failed to import k3d registries config")
+
+// ImportRegistriesConfig inlines a K3s registries.yaml file referenced from
+// registries.config. Like k3d, a single-line value is treated as a file path
+// and a multi-line value as the document itself; relative paths are resolved
+// against baseDir (the directory of k3d.yaml). Inlining the document lets KSail
+// merge its mirrors into an existing setup without dropping the file's auth,
+// TLS, or rewrite settings.
+func ImportRegistriesConfig(k3dConfig *v1alpha5.SimpleConfig, baseDir string) error {
+	if k3dConfig == nil {
+		return nil
+	}
+
+	ref := strings.TrimSpace(k3dConfig.Registries.Config)
+	if ref == "" || strings.Contains(ref, "\n") {
+		return nil
+	}
+
+	path := ref
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec // path comes from the user's k3d.yaml
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRegistriesConfigImport, err)
+	}
+
+	var document map[string]any
+
+	err = yaml.Unmarshal(content, &document)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrRegistriesConfigImport, ref, err)
+	}
+
+	k3dConfig.Registries.Config = string(content)
+
+	return nil
+}
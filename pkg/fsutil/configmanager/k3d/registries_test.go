@@ -0,0 +1,107 @@
+package k3d_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/k3d"
+	v1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importedRegistries = `mirrors:
+  docker.io:
+    endpoint:
+      - https://mirror.example.com
+    rewrite:
+      "^library/(.*)": "mirror/library/$1"
+configs:
+  mirror.example.com:
+    auth:
+      username: ci
+      password: secret
+    tls:
+      insecure_skip_verify: true
+`
+
+func TestImportRegistriesConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inlines a relative file reference", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(dir, "registries.yaml"), []byte(importedRegistries), 0o600,
+		))
+
+		cfg := &v1alpha5.SimpleConfig{}
+		cfg.Registries.Config = "registries.yaml"
+
+		require.NoError(t, k3d.ImportRegistriesConfig(cfg, dir))
+		assert.Equal(t, importedRegistries, cfg.Registries.Config)
+	})
+
+	t.Run("keeps an inline document", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &v1alpha5.SimpleConfig{}
+		cfg.Registries.Config = importedRegistries
+
+		require.NoError(t, k3d.ImportRegistriesConfig(cfg, t.TempDir()))
+		assert.Equal(t, importedRegistries, cfg.Registries.Config)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &v1alpha5.SimpleConfig{}
+		cfg.Registries.Config = "missing.yaml"
+
+		err := k3d.ImportRegistriesConfig(cfg, t.TempDir())
+		require.ErrorIs(t, err, k3d.ErrRegistriesConfigImport)
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(
+			filepath.Join(dir, "registries.yaml"), []byte("mirrors: ["), 0o600,
+		))
+
+		cfg := &v1alpha5.SimpleConfig{}
+		cfg.Registries.Config = "registries.yaml"
+
+		err := k3d.ImportRegistriesConfig(cfg, dir)
+		require.ErrorIs(t, err, k3d.ErrRegistriesConfigImport)
+	})
+}
+
+func TestLoadConfig_ImportsRegistriesFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "registries.yaml"), []byte(importedRegistries), 0o600,
+	))
+
+	configPath := filepath.Join(dir, "k3d.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`apiVersion: k3d.io/v1alpha5
+kind: Simple
+metadata:
+  name: imported
+registries:
+  config: registries.yaml
+`), 0o600))
+
+	config, err := k3d.NewConfigManager(configPath).Load(configmanager.LoadOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, importedRegistries, config.Registries.Config)
+	assert.Equal(t, []string{"https://mirror.example.com"},
+		k3d.ParseRegistryConfig(config.Registries.Config)["docker.io"])
+}
@@ -2,6 +2,9 @@ package kind
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
@@ -16,6 +19,26 @@ const DefaultClusterName = "kind"
 // DefaultNetworkName is the Docker network name used by Kind clusters.
 const DefaultNetworkName = "kind"
 
+// NetworkEnvVar is the environment variable Kind reads to attach cluster nodes
+// to a Docker network other than DefaultNetworkName.
+const NetworkEnvVar = "KIND_EXPERIMENTAL_DOCKER_NETWORK"
+
+// NetworkName returns the Docker network Kind attaches cluster nodes to: the
+// network named in NetworkEnvVar, or DefaultNetworkName when it is unset.
+func NetworkName() string {
+	if name := strings.TrimSpace(os.Getenv(NetworkEnvVar)); name != "" {
+		return name
+	}
+
+	return DefaultNetworkName
+}
+
+// TenantNetworkName returns the Docker network for a tenant's Kind clusters
+// (e.g. "kind-alice"), keeping tenants sharing a host off each other's network.
+func TenantNetworkName(prefix string) string {
+	return DefaultNetworkName + "-" + prefix
+}
+
 // DefaultMirrorsDir is the default directory name for Kind containerd host mirror configuration.
 const DefaultMirrorsDir = "kind/mirrors"
 
@@ -88,6 +111,212 @@ func ApplyKubeletCertRotationPatches(kindConfig *kindv1alpha4.Cluster) {
 	}
 }
 
+// ApplyIngressPortMappings adds the given host port mappings to the first
+// control-plane node so an ingress controller pinned to fixed node ports answers
+// on the host. Mappings whose host port is already mapped on that node are
+// skipped, which keeps the function idempotent and leaves user-defined mappings
+// untouched.
+func ApplyIngressPortMappings(kindConfig *kindv1alpha4.Cluster, mappings []v1alpha1.PortMapping) {
+	if len(mappings) == 0 {
+		return
+	}
+
+	// Ensure at least one node exists
+	if len(kindConfig.Nodes) == 0 {
+		kindConfig.Nodes = []kindv1alpha4.Node{{
+			Role:  kindv1alpha4.ControlPlaneRole,
+			Image: DefaultKindNodeImage,
+		}}
+	}
+
+	for i := range kindConfig.Nodes {
+		node := &kindConfig.Nodes[i]
+		if node.Role != kindv1alpha4.ControlPlaneRole {
+			continue
+		}
+
+		for _, mapping := range mappings {
+			if hasHostPort(node.ExtraPortMappings, mapping.HostPort) {
+				continue
+			}
+
+			node.ExtraPortMappings = append(node.ExtraPortMappings, kindv1alpha4.PortMapping{
+				ContainerPort: mapping.ContainerPort,
+				HostPort:      mapping.HostPort,
+				Protocol:      kindv1alpha4.PortMappingProtocol(mapping.Protocol),
+			})
+		}
+
+		return
+	}
+}
+
+func hasHostPort(mappings []kindv1alpha4.PortMapping, hostPort int32) bool {
+	for _, mapping := range mappings {
+		if mapping.HostPort == hostPort {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApplyIPFamily sets the IP family of the Kind cluster's pod and service networks.
+// Kind's ipv4, ipv6, and dual families match v1alpha1.IPFamily one-to-one; Kind
+// picks the matching default subnets. An unset family leaves kind.yaml untouched.
+func ApplyIPFamily(kindConfig *kindv1alpha4.Cluster, family v1alpha1.IPFamily) {
+	if family == "" {
+		return
+	}
+
+	kindConfig.Networking.IPFamily = kindv1alpha4.ClusterIPFamily(family)
+}
+
+// ApplyAPIServerPort publishes the Kind API server on the given localhost port, so the
+// kubeconfig endpoint survives a recreate. Kind keeps its loopback listen address
+// (127.0.0.1, or ::1 for IPv6 clusters). A zero port leaves kind.yaml untouched.
+func ApplyAPIServerPort(kindConfig *kindv1alpha4.Cluster, port int32) {
+	if port == 0 {
+		return
+	}
+
+	kindConfig.Networking.APIServerPort = port
+}
+
+// ApplyResourceLabels registers the spec.cluster.resourceMetadata labels on every Kind
+// node. Kind cannot label the node containers it runs, so they become Kind node labels,
+// which the kubelet registers on the Kubernetes nodes. Labels already set on a node keep
+// their value. A config without nodes gets Kind's default control-plane node.
+func ApplyResourceLabels(kindConfig *kindv1alpha4.Cluster, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	if len(kindConfig.Nodes) == 0 {
+		kindConfig.Nodes = []kindv1alpha4.Node{{Role: kindv1alpha4.ControlPlaneRole}}
+	}
+
+	for i := range kindConfig.Nodes {
+		node := &kindConfig.Nodes[i]
+
+		if node.Labels == nil {
+			node.Labels = make(map[string]string, len(labels))
+		}
+
+		for key, value := range labels {
+			if _, ok := node.Labels[key]; !ok {
+				node.Labels[key] = value
+			}
+		}
+	}
+}
+
+// controlPlaneTaint is the taint kubeadm registers on control-plane nodes by default.
+// Setting nodeRegistration.taints replaces kubeadm's default, so node taints on a
+// control-plane node are registered alongside it.
+const controlPlaneTaint = "node-role.kubernetes.io/control-plane"
+
+// ApplyNodeSettings registers the spec.cluster.nodes labels and taints on the Kind
+// nodes. Nodes are matched by role and by their index among the nodes of that role
+// in kind.yaml order, which is the order Kind names them in. Labels become Kind node
+// labels; taints become kubeadm nodeRegistration taints for both the init and join
+// configurations, since Kind renders both for every node. GPUs become NVIDIA device
+// mounts (see gpuMounts).
+func ApplyNodeSettings(kindConfig *kindv1alpha4.Cluster, cluster v1alpha1.ClusterSpec) {
+	if len(cluster.Nodes) == 0 {
+		return
+	}
+
+	indexes := map[kindv1alpha4.NodeRole]int{}
+
+	for i := range kindConfig.Nodes {
+		node := &kindConfig.Nodes[i]
+
+		role := v1alpha1.NodeRoleWorker
+		if node.Role == kindv1alpha4.ControlPlaneRole {
+			role = v1alpha1.NodeRoleControlPlane
+		}
+
+		settings := cluster.NodeSettingsFor(role, indexes[node.Role])
+		indexes[node.Role]++
+
+		if len(settings.Labels) > 0 {
+			if node.Labels == nil {
+				node.Labels = make(map[string]string, len(settings.Labels))
+			}
+
+			maps.Copy(node.Labels, settings.Labels)
+		}
+
+		if len(settings.Taints) > 0 {
+			node.KubeadmConfigPatches = append(
+				node.KubeadmConfigPatches,
+				nodeTaintsPatches(settings.Taints, role == v1alpha1.NodeRoleControlPlane)...,
+			)
+		}
+
+		for _, mount := range gpuMounts(settings.GPUs) {
+			if !slices.Contains(node.ExtraMounts, mount) {
+				node.ExtraMounts = append(node.ExtraMounts, mount)
+			}
+		}
+	}
+}
+
+// nvidiaDeviceMountDir is where the NVIDIA container runtime looks for device
+// requests passed as volume mounts.
+const nvidiaDeviceMountDir = "/var/run/nvidia-container-devices/"
+
+// gpuMounts returns the mounts that pass the GPUs selected by gpus through to a Kind
+// node. Kind cannot set Docker device requests, so it uses the NVIDIA container
+// runtime's volume-mount convention instead: mounting /dev/null at
+// /var/run/nvidia-container-devices/<device> exposes that device. The host's Docker
+// must use the NVIDIA runtime by default with
+// accept-nvidia-visible-devices-as-volume-mounts enabled.
+func gpuMounts(gpus string) []kindv1alpha4.Mount {
+	// Invalid values are rejected when the config is loaded.
+	devices, _ := v1alpha1.GPUDevices(gpus)
+
+	mounts := make([]kindv1alpha4.Mount, 0, len(devices))
+	for _, device := range devices {
+		mounts = append(mounts, kindv1alpha4.Mount{
+			HostPath:      "/dev/null",
+			ContainerPath: nvidiaDeviceMountDir + device,
+		})
+	}
+
+	return mounts
+}
+
+// nodeTaintsPatches returns the kubeadm InitConfiguration and JoinConfiguration
+// patches that register taints on a node.
+func nodeTaintsPatches(taints []v1alpha1.NodePoolTaint, controlPlane bool) []string {
+	var builder strings.Builder
+
+	builder.WriteString("nodeRegistration:\n  taints:\n")
+
+	if controlPlane {
+		builder.WriteString("  - key: " + controlPlaneTaint + "\n    effect: NoSchedule\n")
+	}
+
+	for _, taint := range taints {
+		_, _ = fmt.Fprintf(&builder, "  - key: %q\n", taint.Key)
+
+		if taint.Value != "" {
+			_, _ = fmt.Fprintf(&builder, "    value: %q\n", taint.Value)
+		}
+
+		builder.WriteString("    effect: " + string(taint.Effect) + "\n")
+	}
+
+	registration := builder.String()
+
+	return []string{
+		"kind: InitConfiguration\n" + registration,
+		"kind: JoinConfiguration\n" + registration,
+	}
+}
+
 // ImageVerificationPatch is a TOML containerd config patch that enables the image verifier plugin.
 // This requires containerd 2.x (Kind v0.31.0+ / kindest/node:v1.35.1+).
 // Verifier binaries (e.g., Cosign, Notation) must be pre-installed in the Kind node image
@@ -465,3 +465,182 @@ func TestApplyOIDCPatches_CAFileAddsArgAndMounts(t *testing.T) {
 	mount := kindConfig.Nodes[0].ExtraMounts[0]
 	assert.Equal(t, v1alpha1.OIDCCAContainerPath, mount.ContainerPath)
 }
+
+func TestApplyIngressPortMappings_AddsToFirstControlPlane(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &kindv1alpha4.Cluster{
+		Nodes: []kindv1alpha4.Node{
+			{Role: kindv1alpha4.WorkerRole},
+			{Role: kindv1alpha4.ControlPlaneRole},
+			{Role: kindv1alpha4.ControlPlaneRole},
+		},
+	}
+	mappings := v1alpha1.IngressPortMappings(v1alpha1.IngressControllerNginx)
+
+	kind.ApplyIngressPortMappings(kindConfig, mappings)
+	kind.ApplyIngressPortMappings(kindConfig, mappings)
+
+	assert.Empty(t, kindConfig.Nodes[0].ExtraPortMappings)
+	assert.Equal(t, []kindv1alpha4.PortMapping{
+		{ContainerPort: 30080, HostPort: 80, Protocol: kindv1alpha4.PortMappingProtocolTCP},
+		{ContainerPort: 30443, HostPort: 443, Protocol: kindv1alpha4.PortMappingProtocolTCP},
+	}, kindConfig.Nodes[1].ExtraPortMappings)
+	assert.Empty(t, kindConfig.Nodes[2].ExtraPortMappings)
+}
+
+func TestApplyIngressPortMappings_KeepsUserHostPort(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &kindv1alpha4.Cluster{}
+	userMapping := kindv1alpha4.PortMapping{ContainerPort: 31000, HostPort: 80}
+
+	kind.ApplyIngressPortMappings(kindConfig, nil)
+	assert.Empty(t, kindConfig.Nodes)
+
+	kindConfig.Nodes = []kindv1alpha4.Node{{
+		Role:              kindv1alpha4.ControlPlaneRole,
+		ExtraPortMappings: []kindv1alpha4.PortMapping{userMapping},
+	}}
+
+	kind.ApplyIngressPortMappings(
+		kindConfig,
+		v1alpha1.IngressPortMappings(v1alpha1.IngressControllerTraefik),
+	)
+
+	require.Len(t, kindConfig.Nodes[0].ExtraPortMappings, 2)
+	assert.Equal(t, userMapping, kindConfig.Nodes[0].ExtraPortMappings[0])
+	assert.Equal(t, int32(443), kindConfig.Nodes[0].ExtraPortMappings[1].HostPort)
+}
+
+func TestApplyIPFamily(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &kindv1alpha4.Cluster{}
+
+	kind.ApplyIPFamily(kindConfig, "")
+	assert.Empty(t, kindConfig.Networking.IPFamily)
+
+	kind.ApplyIPFamily(kindConfig, v1alpha1.IPFamilyDual)
+	assert.Equal(t, kindv1alpha4.DualStackFamily, kindConfig.Networking.IPFamily)
+
+	kind.ApplyIPFamily(kindConfig, v1alpha1.IPFamilyIPv6)
+	assert.Equal(t, kindv1alpha4.IPv6Family, kindConfig.Networking.IPFamily)
+}
+
+func TestApplyAPIServerPort(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &kindv1alpha4.Cluster{}
+
+	kind.ApplyAPIServerPort(kindConfig, 0)
+	assert.Zero(t, kindConfig.Networking.APIServerPort)
+
+	kind.ApplyAPIServerPort(kindConfig, 16443)
+	assert.Equal(t, int32(16443), kindConfig.Networking.APIServerPort)
+}
+
+func TestApplyNodeSettings(t *testing.T) {
+	t.Parallel()
+
+	second := int32(1)
+	kindConfig := &kindv1alpha4.Cluster{
+		Nodes: []kindv1alpha4.Node{
+			{Role: kindv1alpha4.ControlPlaneRole},
+			{Role: kindv1alpha4.WorkerRole},
+			{Role: kindv1alpha4.WorkerRole},
+		},
+	}
+
+	kind.ApplyNodeSettings(kindConfig, v1alpha1.ClusterSpec{
+		Nodes: []v1alpha1.NodeSpec{
+			{
+				Role:   v1alpha1.NodeRoleWorker,
+				Index:  &second,
+				Labels: map[string]string{"tier": "gpu"},
+				Taints: []v1alpha1.NodePoolTaint{
+					{Key: "gpu", Value: "true", Effect: v1alpha1.TaintEffectNoSchedule},
+				},
+			},
+			{
+				Role:   v1alpha1.NodeRoleControlPlane,
+				Taints: []v1alpha1.NodePoolTaint{{Key: "etcd", Effect: v1alpha1.TaintEffectNoExecute}},
+			},
+		},
+	})
+
+	assert.Empty(t, kindConfig.Nodes[1].Labels)
+	assert.Empty(t, kindConfig.Nodes[1].KubeadmConfigPatches)
+	assert.Equal(t, map[string]string{"tier": "gpu"}, kindConfig.Nodes[2].Labels)
+	assert.Equal(t, []string{
+		"kind: InitConfiguration\nnodeRegistration:\n  taints:\n" +
+			"  - key: \"gpu\"\n    value: \"true\"\n    effect: NoSchedule\n",
+		"kind: JoinConfiguration\nnodeRegistration:\n  taints:\n" +
+			"  - key: \"gpu\"\n    value: \"true\"\n    effect: NoSchedule\n",
+	}, kindConfig.Nodes[2].KubeadmConfigPatches)
+
+	require.Len(t, kindConfig.Nodes[0].KubeadmConfigPatches, 2)
+	assert.Contains(t, kindConfig.Nodes[0].KubeadmConfigPatches[0],
+		"  - key: node-role.kubernetes.io/control-plane\n    effect: NoSchedule\n"+
+			"  - key: \"etcd\"\n    effect: NoExecute\n")
+}
+
+func TestApplyNodeSettings_GPUs(t *testing.T) {
+	t.Parallel()
+
+	second := int32(1)
+	kindConfig := &kindv1alpha4.Cluster{
+		Nodes: []kindv1alpha4.Node{
+			{Role: kindv1alpha4.ControlPlaneRole},
+			{Role: kindv1alpha4.WorkerRole},
+			{Role: kindv1alpha4.WorkerRole},
+		},
+	}
+	cluster := v1alpha1.ClusterSpec{
+		Nodes: []v1alpha1.NodeSpec{
+			{Role: v1alpha1.NodeRoleWorker, GPUs: "2"},
+			{Role: v1alpha1.NodeRoleWorker, Index: &second, GPUs: v1alpha1.GPUsAll},
+		},
+	}
+
+	kind.ApplyNodeSettings(kindConfig, cluster)
+	// Applying twice must not duplicate the mounts.
+	kind.ApplyNodeSettings(kindConfig, cluster)
+
+	assert.Empty(t, kindConfig.Nodes[0].ExtraMounts)
+	assert.Equal(t, []kindv1alpha4.Mount{
+		{HostPath: "/dev/null", ContainerPath: "/var/run/nvidia-container-devices/0"},
+		{HostPath: "/dev/null", ContainerPath: "/var/run/nvidia-container-devices/1"},
+	}, kindConfig.Nodes[1].ExtraMounts)
+	assert.Equal(t, []kindv1alpha4.Mount{
+		{HostPath: "/dev/null", ContainerPath: "/var/run/nvidia-container-devices/all"},
+	}, kindConfig.Nodes[2].ExtraMounts)
+	assert.Equal(t, map[string]string{v1alpha1.GPUNodeLabel: "true"}, kindConfig.Nodes[2].Labels)
+}
+
+func TestApplyResourceLabels(t *testing.T) {
+	t.Parallel()
+
+	kindConfig := &kindv1alpha4.Cluster{
+		Nodes: []kindv1alpha4.Node{
+			{Role: kindv1alpha4.ControlPlaneRole, Labels: map[string]string{"team": "node"}},
+			{Role: kindv1alpha4.WorkerRole},
+		},
+	}
+
+	kind.ApplyResourceLabels(kindConfig, map[string]string{"team": "platform", "env": "dev"})
+
+	assert.Equal(t, map[string]string{"team": "node", "env": "dev"}, kindConfig.Nodes[0].Labels)
+	assert.Equal(t, map[string]string{"team": "platform", "env": "dev"}, kindConfig.Nodes[1].Labels)
+
+	defaultConfig := &kindv1alpha4.Cluster{}
+	kind.ApplyResourceLabels(defaultConfig, map[string]string{"team": "platform"})
+
+	require.Len(t, defaultConfig.Nodes, 1)
+	assert.Equal(t, kindv1alpha4.ControlPlaneRole, defaultConfig.Nodes[0].Role)
+	assert.Equal(t, map[string]string{"team": "platform"}, defaultConfig.Nodes[0].Labels)
+
+	untouched := &kindv1alpha4.Cluster{}
+	kind.ApplyResourceLabels(untouched, nil)
+	assert.Empty(t, untouched.Nodes)
+}
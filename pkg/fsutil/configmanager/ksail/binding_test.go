@@ -368,6 +368,7 @@ func TestAllClusterFieldSelectorsHaveFlagName(t *testing.T) {
 		configmanager.DefaultPolicyEngineFieldSelector(),
 		configmanager.DefaultCSIFieldSelector(),
 		configmanager.DefaultCDIFieldSelector(),
+		configmanager.DefaultMirrorRegistryTLSFieldSelector(),
 		configmanager.DefaultImportImagesFieldSelector(),
 		configmanager.KubernetesVersionFieldSelector(),
 		configmanager.DistributionVersionFieldSelector(),
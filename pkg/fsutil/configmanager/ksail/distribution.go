@@ -22,6 +22,9 @@ import (
 	talosgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/talos"
 	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
+	aksprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/aks"
+	gkeprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/gke"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/trust"
 	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"google.golang.org/protobuf/encoding/protojson"
 	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
@@ -130,6 +133,12 @@ func (m *ConfigManager) loadTalosConfig() (*talosconfigmanager.Configs, error) {
 	// Enable the MutatingAdmissionPolicy feature gate for Calico (see method doc).
 	m.addCalicoFeatureGatePatch(talosManager)
 
+	// Trust the spec.trust.caBundles certificates on every node.
+	err = m.addTrustBundlePatch(talosManager)
+	if err != nil {
+		return nil, err
+	}
+
 	// Inject kubelet cert rotation + CSR approver patches at runtime when
 	// metrics-server is enabled AND both patch files don't already exist on disk.
 	// Projects initialized with v7.4.0+ have the patch files; older projects
@@ -182,6 +191,28 @@ func (m *ConfigManager) addCalicoFeatureGatePatch(
 	)
 }
 
+// addTrustBundlePatch adds a TrustedRootsConfig document with the
+// spec.trust.caBundles certificates, so nodes behind a TLS-intercepting proxy
+// can pull images and reach external endpoints.
+func (m *ConfigManager) addTrustBundlePatch(talosManager *talosconfigmanager.ConfigManager) error {
+	bundle, err := trust.LoadBundle(m.Config.Spec.Trust)
+	if err != nil {
+		return fmt.Errorf("failed to load trust CA bundles: %w", err)
+	}
+
+	if bundle == "" {
+		return nil
+	}
+
+	talosManager.WithAdditionalPatches([]talosconfigmanager.Patch{{
+		Path:    "trusted-roots",
+		Scope:   talosconfigmanager.PatchScopeCluster,
+		Content: []byte(talosgenerator.TrustedRootsConfigYAML(trust.BundleName, bundle)),
+	}})
+
+	return nil
+}
+
 // addHetznerPatches injects Hetzner-specific runtime patches into talosManager:
 //   - external cloud provider patch (always, for CCM node initialization)
 //   - ingress firewall patches (when IngressFirewall is not Disabled AND patch files
@@ -202,6 +233,7 @@ func (m *ConfigManager) addHetznerPatches(
 			v1alpha1.HetznerNetworkCIDR(m.Config.Spec),
 			v1alpha1.HetznerCNIPort(m.Config.Spec),
 			m.Config.Spec.Provider.Hetzner.AllowedCIDRs,
+			m.Config.Spec.Provider.Hetzner.NodePortCIDRs,
 		)
 		if err != nil {
 			return err
@@ -373,6 +405,16 @@ func (m *ConfigManager) warnWorkerRoleLabel(content string) {
 // This allows commands to access the distribution config via cfgManager.DistributionConfig.
 // If distribution config file doesn't exist, an empty DistributionConfig is created.
 func (m *ConfigManager) loadAndCacheDistributionConfig() error {
+	err := m.cacheDistributionConfig()
+	if err != nil {
+		return err
+	}
+
+	return m.applyTenancy()
+}
+
+// cacheDistributionConfig loads and caches the configuration of the cluster's distribution.
+func (m *ConfigManager) cacheDistributionConfig() error {
 	m.DistributionConfig = &clusterprovisioner.DistributionConfig{}
 
 	switch m.Config.Spec.Cluster.Distribution {
@@ -500,6 +542,11 @@ func (m *ConfigManager) getDefaultTalosPatches() []talosconfigmanager.Patch {
 		patches = append(patches, kubeletCertRotationAndApproverPatches()...)
 	}
 
+	// IPv6 and dual-stack clusters need pod and service subnets of that family.
+	if m.Config.Spec.Cluster.Network.IPFamily.IPv6() {
+		patches = append(patches, ipFamilyPatch(m.Config.Spec.Cluster.Network.IPFamily))
+	}
+
 	// When using Hetzner provider, enable external cloud provider so that the
 	// Cloud Controller Manager can initialize nodes with a providerID and write
 	// node labels required by the CSI DaemonSet.
@@ -521,6 +568,17 @@ func disableDefaultCNIPatch() talosconfigmanager.Patch {
 	}
 }
 
+// ipFamilyPatch returns a Talos machine config patch that sets IPv6 or dual-stack pod
+// and service subnets. Used for runtime injection when no scaffolded project exists
+// (init=false) and ipFamily is ipv6 or dual.
+func ipFamilyPatch(family v1alpha1.IPFamily) talosconfigmanager.Patch {
+	return talosconfigmanager.Patch{
+		Path:    "ip-family",
+		Scope:   talosconfigmanager.PatchScopeCluster,
+		Content: []byte(talosconfigmanager.IPFamilyPatchYAML(family, false)),
+	}
+}
+
 // kubeletCertRotationAndApproverPatches returns the patches for enabling kubelet
 // certificate rotation and installing the CSR approver via inlineManifests.
 func kubeletCertRotationAndApproverPatches() []talosconfigmanager.Patch {
@@ -857,10 +915,11 @@ const (
 // cacheGKEConfig caches GKE configuration. The gke.yaml path comes from
 // spec.cluster.distributionConfig (defaulting to "gke.yaml") and, when the
 // file exists, is parsed as a declarative containerpb.Cluster spec (the shape
-// the GKE API's create call consumes). The cluster name is read from the spec
-// when present, with the kubeconfig context as fallback; the project — which
-// is API-call scope, not part of the cluster spec — and a location override
-// resolve from the environment variables named by spec.provider.gcp.
+// the GKE API's create call consumes), with spec.cluster.spot applied to its
+// node pools. The cluster name is read from the spec when present, with the
+// kubeconfig context as fallback; the project — which is API-call scope, not
+// part of the cluster spec — and a location override resolve from the
+// environment variables named by spec.provider.gcp.
 func (m *ConfigManager) cacheGKEConfig() error {
 	configPath := strings.TrimSpace(m.Config.Spec.Cluster.DistributionConfig)
 	if configPath == "" {
@@ -872,6 +931,8 @@ func (m *ConfigManager) cacheGKEConfig() error {
 		return err
 	}
 
+	gkeprovisioner.ApplySpot(clusterSpec, m.Config.Spec.Cluster.Spot)
+
 	name := clusterSpec.GetName()
 	if name == "" {
 		name = m.resolveGKENameFromContext()
@@ -983,11 +1044,11 @@ const (
 // cacheAKSConfig caches AKS configuration. The aks.yaml path comes from
 // spec.cluster.distributionConfig (defaulting to "aks.yaml") and, when the
 // file exists, is parsed as a declarative armcontainerservice.ManagedCluster
-// spec (the shape the AKS API's create call consumes). The cluster name is
-// read from the spec when present, with the kubeconfig context as fallback;
-// the subscription and resource group — which are API-call scope, not part of
-// the cluster spec — resolve from the environment variables named by
-// spec.provider.azure.
+// spec (the shape the AKS API's create call consumes), with spec.cluster.spot
+// applied to its user agent pools. The cluster name is read from the spec when
+// present, with the kubeconfig context as fallback; the subscription and
+// resource group — which are API-call scope, not part of the cluster spec —
+// resolve from the environment variables named by spec.provider.azure.
 func (m *ConfigManager) cacheAKSConfig() error {
 	configPath := strings.TrimSpace(m.Config.Spec.Cluster.DistributionConfig)
 	if configPath == "" {
@@ -999,6 +1060,8 @@ func (m *ConfigManager) cacheAKSConfig() error {
 		return err
 	}
 
+	aksprovisioner.ApplySpot(clusterSpec, m.Config.Spec.Cluster.Spot)
+
 	name := ""
 	if clusterSpec != nil && clusterSpec.Name != nil {
 		name = *clusterSpec.Name
@@ -1086,12 +1149,13 @@ var (
 // This generates three patches: a default action (ingress: block) for all nodes, plus
 // per-role NetworkRuleConfig documents for control-plane and worker nodes.
 // When allowedCIDRs is non-empty, the CP rules restrict apid and kubernetes-api access
-// to the specified CIDR blocks instead of 0.0.0.0/0.
+// to the specified CIDR blocks instead of 0.0.0.0/0. When nodePortCIDRs is non-empty,
+// both roles additionally admit those CIDR blocks on the NodePort range.
 // See: https://www.talos.dev/latest/talos-guides/network/ingress-firewall/
 func ingressFirewallPatches(
 	networkCIDR string,
 	cniPort int,
-	allowedCIDRs []string,
+	allowedCIDRs, nodePortCIDRs []string,
 ) ([]talosconfigmanager.Patch, error) {
 	cidr := strings.TrimSpace(networkCIDR)
 	if cidr == "" {
@@ -1119,13 +1183,20 @@ func ingressFirewallPatches(
 			Path:  "ingress-firewall-cp-rules",
 			Scope: talosconfigmanager.PatchScopeControlPlane,
 			Content: []byte(
-				talosgenerator.IngressFirewallCPRulesYAML(normalizedCIDR, cniPort, allowedCIDRs),
+				talosgenerator.IngressFirewallCPRulesYAML(
+					normalizedCIDR,
+					cniPort,
+					allowedCIDRs,
+					nodePortCIDRs,
+				),
 			),
 		},
 		{
-			Path:    "ingress-firewall-worker-rules",
-			Scope:   talosconfigmanager.PatchScopeWorker,
-			Content: []byte(talosgenerator.IngressFirewallWorkerRulesYAML(normalizedCIDR, cniPort)),
+			Path:  "ingress-firewall-worker-rules",
+			Scope: talosconfigmanager.PatchScopeWorker,
+			Content: []byte(
+				talosgenerator.IngressFirewallWorkerRulesYAML(normalizedCIDR, cniPort, nodePortCIDRs),
+			),
 		},
 	}, nil
 }
@@ -185,6 +185,7 @@ func TestIngressFirewallPatchesErrors(t *testing.T) {
 				testCase.networkCIDR,
 				testCase.cniPort,
 				nil,
+				nil,
 			)
 
 			require.ErrorIs(t, err, testCase.wantErr)
@@ -202,7 +203,7 @@ func TestIngressFirewallPatchesSuccess(t *testing.T) {
 	t.Run("no allowed CIDRs defaults to allow-all", func(t *testing.T) {
 		t.Parallel()
 
-		patches, err := configmanager.IngressFirewallPatchesForTest("10.244.0.0/16", 8472, nil)
+		patches, err := configmanager.IngressFirewallPatchesForTest("10.244.0.0/16", 8472, nil, nil)
 		require.NoError(t, err)
 		require.Len(t, patches, 3)
 
@@ -225,6 +226,7 @@ func TestIngressFirewallPatchesSuccess(t *testing.T) {
 			"10.244.0.0/16",
 			8472,
 			[]string{"192.168.1.0/24"},
+			nil,
 		)
 		require.NoError(t, err)
 		require.Len(t, patches, 3)
@@ -237,7 +239,7 @@ func TestIngressFirewallPatchesSuccess(t *testing.T) {
 	t.Run("non-canonical network CIDR is normalized", func(t *testing.T) {
 		t.Parallel()
 
-		patches, err := configmanager.IngressFirewallPatchesForTest("192.168.1.5/24", 8472, nil)
+		patches, err := configmanager.IngressFirewallPatchesForTest("192.168.1.5/24", 8472, nil, nil)
 		require.NoError(t, err)
 		require.Len(t, patches, 3)
 
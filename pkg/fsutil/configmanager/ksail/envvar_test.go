@@ -0,0 +1,43 @@
+package configmanager_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_StrictEnvRejectsUnsetVariable(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(envvar.StrictEnvVar, "true")
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "  workload:\n", "    tag: ${KSAIL_TEST_UNSET_TAG}\n")
+
+	_, err := newManagerWithDefaultSelectors().Load(configmanagerinterface.LoadOptions{Silent: true})
+
+	require.ErrorIs(t, err, envvar.ErrUnsetVariable)
+	assert.Contains(t, err.Error(), "KSAIL_TEST_UNSET_TAG")
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_StrictEnvAcceptsDefaults(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(envvar.StrictEnvVar, "true")
+	t.Setenv("KSAIL_TEST_REGISTRY_HOST", "ghcr.io")
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t,
+		"  workload:\n",
+		"    tag: ${KSAIL_TEST_UNSET_TAG:-dev}\n",
+		"    git:\n",
+		"      url: https://${KSAIL_TEST_REGISTRY_HOST}/org/repo\n",
+	)
+
+	cfg, err := newManagerWithDefaultSelectors().Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "dev", cfg.Spec.Workload.Tag)
+	assert.Equal(t, "https://ghcr.io/org/repo", cfg.Spec.Workload.Git.URL)
+}
@@ -0,0 +1,141 @@
+package configmanager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/conversion"
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/configbase"
+	"sigs.k8s.io/yaml"
+)
+
+// extendsKey is the top-level ksail.yaml key naming a shared base configuration.
+const extendsKey = "extends"
+
+// ErrInvalidExtends is returned when the base configuration cannot be used.
+var ErrInvalidExtends = errors.New("invalid extends")
+
+// applyExtends merges the base configuration named by the extends key under the config file:
+// the base is read first and the local file merged over it, so local values win. Mappings merge
+// key by key; lists and scalars in the local file replace the base value. Profiles are applied
+// afterwards and may come from either file. Each fetched base is cached under ~/.ksail, and the
+// cached copy is used, with a warning, when the base cannot be fetched.
+func (m *ConfigManager) applyExtends(ctx context.Context, silent bool) error {
+	ref := strings.TrimSpace(envvar.Expand(m.Viper.GetString(extendsKey)))
+	if ref == "" {
+		return nil
+	}
+
+	// Without a home directory the base is fetched uncached.
+	cacheDir, _ := configbase.CacheDir()
+
+	fetched, err := configbase.FetchCached(ctx, ref, configbase.Options{}, cacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to fetch base config %s: %w", ref, err)
+	}
+
+	if fetched.FetchErr != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "failed to fetch base config '%s', using the cached copy: %v",
+			Args:    []any{ref, fetched.FetchErr},
+			Writer:  m.Writer,
+		})
+	}
+
+	base, err := convertBaseConfig(ref, fetched.Data)
+	if err != nil {
+		return err
+	}
+
+	local, err := m.readLocalConfig()
+	if err != nil {
+		return err
+	}
+
+	err = m.Viper.ReadConfig(bytes.NewReader(base))
+	if err != nil {
+		return fmt.Errorf("read base config %s: %w", ref, err)
+	}
+
+	err = m.Viper.MergeConfig(bytes.NewReader(local))
+	if err != nil {
+		return fmt.Errorf("merge %s over base config %s: %w", m.Viper.ConfigFileUsed(), ref, err)
+	}
+
+	if !silent {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "extending base config '%s'",
+			Args:    []any{ref},
+			Writer:  m.Writer,
+		})
+	}
+
+	return nil
+}
+
+// convertBaseConfig checks that the fetched base does not extend another base and upgrades it
+// from an older API version like a local file.
+func convertBaseConfig(ref string, data []byte) ([]byte, error) {
+	converted, fields, err := toCurrentAPIVersion(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: base config %s: %w", ErrInvalidExtends, ref, err)
+	}
+
+	if _, nested := fields[extendsKey]; nested {
+		return nil, fmt.Errorf(
+			"%w: base config %s sets extends itself; a base cannot extend another base",
+			ErrInvalidExtends, ref,
+		)
+	}
+
+	return converted, nil
+}
+
+// readLocalConfig returns the config file content, converted to the current API version the
+// same way convertOlderAPIVersion converted it in memory.
+func (m *ConfigManager) readLocalConfig() ([]byte, error) {
+	configFile := m.Viper.ConfigFileUsed()
+
+	data, err := os.ReadFile(configFile) //nolint:gosec // path resolved by Viper
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", configFile, err)
+	}
+
+	converted, _, err := toCurrentAPIVersion(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configFile, err)
+	}
+
+	return converted, nil
+}
+
+// toCurrentAPIVersion parses a ksail.yaml document and converts it when it declares an older API
+// version. It returns the document's top-level fields alongside the converted content.
+func toCurrentAPIVersion(data []byte) ([]byte, map[string]any, error) {
+	var fields map[string]any
+
+	err := yaml.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse YAML: %w", err)
+	}
+
+	apiVersion, _ := fields["apiVersion"].(string)
+	if !conversion.NeedsConversion(apiVersion) {
+		return data, fields, nil
+	}
+
+	converted, _, err := conversion.Convert(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("convert from %s: %w", apiVersion, err)
+	}
+
+	return converted, fields, nil
+}
@@ -0,0 +1,167 @@
+package configmanager_test
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const platformBaseYAML = "apiVersion: ksail.io/v1alpha1\n" +
+	"kind: Cluster\n" +
+	"spec:\n" +
+	"  cluster:\n" +
+	"    metricsServer: Disabled\n" +
+	"    workers: 3\n" +
+	"profiles:\n" +
+	"  ci:\n" +
+	"    cluster:\n" +
+	"      workers: 0\n"
+
+// pushBaseConfigServer is pushBaseConfig that also returns the registry server, so tests can
+// take it offline.
+func pushBaseConfigServer(t *testing.T, content string) (string, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	image, err := mutate.Append(
+		mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		mutate.Addendum{
+			Layer:       static.NewLayer([]byte(content), "application/vnd.ksail.config.v1+yaml"),
+			Annotations: map[string]string{"org.opencontainers.image.title": "ksail.yaml"},
+		},
+	)
+	require.NoError(t, err)
+
+	ref := strings.TrimPrefix(server.URL, "http://") + "/platform/ksail-base:v1"
+
+	parsed, err := name.ParseReference(ref)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(parsed, image))
+
+	return "oci://" + ref, server
+}
+
+// pushBaseConfig publishes content as ksail.yaml in an OCI artifact on an in-memory registry
+// and returns the oci:// reference to it.
+func pushBaseConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	ref, _ := pushBaseConfigServer(t, content)
+
+	return ref
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ExtendsMergesBaseUnderLocalConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "    workers: 1\n", "extends: "+pushBaseConfig(t, platformBaseYAML))
+
+	var out bytes.Buffer
+
+	manager := newManagerWithDefaultSelectors()
+	manager.Writer = &out
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.MetricsServerDisabled, cfg.Spec.Cluster.MetricsServer,
+		"fields only the base sets are inherited")
+	assert.Equal(t, int32(1), cfg.Spec.Cluster.Workers, "local values win over the base")
+	assert.Equal(t, v1alpha1.DistributionVanilla, cfg.Spec.Cluster.Distribution)
+	assert.Contains(t, out.String(), "extending base config 'oci://")
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ExtendsProvidesProfiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(configmanager.ProfileEnvVar, "")
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "extends: "+pushBaseConfig(t, platformBaseYAML))
+
+	manager := newManagerWithDefaultSelectors()
+	manager.Profile = "ci"
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Zero(t, cfg.Spec.Cluster.Workers)
+	assert.Equal(t, "ci", manager.ActiveProfile())
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ExtendsRejectsNestedBase(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	writeKindConfigFile(t)
+	writeClusterConfigFile(
+		t, "extends: "+pushBaseConfig(t, platformBaseYAML+"extends: https://example.com/ksail.yaml\n"),
+	)
+
+	manager := newManagerWithDefaultSelectors()
+
+	_, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.ErrorIs(t, err, configmanager.ErrInvalidExtends)
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ExtendsFallsBackToCachedBase(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	writeKindConfigFile(t)
+
+	ref, server := pushBaseConfigServer(t, platformBaseYAML)
+	writeClusterConfigFile(t, "extends: "+ref)
+
+	_, err := newManagerWithDefaultSelectors().Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	server.Close()
+
+	var out bytes.Buffer
+
+	manager := newManagerWithDefaultSelectors()
+	manager.Writer = &out
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.MetricsServerDisabled, cfg.Spec.Cluster.MetricsServer,
+		"the cached base is used while the registry is unreachable")
+	assert.Contains(t, out.String(), "using the cached copy")
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ExtendsUsesLoadContext(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "extends: "+pushBaseConfig(t, platformBaseYAML))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := newManagerWithDefaultSelectors().Load(
+		configmanagerinterface.LoadOptions{Context: ctx, Silent: true},
+	)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -142,6 +142,16 @@ func DefaultLocalRegistryFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	}
 }
 
+// DefaultMirrorRegistryTLSFieldSelector creates a standard field selector for mirror registry TLS.
+func DefaultMirrorRegistryTLSFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.MirrorRegistryTLS },
+		FlagName:     "mirror-registry-tls",
+		Description:  "Mirror registry TLS (Enabled: serve HTTPS with a KSail-generated CA, Disabled: plain HTTP)",
+		DefaultValue: v1alpha1.MirrorRegistryTLSDisabled,
+	}
+}
+
 // DefaultMetricsServerFieldSelector creates a standard field selector for Metrics Server.
 func DefaultMetricsServerFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	return FieldSelector[v1alpha1.Cluster]{
@@ -172,6 +182,36 @@ func DefaultCertManagerFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	}
 }
 
+// DefaultSealedSecretsFieldSelector creates a standard field selector for Sealed Secrets.
+func DefaultSealedSecretsFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.SealedSecrets },
+		FlagName:     "sealed-secrets",
+		Description:  "Sealed Secrets controller (Enabled: install, Disabled: skip)",
+		DefaultValue: v1alpha1.SealedSecretsDisabled,
+	}
+}
+
+// DefaultExternalSecretsFieldSelector creates a standard field selector for External Secrets.
+func DefaultExternalSecretsFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.ExternalSecrets },
+		FlagName:     "external-secrets",
+		Description:  "External Secrets Operator (Enabled: install, Disabled: skip)",
+		DefaultValue: v1alpha1.ExternalSecretsDisabled,
+	}
+}
+
+// DefaultBackupFieldSelector creates a standard field selector for Backup.
+func DefaultBackupFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.Backup },
+		FlagName:     "backup",
+		Description:  "Backup system (None: skip, Velero: install Velero with a local MinIO target)",
+		DefaultValue: v1alpha1.BackupNone,
+	}
+}
+
 // DefaultPolicyEngineFieldSelector creates a standard field selector for Policy Engine.
 func DefaultPolicyEngineFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	return FieldSelector[v1alpha1.Cluster]{
@@ -182,6 +222,96 @@ func DefaultPolicyEngineFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	}
 }
 
+// DefaultIngressControllerFieldSelector creates a standard field selector for the ingress controller.
+func DefaultIngressControllerFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.IngressController },
+		FlagName:     "ingress-controller",
+		Description:  "Ingress controller (None: skip, Nginx: ingress-nginx, Traefik, Contour)",
+		DefaultValue: v1alpha1.IngressControllerNone,
+	}
+}
+
+// DefaultGatewayAPIFieldSelector creates a standard field selector for the Gateway API option.
+func DefaultGatewayAPIFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.GatewayAPI },
+		FlagName:     "gateway-api",
+		Description:  "Gateway API (None: skip, CRDs: CRDs only, Cilium: Cilium CNI controller, Envoy: Envoy Gateway)",
+		DefaultValue: v1alpha1.GatewayAPINone,
+	}
+}
+
+// DefaultServiceMeshFieldSelector creates a standard field selector for the service mesh.
+func DefaultServiceMeshFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.ServiceMesh },
+		FlagName:     "service-mesh",
+		Description:  "Service mesh (None: skip, Istio, Linkerd)",
+		DefaultValue: v1alpha1.ServiceMeshNone,
+	}
+}
+
+// DefaultIstioModeFieldSelector creates a standard field selector for the Istio data plane mode.
+func DefaultIstioModeFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.Istio.Mode },
+		FlagName:     "istio-mode",
+		Description:  "Istio data plane mode when --service-mesh=Istio (Sidecar, Ambient)",
+		DefaultValue: v1alpha1.IstioModeSidecar,
+	}
+}
+
+// DefaultKnativeFieldSelector creates a standard field selector for Knative Serving.
+func DefaultKnativeFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.Knative },
+		FlagName:     "knative",
+		Description:  "Knative Serving with Kourier (Enabled: install, Disabled: skip)",
+		DefaultValue: v1alpha1.KnativeDisabled,
+	}
+}
+
+// DefaultIdentityProviderFieldSelector creates a standard field selector for the development identity provider.
+func DefaultIdentityProviderFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.IdentityProvider },
+		FlagName:     "identity-provider",
+		Description:  "Development identity provider (None: skip, Keycloak: OIDC realm with test users)",
+		DefaultValue: v1alpha1.IdentityProviderNone,
+	}
+}
+
+// DefaultArgoCDLayoutFieldSelector creates a standard field selector for the Argo CD layout.
+func DefaultArgoCDLayoutFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Workload.ArgoCD.Layout },
+		FlagName:     "argocd-layout",
+		Description:  "Argo CD layout (Application: single Application, ApplicationSet: app-of-apps)",
+		DefaultValue: v1alpha1.ArgoCDLayoutApplication,
+	}
+}
+
+// DefaultArgoCDGeneratorFieldSelector creates a standard field selector for the Argo CD ApplicationSet generator.
+func DefaultArgoCDGeneratorFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Workload.ArgoCD.ApplicationSet.Generator },
+		FlagName:     "argocd-generator",
+		Description:  "Argo CD ApplicationSet generator (Directory: one app per apps/ directory, Cluster: one per cluster)",
+		DefaultValue: v1alpha1.ArgoCDGeneratorDirectory,
+	}
+}
+
+// DefaultLoggingFieldSelector creates a standard field selector for the log aggregation stack.
+func DefaultLoggingFieldSelector() FieldSelector[v1alpha1.Cluster] {
+	return FieldSelector[v1alpha1.Cluster]{
+		Selector:     func(c *v1alpha1.Cluster) any { return &c.Spec.Cluster.Observability.Logging },
+		FlagName:     "logging",
+		Description:  "Log aggregation stack (None: skip, Loki: Loki with Promtail)",
+		DefaultValue: v1alpha1.LoggingNone,
+	}
+}
+
 // DefaultCSIFieldSelector creates a standard field selector for CSI.
 func DefaultCSIFieldSelector() FieldSelector[v1alpha1.Cluster] {
 	return FieldSelector[v1alpha1.Cluster]{
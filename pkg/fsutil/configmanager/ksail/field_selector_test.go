@@ -123,6 +123,47 @@ func TestStandardFieldSelectors(t *testing.T) {
 				assertPointerSame(t, ptr, &cluster.Spec.Cluster.CertManager)
 			},
 		},
+		{
+			name:    "mirror registry TLS",
+			factory: configmanager.DefaultMirrorRegistryTLSFieldSelector,
+			expectedDesc: "Mirror registry TLS " +
+				"(Enabled: serve HTTPS with a KSail-generated CA, Disabled: plain HTTP)",
+			expectedDefault: v1alpha1.MirrorRegistryTLSDisabled,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.MirrorRegistryTLS)
+			},
+		},
+		{
+			name:            "sealed-secrets",
+			factory:         configmanager.DefaultSealedSecretsFieldSelector,
+			expectedDesc:    "Sealed Secrets controller (Enabled: install, Disabled: skip)",
+			expectedDefault: v1alpha1.SealedSecretsDisabled,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.SealedSecrets)
+			},
+		},
+		{
+			name:            "external-secrets",
+			factory:         configmanager.DefaultExternalSecretsFieldSelector,
+			expectedDesc:    "External Secrets Operator (Enabled: install, Disabled: skip)",
+			expectedDefault: v1alpha1.ExternalSecretsDisabled,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.ExternalSecrets)
+			},
+		},
+		{
+			name:            "backup",
+			factory:         configmanager.DefaultBackupFieldSelector,
+			expectedDesc:    "Backup system (None: skip, Velero: install Velero with a local MinIO target)",
+			expectedDefault: v1alpha1.BackupNone,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.Backup)
+			},
+		},
 		{
 			name:            "csi",
 			factory:         configmanager.DefaultCSIFieldSelector,
@@ -176,6 +217,98 @@ func TestStandardFieldSelectors(t *testing.T) {
 				assertPointerSame(t, ptr, &cluster.Spec.Cluster.PolicyEngine)
 			},
 		},
+		{
+			name:            "ingress controller",
+			factory:         configmanager.DefaultIngressControllerFieldSelector,
+			expectedDesc:    "Ingress controller (None: skip, Nginx: ingress-nginx, Traefik, Contour)",
+			expectedDefault: v1alpha1.IngressControllerNone,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.IngressController)
+			},
+		},
+		{
+			name:    "gateway API",
+			factory: configmanager.DefaultGatewayAPIFieldSelector,
+			expectedDesc: "Gateway API (None: skip, CRDs: CRDs only, " +
+				"Cilium: Cilium CNI controller, Envoy: Envoy Gateway)",
+			expectedDefault: v1alpha1.GatewayAPINone,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.GatewayAPI)
+			},
+		},
+		{
+			name:            "service mesh",
+			factory:         configmanager.DefaultServiceMeshFieldSelector,
+			expectedDesc:    "Service mesh (None: skip, Istio, Linkerd)",
+			expectedDefault: v1alpha1.ServiceMeshNone,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.ServiceMesh)
+			},
+		},
+		{
+			name:            "istio mode",
+			factory:         configmanager.DefaultIstioModeFieldSelector,
+			expectedDesc:    "Istio data plane mode when --service-mesh=Istio (Sidecar, Ambient)",
+			expectedDefault: v1alpha1.IstioModeSidecar,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.Istio.Mode)
+			},
+		},
+		{
+			name:            "knative",
+			factory:         configmanager.DefaultKnativeFieldSelector,
+			expectedDesc:    "Knative Serving with Kourier (Enabled: install, Disabled: skip)",
+			expectedDefault: v1alpha1.KnativeDisabled,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.Knative)
+			},
+		},
+		{
+			name:            "identity provider",
+			factory:         configmanager.DefaultIdentityProviderFieldSelector,
+			expectedDesc:    "Development identity provider (None: skip, Keycloak: OIDC realm with test users)",
+			expectedDefault: v1alpha1.IdentityProviderNone,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.IdentityProvider)
+			},
+		},
+		{
+			name:            "argocd layout",
+			factory:         configmanager.DefaultArgoCDLayoutFieldSelector,
+			expectedDesc:    "Argo CD layout (Application: single Application, ApplicationSet: app-of-apps)",
+			expectedDefault: v1alpha1.ArgoCDLayoutApplication,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Workload.ArgoCD.Layout)
+			},
+		},
+		{
+			name:    "argocd generator",
+			factory: configmanager.DefaultArgoCDGeneratorFieldSelector,
+			expectedDesc: "Argo CD ApplicationSet generator " +
+				"(Directory: one app per apps/ directory, Cluster: one per cluster)",
+			expectedDefault: v1alpha1.ArgoCDGeneratorDirectory,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Workload.ArgoCD.ApplicationSet.Generator)
+			},
+		},
+		{
+			name:            "logging",
+			factory:         configmanager.DefaultLoggingFieldSelector,
+			expectedDesc:    "Log aggregation stack (None: skip, Loki: Loki with Promtail)",
+			expectedDefault: v1alpha1.LoggingNone,
+			assertPointer: func(t *testing.T, cluster *v1alpha1.Cluster, ptr any) {
+				t.Helper()
+				assertPointerSame(t, ptr, &cluster.Spec.Cluster.Observability.Logging)
+			},
+		},
 		{
 			name:            "kubeconfig",
 			factory:         configmanager.DefaultKubeconfigFieldSelector,
@@ -1,6 +1,7 @@
 package configmanager
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
 	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
@@ -39,6 +41,11 @@ type ConfigManager struct {
 	command            *cobra.Command
 	// ConfigFile is the explicit config file path (from --config flag).
 	ConfigFile string
+	// Profile is the explicit profile to overlay (see applyProfile). When empty, the
+	// --profile flag and the KSAIL_PROFILE environment variable are consulted.
+	Profile string
+	// activeProfile is the profile applied by the last readConfig.
+	activeProfile string
 	// localRegistryExplicit tracks if config explicitly set the local registry behavior
 	localRegistryExplicit bool
 	// initErr stores any error from Viper initialization (e.g. invalid --config path).
@@ -104,6 +111,7 @@ func NewCommandConfigManager(
 // Configuration priority: defaults < config files < environment variables < flags.
 func (m *ConfigManager) Load(opts configmanagerinterface.LoadOptions) (*v1alpha1.Cluster, error) {
 	return m.loadConfigWithOptions(
+		m.loadContext(opts.Context),
 		opts.Timer,
 		opts.Silent,
 		opts.IgnoreConfigFile,
@@ -112,6 +120,33 @@ func (m *ConfigManager) Load(opts configmanagerinterface.LoadOptions) (*v1alpha1
 	)
 }
 
+// loadContext returns ctx, or the context of the bound command when ctx is nil,
+// falling back to context.Background.
+func (m *ConfigManager) loadContext(ctx context.Context) context.Context {
+	if ctx != nil {
+		return ctx
+	}
+
+	if m.command != nil && m.command.Context() != nil {
+		return m.command.Context()
+	}
+
+	return context.Background()
+}
+
+// Reset discards the cached configuration so the next Load reads the config
+// file, environment, and flags again into a fresh Cluster. Commands that build
+// several clusters from one configuration use it to start each from an
+// unmutated copy.
+func (m *ConfigManager) Reset() {
+	m.Config = v1alpha1.NewCluster()
+	m.DistributionConfig = nil
+	m.configLoaded = false
+	m.configFileFound = false
+	m.localRegistryExplicit = false
+	m.activeProfile = ""
+}
+
 // IsConfigFileFound returns true if a configuration file was found during LoadConfig.
 // This should only be called after LoadConfig has been called.
 func (m *ConfigManager) IsConfigFileFound() bool {
@@ -144,6 +179,7 @@ func (m *ConfigManager) validateOrLoadDistributionConfig(silent, skipValidation
 
 // loadConfigWithOptions is the internal implementation with silent and skip validation options.
 func (m *ConfigManager) loadConfigWithOptions(
+	ctx context.Context,
 	tmr timer.Timer,
 	silent bool,
 	ignoreConfigFile bool,
@@ -162,7 +198,7 @@ func (m *ConfigManager) loadConfigWithOptions(
 
 	if !ignoreConfigFile {
 		// Use native Viper API to read configuration
-		err := m.readConfig(silent)
+		err := m.readConfig(ctx, silent)
 		if err != nil {
 			return nil, err
 		}
@@ -289,7 +325,20 @@ func (m *ConfigManager) resolveConfigFile() error {
 	return nil
 }
 
-func (m *ConfigManager) readConfig(silent bool) error {
+// UseConfigFile points the manager at the config file at path, as if it had been
+// passed with --config. It takes precedence over the --config flag.
+func (m *ConfigManager) UseConfigFile(path string) error {
+	err := configureViperConfigSource(m.Viper, path)
+	if err != nil {
+		return fmt.Errorf("invalid config path: %w", err)
+	}
+
+	m.ConfigFile = path
+
+	return nil
+}
+
+func (m *ConfigManager) readConfig(ctx context.Context, silent bool) error {
 	err := m.resolveConfigFile()
 	if err != nil {
 		return err
@@ -307,7 +356,7 @@ func (m *ConfigManager) readConfig(silent bool) error {
 			m.notifyUsingDefaults()
 		}
 
-		return nil
+		return m.applyProfile(silent)
 	}
 
 	m.configFileFound = true
@@ -315,6 +364,33 @@ func (m *ConfigManager) readConfig(silent bool) error {
 		m.notifyConfigFound()
 	}
 
+	err = m.convertOlderAPIVersion()
+	if err != nil {
+		return err
+	}
+
+	err = m.applyExtends(ctx, silent)
+	if err != nil {
+		return err
+	}
+
+	return m.applyProfile(silent)
+}
+
+// expandEnvVars expands the configuration's environment variable placeholders,
+// failing on unset variables without a default when KSAIL_STRICT_ENV is set.
+func (m *ConfigManager) expandEnvVars() error {
+	if !envvar.StrictEnabled() {
+		m.Config.ExpandEnvVars()
+
+		return nil
+	}
+
+	err := m.Config.ExpandEnvVarsStrict()
+	if err != nil {
+		return fmt.Errorf("failed to expand environment variables in ksail.yaml: %w", err)
+	}
+
 	return nil
 }
 
@@ -339,7 +415,10 @@ func (m *ConfigManager) unmarshalAndApplyDefaults(ignoreConfigFile bool) error {
 	// Expand environment variables in all string fields.
 	// This happens immediately after unmarshaling, before applying defaults
 	// or making paths absolute, so that env vars can be used anywhere.
-	m.Config.ExpandEnvVars()
+	err = m.expandEnvVars()
+	if err != nil {
+		return err
+	}
 
 	// Do NOT restore defaults for TypeMeta fields - they should be validated as-is.
 	// This ensures validation will catch incorrect/missing apiVersion and kind values.
@@ -409,6 +488,21 @@ func (m *ConfigManager) resolvePathDefaults(ignoreConfigFile bool) error {
 		return fmt.Errorf("failed to resolve scan exceptions path: %w", err)
 	}
 
+	err = m.makeVarsFileAbsolute()
+	if err != nil {
+		return fmt.Errorf("failed to resolve substitution vars file path: %w", err)
+	}
+
+	err = m.makeCABundlesAbsolute()
+	if err != nil {
+		return fmt.Errorf("failed to resolve trust CA bundle path: %w", err)
+	}
+
+	err = m.makeNotifyLogDirAbsolute()
+	if err != nil {
+		return fmt.Errorf("failed to resolve notify log directory path: %w", err)
+	}
+
 	return nil
 }
 
@@ -495,6 +589,53 @@ func (m *ConfigManager) makeScanExceptionsAbsolute() error {
 	return nil
 }
 
+// makeVarsFileAbsolute converts spec.workload.substitution.varsFile to an
+// absolute path relative to the config file's directory, mirroring
+// makeScanExceptionsAbsolute.
+func (m *ConfigManager) makeVarsFileAbsolute() error {
+	absPath, err := m.makePathAbsolute(m.Config.Spec.Workload.Substitution.VarsFile)
+	if err != nil {
+		return err
+	}
+
+	m.Config.Spec.Workload.Substitution.VarsFile = absPath
+
+	return nil
+}
+
+// makeCABundlesAbsolute converts the file-path entries of spec.trust.caBundles to
+// absolute paths relative to the config file's directory, mirroring
+// makeVarsFileAbsolute. Inline PEM entries are left unchanged.
+func (m *ConfigManager) makeCABundlesAbsolute() error {
+	for index, entry := range m.Config.Spec.Trust.CABundles {
+		if v1alpha1.IsInlinePEM(entry) {
+			continue
+		}
+
+		absPath, err := m.makePathAbsolute(entry)
+		if err != nil {
+			return err
+		}
+
+		m.Config.Spec.Trust.CABundles[index] = absPath
+	}
+
+	return nil
+}
+
+// makeNotifyLogDirAbsolute converts spec.notify.logDir to an absolute path
+// relative to the config file's directory, mirroring makeVarsFileAbsolute.
+func (m *ConfigManager) makeNotifyLogDirAbsolute() error {
+	absPath, err := m.makePathAbsolute(m.Config.Spec.Notify.LogDir)
+	if err != nil {
+		return err
+	}
+
+	m.Config.Spec.Notify.LogDir = absPath
+
+	return nil
+}
+
 func (m *ConfigManager) captureChangedFlagValues() map[string]string {
 	if m.command == nil {
 		return nil
@@ -528,6 +528,24 @@ func TestLoadConfigConfigReusedNotification(t *testing.T) {
 	assert.Empty(t, output.String(), "expected no output when reusing cached config")
 }
 
+//nolint:paralleltest // Uses t.Chdir for isolated filesystem state.
+func TestLoadConfigAfterResetReturnsFreshConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	manager, _, _ := loadConfigAndCaptureOutput(t, createStandardFieldSelectors()...)
+
+	first := manager.Config
+	first.Spec.Cluster.Connection.Context = "mutated"
+
+	manager.Reset()
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{})
+	require.NoError(t, err)
+	assert.NotSame(t, first, cfg, "expected Reset to discard the cached config")
+	assert.NotEqual(t, "mutated", cfg.Spec.Cluster.Connection.Context)
+}
+
 //nolint:paralleltest // Uses t.Chdir for isolated filesystem state.
 func TestLoadConfigHonorsExplicitLocalRegistrySetting(t *testing.T) {
 	tempDir := t.TempDir()
@@ -1138,6 +1156,109 @@ spec:
 	assert.Equal(t, expectedExceptions, cluster.Spec.Workload.Scan.Exceptions)
 }
 
+//nolint:paralleltest // Cannot use t.Parallel() because test changes directories using t.Chdir()
+func TestManager_readConfigurationFile_VarsFileAbsolute(t *testing.T) {
+	// Cannot use t.Parallel() because test changes directories using t.Chdir()
+	configContent := `
+apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Vanilla
+  workload:
+    substitution:
+      varsFile: vars/dev.env
+`
+
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "ksail.yaml")
+
+	err := os.WriteFile(configFile, []byte(configContent), 0o600)
+	require.NoError(t, err)
+
+	t.Chdir(tempDir)
+
+	fieldSelectors := createFieldSelectorsWithName()
+	manager := configmanager.NewConfigManager(io.Discard, "", fieldSelectors...)
+
+	cluster, err := manager.Load(configmanagerinterface.LoadOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, cluster)
+
+	expectedVarsFile := filepath.Join(tempDir, "vars", "dev.env")
+	assert.Equal(t, expectedVarsFile, cluster.Spec.Workload.Substitution.VarsFile)
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() because test changes directories using t.Chdir()
+func TestManager_readConfigurationFile_CABundlesAbsolute(t *testing.T) {
+	// Cannot use t.Parallel() because test changes directories using t.Chdir()
+	configContent := `
+apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Vanilla
+  trust:
+    caBundles:
+      - certs/proxy.pem
+      - |
+        -----BEGIN CERTIFICATE-----
+        AAAA
+        -----END CERTIFICATE-----
+`
+
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "ksail.yaml")
+
+	err := os.WriteFile(configFile, []byte(configContent), 0o600)
+	require.NoError(t, err)
+
+	t.Chdir(tempDir)
+
+	fieldSelectors := createFieldSelectorsWithName()
+	manager := configmanager.NewConfigManager(io.Discard, "", fieldSelectors...)
+
+	cluster, err := manager.Load(configmanagerinterface.LoadOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, cluster)
+
+	assert.Equal(t, []string{
+		filepath.Join(tempDir, "certs", "proxy.pem"),
+		"-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n",
+	}, cluster.Spec.Trust.CABundles)
+}
+
+//nolint:paralleltest // Cannot use t.Parallel() because test changes directories using t.Chdir()
+func TestManager_readConfigurationFile_NotifyLogDirAbsolute(t *testing.T) {
+	// Cannot use t.Parallel() because test changes directories using t.Chdir()
+	configContent := `
+apiVersion: ksail.io/v1alpha1
+kind: Cluster
+spec:
+  cluster:
+    distribution: Vanilla
+  notify:
+    logDir: logs
+`
+
+	tempDir := t.TempDir()
+	configFile := filepath.Join(tempDir, "ksail.yaml")
+
+	err := os.WriteFile(configFile, []byte(configContent), 0o600)
+	require.NoError(t, err)
+
+	t.Chdir(tempDir)
+
+	fieldSelectors := createFieldSelectorsWithName()
+	manager := configmanager.NewConfigManager(io.Discard, "", fieldSelectors...)
+
+	cluster, err := manager.Load(configmanagerinterface.LoadOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, cluster)
+
+	assert.Equal(t, filepath.Join(tempDir, "logs"), cluster.Spec.Notify.LogDir)
+}
+
 //nolint:paralleltest // Cannot use t.Parallel() because test changes directories using t.Chdir()
 func TestLoadConfig_ValidationFailureOutputs(t *testing.T) {
 	// Cannot use t.Parallel() because test changes directories using t.Chdir()
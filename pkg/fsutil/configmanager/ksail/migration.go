@@ -1,12 +1,61 @@
 package configmanager
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/conversion"
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 )
 
+// convertOlderAPIVersion upgrades a config file written for an older KSail API
+// version to the current one in memory, so it loads without being edited, and
+// warns with the renamed and removed fields. The file itself is only rewritten
+// by `ksail config migrate`. Files with the current or an unknown apiVersion
+// are left to validation.
+func (m *ConfigManager) convertOlderAPIVersion() error {
+	apiVersion := m.Viper.GetString("apiVersion")
+	if !conversion.NeedsConversion(apiVersion) {
+		return nil
+	}
+
+	configFile := m.Viper.ConfigFileUsed()
+
+	data, err := os.ReadFile(configFile) //nolint:gosec // path resolved by Viper
+	if err != nil {
+		return fmt.Errorf("read %s for conversion: %w", configFile, err)
+	}
+
+	converted, report, err := conversion.Convert(data)
+	if err != nil {
+		return fmt.Errorf("convert %s from %s: %w", configFile, apiVersion, err)
+	}
+
+	err = m.Viper.ReadConfig(bytes.NewReader(converted))
+	if err != nil {
+		return fmt.Errorf("read converted %s: %w", configFile, err)
+	}
+
+	if m.Writer != nil {
+		changes := make([]string, 0, len(report.Changes))
+		for _, change := range report.Changes {
+			changes = append(changes, "\n  - "+change.String())
+		}
+
+		_, _ = fmt.Fprintf(
+			m.Writer,
+			"warning: %s uses apiVersion %s; KSail converted it to %s in memory. "+
+				"Run 'ksail config migrate' to update the file.%s\n",
+			configFile, report.From, report.To, strings.Join(changes, ""),
+		)
+	}
+
+	return nil
+}
+
 // migrateDeprecatedNodeCounts copies legacy spec.cluster.talos.{controlPlanes,workers}
 // into the cluster-level spec.cluster.{controlPlanes,workers} when the new fields are
 // unset. Emits a deprecation notice to the supplied writer when a legacy value is
@@ -0,0 +1,124 @@
+package configmanager
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/cli/flags"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/spf13/viper"
+)
+
+// ProfileEnvVar selects a profile when the --profile flag is not set.
+const ProfileEnvVar = EnvPrefix + "_PROFILE"
+
+// profilesKey is the top-level ksail.yaml key holding the named spec overlays.
+const profilesKey = "profiles"
+
+var (
+	// ErrUnknownProfile is returned when the selected profile is not defined in ksail.yaml.
+	ErrUnknownProfile = errors.New("unknown profile")
+	// ErrProfileWithoutConfig is returned when a profile is selected but no ksail.yaml was found.
+	ErrProfileWithoutConfig = errors.New("profile selected but no ksail.yaml found")
+	// ErrInvalidProfile is returned when a profile is not a mapping of spec fields.
+	ErrInvalidProfile = errors.New("invalid profile")
+)
+
+// ProfileNames returns the profiles defined in the config file read by v, sorted.
+// Viper matches keys case-insensitively, so the names are lowercase.
+func ProfileNames(v *viper.Viper) []string {
+	profiles, _ := v.Get(profilesKey).(map[string]any)
+
+	return slices.Sorted(maps.Keys(profiles))
+}
+
+// resolveProfile returns the selected profile: the explicit Profile field, then the
+// --profile flag of the bound command, then the KSAIL_PROFILE environment variable.
+func (m *ConfigManager) resolveProfile() (string, error) {
+	if m.Profile != "" {
+		return m.Profile, nil
+	}
+
+	if m.command != nil {
+		profile, err := flags.GetProfile(m.command)
+		if err != nil {
+			return "", fmt.Errorf("resolving --profile flag: %w", err)
+		}
+
+		if profile != "" {
+			return profile, nil
+		}
+	}
+
+	return strings.TrimSpace(os.Getenv(ProfileEnvVar)), nil
+}
+
+// applyProfile overlays the selected profile onto the spec read from the config file.
+// The overlay merges into the config-file layer, so environment variables and flags
+// still win over it: defaults < config file < profile < environment < flags. Mappings
+// merge key by key; lists and scalars in the profile replace the base value.
+func (m *ConfigManager) applyProfile(silent bool) error {
+	profile, err := m.resolveProfile()
+	if err != nil || profile == "" {
+		return err
+	}
+
+	if !m.configFileFound {
+		// Silent loads probe for a config file; finding none is not an error there.
+		if silent {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %q (profiles are defined in the %s section of ksail.yaml)",
+			ErrProfileWithoutConfig, profile, profilesKey)
+	}
+
+	profiles, _ := m.Viper.Get(profilesKey).(map[string]any)
+
+	overlay, found := profiles[strings.ToLower(profile)]
+	if !found {
+		available := ProfileNames(m.Viper)
+		if len(available) == 0 {
+			return fmt.Errorf("%w %q: %s defines no profiles",
+				ErrUnknownProfile, profile, m.Viper.ConfigFileUsed())
+		}
+
+		return fmt.Errorf("%w %q (available: %s)",
+			ErrUnknownProfile, profile, strings.Join(available, ", "))
+	}
+
+	// An empty profile (e.g. "full: {}") selects the base spec unchanged.
+	if overlay != nil {
+		spec, isMap := overlay.(map[string]any)
+		if !isMap {
+			return fmt.Errorf("%w %q: must be a mapping of spec fields", ErrInvalidProfile, profile)
+		}
+
+		err = m.Viper.MergeConfigMap(map[string]any{"spec": spec})
+		if err != nil {
+			return fmt.Errorf("apply profile %q: %w", profile, err)
+		}
+	}
+
+	m.activeProfile = profile
+
+	if !silent {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.ActivityType,
+			Content: "using profile '%s'",
+			Args:    []any{profile},
+			Writer:  m.Writer,
+		})
+	}
+
+	return nil
+}
+
+// ActiveProfile returns the profile overlaid onto the loaded config, or "" when none.
+func (m *ConfigManager) ActiveProfile() string {
+	return m.activeProfile
+}
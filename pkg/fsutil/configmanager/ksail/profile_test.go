@@ -0,0 +1,130 @@
+package configmanager_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProfiledConfig writes a ksail.yaml with metrics-server and two workers in the base
+// spec, a ci profile that drops both, and an empty full profile.
+func writeProfiledConfig(t *testing.T) {
+	t.Helper()
+
+	writeKindConfigFile(t)
+	writeClusterConfigFile(
+		t,
+		"    metricsServer: Enabled\n",
+		"    workers: 2\n",
+		"profiles:\n",
+		"  ci:\n",
+		"    cluster:\n",
+		"      metricsServer: Disabled\n",
+		"      workers: 0\n",
+		"  full: {}\n",
+	)
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_WithoutProfileUsesBaseSpec(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(configmanager.ProfileEnvVar, "")
+	writeProfiledConfig(t)
+
+	manager := newManagerWithDefaultSelectors()
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.MetricsServerEnabled, cfg.Spec.Cluster.MetricsServer)
+	assert.Equal(t, int32(2), cfg.Spec.Cluster.Workers)
+	assert.Empty(t, manager.ActiveProfile())
+	assert.Equal(t, []string{"ci", "full"}, configmanager.ProfileNames(manager.Viper))
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ProfileOverlaysSpec(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(configmanager.ProfileEnvVar, "")
+	writeProfiledConfig(t)
+
+	var out bytes.Buffer
+
+	manager := newManagerWithDefaultSelectors()
+	manager.Writer = &out
+	manager.Profile = "ci"
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.MetricsServerDisabled, cfg.Spec.Cluster.MetricsServer)
+	assert.Zero(t, cfg.Spec.Cluster.Workers)
+	assert.Equal(t, v1alpha1.DistributionVanilla, cfg.Spec.Cluster.Distribution,
+		"fields the profile does not set keep their base value")
+	assert.Equal(t, "ci", manager.ActiveProfile())
+	assert.Contains(t, out.String(), "using profile 'ci'")
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ProfileFromEnvironment(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(configmanager.ProfileEnvVar, "full")
+	writeProfiledConfig(t)
+
+	manager := newManagerWithDefaultSelectors()
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.MetricsServerEnabled, cfg.Spec.Cluster.MetricsServer)
+	assert.Equal(t, "full", manager.ActiveProfile())
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_EnvironmentOverridesProfile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(configmanager.ProfileEnvVar, "ci")
+	t.Setenv("KSAIL_SPEC_CLUSTER_WORKERS", "3")
+	writeProfiledConfig(t)
+
+	manager := newManagerWithDefaultSelectors()
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), cfg.Spec.Cluster.Workers)
+	assert.Equal(t, v1alpha1.MetricsServerDisabled, cfg.Spec.Cluster.MetricsServer)
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_UnknownProfile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(configmanager.ProfileEnvVar, "")
+	writeProfiledConfig(t)
+
+	manager := newManagerWithDefaultSelectors()
+	manager.Profile = "staging"
+
+	_, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+
+	require.ErrorIs(t, err, configmanager.ErrUnknownProfile)
+	assert.Contains(t, err.Error(), "available: ci, full")
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfig_ProfileWithoutConfigFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(configmanager.ProfileEnvVar, "ci")
+
+	manager := configmanager.NewConfigManager(io.Discard, "")
+
+	_, err := manager.Load(configmanagerinterface.LoadOptions{})
+
+	require.ErrorIs(t, err, configmanager.ErrProfileWithoutConfig)
+}
@@ -0,0 +1,207 @@
+package configmanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrUnknownField is returned when a key names no field a field selector exposes.
+var ErrUnknownField = errors.New("unknown config field")
+
+// Source is where the effective value of a configuration field came from.
+type Source string
+
+const (
+	// SourceDefault means neither ksail.yaml, the environment, nor a flag set the field.
+	SourceDefault Source = "default"
+	// SourceFile means ksail.yaml set the field, directly or through extends or a profile.
+	SourceFile Source = "file"
+	// SourceEnv means an environment variable overrode the field.
+	SourceEnv Source = "env"
+	// SourceFlag means a CLI flag overrode the field.
+	SourceFlag Source = "flag"
+)
+
+// FieldProvenance is the effective value of one field-selector field and where it came from.
+type FieldProvenance struct {
+	// Path is the dot-separated ksail.yaml path, e.g. "spec.cluster.distribution".
+	Path     string
+	FlagName string
+	// Value is the effective value as the bool, int32, or string ksail.yaml holds.
+	Value  any
+	Source Source
+	// Origin names the flag, environment variable, or file the value came from;
+	// empty for SourceDefault.
+	Origin string
+}
+
+// Provenance reports, for every field selector of the manager, the effective value
+// after Load and the layer that set it, following the load precedence
+// flags > environment > ksail.yaml > defaults.
+func (m *ConfigManager) Provenance() []FieldProvenance {
+	provenance := make([]FieldProvenance, 0, len(m.fieldSelectors))
+
+	for _, selector := range m.fieldSelectors {
+		path, ok := FieldPath(selector)
+		if !ok {
+			continue
+		}
+
+		value := fieldValue(selector.Selector(m.Config))
+		source, origin := m.fieldSource(path, selector.FlagName, value)
+
+		provenance = append(provenance, FieldProvenance{
+			Path:     path,
+			FlagName: selector.FlagName,
+			Value:    value,
+			Source:   source,
+			Origin:   origin,
+		})
+	}
+
+	return provenance
+}
+
+// fieldSource returns the layer that set the field at path to value. An
+// environment variable only counts when it holds value: Viper ignores variables
+// for keys that neither ksail.yaml nor an explicit binding declares.
+func (m *ConfigManager) fieldSource(path, flagName string, value any) (Source, string) {
+	if m.command != nil && m.command.Flags().Changed(flagName) {
+		return SourceFlag, "--" + flagName
+	}
+
+	for _, envVar := range EnvVarNames(path) {
+		envValue, ok := os.LookupEnv(envVar)
+		if ok && strings.EqualFold(envValue, fmt.Sprint(value)) {
+			return SourceEnv, envVar
+		}
+	}
+
+	if m.configFileFound && m.Viper.InConfig(strings.ToLower(path)) {
+		return SourceFile, m.Viper.ConfigFileUsed()
+	}
+
+	return SourceDefault, ""
+}
+
+// FieldPath returns the dot-separated ksail.yaml path of the field selector picks,
+// built from the json tags of the v1alpha1 types, and whether it was found.
+func FieldPath(selector FieldSelector[v1alpha1.Cluster]) (string, bool) {
+	cluster := v1alpha1.NewCluster()
+
+	target := reflect.ValueOf(selector.Selector(cluster))
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return "", false
+	}
+
+	segments, ok := findFieldPath(reflect.ValueOf(cluster).Elem(), target)
+	if !ok {
+		return "", false
+	}
+
+	return strings.Join(segments, "."), true
+}
+
+// findFieldPath searches the struct value for the field target points at and returns
+// the json names leading to it. Inlined structs contribute no segment.
+func findFieldPath(value reflect.Value, target reflect.Value) ([]string, bool) {
+	for index := range value.NumField() {
+		field := value.Type().Field(index)
+		if !field.IsExported() {
+			continue
+		}
+
+		child := value.Field(index)
+		name := jsonName(field)
+
+		if child.Addr().Pointer() == target.Pointer() && child.Type() == target.Type().Elem() {
+			return []string{name}, name != ""
+		}
+
+		if child.Kind() != reflect.Struct {
+			continue
+		}
+
+		segments, ok := findFieldPath(child, target)
+		if !ok {
+			continue
+		}
+
+		if name == "" {
+			return segments, true
+		}
+
+		return append([]string{name}, segments...), true
+	}
+
+	return nil, false
+}
+
+// jsonName returns the json field name of field, or "" when it is inlined or skipped.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// LookupFieldSelector returns the selector among selectors whose flag name or
+// ksail.yaml path (case-insensitive) is key, together with that path.
+func LookupFieldSelector(
+	selectors []FieldSelector[v1alpha1.Cluster],
+	key string,
+) (FieldSelector[v1alpha1.Cluster], string, error) {
+	for _, selector := range selectors {
+		path, ok := FieldPath(selector)
+		if !ok {
+			continue
+		}
+
+		if key == selector.FlagName || strings.EqualFold(key, path) {
+			return selector, path, nil
+		}
+	}
+
+	return FieldSelector[v1alpha1.Cluster]{}, "", fmt.Errorf("%w: %q", ErrUnknownField, key)
+}
+
+// ParseFieldValue parses raw as a value of the field selector picks, the way its
+// CLI flag does, so enum values are validated. It returns the value as the bool,
+// int32, or string it is written to ksail.yaml as.
+func ParseFieldValue(selector FieldSelector[v1alpha1.Cluster], raw string) (any, error) {
+	fieldPtr := selector.Selector(v1alpha1.NewCluster())
+
+	err := setFieldValueFromFlag(fieldPtr, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for %s: %w", raw, selector.FlagName, err)
+	}
+
+	return fieldValue(fieldPtr), nil
+}
+
+// fieldValue returns the field fieldPtr points at as the bool, int32, or string
+// ksail.yaml holds; enums and durations are formatted as their CLI flag would.
+func fieldValue(fieldPtr any) any {
+	switch ptr := fieldPtr.(type) {
+	case *bool:
+		return *ptr
+	case *int32:
+		return *ptr
+	case fmt.Stringer:
+		return ptr.String()
+	case *string:
+		return *ptr
+	case *metav1.Duration:
+		return ptr.Duration.String()
+	default:
+		return fmt.Sprint(reflect.ValueOf(fieldPtr).Elem().Interface())
+	}
+}
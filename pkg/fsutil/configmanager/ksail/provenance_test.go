@@ -0,0 +1,75 @@
+package configmanager_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+
+	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/ksail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		selector configmanager.FieldSelector[v1alpha1.Cluster]
+		want     string
+	}{
+		{configmanager.DefaultDistributionFieldSelector(), "spec.cluster.distribution"},
+		{configmanager.DefaultContextFieldSelector(), "spec.cluster.connection.context"},
+		{configmanager.DefaultLocalRegistryFieldSelector(), "spec.cluster.localRegistry.registry"},
+		{configmanager.WorkersFieldSelector(), "spec.cluster.workers"},
+		{configmanager.StandardSourceDirectoryFieldSelector(), "spec.workload.sourceDirectory"},
+		{configmanager.OIDCIssuerURLFieldSelector(), "spec.cluster.oidc.issuerURL"},
+	}
+
+	for _, test := range tests {
+		path, ok := configmanager.FieldPath(test.selector)
+		require.True(t, ok, test.want)
+		assert.Equal(t, test.want, path)
+	}
+}
+
+func TestEnvVarNames(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"KSAIL_SPEC_CLUSTER_WORKERS"},
+		configmanager.EnvVarNames("spec.cluster.workers"))
+	assert.Equal(t,
+		[]string{"KSAIL_SPEC_CLUSTER_DISTRIBUTION", "KSAIL_SPEC_DISTRIBUTION"},
+		configmanager.EnvVarNames("spec.cluster.distribution"))
+}
+
+func TestLookupFieldSelector(t *testing.T) {
+	t.Parallel()
+
+	selectors := configmanager.DefaultClusterFieldSelectors()
+
+	for _, key := range []string{"gitops-engine", "spec.cluster.gitOpsEngine", "SPEC.CLUSTER.GITOPSENGINE"} {
+		selector, path, err := configmanager.LookupFieldSelector(selectors, key)
+		require.NoError(t, err, key)
+		assert.Equal(t, "gitops-engine", selector.FlagName)
+		assert.Equal(t, "spec.cluster.gitOpsEngine", path)
+	}
+
+	_, _, err := configmanager.LookupFieldSelector(selectors, "workers")
+	require.ErrorIs(t, err, configmanager.ErrUnknownField, "only the given selectors are searched")
+}
+
+func TestParseFieldValue(t *testing.T) {
+	t.Parallel()
+
+	value, err := configmanager.ParseFieldValue(configmanager.WorkersFieldSelector(), "3")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), value)
+
+	value, err = configmanager.ParseFieldValue(configmanager.DefaultGitOpsEngineFieldSelector(), "flux")
+	require.NoError(t, err)
+	assert.Equal(t, "Flux", value, "enum values are canonicalized")
+
+	_, err = configmanager.ParseFieldValue(configmanager.DefaultDistributionFieldSelector(), "Minikube")
+	require.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package configmanager
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	k3dconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/k3d"
+	kindconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/kind"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenancy"
+)
+
+// applyTenancy scopes the loaded cluster to the tenant when tenancy applies to it (see
+// tenancy.Applies). metadata.name, the Kind/K3d config name, and a kubeconfig context following the
+// distribution's naming convention are prefixed with the tenant, so every container, registry, and
+// context name derived from them is too. Kind clusters are also moved to the tenant's Docker
+// network through Kind's own network environment variable, unless the user already set it.
+func (m *ConfigManager) applyTenancy() error {
+	clusterSpec := &m.Config.Spec.Cluster
+	if !tenancy.Applies(*clusterSpec) || m.DistributionConfig == nil {
+		return nil
+	}
+
+	prefix, err := tenancy.Prefix(clusterSpec.Tenancy)
+	if err != nil {
+		return fmt.Errorf("resolve tenant: %w", err)
+	}
+
+	var name string
+
+	switch clusterSpec.Distribution {
+	case v1alpha1.DistributionVanilla:
+		if m.DistributionConfig.Kind == nil {
+			return nil
+		}
+
+		name = kindconfigmanager.ResolveClusterName(m.Config, m.DistributionConfig.Kind)
+		m.DistributionConfig.Kind.Name = tenancy.ScopeName(prefix, name)
+
+		if os.Getenv(kindconfigmanager.NetworkEnvVar) == "" {
+			_ = os.Setenv(kindconfigmanager.NetworkEnvVar, kindconfigmanager.TenantNetworkName(prefix))
+		}
+	case v1alpha1.DistributionK3s:
+		if m.DistributionConfig.K3d == nil {
+			return nil
+		}
+
+		name = k3dconfigmanager.ResolveClusterName(m.Config, m.DistributionConfig.K3d)
+		m.DistributionConfig.K3d.Name = tenancy.ScopeName(prefix, name)
+	default:
+		return nil
+	}
+
+	clusterSpec.Connection.Context = scopeContext(
+		clusterSpec.Distribution, clusterSpec.Connection.Context, prefix, name, m.Config.Name,
+	)
+	m.Config.Name = tenancy.ScopeName(prefix, m.Config.Name)
+
+	return nil
+}
+
+// scopeContext returns the kubeconfig context for the tenant. An empty context, or one following
+// the distribution convention for one of the unscoped names, is rewritten to the scoped name's
+// context; a hand-picked context is the user's to keep.
+func scopeContext(
+	distribution v1alpha1.Distribution,
+	context, prefix string,
+	names ...string,
+) string {
+	context = strings.TrimSpace(context)
+	if context == "" {
+		return distribution.ContextName(tenancy.ScopeName(prefix, names[0]))
+	}
+
+	for _, name := range names {
+		if name != "" && context == distribution.ContextName(name) {
+			return distribution.ContextName(tenancy.ScopeName(prefix, name))
+		}
+	}
+
+	return context
+}
@@ -0,0 +1,58 @@
+package configmanager_test
+
+import (
+	"os"
+	"testing"
+
+	configmanagerinterface "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
+	kindconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/kind"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfigScopesKindClusterToTenant(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(kindconfigmanager.NetworkEnvVar, "")
+
+	require.NoError(t, os.WriteFile("kind.yaml", []byte(kindClusterConfigYAML+"name: dev\n"), 0o600))
+	writeClusterConfigFile(
+		t,
+		"    connection:\n",
+		"      context: kind-dev\n",
+		"    tenancy:\n",
+		"      enabled: true\n",
+		"      prefix: alice\n",
+	)
+
+	manager := newManagerWithDefaultSelectors()
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice-dev", manager.DistributionConfig.Kind.Name)
+	assert.Equal(t, "kind-alice-dev", cfg.Spec.Cluster.Connection.Context)
+	assert.Equal(t, "kind-alice", os.Getenv(kindconfigmanager.NetworkEnvVar))
+}
+
+//nolint:paralleltest // Uses t.Chdir and t.Setenv.
+func TestLoadConfigEnablesTenancyFromEnvironment(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(kindconfigmanager.NetworkEnvVar, "shared")
+	t.Setenv("KSAIL_SPEC_CLUSTER_TENANCY_ENABLED", "true")
+	t.Setenv("KSAIL_SPEC_CLUSTER_TENANCY_PREFIX", "bob")
+
+	writeKindConfigFile(t)
+	writeClusterConfigFile(t, "    connection:\n", "      context: my-context\n")
+
+	manager := newManagerWithDefaultSelectors()
+
+	cfg, err := manager.Load(configmanagerinterface.LoadOptions{Silent: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "bob-kind", manager.DistributionConfig.Kind.Name)
+	assert.Equal(t, "my-context", cfg.Spec.Cluster.Connection.Context,
+		"a hand-picked context is kept")
+	assert.Equal(t, "shared", os.Getenv(kindconfigmanager.NetworkEnvVar),
+		"an explicitly chosen Kind network is kept")
+}
@@ -98,32 +98,63 @@ func configureViperPaths(viperInstance *viper.Viper) {
 	viperInstance.AddConfigPath("/etc/ksail")
 }
 
+// explicitEnvBindings maps nested config keys to environment variable names
+// that differ from the ones AutomaticEnv derives (KSAIL_ plus the upper-cased
+// key with dots replaced by underscores).
+//
+//nolint:gochecknoglobals // immutable lookup table
+var explicitEnvBindings = []struct {
+	key    string
+	envVar string
+}{
+	{"metadata.name", "KSAIL_METADATA_NAME"},
+	{"spec.cluster.distribution", "KSAIL_SPEC_DISTRIBUTION"},
+	{"spec.workload.sourcedirectory", "KSAIL_SPEC_SOURCEDIRECTORY"},
+	{"spec.cluster.connection.context", "KSAIL_SPEC_CONNECTION_CONTEXT"},
+	{"spec.cluster.connection.kubeconfig", "KSAIL_SPEC_CONNECTION_KUBECONFIG"},
+	{"spec.cluster.connection.timeout", "KSAIL_SPEC_CONNECTION_TIMEOUT"},
+	{"spec.cluster.omni.machineclass", "KSAIL_SPEC_CLUSTER_OMNI_MACHINECLASS"},
+	{"spec.cluster.kubernetesversion", "KSAIL_SPEC_CLUSTER_KUBERNETES_VERSION"},
+	{"spec.cluster.talos.version", "KSAIL_SPEC_CLUSTER_TALOS_VERSION"},
+	// Tenancy is a property of the shared host rather than the project, so it is
+	// commonly switched on host-wide from the environment.
+	{"spec.cluster.tenancy.enabled", "KSAIL_SPEC_CLUSTER_TENANCY_ENABLED"},
+	{"spec.cluster.tenancy.prefix", "KSAIL_SPEC_CLUSTER_TENANCY_PREFIX"},
+}
+
+// envKeyReplacer maps config keys to environment variable suffixes.
+//
+//nolint:gochecknoglobals // stateless replacer shared by Viper and EnvVarNames
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
 // configureViperEnvironment sets up environment variable handling for Viper.
 // Uses AutomaticEnv() for automatic environment variable binding with explicit binding for nested fields.
 func configureViperEnvironment(viperInstance *viper.Viper) {
 	viperInstance.SetEnvPrefix(EnvPrefix)
-	viperInstance.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	viperInstance.SetEnvKeyReplacer(envKeyReplacer)
 	viperInstance.AutomaticEnv()
 
 	// Explicitly bind nested environment variables for better compatibility
-	_ = viperInstance.BindEnv("metadata.name", "KSAIL_METADATA_NAME")
-	_ = viperInstance.BindEnv("spec.cluster.distribution", "KSAIL_SPEC_DISTRIBUTION")
-	_ = viperInstance.BindEnv("spec.workload.sourcedirectory", "KSAIL_SPEC_SOURCEDIRECTORY")
-	_ = viperInstance.BindEnv("spec.cluster.connection.context", "KSAIL_SPEC_CONNECTION_CONTEXT")
-	_ = viperInstance.BindEnv(
-		"spec.cluster.connection.kubeconfig",
-		"KSAIL_SPEC_CONNECTION_KUBECONFIG",
-	)
-	_ = viperInstance.BindEnv("spec.cluster.connection.timeout", "KSAIL_SPEC_CONNECTION_TIMEOUT")
-	_ = viperInstance.BindEnv(
-		"spec.cluster.omni.machineclass",
-		"KSAIL_SPEC_CLUSTER_OMNI_MACHINECLASS",
-	)
-	_ = viperInstance.BindEnv(
-		"spec.cluster.kubernetesversion",
-		"KSAIL_SPEC_CLUSTER_KUBERNETES_VERSION",
-	)
-	_ = viperInstance.BindEnv("spec.cluster.talos.version", "KSAIL_SPEC_CLUSTER_TALOS_VERSION")
+	for _, binding := range explicitEnvBindings {
+		_ = viperInstance.BindEnv(binding.key, binding.envVar)
+	}
+}
+
+// EnvVarNames returns the environment variables that override the config key
+// (a dot-separated ksail.yaml path such as "spec.cluster.distribution"), in
+// the order Viper consults them: the AutomaticEnv name first, then any
+// explicit binding.
+func EnvVarNames(key string) []string {
+	key = strings.ToLower(key)
+	names := []string{EnvPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))}
+
+	for _, binding := range explicitEnvBindings {
+		if binding.key == key && binding.envVar != names[0] {
+			names = append(names, binding.envVar)
+		}
+	}
+
+	return names
 }
 
 // addParentDirectoriesToViperPaths adds parent directories containing ksail.yaml to Viper's search paths.
@@ -90,7 +90,12 @@ func LoadConfigFromFile[T any](
 	}
 
 	// Expand environment variables in file content before parsing
-	data = envvar.ExpandBytes(data)
+	data, err = envvar.ExpandConfig(data, nil)
+	if err != nil {
+		var zero T
+
+		return zero, fmt.Errorf("failed to expand environment variables in %s: %w", cleaned, err)
+	}
 
 	// Parse YAML into the default config (which will overwrite defaults with file values)
 	config := createDefault()
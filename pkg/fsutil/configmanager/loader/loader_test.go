@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/devantler-tech/ksail/v7/internal/testutil/rootcheck"
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/loader"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator"
 	"github.com/stretchr/testify/assert"
@@ -517,3 +518,22 @@ kind: TestCluster`
 	require.NoError(t, err)
 	assert.Equal(t, "default-cluster", config.Name)
 }
+
+//nolint:paralleltest // Uses t.Setenv
+func TestLoadConfigFromFile_StrictEnvRejectsUnsetVariable(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "test-config.yaml")
+
+	configContent := `name: ${UNDEFINED_CLUSTER_NAME}
+apiVersion: test/v1
+kind: TestCluster`
+	err := os.WriteFile(configPath, []byte(configContent), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv(envvar.StrictEnvVar, "true")
+
+	_, err = loader.LoadConfigFromFile(configPath, createDefaultConfig)
+
+	require.ErrorIs(t, err, envvar.ErrUnsetVariable)
+	assert.Contains(t, err.Error(), "UNDEFINED_CLUSTER_NAME")
+}
@@ -1,11 +1,16 @@
 package configmanager
 
 import (
+	"context"
+
 	"github.com/devantler-tech/ksail/v7/pkg/timer"
 )
 
 // LoadOptions configures how configuration is loaded.
 type LoadOptions struct {
+	// Context bounds network access while loading, such as fetching the base config named by
+	// extends. Nil uses the context of the command the manager is bound to, if any.
+	Context context.Context //nolint:containedctx // Options struct passed by value to Load.
 	// Timer enables timing output in notifications when provided.
 	Timer timer.Timer
 	// Silent suppresses all loading notifications when true.
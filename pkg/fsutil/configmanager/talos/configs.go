@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"net/url"
 	"slices"
 	"strings"
 	"time"
@@ -885,12 +886,16 @@ type MirrorRegistry struct {
 	// Environment variable placeholders should be resolved before passing.
 
 	Password string
+	// IdentityToken is the optional identity token used instead of a username and password.
+	IdentityToken string
+	// CA is the optional PEM CA certificate the nodes trust for the HTTPS endpoints.
+	CA []byte
 }
 
 // ApplyMirrorRegistries modifies the configs to add registry mirror configurations.
 // This directly patches the underlying v1alpha1.Config structs.
-// It adds both the mirror endpoints and the registry config with insecureSkipVerify: true
-// to allow HTTP connections to local registry containers.
+// It adds the mirror endpoints, the registry auth of mirrors with credentials, and
+// trusts the CA of mirrors served over HTTPS.
 func (c *Configs) ApplyMirrorRegistries(mirrors []MirrorRegistry) error {
 	if len(mirrors) == 0 || c.bundle == nil {
 		return nil
@@ -1022,9 +1027,13 @@ func applyMirrorsToConfig(cfg *v1alpha1.Config, mirrors []MirrorRegistry) error
 		addMirrorEndpoints(cfg, mirror)
 
 		// Add authentication if credentials are provided
-		if mirror.Username != "" || mirror.Password != "" {
+		if mirror.Username != "" || mirror.Password != "" || mirror.IdentityToken != "" {
 			addRegistryAuth(cfg, mirror)
 		}
+
+		if len(mirror.CA) > 0 {
+			addRegistryCA(cfg, mirror)
+		}
 		// NOTE: We intentionally do NOT call addInsecureRegistryConfigs for HTTP endpoints.
 		// containerd will reject TLS configuration for non-HTTPS registries with the error:
 		// "TLS config specified for non-HTTPS registry"
@@ -1072,8 +1081,30 @@ func addRegistryAuth(cfg *v1alpha1.Config, mirror MirrorRegistry) {
 
 	// Set the auth configuration
 	cfg.MachineConfig.MachineRegistries.RegistryConfig[mirror.Host].RegistryAuth = &v1alpha1.RegistryAuthConfig{
-		RegistryUsername: mirror.Username,
-		RegistryPassword: mirror.Password,
+		RegistryUsername:      mirror.Username,
+		RegistryPassword:      mirror.Password,
+		RegistryIdentityToken: mirror.IdentityToken,
+	}
+}
+
+// addRegistryCA trusts the mirror's CA for each of its HTTPS endpoints. Talos keys
+// TLS configuration by the endpoint's host and port, not by the mirrored host.
+//
+//nolint:staticcheck // MachineRegistries is deprecated but still functional in Talos v1.x
+func addRegistryCA(cfg *v1alpha1.Config, mirror MirrorRegistry) {
+	for _, endpoint := range mirror.Endpoints {
+		parsed, err := url.Parse(endpoint)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			continue
+		}
+
+		registryCfg := cfg.MachineConfig.MachineRegistries.RegistryConfig[parsed.Host]
+		if registryCfg == nil {
+			registryCfg = &v1alpha1.RegistryConfig{}
+			cfg.MachineConfig.MachineRegistries.RegistryConfig[parsed.Host] = registryCfg
+		}
+
+		registryCfg.RegistryTLS = &v1alpha1.RegistryTLSConfig{TLSCA: v1alpha1.Base64Bytes(mirror.CA)}
 	}
 }
 
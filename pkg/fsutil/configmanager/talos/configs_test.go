@@ -157,6 +157,31 @@ func TestConfigs_ApplyMirrorRegistries_WithMirrors(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestConfigs_ApplyMirrorRegistries_TrustsCAForHTTPSEndpoints(t *testing.T) {
+	t.Parallel()
+
+	manager := talos.NewConfigManager("", "mirror-tls", "1.32.0", "10.5.0.0/24")
+
+	configs, err := manager.Load(configmanager.LoadOptions{})
+	require.NoError(t, err)
+
+	caPEM := []byte("-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n")
+
+	err = configs.ApplyMirrorRegistries([]talos.MirrorRegistry{
+		{
+			Host:      "docker.io",
+			Endpoints: []string{"https://mirror-tls-docker.io:5000"},
+			CA:        caPEM,
+		},
+	})
+	require.NoError(t, err)
+
+	tlsConfigs := configs.ControlPlane().RegistryTLSConfigs()
+	require.Contains(t, tlsConfigs, "mirror-tls-docker.io:5000")
+	assert.Equal(t, caPEM, tlsConfigs["mirror-tls-docker.io:5000"].CA())
+	assert.NotContains(t, tlsConfigs, "docker.io")
+}
+
 func TestConfigs_ApplyMirrorRegistries_EmptyHost(t *testing.T) {
 	t.Parallel()
 
@@ -7,6 +7,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	talosconfig "github.com/siderolabs/talos/pkg/machinery/config"
 	yamlv3 "gopkg.in/yaml.v3"
 	"sigs.k8s.io/yaml"
@@ -86,6 +87,58 @@ func DisableDefaultCNIPatchYAML(multiDocument bool) string {
 	return legacyDisableDefaultCNIPatchYAML
 }
 
+// Default Talos pod and service subnets, and the IPv6 subnets KSail pairs with them
+// for IPv6 and dual-stack clusters.
+const (
+	DefaultPodSubnet         = "10.244.0.0/16"
+	DefaultServiceSubnet     = "10.96.0.0/12"
+	DefaultPodSubnetIPv6     = "fd00:10:244::/56"
+	DefaultServiceSubnetIPv6 = "fd00:10:96::/112"
+)
+
+// IPFamilyPatchYAML returns the version-appropriate Talos patch that sets the pod and
+// service subnets for the given IP family, or "" for IPv4 (the Talos default).
+// Dual-stack lists the IPv4 subnets first so IPv4 stays the primary family.
+func IPFamilyPatchYAML(family v1alpha1.IPFamily, multiDocument bool) string {
+	if !family.IPv6() {
+		return ""
+	}
+
+	podSubnets := []string{DefaultPodSubnetIPv6}
+	serviceSubnets := []string{DefaultServiceSubnetIPv6}
+
+	if family == v1alpha1.IPFamilyDual {
+		podSubnets = []string{DefaultPodSubnet, DefaultPodSubnetIPv6}
+		serviceSubnets = []string{DefaultServiceSubnet, DefaultServiceSubnetIPv6}
+	}
+
+	var builder strings.Builder
+
+	indent := "    "
+
+	if multiDocument {
+		builder.WriteString("apiVersion: " + multiDocumentAPIVersion + "\nkind: KubeNetworkConfig\n")
+
+		indent = ""
+	} else {
+		builder.WriteString("cluster:\n  network:\n")
+	}
+
+	builder.WriteString(indent + "podSubnets:\n")
+
+	for _, subnet := range podSubnets {
+		builder.WriteString(indent + "  - " + subnet + "\n")
+	}
+
+	builder.WriteString(indent + "serviceSubnets:\n")
+
+	for _, subnet := range serviceSubnets {
+		builder.WriteString(indent + "  - " + subnet + "\n")
+	}
+
+	return builder.String()
+}
+
 // StructuredOIDCPatchYAML maps the legacy kube-apiserver OIDC flags to the
 // structured authentication document required by Talos 1.14.
 func StructuredOIDCPatchYAML(config OIDCPatchConfig) []byte {
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	talos "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/talos"
 	talosconfig "github.com/siderolabs/talos/pkg/machinery/config"
 	"github.com/stretchr/testify/assert"
@@ -119,3 +120,27 @@ func TestMigrateKubernetesPatchesForContract_APIServerVariantUnaffectedByCNI(t *
 	assert.True(t,
 		strings.Contains(got, "KubeAPIServerConfig") || strings.Contains(got, "audit-log-maxage"))
 }
+
+func TestIPFamilyPatchYAML(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, talos.IPFamilyPatchYAML(v1alpha1.IPFamilyIPv4, false))
+
+	assert.Equal(t, `cluster:
+  network:
+    podSubnets:
+      - 10.244.0.0/16
+      - fd00:10:244::/56
+    serviceSubnets:
+      - 10.96.0.0/12
+      - fd00:10:96::/112
+`, talos.IPFamilyPatchYAML(v1alpha1.IPFamilyDual, false))
+
+	assert.Equal(t, `apiVersion: v1alpha1
+kind: KubeNetworkConfig
+podSubnets:
+  - fd00:10:244::/56
+serviceSubnets:
+  - fd00:10:96::/112
+`, talos.IPFamilyPatchYAML(v1alpha1.IPFamilyIPv6, true))
+}
@@ -259,9 +259,12 @@ func forEachYAMLFile(
 	//nolint:wrapcheck // pass-through adapter; fsutil and callbacks wrap their own errors
 	return fsutil.ForEachYAMLFile(dir, func(filePath string, content []byte) error {
 		// Expand environment variables in file content before handing to the caller.
-		expanded := envvar.ExpandWithLookup(string(content), lookup)
+		expanded, err := envvar.ExpandConfig(content, lookup)
+		if err != nil {
+			return fmt.Errorf("failed to expand environment variables in %s: %w", filePath, err)
+		}
 
-		return callback(filePath, []byte(expanded))
+		return callback(filePath, expanded)
 	})
 }
 
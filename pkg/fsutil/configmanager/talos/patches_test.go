@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/talos"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -367,3 +368,21 @@ func TestLoadPatches_ExpandsEnvVarsWithDefault(
 	assert.Contains(t, string(patches[0].Content), "hostname: default-host")
 	assert.NotContains(t, string(patches[0].Content), "${UNDEFINED_HOST")
 }
+
+//nolint:paralleltest // Uses t.Setenv
+func TestLoadPatches_StrictEnvRejectsUnsetVariable(t *testing.T) {
+	tmpDir := t.TempDir()
+	clusterDir := filepath.Join(tmpDir, "cluster")
+
+	require.NoError(t, os.MkdirAll(clusterDir, 0o750))
+
+	patchContent := []byte("machine:\n  network:\n    hostname: ${UNDEFINED_HOST}\n")
+	require.NoError(t, os.WriteFile(filepath.Join(clusterDir, "hostname.yaml"), patchContent, 0o600))
+
+	t.Setenv(envvar.StrictEnvVar, "true")
+
+	_, err := talos.LoadPatches(tmpDir)
+
+	require.ErrorIs(t, err, envvar.ErrUnsetVariable)
+	assert.Contains(t, err.Error(), "hostname.yaml")
+}
@@ -45,6 +45,8 @@ const (
 	ingressFirewallRulesFileName = "ingress-firewall-rules.yaml"
 	// oidcFileName is the name of the OIDC API server configuration patch file.
 	oidcFileName = "oidc.yaml"
+	// ipFamilyFileName is the name of the IPv6/dual-stack pod and service subnet patch file.
+	ipFamilyFileName = "ip-family.yaml"
 )
 
 // ExternalCloudProviderPatchYAML is the Talos machine config patch YAML that enables
@@ -157,6 +159,10 @@ type Config struct {
 	// 0.0.0.0/0 and ::/0 (open to all). Only affects the CP rules; worker rules are
 	// always restricted to the private network CIDR.
 	AllowedCIDRs []string
+	// NodePortCIDRs opens the Kubernetes NodePort range (30000-32767, TCP and UDP) on every
+	// node to the specified CIDR blocks. When empty, no NodePort rules are generated and the
+	// default-block action keeps NodePorts closed.
+	NodePortCIDRs []string
 	// EnableOIDC indicates whether to generate an OIDC API server configuration patch.
 	// Talos 1.14 uses KubeAuthenticationConfig; older releases use API-server flags.
 	EnableOIDC bool
@@ -174,10 +180,16 @@ type Config struct {
 	OIDCGroupsPrefix string
 	// OIDCCAFile is the path to the CA certificate for self-signed OIDC providers.
 	OIDCCAFile string
+	// IPFamily is the IP family of the pod and service networks. When ipv6 or dual,
+	// generates an ip-family.yaml patch with matching pod and service subnets.
+	IPFamily v1alpha1.IPFamily
 	// MultiDocumentKubernetesConfig generates the Talos 1.14 Kubernetes
 	// configuration resources instead of the legacy cluster fields. Enable this
 	// only when the config manager uses a matching Talos version contract.
 	MultiDocumentKubernetesConfig bool
+	// SchemaHeader, when set, is prepended as the first line of every generated patch,
+	// e.g. a yaml-language-server directive pointing editors at the Talos config schema.
+	SchemaHeader string
 }
 
 // Generator generates the Talos directory structure.
@@ -396,6 +408,17 @@ kind: NetworkDefaultActionConfig
 ingress: block
 `
 
+// TrustedRootsConfigYAML returns a Talos TrustedRootsConfig document that adds the
+// PEM certificates in bundle to the machine's trusted roots, so image pulls and
+// outbound TLS from the node trust them.
+func TrustedRootsConfigYAML(name, bundle string) string {
+	return `apiVersion: v1alpha1
+kind: TrustedRootsConfig
+name: ` + name + `
+certificates: |
+` + indentManifest(strings.TrimRight(bundle, "\n"), "  ") + "\n"
+}
+
 // networkRuleConfigTemplate is a single Talos NetworkRuleConfig YAML document. %[1]s is the rule
 // name, %[2]s the port (or port range/list, pre-formatted), %[3]s the protocol, and %[4]s the
 // already-indented `ingress:` list body (e.g. "  - subnet: 10.0.0.0/16\n", or multiple such lines
@@ -411,6 +434,9 @@ portSelector:
 ingress:
 %[4]s`
 
+// nodePortRange is the Kubernetes NodePort range opened by the NodePort rules.
+const nodePortRange = "30000-32767"
+
 // networkRuleConfigDoc renders one NetworkRuleConfig document via networkRuleConfigTemplate.
 func networkRuleConfigDoc(name, port, protocol, ingressLines string) string {
 	return fmt.Sprintf(networkRuleConfigTemplate, name, port, protocol, ingressLines)
@@ -427,11 +453,16 @@ func singleSubnetIngress(cidr string) string {
 // IngressFirewallCPRulesYAML returns the Talos NetworkRuleConfig documents for control-plane
 // nodes. The networkCIDR and cniPort parameters are injected at generation time.
 // When allowedCIDRs is non-empty, the apid and kubernetes-api rules use those CIDRs
-// instead of 0.0.0.0/0 and ::/0.
+// instead of 0.0.0.0/0 and ::/0. When nodePortCIDRs is non-empty, NodePort rules
+// admitting those CIDRs are appended.
 //
 // This is the single source of truth for the CP rules content, shared between the
 // generator (file-based scaffolding) and the runtime config manager (in-memory injection).
-func IngressFirewallCPRulesYAML(networkCIDR string, cniPort int, allowedCIDRs []string) string {
+func IngressFirewallCPRulesYAML(
+	networkCIDR string,
+	cniPort int,
+	allowedCIDRs, nodePortCIDRs []string,
+) string {
 	apiIngress := formatIngressSubnets(allowedCIDRs)
 	networkIngress := singleSubnetIngress(networkCIDR)
 
@@ -444,12 +475,15 @@ func IngressFirewallCPRulesYAML(networkCIDR string, cniPort int, allowedCIDRs []
 		networkRuleConfigDoc("cni-vxlan", strconv.Itoa(cniPort), "udp", networkIngress),
 	}
 
+	docs = append(docs, nodePortRuleDocs(nodePortCIDRs)...)
+
 	return strings.Join(docs, "---\n")
 }
 
 // IngressFirewallWorkerRulesYAML returns the Talos NetworkRuleConfig documents for worker
-// nodes. Workers expose fewer ports than control-plane nodes.
-func IngressFirewallWorkerRulesYAML(networkCIDR string, cniPort int) string {
+// nodes. Workers expose fewer ports than control-plane nodes. When nodePortCIDRs is
+// non-empty, NodePort rules admitting those CIDRs are appended.
+func IngressFirewallWorkerRulesYAML(networkCIDR string, cniPort int, nodePortCIDRs []string) string {
 	networkIngress := singleSubnetIngress(networkCIDR)
 
 	docs := []string{
@@ -458,9 +492,26 @@ func IngressFirewallWorkerRulesYAML(networkCIDR string, cniPort int) string {
 		networkRuleConfigDoc("cni-vxlan", strconv.Itoa(cniPort), "udp", networkIngress),
 	}
 
+	docs = append(docs, nodePortRuleDocs(nodePortCIDRs)...)
+
 	return strings.Join(docs, "---\n")
 }
 
+// nodePortRuleDocs returns the TCP and UDP NetworkRuleConfig documents opening the
+// Kubernetes NodePort range to nodePortCIDRs, or nil when no CIDRs are configured.
+func nodePortRuleDocs(nodePortCIDRs []string) []string {
+	if len(nodePortCIDRs) == 0 {
+		return nil
+	}
+
+	ingress := formatIngressSubnets(nodePortCIDRs)
+
+	return []string{
+		networkRuleConfigDoc("nodeport-tcp", nodePortRange, "tcp", ingress),
+		networkRuleConfigDoc("nodeport-udp", nodePortRange, "udp", ingress),
+	}
+}
+
 // formatIngressSubnets formats allowed CIDRs (or default 0.0.0.0/0 + ::/0) as
 // YAML ingress subnet lines suitable for inclusion in NetworkRuleConfig templates.
 // Each line is indented with two spaces (matching the ingress list level).
@@ -3,6 +3,7 @@ package talosgenerator_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
@@ -10,6 +11,7 @@ import (
 	talosgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/talos"
 	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
 	talosconfig "github.com/siderolabs/talos/pkg/machinery/config"
+	"github.com/siderolabs/talos/pkg/machinery/config/configloader"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -311,6 +313,31 @@ func TestGenerator_Generate_AllowSchedulingOnControlPlanes(t *testing.T) {
 	assert.True(t, os.IsNotExist(err), "expected .gitkeep to not exist when patches are generated")
 }
 
+func TestGenerator_Generate_SchemaHeader(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	gen := talosgenerator.NewGenerator()
+
+	const header = "# yaml-language-server: $schema=talos-config.schema.json"
+
+	config := &talosgenerator.Config{
+		PatchesDir:   "talos",
+		ClusterName:  "schema",
+		SchemaHeader: header,
+	}
+
+	_, err := gen.Generate(config, yamlgenerator.Options{Output: tempDir})
+	require.NoError(t, err)
+
+	for _, name := range []string{"allow-scheduling-on-control-planes.yaml", "cluster-name.yaml"} {
+		//nolint:gosec // Test file path is safe
+		content, err := os.ReadFile(filepath.Join(tempDir, "talos", "cluster", name))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), header+"\ncluster:"), string(content))
+	}
+}
+
 func TestGenerator_Generate_WorkerRoleLabel_NotGeneratedWithWorkers(t *testing.T) {
 	t.Parallel()
 
@@ -1041,7 +1068,7 @@ func TestGenerator_Generate_IngressFirewallFlannelPort(t *testing.T) {
 func TestIngressFirewallCPRulesYAML(t *testing.T) {
 	t.Parallel()
 
-	result := talosgenerator.IngressFirewallCPRulesYAML("10.0.0.0/16", 8472, nil)
+	result := talosgenerator.IngressFirewallCPRulesYAML("10.0.0.0/16", 8472, nil, nil)
 
 	// Verify all expected rule names are present
 	assert.Contains(t, result, "name: kubelet")
@@ -1079,7 +1106,7 @@ func TestIngressFirewallCPRulesYAMLWithAllowedCIDRs(t *testing.T) {
 	t.Parallel()
 
 	allowedCIDRs := []string{"203.0.113.0/24", "198.51.100.0/24"}
-	result := talosgenerator.IngressFirewallCPRulesYAML("10.0.0.0/16", 8472, allowedCIDRs)
+	result := talosgenerator.IngressFirewallCPRulesYAML("10.0.0.0/16", 8472, allowedCIDRs, nil)
 
 	// Verify apid and kubernetes-api use the provided CIDRs
 	assert.Contains(t, result, "subnet: 203.0.113.0/24")
@@ -1108,7 +1135,7 @@ func TestIngressFirewallCPRulesYAMLNormalizesCIDRs(t *testing.T) {
 
 	// "203.0.113.5/24" has host bits; should be normalized to "203.0.113.0/24"
 	allowedCIDRs := []string{"203.0.113.5/24", "2001:db8::1/32"}
-	result := talosgenerator.IngressFirewallCPRulesYAML("10.0.0.0/16", 8472, allowedCIDRs)
+	result := talosgenerator.IngressFirewallCPRulesYAML("10.0.0.0/16", 8472, allowedCIDRs, nil)
 
 	assert.Contains(t, result, "subnet: 203.0.113.0/24")
 	assert.NotContains(t, result, "subnet: 203.0.113.5/24")
@@ -1120,7 +1147,7 @@ func TestIngressFirewallCPRulesYAMLNormalizesCIDRs(t *testing.T) {
 func TestIngressFirewallWorkerRulesYAML(t *testing.T) {
 	t.Parallel()
 
-	result := talosgenerator.IngressFirewallWorkerRulesYAML("192.168.0.0/24", 4789)
+	result := talosgenerator.IngressFirewallWorkerRulesYAML("192.168.0.0/24", 4789, nil)
 
 	// Verify expected rule names are present
 	assert.Contains(t, result, "name: kubelet")
@@ -1146,3 +1173,45 @@ func TestIngressFirewallWorkerRulesYAML(t *testing.T) {
 	assert.Contains(t, result, "10250") // kubelet
 	assert.Contains(t, result, "50000") // apid
 }
+
+// TestIngressFirewallRulesYAMLWithNodePortCIDRs verifies that nodePortCIDRs appends
+// TCP and UDP NodePort rules to both the control-plane and worker rule sets.
+func TestIngressFirewallRulesYAMLWithNodePortCIDRs(t *testing.T) {
+	t.Parallel()
+
+	nodePortCIDRs := []string{"203.0.113.0/24"}
+
+	for name, result := range map[string]string{
+		"control-plane": talosgenerator.IngressFirewallCPRulesYAML(
+			"10.0.0.0/16", 8472, nil, nodePortCIDRs,
+		),
+		"worker": talosgenerator.IngressFirewallWorkerRulesYAML(
+			"10.0.0.0/16", 8472, nodePortCIDRs,
+		),
+	} {
+		assert.Contains(t, result, "name: nodeport-tcp", name)
+		assert.Contains(t, result, "name: nodeport-udp", name)
+		assert.Contains(t, result, "30000-32767", name)
+		assert.Contains(t, result, "subnet: 203.0.113.0/24", name)
+	}
+
+	withoutNodePorts := talosgenerator.IngressFirewallWorkerRulesYAML("10.0.0.0/16", 8472, nil)
+	assert.NotContains(t, withoutNodePorts, "nodeport")
+}
+
+// TestTrustedRootsConfigYAML verifies the generated document is a valid Talos
+// TrustedRootsConfig carrying the bundle verbatim.
+func TestTrustedRootsConfigYAML(t *testing.T) {
+	t.Parallel()
+
+	bundle := "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"
+
+	result := talosgenerator.TrustedRootsConfigYAML("ksail-ca-bundle", bundle)
+
+	cfg, err := configloader.NewFromBytes([]byte(result))
+	require.NoError(t, err)
+
+	certs := cfg.TrustedRoots().ExtraTrustedRootCertificates()
+	require.Len(t, certs, 1)
+	assert.True(t, strings.HasSuffix(certs[0], "ksail-ca-bundle:\n================\n"+bundle), certs[0])
+}
@@ -53,7 +53,7 @@ func patchSpecs() []patchSpec {
 }
 
 // clusterPatchSpecs returns the cluster-scoped patches that have no shared
-// conditions (mirror registries, CNI, cluster name, CDI, cloud provider, OIDC).
+// conditions (mirror registries, CNI, cluster name, CDI, cloud provider, OIDC, IP family).
 func clusterPatchSpecs() []patchSpec {
 	return []patchSpec{
 		{
@@ -102,6 +102,16 @@ func clusterPatchSpecs() []patchSpec {
 			filename: oidcFileName,
 			content:  oidcContent,
 		},
+		{
+			when:     func(cfg *Config) bool { return cfg.IPFamily.IPv6() },
+			subdir:   subdirCluster,
+			filename: ipFamilyFileName,
+			content: func(cfg *Config) (string, error) {
+				return talosconfigmanager.IPFamilyPatchYAML(
+					cfg.IPFamily, cfg.MultiDocumentKubernetesConfig,
+				), nil
+			},
+		},
 	}
 }
 
@@ -177,6 +187,7 @@ func ingressFirewallSpecs() []patchSpec {
 					cfg.NetworkCIDR,
 					cfg.CNIPort,
 					cfg.AllowedCIDRs,
+					cfg.NodePortCIDRs,
 				), nil
 			},
 		},
@@ -185,7 +196,11 @@ func ingressFirewallSpecs() []patchSpec {
 			subdir:   subdirWorkers,
 			filename: ingressFirewallRulesFileName,
 			content: func(cfg *Config) (string, error) {
-				return IngressFirewallWorkerRulesYAML(cfg.NetworkCIDR, cfg.CNIPort), nil
+				return IngressFirewallWorkerRulesYAML(
+					cfg.NetworkCIDR,
+					cfg.CNIPort,
+					cfg.NodePortCIDRs,
+				), nil
 			},
 		},
 	}
@@ -261,7 +276,8 @@ func oidcContent(cfg *Config) (string, error) {
 // content func with side effects (e.g. the OIDC patch reads the CA file off disk)
 // is never invoked for an already-present patch on a no-force re-run — keeping
 // re-generation idempotent even when an input the content depends on has moved.
-// It also skips when content resolves to empty. The stricter stat-error handling
+// It also skips when content resolves to empty, and prepends the model's SchemaHeader
+// to non-empty content. The stricter stat-error handling
 // (propagate unexpected stat failures) is preserved so patches fail loudly on
 // e.g. permission errors rather than silently swallowing them.
 func writePatchFile(rootPath string, spec patchSpec, model *Config, force bool) error {
@@ -287,6 +303,10 @@ func writePatchFile(rootPath string, spec patchSpec, model *Config, force bool)
 		return nil
 	}
 
+	if model.SchemaHeader != "" {
+		content = model.SchemaHeader + "\n" + content
+	}
+
 	err = os.WriteFile(path, []byte(content), filePerm)
 	if err != nil {
 		return fmt.Errorf("failed to write %s: %w", spec.filename, err)
@@ -46,4 +46,43 @@ var (
 
 	// ErrDevcontainerGeneration wraps failures when creating .devcontainer/devcontainer.json.
 	ErrDevcontainerGeneration = errors.New("failed to generate devcontainer configuration")
+
+	// ErrPreCommitGeneration wraps failures when creating .pre-commit-config.yaml.
+	ErrPreCommitGeneration = errors.New("failed to generate pre-commit configuration")
+
+	// ErrTaskRunnerGeneration wraps failures when creating the Taskfile.yml or Makefile.
+	ErrTaskRunnerGeneration = errors.New("failed to generate task runner file")
+
+	// ErrUnknownTaskRunner indicates an unsupported --task-runner value was requested.
+	ErrUnknownTaskRunner = errors.New("unknown task runner")
+
+	// ErrGatewayExampleGeneration wraps failures when creating the example gateway.yaml.
+	ErrGatewayExampleGeneration = errors.New("failed to generate example gateway configuration")
+
+	// ErrPolicyGeneration wraps failures when creating the baseline Kyverno policies.
+	ErrPolicyGeneration = errors.New("failed to generate baseline policies")
+
+	// ErrSecretStoreGeneration wraps failures when creating the ClusterSecretStore.
+	ErrSecretStoreGeneration = errors.New("failed to generate cluster secret store")
+
+	// ErrMeshNamespaceGeneration wraps failures when creating the service mesh namespace.
+	ErrMeshNamespaceGeneration = errors.New("failed to generate service mesh namespace")
+
+	// ErrUnknownLayout indicates an unsupported --layout value was requested.
+	ErrUnknownLayout = errors.New("unknown project layout")
+
+	// ErrLayoutConflict indicates a layout combined with an incompatible scaffolding option.
+	ErrLayoutConflict = errors.New("conflicting project layout")
+
+	// ErrOverlayGeneration wraps failures when creating the base and environment overlays.
+	ErrOverlayGeneration = errors.New("failed to generate environment overlays")
+
+	// ErrHelmChartGeneration wraps failures when creating the example chart and its release.
+	ErrHelmChartGeneration = errors.New("failed to generate example helm chart")
+
+	// ErrMonorepoGeneration wraps failures when creating the apps, infrastructure and clusters directories.
+	ErrMonorepoGeneration = errors.New("failed to generate monorepo layout")
+
+	// ErrArgoCDAppGeneration wraps failures when creating the example Argo CD app directory.
+	ErrArgoCDAppGeneration = errors.New("failed to generate example Argo CD app")
 )
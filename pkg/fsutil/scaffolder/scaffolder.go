@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
@@ -37,10 +38,27 @@ const (
 	// TalosConfigDir is the default directory for Talos distribution configuration (Talos patches).
 	TalosConfigDir = "talos"
 
+	// SchemaBaseURL is where the JSON schemas generated in the repository's schemas/
+	// directory are published.
+	SchemaBaseURL = "https://raw.githubusercontent.com/devantler-tech/ksail/main/schemas/"
+
 	// SchemaHeader is the yaml-language-server directive prepended to generated ksail.yaml
 	// files so editors validate them against the published KSail configuration schema.
-	SchemaHeader = "# yaml-language-server: $schema=" +
-		"https://raw.githubusercontent.com/devantler-tech/ksail/main/schemas/ksail-config.schema.json"
+	SchemaHeader = schemaDirective + SchemaBaseURL + "ksail-config.schema.json"
+
+	// KindSchemaHeader is the yaml-language-server directive prepended to kind.yaml when
+	// schema headers are enabled (see WithSchema).
+	KindSchemaHeader = schemaDirective + SchemaBaseURL + "kind-config.schema.json"
+
+	// K3dSchemaHeader is the yaml-language-server directive prepended to k3d.yaml when
+	// schema headers are enabled (see WithSchema).
+	K3dSchemaHeader = schemaDirective + SchemaBaseURL + "k3d-config.schema.json"
+
+	// TalosSchemaHeader is the yaml-language-server directive prepended to each Talos patch
+	// when schema headers are enabled (see WithSchema).
+	TalosSchemaHeader = schemaDirective + SchemaBaseURL + "talos-config.schema.json"
+
+	schemaDirective = "# yaml-language-server: $schema="
 )
 
 const (
@@ -63,12 +81,31 @@ type Scaffolder struct {
 	// Devcontainer, when true (the default), scaffolds .devcontainer/devcontainer.json.
 	// Disable it via WithDevcontainer(false) (wired to init's --no-devcontainer flag).
 	Devcontainer bool
+	// PreCommit, when true (wired to init's --pre-commit flag), scaffolds a
+	// .pre-commit-config.yaml whose hook runs `ksail verify`. Off by default.
+	PreCommit bool
+	// TaskRunner, when set (wired to init's --task-runner flag), scaffolds a
+	// Taskfile.yml or Makefile with the canonical up, down, validate, test and
+	// push targets. None by default.
+	TaskRunner TaskRunner
 	// MultiClusterEnv, when non-empty (wired to init's --multi-cluster flag), scaffolds a
 	// multi-cluster source layout (clusters/base/ + clusters/<env>/) instead of the flat
 	// single-cluster kustomization, and defaults the generated ksail.yaml's
 	// spec.workload.kustomizationFile to the environment overlay so the GitOps engine
 	// syncs from it.
 	MultiClusterEnv string
+	// Layout (wired to init's --layout flag) selects how the source directory is
+	// organized. LayoutOverlays scaffolds base/ plus overlays/<env>/ and defaults the
+	// generated ksail.yaml's spec.workload.kustomizationFile to the first overlay.
+	// LayoutHelm scaffolds a chart under charts/ plus the Flux HelmRelease or Argo
+	// CD Application that installs it. LayoutMonorepo scaffolds apps/,
+	// infrastructure/ and clusters/<cluster>/ and defaults kustomizationFile to
+	// the cluster's directory.
+	Layout Layout
+	// Schema, when true (wired to init's --schema flag), prepends yaml-language-server
+	// schema headers to the scaffolded distribution configs (kind.yaml, k3d.yaml and the
+	// Talos patches) as well. ksail.yaml always carries SchemaHeader.
+	Schema bool
 }
 
 // NewScaffolder creates a new Scaffolder instance with the provided KSail cluster configuration.
@@ -89,6 +126,7 @@ func NewScaffolder(cfg v1alpha1.Cluster, writer io.Writer, mirrorRegistries []st
 		Writer:                 writer,
 		MirrorRegistries:       mirrorRegistries,
 		Devcontainer:           true,
+		Layout:                 LayoutFlat,
 	}
 }
 
@@ -101,6 +139,22 @@ func (s *Scaffolder) WithDevcontainer(enabled bool) *Scaffolder {
 	return s
 }
 
+// WithPreCommit toggles scaffolding of .pre-commit-config.yaml (wired to the
+// init command's --pre-commit flag). Pre-commit scaffolding is disabled by default.
+func (s *Scaffolder) WithPreCommit(enabled bool) *Scaffolder {
+	s.PreCommit = enabled
+
+	return s
+}
+
+// WithTaskRunner selects the task runner file to scaffold (wired to the init
+// command's --task-runner flag). TaskRunnerNone, the default, scaffolds none.
+func (s *Scaffolder) WithTaskRunner(runner TaskRunner) *Scaffolder {
+	s.TaskRunner = runner
+
+	return s
+}
+
 // WithMultiClusterEnv enables the multi-cluster source layout with envName as the
 // initial environment overlay (wired to the init command's --multi-cluster flag).
 // The name is validated when the layout is derived at the start of Scaffold, so an
@@ -111,6 +165,22 @@ func (s *Scaffolder) WithMultiClusterEnv(envName string) *Scaffolder {
 	return s
 }
 
+// WithLayout selects the source directory layout (wired to the init command's
+// --layout flag). LayoutFlat is the default.
+func (s *Scaffolder) WithLayout(layout Layout) *Scaffolder {
+	s.Layout = layout
+
+	return s
+}
+
+// WithSchema toggles yaml-language-server schema headers on the scaffolded
+// distribution configs (wired to the init command's --schema flag). Disabled by default.
+func (s *Scaffolder) WithSchema(enabled bool) *Scaffolder {
+	s.Schema = enabled
+
+	return s
+}
+
 // WithClusterName sets an explicit cluster name override for the scaffolder.
 // When set, this name is used for ksail.yaml metadata.name and distribution configs
 // (kind.yaml Name, k3d.yaml metadata.name, Talos cluster patches). The kubeconfig
@@ -129,8 +199,12 @@ func (s *Scaffolder) WithClusterName(name string) *Scaffolder {
 //   - ksail.yaml configuration
 //   - Distribution-specific configuration (kind.yaml or k3d.yaml)
 //   - kind/mirrors directory with hosts.toml files (for Kind with mirror registries)
-//   - kustomization.yaml in the source directory
+//   - kustomization.yaml in the source directory (or base/ plus overlays/<env>/
+//     with LayoutOverlays, an example chart and its release with LayoutHelm, or
+//     apps/, infrastructure/ and clusters/<cluster>/ with LayoutMonorepo)
 //   - .devcontainer/devcontainer.json (unless disabled via WithDevcontainer(false))
+//   - .pre-commit-config.yaml (when enabled via WithPreCommit(true))
+//   - Taskfile.yml or Makefile (when selected via WithTaskRunner)
 //
 // Parameters:
 //   - output: The output directory for generated files
@@ -141,6 +215,11 @@ func (s *Scaffolder) WithClusterName(name string) *Scaffolder {
 func (s *Scaffolder) Scaffold(output string, force bool) error {
 	previousDistributionConfig := strings.TrimSpace(s.KSailConfig.Spec.Cluster.DistributionConfig)
 
+	err := s.validateLayout()
+	if err != nil {
+		return err
+	}
+
 	// Derive the multi-cluster layout up front so an invalid or reserved environment
 	// name aborts before any file is written.
 	multiClusterFiles, err := s.deriveMultiClusterFiles()
@@ -183,7 +262,21 @@ func (s *Scaffolder) Scaffold(output string, force bool) error {
 	}
 
 	if s.Devcontainer {
-		return s.generateDevcontainerConfig(output, force)
+		err = s.generateDevcontainerConfig(output, force)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.PreCommit {
+		err = s.generatePreCommitConfig(output, force)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.TaskRunner != TaskRunnerNone {
+		return s.generateTaskRunner(output, force)
 	}
 
 	return nil
@@ -207,17 +300,40 @@ func (s *Scaffolder) deriveMultiClusterFiles() ([]environment.LayoutFile, error)
 
 // generateWorkloadKustomizations writes the source tree's kustomizations: in
 // multi-cluster mode the environment overlay (pointed at by the generated
-// ksail.yaml's kustomizationFile) replaces the flat single-cluster kustomization.
+// ksail.yaml's kustomizationFile) replaces the flat single-cluster kustomization,
+// the overlays layout moves it to base/ below its environment overlays, the
+// helm layout lists the release of an example chart in it, and the monorepo
+// layout moves it to infrastructure/ next to apps/ and clusters/<cluster>/.
+// The Argo CD ApplicationSet layout adds an example app directory in both modes.
 func (s *Scaffolder) generateWorkloadKustomizations(
 	output string,
 	force bool,
 	multiClusterFiles []environment.LayoutFile,
 ) error {
-	if multiClusterFiles != nil {
-		return s.generateMultiClusterLayout(output, force, multiClusterFiles)
+	var err error
+
+	switch {
+	case multiClusterFiles != nil:
+		err = s.generateMultiClusterLayout(output, force, multiClusterFiles)
+	case s.Layout == LayoutOverlays:
+		err = s.generateOverlaysLayout(output, force)
+	case s.Layout == LayoutHelm:
+		err = s.generateHelmLayout(output, force)
+	case s.Layout == LayoutMonorepo:
+		err = s.generateMonorepoLayout(output, force)
+	default:
+		err = s.generateKustomizationConfig(output, force)
+	}
+
+	if err != nil {
+		return err
 	}
 
-	return s.generateKustomizationConfig(output, force)
+	if s.scaffoldsArgoCDApps() {
+		return s.generateArgoCDExampleApp(output, force)
+	}
+
+	return nil
 }
 
 // generateMultiClusterLayout writes the derived multi-cluster layout under the source
@@ -309,6 +425,20 @@ func (s *Scaffolder) applyKSailConfigDefaults() v1alpha1.Cluster {
 		)
 	}
 
+	// In the overlays layout, sync the first environment overlay (overlays/dev/)
+	// unless the user pinned kustomizationFile explicitly.
+	if s.Layout == LayoutOverlays &&
+		strings.TrimSpace(config.Spec.Workload.KustomizationFile) == "" {
+		config.Spec.Workload.KustomizationFile = defaultOverlayKustomizationFile()
+	}
+
+	// In the monorepo layout, sync the cluster's directory (clusters/<cluster>/)
+	// unless the user pinned kustomizationFile explicitly.
+	if s.Layout == LayoutMonorepo &&
+		strings.TrimSpace(config.Spec.Workload.KustomizationFile) == "" {
+		config.Spec.Workload.KustomizationFile = s.defaultMonorepoKustomizationFile()
+	}
+
 	return config
 }
 
@@ -443,16 +573,32 @@ func (s *Scaffolder) notifyFileAction(displayName string, overwritten bool) {
 
 // Configuration file generators.
 
-// schemaHeaderGenerator wraps a KSail YAML generator and prepends SchemaHeader to its
-// output so editors with YAML language-server support validate ksail.yaml out of the box.
-type schemaHeaderGenerator struct {
-	inner generator.Generator[v1alpha1.Cluster, yamlgenerator.Options]
+// schemaHeaderGenerator wraps a YAML generator and prepends a yaml-language-server
+// header to its output so editors with YAML language-server support validate the file
+// out of the box.
+type schemaHeaderGenerator[T any] struct {
+	inner  generator.Generator[T, yamlgenerator.Options]
+	header string
+}
+
+// withSchemaHeader wraps gen in a schemaHeaderGenerator when Schema is enabled and
+// returns it unchanged otherwise.
+func withSchemaHeader[T any](
+	s *Scaffolder,
+	gen generator.Generator[T, yamlgenerator.Options],
+	header string,
+) generator.Generator[T, yamlgenerator.Options] {
+	if !s.Schema {
+		return gen
+	}
+
+	return &schemaHeaderGenerator[T]{inner: gen, header: header}
 }
 
 // Generate renders the model via the wrapped generator, prepends the schema header,
 // and writes the result to opts.Output when set.
-func (g *schemaHeaderGenerator) Generate(
-	model v1alpha1.Cluster,
+func (g *schemaHeaderGenerator[T]) Generate(
+	model T,
 	opts yamlgenerator.Options,
 ) (string, error) {
 	contentOpts := opts
@@ -460,10 +606,10 @@ func (g *schemaHeaderGenerator) Generate(
 
 	body, err := g.inner.Generate(model, contentOpts)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate ksail.yaml content: %w", err)
+		return "", fmt.Errorf("failed to generate YAML content: %w", err)
 	}
 
-	content := SchemaHeader + "\n" + body
+	content := g.header + "\n" + body
 
 	if opts.Output == "" {
 		return content, nil
@@ -490,7 +636,10 @@ func (s *Scaffolder) generateKSailConfig(output string, force bool) error {
 	return generateWithFileHandling(
 		s,
 		GenerationParams[v1alpha1.Cluster]{
-			Gen:         &schemaHeaderGenerator{inner: s.KSailYAMLGenerator},
+			Gen: &schemaHeaderGenerator[v1alpha1.Cluster]{
+				inner:  s.KSailYAMLGenerator,
+				header: SchemaHeader,
+			},
 			Model:       config,
 			Opts:        opts,
 			DisplayName: "ksail.yaml",
@@ -588,11 +737,85 @@ func (s *Scaffolder) generateKustomizationConfig(output string, force bool) erro
 		return err
 	}
 
+	return s.generateKustomizationIn(output, kustomizationDir, force)
+}
+
+// generateKustomizationIn writes the workload kustomization.yaml, and the resources
+// it scaffolds alongside, into kustomizationDir (relative to output). extraResources
+// are listed after the scaffolded ones.
+func (s *Scaffolder) generateKustomizationIn(
+	output, kustomizationDir string,
+	force bool,
+	extraResources ...string,
+) error {
+	var err error
+
 	// GitOps resources (FluxInstance, ArgoCD Application) are created server-side
 	// via the Kubernetes API during cluster creation, not scaffolded, so the
-	// kustomization starts empty (the generator normalizes resources to []).
+	// kustomization starts empty (the generator normalizes resources to [])
+	// unless a service mesh namespace, an example Gateway, the baseline policies,
+	// or a ClusterSecretStore are scaffolded below.
 	kustomization := ktypes.Kustomization{}
 
+	// Scaffold the workload namespace with the service mesh's injection labels
+	// so scaffolded workloads join the mesh. It comes first so the namespace is
+	// listed before the resources deployed into it.
+	if s.scaffoldsMeshNamespace() {
+		err = s.generateMeshNamespace(output, kustomizationDir, force)
+		if err != nil {
+			return err
+		}
+
+		kustomization.Resources = []string{MeshNamespaceFile}
+	}
+
+	// Scaffold an example Gateway/HTTPRoute when a Gateway API controller is
+	// selected, so the project starts with a working route to build on.
+	if s.gatewayClassName() != "" {
+		err = s.generateGatewayExample(output, kustomizationDir, force)
+		if err != nil {
+			return err
+		}
+
+		kustomization.Resources = append(kustomization.Resources, GatewayExampleFile)
+	}
+
+	// Scaffold baseline Pod Security policies when Kyverno is the policy engine,
+	// so they are both applied by GitOps and usable with validate --policies.
+	if s.scaffoldsPolicies() {
+		err = s.generateBaselinePolicies(output, kustomizationDir, force)
+		if err != nil {
+			return err
+		}
+
+		kustomization.Resources = append(kustomization.Resources, PoliciesDir)
+	}
+
+	// Scaffold a ClusterSecretStore on ESO's fake provider when the External
+	// Secrets Operator is enabled, so ExternalSecrets resolve without a backend.
+	if s.scaffoldsSecretStore() {
+		err = s.generateSecretStore(output, kustomizationDir, force)
+		if err != nil {
+			return err
+		}
+
+		kustomization.Resources = append(kustomization.Resources, SecretStoreFile)
+	}
+
+	kustomization.Resources = append(kustomization.Resources, extraResources...)
+
+	// Stamp spec.cluster.resourceMetadata onto every workload resource. Labels are
+	// added without selectors so existing Deployments/Services keep their immutable
+	// selector fields untouched.
+	metadata := s.KSailConfig.Spec.Cluster.ResourceMetadata
+	if len(metadata.Labels) > 0 {
+		kustomization.Labels = []ktypes.Label{{Pairs: maps.Clone(metadata.Labels)}}
+	}
+
+	if len(metadata.Annotations) > 0 {
+		kustomization.CommonAnnotations = maps.Clone(metadata.Annotations)
+	}
+
 	opts := yamlgenerator.Options{
 		Output: filepath.Join(
 			output,
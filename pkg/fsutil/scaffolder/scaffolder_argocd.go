@@ -0,0 +1,90 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+)
+
+// ArgoCDExampleApp is the app directory scaffolded under
+// v1alpha1.ArgoCDAppsDirectory for the ApplicationSet layout.
+const ArgoCDExampleApp = "example"
+
+// argoCDExampleAppTemplate is the kustomization of the example app. It starts
+// empty so the generated Application syncs cleanly before manifests are added.
+const argoCDExampleAppTemplate = `# App scaffolded for spec.workload.argoCD.layout: ApplicationSet.
+# The ApplicationSet KSail creates turns every directory under %[1]s/ into an
+# Argo CD Application named after the directory and deployed to the namespace of
+# the same name. Add manifests here, or copy this directory to add another app.
+apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources: []
+`
+
+// argoCDExampleAppGenerator renders the example app kustomization. It satisfies
+// the generator.Generator contract used by generateWithFileHandling.
+type argoCDExampleAppGenerator struct{}
+
+// Generate writes the kustomization to opts.Output (or returns it when no output
+// path is set), mirroring secretStoreGenerator's write semantics.
+func (g *argoCDExampleAppGenerator) Generate(
+	appsDir string,
+	opts yamlgenerator.Options,
+) (string, error) {
+	content := fmt.Sprintf(argoCDExampleAppTemplate, appsDir)
+
+	if opts.Output == "" {
+		return content, nil
+	}
+
+	result, err := fsutil.TryWriteFile(content, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write example app kustomization: %w", err)
+	}
+
+	return result, nil
+}
+
+// scaffoldsArgoCDApps reports whether Argo CD syncs with the ApplicationSet
+// layout and its directory generator, so an app directory should be scaffolded
+// for the generator to discover.
+func (s *Scaffolder) scaffoldsArgoCDApps() bool {
+	argoCD := s.KSailConfig.Spec.Workload.ArgoCD
+
+	return s.KSailConfig.Spec.Cluster.GitOpsEngine == v1alpha1.GitOpsEngineArgoCD &&
+		argoCD.UsesApplicationSet() &&
+		argoCD.ApplicationSet.ResolvedGenerator() == v1alpha1.ArgoCDGeneratorDirectory
+}
+
+// generateArgoCDExampleApp writes apps/example/kustomization.yaml into the source
+// directory. The apps directory is not referenced by the root kustomization:
+// the root Application syncs the source directory and the ApplicationSet syncs
+// each app.
+func (s *Scaffolder) generateArgoCDExampleApp(output string, force bool) error {
+	displayName := filepath.Join(
+		s.KSailConfig.Spec.Workload.SourceDirectory,
+		v1alpha1.ArgoCDAppsDirectory,
+		ArgoCDExampleApp,
+		"kustomization.yaml",
+	)
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[string]{
+			Gen:   &argoCDExampleAppGenerator{},
+			Model: v1alpha1.ArgoCDAppsDirectory,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrArgoCDAppGeneration, err)
+			},
+		},
+	)
+}
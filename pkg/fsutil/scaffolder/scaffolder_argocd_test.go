@@ -0,0 +1,85 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldGeneratesArgoCDExampleApp(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		engine   v1alpha1.GitOpsEngine
+		argoCD   v1alpha1.ArgoCDConfig
+		wantApps bool
+	}{
+		{name: "single Application", engine: v1alpha1.GitOpsEngineArgoCD},
+		{
+			name:     "ApplicationSet with directory generator",
+			engine:   v1alpha1.GitOpsEngineArgoCD,
+			argoCD:   v1alpha1.ArgoCDConfig{Layout: v1alpha1.ArgoCDLayoutApplicationSet},
+			wantApps: true,
+		},
+		{
+			name:   "ApplicationSet with cluster generator",
+			engine: v1alpha1.GitOpsEngineArgoCD,
+			argoCD: v1alpha1.ArgoCDConfig{
+				Layout:         v1alpha1.ArgoCDLayoutApplicationSet,
+				ApplicationSet: v1alpha1.ArgoCDApplicationSet{Generator: v1alpha1.ArgoCDGeneratorCluster},
+			},
+		},
+		{
+			name:   "Flux ignores the Argo CD layout",
+			engine: v1alpha1.GitOpsEngineFlux,
+			argoCD: v1alpha1.ArgoCDConfig{Layout: v1alpha1.ArgoCDLayoutApplicationSet},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cluster := createKindCluster("argocd-apps")
+			cluster.Spec.Cluster.GitOpsEngine = testCase.engine
+			cluster.Spec.Workload.ArgoCD = testCase.argoCD
+			sourceDir := filepath.Join(tempDir, cluster.Spec.Workload.SourceDirectory)
+
+			instance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+			require.NoError(t, instance.Scaffold(tempDir, false))
+
+			app, err := os.ReadFile(filepath.Join(
+				sourceDir, v1alpha1.ArgoCDAppsDirectory, scaffolder.ArgoCDExampleApp, "kustomization.yaml",
+			))
+			if !testCase.wantApps {
+				require.ErrorIs(t, err, os.ErrNotExist)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var kustomization struct {
+				Kind      string   `json:"kind"`
+				Resources []string `json:"resources"`
+			}
+			require.NoError(t, yaml.Unmarshal(app, &kustomization))
+			assert.Equal(t, "Kustomization", kustomization.Kind)
+			assert.Empty(t, kustomization.Resources)
+
+			root, err := os.ReadFile(filepath.Join(sourceDir, "kustomization.yaml"))
+			require.NoError(t, err)
+			assert.NotContains(t, string(root), v1alpha1.ArgoCDAppsDirectory,
+				"the apps are synced by the ApplicationSet, not the root kustomization")
+		})
+	}
+}
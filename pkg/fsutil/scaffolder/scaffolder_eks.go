@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // EKSConfigFile is the default eksctl configuration filename.
@@ -21,6 +22,11 @@ const (
 	defaultEKSMaxSize         int32 = 3
 )
 
+// eksSpotInstanceTypes diversifies a spot node group across instance types of
+// the default's size, so a capacity shortage in one spot pool does not leave
+// the group empty.
+var eksSpotInstanceTypes = []string{"t3.medium", "t3a.medium", "t2.medium"}
+
 // generateEKSConfig generates the eks.yaml (eksctl ClusterConfig) file.
 // It scaffolds a minimal declarative eksctl `ClusterConfig` with a single
 // managed node group, IAM OIDC enabled, and the addons KSail considers
@@ -45,7 +51,10 @@ func (s *Scaffolder) generateEKSConfig(output string, force bool) error {
 		clusterName = s.ClusterName
 	}
 
-	content := RenderEKSConfig(DefaultEKSConfigParams(clusterName, defaultEKSRegion))
+	params := DefaultEKSConfigParams(clusterName, defaultEKSRegion)
+	params.Spot = s.KSailConfig.Spec.Cluster.Spot.Enabled
+
+	content := RenderEKSConfig(params)
 
 	err := os.WriteFile(configPath, content, filePerm)
 	if err != nil {
@@ -77,6 +86,11 @@ type EKSConfigParams struct {
 	DesiredCapacity   int32
 	MinSize           int32
 	MaxSize           int32
+	// Spot renders the managed node group as a spot node group diversified
+	// across eksSpotInstanceTypes instead of a single on-demand InstanceType.
+	// EKS enables Capacity Rebalancing for spot managed node groups, replacing
+	// instances that receive a rebalance recommendation before they are reclaimed.
+	Spot bool
 }
 
 // DefaultEKSConfigParams returns the default eksctl parameters with the given cluster name and
@@ -99,6 +113,17 @@ func DefaultEKSConfigParams(clusterName, region string) EKSConfigParams {
 	}
 }
 
+// renderEKSInstanceTypes renders the managed node group's instance type
+// lines: a single instanceType for on-demand groups, or the diversified
+// instanceTypes list plus spot: true for spot groups.
+func renderEKSInstanceTypes(params EKSConfigParams) string {
+	if !params.Spot {
+		return "instanceType: " + params.InstanceType
+	}
+
+	return "instanceTypes: [" + strings.Join(eksSpotInstanceTypes, ", ") + "]\n    spot: true"
+}
+
 // RenderEKSConfig renders an eksctl ClusterConfig YAML document from params.
 func RenderEKSConfig(params EKSConfigParams) []byte {
 	return fmt.Appendf(nil, eksDefaultConfigTemplate,
@@ -106,7 +131,7 @@ func RenderEKSConfig(params EKSConfigParams) []byte {
 		params.Region,
 		params.KubernetesVersion,
 		params.NodeGroupName,
-		params.InstanceType,
+		renderEKSInstanceTypes(params),
 		params.DesiredCapacity,
 		params.MinSize,
 		params.MaxSize,
@@ -117,7 +142,7 @@ func RenderEKSConfig(params EKSConfigParams) []byte {
 // eksDefaultConfigTemplate is the scaffolded eksctl ClusterConfig.
 // See https://eksctl.io/usage/schema/ for the full schema.
 // The placeholders are, in order: cluster name, region, Kubernetes version,
-// nodegroup name, instance type, desiredCapacity, minSize, maxSize, amiFamily.
+// nodegroup name, instance type line(s), desiredCapacity, minSize, maxSize, amiFamily.
 const eksDefaultConfigTemplate = `# eksctl cluster configuration.
 # See https://eksctl.io/usage/schema/ for the full schema.
 apiVersion: eksctl.io/v1alpha5
@@ -139,7 +164,7 @@ addons:
 
 managedNodeGroups:
   - name: %s
-    instanceType: %s
+    %s
     desiredCapacity: %d
     minSize: %d
     maxSize: %d
@@ -73,6 +73,22 @@ func TestRenderEKSConfig_RendersClusterNameAndRegion(t *testing.T) {
 	assert.Contains(t, rendered, "managedNodeGroups:")
 }
 
+func TestRenderEKSConfig_SpotRendersDiversifiedSpotNodeGroup(t *testing.T) {
+	t.Parallel()
+
+	params := scaffolder.DefaultEKSConfigParams("prod", "eu-central-1")
+
+	onDemand := string(scaffolder.RenderEKSConfig(params))
+	assert.Contains(t, onDemand, "instanceType: t3.medium")
+	assert.NotContains(t, onDemand, "spot: true")
+
+	params.Spot = true
+	spot := string(scaffolder.RenderEKSConfig(params))
+
+	assert.Contains(t, spot, "instanceTypes: [t3.medium, t3a.medium, t2.medium]\n    spot: true\n")
+	assert.NotContains(t, spot, "instanceType: ")
+}
+
 // TestScaffoldEKSConfigUsesClusterName pins the wiring, not the plumbing: the helpers above already
 // render whatever name they are handed, so only an end-to-end scaffold catches a call site that
 // hands them the package default instead of the requested name (#6307).
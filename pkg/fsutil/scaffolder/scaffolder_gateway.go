@@ -0,0 +1,96 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+)
+
+// GatewayExampleFile is the filename of the scaffolded example Gateway API
+// resources, written next to the workload kustomization.yaml.
+const GatewayExampleFile = "gateway.yaml"
+
+// gatewayExampleTemplate is an example Gateway listening on port 80 plus an
+// HTTPRoute forwarding all traffic to a Service named "example". The single %s
+// is the GatewayClass served by the selected controller.
+const gatewayExampleTemplate = `# Example Gateway API resources scaffolded for spec.cluster.gatewayAPI.
+# The Gateway listens for HTTP on port 80 and the HTTPRoute forwards every
+# request to the "example" Service - point backendRefs at your own workload.
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: example
+spec:
+  gatewayClassName: %s
+  listeners:
+    - name: http
+      protocol: HTTP
+      port: 80
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: example
+spec:
+  parentRefs:
+    - name: example
+  rules:
+    - backendRefs:
+        - name: example
+          port: 80
+`
+
+// gatewayExampleGenerator renders the example Gateway and HTTPRoute for a
+// GatewayClass name. It satisfies the generator.Generator contract used by
+// generateWithFileHandling.
+type gatewayExampleGenerator struct{}
+
+// Generate writes the example to opts.Output (or returns it when no output path
+// is set), mirroring preCommitGenerator's write semantics.
+func (g *gatewayExampleGenerator) Generate(
+	gatewayClassName string,
+	opts yamlgenerator.Options,
+) (string, error) {
+	content := fmt.Sprintf(gatewayExampleTemplate, gatewayClassName)
+
+	if opts.Output == "" {
+		return content, nil
+	}
+
+	result, err := fsutil.TryWriteFile(content, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", GatewayExampleFile, err)
+	}
+
+	return result, nil
+}
+
+// gatewayClassName returns the GatewayClass the example Gateway should use, or
+// "" when spec.cluster.gatewayAPI selects no controller.
+func (s *Scaffolder) gatewayClassName() string {
+	return s.KSailConfig.Spec.Cluster.GatewayAPI.GatewayClassName()
+}
+
+// generateGatewayExample writes gateway.yaml into the kustomization directory.
+func (s *Scaffolder) generateGatewayExample(output, kustomizationDir string, force bool) error {
+	displayName := filepath.Join(kustomizationDir, GatewayExampleFile)
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[string]{
+			Gen:   &gatewayExampleGenerator{},
+			Model: s.gatewayClassName(),
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrGatewayExampleGeneration, err)
+			},
+		},
+	)
+}
@@ -0,0 +1,64 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldGeneratesGatewayExample(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		gatewayAPI    v1alpha1.GatewayAPI
+		expectedClass string
+	}{
+		{name: "none", gatewayAPI: v1alpha1.GatewayAPINone},
+		{name: "crds only", gatewayAPI: v1alpha1.GatewayAPICRDs},
+		{name: "cilium", gatewayAPI: v1alpha1.GatewayAPICilium, expectedClass: "cilium"},
+		{name: "envoy", gatewayAPI: v1alpha1.GatewayAPIEnvoy, expectedClass: "envoy-gateway"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cluster := createKindCluster("gateway-" + string(testCase.gatewayAPI))
+			cluster.Spec.Cluster.GatewayAPI = testCase.gatewayAPI
+			sourceDir := filepath.Join(tempDir, cluster.Spec.Workload.SourceDirectory)
+
+			instance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+			require.NoError(t, instance.Scaffold(tempDir, false))
+
+			kustomization, err := os.ReadFile(filepath.Join(sourceDir, "kustomization.yaml"))
+			require.NoError(t, err)
+
+			var parsed struct {
+				Resources []string `json:"resources"`
+			}
+			require.NoError(t, yaml.Unmarshal(kustomization, &parsed))
+
+			gateway, err := os.ReadFile(filepath.Join(sourceDir, scaffolder.GatewayExampleFile))
+			if testCase.expectedClass == "" {
+				require.ErrorIs(t, err, os.ErrNotExist)
+				assert.Empty(t, parsed.Resources)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, string(gateway), "gatewayClassName: "+testCase.expectedClass)
+			assert.Contains(t, string(gateway), "kind: HTTPRoute")
+			assert.Equal(t, []string{scaffolder.GatewayExampleFile}, parsed.Resources)
+		})
+	}
+}
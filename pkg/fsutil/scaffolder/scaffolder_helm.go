@@ -0,0 +1,314 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
+)
+
+const (
+	// HelmChartsDir is the directory below the source directory that holds the
+	// charts of the helm layout.
+	HelmChartsDir = "charts"
+	// HelmExampleChart is the name (and directory) of the chart the helm layout
+	// scaffolds.
+	HelmExampleChart = "example"
+	// HelmReleaseFile is the filename of the Flux HelmRelease the helm layout
+	// scaffolds next to the workload kustomization.
+	HelmReleaseFile = "helmrelease.yaml"
+	// HelmApplicationFile is the filename of the Argo CD Application the helm
+	// layout scaffolds next to the workload kustomization.
+	HelmApplicationFile = "application.yaml"
+)
+
+// helmChartYAML is the Chart.yaml of the example chart.
+const helmChartYAML = `apiVersion: v2
+name: example
+description: Example chart scaffolded by --layout helm. Replace its templates with your own workload.
+type: application
+version: 0.1.0
+appVersion: "1.27"
+`
+
+// helmValuesYAML holds the example chart's defaults. Each environment's
+// values-<env>.yaml overrides them.
+const helmValuesYAML = `# Defaults shared by every environment. values-<env>.yaml files next to this
+# one override them per environment.
+replicaCount: 1
+
+image:
+  repository: nginx
+  tag: 1.27-alpine
+  pullPolicy: IfNotPresent
+
+service:
+  type: ClusterIP
+  port: 80
+
+environment: base
+`
+
+// helmValuesEnvTemplate is the values file of one environment. The
+// placeholders are the environment name and its replica count.
+const helmValuesEnvTemplate = `# Values for the %[1]s environment, layered over values.yaml.
+replicaCount: %[2]d
+
+environment: %[1]s
+`
+
+// helmHelpersTemplate defines the example chart's names and labels.
+const helmHelpersTemplate = `{{- define "example.fullname" -}}
+{{- if contains .Chart.Name .Release.Name -}}
+{{- .Release.Name | trunc 63 | trimSuffix "-" -}}
+{{- else -}}
+{{- printf "%s-%s" .Release.Name .Chart.Name | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+{{- end -}}
+
+{{- define "example.selectorLabels" -}}
+app.kubernetes.io/name: {{ .Chart.Name }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end -}}
+
+{{- define "example.labels" -}}
+{{ include "example.selectorLabels" . }}
+app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end -}}
+`
+
+// helmDeploymentTemplate is the example chart's Deployment.
+const helmDeploymentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "example.fullname" . }}
+  labels:
+    {{- include "example.labels" . | nindent 4 }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  selector:
+    matchLabels:
+      {{- include "example.selectorLabels" . | nindent 6 }}
+  template:
+    metadata:
+      labels:
+        {{- include "example.selectorLabels" . | nindent 8 }}
+    spec:
+      containers:
+        - name: {{ .Chart.Name }}
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+          imagePullPolicy: {{ .Values.image.pullPolicy }}
+          env:
+            - name: ENVIRONMENT
+              value: {{ .Values.environment | quote }}
+          ports:
+            - name: http
+              containerPort: 80
+              protocol: TCP
+`
+
+// helmServiceTemplate is the example chart's Service.
+const helmServiceTemplate = `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "example.fullname" . }}
+  labels:
+    {{- include "example.labels" . | nindent 4 }}
+spec:
+  type: {{ .Values.service.type }}
+  ports:
+    - port: {{ .Values.service.port }}
+      targetPort: http
+      protocol: TCP
+      name: http
+  selector:
+    {{- include "example.selectorLabels" . | nindent 4 }}
+`
+
+// helmReleaseTemplate installs the example chart from the flux-system
+// GitRepository Flux syncs spec.workload.git from. The placeholders are the
+// chart path within the repository and the environment values file.
+const helmReleaseTemplate = `# Installs the chart in %[1]s from the repository Flux syncs.
+# Swap the last values file to promote the release to another environment.
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: example
+  namespace: flux-system
+spec:
+  interval: 5m
+  releaseName: example
+  targetNamespace: default
+  chart:
+    spec:
+      chart: ./%[1]s
+      reconcileStrategy: Revision
+      sourceRef:
+        kind: GitRepository
+        name: flux-system
+        namespace: flux-system
+      valuesFiles:
+        - ./%[1]s/values.yaml
+        - ./%[1]s/%[2]s
+`
+
+// helmApplicationTemplate installs the example chart with an Argo CD
+// Application tracking spec.workload.git. The placeholders are the clone URL,
+// the branch, the chart path within the repository and the environment values
+// file.
+const helmApplicationTemplate = `# Installs the chart in %[3]s from the repository Argo CD syncs.
+# Swap the last values file to promote the release to another environment.
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: example
+  namespace: argocd
+spec:
+  project: default
+  source:
+    repoURL: %[1]s
+    targetRevision: %[2]s
+    path: %[3]s
+    helm:
+      valueFiles:
+        - values.yaml
+        - %[4]s
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: default
+  syncPolicy:
+    automated:
+      prune: true
+      selfHeal: true
+`
+
+// helmReplicaCounts gives each default environment a distinct example value, so
+// the values files show what differs between environments. Other names get 1.
+//
+//nolint:gochecknoglobals // immutable lookup table
+var helmReplicaCounts = map[string]int{
+	"dev":        1,
+	"staging":    2,
+	"production": 3,
+}
+
+// helmChartFile is one file of the example chart, named relative to the chart directory.
+type helmChartFile struct {
+	name    string
+	content string
+}
+
+// helmValuesFile returns the values file of envName within the chart.
+func helmValuesFile(envName string) string {
+	return "values-" + envName + ".yaml"
+}
+
+// helmChartPath returns the example chart's directory relative to the
+// repository root, which is what both GitOps engines resolve it against.
+func (s *Scaffolder) helmChartPath() string {
+	sourceDir := s.KSailConfig.Spec.Workload.SourceDirectory
+	if sourceDir == "" {
+		sourceDir = v1alpha1.DefaultSourceDirectory
+	}
+
+	return path.Join(filepath.ToSlash(sourceDir), HelmChartsDir, HelmExampleChart)
+}
+
+// generateHelmLayout writes the helm layout into the source directory: an
+// example chart with one values file per environment under charts/, and the
+// kustomization the GitOps engine syncs, listing a Flux HelmRelease or an Argo
+// CD Application that installs the chart with the first environment's values.
+func (s *Scaffolder) generateHelmLayout(output string, force bool) error {
+	err := s.generateHelmChart(output, force)
+	if err != nil {
+		return err
+	}
+
+	kustomizationDir, err := s.resolveKustomizationDir()
+	if err != nil {
+		return err
+	}
+
+	chartPath := s.helmChartPath()
+	valuesFile := helmValuesFile(environment.DefaultOverlayEnvironments()[0])
+
+	releaseFile := HelmReleaseFile
+	release := fmt.Sprintf(helmReleaseTemplate, chartPath, valuesFile)
+
+	if s.KSailConfig.Spec.Cluster.GitOpsEngine == v1alpha1.GitOpsEngineArgoCD {
+		git := s.KSailConfig.Spec.Workload.Git
+
+		releaseFile = HelmApplicationFile
+		release = fmt.Sprintf(
+			helmApplicationTemplate,
+			git.CloneURL(), git.ResolvedBranch(), chartPath, valuesFile,
+		)
+	}
+
+	err = s.generateHelmFile(output, filepath.Join(kustomizationDir, releaseFile), release, force)
+	if err != nil {
+		return err
+	}
+
+	return s.generateKustomizationIn(output, kustomizationDir, force, releaseFile)
+}
+
+// generateHelmChart writes the example chart and its per-environment values files.
+func (s *Scaffolder) generateHelmChart(output string, force bool) error {
+	chartDir := filepath.Join(
+		s.KSailConfig.Spec.Workload.SourceDirectory, HelmChartsDir, HelmExampleChart,
+	)
+
+	files := []helmChartFile{
+		{name: "Chart.yaml", content: helmChartYAML},
+		{name: "values.yaml", content: helmValuesYAML},
+		{name: filepath.Join("templates", "_helpers.tpl"), content: helmHelpersTemplate},
+		{name: filepath.Join("templates", "deployment.yaml"), content: helmDeploymentTemplate},
+		{name: filepath.Join("templates", "service.yaml"), content: helmServiceTemplate},
+	}
+
+	for _, envName := range environment.DefaultOverlayEnvironments() {
+		replicas, ok := helmReplicaCounts[envName]
+		if !ok {
+			replicas = 1
+		}
+
+		files = append(files, helmChartFile{
+			name:    helmValuesFile(envName),
+			content: fmt.Sprintf(helmValuesEnvTemplate, envName, replicas),
+		})
+	}
+
+	for _, file := range files {
+		err := s.generateHelmFile(output, filepath.Join(chartDir, file.name), file.content, force)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateHelmFile writes content to displayName below output.
+func (s *Scaffolder) generateHelmFile(output, displayName, content string, force bool) error {
+	return generateWithFileHandling(
+		s,
+		GenerationParams[string]{
+			Gen:   &overlayFileGenerator{},
+			Model: content,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrHelmChartGeneration, err)
+			},
+		},
+	)
+}
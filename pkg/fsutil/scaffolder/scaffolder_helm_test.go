@@ -0,0 +1,124 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createHelmCluster returns a Kind cluster whose engine syncs a Git repository,
+// as the helm layout requires.
+func createHelmCluster(name string, engine v1alpha1.GitOpsEngine) v1alpha1.Cluster {
+	cluster := createKindCluster(name)
+	cluster.Spec.Cluster.GitOpsEngine = engine
+	cluster.Spec.Workload.Git = v1alpha1.GitSource{
+		URL:    "https://github.com/acme/platform.git",
+		Branch: "trunk",
+	}
+
+	return cluster
+}
+
+func TestScaffoldGeneratesHelmLayoutWithFlux(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(
+		createHelmCluster("helm-flux", v1alpha1.GitOpsEngineFlux), io.Discard, nil,
+	).WithLayout(scaffolder.LayoutHelm)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	sourceDir := filepath.Join(tempDir, sourceDirectory)
+	chartDir := filepath.Join(sourceDir, scaffolder.HelmChartsDir, scaffolder.HelmExampleChart)
+
+	for _, name := range []string{
+		"Chart.yaml",
+		"values.yaml",
+		filepath.Join("templates", "_helpers.tpl"),
+		filepath.Join("templates", "deployment.yaml"),
+		filepath.Join("templates", "service.yaml"),
+	} {
+		assert.FileExists(t, filepath.Join(chartDir, name))
+	}
+
+	for _, env := range []string{"dev", "staging", "production"} {
+		values, readErr := os.ReadFile(filepath.Join(chartDir, "values-"+env+".yaml"))
+		require.NoError(t, readErr, env)
+		assert.Contains(t, string(values), "environment: "+env)
+	}
+
+	kustomization, err := os.ReadFile(filepath.Join(sourceDir, "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(kustomization), "- "+scaffolder.HelmReleaseFile)
+
+	release, err := os.ReadFile(filepath.Join(sourceDir, scaffolder.HelmReleaseFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(release), "kind: HelmRelease")
+	assert.Contains(t, string(release), "chart: ./k8s/charts/example")
+	assert.Contains(t, string(release), "kind: GitRepository")
+	assert.Contains(t, string(release), "- ./k8s/charts/example/values-dev.yaml")
+}
+
+func TestScaffoldGeneratesHelmLayoutWithArgoCD(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(
+		createHelmCluster("helm-argocd", v1alpha1.GitOpsEngineArgoCD), io.Discard, nil,
+	).WithLayout(scaffolder.LayoutHelm)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	sourceDir := filepath.Join(tempDir, sourceDirectory)
+
+	_, err = os.Stat(filepath.Join(sourceDir, scaffolder.HelmReleaseFile))
+	require.ErrorIs(t, err, os.ErrNotExist, "Argo CD installs the chart with an Application")
+
+	kustomization, err := os.ReadFile(filepath.Join(sourceDir, "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(kustomization), "- "+scaffolder.HelmApplicationFile)
+
+	application, err := os.ReadFile(filepath.Join(sourceDir, scaffolder.HelmApplicationFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(application), "repoURL: https://github.com/acme/platform.git")
+	assert.Contains(t, string(application), "targetRevision: trunk")
+	assert.Contains(t, string(application), "path: k8s/charts/example")
+	assert.Contains(t, string(application), "- values-dev.yaml")
+}
+
+func TestScaffoldHelmLayoutRequiresGitOpsSource(t *testing.T) {
+	t.Parallel()
+
+	withoutEngine := createHelmCluster("helm-no-engine", v1alpha1.GitOpsEngineNone)
+
+	withoutGit := createHelmCluster("helm-no-git", v1alpha1.GitOpsEngineFlux)
+	withoutGit.Spec.Workload.Git = v1alpha1.GitSource{}
+
+	for name, cluster := range map[string]v1alpha1.Cluster{
+		"no engine": withoutEngine,
+		"no git":    withoutGit,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			instance := scaffolder.NewScaffolder(cluster, io.Discard, nil).
+				WithLayout(scaffolder.LayoutHelm)
+
+			err := instance.Scaffold(tempDir, false)
+			require.ErrorIs(t, err, scaffolder.ErrLayoutConflict)
+
+			_, statErr := os.Stat(filepath.Join(tempDir, "ksail.yaml"))
+			require.ErrorIs(t, statErr, os.ErrNotExist, "nothing is written on conflict")
+		})
+	}
+}
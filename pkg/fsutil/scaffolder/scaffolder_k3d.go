@@ -42,7 +42,13 @@ func (s *Scaffolder) GenerateK3dRegistryConfig() k3dv1alpha5.SimpleConfigRegistr
 		return registryConfig
 	}
 
-	registryConfig.Config = registry.RenderK3dMirrorConfig(hostEndpoints)
+	rendered, err := registry.RenderK3dMirrorConfig("", hostEndpoints)
+	if err != nil {
+		// Only an imported base document can fail to parse; there is none here.
+		return registryConfig
+	}
+
+	registryConfig.Config = rendered
 
 	return registryConfig
 }
@@ -162,8 +168,11 @@ func (s *Scaffolder) buildK3dExtraArgs() []k3dv1alpha5.K3sArgWithNodeFilters {
 
 	// Disable K3s built-in Traefik when using Cilium CNI. Cilium installs Gateway API CRDs
 	// (e.g. backendtlspolicies.gateway.networking.k8s.io) that conflict with Traefik's CRD
-	// ownership, causing helm-install-traefik to enter CrashLoopBackOff.
-	if s.KSailConfig.Spec.Cluster.CNI == v1alpha1.CNICilium {
+	// ownership, causing helm-install-traefik to enter CrashLoopBackOff. It is also disabled
+	// when KSail installs its own ingress controller, which takes over the default
+	// IngressClass and the localhost:80/443 host ports.
+	if s.KSailConfig.Spec.Cluster.CNI == v1alpha1.CNICilium ||
+		s.KSailConfig.Spec.Cluster.IngressController.Enabled() {
 		extraArgs = append(extraArgs,
 			k3dv1alpha5.K3sArgWithNodeFilters{
 				Arg:         "--disable=traefik",
@@ -202,6 +211,16 @@ func (s *Scaffolder) buildK3dExtraArgs() []k3dv1alpha5.K3sArgWithNodeFilters {
 		)
 	}
 
+	// Give pods and services IPv6 (or dual-stack) subnets when requested
+	for _, arg := range k3dconfigmanager.IPFamilyArgs(s.KSailConfig.Spec.Cluster.Network.IPFamily) {
+		extraArgs = append(extraArgs,
+			k3dv1alpha5.K3sArgWithNodeFilters{
+				Arg:         arg,
+				NodeFilters: []string{serverNodeFilter},
+			},
+		)
+	}
+
 	// Configure API server OIDC flags when OIDC is enabled
 	if s.KSailConfig.Spec.Cluster.OIDC.Enabled() {
 		extraArgs = append(extraArgs, buildK3dOIDCArgs(&s.KSailConfig.Spec.Cluster.OIDC)...)
@@ -289,15 +308,17 @@ func (s *Scaffolder) addK3dLocalRegistryConfig(
 	)
 	registryEndpoint := "http://" + registryHost
 
-	// Parse existing config and add local registry endpoint
-	hostEndpoints := k3dconfigmanager.ParseRegistryConfig(registryConfig.Config)
-	if hostEndpoints == nil {
-		hostEndpoints = make(map[string][]string)
+	// Merge the local registry endpoint into the existing config, keeping its
+	// other mirrors.
+	rendered, err := registry.RenderK3dMirrorConfig(
+		registryConfig.Config,
+		map[string][]string{registryHost: {registryEndpoint}},
+	)
+	if err != nil {
+		return registryConfig
 	}
 
-	hostEndpoints[registryHost] = []string{registryEndpoint}
-
-	registryConfig.Config = registry.RenderK3dMirrorConfig(hostEndpoints)
+	registryConfig.Config = rendered
 
 	return registryConfig
 }
@@ -317,7 +338,7 @@ func (s *Scaffolder) generateK3dConfig(output string, force bool) error {
 	return generateWithFileHandling(
 		s,
 		GenerationParams[*k3dv1alpha5.SimpleConfig]{
-			Gen:         s.K3dGenerator,
+			Gen:         withSchemaHeader(s, s.K3dGenerator, K3dSchemaHeader),
 			Model:       &k3dConfig,
 			Opts:        opts,
 			DisplayName: "k3d.yaml",
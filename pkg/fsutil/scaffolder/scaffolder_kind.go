@@ -33,7 +33,7 @@ func (s *Scaffolder) generateKindConfig(output string, force bool) error {
 	return generateWithFileHandling(
 		s,
 		GenerationParams[*v1alpha4.Cluster]{
-			Gen:         s.KindGenerator,
+			Gen:         withSchemaHeader(s, s.KindGenerator, KindSchemaHeader),
 			Model:       kindConfig,
 			Opts:        opts,
 			DisplayName: "kind.yaml",
@@ -62,12 +62,19 @@ func (s *Scaffolder) buildKindConfig(output string) (*v1alpha4.Cluster, error) {
 		Name: clusterName,
 	}
 
+	// Apply node counts from CLI flags (stored in Talos options) first, so the
+	// node patches below are added to the nodes that are generated.
+	s.applyKindNodeCounts(kindConfig)
+
 	// Disable default CNI if using a non-default CNI (Cilium or Calico)
 	if s.KSailConfig.Spec.Cluster.CNI == v1alpha1.CNICilium ||
 		s.KSailConfig.Spec.Cluster.CNI == v1alpha1.CNICalico {
 		kindConfig.Networking.DisableDefaultCNI = true
 	}
 
+	// Set the pod and service IP family (Kind picks matching default subnets).
+	kindconfigmanager.ApplyIPFamily(kindConfig, s.KSailConfig.Spec.Cluster.Network.IPFamily)
+
 	// Enable kubelet certificate rotation when metrics-server is explicitly enabled.
 	// This is required for secure TLS communication between metrics-server and kubelets.
 	if s.KSailConfig.Spec.Cluster.MetricsServer == v1alpha1.MetricsServerEnabled {
@@ -93,9 +100,6 @@ func (s *Scaffolder) buildKindConfig(output string) (*v1alpha4.Cluster, error) {
 		}
 	}
 
-	// Apply node counts from CLI flags (stored in Talos options)
-	s.applyKindNodeCounts(kindConfig)
-
 	s.addMirrorMountsToKindConfig(kindConfig, output)
 
 	return kindConfig, nil
@@ -0,0 +1,94 @@
+package scaffolder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// Layout selects how the scaffolded GitOps source directory is organized.
+type Layout string
+
+const (
+	// LayoutFlat scaffolds a single kustomization.yaml at the root of the source
+	// directory (or at spec.workload.kustomizationFile).
+	LayoutFlat Layout = "flat"
+	// LayoutOverlays scaffolds a kustomize base/ plus one overlays/<env>/ per
+	// environment that patches it.
+	LayoutOverlays Layout = "overlays"
+	// LayoutHelm scaffolds an example Helm chart with one values file per
+	// environment under charts/, installed by a Flux HelmRelease or an Argo CD
+	// Application listed in the workload kustomization.
+	LayoutHelm Layout = "helm"
+	// LayoutMonorepo scaffolds apps/<name>/ with per-app namespaces and RBAC,
+	// infrastructure/ for cluster-wide components, and clusters/<cluster>/
+	// combining both, after the flux2-multi-tenancy repository structure.
+	LayoutMonorepo Layout = "monorepo"
+)
+
+// ParseLayout parses the value of init's --layout flag. An empty value selects
+// LayoutFlat.
+func ParseLayout(value string) (Layout, error) {
+	switch layout := Layout(strings.ToLower(strings.TrimSpace(value))); layout {
+	case "":
+		return LayoutFlat, nil
+	case LayoutFlat, LayoutOverlays, LayoutHelm, LayoutMonorepo:
+		return layout, nil
+	default:
+		return LayoutFlat, fmt.Errorf("%w: %q (valid: %s, %s, %s, %s)",
+			ErrUnknownLayout, value, LayoutFlat, LayoutOverlays, LayoutHelm, LayoutMonorepo)
+	}
+}
+
+// validateLayout rejects layouts that cannot be combined with the other
+// scaffolding options, so Scaffold fails before any file is written.
+func (s *Scaffolder) validateLayout() error {
+	if s.Layout != LayoutFlat && s.MultiClusterEnv != "" {
+		return fmt.Errorf(
+			"%w: the %s layout organizes one cluster's environments, "+
+				"while --multi-cluster scaffolds one cluster per environment",
+			ErrLayoutConflict, s.Layout,
+		)
+	}
+
+	switch s.Layout {
+	case LayoutHelm:
+		return s.validateHelmLayout()
+	case LayoutMonorepo:
+		if s.scaffoldsArgoCDApps() {
+			return fmt.Errorf(
+				"%w: the %s layout owns the %s/ directory the Argo CD "+
+					"ApplicationSet directory generator discovers apps in",
+				ErrLayoutConflict, s.Layout, v1alpha1.ArgoCDAppsDirectory,
+			)
+		}
+	case LayoutFlat, LayoutOverlays:
+	}
+
+	return nil
+}
+
+// validateHelmLayout checks that a GitOps engine can install the scaffolded
+// chart: Flux builds it from a GitRepository and Argo CD from a Git repoURL, so
+// both need the Git source --push-to records in spec.workload.git.
+func (s *Scaffolder) validateHelmLayout() error {
+	engine := s.KSailConfig.Spec.Cluster.GitOpsEngine
+	if engine != v1alpha1.GitOpsEngineFlux && engine != v1alpha1.GitOpsEngineArgoCD {
+		return fmt.Errorf(
+			"%w: the %s layout installs its chart with a GitOps engine, "+
+				"select one with --gitops-engine Flux or ArgoCD",
+			ErrLayoutConflict, s.Layout,
+		)
+	}
+
+	if !s.KSailConfig.Spec.Workload.Git.IsEnabled() {
+		return fmt.Errorf(
+			"%w: the %s layout requires spec.workload.git, since %s builds charts "+
+				"from a Git repository rather than an OCI artifact; add --push-to",
+			ErrLayoutConflict, s.Layout, engine,
+		)
+	}
+
+	return nil
+}
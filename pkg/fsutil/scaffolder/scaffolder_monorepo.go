@@ -0,0 +1,258 @@
+package scaffolder
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+)
+
+const (
+	// MonorepoAppsDir is the directory below the source directory that holds one
+	// directory per app (and tenant) in the monorepo layout.
+	MonorepoAppsDir = "apps"
+	// MonorepoInfrastructureDir is the directory below the source directory that
+	// holds the cluster-wide components of the monorepo layout.
+	MonorepoInfrastructureDir = "infrastructure"
+	// MonorepoExampleApp is the app the monorepo layout scaffolds. It is also the
+	// name of the app's namespace and ServiceAccount.
+	MonorepoExampleApp = "example"
+	// MonorepoWorkloadDir is the directory of an app holding its manifests, which
+	// are deployed into the app's namespace.
+	MonorepoWorkloadDir = "workload"
+	// MonorepoSyncFile is the filename of the Flux Kustomization that reconciles
+	// an app's manifests as the app's ServiceAccount.
+	MonorepoSyncFile = "sync.yaml"
+	// DefaultMonorepoCluster names the cluster directory when no cluster name is set.
+	DefaultMonorepoCluster = "local"
+)
+
+// monorepoExampleFile is the filename of the example manifest of the scaffolded app.
+const monorepoExampleFile = "example-config.yaml"
+
+// monorepoExampleConfig is the example manifest of the scaffolded app.
+const monorepoExampleConfig = `# Example ConfigMap scaffolded by --layout monorepo. Replace it with the app's
+# manifests; the kustomization next to it deploys them into the app's namespace.
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: example-config
+data:
+  LOG_LEVEL: info
+`
+
+// monorepoSyncTemplate reconciles an app's manifests as the app's ServiceAccount,
+// so they cannot reach beyond what its RoleBindings allow. The placeholders are
+// the app name, the kind of the source Flux syncs and the path of the manifests
+// within that source.
+const monorepoSyncTemplate = `# Reconciles %[1]s's manifests impersonating its ServiceAccount, so they
+# can only change what the app's RoleBindings allow.
+apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: %[1]s
+  namespace: %[1]s
+spec:
+  interval: 5m
+  serviceAccountName: %[1]s
+  sourceRef:
+    kind: %[2]s
+    name: flux-system
+    namespace: flux-system
+  path: ./%[3]s
+  prune: true
+`
+
+// monorepoCluster returns the name of the cluster directory: the cluster name
+// override when set, otherwise DefaultMonorepoCluster.
+func (s *Scaffolder) monorepoCluster() string {
+	if s.ClusterName != "" {
+		return s.ClusterName
+	}
+
+	return DefaultMonorepoCluster
+}
+
+// defaultMonorepoKustomizationFile returns the kustomizationFile the generated
+// ksail.yaml syncs in the monorepo layout: the cluster's directory.
+func (s *Scaffolder) defaultMonorepoKustomizationFile() string {
+	return path.Join(environment.ClustersDir, s.monorepoCluster())
+}
+
+// generateMonorepoLayout writes the monorepo layout into the source directory:
+// infrastructure/ holds the kustomization the flat layout would write,
+// apps/<name>/ holds an app with its own namespace, ServiceAccount and
+// RoleBinding, and clusters/<cluster>/ combines both for the cluster.
+func (s *Scaffolder) generateMonorepoLayout(output string, force bool) error {
+	sourceDir := s.KSailConfig.Spec.Workload.SourceDirectory
+
+	err := s.generateKustomizationIn(
+		output, filepath.Join(sourceDir, MonorepoInfrastructureDir), force,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = s.generateMonorepoApp(output, MonorepoExampleApp, force)
+	if err != nil {
+		return err
+	}
+
+	err = s.generateMonorepoKustomization(
+		output,
+		filepath.Join(sourceDir, MonorepoAppsDir),
+		&ktypes.Kustomization{Resources: []string{MonorepoExampleApp}},
+		force,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The cluster sits at clusters/<cluster>/, two levels below the source directory.
+	return s.generateMonorepoKustomization(
+		output,
+		filepath.Join(sourceDir, environment.ClustersDir, s.monorepoCluster()),
+		&ktypes.Kustomization{Resources: []string{
+			path.Join("..", "..", MonorepoInfrastructureDir),
+			path.Join("..", "..", MonorepoAppsDir),
+		}},
+		force,
+	)
+}
+
+// generateMonorepoApp writes apps/<name>/: the tenant RBAC of the app, its
+// manifests under workload/, and the kustomization listing them. With Flux the
+// manifests are reconciled by a Kustomization impersonating the app's
+// ServiceAccount instead of being listed directly.
+func (s *Scaffolder) generateMonorepoApp(output, name string, force bool) error {
+	appDir := filepath.Join(s.KSailConfig.Spec.Workload.SourceDirectory, MonorepoAppsDir, name)
+
+	rbacFiles, err := tenant.GenerateRBACManifests(tenant.Options{
+		Name:        name,
+		Namespaces:  []string{name},
+		ClusterRole: tenant.DefaultClusterRole,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrMonorepoGeneration, err)
+	}
+
+	resources := make([]string, 0, len(rbacFiles)+1)
+
+	for _, file := range slices.Sorted(maps.Keys(rbacFiles)) {
+		err = s.generateMonorepoFile(output, filepath.Join(appDir, file), rbacFiles[file], force)
+		if err != nil {
+			return err
+		}
+
+		resources = append(resources, file)
+	}
+
+	workloadDir := filepath.Join(appDir, MonorepoWorkloadDir)
+
+	err = s.generateMonorepoFile(
+		output, filepath.Join(workloadDir, monorepoExampleFile), monorepoExampleConfig, force,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = s.generateMonorepoKustomization(
+		output,
+		workloadDir,
+		&ktypes.Kustomization{Namespace: name, Resources: []string{monorepoExampleFile}},
+		force,
+	)
+	if err != nil {
+		return err
+	}
+
+	if s.KSailConfig.Spec.Cluster.GitOpsEngine == v1alpha1.GitOpsEngineFlux {
+		err = s.generateMonorepoFile(
+			output, filepath.Join(appDir, MonorepoSyncFile), s.monorepoSync(name), force,
+		)
+		if err != nil {
+			return err
+		}
+
+		resources = append(resources, MonorepoSyncFile)
+	} else {
+		resources = append(resources, MonorepoWorkloadDir)
+	}
+
+	return s.generateMonorepoKustomization(
+		output, appDir, &ktypes.Kustomization{Resources: resources}, force,
+	)
+}
+
+// monorepoSync renders the Flux Kustomization of the app name. It syncs from the
+// source Flux itself syncs: the OCI artifact, whose root is the source
+// directory, or the Git repository, whose root is the repository's.
+func (s *Scaffolder) monorepoSync(name string) string {
+	sourceKind := "OCIRepository"
+	manifests := path.Join(MonorepoAppsDir, name, MonorepoWorkloadDir)
+
+	if s.KSailConfig.Spec.Workload.Git.IsEnabled() {
+		sourceDir := s.KSailConfig.Spec.Workload.SourceDirectory
+		if sourceDir == "" {
+			sourceDir = v1alpha1.DefaultSourceDirectory
+		}
+
+		sourceKind = "GitRepository"
+		manifests = path.Join(filepath.ToSlash(sourceDir), manifests)
+	}
+
+	return fmt.Sprintf(monorepoSyncTemplate, name, sourceKind, manifests)
+}
+
+// generateMonorepoFile writes content to displayName below output.
+func (s *Scaffolder) generateMonorepoFile(output, displayName, content string, force bool) error {
+	return generateWithFileHandling(
+		s,
+		GenerationParams[string]{
+			Gen:   &overlayFileGenerator{},
+			Model: content,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrMonorepoGeneration, err)
+			},
+		},
+	)
+}
+
+// generateMonorepoKustomization writes kustomization as dir's kustomization.yaml.
+func (s *Scaffolder) generateMonorepoKustomization(
+	output, dir string,
+	kustomization *ktypes.Kustomization,
+	force bool,
+) error {
+	displayName := filepath.Join(dir, "kustomization.yaml")
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[*ktypes.Kustomization]{
+			Gen:   s.KustomizationGenerator,
+			Model: kustomization,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrMonorepoGeneration, err)
+			},
+		},
+	)
+}
@@ -0,0 +1,157 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldGeneratesMonorepoLayout(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("monorepo"), io.Discard, nil).
+		WithLayout(scaffolder.LayoutMonorepo)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	sourceDir := filepath.Join(tempDir, sourceDirectory)
+
+	_, err = os.Stat(filepath.Join(sourceDir, "kustomization.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist, "the flat kustomization moves to infrastructure/")
+	assert.FileExists(t, filepath.Join(sourceDir, "infrastructure", "kustomization.yaml"))
+
+	cluster, err := os.ReadFile(filepath.Join(sourceDir, "clusters", "local", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(cluster), "- ../../infrastructure")
+	assert.Contains(t, string(cluster), "- ../../apps")
+
+	apps, err := os.ReadFile(filepath.Join(sourceDir, "apps", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(apps), "- "+scaffolder.MonorepoExampleApp)
+
+	appDir := filepath.Join(sourceDir, "apps", scaffolder.MonorepoExampleApp)
+
+	app, err := os.ReadFile(filepath.Join(appDir, "kustomization.yaml"))
+	require.NoError(t, err)
+
+	for _, resource := range []string{
+		"namespace.yaml", "serviceaccount.yaml", "rolebinding.yaml", scaffolder.MonorepoWorkloadDir,
+	} {
+		assert.Contains(t, string(app), "- "+resource)
+	}
+
+	assert.NoFileExists(t, filepath.Join(appDir, scaffolder.MonorepoSyncFile),
+		"without Flux the app's manifests are listed directly")
+
+	rolebinding, err := os.ReadFile(filepath.Join(appDir, "rolebinding.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(rolebinding), "namespace: example")
+	assert.Contains(t, string(rolebinding), "name: edit")
+
+	workload, err := os.ReadFile(filepath.Join(appDir, "workload", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(workload), "namespace: example")
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "ksail.yaml"))
+	require.NoError(t, err)
+
+	var cfg v1alpha1.Cluster
+	require.NoError(t, yaml.Unmarshal(content, &cfg))
+	assert.Equal(t, "clusters/local", cfg.Spec.Workload.KustomizationFile,
+		"ksail.yaml syncs the cluster's directory")
+}
+
+func TestScaffoldMonorepoLayoutImpersonatesAppsWithFlux(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		git      v1alpha1.GitSource
+		wantKind string
+		wantPath string
+	}{
+		"oci": {
+			wantKind: "kind: OCIRepository",
+			wantPath: "path: ./apps/example/workload",
+		},
+		"git": {
+			git:      v1alpha1.GitSource{URL: "https://github.com/acme/platform.git"},
+			wantKind: "kind: GitRepository",
+			wantPath: "path: ./k8s/apps/example/workload",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := createKindCluster("monorepo-flux")
+			cluster.Spec.Cluster.GitOpsEngine = v1alpha1.GitOpsEngineFlux
+			cluster.Spec.Workload.Git = test.git
+
+			tempDir := t.TempDir()
+			instance := scaffolder.NewScaffolder(cluster, io.Discard, nil).
+				WithLayout(scaffolder.LayoutMonorepo).
+				WithClusterName("prod")
+
+			err := instance.Scaffold(tempDir, false)
+			require.NoError(t, err)
+
+			sourceDir := filepath.Join(tempDir, sourceDirectory)
+			assert.FileExists(t, filepath.Join(sourceDir, "clusters", "prod", "kustomization.yaml"))
+
+			appDir := filepath.Join(sourceDir, "apps", scaffolder.MonorepoExampleApp)
+
+			app, err := os.ReadFile(filepath.Join(appDir, "kustomization.yaml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(app), "- "+scaffolder.MonorepoSyncFile)
+			assert.NotContains(t, string(app), "- "+scaffolder.MonorepoWorkloadDir)
+
+			sync, err := os.ReadFile(filepath.Join(appDir, scaffolder.MonorepoSyncFile))
+			require.NoError(t, err)
+			assert.Contains(t, string(sync), "serviceAccountName: example")
+			assert.Contains(t, string(sync), test.wantKind)
+			assert.Contains(t, string(sync), test.wantPath)
+		})
+	}
+}
+
+func TestScaffoldMonorepoLayoutMakefileAppliesCluster(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("monorepo-make"), io.Discard, nil).
+		WithLayout(scaffolder.LayoutMonorepo).
+		WithTaskRunner(scaffolder.TaskRunnerMakefile)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, scaffolder.MakefileFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "ksail workload apply -k k8s/clusters/local")
+}
+
+func TestScaffoldMonorepoLayoutConflictsWithArgoCDApplicationSet(t *testing.T) {
+	t.Parallel()
+
+	cluster := createKindCluster("monorepo-appset")
+	cluster.Spec.Cluster.GitOpsEngine = v1alpha1.GitOpsEngineArgoCD
+	cluster.Spec.Workload.ArgoCD.Layout = v1alpha1.ArgoCDLayoutApplicationSet
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(cluster, io.Discard, nil).
+		WithLayout(scaffolder.LayoutMonorepo)
+
+	err := instance.Scaffold(tempDir, false)
+	require.ErrorIs(t, err, scaffolder.ErrLayoutConflict)
+
+	_, statErr := os.Stat(filepath.Join(tempDir, "ksail.yaml"))
+	require.ErrorIs(t, statErr, os.ErrNotExist, "nothing is written on conflict")
+}
@@ -0,0 +1,180 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+)
+
+const (
+	// OverlayExampleFile is the filename of the example ConfigMap scaffolded into
+	// the base of the overlays layout.
+	OverlayExampleFile = "example-config.yaml"
+	// OverlayPatchFile is the filename of the example patch scaffolded into each
+	// environment overlay of the overlays layout.
+	OverlayPatchFile = "example-config-patch.yaml"
+)
+
+// overlayExampleConfig is the example ConfigMap in base/ that every overlay patches.
+const overlayExampleConfig = `# Example ConfigMap scaffolded by --layout overlays. Each overlay in
+# overlays/<env>/ patches it with environment-specific values - replace it with
+# your own workload and patches.
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: example-config
+data:
+  ENVIRONMENT: base
+  LOG_LEVEL: info
+`
+
+// overlayPatchTemplate is the strategic merge patch an overlay applies to the
+// example ConfigMap. The placeholders are the environment name and its log level.
+const overlayPatchTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: example-config
+data:
+  ENVIRONMENT: %s
+  LOG_LEVEL: %s
+`
+
+// overlayLogLevels gives each default environment a distinct example value, so the
+// patches show what differs between environments. Other names get "info".
+//
+//nolint:gochecknoglobals // immutable lookup table
+var overlayLogLevels = map[string]string{
+	"dev":        "debug",
+	"staging":    "info",
+	"production": "warn",
+}
+
+// overlayFileGenerator writes a fixed file content. It satisfies the
+// generator.Generator contract used by generateWithFileHandling.
+type overlayFileGenerator struct{}
+
+// Generate writes content to opts.Output (or returns it when no output path is
+// set), mirroring gatewayExampleGenerator's write semantics.
+func (g *overlayFileGenerator) Generate(content string, opts yamlgenerator.Options) (string, error) {
+	if opts.Output == "" {
+		return content, nil
+	}
+
+	result, err := fsutil.TryWriteFile(content, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", filepath.Base(opts.Output), err)
+	}
+
+	return result, nil
+}
+
+// defaultOverlayKustomizationFile returns the kustomizationFile the generated
+// ksail.yaml syncs in the overlays layout: the first environment's overlay.
+func defaultOverlayKustomizationFile() string {
+	return path.Join(environment.OverlaysDir, environment.DefaultOverlayEnvironments()[0])
+}
+
+// generateOverlaysLayout writes the overlays layout into the source directory:
+// base/ holds the kustomization the flat layout would write plus an example
+// ConfigMap, and overlays/<env>/ references the base and patches the ConfigMap.
+func (s *Scaffolder) generateOverlaysLayout(output string, force bool) error {
+	sourceDir := s.KSailConfig.Spec.Workload.SourceDirectory
+	baseDir := filepath.Join(sourceDir, environment.BaseEnvName)
+
+	err := s.generateOverlayFile(
+		output,
+		filepath.Join(baseDir, OverlayExampleFile),
+		overlayExampleConfig,
+		force,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = s.generateKustomizationIn(output, baseDir, force, OverlayExampleFile)
+	if err != nil {
+		return err
+	}
+
+	// The overlay sits at overlays/<env>/, two levels below the source directory.
+	baseRef := path.Join("..", "..", environment.BaseEnvName)
+
+	for _, envName := range environment.DefaultOverlayEnvironments() {
+		overlayDir := filepath.Join(sourceDir, environment.OverlaysDir, envName)
+
+		logLevel, ok := overlayLogLevels[envName]
+		if !ok {
+			logLevel = "info"
+		}
+
+		err = s.generateOverlayFile(
+			output,
+			filepath.Join(overlayDir, OverlayPatchFile),
+			fmt.Sprintf(overlayPatchTemplate, envName, logLevel),
+			force,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = s.generateOverlayKustomization(output, overlayDir, baseRef, force)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateOverlayFile writes content to displayName below output.
+func (s *Scaffolder) generateOverlayFile(output, displayName, content string, force bool) error {
+	return generateWithFileHandling(
+		s,
+		GenerationParams[string]{
+			Gen:   &overlayFileGenerator{},
+			Model: content,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrOverlayGeneration, err)
+			},
+		},
+	)
+}
+
+// generateOverlayKustomization writes the kustomization.yaml of one environment overlay.
+func (s *Scaffolder) generateOverlayKustomization(
+	output, overlayDir, baseRef string,
+	force bool,
+) error {
+	displayName := filepath.Join(overlayDir, "kustomization.yaml")
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[*ktypes.Kustomization]{
+			Gen: s.KustomizationGenerator,
+			Model: &ktypes.Kustomization{
+				Resources: []string{baseRef},
+				Patches:   []ktypes.Patch{{Path: OverlayPatchFile}},
+			},
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrOverlayGeneration, err)
+			},
+		},
+	)
+}
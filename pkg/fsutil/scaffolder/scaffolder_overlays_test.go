@@ -0,0 +1,121 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    scaffolder.Layout
+		wantErr bool
+	}{
+		{value: "", want: scaffolder.LayoutFlat},
+		{value: "flat", want: scaffolder.LayoutFlat},
+		{value: "Overlays", want: scaffolder.LayoutOverlays},
+		{value: "helm", want: scaffolder.LayoutHelm},
+		{value: " monorepo ", want: scaffolder.LayoutMonorepo},
+		{value: "nested", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := scaffolder.ParseLayout(test.value)
+			if test.wantErr {
+				require.ErrorIs(t, err, scaffolder.ErrUnknownLayout)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestScaffoldGeneratesOverlaysLayout(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("overlays"), io.Discard, nil).
+		WithLayout(scaffolder.LayoutOverlays)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	sourceDir := filepath.Join(tempDir, sourceDirectory)
+
+	_, err = os.Stat(filepath.Join(sourceDir, "kustomization.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist, "the flat kustomization moves to base/")
+
+	base, err := os.ReadFile(filepath.Join(sourceDir, "base", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(base), "- "+scaffolder.OverlayExampleFile)
+
+	for _, env := range []string{"dev", "staging", "production"} {
+		overlay, readErr := os.ReadFile(
+			filepath.Join(sourceDir, "overlays", env, "kustomization.yaml"),
+		)
+		require.NoError(t, readErr, env)
+		assert.Contains(t, string(overlay), "- ../../base")
+		assert.Contains(t, string(overlay), "path: "+scaffolder.OverlayPatchFile)
+
+		patch, readErr := os.ReadFile(
+			filepath.Join(sourceDir, "overlays", env, scaffolder.OverlayPatchFile),
+		)
+		require.NoError(t, readErr, env)
+		assert.Contains(t, string(patch), "ENVIRONMENT: "+env)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "ksail.yaml"))
+	require.NoError(t, err)
+
+	var cfg v1alpha1.Cluster
+	require.NoError(t, yaml.Unmarshal(content, &cfg))
+	assert.Equal(t, "overlays/dev", cfg.Spec.Workload.KustomizationFile,
+		"ksail.yaml syncs the first environment overlay")
+}
+
+func TestScaffoldOverlaysLayoutMakefileAppliesEnv(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("overlays-make"), io.Discard, nil).
+		WithLayout(scaffolder.LayoutOverlays).
+		WithTaskRunner(scaffolder.TaskRunnerMakefile)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, scaffolder.MakefileFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "ksail workload apply --env dev")
+}
+
+func TestScaffoldOverlaysLayoutConflictsWithMultiCluster(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("overlays-mc"), io.Discard, nil).
+		WithLayout(scaffolder.LayoutOverlays).
+		WithMultiClusterEnv("dev")
+
+	err := instance.Scaffold(tempDir, false)
+	require.ErrorIs(t, err, scaffolder.ErrLayoutConflict)
+
+	_, statErr := os.Stat(filepath.Join(tempDir, "ksail.yaml"))
+	require.ErrorIs(t, statErr, os.ErrNotExist, "nothing is written on conflict")
+}
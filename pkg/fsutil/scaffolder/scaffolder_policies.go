@@ -0,0 +1,326 @@
+package scaffolder
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+)
+
+// PoliciesDir is the directory, next to the workload kustomization.yaml, that
+// holds the scaffolded Kyverno baseline Pod Security policies.
+const PoliciesDir = "policies"
+
+// baselinePolicyHeader prefixes every scaffolded policy file.
+const baselinePolicyHeader = `# Kyverno policy scaffolded for spec.cluster.policyEngine: Kyverno, enforcing part
+# of the Pod Security Standards baseline profile. Rules use validate.cel so that
+# "ksail workload validate --policies policies" evaluates them before apply.
+# Switch failureAction to Enforce to block violating resources at admission.
+`
+
+// baselinePolicies maps each scaffolded policy file name to its ClusterPolicy.
+// Every rule matches Pods; Kyverno autogen (and validate --policies) apply it
+// to the pod templates of Deployments, StatefulSets, DaemonSets, Jobs, and
+// CronJobs as well.
+var baselinePolicies = []struct {
+	file    string
+	content string
+}{
+	{
+		file: "disallow-privileged-containers.yaml",
+		content: `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-privileged-containers
+  annotations:
+    policies.kyverno.io/title: Disallow Privileged Containers
+    policies.kyverno.io/category: Pod Security Standards (Baseline)
+    policies.kyverno.io/severity: medium
+spec:
+  background: true
+  rules:
+    - name: privileged-containers
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      validate:
+        failureAction: Audit
+        cel:
+          expressions:
+            - expression: >-
+                object.spec.containers.all(container,
+                !has(container.securityContext) ||
+                !has(container.securityContext.privileged) ||
+                container.securityContext.privileged == false)
+              message: Privileged mode is disallowed.
+            - expression: >-
+                !has(object.spec.initContainers) ||
+                object.spec.initContainers.all(container,
+                !has(container.securityContext) ||
+                !has(container.securityContext.privileged) ||
+                container.securityContext.privileged == false)
+              message: Privileged mode is disallowed for init containers.
+`,
+	},
+	{
+		file: "disallow-host-namespaces.yaml",
+		content: `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-host-namespaces
+  annotations:
+    policies.kyverno.io/title: Disallow Host Namespaces
+    policies.kyverno.io/category: Pod Security Standards (Baseline)
+    policies.kyverno.io/severity: medium
+spec:
+  background: true
+  rules:
+    - name: host-namespaces
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      validate:
+        failureAction: Audit
+        cel:
+          expressions:
+            - expression: >-
+                (!has(object.spec.hostNetwork) || object.spec.hostNetwork == false) &&
+                (!has(object.spec.hostIPC) || object.spec.hostIPC == false) &&
+                (!has(object.spec.hostPID) || object.spec.hostPID == false)
+              message: Sharing the host network, IPC, or PID namespaces is disallowed.
+`,
+	},
+	{
+		file: "disallow-host-path.yaml",
+		content: `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-host-path
+  annotations:
+    policies.kyverno.io/title: Disallow hostPath
+    policies.kyverno.io/category: Pod Security Standards (Baseline)
+    policies.kyverno.io/severity: medium
+spec:
+  background: true
+  rules:
+    - name: host-path
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      validate:
+        failureAction: Audit
+        cel:
+          expressions:
+            - expression: >-
+                !has(object.spec.volumes) ||
+                object.spec.volumes.all(volume, !has(volume.hostPath))
+              message: HostPath volumes are forbidden.
+`,
+	},
+	{
+		file: "disallow-host-ports.yaml",
+		content: `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-host-ports
+  annotations:
+    policies.kyverno.io/title: Disallow hostPorts
+    policies.kyverno.io/category: Pod Security Standards (Baseline)
+    policies.kyverno.io/severity: medium
+spec:
+  background: true
+  rules:
+    - name: host-ports
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      validate:
+        failureAction: Audit
+        cel:
+          expressions:
+            - expression: >-
+                object.spec.containers.all(container, !has(container.ports) ||
+                container.ports.all(port, !has(port.hostPort) || port.hostPort == 0))
+              message: Use of host ports is disallowed.
+            - expression: >-
+                !has(object.spec.initContainers) ||
+                object.spec.initContainers.all(container, !has(container.ports) ||
+                container.ports.all(port, !has(port.hostPort) || port.hostPort == 0))
+              message: Use of host ports is disallowed for init containers.
+`,
+	},
+}
+
+// vulnerabilityPolicyFile is the scaffolded policy that blocks vulnerable images.
+const vulnerabilityPolicyFile = "block-vulnerable-images.yaml"
+
+// vulnerabilityAttestationType is the in-toto predicate type of a cosign
+// vulnerability scan attestation ("cosign attest --type vuln").
+const vulnerabilityAttestationType = "https://cosign.sigstore.dev/attestation/vuln/v1"
+
+// vulnerabilityPolicyTemplate renders the vulnerability admission policy. Its
+// condition counts the findings of a Trivy scan result at the blocked
+// severities whose ID is not allowlisted; %[1]s is the image references, %[2]s
+// the blocked Trivy severities and %[3]s the allowlist, each as a JSON array.
+const vulnerabilityPolicyTemplate = `# Kyverno policy scaffolded for spec.cluster.vulnerabilityPolicy. It admits an
+# image only when it carries a cosign vulnerability attestation
+# (cosign attest --type vuln) of a Trivy scan without findings at the blocked
+# severities, apart from the allowlisted IDs. Regenerate it with
+# "ksail project init --force" after changing the severity or allowlist, and add
+# attestors to verify who signed the attestation.
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: block-vulnerable-images
+  annotations:
+    policies.kyverno.io/title: Block Vulnerable Images
+    policies.kyverno.io/category: Supply Chain Security
+    policies.kyverno.io/severity: high
+spec:
+  background: false
+  webhookTimeoutSeconds: 30
+  rules:
+    - name: vulnerability-scan
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      verifyImages:
+        - imageReferences: %[1]s
+          failureAction: Enforce
+          mutateDigest: false
+          verifyDigest: false
+          attestations:
+            - type: ` + vulnerabilityAttestationType + `
+              conditions:
+                - all:
+                    - key: >-
+                        {{ length((scanner.result.Results[].Vulnerabilities[] || ` + "`[]`" + `)[?contains(` + "`%[2]s`" + `, Severity) && !contains(` + "`%[3]s`" + `, VulnerabilityID)]) }}
+                      operator: Equals
+                      value: 0
+                      message: The image has vulnerabilities at a blocked severity that are not allowlisted.
+`
+
+// renderVulnerabilityPolicy renders the vulnerability admission policy for policy.
+func renderVulnerabilityPolicy(policy v1alpha1.VulnerabilityPolicy) string {
+	blocked := make([]string, 0, len(policy.Severity.Blocked()))
+	for _, severity := range policy.Severity.Blocked() {
+		// Trivy reports severities in upper case.
+		blocked = append(blocked, strings.ToUpper(string(severity)))
+	}
+
+	return fmt.Sprintf(
+		vulnerabilityPolicyTemplate,
+		jsonList(policy.EffectiveImages()), jsonList(blocked), jsonList(policy.Allowlist),
+	)
+}
+
+// jsonList encodes values as a JSON array, which is valid both as a YAML flow
+// sequence and as a JMESPath literal.
+func jsonList(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		// A string slice always encodes.
+		return "[]"
+	}
+
+	return string(encoded)
+}
+
+// policyFileGenerator writes a static policy file. It satisfies the
+// generator.Generator contract used by generateWithFileHandling.
+type policyFileGenerator struct{}
+
+// Generate writes content to opts.Output (or returns it when no output path is
+// set), mirroring gatewayExampleGenerator's write semantics.
+func (g *policyFileGenerator) Generate(content string, opts yamlgenerator.Options) (string, error) {
+	if opts.Output == "" {
+		return content, nil
+	}
+
+	result, err := fsutil.TryWriteFile(content, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", filepath.Base(opts.Output), err)
+	}
+
+	return result, nil
+}
+
+// scaffoldsPolicies reports whether the baseline policies should be scaffolded.
+func (s *Scaffolder) scaffoldsPolicies() bool {
+	return s.KSailConfig.Spec.Cluster.PolicyEngine == v1alpha1.PolicyEngineKyverno
+}
+
+// generateBaselinePolicies writes the baseline Kyverno policies, the
+// vulnerability admission policy when spec.cluster.vulnerabilityPolicy is
+// enabled, plus their own kustomization.yaml into <kustomizationDir>/policies.
+func (s *Scaffolder) generateBaselinePolicies(output, kustomizationDir string, force bool) error {
+	policiesDir := filepath.Join(kustomizationDir, PoliciesDir)
+	kustomization := "apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\nresources:\n"
+
+	for _, policy := range baselinePolicies {
+		err := s.generatePolicyFile(
+			output, filepath.Join(policiesDir, policy.file), baselinePolicyHeader+policy.content, force,
+		)
+		if err != nil {
+			return err
+		}
+
+		kustomization += "  - " + policy.file + "\n"
+	}
+
+	if vulnerabilityPolicy := s.KSailConfig.Spec.Cluster.VulnerabilityPolicy; vulnerabilityPolicy.Enabled() {
+		err := s.generatePolicyFile(
+			output,
+			filepath.Join(policiesDir, vulnerabilityPolicyFile),
+			renderVulnerabilityPolicy(vulnerabilityPolicy),
+			force,
+		)
+		if err != nil {
+			return err
+		}
+
+		kustomization += "  - " + vulnerabilityPolicyFile + "\n"
+	}
+
+	return s.generatePolicyFile(
+		output, filepath.Join(policiesDir, "kustomization.yaml"), kustomization, force,
+	)
+}
+
+// generatePolicyFile writes one file below the policies directory.
+func (s *Scaffolder) generatePolicyFile(output, displayName, content string, force bool) error {
+	return generateWithFileHandling(
+		s,
+		GenerationParams[string]{
+			Gen:   &policyFileGenerator{},
+			Model: content,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrPolicyGeneration, err)
+			},
+		},
+	)
+}
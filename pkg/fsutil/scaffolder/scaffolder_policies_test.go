@@ -0,0 +1,140 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/gitops/celrules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldGeneratesBaselinePolicies(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cluster := createKindCluster("policies")
+	cluster.Spec.Cluster.PolicyEngine = v1alpha1.PolicyEngineKyverno
+	sourceDir := filepath.Join(tempDir, cluster.Spec.Workload.SourceDirectory)
+
+	instance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+	require.NoError(t, instance.Scaffold(tempDir, false))
+
+	kustomization, err := os.ReadFile(filepath.Join(sourceDir, "kustomization.yaml"))
+	require.NoError(t, err)
+
+	var parsed struct {
+		Resources []string `json:"resources"`
+	}
+	require.NoError(t, yaml.Unmarshal(kustomization, &parsed))
+	assert.Equal(t, []string{scaffolder.PoliciesDir}, parsed.Resources)
+
+	policiesDir := filepath.Join(sourceDir, scaffolder.PoliciesDir)
+	assert.FileExists(t, filepath.Join(policiesDir, "kustomization.yaml"))
+
+	rules, skipped, err := celrules.LoadKyvernoPolicies(policiesDir)
+	require.NoError(t, err)
+	assert.Empty(t, skipped, "every baseline rule must be evaluable offline")
+
+	engine, err := celrules.NewEngine(rules)
+	require.NoError(t, err)
+
+	privileged := map[string]any{
+		"kind": "Deployment",
+		"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+			"containers": []any{map[string]any{
+				"name":            "app",
+				"securityContext": map[string]any{"privileged": true},
+			}},
+		}}},
+	}
+	violations := engine.Evaluate(privileged)
+	require.Len(t, violations, 1)
+	assert.Equal(t, celrules.SeverityWarning, violations[0].Severity)
+
+	compliant := map[string]any{
+		"kind": "Pod",
+		"spec": map[string]any{"containers": []any{map[string]any{"name": "app"}}},
+	}
+	assert.Empty(t, engine.Evaluate(compliant))
+}
+
+func TestScaffoldSkipsPoliciesWithoutKyverno(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cluster := createKindCluster("no-policies")
+	sourceDir := filepath.Join(tempDir, cluster.Spec.Workload.SourceDirectory)
+
+	instance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+	require.NoError(t, instance.Scaffold(tempDir, false))
+
+	_, err := os.Stat(filepath.Join(sourceDir, scaffolder.PoliciesDir))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestScaffoldGeneratesVulnerabilityPolicy(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cluster := createKindCluster("vulnerability-policy")
+	cluster.Spec.Cluster.PolicyEngine = v1alpha1.PolicyEngineKyverno
+	cluster.Spec.Cluster.VulnerabilityPolicy = v1alpha1.VulnerabilityPolicy{
+		Severity:  v1alpha1.VulnerabilitySeverityHigh,
+		Allowlist: []string{"CVE-2024-3094"},
+		Images:    []string{"ghcr.io/acme/*"},
+	}
+	policiesDir := filepath.Join(tempDir, cluster.Spec.Workload.SourceDirectory, scaffolder.PoliciesDir)
+
+	instance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+	require.NoError(t, instance.Scaffold(tempDir, false))
+
+	kustomization, err := os.ReadFile(filepath.Join(policiesDir, "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(kustomization), "  - block-vulnerable-images.yaml\n")
+
+	content, err := os.ReadFile(filepath.Join(policiesDir, "block-vulnerable-images.yaml"))
+	require.NoError(t, err)
+
+	var policy struct {
+		Spec struct {
+			Rules []struct {
+				VerifyImages []struct {
+					ImageReferences []string `json:"imageReferences"`
+					FailureAction   string   `json:"failureAction"`
+					Attestations    []struct {
+						Type       string `json:"type"`
+						Conditions []struct {
+							All []struct {
+								Key string `json:"key"`
+							} `json:"all"`
+						} `json:"conditions"`
+					} `json:"attestations"`
+				} `json:"verifyImages"`
+			} `json:"rules"`
+		} `json:"spec"`
+	}
+	require.NoError(t, yaml.Unmarshal(content, &policy))
+	require.Len(t, policy.Spec.Rules, 1)
+	require.Len(t, policy.Spec.Rules[0].VerifyImages, 1)
+
+	verify := policy.Spec.Rules[0].VerifyImages[0]
+	assert.Equal(t, []string{"ghcr.io/acme/*"}, verify.ImageReferences)
+	assert.Equal(t, "Enforce", verify.FailureAction)
+	require.Len(t, verify.Attestations, 1)
+	assert.Equal(t, "https://cosign.sigstore.dev/attestation/vuln/v1", verify.Attestations[0].Type)
+
+	key := verify.Attestations[0].Conditions[0].All[0].Key
+	assert.Contains(t, key, "contains(`[\"HIGH\",\"CRITICAL\"]`, Severity)")
+	assert.Contains(t, key, "!contains(`[\"CVE-2024-3094\"]`, VulnerabilityID)")
+
+	// verifyImages rules cannot be evaluated offline and are reported as skipped.
+	_, skipped, err := celrules.LoadKyvernoPolicies(policiesDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"block-vulnerable-images/vulnerability-scan"}, skipped)
+}
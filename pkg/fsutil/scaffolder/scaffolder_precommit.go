@@ -0,0 +1,79 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+)
+
+// PreCommitConfigFile is the filename of the generated pre-commit configuration.
+const PreCommitConfigFile = ".pre-commit-config.yaml"
+
+// preCommitConfig is the scaffolded pre-commit configuration. It declares a single
+// local hook that runs `ksail verify` (config validation, manifest validation,
+// secret scanning, and policy checks) once per commit. The hook uses the `system`
+// language so pre-commit invokes the ksail binary already on PATH instead of
+// building an isolated environment, and always_run/pass_filenames make it verify
+// the whole project rather than only the staged files — a manifest can break
+// because a file it references changed.
+const preCommitConfig = `# Pre-commit hooks for this KSail project.
+# Install them with 'pre-commit install' (https://pre-commit.com); the ksail CLI
+# must be on PATH.
+repos:
+  - repo: local
+    hooks:
+      - id: ksail-verify
+        name: ksail verify
+        description: Validate config and manifests, scan for secrets, and run policy checks
+        entry: ksail verify
+        language: system
+        pass_filenames: false
+        always_run: true
+`
+
+// preCommitGenerator emits the static pre-commit configuration. It satisfies the
+// generator.Generator contract used by generateWithFileHandling so pre-commit
+// scaffolding shares the same force/skip/notify handling as the other files.
+type preCommitGenerator struct{}
+
+// Generate writes the pre-commit configuration to opts.Output (or returns it when
+// no output path is set), mirroring devcontainerGenerator's write semantics.
+func (g *preCommitGenerator) Generate(
+	_ struct{},
+	opts yamlgenerator.Options,
+) (string, error) {
+	if opts.Output == "" {
+		return preCommitConfig, nil
+	}
+
+	result, err := fsutil.TryWriteFile(preCommitConfig, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", PreCommitConfigFile, err)
+	}
+
+	return result, nil
+}
+
+// generatePreCommitConfig generates .pre-commit-config.yaml wired to `ksail verify`.
+func (s *Scaffolder) generatePreCommitConfig(output string, force bool) error {
+	opts := yamlgenerator.Options{
+		Output: filepath.Join(output, PreCommitConfigFile),
+		Force:  force,
+	}
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[struct{}]{
+			Gen:         &preCommitGenerator{},
+			Model:       struct{}{},
+			Opts:        opts,
+			DisplayName: PreCommitConfigFile,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrPreCommitGeneration, err)
+			},
+		},
+	)
+}
@@ -0,0 +1,46 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldSkipsPreCommitByDefault(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("pre-commit-default"), io.Discard, nil)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	_, statErr := os.Stat(filepath.Join(tempDir, scaffolder.PreCommitConfigFile))
+	require.ErrorIs(t, statErr, os.ErrNotExist)
+}
+
+func TestScaffoldGeneratesPreCommitWhenEnabled(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("pre-commit"), io.Discard, nil).
+		WithPreCommit(true)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, scaffolder.PreCommitConfigFile))
+	require.NoError(t, err)
+
+	var parsed map[string]any
+	require.NoError(t, yaml.Unmarshal(content, &parsed))
+
+	// Snapshot the exact generated .pre-commit-config.yaml so its content is pinned.
+	snaps.MatchSnapshot(t, string(content))
+}
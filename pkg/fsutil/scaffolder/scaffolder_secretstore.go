@@ -0,0 +1,91 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+)
+
+// SecretStoreFile is the filename of the scaffolded ClusterSecretStore, written
+// next to the workload kustomization.yaml.
+const SecretStoreFile = "cluster-secret-store.yaml"
+
+// SecretStoreName is the name of the scaffolded ClusterSecretStore that
+// ExternalSecrets reference in spec.secretStoreRef.
+const SecretStoreName = "fake"
+
+// secretStoreTemplate is a ClusterSecretStore backed by the External Secrets
+// Operator's fake provider. The single %s is the store name.
+const secretStoreTemplate = `# ClusterSecretStore scaffolded for spec.cluster.externalSecrets.
+# The fake provider serves the static keys below instead of calling a real
+# backend, so ExternalSecret manifests can be exercised locally. Reference it
+# with secretStoreRef {kind: ClusterSecretStore, name: %[1]s}, add the keys your
+# ExternalSecrets read, and swap the provider for Vault, AWS Secrets Manager, or
+# another backend outside local development.
+apiVersion: external-secrets.io/v1
+kind: ClusterSecretStore
+metadata:
+  name: %[1]s
+spec:
+  provider:
+    fake:
+      data:
+        - key: example
+          value: example-value
+`
+
+// secretStoreGenerator renders the ClusterSecretStore for a store name. It
+// satisfies the generator.Generator contract used by generateWithFileHandling.
+type secretStoreGenerator struct{}
+
+// Generate writes the store to opts.Output (or returns it when no output path
+// is set), mirroring gatewayExampleGenerator's write semantics.
+func (g *secretStoreGenerator) Generate(
+	storeName string,
+	opts yamlgenerator.Options,
+) (string, error) {
+	content := fmt.Sprintf(secretStoreTemplate, storeName)
+
+	if opts.Output == "" {
+		return content, nil
+	}
+
+	result, err := fsutil.TryWriteFile(content, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", SecretStoreFile, err)
+	}
+
+	return result, nil
+}
+
+// scaffoldsSecretStore reports whether the External Secrets Operator is
+// enabled, so a ClusterSecretStore should be scaffolded.
+func (s *Scaffolder) scaffoldsSecretStore() bool {
+	return s.KSailConfig.Spec.Cluster.ExternalSecrets == v1alpha1.ExternalSecretsEnabled
+}
+
+// generateSecretStore writes cluster-secret-store.yaml into the kustomization
+// directory.
+func (s *Scaffolder) generateSecretStore(output, kustomizationDir string, force bool) error {
+	displayName := filepath.Join(kustomizationDir, SecretStoreFile)
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[string]{
+			Gen:   &secretStoreGenerator{},
+			Model: SecretStoreName,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrSecretStoreGeneration, err)
+			},
+		},
+	)
+}
@@ -0,0 +1,80 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldGeneratesSecretStore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		externalSecrets v1alpha1.ExternalSecrets
+	}{
+		{name: "disabled", externalSecrets: v1alpha1.ExternalSecretsDisabled},
+		{name: "enabled", externalSecrets: v1alpha1.ExternalSecretsEnabled},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cluster := createKindCluster("eso-" + testCase.name)
+			cluster.Spec.Cluster.ExternalSecrets = testCase.externalSecrets
+			sourceDir := filepath.Join(tempDir, cluster.Spec.Workload.SourceDirectory)
+
+			instance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+			require.NoError(t, instance.Scaffold(tempDir, false))
+
+			kustomization, err := os.ReadFile(filepath.Join(sourceDir, "kustomization.yaml"))
+			require.NoError(t, err)
+
+			var parsed struct {
+				Resources []string `json:"resources"`
+			}
+			require.NoError(t, yaml.Unmarshal(kustomization, &parsed))
+
+			store, err := os.ReadFile(filepath.Join(sourceDir, scaffolder.SecretStoreFile))
+			if testCase.externalSecrets != v1alpha1.ExternalSecretsEnabled {
+				require.ErrorIs(t, err, os.ErrNotExist)
+				assert.Empty(t, parsed.Resources)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var manifest struct {
+				APIVersion string `json:"apiVersion"`
+				Kind       string `json:"kind"`
+				Metadata   struct {
+					Name string `json:"name"`
+				} `json:"metadata"`
+				Spec struct {
+					Provider struct {
+						Fake struct {
+							Data []map[string]string `json:"data"`
+						} `json:"fake"`
+					} `json:"provider"`
+				} `json:"spec"`
+			}
+			require.NoError(t, yaml.Unmarshal(store, &manifest))
+
+			assert.Equal(t, "external-secrets.io/v1", manifest.APIVersion)
+			assert.Equal(t, "ClusterSecretStore", manifest.Kind)
+			assert.Equal(t, scaffolder.SecretStoreName, manifest.Metadata.Name)
+			assert.NotEmpty(t, manifest.Spec.Provider.Fake.Data)
+			assert.Equal(t, []string{scaffolder.SecretStoreFile}, parsed.Resources)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// MeshNamespaceFile is the filename of the scaffolded workload Namespace that
+// enrolls its pods in the service mesh, written next to the workload
+// kustomization.yaml.
+const MeshNamespaceFile = "namespace.yaml"
+
+// MeshNamespaceName is the namespace scaffolded workloads (such as the example
+// Gateway) are deployed to.
+const MeshNamespaceName = "default"
+
+// meshNamespaceHeader explains the scaffolded Namespace. It is prepended to the
+// marshalled manifest.
+const meshNamespaceHeader = `# Namespace scaffolded for spec.cluster.serviceMesh.
+# The labels and annotations below enroll pods in this namespace in the mesh.
+# Pods that already run pick up the mesh on their next restart. Pruning is
+# disabled so removing this file never deletes the namespace.
+`
+
+// meshNamespaceManifest is the subset of a Namespace that is scaffolded.
+type meshNamespaceManifest struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Metadata   meshNamespaceMetadata `json:"metadata"`
+}
+
+type meshNamespaceMetadata struct {
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// meshNamespaceGenerator renders the Namespace for a cluster spec. It satisfies
+// the generator.Generator contract used by generateWithFileHandling.
+type meshNamespaceGenerator struct{}
+
+// Generate writes the Namespace to opts.Output (or returns it when no output
+// path is set), mirroring secretStoreGenerator's write semantics.
+func (g *meshNamespaceGenerator) Generate(
+	spec v1alpha1.ClusterSpec,
+	opts yamlgenerator.Options,
+) (string, error) {
+	labels, annotations := v1alpha1.MeshNamespaceMetadata(spec.ServiceMesh, spec.Istio)
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	// Flux and Argo CD would otherwise delete the namespace, and everything in
+	// it, once the file is removed from the kustomization.
+	annotations["kustomize.toolkit.fluxcd.io/prune"] = "disabled"
+	annotations["argocd.argoproj.io/sync-options"] = "Delete=false"
+
+	body, err := yaml.Marshal(meshNamespaceManifest{
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Metadata: meshNamespaceMetadata{
+			Name:        MeshNamespaceName,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", MeshNamespaceFile, err)
+	}
+
+	content := meshNamespaceHeader + string(body)
+
+	if opts.Output == "" {
+		return content, nil
+	}
+
+	result, err := fsutil.TryWriteFile(content, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", MeshNamespaceFile, err)
+	}
+
+	return result, nil
+}
+
+// scaffoldsMeshNamespace reports whether a service mesh is selected, so the
+// workload namespace should be scaffolded with its injection metadata.
+func (s *Scaffolder) scaffoldsMeshNamespace() bool {
+	return s.KSailConfig.Spec.Cluster.ServiceMesh.Enabled()
+}
+
+// generateMeshNamespace writes namespace.yaml into the kustomization directory.
+func (s *Scaffolder) generateMeshNamespace(output, kustomizationDir string, force bool) error {
+	displayName := filepath.Join(kustomizationDir, MeshNamespaceFile)
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[v1alpha1.ClusterSpec]{
+			Gen:   &meshNamespaceGenerator{},
+			Model: s.KSailConfig.Spec.Cluster,
+			Opts: yamlgenerator.Options{
+				Output: filepath.Join(output, displayName),
+				Force:  force,
+			},
+			DisplayName: displayName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrMeshNamespaceGeneration, err)
+			},
+		},
+	)
+}
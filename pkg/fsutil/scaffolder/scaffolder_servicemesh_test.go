@@ -0,0 +1,100 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestScaffoldGeneratesMeshNamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                string
+		mesh                v1alpha1.ServiceMesh
+		istioMode           v1alpha1.IstioMode
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{name: "none", mesh: v1alpha1.ServiceMeshNone},
+		{
+			name:           "istio sidecar",
+			mesh:           v1alpha1.ServiceMeshIstio,
+			expectedLabels: map[string]string{v1alpha1.IstioInjectionLabel: "enabled"},
+		},
+		{
+			name:           "istio ambient",
+			mesh:           v1alpha1.ServiceMeshIstio,
+			istioMode:      v1alpha1.IstioModeAmbient,
+			expectedLabels: map[string]string{v1alpha1.IstioDataplaneModeLabel: "ambient"},
+		},
+		{
+			name: "linkerd",
+			mesh: v1alpha1.ServiceMeshLinkerd,
+			expectedAnnotations: map[string]string{
+				v1alpha1.LinkerdInjectAnnotation: "enabled",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cluster := createKindCluster("mesh")
+			cluster.Spec.Cluster.ServiceMesh = testCase.mesh
+			cluster.Spec.Cluster.Istio.Mode = testCase.istioMode
+			sourceDir := filepath.Join(tempDir, cluster.Spec.Workload.SourceDirectory)
+
+			instance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+			require.NoError(t, instance.Scaffold(tempDir, false))
+
+			kustomization, err := os.ReadFile(filepath.Join(sourceDir, "kustomization.yaml"))
+			require.NoError(t, err)
+
+			var parsed struct {
+				Resources []string `json:"resources"`
+			}
+			require.NoError(t, yaml.Unmarshal(kustomization, &parsed))
+
+			content, err := os.ReadFile(filepath.Join(sourceDir, scaffolder.MeshNamespaceFile))
+			if testCase.mesh == v1alpha1.ServiceMeshNone {
+				require.ErrorIs(t, err, os.ErrNotExist)
+				assert.Empty(t, parsed.Resources)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var manifest struct {
+				Kind     string `json:"kind"`
+				Metadata struct {
+					Name        string            `json:"name"`
+					Labels      map[string]string `json:"labels"`
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+			}
+			require.NoError(t, yaml.Unmarshal(content, &manifest))
+
+			assert.Equal(t, "Namespace", manifest.Kind)
+			assert.Equal(t, scaffolder.MeshNamespaceName, manifest.Metadata.Name)
+			assert.Equal(t, testCase.expectedLabels, manifest.Metadata.Labels)
+			assert.Equal(t, "disabled", manifest.Metadata.Annotations["kustomize.toolkit.fluxcd.io/prune"])
+
+			for key, value := range testCase.expectedAnnotations {
+				assert.Equal(t, value, manifest.Metadata.Annotations[key])
+			}
+
+			assert.Equal(t, []string{scaffolder.MeshNamespaceFile}, parsed.Resources)
+		})
+	}
+}
@@ -60,12 +60,15 @@ func (s *Scaffolder) buildTalosGeneratorConfig() (*talosgenerator.Config, bool)
 	// Enable OIDC API server configuration when OIDC is configured.
 	enableOIDC := s.KSailConfig.Spec.Cluster.OIDC.Enabled()
 
+	// Set IPv6 or dual-stack pod and service subnets when requested.
+	ipFamily := s.KSailConfig.Spec.Cluster.Network.IPFamily
+
 	// Mirror the conditions in generator.getDirectoriesWithPatches() exactly so
 	// .gitkeep notifications match the files the generator actually writes.
 	clusterHasPatches := talosClusterHasPatches(
 		workers, s.MirrorRegistries, disableDefaultCNI, enableKubeletCertRotation,
 		s.ClusterName, enableImageVerification, disableCDI, enableExternalCloudProvider,
-		enableIngressFirewall, enableOIDC,
+		enableIngressFirewall, enableOIDC, ipFamily.IPv6(),
 	)
 
 	config := &talosgenerator.Config{
@@ -82,6 +85,7 @@ func (s *Scaffolder) buildTalosGeneratorConfig() (*talosgenerator.Config, bool)
 		NetworkCIDR:                 networkCIDR,
 		CNIPort:                     cniPort,
 		AllowedCIDRs:                s.KSailConfig.Spec.Provider.Hetzner.AllowedCIDRs,
+		NodePortCIDRs:               s.KSailConfig.Spec.Provider.Hetzner.NodePortCIDRs,
 		EnableOIDC:                  enableOIDC,
 		OIDCIssuerURL:               s.KSailConfig.Spec.Cluster.OIDC.IssuerURL,
 		OIDCClientID:                s.KSailConfig.Spec.Cluster.OIDC.ClientID,
@@ -90,11 +94,16 @@ func (s *Scaffolder) buildTalosGeneratorConfig() (*talosgenerator.Config, bool)
 		OIDCGroupsClaim:             s.KSailConfig.Spec.Cluster.OIDC.GroupsClaim,
 		OIDCGroupsPrefix:            s.KSailConfig.Spec.Cluster.OIDC.GroupsPrefix,
 		OIDCCAFile:                  s.KSailConfig.Spec.Cluster.OIDC.CAFile,
+		IPFamily:                    ipFamily,
 		MultiDocumentKubernetesConfig: usesMultiDocumentKubernetesConfig(
 			s.KSailConfig.Spec.Cluster.Talos.Version,
 		),
 	}
 
+	if s.Schema {
+		config.SchemaHeader = TalosSchemaHeader
+	}
+
 	return config, clusterHasPatches
 }
 
@@ -154,6 +163,7 @@ func (s *Scaffolder) notifyTalosGenerated(
 		{config.EnableIngressFirewall, "control-planes", "ingress-firewall-rules.yaml"},
 		{config.EnableIngressFirewall, "workers", "ingress-firewall-rules.yaml"},
 		{config.EnableOIDC, talosClusterDir, "oidc.yaml"},
+		{config.IPFamily.IPv6(), talosClusterDir, "ip-family.yaml"},
 	}
 
 	for _, patch := range patches {
@@ -193,7 +203,7 @@ func talosClusterHasPatches(
 	disableDefaultCNI, enableKubeletCertRotation bool,
 	clusterName string,
 	enableImageVerification, disableCDI, enableExternalCloudProvider, enableIngressFirewall,
-	enableOIDC bool,
+	enableOIDC, enableIPFamily bool,
 ) bool {
 	return workers == 0 ||
 		len(mirrorRegistries) > 0 ||
@@ -204,5 +214,6 @@ func talosClusterHasPatches(
 		disableCDI ||
 		enableExternalCloudProvider ||
 		enableIngressFirewall ||
-		enableOIDC
+		enableOIDC ||
+		enableIPFamily
 }
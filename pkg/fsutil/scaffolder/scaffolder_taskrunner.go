@@ -0,0 +1,199 @@
+package scaffolder
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	yamlgenerator "github.com/devantler-tech/ksail/v7/pkg/fsutil/generator/yaml"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
+)
+
+// TaskRunner selects the task runner file scaffolded with the canonical
+// developer entrypoints of a project.
+type TaskRunner string
+
+const (
+	// TaskRunnerNone scaffolds no task runner file.
+	TaskRunnerNone TaskRunner = ""
+	// TaskRunnerTaskfile scaffolds a Taskfile.yml for Task (https://taskfile.dev).
+	TaskRunnerTaskfile TaskRunner = "taskfile"
+	// TaskRunnerMakefile scaffolds a Makefile for make.
+	TaskRunnerMakefile TaskRunner = "makefile"
+)
+
+const (
+	// TaskfileFile is the filename of the generated Taskfile.
+	TaskfileFile = "Taskfile.yml"
+	// MakefileFile is the filename of the generated Makefile.
+	MakefileFile = "Makefile"
+)
+
+// ParseTaskRunner parses the value of init's --task-runner flag.
+func ParseTaskRunner(value string) (TaskRunner, error) {
+	switch runner := TaskRunner(strings.ToLower(strings.TrimSpace(value))); runner {
+	case TaskRunnerNone, TaskRunnerTaskfile, TaskRunnerMakefile:
+		return runner, nil
+	default:
+		return TaskRunnerNone, fmt.Errorf("%w: %q (valid: %s, %s)",
+			ErrUnknownTaskRunner, value, TaskRunnerTaskfile, TaskRunnerMakefile)
+	}
+}
+
+// taskRunnerTarget is one canonical developer entrypoint. Every project gets
+// the same targets so contributors find the same workflow everywhere; only the
+// commands behind push depend on how the project deploys.
+type taskRunnerTarget struct {
+	name        string
+	description string
+	commands    []string
+}
+
+// taskRunnerTargets returns the canonical targets of the project: up, down,
+// validate, test and push.
+func (s *Scaffolder) taskRunnerTargets() []taskRunnerTarget {
+	push := taskRunnerTarget{
+		name:        "push",
+		description: "Push the workloads and reconcile the cluster",
+		commands:    []string{"ksail workload push", "ksail workload reconcile"},
+	}
+
+	gitOpsEngine := s.KSailConfig.Spec.Cluster.GitOpsEngine
+	if gitOpsEngine == "" || gitOpsEngine == v1alpha1.GitOpsEngineNone {
+		sourceDirectory := s.KSailConfig.Spec.Workload.SourceDirectory
+		if sourceDirectory == "" {
+			sourceDirectory = v1alpha1.DefaultSourceDirectory
+		}
+
+		push.description = "Apply the workloads to the cluster"
+		push.commands = []string{"ksail workload apply -k " + filepath.ToSlash(sourceDirectory)}
+
+		switch s.Layout {
+		case LayoutOverlays:
+			push.commands = []string{
+				"ksail workload apply --env " + environment.DefaultOverlayEnvironments()[0],
+			}
+		case LayoutMonorepo:
+			push.commands = []string{"ksail workload apply -k " + path.Join(
+				filepath.ToSlash(sourceDirectory), s.defaultMonorepoKustomizationFile(),
+			)}
+		case LayoutFlat, LayoutHelm:
+		}
+	}
+
+	return []taskRunnerTarget{
+		{name: "up", description: "Create the cluster", commands: []string{"ksail cluster create"}},
+		{name: "down", description: "Delete the cluster", commands: []string{"ksail cluster delete"}},
+		{
+			name:        "validate",
+			description: "Validate the Kubernetes manifests",
+			commands:    []string{"ksail workload validate"},
+		},
+		{
+			name:        "test",
+			description: "Run the config, manifest, secret and policy checks",
+			commands:    []string{"ksail verify"},
+		},
+		push,
+	}
+}
+
+// renderTaskfile renders targets as a Taskfile.yml.
+func renderTaskfile(targets []taskRunnerTarget) string {
+	var builder strings.Builder
+
+	builder.WriteString("# Developer entrypoints for this KSail project. Run them with 'task <name>'\n")
+	builder.WriteString("# (https://taskfile.dev) and list them with 'task --list'; the ksail CLI must\n")
+	builder.WriteString("# be on PATH.\n")
+	builder.WriteString("version: \"3\"\n\ntasks:\n")
+
+	for _, target := range targets {
+		fmt.Fprintf(&builder, "  %s:\n    desc: %s\n    cmds:\n", target.name, target.description)
+
+		for _, command := range target.commands {
+			fmt.Fprintf(&builder, "      - %s\n", command)
+		}
+	}
+
+	return builder.String()
+}
+
+// renderMakefile renders targets as a Makefile whose targets are all phony.
+func renderMakefile(targets []taskRunnerTarget) string {
+	names := make([]string, 0, len(targets))
+	for _, target := range targets {
+		names = append(names, target.name)
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("# Developer entrypoints for this KSail project. Run them with 'make <target>';\n")
+	builder.WriteString("# the ksail CLI must be on PATH.\n")
+	fmt.Fprintf(&builder, ".PHONY: %s\n", strings.Join(names, " "))
+
+	for _, target := range targets {
+		fmt.Fprintf(&builder, "\n# %s\n%s:\n", target.description, target.name)
+
+		for _, command := range target.commands {
+			fmt.Fprintf(&builder, "\t%s\n", command)
+		}
+	}
+
+	return builder.String()
+}
+
+// taskRunnerGenerator emits a rendered task runner file. It satisfies the
+// generator.Generator contract used by generateWithFileHandling so task runner
+// scaffolding shares the same force/skip/notify handling as the other files.
+type taskRunnerGenerator struct {
+	fileName string
+	content  string
+}
+
+// Generate writes the task runner file to opts.Output (or returns it when no
+// output path is set), mirroring preCommitGenerator's write semantics.
+func (g *taskRunnerGenerator) Generate(
+	_ struct{},
+	opts yamlgenerator.Options,
+) (string, error) {
+	if opts.Output == "" {
+		return g.content, nil
+	}
+
+	result, err := fsutil.TryWriteFile(g.content, opts.Output, opts.Force)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", g.fileName, err)
+	}
+
+	return result, nil
+}
+
+// generateTaskRunner generates the Taskfile.yml or Makefile selected by TaskRunner.
+func (s *Scaffolder) generateTaskRunner(output string, force bool) error {
+	gen := &taskRunnerGenerator{fileName: TaskfileFile, content: renderTaskfile(s.taskRunnerTargets())}
+	if s.TaskRunner == TaskRunnerMakefile {
+		gen = &taskRunnerGenerator{fileName: MakefileFile, content: renderMakefile(s.taskRunnerTargets())}
+	}
+
+	opts := yamlgenerator.Options{
+		Output: filepath.Join(output, gen.fileName),
+		Force:  force,
+	}
+
+	return generateWithFileHandling(
+		s,
+		GenerationParams[struct{}]{
+			Gen:         gen,
+			Model:       struct{}{},
+			Opts:        opts,
+			DisplayName: gen.fileName,
+			Force:       force,
+			WrapErr: func(err error) error {
+				return fmt.Errorf("%w: %w", ErrTaskRunnerGeneration, err)
+			},
+		},
+	)
+}
@@ -0,0 +1,105 @@
+package scaffolder_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/scaffolder"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseTaskRunner(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    scaffolder.TaskRunner
+		wantErr bool
+	}{
+		{value: "", want: scaffolder.TaskRunnerNone},
+		{value: "taskfile", want: scaffolder.TaskRunnerTaskfile},
+		{value: "Makefile", want: scaffolder.TaskRunnerMakefile},
+		{value: "just", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := scaffolder.ParseTaskRunner(test.value)
+			if test.wantErr {
+				require.ErrorIs(t, err, scaffolder.ErrUnknownTaskRunner)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestScaffoldSkipsTaskRunnerByDefault(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("task-runner-default"), io.Discard, nil)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	for _, file := range []string{scaffolder.TaskfileFile, scaffolder.MakefileFile} {
+		_, statErr := os.Stat(filepath.Join(tempDir, file))
+		require.ErrorIs(t, statErr, os.ErrNotExist)
+	}
+}
+
+func TestScaffoldGeneratesTaskfileWithGitOpsPush(t *testing.T) {
+	t.Parallel()
+
+	cluster := createKindCluster("taskfile")
+	cluster.Spec.Cluster.GitOpsEngine = v1alpha1.GitOpsEngineFlux
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(cluster, io.Discard, nil).
+		WithTaskRunner(scaffolder.TaskRunnerTaskfile)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, scaffolder.TaskfileFile))
+	require.NoError(t, err)
+
+	var parsed map[string]any
+	require.NoError(t, yaml.Unmarshal(content, &parsed))
+
+	snaps.MatchSnapshot(t, string(content))
+}
+
+func TestScaffoldGeneratesMakefileWithApplyPush(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	instance := scaffolder.NewScaffolder(createKindCluster("makefile"), io.Discard, nil).
+		WithTaskRunner(scaffolder.TaskRunnerMakefile)
+
+	err := instance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, scaffolder.MakefileFile))
+	require.NoError(t, err)
+
+	_, statErr := os.Stat(filepath.Join(tempDir, scaffolder.TaskfileFile))
+	require.ErrorIs(t, statErr, os.ErrNotExist)
+
+	// make requires tab-indented recipes; the snapshot does not preserve tabs.
+	assert.Contains(t, string(content), "up:\n\tksail cluster create\n")
+
+	snaps.MatchSnapshot(t, string(content))
+}
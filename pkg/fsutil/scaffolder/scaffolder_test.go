@@ -1572,6 +1572,26 @@ func TestScaffoldK3d_ImageVerificationDisabled_SkipsContainerdConfig(t *testing.
 	)
 }
 
+func TestScaffoldKustomizationStampsResourceMetadata(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cluster := createKindCluster("metadata-test")
+	cluster.Spec.Cluster.ResourceMetadata = v1alpha1.ResourceMetadata{
+		Labels:      map[string]string{"team": "platform"},
+		Annotations: map[string]string{"example.com/owner": "platform"},
+	}
+	scaffolderInstance := scaffolder.NewScaffolder(cluster, io.Discard, nil)
+
+	err := scaffolderInstance.Scaffold(tempDir, false)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "k8s", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "team: platform")
+	assert.Contains(t, string(content), "example.com/owner: platform")
+}
+
 func TestScaffoldTalos_CreatesDirectoryStructure(t *testing.T) {
 	t.Parallel()
 
@@ -1986,6 +2006,63 @@ func TestScaffoldPrependsSchemaHeader(t *testing.T) {
 		"ksail.yaml must start with the yaml-language-server schema directive")
 }
 
+// TestScaffoldWithSchemaPrependsDistributionSchemaHeaders verifies WithSchema adds the
+// yaml-language-server directive to the distribution configs, which otherwise carry none.
+func TestScaffoldWithSchemaPrependsDistributionSchemaHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster v1alpha1.Cluster
+		file    string
+		header  string
+	}{
+		{
+			name:    "kind",
+			cluster: createKindCluster("schema-kind"),
+			file:    "kind.yaml",
+			header:  scaffolder.KindSchemaHeader,
+		},
+		{
+			name:    "k3d",
+			cluster: createK3dCluster("schema-k3d"),
+			file:    "k3d.yaml",
+			header:  scaffolder.K3dSchemaHeader,
+		},
+		{
+			name:    "talos",
+			cluster: createTalosCluster("schema-talos"),
+			file:    filepath.Join("talos", "cluster", "allow-scheduling-on-control-planes.yaml"),
+			header:  scaffolder.TalosSchemaHeader,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, enabled := range []bool{false, true} {
+				tempDir := t.TempDir()
+				scaffolderInstance := scaffolder.NewScaffolder(testCase.cluster, io.Discard, nil).
+					WithSchema(enabled)
+
+				require.NoError(t, scaffolderInstance.Scaffold(tempDir, false))
+
+				//nolint:gosec // G304: Test file path is safe
+				content, err := os.ReadFile(filepath.Join(tempDir, testCase.file))
+				require.NoError(t, err)
+
+				firstLine, _, _ := strings.Cut(string(content), "\n")
+				if enabled {
+					assert.Equal(t, testCase.header, firstLine)
+				} else {
+					assert.NotContains(t, string(content), "yaml-language-server")
+				}
+			}
+		})
+	}
+}
+
 func TestResolveKustomizationDir_ValidPaths(t *testing.T) {
 	t.Parallel()
 
@@ -15,4 +15,5 @@
 //   - k3d: K3d configuration validator
 //   - kind: Kind configuration validator
 //   - ksail: KSail configuration validator
+//   - openapi: Structural resource validator backed by a cluster's OpenAPI v3 schemas
 package validator
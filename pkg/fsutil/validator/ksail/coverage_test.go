@@ -211,6 +211,126 @@ func TestValidate_VClusterCiliumCNI(t *testing.T) {
 	}
 }
 
+// TestValidate_CiliumOptionsWithoutCiliumCNI verifies spec.cluster.cilium set
+// alongside a non-Cilium CNI produces a warning rather than being silently ignored.
+//
+//nolint:varnamelen // Short names keep this table-driven test readable.
+func TestValidate_CiliumOptionsWithoutCiliumCNI(t *testing.T) {
+	t.Parallel()
+
+	v := ksailvalidator.NewValidator()
+
+	config := &v1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: "ksail.io/v1alpha1",
+		},
+		Spec: v1alpha1.Spec{
+			Cluster: v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionVCluster,
+				CNI:          v1alpha1.CNICalico,
+				Cilium:       v1alpha1.OptionsCilium{Hubble: v1alpha1.HubbleEnabled},
+			},
+		},
+	}
+
+	result := v.Validate(config)
+
+	fields := make([]string, 0, len(result.Warnings))
+	for _, warning := range result.Warnings {
+		fields = append(fields, warning.Field)
+	}
+
+	assert.Contains(t, fields, "spec.cluster.cilium")
+
+	config.Spec.Cluster.CNI = v1alpha1.CNICilium
+	result = v.Validate(config)
+
+	for _, warning := range result.Warnings {
+		assert.NotEqual(t, "spec.cluster.cilium", warning.Field)
+	}
+}
+
+// TestValidate_GatewayAPICiliumRequiresCiliumCNI verifies the Cilium Gateway API
+// option is rejected unless the CNI is Cilium.
+//
+//nolint:varnamelen // Short names keep this table-driven test readable.
+func TestValidate_GatewayAPICiliumRequiresCiliumCNI(t *testing.T) {
+	t.Parallel()
+
+	v := ksailvalidator.NewValidator()
+
+	config := &v1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: "ksail.io/v1alpha1",
+		},
+		Spec: v1alpha1.Spec{
+			Cluster: v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionVCluster,
+				CNI:          v1alpha1.CNICalico,
+				GatewayAPI:   v1alpha1.GatewayAPICilium,
+			},
+		},
+	}
+
+	result := v.Validate(config)
+
+	fields := make([]string, 0, len(result.Errors))
+	for _, validationErr := range result.Errors {
+		fields = append(fields, validationErr.Field)
+	}
+
+	assert.Contains(t, fields, "spec.cluster.gatewayAPI")
+
+	config.Spec.Cluster.CNI = v1alpha1.CNICilium
+	result = v.Validate(config)
+
+	for _, validationErr := range result.Errors {
+		assert.NotEqual(t, "spec.cluster.gatewayAPI", validationErr.Field)
+	}
+}
+
+// TestValidate_IstioOptionsWithoutIstioMesh verifies spec.cluster.istio set
+// alongside another service mesh produces a warning rather than being silently ignored.
+//
+//nolint:varnamelen // Short names keep this table-driven test readable.
+func TestValidate_IstioOptionsWithoutIstioMesh(t *testing.T) {
+	t.Parallel()
+
+	v := ksailvalidator.NewValidator()
+
+	config := &v1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: "ksail.io/v1alpha1",
+		},
+		Spec: v1alpha1.Spec{
+			Cluster: v1alpha1.ClusterSpec{
+				Distribution: v1alpha1.DistributionVanilla,
+				ServiceMesh:  v1alpha1.ServiceMeshLinkerd,
+				Istio:        v1alpha1.OptionsIstio{Mode: v1alpha1.IstioModeAmbient},
+			},
+		},
+	}
+
+	result := v.Validate(config)
+
+	fields := make([]string, 0, len(result.Warnings))
+	for _, warning := range result.Warnings {
+		fields = append(fields, warning.Field)
+	}
+
+	assert.Contains(t, fields, "spec.cluster.istio")
+
+	config.Spec.Cluster.ServiceMesh = v1alpha1.ServiceMeshIstio
+	result = v.Validate(config)
+
+	for _, warning := range result.Warnings {
+		assert.NotEqual(t, "spec.cluster.istio", warning.Field)
+	}
+}
+
 // TestValidate_ExternalRegistryPort verifies external registry port validation.
 //
 //nolint:varnamelen // Short names keep this table-driven test readable.
@@ -106,11 +106,27 @@ func (v *Validator) Validate(config *v1alpha1.Cluster) *validator.ValidationResu
 
 	// Validate CNI alignment with distribution config
 	v.validateCNIAlignment(config, result)
+	v.validateCiliumOptions(config, result)
+	v.validateIstioOptions(config, result)
+	v.validateGatewayAPI(config, result)
 	v.validateRegistry(config, result)
+	v.validateRegistryBinding(config, result)
+	v.validateIdentityProvider(config, result)
 	v.validateFlux(config, result)
 	v.validateAutoscalerConfig(config, result)
+	v.validateResourceMetadata(config, result)
+	v.validateSpot(config, result)
+	v.validateNetwork(config, result)
+	v.validateAPIServerPort(config, result)
+	v.validateSeed(config, result)
+	v.validateNodes(config, result)
+	v.validateArgoCDProject(config, result)
+	v.validateArgoCDLayout(config, result)
 	v.validatePublicNet(config, result)
 	v.validateTalosInstallImageSkew(config, result)
+	v.validateActionsRunner(config, result)
+	v.validateVulnerabilityPolicy(config, result)
+	v.validateCloudCredentials(config, result)
 
 	return result
 }
@@ -323,6 +339,63 @@ func (v *Validator) validateCNIAlignment(
 	}
 }
 
+// validateCiliumOptions warns when spec.cluster.cilium is set while another CNI is
+// selected, since the options are then silently ignored.
+func (v *Validator) validateCiliumOptions(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if config.Spec.Cluster.Cilium.IsZero() || config.Spec.Cluster.CNI == v1alpha1.CNICilium {
+		return
+	}
+
+	result.AddWarning(validator.ValidationError{
+		Field:         "spec.cluster.cilium",
+		Message:       "cilium options are ignored because the CNI is not Cilium",
+		CurrentValue:  config.Spec.Cluster.CNI,
+		FixSuggestion: "Set spec.cluster.cni to Cilium, or remove spec.cluster.cilium",
+	})
+}
+
+// validateGatewayAPI rejects the Cilium Gateway API option on clusters that do
+// not run the Cilium CNI, since the controller ships with the CNI itself.
+func (v *Validator) validateGatewayAPI(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if config.Spec.Cluster.GatewayAPI != v1alpha1.GatewayAPICilium ||
+		config.Spec.Cluster.CNI == v1alpha1.CNICilium {
+		return
+	}
+
+	result.AddError(validator.ValidationError{
+		Field:         "spec.cluster.gatewayAPI",
+		Message:       "the Cilium Gateway API controller requires the Cilium CNI",
+		CurrentValue:  config.Spec.Cluster.CNI,
+		ExpectedValue: v1alpha1.CNICilium,
+		FixSuggestion: "Set spec.cluster.cni to Cilium, or set spec.cluster.gatewayAPI to CRDs or Envoy",
+	})
+}
+
+// validateIstioOptions warns when spec.cluster.istio is set while another service
+// mesh is selected, since the options are then silently ignored.
+func (v *Validator) validateIstioOptions(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if config.Spec.Cluster.Istio.IsZero() ||
+		config.Spec.Cluster.ServiceMesh == v1alpha1.ServiceMeshIstio {
+		return
+	}
+
+	result.AddWarning(validator.ValidationError{
+		Field:         "spec.cluster.istio",
+		Message:       "istio options are ignored because the service mesh is not Istio",
+		CurrentValue:  config.Spec.Cluster.ServiceMesh,
+		FixSuggestion: "Set spec.cluster.serviceMesh to Istio, or remove spec.cluster.istio",
+	})
+}
+
 // validateCiliumCNI checks that the distribution config has CNI disabled when Cilium is requested.
 func (v *Validator) validateCiliumCNI(
 	dist v1alpha1.Distribution,
@@ -642,6 +715,38 @@ func (v *Validator) validateRegistry(
 	}
 }
 
+// validateRegistryBinding ensures registries are published on a valid host address and on
+// distinct mirror ports, so a typo fails here rather than when Docker publishes the ports.
+func (v *Validator) validateRegistryBinding(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateRegistryBinding(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.registryBinding",
+			Message:       err.Error(),
+			FixSuggestion: "Use an IP address such as 0.0.0.0 for hostIP and distinct ports between 1 and 65535",
+		})
+	}
+}
+
+// validateIdentityProvider ensures the development identity provider is only selected
+// for clusters whose API server KSail can point at it.
+func (v *Validator) validateIdentityProvider(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateIdentityProvider(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.identityProvider",
+			Message:       err.Error(),
+			FixSuggestion: "Use the Vanilla or K3s distribution on the Docker provider, or set identityProvider to None",
+		})
+	}
+}
+
 // validateFlux ensures Flux-specific settings are valid when Flux is enabled.
 func (v *Validator) validateFlux(
 	_ *v1alpha1.Cluster,
@@ -668,6 +773,185 @@ func (v *Validator) validateAutoscalerConfig(
 	}
 }
 
+// validateResourceMetadata ensures the labels and annotations KSail stamps onto the resources it
+// creates satisfy Kubernetes metadata syntax, so a typo fails here rather than mid-provisioning.
+func (v *Validator) validateResourceMetadata(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateResourceMetadata(config.Spec.Cluster.ResourceMetadata)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.resourceMetadata",
+			Message:       err.Error(),
+			FixSuggestion: "Use Kubernetes label syntax (e.g. team: platform, example.com/cost-center: cc-42)",
+		})
+	}
+}
+
+// validateSpot ensures spot capacity is only requested for cloud distributions and with a reclaim
+// policy the distribution supports, so the mismatch fails before a cluster is created on-demand.
+func (v *Validator) validateSpot(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateSpot(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.spot",
+			Message:       err.Error(),
+			FixSuggestion: "Use spot with the EKS, GKE, or AKS distribution; reclaimPolicy Deallocate is AKS-only",
+		})
+	}
+}
+
+// validateActionsRunner ensures an enabled runner scale set points at a GitHub repository or
+// organization with a valid name and runner range, so the listener does not crash-loop after create.
+func (v *Validator) validateActionsRunner(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateActionsRunner(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.actionsRunner",
+			Message:       err.Error(),
+			FixSuggestion: "Set githubConfigURL to https://github.com/<org>[/<repo>] and keep maxRunners >= minRunners",
+		})
+	}
+}
+
+// validateVulnerabilityPolicy ensures a vulnerability admission policy is only requested with
+// Kyverno, which enforces it, and that its allowlist holds well-formed vulnerability IDs.
+func (v *Validator) validateVulnerabilityPolicy(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateVulnerabilityPolicy(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.vulnerabilityPolicy",
+			Message:       err.Error(),
+			FixSuggestion: "Set spec.cluster.policyEngine to Kyverno and list IDs like CVE-2024-3094",
+		})
+	}
+}
+
+// validateCloudCredentials rejects assumed AWS roles and Hetzner token commands that could
+// not produce credentials, before any cloud resource is touched.
+func (v *Validator) validateCloudCredentials(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateCloudCredentials(&config.Spec.Provider)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.provider",
+			Message:       err.Error(),
+			FixSuggestion: "Use a role ARN like arn:aws:iam::123456789012:role/ksail and a non-empty tokenCommand",
+		})
+	}
+}
+
+// validateNetwork ensures IPv6 and dual-stack networking are only requested where KSail can
+// configure them, so the mismatch fails before an IPv4-only cluster is created.
+func (v *Validator) validateNetwork(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateNetwork(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.network.ipFamily",
+			Message:       err.Error(),
+			FixSuggestion: "Use ipv6 or dual with the Vanilla, K3s, or Talos distribution on the Docker provider",
+		})
+	}
+}
+
+// validateAPIServerPort ensures a pinned API server port is a valid port on a distribution
+// and provider that publish the API server on localhost.
+func (v *Validator) validateAPIServerPort(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateAPIServerPort(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.connection.apiServerPort",
+			Message:       err.Error(),
+			FixSuggestion: "Use a port between 1 and 65535 with Vanilla, K3s, or Talos on Docker, or remove it",
+		})
+	}
+}
+
+// validateSeed ensures spec.seed ConfigMaps and Secrets have valid names, namespaces, and keys.
+func (v *Validator) validateSeed(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateSeed(config.Spec.Seed)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.seed",
+			Message:       err.Error(),
+			FixSuggestion: "Use DNS-1123 names and namespaces, declare each resource once, and give it data or files",
+		})
+	}
+}
+
+// validateNodes ensures spec.cluster.nodes entries select existing nodes and carry valid
+// resources, labels, and taints, on a distribution and provider that can apply them.
+func (v *Validator) validateNodes(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateNodes(&config.Spec.Cluster)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.cluster.nodes",
+			Message:       err.Error(),
+			FixSuggestion: "Use an in-range index and quantities like cpu: 2, memory: 4Gi on Vanilla, K3s, or Talos with Docker",
+		})
+	}
+}
+
+// validateArgoCDProject ensures the declared AppProject restrictions are well-formed, so a bad
+// sync window fails here instead of being silently ignored by Argo CD.
+func (v *Validator) validateArgoCDProject(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	err := v1alpha1.ValidateArgoCDProject(config.Spec.Workload.ArgoCD.Project)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.workload.argoCD.project",
+			Message:       err.Error(),
+			FixSuggestion: "Use kind allow or deny, a cron schedule (e.g. '0 22 * * *'), and a Go duration (e.g. 1h)",
+		})
+	}
+}
+
+// validateArgoCDLayout ensures the ApplicationSet layout can be generated for the configured
+// source. Only checked when Argo CD is the GitOps engine, the only engine the layout affects.
+func (v *Validator) validateArgoCDLayout(
+	config *v1alpha1.Cluster,
+	result *validator.ValidationResult,
+) {
+	if config.Spec.Cluster.GitOpsEngine != v1alpha1.GitOpsEngineArgoCD {
+		return
+	}
+
+	err := v1alpha1.ValidateArgoCDLayout(config.Spec.Workload)
+	if err != nil {
+		result.AddError(validator.ValidationError{
+			Field:         "spec.workload.argoCD.layout",
+			Message:       err.Error(),
+			FixSuggestion: "Set spec.workload.git (Directory generator) or applicationSet.clusterSelector (Cluster)",
+		})
+	}
+}
+
 // validatePublicNet warns when a Hetzner role is left with no public networking.
 // There is no config-time error to raise: KSail always provisions and attaches a
 // private network, so a node can never end up with neither a public IP nor a private
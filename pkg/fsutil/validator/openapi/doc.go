@@ -0,0 +1,9 @@
+// Package openapi provides structural validation of Kubernetes resources
+// against the OpenAPI v3 schemas served by a live cluster.
+//
+// The cluster's schemas cover its built-in kinds as well as every installed
+// CustomResourceDefinition, so custom resources are validated against the
+// exact schema the API server will enforce rather than a catalog copy. Type
+// mismatches, unknown fields, missing required fields and unsupported enum
+// values are reported as field-level validator.ValidationError entries.
+package openapi
@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// componentsRefPrefix is the prefix of the $ref values the API server emits in
+// its OpenAPI v3 documents; every reference points into the same document.
+const componentsRefPrefix = "#/components/schemas/"
+
+// maxRefHops bounds how many $ref hops are followed before a schema is treated
+// as unresolvable, so a self-referencing component cannot loop forever.
+const maxRefHops = 32
+
+// document is the part of a group-version OpenAPI v3 document served under
+// /openapi/v3/<path> that structural validation needs.
+type document struct {
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Schema is the subset of an OpenAPI v3 schema object that describes the
+// structure of a Kubernetes resource: types, fields, required fields, enums and
+// the Kubernetes structural-schema extensions.
+type Schema struct {
+	Type                  string             `json:"type,omitempty"`
+	Properties            map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties  *SchemaOrBool      `json:"additionalProperties,omitempty"`
+	Items                 *Schema            `json:"items,omitempty"`
+	Required              []string           `json:"required,omitempty"`
+	Enum                  []any              `json:"enum,omitempty"`
+	Ref                   string             `json:"$ref,omitempty"`
+	AllOf                 []*Schema          `json:"allOf,omitempty"`
+	OneOf                 []*Schema          `json:"oneOf,omitempty"`
+	AnyOf                 []*Schema          `json:"anyOf,omitempty"`
+	PreserveUnknownFields bool               `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+	IntOrString           bool               `json:"x-kubernetes-int-or-string,omitempty"`
+
+	GroupVersionKinds []GroupVersionKind `json:"x-kubernetes-group-version-kind,omitempty"`
+}
+
+// SchemaOrBool is an additionalProperties value, which OpenAPI allows to be
+// either a schema for the extra fields or a boolean allowing or forbidding them.
+type SchemaOrBool struct {
+	Allows bool
+	Schema *Schema
+}
+
+// UnmarshalJSON decodes either form of additionalProperties.
+func (s *SchemaOrBool) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+
+	if bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
+		s.Allows = bytes.Equal(trimmed, []byte("true"))
+		s.Schema = nil
+
+		return nil
+	}
+
+	var schema Schema
+
+	err := json.Unmarshal(trimmed, &schema)
+	if err != nil {
+		return fmt.Errorf("decode additionalProperties: %w", err)
+	}
+
+	s.Allows = true
+	s.Schema = &schema
+
+	return nil
+}
+
+// GroupVersionKind is an entry of the x-kubernetes-group-version-kind extension
+// that marks the schemas of top-level resources.
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// APIVersion returns the apiVersion manifests of the kind declare.
+func (g GroupVersionKind) APIVersion() string {
+	if g.Group == "" {
+		return g.Version
+	}
+
+	return g.Group + "/" + g.Version
+}
+
+// resolve follows s's $ref chain within components and returns the schema it
+// points to, or nil when a reference cannot be resolved.
+func resolve(s *Schema, components map[string]*Schema) *Schema {
+	for range maxRefHops {
+		if s == nil || s.Ref == "" {
+			return s
+		}
+
+		s = components[strings.TrimPrefix(s.Ref, componentsRefPrefix)]
+	}
+
+	return nil
+}
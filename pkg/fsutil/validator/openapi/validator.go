@@ -0,0 +1,355 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator"
+	openapiclient "k8s.io/client-go/openapi"
+)
+
+// jsonContentType is the content type the OpenAPI v3 documents are requested in.
+const jsonContentType = "application/json"
+
+// kindSchema is the schema of one resource kind together with the components
+// of the document it came from, against which its $refs resolve.
+type kindSchema struct {
+	schema     *Schema
+	components map[string]*Schema
+}
+
+// Validator validates Kubernetes resources structurally against the OpenAPI v3
+// schemas a cluster serves, which cover the built-in kinds as well as every
+// installed CustomResourceDefinition. Each violation is reported as a
+// validator.ValidationError naming the offending field path.
+//
+// A Validator is read-only after construction and safe for concurrent use.
+type Validator struct {
+	kinds map[GroupVersionKind]kindSchema
+}
+
+// NewValidator creates a validator from raw OpenAPI v3 group-version documents
+// as served under /openapi/v3.
+func NewValidator(documents ...[]byte) (*Validator, error) {
+	v := &Validator{kinds: make(map[GroupVersionKind]kindSchema)}
+
+	for idx, data := range documents {
+		var doc document
+
+		err := json.Unmarshal(data, &doc)
+		if err != nil {
+			return nil, fmt.Errorf("decode OpenAPI document %d: %w", idx, err)
+		}
+
+		components := doc.Components.Schemas
+		for _, schema := range components {
+			for _, gvk := range schema.GroupVersionKinds {
+				v.kinds[gvk] = kindSchema{schema: schema, components: components}
+			}
+		}
+	}
+
+	return v, nil
+}
+
+// NewValidatorFromClient creates a validator from every group-version document
+// the cluster behind client serves, e.g. discovery.OpenAPIV3().
+func NewValidatorFromClient(client openapiclient.Client) (*Validator, error) {
+	paths, err := client.Paths()
+	if err != nil {
+		return nil, fmt.Errorf("list OpenAPI v3 paths: %w", err)
+	}
+
+	documents := make([][]byte, 0, len(paths))
+
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		data, err := paths[path].Schema(jsonContentType)
+		if err != nil {
+			return nil, fmt.Errorf("fetch OpenAPI v3 schema for %s: %w", path, err)
+		}
+
+		documents = append(documents, data)
+	}
+
+	return NewValidator(documents...)
+}
+
+// Knows reports whether the cluster serves a schema for apiVersion and kind.
+func (v *Validator) Knows(apiVersion, kind string) bool {
+	_, ok := v.kinds[parseGroupVersionKind(apiVersion, kind)]
+
+	return ok
+}
+
+// Validate checks a decoded resource against the schema of its apiVersion and
+// kind. Resources of a kind the cluster does not serve are left unchecked; use
+// Knows to tell them apart from valid resources.
+func (v *Validator) Validate(obj map[string]any) *validator.ValidationResult {
+	result := validator.NewValidationResult("")
+
+	if obj == nil {
+		result.AddError(validator.ValidationError{
+			Field:         "resource",
+			Message:       "resource is nil",
+			FixSuggestion: "Provide a Kubernetes resource with apiVersion and kind",
+		})
+
+		return result
+	}
+
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+
+	entry, ok := v.kinds[parseGroupVersionKind(apiVersion, kind)]
+	if !ok {
+		return result
+	}
+
+	w := &walker{components: entry.components, kind: kind, result: result}
+	w.validate("", obj, entry.schema)
+
+	return result
+}
+
+// parseGroupVersionKind splits apiVersion into group and version.
+func parseGroupVersionKind(apiVersion, kind string) GroupVersionKind {
+	group, version, found := strings.Cut(apiVersion, "/")
+	if !found {
+		group, version = "", apiVersion
+	}
+
+	return GroupVersionKind{Group: group, Version: version, Kind: kind}
+}
+
+// walker walks a resource and its schema side by side, recording violations.
+type walker struct {
+	components map[string]*Schema
+	kind       string
+	result     *validator.ValidationResult
+}
+
+// validate checks value at path against schema. Null values are accepted like
+// omitted fields, as the API server does.
+func (w *walker) validate(path string, value any, schema *Schema) {
+	schema = resolve(schema, w.components)
+	if schema == nil || value == nil {
+		return
+	}
+
+	for _, sub := range schema.AllOf {
+		w.validate(path, value, sub)
+	}
+
+	if !w.validateType(path, value, schema) {
+		return
+	}
+
+	if len(schema.Enum) > 0 && !containsValue(schema.Enum, value) {
+		w.result.AddError(validator.ValidationError{
+			Field:         path,
+			Message:       "unsupported value",
+			CurrentValue:  value,
+			ExpectedValue: schema.Enum,
+			FixSuggestion: fmt.Sprintf("Set %s to one of %s", path, formatValues(schema.Enum)),
+		})
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		w.validateObject(path, typed, schema)
+	case []any:
+		if schema.Items != nil {
+			for idx, item := range typed {
+				w.validate(path+"["+strconv.Itoa(idx)+"]", item, schema.Items)
+			}
+		}
+	}
+}
+
+// validateType reports a type mismatch and returns whether the value's type is
+// acceptable, so its content is only inspected when it is.
+func (w *walker) validateType(path string, value any, schema *Schema) bool {
+	expected := schema.Type
+
+	switch {
+	case schema.IntOrString:
+		expected = "integer or string"
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		expected = w.alternativeTypes(slices.Concat(schema.OneOf, schema.AnyOf))
+	}
+
+	if expected == "" {
+		return true
+	}
+
+	actual := jsonType(value)
+	for candidate := range strings.SplitSeq(expected, " or ") {
+		if candidate == actual || (candidate == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	w.result.AddError(validator.ValidationError{
+		Field:         path,
+		Message:       fmt.Sprintf("expected %s, got %s", expected, actual),
+		CurrentValue:  value,
+		ExpectedValue: expected,
+		FixSuggestion: fmt.Sprintf("Set %s to a value of type %s", displayPath(path), expected),
+	})
+
+	return false
+}
+
+// alternativeTypes joins the types of oneOf/anyOf alternatives with " or ",
+// returning "" when any alternative accepts every type.
+func (w *walker) alternativeTypes(alternatives []*Schema) string {
+	types := make([]string, 0, len(alternatives))
+
+	for _, alternative := range alternatives {
+		alternative = resolve(alternative, w.components)
+		if alternative == nil || alternative.Type == "" {
+			return ""
+		}
+
+		if !slices.Contains(types, alternative.Type) {
+			types = append(types, alternative.Type)
+		}
+	}
+
+	return strings.Join(types, " or ")
+}
+
+// validateObject checks the required fields of obj and validates each field
+// against its property schema, reporting fields the schema does not declare.
+func (w *walker) validateObject(path string, obj map[string]any, schema *Schema) {
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			field := joinPath(path, name)
+
+			w.result.AddError(validator.ValidationError{
+				Field:         field,
+				Message:       "required field is missing",
+				FixSuggestion: "Add " + field,
+			})
+		}
+	}
+
+	closed := len(schema.Properties) > 0 && !schema.PreserveUnknownFields &&
+		(schema.AdditionalProperties == nil || !schema.AdditionalProperties.Allows)
+
+	for _, name := range slices.Sorted(maps.Keys(obj)) {
+		field := joinPath(path, name)
+
+		if property, ok := schema.Properties[name]; ok {
+			w.validate(field, obj[name], property)
+
+			continue
+		}
+
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			w.validate(field, obj[name], schema.AdditionalProperties.Schema)
+
+			continue
+		}
+
+		if closed {
+			w.result.AddError(validator.ValidationError{
+				Field:        field,
+				Message:      "unknown field",
+				CurrentValue: obj[name],
+				FixSuggestion: fmt.Sprintf(
+					"Remove %s or check its spelling with 'ksail workload explain %s'",
+					field, explainPath(w.kind, path),
+				),
+			})
+		}
+	}
+}
+
+// jsonType returns the JSON schema type of a decoded value. Whole numbers are
+// integers, as YAML and JSON decoding produce float64 for every number.
+func jsonType(value any) string {
+	switch typed := value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int32, int64:
+		return "integer"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+
+		return "number"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// containsValue reports whether enum contains value, comparing numbers by value.
+func containsValue(enum []any, value any) bool {
+	for _, candidate := range enum {
+		if fmt.Sprint(candidate) == fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatValues renders enum values for a fix suggestion.
+func formatValues(values []any) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, fmt.Sprint(value))
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// joinPath appends a field name to a dotted field path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// displayPath names the resource itself when path is the root.
+func displayPath(path string) string {
+	if path == "" {
+		return "the resource"
+	}
+
+	return path
+}
+
+// explainPath builds the 'ksail workload explain' argument documenting the
+// fields of the object at path, dropping list indices it does not accept.
+func explainPath(kind, path string) string {
+	explain := strings.ToLower(kind)
+
+	for segment := range strings.SplitSeq(path, ".") {
+		if segment == "" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(segment, "[")
+		explain += "." + name
+	}
+
+	return explain
+}
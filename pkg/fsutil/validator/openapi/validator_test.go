@@ -0,0 +1,238 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil/validator/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/openapi/openapitest"
+	"sigs.k8s.io/yaml"
+)
+
+// widgetDocument is the OpenAPI v3 document an API server serves for the
+// example.com/v1 group once a Widget CRD is installed.
+const widgetDocument = `{
+  "components": {
+    "schemas": {
+      "com.example.v1.Widget": {
+        "type": "object",
+        "required": ["spec"],
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}]},
+          "spec": {
+            "type": "object",
+            "required": ["size"],
+            "properties": {
+              "size": {"type": "integer"},
+              "color": {"type": "string", "enum": ["red", "blue"]},
+              "port": {"x-kubernetes-int-or-string": true},
+              "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+              "extra": {"type": "object", "x-kubernetes-preserve-unknown-fields": true},
+              "parts": {
+                "type": "array",
+                "items": {"type": "object", "properties": {"name": {"type": "string"}}}
+              }
+            }
+          }
+        },
+        "x-kubernetes-group-version-kind": [{"group": "example.com", "version": "v1", "kind": "Widget"}]
+      },
+      "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+        }
+      }
+    }
+  }
+}`
+
+func newWidgetValidator(t *testing.T) *openapi.Validator {
+	t.Helper()
+
+	v, err := openapi.NewValidator([]byte(widgetDocument))
+	require.NoError(t, err)
+
+	return v
+}
+
+func decode(t *testing.T, manifest string) map[string]any {
+	t.Helper()
+
+	var obj map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(manifest), &obj))
+
+	return obj
+}
+
+func fields(result *validator.ValidationResult) map[string]string {
+	byField := make(map[string]string, len(result.Errors))
+	for _, validationErr := range result.Errors {
+		byField[validationErr.Field] = validationErr.Message
+	}
+
+	return byField
+}
+
+func TestValidate_ValidCustomResource(t *testing.T) {
+	t.Parallel()
+
+	result := newWidgetValidator(t).Validate(decode(t, `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gear
+  labels:
+    app: gear
+spec:
+  size: 3
+  color: red
+  port: http
+  labels:
+    tier: backend
+  extra:
+    anything: [1, 2]
+  parts:
+    - name: bolt
+`))
+
+	assert.True(t, result.Valid, "unexpected errors: %v", result.Errors)
+}
+
+func TestValidate_ReportsFieldLevelErrors(t *testing.T) {
+	t.Parallel()
+
+	result := newWidgetValidator(t).Validate(decode(t, `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gear
+  lables:
+    app: gear
+spec:
+  color: green
+  port: true
+  labels:
+    tier: 1
+  parts:
+    - name: bolt
+      size: 2
+`))
+
+	require.False(t, result.Valid)
+	assert.Equal(t, map[string]string{
+		"metadata.lables":    "unknown field",
+		"spec.size":          "required field is missing",
+		"spec.color":         "unsupported value",
+		"spec.port":          "expected integer or string, got boolean",
+		"spec.labels.tier":   "expected string, got integer",
+		"spec.parts[0].size": "unknown field",
+	}, fields(result))
+
+	for _, validationErr := range result.Errors {
+		assert.NotEmpty(t, validationErr.FixSuggestion, validationErr.Field)
+	}
+}
+
+func TestValidate_UnknownFieldSuggestsExplain(t *testing.T) {
+	t.Parallel()
+
+	result := newWidgetValidator(t).Validate(decode(t, `
+apiVersion: example.com/v1
+kind: Widget
+spec:
+  size: 1
+  parts:
+    - nmae: bolt
+`))
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "spec.parts[0].nmae", result.Errors[0].Field)
+	assert.Contains(t, result.Errors[0].FixSuggestion, "ksail workload explain widget.spec.parts")
+}
+
+func TestValidate_UnknownKindIsUnchecked(t *testing.T) {
+	t.Parallel()
+
+	v := newWidgetValidator(t)
+
+	assert.True(t, v.Knows("example.com/v1", "Widget"))
+	assert.False(t, v.Knows("example.com/v2", "Widget"))
+
+	result := v.Validate(decode(t, "apiVersion: example.com/v2\nkind: Widget\nspec: 1\n"))
+	assert.True(t, result.Valid)
+}
+
+func TestValidate_NilResource(t *testing.T) {
+	t.Parallel()
+
+	result := newWidgetValidator(t).Validate(nil)
+	assert.False(t, result.Valid)
+}
+
+func TestNewValidator_RejectsMalformedDocument(t *testing.T) {
+	t.Parallel()
+
+	_, err := openapi.NewValidator([]byte("{"))
+	require.Error(t, err)
+}
+
+// TestNewValidatorFromClient_BuiltInKinds validates against the OpenAPI v3
+// documents of a real API server, resolving the $ref and allOf chains they use.
+func TestNewValidatorFromClient_BuiltInKinds(t *testing.T) {
+	t.Parallel()
+
+	v, err := openapi.NewValidatorFromClient(openapitest.NewEmbeddedFileClient())
+	require.NoError(t, err)
+
+	valid := v.Validate(decode(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: web
+          image: nginx
+          ports:
+            - containerPort: 80
+          resources:
+            limits:
+              cpu: 500m
+              memory: 1
+`))
+	assert.True(t, valid.Valid, "unexpected errors: %v", valid.Errors)
+
+	invalid := v.Validate(decode(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: two
+  selector: {}
+  template:
+    spec:
+      containers:
+        - name: web
+          imagee: nginx
+`))
+	assert.Equal(t, map[string]string{
+		"spec.replicas": "expected integer, got string",
+		"spec.template.spec.containers[0].imagee": "unknown field",
+	}, fields(invalid))
+}
@@ -4,6 +4,8 @@
 //   - [WriteMessage] for displaying formatted messages with type-specific symbols and colors
 //   - [ProgressGroup] for parallel task execution with live progress indicators
 //   - [StageSeparatingWriter] for automatic blank line insertion between CLI stages
+//   - [Sink] implementations ([FileSink], [SyslogSink], [WebhookSink]) that receive a
+//     copy of every message and stage title once registered with [AddSink]
 //
 // Message types include success (✔), error (✗), warning (⚠), info (ℹ), activity (►),
 // generate (✚), and title messages with customizable emojis.
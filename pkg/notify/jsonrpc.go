@@ -0,0 +1,285 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// JSON-RPC methods and LSP message types emitted by JSONRPCWriter.
+const (
+	jsonrpcVersion         = "2.0"
+	jsonrpcProgressMethod  = "$/progress"
+	jsonrpcLogMethod       = "window/logMessage"
+	jsonrpcProgressPrefix  = "ksail/"
+	jsonrpcLogTypeError    = 1
+	jsonrpcLogTypeWarning  = 2
+	jsonrpcLogTypeInfo     = 3
+	jsonrpcLogTypeLog      = 4
+	jsonrpcProgressBegin   = "begin"
+	jsonrpcProgressReport  = "report"
+	jsonrpcProgressEnd     = "end"
+	jsonrpcProgressDone    = "done"
+	jsonrpcProgressFailed  = "failed"
+	emojiVariationSelector = "\uFE0F"
+)
+
+// ansiEscapePattern matches the SGR color sequences fatih/color may emit.
+//
+//nolint:gochecknoglobals // compiled once and read-only.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// JSONRPCWriter wraps an io.Writer and translates KSail's human-readable output into
+// Language Server Protocol style JSON-RPC 2.0 notifications, so an IDE extension can show
+// native progress UI for long-running commands.
+//
+// Each notification is framed with the LSP base protocol (a Content-Length header followed by
+// the JSON body), so it can be consumed directly by vscode-jsonrpc's StreamMessageReader.
+//
+// Lines are mapped by their leading symbol:
+//   - Title lines (emoji) end the current stage and begin a new $/progress token
+//     (ksail/1, ksail/2, ...).
+//   - Activity (►), generate (✚), and success (✔) lines report $/progress messages on the
+//     open stage.
+//   - Error (✗), warning (⚠), and info (ℹ) lines become window/logMessage notifications with the
+//     matching LSP message type; any other line is logged with type Log.
+//
+// Call Finish when the command returns to end the open stage.
+//
+// Usage:
+//
+//	writer := notify.NewJSONRPCWriter(cmd.OutOrStdout())
+//	cmd.SetOut(writer)
+//	err := run()
+//	_ = writer.Finish(err)
+type JSONRPCWriter struct {
+	underlying io.Writer
+	pending    []byte // Incomplete trailing line awaiting its newline
+	stage      int    // Number of stages begun so far
+	stageOpen  bool   // Whether the latest stage has not ended yet
+
+	mu sync.Mutex
+}
+
+// NewJSONRPCWriter creates a new JSONRPCWriter wrapping the given writer.
+func NewJSONRPCWriter(underlying io.Writer) *JSONRPCWriter {
+	return &JSONRPCWriter{
+		underlying: underlying,
+	}
+}
+
+// Write implements io.Writer. Complete lines are translated into notifications; a trailing
+// partial line is buffered until its newline arrives. Continuation lines (indented lines of a
+// multi-line message) are folded into the message they belong to.
+func (w *JSONRPCWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, data...)
+
+	lastNewline := bytes.LastIndexByte(w.pending, '\n')
+	if lastNewline < 0 {
+		return len(data), nil
+	}
+
+	complete := string(w.pending[:lastNewline])
+	w.pending = append(w.pending[:0], w.pending[lastNewline+1:]...)
+
+	err := w.writeLines(strings.Split(complete, "\n"))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// Finish flushes any buffered partial line and ends the open stage. When cmdErr is non-nil the
+// stage ends as failed and the error is logged, so the client can surface it.
+func (w *JSONRPCWriter) Finish(cmdErr error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) > 0 {
+		line := string(w.pending)
+		w.pending = w.pending[:0]
+
+		err := w.writeLines([]string{line})
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmdErr != nil {
+		err := w.notify(jsonrpcLogMethod, logMessageParams{
+			Type:    jsonrpcLogTypeError,
+			Message: cmdErr.Error(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return w.endStage(jsonrpcProgressFailed)
+	}
+
+	return w.endStage(jsonrpcProgressDone)
+}
+
+// --- internals ---
+
+// progressParams is the params object of a $/progress notification.
+type progressParams struct {
+	Token string        `json:"token"`
+	Value progressValue `json:"value"`
+}
+
+// progressValue is an LSP WorkDoneProgressBegin, WorkDoneProgressReport, or WorkDoneProgressEnd.
+type progressValue struct {
+	Kind    string `json:"kind"`
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// logMessageParams is the params object of a window/logMessage notification.
+type logMessageParams struct {
+	Type    int    `json:"type"`
+	Message string `json:"message"`
+}
+
+// jsonrpcNotification is a JSON-RPC 2.0 notification (a request without an id).
+type jsonrpcNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// writeLines groups lines into messages and translates each one.
+func (w *JSONRPCWriter) writeLines(lines []string) error {
+	var messages []string
+
+	for _, line := range lines {
+		line = strings.TrimRight(ansiEscapePattern.ReplaceAllString(line, ""), " \r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		isContinuation := strings.HasPrefix(line, symbolIndent)
+		if isContinuation && len(messages) > 0 {
+			messages[len(messages)-1] += "\n" + strings.TrimPrefix(line, symbolIndent)
+
+			continue
+		}
+
+		messages = append(messages, strings.TrimLeft(line, " "))
+	}
+
+	for _, message := range messages {
+		err := w.writeMessage(message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeMessage translates a single (possibly multi-line) message based on its leading symbol.
+func (w *JSONRPCWriter) writeMessage(message string) error {
+	if startsWithEmoji([]byte(message)) {
+		return w.beginStage(stripSymbol(message))
+	}
+
+	firstRune, _ := utf8.DecodeRuneInString(message)
+
+	switch firstRune {
+	case '✗':
+		return w.log(jsonrpcLogTypeError, stripSymbol(message))
+	case '⚠':
+		return w.log(jsonrpcLogTypeWarning, stripSymbol(message))
+	case 'ℹ':
+		return w.log(jsonrpcLogTypeInfo, stripSymbol(message))
+	case '►', '✚', '✔':
+		if !w.stageOpen {
+			return w.log(jsonrpcLogTypeInfo, stripSymbol(message))
+		}
+
+		return w.notify(jsonrpcProgressMethod, progressParams{
+			Token: w.token(),
+			Value: progressValue{Kind: jsonrpcProgressReport, Message: stripSymbol(message)},
+		})
+	default:
+		return w.log(jsonrpcLogTypeLog, message)
+	}
+}
+
+// beginStage ends the open stage, if any, and begins a new one titled title.
+func (w *JSONRPCWriter) beginStage(title string) error {
+	err := w.endStage(jsonrpcProgressDone)
+	if err != nil {
+		return err
+	}
+
+	w.stage++
+	w.stageOpen = true
+
+	return w.notify(jsonrpcProgressMethod, progressParams{
+		Token: w.token(),
+		Value: progressValue{Kind: jsonrpcProgressBegin, Title: title},
+	})
+}
+
+// endStage ends the open stage with message. It is a no-op when no stage is open.
+func (w *JSONRPCWriter) endStage(message string) error {
+	if !w.stageOpen {
+		return nil
+	}
+
+	w.stageOpen = false
+
+	return w.notify(jsonrpcProgressMethod, progressParams{
+		Token: w.token(),
+		Value: progressValue{Kind: jsonrpcProgressEnd, Message: message},
+	})
+}
+
+// log emits a window/logMessage notification.
+func (w *JSONRPCWriter) log(messageType int, message string) error {
+	return w.notify(jsonrpcLogMethod, logMessageParams{Type: messageType, Message: message})
+}
+
+// token returns the progress token of the current stage.
+func (w *JSONRPCWriter) token() string {
+	return fmt.Sprintf("%s%d", jsonrpcProgressPrefix, w.stage)
+}
+
+// notify writes one Content-Length framed JSON-RPC notification.
+func (w *JSONRPCWriter) notify(method string, params any) error {
+	body, err := json.Marshal(jsonrpcNotification{
+		JSONRPC: jsonrpcVersion,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s notification: %w", method, err)
+	}
+
+	_, err = fmt.Fprintf(w.underlying, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	if err != nil {
+		return fmt.Errorf("failed to write %s notification: %w", method, err)
+	}
+
+	return nil
+}
+
+// stripSymbol removes the leading symbol or emoji (and any emoji variation selector) of a line.
+func stripSymbol(message string) string {
+	_, size := utf8.DecodeRuneInString(message)
+
+	rest := strings.TrimPrefix(message[size:], emojiVariationSelector)
+
+	return strings.TrimLeft(rest, " ")
+}
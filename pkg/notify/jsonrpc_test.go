@@ -0,0 +1,159 @@
+package notify_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcNotification is a decoded JSON-RPC notification written by JSONRPCWriter.
+type rpcNotification struct {
+	JSONRPC string         `json:"jsonrpc"`
+	Method  string         `json:"method"`
+	Params  map[string]any `json:"params"`
+}
+
+// decodeNotifications parses the Content-Length framed stream written by JSONRPCWriter.
+func decodeNotifications(t *testing.T, stream []byte) []rpcNotification {
+	t.Helper()
+
+	reader := bufio.NewReader(bytes.NewReader(stream))
+
+	var notifications []rpcNotification
+
+	for {
+		header, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			return notifications
+		}
+
+		require.NoError(t, err)
+
+		var length int
+
+		_, err = fmt.Sscanf(header, "Content-Length: %d\r\n", &length)
+		require.NoError(t, err)
+
+		separator, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, "\r\n", separator)
+
+		body := make([]byte, length)
+
+		_, err = io.ReadFull(reader, body)
+		require.NoError(t, err)
+
+		var notification rpcNotification
+
+		require.NoError(t, json.Unmarshal(body, &notification))
+		assert.Equal(t, "2.0", notification.JSONRPC)
+
+		notifications = append(notifications, notification)
+	}
+}
+
+func TestJSONRPCWriter_TranslatesStagesIntoProgress(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	writer := notify.NewJSONRPCWriter(&buf)
+
+	notify.Titlef(writer, "🚀", "Create cluster...")
+	notify.Activityf(writer, "creating cluster")
+	notify.Successf(writer, "cluster created")
+	notify.Titlef(writer, "⚙️", "Configure...")
+	notify.Warningf(writer, "slow start")
+
+	require.NoError(t, writer.Finish(nil))
+
+	got := decodeNotifications(t, buf.Bytes())
+	require.Len(t, got, 7)
+
+	assert.Equal(t, "$/progress", got[0].Method)
+	assert.Equal(t, "ksail/1", got[0].Params["token"])
+	assert.Equal(
+		t,
+		map[string]any{"kind": "begin", "title": "Create cluster..."},
+		got[0].Params["value"],
+	)
+	assert.Equal(
+		t,
+		map[string]any{"kind": "report", "message": "creating cluster"},
+		got[1].Params["value"],
+	)
+	assert.Equal(
+		t,
+		map[string]any{"kind": "report", "message": "cluster created"},
+		got[2].Params["value"],
+	)
+	assert.Equal(t, map[string]any{"kind": "end", "message": "done"}, got[3].Params["value"])
+	assert.Equal(t, "ksail/2", got[4].Params["token"])
+	assert.Equal(
+		t,
+		map[string]any{"kind": "begin", "title": "Configure..."},
+		got[4].Params["value"],
+	)
+	assert.Equal(t, "window/logMessage", got[5].Method)
+	assert.Equal(t, map[string]any{"type": float64(2), "message": "slow start"}, got[5].Params)
+	assert.Equal(t, "ksail/2", got[6].Params["token"])
+	assert.Equal(t, map[string]any{"kind": "end", "message": "done"}, got[6].Params["value"])
+}
+
+func TestJSONRPCWriter_FinishWithErrorFailsOpenStage(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	writer := notify.NewJSONRPCWriter(&buf)
+
+	notify.Titlef(writer, "🗑️", "Delete cluster...")
+
+	require.NoError(t, writer.Finish(errors.New("boom")))
+
+	got := decodeNotifications(t, buf.Bytes())
+	require.Len(t, got, 3)
+
+	assert.Equal(t, "window/logMessage", got[1].Method)
+	assert.Equal(t, map[string]any{"type": float64(1), "message": "boom"}, got[1].Params)
+	assert.Equal(t, map[string]any{"kind": "end", "message": "failed"}, got[2].Params["value"])
+}
+
+func TestJSONRPCWriter_LogsLinesOutsideStagesAndFoldsContinuations(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	writer := notify.NewJSONRPCWriter(&buf)
+
+	notify.Errorf(writer, "first line\nsecond line")
+	notify.Successf(writer, "done")
+
+	_, err := writer.Write([]byte("plain "))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("output"))
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Finish(nil))
+
+	got := decodeNotifications(t, buf.Bytes())
+	require.Len(t, got, 3)
+
+	assert.Equal(
+		t,
+		map[string]any{"type": float64(1), "message": "first line\nsecond line"},
+		got[0].Params,
+	)
+	assert.Equal(t, map[string]any{"type": float64(3), "message": "done"}, got[1].Params)
+	assert.Equal(t, map[string]any{"type": float64(4), "message": "plain output"}, got[2].Params)
+	assert.False(t, strings.Contains(buf.String(), "\x1b["), "color codes must be stripped")
+}
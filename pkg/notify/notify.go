@@ -174,6 +174,8 @@ func WriteMessage(msg Message) {
 		content = fmt.Sprintf(msg.Content, msg.Args...)
 	}
 
+	recordToSinks(msg.Type, msg.Emoji, content)
+
 	// Get message configuration based on type
 	config := getMessageConfig(msg.Type)
 
@@ -70,6 +70,9 @@ type ProgressTask struct {
 	Name string
 	// Fn is the function to execute. It receives a context for cancellation.
 	Fn func(ctx context.Context) error
+	// Weight classifies how heavily the task loads the host (default
+	// WeightLight); see WithResourceLimits.
+	Weight TaskWeight
 }
 
 // ProgressGroup manages parallel execution of tasks with synchronized progress output.
@@ -125,6 +128,7 @@ type ProgressGroup struct {
 
 	// Execution and output options
 	concurrency     int             // Max parallel goroutines (0 = unlimited)
+	slots           *Slots          // Per-weight limits for heavy tasks (nil = unlimited)
 	completedCount  int             // Number of completed tasks (for summary line)
 	failedCount     int             // Number of failed tasks (for summary line)
 	continueOnError bool            // Don't cancel other tasks when one fails
@@ -328,6 +332,10 @@ func (pg *ProgressGroup) Run(ctx context.Context, tasks ...ProgressTask) error {
 		return nil
 	}
 
+	if pg.slots == nil {
+		pg.slots = SlotsFromContext(ctx)
+	}
+
 	// Initialize task status and order
 	for _, task := range tasks {
 		pg.taskStatus[task.Name] = taskPending
@@ -342,6 +350,7 @@ func (pg *ProgressGroup) Run(ctx context.Context, tasks ...ProgressTask) error {
 	// Print title (StageSeparatingWriter handles leading newlines automatically)
 	if pg.title != "" {
 		_, _ = fmt.Fprintf(pg.writer, "%s %s...\n", pg.emoji, pg.title)
+		recordToSinks(TitleType, pg.emoji, pg.title+"...")
 	}
 
 	// Use different modes for TTY vs non-TTY
@@ -543,16 +552,25 @@ func (pg *ProgressGroup) finalizeStreamingOutput() {
 // runTask executes a single task with state tracking: pending→running→complete/failed.
 // Returns a labeled error on failure.
 func (pg *ProgressGroup) runTask(ctx context.Context, task ProgressTask) error {
+	release, err := pg.slots.Acquire(ctx, task.Weight)
+	if err != nil {
+		return fmt.Errorf("%s: %w", task.Name, err)
+	}
+
+	defer release()
+
 	pg.setTaskState(task.Name, taskRunning)
 
-	err := task.Fn(ctx)
+	err = task.Fn(ctx)
 	if err != nil {
 		pg.setTaskState(task.Name, taskFailed)
+		recordToSinks(ErrorType, "", fmt.Sprintf("%s failed: %v", task.Name, err))
 
 		return fmt.Errorf("%s: %w", task.Name, err)
 	}
 
 	pg.setTaskState(task.Name, taskComplete)
+	recordToSinks(SuccessType, "", task.Name+" "+pg.labels.Completed)
 
 	return nil
 }
@@ -619,6 +637,13 @@ func (pg *ProgressGroup) runCIWorker(
 	allErrs *[]error,
 ) func() error {
 	return func() error {
+		release, err := pg.slots.Acquire(ctx, task.Weight)
+		if err != nil {
+			return fmt.Errorf("%s: %w", task.Name, err)
+		}
+
+		defer release()
+
 		pg.setTaskState(task.Name, taskRunning)
 
 		if !pg.appendOnly {
@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// TaskWeight classifies how heavily a ProgressTask loads the host. Heavy tasks
+// are additionally bounded by the ResourceLimits set with WithResourceLimits,
+// so that, for example, parallel image pulls do not saturate a small CI runner.
+type TaskWeight int
+
+const (
+	// WeightLight is the default weight. Light tasks are only bounded by
+	// WithConcurrency.
+	WeightLight TaskWeight = iota
+	// WeightNetwork marks tasks dominated by downloads, such as pulling images
+	// or fetching Helm charts.
+	WeightNetwork
+	// WeightCPU marks tasks that keep the host's CPUs busy, such as booting
+	// nodes or starting large controllers.
+	WeightCPU
+)
+
+const (
+	// cpusPerHeavyTask is the number of host CPUs reserved per running
+	// CPU-heavy task.
+	cpusPerHeavyTask = 2
+	// memoryPerHeavyTask is the host memory reserved per running CPU-heavy
+	// task (4 GiB).
+	memoryPerHeavyTask = 4 << 30
+	// maxNetworkTasks caps concurrent network-heavy tasks on large hosts,
+	// where bandwidth rather than CPU becomes the bottleneck.
+	maxNetworkTasks = 4
+)
+
+// ResourceLimits caps how many tasks of each heavy weight run at once. A zero
+// limit leaves that weight unbounded.
+type ResourceLimits struct {
+	// Network is the maximum number of concurrent WeightNetwork tasks.
+	Network int
+	// CPU is the maximum number of concurrent WeightCPU tasks.
+	CPU int
+}
+
+// ResourceLimitsForHost derives ResourceLimits from the host's CPU count and
+// memory: one CPU-heavy task per two CPUs and per 4 GiB of memory, and one
+// network-heavy task per CPU up to four, but always at least one of each.
+// Unknown values (zero or less) leave the matching limits unbounded.
+func ResourceLimitsForHost(cpus int, memoryBytes int64) ResourceLimits {
+	var limits ResourceLimits
+
+	if cpus > 0 {
+		limits.CPU = max(1, cpus/cpusPerHeavyTask)
+		limits.Network = min(cpus, maxNetworkTasks)
+	}
+
+	if memoryBytes > 0 {
+		memorySlots := max(1, int(memoryBytes/memoryPerHeavyTask))
+		if limits.CPU == 0 || memorySlots < limits.CPU {
+			limits.CPU = memorySlots
+		}
+	}
+
+	return limits
+}
+
+// WithResourceLimits bounds how many heavy tasks of each TaskWeight run at
+// once, in addition to the overall WithConcurrency limit. A task waiting for
+// a slot is shown as pending.
+func WithResourceLimits(limits ResourceLimits) ProgressOption {
+	return WithSlots(NewSlots(limits))
+}
+
+// WithSlots bounds the heavy tasks of the group by slots, shared with any other
+// work that acquires from them. A nil slots falls back to the Slots of the
+// context passed to Run, if any.
+func WithSlots(slots *Slots) ProgressOption {
+	return func(pg *ProgressGroup) {
+		pg.slots = slots
+	}
+}
+
+// Slots holds one counting semaphore per bounded TaskWeight. A single Slots
+// shared across a cluster operation bounds its heavy work as a whole: node
+// boots and image pulls in the provisioner as well as component installs. A
+// nil *Slots bounds nothing.
+type Slots struct {
+	weights map[TaskWeight]chan struct{}
+}
+
+// NewSlots creates the semaphores for the non-zero limits.
+func NewSlots(limits ResourceLimits) *Slots {
+	slots := &Slots{weights: make(map[TaskWeight]chan struct{})}
+
+	if limits.Network > 0 {
+		slots.weights[WeightNetwork] = make(chan struct{}, limits.Network)
+	}
+
+	if limits.CPU > 0 {
+		slots.weights[WeightCPU] = make(chan struct{}, limits.CPU)
+	}
+
+	return slots
+}
+
+// Acquire blocks until a slot for weight is free and returns the function
+// releasing it. Weights without a limit return immediately.
+func (s *Slots) Acquire(ctx context.Context, weight TaskWeight) (func(), error) {
+	if s == nil {
+		return func() {}, nil
+	}
+
+	slot, ok := s.weights[weight]
+	if !ok {
+		return func() {}, nil
+	}
+
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for a free task slot: %w", ctx.Err())
+	}
+
+	// A slot freed by a task that stopped on cancellation races ctx.Done in
+	// the select above; do not start new work once the context is done.
+	err := ctx.Err()
+	if err != nil {
+		<-slot
+
+		return nil, fmt.Errorf("wait for a free task slot: %w", err)
+	}
+
+	return func() { <-slot }, nil
+}
+
+// slotsContextKey is the context key ContextWithSlots stores Slots under.
+type slotsContextKey struct{}
+
+// ContextWithSlots returns a copy of ctx carrying slots, so heavy work deeper
+// in the call chain (provisioners, image pulls, progress groups) shares them.
+// A nil slots returns ctx unchanged.
+func ContextWithSlots(ctx context.Context, slots *Slots) context.Context {
+	if slots == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, slotsContextKey{}, slots)
+}
+
+// SlotsFromContext returns the Slots carried by ctx, or nil.
+func SlotsFromContext(ctx context.Context) *Slots {
+	if ctx == nil {
+		return nil
+	}
+
+	slots, _ := ctx.Value(slotsContextKey{}).(*Slots)
+
+	return slots
+}
+
+// AcquireSlot acquires a slot for weight from the Slots carried by ctx. Without
+// Slots in ctx it returns immediately.
+func AcquireSlot(ctx context.Context, weight TaskWeight) (func(), error) {
+	return SlotsFromContext(ctx).Acquire(ctx, weight)
+}
@@ -0,0 +1,255 @@
+package notify_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+)
+
+func TestResourceLimitsForHost(t *testing.T) {
+	t.Parallel()
+
+	const gib = int64(1) << 30
+
+	tests := []struct {
+		name        string
+		cpus        int
+		memoryBytes int64
+		want        notify.ResourceLimits
+	}{
+		{
+			name:        "small CI runner",
+			cpus:        2,
+			memoryBytes: 7 * gib,
+			want:        notify.ResourceLimits{Network: 2, CPU: 1},
+		},
+		{
+			name:        "workstation",
+			cpus:        16,
+			memoryBytes: 64 * gib,
+			want:        notify.ResourceLimits{Network: 4, CPU: 8},
+		},
+		{
+			name:        "memory bound",
+			cpus:        8,
+			memoryBytes: 8 * gib,
+			want:        notify.ResourceLimits{Network: 4, CPU: 2},
+		},
+		{
+			name: "single CPU, unknown memory",
+			cpus: 1,
+			want: notify.ResourceLimits{Network: 1, CPU: 1},
+		},
+		{
+			name: "unknown host",
+			want: notify.ResourceLimits{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := notify.ResourceLimitsForHost(test.cpus, test.memoryBytes)
+			if got != test.want {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+// concurrencyProbe records the peak number of concurrently running tasks per
+// weight.
+type concurrencyProbe struct {
+	mu     sync.Mutex
+	active map[notify.TaskWeight]int
+	peak   map[notify.TaskWeight]int
+}
+
+func (p *concurrencyProbe) task(name string, weight notify.TaskWeight) notify.ProgressTask {
+	return notify.ProgressTask{
+		Name:   name,
+		Weight: weight,
+		Fn: func(_ context.Context) error {
+			p.mu.Lock()
+			p.active[weight]++
+			p.peak[weight] = max(p.peak[weight], p.active[weight])
+			p.mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			p.mu.Lock()
+			p.active[weight]--
+			p.mu.Unlock()
+
+			return nil
+		},
+	}
+}
+
+// TestProgressGroup_WithResourceLimits verifies that heavy tasks are bounded
+// per weight while light tasks keep running in parallel.
+func TestProgressGroup_WithResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	for _, continueOnError := range []bool{false, true} {
+		probe := &concurrencyProbe{
+			active: make(map[notify.TaskWeight]int),
+			peak:   make(map[notify.TaskWeight]int),
+		}
+
+		opts := []notify.ProgressOption{
+			notify.WithResourceLimits(notify.ResourceLimits{Network: 2, CPU: 1}),
+		}
+		if continueOnError {
+			opts = append(opts, notify.WithContinueOnError())
+		}
+
+		var buf bytes.Buffer
+
+		err := notify.NewProgressGroup("Installing", "📦", &buf, opts...).Run(
+			context.Background(),
+			probe.task("boot-1", notify.WeightCPU),
+			probe.task("boot-2", notify.WeightCPU),
+			probe.task("boot-3", notify.WeightCPU),
+			probe.task("pull-1", notify.WeightNetwork),
+			probe.task("pull-2", notify.WeightNetwork),
+			probe.task("pull-3", notify.WeightNetwork),
+			probe.task("pull-4", notify.WeightNetwork),
+			probe.task("config-1", notify.WeightLight),
+			probe.task("config-2", notify.WeightLight),
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if probe.peak[notify.WeightCPU] != 1 {
+			t.Errorf(
+				"expected at most 1 concurrent CPU-heavy task, saw %d",
+				probe.peak[notify.WeightCPU],
+			)
+		}
+
+		if probe.peak[notify.WeightNetwork] > 2 {
+			t.Errorf(
+				"expected at most 2 concurrent network-heavy tasks, saw %d",
+				probe.peak[notify.WeightNetwork],
+			)
+		}
+
+		if probe.peak[notify.WeightLight] != 2 {
+			t.Errorf(
+				"expected light tasks to run in parallel, saw %d",
+				probe.peak[notify.WeightLight],
+			)
+		}
+	}
+}
+
+// TestProgressGroup_WithResourceLimits_Cancelled verifies that a task waiting
+// for a heavy slot gives up, instead of starting, when the context is
+// cancelled.
+func TestProgressGroup_WithResourceLimits_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		startOnce sync.Once
+		mu        sync.Mutex
+		runs      int
+	)
+
+	started := make(chan struct{})
+	holdSlot := func(taskCtx context.Context) error {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+
+		startOnce.Do(func() { close(started) })
+		<-taskCtx.Done()
+
+		return taskCtx.Err()
+	}
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	var buf bytes.Buffer
+
+	err := notify.NewProgressGroup(
+		"Installing", "📦", &buf,
+		notify.WithResourceLimits(notify.ResourceLimits{CPU: 1}),
+	).Run(ctx,
+		notify.ProgressTask{Name: "boot-1", Weight: notify.WeightCPU, Fn: holdSlot},
+		notify.ProgressTask{Name: "boot-2", Weight: notify.WeightCPU, Fn: holdSlot},
+	)
+	if err == nil {
+		t.Fatal("expected an error after cancellation")
+	}
+
+	if runs != 1 {
+		t.Errorf("expected only the task holding the CPU slot to run, %d ran", runs)
+	}
+}
+
+// TestSlots_SharedThroughContext verifies that work acquiring from the Slots
+// carried by a context and a progress group run with that context draw from
+// the same semaphores.
+func TestSlots_SharedThroughContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := notify.ContextWithSlots(context.Background(), notify.NewSlots(notify.ResourceLimits{CPU: 1}))
+
+	release, err := notify.AcquireSlot(ctx, notify.WeightCPU)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		var buf bytes.Buffer
+
+		done <- notify.NewProgressGroup("Installing", "📦", &buf).Run(ctx,
+			notify.ProgressTask{Name: "boot", Weight: notify.WeightCPU, Fn: func(context.Context) error {
+				return nil
+			}},
+		)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("the task ran while the only CPU slot was held outside the group")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+
+	err = <-done
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+}
+
+func TestAcquireSlot_WithoutSlots(t *testing.T) {
+	t.Parallel()
+
+	if notify.SlotsFromContext(context.Background()) != nil {
+		t.Fatal("expected no slots in a plain context")
+	}
+
+	release, err := notify.AcquireSlot(context.Background(), notify.WeightCPU)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	release()
+}
@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Record is a message as delivered to a [Sink]: its type and plain content
+// without symbols or colors, stamped with the time it was written.
+type Record struct {
+	Time    time.Time
+	Type    MessageType
+	Emoji   string
+	Content string
+}
+
+// Sink receives a copy of every message written with [WriteMessage], in
+// addition to the message's own writer. Sinks keep a durable record of an
+// operation, such as a log file, syslog, or a webhook.
+type Sink interface {
+	// Write records a message. Errors are reported on stderr and do not
+	// interrupt the operation.
+	Write(record Record) error
+	// Close flushes and releases the sink once the operation finishes.
+	Close() error
+}
+
+//nolint:gochecknoglobals // process-wide sink registry shared by every WriteMessage call
+var (
+	sinksMu sync.Mutex
+	sinks   []Sink
+)
+
+// AddSink registers sink to receive every subsequent message until
+// [CloseSinks] is called.
+func AddSink(sink Sink) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+
+	sinks = append(sinks, sink)
+}
+
+// CloseSinks closes and unregisters every registered sink. It returns the
+// joined errors of the sinks that failed to close.
+func CloseSinks() error {
+	sinksMu.Lock()
+
+	closing := sinks
+	sinks = nil
+
+	sinksMu.Unlock()
+
+	errs := make([]error, 0, len(closing))
+
+	for _, sink := range closing {
+		err := sink.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RecordToSinks records a message in the registered sinks without printing
+// it, for messages printed by other means, such as the final command error.
+func RecordToSinks(msgType MessageType, content string) {
+	recordToSinks(msgType, "", content)
+}
+
+// recordToSinks delivers a message to every registered sink.
+func recordToSinks(msgType MessageType, emoji, content string) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+
+	if len(sinks) == 0 {
+		return
+	}
+
+	record := Record{Time: time.Now(), Type: msgType, Emoji: emoji, Content: content}
+
+	for _, sink := range sinks {
+		err := sink.Write(record)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "notify: failed to write message to sink: %v\n", err)
+		}
+	}
+}
+
+// String returns the lowercase name of the message type, as used by sinks.
+func (t MessageType) String() string {
+	switch t {
+	case ErrorType:
+		return "error"
+	case WarningType:
+		return "warning"
+	case ActivityType:
+		return "activity"
+	case GenerateType:
+		return "generate"
+	case SuccessType:
+		return "success"
+	case InfoType:
+		return "info"
+	case TitleType:
+		return "title"
+	default:
+		return "unknown"
+	}
+}
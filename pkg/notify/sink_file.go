@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileSinkDirPerm is the permission of directories created for a log file.
+const fileSinkDirPerm = 0o750
+
+// fileSinkFilePerm is the permission of a created log file.
+const fileSinkFilePerm = 0o600
+
+// FileSink appends every message as a timestamped plain-text line to a file.
+type FileSink struct {
+	file *os.File
+}
+
+// NewFileSink opens path for appending, creating it and its parent
+// directories when they do not exist.
+func NewFileSink(path string) (*FileSink, error) {
+	err := os.MkdirAll(filepath.Dir(path), fileSinkDirPerm)
+	if err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+
+	//nolint:gosec // path comes from the user's ksail.yaml
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fileSinkFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("open log file: %w", err)
+	}
+
+	return &FileSink{file: file}, nil
+}
+
+// Write appends record as "<RFC 3339 time> <type> <content>". Continuation
+// lines of multi-line content are indented.
+func (s *FileSink) Write(record Record) error {
+	content := record.Content
+	if record.Emoji != "" {
+		content = record.Emoji + " " + content
+	}
+
+	_, err := fmt.Fprintf(
+		s.file,
+		"%s %-8s %s\n",
+		record.Time.UTC().Format(time.RFC3339),
+		record.Type,
+		strings.ReplaceAll(strings.TrimRight(content, "\n"), "\n", "\n  "),
+	)
+	if err != nil {
+		return fmt.Errorf("write log file: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the log file.
+func (s *FileSink) Close() error {
+	err := s.file.Close()
+	if err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+//go:build windows || plan9
+
+package notify
+
+import "errors"
+
+// ErrSyslogNotSupported is returned when a syslog sink is requested on a
+// platform without syslog.
+var ErrSyslogNotSupported = errors.New("syslog is not supported on this platform")
+
+// SyslogSink is unavailable on this platform.
+type SyslogSink struct{}
+
+// NewSyslogSink always fails with [ErrSyslogNotSupported].
+func NewSyslogSink(_ string) (*SyslogSink, error) {
+	return nil, ErrSyslogNotSupported
+}
+
+// Write does nothing.
+func (s *SyslogSink) Write(_ Record) error {
+	return nil
+}
+
+// Close does nothing.
+func (s *SyslogSink) Close() error {
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build !windows && !plan9
+
+package notify
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogSink sends every message to the local syslog daemon, mapping error
+// and warning messages to the matching syslog priorities and everything else
+// to info.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink connects to the local syslog daemon, tagging messages with tag.
+func NewSyslogSink(tag string) (*SyslogSink, error) {
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, fmt.Errorf("connect to syslog: %w", err)
+	}
+
+	return &SyslogSink{writer: writer}, nil
+}
+
+// Write sends record to syslog.
+func (s *SyslogSink) Write(record Record) error {
+	var err error
+
+	switch record.Type {
+	case ErrorType:
+		err = s.writer.Err(record.Content)
+	case WarningType:
+		err = s.writer.Warning(record.Content)
+	default:
+		err = s.writer.Info(record.Content)
+	}
+
+	if err != nil {
+		return fmt.Errorf("write syslog: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the syslog connection.
+func (s *SyslogSink) Close() error {
+	err := s.writer.Close()
+	if err != nil {
+		return fmt.Errorf("close syslog: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package notify_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSinkClose = errors.New("close failed")
+
+type recordingSink struct {
+	records  []notify.Record
+	closed   bool
+	closeErr error
+}
+
+func (s *recordingSink) Write(record notify.Record) error {
+	s.records = append(s.records, record)
+
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+
+	return s.closeErr
+}
+
+//nolint:paralleltest // mutates the process-wide sink registry
+func TestSinks_ReceiveMessages(t *testing.T) {
+	sink := &recordingSink{}
+	failing := &recordingSink{closeErr: errSinkClose}
+
+	notify.AddSink(sink)
+	notify.AddSink(failing)
+
+	notify.Titlef(io.Discard, "🚀", "Create cluster...")
+	notify.Successf(io.Discard, "created %s", "kind")
+	notify.RecordToSinks(notify.ErrorType, "boom")
+
+	err := notify.CloseSinks()
+	require.ErrorIs(t, err, errSinkClose)
+
+	notify.Infof(io.Discard, "after close")
+
+	require.Len(t, sink.records, 3)
+	assert.Equal(t, notify.TitleType, sink.records[0].Type)
+	assert.Equal(t, "🚀", sink.records[0].Emoji)
+	assert.Equal(t, "Create cluster...", sink.records[0].Content)
+	assert.Equal(t, notify.SuccessType, sink.records[1].Type)
+	assert.Equal(t, "created kind", sink.records[1].Content)
+	assert.Equal(t, "boom", sink.records[2].Content)
+	assert.True(t, sink.closed)
+	assert.True(t, failing.closed)
+}
+
+func TestFileSink(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "logs", "create.log")
+
+	sink, err := notify.NewFileSink(path)
+	require.NoError(t, err)
+
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, sink.Write(notify.Record{Time: at, Type: notify.TitleType, Emoji: "🚀", Content: "Create"}))
+	require.NoError(t, sink.Write(notify.Record{Time: at, Type: notify.ErrorType, Content: "line one\nline two"}))
+	require.NoError(t, sink.Close())
+
+	data, err := os.ReadFile(path) //nolint:gosec // test-controlled path
+	require.NoError(t, err)
+	assert.Equal(t,
+		"2026-10-18T12:00:00Z title    🚀 Create\n"+
+			"2026-10-18T12:00:00Z error    line one\n  line two\n",
+		string(data),
+	)
+}
+
+func TestWebhookSink(t *testing.T) {
+	t.Parallel()
+
+	var payload notify.WebhookPayload
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := notify.NewWebhookSink(server.URL, "ksail cluster create")
+
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, sink.Write(notify.Record{Time: at, Type: notify.SuccessType, Content: "cluster created"}))
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, notify.WebhookPayload{
+		Operation: "ksail cluster create",
+		Records:   []notify.WebhookRecord{{Time: at, Type: "success", Content: "cluster created"}},
+	}, payload)
+}
+
+func TestWebhookSink_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := notify.NewWebhookSink(server.URL, "ksail workload apply").Close()
+	require.ErrorContains(t, err, "500")
+}
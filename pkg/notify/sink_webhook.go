@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// webhookTimeout bounds the webhook request made when the sink closes.
+const webhookTimeout = 10 * time.Second
+
+// errWebhookStatus is returned when the webhook responds with a non-2xx status.
+var errWebhookStatus = errors.New("webhook returned unexpected status")
+
+// WebhookPayload is the JSON document a [WebhookSink] posts.
+type WebhookPayload struct {
+	// Operation is the command that ran, e.g. "ksail cluster create".
+	Operation string `json:"operation"`
+	// Records are the operation's messages in the order they were written.
+	Records []WebhookRecord `json:"records"`
+}
+
+// WebhookRecord is a single message in a [WebhookPayload].
+type WebhookRecord struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Content string    `json:"content"`
+}
+
+// WebhookSink collects the messages of an operation and posts them as one
+// JSON [WebhookPayload] to a URL when it closes, so a single request records
+// the whole operation.
+type WebhookSink struct {
+	url       string
+	operation string
+	client    *http.Client
+	records   []WebhookRecord
+}
+
+// NewWebhookSink creates a sink posting the messages of operation to url.
+func NewWebhookSink(url, operation string) *WebhookSink {
+	return &WebhookSink{
+		url:       url,
+		operation: operation,
+		client:    &http.Client{Timeout: webhookTimeout},
+		records:   []WebhookRecord{},
+	}
+}
+
+// Write buffers record until the sink closes.
+func (s *WebhookSink) Write(record Record) error {
+	content := record.Content
+	if record.Emoji != "" {
+		content = record.Emoji + " " + content
+	}
+
+	s.records = append(s.records, WebhookRecord{
+		Time:    record.Time.UTC(),
+		Type:    record.Type.String(),
+		Content: content,
+	})
+
+	return nil
+}
+
+// Close posts the buffered messages to the webhook.
+func (s *WebhookSink) Close() error {
+	body, err := json.Marshal(WebhookPayload{Operation: s.operation, Records: s.records})
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %s", errWebhookStatus, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package strutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatBytes renders a byte count in binary units (e.g. "1.5 MiB").
+func FormatBytes(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size)
+	suffixes := []string{"KiB", "MiB", "GiB", "TiB"}
+	index := -1
+
+	for value >= unit && index < len(suffixes)-1 {
+		value /= unit
+		index++
+	}
+
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + " " + suffixes[index]
+}
@@ -0,0 +1,26 @@
+package strutil_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/strutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[int64]string{
+		0:                "0 B",
+		1023:             "1023 B",
+		1024:             "1 KiB",
+		1536:             "1.5 KiB",
+		10 * 1024 * 1024: "10 MiB",
+		3 << 40:          "3 TiB",
+		5 << 50:          "5120 TiB",
+	}
+
+	for size, expected := range tests {
+		assert.Equal(t, expected, strutil.FormatBytes(size), size)
+	}
+}
@@ -0,0 +1,213 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+)
+
+// Operation names a mutating KSail operation.
+type Operation string
+
+const (
+	// OperationCreate records `ksail cluster create`.
+	OperationCreate Operation = "create"
+	// OperationUpdate records an in-place `ksail cluster update`.
+	OperationUpdate Operation = "update"
+	// OperationRecreate records a `ksail cluster update` that recreated the cluster.
+	OperationRecreate Operation = "recreate"
+	// OperationUpgrade records a distribution or Kubernetes version upgrade.
+	OperationUpgrade Operation = "upgrade"
+	// OperationStart records `ksail cluster start`.
+	OperationStart Operation = "start"
+	// OperationStop records `ksail cluster stop`.
+	OperationStop Operation = "stop"
+	// OperationRestore records `ksail cluster restore`.
+	OperationRestore Operation = "restore"
+)
+
+// keyTimeFormat formats entry timestamps as ConfigMap data keys. The keys sort
+// chronologically and only use characters valid in a ConfigMap key.
+const keyTimeFormat = "20060102T150405.000000000Z"
+
+// unknownUser is recorded when the operating system user cannot be resolved.
+const unknownUser = "unknown"
+
+// Change is one configuration field changed by an operation.
+type Change struct {
+	// Field is the configuration field path (e.g. "cluster.cni").
+	Field string `json:"field"`
+	// OldValue is the value before the operation.
+	OldValue string `json:"oldValue,omitempty"`
+	// NewValue is the value after the operation.
+	NewValue string `json:"newValue,omitempty"`
+	// Category classifies the impact of the change (e.g. "in-place").
+	Category string `json:"category,omitempty"`
+}
+
+// Entry is one audited operation.
+type Entry struct {
+	// Time is when the operation completed, in UTC.
+	Time time.Time `json:"time"`
+	// User is the operating system user that ran KSail.
+	User string `json:"user"`
+	// Host is the machine KSail ran on.
+	Host string `json:"host,omitempty"`
+	// Cluster is the name of the cluster the operation targeted.
+	Cluster string `json:"cluster"`
+	// Operation is the operation that was performed.
+	Operation Operation `json:"operation"`
+	// Changes lists the configuration fields the operation changed.
+	Changes []Change `json:"changes,omitempty"`
+	// Error is set when the operation only partially succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// NewEntry returns an entry for operation on cluster, stamped with the
+// current time, the operating system user, and the host name.
+func NewEntry(operation Operation, cluster string) Entry {
+	host, _ := os.Hostname()
+
+	return Entry{
+		Time:      time.Now().UTC(),
+		User:      currentUser(),
+		Host:      host,
+		Cluster:   cluster,
+		Operation: operation,
+	}
+}
+
+// WithChanges returns a copy of the entry that records changes.
+func (e Entry) WithChanges(changes []clusterupdate.Change) Entry {
+	e.Changes = make([]Change, 0, len(changes))
+
+	for _, change := range changes {
+		e.Changes = append(e.Changes, Change{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+			Category: change.Category.String(),
+		})
+	}
+
+	return e
+}
+
+// Key returns the ConfigMap data key the entry is stored under.
+func (e Entry) Key() string {
+	return e.Time.UTC().Format(keyTimeFormat)
+}
+
+// Summary describes the entry in one line, e.g.
+// "update by alice@laptop: cluster.cni Default → Cilium".
+func (e Entry) Summary() string {
+	var summary strings.Builder
+
+	fmt.Fprintf(&summary, "%s by %s", e.Operation, e.Who())
+
+	if len(e.Changes) > 0 {
+		fields := make([]string, 0, len(e.Changes))
+		for _, change := range e.Changes {
+			fields = append(fields, change.String())
+		}
+
+		fmt.Fprintf(&summary, ": %s", strings.Join(fields, ", "))
+	}
+
+	if e.Error != "" {
+		fmt.Fprintf(&summary, " (failed: %s)", e.Error)
+	}
+
+	return summary.String()
+}
+
+// Who returns the user and host that performed the operation as user@host.
+func (e Entry) Who() string {
+	if e.Host == "" {
+		return e.User
+	}
+
+	return e.User + "@" + e.Host
+}
+
+// String renders the change as "field old → new".
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s → %s", c.Field, valueOrNone(c.OldValue), valueOrNone(c.NewValue))
+}
+
+// AppendLocal appends entry to the local audit log of its cluster.
+func AppendLocal(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	err = state.AppendAuditRecord(entry.Cluster, data)
+	if err != nil {
+		return fmt.Errorf("append local audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListLocal returns the entries of the local audit log for clusterName,
+// oldest first. Records that cannot be decoded are skipped.
+func ListLocal(clusterName string) ([]Entry, error) {
+	records, err := state.LoadAuditRecords(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("load local audit log: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(records))
+
+	for _, record := range records {
+		var entry Entry
+
+		if json.Unmarshal(record, &entry) != nil {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sortEntries(entries)
+
+	return entries, nil
+}
+
+// sortEntries orders entries oldest first.
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+}
+
+// currentUser returns the operating system user name, falling back to the
+// USER environment variable and finally to "unknown".
+func currentUser() string {
+	usr, err := user.Current()
+	if err == nil && usr.Username != "" {
+		return usr.Username
+	}
+
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+
+	return unknownUser
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+
+	return value
+}
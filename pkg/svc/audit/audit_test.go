@@ -0,0 +1,87 @@
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEntry(t *testing.T) {
+	t.Parallel()
+
+	entry := audit.NewEntry(audit.OperationCreate, "dev")
+
+	assert.Equal(t, audit.OperationCreate, entry.Operation)
+	assert.Equal(t, "dev", entry.Cluster)
+	assert.NotEmpty(t, entry.User)
+	assert.WithinDuration(t, time.Now().UTC(), entry.Time, 5*time.Second)
+	assert.Equal(t, time.UTC, entry.Time.Location())
+}
+
+func TestEntry_WithChangesAndSummary(t *testing.T) {
+	t.Parallel()
+
+	entry := audit.Entry{
+		User:      "alice",
+		Host:      "laptop",
+		Cluster:   "dev",
+		Operation: audit.OperationUpdate,
+	}.WithChanges([]clusterupdate.Change{
+		{
+			Field:    "cluster.cni",
+			OldValue: "Default",
+			NewValue: "Cilium",
+			Category: clusterupdate.ChangeCategoryInPlace,
+		},
+		{Field: "cluster.workers", OldValue: "", NewValue: "2"},
+	})
+
+	require.Len(t, entry.Changes, 2)
+	assert.Equal(t, clusterupdate.ChangeCategoryInPlace.String(), entry.Changes[0].Category)
+	assert.Equal(t,
+		"update by alice@laptop: cluster.cni Default → Cilium, cluster.workers (none) → 2",
+		entry.Summary(),
+	)
+
+	entry.Error = "one or more changes failed to apply"
+	assert.Contains(t, entry.Summary(), "(failed: one or more changes failed to apply)")
+}
+
+func TestEntry_Key(t *testing.T) {
+	t.Parallel()
+
+	entry := audit.Entry{Time: time.Date(2026, 10, 17, 9, 30, 0, 42, time.UTC)}
+
+	assert.Equal(t, "20261017T093000.000000042Z", entry.Key())
+}
+
+func TestAppendAndListLocal(t *testing.T) {
+	t.Parallel()
+
+	clusterName := "audit-local-" + t.Name()
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(clusterName)
+	})
+
+	later := audit.NewEntry(audit.OperationStop, clusterName)
+	earlier := later
+	earlier.Operation = audit.OperationCreate
+	earlier.Time = later.Time.Add(-time.Hour)
+
+	require.NoError(t, audit.AppendLocal(later))
+	require.NoError(t, audit.AppendLocal(earlier))
+	require.NoError(t, state.AppendAuditRecord(clusterName, []byte("not json")))
+
+	entries, err := audit.ListLocal(clusterName)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, audit.OperationCreate, entries[0].Operation)
+	assert.Equal(t, audit.OperationStop, entries[1].Operation)
+}
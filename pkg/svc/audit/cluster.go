@@ -0,0 +1,236 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// Namespace is the namespace the in-cluster audit log lives in.
+	Namespace = "kube-system"
+	// ConfigMapName is the name of the ConfigMap holding the in-cluster audit log.
+	ConfigMapName = "ksail-audit"
+	// MaxEntries caps the entries kept in the ConfigMap; the oldest are pruned
+	// first so the ConfigMap stays well below the API server's object size limit.
+	MaxEntries = 200
+	// EventReason is the reason of the Events emitted for audited operations.
+	EventReason = "KSailOperation"
+	// eventComponent identifies KSail as the source of emitted Events.
+	eventComponent = "ksail"
+	// maxEventMessage caps the Event message length; the ConfigMap entry
+	// keeps the full list of changes.
+	maxEventMessage = 1024
+	// managedByLabel marks the audit ConfigMap as managed by KSail.
+	managedByLabel = "app.kubernetes.io/managed-by"
+)
+
+// AppendCluster stores entry in the in-cluster audit log and emits an Event
+// describing it. The ConfigMap is created on first use. Conflicting concurrent
+// writers and an API server that is still coming up (right after a start) are
+// retried with backoff; ctx bounds the total time spent.
+func AppendCluster(ctx context.Context, client kubernetes.Interface, entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	var configMap *corev1.ConfigMap
+
+	err = retry.OnError(appendBackoff, isRetriable, func() error {
+		var appendErr error
+
+		configMap, appendErr = appendToConfigMap(ctx, client, entry.Key(), string(data))
+
+		return appendErr
+	})
+	if err != nil {
+		return fmt.Errorf("write audit entry to ConfigMap %s/%s: %w", Namespace, ConfigMapName, err)
+	}
+
+	_, err = client.CoreV1().Events(Namespace).Create(ctx, newEvent(configMap, entry), metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("emit audit event: %w", err)
+	}
+
+	return nil
+}
+
+//nolint:gochecknoglobals // Shared retry schedule for in-cluster audit writes.
+var appendBackoff = wait.Backoff{Steps: 6, Duration: 500 * time.Millisecond, Factor: 2}
+
+// isRetriable reports whether an audit write failed transiently.
+func isRetriable(err error) bool {
+	return apierrors.IsConflict(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		utilnet.IsConnectionRefused(err)
+}
+
+// ListCluster returns the entries of the in-cluster audit log, oldest first.
+// A cluster without an audit log yields no entries; values that cannot be
+// decoded are skipped.
+func ListCluster(ctx context.Context, client kubernetes.Interface) ([]Entry, error) {
+	configMap, err := client.CoreV1().ConfigMaps(Namespace).Get(ctx, ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read audit ConfigMap %s/%s: %w", Namespace, ConfigMapName, err)
+	}
+
+	entries := make([]Entry, 0, len(configMap.Data))
+
+	for _, value := range configMap.Data {
+		var entry Entry
+
+		if json.Unmarshal([]byte(value), &entry) != nil {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sortEntries(entries)
+
+	return entries, nil
+}
+
+// Record appends entry to the local audit log of entry.Cluster and, when
+// client is non-nil, to the in-cluster audit log. An entry without a cluster
+// name is only recorded in the cluster. Both writes are attempted; their
+// errors are joined.
+func Record(ctx context.Context, client kubernetes.Interface, entry Entry) error {
+	var localErr error
+	if entry.Cluster != "" {
+		localErr = AppendLocal(entry)
+	}
+
+	var clusterErr error
+	if client != nil {
+		clusterErr = AppendCluster(ctx, client, entry)
+	}
+
+	return errors.Join(localErr, clusterErr)
+}
+
+// appendToConfigMap adds value under key to the audit ConfigMap, creating it
+// when missing and pruning the oldest entries beyond MaxEntries.
+func appendToConfigMap(
+	ctx context.Context,
+	client kubernetes.Interface,
+	key, value string,
+) (*corev1.ConfigMap, error) {
+	configMaps := client.CoreV1().ConfigMaps(Namespace)
+
+	configMap, err := configMaps.Get(ctx, ConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		created, createErr := configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ConfigMapName,
+				Namespace: Namespace,
+				Labels:    map[string]string{managedByLabel: eventComponent},
+			},
+			Data: map[string]string{key: value},
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(createErr) {
+			// Another writer created the ConfigMap first; retry as an update.
+			return nil, apierrors.NewConflict(corev1.Resource("configmaps"), ConfigMapName, createErr)
+		}
+
+		if createErr != nil {
+			return nil, fmt.Errorf("create: %w", createErr)
+		}
+
+		return created, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+
+	configMap.Data[key] = value
+	pruneOldest(configMap.Data, MaxEntries)
+
+	updated, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("update: %w", err)
+	}
+
+	return updated, nil
+}
+
+// pruneOldest removes the lexically smallest keys — the oldest entries, since
+// keys are timestamps — until at most limit remain.
+func pruneOldest(data map[string]string, limit int) {
+	if len(data) <= limit {
+		return
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys[:len(keys)-limit] {
+		delete(data, key)
+	}
+}
+
+// newEvent builds the Event announcing entry, attached to the audit ConfigMap.
+func newEvent(configMap *corev1.ConfigMap, entry Entry) *corev1.Event {
+	eventType := corev1.EventTypeNormal
+	if entry.Error != "" {
+		eventType = corev1.EventTypeWarning
+	}
+
+	message := entry.Summary()
+	if len(message) > maxEventMessage {
+		message = strings.ToValidUTF8(message[:maxEventMessage-len("...")], "") + "..."
+	}
+
+	timestamp := metav1.NewTime(entry.Time)
+
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", ConfigMapName, entry.Time.UnixNano()),
+			Namespace: Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      "v1",
+			Kind:            "ConfigMap",
+			Namespace:       configMap.Namespace,
+			Name:            configMap.Name,
+			UID:             configMap.UID,
+			ResourceVersion: configMap.ResourceVersion,
+		},
+		Reason:         EventReason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: eventComponent, Host: entry.Host},
+		FirstTimestamp: timestamp,
+		LastTimestamp:  timestamp,
+		Count:          1,
+	}
+}
@@ -0,0 +1,137 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func entryAt(operation audit.Operation, at time.Time) audit.Entry {
+	return audit.Entry{
+		Time:      at,
+		User:      "alice",
+		Host:      "laptop",
+		Cluster:   "dev",
+		Operation: operation,
+	}
+}
+
+func TestAppendCluster_CreatesConfigMapAndEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := fake.NewClientset()
+	start := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, audit.AppendCluster(ctx, client, entryAt(audit.OperationCreate, start)))
+	require.NoError(t, audit.AppendCluster(ctx, client, entryAt(audit.OperationStop, start.Add(time.Hour))))
+
+	configMap, err := client.CoreV1().ConfigMaps(audit.Namespace).
+		Get(ctx, audit.ConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, configMap.Data, 2)
+	assert.Equal(t, "ksail", configMap.Labels["app.kubernetes.io/managed-by"])
+
+	events, err := client.CoreV1().Events(audit.Namespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 2)
+
+	for _, event := range events.Items {
+		assert.Equal(t, audit.EventReason, event.Reason)
+		assert.Equal(t, corev1.EventTypeNormal, event.Type)
+		assert.Equal(t, audit.ConfigMapName, event.InvolvedObject.Name)
+	}
+
+	entries, err := audit.ListCluster(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, audit.OperationCreate, entries[0].Operation)
+	assert.Equal(t, audit.OperationStop, entries[1].Operation)
+	assert.Equal(t, "alice", entries[1].User)
+}
+
+func TestAppendCluster_FailedEntryEmitsWarning(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := fake.NewClientset()
+
+	entry := entryAt(audit.OperationUpdate, time.Now().UTC())
+	entry.Error = "one or more changes failed to apply"
+
+	require.NoError(t, audit.AppendCluster(ctx, client, entry))
+
+	events, err := client.CoreV1().Events(audit.Namespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+	assert.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+	assert.Contains(t, events.Items[0].Message, "update by alice@laptop")
+}
+
+func TestAppendCluster_PrunesOldestEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data := make(map[string]string, audit.MaxEntries)
+	for i := range audit.MaxEntries {
+		entry := entryAt(audit.OperationStart, start.Add(time.Duration(i)*time.Minute))
+		data[entry.Key()] = fmt.Sprintf(`{"time":%q,"operation":"start"}`, entry.Time.Format(time.RFC3339Nano))
+	}
+
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: audit.ConfigMapName, Namespace: audit.Namespace},
+		Data:       data,
+	})
+
+	latest := entryAt(audit.OperationUpdate, start.Add(24*time.Hour))
+	require.NoError(t, audit.AppendCluster(ctx, client, latest))
+
+	entries, err := audit.ListCluster(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, entries, audit.MaxEntries)
+
+	assert.Equal(t, start.Add(time.Minute), entries[0].Time)
+	assert.Equal(t, audit.OperationUpdate, entries[len(entries)-1].Operation)
+}
+
+func TestListCluster_NoAuditLog(t *testing.T) {
+	t.Parallel()
+
+	entries, err := audit.ListCluster(context.Background(), fake.NewClientset())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRecord_WritesLocalAndCluster(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := fake.NewClientset()
+	entry := audit.NewEntry(audit.OperationStart, "audit-record-"+t.Name())
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(entry.Cluster)
+	})
+
+	require.NoError(t, audit.Record(ctx, client, entry))
+
+	local, err := audit.ListLocal(entry.Cluster)
+	require.NoError(t, err)
+	require.Len(t, local, 1)
+
+	inCluster, err := audit.ListCluster(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, inCluster, 1)
+	assert.Equal(t, local[0].Time, inCluster[0].Time)
+}
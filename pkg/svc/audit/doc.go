@@ -0,0 +1,12 @@
+// Package audit records the mutating operations KSail performs on a cluster —
+// who ran them, when, and which configuration fields they changed — so the
+// history of a shared cluster can be reviewed with `ksail cluster audit`.
+//
+// Every entry is written twice. The local copy is appended to
+// ~/.ksail/clusters/<name>/audit.jsonl on the machine that ran the operation.
+// The in-cluster copy is stored in the ksail-audit ConfigMap in kube-system,
+// one data key per entry, capped at [MaxEntries], and announced with a
+// Kubernetes Event on that ConfigMap so it also shows up in
+// `kubectl get events`. The ConfigMap is the durable record: Events expire
+// after the API server's event TTL.
+package audit
@@ -0,0 +1,371 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// manifestName is the archive entry holding the manifest.
+	manifestName = "bundle.json"
+	// signatureName is the archive entry holding the base64-encoded ed25519
+	// signature of the manifest.
+	signatureName = "bundle.json.sig"
+	// filesPrefix is the archive directory holding the project files.
+	filesPrefix = "files/"
+	// maxEntrySize bounds each archive entry read into memory, so a crafted
+	// archive cannot exhaust memory before its signature is checked.
+	maxEntrySize = 64 << 20
+	// entryPerm is the permission of archive entries that are not project
+	// files.
+	entryPerm = 0o644
+	// extractDirPerm is the permission of directories created on extraction.
+	extractDirPerm = 0o755
+	// fileModeMask restricts extracted files to ordinary permission bits.
+	fileModeMask = 0o755
+)
+
+// Bundle is a bundle read and verified by [Open].
+type Bundle struct {
+	// Manifest is the verified manifest of the bundle.
+	Manifest Manifest
+	// Signer is the public key the bundle is signed with.
+	Signer ed25519.PublicKey
+
+	files map[string][]byte
+}
+
+// Write stores the files at paths, relative to root, in a new bundle at
+// target, filling in the file list and public key of manifest and signing it
+// with key. The archive is written to a temporary file first, so a failed
+// export never leaves a partial bundle behind.
+func Write(target string, manifest Manifest, root string, paths []string, key ed25519.PrivateKey) error {
+	manifest.FormatVersion = FormatVersion
+	manifest.PublicKey = base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	manifest.Files = make([]File, 0, len(paths))
+
+	contents := make([][]byte, 0, len(paths))
+
+	for _, relPath := range paths {
+		fullPath := filepath.Join(root, filepath.FromSlash(relPath))
+
+		data, err := os.ReadFile(fullPath) //nolint:gosec // G304: project file collected by the caller
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", relPath, err)
+		}
+
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", relPath, err)
+		}
+
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, File{
+			Path:   relPath,
+			SHA256: hex.EncodeToString(sum[:]),
+			Mode:   uint32(info.Mode().Perm()),
+		})
+		contents = append(contents, data)
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, manifestJSON))
+
+	var buffer bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = errors.Join(
+		writeEntry(tarWriter, manifestName, entryPerm, manifestJSON),
+		writeEntry(tarWriter, signatureName, entryPerm, []byte(signature)),
+	)
+
+	for index, file := range manifest.Files {
+		err = errors.Join(err, writeEntry(tarWriter, filesPrefix+file.Path, int64(file.Mode), contents[index]))
+	}
+
+	err = errors.Join(err, tarWriter.Close(), gzipWriter.Close())
+	if err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	return writeAtomically(target, buffer.Bytes())
+}
+
+// Open reads the bundle at source and verifies it: the manifest must carry a
+// valid signature of its embedded public key, every project file must match
+// its recorded digest, and the archive must hold no other entries. Open does
+// not establish who signed the bundle; see [Bundle.VerifySigner].
+func Open(source string) (*Bundle, error) {
+	entries, err := readEntries(source)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestJSON, hasManifest := entries[manifestName]
+	signature, hasSignature := entries[signatureName]
+
+	if !hasManifest || !hasSignature {
+		return nil, fmt.Errorf("%w: missing %s or %s", ErrInvalidBundle, manifestName, signatureName)
+	}
+
+	var manifest Manifest
+
+	err = json.Unmarshal(manifestJSON, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode manifest: %w", ErrInvalidBundle, err)
+	}
+
+	if manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidBundle, manifest.FormatVersion)
+	}
+
+	signer, err := verifySignature(manifest, manifestJSON, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := verifyFiles(manifest, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bundle{Manifest: manifest, Signer: signer, files: files}, nil
+}
+
+// VerifySigner reports an error unless the bundle is signed with trusted.
+func (b *Bundle) VerifySigner(trusted ed25519.PublicKey) error {
+	if !b.Signer.Equal(trusted) {
+		return fmt.Errorf("%w: signed by %s, trusted key is %s",
+			ErrUntrustedSigner, Fingerprint(b.Signer), Fingerprint(trusted))
+	}
+
+	return nil
+}
+
+// CheckExtract reports ErrFileExists when extracting the bundle below dir would
+// overwrite an existing file, without writing anything.
+func (b *Bundle) CheckExtract(dir string) error {
+	for _, file := range b.Manifest.Files {
+		_, err := os.Lstat(filepath.Join(dir, filepath.FromSlash(file.Path)))
+		if err == nil {
+			return fmt.Errorf("%w: %s", ErrFileExists, file.Path)
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to check %s: %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// Extract writes the project files of the bundle below dir. It refuses to
+// overwrite existing files: when any file already exists, nothing is written.
+func (b *Bundle) Extract(dir string) error {
+	err := b.CheckExtract(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range b.Manifest.Files {
+		target := filepath.Join(dir, filepath.FromSlash(file.Path))
+
+		err = os.MkdirAll(filepath.Dir(target), extractDirPerm)
+		if err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+
+		err = os.WriteFile(target, b.files[file.Path], fs.FileMode(file.Mode)&fileModeMask)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// verifySignature checks signature against the manifest and the public key it
+// embeds, returning that key.
+func verifySignature(manifest Manifest, manifestJSON, signature []byte) (ed25519.PublicKey, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(manifest.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: malformed public key", ErrInvalidBundle)
+	}
+
+	rawSignature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidBundle)
+	}
+
+	if !ed25519.Verify(publicKey, manifestJSON, rawSignature) {
+		return nil, ErrSignatureMismatch
+	}
+
+	return publicKey, nil
+}
+
+// verifyFiles matches the project file entries of the archive against the
+// manifest and returns their contents keyed by path.
+func verifyFiles(manifest Manifest, entries map[string][]byte) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(manifest.Files))
+
+	for _, file := range manifest.Files {
+		if !isSafePath(file.Path) {
+			return nil, fmt.Errorf("%w: unsafe path %q", ErrInvalidBundle, file.Path)
+		}
+
+		data, ok := entries[filesPrefix+file.Path]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is missing", ErrInvalidBundle, file.Path)
+		}
+
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, fmt.Errorf("%w: %s", ErrDigestMismatch, file.Path)
+		}
+
+		files[file.Path] = data
+	}
+
+	for name := range entries {
+		if name == manifestName || name == signatureName {
+			continue
+		}
+
+		if _, ok := files[strings.TrimPrefix(name, filesPrefix)]; !ok || !strings.HasPrefix(name, filesPrefix) {
+			return nil, fmt.Errorf("%w: %s is not listed in the manifest", ErrInvalidBundle, name)
+		}
+	}
+
+	if !slices.ContainsFunc(manifest.Files, func(file File) bool { return file.Path == manifest.ConfigFile }) {
+		return nil, fmt.Errorf("%w: config file %q is not in the bundle", ErrInvalidBundle, manifest.ConfigFile)
+	}
+
+	return files, nil
+}
+
+// readEntries reads the regular file entries of the gzipped tar at source.
+func readEntries(source string) (map[string][]byte, error) {
+	file, err := os.Open(source) //nolint:gosec // G304: user-provided bundle path
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+
+	defer func() { _ = gzipReader.Close() }()
+
+	tarReader := tar.NewReader(gzipReader)
+	entries := make(map[string][]byte)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("%w: %s is not a regular file", ErrInvalidBundle, header.Name)
+		}
+
+		if header.Size > maxEntrySize {
+			return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidBundle, header.Name, maxEntrySize)
+		}
+
+		if _, duplicate := entries[header.Name]; duplicate {
+			return nil, fmt.Errorf("%w: duplicate entry %s", ErrInvalidBundle, header.Name)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tarReader, maxEntrySize))
+		if err != nil {
+			return nil, fmt.Errorf("%w: read %s: %w", ErrInvalidBundle, header.Name, err)
+		}
+
+		entries[header.Name] = data
+	}
+}
+
+// writeEntry adds a regular file entry to the archive.
+func writeEntry(tarWriter *tar.Writer, name string, mode int64, data []byte) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     mode,
+		Size:     int64(len(data)),
+	})
+	if err != nil {
+		return fmt.Errorf("write header of %s: %w", name, err)
+	}
+
+	_, err = tarWriter.Write(data)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// writeAtomically writes data to a temporary file next to target and renames
+// it into place.
+func writeAtomically(target string, data []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(target), filepath.Base(target)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	tmpPath := tmpFile.Name()
+
+	_, err = tmpFile.Write(data)
+	err = errors.Join(err, tmpFile.Close())
+
+	if err == nil {
+		err = os.Rename(tmpPath, target)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpPath)
+
+		return fmt.Errorf("failed to write bundle %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// isSafePath reports whether name is a clean, relative, slash-separated path
+// that stays inside the directory it is extracted to.
+func isSafePath(name string) bool {
+	return name != "." &&
+		filepath.IsLocal(name) &&
+		path.Clean(name) == name &&
+		!strings.Contains(name, `\`)
+}
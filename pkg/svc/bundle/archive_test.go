@@ -0,0 +1,236 @@
+package bundle_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+func writeProjectFile(t *testing.T, root, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(root, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// writeBundle exports a small project and returns the bundle path and key.
+func writeBundle(t *testing.T) (string, ed25519.PrivateKey) {
+	t.Helper()
+
+	root := t.TempDir()
+	writeProjectFile(t, root, "ksail.yaml", "kind: Cluster\n")
+	writeProjectFile(t, root, "kind.yaml", "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\n")
+	writeProjectFile(t, root, "k8s/kustomization.yaml", "resources: []\n")
+
+	key := newKey(t)
+	target := filepath.Join(t.TempDir(), "bundle.tar.gz")
+
+	err := bundle.Write(target, bundle.Manifest{
+		ClusterName: "dev",
+		ConfigFile:  "ksail.yaml",
+		Components:  []bundle.Component{{Name: "cilium", Chart: "cilium-1.16.0"}},
+	}, root, []string{"k8s/kustomization.yaml", "kind.yaml", "ksail.yaml"}, key)
+	require.NoError(t, err)
+
+	return target, key
+}
+
+// rewriteBundle copies the bundle at source, passing each entry through edit,
+// which returns the new contents or nil to drop the entry, and appends extra.
+func rewriteBundle(t *testing.T, source string, edit func(name string, data []byte) []byte, extra ...string) string {
+	t.Helper()
+
+	in, err := os.Open(source) //nolint:gosec // test fixture
+	require.NoError(t, err)
+
+	defer func() { _ = in.Close() }()
+
+	gzipReader, err := gzip.NewReader(in)
+	require.NoError(t, err)
+
+	target := filepath.Join(t.TempDir(), "rewritten.tar.gz")
+	out, err := os.Create(target) //nolint:gosec // test fixture
+	require.NoError(t, err)
+
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+
+		data = edit(header.Name, data)
+		if data == nil {
+			continue
+		}
+
+		header.Size = int64(len(data))
+		require.NoError(t, tarWriter.WriteHeader(header))
+		_, err = tarWriter.Write(data)
+		require.NoError(t, err)
+	}
+
+	for _, name := range extra {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 1, Typeflag: tar.TypeReg}))
+		_, err = tarWriter.Write([]byte("x"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, out.Close())
+
+	return target
+}
+
+func keep(_ string, data []byte) []byte { return data }
+
+func TestWriteAndOpen(t *testing.T) {
+	t.Parallel()
+
+	path, key := writeBundle(t)
+
+	opened, err := bundle.Open(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, bundle.FormatVersion, opened.Manifest.FormatVersion)
+	assert.Equal(t, "dev", opened.Manifest.ClusterName)
+	assert.Len(t, opened.Manifest.Files, 3)
+	assert.Equal(t, []bundle.Component{{Name: "cilium", Chart: "cilium-1.16.0"}}, opened.Manifest.Components)
+	require.NoError(t, opened.VerifySigner(key.Public().(ed25519.PublicKey)))
+	require.ErrorIs(t, opened.VerifySigner(newKey(t).Public().(ed25519.PublicKey)), bundle.ErrUntrustedSigner)
+}
+
+func TestOpen_RejectsTamperedBundles(t *testing.T) {
+	t.Parallel()
+
+	path, _ := writeBundle(t)
+
+	tests := []struct {
+		name    string
+		edit    func(name string, data []byte) []byte
+		extra   []string
+		wantErr error
+	}{
+		{
+			name: "modified file",
+			edit: func(name string, data []byte) []byte {
+				if name == "files/kind.yaml" {
+					return []byte("kind: Cluster\nnodes: []\n")
+				}
+
+				return data
+			},
+			wantErr: bundle.ErrDigestMismatch,
+		},
+		{
+			name: "modified manifest",
+			edit: func(name string, data []byte) []byte {
+				if name == "bundle.json" {
+					return append(data, ' ')
+				}
+
+				return data
+			},
+			wantErr: bundle.ErrSignatureMismatch,
+		},
+		{
+			name: "missing file",
+			edit: func(name string, data []byte) []byte {
+				if name == "files/ksail.yaml" {
+					return nil
+				}
+
+				return data
+			},
+			wantErr: bundle.ErrInvalidBundle,
+		},
+		{
+			name:    "unlisted file",
+			edit:    keep,
+			extra:   []string{"files/extra.yaml"},
+			wantErr: bundle.ErrInvalidBundle,
+		},
+		{
+			name: "missing signature",
+			edit: func(name string, data []byte) []byte {
+				if name == "bundle.json.sig" {
+					return nil
+				}
+
+				return data
+			},
+			wantErr: bundle.ErrInvalidBundle,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := bundle.Open(rewriteBundle(t, path, test.edit, test.extra...))
+			require.ErrorIs(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	path, _ := writeBundle(t)
+
+	opened, err := bundle.Open(path)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, opened.Extract(dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, "k8s", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "resources: []\n", string(content))
+}
+
+func TestExtract_RefusesToOverwrite(t *testing.T) {
+	t.Parallel()
+
+	path, _ := writeBundle(t)
+
+	opened, err := bundle.Open(path)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeProjectFile(t, dir, "ksail.yaml", "mine\n")
+
+	require.ErrorIs(t, opened.CheckExtract(dir), bundle.ErrFileExists)
+	require.ErrorIs(t, opened.Extract(dir), bundle.ErrFileExists)
+
+	_, err = os.Stat(filepath.Join(dir, "kind.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist, "nothing is extracted when a file exists")
+}
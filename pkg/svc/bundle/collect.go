@@ -0,0 +1,165 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// ProjectFiles returns the regular files at paths, relative to root and
+// slash-separated, sorted and without duplicates. Relative paths are resolved
+// against root, directories are walked recursively, and paths that do not
+// exist are skipped, so optional project directories may be passed as-is.
+// Symbolic links and other special files are skipped.
+func ProjectFiles(root string, paths ...string) ([]string, error) {
+	var files []string
+
+	for _, entry := range paths {
+		if entry == "" {
+			continue
+		}
+
+		if !filepath.IsAbs(entry) {
+			entry = filepath.Join(root, entry)
+		}
+
+		relPath, err := filepath.Rel(root, entry)
+		if err != nil || !filepath.IsLocal(relPath) && relPath != "." {
+			return nil, fmt.Errorf("%w: %s", ErrOutsideProject, entry)
+		}
+
+		err = filepath.WalkDir(entry, func(walkPath string, dirEntry fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+
+			if !dirEntry.Type().IsRegular() {
+				return nil
+			}
+
+			relFile, err := filepath.Rel(root, walkPath)
+			if err != nil {
+				return fmt.Errorf("resolve %s: %w", walkPath, err)
+			}
+
+			files = append(files, filepath.ToSlash(relFile))
+
+			return nil
+		})
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect %s: %w", entry, err)
+		}
+	}
+
+	slices.Sort(files)
+
+	return slices.Compact(files), nil
+}
+
+// Components converts the deployed component versions reported by the
+// detector into bundle components.
+func Components(versions []detector.ComponentVersion) []Component {
+	components := make([]Component, 0, len(versions))
+
+	for _, version := range versions {
+		if !version.Deployed() {
+			continue
+		}
+
+		components = append(components, Component{
+			Name:       version.Component,
+			Namespace:  version.Namespace,
+			Chart:      version.Chart,
+			AppVersion: version.AppVersion,
+		})
+	}
+
+	return components
+}
+
+// ComponentDrift returns one message for each component in recorded that is
+// missing from installed or installed with another chart, sorted.
+func ComponentDrift(recorded, installed []Component) []string {
+	charts := make(map[string]string, len(installed))
+	for _, component := range installed {
+		charts[component.Name] = component.Chart
+	}
+
+	var drift []string
+
+	for _, component := range recorded {
+		chart, ok := charts[component.Name]
+
+		switch {
+		case !ok:
+			drift = append(drift, fmt.Sprintf("%s: bundle has %s, not installed", component.Name, component.Chart))
+		case chart != component.Chart:
+			drift = append(drift, fmt.Sprintf("%s: bundle has %s, installed %s", component.Name, component.Chart, chart))
+		}
+	}
+
+	slices.Sort(drift)
+
+	return drift
+}
+
+// FluxArtifacts lists the Flux OCIRepositories in the cluster with the
+// revision each last reconciled. A cluster without Flux has none.
+func FluxArtifacts(ctx context.Context, client dynamic.Interface) ([]Artifact, error) {
+	list, err := client.Resource(flux.OCIRepositoryGVR()).Namespace(metav1.NamespaceAll).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("list OCIRepositories: %w", err)
+	}
+
+	artifacts := make([]Artifact, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		url, _, _ := unstructured.NestedString(item.Object, "spec", "url")
+		revision, _, _ := unstructured.NestedString(item.Object, "status", "artifact", "revision")
+
+		artifacts = append(artifacts, Artifact{
+			Name:      item.GetName(),
+			Namespace: item.GetNamespace(),
+			URL:       url,
+			Revision:  revision,
+			Digest:    revisionDigest(revision),
+		})
+	}
+
+	return artifacts, nil
+}
+
+// revisionDigest extracts the digest from a Flux OCI revision, which is
+// either "<tag>@<digest>" or the bare digest.
+func revisionDigest(revision string) string {
+	if index := strings.LastIndex(revision, "@"); index >= 0 {
+		return revision[index+1:]
+	}
+
+	if strings.Contains(revision, ":") {
+		return revision
+	}
+
+	return ""
+}
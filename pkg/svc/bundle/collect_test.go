@@ -0,0 +1,97 @@
+package bundle_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/bundle"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestProjectFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeProjectFile(t, root, "ksail.yaml", "kind: Cluster\n")
+	writeProjectFile(t, root, "talos/cluster/patch.yaml", "machine: {}\n")
+	writeProjectFile(t, root, "k8s/kustomization.yaml", "resources: []\n")
+	require.NoError(t, os.Symlink("ksail.yaml", filepath.Join(root, "k8s", "link.yaml")))
+
+	files, err := bundle.ProjectFiles(root,
+		filepath.Join(root, "ksail.yaml"), "talos", filepath.Join(root, "k8s"), "ksail.yaml", "missing", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"k8s/kustomization.yaml", "ksail.yaml", "talos/cluster/patch.yaml"}, files)
+}
+
+func TestProjectFiles_RejectsPathsOutsideProject(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "project")
+	require.NoError(t, os.MkdirAll(root, 0o750))
+
+	_, err := bundle.ProjectFiles(root, "../kind.yaml")
+	require.ErrorIs(t, err, bundle.ErrOutsideProject)
+}
+
+func TestComponentDrift(t *testing.T) {
+	t.Parallel()
+
+	versions := []detector.ComponentVersion{
+		{Component: "cilium", Chart: "cilium-1.17.0", Status: "deployed"},
+		{Component: "flux", Chart: "flux-operator-0.20.0", Status: "deployed"},
+		{Component: "metrics-server", Chart: "metrics-server-3.12.0", Status: "failed"},
+	}
+
+	drift := bundle.ComponentDrift([]bundle.Component{
+		{Name: "cilium", Chart: "cilium-1.16.0"},
+		{Name: "flux", Chart: "flux-operator-0.20.0"},
+		{Name: "metrics-server", Chart: "metrics-server-3.12.0"},
+	}, bundle.Components(versions))
+
+	assert.Equal(t, []string{
+		"cilium: bundle has cilium-1.16.0, installed cilium-1.17.0",
+		"metrics-server: bundle has metrics-server-3.12.0, not installed",
+	}, drift)
+}
+
+func ociRepository(name, url, revision string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "source.toolkit.fluxcd.io/v1",
+		"kind":       "OCIRepository",
+		"metadata":   map[string]any{"name": name, "namespace": "flux-system"},
+		"spec":       map[string]any{"url": url},
+		"status":     map[string]any{"artifact": map[string]any{"revision": revision}},
+	}}
+}
+
+func TestFluxArtifacts(t *testing.T) {
+	t.Parallel()
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "ocirepositories"}: "OCIRepositoryList",
+		},
+		ociRepository("apps", "oci://ghcr.io/org/apps", "latest@sha256:abc"),
+		ociRepository("pending", "oci://ghcr.io/org/pending", ""),
+	)
+
+	artifacts, err := bundle.FluxArtifacts(context.Background(), client)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []bundle.Artifact{
+		{
+			Name: "apps", Namespace: "flux-system", URL: "oci://ghcr.io/org/apps",
+			Revision: "latest@sha256:abc", Digest: "sha256:abc",
+		},
+		{Name: "pending", Namespace: "flux-system", URL: "oci://ghcr.io/org/pending"},
+	}, artifacts)
+}
@@ -0,0 +1,15 @@
+// Package bundle packs everything needed to reproduce a KSail environment on
+// another machine into one signed, portable archive: the ksail.yaml, the
+// distribution's own configuration, the workload source directory, the
+// versions of the components KSail installed, and the digests of the OCI
+// artifacts the GitOps engine reconciles.
+//
+// A bundle is written with [Write] and read back with [Open], which rejects
+// archives whose signature or file digests do not match their manifest.
+// [Bundle.Extract] then materializes the project files into a directory, so
+// `ksail cluster create --from-bundle` can create the cluster from them.
+//
+// Bundles are signed with an ed25519 key. The public key is embedded in the
+// manifest, which proves the archive is intact; [Bundle.VerifySigner] checks it
+// against a trusted key to prove who exported it.
+package bundle
@@ -0,0 +1,24 @@
+package bundle
+
+import "errors"
+
+var (
+	// ErrInvalidBundle indicates that an archive is not a well-formed bundle.
+	ErrInvalidBundle = errors.New("invalid bundle")
+	// ErrSignatureMismatch indicates that the bundle signature does not match
+	// its manifest, so the bundle was modified after it was exported.
+	ErrSignatureMismatch = errors.New("bundle signature does not match its manifest")
+	// ErrDigestMismatch indicates that a file in the bundle does not match the
+	// digest its manifest records.
+	ErrDigestMismatch = errors.New("bundle file does not match its digest")
+	// ErrUntrustedSigner indicates that the bundle is signed with a key other
+	// than the trusted one.
+	ErrUntrustedSigner = errors.New("bundle is not signed by the trusted key")
+	// ErrFileExists indicates that extracting a bundle would overwrite a file.
+	ErrFileExists = errors.New("file already exists")
+	// ErrOutsideProject indicates that a project path points outside the
+	// project directory, so it cannot be stored relative to it.
+	ErrOutsideProject = errors.New("path is outside the project directory")
+	// ErrInvalidKey indicates that a key file holds no usable ed25519 key.
+	ErrInvalidKey = errors.New("invalid ed25519 key")
+)
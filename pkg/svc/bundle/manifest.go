@@ -0,0 +1,74 @@
+package bundle
+
+import (
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// FormatVersion is the version of the bundle layout written by [Write]. [Open]
+// rejects bundles of other versions.
+const FormatVersion = 1
+
+// Manifest describes the contents of a bundle. It is stored as bundle.json at
+// the root of the archive and is the document the signature covers.
+type Manifest struct {
+	// FormatVersion is the bundle layout version; see [FormatVersion].
+	FormatVersion int `json:"formatVersion"`
+	// KSailVersion is the version of KSail that exported the bundle.
+	KSailVersion string `json:"ksailVersion"`
+	// ClusterName is the name of the exported cluster.
+	ClusterName string `json:"clusterName"`
+	// Distribution is the Kubernetes distribution of the exported cluster.
+	Distribution v1alpha1.Distribution `json:"distribution"`
+	// Provider is the infrastructure provider of the exported cluster.
+	Provider v1alpha1.Provider `json:"provider"`
+	// CreatedAt is when the bundle was exported.
+	CreatedAt time.Time `json:"createdAt"`
+	// ConfigFile is the path of the ksail.yaml among Files.
+	ConfigFile string `json:"configFile"`
+	// Files lists the project files in the bundle with their digests.
+	Files []File `json:"files"`
+	// Components lists the KSail-managed components installed in the cluster.
+	Components []Component `json:"components,omitempty"`
+	// Artifacts lists the OCI artifacts the GitOps engine reconciled.
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+	// PublicKey is the base64-encoded ed25519 key the bundle is signed with.
+	PublicKey string `json:"publicKey"`
+}
+
+// File is a project file in a bundle.
+type File struct {
+	// Path is the slash-separated path relative to the project directory.
+	Path string `json:"path"`
+	// SHA256 is the hex-encoded SHA-256 digest of the file contents.
+	SHA256 string `json:"sha256"`
+	// Mode holds the file's permission bits.
+	Mode uint32 `json:"mode"`
+}
+
+// Component is a KSail-managed component installed in the exported cluster.
+type Component struct {
+	// Name is the KSail component name, e.g. "cilium".
+	Name string `json:"name"`
+	// Namespace is the namespace of the component's Helm release.
+	Namespace string `json:"namespace,omitempty"`
+	// Chart is the chart name and version, e.g. "cilium-1.16.0".
+	Chart string `json:"chart"`
+	// AppVersion is the application version of the chart.
+	AppVersion string `json:"appVersion,omitempty"`
+}
+
+// Artifact is an OCI artifact reconciled by the GitOps engine.
+type Artifact struct {
+	// Name is the name of the source object, e.g. the Flux OCIRepository.
+	Name string `json:"name"`
+	// Namespace is the namespace of the source object.
+	Namespace string `json:"namespace"`
+	// URL is the OCI repository URL.
+	URL string `json:"url"`
+	// Revision is the revision last reconciled, e.g. "latest@sha256:…".
+	Revision string `json:"revision"`
+	// Digest is the manifest digest of Revision, e.g. "sha256:…".
+	Digest string `json:"digest,omitempty"`
+}
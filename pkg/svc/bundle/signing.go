@@ -0,0 +1,162 @@
+package bundle
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// signingDirName is the directory under ~/.ksail holding the default
+	// bundle signing key.
+	signingDirName = "bundle-signing"
+	// SigningKeyFile is the file name of the default signing key.
+	SigningKeyFile = "bundle.key"
+	// PublicKeyFile is the file name of the default signing key's public half,
+	// written next to it for distribution to the machines that verify bundles.
+	PublicKeyFile = "bundle.pub"
+	// signingDirPerm keeps the signing key directory private to the user.
+	signingDirPerm = 0o700
+	// signingKeyPerm is the permission of the private key file.
+	signingKeyPerm = 0o600
+	// publicKeyPerm is the permission of the public key file.
+	publicKeyPerm = 0o644
+	// privateKeyPEMType and publicKeyPEMType are the PEM block types of PKCS #8
+	// private keys and PKIX public keys, as written by `openssl genpkey`.
+	privateKeyPEMType = "PRIVATE KEY"
+	publicKeyPEMType  = "PUBLIC KEY"
+)
+
+// DefaultSigningDir returns the directory the default signing key is kept in
+// (~/.ksail/bundle-signing).
+func DefaultSigningDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".ksail", signingDirName), nil
+}
+
+// LoadOrCreateSigningKey loads the signing key stored in dir, generating and
+// storing a new one, together with its public key, when dir holds none.
+func LoadOrCreateSigningKey(dir string) (ed25519.PrivateKey, error) {
+	keyPath := filepath.Join(dir, SigningKeyFile)
+
+	key, err := LoadSigningKey(keyPath)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return key, err
+	}
+
+	_, key, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signing key: %w", err)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	err = os.MkdirAll(dir, signingDirPerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key directory: %w", err)
+	}
+
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Bytes: keyDER}),
+		signingKeyPerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write signing key: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, PublicKeyFile),
+		pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Bytes: publicDER}), publicKeyPerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	return key, nil
+}
+
+// LoadSigningKey reads a PEM-encoded PKCS #8 ed25519 private key.
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path, privateKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s: %w", ErrInvalidKey, path, err)
+	}
+
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w in %s: not an ed25519 private key", ErrInvalidKey, path)
+	}
+
+	return key, nil
+}
+
+// LoadPublicKey reads a PEM-encoded PKIX ed25519 public key, as written next
+// to the default signing key or by `openssl pkey -pubout`.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path, publicKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s: %w", ErrInvalidKey, path, err)
+	}
+
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w in %s: not an ed25519 public key", ErrInvalidKey, path)
+	}
+
+	return key, nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of key in the form OpenSSH
+// prints, e.g. "SHA256:…", for users to compare keys at a glance.
+func Fingerprint(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// readPEM returns the contents of the first PEM block of blockType in path.
+func readPEM(path, blockType string) ([]byte, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: user-provided key path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%w in %s: no %q PEM block", ErrInvalidKey, path, blockType)
+		}
+
+		if block.Type == blockType {
+			return block.Bytes, nil
+		}
+	}
+}
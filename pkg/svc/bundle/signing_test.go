@@ -0,0 +1,56 @@
+package bundle_test
+
+import (
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/bundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateSigningKey(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "bundle-signing")
+
+	created, err := bundle.LoadOrCreateSigningKey(dir)
+	require.NoError(t, err)
+
+	loaded, err := bundle.LoadOrCreateSigningKey(dir)
+	require.NoError(t, err)
+	assert.True(t, created.Equal(loaded), "the stored key is reused")
+
+	public, err := bundle.LoadPublicKey(filepath.Join(dir, bundle.PublicKeyFile))
+	require.NoError(t, err)
+	assert.True(t, public.Equal(created.Public()))
+
+	info, err := os.Stat(filepath.Join(dir, bundle.SigningKeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestLoadPublicKey_RejectsPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := bundle.LoadOrCreateSigningKey(dir)
+	require.NoError(t, err)
+
+	_, err = bundle.LoadPublicKey(filepath.Join(dir, bundle.SigningKeyFile))
+	require.ErrorIs(t, err, bundle.ErrInvalidKey)
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	key := newKey(t)
+
+	fingerprint := bundle.Fingerprint(key.Public().(ed25519.PublicKey))
+	assert.True(t, strings.HasPrefix(fingerprint, "SHA256:"))
+	assert.Equal(t, fingerprint, bundle.Fingerprint(key.Public().(ed25519.PublicKey)))
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/kubeconform"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/configbase"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/oidc"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+)
+
+// Category names.
+const (
+	CategorySchemas     = "schemas"
+	CategorySchemaStore = "schema-store"
+	CategorySessions    = "sessions"
+	CategoryChat        = "chat"
+	CategoryOIDC        = "oidc"
+	CategoryState       = "state"
+	CategoryBases       = "bases"
+)
+
+// ErrUnknownCategory is returned when a category name does not exist.
+var ErrUnknownCategory = errors.New("unknown cache category")
+
+const (
+	day = 24 * time.Hour
+	// mebibyte is the unit the default size limits are expressed in.
+	mebibyte = 1 << 20
+)
+
+// Category is one kind of data KSail keeps on the local machine, with the
+// retention policy garbage collection applies to it.
+type Category struct {
+	// Name identifies the category (e.g. "schemas").
+	Name string
+	// Description says what the category holds.
+	Description string
+	// Dir is the directory holding the category's entries. Each top-level file
+	// or directory in it is one entry.
+	Dir string
+	// TTL removes entries not modified for longer than it. Zero keeps entries
+	// regardless of age.
+	TTL time.Duration
+	// MaxSize removes the least recently modified entries once the category
+	// grows past it, in bytes. Zero is unbounded.
+	MaxSize int64
+	// Protected categories hold data KSail needs to operate. Garbage collection
+	// skips them; they are only cleaned when named explicitly.
+	Protected bool
+}
+
+// DefaultCategories returns every category KSail stores data in, with its
+// default retention policy.
+func DefaultCategories() ([]Category, error) {
+	sessionsDir, err := state.SessionsDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve sessions directory: %w", err)
+	}
+
+	clustersDir, err := state.ClustersDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve cluster state directory: %w", err)
+	}
+
+	oidcDir, err := oidc.CacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve OIDC token cache directory: %w", err)
+	}
+
+	schemaStoreDir, err := kubeconform.DefaultSchemaStoreDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve schema store directory: %w", err)
+	}
+
+	basesDir, err := configbase.CacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve base configuration cache directory: %w", err)
+	}
+
+	// Chat sessions live beside the other state; see pkg/cli/ui/chat.
+	chatDir := filepath.Join(filepath.Dir(sessionsDir), "chat", "sessions")
+
+	return []Category{
+		{
+			Name:        CategorySchemas,
+			Description: "Downloaded Kubernetes and CRD JSON schemas",
+			Dir:         schemaCacheDir(),
+			TTL:         30 * day,
+			MaxSize:     512 * mebibyte,
+		},
+		{
+			Name:        CategorySchemaStore,
+			Description: "Schemas stored for offline validation",
+			Dir:         schemaStoreDir,
+			Protected:   true,
+		},
+		{
+			Name:        CategorySessions,
+			Description: "Recorded node access session transcripts",
+			Dir:         sessionsDir,
+			TTL:         90 * day,
+			MaxSize:     256 * mebibyte,
+		},
+		{
+			Name:        CategoryChat,
+			Description: "Saved chat sessions",
+			Dir:         chatDir,
+			TTL:         90 * day,
+			MaxSize:     128 * mebibyte,
+		},
+		{
+			Name:        CategoryBases,
+			Description: "Fetched base configurations referenced by extends",
+			Dir:         basesDir,
+			TTL:         30 * day,
+			MaxSize:     64 * mebibyte,
+		},
+		{
+			Name:        CategoryOIDC,
+			Description: "Cached OIDC tokens",
+			Dir:         oidcDir,
+			Protected:   true,
+		},
+		{
+			Name:        CategoryState,
+			Description: "Cluster state used as the update baseline",
+			Dir:         clustersDir,
+			Protected:   true,
+		},
+	}, nil
+}
+
+// SelectCategories returns the categories named in names, in the order of
+// categories. With no names it returns every unprotected category.
+func SelectCategories(categories []Category, names []string) ([]Category, error) {
+	if len(names) == 0 {
+		selected := make([]Category, 0, len(categories))
+
+		for _, category := range categories {
+			if !category.Protected {
+				selected = append(selected, category)
+			}
+		}
+
+		return selected, nil
+	}
+
+	wanted := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		if !hasCategory(categories, name) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCategory, name)
+		}
+
+		wanted[name] = true
+	}
+
+	selected := make([]Category, 0, len(wanted))
+
+	for _, category := range categories {
+		if wanted[category.Name] {
+			selected = append(selected, category)
+		}
+	}
+
+	return selected, nil
+}
+
+// hasCategory reports whether categories contains one named name.
+func hasCategory(categories []Category, name string) bool {
+	for _, category := range categories {
+		if category.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schemaCacheDir returns the directory kubeconform and the Flux substitution
+// validator cache downloaded schemas in.
+func schemaCacheDir() string {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "ksail", "kubeconform")
+	}
+
+	return filepath.Join(userCacheDir, "ksail", "kubeconform")
+}
@@ -0,0 +1,196 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNow() time.Time {
+	return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+}
+
+// writeEntry creates a file of size bytes under dir, last modified age ago.
+func writeEntry(t *testing.T, dir, name string, size int, age time.Duration) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o600))
+
+	modTime := testNow().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	return path
+}
+
+func TestInspect(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeEntry(t, dir, "a.json", 10, time.Hour)
+	writeEntry(t, dir, "b.json", 20, 48*time.Hour)
+
+	usages, err := cache.Inspect([]cache.Category{
+		{Name: "schemas", Dir: dir},
+		{Name: "missing", Dir: filepath.Join(dir, "does-not-exist")},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+	assert.Equal(t, 2, usages[0].Entries)
+	assert.Equal(t, int64(30), usages[0].Size)
+	assert.Equal(t, testNow().Add(-48*time.Hour), usages[0].Oldest.UTC())
+	assert.Equal(t, 0, usages[1].Entries, "a missing directory is an empty category")
+}
+
+func TestClean_RemovesExpiredEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	fresh := writeEntry(t, dir, "fresh.log", 10, time.Hour)
+	expired := writeEntry(t, dir, "expired.log", 10, 10*24*time.Hour)
+
+	results, err := cache.Clean(
+		[]cache.Category{{Name: "sessions", Dir: dir, TTL: 7 * 24 * time.Hour}},
+		cache.CleanOptions{Now: testNow()},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 1, results[0].Removed)
+	assert.Equal(t, int64(10), results[0].Freed)
+	assert.FileExists(t, fresh)
+	assert.NoFileExists(t, expired)
+}
+
+func TestClean_EvictsOldestOverSizeLimit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	oldest := writeEntry(t, dir, "oldest.json", 40, 3*time.Hour)
+	older := writeEntry(t, dir, "older.json", 40, 2*time.Hour)
+	newest := writeEntry(t, dir, "newest.json", 40, time.Hour)
+
+	results, err := cache.Clean(
+		[]cache.Category{{Name: "schemas", Dir: dir, MaxSize: 100}},
+		cache.CleanOptions{Now: testNow()},
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, results[0].Removed)
+	assert.NoFileExists(t, oldest)
+	assert.FileExists(t, older)
+	assert.FileExists(t, newest)
+}
+
+func TestClean_AllAndDryRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	stateFile := writeEntry(t, dir, "dev/spec.json", 10, time.Hour)
+	category := cache.Category{Name: "state", Dir: dir, Protected: true}
+
+	results, err := cache.Clean([]cache.Category{category}, cache.CleanOptions{All: true, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, results[0].Removed)
+	assert.FileExists(t, stateFile, "a dry run must not remove anything")
+
+	_, err = cache.Clean([]cache.Category{category}, cache.CleanOptions{All: true})
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(dir, "dev"))
+}
+
+func TestCollect_SkipsProtectedCategories(t *testing.T) {
+	t.Parallel()
+
+	schemas := t.TempDir()
+	states := t.TempDir()
+	expiredSchema := writeEntry(t, schemas, "old.json", 10, 60*24*time.Hour)
+	expiredState := writeEntry(t, states, "dev/spec.json", 10, 60*24*time.Hour)
+
+	_, err := cache.Collect([]cache.Category{
+		{Name: "schemas", Dir: schemas, TTL: 24 * time.Hour},
+		{Name: "state", Dir: states, TTL: 24 * time.Hour, Protected: true},
+	}, testNow())
+
+	require.NoError(t, err)
+	assert.NoFileExists(t, expiredSchema)
+	assert.FileExists(t, expiredState)
+}
+
+func TestSelectCategories(t *testing.T) {
+	t.Parallel()
+
+	categories := []cache.Category{
+		{Name: "schemas"},
+		{Name: "sessions"},
+		{Name: "state", Protected: true},
+	}
+
+	selected, err := cache.SelectCategories(categories, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []cache.Category{{Name: "schemas"}, {Name: "sessions"}}, selected)
+
+	selected, err = cache.SelectCategories(categories, []string{"state", "schemas"})
+	require.NoError(t, err)
+	assert.Equal(t, []cache.Category{{Name: "schemas"}, {Name: "state", Protected: true}}, selected)
+
+	_, err = cache.SelectCategories(categories, []string{"charts"})
+	require.ErrorIs(t, err, cache.ErrUnknownCategory)
+}
+
+//nolint:paralleltest // Overrides HOME.
+func TestDefaultCategories(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+	categories, err := cache.DefaultCategories()
+	require.NoError(t, err)
+
+	dirs := map[string]string{}
+	protected := map[string]bool{}
+
+	for _, category := range categories {
+		dirs[category.Name] = category.Dir
+		protected[category.Name] = category.Protected
+	}
+
+	assert.Equal(t, filepath.Join(home, ".ksail", "clusters"), dirs[cache.CategoryState])
+	assert.Equal(t, filepath.Join(home, ".ksail", "sessions"), dirs[cache.CategorySessions])
+	assert.Equal(t, filepath.Join(home, ".ksail", "chat", "sessions"), dirs[cache.CategoryChat])
+	assert.Equal(t, filepath.Join(home, ".cache", "ksail", "kubeconform"), dirs[cache.CategorySchemas])
+	assert.Equal(t, filepath.Join(home, ".ksail", "schemas"), dirs[cache.CategorySchemaStore])
+	assert.True(t, protected[cache.CategorySchemaStore], "offline validation depends on the schema store")
+	assert.False(t, protected[cache.CategorySchemas])
+	assert.Equal(t, filepath.Join(home, ".ksail", "bases"), dirs[cache.CategoryBases])
+	assert.False(t, protected[cache.CategoryBases], "fetched bases are refetched on demand")
+}
+
+//nolint:paralleltest // Overrides HOME.
+func TestMaybeCollect_RunsAtMostDaily(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+	sessions := filepath.Join(home, ".ksail", "sessions")
+	writeEntry(t, sessions, "old.log", 10, 365*24*time.Hour)
+
+	require.NoError(t, cache.MaybeCollect(testNow()))
+	assert.NoFileExists(t, filepath.Join(sessions, "old.log"))
+
+	// Within a day of the last run, nothing is collected.
+	stale := writeEntry(t, sessions, "stale.log", 10, 365*24*time.Hour)
+	require.NoError(t, cache.MaybeCollect(testNow().Add(time.Hour)))
+	assert.FileExists(t, stale)
+
+	require.NoError(t, cache.MaybeCollect(testNow().Add(25*time.Hour)))
+	assert.NoFileExists(t, stale)
+}
@@ -0,0 +1,18 @@
+// Package cache manages the data KSail keeps on the local machine: downloaded
+// schemas, the offline schema store, recorded node access sessions, chat
+// sessions, fetched base configurations, OIDC tokens, and cluster state.
+//
+// Each kind of data is a Category with a retention policy (a TTL and a size
+// limit). Garbage collection removes the entries of a category that outlived
+// its TTL and then the least recently modified ones until it fits its size
+// limit, so ~/.ksail and the user cache directory stop growing unbounded.
+// Protected categories (the offline schema store, OIDC tokens, cluster state)
+// hold data KSail needs to operate and are never garbage-collected; they are
+// only emptied on request.
+//
+// Helm charts and image tarballs are out of scope. Charts are downloaded into
+// Helm's shared repository and content caches (HELM_REPOSITORY_CACHE,
+// HELM_CONTENT_CACHE), which other Helm clients use too, and image tarballs are
+// written to the path the user passes to 'ksail workload export'. KSail
+// owns neither location, so it leaves both to the user.
+package cache
@@ -0,0 +1,294 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+)
+
+// collectInterval is how often MaybeCollect garbage-collects.
+const collectInterval = day
+
+// collectStampName is the file under ~/.ksail whose modification time records
+// the last automatic garbage collection.
+const collectStampName = "cache-gc.stamp"
+
+// Usage is the disk usage of one category.
+type Usage struct {
+	// Category is the inspected category.
+	Category Category
+	// Entries is the number of top-level files and directories it holds.
+	Entries int
+	// Size is the total size of its files in bytes.
+	Size int64
+	// Oldest is the modification time of its least recently modified entry;
+	// zero when it is empty.
+	Oldest time.Time
+}
+
+// CleanOptions configures Clean.
+type CleanOptions struct {
+	// All removes every entry instead of only the expired and over-size ones.
+	All bool
+	// DryRun reports what would be removed without removing it.
+	DryRun bool
+	// Now is the reference time TTLs are measured from.
+	Now time.Time
+}
+
+// CleanResult is what Clean removed from one category.
+type CleanResult struct {
+	// Category is the cleaned category.
+	Category Category
+	// Removed is the number of entries removed.
+	Removed int
+	// Freed is the number of bytes freed.
+	Freed int64
+}
+
+// entry is one top-level file or directory of a category.
+type entry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Inspect returns the disk usage of every category. A category whose
+// directory does not exist yet is reported as empty.
+func Inspect(categories []Category) ([]Usage, error) {
+	usages := make([]Usage, 0, len(categories))
+
+	for _, category := range categories {
+		entries, err := readEntries(category.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("inspect %s: %w", category.Name, err)
+		}
+
+		usage := Usage{Category: category, Entries: len(entries)}
+
+		for _, current := range entries {
+			usage.Size += current.size
+
+			if usage.Oldest.IsZero() || current.modTime.Before(usage.Oldest) {
+				usage.Oldest = current.modTime
+			}
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// Clean removes entries from every category: with opts.All all of them,
+// otherwise the entries older than the category's TTL and then the least
+// recently modified ones until the category fits its MaxSize.
+func Clean(categories []Category, opts CleanOptions) ([]CleanResult, error) {
+	results := make([]CleanResult, 0, len(categories))
+
+	for _, category := range categories {
+		result, err := cleanCategory(category, opts)
+		if err != nil {
+			return results, fmt.Errorf("clean %s: %w", category.Name, err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Collect garbage-collects every unprotected category by its retention policy.
+func Collect(categories []Category, now time.Time) ([]CleanResult, error) {
+	unprotected, _ := SelectCategories(categories, nil)
+
+	return Clean(unprotected, CleanOptions{Now: now})
+}
+
+// MaybeCollect garbage-collects the default categories at most once per day,
+// recording each run in ~/.ksail/cache-gc.stamp. It is best-effort: callers
+// run it opportunistically and a failure only postpones the collection.
+func MaybeCollect(now time.Time) error {
+	categories, err := DefaultCategories()
+	if err != nil {
+		return err
+	}
+
+	stamp, err := collectStampPath()
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(stamp)
+	if err == nil && now.Sub(info.ModTime()) < collectInterval {
+		return nil
+	}
+
+	err = touch(stamp, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = Collect(categories, now)
+
+	return err
+}
+
+// cleanCategory applies opts to one category.
+func cleanCategory(category Category, opts CleanOptions) (CleanResult, error) {
+	result := CleanResult{Category: category}
+
+	entries, err := readEntries(category.Dir)
+	if err != nil {
+		return result, err
+	}
+
+	for _, current := range entriesToRemove(entries, category, opts) {
+		if !opts.DryRun {
+			err = os.RemoveAll(current.path)
+			if err != nil {
+				return result, fmt.Errorf("remove %s: %w", current.path, err)
+			}
+		}
+
+		result.Removed++
+		result.Freed += current.size
+	}
+
+	return result, nil
+}
+
+// entriesToRemove picks the entries Clean removes from a category.
+func entriesToRemove(entries []entry, category Category, opts CleanOptions) []entry {
+	if opts.All {
+		return entries
+	}
+
+	// Oldest first, so expired entries form a prefix and the size limit
+	// evicts the least recently modified entries.
+	slices.SortFunc(entries, func(left, right entry) int {
+		return left.modTime.Compare(right.modTime)
+	})
+
+	var total int64
+	for _, current := range entries {
+		total += current.size
+	}
+
+	removed := 0
+
+	for _, current := range entries {
+		expired := category.TTL > 0 && opts.Now.Sub(current.modTime) > category.TTL
+		oversize := category.MaxSize > 0 && total > category.MaxSize
+
+		if !expired && !oversize {
+			break
+		}
+
+		total -= current.size
+		removed++
+	}
+
+	return entries[:removed]
+}
+
+// readEntries returns the top-level entries of dir with their total size and
+// latest modification time. A missing dir has no entries.
+func readEntries(dir string) ([]entry, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read %s: %w", dir, err)
+	}
+
+	entries := make([]entry, 0, len(dirEntries))
+
+	for _, dirEntry := range dirEntries {
+		current, err := measure(filepath.Join(dir, dirEntry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, current)
+	}
+
+	return entries, nil
+}
+
+// measure returns the total size and latest modification time of the files
+// under path. Symlinks are counted as themselves and not followed.
+func measure(path string) (entry, error) {
+	measured := entry{path: path}
+
+	err := filepath.WalkDir(path, func(_ string, dirEntry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return fmt.Errorf("stat: %w", err)
+		}
+
+		if !dirEntry.IsDir() {
+			measured.size += info.Size()
+		}
+
+		if info.ModTime().After(measured.modTime) {
+			measured.modTime = info.ModTime()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return measured, fmt.Errorf("measure %s: %w", path, err)
+	}
+
+	return measured, nil
+}
+
+// collectStampPath returns the path of the automatic collection stamp.
+func collectStampPath() (string, error) {
+	clustersDir, err := state.ClustersDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve state directory: %w", err)
+	}
+
+	return filepath.Join(filepath.Dir(clustersDir), collectStampName), nil
+}
+
+// touch creates path if needed and sets its modification time to now.
+func touch(path string, now time.Time) error {
+	const (
+		dirPermissions  = 0o700
+		filePermissions = 0o600
+	)
+
+	err := os.MkdirAll(filepath.Dir(path), dirPermissions)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, filePermissions) //nolint:gosec // fixed path under ~/.ksail
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+
+	_ = file.Close()
+
+	err = os.Chtimes(path, now, now)
+	if err != nil {
+		return fmt.Errorf("update %s: %w", path, err)
+	}
+
+	return nil
+}
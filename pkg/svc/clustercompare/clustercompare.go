@@ -0,0 +1,381 @@
+package clustercompare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SpecSource is where the configuration of a Snapshot came from.
+type SpecSource string
+
+const (
+	// SpecSourceInCluster is the state KSail keeps in the cluster's
+	// kube-system/ksail-state ConfigMap.
+	SpecSourceInCluster SpecSource = "in-cluster state"
+	// SpecSourceLocal is the state saved in ~/.ksail on the machine that created
+	// or last updated the cluster.
+	SpecSourceLocal SpecSource = "local state"
+	// SpecSourceDetected is the configuration detected from the live cluster. It
+	// covers only the components the detector can see.
+	SpecSourceDetected SpecSource = "detected"
+	// SpecSourceUnknown means no configuration could be determined.
+	SpecSourceUnknown SpecSource = "unknown"
+)
+
+// Section groups the differences of a Result.
+type Section string
+
+const (
+	// SectionCluster covers the nodes: Kubernetes version, counts, and runtime.
+	SectionCluster Section = "cluster"
+	// SectionConfig covers the ClusterSpec fields, named by their ksail.yaml path.
+	SectionConfig Section = "config"
+	// SectionComponents covers the Helm releases of KSail-managed components.
+	SectionComponents Section = "components"
+)
+
+// controlPlaneLabel marks control-plane nodes.
+const controlPlaneLabel = "node-role.kubernetes.io/control-plane"
+
+// specPathPrefix names ClusterSpec fields as they appear in ksail.yaml.
+const specPathPrefix = "spec.cluster."
+
+// machineLocalFields are ClusterSpec fields that describe the machine a cluster
+// was created from rather than the cluster, so they differ between any two
+// clusters and are never compared.
+//
+//nolint:gochecknoglobals // constant lookup set
+var machineLocalFields = []string{"connection", "distributionConfig"}
+
+// Snapshot is what is known about one cluster at one point in time.
+type Snapshot struct {
+	Name string `json:"name"`
+	// SpecSource is where Spec came from; Spec is nil when it is SpecSourceUnknown.
+	SpecSource SpecSource            `json:"specSource"`
+	Spec       *v1alpha1.ClusterSpec `json:"spec,omitempty"`
+	// KubernetesVersion is the kubelet version of the first control-plane node.
+	KubernetesVersion string `json:"kubernetesVersion"`
+	ControlPlanes     int    `json:"controlPlanes"`
+	Workers           int    `json:"workers"`
+	// OSImage and ContainerRuntime are those of the first control-plane node.
+	OSImage          string                      `json:"osImage,omitempty"`
+	ContainerRuntime string                      `json:"containerRuntime,omitempty"`
+	Components       []detector.ComponentVersion `json:"components"`
+	// Warnings lists sections that could not be captured.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Sources are the clients Capture reads from. Only Clientset is required; a nil
+// Helm client skips component versions and detection, and a nil LocalSpec skips
+// the local state.
+type Sources struct {
+	Clientset kubernetes.Interface
+	Helm      helm.Interface
+	// LocalSpec loads the ClusterSpec saved in the local state, returning
+	// state.ErrStateNotFound when there is none (see state.LoadClusterSpec).
+	LocalSpec func(clusterName string) (*v1alpha1.ClusterSpec, error)
+}
+
+// Capture builds the snapshot of clusterName. It fails only when the nodes
+// cannot be listed; other sections that fail are recorded as warnings.
+func Capture(ctx context.Context, clusterName string, sources Sources) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Name:       clusterName,
+		SpecSource: SpecSourceUnknown,
+		Components: []detector.ComponentVersion{},
+	}
+
+	err := snapshot.captureNodes(ctx, sources.Clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.captureSpec(ctx, sources)
+
+	if sources.Helm != nil {
+		versions, err := detector.DetectComponentVersions(ctx, sources.Helm)
+		if err != nil {
+			snapshot.warn("components: %v", err)
+		} else {
+			snapshot.Components = versions
+		}
+	}
+
+	return snapshot, nil
+}
+
+// warn records a section that could not be captured.
+func (s *Snapshot) warn(format string, args ...any) {
+	s.Warnings = append(s.Warnings, fmt.Sprintf(format, args...))
+}
+
+// captureNodes counts the nodes by role and records the version, operating
+// system, and runtime of the first control-plane node (or the first node when
+// none is labelled control-plane).
+func (s *Snapshot) captureNodes(ctx context.Context, clientset kubernetes.Interface) error {
+	list, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list nodes: %w", err)
+	}
+
+	reference := -1
+
+	for index, node := range list.Items {
+		if _, ok := node.Labels[controlPlaneLabel]; !ok {
+			s.Workers++
+
+			continue
+		}
+
+		s.ControlPlanes++
+
+		if reference < 0 {
+			reference = index
+		}
+	}
+
+	if reference < 0 && len(list.Items) > 0 {
+		reference = 0
+	}
+
+	if reference >= 0 {
+		info := list.Items[reference].Status.NodeInfo
+		s.KubernetesVersion = info.KubeletVersion
+		s.OSImage = info.OSImage
+		s.ContainerRuntime = info.ContainerRuntimeVersion
+	}
+
+	return nil
+}
+
+// captureSpec resolves the cluster's configuration from the in-cluster state,
+// then the local state, then component detection.
+func (s *Snapshot) captureSpec(ctx context.Context, sources Sources) {
+	saved, err := state.LoadInClusterState(ctx, sources.Clientset)
+	if err == nil {
+		s.Spec, s.SpecSource = saved.Spec, SpecSourceInCluster
+
+		return
+	}
+
+	if !errors.Is(err, state.ErrStateNotFound) {
+		s.warn("in-cluster state: %v", err)
+	}
+
+	if sources.LocalSpec != nil {
+		spec, err := sources.LocalSpec(s.Name)
+		if err == nil {
+			s.Spec, s.SpecSource = spec, SpecSourceLocal
+
+			return
+		}
+
+		if !errors.Is(err, state.ErrStateNotFound) {
+			s.warn("local state: %v", err)
+		}
+	}
+
+	if sources.Helm == nil {
+		return
+	}
+
+	spec, err := detector.NewComponentDetector(sources.Helm, sources.Clientset, nil).
+		DetectComponents(ctx, "", "")
+	if err != nil {
+		s.warn("component detection: %v", err)
+
+		return
+	}
+
+	s.Spec, s.SpecSource = spec, SpecSourceDetected
+}
+
+// Difference is one field on which two clusters disagree. An empty A or B means
+// the field is unset (or the component not installed) on that cluster.
+type Difference struct {
+	Section Section `json:"section"`
+	Field   string  `json:"field"`
+	A       string  `json:"a"`
+	B       string  `json:"b"`
+}
+
+// Result is the comparison of two snapshots.
+type Result struct {
+	A           *Snapshot    `json:"a"`
+	B           *Snapshot    `json:"b"`
+	Differences []Difference `json:"differences"`
+}
+
+// Compare lists the differences between a and b: node facts first, then the
+// configuration, then the components. The configuration is compared only when
+// both are known; a field missing from a detected configuration is skipped,
+// since the detector cannot see it.
+func Compare(a, b *Snapshot) Result {
+	result := Result{A: a, B: b, Differences: []Difference{}}
+
+	result.add(SectionCluster, "kubernetesVersion", a.KubernetesVersion, b.KubernetesVersion)
+	result.add(SectionCluster, "controlPlanes", strconv.Itoa(a.ControlPlanes), strconv.Itoa(b.ControlPlanes))
+	result.add(SectionCluster, "workers", strconv.Itoa(a.Workers), strconv.Itoa(b.Workers))
+	result.add(SectionCluster, "osImage", a.OSImage, b.OSImage)
+	result.add(SectionCluster, "containerRuntime", a.ContainerRuntime, b.ContainerRuntime)
+
+	if a.Spec != nil && b.Spec != nil {
+		result.compareSpecs(a, b)
+	}
+
+	result.compareComponents(a.Components, b.Components)
+
+	return result
+}
+
+// add records a difference when valueA and valueB differ.
+func (r *Result) add(section Section, field, valueA, valueB string) {
+	if valueA != valueB {
+		r.Differences = append(r.Differences, Difference{Section: section, Field: field, A: valueA, B: valueB})
+	}
+}
+
+// compareSpecs compares the flattened ClusterSpec fields of a and b.
+func (r *Result) compareSpecs(a, b *Snapshot) {
+	fieldsA, fieldsB := flattenSpec(a.Spec), flattenSpec(b.Spec)
+
+	paths := make([]string, 0, len(fieldsA)+len(fieldsB))
+	for path := range fieldsA {
+		paths = append(paths, path)
+	}
+
+	for path := range fieldsB {
+		if _, ok := fieldsA[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		valueA, inA := fieldsA[path]
+		valueB, inB := fieldsB[path]
+
+		if (!inA && a.SpecSource == SpecSourceDetected) || (!inB && b.SpecSource == SpecSourceDetected) {
+			continue
+		}
+
+		r.add(SectionConfig, specPathPrefix+path, valueA, valueB)
+	}
+}
+
+// flattenSpec maps every set leaf field of spec to its value, keyed by the
+// dotted path of its JSON names. Non-empty lists are kept whole as compact JSON.
+func flattenSpec(spec *v1alpha1.ClusterSpec) map[string]string {
+	fields := map[string]string{}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fields
+	}
+
+	var tree map[string]any
+
+	err = json.Unmarshal(data, &tree)
+	if err != nil {
+		return fields
+	}
+
+	for _, field := range machineLocalFields {
+		delete(tree, field)
+	}
+
+	flatten("", tree, fields)
+
+	return fields
+}
+
+// flatten walks value, recording leaves under prefix.
+func flatten(prefix string, value any, fields map[string]string) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, child := range typed {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+
+			flatten(path, child, fields)
+		}
+	case string:
+		fields[prefix] = typed
+	case []any:
+		if len(typed) > 0 {
+			encoded, err := json.Marshal(typed)
+			if err == nil {
+				fields[prefix] = string(encoded)
+			}
+		}
+	case nil:
+	default:
+		encoded, err := json.Marshal(typed)
+		if err == nil {
+			fields[prefix] = string(encoded)
+		}
+	}
+}
+
+// compareComponents compares the releases of the components installed in
+// either cluster, in display order.
+func (r *Result) compareComponents(a, b []detector.ComponentVersion) {
+	versionsB := make(map[string]detector.ComponentVersion, len(b))
+	for _, version := range b {
+		versionsB[version.Component] = version
+	}
+
+	seen := make(map[string]bool, len(a))
+
+	for _, version := range a {
+		seen[version.Component] = true
+
+		other, ok := versionsB[version.Component]
+
+		valueB := ""
+		if ok {
+			valueB = componentValue(other)
+		}
+
+		r.add(SectionComponents, version.Component, componentValue(version), valueB)
+	}
+
+	for _, version := range b {
+		if !seen[version.Component] {
+			r.add(SectionComponents, version.Component, "", componentValue(version))
+		}
+	}
+}
+
+// componentValue describes an installed component by chart, app version, and,
+// when not deployed, release status.
+func componentValue(version detector.ComponentVersion) string {
+	var value strings.Builder
+
+	value.WriteString(version.Chart)
+
+	if version.AppVersion != "" {
+		value.WriteString(" (app " + version.AppVersion + ")")
+	}
+
+	if !version.Deployed() {
+		value.WriteString(" [" + version.Status + "]")
+	}
+
+	return value.String()
+}
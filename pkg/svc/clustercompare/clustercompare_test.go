@@ -0,0 +1,211 @@
+package clustercompare_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clustercompare"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var errHelmForbidden = errors.New("secrets is forbidden")
+
+func node(name, version string, controlPlane bool) *corev1.Node {
+	labels := map[string]string{}
+	if controlPlane {
+		labels["node-role.kubernetes.io/control-plane"] = ""
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+			KubeletVersion:          version,
+			OSImage:                 "Debian GNU/Linux 12 (bookworm)",
+			ContainerRuntimeVersion: "containerd://2.1.0",
+		}},
+	}
+}
+
+func helmWith(t *testing.T, releases ...helm.ReleaseInfo) *helm.MockInterface {
+	t.Helper()
+
+	helmClient := helm.NewMockInterface(t)
+	helmClient.On("ListReleases", mock.Anything).Return(releases, nil).Maybe()
+	helmClient.On("ReleaseExists", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Maybe()
+
+	return helmClient
+}
+
+func cilium(chart, appVersion string) helm.ReleaseInfo {
+	return helm.ReleaseInfo{
+		Name: detector.ReleaseCilium, Namespace: detector.NamespaceCilium,
+		Revision: 1, Status: "deployed", Chart: chart, AppVersion: appVersion,
+	}
+}
+
+func TestCapture_PrefersInClusterState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clientset := fake.NewClientset(
+		node("mine-control-plane", "v1.35.1", true),
+		node("mine-worker", "v1.35.1", false),
+		node("mine-worker2", "v1.35.1", false),
+	)
+
+	saved := &v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionVanilla, CNI: v1alpha1.CNICilium}
+	require.NoError(t, state.SaveInClusterState(ctx, clientset, "mine", saved, nil, time.Now()))
+
+	snapshot, err := clustercompare.Capture(ctx, "mine", clustercompare.Sources{
+		Clientset: clientset,
+		Helm:      helmWith(t, cilium("cilium-1.16.3", "1.16.3")),
+		LocalSpec: func(string) (*v1alpha1.ClusterSpec, error) {
+			t.Fatal("local state read although the in-cluster state exists")
+
+			return nil, nil
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, clustercompare.SpecSourceInCluster, snapshot.SpecSource)
+	assert.Equal(t, v1alpha1.CNICilium, snapshot.Spec.CNI)
+	assert.Equal(t, "v1.35.1", snapshot.KubernetesVersion)
+	assert.Equal(t, 1, snapshot.ControlPlanes)
+	assert.Equal(t, 2, snapshot.Workers)
+	assert.Equal(t, "containerd://2.1.0", snapshot.ContainerRuntime)
+	require.Len(t, snapshot.Components, 1)
+	assert.Equal(t, "Cilium", snapshot.Components[0].Component)
+	assert.Empty(t, snapshot.Warnings)
+}
+
+func TestCapture_FallsBackToLocalStateThenDetection(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	local := &v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionK3s}
+
+	fromLocal, err := clustercompare.Capture(ctx, "mine", clustercompare.Sources{
+		Clientset: fake.NewClientset(node("mine-server-0", "v1.35.1+k3s1", true)),
+		LocalSpec: func(name string) (*v1alpha1.ClusterSpec, error) {
+			assert.Equal(t, "mine", name)
+
+			return local, nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, clustercompare.SpecSourceLocal, fromLocal.SpecSource)
+	assert.Same(t, local, fromLocal.Spec)
+
+	detected, err := clustercompare.Capture(ctx, "theirs", clustercompare.Sources{
+		Clientset: fake.NewClientset(node("theirs-control-plane", "v1.35.1", true)),
+		Helm:      helmWith(t, cilium("cilium-1.16.3", "1.16.3")),
+		LocalSpec: func(string) (*v1alpha1.ClusterSpec, error) {
+			return nil, state.ErrStateNotFound
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, clustercompare.SpecSourceDetected, detected.SpecSource)
+	assert.Equal(t, v1alpha1.CNICilium, detected.Spec.CNI)
+	assert.Empty(t, detected.Warnings)
+}
+
+func TestCapture_RecordsHelmFailureAsWarning(t *testing.T) {
+	t.Parallel()
+
+	helmClient := helm.NewMockInterface(t)
+	helmClient.On("ListReleases", mock.Anything).Return(nil, errHelmForbidden)
+	helmClient.On("ReleaseExists", mock.Anything, mock.Anything, mock.Anything).Return(false, errHelmForbidden).Maybe()
+
+	snapshot, err := clustercompare.Capture(context.Background(), "mine", clustercompare.Sources{
+		Clientset: fake.NewClientset(node("mine-control-plane", "v1.35.1", true)),
+		Helm:      helmClient,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, clustercompare.SpecSourceUnknown, snapshot.SpecSource)
+	assert.Nil(t, snapshot.Spec)
+	assert.Empty(t, snapshot.Components)
+	assert.Len(t, snapshot.Warnings, 2)
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	mine := &clustercompare.Snapshot{
+		Name:              "mine",
+		SpecSource:        clustercompare.SpecSourceInCluster,
+		KubernetesVersion: "v1.35.1",
+		ControlPlanes:     1,
+		Workers:           2,
+		OSImage:           "Debian GNU/Linux 12 (bookworm)",
+		Spec: &v1alpha1.ClusterSpec{
+			Distribution: v1alpha1.DistributionVanilla,
+			CNI:          v1alpha1.CNICilium,
+			CertManager:  v1alpha1.CertManagerEnabled,
+			Connection:   v1alpha1.Connection{Context: "kind-mine"},
+		},
+		Components: []detector.ComponentVersion{
+			{Component: "Cilium", Chart: "cilium-1.16.3", AppVersion: "1.16.3", Status: "deployed"},
+			{Component: "cert-manager", Chart: "cert-manager-v1.16.2", AppVersion: "v1.16.2", Status: "deployed"},
+		},
+	}
+	theirs := &clustercompare.Snapshot{
+		Name:              "theirs",
+		SpecSource:        clustercompare.SpecSourceDetected,
+		KubernetesVersion: "v1.34.0",
+		ControlPlanes:     1,
+		OSImage:           "Debian GNU/Linux 12 (bookworm)",
+		Spec: &v1alpha1.ClusterSpec{
+			CNI:         v1alpha1.CNICilium,
+			CertManager: v1alpha1.CertManagerDisabled,
+		},
+		Components: []detector.ComponentVersion{
+			{Component: "Cilium", Chart: "cilium-1.15.0", AppVersion: "1.15.0", Status: "failed"},
+			{Component: "Flux", Chart: "flux-operator-0.9.0", Status: "deployed"},
+		},
+	}
+
+	result := clustercompare.Compare(mine, theirs)
+
+	// The distribution is unknown to the detector and the connection is local to
+	// the machine, so neither is reported.
+	assert.Equal(t, []clustercompare.Difference{
+		{Section: clustercompare.SectionCluster, Field: "kubernetesVersion", A: "v1.35.1", B: "v1.34.0"},
+		{Section: clustercompare.SectionCluster, Field: "workers", A: "2", B: "0"},
+		{Section: clustercompare.SectionConfig, Field: "spec.cluster.certManager", A: "Enabled", B: "Disabled"},
+		{
+			Section: clustercompare.SectionComponents, Field: "Cilium",
+			A: "cilium-1.16.3 (app 1.16.3)", B: "cilium-1.15.0 (app 1.15.0) [failed]",
+		},
+		{
+			Section: clustercompare.SectionComponents, Field: "cert-manager",
+			A: "cert-manager-v1.16.2 (app v1.16.2)",
+		},
+		{Section: clustercompare.SectionComponents, Field: "Flux", B: "flux-operator-0.9.0"},
+	}, result.Differences)
+}
+
+func TestCompare_IdenticalClusters(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &clustercompare.Snapshot{
+		Name:              "mine",
+		SpecSource:        clustercompare.SpecSourceLocal,
+		KubernetesVersion: "v1.35.1",
+		ControlPlanes:     1,
+		Spec:              &v1alpha1.ClusterSpec{CNI: v1alpha1.CNICilium},
+	}
+
+	assert.Empty(t, clustercompare.Compare(snapshot, snapshot).Differences)
+}
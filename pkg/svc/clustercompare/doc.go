@@ -0,0 +1,14 @@
+// Package clustercompare explains why "it works on my cluster" by comparing two
+// KSail clusters for `ksail cluster diff <a> <b>`.
+//
+// Each cluster is captured once with [Capture] into a [Snapshot]: its
+// configuration (from the state KSail keeps in the cluster, the creator's local
+// state, or, failing both, component detection against the live cluster), its
+// nodes' Kubernetes version, count, and runtime, and the versions of the
+// KSail-managed components read from their Helm releases. [Compare] then lists
+// every field on which the two snapshots disagree.
+//
+// Only node listing is essential: a cluster whose nodes cannot be listed is
+// unreachable and Capture fails. Every other section degrades to a warning on
+// the snapshot so a partial comparison is still shown.
+package clustercompare
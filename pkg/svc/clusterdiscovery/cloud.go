@@ -213,7 +213,8 @@ func (d *Discoverer) listKubernetes(ctx context.Context) ([]Cluster, error) {
 }
 
 // kubernetesClusters converts the provider's ClusterInfo to discovered clusters, mapping the
-// detected distribution string to its enum (falling back to Vanilla on an unknown value).
+// detected distribution string to its enum (falling back to Vanilla on an unknown value). A
+// paused cluster is reported stopped; otherwise the run-state is left unknown.
 func kubernetesClusters(infos []kubernetesprovider.ClusterInfo) []Cluster {
 	clusters := make([]Cluster, 0, len(infos))
 
@@ -225,10 +226,16 @@ func kubernetesClusters(infos []kubernetesprovider.ClusterInfo) []Cluster {
 			distribution = v1alpha1.DistributionVanilla
 		}
 
+		runState := RunStateUnknown
+		if info.Paused {
+			runState = RunStateStopped
+		}
+
 		clusters = append(clusters, Cluster{
 			Name:         info.Name,
 			Distribution: distribution,
 			Provider:     v1alpha1.ProviderKubernetes,
+			RunState:     runState,
 		})
 	}
 
@@ -13,10 +13,12 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	talosconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/talos"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	kubernetesprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/kubernetes"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clustererr"
@@ -45,6 +47,10 @@ const (
 	// never actionable by ksail-only operations. Distinct from RunStateUnknown, which is a managed
 	// cluster whose provider cannot report run-state.
 	RunStateUnmanaged RunState = "unmanaged"
+	// RunStateMissing marks a cluster recorded in the local state registry whose infrastructure no
+	// longer exists (e.g. its containers were removed outside ksail). Discovery itself never reports
+	// it; callers that merge the registry (`ksail cluster list`) assign it.
+	RunStateMissing RunState = "missing"
 )
 
 // Cluster is a single cluster discovered from a provider.
@@ -56,6 +62,12 @@ type Cluster struct {
 	// (Docker today). RunStateUnknown for providers that do not — callers must not treat unknown as
 	// stopped.
 	RunState RunState
+	// Nodes is the number of nodes (containers) the cluster has, when the provider reports it (Docker
+	// with probing enabled). Zero means unknown.
+	Nodes int
+	// Created is when the cluster's oldest node was created, when the provider reports it (Docker
+	// with probing enabled). The zero time means unknown.
+	Created time.Time
 }
 
 // ProviderError records a non-fatal failure while listing one provider. Discovery continues for the
@@ -121,10 +133,20 @@ type Discoverer struct {
 	// RunStateUnknown (never a discovery failure). Primarily for tests.
 	DockerStatus func(ctx context.Context, distribution v1alpha1.Distribution, name string) RunState
 
-	// ProbeRunState gates the per-cluster Docker run-state probe in listDocker. Callers that render
-	// run-state (the web UI) set it true; callers that only enumerate names (the `cluster list` CLI,
-	// which does not display run-state) leave it false to avoid N wasted Docker round-trips per
-	// invocation. A configured DockerStatus seam also enables probing so tests need not set this.
+	// DockerNodes lists a Docker-based cluster's nodes by distribution and name, from which discovery
+	// derives Nodes and Created (and run-state when DockerStatus is nil). Nil means a real query via a
+	// docker provider built from the environment; a listing that errors leaves the facts unknown.
+	// Primarily for tests.
+	DockerNodes func(
+		ctx context.Context,
+		distribution v1alpha1.Distribution,
+		name string,
+	) ([]provider.NodeInfo, error)
+
+	// ProbeRunState gates the per-cluster Docker probe (run-state, node count, age) in listDocker.
+	// Callers that render those facts (the web UI, `ksail cluster list`) set it true; callers that only
+	// enumerate names leave it false to avoid N Docker round-trips per invocation. A configured
+	// DockerStatus or DockerNodes seam also enables probing so tests need not set this.
 	ProbeRunState bool
 }
 
@@ -282,19 +304,20 @@ func (d *Discoverer) listDocker(ctx context.Context) ([]Cluster, error) {
 
 			seen[name] = struct{}{}
 
-			// Only probe run-state when a caller actually renders it (web UI). The
-			// `cluster list` CLI does not display run-state, so it skips the per-cluster
-			// Docker round-trip and leaves RunStateUnknown.
-			runState := RunStateUnknown
-			if d.ProbeRunState || d.DockerStatus != nil {
-				runState = d.dockerRunState(ctx, distribution, name)
+			// Only probe when a caller actually renders the facts; name-only callers skip the
+			// per-cluster Docker round-trip and leave them unknown.
+			var facts dockerFacts
+			if d.ProbeRunState || d.DockerStatus != nil || d.DockerNodes != nil {
+				facts = d.probeDocker(ctx, distribution, name)
 			}
 
 			clusters = append(clusters, Cluster{
 				Name:         name,
 				Distribution: distribution,
 				Provider:     v1alpha1.ProviderDocker,
-				RunState:     runState,
+				RunState:     facts.runState,
+				Nodes:        facts.nodes,
+				Created:      facts.created,
 			})
 		}
 	}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterdiscovery"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	kubernetesprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/kubernetes"
 	clusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clustererr"
@@ -204,6 +206,26 @@ func TestDiscover_KubernetesUnknownDistributionFallsBackToVanilla(t *testing.T)
 	assert.Equal(t, v1alpha1.DistributionVanilla, clusters[0].Distribution)
 }
 
+func TestDiscover_KubernetesPausedClusterIsStopped(t *testing.T) {
+	t.Parallel()
+
+	discoverer := &clusterdiscovery.Discoverer{
+		Kubernetes: fakeKubeLister{
+			infos: []kubernetesprovider.ClusterInfo{
+				{Name: "paused", Distribution: "VCluster", Paused: true},
+				{Name: "active", Distribution: "VCluster"},
+			},
+		},
+	}
+
+	clusters, _ := discoverer.Discover(context.Background(),
+		[]v1alpha1.Provider{v1alpha1.ProviderKubernetes})
+
+	require.Len(t, clusters, 2)
+	assert.Equal(t, clusterdiscovery.RunStateStopped, clusters[0].RunState)
+	assert.Equal(t, clusterdiscovery.RunStateUnknown, clusters[1].RunState)
+}
+
 func TestDiscover_SkipsCloudProvidersWithoutCredentials(t *testing.T) {
 	t.Parallel()
 
@@ -310,6 +332,35 @@ func TestDiscover_DockerReportsRunStateFromSeam(t *testing.T) {
 	assert.Equal(t, clusterdiscovery.RunStateStopped, byName["stopped-one"])
 }
 
+func TestDiscover_DockerReportsNodeFactsFromSeam(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	discoverer := &clusterdiscovery.Discoverer{
+		DockerFactory: dockerFactoryFor(map[v1alpha1.Distribution][]string{
+			v1alpha1.DistributionK3s: {"dev"},
+		}),
+		DockerNodes: func(
+			_ context.Context, _ v1alpha1.Distribution, _ string,
+		) ([]provider.NodeInfo, error) {
+			return []provider.NodeInfo{
+				{State: "running", Created: created.Add(time.Second)},
+				{State: "running", Created: created},
+			}, nil
+		},
+	}
+
+	clusters, failures := discoverer.Discover(context.Background(),
+		[]v1alpha1.Provider{v1alpha1.ProviderDocker})
+
+	require.Empty(t, failures)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, clusterdiscovery.RunStateRunning, clusters[0].RunState)
+	assert.Equal(t, 2, clusters[0].Nodes)
+	assert.Equal(t, created, clusters[0].Created)
+}
+
 func TestProviderSets(t *testing.T) {
 	t.Parallel()
 
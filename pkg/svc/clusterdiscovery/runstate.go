@@ -2,57 +2,116 @@ package clusterdiscovery
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
 )
 
-// dockerRunState reports a Docker-based cluster's run-state, dispatching to the injected DockerStatus
-// seam when present (tests) and otherwise to a real docker-provider probe. Any error is swallowed to
-// RunStateUnknown so a status probe never turns into a discovery failure or hides the cluster.
-func (d *Discoverer) dockerRunState(
+// dockerFacts is what a single probe learns about a Docker-based cluster.
+type dockerFacts struct {
+	runState RunState
+	nodes    int
+	created  time.Time
+}
+
+// probeDocker reports a Docker-based cluster's run-state, node count and creation time. Without seams
+// it lists the cluster's containers once and derives all three from that listing. The DockerStatus
+// seam, when present (tests), supplies run-state verbatim; node facts then come only from the
+// DockerNodes seam so a test never reaches a real daemon. Any error is swallowed to RunStateUnknown
+// and zero facts so a probe never turns into a discovery failure or hides the cluster.
+func (d *Discoverer) probeDocker(
 	ctx context.Context,
 	distribution v1alpha1.Distribution,
 	name string,
-) RunState {
+) dockerFacts {
+	if d.DockerStatus != nil && d.DockerNodes == nil {
+		return dockerFacts{runState: d.DockerStatus(ctx, distribution, name)}
+	}
+
+	var facts dockerFacts
+
+	nodes, err := d.dockerNodes(ctx, distribution, name)
+	if err == nil {
+		facts = factsFromNodes(nodes)
+	}
+
 	if d.DockerStatus != nil {
-		return d.DockerStatus(ctx, distribution, name)
+		facts.runState = d.DockerStatus(ctx, distribution, name)
+	}
+
+	return facts
+}
+
+// dockerNodes lists a Docker-based cluster's nodes, dispatching to the injected DockerNodes seam when
+// present and otherwise to a real docker-provider query.
+func (d *Discoverer) dockerNodes(
+	ctx context.Context,
+	distribution v1alpha1.Distribution,
+	name string,
+) ([]provider.NodeInfo, error) {
+	if d.DockerNodes != nil {
+		return d.DockerNodes(ctx, distribution, name)
 	}
 
-	return defaultDockerRunState(ctx, distribution, name)
+	return ListDockerNodes(ctx, distribution, name)
 }
 
-// defaultDockerRunState queries the Docker provider for a cluster's status and maps its provider-level
-// phase to a RunState. It builds a fresh docker client per call (status is read on demand, not on a
-// hot path) and returns RunStateUnknown on any failure so discovery stays best-effort.
-func defaultDockerRunState(
+// ListDockerNodes queries the Docker provider for the nodes of the distribution's cluster name. It
+// builds a fresh docker client per call (nodes are read on demand, not on a hot path).
+func ListDockerNodes(
 	ctx context.Context,
 	distribution v1alpha1.Distribution,
 	name string,
-) RunState {
+) ([]provider.NodeInfo, error) {
 	scheme, ok := dockerLabelScheme(distribution)
 	if !ok {
-		return RunStateUnknown
+		return []provider.NodeInfo{}, nil
 	}
 
 	cli, err := dockerclient.GetDockerClient()
 	if err != nil {
-		return RunStateUnknown
+		return nil, fmt.Errorf("create docker client: %w", err)
 	}
 
 	defer func() { _ = cli.Close() }()
 
-	status, err := dockerprovider.NewProvider(cli, scheme).GetClusterStatus(ctx, name)
-	if err != nil || status == nil {
-		return RunStateUnknown
+	nodes, err := dockerprovider.NewProvider(cli, scheme).ListNodes(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("list %s nodes: %w", name, err)
 	}
 
+	return nodes, nil
+}
+
+// factsFromNodes maps a node listing to run-state (running only when every node is running), the node
+// count, and the earliest node creation time as the cluster's age. An empty listing is
+// RunStateUnknown, matching a cluster whose provider reports no status.
+func factsFromNodes(nodes []provider.NodeInfo) dockerFacts {
+	status := provider.BuildClusterStatus(nodes, "running")
+	if status == nil {
+		return dockerFacts{}
+	}
+
+	facts := dockerFacts{runState: RunStateStopped, nodes: len(nodes)}
 	if status.Ready {
-		return RunStateRunning
+		facts.runState = RunStateRunning
+	}
+
+	for _, node := range nodes {
+		if node.Created.IsZero() {
+			continue
+		}
+
+		if facts.created.IsZero() || node.Created.Before(facts.created) {
+			facts.created = node.Created
+		}
 	}
 
-	return RunStateStopped
+	return facts
 }
 
 // dockerLabelScheme maps a Docker-based distribution to the container label scheme its nodes carry, so
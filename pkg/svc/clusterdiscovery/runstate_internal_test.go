@@ -2,13 +2,18 @@ package clusterdiscovery
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
 	"github.com/stretchr/testify/assert"
 )
 
+var errNodeListing = errors.New("list nodes")
+
 func TestDockerLabelScheme(t *testing.T) {
 	t.Parallel()
 
@@ -33,9 +38,9 @@ func TestDockerLabelScheme(t *testing.T) {
 	}
 }
 
-// TestDockerRunStateUsesSeam pins that the injected DockerStatus seam is used verbatim when present,
+// TestProbeDockerUsesStatusSeam pins that the injected DockerStatus seam is used verbatim when present,
 // so callers can drive run-state without a real Docker daemon.
-func TestDockerRunStateUsesSeam(t *testing.T) {
+func TestProbeDockerUsesStatusSeam(t *testing.T) {
 	t.Parallel()
 
 	discoverer := &Discoverer{
@@ -46,6 +51,51 @@ func TestDockerRunStateUsesSeam(t *testing.T) {
 		},
 	}
 
-	got := discoverer.dockerRunState(t.Context(), v1alpha1.DistributionVanilla, "x")
-	assert.Equal(t, RunStateStopped, got)
+	got := discoverer.probeDocker(t.Context(), v1alpha1.DistributionVanilla, "x")
+	assert.Equal(t, dockerFacts{runState: RunStateStopped}, got)
+}
+
+// TestProbeDockerDerivesFactsFromNodes pins that a single node listing yields run-state, node count,
+// and the oldest node's creation time, and that a failed listing leaves every fact unknown.
+func TestProbeDockerDerivesFactsFromNodes(t *testing.T) {
+	t.Parallel()
+
+	oldest := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		nodes []provider.NodeInfo
+		err   error
+		want  dockerFacts
+	}{
+		{
+			name: "all running",
+			nodes: []provider.NodeInfo{
+				{State: "running", Created: oldest.Add(time.Minute)},
+				{State: "running", Created: oldest},
+				{State: "running"},
+			},
+			want: dockerFacts{runState: RunStateRunning, nodes: 3, created: oldest},
+		},
+		{
+			name:  "some stopped",
+			nodes: []provider.NodeInfo{{State: "running"}, {State: "exited"}},
+			want:  dockerFacts{runState: RunStateStopped, nodes: 2},
+		},
+		{name: "no nodes", nodes: []provider.NodeInfo{}, want: dockerFacts{}},
+		{name: "listing fails", err: errNodeListing, want: dockerFacts{}},
+	}
+
+	for _, testCase := range cases {
+		discoverer := &Discoverer{
+			DockerNodes: func(
+				_ context.Context, _ v1alpha1.Distribution, _ string,
+			) ([]provider.NodeInfo, error) {
+				return testCase.nodes, testCase.err
+			},
+		}
+
+		got := discoverer.probeDocker(t.Context(), v1alpha1.DistributionK3s, "x")
+		assert.Equal(t, testCase.want, got, testCase.name)
+	}
 }
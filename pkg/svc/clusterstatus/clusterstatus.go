@@ -0,0 +1,429 @@
+package clusterstatus
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Health is the overall verdict of a Report.
+type Health string
+
+const (
+	// HealthHealthy means every node is Ready, every control-plane component is
+	// running, and the GitOps engine and components report no problems.
+	HealthHealthy Health = "Healthy"
+	// HealthDegraded means the cluster serves requests but a node is NotReady, a
+	// GitOps resource is not reconciled, or a component release is not deployed.
+	HealthDegraded Health = "Degraded"
+	// HealthUnhealthy means no node is Ready or a control-plane component is down.
+	HealthUnhealthy Health = "Unhealthy"
+)
+
+// controlPlaneSelector matches the static control-plane pods of kubeadm-based
+// distributions (Vanilla, Talos).
+const controlPlaneSelector = "tier=control-plane"
+
+// dnsSelector matches the cluster DNS pods (CoreDNS) on every distribution.
+const dnsSelector = "k8s-app=kube-dns"
+
+// embeddedAPIServer names the control-plane entry reported for distributions
+// that run their control plane inside one process (K3s, vCluster, KWOK): the API
+// server answering the node listing is the evidence it is up.
+const embeddedAPIServer = "kube-apiserver"
+
+// NodeStatus is the readiness of one node.
+type NodeStatus struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+	Ready bool     `json:"ready"`
+	// Version is the kubelet version.
+	Version string `json:"version"`
+	// Message explains why a node is not Ready.
+	Message string `json:"message,omitempty"`
+}
+
+// ComponentHealth is the health of one control-plane component, aggregated over
+// its pods (one per control-plane node).
+type ComponentHealth struct {
+	Name      string `json:"name"`
+	ReadyPods int    `json:"readyPods"`
+	TotalPods int    `json:"totalPods"`
+	// Embedded is true for a control plane running inside the distribution's
+	// server process, which has no pods of its own.
+	Embedded bool `json:"embedded,omitempty"`
+}
+
+// Ready reports whether every pod of the component is Ready.
+func (c ComponentHealth) Ready() bool {
+	return c.Embedded || (c.TotalPods > 0 && c.ReadyPods == c.TotalPods)
+}
+
+// GitOpsResource is the reconciliation state of one GitOps resource: a Flux
+// Kustomization or HelmRelease, or an Argo CD Application.
+type GitOpsResource struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	State     string `json:"state"`
+	Healthy   bool   `json:"healthy"`
+	Message   string `json:"message,omitempty"`
+}
+
+// GitOpsStatus is the reconciliation state of the installed GitOps engine.
+type GitOpsStatus struct {
+	// Engine is the detected engine; GitOpsEngineNone when none is installed.
+	Engine    v1alpha1.GitOpsEngine `json:"engine"`
+	Resources []GitOpsResource      `json:"resources"`
+}
+
+// Report is the aggregated health of a cluster at one point in time.
+type Report struct {
+	ClusterName  string                      `json:"clusterName"`
+	Health       Health                      `json:"health"`
+	CheckedAt    time.Time                   `json:"checkedAt"`
+	Nodes        []NodeStatus                `json:"nodes"`
+	ControlPlane []ComponentHealth           `json:"controlPlane"`
+	GitOps       GitOpsStatus                `json:"gitops"`
+	Components   []detector.ComponentVersion `json:"components"`
+	// Warnings lists sections that could not be collected.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Sources are the clients Collect reads from. Only Clientset is required; a nil
+// Helm client skips GitOps engine detection and component versions, and a nil
+// status function skips that engine's resources.
+type Sources struct {
+	Clientset      kubernetes.Interface
+	Helm           helm.Interface
+	FluxStatuses   func(ctx context.Context) ([]fluxclient.ResourceStatus, error)
+	ArgoCDStatuses func(ctx context.Context) ([]argocd.ApplicationStatus, error)
+	// Now stamps the report; time.Now when nil.
+	Now func() time.Time
+}
+
+// Collect builds the health report for clusterName. It fails only when the
+// nodes cannot be listed; other sections that fail are recorded as warnings.
+func Collect(ctx context.Context, clusterName string, sources Sources) (Report, error) {
+	now := time.Now
+	if sources.Now != nil {
+		now = sources.Now
+	}
+
+	report := Report{
+		ClusterName: clusterName,
+		CheckedAt:   now(),
+		GitOps:      GitOpsStatus{Engine: v1alpha1.GitOpsEngineNone, Resources: []GitOpsResource{}},
+		Components:  []detector.ComponentVersion{},
+	}
+
+	nodes, err := collectNodes(ctx, sources.Clientset)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report.Nodes = nodes
+
+	controlPlane, err := collectControlPlane(ctx, sources.Clientset)
+	if err != nil {
+		report.warn("control plane: %v", err)
+	}
+
+	report.ControlPlane = controlPlane
+
+	if sources.Helm != nil {
+		collectGitOps(ctx, sources, &report)
+		collectComponents(ctx, sources.Helm, &report)
+	}
+
+	report.Health = report.evaluate()
+
+	return report, nil
+}
+
+// warn records a section that could not be collected.
+func (r *Report) warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// evaluate derives the overall health from the collected sections.
+func (r *Report) evaluate() Health {
+	readyNodes := 0
+
+	for _, node := range r.Nodes {
+		if node.Ready {
+			readyNodes++
+		}
+	}
+
+	if readyNodes == 0 {
+		return HealthUnhealthy
+	}
+
+	for _, component := range r.ControlPlane {
+		if !component.Ready() {
+			return HealthUnhealthy
+		}
+	}
+
+	if readyNodes < len(r.Nodes) {
+		return HealthDegraded
+	}
+
+	for _, resource := range r.GitOps.Resources {
+		if !resource.Healthy {
+			return HealthDegraded
+		}
+	}
+
+	for _, component := range r.Components {
+		if !component.Deployed() {
+			return HealthDegraded
+		}
+	}
+
+	return HealthHealthy
+}
+
+// collectNodes lists the nodes and their readiness, sorted by name.
+func collectNodes(ctx context.Context, clientset kubernetes.Interface) ([]NodeStatus, error) {
+	list, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	nodes := make([]NodeStatus, 0, len(list.Items))
+	for i := range list.Items {
+		nodes = append(nodes, nodeStatus(&list.Items[i]))
+	}
+
+	slices.SortFunc(nodes, func(a, b NodeStatus) int { return strings.Compare(a.Name, b.Name) })
+
+	return nodes, nil
+}
+
+// nodeStatus derives a NodeStatus from the node's Ready condition and role labels.
+func nodeStatus(node *corev1.Node) NodeStatus {
+	status := NodeStatus{
+		Name:    node.Name,
+		Roles:   nodeRoles(node),
+		Version: node.Status.NodeInfo.KubeletVersion,
+		Message: "Ready condition missing",
+	}
+
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != corev1.NodeReady {
+			continue
+		}
+
+		status.Ready = cond.Status == corev1.ConditionTrue
+		status.Message = ""
+
+		if !status.Ready {
+			status.Message = cond.Message
+			if status.Message == "" {
+				status.Message = cond.Reason
+			}
+		}
+	}
+
+	return status
+}
+
+// nodeRoles reads the node-role.kubernetes.io/<role> labels, sorted.
+func nodeRoles(node *corev1.Node) []string {
+	const rolePrefix = "node-role.kubernetes.io/"
+
+	roles := []string{}
+
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, rolePrefix); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	slices.Sort(roles)
+
+	return roles
+}
+
+// collectControlPlane reports the control-plane components running as pods in
+// kube-system plus cluster DNS. Distributions without control-plane pods get a
+// single embedded API server entry.
+func collectControlPlane(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+) ([]ComponentHealth, error) {
+	components := []ComponentHealth{}
+
+	for _, selector := range []string{controlPlaneSelector, dnsSelector} {
+		pods, err := clientset.CoreV1().Pods(metav1.NamespaceSystem).List(
+			ctx, metav1.ListOptions{LabelSelector: selector},
+		)
+		if err != nil {
+			return components, fmt.Errorf("list kube-system pods (%s): %w", selector, err)
+		}
+
+		if selector == controlPlaneSelector && len(pods.Items) == 0 {
+			components = append(components, ComponentHealth{Name: embeddedAPIServer, Embedded: true})
+
+			continue
+		}
+
+		components = append(components, groupPods(pods.Items)...)
+	}
+
+	return components, nil
+}
+
+// groupPods aggregates pods into one ComponentHealth per component, sorted by name.
+func groupPods(pods []corev1.Pod) []ComponentHealth {
+	byName := map[string]*ComponentHealth{}
+
+	for i := range pods {
+		name := podComponent(&pods[i])
+
+		component, ok := byName[name]
+		if !ok {
+			component = &ComponentHealth{Name: name}
+			byName[name] = component
+		}
+
+		component.TotalPods++
+
+		if podReady(&pods[i]) {
+			component.ReadyPods++
+		}
+	}
+
+	components := make([]ComponentHealth, 0, len(byName))
+	for _, component := range byName {
+		components = append(components, *component)
+	}
+
+	slices.SortFunc(components, func(a, b ComponentHealth) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return components
+}
+
+// podComponent names the component a pod belongs to from its component or
+// k8s-app label, falling back to the pod name.
+func podComponent(pod *corev1.Pod) string {
+	for _, label := range []string{"component", "k8s-app"} {
+		if value := pod.Labels[label]; value != "" {
+			return value
+		}
+	}
+
+	return pod.Name
+}
+
+// podReady reports whether the pod's Ready condition is True.
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// collectGitOps detects the GitOps engine and reads its resources' state.
+func collectGitOps(ctx context.Context, sources Sources, report *Report) {
+	engine, err := detector.DetectGitOpsEngine(ctx, sources.Helm)
+	if err != nil {
+		report.warn("GitOps engine: %v", err)
+
+		return
+	}
+
+	report.GitOps.Engine = engine
+
+	//nolint:exhaustive // engines other than Flux and ArgoCD have no resources to report
+	switch engine {
+	case v1alpha1.GitOpsEngineFlux:
+		if sources.FluxStatuses == nil {
+			return
+		}
+
+		statuses, err := sources.FluxStatuses(ctx)
+		if err != nil {
+			report.warn("Flux resources: %v", err)
+
+			return
+		}
+
+		for _, status := range statuses {
+			resource := GitOpsResource{
+				Kind:      status.Kind,
+				Name:      status.Name,
+				Namespace: status.Namespace,
+				State:     status.State(),
+				Healthy:   status.Healthy(),
+			}
+
+			// A healthy resource's message is routine ("Applied revision ..."); only
+			// failures are worth showing.
+			if !resource.Healthy {
+				resource.Message = firstLine(status.Message)
+			}
+
+			report.GitOps.Resources = append(report.GitOps.Resources, resource)
+		}
+	case v1alpha1.GitOpsEngineArgoCD:
+		if sources.ArgoCDStatuses == nil {
+			return
+		}
+
+		statuses, err := sources.ArgoCDStatuses(ctx)
+		if err != nil {
+			report.warn("Argo CD applications: %v", err)
+
+			return
+		}
+
+		for _, status := range statuses {
+			report.GitOps.Resources = append(report.GitOps.Resources, GitOpsResource{
+				Kind:      "Application",
+				Name:      status.Name,
+				Namespace: argocd.DefaultNamespace,
+				State:     status.State(),
+				Healthy:   status.Healthy(),
+				Message:   firstLine(status.Message),
+			})
+		}
+	}
+}
+
+// collectComponents reads the installed component versions.
+func collectComponents(ctx context.Context, helmClient helm.Interface, report *Report) {
+	versions, err := detector.DetectComponentVersions(ctx, helmClient)
+	if err != nil {
+		report.warn("component versions: %v", err)
+
+		return
+	}
+
+	report.Components = versions
+}
+
+// firstLine trims a condition message to its first line; Helm errors can span many.
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+
+	return line
+}
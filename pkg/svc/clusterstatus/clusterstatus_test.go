@@ -0,0 +1,320 @@
+package clusterstatus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterstatus"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var errAPI = errors.New("api unavailable")
+
+func node(name string, ready bool, roles ...string) *corev1.Node {
+	status, message := corev1.ConditionFalse, "container runtime is down"
+	if ready {
+		status, message = corev1.ConditionTrue, "kubelet is posting ready status"
+	}
+
+	labels := map[string]string{}
+	for _, role := range roles {
+		labels["node-role.kubernetes.io/"+role] = ""
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.33.1"},
+			Conditions: []corev1.NodeCondition{{
+				Type:    corev1.NodeReady,
+				Status:  status,
+				Reason:  "KubeletNotReady",
+				Message: message,
+			}},
+		},
+	}
+}
+
+func pod(name string, labels map[string]string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem, Labels: labels},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func controlPlanePod(component, nodeName string, ready bool) *corev1.Pod {
+	return pod(component+"-"+nodeName, map[string]string{"tier": "control-plane", "component": component}, ready)
+}
+
+func corednsPod(name string, ready bool) *corev1.Pod {
+	return pod(name, map[string]string{"k8s-app": "kube-dns"}, ready)
+}
+
+func fixedNow() time.Time {
+	return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+}
+
+func TestCollect_HealthyKubeadmCluster(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(
+		node("dev-worker", true),
+		node("dev-control-plane", true, "control-plane"),
+		controlPlanePod("kube-apiserver", "dev-control-plane", true),
+		controlPlanePod("etcd", "dev-control-plane", true),
+		corednsPod("coredns-a", true),
+		corednsPod("coredns-b", true),
+		pod("unrelated", map[string]string{"app": "demo"}, false),
+	)
+
+	report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{
+		Clientset: clientset,
+		Now:       fixedNow,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, clusterstatus.HealthHealthy, report.Health)
+	assert.Equal(t, fixedNow(), report.CheckedAt)
+	assert.Equal(t, []clusterstatus.NodeStatus{
+		{Name: "dev-control-plane", Roles: []string{"control-plane"}, Ready: true, Version: "v1.33.1"},
+		{Name: "dev-worker", Roles: []string{}, Ready: true, Version: "v1.33.1"},
+	}, report.Nodes)
+	assert.Equal(t, []clusterstatus.ComponentHealth{
+		{Name: "etcd", ReadyPods: 1, TotalPods: 1},
+		{Name: "kube-apiserver", ReadyPods: 1, TotalPods: 1},
+		{Name: "kube-dns", ReadyPods: 2, TotalPods: 2},
+	}, report.ControlPlane)
+	assert.Equal(t, v1alpha1.GitOpsEngineNone, report.GitOps.Engine)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestCollect_EmbeddedControlPlane(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(node("k3d-dev-server-0", true), corednsPod("coredns", true))
+
+	report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{Clientset: clientset})
+	require.NoError(t, err)
+
+	assert.Equal(t, []clusterstatus.ComponentHealth{
+		{Name: "kube-apiserver", Embedded: true},
+		{Name: "kube-dns", ReadyPods: 1, TotalPods: 1},
+	}, report.ControlPlane)
+	assert.Equal(t, clusterstatus.HealthHealthy, report.Health)
+}
+
+func TestCollect_Health(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    clusterstatus.Health
+	}{
+		{
+			name:    "not ready worker degrades",
+			objects: []runtime.Object{node("cp", true), node("worker", false), corednsPod("coredns", true)},
+			want:    clusterstatus.HealthDegraded,
+		},
+		{
+			name:    "no ready node is unhealthy",
+			objects: []runtime.Object{node("cp", false)},
+			want:    clusterstatus.HealthUnhealthy,
+		},
+		{
+			name: "control-plane pod down is unhealthy",
+			objects: []runtime.Object{
+				node("cp", true),
+				controlPlanePod("kube-scheduler", "cp", false),
+				corednsPod("coredns", true),
+			},
+			want: clusterstatus.HealthUnhealthy,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{
+				Clientset: fake.NewClientset(testCase.objects...),
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.want, report.Health)
+		})
+	}
+}
+
+func TestCollect_NotReadyNodeMessage(t *testing.T) {
+	t.Parallel()
+
+	report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{
+		Clientset: fake.NewClientset(node("cp", true), node("worker", false)),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "container runtime is down", report.Nodes[1].Message)
+}
+
+func TestCollect_NodeListErrorFails(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errAPI
+	})
+
+	_, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{Clientset: clientset})
+
+	require.ErrorIs(t, err, errAPI)
+}
+
+func TestCollect_ControlPlaneErrorIsWarning(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(node("cp", true))
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errAPI
+	})
+
+	report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{Clientset: clientset})
+	require.NoError(t, err)
+
+	require.Len(t, report.Warnings, 1)
+	assert.Contains(t, report.Warnings[0], "control plane")
+	assert.Equal(t, clusterstatus.HealthHealthy, report.Health)
+}
+
+func helmWith(t *testing.T, releases ...helm.ReleaseInfo) *helm.MockInterface {
+	t.Helper()
+
+	helmClient := helm.NewMockInterface(t)
+	helmClient.On("ReleaseExists", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, name, namespace string) bool {
+			for _, release := range releases {
+				if release.Name == name && release.Namespace == namespace {
+					return true
+				}
+			}
+
+			return false
+		},
+		nil,
+	).Maybe()
+	helmClient.On("ListReleases", mock.Anything).Return(releases, nil).Maybe()
+
+	return helmClient
+}
+
+func TestCollect_FluxResourcesAndVersions(t *testing.T) {
+	t.Parallel()
+
+	helmClient := helmWith(t,
+		helm.ReleaseInfo{
+			Name: detector.ReleaseFluxOperator, Namespace: detector.NamespaceFluxOperator,
+			Status: "deployed", Chart: "flux-operator-0.9.0", AppVersion: "v0.9.0",
+		},
+		helm.ReleaseInfo{
+			Name: detector.ReleaseCertManager, Namespace: detector.NamespaceCertManager,
+			Status: "failed", Chart: "cert-manager-v1.16.0", AppVersion: "v1.16.0",
+		},
+	)
+
+	report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{
+		Clientset: fake.NewClientset(node("cp", true)),
+		Helm:      helmClient,
+		FluxStatuses: func(context.Context) ([]fluxclient.ResourceStatus, error) {
+			return []fluxclient.ResourceStatus{
+				{
+					Kind: fluxclient.KindKustomization, Name: "flux-system", Namespace: "flux-system",
+					Ready: true, Message: "Applied revision: main@sha1:abc",
+				},
+				{
+					Kind: fluxclient.KindHelmRelease, Name: "podinfo", Namespace: "apps",
+					Stalled: true, Message: "install retries exhausted\nmore detail",
+				},
+			}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, clusterstatus.GitOpsStatus{
+		Engine: v1alpha1.GitOpsEngineFlux,
+		Resources: []clusterstatus.GitOpsResource{
+			{Kind: "Kustomization", Name: "flux-system", Namespace: "flux-system", State: "Ready", Healthy: true},
+			{
+				Kind: "HelmRelease", Name: "podinfo", Namespace: "apps", State: "Stalled",
+				Message: "install retries exhausted",
+			},
+		},
+	}, report.GitOps)
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, "cert-manager", report.Components[0].Component)
+	assert.Equal(t, "Flux", report.Components[1].Component)
+	assert.Equal(t, clusterstatus.HealthDegraded, report.Health)
+}
+
+func TestCollect_ArgoCDApplications(t *testing.T) {
+	t.Parallel()
+
+	helmClient := helmWith(t, helm.ReleaseInfo{
+		Name: detector.ReleaseArgoCD, Namespace: detector.NamespaceArgoCD, Status: "deployed",
+	})
+
+	report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{
+		Clientset: fake.NewClientset(node("cp", true)),
+		Helm:      helmClient,
+		ArgoCDStatuses: func(context.Context) ([]argocd.ApplicationStatus, error) {
+			return []argocd.ApplicationStatus{{Name: "ksail", Sync: "Synced", Health: "Healthy"}}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.GitOpsEngineArgoCD, report.GitOps.Engine)
+	assert.Equal(t, []clusterstatus.GitOpsResource{
+		{Kind: "Application", Name: "ksail", Namespace: "argocd", State: "Healthy", Healthy: true},
+	}, report.GitOps.Resources)
+	assert.Equal(t, clusterstatus.HealthHealthy, report.Health)
+}
+
+func TestCollect_GitOpsErrorIsWarning(t *testing.T) {
+	t.Parallel()
+
+	helmClient := helmWith(t, helm.ReleaseInfo{
+		Name: detector.ReleaseFluxOperator, Namespace: detector.NamespaceFluxOperator, Status: "deployed",
+	})
+
+	report, err := clusterstatus.Collect(context.Background(), "dev", clusterstatus.Sources{
+		Clientset: fake.NewClientset(node("cp", true)),
+		Helm:      helmClient,
+		FluxStatuses: func(context.Context) ([]fluxclient.ResourceStatus, error) {
+			return nil, errAPI
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Warnings, 1)
+	assert.Contains(t, report.Warnings[0], "Flux resources")
+}
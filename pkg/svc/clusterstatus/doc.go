@@ -0,0 +1,11 @@
+// Package clusterstatus aggregates the health of a running cluster into a
+// single report for `ksail cluster status`: node readiness, the health of the
+// control-plane components running in kube-system, the reconciliation state of
+// the installed GitOps engine (Flux Kustomizations and HelmReleases, or Argo CD
+// Applications), and the versions of the KSail-managed components read from
+// their Helm releases.
+//
+// Only node listing is essential: a cluster whose nodes cannot be listed is
+// unreachable and Collect fails. Every other section degrades to a warning in
+// the report so a partial answer is still shown.
+package clusterstatus
@@ -0,0 +1,89 @@
+package configbase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+)
+
+const (
+	// cacheDirPerm is the permission for the cache directory.
+	cacheDirPerm = 0o700
+	// cacheFilePerm is the permission for cached base configurations.
+	cacheFilePerm = 0o600
+)
+
+// Cached is a base configuration returned by FetchCached.
+type Cached struct {
+	// Data is the base configuration.
+	Data []byte
+	// FetchErr is the error fetching the reference failed with when Data is the
+	// cached copy, or nil when Data was just fetched.
+	FetchErr error
+}
+
+// CacheDir returns the default base configuration cache directory.
+// Returns an error if the user's home directory cannot be determined.
+func CacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".ksail", "bases"), nil
+}
+
+// CacheKey returns the file name the base configuration ref points at is cached
+// under.
+func CacheKey(ref string) string {
+	sum := sha256.Sum256([]byte(ref))
+
+	return hex.EncodeToString(sum[:]) + ".yaml"
+}
+
+// FetchCached fetches ref like Fetch and keeps the result in cacheDir, keyed by
+// ref. When the fetch fails, the copy cached by an earlier fetch is returned
+// instead, with the fetch error in Cached.FetchErr, so commands keep working
+// offline. An empty cacheDir disables the cache.
+func FetchCached(ctx context.Context, ref string, opts Options, cacheDir string) (Cached, error) {
+	data, fetchErr := Fetch(ctx, ref, opts)
+	if cacheDir == "" {
+		return Cached{Data: data}, fetchErr
+	}
+
+	cachePath := filepath.Join(cacheDir, CacheKey(ref))
+
+	if fetchErr == nil {
+		// A cache that cannot be written only costs the offline fallback.
+		_ = writeCache(cacheDir, cachePath, data)
+
+		return Cached{Data: data}, nil
+	}
+
+	cached, err := os.ReadFile(cachePath) //nolint:gosec // G304: cache dir is under ~/.ksail
+	if err != nil {
+		return Cached{}, fetchErr
+	}
+
+	return Cached{Data: cached, FetchErr: fetchErr}, nil
+}
+
+// writeCache stores data at cachePath, creating cacheDir when needed.
+func writeCache(cacheDir, cachePath string, data []byte) error {
+	err := os.MkdirAll(cacheDir, cacheDirPerm)
+	if err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	err = fsutil.AtomicWriteFile(cachePath, data, cacheFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package configbase_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/configbase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchCached_FallsBackToCachedCopy(t *testing.T) {
+	t.Parallel()
+
+	var offline atomic.Bool
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if offline.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(baseConfig))
+	}))
+	t.Cleanup(server.Close)
+
+	opts := configbase.Options{HTTPClient: server.Client()}
+	ref := server.URL + "/ksail-base.yaml"
+	cacheDir := t.TempDir()
+
+	fresh, err := configbase.FetchCached(t.Context(), ref, opts, cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, baseConfig, string(fresh.Data))
+	require.NoError(t, fresh.FetchErr)
+
+	cached, err := os.ReadFile(filepath.Join(cacheDir, configbase.CacheKey(ref)))
+	require.NoError(t, err)
+	assert.Equal(t, baseConfig, string(cached))
+
+	offline.Store(true)
+
+	stale, err := configbase.FetchCached(t.Context(), ref, opts, cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, baseConfig, string(stale.Data))
+	require.ErrorIs(t, stale.FetchErr, configbase.ErrUnexpectedStatus)
+
+	_, err = configbase.FetchCached(t.Context(), server.URL+"/other.yaml", opts, cacheDir)
+	require.ErrorIs(t, err, configbase.ErrUnexpectedStatus, "a reference never fetched has no fallback")
+}
+
+func TestFetchCached_WithoutCacheDir(t *testing.T) {
+	t.Parallel()
+
+	_, err := configbase.FetchCached(t.Context(), "file:///ksail.yaml", configbase.Options{}, "")
+	require.ErrorIs(t, err, configbase.ErrUnsupportedReference)
+}
+
+func TestCacheKey(t *testing.T) {
+	t.Parallel()
+
+	key := configbase.CacheKey("oci://ghcr.io/acme/ksail-base:v1")
+
+	assert.Equal(t, key, configbase.CacheKey("oci://ghcr.io/acme/ksail-base:v1"))
+	assert.NotEqual(t, key, configbase.CacheKey("oci://ghcr.io/acme/ksail-base:v2"))
+	assert.Equal(t, ".yaml", filepath.Ext(key))
+}
@@ -0,0 +1,223 @@
+package configbase
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	// SchemeOCI prefixes a reference to an OCI artifact holding the base configuration.
+	SchemeOCI = "oci://"
+	// SchemeHTTPS prefixes a URL serving the base configuration.
+	SchemeHTTPS = "https://"
+
+	// MaxSize is the largest base configuration Fetch accepts.
+	MaxSize = 1 << 20
+
+	// fetchTimeout bounds a fetch so an unreachable host cannot hang every command.
+	fetchTimeout = 30 * time.Second
+
+	// titleAnnotation names the file a layer holds (set by oras and similar tools).
+	titleAnnotation = "org.opencontainers.image.title"
+)
+
+// Errors returned by Fetch.
+var (
+	// ErrUnsupportedReference is returned for a reference that is neither oci:// nor https://.
+	ErrUnsupportedReference = errors.New("unsupported reference: must start with oci:// or https://")
+	// ErrUnexpectedStatus is returned when an HTTPS server answers with a non-2xx status.
+	ErrUnexpectedStatus = errors.New("unexpected HTTP status")
+	// ErrConfigNotFound is returned when an OCI artifact holds no ksail.yaml.
+	ErrConfigNotFound = errors.New("artifact contains no ksail.yaml")
+	// ErrTooLarge is returned when the base configuration exceeds MaxSize.
+	ErrTooLarge = errors.New("base configuration exceeds 1 MiB")
+)
+
+// configFileNames are the file names Fetch looks for in an OCI artifact.
+//
+//nolint:gochecknoglobals // immutable lookup table
+var configFileNames = []string{"ksail.yaml", "ksail.yml"}
+
+// Options configures Fetch.
+type Options struct {
+	// HTTPClient fetches https:// references. Nil uses a client with a 30 second timeout.
+	HTTPClient *http.Client
+	// Keychain resolves registry credentials for oci:// references. Nil uses the Docker
+	// config keychain, so `docker login` credentials apply.
+	Keychain authn.Keychain
+}
+
+// Fetch returns the base configuration ref points at. An https:// URL is downloaded as is. An
+// oci:// reference is pulled and searched for ksail.yaml: a layer whose title annotation names
+// it (`oras push`), or a ksail.yaml at the root of a tar layer (`flux push artifact`,
+// `ksail workload push`).
+func Fetch(ctx context.Context, ref string, opts Options) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	switch {
+	case strings.HasPrefix(ref, SchemeHTTPS):
+		return fetchHTTPS(ctx, ref, opts.HTTPClient)
+	case strings.HasPrefix(ref, SchemeOCI):
+		return fetchOCI(ctx, strings.TrimPrefix(ref, SchemeOCI), opts.Keychain)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedReference, ref)
+	}
+}
+
+func fetchHTTPS(ctx context.Context, url string, client *http.Client) ([]byte, error) {
+	if client == nil {
+		client = &http.Client{Timeout: fetchTimeout}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request for %s: %w", url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", url, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%w: get %s: %s", ErrUnexpectedStatus, url, resp.Status)
+	}
+
+	return readLimited(resp.Body)
+}
+
+func fetchOCI(ctx context.Context, ref string, keychain authn.Keychain) ([]byte, error) {
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", ref, err)
+	}
+
+	image, err := remote.Image(
+		parsed, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pull %s: %w", ref, err)
+	}
+
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("read manifest of %s: %w", ref, err)
+	}
+
+	for _, desc := range manifest.Layers {
+		data, found, err := readConfigLayer(image, desc)
+		if err != nil {
+			return nil, fmt.Errorf("read layer %s of %s: %w", desc.Digest, ref, err)
+		}
+
+		if found {
+			return data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrConfigNotFound, ref)
+}
+
+// readConfigLayer returns the ksail.yaml in the layer desc describes, reporting whether the
+// layer holds one.
+func readConfigLayer(image v1.Image, desc v1.Descriptor) ([]byte, bool, error) {
+	isConfigFile := slices.Contains(configFileNames, desc.Annotations[titleAnnotation])
+	if !isConfigFile && !strings.Contains(string(desc.MediaType), "tar") {
+		return nil, false, nil
+	}
+
+	layer, err := image.LayerByDigest(desc.Digest)
+	if err != nil {
+		return nil, false, fmt.Errorf("get layer: %w", err)
+	}
+
+	blob, err := layer.Compressed()
+	if err != nil {
+		return nil, false, fmt.Errorf("open layer: %w", err)
+	}
+
+	defer func() { _ = blob.Close() }()
+
+	if isConfigFile {
+		data, err := readLimited(blob)
+
+		return data, err == nil, err
+	}
+
+	return findInTar(blob)
+}
+
+// findInTar returns the ksail.yaml at the root of a tar stream, gzip-compressed or not.
+func findInTar(blob io.Reader) ([]byte, bool, error) {
+	buffered := bufio.NewReader(blob)
+
+	var stream io.Reader = buffered
+
+	if magic, _ := buffered.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, false, fmt.Errorf("open gzip stream: %w", err)
+		}
+
+		defer func() { _ = gzipReader.Close() }()
+
+		stream = gzipReader
+	}
+
+	tarReader := tar.NewReader(stream)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, false, nil
+		}
+
+		if err != nil {
+			return nil, false, fmt.Errorf("read tar entry: %w", err)
+		}
+
+		if header.Typeflag == tar.TypeReg &&
+			slices.Contains(configFileNames, path.Clean(header.Name)) {
+			data, err := readLimited(tarReader)
+
+			return data, err == nil, err
+		}
+	}
+}
+
+// readLimited reads reader to the end, failing once it passes MaxSize.
+func readLimited(reader io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read base configuration: %w", err)
+	}
+
+	if len(data) > MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	return data, nil
+}
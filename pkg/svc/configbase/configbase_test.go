@@ -0,0 +1,151 @@
+package configbase_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/configbase"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baseConfig = "apiVersion: ksail.io/v1alpha1\nkind: Cluster\nspec:\n  cluster:\n    distribution: K3s\n"
+
+func tarGzip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg,
+		}))
+
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	return buf.Bytes()
+}
+
+// pushArtifact pushes an image with the given layer to an in-memory registry and returns its
+// oci:// reference.
+func pushArtifact(t *testing.T, addendum mutate.Addendum) string {
+	t.Helper()
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	image, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1), addendum)
+	require.NoError(t, err)
+
+	ref := strings.TrimPrefix(server.URL, "http://") + "/platform/ksail-base:v1"
+
+	parsed, err := name.ParseReference(ref)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(parsed, image))
+
+	return configbase.SchemeOCI + ref
+}
+
+func TestFetch_HTTPS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ksail-base.yaml" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(baseConfig))
+	}))
+	t.Cleanup(server.Close)
+
+	opts := configbase.Options{HTTPClient: server.Client()}
+
+	data, err := configbase.Fetch(t.Context(), server.URL+"/ksail-base.yaml", opts)
+	require.NoError(t, err)
+	assert.Equal(t, baseConfig, string(data))
+
+	_, err = configbase.Fetch(t.Context(), server.URL+"/missing.yaml", opts)
+	require.ErrorIs(t, err, configbase.ErrUnexpectedStatus)
+}
+
+func TestFetch_OCI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		addendum mutate.Addendum
+		wantErr  error
+	}{
+		{
+			name: "ksail.yaml in a tar layer",
+			addendum: mutate.Addendum{Layer: static.NewLayer(
+				tarGzip(t, map[string]string{"./ksail.yaml": baseConfig, "README.md": "base"}),
+				types.OCILayer,
+			)},
+		},
+		{
+			name: "single file layer named by its title",
+			addendum: mutate.Addendum{
+				Layer:       static.NewLayer([]byte(baseConfig), "application/vnd.ksail.config.v1+yaml"),
+				Annotations: map[string]string{"org.opencontainers.image.title": "ksail.yaml"},
+			},
+		},
+		{
+			name: "no ksail.yaml",
+			addendum: mutate.Addendum{Layer: static.NewLayer(
+				tarGzip(t, map[string]string{"nested/ksail.yaml": baseConfig}), types.OCILayer,
+			)},
+			wantErr: configbase.ErrConfigNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := configbase.Fetch(
+				t.Context(), pushArtifact(t, test.addendum), configbase.Options{},
+			)
+
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, baseConfig, string(data))
+		})
+	}
+}
+
+func TestFetch_UnsupportedReference(t *testing.T) {
+	t.Parallel()
+
+	for _, ref := range []string{"http://example.com/ksail.yaml", "./base/ksail.yaml"} {
+		_, err := configbase.Fetch(t.Context(), ref, configbase.Options{})
+		require.ErrorIs(t, err, configbase.ErrUnsupportedReference, ref)
+	}
+}
@@ -0,0 +1,8 @@
+// Package configbase fetches the shared base configuration a ksail.yaml names
+// in its top-level extends key.
+//
+// [Fetch] downloads the base from an HTTPS URL or pulls it from an OCI
+// artifact, so platform teams can publish defaults once and every project
+// picks them up on its next command. The config manager merges the result
+// under the local file, so local values win.
+package configbase
@@ -0,0 +1,92 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// HoursPerMonth is the number of hours used to convert between hourly and
+// monthly prices when a catalog only publishes one of them (365 × 24 / 12).
+const HoursPerMonth = 730
+
+// ErrNoPriceCatalog is returned when the cluster's provider has no price
+// catalog KSail can query.
+var ErrNoPriceCatalog = errors.New("no price catalog available for provider")
+
+// ErrPriceNotFound is returned when a resource the cluster would create is
+// missing from the provider's price catalog (e.g. an unknown server type or a
+// type that is not offered in the configured location).
+var ErrPriceNotFound = errors.New("price not found in catalog")
+
+// LineItem is one billable resource in an Estimate.
+type LineItem struct {
+	// Resource describes what is billed (e.g. "control-plane server").
+	Resource string `json:"resource"`
+	// Type is the provider SKU of the resource (e.g. "cx23", "ipv4").
+	Type string `json:"type"`
+	// Quantity is the number of units the cluster creates.
+	Quantity int32 `json:"quantity"`
+	// Hourly is the total hourly price for all units.
+	Hourly float64 `json:"hourly"`
+	// Monthly is the total monthly price for all units.
+	Monthly float64 `json:"monthly"`
+}
+
+// Estimate is the projected cost of a cluster.
+type Estimate struct {
+	// Provider is the infrastructure provider the estimate was priced for.
+	Provider v1alpha1.Provider `json:"provider"`
+	// Location is the provider location the prices apply to, when relevant.
+	Location string `json:"location,omitempty"`
+	// Currency is the ISO 4217 currency of every price in the estimate.
+	Currency string `json:"currency,omitempty"`
+	// Items lists the billable resources the cluster creates.
+	Items []LineItem `json:"items"`
+	// Hourly is the total hourly price of all items.
+	Hourly float64 `json:"hourly"`
+	// Monthly is the total monthly price of all items.
+	Monthly float64 `json:"monthly"`
+	// Notes carries caveats, such as usage-based resources not included in the totals.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// add appends item and folds its prices into the totals.
+func (e *Estimate) add(item LineItem) {
+	e.Items = append(e.Items, item)
+	e.Hourly += item.Hourly
+	e.Monthly += item.Monthly
+}
+
+// Estimator prices clusters against provider price catalogs. Catalog clients
+// are only needed for the providers being estimated; a nil client for the
+// selected provider is reported as ErrNoPriceCatalog.
+type Estimator struct {
+	// HetznerPricing fetches the Hetzner Cloud price catalog.
+	HetznerPricing HetznerPricingClient
+}
+
+// Estimate projects the hourly and monthly cost of cluster.
+func (e Estimator) Estimate(ctx context.Context, cluster *v1alpha1.Cluster) (Estimate, error) {
+	provider := cluster.Spec.Cluster.Provider
+
+	//nolint:exhaustive // providers without a price catalog fall through to the default case
+	switch provider {
+	case v1alpha1.ProviderDocker, v1alpha1.ProviderKubernetes, "":
+		return Estimate{
+			Provider: provider,
+			Items:    []LineItem{},
+			Notes:    []string{"nodes run on local or existing infrastructure; no cloud resources are billed"},
+		}, nil
+	case v1alpha1.ProviderHetzner:
+		if e.HetznerPricing == nil {
+			return Estimate{}, fmt.Errorf("%w: %s", ErrNoPriceCatalog, provider)
+		}
+
+		return estimateHetzner(ctx, e.HetznerPricing, cluster)
+	default:
+		return Estimate{}, fmt.Errorf("%w: %s", ErrNoPriceCatalog, provider)
+	}
+}
@@ -0,0 +1,212 @@
+package cost_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/cost"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errPricingUnavailable = errors.New("pricing unavailable")
+
+// fakePricing returns a fixed Hetzner price catalog.
+type fakePricing struct {
+	pricing hcloud.Pricing
+	err     error
+}
+
+func (f fakePricing) Get(context.Context) (hcloud.Pricing, *hcloud.Response, error) {
+	return f.pricing, nil, f.err
+}
+
+func hetznerCatalog() hcloud.Pricing {
+	fsn1 := &hcloud.Location{Name: "fsn1"}
+	price := func(value string) hcloud.Price { return hcloud.Price{Net: value} }
+
+	return hcloud.Pricing{
+		Currency: "EUR",
+		VATRate:  "19.00",
+		ServerTypes: []hcloud.ServerTypePricing{
+			{
+				ServerType: &hcloud.ServerType{Name: "cx23"},
+				Pricings: []hcloud.ServerTypeLocationPricing{
+					{Location: fsn1, Hourly: price("0.0060"), Monthly: price("3.4900")},
+				},
+			},
+			{
+				ServerType: &hcloud.ServerType{Name: "cx43"},
+				Pricings: []hcloud.ServerTypeLocationPricing{
+					{Location: fsn1, Hourly: price("0.0150"), Monthly: price("9.4900")},
+				},
+			},
+		},
+		PrimaryIPs: []hcloud.PrimaryIPPricing{
+			{
+				Type: "ipv4",
+				Pricings: []hcloud.PrimaryIPTypePricing{
+					{
+						Location: "fsn1",
+						Hourly:   hcloud.PrimaryIPPrice{Net: "0.0008"},
+						Monthly:  hcloud.PrimaryIPPrice{Net: "0.5000"},
+					},
+				},
+			},
+		},
+		FloatingIPs: []hcloud.FloatingIPTypePricing{
+			{
+				Type: hcloud.FloatingIPTypeIPv4,
+				Pricings: []hcloud.FloatingIPTypeLocationPricing{
+					{Location: fsn1, Monthly: price("3.0000")},
+				},
+			},
+		},
+		LoadBalancerTypes: []hcloud.LoadBalancerTypePricing{
+			{
+				LoadBalancerType: &hcloud.LoadBalancerType{Name: "lb11"},
+				Pricings: []hcloud.LoadBalancerTypeLocationPricing{
+					{Location: fsn1, Hourly: price("0.0080"), Monthly: price("5.3900")},
+				},
+			},
+		},
+		Volume: hcloud.VolumePricing{PerGBMonthly: price("0.0440")},
+	}
+}
+
+func hetznerCluster(controlPlanes, workers int32) *v1alpha1.Cluster {
+	cluster := v1alpha1.NewCluster()
+	cluster.Spec.Cluster.Provider = v1alpha1.ProviderHetzner
+	cluster.Spec.Cluster.Distribution = v1alpha1.DistributionTalos
+	cluster.Spec.Cluster.ControlPlanes = controlPlanes
+	cluster.Spec.Cluster.Workers = workers
+	cluster.Spec.Provider.Hetzner.Location = "fsn1"
+	cluster.Spec.Provider.Hetzner.ControlPlaneServerType = "cx23"
+	cluster.Spec.Provider.Hetzner.WorkerServerType = "cx43"
+
+	return cluster
+}
+
+func TestEstimate_Hetzner(t *testing.T) {
+	t.Parallel()
+
+	estimator := cost.Estimator{HetznerPricing: fakePricing{pricing: hetznerCatalog()}}
+
+	estimate, err := estimator.Estimate(context.Background(), hetznerCluster(3, 2))
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.ProviderHetzner, estimate.Provider)
+	assert.Equal(t, "fsn1", estimate.Location)
+	assert.Equal(t, "EUR", estimate.Currency)
+	require.Len(t, estimate.Items, 3)
+
+	assert.Equal(t, "control-plane server", estimate.Items[0].Resource)
+	assert.Equal(t, int32(3), estimate.Items[0].Quantity)
+	assert.InDelta(t, 10.47, estimate.Items[0].Monthly, 1e-9)
+
+	assert.Equal(t, "cx43", estimate.Items[1].Type)
+	assert.InDelta(t, 0.03, estimate.Items[1].Hourly, 1e-9)
+
+	assert.Equal(t, "primary IPv4", estimate.Items[2].Resource)
+	assert.Equal(t, int32(5), estimate.Items[2].Quantity)
+
+	assert.InDelta(t, 10.47+18.98+2.5, estimate.Monthly, 1e-9)
+	assert.InDelta(t, 0.018+0.03+0.004, estimate.Hourly, 1e-9)
+	assert.Len(t, estimate.Notes, 3)
+}
+
+func TestEstimate_HetznerSkipsDisabledIPv4AndAddsFloatingIP(t *testing.T) {
+	t.Parallel()
+
+	cluster := hetznerCluster(1, 2)
+	cluster.Spec.Provider.Hetzner.WorkerPublicIPv4 = new(false)
+	cluster.Spec.Provider.Hetzner.FloatingIPEnabled = true
+
+	estimator := cost.Estimator{HetznerPricing: fakePricing{pricing: hetznerCatalog()}}
+
+	estimate, err := estimator.Estimate(context.Background(), cluster)
+	require.NoError(t, err)
+	require.Len(t, estimate.Items, 4)
+
+	assert.Equal(t, int32(1), estimate.Items[2].Quantity)
+	assert.Equal(t, "floating IPv4", estimate.Items[3].Resource)
+	assert.InDelta(t, 3.0/cost.HoursPerMonth, estimate.Items[3].Hourly, 1e-9)
+}
+
+func TestEstimate_HetznerUnknownServerType(t *testing.T) {
+	t.Parallel()
+
+	cluster := hetznerCluster(1, 0)
+	cluster.Spec.Provider.Hetzner.ControlPlaneServerType = "cx999"
+
+	estimator := cost.Estimator{HetznerPricing: fakePricing{pricing: hetznerCatalog()}}
+
+	_, err := estimator.Estimate(context.Background(), cluster)
+	require.ErrorIs(t, err, cost.ErrPriceNotFound)
+}
+
+func TestEstimate_HetznerUnofferedLocation(t *testing.T) {
+	t.Parallel()
+
+	cluster := hetznerCluster(1, 0)
+	cluster.Spec.Provider.Hetzner.Location = "ash"
+
+	estimator := cost.Estimator{HetznerPricing: fakePricing{pricing: hetznerCatalog()}}
+
+	_, err := estimator.Estimate(context.Background(), cluster)
+	require.ErrorIs(t, err, cost.ErrPriceNotFound)
+	assert.Contains(t, err.Error(), "not offered in location ash")
+}
+
+func TestEstimate_HetznerPricingError(t *testing.T) {
+	t.Parallel()
+
+	estimator := cost.Estimator{HetznerPricing: fakePricing{err: errPricingUnavailable}}
+
+	_, err := estimator.Estimate(context.Background(), hetznerCluster(1, 0))
+	require.ErrorIs(t, err, errPricingUnavailable)
+}
+
+func TestEstimate_LocalProvidersAreFree(t *testing.T) {
+	t.Parallel()
+
+	for _, provider := range []v1alpha1.Provider{v1alpha1.ProviderDocker, v1alpha1.ProviderKubernetes} {
+		t.Run(string(provider), func(t *testing.T) {
+			t.Parallel()
+
+			cluster := v1alpha1.NewCluster()
+			cluster.Spec.Cluster.Provider = provider
+
+			estimate, err := cost.Estimator{}.Estimate(context.Background(), cluster)
+			require.NoError(t, err)
+			assert.Empty(t, estimate.Items)
+			assert.Zero(t, estimate.Monthly)
+			assert.NotEmpty(t, estimate.Notes)
+		})
+	}
+}
+
+func TestEstimate_NoPriceCatalog(t *testing.T) {
+	t.Parallel()
+
+	for _, provider := range []v1alpha1.Provider{
+		v1alpha1.ProviderHetzner,
+		v1alpha1.ProviderAWS,
+		v1alpha1.ProviderGCP,
+		v1alpha1.ProviderAzure,
+		v1alpha1.ProviderOmni,
+	} {
+		t.Run(string(provider), func(t *testing.T) {
+			t.Parallel()
+
+			cluster := v1alpha1.NewCluster()
+			cluster.Spec.Cluster.Provider = provider
+
+			_, err := cost.Estimator{}.Estimate(context.Background(), cluster)
+			require.ErrorIs(t, err, cost.ErrNoPriceCatalog)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Package cost estimates the hourly and monthly cloud cost of a KSail cluster
+// before it is provisioned, so `ksail cluster cost` and `ksail cluster create
+// --dry-run` can surface an expensive fleet before the first server is billed.
+//
+// Estimates are priced from the provider's own price catalog: Hetzner via the
+// hcloud pricing API (servers, primary IPv4 addresses, and the optional
+// floating IP), with per-unit prices for load balancers and volumes that
+// workloads create later reported as notes. Local providers (Docker and
+// Kubernetes) carry no cloud cost. Providers without a price catalog reachable
+// from KSail return ErrNoPriceCatalog.
+package cost
@@ -0,0 +1,300 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// hetznerIPv4 is the primary and floating IP pricing type for IPv4 addresses.
+	// IPv6 addresses are free and therefore never priced.
+	hetznerIPv4 = "ipv4"
+	// hetznerServiceLoadBalancerType is the load balancer type the hcloud cloud
+	// controller manager provisions for a Service of type LoadBalancer by default.
+	hetznerServiceLoadBalancerType = "lb11"
+)
+
+// HetznerPricingClient fetches the Hetzner Cloud price catalog. It is satisfied
+// by hcloud.PricingClient (client.Pricing).
+type HetznerPricingClient interface {
+	Get(ctx context.Context) (hcloud.Pricing, *hcloud.Response, error)
+}
+
+// estimateHetzner prices the servers and IP addresses KSail creates for a
+// Hetzner cluster. Prices are the catalog's net prices (excluding VAT); the
+// monthly figures are Hetzner's monthly caps, which are slightly below hourly
+// price × hours in a month.
+func estimateHetzner(
+	ctx context.Context,
+	client HetznerPricingClient,
+	cluster *v1alpha1.Cluster,
+) (Estimate, error) {
+	pricing, _, err := client.Get(ctx)
+	if err != nil {
+		return Estimate{}, fmt.Errorf("fetch hetzner pricing: %w", err)
+	}
+
+	opts := cluster.Spec.Provider.Hetzner
+	spec := cluster.Spec.Cluster
+	location := valueOrDefault(opts.Location, v1alpha1.DefaultHetznerLocation)
+
+	estimate := Estimate{
+		Provider: v1alpha1.ProviderHetzner,
+		Location: location,
+		Currency: pricing.Currency,
+		Items:    []LineItem{},
+	}
+
+	pools := []struct {
+		role       string
+		serverType string
+		count      int32
+		publicIPv4 *bool
+	}{
+		{
+			role:       "control-plane server",
+			serverType: opts.ControlPlaneServerType,
+			count:      spec.ControlPlanes,
+			publicIPv4: opts.ControlPlanePublicIPv4,
+		},
+		{
+			role:       "worker server",
+			serverType: opts.WorkerServerType,
+			count:      spec.Workers,
+			publicIPv4: opts.WorkerPublicIPv4,
+		},
+	}
+
+	var ipv4Count int32
+
+	for _, pool := range pools {
+		if pool.count <= 0 {
+			continue
+		}
+
+		serverType := valueOrDefault(pool.serverType, v1alpha1.DefaultHetznerServerType)
+
+		item, err := hetznerServerItem(pricing, pool.role, serverType, location, pool.count)
+		if err != nil {
+			return Estimate{}, err
+		}
+
+		estimate.add(item)
+
+		if pool.publicIPv4 == nil || *pool.publicIPv4 {
+			ipv4Count += pool.count
+		}
+	}
+
+	if ipv4Count > 0 {
+		item, err := hetznerPrimaryIPv4Item(pricing, location, ipv4Count)
+		if err != nil {
+			return Estimate{}, err
+		}
+
+		estimate.add(item)
+	}
+
+	if opts.FloatingIPEnabled {
+		item, err := hetznerFloatingIPv4Item(
+			pricing,
+			valueOrDefault(opts.FloatingIPLocation, location),
+		)
+		if err != nil {
+			return Estimate{}, err
+		}
+
+		estimate.add(item)
+	}
+
+	estimate.Notes = hetznerNotes(pricing, location)
+
+	return estimate, nil
+}
+
+// hetznerServerItem prices count servers of serverType in location.
+func hetznerServerItem(
+	pricing hcloud.Pricing,
+	role, serverType, location string,
+	count int32,
+) (LineItem, error) {
+	for _, typePricing := range pricing.ServerTypes {
+		if typePricing.ServerType == nil || typePricing.ServerType.Name != serverType {
+			continue
+		}
+
+		for _, locationPricing := range typePricing.Pricings {
+			if locationPricing.Location == nil || locationPricing.Location.Name != location {
+				continue
+			}
+
+			return lineItem(
+				role,
+				serverType,
+				count,
+				locationPricing.Hourly.Net,
+				locationPricing.Monthly.Net,
+			)
+		}
+
+		return LineItem{}, fmt.Errorf(
+			"%w: server type %s is not offered in location %s",
+			ErrPriceNotFound, serverType, location,
+		)
+	}
+
+	return LineItem{}, fmt.Errorf("%w: unknown server type %s", ErrPriceNotFound, serverType)
+}
+
+// hetznerPrimaryIPv4Item prices count public IPv4 primary IPs in location.
+func hetznerPrimaryIPv4Item(pricing hcloud.Pricing, location string, count int32) (LineItem, error) {
+	for _, ipPricing := range pricing.PrimaryIPs {
+		if ipPricing.Type != hetznerIPv4 {
+			continue
+		}
+
+		for _, locationPricing := range ipPricing.Pricings {
+			if locationPricing.Location != location {
+				continue
+			}
+
+			return lineItem(
+				"primary IPv4",
+				hetznerIPv4,
+				count,
+				locationPricing.Hourly.Net,
+				locationPricing.Monthly.Net,
+			)
+		}
+	}
+
+	return LineItem{}, fmt.Errorf(
+		"%w: primary IPv4 pricing for location %s", ErrPriceNotFound, location,
+	)
+}
+
+// hetznerFloatingIPv4Item prices the floating IPv4 fronting the control plane.
+// Floating IPs are billed monthly only, so the hourly price is derived.
+func hetznerFloatingIPv4Item(pricing hcloud.Pricing, location string) (LineItem, error) {
+	for _, ipPricing := range pricing.FloatingIPs {
+		if ipPricing.Type != hcloud.FloatingIPTypeIPv4 {
+			continue
+		}
+
+		for _, locationPricing := range ipPricing.Pricings {
+			if locationPricing.Location == nil || locationPricing.Location.Name != location {
+				continue
+			}
+
+			monthly, err := parsePrice(locationPricing.Monthly.Net)
+			if err != nil {
+				return LineItem{}, err
+			}
+
+			return LineItem{
+				Resource: "floating IPv4",
+				Type:     hetznerIPv4,
+				Quantity: 1,
+				Hourly:   monthly / HoursPerMonth,
+				Monthly:  monthly,
+			}, nil
+		}
+	}
+
+	return LineItem{}, fmt.Errorf(
+		"%w: floating IPv4 pricing for location %s", ErrPriceNotFound, location,
+	)
+}
+
+// hetznerNotes lists the usage-based resources the totals leave out, with
+// their unit prices where the catalog has them.
+func hetznerNotes(pricing hcloud.Pricing, location string) []string {
+	notes := []string{
+		fmt.Sprintf("prices exclude VAT (%s%%) and traffic beyond the included volume", pricing.VATRate),
+	}
+
+	for _, lbPricing := range pricing.LoadBalancerTypes {
+		if lbPricing.LoadBalancerType == nil ||
+			lbPricing.LoadBalancerType.Name != hetznerServiceLoadBalancerType {
+			continue
+		}
+
+		for _, locationPricing := range lbPricing.Pricings {
+			if locationPricing.Location == nil || locationPricing.Location.Name != location {
+				continue
+			}
+
+			notes = append(notes, fmt.Sprintf(
+				"each Service of type LoadBalancer adds an %s load balancer at %s %s/month",
+				hetznerServiceLoadBalancerType,
+				formatPrice(locationPricing.Monthly.Net),
+				pricing.Currency,
+			))
+		}
+	}
+
+	if pricing.Volume.PerGBMonthly.Net != "" {
+		notes = append(notes, fmt.Sprintf(
+			"each PersistentVolume (Hetzner volume) adds %s %s per GB/month",
+			formatPrice(pricing.Volume.PerGBMonthly.Net),
+			pricing.Currency,
+		))
+	}
+
+	return notes
+}
+
+// lineItem builds a LineItem from per-unit catalog price strings.
+func lineItem(resource, itemType string, count int32, hourly, monthly string) (LineItem, error) {
+	unitHourly, err := parsePrice(hourly)
+	if err != nil {
+		return LineItem{}, err
+	}
+
+	unitMonthly, err := parsePrice(monthly)
+	if err != nil {
+		return LineItem{}, err
+	}
+
+	return LineItem{
+		Resource: resource,
+		Type:     itemType,
+		Quantity: count,
+		Hourly:   unitHourly * float64(count),
+		Monthly:  unitMonthly * float64(count),
+	}, nil
+}
+
+// parsePrice parses a decimal catalog price string.
+func parsePrice(value string) (float64, error) {
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse catalog price %q: %w", value, err)
+	}
+
+	return price, nil
+}
+
+// formatPrice renders a catalog price string with two decimals, falling back
+// to the raw value when it does not parse.
+func formatPrice(value string) string {
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
+
+// valueOrDefault returns value, or fallback when value is empty.
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
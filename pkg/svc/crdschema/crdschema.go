@@ -156,6 +156,39 @@ func MaterializeBytes(data []byte, source, destDir string) (Result, error) {
 	return result, nil
 }
 
+// MaterializeCRDs converts already-decoded CustomResourceDefinitions, such as
+// those listed from a live cluster, into kubeconform schemas under destDir,
+// using the same {group}/{kind}_{version}.json layout as Materialize. source is
+// used only to attribute warnings. Like Materialize, a CRD (or a single version)
+// that cannot be converted is reported as a Warning and skipped; an error is
+// returned only when a schema cannot be written to destDir.
+func MaterializeCRDs(
+	crds []apiextensionsv1.CustomResourceDefinition,
+	source, destDir string,
+) (Result, error) {
+	var result Result
+
+	for i := range crds {
+		schemas, warnings := schemasFromCRDObject(&crds[i])
+		for _, warning := range warnings {
+			warning.Source = source
+			warning.CRD = crds[i].Name
+			result.Warnings = append(result.Warnings, warning)
+		}
+
+		for _, schema := range schemas {
+			err := writeSchema(destDir, schema)
+			if err != nil {
+				return result, err
+			}
+
+			result.Written++
+		}
+	}
+
+	return result, nil
+}
+
 // materializeFile extracts and writes every CRD schema found in a single file's
 // documents, appending warnings to result. It returns an error only when a schema
 // cannot be written to destDir.
@@ -208,15 +241,24 @@ func schemasFromCRD(doc []byte) (string, []versionSchema, []Warning) {
 		return "", nil, []Warning{{Reason: "parse CRD: " + err.Error()}}
 	}
 
+	schemas, warnings := schemasFromCRDObject(&crd)
+
+	return crd.Name, schemas, warnings
+}
+
+// schemasFromCRDObject returns one JSON schema per version of crd that carries
+// an openAPIV3Schema, and warnings for versions (or the whole CRD) that could
+// not be converted.
+func schemasFromCRDObject(crd *apiextensionsv1.CustomResourceDefinition) ([]versionSchema, []Warning) {
 	group := crd.Spec.Group
 	kind := crd.Spec.Names.Kind
 
 	if group == "" || kind == "" {
-		return crd.Name, nil, []Warning{{Reason: "CRD is missing spec.group or spec.names.kind"}}
+		return nil, []Warning{{Reason: "CRD is missing spec.group or spec.names.kind"}}
 	}
 
 	if unsafePathComponent(group) || unsafePathComponent(kind) {
-		return crd.Name, nil, []Warning{{
+		return nil, []Warning{{
 			Reason: fmt.Sprintf(
 				"spec.group %q or spec.names.kind %q is not a safe path component", group, kind),
 		}}
@@ -238,7 +280,7 @@ func schemasFromCRD(doc []byte) (string, []versionSchema, []Warning) {
 		schemas = append(schemas, *schema)
 	}
 
-	return crd.Name, schemas, warnings
+	return schemas, warnings
 }
 
 // schemaForVersion converts a single CRD version into a kubeconform schema. It
@@ -10,6 +10,8 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/client/kubeconform"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/crdschema"
 	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
 )
 
 // widgetCRD is a CRD manifest for a fictional kind absent from both the built-in
@@ -480,3 +482,32 @@ func TestMaterializeBytes_DerivedSchemaValidatesViaKubeconform(t *testing.T) {
 	)
 	require.Error(t, err, "invalid CR should fail against the rendered-source derived schema")
 }
+
+// TestMaterializeCRDs_WritesSchemasAndWarns proves decoded CRDs (as listed from
+// a live cluster, where no manifest exists on disk) yield the same per-version
+// schemas as Materialize, and that an unconvertible CRD is warned with the
+// caller-supplied source and its own name rather than failing.
+func TestMaterializeCRDs_WritesSchemasAndWarns(t *testing.T) {
+	t.Parallel()
+
+	var widget apiextensionsv1.CustomResourceDefinition
+
+	require.NoError(t, yaml.Unmarshal([]byte(widgetCRD), &widget))
+
+	broken := apiextensionsv1.CustomResourceDefinition{}
+	broken.Name = "broken.example.com"
+
+	dest := t.TempDir()
+
+	result, err := crdschema.MaterializeCRDs(
+		[]apiextensionsv1.CustomResourceDefinition{widget, broken},
+		"cluster",
+		dest,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Written)
+	require.FileExists(t, filepath.Join(dest, "example.com", "widget_v1.json"))
+	require.Len(t, result.Warnings, 1)
+	require.Equal(t, "cluster", result.Warnings[0].Source)
+	require.Equal(t, "broken.example.com", result.Warnings[0].CRD)
+}
@@ -0,0 +1,182 @@
+package credentials_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testRoleARN = "arn:aws:iam::123456789012:role/ksail"
+
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>session-access</AccessKeyId>
+      <SecretAccessKey>session-secret</SecretAccessKey>
+      <SessionToken>session-token</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/ksail/ksail</Arn>
+      <AssumedRoleId>AROAEXAMPLE:ksail</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`
+
+// newAssumeRoleSelection resolves static base credentials from custom variables with an
+// assumed role, as a cluster spec with assumeRoleArn would.
+func newAssumeRoleSelection(t *testing.T, duration time.Duration) credentials.AWSResolution {
+	t.Helper()
+
+	t.Setenv("KSAIL_TEST_BASE_ACCESS", "base-access")
+	t.Setenv("KSAIL_TEST_BASE_SECRET", "base-secret")
+
+	return credentials.ResolveAWS(credentials.NewAWSOptionsResolver(v1alpha1.OptionsAWS{
+		AccessKeyIDEnvVar:     "KSAIL_TEST_BASE_ACCESS",
+		SecretAccessKeyEnvVar: "KSAIL_TEST_BASE_SECRET",
+		AssumeRoleARN:         testRoleARN,
+		AssumeRoleDuration:    metav1.Duration{Duration: duration},
+	}))
+}
+
+func stsLoader(endpoint string) func(context.Context, ...func(*config.LoadOptions) error) (aws.Config, error) {
+	return func(context.Context, ...func(*config.LoadOptions) error) (aws.Config, error) {
+		return aws.Config{Region: "eu-west-1", BaseEndpoint: aws.String(endpoint)}, nil
+	}
+}
+
+func TestFreezeAWSAssumesConfiguredRole(t *testing.T) {
+	// Not parallel: t.Setenv changes the process environment.
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls++
+
+		assert.NoError(t, request.ParseForm())
+		assert.Equal(t, "AssumeRole", request.PostForm.Get("Action"))
+		assert.Equal(t, testRoleARN, request.PostForm.Get("RoleArn"))
+		assert.Equal(t, "ksail", request.PostForm.Get("RoleSessionName"))
+		assert.Equal(t, "7200", request.PostForm.Get("DurationSeconds"))
+		assert.Contains(t, request.Header.Get("Authorization"), "Credential=base-access/")
+
+		writer.Header().Set("Content-Type", "text/xml")
+		_, _ = fmt.Fprintf(writer, assumeRoleResponse, time.Now().Add(2*time.Hour).UTC().Format(time.RFC3339))
+	}))
+	t.Cleanup(server.Close)
+
+	selection := newAssumeRoleSelection(t, 2*time.Hour)
+	assert.Equal(t, testRoleARN, selection.AssumeRoleARN())
+
+	frozen, err := credentials.FreezeAWSResolutionForTest(t.Context(), "", selection, stsLoader(server.URL))
+	require.NoError(t, err)
+	assert.True(t, frozen.IsFrozen())
+	assert.Equal(t, "session-access", frozen.AccessKeyID)
+	assert.Equal(t, "session-secret", frozen.SecretAccessKey)
+	assert.Equal(t, "session-token", frozen.SessionToken)
+
+	configs := credentials.OptionsForFrozenAWSConfig(
+		frozen,
+		func(cfg aws.Config) aws.Config { return cfg },
+		func(string, string, string, string) aws.Config { return aws.Config{} },
+		func() aws.Config { return aws.Config{} },
+	)
+	require.NotEmpty(t, configs)
+
+	session, err := configs[0].Credentials.Retrieve(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "session-access", session.AccessKeyID)
+	assert.True(t, session.CanExpire)
+	assert.Equal(t, 1, calls, "the SDK reuses the cached session until it nears expiry")
+}
+
+func TestFreezeAWSReportsRejectedRole(t *testing.T) {
+	// Not parallel: t.Setenv changes the process environment.
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Content-Type", "text/xml")
+		writer.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(writer, `<ErrorResponse><Error><Type>Sender</Type>`+
+			`<Code>AccessDenied</Code><Message>not authorized</Message></Error></ErrorResponse>`)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := credentials.FreezeAWSResolutionForTest(
+		t.Context(), "", newAssumeRoleSelection(t, 0), stsLoader(server.URL),
+	)
+	require.ErrorIs(t, err, credentials.ErrAWSAssumeRoleFailed)
+	assert.Contains(t, err.Error(), testRoleARN)
+}
+
+func TestAWSOptionsWithDefaultsKeepsAssumedRole(t *testing.T) {
+	t.Parallel()
+
+	options := credentials.AWSOptionsWithDefaults(v1alpha1.OptionsAWS{
+		AssumeRoleARN:      testRoleARN,
+		AssumeRoleDuration: metav1.Duration{Duration: time.Hour},
+	})
+	assert.Equal(t, testRoleARN, options.AssumeRoleARN)
+	assert.Equal(t, time.Hour, options.AssumeRoleDuration.Duration)
+	assert.Equal(t, "AWS_ACCESS_KEY_ID", options.AccessKeyIDEnvVar)
+}
+
+func TestCurrentChildEnvironmentRenewsAgingSession(t *testing.T) {
+	// Not parallel: t.Setenv changes the process environment.
+	var calls int
+
+	// The first session is already past half its lifetime; the second is fresh.
+	lifetimes := []time.Duration{20 * time.Minute, time.Hour}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		expires := time.Now().Add(lifetimes[min(calls, len(lifetimes)-1)]).UTC().Format(time.RFC3339)
+		calls++
+
+		response := strings.ReplaceAll(assumeRoleResponse, "session-access", fmt.Sprintf("session-access-%d", calls))
+
+		writer.Header().Set("Content-Type", "text/xml")
+		_, _ = fmt.Fprintf(writer, response, expires)
+	}))
+	t.Cleanup(server.Close)
+
+	frozen, err := credentials.FreezeAWSResolutionForTest(
+		t.Context(), "", newAssumeRoleSelection(t, time.Hour), stsLoader(server.URL),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, frozen.AssumeRoleDuration())
+	assert.Contains(t, frozen.ChildEnvironment(nil), "AWS_ACCESS_KEY_ID=session-access-1")
+
+	environment, err := frozen.CurrentChildEnvironment(t.Context(), []string{"PATH=/usr/bin"})
+	require.NoError(t, err)
+	assert.Contains(t, environment, "PATH=/usr/bin")
+	assert.Contains(t, environment, "AWS_ACCESS_KEY_ID=session-access-2")
+	assert.Contains(t, environment, "AWS_SESSION_TOKEN=session-token")
+
+	environment, err = frozen.CurrentChildEnvironment(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Contains(t, environment, "AWS_ACCESS_KEY_ID=session-access-2")
+	assert.Equal(t, 2, calls, "a fresh session is handed to every child process until it ages")
+
+	options := credentials.OptionsForRefreshingAWSChildEnvironment(
+		frozen,
+		nil,
+		func(source func(context.Context) ([]string, error)) func(context.Context) ([]string, error) {
+			return source
+		},
+		func() func(context.Context) ([]string, error) { return nil },
+	)
+	require.Len(t, options, 2, "a frozen resolution requires credential values")
+
+	environment, err = options[0](t.Context())
+	require.NoError(t, err)
+	assert.Contains(t, environment, "AWS_ACCESS_KEY_ID=session-access-2")
+}
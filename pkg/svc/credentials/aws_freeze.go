@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	awsconfigutil "github.com/devantler-tech/ksail/v7/pkg/awsconfig"
 )
 
@@ -22,8 +25,13 @@ var (
 	// ErrAWSCredentialsUnavailable reports an AWS configuration whose provider returned no usable
 	// concrete access and secret key pair.
 	ErrAWSCredentialsUnavailable = errors.New("AWS credentials are unavailable")
+	// ErrAWSAssumeRoleFailed reports an STS AssumeRole call that did not return a session.
+	ErrAWSAssumeRoleFailed = errors.New("assume AWS role")
 )
 
+// awsRoleSessionName identifies KSail sessions in CloudTrail for an assumed role.
+const awsRoleSessionName = "ksail"
+
 type awsConfigLoader func(
 	context.Context,
 	...func(*config.LoadOptions) error,
@@ -40,7 +48,9 @@ func ResolveFrozenAWS(
 	return FreezeAWS(ctx, ResolveAWS(resolver), region)
 }
 
-// FreezeAWS resolves an existing immutable selection to one concrete credential tuple.
+// FreezeAWS resolves an existing immutable selection to one concrete credential tuple. When the
+// selection carries an assumed role, the resolved credentials are exchanged for a role session:
+// the tuple is that session's, and the SDK configuration refreshes the session before it expires.
 func FreezeAWS(
 	ctx context.Context,
 	selection AWSResolution,
@@ -81,25 +91,95 @@ func freezeAWSResolution(
 		selection.Region = frozenAWSRegion(selection, cfg)
 	}
 
-	selection.Profile = ""
-	selection.AccessKeyID = credentialValues.AccessKeyID
-	selection.SecretAccessKey = credentialValues.SecretAccessKey
-	selection.SessionToken = credentialValues.SessionToken
-	selection.frozen = true
-
-	frozenProvider := awscredentials.NewStaticCredentialsProvider(
+	frozenProvider := aws.NewCredentialsCache(awscredentials.NewStaticCredentialsProvider(
 		credentialValues.AccessKeyID,
 		credentialValues.SecretAccessKey,
 		credentialValues.SessionToken,
-	)
+	))
 	cfg = sanitizeAWSConfigIdentity(cfg, selection.Region)
 	cfg.Region = selection.Region
-	cfg.Credentials = aws.NewCredentialsCache(frozenProvider)
+	cfg.Credentials = frozenProvider
+
+	if selection.roleARN != "" {
+		frozenProvider, credentialValues, err = assumeAWSRole(ctx, cfg, selection)
+		if err != nil {
+			return AWSResolution{}, err
+		}
+
+		cfg.Credentials = frozenProvider
+		selection.roleSession = frozenProvider
+	}
+
+	selection.Profile = ""
+	selection.AccessKeyID = credentialValues.AccessKeyID
+	selection.SecretAccessKey = credentialValues.SecretAccessKey
+	selection.SessionToken = credentialValues.SessionToken
+	selection.frozen = true
 	selection.sdkConfig = &cfg
 
 	return selection, nil
 }
 
+// assumeAWSRole exchanges the frozen base credentials in cfg for a session of the selection's role.
+// The returned provider re-assumes the role once the session expires, so SDK calls in long
+// operations keep working; the returned values are the first session's. Child processes get a
+// current session per process through CurrentChildEnvironment.
+func assumeAWSRole(
+	ctx context.Context,
+	cfg aws.Config,
+	selection AWSResolution,
+) (*aws.CredentialsCache, aws.Credentials, error) {
+	provider := aws.NewCredentialsCache(
+		stscreds.NewAssumeRoleProvider(
+			sts.NewFromConfig(cfg),
+			selection.roleARN,
+			func(options *stscreds.AssumeRoleOptions) {
+				options.RoleSessionName = awsRoleSessionName
+				options.Duration = selection.roleDuration
+			},
+		),
+	)
+
+	session, err := provider.Retrieve(ctx)
+	if err != nil {
+		return nil, aws.Credentials{}, fmt.Errorf(
+			"%w %s: %w",
+			ErrAWSAssumeRoleFailed,
+			selection.roleARN,
+			err,
+		)
+	}
+
+	return provider, session, nil
+}
+
+// CurrentChildEnvironment is ChildEnvironment with the assumed role's current session in place of
+// the first one. A session past half its lifetime is renewed first, so each child process starts
+// with at least half a session ahead of it: a child outliving that still fails once its session
+// expires, which is why AssumeRoleDuration must exceed the slowest eksctl run. Without an assumed
+// role it equals ChildEnvironment.
+func (r AWSResolution) CurrentChildEnvironment(ctx context.Context, parent []string) ([]string, error) {
+	if r.roleSession == nil {
+		return r.ChildEnvironment(parent), nil
+	}
+
+	session, err := r.roleSession.Retrieve(ctx)
+	if err == nil && session.CanExpire && time.Until(session.Expires) < r.roleDuration/2 {
+		r.roleSession.Invalidate()
+		session, err = r.roleSession.Retrieve(ctx)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrAWSAssumeRoleFailed, r.roleARN, err)
+	}
+
+	r.AccessKeyID = session.AccessKeyID
+	r.SecretAccessKey = session.SecretAccessKey
+	r.SessionToken = session.SessionToken
+
+	return r.ChildEnvironment(parent), nil
+}
+
 func loadFrozenAWSConfig(
 	ctx context.Context,
 	loader awsConfigLoader,
@@ -9,9 +9,11 @@
 package credentials
 
 import (
+	"context"
 	"os"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
@@ -54,6 +56,10 @@ const (
 	// provider, but resolved the same way (secure store override, otherwise the environment) so it can
 	// be configured from the Settings page instead of only via the environment.
 	CopilotToken Key = "copilot.token"
+	// GitHubToken is the GitHub token the Actions Runner Controller registers in-cluster runners with
+	// (spec.cluster.actionsRunner). Like the Copilot token it is not a cloud credential, but resolving it
+	// through the secure store keeps it out of ksail.yaml.
+	GitHubToken Key = "github.token"
 )
 
 // Default environment variable names. These mirror the canonical defaults declared on the v1alpha1
@@ -77,6 +83,8 @@ const (
 	// copilotEnvFallback is the secondary variable webchat.copilotToken() reads after the primary; the
 	// credential resolution mirrors it so Settings recognizes a Copilot token set only via COPILOT_TOKEN.
 	copilotEnvFallback = "COPILOT_TOKEN"
+	// defaultGitHubTokenEnvVar is the variable GitHub CLI and Actions conventionally expose a token in.
+	defaultGitHubTokenEnvVar = "GITHUB_TOKEN" //nolint:gosec // env var NAME, not a secret
 )
 
 // AllKeys returns every credential key in a stable order. The Settings UI and API iterate this.
@@ -95,6 +103,7 @@ func AllKeys() []Key {
 		AzureSubscriptionID,
 		AzureResourceGroup,
 		CopilotToken,
+		GitHubToken,
 	}
 }
 
@@ -115,6 +124,7 @@ func DefaultEnvVar(key Key) string {
 		AzureSubscriptionID:   defaultAzureSubscriptionEnvVar,
 		AzureResourceGroup:    defaultAzureResourceGroupEnvVar,
 		CopilotToken:          defaultCopilotTokenEnvVar,
+		GitHubToken:           defaultGitHubTokenEnvVar,
 	}[key]
 }
 
@@ -136,20 +146,30 @@ type EnvResolver struct{}
 // AWSOptionsResolver resolves from immutable per-cluster AWS variable-name
 // overrides without mutating the process environment.
 type AWSOptionsResolver struct {
-	envVars map[Key]string
+	envVars      map[Key]string
+	roleARN      string
+	roleDuration time.Duration
 }
 
 // NewAWSOptionsResolver returns an environment-only resolver honoring the
 // variable names configured on one AWS provider spec. Empty names retain their
 // canonical defaults. The resolver owns its map and is safe for concurrent use.
+// A configured assumed role is carried into every resolution it produces.
 func NewAWSOptionsResolver(options v1alpha1.OptionsAWS) AWSOptionsResolver {
-	return AWSOptionsResolver{envVars: map[Key]string{
+	resolver := AWSOptionsResolver{envVars: map[Key]string{
 		AWSRegion:          options.RegionEnvVar,
 		AWSProfile:         options.ProfileEnvVar,
 		AWSAccessKeyID:     options.AccessKeyIDEnvVar,
 		AWSSecretAccessKey: options.SecretAccessKeyEnvVar,
 		AWSSessionToken:    options.SessionTokenEnvVar,
 	}}
+
+	if roleARN := strings.TrimSpace(options.AssumeRoleARN); roleARN != "" {
+		resolver.roleARN = roleARN
+		resolver.roleDuration = options.EffectiveAssumeRoleDuration()
+	}
+
+	return resolver
 }
 
 // AWSOptionsWithDefaults returns a complete set of AWS environment-variable names without
@@ -163,6 +183,8 @@ func AWSOptionsWithDefaults(options v1alpha1.OptionsAWS) v1alpha1.OptionsAWS {
 		AccessKeyIDEnvVar:     resolver.EnvVar(AWSAccessKeyID),
 		SecretAccessKeyEnvVar: resolver.EnvVar(AWSSecretAccessKey),
 		SessionTokenEnvVar:    resolver.EnvVar(AWSSessionToken),
+		AssumeRoleARN:         options.AssumeRoleARN,
+		AssumeRoleDuration:    options.AssumeRoleDuration,
 	}
 }
 
@@ -189,6 +211,17 @@ func (r AWSOptionsResolver) Value(key Key) string {
 	return resolveEnvValue(key, r.EnvVar(key))
 }
 
+// assumeRole returns the configured role ARN and session lifetime, or "" when none is configured.
+func (r AWSOptionsResolver) assumeRole() (string, time.Duration) {
+	return r.roleARN, r.roleDuration
+}
+
+// awsRoleResolver is implemented by resolvers that ask FreezeAWS to exchange the resolved
+// credentials for an assumed-role session.
+type awsRoleResolver interface {
+	assumeRole() (string, time.Duration)
+}
+
 // AWSResolution is an immutable snapshot of an AWS credential selection. ResolveFrozenAWS upgrades
 // it to one concrete credential tuple for identity-sensitive operations. Source variable names are
 // retained privately so child process environments can remove both stale canonical values and
@@ -205,6 +238,9 @@ type AWSResolution struct {
 	hasCustomCredentialEnv bool
 	frozen                 bool
 	sdkConfig              *aws.Config
+	roleARN                string
+	roleDuration           time.Duration
+	roleSession            *aws.CredentialsCache
 }
 
 // ResolveAWS snapshots all AWS credential values and their configured source
@@ -225,6 +261,10 @@ func ResolveAWS(resolver Resolver) AWSResolution {
 	}
 	resolution.sourceRegionEnvVar = resolver.EnvVar(AWSRegion)
 
+	if roleResolver, ok := resolver.(awsRoleResolver); ok {
+		resolution.roleARN, resolution.roleDuration = roleResolver.assumeRole()
+	}
+
 	credentialSources := [...]struct {
 		key           Key
 		canonicalName string
@@ -254,6 +294,16 @@ func (r AWSResolution) HasCustomCredentialSources() bool {
 	return r.hasCustomCredentialEnv
 }
 
+// AssumeRoleARN returns the IAM role FreezeAWS assumes with the resolved credentials, or "".
+func (r AWSResolution) AssumeRoleARN() string {
+	return r.roleARN
+}
+
+// AssumeRoleDuration returns the lifetime of each session of the role FreezeAWS assumes, or 0.
+func (r AWSResolution) AssumeRoleDuration() time.Duration {
+	return r.roleDuration
+}
+
 // IsFrozen reports whether the selection was resolved to one concrete static credential tuple.
 func (r AWSResolution) IsFrozen() bool {
 	return r.frozen
@@ -323,6 +373,27 @@ func OptionsForAWSChildEnvironment[T any](
 	)
 }
 
+// OptionsForRefreshingAWSChildEnvironment is OptionsForAWSChildEnvironment for
+// consumers that build the environment per child process: the source they
+// receive returns CurrentChildEnvironment, so a process started late in a long
+// operation gets a live assumed-role session instead of the first one.
+func OptionsForRefreshingAWSChildEnvironment[T any](
+	resolution AWSResolution,
+	parent []string,
+	withEnvironmentSource func(source func(context.Context) ([]string, error)) T,
+	requireCredentialValues func() T,
+) []T {
+	parent = append([]string{}, parent...)
+
+	return optionsWithCredentialRequirement(
+		withEnvironmentSource(func(ctx context.Context) ([]string, error) {
+			return resolution.CurrentChildEnvironment(ctx, parent)
+		}),
+		resolution.requiresCredentialValues(),
+		requireCredentialValues,
+	)
+}
+
 // ResolveAWSClientOptions snapshots one AWS identity and builds the paired
 // option sets that isolate an environment-based client and pin an SDK-backed
 // client to that same identity. Both option sets inherit the same fail-closed
@@ -51,6 +51,7 @@ func TestDefaultEnvVar_MatchesCanonicalSources(t *testing.T) {
 	)
 	// The Copilot token has no external canonical source; it mirrors webchat's primary token variable.
 	assert.Equal(t, "KSAIL_COPILOT_TOKEN", credentials.DefaultEnvVar(credentials.CopilotToken))
+	assert.Equal(t, "GITHUB_TOKEN", credentials.DefaultEnvVar(credentials.GitHubToken))
 }
 
 func TestDefaultEnvVar_UnknownKeyIsEmpty(t *testing.T) {
@@ -135,7 +135,7 @@ func DetectStore() (Store, bool) {
 func IsSecret(key Key) bool {
 	switch key {
 	case HetznerToken, OmniServiceAccountKey, AWSAccessKeyID, AWSSecretAccessKey, AWSSessionToken,
-		CopilotToken:
+		CopilotToken, GitHubToken:
 		return true
 	case OmniEndpoint, AWSRegion, AWSProfile, GCPProject, GCPLocation,
 		AzureSubscriptionID, AzureResourceGroup:
@@ -149,6 +149,10 @@ func IsSecret(key Key) bool {
 // rather than an infrastructure provider.
 const copilotGroup = "GitHub Copilot"
 
+// githubActionsGroup is the Settings UI section label for the GitHub token the in-cluster Actions
+// runners register with.
+const githubActionsGroup = "GitHub Actions"
+
 // ProviderFor returns the Settings UI group a credential belongs to: the infrastructure provider for
 // cloud credentials, or a feature group (e.g. the AI assistant) for non-provider credentials.
 func ProviderFor(key Key) string {
@@ -165,6 +169,8 @@ func ProviderFor(key Key) string {
 		return string(v1alpha1.ProviderAzure)
 	case CopilotToken:
 		return copilotGroup
+	case GitHubToken:
+		return githubActionsGroup
 	default:
 		return ""
 	}
@@ -187,6 +193,7 @@ func Label(key Key) string {
 		AzureSubscriptionID:   "Subscription ID",
 		AzureResourceGroup:    "Resource group",
 		CopilotToken:          "Token",
+		GitHubToken:           "Token",
 	}
 
 	if label, ok := labels[key]; ok {
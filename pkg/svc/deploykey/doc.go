@@ -0,0 +1,9 @@
+// Package deploykey manages the SSH deploy keys GitOps engines clone private
+// spec.workload.git repositories with.
+//
+// Keys are ed25519 key pairs generated on first use and kept under
+// ~/.ksail/deploy-keys, one per repository, so every cluster tracking the
+// repository reuses the key that was registered on it. Register adds a key to
+// the repository through the Git provider API, and KnownHosts scans the Git
+// host's SSH host key for Flux, which verifies it before cloning.
+package deploykey
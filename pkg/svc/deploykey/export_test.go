@@ -0,0 +1,14 @@
+package deploykey
+
+import "github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+
+// ExportSetNewProvider replaces the Git provider constructor and returns a function
+// restoring it.
+func ExportSetNewProvider(
+	fn func(providerName, token string) (gitprovider.Provider, error),
+) func() {
+	previous := newProvider
+	newProvider = fn
+
+	return func() { newProvider = previous }
+}
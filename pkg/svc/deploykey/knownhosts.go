@@ -0,0 +1,118 @@
+package deploykey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// defaultSSHPort is the port of ssh:// URLs without an explicit one.
+	defaultSSHPort = "22"
+	// scanTimeout bounds the host key scan when the context has no deadline.
+	scanTimeout = 15 * time.Second
+)
+
+var (
+	// ErrHostKeyNotScanned is returned when the SSH server did not present a host key.
+	ErrHostKeyNotScanned = errors.New("SSH server did not present a host key")
+
+	// errHostKeyScanned aborts the handshake once the host key is known; no
+	// authentication is attempted.
+	errHostKeyScanned = errors.New("host key scanned")
+)
+
+// KnownHosts returns the known_hosts entry of the SSH server cloneURL points at, in the form
+// Flux verifies the server with before cloning. cloneURL is an ssh:// URL or the scp-like
+// git@host:owner/repo.git form.
+func KnownHosts(ctx context.Context, cloneURL string) ([]byte, error) {
+	address, err := sshAddress(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKey, err := scanHostKey(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("scan SSH host key of %s: %w", address, err)
+	}
+
+	return []byte(knownhosts.Line([]string{address}, hostKey) + "\n"), nil
+}
+
+// sshAddress returns the host:port the SSH server of cloneURL listens on.
+func sshAddress(cloneURL string) (string, error) {
+	trimmed := strings.TrimSpace(cloneURL)
+
+	if strings.Contains(trimmed, "://") {
+		parsed, err := url.Parse(trimmed)
+		if err != nil || parsed.Scheme != "ssh" || parsed.Hostname() == "" {
+			return "", fmt.Errorf("%w: %q (expected an ssh:// URL)", ErrInvalidRepositoryURL, cloneURL)
+		}
+
+		port := parsed.Port()
+		if port == "" {
+			port = defaultSSHPort
+		}
+
+		return net.JoinHostPort(parsed.Hostname(), port), nil
+	}
+
+	_, hostPath, _ := strings.Cut(trimmed, "@")
+
+	host, _, found := strings.Cut(hostPath, ":")
+	if !found || host == "" {
+		return "", fmt.Errorf("%w: %q (expected an ssh:// URL)", ErrInvalidRepositoryURL, cloneURL)
+	}
+
+	return net.JoinHostPort(host, defaultSSHPort), nil
+}
+
+// scanHostKey connects to address and returns the host key the server presents during the
+// handshake.
+func scanHostKey(ctx context.Context, address string) (ssh.PublicKey, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("dial: %w", err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(scanTimeout)
+	}
+
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return nil, fmt.Errorf("set deadline: %w", err)
+	}
+
+	var hostKey ssh.PublicKey
+
+	_, _, _, err = ssh.NewClientConn(conn, address, &ssh.ClientConfig{
+		User: "git",
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+
+			return errHostKeyScanned
+		},
+	})
+	if hostKey != nil {
+		return hostKey, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("handshake: %w", err)
+	}
+
+	return nil, ErrHostKeyNotScanned
+}
@@ -0,0 +1,72 @@
+package deploykey_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// startSSHServer accepts SSH handshakes on a loopback port with a fresh host key and
+// returns the listen address and the host key.
+func startSSHServer(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromSigner(privateKey)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer func() { _ = conn.Close() }()
+
+				_, _, _, _ = ssh.NewServerConn(conn, config)
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), signer.PublicKey()
+}
+
+func TestKnownHosts(t *testing.T) {
+	t.Parallel()
+
+	address, hostKey := startSSHServer(t)
+
+	line, err := deploykey.KnownHosts(context.Background(), "ssh://git@"+address+"/org/repo.git")
+	require.NoError(t, err)
+
+	want := "[127.0.0.1]:" + address[strings.LastIndex(address, ":")+1:] + " " +
+		string(ssh.MarshalAuthorizedKey(hostKey))
+	assert.Equal(t, want, string(line))
+}
+
+func TestKnownHosts_RejectsHTTPSURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := deploykey.KnownHosts(context.Background(), "https://github.com/org/repo.git")
+	require.ErrorIs(t, err, deploykey.ErrInvalidRepositoryURL)
+}
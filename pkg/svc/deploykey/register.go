@@ -0,0 +1,43 @@
+package deploykey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+)
+
+// Title is the name KSail registers deploy keys under in the repository settings.
+const Title = "ksail"
+
+// newProvider creates the Git provider client deploy keys are registered with.
+//
+//nolint:gochecknoglobals // test seam replacing the Git provider API
+var newProvider = gitprovider.New
+
+// Register adds key as a deploy key of repositoryURL (an HTTPS clone URL or host/owner/repo)
+// through the Git provider API, authenticating with token. Deploy keys are read-only unless
+// readWrite is set. A key the repository already has counts as registered.
+func Register(ctx context.Context, repositoryURL, token string, key Key, readWrite bool) error {
+	ref, err := gitprovider.ParseRepoRef(repositoryURL)
+	if err != nil {
+		return fmt.Errorf("parse git source: %w", err)
+	}
+
+	provider, err := newProvider(ref.Provider, token)
+	if err != nil {
+		return fmt.Errorf("create git provider client: %w", err)
+	}
+
+	_, err = provider.CreateDeployKey(ctx, ref.Owner, ref.Name, gitprovider.DeployKeyOptions{
+		Title:     Title,
+		PublicKey: key.AuthorizedKey,
+		ReadWrite: readWrite,
+	})
+	if err != nil && !errors.Is(err, gitprovider.ErrDeployKeyAlreadyExists) {
+		return fmt.Errorf("create deploy key: %w", err)
+	}
+
+	return nil
+}
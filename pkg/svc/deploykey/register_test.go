@@ -0,0 +1,84 @@
+package deploykey_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenant/gitprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider records deploy key registrations; other Provider methods are not used.
+type fakeProvider struct {
+	gitprovider.Provider
+
+	err        error
+	registered []string
+	opts       []gitprovider.DeployKeyOptions
+}
+
+func (f *fakeProvider) CreateDeployKey(
+	_ context.Context,
+	owner, repo string,
+	opts gitprovider.DeployKeyOptions,
+) (int64, error) {
+	f.registered = append(f.registered, owner+"/"+repo)
+	f.opts = append(f.opts, opts)
+
+	return 1, f.err
+}
+
+//nolint:paralleltest // replaces the package-level Git provider seam
+func TestRegister(t *testing.T) {
+	provider := &fakeProvider{}
+
+	var gotProvider, gotToken string
+
+	restore := deploykey.ExportSetNewProvider(
+		func(providerName, token string) (gitprovider.Provider, error) {
+			gotProvider, gotToken = providerName, token
+
+			return provider, nil
+		},
+	)
+	defer restore()
+
+	key := deploykey.Key{AuthorizedKey: "ssh-ed25519 AAAAC3Nz"}
+
+	err := deploykey.Register(
+		context.Background(), "https://github.com/org/repo.git", "token", key, false,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "github", gotProvider)
+	assert.Equal(t, "token", gotToken)
+	assert.Equal(t, []string{"org/repo"}, provider.registered)
+	assert.Equal(t, []gitprovider.DeployKeyOptions{
+		{Title: deploykey.Title, PublicKey: "ssh-ed25519 AAAAC3Nz"},
+	}, provider.opts)
+
+	provider.err = fmt.Errorf("%w: org/repo", gitprovider.ErrDeployKeyAlreadyExists)
+
+	err = deploykey.Register(
+		context.Background(), "https://github.com/org/repo.git", "token", key, false,
+	)
+	require.NoError(t, err, "an already registered key counts as registered")
+
+	provider.err = gitprovider.ErrGitHubAPI
+
+	err = deploykey.Register(
+		context.Background(), "https://github.com/org/repo.git", "token", key, false,
+	)
+	require.ErrorIs(t, err, gitprovider.ErrGitHubAPI)
+}
+
+func TestRegister_RequiresToken(t *testing.T) {
+	t.Parallel()
+
+	err := deploykey.Register(
+		context.Background(), "https://github.com/org/repo.git", "", deploykey.Key{}, false,
+	)
+	require.ErrorIs(t, err, gitprovider.ErrTokenRequired)
+}
@@ -0,0 +1,135 @@
+package deploykey
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sshbootstrap "github.com/devantler-tech/ksail/v7/pkg/svc/bootstrap/ssh"
+)
+
+const (
+	// dirName is the directory under ~/.ksail holding the deploy keys.
+	dirName = "deploy-keys"
+	// publicKeySuffix is appended to the private key file name for its public half.
+	publicKeySuffix = ".pub"
+	// dirPerm keeps the deploy key directory private to the user.
+	dirPerm = 0o700
+	// privateKeyPerm is the permission of the private key file.
+	privateKeyPerm = 0o600
+	// publicKeyPerm is the permission of the public key file.
+	publicKeyPerm = 0o644
+)
+
+// ErrInvalidRepositoryURL is returned when no key name can be derived from a repository URL.
+var ErrInvalidRepositoryURL = errors.New("invalid repository URL")
+
+// Key is the deploy key of one repository.
+type Key struct {
+	// Path is the file the private key is stored in; the public key sits next to it with a
+	// .pub suffix.
+	Path string
+	// PrivateKeyPEM is the OpenSSH PEM encoding of the private key.
+	PrivateKeyPEM []byte
+	// AuthorizedKey is the public key as a single authorized_keys line, the form Git
+	// providers accept as a deploy key.
+	AuthorizedKey string
+}
+
+// DefaultDir returns the directory deploy keys are kept in (~/.ksail/deploy-keys).
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".ksail", dirName), nil
+}
+
+// KeyName returns the file name the deploy key of repositoryURL is stored under, such as
+// github.com_org_repo. The HTTPS and SSH URLs of a repository map to the same name.
+func KeyName(repositoryURL string) (string, error) {
+	trimmed := strings.TrimSpace(repositoryURL)
+	if _, rest, found := strings.Cut(trimmed, "://"); found {
+		trimmed = rest
+	}
+
+	if _, rest, found := strings.Cut(trimmed, "@"); found {
+		trimmed = rest
+	}
+
+	trimmed = strings.TrimSuffix(strings.TrimSuffix(trimmed, "/"), ".git")
+
+	name := strings.NewReplacer("/", "_", ":", "_").Replace(trimmed)
+	if name == "" || !strings.Contains(name, "_") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRepositoryURL, repositoryURL)
+	}
+
+	return name, nil
+}
+
+// Load reads the deploy key of repositoryURL from dir. It returns an error wrapping
+// fs.ErrNotExist when no key has been generated yet.
+func Load(dir, repositoryURL string) (Key, error) {
+	name, err := KeyName(repositoryURL)
+	if err != nil {
+		return Key{}, err
+	}
+
+	keyPath := filepath.Join(dir, name)
+
+	pemBytes, err := os.ReadFile(keyPath) //nolint:gosec // KSail-managed path
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to read deploy key: %w", err)
+	}
+
+	pair, err := sshbootstrap.ParsePrivateKey(pemBytes)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to load deploy key %s: %w", keyPath, err)
+	}
+
+	return Key{Path: keyPath, PrivateKeyPEM: pemBytes, AuthorizedKey: pair.AuthorizedKey}, nil
+}
+
+// LoadOrCreate loads the deploy key of repositoryURL stored in dir, generating and storing
+// a new one, together with its public key, when dir holds none. created reports whether
+// the key was generated, in which case it still has to be registered on the repository.
+func LoadOrCreate(dir, repositoryURL string) (Key, bool, error) {
+	key, err := Load(dir, repositoryURL)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return key, false, err
+	}
+
+	name, err := KeyName(repositoryURL)
+	if err != nil {
+		return Key{}, false, err
+	}
+
+	pair, err := sshbootstrap.GenerateKeyPair()
+	if err != nil {
+		return Key{}, false, fmt.Errorf("failed to generate deploy key: %w", err)
+	}
+
+	err = os.MkdirAll(dir, dirPerm)
+	if err != nil {
+		return Key{}, false, fmt.Errorf("failed to create deploy key directory: %w", err)
+	}
+
+	keyPath := filepath.Join(dir, name)
+
+	err = os.WriteFile(keyPath, pair.PrivateKeyPEM, privateKeyPerm)
+	if err != nil {
+		return Key{}, false, fmt.Errorf("failed to write deploy key: %w", err)
+	}
+
+	err = os.WriteFile(keyPath+publicKeySuffix, []byte(pair.AuthorizedKey+"\n"), publicKeyPerm)
+	if err != nil {
+		return Key{}, false, fmt.Errorf("failed to write deploy key public key: %w", err)
+	}
+
+	return Key{Path: keyPath, PrivateKeyPEM: pair.PrivateKeyPEM, AuthorizedKey: pair.AuthorizedKey},
+		true, nil
+}
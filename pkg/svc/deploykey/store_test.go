@@ -0,0 +1,58 @@
+package deploykey_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyName(t *testing.T) {
+	t.Parallel()
+
+	for _, repositoryURL := range []string{
+		"https://github.com/org/repo.git",
+		"ssh://git@github.com/org/repo.git",
+		"git@github.com:org/repo.git",
+		"github.com/org/repo/",
+	} {
+		name, err := deploykey.KeyName(repositoryURL)
+		require.NoError(t, err, repositoryURL)
+		assert.Equal(t, "github.com_org_repo", name, repositoryURL)
+	}
+
+	_, err := deploykey.KeyName("https://")
+	require.ErrorIs(t, err, deploykey.ErrInvalidRepositoryURL)
+}
+
+func TestLoadOrCreate_GeneratesOnceAndReuses(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "deploy-keys")
+
+	_, err := deploykey.Load(dir, "https://github.com/org/repo.git")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	key, created, err := deploykey.LoadOrCreate(dir, "https://github.com/org/repo.git")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.True(t, strings.HasPrefix(key.AuthorizedKey, "ssh-ed25519 "))
+	assert.Equal(t, filepath.Join(dir, "github.com_org_repo"), key.Path)
+
+	info, err := os.Stat(key.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	public, err := os.ReadFile(key.Path + ".pub")
+	require.NoError(t, err)
+	assert.Equal(t, key.AuthorizedKey+"\n", string(public))
+
+	reused, created, err := deploykey.LoadOrCreate(dir, "ssh://git@github.com/org/repo.git")
+	require.NoError(t, err)
+	assert.False(t, created, "the SSH URL of the same repository must reuse its key")
+	assert.Equal(t, key, reused)
+}
@@ -0,0 +1,182 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/credentials"
+)
+
+// ErrCapabilityUnsupported indicates that a workload target does not support a
+// capability a command needs.
+var ErrCapabilityUnsupported = errors.New("capability not supported by target")
+
+// Capability is a workload feature that depends on how a cluster was created,
+// rather than only on the Kubernetes API every context exposes.
+type Capability string
+
+const (
+	// CapabilityImageTransfer is exporting images from and importing images into
+	// the container runtime of the cluster nodes. It needs nodes running as
+	// Docker containers with shell access.
+	CapabilityImageTransfer Capability = "image transfer"
+	// CapabilityNodeDebug is host-level node debugging. It needs Talos API access
+	// or nodes running as Docker containers.
+	CapabilityNodeDebug Capability = "node debugging"
+	// CapabilityHostAccess is the cluster calling back into processes on the
+	// developer's host, such as a webhook server under a debugger. It needs a
+	// control plane running as a Docker container on a local network.
+	CapabilityHostAccess Capability = "host access"
+)
+
+// Target is a kubeconfig context resolved as the target of a workload command.
+// Any context is a valid target for commands that only use the Kubernetes API;
+// Info additionally carries the detected distribution and provider when the
+// context belongs to a cluster KSail recognizes, which gates the capabilities
+// that reach past the API.
+type Target struct {
+	// Context is the resolved context name.
+	Context string
+	// KubeconfigPath is the resolved kubeconfig path.
+	KubeconfigPath string
+	// ServerURL is the API server of the context's cluster.
+	ServerURL string
+	// Info is the detected cluster info, or nil when the context does not
+	// belong to a cluster KSail recognizes.
+	Info *Info
+	// DetectErr explains why Info is nil.
+	DetectErr error
+}
+
+// DetectTarget resolves contextName (or the current context when empty) in the
+// kubeconfig at kubeconfigPath as a workload target. Unlike DetectInfo, a
+// context KSail cannot attribute to a distribution and provider is not an
+// error: it yields a Target without Info. Errors are returned only when the
+// context itself cannot be resolved.
+func DetectTarget(ctx context.Context, kubeconfigPath, contextName string) (*Target, error) {
+	return DetectTargetWithResolver(ctx, kubeconfigPath, contextName, credentials.EnvResolver{})
+}
+
+// DetectTargetWithResolver is DetectTarget with an explicit
+// credentials.Resolver, see DetectInfoWithResolver.
+func DetectTargetWithResolver(
+	ctx context.Context,
+	kubeconfigPath, contextName string,
+	resolver credentials.Resolver,
+) (*Target, error) {
+	resolved, err := loadKubeContext(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &Target{
+		Context:        resolved.contextName,
+		KubeconfigPath: resolved.kubeconfigPath,
+		ServerURL:      resolved.serverURL,
+	}
+
+	info, err := DetectInfoWithResolver(
+		ctx,
+		resolved.kubeconfigPath,
+		resolved.contextName,
+		resolver,
+	)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("detect cluster info: %w", ctx.Err())
+		}
+
+		target.DetectErr = err
+
+		return target, nil
+	}
+
+	target.Info = info
+
+	return target, nil
+}
+
+// Managed reports whether the target belongs to a cluster KSail recognizes.
+func (t *Target) Managed() bool {
+	return t.Info != nil
+}
+
+// Supports reports whether the target supports capability.
+func (t *Target) Supports(capability Capability) bool {
+	return t.unsupportedReason(capability) == ""
+}
+
+// Require returns an ErrCapabilityUnsupported error explaining why the target
+// does not support capability, or nil when it does.
+func (t *Target) Require(capability Capability) error {
+	reason := t.unsupportedReason(capability)
+	if reason == "" {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: context %q does not support %s: %s",
+		ErrCapabilityUnsupported, t.Context, capability, reason,
+	)
+}
+
+// unsupportedReason returns why the target does not support capability, or ""
+// when it does.
+func (t *Target) unsupportedReason(capability Capability) string {
+	if t.Info == nil {
+		reason := "it is not a cluster created by KSail"
+		if t.DetectErr != nil {
+			reason += " (" + t.DetectErr.Error() + ")"
+		}
+
+		return reason
+	}
+
+	distribution := t.Info.Distribution
+	dockerProvider := t.Info.Provider == v1alpha1.ProviderDocker || t.Info.Provider == ""
+
+	switch capability {
+	case CapabilityImageTransfer:
+		switch {
+		case distribution == v1alpha1.DistributionTalos,
+			distribution == v1alpha1.DistributionVCluster:
+			return fmt.Sprintf("%s nodes do not allow importing images", distribution)
+		case !dockerProvider:
+			return fmt.Sprintf(
+				"only clusters on the %s provider are supported", v1alpha1.ProviderDocker,
+			)
+		}
+	case CapabilityNodeDebug:
+		switch distribution {
+		case v1alpha1.DistributionTalos:
+		case v1alpha1.DistributionVanilla, v1alpha1.DistributionK3s,
+			v1alpha1.DistributionVCluster, v1alpha1.DistributionKWOK:
+			if !dockerProvider {
+				return fmt.Sprintf(
+					"only %s clusters on the %s provider are supported",
+					distribution, v1alpha1.ProviderDocker,
+				)
+			}
+		default:
+			return fmt.Sprintf(
+				"%s nodes are only reachable through the cloud's own access tooling",
+				distribution,
+			)
+		}
+	case CapabilityHostAccess:
+		switch distribution {
+		case v1alpha1.DistributionEKS, v1alpha1.DistributionGKE, v1alpha1.DistributionAKS:
+			return fmt.Sprintf("%s control planes cannot reach the local host", distribution)
+		default:
+			if !dockerProvider {
+				return fmt.Sprintf(
+					"only clusters on the %s provider are supported", v1alpha1.ProviderDocker,
+				)
+			}
+		}
+	}
+
+	return ""
+}
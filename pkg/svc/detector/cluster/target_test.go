@@ -0,0 +1,123 @@
+package cluster_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	cluster "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// targetKubeconfig holds a KSail Kind cluster, a local Talos cluster, and a
+// staging cluster whose context name and endpoint KSail does not recognize.
+const targetKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: kind-dev
+- cluster:
+    server: https://127.0.0.1:7443
+  name: talos-dev
+- cluster:
+    server: https://staging.example.com:6443
+  name: staging
+contexts:
+- context:
+    cluster: kind-dev
+    user: kind-dev
+  name: kind-dev
+- context:
+    cluster: talos-dev
+    user: admin@talos-dev
+  name: admin@talos-dev
+- context:
+    cluster: staging
+    user: staging
+  name: staging
+users:
+- name: kind-dev
+  user: {}
+- name: admin@talos-dev
+  user: {}
+- name: staging
+  user: {}
+`
+
+func writeTargetKubeconfig(t *testing.T) string {
+	t.Helper()
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(targetKubeconfig), 0o600))
+
+	return kubeconfigPath
+}
+
+func TestDetectTarget_UnrecognizedContextIsAPlainTarget(t *testing.T) {
+	t.Parallel()
+
+	target, err := cluster.DetectTarget(t.Context(), writeTargetKubeconfig(t), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "staging", target.Context)
+	assert.Equal(t, "https://staging.example.com:6443", target.ServerURL)
+	assert.False(t, target.Managed())
+	require.ErrorIs(t, target.DetectErr, cluster.ErrUnknownContextPattern)
+
+	err = target.Require(cluster.CapabilityImageTransfer)
+	require.ErrorIs(t, err, cluster.ErrCapabilityUnsupported)
+	assert.Contains(t, err.Error(), `context "staging" does not support image transfer`)
+	assert.False(t, target.Supports(cluster.CapabilityNodeDebug))
+}
+
+func TestDetectTarget_KindClusterSupportsEveryCapability(t *testing.T) {
+	t.Parallel()
+
+	target, err := cluster.DetectTarget(t.Context(), writeTargetKubeconfig(t), "kind-dev")
+
+	require.NoError(t, err)
+	require.True(t, target.Managed())
+	assert.Equal(t, v1alpha1.DistributionVanilla, target.Info.Distribution)
+	require.NoError(t, target.Require(cluster.CapabilityImageTransfer))
+	require.NoError(t, target.Require(cluster.CapabilityNodeDebug))
+	require.NoError(t, target.Require(cluster.CapabilityHostAccess))
+}
+
+func TestDetectTarget_TalosClusterGatesImageTransfer(t *testing.T) {
+	t.Parallel()
+
+	target, err := cluster.DetectTarget(t.Context(), writeTargetKubeconfig(t), "admin@talos-dev")
+
+	require.NoError(t, err)
+	require.True(t, target.Managed())
+	assert.True(t, target.Supports(cluster.CapabilityNodeDebug))
+	require.ErrorIs(t, target.Require(cluster.CapabilityImageTransfer), cluster.ErrCapabilityUnsupported)
+}
+
+func TestDetectTarget_ManagedCloudClusterGatesNodeDebug(t *testing.T) {
+	t.Parallel()
+
+	target := &cluster.Target{
+		Context: "arn:aws:eks:eu-west-1:123456789012:cluster/prod",
+		Info: &cluster.Info{
+			Distribution: v1alpha1.DistributionEKS,
+			Provider:     v1alpha1.ProviderAWS,
+		},
+	}
+
+	assert.False(t, target.Supports(cluster.CapabilityNodeDebug))
+	assert.False(t, target.Supports(cluster.CapabilityImageTransfer))
+	assert.False(t, target.Supports(cluster.CapabilityHostAccess))
+}
+
+func TestDetectTarget_MissingContextErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := cluster.DetectTarget(t.Context(), writeTargetKubeconfig(t), "prod")
+
+	require.ErrorIs(t, err, cluster.ErrContextNotFound)
+}
@@ -53,6 +53,16 @@ func NewComponentDetector(
 	}
 }
 
+// Clientset returns the Kubernetes client the detector probes with, or nil
+// when the detector (or its client) is unset.
+func (d *ComponentDetector) Clientset() kubernetes.Interface {
+	if d == nil {
+		return nil
+	}
+
+	return d.k8sClientset
+}
+
 // releaseSet is an in-memory index of the latest Helm release revision keyed
 // by name+namespace for O(1) deployed-state checks after one ListReleases call.
 type releaseSet map[releaseKey]helm.ReleaseInfo
@@ -186,11 +196,58 @@ func (d *ComponentDetector) detectAllComponents(
 		return nil, fmt.Errorf("detect CertManager: %w", err)
 	}
 
+	spec.SealedSecrets, err = d.detectSealedSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect SealedSecrets: %w", err)
+	}
+
+	spec.ExternalSecrets, err = d.detectExternalSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect ExternalSecrets: %w", err)
+	}
+
+	spec.Backup, err = d.detectBackup(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect Backup: %w", err)
+	}
+
 	spec.PolicyEngine, err = d.detectPolicyEngine(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("detect PolicyEngine: %w", err)
 	}
 
+	spec.IngressController, err = d.detectIngressController(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect IngressController: %w", err)
+	}
+
+	spec.GatewayAPI, err = d.detectGatewayAPI(ctx, spec.CNI)
+	if err != nil {
+		return nil, fmt.Errorf("detect GatewayAPI: %w", err)
+	}
+
+	spec.ServiceMesh, err = d.detectServiceMesh(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect ServiceMesh: %w", err)
+	}
+
+	spec.Istio.Mode, err = d.detectIstioMode(ctx, spec.ServiceMesh)
+	if err != nil {
+		return nil, fmt.Errorf("detect Istio mode: %w", err)
+	}
+
+	spec.Knative, err = d.detectKnative(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect Knative: %w", err)
+	}
+
+	spec.IdentityProvider = d.detectIdentityProvider(ctx)
+
+	spec.Observability.Logging, err = d.detectLogging(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect Logging: %w", err)
+	}
+
 	spec.GitOpsEngine, err = d.detectGitOpsEngine(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("detect GitOpsEngine: %w", err)
@@ -507,6 +564,29 @@ func (d *ComponentDetector) detectCertManager(ctx context.Context) (v1alpha1.Cer
 	}, v1alpha1.CertManagerDisabled)
 }
 
+// detectSealedSecrets checks for the Sealed Secrets controller Helm release.
+func (d *ComponentDetector) detectSealedSecrets(ctx context.Context) (v1alpha1.SealedSecrets, error) {
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.SealedSecrets]{
+		{ReleaseSealedSecrets, NamespaceSealedSecrets, v1alpha1.SealedSecretsEnabled},
+	}, v1alpha1.SealedSecretsDisabled)
+}
+
+// detectExternalSecrets checks for the External Secrets Operator Helm release.
+func (d *ComponentDetector) detectExternalSecrets(
+	ctx context.Context,
+) (v1alpha1.ExternalSecrets, error) {
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.ExternalSecrets]{
+		{ReleaseExternalSecrets, NamespaceExternalSecrets, v1alpha1.ExternalSecretsEnabled},
+	}, v1alpha1.ExternalSecretsDisabled)
+}
+
+// detectBackup checks for the Velero Helm release.
+func (d *ComponentDetector) detectBackup(ctx context.Context) (v1alpha1.Backup, error) {
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.Backup]{
+		{ReleaseVelero, NamespaceVelero, v1alpha1.BackupVelero},
+	}, v1alpha1.BackupNone)
+}
+
 // detectPolicyEngine checks for Kyverno or Gatekeeper Helm releases.
 func (d *ComponentDetector) detectPolicyEngine(
 	ctx context.Context,
@@ -517,6 +597,102 @@ func (d *ComponentDetector) detectPolicyEngine(
 	}, v1alpha1.PolicyEngineNone)
 }
 
+// detectIngressController checks for ingress-nginx, Traefik, or Contour Helm releases.
+func (d *ComponentDetector) detectIngressController(
+	ctx context.Context,
+) (v1alpha1.IngressController, error) {
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.IngressController]{
+		{ReleaseIngressNginx, NamespaceIngressNginx, v1alpha1.IngressControllerNginx},
+		{ReleaseTraefik, NamespaceTraefik, v1alpha1.IngressControllerTraefik},
+		{ReleaseContour, NamespaceContour, v1alpha1.IngressControllerContour},
+	}, v1alpha1.IngressControllerNone)
+}
+
+// detectServiceMesh checks for the Istio or Linkerd control plane Helm releases.
+func (d *ComponentDetector) detectServiceMesh(ctx context.Context) (v1alpha1.ServiceMesh, error) {
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.ServiceMesh]{
+		{ReleaseIstiod, NamespaceIstio, v1alpha1.ServiceMeshIstio},
+		{ReleaseLinkerdControlPlane, NamespaceLinkerd, v1alpha1.ServiceMeshLinkerd},
+	}, v1alpha1.ServiceMeshNone)
+}
+
+// detectIstioMode reports Ambient when the ztunnel release is present. It
+// returns the zero value unless Istio is the detected mesh, so clusters without
+// Istio keep an empty istio block.
+func (d *ComponentDetector) detectIstioMode(
+	ctx context.Context,
+	mesh v1alpha1.ServiceMesh,
+) (v1alpha1.IstioMode, error) {
+	if mesh != v1alpha1.ServiceMeshIstio {
+		return "", nil
+	}
+
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.IstioMode]{
+		{ReleaseZtunnel, NamespaceIstio, v1alpha1.IstioModeAmbient},
+	}, v1alpha1.IstioModeSidecar)
+}
+
+// detectKnative checks for the Knative Operator Helm release.
+func (d *ComponentDetector) detectKnative(ctx context.Context) (v1alpha1.Knative, error) {
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.Knative]{
+		{ReleaseKnativeOperator, NamespaceKnativeOperator, v1alpha1.KnativeEnabled},
+	}, v1alpha1.KnativeDisabled)
+}
+
+// detectIdentityProvider checks for the development Keycloak Deployment.
+func (d *ComponentDetector) detectIdentityProvider(ctx context.Context) v1alpha1.IdentityProvider {
+	if d.deploymentExists(ctx, DeploymentKeycloak, NamespaceKeycloak) {
+		return v1alpha1.IdentityProviderKeycloak
+	}
+
+	return v1alpha1.IdentityProviderNone
+}
+
+// detectGatewayAPI checks for an Envoy Gateway Helm release, then for the Gateway
+// API CRDs. CRDs on a Cilium cluster are reported as Cilium, since the Cilium CNI
+// installs them together with its gateway controller.
+func (d *ComponentDetector) detectGatewayAPI(
+	ctx context.Context,
+	cni v1alpha1.CNI,
+) (v1alpha1.GatewayAPI, error) {
+	gatewayAPI, err := detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.GatewayAPI]{
+		{ReleaseEnvoyGateway, NamespaceEnvoyGateway, v1alpha1.GatewayAPIEnvoy},
+	}, v1alpha1.GatewayAPINone)
+	if err != nil || gatewayAPI != v1alpha1.GatewayAPINone {
+		return gatewayAPI, err
+	}
+
+	if !d.gatewayAPICRDsExist() {
+		return v1alpha1.GatewayAPINone, nil
+	}
+
+	if cni == v1alpha1.CNICilium {
+		return v1alpha1.GatewayAPICilium, nil
+	}
+
+	return v1alpha1.GatewayAPICRDs, nil
+}
+
+// gatewayAPICRDsExist reports whether the API server serves the Gateway API
+// v1 group version.
+func (d *ComponentDetector) gatewayAPICRDsExist() bool {
+	if d.k8sClientset == nil {
+		return false
+	}
+
+	_, err := d.k8sClientset.Discovery().ServerResourcesForGroupVersion(GatewayAPIGroupVersion)
+
+	// Any error (including not-found) means the CRDs are not available.
+	return err == nil
+}
+
+// detectLogging checks for the Loki Helm release installed by KSail's logging stack.
+func (d *ComponentDetector) detectLogging(ctx context.Context) (v1alpha1.Logging, error) {
+	return detectFirstRelease(ctx, d.helmClient, []releaseMapping[v1alpha1.Logging]{
+		{ReleaseLoki, NamespaceLogging, v1alpha1.LoggingLoki},
+	}, v1alpha1.LoggingNone)
+}
+
 // gitOpsEngineReleaseMappings is the single source of truth for the Helm
 // releases that identify a KSail-managed GitOps engine, in priority order.
 func gitOpsEngineReleaseMappings() []releaseMapping[v1alpha1.GitOpsEngine] {
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes/fake"
 )
 
@@ -602,6 +603,346 @@ func TestDetectPolicyEngine_None(t *testing.T) {
 	assert.Equal(t, v1alpha1.PolicyEngineNone, policyEngine)
 }
 
+func TestDetectIngressController_Traefik(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, detector.ReleaseIngressNginx, detector.NamespaceIngressNginx).
+		Return(false, nil)
+	helmClient.On("ReleaseExists", ctx, detector.ReleaseTraefik, detector.NamespaceTraefik).
+		Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	ingressController, err := d.ExportDetectIngressController(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.IngressControllerTraefik, ingressController)
+}
+
+func TestDetectIngressController_None(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	ingressController, err := d.ExportDetectIngressController(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.IngressControllerNone, ingressController)
+}
+
+func TestDetectServiceMesh_Linkerd(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+
+	helmClient.On("ReleaseExists", ctx, detector.ReleaseIstiod, detector.NamespaceIstio).
+		Return(false, nil)
+	helmClient.On("ReleaseExists", ctx, detector.ReleaseLinkerdControlPlane, detector.NamespaceLinkerd).
+		Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, fake.NewClientset(), nil)
+	serviceMesh, err := d.ExportDetectServiceMesh(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ServiceMeshLinkerd, serviceMesh)
+}
+
+func TestDetectIstioMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mesh     v1alpha1.ServiceMesh
+		ztunnel  bool
+		expected v1alpha1.IstioMode
+	}{
+		{name: "not istio", mesh: v1alpha1.ServiceMeshLinkerd, expected: ""},
+		{name: "sidecar", mesh: v1alpha1.ServiceMeshIstio, expected: v1alpha1.IstioModeSidecar},
+		{
+			name:     "ambient",
+			mesh:     v1alpha1.ServiceMeshIstio,
+			ztunnel:  true,
+			expected: v1alpha1.IstioModeAmbient,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			helmClient := helm.NewMockInterface(t)
+
+			if testCase.mesh == v1alpha1.ServiceMeshIstio {
+				helmClient.On("ReleaseExists", ctx, detector.ReleaseZtunnel, detector.NamespaceIstio).
+					Return(testCase.ztunnel, nil)
+			}
+
+			d := detector.NewComponentDetector(helmClient, fake.NewClientset(), nil)
+			mode, err := d.ExportDetectIstioMode(ctx, testCase.mesh)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, mode)
+		})
+	}
+}
+
+func TestDetectGatewayAPI_Envoy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+
+	helmClient.On("ReleaseExists", ctx, detector.ReleaseEnvoyGateway, detector.NamespaceEnvoyGateway).
+		Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, fake.NewClientset(), nil)
+	gatewayAPI, err := d.ExportDetectGatewayAPI(ctx, v1alpha1.CNICilium)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.GatewayAPIEnvoy, gatewayAPI)
+}
+
+func TestDetectGatewayAPI_CRDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cni      v1alpha1.CNI
+		served   bool
+		expected v1alpha1.GatewayAPI
+	}{
+		{name: "no CRDs", cni: v1alpha1.CNIDefault, expected: v1alpha1.GatewayAPINone},
+		{name: "CRDs only", cni: v1alpha1.CNIDefault, served: true, expected: v1alpha1.GatewayAPICRDs},
+		{name: "Cilium", cni: v1alpha1.CNICilium, served: true, expected: v1alpha1.GatewayAPICilium},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			helmClient := helm.NewMockInterface(t)
+			k8sClientset := fake.NewClientset()
+
+			helmClient.On("ReleaseExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+			if testCase.served {
+				fakeDiscovery, ok := k8sClientset.Discovery().(*fakediscovery.FakeDiscovery)
+				require.True(t, ok)
+
+				fakeDiscovery.Resources = []*metav1.APIResourceList{
+					{GroupVersion: detector.GatewayAPIGroupVersion},
+				}
+			}
+
+			d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+			gatewayAPI, err := d.ExportDetectGatewayAPI(ctx, testCase.cni)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, gatewayAPI)
+		})
+	}
+}
+
+func TestDetectSealedSecrets_Enabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On(
+		"ReleaseExists", ctx, detector.ReleaseSealedSecrets, detector.NamespaceSealedSecrets,
+	).Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	sealedSecrets, err := d.ExportDetectSealedSecrets(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.SealedSecretsEnabled, sealedSecrets)
+}
+
+func TestDetectSealedSecrets_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	sealedSecrets, err := d.ExportDetectSealedSecrets(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.SealedSecretsDisabled, sealedSecrets)
+}
+
+func TestDetectExternalSecrets_Enabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On(
+		"ReleaseExists", ctx, detector.ReleaseExternalSecrets, detector.NamespaceExternalSecrets,
+	).Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	externalSecrets, err := d.ExportDetectExternalSecrets(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ExternalSecretsEnabled, externalSecrets)
+}
+
+func TestDetectExternalSecrets_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	externalSecrets, err := d.ExportDetectExternalSecrets(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ExternalSecretsDisabled, externalSecrets)
+}
+
+func TestDetectKnative_Enabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On(
+		"ReleaseExists", ctx, detector.ReleaseKnativeOperator, detector.NamespaceKnativeOperator,
+	).Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	knative, err := d.ExportDetectKnative(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.KnativeEnabled, knative)
+}
+
+func TestDetectKnative_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	knative, err := d.ExportDetectKnative(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.KnativeDisabled, knative)
+}
+
+func TestDetectIdentityProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+
+	none := detector.NewComponentDetector(helmClient, fake.NewClientset(), nil)
+	assert.Equal(t, v1alpha1.IdentityProviderNone, none.ExportDetectIdentityProvider(ctx))
+
+	k8sClientset := fake.NewClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      detector.DeploymentKeycloak,
+			Namespace: detector.NamespaceKeycloak,
+		},
+	})
+
+	keycloak := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	assert.Equal(t, v1alpha1.IdentityProviderKeycloak, keycloak.ExportDetectIdentityProvider(ctx))
+}
+
+func TestDetectBackup_Velero(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On(
+		"ReleaseExists", ctx, detector.ReleaseVelero, detector.NamespaceVelero,
+	).Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	backup, err := d.ExportDetectBackup(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.BackupVelero, backup)
+}
+
+func TestDetectBackup_None(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	backup, err := d.ExportDetectBackup(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.BackupNone, backup)
+}
+
+func TestDetectLogging_Loki(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, detector.ReleaseLoki, detector.NamespaceLogging).
+		Return(true, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	logging, err := d.ExportDetectLogging(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.LoggingLoki, logging)
+}
+
+func TestDetectLogging_None(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	k8sClientset := fake.NewClientset()
+
+	helmClient.On("ReleaseExists", ctx, mock.Anything, mock.Anything).Return(false, nil)
+
+	d := detector.NewComponentDetector(helmClient, k8sClientset, nil)
+	logging, err := d.ExportDetectLogging(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.LoggingNone, logging)
+}
+
 func TestDetectGitOpsEngine_Flux(t *testing.T) {
 	t.Parallel()
 
@@ -1,5 +1,7 @@
 // Package detector detects installed Kubernetes components by querying the
 // cluster via Helm release history and Kubernetes API. This is used by the
 // update command to build an accurate baseline of the running cluster state,
-// replacing client-side state persistence.
+// replacing client-side state persistence. It also lists the state a delete or
+// recreate would destroy, so destructive commands can refuse to run without
+// an explicit override (--force-data-loss, --force-drain).
 package detector
@@ -53,6 +53,70 @@ func (d *ComponentDetector) ExportDetectPolicyEngine(
 	return d.detectPolicyEngine(ctx)
 }
 
+// ExportDetectIngressController exposes detectIngressController for testing.
+func (d *ComponentDetector) ExportDetectIngressController(
+	ctx context.Context,
+) (v1alpha1.IngressController, error) {
+	return d.detectIngressController(ctx)
+}
+
+// ExportDetectServiceMesh exposes detectServiceMesh for testing.
+func (d *ComponentDetector) ExportDetectServiceMesh(
+	ctx context.Context,
+) (v1alpha1.ServiceMesh, error) {
+	return d.detectServiceMesh(ctx)
+}
+
+// ExportDetectIstioMode exposes detectIstioMode for testing.
+func (d *ComponentDetector) ExportDetectIstioMode(
+	ctx context.Context,
+	mesh v1alpha1.ServiceMesh,
+) (v1alpha1.IstioMode, error) {
+	return d.detectIstioMode(ctx, mesh)
+}
+
+// ExportDetectIdentityProvider exposes detectIdentityProvider for testing.
+func (d *ComponentDetector) ExportDetectIdentityProvider(ctx context.Context) v1alpha1.IdentityProvider {
+	return d.detectIdentityProvider(ctx)
+}
+
+// ExportDetectKnative exposes detectKnative for testing.
+func (d *ComponentDetector) ExportDetectKnative(ctx context.Context) (v1alpha1.Knative, error) {
+	return d.detectKnative(ctx)
+}
+
+// ExportDetectGatewayAPI exposes detectGatewayAPI for testing.
+func (d *ComponentDetector) ExportDetectGatewayAPI(
+	ctx context.Context,
+	cni v1alpha1.CNI,
+) (v1alpha1.GatewayAPI, error) {
+	return d.detectGatewayAPI(ctx, cni)
+}
+
+// ExportDetectSealedSecrets exposes detectSealedSecrets for testing.
+func (d *ComponentDetector) ExportDetectSealedSecrets(
+	ctx context.Context,
+) (v1alpha1.SealedSecrets, error) {
+	return d.detectSealedSecrets(ctx)
+}
+
+// ExportDetectExternalSecrets exposes detectExternalSecrets for testing.
+func (d *ComponentDetector) ExportDetectExternalSecrets(
+	ctx context.Context,
+) (v1alpha1.ExternalSecrets, error) {
+	return d.detectExternalSecrets(ctx)
+}
+
+// ExportDetectBackup exposes detectBackup for testing.
+func (d *ComponentDetector) ExportDetectBackup(ctx context.Context) (v1alpha1.Backup, error) {
+	return d.detectBackup(ctx)
+}
+
+// ExportDetectLogging exposes detectLogging for testing.
+func (d *ComponentDetector) ExportDetectLogging(ctx context.Context) (v1alpha1.Logging, error) {
+	return d.detectLogging(ctx)
+}
+
 // ExportDetectGitOpsEngine exposes detectGitOpsEngine for testing.
 func (d *ComponentDetector) ExportDetectGitOpsEngine(
 	ctx context.Context,
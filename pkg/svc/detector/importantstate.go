@@ -0,0 +1,131 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ImportantState is the cluster state a delete or recreate destroys that KSail cannot rebuild
+// from ksail.yaml: volumes holding data, load balancers other systems may point at, and
+// namespaces KSail did not create. Every entry is a human-readable line for a warning.
+type ImportantState struct {
+	// PersistentVolumeClaims lists bound claims as "namespace/name (capacity)".
+	PersistentVolumeClaims []string
+	// LoadBalancers lists LoadBalancer Services as "namespace/name" with their ingress address.
+	LoadBalancers []string
+	// Namespaces lists namespaces that are neither built in nor created by a KSail component.
+	Namespaces []string
+}
+
+// Empty reports whether no important state was found.
+func (s ImportantState) Empty() bool {
+	return len(s.PersistentVolumeClaims) == 0 && len(s.LoadBalancers) == 0 && len(s.Namespaces) == 0
+}
+
+// builtinNamespaces exist in every cluster. They are never reported as namespaces, but claims
+// and load balancers in them (notably "default") still are.
+//
+//nolint:gochecknoglobals // immutable lookup table
+var builtinNamespaces = []string{"default", "kube-node-lease", "kube-public", NamespaceKubeSystem}
+
+// componentNamespaces are the namespaces KSail installs components into. Their contents are
+// rebuilt from ksail.yaml on create, so nothing in them is reported.
+//
+//nolint:gochecknoglobals // immutable lookup table
+var componentNamespaces = []string{
+	NamespaceKubeSystem, NamespaceCalico, "calico-system", "calico-apiserver",
+	NamespaceCertManager, NamespaceExternalSecrets, NamespaceVelero, NamespaceKyverno,
+	NamespaceGatekeeper, NamespaceIngressNginx, NamespaceTraefik, NamespaceContour,
+	NamespaceEnvoyGateway, NamespaceIstio, NamespaceLinkerd, NamespaceKnativeOperator,
+	NamespaceKeycloak, NamespaceLogging, NamespaceFluxOperator, NamespaceArgoCD,
+	NamespaceLocalPathStorage, NamespaceMetalLB,
+}
+
+// DetectImportantState lists the bound PersistentVolumeClaims, LoadBalancer Services, and
+// non-KSail namespaces in the cluster. Claims and load balancers inside KSail component
+// namespaces are left out because recreating the cluster restores them.
+func DetectImportantState(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+) (ImportantState, error) {
+	var state ImportantState
+
+	claims, err := clientset.CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return state, fmt.Errorf("list persistent volume claims: %w", err)
+	}
+
+	for _, claim := range claims.Items {
+		if claim.Status.Phase != corev1.ClaimBound || isComponentNamespace(claim.Namespace) {
+			continue
+		}
+
+		entry := claim.Namespace + "/" + claim.Name
+		if capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]; ok {
+			entry += " (" + capacity.String() + ")"
+		}
+
+		state.PersistentVolumeClaims = append(state.PersistentVolumeClaims, entry)
+	}
+
+	services, err := clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return state, fmt.Errorf("list services: %w", err)
+	}
+
+	for _, service := range services.Items {
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer ||
+			isComponentNamespace(service.Namespace) {
+			continue
+		}
+
+		state.LoadBalancers = append(
+			state.LoadBalancers, service.Namespace+"/"+service.Name+loadBalancerAddress(service),
+		)
+	}
+
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return state, fmt.Errorf("list namespaces: %w", err)
+	}
+
+	for _, namespace := range namespaces.Items {
+		if slices.Contains(builtinNamespaces, namespace.Name) || isComponentNamespace(namespace.Name) {
+			continue
+		}
+
+		state.Namespaces = append(state.Namespaces, namespace.Name)
+	}
+
+	slices.Sort(state.PersistentVolumeClaims)
+	slices.Sort(state.LoadBalancers)
+	slices.Sort(state.Namespaces)
+
+	return state, nil
+}
+
+// isComponentNamespace reports whether namespace belongs to a KSail-installed component.
+func isComponentNamespace(namespace string) bool {
+	return slices.Contains(componentNamespaces, namespace)
+}
+
+// loadBalancerAddress returns the first ingress IP or hostname of service as " (address)", or
+// an empty string while none is assigned.
+func loadBalancerAddress(service corev1.Service) string {
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return " (" + ingress.IP + ")"
+		}
+
+		if ingress.Hostname != "" {
+			return " (" + ingress.Hostname + ")"
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,111 @@
+package detector_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func boundClaim(namespace, name, capacity string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(capacity),
+			},
+		},
+	}
+}
+
+func loadBalancer(namespace, name, address string) *corev1.Service {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}
+
+	if address != "" {
+		service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: address}}
+	}
+
+	return service
+}
+
+func TestDetectImportantState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    detector.ImportantState
+	}{
+		{
+			name: "fresh cluster holds nothing important",
+			objects: []runtime.Object{
+				namespace("default"),
+				namespace("kube-system"),
+				namespace("kube-public"),
+				namespace("kube-node-lease"),
+				namespace("flux-system"),
+				namespace("local-path-storage"),
+				loadBalancer("traefik", "traefik", "172.18.0.2"),
+				boundClaim("logging", "loki-0", "10Gi"),
+			},
+		},
+		{
+			name: "user state is reported",
+			objects: []runtime.Object{
+				namespace("default"),
+				namespace("shop"),
+				namespace("monitoring"),
+				boundClaim("shop", "postgres-0", "20Gi"),
+				boundClaim("default", "scratch", "1Gi"),
+				loadBalancer("shop", "storefront", "203.0.113.10"),
+				loadBalancer("shop", "pending", ""),
+			},
+			want: detector.ImportantState{
+				PersistentVolumeClaims: []string{"default/scratch (1Gi)", "shop/postgres-0 (20Gi)"},
+				LoadBalancers:          []string{"shop/pending", "shop/storefront (203.0.113.10)"},
+				Namespaces:             []string{"monitoring", "shop"},
+			},
+		},
+		{
+			name: "unbound claims and cluster IP services are ignored",
+			objects: []runtime.Object{
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+					Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			state, err := detector.DetectImportantState(
+				t.Context(), fake.NewClientset(test.objects...),
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, state)
+			assert.Equal(t, test.want.Empty(), state.Empty())
+		})
+	}
+}
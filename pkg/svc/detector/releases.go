@@ -27,6 +27,19 @@ const (
 	// NamespaceCertManager is the namespace where cert-manager is installed.
 	NamespaceCertManager = "cert-manager"
 
+	// ReleaseSealedSecrets is the Helm release name for the Sealed Secrets controller.
+	ReleaseSealedSecrets = "sealed-secrets-controller"
+	// NamespaceSealedSecrets is the namespace where the Sealed Secrets controller is installed.
+	NamespaceSealedSecrets = "kube-system"
+	// ReleaseExternalSecrets is the Helm release name for the External Secrets Operator.
+	ReleaseExternalSecrets = "external-secrets"
+	// NamespaceExternalSecrets is the namespace where the External Secrets Operator is installed.
+	NamespaceExternalSecrets = "external-secrets"
+	// ReleaseVelero is the Helm release name for Velero.
+	ReleaseVelero = "velero"
+	// NamespaceVelero is the namespace where Velero is installed.
+	NamespaceVelero = "velero"
+
 	// ReleaseKyverno is the Helm release name for the Kyverno policy engine.
 	ReleaseKyverno = "kyverno"
 	// NamespaceKyverno is the namespace where Kyverno is installed.
@@ -36,6 +49,53 @@ const (
 	// NamespaceGatekeeper is the namespace where Gatekeeper is installed.
 	NamespaceGatekeeper = "gatekeeper-system"
 
+	// ReleaseIngressNginx is the Helm release name for the ingress-nginx controller.
+	ReleaseIngressNginx = "ingress-nginx"
+	// NamespaceIngressNginx is the namespace where ingress-nginx is installed.
+	NamespaceIngressNginx = "ingress-nginx"
+	// ReleaseTraefik is the Helm release name for the Traefik ingress controller.
+	// K3s' bundled Traefik lives in kube-system and is not matched.
+	ReleaseTraefik = "traefik"
+	// NamespaceTraefik is the namespace where KSail installs Traefik.
+	NamespaceTraefik = "traefik"
+	// ReleaseContour is the Helm release name for the Contour ingress controller.
+	ReleaseContour = "contour"
+	// NamespaceContour is the namespace where Contour is installed.
+	NamespaceContour = "projectcontour"
+
+	// ReleaseEnvoyGateway is the Helm release name for Envoy Gateway.
+	ReleaseEnvoyGateway = "eg"
+	// NamespaceEnvoyGateway is the namespace where Envoy Gateway is installed.
+	NamespaceEnvoyGateway = "envoy-gateway-system"
+	// GatewayAPIGroupVersion is the group version served once the Gateway API
+	// CRDs are installed.
+	GatewayAPIGroupVersion = "gateway.networking.k8s.io/v1"
+
+	// ReleaseIstiod is the Helm release name for the Istio control plane.
+	ReleaseIstiod = "istiod"
+	// ReleaseZtunnel is the Helm release name for Istio's ambient node proxy.
+	ReleaseZtunnel = "ztunnel"
+	// NamespaceIstio is the namespace where Istio is installed.
+	NamespaceIstio = "istio-system"
+	// ReleaseLinkerdControlPlane is the Helm release name for the Linkerd control plane.
+	ReleaseLinkerdControlPlane = "linkerd-control-plane"
+	// NamespaceLinkerd is the namespace where Linkerd is installed.
+	NamespaceLinkerd = "linkerd"
+	// ReleaseKnativeOperator is the Helm release name for the Knative Operator.
+	ReleaseKnativeOperator = "knative-operator"
+	// NamespaceKnativeOperator is the namespace where the Knative Operator is installed.
+	NamespaceKnativeOperator = "knative-operator"
+	// DeploymentKeycloak is the Deployment name of the development Keycloak, which
+	// KSail applies as plain manifests rather than a Helm release.
+	DeploymentKeycloak = "keycloak"
+	// NamespaceKeycloak is the namespace where the development Keycloak is installed.
+	NamespaceKeycloak = "keycloak"
+
+	// ReleaseLoki is the Helm release name for Loki.
+	ReleaseLoki = "loki"
+	// NamespaceLogging is the namespace where Loki and Promtail are installed.
+	NamespaceLogging = "logging"
+
 	// ReleaseFluxOperator is the Helm release name for the Flux operator.
 	ReleaseFluxOperator = "flux-operator"
 	// NamespaceFluxOperator is the namespace where the Flux operator is installed.
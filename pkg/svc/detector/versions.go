@@ -0,0 +1,109 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+)
+
+// ComponentVersion is the installed version of one KSail-managed component, read
+// from its Helm release.
+type ComponentVersion struct {
+	// Component is the display name of the component (e.g. "Cilium", "Flux").
+	Component string `json:"component"`
+	// Release and Namespace identify the Helm release.
+	Release   string `json:"release"`
+	Namespace string `json:"namespace"`
+	// Chart is the chart name and version (e.g. "cilium-1.16.3").
+	Chart string `json:"chart"`
+	// AppVersion is the version of the application the chart deploys.
+	AppVersion string `json:"appVersion,omitempty"`
+	// Status is the Helm release status (e.g. "deployed", "failed").
+	Status string `json:"status"`
+}
+
+// Deployed reports whether the release is in the deployed state.
+func (v ComponentVersion) Deployed() bool {
+	return strings.EqualFold(v.Status, "deployed")
+}
+
+// componentRelease maps a KSail-managed Helm release to its component name.
+type componentRelease struct {
+	component string
+	release   string
+	namespace string
+}
+
+// componentReleases lists the Helm releases KSail installs, in display order.
+func componentReleases() []componentRelease {
+	return []componentRelease{
+		{"Cilium", ReleaseCilium, NamespaceCilium},
+		{"Calico", ReleaseCalico, NamespaceCalico},
+		{"Hetzner CSI", ReleaseHCloudCSI, NamespaceHCloudCSI},
+		{"metrics-server", ReleaseMetricsServer, NamespaceMetricsServer},
+		{"MetalLB", ReleaseMetalLB, NamespaceMetalLB},
+		{"cert-manager", ReleaseCertManager, NamespaceCertManager},
+		{"Sealed Secrets", ReleaseSealedSecrets, NamespaceSealedSecrets},
+		{"External Secrets", ReleaseExternalSecrets, NamespaceExternalSecrets},
+		{"Velero", ReleaseVelero, NamespaceVelero},
+		{"Kyverno", ReleaseKyverno, NamespaceKyverno},
+		{"Gatekeeper", ReleaseGatekeeper, NamespaceGatekeeper},
+		{"ingress-nginx", ReleaseIngressNginx, NamespaceIngressNginx},
+		{"Traefik", ReleaseTraefik, NamespaceTraefik},
+		{"Contour", ReleaseContour, NamespaceContour},
+		{"Envoy Gateway", ReleaseEnvoyGateway, NamespaceEnvoyGateway},
+		{"Istio", ReleaseIstiod, NamespaceIstio},
+		{"Linkerd", ReleaseLinkerdControlPlane, NamespaceLinkerd},
+		{"Knative", ReleaseKnativeOperator, NamespaceKnativeOperator},
+		{"Loki", ReleaseLoki, NamespaceLogging},
+		{"Cluster Autoscaler", ReleaseClusterAutoscaler, NamespaceClusterAutoscaler},
+		{"AWS Load Balancer Controller", ReleaseAWSLoadBalancerController, NamespaceAWSLoadBalancerController},
+		{"Flux", ReleaseFluxOperator, NamespaceFluxOperator},
+		{"Argo CD", ReleaseArgoCD, NamespaceArgoCD},
+	}
+}
+
+// DetectComponentVersions lists the installed versions of the KSail-managed
+// components in the cluster, in a stable display order. Releases KSail does not
+// manage are ignored; for a release with several revisions the latest wins.
+func DetectComponentVersions(
+	ctx context.Context,
+	helmClient helm.Interface,
+) ([]ComponentVersion, error) {
+	releases, err := helmClient.ListReleases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list helm releases: %w", err)
+	}
+
+	latest := make(releaseSet, len(releases))
+	for _, r := range releases {
+		key := releaseKey{name: r.Name, namespace: r.Namespace}
+
+		current, exists := latest[key]
+		if !exists || r.Revision >= current.Revision {
+			latest[key] = r
+		}
+	}
+
+	versions := []ComponentVersion{}
+
+	for _, known := range componentReleases() {
+		release, ok := latest[releaseKey{name: known.release, namespace: known.namespace}]
+		if !ok {
+			continue
+		}
+
+		versions = append(versions, ComponentVersion{
+			Component:  known.component,
+			Release:    release.Name,
+			Namespace:  release.Namespace,
+			Chart:      release.Chart,
+			AppVersion: release.AppVersion,
+			Status:     release.Status,
+		})
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,76 @@
+package detector_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectComponentVersions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+
+	helmClient.On("ListReleases", ctx).Return([]helm.ReleaseInfo{
+		{
+			Name: detector.ReleaseFluxOperator, Namespace: detector.NamespaceFluxOperator,
+			Revision: 1, Status: "deployed", Chart: "flux-operator-0.9.0", AppVersion: "v0.9.0",
+		},
+		{
+			Name: detector.ReleaseCilium, Namespace: detector.NamespaceCilium,
+			Revision: 1, Status: "superseded", Chart: "cilium-1.15.0", AppVersion: "1.15.0",
+		},
+		{
+			Name: detector.ReleaseCilium, Namespace: detector.NamespaceCilium,
+			Revision: 2, Status: "deployed", Chart: "cilium-1.16.3", AppVersion: "1.16.3",
+		},
+		{Name: "my-app", Namespace: "default", Revision: 1, Status: "deployed", Chart: "my-app-1.0.0"},
+	}, nil).Once()
+
+	versions, err := detector.DetectComponentVersions(ctx, helmClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, []detector.ComponentVersion{
+		{
+			Component: "Cilium", Release: detector.ReleaseCilium, Namespace: detector.NamespaceCilium,
+			Chart: "cilium-1.16.3", AppVersion: "1.16.3", Status: "deployed",
+		},
+		{
+			Component: "Flux", Release: detector.ReleaseFluxOperator, Namespace: detector.NamespaceFluxOperator,
+			Chart: "flux-operator-0.9.0", AppVersion: "v0.9.0", Status: "deployed",
+		},
+	}, versions)
+	assert.True(t, versions[0].Deployed())
+}
+
+func TestDetectComponentVersions_NoReleases(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+
+	helmClient.On("ListReleases", ctx).Return([]helm.ReleaseInfo{}, nil).Once()
+
+	versions, err := detector.DetectComponentVersions(ctx, helmClient)
+
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestDetectComponentVersions_ListError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+
+	helmClient.On("ListReleases", ctx).Return(nil, errHelm).Once()
+
+	_, err := detector.DetectComponentVersions(ctx, helmClient)
+
+	require.ErrorIs(t, err, errHelm)
+}
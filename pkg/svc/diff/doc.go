@@ -3,5 +3,7 @@
 // into in-place, reboot-required, and recreate-required categories.
 //
 // It also provides helpers for merging provisioner-specific diffs into a
-// single UpdateResult, deduplicating overlapping field names.
+// single UpdateResult, deduplicating overlapping field names, and for
+// previewing Kubernetes objects: PreviewApply compares a live object with the
+// result of a dry-run server-side apply and renders a unified diff.
 package diff
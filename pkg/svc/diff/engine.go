@@ -15,6 +15,10 @@ import (
 // renames cannot silently disconnect detection from application.
 const EKSLoadBalancerControllerField = "cluster.eks.experimentalAWSLoadBalancerController"
 
+// ArgoCDProjectField is the diff key for spec.workload.argoCD.project drift,
+// shared with reconciliation for the same reason.
+const ArgoCDProjectField = "cluster.workload.argoCD.project"
+
 // Engine computes configuration differences and classifies their impact.
 type Engine struct {
 	distribution v1alpha1.Distribution
@@ -109,6 +113,26 @@ func (e *Engine) CheckFluxDistributionVersion(
 		clusterupdate.ChangeCategoryInPlace)
 }
 
+// CheckArgoCDProject compares the running KSail AppProject's restrictions
+// against those declared in spec.workload.argoCD.project and appends an in-place
+// change when they differ. Only relevant for the ArgoCD engine. Both sides are
+// rendered by the caller into the same summary format ("None" when no
+// restriction is in effect), so the comparison is a plain string match.
+func (e *Engine) CheckArgoCDProject(
+	oldSummary, newSummary string,
+	gitOpsEngine v1alpha1.GitOpsEngine,
+	result *clusterupdate.UpdateResult,
+) {
+	if gitOpsEngine != v1alpha1.GitOpsEngineArgoCD {
+		return
+	}
+
+	appendChange(result, ArgoCDProjectField,
+		oldSummary, newSummary, "",
+		"Argo CD AppProject restrictions can be updated in-place by re-asserting the project",
+		clusterupdate.ChangeCategoryInPlace)
+}
+
 // fieldRule describes how to diff a single scalar field.
 type fieldRule struct {
 	field    string
@@ -263,6 +287,27 @@ func (e *Engine) scalarFieldRules() []fieldRule {
 			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.CertManager.String() },
 			defaultVal: string(v1alpha1.CertManagerDisabled),
 		},
+		{
+			field:      "cluster.sealedSecrets",
+			category:   clusterupdate.ChangeCategoryInPlace,
+			reason:     "the Sealed Secrets controller can be installed/uninstalled via Helm",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.SealedSecrets.String() },
+			defaultVal: string(v1alpha1.SealedSecretsDisabled),
+		},
+		{
+			field:      "cluster.externalSecrets",
+			category:   clusterupdate.ChangeCategoryInPlace,
+			reason:     "the External Secrets Operator can be installed/uninstalled via Helm",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.ExternalSecrets.String() },
+			defaultVal: string(v1alpha1.ExternalSecretsDisabled),
+		},
+		{
+			field:      "cluster.backup",
+			category:   clusterupdate.ChangeCategoryInPlace,
+			reason:     "Velero can be installed/uninstalled via Helm",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.Backup.String() },
+			defaultVal: string(v1alpha1.BackupNone),
+		},
 		{
 			field:      "cluster.policyEngine",
 			category:   clusterupdate.ChangeCategoryInPlace,
@@ -270,6 +315,66 @@ func (e *Engine) scalarFieldRules() []fieldRule {
 			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.PolicyEngine.String() },
 			defaultVal: string(v1alpha1.PolicyEngineNone),
 		},
+		{
+			field:    "cluster.ingressController",
+			category: clusterupdate.ChangeCategoryInPlace,
+			reason: "ingress controller can be switched via Helm install/uninstall; " +
+				"localhost:80/443 host port mappings are only added at cluster creation",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.IngressController.String() },
+			defaultVal: string(v1alpha1.IngressControllerNone),
+		},
+		{
+			field:    "cluster.gatewayAPI",
+			category: clusterupdate.ChangeCategoryInPlace,
+			reason:   "Gateway API CRDs and Envoy Gateway are installed or uninstalled via Helm",
+			// The Cilium CNI always ships the CRDs and its controller, so
+			// compare the effective value: CRDs/Cilium on top of it add nothing.
+			getVal: func(s *v1alpha1.ClusterSpec) string {
+				return string(s.GatewayAPI.EffectiveValue(s.CNI))
+			},
+			defaultVal: string(v1alpha1.GatewayAPINone),
+		},
+		{
+			field:    "cluster.serviceMesh",
+			category: clusterupdate.ChangeCategoryInPlace,
+			reason: "service mesh can be switched via Helm install/uninstall; " +
+				"workloads pick up proxies on restart",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.ServiceMesh.String() },
+			defaultVal: string(v1alpha1.ServiceMeshNone),
+		},
+		{
+			field:    "cluster.istio.mode",
+			category: clusterupdate.ChangeCategoryInPlace,
+			reason:   "Istio can be reinstalled in the other data plane mode via Helm",
+			// The mode only means something while Istio is installed, so both
+			// sides are empty (and skipped) for any other mesh.
+			getVal:           istioModeValue,
+			skipWhenOldEmpty: true,
+			skipWhenNewEmpty: true,
+		},
+		{
+			field:    "cluster.knative",
+			category: clusterupdate.ChangeCategoryInPlace,
+			reason: "Knative Serving can be installed or uninstalled via Helm; " +
+				"the localhost:8080 host port mapping is only added at cluster creation",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.Knative.String() },
+			defaultVal: string(v1alpha1.KnativeDisabled),
+		},
+		{
+			field:    "cluster.identityProvider",
+			category: clusterupdate.ChangeCategoryInPlace,
+			reason: "the identity provider can be installed or uninstalled in place; " +
+				"its host port mapping and the API server OIDC flags are only set at cluster creation",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.IdentityProvider.String() },
+			defaultVal: string(v1alpha1.IdentityProviderNone),
+		},
+		{
+			field:      "cluster.observability.logging",
+			category:   clusterupdate.ChangeCategoryInPlace,
+			reason:     "Loki and Promtail can be installed or uninstalled via Helm",
+			getVal:     func(s *v1alpha1.ClusterSpec) string { return s.Observability.Logging.String() },
+			defaultVal: string(v1alpha1.LoggingNone),
+		},
 		{
 			field:      "cluster.gitOpsEngine",
 			category:   clusterupdate.ChangeCategoryInPlace,
@@ -280,6 +385,18 @@ func (e *Engine) scalarFieldRules() []fieldRule {
 	}
 }
 
+func istioModeValue(spec *v1alpha1.ClusterSpec) string {
+	if spec.ServiceMesh != v1alpha1.ServiceMeshIstio {
+		return ""
+	}
+
+	if spec.Istio.Mode == "" {
+		return string(v1alpha1.IstioModeSidecar)
+	}
+
+	return spec.Istio.Mode.String()
+}
+
 func eksLoadBalancerControllerValue(spec *v1alpha1.ClusterSpec) string {
 	active := spec.EKS.ExperimentalAWSLoadBalancerController &&
 		spec.LoadBalancer == v1alpha1.LoadBalancerEnabled
@@ -542,6 +659,12 @@ func (e *Engine) checkVanillaOptionsChange(
 		oldSpec.Vanilla.MirrorsDir, newSpec.Vanilla.MirrorsDir,
 		"", "Kind containerd mirror config is baked at cluster creation",
 		clusterupdate.ChangeCategoryRecreateRequired)
+
+	// NodeImageDockerfile change requires recreate (nodes boot from the derived image)
+	appendChange(result, "cluster.vanilla.nodeImageDockerfile",
+		oldSpec.Vanilla.NodeImageDockerfile, newSpec.Vanilla.NodeImageDockerfile,
+		"", "Kind nodes boot from the derived node image built at cluster creation",
+		clusterupdate.ChangeCategoryRecreateRequired)
 }
 
 // checkTalosOptionsChange checks Talos-specific option changes.
@@ -635,6 +635,20 @@ func TestEngine_VanillaOptionsChange(t *testing.T) {
 		"kind/mirrors", "other/mirrors", clusterupdate.ChangeCategoryRecreateRequired)
 }
 
+func TestEngine_VanillaNodeImageDockerfileChange(t *testing.T) {
+	t.Parallel()
+
+	old := newBaseSpec()
+	newer := clone(old)
+	newer.Vanilla.NodeImageDockerfile = "RUN apt-get install -y nfs-common"
+
+	engine := diff.NewEngine(v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+	result := engine.ComputeDiff(old, newer, nil, nil)
+
+	assertSingleChange(t, result.RecreateRequired, "cluster.vanilla.nodeImageDockerfile",
+		"", "RUN apt-get install -y nfs-common", clusterupdate.ChangeCategoryRecreateRequired)
+}
+
 func TestEngine_VanillaOptionsChange_SkippedForNonVanilla(t *testing.T) {
 	t.Parallel()
 
@@ -1055,6 +1069,54 @@ func TestEngine_MultipleChanges(t *testing.T) {
 	}
 }
 
+func TestEngine_GatewayAPI_CiliumCNINormalization(t *testing.T) {
+	t.Parallel()
+
+	old := newBaseSpec()
+	old.CNI = v1alpha1.CNICilium
+	old.GatewayAPI = v1alpha1.GatewayAPICilium // detected: CRDs served on a Cilium cluster
+
+	newer := clone(old)
+	newer.GatewayAPI = v1alpha1.GatewayAPINone
+
+	engine := diff.NewEngine(v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+	result := engine.ComputeDiff(old, newer, nil, nil)
+
+	if result.TotalChanges() != 0 {
+		t.Errorf("expected no changes for None vs Cilium on the Cilium CNI, got %d", result.TotalChanges())
+	}
+
+	newer.GatewayAPI = v1alpha1.GatewayAPIEnvoy
+	result = engine.ComputeDiff(old, newer, nil, nil)
+
+	if len(result.InPlaceChanges) != 1 || result.InPlaceChanges[0].Field != "cluster.gatewayAPI" {
+		t.Errorf("expected a single in-place cluster.gatewayAPI change, got %+v", result.InPlaceChanges)
+	}
+}
+
+func TestEngine_IstioMode_OnlyDiffedWhileIstioIsInstalled(t *testing.T) {
+	t.Parallel()
+
+	old := newBaseSpec()
+	newer := clone(old)
+	newer.ServiceMesh = v1alpha1.ServiceMeshIstio
+	newer.Istio.Mode = v1alpha1.IstioModeAmbient
+
+	engine := diff.NewEngine(v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+	result := engine.ComputeDiff(old, newer, nil, nil)
+
+	if len(result.InPlaceChanges) != 1 || result.InPlaceChanges[0].Field != "cluster.serviceMesh" {
+		t.Errorf("expected a single in-place cluster.serviceMesh change, got %+v", result.InPlaceChanges)
+	}
+
+	old.ServiceMesh = v1alpha1.ServiceMeshIstio
+	result = engine.ComputeDiff(old, newer, nil, nil)
+
+	if len(result.InPlaceChanges) != 1 || result.InPlaceChanges[0].Field != "cluster.istio.mode" {
+		t.Errorf("expected a single in-place cluster.istio.mode change, got %+v", result.InPlaceChanges)
+	}
+}
+
 func TestEngine_DefaultVsDisabled_NoFalsePositive_Vanilla(t *testing.T) {
 	t.Parallel()
 
@@ -1385,6 +1447,45 @@ func assertFluxDistributionVersionChange(
 	}
 }
 
+func TestEngine_CheckArgoCDProject(t *testing.T) {
+	t.Parallel()
+
+	engine := diff.NewEngine(v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+	restricted := "sourceRepos=https://github.com/org/*"
+
+	tests := []struct {
+		name         string
+		oldSummary   string
+		newSummary   string
+		gitOpsEngine v1alpha1.GitOpsEngine
+		wantChanges  int
+	}{
+		{"flux not applicable", "None", restricted, v1alpha1.GitOpsEngineFlux, 0},
+		{"unchanged", restricted, restricted, v1alpha1.GitOpsEngineArgoCD, 0},
+		{"restrictions added", "None", restricted, v1alpha1.GitOpsEngineArgoCD, 1},
+		{"restrictions removed", restricted, "None", v1alpha1.GitOpsEngineArgoCD, 1},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := &clusterupdate.UpdateResult{}
+			engine.CheckArgoCDProject(
+				testCase.oldSummary, testCase.newSummary, testCase.gitOpsEngine, result,
+			)
+
+			if got := len(result.InPlaceChanges); got != testCase.wantChanges {
+				t.Fatalf("want %d in-place changes, got %d", testCase.wantChanges, got)
+			}
+
+			if testCase.wantChanges > 0 && result.InPlaceChanges[0].Field != diff.ArgoCDProjectField {
+				t.Errorf("expected field %s, got %s", diff.ArgoCDProjectField, result.InPlaceChanges[0].Field)
+			}
+		})
+	}
+}
+
 func TestEngine_TalosBaselineNodeCountDetected_WhenAutoscalingEnabled(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,160 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// objectDiffContextLines is the number of unchanged lines shown around each
+// hunk of an object diff.
+const objectDiffContextLines = 3
+
+// ObjectAction classifies what applying a manifest does to its live object.
+type ObjectAction string
+
+const (
+	// ObjectCreate means the object does not exist yet and would be created.
+	ObjectCreate ObjectAction = "create"
+	// ObjectUpdate means the live object exists and would change.
+	ObjectUpdate ObjectAction = "update"
+	// ObjectUnchanged means applying the manifest would not change the live object.
+	ObjectUnchanged ObjectAction = "unchanged"
+)
+
+// ignoredObjectMetadata lists server-managed metadata fields stripped from both
+// sides of an object diff, since they change on every write and would
+// otherwise drown out the user's changes.
+//
+//nolint:gochecknoglobals // read-only lookup table.
+var ignoredObjectMetadata = []string{
+	"managedFields",
+	"resourceVersion",
+	"generation",
+	"uid",
+	"creationTimestamp",
+}
+
+// ObjectDiff is the previewed change of applying a single Kubernetes object.
+type ObjectDiff struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Action    ObjectAction
+	// Diff is the unified diff from the live object to the merged object. It
+	// is empty when Action is ObjectUnchanged.
+	Diff string
+}
+
+// Label returns the Kind/Namespace/Name path identifying the object. The
+// namespace segment is omitted for cluster-scoped objects.
+func (d ObjectDiff) Label() string {
+	return path.Join(d.Kind, d.Namespace, d.Name)
+}
+
+// CompareObjects diffs live (nil when the object does not exist yet) against
+// merged, the object as it would be stored after the apply. Status and
+// server-managed metadata are ignored on both sides.
+func CompareObjects(live, merged *unstructured.Unstructured) (ObjectDiff, error) {
+	result := ObjectDiff{
+		Kind:      merged.GetKind(),
+		Name:      merged.GetName(),
+		Namespace: merged.GetNamespace(),
+	}
+
+	before, err := NormalizedObjectYAML(live)
+	if err != nil {
+		return ObjectDiff{}, err
+	}
+
+	after, err := NormalizedObjectYAML(merged)
+	if err != nil {
+		return ObjectDiff{}, err
+	}
+
+	switch {
+	case live == nil:
+		result.Action = ObjectCreate
+	case before == after:
+		result.Action = ObjectUnchanged
+
+		return result, nil
+	default:
+		result.Action = ObjectUpdate
+	}
+
+	label := result.Label()
+
+	result.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: "live/" + label,
+		ToFile:   "merged/" + label,
+		Context:  objectDiffContextLines,
+	})
+	if err != nil {
+		return ObjectDiff{}, fmt.Errorf("render diff for %s: %w", label, err)
+	}
+
+	return result, nil
+}
+
+// PreviewApply compares the live object with the result of a dry-run
+// server-side apply of obj through resource, so defaulting, admission, and
+// field ownership are reflected exactly as a real apply would. Nothing is
+// persisted.
+func PreviewApply(
+	ctx context.Context,
+	resource dynamic.ResourceInterface,
+	obj *unstructured.Unstructured,
+	fieldManager string,
+) (ObjectDiff, error) {
+	live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+
+	switch {
+	case apierrors.IsNotFound(err):
+		live = nil
+	case err != nil:
+		return ObjectDiff{}, fmt.Errorf("get live object: %w", err)
+	}
+
+	merged, err := resource.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+		DryRun:       []string{metav1.DryRunAll},
+	})
+	if err != nil {
+		return ObjectDiff{}, err //nolint:wrapcheck // the API server message is the diff error.
+	}
+
+	return CompareObjects(live, merged)
+}
+
+// NormalizedObjectYAML marshals obj to YAML without its status and
+// server-managed metadata. A nil object renders as the empty string.
+func NormalizedObjectYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	normalized := obj.DeepCopy()
+	unstructured.RemoveNestedField(normalized.Object, "status")
+
+	for _, field := range ignoredObjectMetadata {
+		unstructured.RemoveNestedField(normalized.Object, "metadata", field)
+	}
+
+	out, err := yaml.Marshal(normalized.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshal %s: %w", obj.GetName(), err)
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,127 @@
+package diff_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newConfigMap(name, value string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName(name)
+	obj.SetNamespace("apps")
+	obj.Object["data"] = map[string]any{"key": value}
+
+	return obj
+}
+
+func TestCompareObjects(t *testing.T) {
+	t.Parallel()
+
+	live := newConfigMap("settings", "old")
+	live.SetResourceVersion("42")
+	live.SetUID("abc")
+	live.Object["status"] = map[string]any{"phase": "Ready"}
+
+	tests := []struct {
+		name       string
+		live       *unstructured.Unstructured
+		merged     *unstructured.Unstructured
+		wantAction diff.ObjectAction
+		wantLines  []string
+	}{
+		{
+			name:       "create",
+			merged:     newConfigMap("settings", "new"),
+			wantAction: diff.ObjectCreate,
+			wantLines:  []string{"+++ merged/ConfigMap/apps/settings", "+  key: new"},
+		},
+		{
+			name:       "update",
+			live:       live,
+			merged:     newConfigMap("settings", "new"),
+			wantAction: diff.ObjectUpdate,
+			wantLines:  []string{"--- live/ConfigMap/apps/settings", "-  key: old", "+  key: new"},
+		},
+		{
+			name:       "unchanged ignores status and server metadata",
+			live:       live,
+			merged:     newConfigMap("settings", "old"),
+			wantAction: diff.ObjectUnchanged,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := diff.CompareObjects(testCase.live, testCase.merged)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantAction, result.Action)
+			assert.Equal(t, "ConfigMap/apps/settings", result.Label())
+
+			if len(testCase.wantLines) == 0 {
+				assert.Empty(t, result.Diff)
+			}
+
+			for _, line := range testCase.wantLines {
+				assert.Contains(t, result.Diff, line)
+			}
+
+			assert.NotContains(t, result.Diff, "resourceVersion")
+		})
+	}
+}
+
+func TestObjectDiffLabel_ClusterScoped(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Namespace/apps", diff.ObjectDiff{Kind: "Namespace", Name: "apps"}.Label())
+}
+
+func TestPreviewApply_DiffsDryRunAgainstLive(t *testing.T) {
+	t.Parallel()
+
+	client := dynamicfake.NewSimpleDynamicClient(clientgoscheme.Scheme, newConfigMap("settings", "old"))
+
+	var dryRun []string
+
+	// The fake dynamic client does not implement server-side apply, so echo
+	// the applied object back as the API server would for a plain ConfigMap.
+	client.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch, _ := action.(k8stesting.PatchActionImpl)
+		dryRun = patch.PatchOptions.DryRun
+
+		obj := &unstructured.Unstructured{}
+
+		err := json.Unmarshal(patch.GetPatch(), &obj.Object)
+		if err != nil {
+			return true, nil, err
+		}
+
+		return true, obj, nil
+	})
+
+	resource := client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace("apps")
+
+	result, err := diff.PreviewApply(context.Background(), resource, newConfigMap("settings", "new"), "test")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"All"}, dryRun)
+	assert.Equal(t, diff.ObjectUpdate, result.Action)
+	assert.Contains(t, result.Diff, "+  key: new")
+}
@@ -0,0 +1,55 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// OverlaysDir is the sub-directory under a GitOps source directory that holds
+// the per-environment kustomize overlays of the overlays project layout
+// (<sourceDir>/overlays/<env>/). Each overlay patches the shared base at
+// <sourceDir>/base/ (see [BaseEnvName]).
+//
+// Unlike the multi-cluster layout, where every environment is a separate
+// cluster config (ksail.<env>.yaml) syncing its own clusters/<env>/ overlay,
+// the overlays layout keeps one project whose workload commands pick the
+// overlay with --env.
+const OverlaysDir = "overlays"
+
+// ErrOverlayNotFound is returned by ResolveOverlayDir when the source directory
+// has no overlay directory for the environment.
+var ErrOverlayNotFound = errors.New("environment overlay not found")
+
+// DefaultOverlayEnvironments returns the environments the overlays project
+// layout scaffolds, in promotion order.
+func DefaultOverlayEnvironments() []string {
+	return []string{"dev", "staging", "production"}
+}
+
+// ResolveOverlayDir returns the overlay directory of envName below sourceDir
+// (<sourceDir>/overlays/<envName>). The name is validated like every other
+// environment name, so it cannot traverse out of the overlays directory, and the
+// directory must exist.
+func ResolveOverlayDir(sourceDir, envName string) (string, error) {
+	if envName == "" {
+		return "", fmt.Errorf("invalid environment name: %w", ErrEmptyEnvironmentName)
+	}
+
+	err := v1alpha1.ValidateClusterName(envName)
+	if err != nil {
+		return "", fmt.Errorf("invalid environment name: %w", err)
+	}
+
+	dir := filepath.Join(sourceDir, OverlaysDir, envName)
+
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%w: %q has no directory %s", ErrOverlayNotFound, envName, dir)
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,31 @@
+package environment_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/environment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveOverlayDir(t *testing.T) {
+	t.Parallel()
+
+	sourceDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceDir, "overlays", "staging"), 0o750))
+
+	dir, err := environment.ResolveOverlayDir(sourceDir, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(sourceDir, "overlays", "staging"), dir)
+
+	_, err = environment.ResolveOverlayDir(sourceDir, "production")
+	require.ErrorIs(t, err, environment.ErrOverlayNotFound)
+
+	_, err = environment.ResolveOverlayDir(sourceDir, "")
+	require.ErrorIs(t, err, environment.ErrEmptyEnvironmentName)
+
+	_, err = environment.ResolveOverlayDir(sourceDir, "../staging")
+	require.Error(t, err, "names cannot traverse out of the overlays directory")
+}
@@ -6,6 +6,10 @@
 // containing un-substituted Flux variables still validates against kubeconform
 // without a live cluster. Schema lookups are disk-cache only (no network) to
 // keep validation fast, deterministic, and offline-friendly.
+//
+// Substitute expands the same references with real values instead, for the
+// workload apply and render commands that resolve variables from ksail.yaml,
+// a vars file, and the environment.
 package fluxsubst
 
 import (
@@ -0,0 +1,140 @@
+package fluxsubst
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// varNamePattern matches a valid substitution variable name.
+var varNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ErrInvalidVarsFile is returned when a vars file line is not a KEY=VALUE pair
+// with a valid variable name.
+var ErrInvalidVarsFile = errors.New("invalid vars file")
+
+// Substitute expands Flux postBuild variable references (${VAR},
+// ${VAR:-default}, ${VAR:=default}) in data with real values, as Flux does when
+// it applies a Kustomization with postBuild.substitute.
+//
+// A reference resolves to lookup's value when the variable is defined, and to
+// its default otherwise. References with neither are left untouched so they stay
+// visible instead of silently becoming empty strings. As in Flux, "$${VAR}"
+// escapes a reference and is emitted as the literal "${VAR}".
+func Substitute(data []byte, lookup func(string) (string, bool)) []byte {
+	matches := fluxVarPattern.FindAllSubmatchIndex(data, -1)
+	if len(matches) == 0 {
+		return data
+	}
+
+	var out bytes.Buffer
+
+	last := 0
+
+	for _, match := range matches {
+		start, end := match[0], match[1]
+
+		if start > 0 && data[start-1] == '$' {
+			// Escaped reference: drop the escaping '$' and keep the literal.
+			out.Write(data[last : start-1])
+			out.Write(data[start:end])
+
+			last = end
+
+			continue
+		}
+
+		out.Write(data[last:start])
+		out.WriteString(resolveVar(data, match, lookup))
+
+		last = end
+	}
+
+	out.Write(data[last:])
+
+	return out.Bytes()
+}
+
+// resolveVar resolves one reference located by the submatch indices of
+// fluxVarPattern (full, name, operator, default).
+func resolveVar(data []byte, match []int, lookup func(string) (string, bool)) string {
+	name := string(data[match[2]:match[3]])
+
+	if value, ok := lookup(name); ok {
+		return value
+	}
+
+	if match[4] >= 0 {
+		return string(data[match[6]:match[7]])
+	}
+
+	return string(data[match[0]:match[1]])
+}
+
+// LoadVarsFile reads substitution variables from a dotenv-style file (see
+// ParseVars).
+func LoadVarsFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-supplied vars file path
+	if err != nil {
+		return nil, fmt.Errorf("read vars file %q: %w", path, err)
+	}
+
+	vars, err := ParseVars(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return vars, nil
+}
+
+// ParseVars parses dotenv-style KEY=VALUE lines. Blank lines and lines starting
+// with '#' are ignored, an optional "export " prefix is accepted, and a value
+// wrapped in matching single or double quotes is unquoted.
+func ParseVars(data []byte) (map[string]string, error) {
+	vars := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+
+		if !found || !varNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("%w: line %d: expected KEY=VALUE", ErrInvalidVarsFile, lineNumber)
+		}
+
+		vars[name] = unquote(strings.TrimSpace(value))
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("scan vars: %w", err)
+	}
+
+	return vars, nil
+}
+
+// unquote strips one pair of matching surrounding single or double quotes.
+func unquote(value string) string {
+	if len(value) >= 2 { //nolint:mnd // opening and closing quote
+		first, last := value[0], value[len(value)-1]
+		if first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
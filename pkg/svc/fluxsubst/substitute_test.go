@@ -0,0 +1,114 @@
+package fluxsubst_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/fluxsubst"
+)
+
+func mapLookup(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+
+		return value, ok
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	t.Parallel()
+
+	lookup := mapLookup(map[string]string{"REGISTRY": "localhost:5000", "EMPTY": ""})
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"defined", "image: ${REGISTRY}/app", "image: localhost:5000/app"},
+		{"defined empty", "value: '${EMPTY}'", "value: ''"},
+		{"default", "replicas: ${REPLICAS:-2}", "replicas: 2"},
+		{"assign default", "replicas: ${REPLICAS:=3}", "replicas: 3"},
+		{"defined wins over default", "image: ${REGISTRY:-ghcr.io}", "image: localhost:5000"},
+		{"unresolved kept", "host: ${HOST}", "host: ${HOST}"},
+		{"escaped", "script: echo $${REGISTRY}", "script: echo ${REGISTRY}"},
+		{"multiple", "${REGISTRY}-${REGISTRY}", "localhost:5000-localhost:5000"},
+		{"no references", "kind: ConfigMap", "kind: ConfigMap"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := string(fluxsubst.Substitute([]byte(test.input), lookup))
+			if got != test.want {
+				t.Fatalf("Substitute(%q) = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseVars(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("# registry\nREGISTRY=localhost:5000\n\nexport DOMAIN = \"dev.local\"\nGREETING='hello world'\n")
+
+	vars, err := fluxsubst.ParseVars(data)
+	if err != nil {
+		t.Fatalf("ParseVars: %v", err)
+	}
+
+	want := map[string]string{
+		"REGISTRY": "localhost:5000",
+		"DOMAIN":   "dev.local",
+		"GREETING": "hello world",
+	}
+
+	if len(vars) != len(want) {
+		t.Fatalf("got %v, want %v", vars, want)
+	}
+
+	for key, value := range want {
+		if vars[key] != value {
+			t.Fatalf("%s = %q, want %q", key, vars[key], value)
+		}
+	}
+}
+
+func TestParseVars_InvalidLine(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"REGISTRY", "1ABC=value", "=value"} {
+		_, err := fluxsubst.ParseVars([]byte(input))
+		if !errors.Is(err, fluxsubst.ErrInvalidVarsFile) {
+			t.Fatalf("ParseVars(%q) error = %v, want ErrInvalidVarsFile", input, err)
+		}
+	}
+}
+
+func TestLoadVarsFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "dev.env")
+
+	err := os.WriteFile(path, []byte("REGISTRY=localhost:5000\n"), 0o600)
+	if err != nil {
+		t.Fatalf("write vars file: %v", err)
+	}
+
+	vars, err := fluxsubst.LoadVarsFile(path)
+	if err != nil {
+		t.Fatalf("LoadVarsFile: %v", err)
+	}
+
+	if vars["REGISTRY"] != "localhost:5000" {
+		t.Fatalf("REGISTRY = %q, want localhost:5000", vars["REGISTRY"])
+	}
+
+	_, err = fluxsubst.LoadVarsFile(filepath.Join(t.TempDir(), "missing.env"))
+	if err == nil {
+		t.Fatal("expected an error for a missing vars file")
+	}
+}
@@ -0,0 +1,316 @@
+package celrules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"sigs.k8s.io/yaml"
+)
+
+// kyvernoAPIGroupPrefix identifies Kyverno policy documents by apiVersion.
+const kyvernoAPIGroupPrefix = "kyverno.io/"
+
+// kyvernoFailureActionEnforce is the Kyverno failure action that blocks
+// admission; every other action (Audit) only reports.
+const kyvernoFailureActionEnforce = "Enforce"
+
+// kyvernoAutogenAnnotation lets a policy opt out of (or narrow) Kyverno's
+// automatic rule generation for Pod controllers.
+const kyvernoAutogenAnnotation = "pod-policies.kyverno.io/autogen-controllers"
+
+// podControllerKinds are the controllers whose pod template lives at
+// spec.template.spec, mirroring Kyverno's autogen for Pod-matching rules.
+var podControllerKinds = []string{
+	"DaemonSet", "Deployment", "Job", "ReplicaSet", "ReplicationController", "StatefulSet",
+}
+
+var (
+	errNoPolicies        = errors.New("no Kyverno policies found")
+	errPolicyNameMissing = errors.New("Kyverno policy has no metadata.name")
+)
+
+// kyvernoPolicy is the subset of a Kyverno ClusterPolicy/Policy KSail evaluates.
+type kyvernoPolicy struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		ValidationFailureAction string        `json:"validationFailureAction"`
+		Rules                   []kyvernoRule `json:"rules"`
+	} `json:"spec"`
+}
+
+// kyvernoRule is one rule of a Kyverno policy; only CEL validate rules are used.
+type kyvernoRule struct {
+	Name  string `json:"name"`
+	Match struct {
+		Any       []kyvernoResourceFilter `json:"any"`
+		All       []kyvernoResourceFilter `json:"all"`
+		Resources kyvernoResources        `json:"resources"`
+	} `json:"match"`
+	Validate *struct {
+		FailureAction string `json:"failureAction"`
+		CEL           *struct {
+			Expressions []struct {
+				Expression string `json:"expression"`
+				Message    string `json:"message"`
+			} `json:"expressions"`
+		} `json:"cel"`
+	} `json:"validate"`
+}
+
+// kyvernoResourceFilter wraps the resources block of a match.any/all entry.
+type kyvernoResourceFilter struct {
+	Resources kyvernoResources `json:"resources"`
+}
+
+// kyvernoResources lists the kinds a rule matches.
+type kyvernoResources struct {
+	Kinds []string `json:"kinds"`
+}
+
+// LoadKyvernoPolicies reads the Kyverno ClusterPolicy and Policy documents at
+// path (a file or a directory walked for *.yaml/*.yml) and converts every CEL
+// validate rule (validate.cel.expressions) into a Rule the Engine evaluates
+// offline. Enforce rules become error-severity rules and Audit rules
+// warning-severity ones. Rules matching Pods are also applied to the pod
+// templates of Pod controllers and CronJobs, mirroring Kyverno's autogen.
+//
+// Rules KSail cannot evaluate offline (pattern, deny, or foreach validations,
+// and mutate/generate/verifyImages rules) are skipped; their "policy/rule"
+// names are returned so callers can report them.
+func LoadKyvernoPolicies(path string) ([]Rule, []string, error) {
+	files, err := policyFiles(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		rules   []Rule
+		skipped []string
+	)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file) //nolint:gosec // path is user-supplied by design.
+		if err != nil {
+			return nil, nil, fmt.Errorf("read policy file: %w", err)
+		}
+
+		for _, doc := range fsutil.SplitYAMLDocuments(content) {
+			var policy kyvernoPolicy
+
+			err = yaml.Unmarshal(doc, &policy)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parse policy file %s: %w", file, err)
+			}
+
+			if !isKyvernoPolicy(policy) {
+				continue
+			}
+
+			if policy.Metadata.Name == "" {
+				return nil, nil, fmt.Errorf("%w (in %s)", errPolicyNameMissing, file)
+			}
+
+			converted, unsupported := convertKyvernoPolicy(policy)
+			rules = append(rules, converted...)
+			skipped = append(skipped, unsupported...)
+		}
+	}
+
+	if len(rules) == 0 && len(skipped) == 0 {
+		return nil, nil, fmt.Errorf("%w in %s", errNoPolicies, path)
+	}
+
+	if len(rules) > 0 {
+		err = validateRules(rules)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid Kyverno policies in %s: %w", path, err)
+		}
+	}
+
+	return rules, skipped, nil
+}
+
+// policyFiles returns path itself when it is a file, or every YAML file below
+// it when it is a directory.
+func policyFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("read policies: %w", err)
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		ext := filepath.Ext(file)
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, file)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk policies directory %s: %w", path, err)
+	}
+
+	return files, nil
+}
+
+// isKyvernoPolicy reports whether a decoded document is a Kyverno validation
+// policy (ClusterPolicy or namespaced Policy).
+func isKyvernoPolicy(policy kyvernoPolicy) bool {
+	if !strings.HasPrefix(policy.APIVersion, kyvernoAPIGroupPrefix) {
+		return false
+	}
+
+	return policy.Kind == "ClusterPolicy" || policy.Kind == "Policy"
+}
+
+// convertKyvernoPolicy turns a policy's CEL validate rules into engine rules,
+// returning the names of rules that cannot be evaluated offline.
+func convertKyvernoPolicy(policy kyvernoPolicy) ([]Rule, []string) {
+	var (
+		rules   []Rule
+		skipped []string
+	)
+
+	for _, rule := range policy.Spec.Rules {
+		name := policy.Metadata.Name + "/" + rule.Name
+
+		if rule.Validate == nil || rule.Validate.CEL == nil ||
+			len(rule.Validate.CEL.Expressions) == 0 {
+			skipped = append(skipped, name)
+
+			continue
+		}
+
+		severity := SeverityWarning
+		if strings.EqualFold(failureAction(policy, rule), kyvernoFailureActionEnforce) {
+			severity = SeverityError
+		}
+
+		kinds := matchedKinds(rule)
+		autogen := policy.Metadata.Annotations[kyvernoAutogenAnnotation] != "none"
+
+		for index, expression := range rule.Validate.CEL.Expressions {
+			ruleName := name
+			if len(rule.Validate.CEL.Expressions) > 1 {
+				ruleName = fmt.Sprintf("%s[%d]", name, index)
+			}
+
+			guarded := guardKinds(expression.Expression, kinds, autogen)
+			if policy.Kind == "Policy" && policy.Metadata.Namespace != "" {
+				guarded = fmt.Sprintf(
+					"!has(object.metadata.namespace) || object.metadata.namespace != %q || (%s)",
+					policy.Metadata.Namespace, guarded,
+				)
+			}
+
+			rules = append(rules, Rule{
+				Name:       ruleName,
+				Expression: guarded,
+				Message:    expression.Message,
+				Severity:   severity,
+			})
+		}
+	}
+
+	return rules, skipped
+}
+
+// failureAction returns the rule-level validate.failureAction, falling back to
+// the deprecated policy-level spec.validationFailureAction. Kyverno defaults
+// to Audit when neither is set.
+func failureAction(policy kyvernoPolicy, rule kyvernoRule) string {
+	if rule.Validate.FailureAction != "" {
+		return rule.Validate.FailureAction
+	}
+
+	return policy.Spec.ValidationFailureAction
+}
+
+// matchedKinds collects the kinds a rule matches across match.any, match.all,
+// and the legacy match.resources block. Group/version qualifiers such as
+// "apps/v1/Deployment" are reduced to the bare kind. A nil result or a "*"
+// entry means the rule applies to every kind.
+func matchedKinds(rule kyvernoRule) []string {
+	var kinds []string
+
+	collect := func(resources kyvernoResources) {
+		for _, kind := range resources.Kinds {
+			kinds = append(kinds, kind[strings.LastIndex(kind, "/")+1:])
+		}
+	}
+
+	for _, filter := range rule.Match.Any {
+		collect(filter.Resources)
+	}
+
+	for _, filter := range rule.Match.All {
+		collect(filter.Resources)
+	}
+
+	collect(rule.Match.Resources)
+
+	return kinds
+}
+
+// guardKinds wraps expression so it only applies to the matched kinds and
+// passes for every other document. When the rule matches Pods and autogen is
+// enabled, the expression is additionally rewritten for the pod templates of
+// Pod controllers (object.spec.template.spec) and CronJobs
+// (object.spec.jobTemplate.spec.template.spec), as Kyverno does in-cluster.
+func guardKinds(expression string, kinds []string, autogen bool) string {
+	if len(kinds) == 0 || slices.Contains(kinds, "*") {
+		return expression
+	}
+
+	guards := []string{fmt.Sprintf("object.kind in %s ? (%s)", celStringList(kinds), expression)}
+
+	if autogen && slices.Contains(kinds, "Pod") {
+		guards = append(guards,
+			fmt.Sprintf(
+				"object.kind in %s ? (%s)",
+				celStringList(podControllerKinds),
+				strings.ReplaceAll(expression, "object.spec", "object.spec.template.spec"),
+			),
+			fmt.Sprintf(
+				"object.kind == 'CronJob' ? (%s)",
+				strings.ReplaceAll(
+					expression, "object.spec", "object.spec.jobTemplate.spec.template.spec",
+				),
+			),
+		)
+	}
+
+	return strings.Join(guards, " : ") + " : true"
+}
+
+// celStringList renders values as a CEL list literal of single-quoted strings.
+func celStringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "'"+value+"'")
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
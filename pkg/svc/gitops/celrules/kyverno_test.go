@@ -0,0 +1,136 @@
+package celrules_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/gitops/celrules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hostNetworkPolicy is a Kyverno ClusterPolicy with one Enforce CEL rule on
+// Pods and one pattern rule KSail cannot evaluate offline.
+const hostNetworkPolicy = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-host-network
+spec:
+  rules:
+    - name: host-network
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      validate:
+        failureAction: Enforce
+        cel:
+          expressions:
+            - expression: "!has(object.spec.hostNetwork) || object.spec.hostNetwork == false"
+              message: hostNetwork is disallowed
+    - name: pattern-rule
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+      validate:
+        pattern:
+          spec:
+            hostPID: "false"
+`
+
+// writePolicy writes content to a policy file in a fresh directory and returns
+// the directory.
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(content), 0o600))
+
+	return dir
+}
+
+func TestLoadKyvernoPolicies_ConvertsCELRules(t *testing.T) {
+	t.Parallel()
+
+	rules, skipped, err := celrules.LoadKyvernoPolicies(writePolicy(t, hostNetworkPolicy))
+	require.NoError(t, err)
+
+	require.Len(t, rules, 1)
+	assert.Equal(t, "disallow-host-network/host-network", rules[0].Name)
+	assert.Equal(t, celrules.SeverityError, rules[0].Severity)
+	assert.Equal(t, "hostNetwork is disallowed", rules[0].Message)
+	assert.Equal(t, []string{"disallow-host-network/pattern-rule"}, skipped)
+}
+
+func TestLoadKyvernoPolicies_AutogenAppliesToPodTemplates(t *testing.T) {
+	t.Parallel()
+
+	rules, _, err := celrules.LoadKyvernoPolicies(writePolicy(t, hostNetworkPolicy))
+	require.NoError(t, err)
+
+	engine, err := celrules.NewEngine(rules)
+	require.NoError(t, err)
+
+	hostNetworkPod := map[string]any{"hostNetwork": true}
+
+	pod := map[string]any{"kind": "Pod", "spec": hostNetworkPod}
+	assert.Len(t, engine.Evaluate(pod), 1, "a hostNetwork Pod must violate the rule")
+
+	deploy := map[string]any{
+		"kind": "Deployment",
+		"spec": map[string]any{"template": map[string]any{"spec": hostNetworkPod}},
+	}
+	assert.Len(t, engine.Evaluate(deploy), 1, "the rule must apply to Deployment pod templates")
+
+	cronJob := map[string]any{
+		"kind": "CronJob",
+		"spec": map[string]any{"jobTemplate": map[string]any{"spec": map[string]any{
+			"template": map[string]any{"spec": hostNetworkPod},
+		}}},
+	}
+	assert.Len(t, engine.Evaluate(cronJob), 1, "the rule must apply to CronJob pod templates")
+
+	configMap := map[string]any{"kind": "ConfigMap", "data": map[string]any{}}
+	assert.Empty(t, engine.Evaluate(configMap), "unmatched kinds must pass")
+}
+
+func TestLoadKyvernoPolicies_AuditIsWarning(t *testing.T) {
+	t.Parallel()
+
+	policy := `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: audit-policy
+spec:
+  validationFailureAction: Audit
+  rules:
+    - name: rule
+      match:
+        resources:
+          kinds:
+            - v1/ConfigMap
+      validate:
+        cel:
+          expressions:
+            - expression: "has(object.data)"
+`
+
+	rules, _, err := celrules.LoadKyvernoPolicies(writePolicy(t, policy))
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, celrules.SeverityWarning, rules[0].Severity)
+}
+
+func TestLoadKyvernoPolicies_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := celrules.LoadKyvernoPolicies(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	_, _, err = celrules.LoadKyvernoPolicies(writePolicy(t, "apiVersion: v1\nkind: ConfigMap\n"))
+	require.ErrorContains(t, err, "no Kyverno policies found")
+}
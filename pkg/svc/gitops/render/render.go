@@ -167,6 +167,22 @@ func Expand(ctx context.Context, stream []byte, opts Options) (Result, error) {
 	return result, nil
 }
 
+// Parse splits a manifest stream into Documents in the same deterministic order
+// Expand produces, without rendering any HelmRelease. Callers that skip Helm
+// rendering use it to keep the stable output and per-document identities.
+func Parse(stream []byte) []Document {
+	docs := fsutil.SplitYAMLDocuments(stream)
+	documents := make([]Document, 0, len(docs))
+
+	for _, doc := range docs {
+		documents = append(documents, newDocument(doc, parseObjectMeta(doc), Provenance{Origin: OriginStream}))
+	}
+
+	sortDocuments(documents)
+
+	return documents
+}
+
 // expandHelmRelease renders a single HelmRelease document, appending either its
 // rendered children (on success) or the HelmRelease itself plus a Degradation
 // (on failure) to result.
@@ -247,6 +247,17 @@ metadata:
 	assert.Contains(t, out, "\n---\n")
 }
 
+func TestParseKeepsHelmReleaseAndSorts(t *testing.T) {
+	t.Parallel()
+
+	docs := render.Parse(joinDocs(helmReleaseYAML, configMapYAML))
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "ConfigMap", docs[0].Kind)
+	assert.Equal(t, "HelmRelease", docs[1].Kind)
+	assert.Equal(t, render.OriginStream, docs[1].Provenance.Origin)
+}
+
 func joinDocsString(docs ...string) string {
 	return strings.Join(docs, "\n---\n")
 }
@@ -0,0 +1,251 @@
+package hostdebug
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+	talosclient "github.com/siderolabs/talos/pkg/machinery/client"
+	"golang.org/x/sync/errgroup"
+)
+
+// kernelSource is the console prefix of kernel (dmesg) messages.
+const kernelSource = "kernel"
+
+// talosSystemNamespace is the containerd namespace Talos runs its system services in.
+const talosSystemNamespace = "system"
+
+// ErrConsoleRequiresTalos is returned when the node console is requested for a
+// distribution other than Talos.
+var ErrConsoleRequiresTalos = errors.New(
+	"node console streaming requires a Talos cluster; use 'ksail workload debug --host <node>' " +
+		"for a shell on other distributions",
+)
+
+// ErrNoConsoleSources is returned when both kernel messages and service logs are disabled.
+var ErrNoConsoleSources = errors.New("nothing to stream: enable kernel messages or name at least one service")
+
+// ConsoleOptions carries the resolved inputs for a Talos node console session.
+type ConsoleOptions struct {
+	// Info is the detected cluster (distribution, provider, cluster name).
+	Info *clusterdetector.Info
+	// KubeconfigPath is the resolved kubeconfig path (used for cloud-provider
+	// node IP resolution via the Kubernetes API).
+	KubeconfigPath string
+	// ContextName is the kubeconfig context, if any.
+	ContextName string
+	// NodeName is the target Talos node.
+	NodeName string
+	// Kernel streams the node's kernel ring buffer (dmesg).
+	Kernel bool
+	// Services are the Talos services whose logs are streamed (e.g. kubelet, etcd).
+	Services []string
+	// Follow keeps streaming new output until ctx is cancelled.
+	Follow bool
+	// TailLines limits each service log to its last lines; -1 streams the whole log.
+	TailLines int32
+	// Out receives the service overview and the prefixed log lines.
+	Out io.Writer
+}
+
+// RunConsole streams a Talos node's console over the Talos API: an overview of
+// the node's services, followed by its kernel messages and the logs of the
+// selected services, each line prefixed with its source. Talos has no shell, so
+// this is the node-level counterpart of the docker exec session other
+// distributions get from Run.
+func RunConsole(ctx context.Context, opts ConsoleOptions) error {
+	if opts.Info.Distribution != v1alpha1.DistributionTalos {
+		return fmt.Errorf("%w (got %s)", ErrConsoleRequiresTalos, opts.Info.Distribution)
+	}
+
+	if !opts.Kernel && len(opts.Services) == 0 {
+		return ErrNoConsoleSources
+	}
+
+	nodeEndpoint, err := resolveTalosNodeEndpoint(ctx, Options{
+		Info:           opts.Info,
+		KubeconfigPath: opts.KubeconfigPath,
+		ContextName:    opts.ContextName,
+		NodeName:       opts.NodeName,
+	})
+	if err != nil {
+		return err
+	}
+
+	talosClient, err := newTalosNodeClient(ctx, nodeEndpoint, resolveTalosconfigPath())
+	if err != nil {
+		return err
+	}
+
+	defer talosClient.Close() //nolint:errcheck
+
+	nodeCtx := talosclient.WithNode(ctx, nodeEndpoint)
+
+	services, err := talosClient.ServiceList(nodeCtx)
+	if err != nil {
+		return fmt.Errorf("list services on %s: %w", opts.NodeName, err)
+	}
+
+	err = writeServiceOverview(opts.Out, services)
+	if err != nil {
+		return err
+	}
+
+	return streamConsole(nodeCtx, talosClient, opts)
+}
+
+// writeServiceOverview prints the node's services as a SERVICE/STATE/HEALTH table.
+func writeServiceOverview(writer io.Writer, resp *machine.ServiceListResponse) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0) //nolint:mnd // tabwriter column padding
+
+	_, _ = fmt.Fprintln(table, "SERVICE\tSTATE\tHEALTH")
+
+	for _, message := range resp.GetMessages() {
+		for _, service := range message.GetServices() {
+			_, _ = fmt.Fprintf(table, "%s\t%s\t%s\n",
+				service.GetId(), service.GetState(), serviceHealth(service.GetHealth()))
+		}
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("write service overview: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(writer)
+
+	return nil
+}
+
+// serviceHealth renders a service's health check result.
+func serviceHealth(health *machine.ServiceHealth) string {
+	switch {
+	case health == nil || health.GetUnknown():
+		return "?"
+	case health.GetHealthy():
+		return "OK"
+	default:
+		return "Fail"
+	}
+}
+
+// streamConsole streams every enabled source concurrently and returns when all
+// have ended. A cancelled ctx (Ctrl+C) ends the session without an error.
+func streamConsole(ctx context.Context, talosClient *talosclient.Client, opts ConsoleOptions) error {
+	sources := consoleSources(opts)
+	output := newPrefixWriter(opts.Out, sources)
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	for _, source := range sources {
+		group.Go(func() error {
+			var (
+				stream talosclient.MachineStream
+				err    error
+			)
+
+			if source == kernelSource {
+				stream, err = talosClient.Dmesg(groupCtx, opts.Follow, false)
+			} else {
+				stream, err = talosClient.Logs(
+					groupCtx, talosSystemNamespace, common.ContainerDriver_CONTAINERD,
+					source, opts.Follow, opts.TailLines,
+				)
+			}
+
+			if err != nil {
+				return fmt.Errorf("stream %s: %w", source, err)
+			}
+
+			reader, err := talosclient.ReadStream(stream)
+			if err != nil {
+				return fmt.Errorf("read %s stream: %w", source, err)
+			}
+
+			defer reader.Close() //nolint:errcheck
+
+			return output.Copy(source, reader)
+		})
+	}
+
+	err := group.Wait()
+	if err != nil && ctx.Err() == nil {
+		return err //nolint:wrapcheck // already wrapped with the failing source
+	}
+
+	return nil
+}
+
+// consoleSources lists the enabled sources: kernel first, then the services in
+// the order given, without duplicates.
+func consoleSources(opts ConsoleOptions) []string {
+	var sources []string
+
+	if opts.Kernel {
+		sources = append(sources, kernelSource)
+	}
+
+	for _, service := range opts.Services {
+		if service != "" && !slices.Contains(sources, service) {
+			sources = append(sources, service)
+		}
+	}
+
+	return sources
+}
+
+// prefixWriter interleaves the lines of several sources onto one writer, each
+// prefixed with its source name padded to a common width.
+type prefixWriter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	width  int
+	single bool
+}
+
+// newPrefixWriter returns a prefixWriter for sources writing to out. A single
+// source is written without a prefix.
+func newPrefixWriter(out io.Writer, sources []string) *prefixWriter {
+	width := 0
+	for _, source := range sources {
+		width = max(width, len(source))
+	}
+
+	return &prefixWriter{out: out, width: width, single: len(sources) == 1}
+}
+
+// Copy writes every line read from reader, prefixed with source, until reader
+// is exhausted.
+func (w *prefixWriter) Copy(source string, reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		w.mu.Lock()
+
+		if w.single {
+			_, _ = fmt.Fprintln(w.out, line)
+		} else {
+			_, _ = fmt.Fprintf(w.out, "%-*s | %s\n", w.width, source, line)
+		}
+
+		w.mu.Unlock()
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return fmt.Errorf("read %s output: %w", source, err)
+	}
+
+	return nil
+}
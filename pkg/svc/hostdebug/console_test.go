@@ -0,0 +1,102 @@
+package hostdebug_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/hostdebug"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+)
+
+func TestRunConsoleRequiresTalos(t *testing.T) {
+	t.Parallel()
+
+	err := hostdebug.RunConsole(context.Background(), hostdebug.ConsoleOptions{
+		Info:   &clusterdetector.Info{Distribution: v1alpha1.DistributionVanilla},
+		Kernel: true,
+	})
+	if !errors.Is(err, hostdebug.ErrConsoleRequiresTalos) {
+		t.Fatalf("expected ErrConsoleRequiresTalos, got %v", err)
+	}
+}
+
+func TestRunConsoleRequiresASource(t *testing.T) {
+	t.Parallel()
+
+	err := hostdebug.RunConsole(context.Background(), hostdebug.ConsoleOptions{
+		Info: &clusterdetector.Info{Distribution: v1alpha1.DistributionTalos},
+	})
+	if !errors.Is(err, hostdebug.ErrNoConsoleSources) {
+		t.Fatalf("expected ErrNoConsoleSources, got %v", err)
+	}
+}
+
+func TestWriteServiceOverview(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	err := hostdebug.ExportWriteServiceOverview(&out, &machine.ServiceListResponse{
+		Messages: []*machine.ServiceList{{
+			Services: []*machine.ServiceInfo{
+				{Id: "apid", State: "Running", Health: &machine.ServiceHealth{Healthy: true}},
+				{Id: "kubelet", State: "Running", Health: &machine.ServiceHealth{}},
+				{Id: "etcd", State: "Preparing", Health: &machine.ServiceHealth{Unknown: true}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("write overview: %v", err)
+	}
+
+	want := "SERVICE  STATE      HEALTH\n" +
+		"apid     Running    OK\n" +
+		"kubelet  Running    Fail\n" +
+		"etcd     Preparing  ?\n\n"
+	if out.String() != want {
+		t.Fatalf("unexpected overview:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestPrefixWriterPadsSources(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	err := hostdebug.ExportCopyPrefixed(&out, []string{"kernel", "etcd"}, map[string]io.Reader{
+		"kernel": strings.NewReader("[    0.000000] Linux version 6.12\r\n"),
+		"etcd":   strings.NewReader("ready to serve client requests\n"),
+	})
+	if err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+
+	want := "kernel | [    0.000000] Linux version 6.12\n" +
+		"etcd   | ready to serve client requests\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%q\nwant:\n%q", out.String(), want)
+	}
+}
+
+func TestPrefixWriterSingleSourceHasNoPrefix(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	err := hostdebug.ExportCopyPrefixed(&out, []string{"kubelet"}, map[string]io.Reader{
+		"kubelet": strings.NewReader("a\nb\n"),
+	})
+	if err != nil {
+		t.Fatalf("copy: %v", err)
+	}
+
+	if out.String() != "a\nb\n" {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
@@ -2,10 +2,12 @@ package hostdebug
 
 import (
 	"context"
+	"io"
 
 	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 )
 
 // ExportFindClusterNode exposes the unexported findClusterNode helper so the
@@ -19,3 +21,24 @@ func ExportFindClusterNode(
 ) (provider.NodeInfo, error) {
 	return findClusterNode(ctx, dockerClient, scheme, clusterName, nodeName)
 }
+
+// ExportWriteServiceOverview exposes writeServiceOverview for testing the
+// console's service table.
+func ExportWriteServiceOverview(writer io.Writer, resp *machine.ServiceListResponse) error {
+	return writeServiceOverview(writer, resp)
+}
+
+// ExportCopyPrefixed copies each reader in readers (keyed by source) through a
+// single prefixWriter, one source after the other in sources order.
+func ExportCopyPrefixed(out io.Writer, sources []string, readers map[string]io.Reader) error {
+	writer := newPrefixWriter(out, sources)
+
+	for _, source := range sources {
+		err := writer.Copy(source, readers[source])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -63,6 +63,9 @@ type Options struct {
 	Image string
 	// Args is the command to run inside the debug container/shell.
 	Args []string
+	// Transcript, when non-nil, receives a copy of everything the session
+	// prints to the terminal, for session recording.
+	Transcript io.Writer
 }
 
 // Run detects the cluster distribution and provider from opts.Info and routes to
@@ -86,7 +89,7 @@ func Run(ctx context.Context, opts Options) error {
 			)
 		}
 
-		return runDockerHostDebug(ctx, info, opts.NodeName, opts.Args)
+		return runDockerHostDebug(ctx, info, opts.NodeName, opts.Args, opts.Transcript)
 	case v1alpha1.DistributionEKS, v1alpha1.DistributionGKE, v1alpha1.DistributionAKS:
 		// Host-level debug on managed cloud nodes is not supported via KSail's
 		// host-debug path; users should use the cloud's own access mechanism
@@ -137,17 +140,19 @@ func findClusterNode(
 
 // runTalosHostDebugFromInfo resolves the Talos node endpoint and launches a debug container.
 func runTalosHostDebugFromInfo(ctx context.Context, opts Options) error {
-	talosconfigPath := os.Getenv("TALOSCONFIG")
-	if talosconfigPath == "" {
-		talosconfigPath = "~/.talos/config"
-	}
-
 	nodeEndpoint, err := resolveTalosNodeEndpoint(ctx, opts)
 	if err != nil {
 		return err
 	}
 
-	return runTalosHostDebug(ctx, nodeEndpoint, talosconfigPath, opts.Image, opts.Args)
+	return runTalosHostDebug(
+		ctx,
+		nodeEndpoint,
+		resolveTalosconfigPath(),
+		opts.Image,
+		opts.Args,
+		opts.Transcript,
+	)
 }
 
 // resolveTalosNodeEndpoint resolves a node name to a Talos API endpoint.
@@ -191,6 +196,7 @@ func runDockerHostDebug(
 	info *clusterdetector.Info,
 	nodeName string,
 	execArgs []string,
+	transcript io.Writer,
 ) error {
 	scheme := DistributionToLabelScheme(info.Distribution)
 
@@ -211,7 +217,7 @@ func runDockerHostDebug(
 		shellCmd = []string{"/bin/sh"}
 	}
 
-	return runInteractiveDockerExec(ctx, dockerClient, node.Name, shellCmd)
+	return runInteractiveDockerExec(ctx, dockerClient, node.Name, shellCmd, transcript)
 }
 
 // runInteractiveDockerExec runs an interactive exec session in a Docker container
@@ -221,6 +227,7 @@ func runInteractiveDockerExec(
 	dockerClient docker.Client,
 	containerName string,
 	cmdArgs []string,
+	transcript io.Writer,
 ) error {
 	stdinFd, err := stdinFD()
 	if err != nil {
@@ -257,7 +264,7 @@ func runInteractiveDockerExec(
 		defer restoreFunc()
 	}
 
-	pipeDockerExecStreams(&resp, isTTY)
+	pipeDockerExecStreams(&resp, isTTY, transcript)
 
 	return checkExecExitCode(ctx, dockerClient, execID.ID)
 }
@@ -297,7 +304,12 @@ func setupRawTerminal() (func(), error) {
 
 // pipeDockerExecStreams pipes stdin/stdout between the terminal and Docker exec.
 // When isTTY is true, raw copy is used. When false, stdcopy demuxes stdout/stderr.
-func pipeDockerExecStreams(resp *dockertypes.HijackedResponse, isTTY bool) {
+// Output is also copied to transcript when it is non-nil.
+func pipeDockerExecStreams(
+	resp *dockertypes.HijackedResponse,
+	isTTY bool,
+	transcript io.Writer,
+) {
 	doneCh := make(chan error, 1)
 	stdinReader, stdinWriter := io.Pipe()
 
@@ -317,10 +329,12 @@ func pipeDockerExecStreams(resp *dockertypes.HijackedResponse, isTTY bool) {
 		doneCh <- copyErr
 	}()
 
+	stdout, stderr := teeOutput(transcript)
+
 	if isTTY {
-		_, _ = io.Copy(os.Stdout, resp.Reader)
+		_, _ = io.Copy(stdout, resp.Reader)
 	} else {
-		_, _ = stdcopy.StdCopy(os.Stdout, os.Stderr, resp.Reader)
+		_, _ = stdcopy.StdCopy(stdout, stderr, resp.Reader)
 	}
 
 	// Cancel stdin forwarding now that the attach stream has ended.
@@ -330,6 +344,16 @@ func pipeDockerExecStreams(resp *dockertypes.HijackedResponse, isTTY bool) {
 	<-doneCh
 }
 
+// teeOutput returns the writers session output is copied to: the terminal's
+// stdout and stderr, each duplicated into transcript when it is non-nil.
+func teeOutput(transcript io.Writer) (io.Writer, io.Writer) {
+	if transcript == nil {
+		return os.Stdout, os.Stderr
+	}
+
+	return io.MultiWriter(os.Stdout, transcript), io.MultiWriter(os.Stderr, transcript)
+}
+
 // checkExecExitCode inspects the exec session and returns an error for non-zero exit codes.
 func checkExecExitCode(
 	ctx context.Context,
@@ -0,0 +1,52 @@
+package hostdebug
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	talosclient "github.com/siderolabs/talos/pkg/machinery/client"
+	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
+)
+
+// defaultTalosconfigPath is used when the TALOSCONFIG environment variable is unset.
+const defaultTalosconfigPath = "~/.talos/config"
+
+// resolveTalosconfigPath returns the talosconfig to authenticate against Talos nodes
+// with: $TALOSCONFIG, or ~/.talos/config.
+func resolveTalosconfigPath() string {
+	if path := os.Getenv("TALOSCONFIG"); path != "" {
+		return path
+	}
+
+	return defaultTalosconfigPath
+}
+
+// newTalosNodeClient opens a Talos API client for nodeEndpoint authenticated
+// with the talosconfig at configPath. The caller closes the client.
+func newTalosNodeClient(
+	ctx context.Context,
+	nodeEndpoint string,
+	configPath string,
+) (*talosclient.Client, error) {
+	expandedPath, err := fsutil.ExpandHomePath(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("expand talosconfig path: %w", err)
+	}
+
+	talosConfig, err := clientconfig.Open(expandedPath)
+	if err != nil {
+		return nil, fmt.Errorf("open talosconfig %q: %w", expandedPath, err)
+	}
+
+	talosClient, err := talosclient.New(ctx,
+		talosclient.WithEndpoints(nodeEndpoint),
+		talosclient.WithConfig(talosConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create Talos client: %w", err)
+	}
+
+	return talosClient, nil
+}
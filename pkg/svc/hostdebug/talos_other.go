@@ -5,6 +5,7 @@ package hostdebug
 import (
 	"context"
 	"errors"
+	"io"
 )
 
 // ErrTalosDebugNotSupportedOnWindows is returned when attempting Talos host-level debugging on Windows.
@@ -19,6 +20,7 @@ func runTalosHostDebug(
 	_ string,
 	_ string,
 	_ []string,
+	_ io.Writer,
 ) error {
 	return ErrTalosDebugNotSupportedOnWindows
 }
@@ -11,12 +11,10 @@ import (
 	"os/signal"
 	"syscall"
 
-	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
 	"github.com/siderolabs/gen/channel"
 	"github.com/siderolabs/talos/pkg/machinery/api/common"
 	"github.com/siderolabs/talos/pkg/machinery/api/machine"
 	talosclient "github.com/siderolabs/talos/pkg/machinery/client"
-	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
 	"golang.org/x/term"
 )
 
@@ -39,23 +37,11 @@ func runTalosHostDebug(
 	talosconfigPath string,
 	image string,
 	args []string,
+	transcript io.Writer,
 ) error {
-	expandedPath, err := fsutil.ExpandHomePath(talosconfigPath)
+	talosClient, err := newTalosNodeClient(ctx, nodeEndpoint, talosconfigPath)
 	if err != nil {
-		return fmt.Errorf("expand talosconfig path: %w", err)
-	}
-
-	talosConfig, err := clientconfig.Open(expandedPath)
-	if err != nil {
-		return fmt.Errorf("open talosconfig %q: %w", expandedPath, err)
-	}
-
-	talosClient, err := talosclient.New(ctx,
-		talosclient.WithEndpoints(nodeEndpoint),
-		talosclient.WithConfig(talosConfig),
-	)
-	if err != nil {
-		return fmt.Errorf("create Talos client: %w", err)
+		return err
 	}
 
 	defer talosClient.Close() //nolint:errcheck
@@ -79,7 +65,7 @@ func runTalosHostDebug(
 		return fmt.Errorf("create debug container stream: %w", err)
 	}
 
-	return streamDebugContainer(nodeCtx, cancel, runStream, imgName, args)
+	return streamDebugContainer(nodeCtx, cancel, runStream, imgName, args, transcript)
 }
 
 // pullImageOnTalosNode pulls the specified image on the target Talos node
@@ -107,6 +93,7 @@ func streamDebugContainer(
 	stream debugStream,
 	imageName string,
 	args []string,
+	transcript io.Writer,
 ) error {
 	defer cancel()
 
@@ -144,7 +131,9 @@ func streamDebugContainer(
 
 	var exitCode int32 = -1
 
-	recvDone := startRecvLoop(ctx, stream, &exitCode)
+	stdout, _ := teeOutput(transcript)
+
+	recvDone := startRecvLoop(ctx, stream, stdout, &exitCode)
 
 	return waitForStreams(ctx, cancel, stream, sendC,
 		stdinDone, sendDone, recvDone, &exitCode,
@@ -179,10 +168,12 @@ func sendContainerSpec(
 	return nil
 }
 
-// startRecvLoop receives messages from the debug stream and writes stdout data.
+// startRecvLoop receives messages from the debug stream and writes stdout data
+// to stdout.
 func startRecvLoop(
 	ctx context.Context,
 	stream debugStream,
+	stdout io.Writer,
 	exitCode *int32,
 ) chan error {
 	recvDone := make(chan error, 1)
@@ -205,7 +196,7 @@ func startRecvLoop(
 			switch msg.GetResp().(type) {
 			case *machine.DebugContainerRunResponse_StdoutData:
 				if stdoutData := msg.GetStdoutData(); stdoutData != nil {
-					_, _ = os.Stdout.Write(stdoutData)
+					_, _ = stdout.Write(stdoutData)
 				}
 			case *machine.DebugContainerRunResponse_ExitCode:
 				*exitCode = msg.GetExitCode()
@@ -0,0 +1,260 @@
+package image
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// ociIndexFile is the entry point of an OCI image layout archive.
+	ociIndexFile = "index.json"
+	// dockerManifestFile is the entry point of a legacy docker save archive.
+	dockerManifestFile = "manifest.json"
+	// maxMetadataBlobSize bounds the blobs read as indexes, manifests or
+	// configs; larger blobs are layers.
+	maxMetadataBlobSize = 4 << 20
+	// containerdImageNameAnnotation names the image of an index entry in
+	// archives written by ctr and docker save.
+	containerdImageNameAnnotation = "io.containerd.image.name"
+)
+
+// ArchiveImage is an image of an image archive.
+type ArchiveImage struct {
+	// Name is the reference the image is imported as, or its digest when the
+	// archive does not name it.
+	Name string
+	// Platforms are the os/arch[/variant] platforms the archive holds the
+	// content of. Platforms an image index lists without their manifest being
+	// in the archive are left out, as they cannot be imported.
+	Platforms []string
+}
+
+// ArchiveImages lists the images of an OCI image layout or docker save tar
+// archive with the platforms each can be imported for, following image
+// indexes into the manifests of multi-platform images.
+func ArchiveImages(archivePath string) ([]ArchiveImage, error) {
+	files, err := readArchiveMetadata(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexJSON, ok := files[ociIndexFile]; ok {
+		return ociArchiveImages(indexJSON, files)
+	}
+
+	if manifestJSON, ok := files[dockerManifestFile]; ok {
+		return dockerArchiveImages(manifestJSON, files)
+	}
+
+	return nil, fmt.Errorf("%w: %s or %s", ErrFileNotFoundInArchive, ociIndexFile, dockerManifestFile)
+}
+
+// checkArchivePlatform returns ErrPlatformNotInArchive when an image of the
+// archive at archivePath holds no content for platform, which the nodes would
+// otherwise fail to run with exec format errors. Archives that cannot be
+// inspected are left for ctr to report on.
+func checkArchivePlatform(archivePath, platform string) error {
+	images, err := ArchiveImages(archivePath)
+	if err != nil {
+		return nil //nolint:nilerr // ctr import reports archives it cannot read.
+	}
+
+	var mismatched []string
+
+	for _, image := range images {
+		if len(image.Platforms) == 0 {
+			continue
+		}
+
+		if !slices.ContainsFunc(image.Platforms, func(candidate string) bool {
+			return platformMatches(candidate, platform)
+		}) {
+			mismatched = append(mismatched,
+				fmt.Sprintf("%s (%s)", image.Name, strings.Join(image.Platforms, ", ")))
+		}
+	}
+
+	if len(mismatched) > 0 {
+		return fmt.Errorf("%w %s: %s", ErrPlatformNotInArchive, platform, strings.Join(mismatched, "; "))
+	}
+
+	return nil
+}
+
+// platformMatches reports whether an os/arch[/variant] platform runs on a node
+// of nodePlatform. Variants are ignored, as nodes report only os/arch.
+func platformMatches(platform, nodePlatform string) bool {
+	const osArchParts = 2
+
+	parts := strings.SplitN(platform, "/", osArchParts+1)
+	nodeParts := strings.SplitN(nodePlatform, "/", osArchParts+1)
+
+	return len(parts) >= osArchParts && len(nodeParts) >= osArchParts &&
+		parts[0] == nodeParts[0] && parts[1] == nodeParts[1]
+}
+
+// readArchiveMetadata reads the index, manifest and config files of a tar
+// archive, skipping layers.
+func readArchiveMetadata(archivePath string) (map[string][]byte, error) {
+	archive, err := os.Open(archivePath) //nolint:gosec // Path is from internal code
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image archive: %w", err)
+	}
+
+	defer func() { _ = archive.Close() }()
+
+	files := make(map[string][]byte)
+	tarReader := tar.NewReader(archive)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || header.Size > maxMetadataBlobSize {
+			continue
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+
+		files[strings.TrimPrefix(header.Name, "./")] = content
+	}
+}
+
+// ociArchiveImages lists the images of the index.json of an OCI image layout.
+func ociArchiveImages(indexJSON []byte, files map[string][]byte) ([]ArchiveImage, error) {
+	var index ocispec.Index
+
+	err := json.Unmarshal(indexJSON, &index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ociIndexFile, err)
+	}
+
+	images := make([]ArchiveImage, 0, len(index.Manifests))
+
+	for _, descriptor := range index.Manifests {
+		name := descriptor.Annotations[containerdImageNameAnnotation]
+		if name == "" {
+			name = descriptor.Annotations[ocispec.AnnotationRefName]
+		}
+
+		if name == "" {
+			name = descriptor.Digest.String()
+		}
+
+		platforms := descriptorPlatforms(descriptor, files)
+		slices.Sort(platforms)
+
+		images = append(images, ArchiveImage{Name: name, Platforms: slices.Compact(platforms)})
+	}
+
+	return images, nil
+}
+
+// descriptorPlatforms returns the platforms of the manifests descriptor points
+// to that the archive holds, following nested image indexes.
+func descriptorPlatforms(descriptor ocispec.Descriptor, files map[string][]byte) []string {
+	content, ok := files[blobPath(descriptor)]
+	if !ok {
+		return nil
+	}
+
+	// Image indexes and manifests are told apart by their fields, as docker
+	// manifest lists and some OCI writers omit mediaType.
+	var blob struct {
+		Manifests []ocispec.Descriptor `json:"manifests"`
+		Config    *ocispec.Descriptor  `json:"config"`
+	}
+
+	err := json.Unmarshal(content, &blob)
+	if err != nil {
+		return nil
+	}
+
+	if blob.Config == nil {
+		var platforms []string
+
+		for _, child := range blob.Manifests {
+			platforms = append(platforms, descriptorPlatforms(child, files)...)
+		}
+
+		return platforms
+	}
+
+	if descriptor.Platform != nil {
+		return []string{formatPlatform(*descriptor.Platform)}
+	}
+
+	return configPlatform(files[blobPath(*blob.Config)])
+}
+
+// dockerArchiveImages lists the images of the manifest.json of a legacy docker
+// save archive.
+func dockerArchiveImages(manifestJSON []byte, files map[string][]byte) ([]ArchiveImage, error) {
+	var entries []struct {
+		Config   string   `json:"Config"`
+		RepoTags []string `json:"RepoTags"`
+	}
+
+	err := json.Unmarshal(manifestJSON, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", dockerManifestFile, err)
+	}
+
+	images := make([]ArchiveImage, 0, len(entries))
+
+	for _, entry := range entries {
+		name := entry.Config
+		if len(entry.RepoTags) > 0 {
+			name = entry.RepoTags[0]
+		}
+
+		images = append(images, ArchiveImage{Name: name, Platforms: configPlatform(files[entry.Config])})
+	}
+
+	return images, nil
+}
+
+// configPlatform returns the platform of an image config, or nil when the
+// config is missing or names no platform.
+func configPlatform(config []byte) []string {
+	var image ocispec.Image
+
+	err := json.Unmarshal(config, &image)
+	if err != nil || image.OS == "" || image.Architecture == "" {
+		return nil
+	}
+
+	return []string{formatPlatform(image.Platform)}
+}
+
+// blobPath returns the archive path of the blob descriptor points to.
+func blobPath(descriptor ocispec.Descriptor) string {
+	return "blobs/" + descriptor.Digest.Algorithm().String() + "/" + descriptor.Digest.Encoded()
+}
+
+// formatPlatform formats platform as os/arch[/variant].
+func formatPlatform(platform ocispec.Platform) string {
+	formatted := platform.OS + "/" + platform.Architecture
+	if platform.Variant != "" {
+		formatted += "/" + platform.Variant
+	}
+
+	return formatted
+}
@@ -0,0 +1,186 @@
+package image_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/docker/docker/api/types/container"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveImagesFollowsImageIndexes(t *testing.T) {
+	t.Parallel()
+
+	archive := newTestArchive()
+	armManifest := archive.manifest(t, ocispec.Platform{OS: "linux", Architecture: "arm64"})
+	amdManifest := archive.manifest(t, ocispec.Platform{OS: "linux", Architecture: "amd64"})
+	// Exported for one platform: the index lists amd64, but its manifest is missing.
+	delete(archive.files, "blobs/sha256/"+amdManifest.Digest.Encoded())
+
+	index := archive.blob(t, ocispec.MediaTypeImageIndex, ocispec.Index{
+		Manifests: []ocispec.Descriptor{
+			withPlatform(amdManifest, "amd64"),
+			withPlatform(armManifest, "arm64"),
+		},
+	})
+	index.Annotations = map[string]string{"io.containerd.image.name": "docker.io/library/nginx:1.27"}
+
+	single := archive.manifest(t, ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"})
+	single.Annotations = map[string]string{ocispec.AnnotationRefName: "my-app:dev"}
+
+	archivePath := archive.write(t, ocispec.Index{Manifests: []ocispec.Descriptor{index, single}})
+
+	images, err := image.ArchiveImages(archivePath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []image.ArchiveImage{
+		{Name: "docker.io/library/nginx:1.27", Platforms: []string{"linux/arm64"}},
+		{Name: "my-app:dev", Platforms: []string{"linux/arm/v7"}},
+	}, images)
+}
+
+func TestArchiveImagesReadsDockerSaveManifest(t *testing.T) {
+	t.Parallel()
+
+	archive := newTestArchive()
+	archive.files["config.json"] = mustJSON(t, ocispec.Image{
+		Platform: ocispec.Platform{OS: "linux", Architecture: "amd64"},
+	})
+	archive.files["manifest.json"] = mustJSON(t, []map[string]any{
+		{"Config": "config.json", "RepoTags": []string{"redis:7"}},
+	})
+
+	images, err := image.ArchiveImages(archive.writeFiles(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, []image.ArchiveImage{{Name: "redis:7", Platforms: []string{"linux/amd64"}}}, images)
+}
+
+func TestImportRejectsArchiveWithoutNodePlatform(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := docker.NewMockAPIClient(t)
+
+	archive := newTestArchive()
+	manifest := archive.manifest(t, ocispec.Platform{OS: "linux", Architecture: "arm64"})
+	manifest.Annotations = map[string]string{"io.containerd.image.name": "my-app:dev"}
+	archivePath := archive.write(t, ocispec.Index{Manifests: []ocispec.Descriptor{manifest}})
+
+	mockClient.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return([]container.Summary{
+			{
+				Names:  []string{"/my-cluster-control-plane"},
+				Labels: map[string]string{"io.x-k8s.kind.role": "control-plane"},
+			},
+		}, nil)
+
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
+
+	err := image.NewImporter(mockClient).Import(ctx, "my-cluster", v1alpha1.DistributionVanilla,
+		v1alpha1.ProviderDocker, image.ImportOptions{InputPath: archivePath})
+
+	require.ErrorIs(t, err, image.ErrPlatformNotInArchive)
+	assert.Contains(t, err.Error(), "my-app:dev (linux/arm64)")
+}
+
+// testArchive assembles the files of an OCI image layout archive.
+type testArchive struct {
+	files map[string][]byte
+}
+
+func newTestArchive() *testArchive {
+	return &testArchive{files: make(map[string][]byte)}
+}
+
+// blob stores content as a blob and returns its descriptor.
+func (a *testArchive) blob(t *testing.T, mediaType string, content any) ocispec.Descriptor {
+	t.Helper()
+
+	data := mustJSON(t, content)
+	sum := sha256.Sum256(data)
+	hexDigest := hex.EncodeToString(sum[:])
+	a.files["blobs/sha256/"+hexDigest] = data
+
+	return ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.NewDigestFromEncoded(digest.SHA256, hexDigest),
+		Size:      int64(len(data)),
+	}
+}
+
+// manifest stores an image manifest and config for platform.
+func (a *testArchive) manifest(t *testing.T, platform ocispec.Platform) ocispec.Descriptor {
+	t.Helper()
+
+	config := a.blob(t, ocispec.MediaTypeImageConfig, ocispec.Image{Platform: platform})
+
+	return a.blob(t, ocispec.MediaTypeImageManifest, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+	})
+}
+
+// write writes the archive with index as its index.json.
+func (a *testArchive) write(t *testing.T, index ocispec.Index) string {
+	t.Helper()
+
+	a.files["index.json"] = mustJSON(t, index)
+
+	return a.writeFiles(t)
+}
+
+// writeFiles writes the files as a tar archive and returns its path.
+func (a *testArchive) writeFiles(t *testing.T) string {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	tarWriter := tar.NewWriter(&buffer)
+
+	for name, content := range a.files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg,
+		}))
+
+		_, err := tarWriter.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tarWriter.Close())
+
+	archivePath := filepath.Join(t.TempDir(), "images.tar")
+	require.NoError(t, os.WriteFile(archivePath, buffer.Bytes(), 0o600))
+
+	return archivePath
+}
+
+func withPlatform(descriptor ocispec.Descriptor, architecture string) ocispec.Descriptor {
+	descriptor.Platform = &ocispec.Platform{OS: "linux", Architecture: architecture}
+
+	return descriptor
+}
+
+func mustJSON(t *testing.T, value any) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,139 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/google/go-containerregistry/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// LoadImages saves images from the Docker daemon and imports them into the
+// containerd runtime of every cluster node, so pods can use them without a
+// registry.
+func (i *Importer) LoadImages(
+	ctx context.Context,
+	clusterName string,
+	distribution v1alpha1.Distribution,
+	providerType v1alpha1.Provider,
+	refs []string,
+) error {
+	err := validateImageOpParams(distribution, providerType)
+	if err != nil {
+		return err
+	}
+
+	archive, err := os.CreateTemp("", "ksail-images-*.tar")
+	if err != nil {
+		return fmt.Errorf("create image archive: %w", err)
+	}
+
+	defer func() { _ = os.Remove(archive.Name()) }()
+
+	err = i.saveImages(ctx, refs, archive)
+
+	closeErr := archive.Close()
+	if err != nil {
+		return err
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("close image archive: %w", closeErr)
+	}
+
+	return i.Import(ctx, clusterName, distribution, providerType, ImportOptions{InputPath: archive.Name()})
+}
+
+// PushImages pushes images from the Docker daemon to their registries without
+// credentials, as the local registry needs none. Push progress is streamed to
+// output (io.Discard to drop it).
+func (i *Importer) PushImages(ctx context.Context, refs []string, output io.Writer) error {
+	auth, err := registry.EncodeAuthConfig(registry.AuthConfig{})
+	if err != nil {
+		return fmt.Errorf("encode registry auth: %w", err)
+	}
+
+	for _, ref := range refs {
+		reader, err := i.dockerClient.ImagePush(ctx, ref, image.PushOptions{RegistryAuth: auth})
+		if err != nil {
+			return fmt.Errorf("push image %s: %w", ref, err)
+		}
+
+		err = jsonmessage.DisplayJSONMessagesStream(reader, output, 0, false, nil)
+		_ = reader.Close()
+
+		if err != nil {
+			return fmt.Errorf("push image %s: %w", ref, err)
+		}
+	}
+
+	return nil
+}
+
+// RegistryRef returns ref moved onto the registry at host, keeping its path and
+// tag, e.g. my-app:dev on localhost:5050 becomes localhost:5050/my-app:dev.
+func RegistryRef(ref, host string) (string, error) {
+	// An empty default registry keeps Docker Hub names such as my-app from
+	// gaining the library/ prefix.
+	tag, err := name.NewTag(ref, name.WithDefaultRegistry(""))
+	if err != nil {
+		return "", fmt.Errorf("parse image reference %s: %w", ref, err)
+	}
+
+	return fmt.Sprintf("%s/%s:%s", host, tag.RepositoryStr(), tag.TagStr()), nil
+}
+
+// NodePlatforms returns the os/arch platforms of the cluster nodes, such as
+// linux/arm64, sorted and joined by commas as the platform of an image build
+// the nodes can all run.
+func NodePlatforms(ctx context.Context, clientset kubernetes.Interface) (string, error) {
+	nodeList, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var platforms []string
+
+	for _, node := range nodeList.Items {
+		nodeInfo := node.Status.NodeInfo
+		if nodeInfo.OperatingSystem == "" || nodeInfo.Architecture == "" {
+			continue
+		}
+
+		platforms = append(platforms, nodeInfo.OperatingSystem+"/"+nodeInfo.Architecture)
+	}
+
+	if len(platforms) == 0 {
+		return "", ErrNoNodes
+	}
+
+	slices.Sort(platforms)
+
+	return strings.Join(slices.Compact(platforms), ","), nil
+}
+
+// saveImages writes images from the Docker daemon to writer as a docker save archive.
+func (i *Importer) saveImages(ctx context.Context, refs []string, writer io.Writer) error {
+	reader, err := i.dockerClient.ImageSave(ctx, refs)
+	if err != nil {
+		return fmt.Errorf("save images: %w", err)
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	_, err = io.Copy(writer, reader)
+	if err != nil {
+		return fmt.Errorf("write image archive: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package image_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegistryRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "my-app:dev", want: "localhost:5050/my-app:dev"},
+		{ref: "my-app", want: "localhost:5050/my-app:latest"},
+		{ref: "team/my-app:v1", want: "localhost:5050/team/my-app:v1"},
+		{ref: "ghcr.io/org/my-app:v1", want: "localhost:5050/org/my-app:v1"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.ref, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := image.RegistryRef(testCase.ref, "localhost:5050")
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestLoadImagesRejectsTalos(t *testing.T) {
+	t.Parallel()
+
+	importer := image.NewImporter(docker.NewMockAPIClient(t))
+
+	err := importer.LoadImages(context.Background(), "my-cluster",
+		v1alpha1.DistributionTalos, v1alpha1.ProviderDocker, []string{"my-app:dev"})
+	require.ErrorIs(t, err, image.ErrUnsupportedDistribution)
+}
+
+func TestPushImagesStreamsEveryRef(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+
+	for _, ref := range []string{"localhost:5050/a:dev", "localhost:5050/b:dev"} {
+		mockClient.EXPECT().ImagePush(mock.Anything, ref, mock.Anything).
+			Return(io.NopCloser(strings.NewReader(`{"status":"pushed"}`+"\n")), nil)
+	}
+
+	err := image.NewImporter(mockClient).PushImages(context.Background(),
+		[]string{"localhost:5050/a:dev", "localhost:5050/b:dev"}, io.Discard)
+	require.NoError(t, err)
+}
+
+func TestNodePlatformsDeduplicatesArchitectures(t *testing.T) {
+	t.Parallel()
+
+	node := func(name, arch string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+				OperatingSystem: "linux",
+				Architecture:    arch,
+			}},
+		}
+	}
+
+	clientset := fake.NewClientset(
+		node("worker-1", "arm64"),
+		node("control-plane", "amd64"),
+		node("worker-2", "arm64"),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "not-ready"}},
+	)
+
+	platforms, err := image.NodePlatforms(context.Background(), clientset)
+	require.NoError(t, err)
+
+	assert.Equal(t, "linux/amd64,linux/arm64", platforms)
+}
+
+func TestNodePlatformsWithoutNodesFails(t *testing.T) {
+	t.Parallel()
+
+	_, err := image.NodePlatforms(context.Background(), fake.NewClientset())
+	require.ErrorIs(t, err, image.ErrNoNodes)
+}
@@ -5,9 +5,16 @@
 //   - Vanilla (Kind): Uses Docker exec to run ctr commands in Kind nodes
 //   - K3s (K3d): Uses Docker exec to run ctr commands in K3d nodes
 //
+// Images in the nodes' containerd can also be listed and pruned (see Runtime):
+// through ctr via Docker exec for Kind and K3d, and through the image service
+// of the Talos Machine API for Talos.
+//
+// Images built on the host can be loaded into the nodes (see Importer.LoadImages)
+// or pushed to the cluster's local registry (see Importer.PushImages).
+//
 // Note: Talos distribution is NOT supported for image export/import operations.
 // Talos is an immutable OS without shell access, and its Machine API does not
-// expose image export functionality. Only ImageList and ImagePull APIs are available.
+// expose image export functionality. Only image list, pull and remove APIs are available.
 //
 // All operations use Go libraries only (Docker SDK) and do not rely on any
 // binaries installed on the host machine.
@@ -34,4 +41,7 @@ var (
 	// ErrBlobIntegrityFailed is returned when an OCI blob's SHA256 digest does not match
 	// its expected value (filename). This indicates a truncated or corrupted export.
 	ErrBlobIntegrityFailed = errors.New("blob integrity check failed")
+	// ErrPlatformNotInArchive is returned when an image archive holds images
+	// without content for the platform of the cluster nodes.
+	ErrPlatformNotInArchive = errors.New("image archive has no content for node platform")
 )
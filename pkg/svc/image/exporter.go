@@ -8,7 +8,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
@@ -44,6 +43,9 @@ type ExportOptions struct {
 	// Images is an optional list of specific images to export.
 	// If empty, all images in the cluster will be exported.
 	Images []string
+	// Platform is the os/arch platform to export, such as linux/arm64.
+	// Defaults to the platform of the node the images are exported from.
+	Platform string
 }
 
 // Exporter handles exporting container images from cluster containerd.
@@ -107,7 +109,7 @@ func (e *Exporter) Export(
 	return e.exportImagesFromNode(
 		ctx,
 		nodeName,
-		opts.OutputPath,
+		opts,
 		images,
 		tmpPath,
 		repairImages,
@@ -141,7 +143,7 @@ func (e *Exporter) findExportNode(
 func (e *Exporter) exportImagesFromNode(
 	ctx context.Context,
 	nodeName string,
-	outputPath string,
+	opts ExportOptions,
 	images []string,
 	tmpBasePath string,
 	repairImages []string,
@@ -150,10 +152,15 @@ func (e *Exporter) exportImagesFromNode(
 	// Create a temporary file path inside the container
 	tmpPath := tmpBasePath + "/ksail-images-export.tar"
 
-	// Detect the node's platform (OS/architecture)
-	platform, err := e.detectNodePlatform(ctx, nodeName)
-	if err != nil {
-		return fmt.Errorf("failed to detect node platform: %w", err)
+	// Export the requested platform, or the node's own platform (OS/architecture)
+	platform := opts.Platform
+	if platform == "" {
+		var err error
+
+		platform, err = detectNodePlatform(ctx, e.executor, nodeName)
+		if err != nil {
+			return fmt.Errorf("failed to detect node platform: %w", err)
+		}
 	}
 
 	exportImages, exportErr := e.tryExportImagesWithRepair(
@@ -166,7 +173,7 @@ func (e *Exporter) exportImagesFromNode(
 		resolveImages,
 	)
 	if exportErr != nil {
-		err = e.fallbackExportImages(
+		err := e.fallbackExportImages(
 			ctx,
 			nodeName,
 			tmpPath,
@@ -180,7 +187,7 @@ func (e *Exporter) exportImagesFromNode(
 	}
 
 	// Copy the tar file from container to host
-	err = e.copyFromContainer(ctx, nodeName, tmpPath, outputPath)
+	err := e.copyFromContainer(ctx, nodeName, tmpPath, opts.OutputPath)
 	if err != nil {
 		return fmt.Errorf("failed to copy export file from container: %w", err)
 	}
@@ -188,7 +195,7 @@ func (e *Exporter) exportImagesFromNode(
 	// Validate blob integrity in the exported OCI tar archive.
 	// Catches truncated or corrupted blobs that ctr export may silently produce
 	// when the containerd content store has incomplete data.
-	err = ValidateExportedTar(outputPath)
+	err = ValidateExportedTar(opts.OutputPath)
 	if err != nil {
 		return err
 	}
@@ -199,31 +206,6 @@ func (e *Exporter) exportImagesFromNode(
 	return nil
 }
 
-// detectNodePlatform detects the OS/architecture of the node container.
-// Returns a platform string in the format "os/arch" (e.g., "linux/amd64", "linux/arm64").
-func (e *Exporter) detectNodePlatform(ctx context.Context, nodeName string) (string, error) {
-	// Use uname to detect architecture
-	output, err := e.executor.ExecInContainer(ctx, nodeName, []string{"uname", "-m"})
-	if err != nil {
-		return "", fmt.Errorf("failed to detect architecture: %w", err)
-	}
-
-	arch := strings.TrimSpace(output)
-
-	// Convert uname output to Go/OCI architecture names
-	switch arch {
-	case "x86_64":
-		arch = "amd64"
-	case "aarch64":
-		arch = "arm64"
-	case "armv7l":
-		arch = "arm"
-	}
-
-	// Always linux for Kubernetes nodes
-	return "linux/" + arch, nil
-}
-
 // copyFromContainer copies a file from a container to the host.
 func (e *Exporter) copyFromContainer(
 	ctx context.Context,
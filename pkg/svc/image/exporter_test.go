@@ -76,7 +76,7 @@ func setupWhoamiExplicitExportTest(
 		[]string{ctrCommand, "--namespace=k8s.io", "images", "list", "-q"},
 		"docker.io/traefik/whoami:v1.10@sha256:abc123\n",
 	)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, kindExporterNodeName)
 }
 
 func expectRepairPullSuccess(
@@ -304,7 +304,7 @@ func TestExportWithSpecificImages(t *testing.T) {
 	setupEmptyImageListMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Mock platform detection (uname -m)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Mock exec for export command
 	exportCmd := []string{
@@ -344,6 +344,39 @@ func TestExportWithSpecificImages(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestExportWithPlatformSkipsNodePlatformDetection(t *testing.T) {
+	t.Parallel()
+
+	ctx, mockClient, outputPath := newExporterTestContext(t)
+
+	setupKindNodeListMock(ctx, mockClient)
+	setupEmptyImageListMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+
+	// No uname -m: the requested platform is exported as is.
+	exportCmd := []string{
+		ctrCommand, "--namespace=k8s.io", "images", "export",
+		"--platform", "linux/arm64",
+		kindExporterTarPath, "docker.io/library/nginx:latest",
+	}
+	setupExecMockWithCmdForExporter(ctx, t, mockClient, kindExporterNodeName, exportCmd)
+	expectCopiedExportTar(ctx, t, mockClient)
+	setupExecMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+
+	err := image.NewExporter(mockClient).Export(
+		ctx,
+		"my-cluster",
+		v1alpha1.DistributionVanilla,
+		v1alpha1.ProviderDocker,
+		image.ExportOptions{
+			OutputPath: outputPath,
+			Images:     []string{"nginx:latest"},
+			Platform:   "linux/arm64",
+		},
+	)
+
+	require.NoError(t, err)
+}
+
 func TestExportWithSpecificImagesResolvesLocalDigestRef(t *testing.T) {
 	t.Parallel()
 
@@ -384,7 +417,7 @@ func TestExportWithSpecificImagesFallsBackWhenLocalImageListFails(t *testing.T)
 		[]string{ctrCommand, "--namespace=k8s.io", "images", "list", "-q"},
 		"ctr: list failed",
 	)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, kindExporterNodeName)
 	setupExecMockWithCmdForExporter(
 		ctx, t, mockClient, kindExporterNodeName,
 		buildKindCtrExportCommand("docker.io/traefik/whoami:v1.10"),
@@ -425,7 +458,7 @@ func TestExportK3sDistribution(t *testing.T) {
 	setupEmptyImageListMockForExporter(ctx, t, mockClient, "k3d-my-cluster-server-0")
 
 	// Mock platform detection (uname -m)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, "k3d-my-cluster-server-0")
+	setupPlatformDetectMock(ctx, t, mockClient, "k3d-my-cluster-server-0")
 
 	// Mock exec for export - K3d uses /tmp path
 	k3dExportCmd := []string{
@@ -482,7 +515,7 @@ func TestExportEmptyProvider(t *testing.T) {
 	setupEmptyImageListMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Mock platform detection (uname -m)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Mock exec for export
 	setupExecMockWithCmdForExporter(
@@ -540,7 +573,7 @@ func TestExportCopyFromContainerFails(t *testing.T) {
 	setupEmptyImageListMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Mock platform detection (uname -m)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Mock exec for export
 	setupExecMockWithCmdForExporter(
@@ -587,7 +620,7 @@ func TestExportExecFails(t *testing.T) {
 	ctx, mockClient, outputPath := newExporterTestContext(t)
 	setupKindNodeListMock(ctx, mockClient)
 	setupEmptyImageListMockForExporter(ctx, t, mockClient, kindExporterNodeName)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, kindExporterNodeName)
 
 	exportCmd := buildKindCtrExportCommand("docker.io/library/nginx:latest")
 	setupKindExecFailWithCmdForExporter(ctx, t, mockClient, exportCmd, "ctr: export failed")
@@ -620,7 +653,7 @@ func TestExportMissingContentRetriesPullForExplicitImages(t *testing.T) {
 		[]string{ctrCommand, "--namespace=k8s.io", "images", "list", "-q"},
 		"docker.io/traefik/whoami:v1.10@sha256:abc123\n",
 	)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, kindExporterNodeName)
 
 	exportCmd := buildKindCtrExportCommand("docker.io/traefik/whoami:v1.10@sha256:abc123")
 	setupKindExecFailWithCmdForExporter(
@@ -665,7 +698,7 @@ func TestExportRepairPullSucceedsWhenRmFails(t *testing.T) {
 		[]string{ctrCommand, "--namespace=k8s.io", "images", "list", "-q"},
 		"docker.io/traefik/whoami:v1.10@sha256:abc123\n",
 	)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, kindExporterNodeName)
 
 	exportCmd := buildKindCtrExportCommand("docker.io/traefik/whoami:v1.10@sha256:abc123")
 	setupKindExecFailWithCmdForExporter(
@@ -834,7 +867,7 @@ func TestExportFallbackReportsFailedImages(t *testing.T) {
 
 	setupEmptyImageListMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
 
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
 	setupFallbackExportMocks(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Mock CopyFromContainer
@@ -897,7 +930,7 @@ func TestExportListImagesFiltersDigests(t *testing.T) {
 		[]string{ctrCommand, "--namespace=k8s.io", "images", "list", "-q"}, imageList)
 
 	// Mock platform detection (uname -m)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, "my-cluster-control-plane")
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
 
 	// Second exec is for exporting - only named images
 	exportCmd := []string{
@@ -1065,7 +1098,7 @@ func runResolvedSpecificImageExportTest(t *testing.T, testCase specificImageExpo
 		[]string{ctrCommand, "--namespace=k8s.io", "images", "list", "-q"},
 		testCase.localImageList,
 	)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, kindExporterNodeName)
 	setupExecMockWithCmdForExporter(
 		ctx, t, mockClient, kindExporterNodeName,
 		buildKindCtrExportCommand(testCase.expectedExportImage...),
@@ -1105,7 +1138,7 @@ func runNodeSelectionTest(
 	setupEmptyImageListMockForExporter(ctx, t, mockClient, expectedNodeName)
 
 	// Mock platform detection (uname -m)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, expectedNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, expectedNodeName)
 
 	// Mock export command
 	setupExecMockForExporter(ctx, t, mockClient, expectedNodeName)
@@ -1124,8 +1157,8 @@ func runNodeSelectionTest(
 	require.NoError(t, err)
 }
 
-// setupPlatformDetectMockForExporter sets up mock for uname -m platform detection.
-func setupPlatformDetectMockForExporter(
+// setupPlatformDetectMock sets up mock for uname -m platform detection.
+func setupPlatformDetectMock(
 	ctx context.Context,
 	t *testing.T,
 	mockClient *docker.MockAPIClient,
@@ -1339,7 +1372,7 @@ func setupFallbackRepairRetryMocks(
 		[]string{ctrCommand, "--namespace=k8s.io", "images", "list", "-q"},
 		"docker.io/traefik/whoami:v1.10@sha256:abc123\n",
 	)
-	setupPlatformDetectMockForExporter(ctx, t, mockClient, kindExporterNodeName)
+	setupPlatformDetectMock(ctx, t, mockClient, kindExporterNodeName)
 
 	exportCmd := buildKindCtrExportCommand("docker.io/traefik/whoami:v1.10@sha256:abc123")
 	missingContentErr := "ctr: failed to get reader: content digest sha256:missing: not found"
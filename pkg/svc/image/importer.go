@@ -13,6 +13,7 @@ import (
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
 	"github.com/docker/docker/api/types/container"
 )
@@ -82,17 +83,36 @@ func (i *Importer) Import(
 		return err
 	}
 
+	// Nodes of a Docker cluster share the architecture of the Docker host, so
+	// the platform of the first node selects the content to import for all.
+	platform, err := detectNodePlatform(ctx, i.executor, k8sNodes[0].Name)
+	if err != nil {
+		return fmt.Errorf("failed to detect node platform: %w", err)
+	}
+
+	err = checkArchivePlatform(inputPath, platform)
+	if err != nil {
+		return err
+	}
+
 	// Get the appropriate temp path for this distribution
 	tmpBasePath := getTempPath(distribution)
 
-	// Import to all K8s nodes in parallel
+	// Import to all K8s nodes in parallel, each import holding a CPU slot of
+	// the notify.Slots carried by ctx, if any.
 	var waitGroup sync.WaitGroup
 
 	errChan := make(chan error, len(k8sNodes))
 
 	for _, node := range k8sNodes {
 		waitGroup.Go(func() {
-			importErr := i.importImagesToNode(ctx, node.Name, inputPath, tmpBasePath)
+			release, importErr := notify.AcquireSlot(ctx, notify.WeightCPU)
+			if importErr == nil {
+				importErr = i.importImagesToNode(ctx, node.Name, inputPath, tmpBasePath, platform)
+
+				release()
+			}
+
 			if importErr != nil {
 				errChan <- fmt.Errorf("failed to import images to node %s: %w", node.Name, importErr)
 			}
@@ -160,6 +180,7 @@ func (i *Importer) importImagesToNode(
 	nodeName string,
 	inputPath string,
 	tmpBasePath string,
+	platform string,
 ) error {
 	// Copy the tar file from host to container
 	tmpPath := tmpBasePath + "/ksail-images-import.tar"
@@ -172,9 +193,11 @@ func (i *Importer) importImagesToNode(
 	// Build ctr import command
 	// Note: We don't use --all-platforms because multi-platform images may have
 	// manifests for platforms whose layers aren't present, causing import to fail.
+	// --platform selects the node's manifest from the image indexes instead.
 	cmd := []string{
 		ctrCommand, ctrNamespaceArg, ctrImages, "import",
 		"--digests",
+		"--platform", platform,
 		tmpPath,
 	}
 
@@ -248,6 +248,8 @@ func TestImportSuccessVanilla(t *testing.T) {
 			},
 		}, nil)
 
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
+
 	// Import happens to ALL K8s nodes, so we expect 2 imports
 	for _, nodeName := range []string{"my-cluster-control-plane", "my-cluster-worker"} {
 		// Mock CopyToContainer
@@ -311,6 +313,8 @@ func TestImportSuccessK3s(t *testing.T) {
 			},
 		}, nil)
 
+	setupPlatformDetectMock(ctx, t, mockClient, "k3d-my-cluster-server-0")
+
 	// Import happens only to server and agent nodes (not loadbalancer or registry)
 	for _, nodeName := range []string{"k3d-my-cluster-server-0", "k3d-my-cluster-agent-0"} {
 		// Mock CopyToContainer
@@ -360,6 +364,8 @@ func TestImportCopyToContainerFails(t *testing.T) {
 			},
 		}, nil)
 
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
+
 	// Mock CopyToContainer - fails
 	mockClient.EXPECT().
 		CopyToContainer(ctx, "my-cluster-control-plane", "/root", mock.Anything, container.CopyToContainerOptions{}).
@@ -401,6 +407,8 @@ func TestImportExecFails(t *testing.T) {
 			},
 		}, nil)
 
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
+
 	// Mock CopyToContainer - succeeds
 	mockClient.EXPECT().
 		CopyToContainer(ctx, "my-cluster-control-plane", "/root", mock.Anything, container.CopyToContainerOptions{}).
@@ -457,6 +465,8 @@ func TestImportEmptyProvider(t *testing.T) {
 			},
 		}, nil)
 
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
+
 	// Mock CopyToContainer
 	mockClient.EXPECT().
 		CopyToContainer(ctx, "my-cluster-control-plane", "/root", mock.Anything, container.CopyToContainerOptions{}).
@@ -508,6 +518,8 @@ func TestImportMultipleNodesPartialFailure(t *testing.T) {
 			},
 		}, nil)
 
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
+
 	// First node succeeds
 	mockClient.EXPECT().
 		CopyToContainer(ctx, "my-cluster-control-plane", "/root", mock.Anything, container.CopyToContainerOptions{}).
@@ -564,6 +576,8 @@ func TestImportLargeFile(t *testing.T) {
 			},
 		}, nil)
 
+	setupPlatformDetectMock(ctx, t, mockClient, "my-cluster-control-plane")
+
 	// Verify the tar archive sent to container has correct structure
 	mockClient.EXPECT().
 		CopyToContainer(ctx, "my-cluster-control-plane", "/root", mock.MatchedBy(func(reader io.Reader) bool {
@@ -634,13 +648,15 @@ func setupImportExecMockForImporter(
 	mockClient.EXPECT().
 		ContainerExecCreate(ctx, containerName, mock.MatchedBy(func(opts container.ExecOptions) bool {
 			// Verify the import command structure
-			return len(opts.Cmd) >= 5 &&
+			return len(opts.Cmd) == 8 &&
 				opts.Cmd[0] == ctrCommand &&
 				opts.Cmd[1] == "--namespace=k8s.io" &&
 				opts.Cmd[2] == "images" &&
 				opts.Cmd[3] == "import" &&
 				opts.Cmd[4] == "--digests" &&
-				opts.Cmd[5] == importPath
+				opts.Cmd[5] == "--platform" &&
+				opts.Cmd[6] == "linux/amd64" &&
+				opts.Cmd[7] == importPath
 		})).
 		Return(container.ExecCreateResponse{ID: execID}, nil).Once()
 
@@ -0,0 +1,156 @@
+package image
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// imageIDPrefix prefixes the image ID references containerd's CRI plugin adds
+// next to the named references of every image it pulls.
+const imageIDPrefix = "sha256:"
+
+// NodeImage is an image stored in the containerd runtime of one cluster node.
+// All references to the same manifest digest are grouped into one NodeImage.
+type NodeImage struct {
+	// Node is the name of the node holding the image.
+	Node string
+	// Digest is the digest of the image manifest or index.
+	Digest string
+	// Refs are the references pointing at the digest, named references first.
+	Refs []string
+	// Size is the size of the image content in bytes.
+	Size int64
+	// InUse reports whether a container on the node references the image.
+	InUse bool
+}
+
+// Name returns the first named reference of the image, or its digest when
+// the image is only known by ID.
+func (i NodeImage) Name() string {
+	if len(i.Refs) > 0 && !strings.HasPrefix(i.Refs[0], imageIDPrefix) {
+		return i.Refs[0]
+	}
+
+	return i.Digest
+}
+
+// Runtime lists and removes the images held by the containerd runtimes of a
+// cluster's nodes.
+type Runtime interface {
+	// ListImages returns the images of every node, sorted by node and name.
+	ListImages(ctx context.Context) ([]NodeImage, error)
+	// RemoveImage removes all references of image from its node.
+	RemoveImage(ctx context.Context, image NodeImage) error
+}
+
+// PruneOptions configures Prune.
+type PruneOptions struct {
+	// DryRun reports the images that would be removed without removing them.
+	DryRun bool
+}
+
+// Prune removes every image no container on its node references and returns
+// the images it removed. Removal continues past failures; the failures are
+// returned joined, next to the images that were removed.
+func Prune(ctx context.Context, imageRuntime Runtime, opts PruneOptions) ([]NodeImage, error) {
+	images, err := imageRuntime.ListImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		removed []NodeImage
+		errs    []error
+	)
+
+	for _, image := range images {
+		if image.InUse {
+			continue
+		}
+
+		if !opts.DryRun {
+			err = imageRuntime.RemoveImage(ctx, image)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("remove %s from node %s: %w", image.Name(), image.Node, err))
+
+				continue
+			}
+		}
+
+		removed = append(removed, image)
+	}
+
+	return removed, errors.Join(errs...)
+}
+
+// imageEntry is one image reference as reported by a node's containerd.
+type imageEntry struct {
+	ref    string
+	digest string
+	size   int64
+}
+
+// groupNodeImages groups the references of a node's images by digest and marks
+// the images referenced by containerImages, which may name an image by any of
+// its references or by its digest.
+func groupNodeImages(node string, entries []imageEntry, containerImages []string) []NodeImage {
+	byDigest := make(map[string]*NodeImage)
+	digestOf := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		digestOf[entry.ref] = entry.digest
+
+		image, ok := byDigest[entry.digest]
+		if !ok {
+			image = &NodeImage{Node: node, Digest: entry.digest}
+			byDigest[entry.digest] = image
+		}
+
+		image.Refs = append(image.Refs, entry.ref)
+		image.Size = max(image.Size, entry.size)
+	}
+
+	for _, containerImage := range containerImages {
+		digest, ok := digestOf[containerImage]
+		if !ok {
+			digest = containerImage
+		}
+
+		if image, found := byDigest[digest]; found {
+			image.InUse = true
+		}
+	}
+
+	images := make([]NodeImage, 0, len(byDigest))
+
+	for _, image := range byDigest {
+		slices.SortFunc(image.Refs, compareRefs)
+		images = append(images, *image)
+	}
+
+	slices.SortFunc(images, func(a, b NodeImage) int {
+		return cmp.Or(cmp.Compare(a.Node, b.Node), cmp.Compare(a.Name(), b.Name()))
+	})
+
+	return images
+}
+
+// compareRefs orders named references before image ID references, then
+// alphabetically.
+func compareRefs(a, b string) int {
+	aID := strings.HasPrefix(a, imageIDPrefix)
+	bID := strings.HasPrefix(b, imageIDPrefix)
+
+	switch {
+	case aID == bID:
+		return cmp.Compare(a, b)
+	case aID:
+		return 1
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,192 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+)
+
+const (
+	// ctrContainers is the ctr subcommand group for container operations.
+	ctrContainers = "containers"
+	// ctrSizeUnitBase is the factor between consecutive ctr size units.
+	ctrSizeUnitBase = 1024
+)
+
+// ctrSizeUnits are the binary units ctr renders image sizes in, smallest first.
+//
+//nolint:gochecknoglobals // Constant lookup table.
+var ctrSizeUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+
+// DockerRuntime reaches the containerd runtimes of Kind and K3d nodes by
+// running ctr in the node containers through Docker exec.
+type DockerRuntime struct {
+	dockerClient dockerclient.Client
+	executor     *ContainerExecutor
+	clusterName  string
+	distribution v1alpha1.Distribution
+}
+
+// NewDockerRuntime creates a Runtime for the nodes of a Kind or K3d cluster.
+func NewDockerRuntime(
+	dockerClient dockerclient.Client,
+	clusterName string,
+	distribution v1alpha1.Distribution,
+	providerType v1alpha1.Provider,
+) (*DockerRuntime, error) {
+	err := validateImageOpParams(distribution, providerType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DockerRuntime{
+		dockerClient: dockerClient,
+		executor:     NewContainerExecutor(dockerClient),
+		clusterName:  clusterName,
+		distribution: distribution,
+	}, nil
+}
+
+// ListImages lists the images of every node of the cluster with ctr, marking
+// the images used by the node's containers.
+func (r *DockerRuntime) ListImages(ctx context.Context) ([]NodeImage, error) {
+	nodes, err := listClusterNodes(ctx, r.dockerClient, r.clusterName, r.distribution)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []NodeImage
+
+	for _, node := range nodes {
+		// Skip helper containers without containerd
+		if isHelperContainer(node.Role) {
+			continue
+		}
+
+		nodeImages, err := r.listNodeImages(ctx, node.Name)
+		if err != nil {
+			return nil, fmt.Errorf("list images on node %s: %w", node.Name, err)
+		}
+
+		images = append(images, nodeImages...)
+	}
+
+	return images, nil
+}
+
+// RemoveImage removes all references of image from its node with ctr.
+func (r *DockerRuntime) RemoveImage(ctx context.Context, image NodeImage) error {
+	cmd := append([]string{ctrCommand, ctrNamespaceArg, ctrImages, "rm"}, image.Refs...)
+
+	_, err := r.executor.ExecInContainer(ctx, image.Node, cmd)
+	if err != nil {
+		return fmt.Errorf("failed to remove image: %w", err)
+	}
+
+	return nil
+}
+
+// listNodeImages lists the images of one node and the images its containers use.
+func (r *DockerRuntime) listNodeImages(ctx context.Context, nodeName string) ([]NodeImage, error) {
+	imagesOutput, err := r.executor.ExecInContainer(ctx, nodeName,
+		[]string{ctrCommand, ctrNamespaceArg, ctrImages, "ls"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	containersOutput, err := r.executor.ExecInContainer(ctx, nodeName,
+		[]string{ctrCommand, ctrNamespaceArg, ctrContainers, "ls"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	return groupNodeImages(nodeName, parseCtrImages(imagesOutput), parseCtrContainerImages(containersOutput)), nil
+}
+
+// parseCtrImages parses the table printed by `ctr images ls`:
+//
+//	REF  TYPE  DIGEST  SIZE  PLATFORMS  LABELS
+//
+// where SIZE is a value and a unit separated by a space, e.g. "27.2 MiB".
+func parseCtrImages(output string) []imageEntry {
+	const minFields = 5
+
+	var entries []imageEntry
+
+	for _, line := range tableRows(output) {
+		fields := strings.Fields(line)
+		if len(fields) < minFields {
+			continue
+		}
+
+		entries = append(entries, imageEntry{
+			ref:    fields[0],
+			digest: fields[2],
+			size:   parseCtrSize(fields[3], fields[4]),
+		})
+	}
+
+	return entries
+}
+
+// parseCtrContainerImages returns the IMAGE column of the table printed by
+// `ctr containers ls`:
+//
+//	CONTAINER  IMAGE  RUNTIME
+func parseCtrContainerImages(output string) []string {
+	const minFields = 2
+
+	var images []string
+
+	for _, line := range tableRows(output) {
+		fields := strings.Fields(line)
+		if len(fields) >= minFields {
+			images = append(images, fields[1])
+		}
+	}
+
+	return images
+}
+
+// tableRows returns the non-empty lines of a ctr table without its header.
+func tableRows(output string) []string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) <= 1 {
+		return nil
+	}
+
+	rows := make([]string, 0, len(lines)-1)
+
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) != "" {
+			rows = append(rows, line)
+		}
+	}
+
+	return rows
+}
+
+// parseCtrSize converts a size rendered by ctr, such as "27.2" "MiB", back into
+// bytes. The value is rounded to one decimal by ctr, so the result is an
+// approximation. Unparsable sizes count as zero.
+func parseCtrSize(value, unit string) int64 {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+
+	for _, candidate := range ctrSizeUnits {
+		if candidate == unit {
+			return int64(math.Round(number))
+		}
+
+		number *= ctrSizeUnitBase
+	}
+
+	return 0
+}
@@ -0,0 +1,198 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"github.com/siderolabs/talos/pkg/machinery/api/common"
+	"github.com/siderolabs/talos/pkg/machinery/api/machine"
+	talosclient "github.com/siderolabs/talos/pkg/machinery/client"
+	clientconfig "github.com/siderolabs/talos/pkg/machinery/client/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// criNamespace is the containerd namespace of the Kubernetes CRI plugin.
+const criNamespace = "k8s.io"
+
+// TalosNode is a Talos node addressed through the Talos API.
+type TalosNode struct {
+	// Name is the Kubernetes node name.
+	Name string
+	// Address is the node address the Talos API proxies requests to.
+	Address string
+}
+
+// TalosNodes returns the Kubernetes nodes of a Talos cluster with their
+// internal addresses.
+func TalosNodes(ctx context.Context, clientset kubernetes.Interface) ([]TalosNode, error) {
+	nodeList, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	nodes := make([]TalosNode, 0, len(nodeList.Items))
+
+	for _, node := range nodeList.Items {
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				nodes = append(nodes, TalosNode{Name: node.Name, Address: address.Address})
+
+				break
+			}
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, ErrNoNodes
+	}
+
+	return nodes, nil
+}
+
+// TalosRuntime reaches the containerd runtimes of Talos nodes through the
+// image service of the Talos machine API.
+type TalosRuntime struct {
+	client *talosclient.Client
+	nodes  []TalosNode
+}
+
+// NewTalosRuntime creates a Runtime for Talos nodes, connecting to the
+// endpoints of the current context of the talosconfig at talosconfigPath.
+// Close the runtime to release the connection.
+func NewTalosRuntime(ctx context.Context, talosconfigPath string, nodes []TalosNode) (*TalosRuntime, error) {
+	expandedPath, err := fsutil.ExpandHomePath(talosconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("expand talosconfig path: %w", err)
+	}
+
+	talosConfig, err := clientconfig.Open(expandedPath)
+	if err != nil {
+		return nil, fmt.Errorf("open talosconfig %q: %w", expandedPath, err)
+	}
+
+	client, err := talosclient.New(ctx, talosclient.WithConfig(talosConfig))
+	if err != nil {
+		return nil, fmt.Errorf("create Talos client: %w", err)
+	}
+
+	return &TalosRuntime{client: client, nodes: nodes}, nil
+}
+
+// Close closes the connection to the Talos API.
+func (r *TalosRuntime) Close() error {
+	err := r.client.Close()
+	if err != nil {
+		return fmt.Errorf("close Talos client: %w", err)
+	}
+
+	return nil
+}
+
+// ListImages lists the CRI images of every node, marking the images used by
+// the node's Kubernetes containers.
+func (r *TalosRuntime) ListImages(ctx context.Context) ([]NodeImage, error) {
+	var images []NodeImage
+
+	for _, node := range r.nodes {
+		nodeCtx := talosclient.WithNode(ctx, node.Address)
+
+		entries, err := r.listNodeImageEntries(nodeCtx)
+		if err != nil {
+			return nil, fmt.Errorf("list images on node %s: %w", node.Name, err)
+		}
+
+		containerImages, err := r.listNodeContainerImages(nodeCtx)
+		if err != nil {
+			return nil, fmt.Errorf("list containers on node %s: %w", node.Name, err)
+		}
+
+		images = append(images, groupNodeImages(node.Name, entries, containerImages)...)
+	}
+
+	return images, nil
+}
+
+// RemoveImage removes all references of image from its node.
+func (r *TalosRuntime) RemoveImage(ctx context.Context, image NodeImage) error {
+	address := image.Node
+
+	for _, node := range r.nodes {
+		if node.Name == image.Node {
+			address = node.Address
+
+			break
+		}
+	}
+
+	nodeCtx := talosclient.WithNode(ctx, address)
+
+	for _, ref := range image.Refs {
+		_, err := r.client.ImageClient.Remove(nodeCtx, &machine.ImageServiceRemoveRequest{
+			Containerd: criContainerd(),
+			ImageRef:   ref,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove image %s: %w", ref, err)
+		}
+	}
+
+	return nil
+}
+
+// listNodeImageEntries streams the CRI images of the node addressed by nodeCtx.
+func (r *TalosRuntime) listNodeImageEntries(nodeCtx context.Context) ([]imageEntry, error) {
+	stream, err := r.client.ImageClient.List(nodeCtx, &machine.ImageServiceListRequest{
+		Containerd: criContainerd(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	var entries []imageEntry
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive image: %w", err)
+		}
+
+		entries = append(entries, imageEntry{ref: msg.GetName(), digest: msg.GetDigest(), size: msg.GetSize()})
+	}
+}
+
+// listNodeContainerImages returns the images of the Kubernetes containers and
+// pod sandboxes on the node addressed by nodeCtx.
+func (r *TalosRuntime) listNodeContainerImages(nodeCtx context.Context) ([]string, error) {
+	resp, err := r.client.Containers(nodeCtx, criNamespace, common.ContainerDriver_CRI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var images []string
+
+	for _, msg := range resp.GetMessages() {
+		for _, container := range msg.GetContainers() {
+			images = append(images, container.GetImage())
+		}
+	}
+
+	return images, nil
+}
+
+// criContainerd selects the containerd instance and namespace of the
+// Kubernetes CRI plugin.
+func criContainerd() *common.ContainerdInstance {
+	return &common.ContainerdInstance{
+		Driver:    common.ContainerDriver_CRI,
+		Namespace: common.ContainerdNamespace_NS_CRI,
+	}
+}
@@ -0,0 +1,204 @@
+package image_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var errRemoveFailed = errors.New("remove failed")
+
+const (
+	ctrImagesLsOutput = `REF                                      TYPE                                                 DIGEST          SIZE      PLATFORMS    LABELS
+docker.io/library/nginx:latest           application/vnd.oci.image.index.v1+json              sha256:nginx    67.5 MiB  linux/amd64  io.cri-containerd.image=managed
+docker.io/library/redis:7                application/vnd.oci.image.index.v1+json              sha256:redis    40.0 MiB  linux/amd64  io.cri-containerd.image=managed
+registry.k8s.io/pause:3.10               application/vnd.oci.image.index.v1+json              sha256:pause    320.0 KiB linux/amd64  io.cri-containerd.image=managed
+sha256:1111                              application/vnd.oci.image.index.v1+json              sha256:nginx    67.5 MiB  linux/amd64  io.cri-containerd.image=managed
+sha256:2222                              application/vnd.oci.image.index.v1+json              sha256:redis    40.0 MiB  linux/amd64  io.cri-containerd.image=managed
+`
+	ctrContainersLsOutput = `CONTAINER    IMAGE                             RUNTIME
+0123abcd     registry.k8s.io/pause:3.10        io.containerd.runc.v2
+4567efgh     sha256:1111                       io.containerd.runc.v2
+`
+)
+
+// stubRuntime serves a fixed image list and records removals.
+type stubRuntime struct {
+	images  []image.NodeImage
+	removed []string
+	failOn  string
+}
+
+func (s *stubRuntime) ListImages(_ context.Context) ([]image.NodeImage, error) {
+	return s.images, nil
+}
+
+func (s *stubRuntime) RemoveImage(_ context.Context, nodeImage image.NodeImage) error {
+	if nodeImage.Name() == s.failOn {
+		return errRemoveFailed
+	}
+
+	s.removed = append(s.removed, nodeImage.Name())
+
+	return nil
+}
+
+func newKindInventoryRuntime(t *testing.T) (context.Context, *docker.MockAPIClient, *image.DockerRuntime) {
+	t.Helper()
+
+	ctx := context.Background()
+	mockClient := docker.NewMockAPIClient(t)
+
+	setupKindNodeListMock(ctx, mockClient)
+	setupExecMockWithStdoutForExporter(ctx, t, mockClient, kindExporterNodeName,
+		[]string{ctrCommand, "--namespace=k8s.io", "images", "ls"}, ctrImagesLsOutput)
+	setupExecMockWithStdoutForExporter(ctx, t, mockClient, kindExporterNodeName,
+		[]string{ctrCommand, "--namespace=k8s.io", "containers", "ls"}, ctrContainersLsOutput)
+
+	runtime, err := image.NewDockerRuntime(mockClient, "my-cluster",
+		v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+	require.NoError(t, err)
+
+	return ctx, mockClient, runtime
+}
+
+func TestDockerRuntimeListImagesGroupsRefsAndMarksUsage(t *testing.T) {
+	t.Parallel()
+
+	ctx, _, runtime := newKindInventoryRuntime(t)
+
+	images, err := runtime.ListImages(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []image.NodeImage{
+		{
+			Node: kindExporterNodeName, Digest: "sha256:nginx",
+			Refs:  []string{"docker.io/library/nginx:latest", "sha256:1111"},
+			Size:  70778880, // 67.5 MiB
+			InUse: true,
+		},
+		{
+			Node: kindExporterNodeName, Digest: "sha256:redis",
+			Refs: []string{"docker.io/library/redis:7", "sha256:2222"},
+			Size: 40 << 20,
+		},
+		{
+			Node: kindExporterNodeName, Digest: "sha256:pause",
+			Refs:  []string{"registry.k8s.io/pause:3.10"},
+			Size:  320 << 10,
+			InUse: true,
+		},
+	}, images)
+}
+
+func TestDockerRuntimeRemoveImageRemovesAllRefs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockClient := docker.NewMockAPIClient(t)
+
+	setupExecMockWithCmdForExporter(ctx, t, mockClient, kindExporterNodeName,
+		[]string{ctrCommand, "--namespace=k8s.io", "images", "rm", "docker.io/library/redis:7", "sha256:2222"})
+
+	runtime, err := image.NewDockerRuntime(mockClient, "my-cluster",
+		v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker)
+	require.NoError(t, err)
+
+	err = runtime.RemoveImage(ctx, image.NodeImage{
+		Node: kindExporterNodeName,
+		Refs: []string{"docker.io/library/redis:7", "sha256:2222"},
+	})
+	require.NoError(t, err)
+}
+
+func TestNewDockerRuntimeRejectsTalos(t *testing.T) {
+	t.Parallel()
+
+	_, err := image.NewDockerRuntime(docker.NewMockAPIClient(t), "my-cluster",
+		v1alpha1.DistributionTalos, v1alpha1.ProviderDocker)
+	require.ErrorIs(t, err, image.ErrUnsupportedDistribution)
+}
+
+func TestPruneRemovesUnusedImages(t *testing.T) {
+	t.Parallel()
+
+	runtime := &stubRuntime{images: []image.NodeImage{
+		{Node: "node-1", Digest: "sha256:a", Refs: []string{"nginx:latest"}, InUse: true},
+		{Node: "node-1", Digest: "sha256:b", Refs: []string{"redis:7"}},
+		{Node: "node-2", Digest: "sha256:c", Refs: []string{"sha256:cccc"}},
+	}}
+
+	removed, err := image.Prune(context.Background(), runtime, image.PruneOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"redis:7", "sha256:c"}, runtime.removed)
+	assert.Len(t, removed, 2)
+}
+
+func TestPruneDryRunRemovesNothing(t *testing.T) {
+	t.Parallel()
+
+	runtime := &stubRuntime{images: []image.NodeImage{
+		{Node: "node-1", Digest: "sha256:b", Refs: []string{"redis:7"}},
+	}}
+
+	removed, err := image.Prune(context.Background(), runtime, image.PruneOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.Empty(t, runtime.removed)
+	assert.Len(t, removed, 1)
+}
+
+func TestPruneContinuesPastFailures(t *testing.T) {
+	t.Parallel()
+
+	runtime := &stubRuntime{
+		images: []image.NodeImage{
+			{Node: "node-1", Digest: "sha256:a", Refs: []string{"nginx:latest"}},
+			{Node: "node-1", Digest: "sha256:b", Refs: []string{"redis:7"}},
+		},
+		failOn: "nginx:latest",
+	}
+
+	removed, err := image.Prune(context.Background(), runtime, image.PruneOptions{})
+	require.ErrorIs(t, err, errRemoveFailed)
+
+	assert.Equal(t, []string{"redis:7"}, runtime.removed)
+	assert.Len(t, removed, 1)
+}
+
+func TestTalosNodesUsesInternalAddresses(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "talos-cp-1"},
+			Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "talos-cp-1"},
+				{Type: corev1.NodeInternalIP, Address: "10.5.0.2"},
+			}},
+		},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "no-address"}},
+	)
+
+	nodes, err := image.TalosNodes(context.Background(), clientset)
+	require.NoError(t, err)
+
+	assert.Equal(t, []image.TalosNode{{Name: "talos-cp-1", Address: "10.5.0.2"}}, nodes)
+}
+
+func TestTalosNodesWithoutAddressesFails(t *testing.T) {
+	t.Parallel()
+
+	_, err := image.TalosNodes(context.Background(), fake.NewClientset())
+	require.ErrorIs(t, err, image.ErrNoNodes)
+}
@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
@@ -136,3 +137,28 @@ func getTempPath(distribution v1alpha1.Distribution) string {
 
 	return tmpPathTmp
 }
+
+// detectNodePlatform detects the OS/architecture of the node container.
+// Returns a platform string in the format "os/arch" (e.g., "linux/amd64", "linux/arm64").
+func detectNodePlatform(ctx context.Context, executor *ContainerExecutor, nodeName string) (string, error) {
+	// Use uname to detect architecture
+	output, err := executor.ExecInContainer(ctx, nodeName, []string{"uname", "-m"})
+	if err != nil {
+		return "", fmt.Errorf("failed to detect architecture: %w", err)
+	}
+
+	arch := strings.TrimSpace(output)
+
+	// Convert uname output to Go/OCI architecture names
+	switch arch {
+	case "x86_64":
+		arch = "amd64"
+	case "aarch64":
+		arch = "arm64"
+	case "armv7l":
+		arch = "arm"
+	}
+
+	// Always linux for Kubernetes nodes
+	return "linux/" + arch, nil
+}
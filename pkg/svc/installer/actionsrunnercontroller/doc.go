@@ -0,0 +1,9 @@
+// Package actionsrunnercontrollerinstaller provides an installer for GitHub Actions
+// Runner Controller (ARC), installed when spec.cluster.actionsRunner is enabled.
+//
+// The installer deploys the gha-runner-scale-set-controller chart and a single
+// gha-runner-scale-set chart that registers ephemeral runners with the configured
+// repository or organization, so workflows can target the local cluster with runs-on.
+//
+// See: https://github.com/actions/actions-runner-controller
+package actionsrunnercontrollerinstaller
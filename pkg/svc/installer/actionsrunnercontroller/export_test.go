@@ -0,0 +1,6 @@
+package actionsrunnercontrollerinstaller
+
+// BuildScaleSetValuesYaml exports buildScaleSetValuesYaml for testing.
+func BuildScaleSetValuesYaml(opts Options, token string) (string, error) {
+	return buildScaleSetValuesYaml(opts, token)
+}
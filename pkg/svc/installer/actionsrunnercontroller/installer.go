@@ -0,0 +1,223 @@
+package actionsrunnercontrollerinstaller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	controllerReleaseName = "arc"
+	controllerNamespace   = "arc-systems"
+	controllerChartName   = "oci://ghcr.io/actions/actions-runner-controller-charts/gha-runner-scale-set-controller"
+	// controllerServiceAccount is the service account the controller chart creates for the
+	// "arc" release; the scale set chart grants it access to the runner namespace.
+	controllerServiceAccount = controllerReleaseName + "-gha-rs-controller"
+
+	scaleSetNamespace = "arc-runners"
+	scaleSetChartName = "oci://ghcr.io/actions/actions-runner-controller-charts/gha-runner-scale-set"
+
+	// imagesPlaceholderToken renders the scale set chart for image listing when no token is
+	// configured; the chart refuses to render without a GitHub credential.
+	imagesPlaceholderToken = "placeholder"
+)
+
+// ErrTokenMissing is returned by Install when no GitHub token is configured.
+var ErrTokenMissing = errors.New(
+	"no GitHub token for the actions runner: set github.token in the KSail credential store or GITHUB_TOKEN",
+)
+
+// Options configures the runner scale set registered by the installer.
+type Options struct {
+	// GitHubConfigURL is the repository or organization URL the runners register with.
+	GitHubConfigURL string
+	// Name is the runner scale set name, used as its Helm release and runs-on label.
+	Name string
+	// Labels are additional runs-on labels of the scale set.
+	Labels []string
+	// MinRunners is the number of idle runners kept ready.
+	MinRunners int32
+	// MaxRunners caps the number of concurrent runners; 0 leaves it unbounded.
+	MaxRunners int32
+	// Token is the GitHub token the listener registers runners with.
+	Token string
+}
+
+// Installer installs or upgrades GitHub Actions Runner Controller and one runner scale set
+// via their Helm OCI charts.
+type Installer struct {
+	client  helm.Interface
+	timeout time.Duration
+	opts    Options
+}
+
+// NewInstaller creates a new actions-runner-controller installer instance.
+func NewInstaller(client helm.Interface, timeout time.Duration, opts Options) *Installer {
+	return &Installer{
+		client:  client,
+		timeout: timeout,
+		opts:    opts,
+	}
+}
+
+// Install installs or upgrades the controller, then the runner scale set that depends on its CRDs.
+func (a *Installer) Install(ctx context.Context) error {
+	if a.opts.Token == "" {
+		return ErrTokenMissing
+	}
+
+	scaleSet, err := a.scaleSetChartSpec(a.opts.Token)
+	if err != nil {
+		return err
+	}
+
+	// Set context deadline longer than Helm timeout to ensure Helm has
+	// sufficient time to complete its kstatus-based wait operation.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 2*a.timeout+helm.ContextTimeoutBuffer)
+	defer cancel()
+
+	err = helm.InstallChartWithRetry(timeoutCtx, a.client, a.controllerChartSpec(), controllerReleaseName)
+	if err != nil {
+		return fmt.Errorf("failed to install actions-runner-controller: %w", err)
+	}
+
+	err = helm.InstallChartWithRetry(timeoutCtx, a.client, scaleSet, scaleSet.ReleaseName)
+	if err != nil {
+		return fmt.Errorf("failed to install runner scale set %s: %w", scaleSet.ReleaseName, err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the runner scale set before the controller, so the controller can
+// deregister the runners and clean up their finalizers.
+func (a *Installer) Uninstall(ctx context.Context) error {
+	err := a.client.UninstallRelease(ctx, a.opts.Name, scaleSetNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall runner scale set %s: %w", a.opts.Name, err)
+	}
+
+	err = a.client.UninstallRelease(ctx, controllerReleaseName, controllerNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall actions-runner-controller: %w", err)
+	}
+
+	return nil
+}
+
+// Images returns the container images used by the controller and the runner scale set.
+func (a *Installer) Images(ctx context.Context) ([]string, error) {
+	token := a.opts.Token
+	if token == "" {
+		token = imagesPlaceholderToken
+	}
+
+	scaleSet, err := a.scaleSetChartSpec(token)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := helmutil.ImagesFromChart(ctx, a.client, a.controllerChartSpec())
+	if err != nil {
+		return nil, fmt.Errorf("listing controller images: %w", err)
+	}
+
+	runnerImages, err := helmutil.ImagesFromChart(ctx, a.client, scaleSet)
+	if err != nil {
+		return nil, fmt.Errorf("listing runner scale set images: %w", err)
+	}
+
+	images = append(images, runnerImages...)
+	slices.Sort(images)
+
+	return slices.Compact(images), nil
+}
+
+func (a *Installer) controllerChartSpec() *helm.ChartSpec {
+	return &helm.ChartSpec{
+		ReleaseName:     controllerReleaseName,
+		ChartName:       controllerChartName,
+		Namespace:       controllerNamespace,
+		Version:         chartVersion(),
+		CreateNamespace: true,
+		Atomic:          true,
+		UpgradeCRDs:     true,
+		Timeout:         a.timeout,
+		Wait:            true,
+		WaitForJobs:     true,
+	}
+}
+
+func (a *Installer) scaleSetChartSpec(token string) (*helm.ChartSpec, error) {
+	valuesYaml, err := buildScaleSetValuesYaml(a.opts, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &helm.ChartSpec{
+		ReleaseName:     a.opts.Name,
+		ChartName:       scaleSetChartName,
+		Namespace:       scaleSetNamespace,
+		Version:         chartVersion(),
+		CreateNamespace: true,
+		Atomic:          true,
+		Timeout:         a.timeout,
+		Wait:            true,
+		ValuesYaml:      valuesYaml,
+	}, nil
+}
+
+type githubConfigSecret struct {
+	GitHubToken string `json:"github_token"`
+}
+
+type controllerServiceAccountRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type scaleSetValues struct {
+	GitHubConfigURL          string                      `json:"githubConfigUrl"`
+	GitHubConfigSecret       githubConfigSecret          `json:"githubConfigSecret"`
+	RunnerScaleSetName       string                      `json:"runnerScaleSetName"`
+	ScaleSetLabels           []string                    `json:"scaleSetLabels,omitempty"`
+	MinRunners               *int32                      `json:"minRunners,omitempty"`
+	MaxRunners               *int32                      `json:"maxRunners,omitempty"`
+	ControllerServiceAccount controllerServiceAccountRef `json:"controllerServiceAccount"`
+}
+
+// buildScaleSetValuesYaml generates the gha-runner-scale-set values. It marshals a typed
+// struct via sigs.k8s.io/yaml so user-supplied URLs, labels, and the token cannot inject YAML.
+func buildScaleSetValuesYaml(opts Options, token string) (string, error) {
+	vals := scaleSetValues{
+		GitHubConfigURL:    opts.GitHubConfigURL,
+		GitHubConfigSecret: githubConfigSecret{GitHubToken: token},
+		RunnerScaleSetName: opts.Name,
+		ScaleSetLabels:     opts.Labels,
+		ControllerServiceAccount: controllerServiceAccountRef{
+			Namespace: controllerNamespace,
+			Name:      controllerServiceAccount,
+		},
+	}
+
+	if opts.MinRunners > 0 {
+		vals.MinRunners = &opts.MinRunners
+	}
+
+	if opts.MaxRunners > 0 {
+		vals.MaxRunners = &opts.MaxRunners
+	}
+
+	out, err := yaml.Marshal(vals)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal runner scale set values: %w", err)
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,214 @@
+package actionsrunnercontrollerinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	actionsrunnercontrollerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/actionsrunnercontroller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	testControllerChart = "oci://ghcr.io/actions/actions-runner-controller-charts/gha-runner-scale-set-controller"
+	testScaleSetChart   = "oci://ghcr.io/actions/actions-runner-controller-charts/gha-runner-scale-set"
+)
+
+func testOptions() actionsrunnercontrollerinstaller.Options {
+	return actionsrunnercontrollerinstaller.Options{
+		GitHubConfigURL: "https://github.com/devantler-tech/ksail",
+		Name:            "ksail",
+		Labels:          []string{"local"},
+		MaxRunners:      2,
+		Token:           "ghp_test",
+	}
+}
+
+func TestNewInstaller(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Minute, testOptions())
+
+	require.NotNil(t, installer)
+}
+
+func TestBuildScaleSetValuesYaml(t *testing.T) {
+	t.Parallel()
+
+	out, err := actionsrunnercontrollerinstaller.BuildScaleSetValuesYaml(testOptions(), "ghp_test")
+	require.NoError(t, err)
+
+	var values map[string]any
+
+	require.NoError(t, yaml.Unmarshal([]byte(out), &values))
+	assert.Equal(t, "https://github.com/devantler-tech/ksail", values["githubConfigUrl"])
+	assert.Equal(t, map[string]any{"github_token": "ghp_test"}, values["githubConfigSecret"])
+	assert.Equal(t, "ksail", values["runnerScaleSetName"])
+	assert.Equal(t, []any{"local"}, values["scaleSetLabels"])
+	assert.InDelta(t, 2, values["maxRunners"], 0)
+	assert.NotContains(t, values, "minRunners")
+	assert.Equal(
+		t,
+		map[string]any{"namespace": "arc-systems", "name": "arc-gha-rs-controller"},
+		values["controllerServiceAccount"],
+	)
+}
+
+func TestInstallerInstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Second, testOptions())
+
+	expectControllerInstall(t, client, nil)
+	expectScaleSetInstall(t, client, nil)
+
+	require.NoError(t, installer.Install(context.Background()))
+}
+
+func TestInstallerInstallControllerError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Second, testOptions())
+
+	expectControllerInstall(t, client, assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to install actions-runner-controller")
+}
+
+func TestInstallerInstallScaleSetError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Second, testOptions())
+
+	expectControllerInstall(t, client, nil)
+	expectScaleSetInstall(t, client, assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to install runner scale set ksail")
+}
+
+func TestInstallerUninstallSuccess(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Second, testOptions())
+
+	scaleSet := client.EXPECT().UninstallRelease(mock.Anything, "ksail", "arc-runners").Return(nil)
+	client.EXPECT().
+		UninstallRelease(mock.Anything, "arc", "arc-systems").
+		Return(nil).
+		NotBefore(scaleSet.Call)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+}
+
+func TestInstallerUninstallError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Second, testOptions())
+
+	client.EXPECT().UninstallRelease(mock.Anything, "ksail", "arc-runners").Return(assert.AnError)
+
+	err := installer.Uninstall(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to uninstall runner scale set ksail")
+}
+
+func TestInstallerImagesUsesPlaceholderToken(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions()
+	opts.Token = ""
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Second, opts)
+
+	client.EXPECT().
+		TemplateChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+			return spec.ChartName == testControllerChart
+		})).
+		Return("", nil)
+	client.EXPECT().
+		TemplateChart(mock.Anything, mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+			return spec.ChartName == testScaleSetChart &&
+				assert.Contains(t, spec.ValuesYaml, "github_token: placeholder")
+		})).
+		Return("", nil)
+
+	images, err := installer.Images(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, images)
+}
+
+func expectControllerInstall(t *testing.T, client *helm.MockInterface, installErr error) {
+	t.Helper()
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				if spec.ChartName != testControllerChart {
+					return false
+				}
+
+				assert.Equal(t, "arc", spec.ReleaseName)
+				assert.Equal(t, "arc-systems", spec.Namespace)
+				assert.NotEmpty(t, spec.Version)
+				assert.True(t, spec.CreateNamespace)
+				assert.True(t, spec.UpgradeCRDs)
+
+				return true
+			}),
+		).
+		Return(nil, installErr)
+}
+
+func expectScaleSetInstall(t *testing.T, client *helm.MockInterface, installErr error) {
+	t.Helper()
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				if spec.ChartName != testScaleSetChart {
+					return false
+				}
+
+				assert.Equal(t, "ksail", spec.ReleaseName)
+				assert.Equal(t, "arc-runners", spec.Namespace)
+				assert.True(t, spec.CreateNamespace)
+				assert.Contains(t, spec.ValuesYaml, "github_token: ghp_test")
+
+				return true
+			}),
+		).
+		Return(nil, installErr)
+}
+
+func TestInstallerInstallRequiresToken(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions()
+	opts.Token = ""
+
+	client := helm.NewMockInterface(t)
+	installer := actionsrunnercontrollerinstaller.NewInstaller(client, 5*time.Second, opts)
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, actionsrunnercontrollerinstaller.ErrTokenMissing)
+}
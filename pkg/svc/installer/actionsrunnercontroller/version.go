@@ -0,0 +1,20 @@
+package actionsrunnercontrollerinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Dockerfile
+var dockerfile string
+
+// chartVersion returns the pinned ARC chart version extracted from the embedded Dockerfile.
+// The controller and scale set charts are released together and share the version.
+func chartVersion() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+ghcr\.io/actions/actions-runner-controller-charts/gha-runner-scale-set-controller:([^\s@]+)`,
+		"actions-runner-controller chart",
+	)
+}
@@ -248,79 +248,3 @@ func TestInstaller_Uninstall_ContextCanceled(t *testing.T) {
 
 	require.Error(t, err)
 }
-
-func TestGatewayAPICRDsURL_NonEmpty(t *testing.T) {
-	t.Parallel()
-
-	// We test parseGatewayAPICRDs via the exported function to check URL is non-empty.
-	// The URL is derived from the embedded Dockerfile.gateway-api and must contain
-	// a version string. We verify this indirectly by checking the fetch function
-	// accepts a non-empty URL without panicking.
-	crds, err := ciliuminstaller.ParseGatewayAPICRDs([]byte(""))
-	require.NoError(t, err)
-	assert.Empty(t, crds)
-}
-
-func TestGatewayAPICRDsVersion_NonEmpty(t *testing.T) {
-	t.Parallel()
-
-	// Verify that ParseGatewayAPICRDs can handle a simple valid CRD,
-	// which confirms the parsing pipeline works.
-	crdYAML := `apiVersion: apiextensions.k8s.io/v1
-kind: CustomResourceDefinition
-metadata:
-  name: gateways.gateway.networking.k8s.io
-spec:
-  group: gateway.networking.k8s.io
-  names:
-    kind: Gateway
-    plural: gateways
-  scope: Namespaced
-  versions:
-    - name: v1
-      served: true
-      storage: true
-      schema:
-        openAPIV3Schema:
-          type: object
-`
-
-	crds, err := ciliuminstaller.ParseGatewayAPICRDs([]byte(crdYAML))
-
-	require.NoError(t, err)
-	require.Len(t, crds, 1)
-	assert.Equal(t, "gateways.gateway.networking.k8s.io", crds[0].Name)
-}
-
-func TestParseGatewayAPICRDs_InvalidYAML(t *testing.T) {
-	t.Parallel()
-
-	// This document looks like a CRD (has kind: CustomResourceDefinition) but
-	// has an invalid spec field that will cause JSON unmarshal to fail.
-	invalidCRD := `apiVersion: apiextensions.k8s.io/v1
-kind: CustomResourceDefinition
-metadata:
-  name: test.example.com
-spec:
-  versions:
-    - name: true
-      served: not-a-bool
-      storage: not-a-bool
-      schema:
-        openAPIV3Schema:
-          type: object
-`
-
-	crds, err := ciliuminstaller.ParseGatewayAPICRDs([]byte(invalidCRD))
-
-	// The parser may return an error on invalid CRD structure, or silently
-	// skip it — either way the result should not be a valid CRD list.
-	if err != nil {
-		assert.Contains(t, err.Error(), "unmarshal")
-	} else {
-		// If no error, the CRD fields should be degraded/empty
-		for _, crd := range crds {
-			assert.Equal(t, "test.example.com", crd.Name)
-		}
-	}
-}
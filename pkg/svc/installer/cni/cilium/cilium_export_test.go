@@ -1,11 +1,7 @@
 //nolint:gochecknoglobals // export_test.go pattern requires global variables to expose internal functions
 package ciliuminstaller
 
-// GatewayAPICRDsURLForTest exposes gatewayAPICRDsURL for testing.
-var GatewayAPICRDsURLForTest = gatewayAPICRDsURL
-
-// GatewayAPICRDsVersionForTest exposes gatewayAPICRDsVersion for testing.
-var GatewayAPICRDsVersionForTest = gatewayAPICRDsVersion
+import v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 
 // DefaultCiliumValuesForTest exposes defaultCiliumValues for testing (haEnabled=false).
 var DefaultCiliumValuesForTest = func() map[string]string {
@@ -26,3 +22,15 @@ var TalosCiliumValuesForTest = talosCiliumValues
 
 // DockerCiliumValuesForTest exposes dockerCiliumValues for testing.
 var DockerCiliumValuesForTest = dockerCiliumValues
+
+// CiliumValuesForTest exposes getCiliumValues for an installer configured with
+// the given distribution, provider, and spec.cluster.cilium options.
+var CiliumValuesForTest = func(
+	distribution v1alpha1.Distribution,
+	provider v1alpha1.Provider,
+	options v1alpha1.OptionsCilium,
+) map[string]string {
+	inst := &Installer{distribution: distribution, provider: provider}
+
+	return inst.WithOptions(options).getCiliumValues()
+}
@@ -1,37 +1,14 @@
 package ciliuminstaller_test
 
 import (
-	"strings"
 	"testing"
 
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	ciliuminstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/cni/cilium"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestGatewayAPICRDsVersion_NonEmptyAndSemver(t *testing.T) {
-	t.Parallel()
-
-	version := ciliuminstaller.GatewayAPICRDsVersionForTest()
-
-	require.NotEmpty(t, version, "gateway API CRDs version should not be empty")
-	// Version should look like a semver (e.g., "1.2.0")
-	parts := strings.Split(version, ".")
-	assert.GreaterOrEqual(t, len(parts), 2, "version should have at least major.minor")
-}
-
-func TestGatewayAPICRDsURL_ContainsVersion(t *testing.T) {
-	t.Parallel()
-
-	url := ciliuminstaller.GatewayAPICRDsURLForTest()
-	version := ciliuminstaller.GatewayAPICRDsVersionForTest()
-
-	require.NotEmpty(t, url, "gateway API CRDs URL should not be empty")
-	assert.Contains(t, url, version, "URL should contain the version string")
-	assert.Contains(t, url, "experimental-install.yaml", "URL should reference experimental bundle")
-	assert.True(t, strings.HasPrefix(url, "https://"), "URL should use HTTPS")
-}
-
 func TestDefaultCiliumValues(t *testing.T) {
 	t.Parallel()
 
@@ -81,3 +58,52 @@ func TestDockerCiliumValues(t *testing.T) {
 	assert.Contains(t, values["envoy.securityContext.capabilities.envoy"], "NET_BIND_SERVICE")
 	assert.Contains(t, values["envoy.securityContext.capabilities.envoy"], "NET_ADMIN")
 }
+
+func TestCiliumValues_HubbleOption(t *testing.T) {
+	t.Parallel()
+
+	defaults := ciliuminstaller.CiliumValuesForTest(
+		v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker, v1alpha1.OptionsCilium{},
+	)
+	assert.NotContains(t, defaults, "hubble.enabled", "Default must keep the chart's Hubble defaults")
+
+	enabled := ciliuminstaller.CiliumValuesForTest(
+		v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker,
+		v1alpha1.OptionsCilium{Hubble: v1alpha1.HubbleEnabled},
+	)
+	assert.Equal(t, "true", enabled["hubble.enabled"])
+	assert.Equal(t, "true", enabled["hubble.relay.enabled"])
+
+	disabled := ciliuminstaller.CiliumValuesForTest(
+		v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker,
+		v1alpha1.OptionsCilium{Hubble: v1alpha1.HubbleDisabled},
+	)
+	assert.Equal(t, "false", disabled["hubble.enabled"])
+	assert.NotContains(t, disabled, "hubble.relay.enabled")
+}
+
+func TestCiliumValues_KubeProxyReplacementOption(t *testing.T) {
+	t.Parallel()
+
+	talosDefault := ciliuminstaller.CiliumValuesForTest(
+		v1alpha1.DistributionTalos, v1alpha1.ProviderDocker, v1alpha1.OptionsCilium{},
+	)
+	assert.Equal(t, "true", talosDefault["kubeProxyReplacement"])
+
+	vanillaDefault := ciliuminstaller.CiliumValuesForTest(
+		v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker, v1alpha1.OptionsCilium{},
+	)
+	assert.NotContains(t, vanillaDefault, "kubeProxyReplacement")
+
+	vanillaEnabled := ciliuminstaller.CiliumValuesForTest(
+		v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker,
+		v1alpha1.OptionsCilium{KubeProxyReplacement: v1alpha1.KubeProxyReplacementEnabled},
+	)
+	assert.Equal(t, "true", vanillaEnabled["kubeProxyReplacement"])
+
+	talosDisabled := ciliuminstaller.CiliumValuesForTest(
+		v1alpha1.DistributionTalos, v1alpha1.ProviderDocker,
+		v1alpha1.OptionsCilium{KubeProxyReplacement: v1alpha1.KubeProxyReplacementDisabled},
+	)
+	assert.Equal(t, "false", talosDisabled["kubeProxyReplacement"])
+}
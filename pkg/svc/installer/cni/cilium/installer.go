@@ -26,6 +26,7 @@ type Installer struct {
 	provider               v1alpha1.Provider
 	loadBalancer           v1alpha1.LoadBalancer
 	haEnabled              bool
+	options                v1alpha1.OptionsCilium
 	gatewayAPICRDInstaller GatewayAPICRDInstallerFunc
 	// apiServerChecker is called for distributions that may have an API server
 	// timing gap. It defaults to WaitForAPIServerStability and can be overridden
@@ -63,6 +64,14 @@ func NewInstaller(
 	return ciliumInstaller
 }
 
+// WithOptions applies the spec.cluster.cilium options (Hubble and kube-proxy
+// replacement) to the chart values and returns the installer for chaining.
+func (c *Installer) WithOptions(options v1alpha1.OptionsCilium) *Installer {
+	c.options = options
+
+	return c
+}
+
 // Install installs or upgrades Cilium via its Helm chart.
 func (c *Installer) Install(ctx context.Context) error {
 	err := c.PrepareInstall(ctx, c.distribution, c.apiServerChecker)
@@ -225,6 +234,35 @@ func (c *Installer) getCiliumValues() map[string]string {
 		// behaves like other cloud providers).
 	}
 
+	// User options are applied last so they override distribution defaults
+	// (e.g. kubeProxyReplacement on Talos).
+	maps.Copy(values, c.optionValues())
+
+	return values
+}
+
+// optionValues returns the Helm values derived from spec.cluster.cilium.
+// Default leaves the corresponding chart or distribution values untouched.
+func (c *Installer) optionValues() map[string]string {
+	values := map[string]string{}
+
+	switch c.options.Hubble {
+	case v1alpha1.HubbleEnabled:
+		values["hubble.enabled"] = "true"
+		values["hubble.relay.enabled"] = "true"
+	case v1alpha1.HubbleDisabled:
+		values["hubble.enabled"] = "false"
+	case "", v1alpha1.HubbleDefault:
+	}
+
+	switch c.options.KubeProxyReplacement {
+	case v1alpha1.KubeProxyReplacementEnabled:
+		values["kubeProxyReplacement"] = "true"
+	case v1alpha1.KubeProxyReplacementDisabled:
+		values["kubeProxyReplacement"] = "false"
+	case "", v1alpha1.KubeProxyReplacementDefault:
+	}
+
 	return values
 }
 
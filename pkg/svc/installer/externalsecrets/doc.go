@@ -0,0 +1,10 @@
+// Package externalsecretsinstaller provides an installer for the External
+// Secrets Operator selected by spec.cluster.externalSecrets.
+//
+// The operator syncs secrets from external stores (Vault, AWS Secrets Manager,
+// and others) into Kubernetes Secrets. It is installed as external-secrets in
+// the external-secrets namespace together with its CRDs, so the
+// ClusterSecretStore scaffolded by `ksail project init` can be applied.
+//
+// See: https://external-secrets.io
+package externalsecretsinstaller
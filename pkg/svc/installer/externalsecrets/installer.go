@@ -0,0 +1,60 @@
+package externalsecretsinstaller
+
+import (
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const (
+	repoName = "external-secrets"
+	repoURL  = "https://charts.external-secrets.io"
+
+	// Namespace is the namespace the External Secrets Operator is installed into.
+	Namespace = "external-secrets"
+	// ReleaseName is the Helm release name of the External Secrets Operator.
+	ReleaseName = "external-secrets"
+)
+
+// Installer installs or upgrades the External Secrets Operator.
+//
+// It embeds helmutil.Base to provide standard Helm chart lifecycle management.
+type Installer struct {
+	*helmutil.Base
+}
+
+// NewInstaller creates a new External Secrets Operator installer instance.
+func NewInstaller(client helm.Interface, timeout time.Duration) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"external-secrets",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: repoName,
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName:     ReleaseName,
+				ChartName:       repoName + "/external-secrets",
+				Namespace:       Namespace,
+				Version:         chartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      buildValuesYaml(),
+			},
+		),
+	}
+}
+
+// buildValuesYaml returns the Helm values YAML for the operator. The CRDs are
+// installed with the chart so a ClusterSecretStore can be applied right after
+// installation.
+func buildValuesYaml() string {
+	return "installCRDs: true"
+}
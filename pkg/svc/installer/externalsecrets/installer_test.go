@@ -0,0 +1,92 @@
+package externalsecretsinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	externalsecretsinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/externalsecrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildValuesYaml_InstallsCRDs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "installCRDs: true", externalsecretsinstaller.BuildValuesYaml())
+}
+
+func TestInstallerInstall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		installErr error
+	}{
+		{name: "success"},
+		{name: "install error", installErr: assert.AnError},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := helm.NewMockInterface(t)
+			installer := externalsecretsinstaller.NewInstaller(client, 5*time.Second)
+
+			client.EXPECT().
+				GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			client.EXPECT().
+				AddRepository(
+					mock.Anything,
+					mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+						return entry != nil &&
+							entry.URL == "https://charts.external-secrets.io"
+					}),
+					mock.Anything,
+				).
+				Return(nil)
+			client.EXPECT().
+				InstallOrUpgradeChart(
+					mock.Anything,
+					mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+						return spec != nil &&
+							spec.ReleaseName == externalsecretsinstaller.ReleaseName &&
+							spec.Namespace == externalsecretsinstaller.Namespace &&
+							spec.CreateNamespace &&
+							spec.Version != ""
+					}),
+				).
+				Return(nil, testCase.installErr)
+
+			err := installer.Install(context.Background())
+			if testCase.installErr != nil {
+				require.ErrorIs(t, err, testCase.installErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInstallerUninstall(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := externalsecretsinstaller.NewInstaller(client, 5*time.Second)
+
+	client.EXPECT().
+		UninstallRelease(
+			mock.Anything,
+			externalsecretsinstaller.ReleaseName,
+			externalsecretsinstaller.Namespace,
+		).
+		Return(nil)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+}
@@ -0,0 +1,16 @@
+package externalsecretsinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Chart.yaml
+var chartYAML string
+
+// chartVersion returns the pinned External Secrets Operator chart version extracted
+// from the embedded Chart.yaml (kept in sync by Dependabot's helm ecosystem).
+func chartVersion() string {
+	return parser.ParseChartVersionFromChartYaml(chartYAML, "external-secrets")
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	clusterdetector "github.com/devantler-tech/ksail/v7/pkg/svc/detector/cluster"
+	actionsrunnercontrollerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/actionsrunnercontroller"
 	argocdinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/argocd"
 	awslbcontrollerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/awslbcontroller"
 	certmanagerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/certmanager"
@@ -15,15 +17,28 @@ import (
 	clusterautoscalerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/clusterautoscaler"
 	calicoinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/cni/calico"
 	ciliuminstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/cni/cilium"
+	externalsecretsinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/externalsecrets"
 	fluxinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/flux"
 	gatekeeperinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/gatekeeper"
+	gatewayapiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/gatewayapi"
 	hcloudccminstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/hcloudccm"
 	hetznercsiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/hetznercsi"
+	contourinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress/contour"
+	nginxinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress/nginx"
+	traefikinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress/traefik"
+	istioinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/istio"
+	keycloakinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/keycloak"
+	knativeinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/knative"
 	kubeletcsrapproverinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/kubeletcsrapprover"
 	kyvernoinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/kyverno"
+	linkerdinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/linkerd"
 	localpathstorageinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/localpathstorage"
+	lokiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/loki"
 	metallbinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/metallb"
 	metricsserverinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/metricsserver"
+	nvidiadeviceplugininstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/nvidiadeviceplugin"
+	sealedsecretsinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/sealedsecrets"
+	veleroinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/velero"
 )
 
 // Factory creates installers based on cluster configuration.
@@ -100,8 +115,19 @@ func (f *Factory) CreateInstallersForConfig(cfg *v1alpha1.Cluster) (map[string]I
 	f.addGitOpsInstaller(installers, spec, cfg.Spec.Workload.Flux.OperatorVersion, haEnabled)
 	f.addCNIInstaller(installers, spec, haEnabled)
 	f.addPolicyEngineInstaller(installers, spec, haEnabled)
+	f.addIngressControllerInstaller(installers, spec, haEnabled)
+	f.addGatewayAPIInstaller(installers, spec)
+	f.addServiceMeshInstaller(installers, spec)
+	f.addKnativeInstaller(installers, spec)
+	f.addIdentityProviderInstaller(installers, spec)
 	f.addCertManagerInstaller(installers, spec, haEnabled)
+	f.addLoggingInstaller(installers, spec)
+	f.addSealedSecretsInstaller(installers, spec)
+	f.addExternalSecretsInstaller(installers, spec)
+	f.addBackupInstaller(installers, spec)
 	f.addMetricsServerInstaller(installers, spec, haEnabled)
+	f.addNVIDIADevicePluginInstaller(installers, spec)
+	f.addActionsRunnerInstaller(installers, spec)
 	f.addCSIInstallers(installers, cfg, haEnabled)
 
 	err := f.addLoadBalancerInstaller(installers, cfg, haEnabled)
@@ -190,7 +216,7 @@ func (f *Factory) addCNIInstaller(
 		installers["cilium"] = ciliuminstaller.NewInstaller(
 			f.helmClient, f.kubeconfig, f.kubecontext, f.timeout,
 			f.distribution, spec.Provider, spec.LoadBalancer, haEnabled,
-		)
+		).WithOptions(spec.Cilium)
 	case v1alpha1.CNICalico:
 		installers["calico"] = calicoinstaller.NewInstaller(
 			f.helmClient, f.kubeconfig, f.kubecontext,
@@ -228,6 +254,183 @@ func (f *Factory) addPolicyEngineInstaller(
 	}
 }
 
+func (f *Factory) addIngressControllerInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+	haEnabled bool,
+) {
+	if !spec.IngressController.Enabled() {
+		return
+	}
+
+	installers[IngressControllerComponentName(spec.IngressController)] = NewIngressControllerInstaller(
+		f.helmClient, f.timeout, spec.IngressController, spec.Provider.NeedsLocalDocker(), haEnabled,
+	)
+}
+
+// IngressControllerComponentName returns the component (and Helm release) name of
+// the given ingress controller, or "" when none is selected.
+func IngressControllerComponentName(controller v1alpha1.IngressController) string {
+	switch controller {
+	case v1alpha1.IngressControllerNginx:
+		return "ingress-nginx"
+	case v1alpha1.IngressControllerTraefik:
+		return "traefik"
+	case v1alpha1.IngressControllerContour:
+		return "contour"
+	case v1alpha1.IngressControllerNone:
+		return ""
+	}
+
+	return ""
+}
+
+// NewIngressControllerInstaller creates the installer for the selected ingress
+// controller, or nil when none is selected. exposeNodePorts pins the controller
+// Service to the shared ingress node ports (Docker provider) instead of
+// requesting a LoadBalancer.
+func NewIngressControllerInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+	controller v1alpha1.IngressController,
+	exposeNodePorts, haEnabled bool,
+) Installer {
+	switch controller {
+	case v1alpha1.IngressControllerNginx:
+		return nginxinstaller.NewInstaller(client, timeout, exposeNodePorts, haEnabled)
+	case v1alpha1.IngressControllerTraefik:
+		return traefikinstaller.NewInstaller(client, timeout, exposeNodePorts, haEnabled)
+	case v1alpha1.IngressControllerContour:
+		return contourinstaller.NewInstaller(client, timeout, exposeNodePorts, haEnabled)
+	case v1alpha1.IngressControllerNone:
+		return nil
+	}
+
+	return nil
+}
+
+func (f *Factory) addServiceMeshInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if !spec.ServiceMesh.Enabled() {
+		return
+	}
+
+	installers[ServiceMeshComponentName(spec.ServiceMesh)] = NewServiceMeshInstaller(
+		f.helmClient, f.timeout, spec, f.kubeconfig, f.kubecontext,
+	)
+}
+
+// ServiceMeshComponentName returns the component name of the given service
+// mesh, or "" when none is selected.
+func ServiceMeshComponentName(mesh v1alpha1.ServiceMesh) string {
+	switch mesh {
+	case v1alpha1.ServiceMeshIstio:
+		return "istio"
+	case v1alpha1.ServiceMeshLinkerd:
+		return "linkerd"
+	case v1alpha1.ServiceMeshNone:
+		return ""
+	}
+
+	return ""
+}
+
+// NewServiceMeshInstaller creates the installer for the service mesh selected
+// in spec, or nil when none is selected. kubeconfig and kubeContext let the
+// Linkerd installer keep the trust anchor of an existing installation.
+func NewServiceMeshInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+	spec v1alpha1.ClusterSpec,
+	kubeconfig, kubeContext string,
+) Installer {
+	switch spec.ServiceMesh {
+	case v1alpha1.ServiceMeshIstio:
+		return istioinstaller.NewInstaller(
+			client, timeout, spec.Istio.Ambient(), IstioPlatform(spec),
+		)
+	case v1alpha1.ServiceMeshLinkerd:
+		return linkerdinstaller.NewInstaller(client, timeout, kubeconfig, kubeContext)
+	case v1alpha1.ServiceMeshNone:
+		return nil
+	}
+
+	return nil
+}
+
+// IstioPlatform returns the Istio global.platform value for the cluster: "k3d"
+// for K3s on Docker, whose CNI directories differ from the chart defaults, and
+// "" otherwise.
+func IstioPlatform(spec v1alpha1.ClusterSpec) string {
+	if spec.Distribution == v1alpha1.DistributionK3s && spec.Provider.NeedsLocalDocker() {
+		return "k3d"
+	}
+
+	return ""
+}
+
+func (f *Factory) addKnativeInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if spec.Knative == v1alpha1.KnativeEnabled {
+		installers["knative"] = knativeinstaller.NewInstaller(
+			f.helmClient,
+			f.kubeconfig,
+			f.kubecontext,
+			f.timeout,
+			spec.Provider.NeedsLocalDocker(),
+		)
+	}
+}
+
+func (f *Factory) addIdentityProviderInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if spec.IdentityProvider == v1alpha1.IdentityProviderKeycloak {
+		installers["keycloak"] = keycloakinstaller.NewInstaller(
+			f.kubeconfig,
+			f.kubecontext,
+			f.timeout,
+		)
+	}
+}
+
+// GatewayAPIComponentName is the component name of the Gateway API installer.
+const GatewayAPIComponentName = "gateway-api"
+
+func (f *Factory) addGatewayAPIInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if !spec.GatewayAPI.NeedsInstall() {
+		return
+	}
+
+	installers[GatewayAPIComponentName] = NewGatewayAPIInstaller(
+		f.helmClient, f.kubeconfig, f.kubecontext, f.timeout, spec.GatewayAPI,
+	)
+}
+
+// NewGatewayAPIInstaller creates the installer for the selected Gateway API
+// option, or nil when the option installs nothing (None, or Cilium whose
+// controller ships with the Cilium CNI).
+func NewGatewayAPIInstaller(
+	client helm.Interface,
+	kubeconfig, kubecontext string,
+	timeout time.Duration,
+	gatewayAPI v1alpha1.GatewayAPI,
+) Installer {
+	if !gatewayAPI.NeedsInstall() {
+		return nil
+	}
+
+	return gatewayapiinstaller.NewInstaller(client, kubeconfig, kubecontext, timeout, gatewayAPI)
+}
+
 func (f *Factory) addCertManagerInstaller(
 	installers map[string]Installer,
 	spec v1alpha1.ClusterSpec,
@@ -240,6 +443,60 @@ func (f *Factory) addCertManagerInstaller(
 	}
 }
 
+func (f *Factory) addLoggingInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if spec.Observability.Logging == v1alpha1.LoggingLoki {
+		installers["loki"] = lokiinstaller.NewInstaller(f.helmClient, f.timeout)
+	}
+}
+
+func (f *Factory) addSealedSecretsInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if spec.SealedSecrets == v1alpha1.SealedSecretsEnabled {
+		installers["sealed-secrets"] = sealedsecretsinstaller.NewInstaller(f.helmClient, f.timeout)
+	}
+}
+
+func (f *Factory) addExternalSecretsInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if spec.ExternalSecrets == v1alpha1.ExternalSecretsEnabled {
+		installers["external-secrets"] = externalsecretsinstaller.NewInstaller(
+			f.helmClient,
+			f.timeout,
+		)
+	}
+}
+
+func (f *Factory) addBackupInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if spec.Backup != v1alpha1.BackupVelero {
+		return
+	}
+
+	clusterName := f.distribution.DefaultClusterName()
+
+	_, contextClusterName, err := clusterdetector.DetectDistributionFromContext(f.kubecontext)
+	if err == nil && contextClusterName != "" {
+		clusterName = contextClusterName
+	}
+
+	installers["velero"] = veleroinstaller.NewInstaller(
+		f.helmClient,
+		f.dockerClient,
+		f.timeout,
+		clusterName,
+		veleroinstaller.DockerNetworkName(f.distribution, clusterName),
+	)
+}
+
 func (f *Factory) addMetricsServerInstaller(
 	installers map[string]Installer,
 	spec v1alpha1.ClusterSpec,
@@ -254,6 +511,42 @@ func (f *Factory) addMetricsServerInstaller(
 	}
 }
 
+func (f *Factory) addNVIDIADevicePluginInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if spec.RequestsGPUs() {
+		installers[nvidiadeviceplugininstaller.ReleaseName] = nvidiadeviceplugininstaller.NewInstaller(
+			f.helmClient, f.timeout,
+		)
+	}
+}
+
+// addActionsRunnerInstaller adds actions-runner-controller without a GitHub token: the
+// factory only needs it to list images and to uninstall. The CLI resolves the token from
+// the credential store when it installs the runner.
+func (f *Factory) addActionsRunnerInstaller(
+	installers map[string]Installer,
+	spec v1alpha1.ClusterSpec,
+) {
+	if !spec.ActionsRunner.Enabled || spec.Distribution == v1alpha1.DistributionKWOK {
+		return
+	}
+
+	runner := spec.ActionsRunner
+	installers["actions-runner-controller"] = actionsrunnercontrollerinstaller.NewInstaller(
+		f.helmClient,
+		f.timeout,
+		actionsrunnercontrollerinstaller.Options{
+			GitHubConfigURL: runner.GitHubConfigURL,
+			Name:            runner.EffectiveName(),
+			Labels:          runner.Labels,
+			MinRunners:      runner.MinRunners,
+			MaxRunners:      runner.MaxRunners,
+		},
+	)
+}
+
 func (f *Factory) addCSIInstallers(
 	installers map[string]Installer,
 	cfg *v1alpha1.Cluster,
@@ -235,6 +235,314 @@ func TestFactory_CreateInstallersForConfig_NoPolicyEngine(t *testing.T) {
 	assert.NotContains(t, installers, "gatekeeper")
 }
 
+func TestFactory_CreateInstallersForConfig_IngressController(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		controller  v1alpha1.IngressController
+		expectedKey string
+	}{
+		{name: "nginx", controller: v1alpha1.IngressControllerNginx, expectedKey: "ingress-nginx"},
+		{name: "traefik", controller: v1alpha1.IngressControllerTraefik, expectedKey: "traefik"},
+		{name: "contour", controller: v1alpha1.IngressControllerContour, expectedKey: "contour"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+			cfg := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+				clusterSpec.IngressController = testCase.controller
+			})
+
+			installers, err := factory.CreateInstallersForConfig(cfg)
+			require.NoError(t, err)
+
+			assert.Contains(t, installers, testCase.expectedKey)
+			assert.Equal(
+				t, testCase.expectedKey, installer.IngressControllerComponentName(testCase.controller),
+			)
+		})
+	}
+}
+
+func TestFactory_CreateInstallersForConfig_NoIngressController(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+	cfg := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.IngressController = v1alpha1.IngressControllerNone
+	})
+
+	installers, err := factory.CreateInstallersForConfig(cfg)
+	require.NoError(t, err)
+
+	assert.Empty(t, installers)
+	assert.Nil(t, installer.NewIngressControllerInstaller(
+		nil, time.Minute, v1alpha1.IngressControllerNone, true, false,
+	))
+}
+
+func TestFactory_CreateInstallersForConfig_ServiceMesh(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mesh        v1alpha1.ServiceMesh
+		expectedKey string
+	}{
+		{name: "istio", mesh: v1alpha1.ServiceMeshIstio, expectedKey: "istio"},
+		{name: "linkerd", mesh: v1alpha1.ServiceMeshLinkerd, expectedKey: "linkerd"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+			cfg := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+				clusterSpec.ServiceMesh = testCase.mesh
+			})
+
+			installers, err := factory.CreateInstallersForConfig(cfg)
+			require.NoError(t, err)
+
+			assert.Contains(t, installers, testCase.expectedKey)
+			assert.Equal(t, testCase.expectedKey, installer.ServiceMeshComponentName(testCase.mesh))
+		})
+	}
+
+	assert.Nil(t, installer.NewServiceMeshInstaller(
+		nil, time.Minute, v1alpha1.ClusterSpec{ServiceMesh: v1alpha1.ServiceMeshNone}, "", "",
+	))
+}
+
+func TestIstioPlatform(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "k3d", installer.IstioPlatform(v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionK3s,
+		Provider:     v1alpha1.ProviderDocker,
+	}))
+	assert.Empty(t, installer.IstioPlatform(v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionVanilla,
+		Provider:     v1alpha1.ProviderDocker,
+	}))
+}
+
+func TestFactory_CreateInstallersForConfig_Logging(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	withLoki := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Observability.Logging = v1alpha1.LoggingLoki
+	})
+
+	installers, err := factory.CreateInstallersForConfig(withLoki)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "loki")
+
+	withoutLoki := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Observability.Logging = v1alpha1.LoggingNone
+	})
+
+	installers, err = factory.CreateInstallersForConfig(withoutLoki)
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "loki")
+}
+
+func TestFactory_CreateInstallersForConfig_SealedSecrets(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	enabled := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.SealedSecrets = v1alpha1.SealedSecretsEnabled
+	})
+
+	installers, err := factory.CreateInstallersForConfig(enabled)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "sealed-secrets")
+
+	disabled := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.SealedSecrets = v1alpha1.SealedSecretsDisabled
+	})
+
+	installers, err = factory.CreateInstallersForConfig(disabled)
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "sealed-secrets")
+}
+
+func TestFactory_CreateInstallersForConfig_NVIDIADevicePlugin(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	withGPUs := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Nodes = []v1alpha1.NodeSpec{
+			{Role: v1alpha1.NodeRoleWorker, GPUs: v1alpha1.GPUsAll},
+		}
+	})
+
+	installers, err := factory.CreateInstallersForConfig(withGPUs)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "nvidia-device-plugin")
+
+	withoutGPUs := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Nodes = []v1alpha1.NodeSpec{{Role: v1alpha1.NodeRoleWorker}}
+	})
+
+	installers, err = factory.CreateInstallersForConfig(withoutGPUs)
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "nvidia-device-plugin")
+}
+
+func TestFactory_CreateInstallersForConfig_ActionsRunner(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	enabled := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.ActionsRunner = v1alpha1.ActionsRunnerSpec{
+			Enabled:         true,
+			GitHubConfigURL: "https://github.com/devantler-tech/ksail",
+		}
+	})
+
+	installers, err := factory.CreateInstallersForConfig(enabled)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "actions-runner-controller")
+
+	installers, err = factory.CreateInstallersForConfig(newTestCluster())
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "actions-runner-controller")
+}
+
+func TestFactory_CreateInstallersForConfig_Knative(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	enabled := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Knative = v1alpha1.KnativeEnabled
+	})
+
+	installers, err := factory.CreateInstallersForConfig(enabled)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "knative")
+
+	disabled := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Knative = v1alpha1.KnativeDisabled
+	})
+
+	installers, err = factory.CreateInstallersForConfig(disabled)
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "knative")
+}
+
+func TestFactory_CreateInstallersForConfig_IdentityProvider(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	keycloak := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.IdentityProvider = v1alpha1.IdentityProviderKeycloak
+	})
+
+	installers, err := factory.CreateInstallersForConfig(keycloak)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "keycloak")
+
+	installers, err = factory.CreateInstallersForConfig(newTestCluster())
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "keycloak")
+}
+
+func TestFactory_CreateInstallersForConfig_ExternalSecrets(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	enabled := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.ExternalSecrets = v1alpha1.ExternalSecretsEnabled
+	})
+
+	installers, err := factory.CreateInstallersForConfig(enabled)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "external-secrets")
+
+	disabled := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.ExternalSecrets = v1alpha1.ExternalSecretsDisabled
+	})
+
+	installers, err = factory.CreateInstallersForConfig(disabled)
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "external-secrets")
+}
+
+func TestFactory_CreateInstallersForConfig_Backup(t *testing.T) {
+	t.Parallel()
+
+	factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+
+	velero := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Backup = v1alpha1.BackupVelero
+	})
+
+	installers, err := factory.CreateInstallersForConfig(velero)
+	require.NoError(t, err)
+	assert.Contains(t, installers, "velero")
+
+	none := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+		clusterSpec.Backup = v1alpha1.BackupNone
+	})
+
+	installers, err = factory.CreateInstallersForConfig(none)
+	require.NoError(t, err)
+	assert.NotContains(t, installers, "velero")
+}
+
+func TestFactory_CreateInstallersForConfig_GatewayAPI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		gatewayAPI v1alpha1.GatewayAPI
+		expected   bool
+	}{
+		{name: "none", gatewayAPI: v1alpha1.GatewayAPINone},
+		{name: "crds", gatewayAPI: v1alpha1.GatewayAPICRDs, expected: true},
+		{name: "cilium", gatewayAPI: v1alpha1.GatewayAPICilium},
+		{name: "envoy", gatewayAPI: v1alpha1.GatewayAPIEnvoy, expected: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := newTestFactory(t, v1alpha1.DistributionVanilla)
+			cfg := newTestCluster(func(clusterSpec *v1alpha1.ClusterSpec) {
+				clusterSpec.GatewayAPI = testCase.gatewayAPI
+			})
+
+			installers, err := factory.CreateInstallersForConfig(cfg)
+			require.NoError(t, err)
+
+			if testCase.expected {
+				assert.Contains(t, installers, installer.GatewayAPIComponentName)
+			} else {
+				assert.NotContains(t, installers, installer.GatewayAPIComponentName)
+				assert.Nil(t, installer.NewGatewayAPIInstaller(
+					nil, "", "", time.Minute, testCase.gatewayAPI,
+				))
+			}
+		})
+	}
+}
+
 func TestFactory_CreateInstallersForConfig_CertManager(t *testing.T) {
 	t.Parallel()
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/sopsutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -58,6 +59,11 @@ func BuildRegistrySecret(clusterCfg *v1alpha1.Cluster) (*corev1.Secret, error) {
 	return buildRegistrySecret(clusterCfg)
 }
 
+// BuildDeployKeySecret exports buildDeployKeySecret for testing.
+func BuildDeployKeySecret(key deploykey.Key, knownHosts []byte) *corev1.Secret {
+	return buildDeployKeySecret(key, knownHosts)
+}
+
 // IsTransientAPIError exports isTransientAPIError for testing.
 func IsTransientAPIError(err error) bool {
 	return isTransientAPIError(err)
@@ -290,6 +290,21 @@ func buildInstance(
 
 	intervalPtr := &metav1.Duration{Duration: fluxIntervalFallback}
 
+	sync := &Sync{
+		Kind:       fluxOCIRepositoryKind,
+		URL:        repoURL,
+		Ref:        tag,
+		Path:       normalizeFluxPath(clusterCfg.Spec.Workload.KustomizationFile),
+		Provider:   "generic",
+		Interval:   intervalPtr,
+		PullSecret: pullSecret,
+	}
+
+	// A configured Git source (ksail init --push-to) replaces the OCI artifact entirely.
+	if clusterCfg.Spec.Workload.Git.IsEnabled() {
+		sync = buildGitSync(clusterCfg)
+	}
+
 	return &FluxInstance{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: fluxInstanceGroupVersion.String(),
@@ -305,15 +320,7 @@ func buildInstance(
 				Registry: fluxDistributionRegistry,
 				Artifact: distributionArtifact(),
 			},
-			Sync: &Sync{
-				Kind:       fluxOCIRepositoryKind,
-				URL:        repoURL,
-				Ref:        tag,
-				Path:       normalizeFluxPath(clusterCfg.Spec.Workload.KustomizationFile),
-				Provider:   "generic",
-				Interval:   intervalPtr,
-				PullSecret: pullSecret,
-			},
+			Sync: sync,
 		},
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 const (
 	fluxInstanceKind        = "FluxInstance"
 	fluxOCIRepositoryKind   = "OCIRepository"
+	fluxGitRepositoryKind   = "GitRepository"
 	fluxInstanceDefaultName = "flux"
 	fluxInstanceGroup       = "fluxcd.controlplane.io"
 	fluxInstanceVersion     = "v1"
@@ -0,0 +1,161 @@
+package fluxinstaller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/deploykey"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// GitCredentialsSecretName is the name of the secret Flux uses to clone a private
+	// spec.workload.git repository: basic auth for a token, or the SSH identity and known
+	// hosts for a deploy key. It is only created when either is configured.
+	//nolint:gosec // not credentials, just a secret name constant
+	GitCredentialsSecretName = "ksail-git-credentials"
+
+	// gitTokenUsername is the username paired with an access token for HTTPS git auth. GitHub,
+	// GitLab and Gitea all accept any non-empty username when the password is a token.
+	gitTokenUsername = "git"
+)
+
+// buildGitSync returns the FluxInstance sync block for a spec.workload.git source. Unlike the
+// OCI artifact (whose root is the source directory), a Git checkout contains the whole
+// repository, so the sync path is prefixed with spec.workload.sourceDirectory.
+func buildGitSync(clusterCfg *v1alpha1.Cluster) *Sync {
+	git := clusterCfg.Spec.Workload.Git
+
+	sourceDir := strings.TrimSpace(clusterCfg.Spec.Workload.SourceDirectory)
+	if sourceDir == "" {
+		sourceDir = defaultSourceDirectory
+	}
+
+	kustomizationPath := strings.TrimPrefix(
+		normalizeFluxPath(clusterCfg.Spec.Workload.KustomizationFile), "./",
+	)
+
+	var pullSecret string
+	if git.DeployKey || git.ResolveToken() != "" {
+		pullSecret = GitCredentialsSecretName
+	}
+
+	return &Sync{
+		Kind:       fluxGitRepositoryKind,
+		URL:        git.CloneURL(),
+		Ref:        "refs/heads/" + git.ResolvedBranch(),
+		Path:       normalizeFluxPath(path.Join(sourceDir, kustomizationPath)),
+		Provider:   "generic",
+		Interval:   &metav1.Duration{Duration: fluxIntervalFallback},
+		PullSecret: pullSecret,
+	}
+}
+
+// ensureGitCredentialsSecret creates or updates the secret Flux uses to clone a private
+// spec.workload.git repository. It is a no-op when no git source, token or deploy key is
+// configured.
+func ensureGitCredentialsSecret(
+	ctx context.Context,
+	restConfig *rest.Config,
+	clusterCfg *v1alpha1.Cluster,
+) error {
+	git := clusterCfg.Spec.Workload.Git
+	if !git.IsEnabled() {
+		return nil
+	}
+
+	var secret *corev1.Secret
+
+	switch token := git.ResolveToken(); {
+	case git.DeployKey:
+		var err error
+
+		secret, err = loadDeployKeySecret(ctx, git)
+		if err != nil {
+			return err
+		}
+	case token != "":
+		secret = buildGitCredentialsSecret(token)
+	default:
+		return nil
+	}
+
+	k8sClient, err := newCoreV1Client(restConfig)
+	if err != nil {
+		return err
+	}
+
+	err = upsertSecret(ctx, k8sClient, secret)
+	if err != nil {
+		return fmt.Errorf("failed to create git credentials secret: %w", err)
+	}
+
+	return nil
+}
+
+// loadDeployKeySecret reads the repository's deploy key from the KSail deploy key store and
+// scans the Git host's SSH host key, which Flux requires to verify the server.
+func loadDeployKeySecret(ctx context.Context, git v1alpha1.GitSource) (*corev1.Secret, error) {
+	dir, err := deploykey.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := deploykey.Load(dir, git.URL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w (generate and register it with 'ksail workload deploy-key')", err,
+		)
+	}
+
+	knownHosts, err := deploykey.KnownHosts(ctx, git.CloneURL())
+	if err != nil {
+		return nil, fmt.Errorf("failed to trust the git host: %w", err)
+	}
+
+	return buildDeployKeySecret(key, knownHosts), nil
+}
+
+// buildGitCredentialsSecret creates the Secret object for Git HTTPS authentication.
+func buildGitCredentialsSecret(token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GitCredentialsSecretName,
+			Namespace: fluxclient.DefaultNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "ksail",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": []byte(gitTokenUsername),
+			"password": []byte(token),
+		},
+	}
+}
+
+// buildDeployKeySecret creates the Secret object for Git SSH authentication in the format
+// Flux's source-controller reads: the private key, its public key and the host's known_hosts.
+func buildDeployKeySecret(key deploykey.Key, knownHosts []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GitCredentialsSecretName,
+			Namespace: fluxclient.DefaultNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "ksail",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"identity":     key.PrivateKeyPEM,
+			"identity.pub": []byte(key.AuthorizedKey + "\n"),
+			"known_hosts":  knownHosts,
+		},
+	}
+}
@@ -95,6 +95,12 @@ func setupFluxCore(ctx context.Context, params setupParams) error {
 		return err
 	}
 
+	// For a private spec.workload.git source, create the clone credentials before FluxInstance
+	err = ensureGitCredentialsSecret(ctx, params.restConfig, params.clusterCfg)
+	if err != nil {
+		return err
+	}
+
 	// Setup FluxInstance
 	fluxMgr := newFluxInstanceManager(
 		params.restConfig,
@@ -123,6 +129,11 @@ func setupFluxCore(ctx context.Context, params setupParams) error {
 		return err
 	}
 
+	// A Git source syncs through a GitRepository; there is no OCIRepository to patch.
+	if params.clusterCfg.Spec.Workload.Git.IsEnabled() {
+		return nil
+	}
+
 	// Wait for OCIRepository API to be available before patching
 	ociPatcher := newOCIRepositoryPatcher(
 		params.restConfig,
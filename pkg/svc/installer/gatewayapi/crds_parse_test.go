@@ -0,0 +1,141 @@
+package gatewayapiinstaller_test
+
+import (
+	"testing"
+
+	gatewayapiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/gatewayapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGatewayAPICRDs_ValidCRD(t *testing.T) {
+	t.Parallel()
+
+	validCRD := `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: test-valid.example.com
+spec:
+  group: example.com
+  names:
+    kind: TestValid
+    plural: testvalids
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+`
+	crds, err := gatewayapiinstaller.ParseCRDs([]byte(validCRD))
+	require.NoError(t, err)
+	assert.Len(t, crds, 1)
+	assert.Equal(t, "test-valid.example.com", crds[0].Name)
+}
+
+func TestParseGatewayAPICRDs_YAMLSeparatorsOnly(t *testing.T) {
+	t.Parallel()
+
+	crds, err := gatewayapiinstaller.ParseCRDs([]byte("---\n---\n---\n"))
+	require.NoError(t, err)
+	assert.Empty(t, crds)
+}
+
+func TestParseGatewayAPICRDs_NonCRDKindsFiltered(t *testing.T) {
+	t.Parallel()
+
+	bundle := `---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: gateway-sa
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: gateway-svc
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: gateway-deploy
+`
+	crds, err := gatewayapiinstaller.ParseCRDs([]byte(bundle))
+	require.NoError(t, err)
+	assert.Empty(t, crds)
+}
+
+func TestParseGatewayAPICRDs_EmptyInput(t *testing.T) {
+	t.Parallel()
+
+	crds, err := gatewayapiinstaller.ParseCRDs([]byte(""))
+	require.NoError(t, err)
+	assert.Empty(t, crds)
+}
+
+func TestParseGatewayAPICRDs_SingleDocument(t *testing.T) {
+	t.Parallel()
+
+	// Verify that ParseCRDs can handle a simple valid CRD,
+	// which confirms the parsing pipeline works.
+	crdYAML := `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.gateway.networking.k8s.io
+spec:
+  group: gateway.networking.k8s.io
+  names:
+    kind: Gateway
+    plural: gateways
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+`
+
+	crds, err := gatewayapiinstaller.ParseCRDs([]byte(crdYAML))
+
+	require.NoError(t, err)
+	require.Len(t, crds, 1)
+	assert.Equal(t, "gateways.gateway.networking.k8s.io", crds[0].Name)
+}
+
+func TestParseGatewayAPICRDs_InvalidYAML(t *testing.T) {
+	t.Parallel()
+
+	// This document looks like a CRD (has kind: CustomResourceDefinition) but
+	// has an invalid spec field that will cause JSON unmarshal to fail.
+	invalidCRD := `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: test.example.com
+spec:
+  versions:
+    - name: true
+      served: not-a-bool
+      storage: not-a-bool
+      schema:
+        openAPIV3Schema:
+          type: object
+`
+
+	crds, err := gatewayapiinstaller.ParseCRDs([]byte(invalidCRD))
+
+	// The parser may return an error on invalid CRD structure, or silently
+	// skip it — either way the result should not be a valid CRD list.
+	if err != nil {
+		assert.Contains(t, err.Error(), "unmarshal")
+	} else {
+		// If no error, the CRD fields should be degraded/empty
+		for _, crd := range crds {
+			assert.Equal(t, "test.example.com", crd.Name)
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package ciliuminstaller_test
+package gatewayapiinstaller_test
 
 import (
 	"context"
@@ -8,7 +8,7 @@ import (
 	"testing"
 	"time"
 
-	ciliuminstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/cni/cilium"
+	gatewayapiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/gatewayapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -53,7 +53,7 @@ func TestFetchGatewayAPICRDsWithRetry_RetriesTransientHTTPStatus(t *testing.T) {
 	)
 	defer server.Close()
 
-	crds, err := ciliuminstaller.FetchGatewayAPICRDsWithRetryForTest(
+	crds, err := gatewayapiinstaller.FetchCRDsWithRetry(
 		context.Background(),
 		server.URL,
 		time.Second,
@@ -80,7 +80,7 @@ func TestFetchGatewayAPICRDsWithRetry_DoesNotRetryNonRetryableStatus(t *testing.
 	)
 	defer server.Close()
 
-	_, err := ciliuminstaller.FetchGatewayAPICRDsWithRetryForTest(
+	_, err := gatewayapiinstaller.FetchCRDsWithRetry(
 		context.Background(),
 		server.URL,
 		time.Second,
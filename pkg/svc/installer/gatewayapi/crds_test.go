@@ -1,13 +1,14 @@
-package ciliuminstaller_test
+package gatewayapiinstaller_test
 
 import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
-	ciliuminstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/cni/cilium"
+	gatewayapiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/gatewayapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,7 +82,7 @@ func TestParseGatewayAPICRDs(t *testing.T) {
 	t.Run("parses multiple CRDs from bundle", func(t *testing.T) {
 		t.Parallel()
 
-		crds, err := ciliuminstaller.ParseGatewayAPICRDs([]byte(sampleCRDBundle))
+		crds, err := gatewayapiinstaller.ParseCRDs([]byte(sampleCRDBundle))
 
 		require.NoError(t, err)
 		require.Len(t, crds, 2)
@@ -92,7 +93,7 @@ func TestParseGatewayAPICRDs(t *testing.T) {
 	t.Run("returns empty slice for empty input", func(t *testing.T) {
 		t.Parallel()
 
-		crds, err := ciliuminstaller.ParseGatewayAPICRDs([]byte(""))
+		crds, err := gatewayapiinstaller.ParseCRDs([]byte(""))
 
 		require.NoError(t, err)
 		assert.Empty(t, crds)
@@ -101,7 +102,7 @@ func TestParseGatewayAPICRDs(t *testing.T) {
 	t.Run("skips non-CRD documents", func(t *testing.T) {
 		t.Parallel()
 
-		crds, err := ciliuminstaller.ParseGatewayAPICRDs([]byte(sampleMixedBundle))
+		crds, err := gatewayapiinstaller.ParseCRDs([]byte(sampleMixedBundle))
 
 		require.NoError(t, err)
 		require.Len(t, crds, 1)
@@ -111,7 +112,7 @@ func TestParseGatewayAPICRDs(t *testing.T) {
 	t.Run("handles whitespace-only documents", func(t *testing.T) {
 		t.Parallel()
 
-		crds, err := ciliuminstaller.ParseGatewayAPICRDs([]byte("---\n\n---\n  \n"))
+		crds, err := gatewayapiinstaller.ParseCRDs([]byte("---\n\n---\n  \n"))
 
 		require.NoError(t, err)
 		assert.Empty(t, crds)
@@ -127,7 +128,7 @@ func TestFetchGatewayAPICRDs(t *testing.T) {
 		server := newCRDServer(http.StatusOK, sampleCRDBundle)
 		defer server.Close()
 
-		crds, err := ciliuminstaller.FetchGatewayAPICRDs(
+		crds, err := gatewayapiinstaller.FetchCRDs(
 			context.Background(), server.URL, 5*time.Second,
 		)
 
@@ -142,7 +143,7 @@ func TestFetchGatewayAPICRDs(t *testing.T) {
 		server := newCRDServer(http.StatusNotFound, "")
 		defer server.Close()
 
-		_, err := ciliuminstaller.FetchGatewayAPICRDs(
+		_, err := gatewayapiinstaller.FetchCRDs(
 			context.Background(), server.URL, 5*time.Second,
 		)
 
@@ -155,7 +156,7 @@ func TestFetchGatewayAPICRDs(t *testing.T) {
 	t.Run("returns error on connection failure", func(t *testing.T) {
 		t.Parallel()
 
-		_, err := ciliuminstaller.FetchGatewayAPICRDs(
+		_, err := gatewayapiinstaller.FetchCRDs(
 			context.Background(), "http://127.0.0.1:0/nonexistent", 5*time.Second,
 		)
 
@@ -172,7 +173,7 @@ func TestFetchGatewayAPICRDs(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
 
-		_, err := ciliuminstaller.FetchGatewayAPICRDs(
+		_, err := gatewayapiinstaller.FetchCRDs(
 			ctx, server.URL, 5*time.Second,
 		)
 
@@ -180,6 +181,29 @@ func TestFetchGatewayAPICRDs(t *testing.T) {
 	})
 }
 
+func TestGatewayAPICRDsVersion_NonEmptyAndSemver(t *testing.T) {
+	t.Parallel()
+
+	version := gatewayapiinstaller.CRDsVersion()
+
+	require.NotEmpty(t, version, "gateway API CRDs version should not be empty")
+	// Version should look like a semver (e.g., "1.2.0")
+	parts := strings.Split(version, ".")
+	assert.GreaterOrEqual(t, len(parts), 2, "version should have at least major.minor")
+}
+
+func TestGatewayAPICRDsURL_ContainsVersion(t *testing.T) {
+	t.Parallel()
+
+	url := gatewayapiinstaller.CRDsURL()
+	version := gatewayapiinstaller.CRDsVersion()
+
+	require.NotEmpty(t, url, "gateway API CRDs URL should not be empty")
+	assert.Contains(t, url, version, "URL should contain the version string")
+	assert.Contains(t, url, "experimental-install.yaml", "URL should reference experimental bundle")
+	assert.True(t, strings.HasPrefix(url, "https://"), "URL should use HTTPS")
+}
+
 func newCRDServer(statusCode int, body string) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
 		if statusCode != http.StatusOK {
@@ -0,0 +1,6 @@
+// Package gatewayapiinstaller installs the Kubernetes Gateway API CRDs and,
+// optionally, Envoy Gateway as the controller implementing them.
+//
+// The CRD install is shared with the Cilium CNI installer, whose built-in
+// gateway controller needs the experimental CRDs to exist before it starts.
+package gatewayapiinstaller
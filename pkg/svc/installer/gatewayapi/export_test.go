@@ -0,0 +1,51 @@
+package gatewayapiinstaller
+
+import (
+	"context"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ParseCRDs exports parseCRDs for testing.
+func ParseCRDs(data []byte) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	return parseCRDs(data)
+}
+
+// FetchCRDs exports fetchCRDs for testing.
+func FetchCRDs(
+	ctx context.Context,
+	url string,
+	timeout time.Duration,
+) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	return fetchCRDs(ctx, url, timeout)
+}
+
+// FetchCRDsWithRetry exports the configurable retry helper for testing.
+func FetchCRDsWithRetry(
+	ctx context.Context,
+	url string,
+	timeout time.Duration,
+	maxRetries int,
+	baseWait, maxWait time.Duration,
+) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	return fetchCRDsWithRetry(ctx, url, timeout, maxRetries, baseWait, maxWait)
+}
+
+// CRDsURL exports crdsURL for testing.
+func CRDsURL() string {
+	return crdsURL()
+}
+
+// CRDsVersion exports crdsVersion for testing.
+func CRDsVersion() string {
+	return crdsVersion()
+}
+
+// SetClusterHooksForTest overrides the CRD installer and GatewayClass
+// apply/delete hooks so Install and Uninstall can run without a cluster.
+func (g *Installer) SetClusterHooksForTest(crds, applyClass, deleteClass func(ctx context.Context) error) {
+	g.crdInstaller = crds
+	g.gatewayClassApplier = applyClass
+	g.gatewayClassDeleter = deleteClass
+}
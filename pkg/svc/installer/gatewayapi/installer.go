@@ -0,0 +1,211 @@
+package gatewayapiinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	envoyReleaseName = "eg"
+	envoyNamespace   = "envoy-gateway-system"
+	envoyChartName   = "oci://docker.io/envoyproxy/gateway-helm"
+
+	// envoyControllerName is the controllerName Envoy Gateway reconciles
+	// GatewayClasses for.
+	envoyControllerName = "gateway.envoyproxy.io/gatewayclass-controller"
+)
+
+// Installer installs the Gateway API CRDs and, for GatewayAPIEnvoy, Envoy
+// Gateway plus a GatewayClass pointing at it.
+//
+// Uninstall leaves the CRDs in place: deleting them would cascade-delete every
+// Gateway and route in the cluster, including ones KSail does not manage.
+type Installer struct {
+	client     helm.Interface
+	kubeconfig string
+	context    string
+	timeout    time.Duration
+	controller v1alpha1.GatewayAPI
+
+	// crdInstaller, gatewayClassApplier, and gatewayClassDeleter default to the
+	// live implementations and can be overridden in tests to avoid needing a
+	// real cluster.
+	crdInstaller        func(ctx context.Context) error
+	gatewayClassApplier func(ctx context.Context) error
+	gatewayClassDeleter func(ctx context.Context) error
+}
+
+// NewInstaller creates a new Gateway API installer for the given option.
+// Only GatewayAPICRDs and GatewayAPIEnvoy install anything; Cilium's controller
+// and CRDs come with the Cilium CNI installer.
+func NewInstaller(
+	client helm.Interface,
+	kubeconfig, context string,
+	timeout time.Duration,
+	controller v1alpha1.GatewayAPI,
+) *Installer {
+	gatewayInstaller := &Installer{
+		client:     client,
+		kubeconfig: kubeconfig,
+		context:    context,
+		timeout:    timeout,
+		controller: controller,
+	}
+	gatewayInstaller.crdInstaller = gatewayInstaller.installCRDs
+	gatewayInstaller.gatewayClassApplier = gatewayInstaller.applyEnvoyGatewayClass
+	gatewayInstaller.gatewayClassDeleter = gatewayInstaller.deleteEnvoyGatewayClass
+
+	return gatewayInstaller
+}
+
+// Install applies the Gateway API CRDs and, for Envoy, installs the Envoy
+// Gateway chart and its GatewayClass.
+func (g *Installer) Install(ctx context.Context) error {
+	err := g.crdInstaller(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install Gateway API CRDs: %w", err)
+	}
+
+	if g.controller != v1alpha1.GatewayAPIEnvoy {
+		return nil
+	}
+
+	installCtx, cancel := context.WithTimeout(ctx, g.timeout+helm.ContextTimeoutBuffer)
+	defer cancel()
+
+	err = helm.InstallChartWithRetry(installCtx, g.client, g.envoyChartSpec(), "envoy-gateway")
+	if err != nil {
+		return fmt.Errorf("installing envoy-gateway chart: %w", err)
+	}
+
+	err = g.gatewayClassApplier(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to apply Envoy GatewayClass: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes Envoy Gateway and its GatewayClass. The Gateway API CRDs
+// are intentionally kept; see Installer.
+func (g *Installer) Uninstall(ctx context.Context) error {
+	if g.controller != v1alpha1.GatewayAPIEnvoy {
+		return nil
+	}
+
+	err := g.gatewayClassDeleter(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete Envoy GatewayClass: %w", err)
+	}
+
+	err = g.client.UninstallRelease(ctx, envoyReleaseName, envoyNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall envoy-gateway release: %w", err)
+	}
+
+	return nil
+}
+
+// Images returns the container images used by Envoy Gateway. The CRDs-only
+// option runs no workloads and returns no images.
+func (g *Installer) Images(ctx context.Context) ([]string, error) {
+	if g.controller != v1alpha1.GatewayAPIEnvoy {
+		return nil, nil
+	}
+
+	images, err := helmutil.ImagesFromChart(ctx, g.client, g.envoyChartSpec())
+	if err != nil {
+		return nil, fmt.Errorf("listing images: %w", err)
+	}
+
+	return images, nil
+}
+
+func (g *Installer) envoyChartSpec() *helm.ChartSpec {
+	return &helm.ChartSpec{
+		ReleaseName:     envoyReleaseName,
+		ChartName:       envoyChartName,
+		Namespace:       envoyNamespace,
+		Version:         envoyChartVersion(),
+		CreateNamespace: true,
+		Atomic:          true,
+		Wait:            true,
+		WaitForJobs:     true,
+		Timeout:         g.timeout,
+	}
+}
+
+func (g *Installer) installCRDs(ctx context.Context) error {
+	restConfig, err := k8s.BuildRESTConfig(g.kubeconfig, g.context)
+	if err != nil {
+		return fmt.Errorf("build REST config: %w", err)
+	}
+
+	return InstallCRDs(ctx, restConfig, g.timeout)
+}
+
+func gatewayClassGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "gateway.networking.k8s.io",
+		Version:  "v1",
+		Resource: "gatewayclasses",
+	}
+}
+
+// applyEnvoyGatewayClass applies the GatewayClass served by Envoy Gateway using
+// Server-Side Apply, so user edits to fields KSail does not own are preserved.
+func (g *Installer) applyEnvoyGatewayClass(ctx context.Context) error {
+	client, err := k8s.NewDynamicClient(g.kubeconfig, g.context)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	gatewayClass := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "GatewayClass",
+			"metadata": map[string]any{
+				"name": v1alpha1.EnvoyGatewayClassName,
+			},
+			"spec": map[string]any{
+				"controllerName": envoyControllerName,
+			},
+		},
+	}
+
+	_, err = client.Resource(gatewayClassGVR()).
+		Apply(ctx, v1alpha1.EnvoyGatewayClassName, gatewayClass, metav1.ApplyOptions{FieldManager: "ksail"})
+	if err != nil {
+		return fmt.Errorf("failed to apply GatewayClass: %w", err)
+	}
+
+	return nil
+}
+
+// deleteEnvoyGatewayClass removes the Envoy GatewayClass, tolerating a class or
+// CRD that is already gone.
+func (g *Installer) deleteEnvoyGatewayClass(ctx context.Context) error {
+	client, err := k8s.NewDynamicClient(g.kubeconfig, g.context)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	err = client.Resource(gatewayClassGVR()).
+		Delete(ctx, v1alpha1.EnvoyGatewayClassName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete GatewayClass: %w", err)
+	}
+
+	return nil
+}
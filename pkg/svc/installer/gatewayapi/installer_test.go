@@ -0,0 +1,135 @@
+package gatewayapiinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	gatewayapiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/gatewayapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type hookCalls struct {
+	crds, applyClass, deleteClass int
+}
+
+func newTestInstaller(
+	t *testing.T,
+	controller v1alpha1.GatewayAPI,
+	crdErr error,
+) (*gatewayapiinstaller.Installer, *helm.MockInterface, *hookCalls) {
+	t.Helper()
+
+	client := helm.NewMockInterface(t)
+	calls := &hookCalls{}
+	installer := gatewayapiinstaller.NewInstaller(client, "", "", 5*time.Second, controller)
+	installer.SetClusterHooksForTest(
+		func(context.Context) error {
+			calls.crds++
+
+			return crdErr
+		},
+		func(context.Context) error {
+			calls.applyClass++
+
+			return nil
+		},
+		func(context.Context) error {
+			calls.deleteClass++
+
+			return nil
+		},
+	)
+
+	return installer, client, calls
+}
+
+func TestInstall_CRDsOnly(t *testing.T) {
+	t.Parallel()
+
+	installer, _, calls := newTestInstaller(t, v1alpha1.GatewayAPICRDs, nil)
+
+	require.NoError(t, installer.Install(context.Background()))
+	assert.Equal(t, 1, calls.crds)
+	assert.Zero(t, calls.applyClass)
+}
+
+func TestInstall_CRDError(t *testing.T) {
+	t.Parallel()
+
+	installer, _, calls := newTestInstaller(t, v1alpha1.GatewayAPIEnvoy, assert.AnError)
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Zero(t, calls.applyClass)
+}
+
+func TestInstall_Envoy(t *testing.T) {
+	t.Parallel()
+
+	installer, client, calls := newTestInstaller(t, v1alpha1.GatewayAPIEnvoy, nil)
+
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				return spec.ReleaseName == "eg" &&
+					spec.Namespace == "envoy-gateway-system" &&
+					spec.ChartName == "oci://docker.io/envoyproxy/gateway-helm" &&
+					spec.Version != ""
+			}),
+		).
+		Return(nil, nil)
+
+	require.NoError(t, installer.Install(context.Background()))
+	assert.Equal(t, 1, calls.crds)
+	assert.Equal(t, 1, calls.applyClass)
+}
+
+func TestUninstall_EnvoyKeepsCRDs(t *testing.T) {
+	t.Parallel()
+
+	installer, client, calls := newTestInstaller(t, v1alpha1.GatewayAPIEnvoy, nil)
+
+	client.EXPECT().
+		UninstallRelease(mock.Anything, "eg", "envoy-gateway-system").
+		Return(nil)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+	assert.Equal(t, 1, calls.deleteClass)
+	assert.Zero(t, calls.crds)
+}
+
+func TestUninstall_CRDsOnlyIsNoop(t *testing.T) {
+	t.Parallel()
+
+	installer, _, calls := newTestInstaller(t, v1alpha1.GatewayAPICRDs, nil)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+	assert.Zero(t, calls.deleteClass)
+}
+
+func TestImages(t *testing.T) {
+	t.Parallel()
+
+	crdsOnly, _, _ := newTestInstaller(t, v1alpha1.GatewayAPICRDs, nil)
+
+	images, err := crdsOnly.Images(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, images)
+
+	envoy, client, _ := newTestInstaller(t, v1alpha1.GatewayAPIEnvoy, nil)
+
+	client.EXPECT().
+		TemplateChart(mock.Anything, mock.Anything).
+		Return("image: docker.io/envoyproxy/gateway:v1.5.0\n", nil)
+
+	images, err = envoy.Images(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, images, "docker.io/envoyproxy/gateway:v1.5.0")
+}
@@ -0,0 +1,20 @@
+package gatewayapiinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Dockerfile.envoy
+var envoyDockerfile string
+
+// envoyChartVersion returns the pinned Envoy Gateway chart version extracted
+// from the embedded Dockerfile.envoy.
+func envoyChartVersion() string {
+	return parser.ParseImageFromDockerfile(
+		envoyDockerfile,
+		`FROM\s+docker\.io/envoyproxy/gateway-helm:([^\s@]+)`,
+		"envoy gateway chart",
+	)
+}
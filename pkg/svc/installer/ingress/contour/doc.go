@@ -0,0 +1,6 @@
+// Package contourinstaller provides an installer for installing Contour on a Kubernetes cluster.
+//
+// Contour is the control plane; Envoy (a DaemonSet) is the data plane and is what
+// the shared ingress node ports or LoadBalancer Service point at. See the parent
+// ingress package for the shared conventions.
+package contourinstaller
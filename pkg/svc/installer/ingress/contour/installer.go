@@ -0,0 +1,91 @@
+package contourinstaller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const repoURL = "https://projectcontour.github.io/helm-charts/"
+
+// Installer installs or upgrades Contour.
+//
+// It embeds helmutil.Base to provide standard Helm chart lifecycle management.
+type Installer struct {
+	*helmutil.Base
+}
+
+// NewInstaller creates a new Contour installer instance.
+//
+// When exposeNodePorts is true the Envoy Service is a NodePort pinned to the
+// shared ingress node ports, which the Docker-based provisioners map to
+// localhost:80/443; otherwise it is a LoadBalancer. When haEnabled is true the
+// Contour control plane runs two replicas behind a PodDisruptionBudget (Envoy is
+// a DaemonSet and already runs on every node).
+func NewInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+	exposeNodePorts bool,
+	haEnabled bool,
+) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"contour",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: "contour",
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName:     "contour",
+				ChartName:       "contour/contour",
+				Namespace:       "projectcontour",
+				Version:         chartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      buildValuesYaml(exposeNodePorts, haEnabled),
+			},
+		),
+	}
+}
+
+// buildValuesYaml returns the Helm values YAML for Contour. The chart's
+// IngressClass is made the cluster default so class-less Ingresses are served.
+func buildValuesYaml(exposeNodePorts, haEnabled bool) string {
+	values := `contour:
+  ingressClass:
+    name: contour
+    create: true
+    default: true`
+
+	if haEnabled {
+		values += `
+  replicaCount: 2
+  pdb:
+    create: true
+    minAvailable: 1`
+	}
+
+	values += fmt.Sprintf(`
+envoy:
+  service:
+    type: %s`, ingress.ServiceType(exposeNodePorts))
+
+	if exposeNodePorts {
+		values += fmt.Sprintf(`
+    nodePorts:
+      http: %d
+      https: %d`, v1alpha1.IngressHTTPNodePort, v1alpha1.IngressHTTPSNodePort)
+	}
+
+	return values
+}
@@ -0,0 +1,128 @@
+package contourinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	contourinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress/contour"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildValuesYaml_NodePorts(t *testing.T) {
+	t.Parallel()
+
+	values := parseValues(t, contourinstaller.BuildValuesYaml(true, false))
+
+	assert.Equal(t, "NodePort", lookup(t, values, "envoy", "service", "type"))
+	assert.InDelta(t, 30080, lookup(t, values, "envoy", "service", "nodePorts", "http"), 0)
+	assert.InDelta(t, 30443, lookup(t, values, "envoy", "service", "nodePorts", "https"), 0)
+	assert.Equal(t, true, lookup(t, values, "contour", "ingressClass", "default"))
+	assert.Nil(t, lookup(t, values, "contour", "replicaCount"))
+}
+
+func TestBuildValuesYaml_LoadBalancer(t *testing.T) {
+	t.Parallel()
+
+	values := parseValues(t, contourinstaller.BuildValuesYaml(false, false))
+
+	assert.Equal(t, "LoadBalancer", lookup(t, values, "envoy", "service", "type"))
+	assert.Nil(t, lookup(t, values, "envoy", "service", "nodePorts"))
+}
+
+func TestBuildValuesYaml_HAEnabled(t *testing.T) {
+	t.Parallel()
+
+	values := parseValues(t, contourinstaller.BuildValuesYaml(true, true))
+
+	assert.InDelta(t, 2, lookup(t, values, "contour", "replicaCount"), 0)
+	assert.Equal(t, true, lookup(t, values, "contour", "pdb", "create"))
+	assert.Equal(t, "NodePort", lookup(t, values, "envoy", "service", "type"))
+}
+
+func TestInstallerInstall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		installErr error
+	}{
+		{name: "success"},
+		{name: "install error", installErr: assert.AnError},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := helm.NewMockInterface(t)
+			installer := contourinstaller.NewInstaller(client, 5*time.Second, true, false)
+
+			client.EXPECT().
+				GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			client.EXPECT().
+				AddRepository(
+					mock.Anything,
+					mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+						return entry != nil && entry.URL == "https://projectcontour.github.io/helm-charts/"
+					}),
+					mock.Anything,
+				).
+				Return(nil)
+			client.EXPECT().
+				InstallOrUpgradeChart(
+					mock.Anything,
+					mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+						assert.Equal(t, "contour", spec.ReleaseName)
+						assert.Equal(t, "contour/contour", spec.ChartName)
+						assert.Equal(t, "projectcontour", spec.Namespace)
+						assert.NotEmpty(t, spec.Version)
+						assert.True(t, spec.CreateNamespace)
+						assert.True(t, spec.Wait)
+
+						return true
+					}),
+				).
+				Return(nil, testCase.installErr)
+
+			err := installer.Install(context.Background())
+			if testCase.installErr != nil {
+				require.ErrorIs(t, err, testCase.installErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// parseValues decodes a values YAML document into a generic map.
+func parseValues(t *testing.T, valuesYaml string) map[string]any {
+	t.Helper()
+
+	var values map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(valuesYaml), &values))
+
+	return values
+}
+
+// lookup walks a nested values map along path.
+func lookup(t *testing.T, values map[string]any, path ...string) any {
+	t.Helper()
+
+	var current any = values
+	for _, key := range path {
+		node, ok := current.(map[string]any)
+		require.True(t, ok, "expected a map at %q", key)
+
+		current = node[key]
+	}
+
+	return current
+}
@@ -0,0 +1,25 @@
+// Package ingress groups the ingress controller installers selected by
+// spec.cluster.ingressController and the conventions they share.
+//
+// # Overview
+//
+// Every controller is installed from its upstream Helm chart and configured the
+// same way so the rest of KSail can treat them interchangeably:
+//
+//   - The chart's IngressClass is marked as the cluster default, so Ingress
+//     resources without an ingressClassName are picked up.
+//   - On the Docker provider the controller Service is a NodePort pinned to
+//     v1alpha1.IngressHTTPNodePort and v1alpha1.IngressHTTPSNodePort. The Kind,
+//     K3d, and Talos provisioners map host ports 80 and 443 onto those node ports
+//     (see v1alpha1.IngressPortMappings), so ingresses answer on localhost.
+//   - On every other provider the Service is a LoadBalancer and relies on the
+//     provider's (or MetalLB's) load-balancer support.
+//
+// Package Structure
+//
+//	ingress.go - Shared helpers (Service type selection).
+//	doc.go     - This package documentation.
+//	nginx/     - ingress-nginx installer.
+//	traefik/   - Traefik installer.
+//	contour/   - Contour installer (Envoy data plane).
+package ingress
@@ -0,0 +1,18 @@
+package ingress
+
+// Kubernetes Service types the ingress controller charts are configured with.
+const (
+	serviceTypeNodePort     = "NodePort"
+	serviceTypeLoadBalancer = "LoadBalancer"
+)
+
+// ServiceType returns the Service type for an ingress controller: NodePort when
+// exposeNodePorts is true (the Docker provider, where the provisioners map host
+// ports onto the fixed node ports), LoadBalancer otherwise.
+func ServiceType(exposeNodePorts bool) string {
+	if exposeNodePorts {
+		return serviceTypeNodePort
+	}
+
+	return serviceTypeLoadBalancer
+}
@@ -0,0 +1,15 @@
+package ingress_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "NodePort", ingress.ServiceType(true))
+	assert.Equal(t, "LoadBalancer", ingress.ServiceType(false))
+}
@@ -0,0 +1,5 @@
+// Package nginxinstaller provides an installer for installing ingress-nginx on a Kubernetes cluster.
+//
+// The controller is exposed on fixed node ports (Docker provider) or through a
+// LoadBalancer Service; see the parent ingress package for the shared conventions.
+package nginxinstaller
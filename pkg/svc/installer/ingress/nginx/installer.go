@@ -0,0 +1,91 @@
+package nginxinstaller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const repoURL = "https://kubernetes.github.io/ingress-nginx"
+
+// Installer installs or upgrades ingress-nginx.
+//
+// It embeds helmutil.Base to provide standard Helm chart lifecycle management.
+type Installer struct {
+	*helmutil.Base
+}
+
+// NewInstaller creates a new ingress-nginx installer instance.
+//
+// When exposeNodePorts is true the controller Service is a NodePort pinned to the
+// shared ingress node ports, which the Docker-based provisioners map to
+// localhost:80/443; otherwise it is a LoadBalancer. When haEnabled is true the
+// controller runs two replicas behind a PodDisruptionBudget.
+func NewInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+	exposeNodePorts bool,
+	haEnabled bool,
+) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"ingress-nginx",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: "ingress-nginx",
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName:     "ingress-nginx",
+				ChartName:       "ingress-nginx/ingress-nginx",
+				Namespace:       "ingress-nginx",
+				Version:         chartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      buildValuesYaml(exposeNodePorts, haEnabled),
+			},
+		),
+	}
+}
+
+// buildValuesYaml returns the Helm values YAML for ingress-nginx. The chart's
+// IngressClass is made the cluster default so class-less Ingresses are served.
+func buildValuesYaml(exposeNodePorts, haEnabled bool) string {
+	values := fmt.Sprintf(`controller:
+  watchIngressWithoutClass: true
+  ingressClassResource:
+    default: true
+  service:
+    type: %s`, ingress.ServiceType(exposeNodePorts))
+
+	if exposeNodePorts {
+		values += fmt.Sprintf(`
+    nodePorts:
+      http: %d
+      https: %d`, v1alpha1.IngressHTTPNodePort, v1alpha1.IngressHTTPSNodePort)
+	}
+
+	if haEnabled {
+		values += `
+  replicaCount: 2
+  minAvailable: 1
+  topologySpreadConstraints:
+    - maxSkew: 1
+      topologyKey: kubernetes.io/hostname
+      whenUnsatisfiable: ScheduleAnyway
+      labelSelector:
+        matchLabels:
+          app.kubernetes.io/component: controller`
+	}
+
+	return values
+}
@@ -0,0 +1,5 @@
+// Package traefikinstaller provides an installer for installing Traefik on a Kubernetes cluster.
+//
+// The controller is exposed on fixed node ports (Docker provider) or through a
+// LoadBalancer Service; see the parent ingress package for the shared conventions.
+package traefikinstaller
@@ -0,0 +1,5 @@
+//nolint:gochecknoglobals // export_test.go pattern requires global variables to expose internal functions
+package traefikinstaller
+
+// BuildValuesYaml exposes buildValuesYaml for testing.
+var BuildValuesYaml = buildValuesYaml
@@ -0,0 +1,95 @@
+package traefikinstaller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const repoURL = "https://traefik.github.io/charts"
+
+// Installer installs or upgrades Traefik.
+//
+// It embeds helmutil.Base to provide standard Helm chart lifecycle management.
+type Installer struct {
+	*helmutil.Base
+}
+
+// NewInstaller creates a new Traefik installer instance.
+//
+// When exposeNodePorts is true the Traefik Service is a NodePort pinned to the
+// shared ingress node ports, which the Docker-based provisioners map to
+// localhost:80/443; otherwise it is a LoadBalancer. When haEnabled is true Traefik
+// runs two replicas behind a PodDisruptionBudget.
+func NewInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+	exposeNodePorts bool,
+	haEnabled bool,
+) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"traefik",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: "traefik",
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName:     "traefik",
+				ChartName:       "traefik/traefik",
+				Namespace:       "traefik",
+				Version:         chartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      buildValuesYaml(exposeNodePorts, haEnabled),
+			},
+		),
+	}
+}
+
+// buildValuesYaml returns the Helm values YAML for Traefik. The chart's
+// IngressClass is made the cluster default so class-less Ingresses are served.
+func buildValuesYaml(exposeNodePorts, haEnabled bool) string {
+	values := fmt.Sprintf(`ingressClass:
+  enabled: true
+  isDefaultClass: true
+service:
+  type: %s`, ingress.ServiceType(exposeNodePorts))
+
+	if exposeNodePorts {
+		values += fmt.Sprintf(`
+ports:
+  web:
+    nodePort: %d
+  websecure:
+    nodePort: %d`, v1alpha1.IngressHTTPNodePort, v1alpha1.IngressHTTPSNodePort)
+	}
+
+	if haEnabled {
+		values += `
+deployment:
+  replicas: 2
+podDisruptionBudget:
+  enabled: true
+  minAvailable: 1
+topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: kubernetes.io/hostname
+    whenUnsatisfiable: ScheduleAnyway
+    labelSelector:
+      matchLabels:
+        app.kubernetes.io/name: traefik`
+	}
+
+	return values
+}
@@ -0,0 +1,12 @@
+// Package istioinstaller provides an installer for the Istio service mesh
+// selected by spec.cluster.serviceMesh: Istio.
+//
+// The mesh is installed from Istio's Helm charts into istio-system: base (CRDs
+// and cluster roles) and istiod (the control plane). In ambient mode
+// (spec.cluster.istio.mode: Ambient) the Istio CNI node agent and the ztunnel
+// DaemonSet follow, and istiod and the CNI agent use the ambient profile.
+// Every chart is installed with Helm's readiness wait, so Install returns once
+// the control plane (and, in ambient mode, ztunnel on every node) is ready.
+//
+// See: https://istio.io/latest/docs/setup/install/helm/
+package istioinstaller
@@ -0,0 +1,11 @@
+package istioinstaller
+
+// BuildValuesYaml exposes buildValuesYaml for testing.
+//
+//nolint:gochecknoglobals // export_test.go pattern requires global variables to expose internal functions
+var BuildValuesYaml = buildValuesYaml
+
+// ChartVersion exposes chartVersion for testing.
+//
+//nolint:gochecknoglobals // export_test.go pattern requires global variables to expose internal functions
+var ChartVersion = chartVersion
@@ -0,0 +1,150 @@
+package istioinstaller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const (
+	repoName = "istio"
+	repoURL  = "https://istio-release.storage.googleapis.com/charts"
+
+	// Namespace is the namespace the Istio control plane is installed into.
+	Namespace = "istio-system"
+	// BaseReleaseName is the Helm release name of the Istio base chart.
+	BaseReleaseName = "istio-base"
+	// IstiodReleaseName is the Helm release name of istiod.
+	IstiodReleaseName = "istiod"
+	// CNIReleaseName is the Helm release name of the Istio CNI node agent.
+	CNIReleaseName = "istio-cni"
+	// ZtunnelReleaseName is the Helm release name of ztunnel.
+	ZtunnelReleaseName = "ztunnel"
+
+	// ambientProfile is the Istio chart profile for the ambient data plane.
+	ambientProfile = "ambient"
+)
+
+// Installer installs or upgrades Istio.
+//
+// The charts are installed in dependency order (base, istiod, then the CNI
+// agent and ztunnel in ambient mode) and removed in reverse order.
+type Installer struct {
+	charts []*helmutil.Base
+}
+
+// NewInstaller creates a new Istio installer. ambient selects the ambient data
+// plane instead of sidecar injection. platform is passed to the charts as
+// global.platform (e.g. "k3d") so the CNI agent finds the distribution's CNI
+// directories; leave it empty for the chart defaults.
+func NewInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+	ambient bool,
+	platform string,
+) *Installer {
+	repo := &helm.RepositoryEntry{
+		Name: repoName,
+		URL:  repoURL,
+	}
+
+	newChart := func(chart, release, valuesYaml string) *helmutil.Base {
+		return helmutil.NewBase(
+			release,
+			client,
+			timeout,
+			repo,
+			&helm.ChartSpec{
+				ReleaseName:     release,
+				ChartName:       repoName + "/" + chart,
+				Namespace:       Namespace,
+				Version:         chartVersion(chart),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				UpgradeCRDs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      valuesYaml,
+			},
+		)
+	}
+
+	charts := []*helmutil.Base{
+		newChart("base", BaseReleaseName, ""),
+		newChart("istiod", IstiodReleaseName, buildValuesYaml(ambient, platform)),
+	}
+
+	if ambient {
+		charts = append(charts,
+			newChart("cni", CNIReleaseName, buildValuesYaml(ambient, platform)),
+			newChart("ztunnel", ZtunnelReleaseName, ""),
+		)
+	}
+
+	return &Installer{charts: charts}
+}
+
+// Install installs the Istio charts in dependency order, waiting for each to
+// become ready before installing the next.
+func (i *Installer) Install(ctx context.Context) error {
+	for _, chart := range i.charts {
+		err := chart.Install(ctx)
+		if err != nil {
+			return err //nolint:wrapcheck // helmutil.Base already wraps with the release name
+		}
+	}
+
+	return nil
+}
+
+// Uninstall removes the Istio charts in reverse dependency order.
+func (i *Installer) Uninstall(ctx context.Context) error {
+	for _, chart := range slices.Backward(i.charts) {
+		err := chart.Uninstall(ctx)
+		if err != nil {
+			return err //nolint:wrapcheck // helmutil.Base already wraps with the release name
+		}
+	}
+
+	return nil
+}
+
+// Images returns the container images used by the Istio charts.
+func (i *Installer) Images(ctx context.Context) ([]string, error) {
+	var images []string
+
+	for _, chart := range i.charts {
+		chartImages, err := chart.Images(ctx)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // helmutil.Base already wraps with the release name
+		}
+
+		images = append(images, chartImages...)
+	}
+
+	return images, nil
+}
+
+// buildValuesYaml returns the Helm values shared by istiod and the CNI agent:
+// the ambient profile in ambient mode and the distribution platform when set.
+// It returns "" when neither applies so the chart defaults are used.
+func buildValuesYaml(ambient bool, platform string) string {
+	var values strings.Builder
+
+	if ambient {
+		fmt.Fprintf(&values, "profile: %s\n", ambientProfile)
+	}
+
+	if platform != "" {
+		fmt.Fprintf(&values, "global:\n  platform: %s\n", platform)
+	}
+
+	return values.String()
+}
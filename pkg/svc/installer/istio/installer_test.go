@@ -0,0 +1,142 @@
+package istioinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	istioinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/istio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartVersion_PinsAllCharts(t *testing.T) {
+	t.Parallel()
+
+	version := istioinstaller.ChartVersion("base")
+
+	require.NotEmpty(t, version)
+
+	for _, chart := range []string{"istiod", "cni", "ztunnel"} {
+		assert.Equal(t, version, istioinstaller.ChartVersion(chart), chart)
+	}
+}
+
+func TestBuildValuesYaml(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, istioinstaller.BuildValuesYaml(false, ""))
+	assert.Equal(t, "profile: ambient\n", istioinstaller.BuildValuesYaml(true, ""))
+	assert.Equal(t,
+		"profile: ambient\nglobal:\n  platform: k3d\n",
+		istioinstaller.BuildValuesYaml(true, "k3d"),
+	)
+}
+
+func TestInstallerInstall_ReleaseOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		ambient bool
+		want    []string
+	}{
+		{
+			name: "Sidecar",
+			want: []string{istioinstaller.BaseReleaseName, istioinstaller.IstiodReleaseName},
+		},
+		{
+			name:    "Ambient",
+			ambient: true,
+			want: []string{
+				istioinstaller.BaseReleaseName,
+				istioinstaller.IstiodReleaseName,
+				istioinstaller.CNIReleaseName,
+				istioinstaller.ZtunnelReleaseName,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := helm.NewMockInterface(t)
+			installer := istioinstaller.NewInstaller(client, 5*time.Second, testCase.ambient, "")
+
+			var releases []string
+
+			client.EXPECT().
+				GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			client.EXPECT().
+				AddRepository(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+			client.EXPECT().
+				InstallOrUpgradeChart(
+					mock.Anything,
+					mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+						assert.Equal(t, istioinstaller.Namespace, spec.Namespace)
+						assert.True(t, spec.Wait)
+
+						releases = append(releases, spec.ReleaseName)
+
+						return true
+					}),
+				).
+				Return(nil, nil)
+
+			require.NoError(t, installer.Install(context.Background()))
+			assert.Equal(t, testCase.want, releases)
+		})
+	}
+}
+
+func TestInstallerInstall_StopsOnError(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := istioinstaller.NewInstaller(client, 5*time.Second, true, "")
+
+	client.EXPECT().
+		GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil)
+	client.EXPECT().
+		AddRepository(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(mock.Anything, mock.Anything).
+		Return(nil, assert.AnError).
+		Once()
+
+	err := installer.Install(context.Background())
+
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestInstallerUninstall_ReverseOrder(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := istioinstaller.NewInstaller(client, 5*time.Second, true, "")
+
+	var releases []string
+
+	client.EXPECT().
+		UninstallRelease(mock.Anything, mock.Anything, istioinstaller.Namespace).
+		RunAndReturn(func(_ context.Context, release, _ string) error {
+			releases = append(releases, release)
+
+			return nil
+		})
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+	assert.Equal(t, []string{
+		istioinstaller.ZtunnelReleaseName,
+		istioinstaller.CNIReleaseName,
+		istioinstaller.IstiodReleaseName,
+		istioinstaller.BaseReleaseName,
+	}, releases)
+}
@@ -0,0 +1,16 @@
+package istioinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Chart.yaml
+var chartYAML string
+
+// chartVersion returns the pinned version of the named Istio chart extracted
+// from the embedded Chart.yaml (kept in sync by Dependabot's helm ecosystem).
+func chartVersion(chart string) string {
+	return parser.ParseChartVersionFromChartYaml(chartYAML, chart)
+}
@@ -0,0 +1,14 @@
+// Package keycloakinstaller provides an installer for the development Keycloak
+// selected by spec.cluster.identityProvider.
+//
+// Keycloak runs in dev mode in the keycloak namespace and imports the ksail
+// realm on start: a public "kubernetes" OIDC client for `ksail cluster oidc
+// get-token`, whose ID tokens carry a groups claim, and test users in the
+// developers and viewers groups. It serves HTTPS with a certificate signed by
+// the KSail CA under ~/.ksail/registry-tls, on a NodePort pinned to
+// v1alpha1.KeycloakHTTPSNodePort that the Kind and K3d provisioners map to the
+// same host port, so v1alpha1.KeycloakIssuerURL is reachable from the host and
+// from the API server alike.
+//
+// See: https://www.keycloak.org/server/importExport
+package keycloakinstaller
@@ -0,0 +1,25 @@
+//nolint:gochecknoglobals // export_test.go exposes unexported symbols for testing via package-level vars.
+package keycloakinstaller
+
+import "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+
+// ExportApplyResources exposes applyResources for testing.
+var ExportApplyResources = applyResources
+
+// ExportDeleteResources exposes deleteResources for testing.
+var ExportDeleteResources = deleteResources
+
+// ExportServingCertificate exposes servingCertificate for testing.
+var ExportServingCertificate = servingCertificate
+
+// ExportRealmJSON exposes the embedded realm for testing.
+var ExportRealmJSON = realmJSON
+
+// SetTLSAuthorityLoaderForTests replaces the KSail CA loader and returns a
+// function restoring it.
+func SetTLSAuthorityLoaderForTests(loader func() (*registry.TLSAuthority, error)) func() {
+	previous := loadTLSAuthority
+	loadTLSAuthority = loader
+
+	return func() { loadTLSAuthority = previous }
+}
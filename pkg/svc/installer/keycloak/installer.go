@@ -0,0 +1,180 @@
+package keycloakinstaller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s/readiness"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// fieldManager is the server-side apply field manager of the Keycloak resources.
+const fieldManager = "ksail"
+
+//nolint:gochecknoglobals // Test seam: replaced to issue certificates from a temporary CA.
+var loadTLSAuthority = registry.LoadDefaultTLSAuthority
+
+// Installer installs the development Keycloak identity provider.
+type Installer struct {
+	kubeconfig string
+	context    string
+	timeout    time.Duration
+}
+
+// NewInstaller creates a new Keycloak installer instance.
+func NewInstaller(kubeconfig, context string, timeout time.Duration) *Installer {
+	return &Installer{
+		kubeconfig: kubeconfig,
+		context:    context,
+		timeout:    timeout,
+	}
+}
+
+// Install applies the Keycloak resources and waits for Keycloak to become ready.
+// The serving certificate is issued by the KSail CA, the CA the API server is
+// configured to trust for the OIDC issuer.
+func (k *Installer) Install(ctx context.Context) error {
+	certPEM, keyPEM, err := servingCertificate()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := k8s.NewClientset(k.kubeconfig, k.context)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	err = applyResources(ctx, clientset, certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+
+	checks := []readiness.Check{
+		{Type: "deployment", Namespace: Namespace, Name: DeploymentName},
+	}
+
+	err = readiness.WaitForMultipleResources(ctx, clientset, checks, k.timeout)
+	if err != nil {
+		return fmt.Errorf("wait for keycloak deployment: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes the keycloak namespace and everything in it.
+func (k *Installer) Uninstall(ctx context.Context) error {
+	clientset, err := k8s.NewClientset(k.kubeconfig, k.context)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	return deleteResources(ctx, clientset)
+}
+
+// Images returns the container images used by Keycloak.
+func (k *Installer) Images(_ context.Context) ([]string, error) {
+	return []string{Image()}, nil
+}
+
+// servingCertificate returns the PEM certificate and key Keycloak serves HTTPS
+// with, issuing them from the KSail CA when missing or about to expire.
+func servingCertificate() ([]byte, []byte, error) {
+	authority, err := loadTLSAuthority()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load KSail CA: %w", err)
+	}
+
+	certDir, err := authority.EnsureServerCertificate(DeploymentName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("issue keycloak serving certificate: %w", err)
+	}
+
+	certPEM, err := os.ReadFile(filepath.Join(certDir, registry.TLSCertFile)) //nolint:gosec // KSail-managed path
+	if err != nil {
+		return nil, nil, fmt.Errorf("read keycloak serving certificate: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(certDir, registry.TLSKeyFile)) //nolint:gosec // KSail-managed path
+	if err != nil {
+		return nil, nil, fmt.Errorf("read keycloak serving key: %w", err)
+	}
+
+	return certPEM, keyPEM, nil
+}
+
+// applyResources server-side applies the Keycloak namespace, serving
+// certificate, realm, Deployment and Service, so reinstalling updates them in
+// place.
+func applyResources(ctx context.Context, clientset kubernetes.Interface, certPEM, keyPEM []byte) error {
+	err := apply(ctx, clientset.CoreV1().Namespaces().Patch, Namespace, buildNamespace())
+	if err != nil {
+		return err
+	}
+
+	err = apply(ctx, clientset.CoreV1().Secrets(Namespace).Patch, tlsSecretName, buildTLSSecret(certPEM, keyPEM))
+	if err != nil {
+		return err
+	}
+
+	err = apply(ctx, clientset.CoreV1().ConfigMaps(Namespace).Patch, realmConfigMapName, buildRealmConfigMap())
+	if err != nil {
+		return err
+	}
+
+	err = apply(ctx, clientset.AppsV1().Deployments(Namespace).Patch, DeploymentName, buildDeployment())
+	if err != nil {
+		return err
+	}
+
+	return apply(ctx, clientset.CoreV1().Services(Namespace).Patch, DeploymentName, buildService())
+}
+
+// patchFunc is the Patch method of a typed client.
+type patchFunc[T any] func(
+	ctx context.Context,
+	name string,
+	patchType types.PatchType,
+	data []byte,
+	opts metav1.PatchOptions,
+	subresources ...string,
+) (T, error)
+
+// apply server-side applies object under name with patch.
+func apply[T any](ctx context.Context, patch patchFunc[T], name string, object any) error {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+
+	force := true
+
+	_, err = patch(ctx, name, types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		return fmt.Errorf("apply %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// deleteResources deletes the keycloak namespace. A missing namespace is not
+// an error.
+func deleteResources(ctx context.Context, clientset kubernetes.Interface) error {
+	err := clientset.CoreV1().Namespaces().Delete(ctx, Namespace, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete keycloak namespace: %w", err)
+	}
+
+	return nil
+}
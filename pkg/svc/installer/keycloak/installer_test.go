@@ -0,0 +1,137 @@
+package keycloakinstaller_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	keycloakinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/keycloak"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestImages(t *testing.T) {
+	t.Parallel()
+
+	installer := keycloakinstaller.NewInstaller("", "", time.Minute)
+
+	images, err := installer.Images(t.Context())
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	assert.Regexp(t, `^quay\.io/keycloak/keycloak:\S+$`, images[0])
+}
+
+func TestRealm_MatchesOIDCDefaults(t *testing.T) {
+	t.Parallel()
+
+	var realm struct {
+		Realm   string `json:"realm"`
+		Clients []struct {
+			ClientID     string   `json:"clientId"`
+			PublicClient bool     `json:"publicClient"`
+			RedirectURIs []string `json:"redirectUris"`
+		} `json:"clients"`
+		Users []struct {
+			Username      string   `json:"username"`
+			EmailVerified bool     `json:"emailVerified"`
+			Groups        []string `json:"groups"`
+		} `json:"users"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(keycloakinstaller.ExportRealmJSON), &realm))
+
+	assert.Equal(t, v1alpha1.KeycloakRealm, realm.Realm)
+	require.Len(t, realm.Clients, 1)
+	assert.Equal(t, v1alpha1.KeycloakClientID, realm.Clients[0].ClientID)
+	assert.True(t, realm.Clients[0].PublicClient)
+	assert.Contains(t, realm.Clients[0].RedirectURIs, "http://localhost:18000/callback")
+
+	for _, user := range realm.Users {
+		assert.True(t, user.EmailVerified, "the API server rejects unverified email usernames")
+		assert.NotEmpty(t, user.Groups)
+	}
+}
+
+func TestApplyResources(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset()
+
+	err := keycloakinstaller.ExportApplyResources(t.Context(), clientset, []byte("cert"), []byte("key"))
+	require.NoError(t, err)
+
+	secret, err := clientset.CoreV1().Secrets(keycloakinstaller.Namespace).
+		Get(t.Context(), "keycloak-tls", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, []byte("cert"), secret.Data[corev1.TLSCertKey])
+
+	realm, err := clientset.CoreV1().ConfigMaps(keycloakinstaller.Namespace).
+		Get(t.Context(), "keycloak-realm", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.JSONEq(t, keycloakinstaller.ExportRealmJSON, realm.Data["ksail-realm.json"])
+
+	deployment, err := clientset.AppsV1().Deployments(keycloakinstaller.Namespace).
+		Get(t.Context(), keycloakinstaller.DeploymentName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, keycloakinstaller.Image(), container.Image)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "KC_HOSTNAME", Value: "https://127.0.0.1:31843"})
+
+	service, err := clientset.CoreV1().Services(keycloakinstaller.Namespace).
+		Get(t.Context(), keycloakinstaller.DeploymentName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ServiceTypeNodePort, service.Spec.Type)
+	assert.Equal(t, v1alpha1.KeycloakHTTPSNodePort, service.Spec.Ports[0].NodePort)
+
+	// Reapplying updates the resources in place.
+	err = keycloakinstaller.ExportApplyResources(t.Context(), clientset, []byte("renewed"), []byte("key"))
+	require.NoError(t, err)
+
+	secret, err = clientset.CoreV1().Secrets(keycloakinstaller.Namespace).
+		Get(t.Context(), "keycloak-tls", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("renewed"), secret.Data[corev1.TLSCertKey])
+}
+
+func TestDeleteResources(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: keycloakinstaller.Namespace},
+	})
+
+	require.NoError(t, keycloakinstaller.ExportDeleteResources(t.Context(), clientset))
+
+	_, err := clientset.CoreV1().Namespaces().Get(t.Context(), keycloakinstaller.Namespace, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, keycloakinstaller.ExportDeleteResources(t.Context(), clientset),
+		"deleting a missing namespace is not an error")
+}
+
+//nolint:paralleltest // Replaces the package-level CA loader.
+func TestServingCertificate(t *testing.T) {
+	dir := t.TempDir()
+
+	restore := keycloakinstaller.SetTLSAuthorityLoaderForTests(func() (*registry.TLSAuthority, error) {
+		return registry.LoadOrCreateTLSAuthority(dir)
+	})
+	t.Cleanup(restore)
+
+	certPEM, keyPEM, err := keycloakinstaller.ExportServingCertificate()
+	require.NoError(t, err)
+	assert.Contains(t, string(certPEM), "BEGIN CERTIFICATE")
+	assert.NotEmpty(t, keyPEM)
+
+	again, _, err := keycloakinstaller.ExportServingCertificate()
+	require.NoError(t, err)
+	assert.Equal(t, certPEM, again, "a valid certificate is reused")
+}
@@ -0,0 +1,177 @@
+package keycloakinstaller
+
+import (
+	_ "embed"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+//go:embed realm.json
+var realmJSON string
+
+const (
+	// Namespace is the namespace Keycloak is installed in.
+	Namespace = "keycloak"
+	// DeploymentName is the name of the Keycloak Deployment and Service.
+	DeploymentName = "keycloak"
+	// tlsSecretName holds the serving certificate signed by the KSail CA.
+	tlsSecretName = "keycloak-tls"
+	// realmConfigMapName holds the realm Keycloak imports on start.
+	realmConfigMapName = "keycloak-realm"
+	// realmFileName is the file name of the realm in realmConfigMapName.
+	realmFileName = "ksail-realm.json"
+
+	// httpsPort is the container port Keycloak serves HTTPS on.
+	httpsPort int32 = 8443
+	// managementPort is the container port of Keycloak's health endpoints.
+	managementPort int32 = 9000
+
+	tlsMountPath   = "/opt/keycloak/conf/tls"
+	realmMountPath = "/opt/keycloak/data/import"
+
+	// adminCredential is the user name and password of the bootstrap admin of
+	// the master realm. Keycloak is a local development identity provider, so a
+	// well-known credential is deliberate.
+	adminCredential = "admin"
+)
+
+// labels returns the labels of every Keycloak resource and the pod selector.
+func labels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       DeploymentName,
+		"app.kubernetes.io/managed-by": "ksail",
+	}
+}
+
+// hostname returns the URL Keycloak derives its issuer URLs from: the issuer
+// URL of the realm without the realm path.
+func hostname() string {
+	return strings.TrimSuffix(v1alpha1.KeycloakIssuerURL, "/realms/"+v1alpha1.KeycloakRealm)
+}
+
+func objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: Namespace, Labels: labels()}
+}
+
+func buildNamespace() *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: Namespace, Labels: labels()},
+	}
+}
+
+func buildTLSSecret(certPEM, keyPEM []byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: objectMeta(tlsSecretName),
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+}
+
+func buildRealmConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: objectMeta(realmConfigMapName),
+		Data:       map[string]string{realmFileName: realmJSON},
+	}
+}
+
+// buildDeployment returns the Keycloak Deployment. Keycloak runs in dev mode
+// with its embedded database and imports the realm on every start, so a
+// restarted pod comes back with the same users and client. KC_HOSTNAME pins the
+// issuer of the tokens to v1alpha1.KeycloakIssuerURL regardless of the address a
+// request arrives on.
+func buildDeployment() *appsv1.Deployment {
+	replicas := int32(1)
+
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: objectMeta(DeploymentName),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels()},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels()},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  DeploymentName,
+						Image: Image(),
+						Args:  []string{"start-dev", "--import-realm"},
+						Env: []corev1.EnvVar{
+							{Name: "KC_BOOTSTRAP_ADMIN_USERNAME", Value: adminCredential},
+							{Name: "KC_BOOTSTRAP_ADMIN_PASSWORD", Value: adminCredential},
+							{Name: "KC_HOSTNAME", Value: hostname()},
+							{Name: "KC_HTTP_ENABLED", Value: "false"},
+							{Name: "KC_HTTPS_PORT", Value: strconv.Itoa(int(httpsPort))},
+							{Name: "KC_HTTPS_CERTIFICATE_FILE", Value: tlsMountPath + "/" + corev1.TLSCertKey},
+							{Name: "KC_HTTPS_CERTIFICATE_KEY_FILE", Value: tlsMountPath + "/" + corev1.TLSPrivateKeyKey},
+							{Name: "KC_HEALTH_ENABLED", Value: "true"},
+						},
+						Ports: []corev1.ContainerPort{
+							{Name: "https", ContainerPort: httpsPort, Protocol: corev1.ProtocolTCP},
+							{Name: "management", ContainerPort: managementPort, Protocol: corev1.ProtocolTCP},
+						},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path:   "/health/ready",
+									Port:   intstr.FromString("management"),
+									Scheme: corev1.URISchemeHTTPS,
+								},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "tls", MountPath: tlsMountPath, ReadOnly: true},
+							{Name: "realm", MountPath: realmMountPath, ReadOnly: true},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "tls",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: tlsSecretName},
+							},
+						},
+						{
+							Name: "realm",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: realmConfigMapName},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildService returns the NodePort Service pinned to v1alpha1.KeycloakHTTPSNodePort.
+func buildService() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: objectMeta(DeploymentName),
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeNodePort,
+			Selector: labels(),
+			Ports: []corev1.ServicePort{{
+				Name:       "https",
+				Port:       httpsPort,
+				TargetPort: intstr.FromString("https"),
+				NodePort:   v1alpha1.KeycloakHTTPSNodePort,
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package keycloakinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Dockerfile
+var dockerfile string
+
+// Image returns the Keycloak image the development identity provider runs.
+func Image() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+(quay\.io/keycloak/keycloak:[^\s]+)`,
+		"keycloak",
+	)
+}
@@ -0,0 +1,13 @@
+// Package knativeinstaller provides an installer for Knative Serving selected
+// by spec.cluster.knative.
+//
+// Knative Serving is installed through the Knative Operator: the operator chart
+// is installed as knative-operator in the knative-operator namespace, and a
+// KnativeServing resource in the knative-serving namespace asks it to deploy
+// Serving with the Kourier networking layer. On the Docker provider the Kourier
+// gateway is a NodePort pinned to v1alpha1.KnativeHTTPNodePort, which the Kind
+// and K3d provisioners map to host port 8080, and services are published under
+// the 127.0.0.1.sslip.io magic DNS domain so their URLs resolve to localhost.
+//
+// See: https://knative.dev/docs/serving/
+package knativeinstaller
@@ -0,0 +1,11 @@
+//nolint:gochecknoglobals // export_test.go exposes unexported symbols for testing via package-level vars.
+package knativeinstaller
+
+// ExportBuildServing exposes (*Installer).buildServing for testing.
+var ExportBuildServing = (*Installer).buildServing
+
+// ExportWaitForServingReady exposes (*Installer).waitForServingReady for testing.
+var ExportWaitForServingReady = (*Installer).waitForServingReady
+
+// ExportDeleteServing exposes deleteServing for testing.
+var ExportDeleteServing = deleteServing
@@ -0,0 +1,326 @@
+package knativeinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/ingress"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	repoName = "knative-operator"
+	repoURL  = "https://knative.github.io/operator"
+
+	// Namespace is the namespace the Knative Operator is installed into.
+	Namespace = "knative-operator"
+	// ReleaseName is the Helm release name of the Knative Operator.
+	ReleaseName = "knative-operator"
+	// ServingNamespace is the namespace Knative Serving is deployed into.
+	ServingNamespace = "knative-serving"
+	// ServingName is the name of the KnativeServing resource KSail manages.
+	ServingName = "knative-serving"
+
+	// kourierIngressClass selects Kourier as Knative's networking layer.
+	kourierIngressClass = "kourier.ingress.networking.knative.dev"
+
+	// fieldManager is the Server-Side Apply field manager KSail applies with.
+	fieldManager = "ksail"
+
+	// pollInterval is the interval between KnativeServing CRD and readiness checks.
+	pollInterval = 2 * time.Second
+	// crdPollTimeout is the maximum time to wait for the KnativeServing CRD to
+	// be registered after the operator chart is installed.
+	crdPollTimeout = 60 * time.Second
+)
+
+//nolint:gochecknoglobals // GroupVersionResources are immutable lookup values.
+var (
+	knativeServingGVR = schema.GroupVersionResource{
+		Group:    "operator.knative.dev",
+		Version:  "v1beta1",
+		Resource: "knativeservings",
+	}
+	namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+// Installer installs or upgrades Knative Serving.
+//
+// It embeds helmutil.Base for the Knative Operator Helm lifecycle and adds a
+// post-install step that applies the KnativeServing resource and waits for
+// Serving to become ready.
+type Installer struct {
+	*helmutil.Base
+
+	kubeconfig      string
+	context         string
+	timeout         time.Duration
+	exposeNodePorts bool
+}
+
+// NewInstaller creates a new Knative Serving installer instance.
+// When exposeNodePorts is true (the Docker provider) the Kourier gateway is a
+// NodePort pinned to the fixed Knative node ports and services use the sslip.io
+// magic DNS domain; otherwise Kourier requests a LoadBalancer and Knative keeps
+// its default domain.
+func NewInstaller(
+	client helm.Interface,
+	kubeconfig, context string,
+	timeout time.Duration,
+	exposeNodePorts bool,
+) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"knative",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: repoName,
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName:     ReleaseName,
+				ChartName:       repoName + "/knative-operator",
+				Namespace:       Namespace,
+				Version:         chartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+			},
+		),
+		kubeconfig:      kubeconfig,
+		context:         context,
+		timeout:         timeout,
+		exposeNodePorts: exposeNodePorts,
+	}
+}
+
+// Install installs or upgrades the Knative Operator via its Helm chart, then
+// applies the KnativeServing resource and waits for Serving to become ready.
+func (k *Installer) Install(ctx context.Context) error {
+	err := k.Base.Install(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install knative operator: %w", err)
+	}
+
+	dynamicClient, err := k8s.NewDynamicClient(k.kubeconfig, k.context)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	err = k.waitForCRDs(ctx, dynamicClient, pollInterval, crdPollTimeout)
+	if err != nil {
+		return fmt.Errorf("knative operator CRDs not ready: %w", err)
+	}
+
+	err = k.applyServing(ctx, dynamicClient)
+	if err != nil {
+		return err
+	}
+
+	err = k.waitForServingReady(ctx, dynamicClient, pollInterval, k.timeout)
+	if err != nil {
+		return fmt.Errorf("knative serving not ready: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall deletes the KnativeServing resource, which makes the operator tear
+// down Serving and Kourier, and then uninstalls the Knative Operator release.
+func (k *Installer) Uninstall(ctx context.Context) error {
+	dynamicClient, err := k8s.NewDynamicClient(k.kubeconfig, k.context)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	err = deleteServing(ctx, dynamicClient)
+	if err != nil {
+		return err
+	}
+
+	err = k.Base.Uninstall(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to uninstall knative operator: %w", err)
+	}
+
+	return nil
+}
+
+// waitForCRDs polls the KnativeServing CRD until it is available. Only
+// CRD-not-yet-registered errors (404 Not Found) are retried; other errors
+// (RBAC, network, auth) cause an immediate failure.
+func (k *Installer) waitForCRDs(
+	ctx context.Context,
+	client dynamic.Interface,
+	interval, timeout time.Duration,
+) error {
+	deadline := time.After(timeout)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr error
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context cancelled waiting for knative CRDs: %w", ctx.Err())
+		case <-deadline:
+			return fmt.Errorf("timed out waiting for knative CRDs after %s: %w", timeout, lastErr)
+		case <-ticker.C:
+			_, err := client.Resource(knativeServingGVR).Namespace(ServingNamespace).
+				List(ctx, metav1.ListOptions{Limit: 1})
+			if err == nil {
+				return nil
+			}
+
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("unexpected error checking knative CRDs: %w", err)
+			}
+
+			lastErr = err
+		}
+	}
+}
+
+// applyServing creates the knative-serving namespace and applies the
+// KnativeServing resource using Server-Side Apply, so only the fields owned by
+// the "ksail" field manager are managed and user customizations are preserved.
+func (k *Installer) applyServing(ctx context.Context, client dynamic.Interface) error {
+	namespace := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]any{"name": ServingNamespace},
+		},
+	}
+
+	_, err := client.Resource(namespaceGVR).
+		Apply(ctx, ServingNamespace, namespace, metav1.ApplyOptions{FieldManager: fieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to apply %s namespace: %w", ServingNamespace, err)
+	}
+
+	_, err = client.Resource(knativeServingGVR).Namespace(ServingNamespace).
+		Apply(ctx, ServingName, k.buildServing(), metav1.ApplyOptions{FieldManager: fieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to apply KnativeServing: %w", err)
+	}
+
+	return nil
+}
+
+// buildServing returns the KnativeServing resource that deploys Serving with
+// Kourier. On the Docker provider Kourier is pinned to the fixed Knative node
+// ports and the sslip.io domain makes every service URL resolve to localhost.
+func (k *Installer) buildServing() *unstructured.Unstructured {
+	kourier := map[string]any{
+		"enabled":      true,
+		"service-type": ingress.ServiceType(k.exposeNodePorts),
+	}
+
+	config := map[string]any{
+		"network": map[string]any{
+			"ingress-class": kourierIngressClass,
+		},
+	}
+
+	if k.exposeNodePorts {
+		kourier["http-port"] = int64(v1alpha1.KnativeHTTPNodePort)
+		kourier["https-port"] = int64(v1alpha1.KnativeHTTPSNodePort)
+		config["domain"] = map[string]any{
+			v1alpha1.KnativeMagicDNSDomain: "",
+		}
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": knativeServingGVR.GroupVersion().String(),
+			"kind":       "KnativeServing",
+			"metadata": map[string]any{
+				"name":      ServingName,
+				"namespace": ServingNamespace,
+			},
+			"spec": map[string]any{
+				"ingress": map[string]any{"kourier": kourier},
+				"config":  config,
+			},
+		},
+	}
+}
+
+// waitForServingReady polls the KnativeServing resource until its Ready
+// condition is True.
+func (k *Installer) waitForServingReady(
+	ctx context.Context,
+	client dynamic.Interface,
+	interval, timeout time.Duration,
+) error {
+	deadline := time.After(timeout)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context cancelled waiting for knative serving: %w", ctx.Err())
+		case <-deadline:
+			return fmt.Errorf("timed out waiting for knative serving after %s", timeout)
+		case <-ticker.C:
+			serving, err := client.Resource(knativeServingGVR).Namespace(ServingNamespace).
+				Get(ctx, ServingName, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get KnativeServing: %w", err)
+			}
+
+			if servingReady(serving) {
+				return nil
+			}
+		}
+	}
+}
+
+// servingReady reports whether the KnativeServing status has a Ready
+// condition set to True.
+func servingReady(serving *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(serving.Object, "status", "conditions")
+
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if condition["type"] == "Ready" && condition["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deleteServing deletes the KnativeServing resource. A missing resource (or a
+// missing CRD, when the operator is already gone) is not an error.
+func deleteServing(ctx context.Context, client dynamic.Interface) error {
+	err := client.Resource(knativeServingGVR).Namespace(ServingNamespace).
+		Delete(ctx, ServingName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete KnativeServing: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package knativeinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	knativeinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/knative"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestBuildServing_NodePorts(t *testing.T) {
+	t.Parallel()
+
+	installer := newInstaller(t, true)
+	serving := knativeinstaller.ExportBuildServing(installer)
+
+	assert.Equal(t, "KnativeServing", serving.GetKind())
+	assert.Equal(t, knativeinstaller.ServingNamespace, serving.GetNamespace())
+	assert.Equal(t, "NodePort", nested(t, serving, "spec", "ingress", "kourier", "service-type"))
+	assert.Equal(t, int64(31080), nested(t, serving, "spec", "ingress", "kourier", "http-port"))
+	assert.Equal(t, int64(31443), nested(t, serving, "spec", "ingress", "kourier", "https-port"))
+	assert.Equal(t,
+		"kourier.ingress.networking.knative.dev",
+		nested(t, serving, "spec", "config", "network", "ingress-class"),
+	)
+
+	domain, found, err := unstructured.NestedMap(serving.Object, "spec", "config", "domain")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, map[string]any{"127.0.0.1.sslip.io": ""}, domain)
+}
+
+func TestBuildServing_LoadBalancer(t *testing.T) {
+	t.Parallel()
+
+	installer := newInstaller(t, false)
+	serving := knativeinstaller.ExportBuildServing(installer)
+
+	assert.Equal(t, "LoadBalancer", nested(t, serving, "spec", "ingress", "kourier", "service-type"))
+
+	_, found, err := unstructured.NestedFieldNoCopy(
+		serving.Object, "spec", "ingress", "kourier", "http-port",
+	)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, found, err = unstructured.NestedFieldNoCopy(serving.Object, "spec", "config", "domain")
+	require.NoError(t, err)
+	assert.False(t, found, "the default Knative domain is kept off the Docker provider")
+}
+
+func TestWaitForServingReady(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  string
+		wantErr bool
+	}{
+		{name: "ready", status: "True"},
+		{name: "not ready times out", status: "False", wantErr: true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			serving := newServing()
+			require.NoError(t, unstructured.SetNestedSlice(serving.Object, []any{
+				map[string]any{"type": "Ready", "status": testCase.status},
+			}, "status", "conditions"))
+
+			err := knativeinstaller.ExportWaitForServingReady(
+				newInstaller(t, true),
+				context.Background(),
+				newDynamicClient(serving),
+				time.Millisecond,
+				50*time.Millisecond,
+			)
+			if testCase.wantErr {
+				require.ErrorContains(t, err, "timed out waiting for knative serving")
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteServing(t *testing.T) {
+	t.Parallel()
+
+	client := newDynamicClient(newServing())
+
+	require.NoError(t, knativeinstaller.ExportDeleteServing(context.Background(), client))
+	require.NoError(
+		t,
+		knativeinstaller.ExportDeleteServing(context.Background(), client),
+		"deleting a missing KnativeServing is not an error",
+	)
+}
+
+func newInstaller(t *testing.T, exposeNodePorts bool) *knativeinstaller.Installer {
+	t.Helper()
+
+	return knativeinstaller.NewInstaller(
+		helm.NewMockInterface(t),
+		"~/.kube/config",
+		"test-context",
+		5*time.Minute,
+		exposeNodePorts,
+	)
+}
+
+func newServing() *unstructured.Unstructured {
+	serving := &unstructured.Unstructured{}
+	serving.SetAPIVersion("operator.knative.dev/v1beta1")
+	serving.SetKind("KnativeServing")
+	serving.SetName(knativeinstaller.ServingName)
+	serving.SetNamespace(knativeinstaller.ServingNamespace)
+
+	return serving
+}
+
+func newDynamicClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			{
+				Group:    "operator.knative.dev",
+				Version:  "v1beta1",
+				Resource: "knativeservings",
+			}: "KnativeServingList",
+		},
+		objects...,
+	)
+}
+
+func nested(t *testing.T, obj *unstructured.Unstructured, fields ...string) any {
+	t.Helper()
+
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	require.NoError(t, err)
+	require.True(t, found, "field %v not found", fields)
+
+	return value
+}
@@ -0,0 +1,12 @@
+// Package linkerdinstaller provides an installer for the Linkerd service mesh
+// selected by spec.cluster.serviceMesh: Linkerd.
+//
+// Linkerd is installed from its edge Helm charts into the linkerd namespace:
+// linkerd-crds first, then linkerd-control-plane with Helm's readiness wait.
+// The control plane needs an mTLS trust anchor and an identity issuer
+// certificate. On first install the installer generates both; on later
+// installs it reads them back from the cluster, so upgrades never rotate the
+// trust root under running meshed workloads.
+//
+// See: https://linkerd.io/2/tasks/install-helm/
+package linkerdinstaller
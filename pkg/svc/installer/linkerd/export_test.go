@@ -0,0 +1,14 @@
+package linkerdinstaller
+
+// Identity exposes identity for testing.
+type Identity = identity
+
+//nolint:gochecknoglobals // export_test.go pattern requires global variables to expose internal functions
+var (
+	// GenerateIdentity exposes generateIdentity for testing.
+	GenerateIdentity = generateIdentity
+	// ReadIdentity exposes readIdentity for testing.
+	ReadIdentity = readIdentity
+	// BuildValuesYaml exposes buildValuesYaml for testing.
+	BuildValuesYaml = buildValuesYaml
+)
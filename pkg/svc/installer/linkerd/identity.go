@@ -0,0 +1,191 @@
+package linkerdinstaller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// trustRootsConfigMap holds the trust anchor bundle written by the chart.
+	trustRootsConfigMap = "linkerd-identity-trust-roots"
+	// trustRootsKey is the trust anchor bundle key in trustRootsConfigMap.
+	trustRootsKey = "ca-bundle.crt"
+	// issuerSecret holds the identity issuer certificate and key written by the chart.
+	issuerSecret = "linkerd-identity-issuer"
+	// issuerCertKey and issuerKeyKey are the issuer entries in issuerSecret.
+	issuerCertKey = "crt.pem"
+	issuerKeyKey  = "key.pem"
+
+	// trustAnchorCommonName and issuerCommonName follow the names Linkerd's
+	// own certificate guide uses; the issuer name must match the identity
+	// trust domain.
+	trustAnchorCommonName = "root.linkerd.cluster.local"
+	issuerCommonName      = "identity.linkerd.cluster.local"
+
+	// certificateValidity is how long the generated certificates are valid.
+	// KSail clusters are development clusters that are recreated long before
+	// this, and the certificates are reused on upgrade rather than rotated.
+	certificateValidity = 10 * 365 * 24 * time.Hour
+	// serialNumberBits bounds the random certificate serial numbers.
+	serialNumberBits = 128
+)
+
+// ErrIncompleteIdentity is returned when the cluster holds only part of a
+// Linkerd identity (e.g. the trust roots without the issuer).
+var ErrIncompleteIdentity = errors.New("linkerd identity is incomplete")
+
+// identity is the trust anchor and issuer credentials the control plane chart
+// requires.
+type identity struct {
+	TrustAnchorsPEM string
+	IssuerCertPEM   string
+	IssuerKeyPEM    string
+}
+
+// generateIdentity creates a self-signed ECDSA trust anchor and an issuer
+// certificate signed by it.
+func generateIdentity(now time.Time) (identity, error) {
+	anchorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return identity{}, fmt.Errorf("generate trust anchor key: %w", err)
+	}
+
+	anchorTemplate, err := caTemplate(trustAnchorCommonName, now)
+	if err != nil {
+		return identity{}, err
+	}
+
+	anchorTemplate.MaxPathLen = 1
+
+	anchorDER, err := x509.CreateCertificate(
+		rand.Reader, anchorTemplate, anchorTemplate, &anchorKey.PublicKey, anchorKey,
+	)
+	if err != nil {
+		return identity{}, fmt.Errorf("create trust anchor: %w", err)
+	}
+
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return identity{}, fmt.Errorf("generate issuer key: %w", err)
+	}
+
+	issuerTemplate, err := caTemplate(issuerCommonName, now)
+	if err != nil {
+		return identity{}, err
+	}
+
+	issuerTemplate.MaxPathLenZero = true
+
+	issuerDER, err := x509.CreateCertificate(
+		rand.Reader, issuerTemplate, anchorTemplate, &issuerKey.PublicKey, anchorKey,
+	)
+	if err != nil {
+		return identity{}, fmt.Errorf("create issuer certificate: %w", err)
+	}
+
+	issuerKeyDER, err := x509.MarshalECPrivateKey(issuerKey)
+	if err != nil {
+		return identity{}, fmt.Errorf("encode issuer key: %w", err)
+	}
+
+	return identity{
+		TrustAnchorsPEM: encodePEM("CERTIFICATE", anchorDER),
+		IssuerCertPEM:   encodePEM("CERTIFICATE", issuerDER),
+		IssuerKeyPEM:    encodePEM("EC PRIVATE KEY", issuerKeyDER),
+	}, nil
+}
+
+// caTemplate returns a CA certificate template valid from now.
+func caTemplate(commonName string, now time.Time) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return nil, fmt.Errorf("generate serial number: %w", err)
+	}
+
+	return &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certificateValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil
+}
+
+func encodePEM(blockType string, der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}))
+}
+
+// loadIdentity reads the identity of an existing Linkerd installation from the
+// cluster of the given kubeconfig. It reports false when Linkerd has not been
+// installed yet.
+func loadIdentity(
+	ctx context.Context,
+	kubeconfig, kubeContext string,
+) (identity, bool, error) {
+	restConfig, err := k8s.BuildRESTConfig(kubeconfig, kubeContext)
+	if err != nil {
+		return identity{}, false, fmt.Errorf("build rest config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return identity{}, false, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	return readIdentity(ctx, clientset)
+}
+
+// readIdentity reads the trust roots ConfigMap and issuer Secret the control
+// plane chart created. It reports false when neither exists.
+func readIdentity(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+) (identity, bool, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(Namespace).
+		Get(ctx, trustRootsConfigMap, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return identity{}, false, nil
+	}
+
+	if err != nil {
+		return identity{}, false, fmt.Errorf("get %s: %w", trustRootsConfigMap, err)
+	}
+
+	secret, err := clientset.CoreV1().Secrets(Namespace).
+		Get(ctx, issuerSecret, metav1.GetOptions{})
+	if err != nil {
+		return identity{}, false, fmt.Errorf("get %s: %w", issuerSecret, err)
+	}
+
+	existing := identity{
+		TrustAnchorsPEM: configMap.Data[trustRootsKey],
+		IssuerCertPEM:   string(secret.Data[issuerCertKey]),
+		IssuerKeyPEM:    string(secret.Data[issuerKeyKey]),
+	}
+
+	if existing.TrustAnchorsPEM == "" || existing.IssuerCertPEM == "" ||
+		existing.IssuerKeyPEM == "" {
+		return identity{}, false, fmt.Errorf(
+			"%w: %s/%s or %s/%s is missing data",
+			ErrIncompleteIdentity, Namespace, trustRootsConfigMap, Namespace, issuerSecret,
+		)
+	}
+
+	return existing, true, nil
+}
@@ -0,0 +1,132 @@
+package linkerdinstaller_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	linkerdinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/linkerd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGenerateIdentity_IssuerChainsToTrustAnchor(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	meshIdentity, err := linkerdinstaller.GenerateIdentity(now)
+	require.NoError(t, err)
+
+	anchor := parseCertificate(t, meshIdentity.TrustAnchorsPEM)
+	issuer := parseCertificate(t, meshIdentity.IssuerCertPEM)
+
+	assert.True(t, anchor.IsCA)
+	assert.Equal(t, "identity.linkerd.cluster.local", issuer.Subject.CommonName)
+	assert.True(t, issuer.IsCA)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(anchor)
+
+	_, err = issuer.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	require.NoError(t, err)
+
+	keyBlock, _ := pem.Decode([]byte(meshIdentity.IssuerKeyPEM))
+	require.NotNil(t, keyBlock)
+	assert.Equal(t, "EC PRIVATE KEY", keyBlock.Type)
+}
+
+func TestReadIdentity(t *testing.T) {
+	t.Parallel()
+
+	trustRoots := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "linkerd-identity-trust-roots",
+			Namespace: linkerdinstaller.Namespace,
+		},
+		Data: map[string]string{"ca-bundle.crt": "anchor"},
+	}
+	issuer := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "linkerd-identity-issuer",
+			Namespace: linkerdinstaller.Namespace,
+		},
+		Data: map[string][]byte{"crt.pem": []byte("crt"), "key.pem": []byte("key")},
+	}
+	emptyIssuer := issuer.DeepCopy()
+	emptyIssuer.Data = nil
+
+	t.Run("NotInstalled", func(t *testing.T) {
+		t.Parallel()
+
+		_, found, err := linkerdinstaller.ReadIdentity(t.Context(), fake.NewClientset())
+
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("Installed", func(t *testing.T) {
+		t.Parallel()
+
+		meshIdentity, found, err := linkerdinstaller.ReadIdentity(
+			t.Context(), fake.NewClientset(trustRoots, issuer),
+		)
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, linkerdinstaller.Identity{
+			TrustAnchorsPEM: "anchor",
+			IssuerCertPEM:   "crt",
+			IssuerKeyPEM:    "key",
+		}, meshIdentity)
+	})
+
+	t.Run("Incomplete", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := linkerdinstaller.ReadIdentity(
+			t.Context(), fake.NewClientset(trustRoots, emptyIssuer),
+		)
+
+		require.ErrorIs(t, err, linkerdinstaller.ErrIncompleteIdentity)
+	})
+}
+
+func TestBuildValuesYaml_CarriesIdentity(t *testing.T) {
+	t.Parallel()
+
+	values, err := linkerdinstaller.BuildValuesYaml(linkerdinstaller.Identity{
+		TrustAnchorsPEM: "anchor",
+		IssuerCertPEM:   "crt",
+		IssuerKeyPEM:    "key",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, values, "identityTrustAnchorsPEM: anchor")
+	assert.Contains(t, values, "crtPEM: crt")
+	assert.Contains(t, values, "keyPEM: key")
+
+	empty, err := linkerdinstaller.BuildValuesYaml(linkerdinstaller.Identity{})
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func parseCertificate(t *testing.T, certPEM string) *x509.Certificate {
+	t.Helper()
+
+	block, _ := pem.Decode([]byte(certPEM))
+	require.NotNil(t, block)
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	return cert
+}
@@ -0,0 +1,200 @@
+package linkerdinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	repoName = "linkerd-edge"
+	repoURL  = "https://helm.linkerd.io/edge"
+
+	// Namespace is the namespace Linkerd is installed into.
+	Namespace = "linkerd"
+	// CRDsReleaseName is the Helm release name of the Linkerd CRDs.
+	CRDsReleaseName = "linkerd-crds"
+	// ControlPlaneReleaseName is the Helm release name of the Linkerd control plane.
+	ControlPlaneReleaseName = "linkerd-control-plane"
+)
+
+// Installer installs or upgrades Linkerd.
+//
+// The CRDs are installed before the control plane; Uninstall removes them in
+// reverse order.
+type Installer struct {
+	client      helm.Interface
+	timeout     time.Duration
+	kubeconfig  string
+	kubeContext string
+}
+
+// NewInstaller creates a new Linkerd installer instance.
+//
+// kubeconfig and kubeContext are used to read the identity of an existing
+// installation so upgrades keep its trust anchor. Pass an empty kubeconfig to
+// always generate a fresh identity (tests or environments without cluster
+// access).
+func NewInstaller(
+	client helm.Interface,
+	timeout time.Duration,
+	kubeconfig, kubeContext string,
+) *Installer {
+	return &Installer{
+		client:      client,
+		timeout:     timeout,
+		kubeconfig:  kubeconfig,
+		kubeContext: kubeContext,
+	}
+}
+
+// Install installs the Linkerd CRDs and then the control plane, waiting for
+// the control plane deployments to become ready.
+func (l *Installer) Install(ctx context.Context) error {
+	err := l.crds().Install(ctx)
+	if err != nil {
+		return err //nolint:wrapcheck // helmutil.Base already wraps with the release name
+	}
+
+	meshIdentity, err := l.resolveIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	controlPlane, err := l.controlPlane(meshIdentity)
+	if err != nil {
+		return err
+	}
+
+	return controlPlane.Install(ctx) //nolint:wrapcheck // helmutil.Base already wraps
+}
+
+// Uninstall removes the control plane and then the CRDs.
+func (l *Installer) Uninstall(ctx context.Context) error {
+	controlPlane, err := l.controlPlane(identity{})
+	if err != nil {
+		return err
+	}
+
+	err = controlPlane.Uninstall(ctx)
+	if err != nil {
+		return err //nolint:wrapcheck // helmutil.Base already wraps with the release name
+	}
+
+	return l.crds().Uninstall(ctx) //nolint:wrapcheck // helmutil.Base already wraps
+}
+
+// Images returns the container images used by the Linkerd charts. The control
+// plane is templated with a throwaway identity, which does not affect its images.
+func (l *Installer) Images(ctx context.Context) ([]string, error) {
+	images, err := l.crds().Images(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // helmutil.Base already wraps with the release name
+	}
+
+	meshIdentity, err := generateIdentity(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlane, err := l.controlPlane(meshIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	controlPlaneImages, err := controlPlane.Images(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // helmutil.Base already wraps with the release name
+	}
+
+	return append(images, controlPlaneImages...), nil
+}
+
+// resolveIdentity returns the identity of the existing installation, or a
+// freshly generated one on first install.
+func (l *Installer) resolveIdentity(ctx context.Context) (identity, error) {
+	if l.kubeconfig != "" {
+		existing, found, err := loadIdentity(ctx, l.kubeconfig, l.kubeContext)
+		if err != nil {
+			return identity{}, fmt.Errorf("read existing linkerd identity: %w", err)
+		}
+
+		if found {
+			return existing, nil
+		}
+	}
+
+	return generateIdentity(time.Now())
+}
+
+func (l *Installer) crds() *helmutil.Base {
+	return l.base("linkerd-crds", CRDsReleaseName, crdsChartVersion(), "")
+}
+
+func (l *Installer) controlPlane(meshIdentity identity) (*helmutil.Base, error) {
+	valuesYaml, err := buildValuesYaml(meshIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.base(
+		"linkerd-control-plane", ControlPlaneReleaseName, controlPlaneChartVersion(), valuesYaml,
+	), nil
+}
+
+func (l *Installer) base(chart, release, version, valuesYaml string) *helmutil.Base {
+	return helmutil.NewBase(
+		release,
+		l.client,
+		l.timeout,
+		&helm.RepositoryEntry{
+			Name: repoName,
+			URL:  repoURL,
+		},
+		&helm.ChartSpec{
+			ReleaseName:     release,
+			ChartName:       repoName + "/" + chart,
+			Namespace:       Namespace,
+			Version:         version,
+			RepoURL:         repoURL,
+			CreateNamespace: true,
+			Atomic:          true,
+			Wait:            true,
+			WaitForJobs:     true,
+			UpgradeCRDs:     true,
+			Timeout:         l.timeout,
+			ValuesYaml:      valuesYaml,
+		},
+	)
+}
+
+// buildValuesYaml returns the control plane values carrying the trust anchor
+// and issuer credentials. An empty identity yields no values.
+func buildValuesYaml(meshIdentity identity) (string, error) {
+	if meshIdentity == (identity{}) {
+		return "", nil
+	}
+
+	values := map[string]any{
+		"identityTrustAnchorsPEM": meshIdentity.TrustAnchorsPEM,
+		"identity": map[string]any{
+			"issuer": map[string]any{
+				"tls": map[string]any{
+					"crtPEM": meshIdentity.IssuerCertPEM,
+					"keyPEM": meshIdentity.IssuerKeyPEM,
+				},
+			},
+		},
+	}
+
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("marshal linkerd values: %w", err)
+	}
+
+	return string(out), nil
+}
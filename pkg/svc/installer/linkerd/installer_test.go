@@ -0,0 +1,86 @@
+package linkerdinstaller_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	linkerdinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/linkerd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallerInstall_CRDsBeforeControlPlane(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := linkerdinstaller.NewInstaller(client, 5*time.Second, "", "")
+
+	var releases []string
+
+	client.EXPECT().
+		GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil)
+	client.EXPECT().
+		AddRepository(
+			mock.Anything,
+			mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+				return entry != nil && entry.URL == "https://helm.linkerd.io/edge"
+			}),
+			mock.Anything,
+		).
+		Return(nil)
+	client.EXPECT().
+		InstallOrUpgradeChart(
+			mock.Anything,
+			mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+				assert.Equal(t, linkerdinstaller.Namespace, spec.Namespace)
+				assert.NotEmpty(t, spec.Version)
+				assert.True(t, spec.Wait)
+
+				if spec.ReleaseName == linkerdinstaller.ControlPlaneReleaseName {
+					assert.True(t,
+						strings.Contains(spec.ValuesYaml, "identityTrustAnchorsPEM"),
+						"control plane values must carry the trust anchor",
+					)
+				}
+
+				releases = append(releases, spec.ReleaseName)
+
+				return true
+			}),
+		).
+		Return(nil, nil)
+
+	require.NoError(t, installer.Install(context.Background()))
+	assert.Equal(t, []string{
+		linkerdinstaller.CRDsReleaseName,
+		linkerdinstaller.ControlPlaneReleaseName,
+	}, releases)
+}
+
+func TestInstallerUninstall_ControlPlaneFirst(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := linkerdinstaller.NewInstaller(client, 5*time.Second, "", "")
+
+	var releases []string
+
+	client.EXPECT().
+		UninstallRelease(mock.Anything, mock.Anything, linkerdinstaller.Namespace).
+		RunAndReturn(func(_ context.Context, release, _ string) error {
+			releases = append(releases, release)
+
+			return nil
+		})
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+	assert.Equal(t, []string{
+		linkerdinstaller.ControlPlaneReleaseName,
+		linkerdinstaller.CRDsReleaseName,
+	}, releases)
+}
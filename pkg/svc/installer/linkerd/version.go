@@ -0,0 +1,22 @@
+package linkerdinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Chart.yaml
+var chartYAML string
+
+// crdsChartVersion returns the pinned linkerd-crds chart version extracted from
+// the embedded Chart.yaml (kept in sync by Dependabot's helm ecosystem).
+func crdsChartVersion() string {
+	return parser.ParseChartVersionFromChartYaml(chartYAML, "linkerd-crds")
+}
+
+// controlPlaneChartVersion returns the pinned linkerd-control-plane chart
+// version extracted from the embedded Chart.yaml.
+func controlPlaneChartVersion() string {
+	return parser.ParseChartVersionFromChartYaml(chartYAML, "linkerd-control-plane")
+}
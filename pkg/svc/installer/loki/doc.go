@@ -0,0 +1,7 @@
+// Package lokiinstaller provides an installer for the Loki log aggregation stack
+// selected by spec.cluster.observability.logging.
+//
+// Grafana Loki runs in single-binary mode with filesystem storage, which is
+// enough for a development cluster, and Promtail runs as a DaemonSet shipping
+// every container's logs to Loki's push endpoint.
+package lokiinstaller
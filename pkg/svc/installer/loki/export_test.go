@@ -0,0 +1,8 @@
+//nolint:gochecknoglobals // export_test.go pattern requires global variables to expose internal functions
+package lokiinstaller
+
+// BuildLokiValuesYaml exposes buildLokiValuesYaml for testing.
+var BuildLokiValuesYaml = buildLokiValuesYaml
+
+// BuildPromtailValuesYaml exposes buildPromtailValuesYaml for testing.
+var BuildPromtailValuesYaml = buildPromtailValuesYaml
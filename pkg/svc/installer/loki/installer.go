@@ -0,0 +1,184 @@
+package lokiinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const (
+	repoName = "grafana"
+	repoURL  = "https://grafana.github.io/helm-charts"
+
+	// Namespace is the namespace Loki and Promtail are installed into.
+	Namespace = "logging"
+	// LokiReleaseName is the Helm release name for Loki.
+	LokiReleaseName = "loki"
+	// PromtailReleaseName is the Helm release name for Promtail.
+	PromtailReleaseName = "promtail"
+
+	// lokiPort is the HTTP port of the single-binary Loki Service.
+	lokiPort = 3100
+)
+
+// Installer installs or upgrades Loki and Promtail.
+//
+// Loki is installed before Promtail so the DaemonSet's push endpoint exists by
+// the time it starts shipping logs; Uninstall removes them in reverse order.
+type Installer struct {
+	loki     *helmutil.Base
+	promtail *helmutil.Base
+}
+
+// NewInstaller creates a new Loki + Promtail installer instance.
+func NewInstaller(client helm.Interface, timeout time.Duration) *Installer {
+	repo := &helm.RepositoryEntry{
+		Name: repoName,
+		URL:  repoURL,
+	}
+
+	return &Installer{
+		loki: helmutil.NewBase(
+			"loki",
+			client,
+			timeout,
+			repo,
+			&helm.ChartSpec{
+				ReleaseName:     LokiReleaseName,
+				ChartName:       repoName + "/loki",
+				Namespace:       Namespace,
+				Version:         lokiChartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      buildLokiValuesYaml(),
+			},
+		),
+		promtail: helmutil.NewBase(
+			"promtail",
+			client,
+			timeout,
+			repo,
+			&helm.ChartSpec{
+				ReleaseName:     PromtailReleaseName,
+				ChartName:       repoName + "/promtail",
+				Namespace:       Namespace,
+				Version:         promtailChartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				Timeout:         timeout,
+				ValuesYaml:      buildPromtailValuesYaml(),
+			},
+		),
+	}
+}
+
+// Install installs Loki and then Promtail.
+func (i *Installer) Install(ctx context.Context) error {
+	err := i.loki.Install(ctx)
+	if err != nil {
+		return fmt.Errorf("install loki: %w", err)
+	}
+
+	err = i.promtail.Install(ctx)
+	if err != nil {
+		return fmt.Errorf("install promtail: %w", err)
+	}
+
+	return nil
+}
+
+// Uninstall removes Promtail and then Loki.
+func (i *Installer) Uninstall(ctx context.Context) error {
+	err := i.promtail.Uninstall(ctx)
+	if err != nil {
+		return fmt.Errorf("uninstall promtail: %w", err)
+	}
+
+	err = i.loki.Uninstall(ctx)
+	if err != nil {
+		return fmt.Errorf("uninstall loki: %w", err)
+	}
+
+	return nil
+}
+
+// Images returns the container images used by the Loki and Promtail charts.
+func (i *Installer) Images(ctx context.Context) ([]string, error) {
+	lokiImages, err := i.loki.Images(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list loki images: %w", err)
+	}
+
+	promtailImages, err := i.promtail.Images(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list promtail images: %w", err)
+	}
+
+	return append(lokiImages, promtailImages...), nil
+}
+
+// PushURL returns the in-cluster Loki push endpoint Promtail ships logs to.
+func PushURL() string {
+	return fmt.Sprintf(
+		"http://%s.%s.svc.cluster.local:%d/loki/api/v1/push",
+		LokiReleaseName, Namespace, lokiPort,
+	)
+}
+
+// buildLokiValuesYaml returns the Helm values YAML for Loki: a single binary
+// with filesystem storage and no gateway, caches, canary, or bundled MinIO.
+func buildLokiValuesYaml() string {
+	return `deploymentMode: SingleBinary
+loki:
+  auth_enabled: false
+  commonConfig:
+    replication_factor: 1
+  storage:
+    type: filesystem
+  schemaConfig:
+    configs:
+      - from: "2024-01-01"
+        store: tsdb
+        object_store: filesystem
+        schema: v13
+        index:
+          prefix: index_
+          period: 24h
+singleBinary:
+  replicas: 1
+read:
+  replicas: 0
+write:
+  replicas: 0
+backend:
+  replicas: 0
+gateway:
+  enabled: false
+chunksCache:
+  enabled: false
+resultsCache:
+  enabled: false
+lokiCanary:
+  enabled: false
+test:
+  enabled: false
+minio:
+  enabled: false`
+}
+
+// buildPromtailValuesYaml returns the Helm values YAML for Promtail, pointing
+// its single client at the Loki push endpoint.
+func buildPromtailValuesYaml() string {
+	return fmt.Sprintf(`config:
+  clients:
+    - url: %s`, PushURL())
+}
@@ -0,0 +1,153 @@
+package lokiinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	lokiinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/loki"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildLokiValuesYaml_SingleBinary(t *testing.T) {
+	t.Parallel()
+
+	values := parseValues(t, lokiinstaller.BuildLokiValuesYaml())
+
+	assert.Equal(t, "SingleBinary", lookup(t, values, "deploymentMode"))
+	assert.Equal(t, "filesystem", lookup(t, values, "loki", "storage", "type"))
+	assert.InDelta(t, 1, lookup(t, values, "singleBinary", "replicas"), 0)
+	assert.InDelta(t, 0, lookup(t, values, "read", "replicas"), 0)
+	assert.Equal(t, false, lookup(t, values, "gateway", "enabled"))
+	assert.Equal(t, false, lookup(t, values, "minio", "enabled"))
+}
+
+func TestBuildPromtailValuesYaml_PushesToLoki(t *testing.T) {
+	t.Parallel()
+
+	values := parseValues(t, lokiinstaller.BuildPromtailValuesYaml())
+
+	clients, ok := lookup(t, values, "config", "clients").([]any)
+	require.True(t, ok)
+	require.Len(t, clients, 1)
+
+	client, ok := clients[0].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "http://loki.logging.svc.cluster.local:3100/loki/api/v1/push", client["url"])
+}
+
+func TestInstallerInstall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		installErr error
+	}{
+		{name: "success"},
+		{name: "install error", installErr: assert.AnError},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := helm.NewMockInterface(t)
+			installer := lokiinstaller.NewInstaller(client, 5*time.Second)
+
+			var releases []string
+
+			client.EXPECT().
+				GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			client.EXPECT().
+				AddRepository(
+					mock.Anything,
+					mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+						return entry != nil && entry.URL == "https://grafana.github.io/helm-charts"
+					}),
+					mock.Anything,
+				).
+				Return(nil)
+			client.EXPECT().
+				InstallOrUpgradeChart(
+					mock.Anything,
+					mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+						assert.Equal(t, lokiinstaller.Namespace, spec.Namespace)
+						assert.NotEmpty(t, spec.Version)
+						assert.True(t, spec.CreateNamespace)
+
+						releases = append(releases, spec.ReleaseName)
+
+						return true
+					}),
+				).
+				Return(nil, testCase.installErr)
+
+			err := installer.Install(context.Background())
+			if testCase.installErr != nil {
+				require.ErrorIs(t, err, testCase.installErr)
+				assert.Equal(t, []string{lokiinstaller.LokiReleaseName}, releases)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t,
+				[]string{lokiinstaller.LokiReleaseName, lokiinstaller.PromtailReleaseName},
+				releases,
+			)
+		})
+	}
+}
+
+func TestInstallerUninstall_RemovesPromtailFirst(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := lokiinstaller.NewInstaller(client, 5*time.Second)
+
+	var releases []string
+
+	client.EXPECT().
+		UninstallRelease(mock.Anything, mock.Anything, lokiinstaller.Namespace).
+		RunAndReturn(func(_ context.Context, release, _ string) error {
+			releases = append(releases, release)
+
+			return nil
+		})
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+	assert.Equal(t,
+		[]string{lokiinstaller.PromtailReleaseName, lokiinstaller.LokiReleaseName},
+		releases,
+	)
+}
+
+// parseValues decodes a values YAML document into a generic map.
+func parseValues(t *testing.T, valuesYaml string) map[string]any {
+	t.Helper()
+
+	var values map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(valuesYaml), &values))
+
+	return values
+}
+
+// lookup walks a nested values map along path.
+func lookup(t *testing.T, values map[string]any, path ...string) any {
+	t.Helper()
+
+	var current any = values
+	for _, key := range path {
+		node, ok := current.(map[string]any)
+		require.True(t, ok, "expected a map at %q", key)
+
+		current = node[key]
+	}
+
+	return current
+}
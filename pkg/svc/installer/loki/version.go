@@ -0,0 +1,22 @@
+package lokiinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Chart.yaml
+var chartYAML string
+
+// lokiChartVersion returns the pinned Loki chart version extracted from the
+// embedded Chart.yaml (kept in sync by Dependabot's helm ecosystem).
+func lokiChartVersion() string {
+	return parser.ParseChartVersionFromChartYaml(chartYAML, "loki")
+}
+
+// promtailChartVersion returns the pinned Promtail chart version extracted from
+// the embedded Chart.yaml.
+func promtailChartVersion() string {
+	return parser.ParseChartVersionFromChartYaml(chartYAML, "promtail")
+}
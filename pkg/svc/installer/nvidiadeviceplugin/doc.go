@@ -0,0 +1,9 @@
+// Package nvidiadeviceplugininstaller provides an installer for the NVIDIA device
+// plugin, installed when spec.cluster.nodes passes GPUs through to the nodes.
+//
+// The device plugin advertises the GPUs of each labeled node as the nvidia.com/gpu
+// extended resource, so GPU workloads can request them and be scheduled locally on
+// Kind and K3d clusters.
+//
+// See: https://github.com/NVIDIA/k8s-device-plugin
+package nvidiadeviceplugininstaller
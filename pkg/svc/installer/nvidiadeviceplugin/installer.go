@@ -0,0 +1,64 @@
+package nvidiadeviceplugininstaller
+
+import (
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const (
+	repoName = "nvdp"
+	repoURL  = "https://nvidia.github.io/k8s-device-plugin"
+
+	// Namespace is the namespace the NVIDIA device plugin is installed into.
+	Namespace = "nvidia-device-plugin"
+	// ReleaseName is the Helm release name of the NVIDIA device plugin.
+	ReleaseName = "nvidia-device-plugin"
+)
+
+// Installer installs or upgrades the NVIDIA device plugin.
+//
+// It embeds helmutil.Base to provide standard Helm chart lifecycle management.
+type Installer struct {
+	*helmutil.Base
+}
+
+// NewInstaller creates a new NVIDIA device plugin installer instance.
+func NewInstaller(client helm.Interface, timeout time.Duration) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"nvidia-device-plugin",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: repoName,
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName:     ReleaseName,
+				ChartName:       repoName + "/nvidia-device-plugin",
+				Namespace:       Namespace,
+				Version:         chartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      buildValuesYaml(),
+			},
+		),
+	}
+}
+
+// buildValuesYaml returns the Helm values YAML for the device plugin. GPU feature
+// discovery and node feature discovery are left disabled: the chart's default node
+// affinity already matches the nvidia.com/gpu.present label KSail puts on GPU
+// nodes, so no extra discovery components are needed on a local cluster.
+func buildValuesYaml() string {
+	return `gfd:
+  enabled: false
+nfd:
+  enabled: false`
+}
@@ -0,0 +1,95 @@
+package nvidiadeviceplugininstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	nvidiadeviceplugininstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/nvidiadeviceplugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildValuesYaml_DisablesDiscovery(t *testing.T) {
+	t.Parallel()
+
+	values := nvidiadeviceplugininstaller.BuildValuesYaml()
+
+	assert.Contains(t, values, "gfd:\n  enabled: false")
+	assert.Contains(t, values, "nfd:\n  enabled: false")
+}
+
+func TestInstallerInstall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		installErr error
+	}{
+		{name: "success"},
+		{name: "install error", installErr: assert.AnError},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := helm.NewMockInterface(t)
+			installer := nvidiadeviceplugininstaller.NewInstaller(client, 5*time.Second)
+
+			client.EXPECT().
+				GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			client.EXPECT().
+				AddRepository(
+					mock.Anything,
+					mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+						return entry != nil &&
+							entry.URL == "https://nvidia.github.io/k8s-device-plugin"
+					}),
+					mock.Anything,
+				).
+				Return(nil)
+			client.EXPECT().
+				InstallOrUpgradeChart(
+					mock.Anything,
+					mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+						return spec != nil &&
+							spec.ReleaseName == nvidiadeviceplugininstaller.ReleaseName &&
+							spec.Namespace == nvidiadeviceplugininstaller.Namespace &&
+							spec.CreateNamespace &&
+							spec.Version != ""
+					}),
+				).
+				Return(nil, testCase.installErr)
+
+			err := installer.Install(context.Background())
+			if testCase.installErr != nil {
+				require.ErrorIs(t, err, testCase.installErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInstallerUninstall(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := nvidiadeviceplugininstaller.NewInstaller(client, 5*time.Second)
+
+	client.EXPECT().
+		UninstallRelease(
+			mock.Anything,
+			nvidiadeviceplugininstaller.ReleaseName,
+			nvidiadeviceplugininstaller.Namespace,
+		).
+		Return(nil)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+}
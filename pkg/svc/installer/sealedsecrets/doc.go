@@ -0,0 +1,9 @@
+// Package sealedsecretsinstaller provides an installer for the Bitnami Sealed
+// Secrets controller selected by spec.cluster.sealedSecrets.
+//
+// The controller decrypts SealedSecret resources into regular Secrets inside the
+// cluster. It is installed as sealed-secrets-controller in kube-system, the
+// defaults kubeseal and `ksail workload cipher seal` look for.
+//
+// See: https://github.com/bitnami-labs/sealed-secrets
+package sealedsecretsinstaller
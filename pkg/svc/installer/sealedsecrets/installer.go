@@ -0,0 +1,60 @@
+package sealedsecretsinstaller
+
+import (
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const (
+	repoName = "sealed-secrets"
+	repoURL  = "https://bitnami-labs.github.io/sealed-secrets"
+
+	// Namespace is the namespace the Sealed Secrets controller is installed into.
+	Namespace = "kube-system"
+	// ReleaseName is the Helm release name of the Sealed Secrets controller. It
+	// doubles as the controller's Service name, matching kubeseal's default.
+	ReleaseName = "sealed-secrets-controller"
+)
+
+// Installer installs or upgrades the Sealed Secrets controller.
+//
+// It embeds helmutil.Base to provide standard Helm chart lifecycle management.
+type Installer struct {
+	*helmutil.Base
+}
+
+// NewInstaller creates a new Sealed Secrets installer instance.
+func NewInstaller(client helm.Interface, timeout time.Duration) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"sealed-secrets",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: repoName,
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName: ReleaseName,
+				ChartName:   repoName + "/sealed-secrets",
+				Namespace:   Namespace,
+				Version:     chartVersion(),
+				RepoURL:     repoURL,
+				Atomic:      true,
+				Wait:        true,
+				WaitForJobs: true,
+				Timeout:     timeout,
+				ValuesYaml:  buildValuesYaml(),
+			},
+		),
+	}
+}
+
+// buildValuesYaml returns the Helm values YAML for the controller. The chart
+// names its resources after the release only when fullnameOverride is set;
+// without it the Service would not be found under kubeseal's default name.
+func buildValuesYaml() string {
+	return "fullnameOverride: " + ReleaseName
+}
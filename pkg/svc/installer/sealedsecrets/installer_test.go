@@ -0,0 +1,94 @@
+package sealedsecretsinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	sealedsecretsinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/sealedsecrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildValuesYaml_OverridesFullname(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"fullnameOverride: "+sealedsecretsinstaller.ReleaseName,
+		sealedsecretsinstaller.BuildValuesYaml(),
+	)
+}
+
+func TestInstallerInstall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		installErr error
+	}{
+		{name: "success"},
+		{name: "install error", installErr: assert.AnError},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := helm.NewMockInterface(t)
+			installer := sealedsecretsinstaller.NewInstaller(client, 5*time.Second)
+
+			client.EXPECT().
+				GetReleaseStorageLabels(mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil)
+			client.EXPECT().
+				AddRepository(
+					mock.Anything,
+					mock.MatchedBy(func(entry *helm.RepositoryEntry) bool {
+						return entry != nil &&
+							entry.URL == "https://bitnami-labs.github.io/sealed-secrets"
+					}),
+					mock.Anything,
+				).
+				Return(nil)
+			client.EXPECT().
+				InstallOrUpgradeChart(
+					mock.Anything,
+					mock.MatchedBy(func(spec *helm.ChartSpec) bool {
+						return spec != nil &&
+							spec.ReleaseName == sealedsecretsinstaller.ReleaseName &&
+							spec.Namespace == sealedsecretsinstaller.Namespace &&
+							spec.Version != ""
+					}),
+				).
+				Return(nil, testCase.installErr)
+
+			err := installer.Install(context.Background())
+			if testCase.installErr != nil {
+				require.ErrorIs(t, err, testCase.installErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestInstallerUninstall(t *testing.T) {
+	t.Parallel()
+
+	client := helm.NewMockInterface(t)
+	installer := sealedsecretsinstaller.NewInstaller(client, 5*time.Second)
+
+	client.EXPECT().
+		UninstallRelease(
+			mock.Anything,
+			sealedsecretsinstaller.ReleaseName,
+			sealedsecretsinstaller.Namespace,
+		).
+		Return(nil)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+}
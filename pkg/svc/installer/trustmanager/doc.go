@@ -0,0 +1,11 @@
+// Package trustmanagerinstaller installs cert-manager's trust-manager via Helm
+// and publishes the spec.trust.caBundles certificates through it.
+//
+// After the chart is installed, a cluster-scoped Bundle named ksail-ca-bundle
+// combines the default public CAs with the configured certificates, and
+// trust-manager writes the result to a ConfigMap of the same name in every
+// namespace under the ca-certificates.crt key. Workloads mount that ConfigMap
+// (for example at /etc/ssl/certs) to trust a TLS-intercepting proxy.
+//
+// See: https://cert-manager.io/docs/trust/trust-manager/
+package trustmanagerinstaller
@@ -0,0 +1,8 @@
+//nolint:gochecknoglobals // export_test.go pattern requires global variables to expose internal functions
+package trustmanagerinstaller
+
+// BuildBundle exposes buildBundle for testing.
+var BuildBundle = buildBundle
+
+// BuildValuesYaml exposes buildValuesYaml for testing.
+var BuildValuesYaml = buildValuesYaml
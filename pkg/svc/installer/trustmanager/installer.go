@@ -0,0 +1,182 @@
+package trustmanagerinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/trust"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	repoName = "jetstack"
+	repoURL  = "https://charts.jetstack.io"
+
+	// Namespace is the namespace trust-manager is installed into. It is also
+	// trust-manager's trust namespace, the only namespace Bundle sources may
+	// reference Secrets and ConfigMaps from.
+	Namespace = "cert-manager"
+	// ReleaseName is the Helm release name of trust-manager.
+	ReleaseName = "trust-manager"
+
+	// bundlePollInterval is the interval between attempts to apply the Bundle
+	// while its CRD is still being registered.
+	bundlePollInterval = 2 * time.Second
+	// bundlePollTimeout bounds how long the Bundle CRD may take to register.
+	bundlePollTimeout = 60 * time.Second
+)
+
+// Installer installs or upgrades trust-manager.
+//
+// It embeds helmutil.Base for the Helm lifecycle and adds a post-install step
+// that applies the ksail-ca-bundle Bundle with the configured certificates.
+type Installer struct {
+	*helmutil.Base
+
+	kubeconfig string
+	context    string
+	bundle     string
+}
+
+// NewInstaller creates a new trust-manager installer that publishes bundle, a
+// PEM-encoded set of CA certificates, alongside the default public CAs.
+func NewInstaller(
+	client helm.Interface,
+	kubeconfig, context string,
+	timeout time.Duration,
+	bundle string,
+) *Installer {
+	return &Installer{
+		Base: helmutil.NewBase(
+			"trust-manager",
+			client,
+			timeout,
+			&helm.RepositoryEntry{
+				Name: repoName,
+				URL:  repoURL,
+			},
+			&helm.ChartSpec{
+				ReleaseName:     ReleaseName,
+				ChartName:       repoName + "/trust-manager",
+				Namespace:       Namespace,
+				Version:         chartVersion(),
+				RepoURL:         repoURL,
+				CreateNamespace: true,
+				Atomic:          true,
+				Wait:            true,
+				WaitForJobs:     true,
+				Timeout:         timeout,
+				ValuesYaml:      buildValuesYaml(),
+			},
+		),
+		kubeconfig: kubeconfig,
+		context:    context,
+		bundle:     bundle,
+	}
+}
+
+// Install installs or upgrades trust-manager via its Helm chart, then applies
+// the Bundle that distributes the CA certificates.
+func (t *Installer) Install(ctx context.Context) error {
+	err := t.Base.Install(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to install trust-manager: %w", err)
+	}
+
+	err = t.applyBundle(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to apply trust bundle: %w", err)
+	}
+
+	return nil
+}
+
+// buildValuesYaml returns the Helm values YAML for trust-manager. The webhook
+// certificate is generated by Helm so trust-manager does not depend on
+// cert-manager being installed.
+func buildValuesYaml() string {
+	return `app:
+  webhook:
+    tls:
+      helmCert:
+        enabled: true
+`
+}
+
+// applyBundle applies the ksail-ca-bundle Bundle using Server-Side Apply,
+// retrying while the Bundle CRD is not yet served.
+func (t *Installer) applyBundle(ctx context.Context) error {
+	dynamicClient, err := k8s.NewDynamicClient(t.kubeconfig, t.context)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	gvr := schema.GroupVersionResource{
+		Group:    "trust.cert-manager.io",
+		Version:  "v1alpha1",
+		Resource: "bundles",
+	}
+	bundle := buildBundle(t.bundle)
+
+	var lastErr error
+
+	err = wait.PollUntilContextTimeout(ctx, bundlePollInterval, bundlePollTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			_, lastErr = dynamicClient.Resource(gvr).
+				Apply(ctx, trust.BundleName, bundle, metav1.ApplyOptions{FieldManager: "ksail"})
+			if lastErr == nil {
+				return true, nil
+			}
+
+			// Only retry while the CRD is not yet registered.
+			if apierrors.IsNotFound(lastErr) {
+				return false, nil
+			}
+
+			return false, lastErr
+		},
+	)
+	if err != nil {
+		if lastErr != nil {
+			return fmt.Errorf("failed to apply Bundle: %w", lastErr)
+		}
+
+		return fmt.Errorf("failed to apply Bundle: %w", err)
+	}
+
+	return nil
+}
+
+// buildBundle returns the cluster-scoped Bundle that combines the default
+// public CAs with the PEM certificates and publishes them as a ConfigMap in
+// every namespace.
+func buildBundle(pem string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "trust.cert-manager.io/v1alpha1",
+			"kind":       "Bundle",
+			"metadata": map[string]any{
+				"name": trust.BundleName,
+			},
+			"spec": map[string]any{
+				"sources": []any{
+					map[string]any{"useDefaultCAs": true},
+					map[string]any{"inLine": pem},
+				},
+				"target": map[string]any{
+					"configMap": map[string]any{
+						"key": trust.BundleKey,
+					},
+				},
+			},
+		},
+	}
+}
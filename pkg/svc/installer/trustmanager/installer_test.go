@@ -0,0 +1,53 @@
+package trustmanagerinstaller_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	trustmanagerinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/trustmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const testPEM = "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"
+
+func TestNewInstaller(t *testing.T) {
+	t.Parallel()
+
+	installer := trustmanagerinstaller.NewInstaller(
+		helm.NewMockInterface(t), "~/.kube/config", "kind-kind", 5*time.Minute, testPEM,
+	)
+
+	assert.NotNil(t, installer)
+}
+
+func TestBuildValuesYaml_UsesHelmWebhookCert(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, trustmanagerinstaller.BuildValuesYaml(), "helmCert:\n        enabled: true")
+}
+
+func TestBuildBundle(t *testing.T) {
+	t.Parallel()
+
+	bundle := trustmanagerinstaller.BuildBundle(testPEM)
+
+	assert.Equal(t, "Bundle", bundle.GetKind())
+	assert.Equal(t, "ksail-ca-bundle", bundle.GetName())
+	assert.Empty(t, bundle.GetNamespace())
+
+	sources, found, err := unstructured.NestedSlice(bundle.Object, "spec", "sources")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, []any{
+		map[string]any{"useDefaultCAs": true},
+		map[string]any{"inLine": testPEM},
+	}, sources)
+
+	key, found, err := unstructured.NestedString(bundle.Object, "spec", "target", "configMap", "key")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "ca-certificates.crt", key)
+}
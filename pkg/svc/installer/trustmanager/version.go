@@ -0,0 +1,19 @@
+package trustmanagerinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Dockerfile
+var dockerfile string
+
+// chartVersion returns the pinned trust-manager chart version extracted from the embedded Dockerfile.
+func chartVersion() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+quay\.io/jetstack/trust-manager:([^\s@]+)`,
+		"trust-manager chart",
+	)
+}
@@ -0,0 +1,12 @@
+// Package veleroinstaller provides an installer for Velero, the backup system
+// selected by spec.cluster.backup: Velero.
+//
+// Velero needs an object store for its backups. For local clusters the
+// installer runs a MinIO container on the cluster's Docker network, creates
+// the velero bucket in it, and points Velero's default BackupStorageLocation
+// at the container's IP through the AWS object-store plugin. MinIO keeps its
+// data in a named Docker volume that outlives the cluster, so a recreated
+// cluster sees the backups taken by its predecessor.
+//
+// See: https://velero.io
+package veleroinstaller
@@ -0,0 +1,4 @@
+package veleroinstaller
+
+// BuildValuesYaml exports buildValuesYaml for testing.
+var BuildValuesYaml = buildValuesYaml
@@ -0,0 +1,184 @@
+package veleroinstaller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/installer/internal/helmutil"
+)
+
+const (
+	repoName = "vmware-tanzu"
+	repoURL  = "https://vmware-tanzu.github.io/helm-charts"
+
+	// Namespace is the namespace Velero is installed into.
+	Namespace = "velero"
+	// ReleaseName is the Helm release name of Velero.
+	ReleaseName = "velero"
+
+	// imagesEndpoint stands in for the MinIO endpoint when the chart is only
+	// templated to list its images.
+	imagesEndpoint = "http://minio:" + minioPort
+)
+
+// Installer installs Velero together with the MinIO container it stores its
+// backups in.
+type Installer struct {
+	client       helm.Interface
+	dockerClient dockerclient.Client
+	timeout      time.Duration
+	clusterName  string
+	networkName  string
+}
+
+// NewInstaller creates a new Velero installer for the cluster clusterName,
+// whose nodes are attached to the Docker network networkName. A nil
+// dockerClient makes the installer open (and close) its own Docker client
+// for each operation that manages the MinIO container.
+func NewInstaller(
+	client helm.Interface,
+	dockerClient dockerclient.Client,
+	timeout time.Duration,
+	clusterName string,
+	networkName string,
+) *Installer {
+	return &Installer{
+		client:       client,
+		dockerClient: dockerClient,
+		timeout:      timeout,
+		clusterName:  clusterName,
+		networkName:  networkName,
+	}
+}
+
+// Install starts the MinIO container, creates the Velero bucket, and installs
+// or upgrades the Velero chart with its default BackupStorageLocation pointed
+// at MinIO.
+func (v *Installer) Install(ctx context.Context) error {
+	var endpoint string
+
+	err := v.withMinIO(func(store *minio) error {
+		var ensureErr error
+
+		endpoint, ensureErr = store.ensure(ctx)
+
+		return ensureErr
+	})
+	if err != nil {
+		return err
+	}
+
+	return v.base(endpoint).Install(ctx) //nolint:wrapcheck // helmutil.Base already wraps
+}
+
+// Uninstall removes the Velero release and the MinIO container. The MinIO data
+// volume is kept, so reinstalling Velero makes earlier backups available
+// again.
+func (v *Installer) Uninstall(ctx context.Context) error {
+	err := v.base(imagesEndpoint).Uninstall(ctx)
+	if err != nil {
+		return err //nolint:wrapcheck // helmutil.Base already wraps
+	}
+
+	return v.withMinIO(func(store *minio) error {
+		return store.remove(ctx)
+	})
+}
+
+// Images returns the images of the Velero chart (including the AWS plugin
+// init container) and the MinIO image.
+func (v *Installer) Images(ctx context.Context) ([]string, error) {
+	images, err := v.base(imagesEndpoint).Images(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // helmutil.Base already wraps
+	}
+
+	return append(images, MinIOImage()), nil
+}
+
+// base returns the Helm lifecycle for the Velero chart configured to store
+// backups at the given S3 endpoint.
+func (v *Installer) base(endpoint string) *helmutil.Base {
+	return helmutil.NewBase(
+		"velero",
+		v.client,
+		v.timeout,
+		&helm.RepositoryEntry{
+			Name: repoName,
+			URL:  repoURL,
+		},
+		&helm.ChartSpec{
+			ReleaseName:     ReleaseName,
+			ChartName:       repoName + "/velero",
+			Namespace:       Namespace,
+			Version:         chartVersion(),
+			RepoURL:         repoURL,
+			CreateNamespace: true,
+			Atomic:          true,
+			Wait:            true,
+			WaitForJobs:     true,
+			Timeout:         v.timeout,
+			ValuesYaml:      buildValuesYaml(endpoint),
+		},
+	)
+}
+
+// withMinIO runs fn against the cluster's MinIO container, opening a Docker
+// client for the call when the installer was created without one.
+func (v *Installer) withMinIO(fn func(store *minio) error) error {
+	dockerClient := v.dockerClient
+
+	if dockerClient == nil {
+		client, err := dockerclient.GetDockerClient()
+		if err != nil {
+			return fmt.Errorf("create docker client: %w", err)
+		}
+
+		defer func() { _ = client.Close() }()
+
+		dockerClient = client
+	}
+
+	return fn(&minio{
+		dockerClient:  dockerClient,
+		containerName: MinIOContainerName(v.clusterName),
+		networkName:   v.networkName,
+	})
+}
+
+// buildValuesYaml returns the Helm values YAML for Velero. Volume snapshots
+// are disabled because local clusters have no snapshot-capable storage;
+// backups store resource manifests in the MinIO bucket through the AWS
+// object-store plugin.
+func buildValuesYaml(endpoint string) string {
+	return fmt.Sprintf(`initContainers:
+  - name: velero-plugin-for-aws
+    image: %s
+    imagePullPolicy: IfNotPresent
+    volumeMounts:
+      - mountPath: /target
+        name: plugins
+configuration:
+  backupStorageLocation:
+    - name: default
+      default: true
+      provider: aws
+      bucket: %s
+      config:
+        region: %s
+        s3ForcePathStyle: "true"
+        s3Url: %s
+  volumeSnapshotLocation: []
+snapshotsEnabled: false
+credentials:
+  useSecret: true
+  secretContents:
+    cloud: |
+      [default]
+      aws_access_key_id=%s
+      aws_secret_access_key=%s
+`, AWSPluginImage(), Bucket, minioRegion, endpoint, minioAccessKey, minioSecretKey)
+}
@@ -0,0 +1,113 @@
+package veleroinstaller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	veleroinstaller "github.com/devantler-tech/ksail/v7/pkg/svc/installer/velero"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildValuesYaml_PointsAtMinIO(t *testing.T) {
+	t.Parallel()
+
+	values := veleroinstaller.BuildValuesYaml("http://172.18.0.5:9000")
+
+	assert.Contains(t, values, "s3Url: http://172.18.0.5:9000")
+	assert.Contains(t, values, "bucket: "+veleroinstaller.Bucket)
+	assert.Contains(t, values, `s3ForcePathStyle: "true"`)
+	assert.Contains(t, values, "image: "+veleroinstaller.AWSPluginImage())
+	assert.Contains(t, values, "snapshotsEnabled: false")
+}
+
+func TestDockerNetworkName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		distribution v1alpha1.Distribution
+		want         string
+	}{
+		{v1alpha1.DistributionVanilla, "kind"},
+		{v1alpha1.DistributionK3s, "k3d-dev"},
+		{v1alpha1.DistributionTalos, "dev"},
+		{v1alpha1.DistributionVCluster, "vcluster.dev"},
+		{v1alpha1.DistributionKWOK, "kwok-dev"},
+		{v1alpha1.DistributionEKS, ""},
+	}
+
+	for _, testCase := range tests {
+		t.Run(string(testCase.distribution), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t,
+				testCase.want,
+				veleroinstaller.DockerNetworkName(testCase.distribution, "dev"),
+			)
+		})
+	}
+}
+
+func TestInstallerInstall_RequiresDockerNetwork(t *testing.T) {
+	t.Parallel()
+
+	installer := veleroinstaller.NewInstaller(
+		helm.NewMockInterface(t),
+		dockerclient.NewMockAPIClient(t),
+		5*time.Second,
+		"dev",
+		"",
+	)
+
+	err := installer.Install(context.Background())
+	require.ErrorIs(t, err, veleroinstaller.ErrNoDockerNetwork)
+}
+
+func TestInstallerUninstall_RemovesReleaseAndMinIO(t *testing.T) {
+	t.Parallel()
+
+	helmClient := helm.NewMockInterface(t)
+	dockerClient := dockerclient.NewMockAPIClient(t)
+	installer := veleroinstaller.NewInstaller(helmClient, dockerClient, 5*time.Second, "dev", "kind")
+
+	helmClient.EXPECT().
+		UninstallRelease(mock.Anything, veleroinstaller.ReleaseName, veleroinstaller.Namespace).
+		Return(nil)
+	dockerClient.EXPECT().
+		ContainerList(mock.Anything, mock.Anything).
+		Return([]container.Summary{{ID: "minio-id", State: "running"}}, nil)
+	dockerClient.EXPECT().
+		ContainerRemove(mock.Anything, "minio-id", container.RemoveOptions{Force: true}).
+		Return(nil)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+}
+
+func TestInstallerUninstall_NoMinIOContainer(t *testing.T) {
+	t.Parallel()
+
+	helmClient := helm.NewMockInterface(t)
+	dockerClient := dockerclient.NewMockAPIClient(t)
+	installer := veleroinstaller.NewInstaller(helmClient, dockerClient, 5*time.Second, "dev", "kind")
+
+	helmClient.EXPECT().
+		UninstallRelease(mock.Anything, veleroinstaller.ReleaseName, veleroinstaller.Namespace).
+		Return(nil)
+	dockerClient.EXPECT().
+		ContainerList(mock.Anything, mock.Anything).
+		Return([]container.Summary{}, nil)
+
+	require.NoError(t, installer.Uninstall(context.Background()))
+}
+
+func TestMinIOContainerName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "dev-velero-minio", veleroinstaller.MinIOContainerName("dev"))
+}
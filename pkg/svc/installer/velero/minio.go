@@ -0,0 +1,278 @@
+package veleroinstaller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+)
+
+const (
+	// Bucket is the MinIO bucket Velero stores its backups in.
+	Bucket = "velero"
+
+	// minioPort is the S3 API port MinIO listens on inside the container.
+	minioPort = "9000"
+	// minioDataDir is the directory MinIO serves from inside the container.
+	minioDataDir = "/data"
+	// minioRegion is the region Velero's AWS plugin signs requests for. MinIO
+	// accepts any region; the value only has to be non-empty.
+	minioRegion = "minio"
+
+	// minioAccessKey and minioSecretKey are the root credentials of the local
+	// MinIO container. It is only reachable on the cluster's Docker network.
+	minioAccessKey = "ksail"
+	minioSecretKey = "ksail-velero" //nolint:gosec // local-only MinIO root credentials
+
+	// bucketPollInterval is the interval between bucket creation attempts
+	// while MinIO is starting up.
+	bucketPollInterval = 2 * time.Second
+	// bucketPollTimeout bounds how long MinIO may take to accept requests.
+	bucketPollTimeout = 60 * time.Second
+)
+
+// ErrNoDockerNetwork is returned when the cluster has no local Docker network
+// for the MinIO container to join.
+var ErrNoDockerNetwork = errors.New("velero backup requires a cluster on a local Docker network")
+
+// MinIOContainerName returns the name of the MinIO container that backs
+// Velero for clusterName.
+func MinIOContainerName(clusterName string) string {
+	return clusterName + "-velero-minio"
+}
+
+// DockerNetworkName returns the Docker network the nodes of a clusterName
+// cluster of the given distribution are attached to, or "" for distributions
+// that do not run on a local Docker network.
+func DockerNetworkName(distribution v1alpha1.Distribution, clusterName string) string {
+	switch distribution {
+	case v1alpha1.DistributionVanilla:
+		return "kind"
+	case v1alpha1.DistributionK3s:
+		return "k3d-" + clusterName
+	case v1alpha1.DistributionTalos:
+		return clusterName
+	case v1alpha1.DistributionVCluster:
+		return "vcluster." + clusterName
+	case v1alpha1.DistributionKWOK:
+		return "kwok-" + clusterName
+	case v1alpha1.DistributionEKS, v1alpha1.DistributionGKE, v1alpha1.DistributionAKS:
+		// The managed cloud distributions do not use a local Docker network.
+		return ""
+	default:
+		return ""
+	}
+}
+
+// RemoveMinIO removes the MinIO container of clusterName, if any, keeping its
+// data volume. Cluster deletion calls it before the cluster's Docker network is
+// removed, which fails while containers are still attached.
+func RemoveMinIO(ctx context.Context, dockerClient dockerclient.Client, clusterName string) error {
+	store := &minio{
+		dockerClient:  dockerClient,
+		containerName: MinIOContainerName(clusterName),
+	}
+
+	return store.remove(ctx)
+}
+
+// minio manages the MinIO container that serves as Velero's S3 target.
+type minio struct {
+	dockerClient  dockerclient.Client
+	containerName string
+	networkName   string
+}
+
+// ensure starts the MinIO container (creating it on first use), creates the
+// Velero bucket, and returns the S3 endpoint pods can reach it on. Pods
+// cannot resolve Docker container names, so the endpoint uses the
+// container's IP on the cluster network.
+func (m *minio) ensure(ctx context.Context) (string, error) {
+	if m.networkName == "" {
+		return "", ErrNoDockerNetwork
+	}
+
+	err := m.ensureRunning(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	err = m.ensureBucket(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ipAddress, err := dockerclient.ResolveContainerIPOnNetwork(
+		ctx, m.dockerClient, m.containerName, m.networkName,
+	)
+	if err != nil {
+		return "", fmt.Errorf("resolve minio address: %w", err)
+	}
+
+	return "http://" + net.JoinHostPort(ipAddress, minioPort), nil
+}
+
+// ensureRunning creates and starts the MinIO container, or starts it when it
+// exists but is stopped.
+func (m *minio) ensureRunning(ctx context.Context) error {
+	containers, err := m.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(containers) > 0 {
+		if strings.EqualFold(containers[0].State, "running") {
+			return nil
+		}
+
+		err = m.dockerClient.ContainerStart(ctx, m.containerName, container.StartOptions{})
+		if err != nil {
+			return fmt.Errorf("start minio container: %w", err)
+		}
+
+		return nil
+	}
+
+	return m.create(ctx)
+}
+
+// create pulls the MinIO image when missing and creates and starts the
+// container on the cluster network.
+func (m *minio) create(ctx context.Context) error {
+	imageName := MinIOImage()
+
+	_, err := m.dockerClient.ImageInspect(ctx, imageName)
+	if err != nil {
+		err = dockerclient.PullImage(ctx, m.dockerClient, imageName)
+		if err != nil {
+			return fmt.Errorf("pull minio image: %w", err)
+		}
+	}
+
+	containerConfig := &container.Config{
+		Image: imageName,
+		Cmd:   []string{"server", minioDataDir},
+		Env: []string{
+			"MINIO_ROOT_USER=" + minioAccessKey,
+			"MINIO_ROOT_PASSWORD=" + minioSecretKey,
+		},
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       "minio",
+			"app.kubernetes.io/part-of":    "velero",
+			"app.kubernetes.io/managed-by": "ksail",
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		RestartPolicy: container.RestartPolicy{
+			Name: container.RestartPolicyUnlessStopped,
+		},
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeVolume,
+				Source: m.containerName + "-data",
+				Target: minioDataDir,
+			},
+		},
+	}
+
+	networkConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			m.networkName: {},
+		},
+	}
+
+	resp, err := m.dockerClient.ContainerCreate(
+		ctx,
+		containerConfig,
+		hostConfig,
+		networkConfig,
+		nil,
+		m.containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("create minio container: %w", err)
+	}
+
+	err = m.dockerClient.ContainerStart(ctx, resp.ID, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("start minio container: %w", err)
+	}
+
+	return nil
+}
+
+// ensureBucket creates the Velero bucket with the mc client bundled in the
+// MinIO image, retrying while MinIO is still starting up.
+func (m *minio) ensureBucket(ctx context.Context) error {
+	executor := image.NewContainerExecutor(m.dockerClient)
+	script := fmt.Sprintf(
+		"mc alias set local http://127.0.0.1:%s %s %s >/dev/null && "+
+			"mc mb --ignore-existing local/%s",
+		minioPort, minioAccessKey, minioSecretKey, Bucket,
+	)
+
+	pollCtx, cancel := context.WithTimeout(ctx, bucketPollTimeout)
+	defer cancel()
+
+	for {
+		_, err := executor.ExecInContainer(pollCtx, m.containerName, []string{"sh", "-c", script})
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-pollCtx.Done():
+			return fmt.Errorf("create velero bucket in minio: %w", err)
+		case <-time.After(bucketPollInterval):
+		}
+	}
+}
+
+// remove stops and removes the MinIO container. The data volume is kept so
+// backups survive the cluster being deleted and recreated.
+func (m *minio) remove(ctx context.Context) error {
+	containers, err := m.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(containers) == 0 {
+		return nil
+	}
+
+	err = m.dockerClient.ContainerRemove(ctx, containers[0].ID, container.RemoveOptions{
+		Force: true,
+	})
+	if err != nil {
+		return fmt.Errorf("remove minio container: %w", err)
+	}
+
+	return nil
+}
+
+// list returns the MinIO container, running or stopped, if it exists.
+func (m *minio) list(ctx context.Context) ([]container.Summary, error) {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("name", "^/"+m.containerName+"$")
+
+	containers, err := m.dockerClient.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filterArgs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list minio containers: %w", err)
+	}
+
+	return containers, nil
+}
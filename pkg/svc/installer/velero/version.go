@@ -0,0 +1,38 @@
+package veleroinstaller
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Chart.yaml
+var chartYAML string
+
+//go:embed Dockerfile
+var dockerfile string
+
+// chartVersion returns the pinned Velero chart version extracted from the
+// embedded Chart.yaml (kept in sync by Dependabot's helm ecosystem).
+func chartVersion() string {
+	return parser.ParseChartVersionFromChartYaml(chartYAML, "velero")
+}
+
+// MinIOImage returns the MinIO image used as Velero's local S3 target.
+func MinIOImage() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+(quay\.io/minio/minio:[^\s]+)`,
+		"minio",
+	)
+}
+
+// AWSPluginImage returns the Velero AWS object-store plugin image, which
+// speaks the S3 protocol MinIO implements.
+func AWSPluginImage() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+(docker\.io/velero/velero-plugin-for-aws:[^\s]+)`,
+		"velero-plugin-for-aws",
+	)
+}
@@ -0,0 +1,7 @@
+// Package loadtest runs HTTP load tests from inside a cluster for
+// `ksail workload loadtest`. A k6 load generator runs as a one-off Job at a
+// constant request rate against a URL or in-cluster Service, and the latency
+// and error summary it prints is parsed from the pod logs — so HPA behavior and
+// resource limits can be validated locally without installing a load tool or
+// exposing the workload outside the cluster.
+package loadtest
@@ -0,0 +1,20 @@
+package loadtest
+
+import "errors"
+
+var (
+	// ErrInvalidTarget is returned when the target is neither an http(s) URL nor a
+	// Service reference.
+	ErrInvalidTarget = errors.New("invalid load test target")
+	// ErrServiceHasNoPorts is returned when a Service target exposes no ports.
+	ErrServiceHasNoPorts = errors.New("service exposes no ports")
+	// ErrServicePortNotFound is returned when a Service target names a port the
+	// Service does not expose.
+	ErrServicePortNotFound = errors.New("service port not found")
+	// ErrInvalidOptions is returned when the rate, duration or VU count is not positive.
+	ErrInvalidOptions = errors.New("invalid load test options")
+	// ErrJobFailed is returned when the load generator Job fails.
+	ErrJobFailed = errors.New("load test job failed")
+	// ErrSummaryNotFound is returned when the load generator's logs contain no summary.
+	ErrSummaryNotFound = errors.New("load test summary not found in job logs")
+)
@@ -0,0 +1,17 @@
+package loadtest
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// SetReadPodLogsForTest replaces the pod log reader and returns a restore func.
+func SetReadPodLogsForTest(
+	fn func(ctx context.Context, client kubernetes.Interface, namespace, pod string) (string, error),
+) func() {
+	original := readPodLogs
+	readPodLogs = fn
+
+	return func() { readPodLogs = original }
+}
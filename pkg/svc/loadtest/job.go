@@ -0,0 +1,166 @@
+package loadtest
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+//go:embed script.js
+var script string
+
+const (
+	// DefaultRPS is the request rate used when none is configured.
+	DefaultRPS = 10
+	// DefaultDuration is how long the load is applied when no duration is configured.
+	DefaultDuration = 30 * time.Second
+	// DefaultMethod is the HTTP method used when none is configured.
+	DefaultMethod = "GET"
+
+	// SummaryMarker prefixes the summary line the k6 script prints.
+	SummaryMarker = "KSAIL_LOADTEST_SUMMARY "
+
+	// jobNamePrefix is the generateName prefix of load generator Jobs.
+	jobNamePrefix = "ksail-loadtest-"
+	// jobStartupSlack bounds image pull and scheduling on top of the test duration.
+	jobStartupSlack = 5 * time.Minute
+	// jobTTL keeps a finished Job around briefly when cleanup is skipped or fails.
+	jobTTL = 10 * time.Minute
+	// containerName is the name of the load generator container.
+	containerName = "k6"
+)
+
+// Label values stamped onto load generator Jobs and their pods.
+const (
+	LabelName      = "app.kubernetes.io/name"
+	LabelManagedBy = "app.kubernetes.io/managed-by"
+	nameValue      = "ksail-loadtest"
+	managedByValue = "ksail"
+)
+
+// Options configures a load test run.
+type Options struct {
+	// Namespace is where the load generator Job runs.
+	Namespace string
+	// Target is the URL requested; see ResolveTarget.
+	Target string
+	// Method is the HTTP method; empty means DefaultMethod.
+	Method string
+	// RPS is the constant request rate per second; 0 means DefaultRPS.
+	RPS int
+	// Duration is how long the load is applied; 0 means DefaultDuration.
+	Duration time.Duration
+	// VUs caps the concurrent virtual users issuing requests; 0 means one per
+	// request per second, enough for responses up to a second.
+	VUs int
+	// Image is the k6 image; empty means DefaultImage.
+	Image string
+	// Keep leaves the finished Job in the cluster instead of deleting it.
+	Keep bool
+}
+
+// withDefaults fills unset options and validates the rest.
+func (o Options) withDefaults() (Options, error) {
+	if o.Method == "" {
+		o.Method = DefaultMethod
+	}
+
+	if o.RPS == 0 {
+		o.RPS = DefaultRPS
+	}
+
+	if o.Duration == 0 {
+		o.Duration = DefaultDuration
+	}
+
+	if o.VUs == 0 {
+		o.VUs = o.RPS
+	}
+
+	if o.Image == "" {
+		o.Image = DefaultImage()
+	}
+
+	if o.RPS < 0 || o.VUs < 0 || o.Duration < time.Second {
+		return o, fmt.Errorf("%w: rps, vus and a duration of at least 1s must be positive",
+			ErrInvalidOptions)
+	}
+
+	return o, nil
+}
+
+// BuildJob returns the load generator Job for opts. The pod satisfies the
+// restricted Pod Security Standard, so it runs in any namespace.
+func BuildJob(opts Options) (*batchv1.Job, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		LabelName:      nameValue,
+		LabelManagedBy: managedByValue,
+	}
+
+	deadline := int64((opts.Duration + jobStartupSlack) / time.Second)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: jobNamePrefix,
+			Namespace:    opts.Namespace,
+			Labels:       labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To[int32](0),
+			ActiveDeadlineSeconds:   &deadline,
+			TTLSecondsAfterFinished: ptr.To(int32(jobTTL / time.Second)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: ptr.To(true),
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+					Containers: []corev1.Container{loadGeneratorContainer(opts)},
+				},
+			},
+		},
+	}, nil
+}
+
+// loadGeneratorContainer returns the k6 container. The script is passed in an
+// environment variable and written to /tmp, so no ConfigMap has to be managed.
+func loadGeneratorContainer(opts Options) corev1.Container {
+	return corev1.Container{
+		Name:  containerName,
+		Image: opts.Image,
+		Command: []string{
+			"sh", "-c",
+			`printf '%s' "$KSAIL_SCRIPT" > /tmp/loadtest.js && exec k6 run --quiet /tmp/loadtest.js`,
+		},
+		Env: []corev1.EnvVar{
+			{Name: "KSAIL_SCRIPT", Value: script},
+			{Name: "KSAIL_TARGET", Value: opts.Target},
+			{Name: "KSAIL_METHOD", Value: opts.Method},
+			{Name: "KSAIL_RPS", Value: strconv.Itoa(opts.RPS)},
+			{Name: "KSAIL_VUS", Value: strconv.Itoa(opts.VUs)},
+			{Name: "KSAIL_DURATION", Value: strconv.Itoa(int(opts.Duration/time.Second)) + "s"},
+			{Name: "KSAIL_SUMMARY_MARKER", Value: SummaryMarker},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+}
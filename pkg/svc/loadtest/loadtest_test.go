@@ -0,0 +1,220 @@
+package loadtest_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/loadtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const testSummaryLine = loadtest.SummaryMarker +
+	`{"requests":300,"rate":9.98,"failedRate":0.01,"dropped":2,` +
+	`"latencyMs":{"min":1.5,"avg":4.2,"p50":3.9,"p90":6.1,"p95":7.3,"p99":12.8,"max":20.4}}`
+
+func newService(ports ...corev1.ServicePort) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec:       corev1.ServiceSpec{Ports: ports},
+	}
+}
+
+func TestResolveTarget(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset(newService(
+		corev1.ServicePort{Name: "http", Port: 80},
+		corev1.ServicePort{Name: "metrics", Port: 9090},
+	))
+
+	testCases := []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{name: "url is used verbatim", target: "https://example.com/x", expected: "https://example.com/x"},
+		{name: "service first port", target: "web", expected: "http://web.apps.svc:80/"},
+		{name: "service namespace and path", target: "web.apps/healthz", expected: "http://web.apps.svc:80/healthz"},
+		{name: "service named port", target: "web:metrics/metrics", expected: "http://web.apps.svc:9090/metrics"},
+		{name: "service port number", target: "web:9090", expected: "http://web.apps.svc:9090/"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolved, err := loadtest.ResolveTarget(context.Background(), client, "apps", testCase.target)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, resolved)
+		})
+	}
+}
+
+func TestResolveTarget_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset(newService(corev1.ServicePort{Name: "http", Port: 80}))
+	ctx := context.Background()
+
+	_, err := loadtest.ResolveTarget(ctx, client, "apps", "ftp://web")
+	require.ErrorIs(t, err, loadtest.ErrInvalidTarget)
+
+	_, err = loadtest.ResolveTarget(ctx, client, "apps", "web:grpc")
+	require.ErrorIs(t, err, loadtest.ErrServicePortNotFound)
+
+	_, err = loadtest.ResolveTarget(ctx, client, "apps", "missing")
+	require.Error(t, err)
+}
+
+func TestBuildJob(t *testing.T) {
+	t.Parallel()
+
+	job, err := loadtest.BuildJob(loadtest.Options{
+		Namespace: "apps",
+		Target:    "http://web.apps.svc:80/",
+		RPS:       50,
+		Duration:  time.Minute,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "apps", job.Namespace)
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, loadtest.DefaultImage(), container.Image)
+
+	env := map[string]string{}
+	for _, variable := range container.Env {
+		env[variable.Name] = variable.Value
+	}
+
+	assert.Equal(t, "http://web.apps.svc:80/", env["KSAIL_TARGET"])
+	assert.Equal(t, "GET", env["KSAIL_METHOD"])
+	assert.Equal(t, "50", env["KSAIL_RPS"])
+	assert.Equal(t, "50", env["KSAIL_VUS"], "VUs default to the request rate")
+	assert.Equal(t, "60s", env["KSAIL_DURATION"])
+	assert.Contains(t, env["KSAIL_SCRIPT"], "handleSummary")
+}
+
+func TestBuildJob_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := loadtest.BuildJob(loadtest.Options{Target: "http://x", Duration: time.Millisecond})
+
+	require.ErrorIs(t, err, loadtest.ErrInvalidOptions)
+}
+
+func TestParseSummary(t *testing.T) {
+	t.Parallel()
+
+	summary, err := loadtest.ParseSummary("k6 progress\n" + testSummaryLine + "\n")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), summary.Requests)
+	assert.Equal(t, int64(2), summary.Dropped)
+	assert.InDelta(t, 7.3, summary.LatencyMs.P95, 0)
+
+	_, err = loadtest.ParseSummary("no summary here")
+	require.ErrorIs(t, err, loadtest.ErrSummaryNotFound)
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	summary, err := loadtest.ParseSummary(testSummaryLine)
+	require.NoError(t, err)
+
+	summary.Target = "http://web.apps.svc:80/"
+
+	var out bytes.Buffer
+
+	require.NoError(t, loadtest.WriteSummary(&out, summary))
+	assert.Contains(t, out.String(), "300 (10.0/s)")
+	assert.Contains(t, out.String(), "1.00%")
+	assert.Contains(t, out.String(), "3.90ms / 6.10ms / 7.30ms / 12.80ms")
+}
+
+// completeJobsOnCreate makes the fake clientset name created Jobs, mark them
+// with condition, and add the pod the Job controller would have run.
+func completeJobsOnCreate(client *fake.Clientset, condition batchv1.JobConditionType) {
+	client.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		job := action.(k8stesting.CreateAction).GetObject().(*batchv1.Job) //nolint:forcetypeassert // test reactor
+		job.Name = "ksail-loadtest-abc"
+		job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue}}
+
+		_ = client.Tracker().Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name + "-pod",
+			Namespace: job.Namespace,
+			Labels:    map[string]string{"job-name": job.Name},
+		}})
+
+		return false, nil, nil
+	})
+}
+
+//nolint:paralleltest // Replaces the package-level pod log reader.
+func TestRun(t *testing.T) {
+	client := fake.NewClientset()
+	completeJobsOnCreate(client, batchv1.JobComplete)
+
+	restore := loadtest.SetReadPodLogsForTest(
+		func(context.Context, kubernetes.Interface, string, string) (string, error) {
+			return testSummaryLine + "\n", nil
+		},
+	)
+	defer restore()
+
+	var progress bytes.Buffer
+
+	summary, err := loadtest.Run(context.Background(), client, loadtest.Options{
+		Namespace: "apps",
+		Target:    "http://web.apps.svc:80/",
+	}, &progress)
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://web.apps.svc:80/", summary.Target)
+	assert.Equal(t, int64(300), summary.Requests)
+	assert.Contains(t, progress.String(), "load testing http://web.apps.svc:80/ at 10 req/s")
+
+	jobs, err := client.BatchV1().Jobs("apps").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, jobs.Items, "the job is deleted after the run")
+}
+
+//nolint:paralleltest // Replaces the package-level pod log reader.
+func TestRun_JobFailed(t *testing.T) {
+	client := fake.NewClientset()
+	completeJobsOnCreate(client, batchv1.JobFailed)
+
+	restore := loadtest.SetReadPodLogsForTest(
+		func(context.Context, kubernetes.Interface, string, string) (string, error) {
+			return "level=error msg=\"dial tcp: lookup web: no such host\"\n", nil
+		},
+	)
+	defer restore()
+
+	_, err := loadtest.Run(context.Background(), client, loadtest.Options{
+		Namespace: "apps",
+		Target:    "http://web/",
+		Keep:      true,
+	}, &bytes.Buffer{})
+
+	require.ErrorIs(t, err, loadtest.ErrJobFailed)
+	require.ErrorContains(t, err, "no such host")
+
+	jobs, err := client.BatchV1().Jobs("apps").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, jobs.Items, 1, "--keep leaves the job in place")
+}
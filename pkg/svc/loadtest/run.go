@@ -0,0 +1,205 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/k8s/readiness"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// progressInterval is how often a running load test reports its elapsed time.
+	progressInterval = 10 * time.Second
+	// failureLogLines is how many trailing log lines a failed Job's error includes.
+	failureLogLines = 10
+)
+
+// readPodLogs returns the logs of the load generator container of a pod; a
+// package-level seam because the fake clientset cannot serve custom logs.
+//
+//nolint:gochecknoglobals // Test seam: lets tests return canned k6 output.
+var readPodLogs = func(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, pod string,
+) (string, error) {
+	raw, err := client.CoreV1().Pods(namespace).
+		GetLogs(pod, &corev1.PodLogOptions{Container: containerName}).
+		DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("read logs of pod %s/%s: %w", namespace, pod, err)
+	}
+
+	return string(raw), nil
+}
+
+// Run creates the load generator Job for opts, waits for it to finish while
+// reporting progress to writer, and returns the parsed summary. The Job is
+// deleted afterwards — also when ctx is cancelled — unless opts.Keep is set.
+func Run(
+	ctx context.Context,
+	client kubernetes.Interface,
+	opts Options,
+	writer io.Writer,
+) (Summary, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	job, err := BuildJob(opts)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	created, err := client.BatchV1().Jobs(opts.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return Summary{}, fmt.Errorf("create load test job: %w", err)
+	}
+
+	if !opts.Keep {
+		defer deleteJob(context.WithoutCancel(ctx), client, created, writer)
+	}
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "load testing %s at %d req/s for %s (job %s/%s)",
+		Args:    []any{opts.Target, opts.RPS, opts.Duration, created.Namespace, created.Name},
+		Writer:  writer,
+	})
+
+	succeeded, err := waitForJob(ctx, client, created, opts.Duration, writer)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	logs, err := jobLogs(ctx, client, created)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	if !succeeded {
+		return Summary{}, fmt.Errorf("%w: %s/%s\n%s",
+			ErrJobFailed, created.Namespace, created.Name, tailLines(logs, failureLogLines))
+	}
+
+	summary, err := ParseSummary(logs)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary.Target = opts.Target
+
+	return summary, nil
+}
+
+// waitForJob polls job until it completes or fails and reports whether it
+// succeeded, writing the elapsed time to writer every progressInterval.
+func waitForJob(
+	ctx context.Context,
+	client kubernetes.Interface,
+	job *batchv1.Job,
+	duration time.Duration,
+	writer io.Writer,
+) (bool, error) {
+	started := time.Now()
+	lastReport := started
+	succeeded := false
+
+	err := readiness.PollForReadiness(ctx, duration+jobStartupSlack, func(ctx context.Context) (bool, error) {
+		current, err := client.BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("get load test job: %w", err)
+		}
+
+		if jobHasCondition(current, batchv1.JobComplete) {
+			succeeded = true
+
+			return true, nil
+		}
+
+		if jobHasCondition(current, batchv1.JobFailed) {
+			return true, nil
+		}
+
+		if time.Since(lastReport) >= progressInterval {
+			lastReport = time.Now()
+
+			notify.WriteMessage(notify.Message{
+				Type:    notify.ActivityType,
+				Content: "load test running (%s elapsed of %s)",
+				Args:    []any{time.Since(started).Round(time.Second), duration},
+				Writer:  writer,
+			})
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("wait for load test job: %w", err)
+	}
+
+	return succeeded, nil
+}
+
+// jobHasCondition reports whether job has condition conditionType set to True.
+func jobHasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jobLogs returns the logs of the pod job ran.
+func jobLogs(ctx context.Context, client kubernetes.Interface, job *batchv1.Job) (string, error) {
+	pods, err := client.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + job.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("list load test pods: %w", err)
+	}
+
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("%w: no pod found for job %s/%s", ErrSummaryNotFound, job.Namespace, job.Name)
+	}
+
+	return readPodLogs(ctx, client, job.Namespace, pods.Items[len(pods.Items)-1].Name)
+}
+
+// deleteJob removes job and its pod, warning on failure since the Job's TTL
+// removes it eventually anyway.
+func deleteJob(ctx context.Context, client kubernetes.Interface, job *batchv1.Job, writer io.Writer) {
+	err := client.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+	})
+	if err != nil {
+		notify.WriteMessage(notify.Message{
+			Type:    notify.WarningType,
+			Content: "failed to delete load test job %s/%s: %v",
+			Args:    []any{job.Namespace, job.Name, err},
+			Writer:  writer,
+		})
+	}
+}
+
+// tailLines returns the last n lines of text.
+func tailLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,54 @@
+// Load test script run by `ksail workload loadtest`. Every setting arrives as an
+// environment variable so the script itself never changes between runs.
+import http from 'k6/http';
+
+const vus = Number(__ENV.KSAIL_VUS);
+
+export const options = {
+  scenarios: {
+    load: {
+      executor: 'constant-arrival-rate',
+      rate: Number(__ENV.KSAIL_RPS),
+      timeUnit: '1s',
+      duration: __ENV.KSAIL_DURATION,
+      preAllocatedVUs: vus,
+      maxVUs: vus,
+    },
+  },
+  summaryTrendStats: ['min', 'avg', 'med', 'p(90)', 'p(95)', 'p(99)', 'max'],
+};
+
+export default function () {
+  http.request(__ENV.KSAIL_METHOD, __ENV.KSAIL_TARGET);
+}
+
+function metricValues(data, name) {
+  return data.metrics[name] ? data.metrics[name].values : {};
+}
+
+// handleSummary replaces k6's end-of-test report with one marker-prefixed JSON
+// line that KSail finds in the pod logs and parses.
+export function handleSummary(data) {
+  const reqs = metricValues(data, 'http_reqs');
+  const failed = metricValues(data, 'http_req_failed');
+  const dropped = metricValues(data, 'dropped_iterations');
+  const duration = metricValues(data, 'http_req_duration');
+
+  const summary = {
+    requests: reqs.count || 0,
+    rate: reqs.rate || 0,
+    failedRate: failed.rate || 0,
+    dropped: dropped.count || 0,
+    latencyMs: {
+      min: duration.min || 0,
+      avg: duration.avg || 0,
+      p50: duration.med || 0,
+      p90: duration['p(90)'] || 0,
+      p95: duration['p(95)'] || 0,
+      p99: duration['p(99)'] || 0,
+      max: duration.max || 0,
+    },
+  };
+
+  return { stdout: __ENV.KSAIL_SUMMARY_MARKER + JSON.stringify(summary) + '\n' };
+}
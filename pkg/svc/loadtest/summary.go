@@ -0,0 +1,98 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Latency holds request latency statistics in milliseconds.
+type Latency struct {
+	Min float64 `json:"min"`
+	Avg float64 `json:"avg"`
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// Summary is the result of a load test run.
+type Summary struct {
+	// Target is the URL that was requested.
+	Target string `json:"target"`
+	// Requests is the number of requests completed.
+	Requests int64 `json:"requests"`
+	// Rate is the achieved requests per second.
+	Rate float64 `json:"rate"`
+	// FailedRate is the fraction of requests that failed (0-1).
+	FailedRate float64 `json:"failedRate"`
+	// Dropped counts requests that could not be issued on schedule because every
+	// virtual user was busy — a sign the target is saturated or VUs is too low.
+	Dropped int64 `json:"dropped"`
+	// LatencyMs holds request latency statistics in milliseconds.
+	LatencyMs Latency `json:"latencyMs"`
+}
+
+// ParseSummary extracts the summary the k6 script printed from the load
+// generator's logs. The last summary line wins.
+func ParseSummary(logs string) (Summary, error) {
+	var line string
+
+	for candidate := range strings.Lines(logs) {
+		if rest, ok := strings.CutPrefix(candidate, SummaryMarker); ok {
+			line = rest
+		}
+	}
+
+	if line == "" {
+		return Summary{}, ErrSummaryNotFound
+	}
+
+	var summary Summary
+
+	err := json.Unmarshal([]byte(line), &summary)
+	if err != nil {
+		return Summary{}, fmt.Errorf("parse load test summary: %w", err)
+	}
+
+	return summary, nil
+}
+
+// WriteSummary renders summary as an aligned, human-readable report.
+func WriteSummary(writer io.Writer, summary Summary) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	latency := summary.LatencyMs
+	rows := [][2]string{
+		{"Target", summary.Target},
+		{"Requests", fmt.Sprintf("%d (%.1f/s)", summary.Requests, summary.Rate)},
+		{"Failed", fmt.Sprintf("%.2f%%", summary.FailedRate*100)}, //nolint:mnd // fraction to percent
+		{"Dropped", fmt.Sprintf("%d", summary.Dropped)},
+		{"Latency min/avg/max", fmt.Sprintf("%s / %s / %s",
+			formatMs(latency.Min), formatMs(latency.Avg), formatMs(latency.Max))},
+		{"Latency p50/p90/p95/p99", fmt.Sprintf("%s / %s / %s / %s",
+			formatMs(latency.P50), formatMs(latency.P90), formatMs(latency.P95), formatMs(latency.P99))},
+	}
+
+	for _, row := range rows {
+		_, err := fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1])
+		if err != nil {
+			return fmt.Errorf("write summary: %w", err)
+		}
+	}
+
+	err := tw.Flush()
+	if err != nil {
+		return fmt.Errorf("write summary: %w", err)
+	}
+
+	return nil
+}
+
+// formatMs formats a latency in milliseconds.
+func formatMs(ms float64) string {
+	return fmt.Sprintf("%.2fms", ms)
+}
@@ -0,0 +1,85 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ResolveTarget turns target into the URL the load generator requests. An
+// http(s) URL is used verbatim. Anything else is a Service reference of the form
+// name[.namespace][:port][/path], resolved against the cluster so a missing
+// Service or port fails before a Job is created; namespace defaults to
+// defaultNamespace and port to the Service's first port.
+func ResolveTarget(
+	ctx context.Context,
+	client kubernetes.Interface,
+	defaultNamespace, target string,
+) (string, error) {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		parsed, err := url.Parse(target)
+		if err != nil || parsed.Host == "" {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTarget, target)
+		}
+
+		return target, nil
+	}
+
+	ref, path, _ := strings.Cut(target, "/")
+
+	hostPart, portPart, hasPort := strings.Cut(ref, ":")
+	name, namespace, _ := strings.Cut(hostPart, ".")
+
+	if name == "" || (hasPort && portPart == "") || strings.Contains(target, "://") {
+		return "", fmt.Errorf("%w: %q (expected an http(s) URL or service[.namespace][:port][/path])",
+			ErrInvalidTarget, target)
+	}
+
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	port, err := servicePort(ctx, client, namespace, name, portPart)
+	if err != nil {
+		return "", err
+	}
+
+	host := net.JoinHostPort(name+"."+namespace+".svc", strconv.Itoa(int(port)))
+
+	return "http://" + host + "/" + path, nil
+}
+
+// servicePort returns the port of Service namespace/name matching want (a port
+// name or number), or its first port when want is empty.
+func servicePort(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name, want string,
+) (int32, error) {
+	svc, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("get service %s/%s: %w", namespace, name, err)
+	}
+
+	if len(svc.Spec.Ports) == 0 {
+		return 0, fmt.Errorf("%w: %s/%s", ErrServiceHasNoPorts, namespace, name)
+	}
+
+	if want == "" {
+		return svc.Spec.Ports[0].Port, nil
+	}
+
+	for _, port := range svc.Spec.Ports {
+		if port.Name == want || strconv.Itoa(int(port.Port)) == want {
+			return port.Port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q on %s/%s", ErrServicePortNotFound, want, namespace, name)
+}
@@ -0,0 +1,19 @@
+package loadtest
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Dockerfile
+var dockerfile string
+
+// DefaultImage returns the k6 image the load generator Job runs.
+func DefaultImage() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+(docker\.io/grafana/k6:[^\s]+)`,
+		"k6",
+	)
+}
@@ -0,0 +1,23 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// NewWatchedServer returns a server whose project resources are watched until
+// ctx is done, with kubeconfigPath standing in for the resolved kubeconfig.
+func NewWatchedServer(ctx context.Context, cfg ServerConfig, kubeconfigPath string) (*mcpsdk.Server, error) {
+	server, project := newServer(cfg)
+
+	watcher, err := newProjectWatcher(server, project, kubeconfigPath, slog.New(slog.DiscardHandler))
+	if err != nil {
+		return nil, err
+	}
+
+	go watcher.run(ctx)
+
+	return server, nil
+}
@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Project resource URIs. The config resource serves the ksail.yaml found from
+// the working directory; the clusters resource lists the local cluster state.
+const (
+	ConfigResourceURI   = "ksail://config"
+	ClustersResourceURI = "ksail://clusters"
+
+	yamlMIMEType   = "application/yaml"
+	configFileName = "ksail.yaml"
+)
+
+// ErrNoProjectConfig is returned when no ksail.yaml is found from the working directory.
+var ErrNoProjectConfig = errors.New("no ksail.yaml found in the working directory or its parents")
+
+// clusterSummary is one entry of the clusters resource.
+type clusterSummary struct {
+	Name         string                `json:"name"`
+	Distribution v1alpha1.Distribution `json:"distribution"`
+	Provider     v1alpha1.Provider     `json:"provider"`
+	SavedAt      time.Time             `json:"savedAt"`
+}
+
+// projectContext is the server's view of the project configuration and the
+// local cluster state. It is loaded when the server starts and refreshed by
+// the watcher whenever the underlying files change.
+type projectContext struct {
+	workingDir string
+
+	mu         sync.RWMutex
+	configPath string
+	config     []byte
+	configErr  error
+	clusters   []clusterSummary
+	clusterErr error
+}
+
+// newProjectContext returns a projectContext for workingDir (the current
+// directory when empty) with its configuration and cluster state loaded.
+func newProjectContext(workingDir string) *projectContext {
+	if workingDir == "" {
+		workingDir = "."
+	}
+
+	absDir, err := filepath.Abs(workingDir)
+	if err == nil {
+		workingDir = absDir
+	}
+
+	project := &projectContext{workingDir: workingDir}
+	project.refreshConfig()
+	project.refreshClusters()
+
+	return project
+}
+
+// refreshConfig re-resolves and re-reads ksail.yaml.
+func (p *projectContext) refreshConfig() {
+	path := findProjectConfig(p.workingDir)
+
+	var (
+		data []byte
+		err  = ErrNoProjectConfig
+	)
+
+	if path != "" {
+		data, err = os.ReadFile(path) //nolint:gosec // path is ksail.yaml in the working directory or a parent
+		if err != nil {
+			err = fmt.Errorf("read %s: %w", path, err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.configPath, p.config, p.configErr = path, data, err
+}
+
+// refreshClusters re-reads the local cluster state registry.
+func (p *projectContext) refreshClusters() {
+	entries, err := state.ListClusterStates()
+
+	clusters := make([]clusterSummary, 0, len(entries))
+
+	for _, entry := range entries {
+		clusters = append(clusters, clusterSummary{
+			Name:         entry.Name,
+			Distribution: entry.Spec.Distribution,
+			Provider:     entry.Spec.Provider,
+			SavedAt:      entry.SavedAt,
+		})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clusters, p.clusterErr = clusters, err
+}
+
+// configDirs returns the directories whose ksail.yaml decides the project
+// configuration: the working directory and, when different, the directory of
+// the ksail.yaml found above it.
+func (p *projectContext) configDirs() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	dirs := []string{p.workingDir}
+	if p.configPath != "" && filepath.Dir(p.configPath) != p.workingDir {
+		dirs = append(dirs, filepath.Dir(p.configPath))
+	}
+
+	return dirs
+}
+
+// findProjectConfig returns the ksail.yaml in dir or its nearest parent, or
+// "" when there is none, mirroring the CLI's config discovery.
+func findProjectConfig(dir string) string {
+	for {
+		path := filepath.Join(dir, configFileName)
+
+		info, err := os.Stat(path)
+		if err == nil && info.Mode().IsRegular() {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// registerProjectResources registers the config and clusters resources, served
+// from project.
+func registerProjectResources(server *mcpsdk.Server, project *projectContext) {
+	server.AddResource(&mcpsdk.Resource{
+		URI:   ConfigResourceURI,
+		Name:  "config",
+		Title: "KSail project configuration",
+		Description: "The ksail.yaml found from the server's working directory. " +
+			"Subscribe to be notified when it changes.",
+		MIMEType: yamlMIMEType,
+	}, project.configHandler)
+
+	server.AddResource(&mcpsdk.Resource{
+		URI:   ClustersResourceURI,
+		Name:  "clusters",
+		Title: "Local clusters",
+		Description: "Clusters recorded in the local KSail state (~/.ksail/clusters) with their " +
+			"distribution, provider, and when their spec was last saved. " +
+			"Subscribe to be notified when clusters are created, updated, or deleted.",
+		MIMEType: jsonMIMEType,
+	}, project.clustersHandler)
+}
+
+// configHandler serves the cached ksail.yaml.
+func (p *projectContext) configHandler(
+	_ context.Context,
+	req *mcpsdk.ReadResourceRequest,
+) (*mcpsdk.ReadResourceResult, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.configErr != nil {
+		return nil, p.configErr
+	}
+
+	return &mcpsdk.ReadResourceResult{
+		Contents: []*mcpsdk.ResourceContents{
+			{URI: req.Params.URI, MIMEType: yamlMIMEType, Text: string(p.config)},
+		},
+	}, nil
+}
+
+// clustersHandler serves the cached cluster list.
+func (p *projectContext) clustersHandler(
+	_ context.Context,
+	req *mcpsdk.ReadResourceRequest,
+) (*mcpsdk.ReadResourceResult, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.clusterErr != nil {
+		return nil, p.clusterErr
+	}
+
+	body, err := json.MarshalIndent(p.clusters, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode clusters: %w", err)
+	}
+
+	return &mcpsdk.ReadResourceResult{
+		Contents: []*mcpsdk.ResourceContents{
+			{URI: req.Params.URI, MIMEType: jsonMIMEType, Text: string(body)},
+		},
+	}, nil
+}
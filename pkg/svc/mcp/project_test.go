@@ -0,0 +1,153 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/mcp"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = "apiVersion: ksail.io/v1alpha1\nkind: Cluster\n"
+
+// setupProject points HOME at a temporary directory and returns a working
+// directory holding a ksail.yaml.
+func setupProject(t *testing.T) string {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+
+	workDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, "ksail.yaml"), []byte(testConfig), 0o600))
+
+	return workDir
+}
+
+// readResource returns the text of the resource at uri.
+func readResource(ctx context.Context, t *testing.T, session *mcpsdk.ClientSession, uri string) string {
+	t.Helper()
+
+	result, err := session.ReadResource(ctx, &mcpsdk.ReadResourceParams{URI: uri})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+
+	return result.Contents[0].Text
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestNewServer_ProjectResources(t *testing.T) {
+	workDir := setupProject(t)
+
+	require.NoError(t, state.SaveClusterSpec("dev", &v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionK3s,
+		Provider:     v1alpha1.ProviderDocker,
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	session := connectClientServer(ctx, t, mcp.ServerConfig{
+		Name: "test-server", Version: "0.1.0", RootCmd: newTestCobraTree(), WorkingDirectory: workDir,
+	})
+
+	assert.Equal(t, testConfig, readResource(ctx, t, session, mcp.ConfigResourceURI))
+
+	var clusters []struct {
+		Name         string `json:"name"`
+		Distribution string `json:"distribution"`
+		Provider     string `json:"provider"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(readResource(ctx, t, session, mcp.ClustersResourceURI)), &clusters))
+	require.Len(t, clusters, 1)
+	assert.Equal(t, "dev", clusters[0].Name)
+	assert.Equal(t, "K3s", clusters[0].Distribution)
+	assert.Equal(t, "Docker", clusters[0].Provider)
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestNewServer_ConfigResourceWithoutProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	session := connectClientServer(ctx, t, mcp.ServerConfig{
+		Name: "test-server", Version: "0.1.0", RootCmd: newTestCobraTree(), WorkingDirectory: t.TempDir(),
+	})
+
+	_, err := session.ReadResource(ctx, &mcpsdk.ReadResourceParams{URI: mcp.ConfigResourceURI})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no ksail.yaml found")
+}
+
+//nolint:paralleltest // uses t.Setenv
+func TestWatchedServer_NotifiesResourceUpdates(t *testing.T) {
+	workDir := setupProject(t)
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	server, err := mcp.NewWatchedServer(ctx, mcp.ServerConfig{
+		Name: "test-server", Version: "0.1.0", RootCmd: newTestCobraTree(), WorkingDirectory: workDir,
+	}, kubeconfigPath)
+	require.NoError(t, err)
+
+	updated := make(chan string, 10)
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "0.0.1"},
+		&mcpsdk.ClientOptions{
+			ResourceUpdatedHandler: func(_ context.Context, req *mcpsdk.ResourceUpdatedNotificationRequest) {
+				updated <- req.Params.URI
+			},
+		})
+
+	serverTransport, clientTransport := mcpsdk.NewInMemoryTransports()
+
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	for _, uri := range []string{mcp.ConfigResourceURI, mcp.ClustersResourceURI, mcp.FluxStatusResourceURI} {
+		require.NoError(t, session.Subscribe(ctx, &mcpsdk.SubscribeParams{URI: uri}))
+	}
+
+	const changedConfig = testConfig + "spec: {}\n"
+
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, "ksail.yaml"), []byte(changedConfig), 0o600))
+	assert.Equal(t, mcp.ConfigResourceURI, waitForUpdate(t, updated))
+	assert.Equal(t, changedConfig, readResource(ctx, t, session, mcp.ConfigResourceURI))
+
+	require.NoError(t, state.SaveClusterSpec("dev", &v1alpha1.ClusterSpec{}))
+	assert.Equal(t, mcp.ClustersResourceURI, waitForUpdate(t, updated))
+	assert.Contains(t, readResource(ctx, t, session, mcp.ClustersResourceURI), `"name": "dev"`)
+
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte("apiVersion: v1\n"), 0o600))
+	assert.Equal(t, mcp.FluxStatusResourceURI, waitForUpdate(t, updated))
+}
+
+// waitForUpdate returns the next resource-updated URI.
+func waitForUpdate(t *testing.T, updated <-chan string) string {
+	t.Helper()
+
+	select {
+	case uri := <-updated:
+		return uri
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a resource update notification")
+
+		return ""
+	}
+}
@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Flux status resource URIs. The static resource reads the kubeconfig's current
+// context; the template selects a context by name.
+const (
+	FluxStatusResourceURI         = "ksail://flux/status"
+	FluxStatusResourceURITemplate = FluxStatusResourceURI + "/{context}"
+
+	jsonMIMEType = "application/json"
+)
+
+// FluxStatusFunc lists the Flux Kustomization and HelmRelease statuses of the
+// cluster behind a kubeconfig context (empty for the current context).
+type FluxStatusFunc func(ctx context.Context, contextName string) ([]fluxclient.ResourceStatus, error)
+
+// fluxStatusDocument is the JSON body of the Flux status resources.
+type fluxStatusDocument struct {
+	Context   string                      `json:"context,omitempty"`
+	Unhealthy int                         `json:"unhealthy"`
+	Resources []fluxclient.ResourceStatus `json:"resources"`
+}
+
+// defaultFluxStatus resolves the kubeconfig (KUBECONFIG, else ~/.kube/config) and
+// lists the Flux resource statuses for contextName.
+func defaultFluxStatus(ctx context.Context, contextName string) ([]fluxclient.ResourceStatus, error) {
+	kubeconfigPath, err := k8s.ResolveKubeconfigPath("")
+	if err != nil {
+		return nil, fmt.Errorf("resolve kubeconfig: %w", err)
+	}
+
+	fluxReconciler, err := fluxclient.NewReconcilerForContext(kubeconfigPath, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("create flux client: %w", err)
+	}
+
+	statuses, err := fluxReconciler.ListResourceStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list flux resource statuses: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// registerResources registers the read-only MCP resources: the reconciliation
+// state (Ready/Stalled/Drifted/Suspended) of every Flux Kustomization and
+// HelmRelease, so agents can spot drift and stalled releases without running a
+// command.
+func registerResources(server *mcpsdk.Server, cfg ServerConfig) {
+	status := cfg.FluxStatus
+	if status == nil {
+		status = defaultFluxStatus
+	}
+
+	const description = "Reconciliation status of every Flux Kustomization and HelmRelease " +
+		"(ready, stalled, drifted via HelmRelease drift detection, suspended) with the reason " +
+		"and message of the most significant condition."
+
+	server.AddResource(&mcpsdk.Resource{
+		URI:         FluxStatusResourceURI,
+		Name:        "flux-status",
+		Title:       "Flux status (current context)",
+		Description: description,
+		MIMEType:    jsonMIMEType,
+	}, fluxStatusHandler(status))
+
+	server.AddResourceTemplate(&mcpsdk.ResourceTemplate{
+		URITemplate: FluxStatusResourceURITemplate,
+		Name:        "flux-status-context",
+		Title:       "Flux status (by kubeconfig context)",
+		Description: description,
+		MIMEType:    jsonMIMEType,
+	}, fluxStatusHandler(status))
+}
+
+// fluxStatusHandler serves the Flux status document for the context named in the
+// requested URI (none for the static resource).
+func fluxStatusHandler(status FluxStatusFunc) mcpsdk.ResourceHandler {
+	return func(ctx context.Context, req *mcpsdk.ReadResourceRequest) (*mcpsdk.ReadResourceResult, error) {
+		uri := req.Params.URI
+
+		contextName, err := url.PathUnescape(
+			strings.TrimPrefix(strings.TrimPrefix(uri, FluxStatusResourceURI), "/"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid context in %s: %w", uri, err)
+		}
+
+		statuses, err := status(ctx, contextName)
+		if err != nil {
+			return nil, err
+		}
+
+		doc := fluxStatusDocument{Context: contextName, Resources: statuses}
+		if doc.Resources == nil {
+			doc.Resources = []fluxclient.ResourceStatus{}
+		}
+
+		for _, resource := range statuses {
+			if !resource.Healthy() {
+				doc.Unhealthy++
+			}
+		}
+
+		body, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encode flux status: %w", err)
+		}
+
+		return &mcpsdk.ReadResourceResult{
+			Contents: []*mcpsdk.ResourceContents{
+				{URI: uri, MIMEType: jsonMIMEType, Text: string(body)},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,77 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/mcp"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer_FluxStatusResources(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	var requestedContexts []string
+
+	cfg := mcp.ServerConfig{
+		Name:    "test-server",
+		Version: "0.1.0",
+		RootCmd: newTestCobraTree(),
+		FluxStatus: func(_ context.Context, contextName string) ([]fluxclient.ResourceStatus, error) {
+			requestedContexts = append(requestedContexts, contextName)
+
+			return []fluxclient.ResourceStatus{
+				{Kind: fluxclient.KindKustomization, Name: "flux-system", Namespace: "flux-system", Ready: true},
+				{
+					Kind: fluxclient.KindHelmRelease, Name: "podinfo", Namespace: "podinfo",
+					Ready: true, Drifted: true, Reason: "DriftDetected",
+				},
+			}, nil
+		},
+	}
+
+	clientSession := connectClientServer(ctx, t, cfg)
+
+	resources, err := clientSession.ListResources(ctx, nil)
+	require.NoError(t, err)
+
+	uris := make([]string, 0, len(resources.Resources))
+	for _, resource := range resources.Resources {
+		uris = append(uris, resource.URI)
+	}
+
+	assert.ElementsMatch(t, []string{
+		mcp.ClustersResourceURI, mcp.ConfigResourceURI, mcp.FluxStatusResourceURI,
+	}, uris)
+
+	templates, err := clientSession.ListResourceTemplates(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, templates.ResourceTemplates, 1)
+	assert.Equal(t, mcp.FluxStatusResourceURITemplate, templates.ResourceTemplates[0].URITemplate)
+
+	for _, uri := range []string{mcp.FluxStatusResourceURI, mcp.FluxStatusResourceURI + "/kind-dev"} {
+		result, err := clientSession.ReadResource(ctx, &mcpsdk.ReadResourceParams{URI: uri})
+		require.NoError(t, err)
+		require.Len(t, result.Contents, 1)
+
+		var doc struct {
+			Context   string                      `json:"context"`
+			Unhealthy int                         `json:"unhealthy"`
+			Resources []fluxclient.ResourceStatus `json:"resources"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &doc))
+
+		assert.Equal(t, 1, doc.Unhealthy)
+		assert.Len(t, doc.Resources, 2)
+		assert.True(t, doc.Resources[1].Drifted)
+	}
+
+	assert.Equal(t, []string{"", "kind-dev"}, requestedContexts)
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
 	"github.com/devantler-tech/ksail/v7/pkg/toolgen"
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/spf13/cobra"
@@ -35,6 +36,9 @@ type ServerConfig struct {
 	// when the MCP client launches the server with a minimal PATH. When empty,
 	// tool execution falls back to resolving the root command name via PATH.
 	ExecutablePath string
+	// FluxStatus lists Flux resource statuses for the flux status resources.
+	// When nil, the kubeconfig from KUBECONFIG (or ~/.kube/config) is used.
+	FluxStatus FluxStatusFunc
 }
 
 // DefaultConfig returns a default server configuration.
@@ -51,6 +55,14 @@ func DefaultConfig(rootCmd *cobra.Command, version string) ServerConfig {
 
 // NewServer creates and configures a new MCP server with KSail tools.
 func NewServer(cfg ServerConfig) (*mcpsdk.Server, error) {
+	server, _ := newServer(cfg)
+
+	return server, nil
+}
+
+// newServer creates the MCP server together with the project context backing
+// its config and clusters resources, so RunServer can keep it up to date.
+func newServer(cfg ServerConfig) (*mcpsdk.Server, *projectContext) {
 	// Create server instance
 	server := mcpsdk.NewServer(&mcpsdk.Implementation{
 		Name:    cfg.Name,
@@ -63,10 +75,18 @@ func NewServer(cfg ServerConfig) (*mcpsdk.Server, error) {
 			"workload_write (apply/create/scale/rollout, plus cipher SOPS secret encryption), " +
 			"tenant_write (multi-tenancy).\n" +
 			"Cluster lifecycle tools use --name and optionally --kubeconfig to target a cluster. " +
-			"Workload tools accept --context to target a kubeconfig context and --namespace for namespace scoping.",
+			"Workload tools accept --context to target a kubeconfig context and --namespace for namespace scoping.\n" +
+			"Resources: " + FluxStatusResourceURI + " (and " + FluxStatusResourceURITemplate + ") report " +
+			"per-Kustomization/HelmRelease Flux status, including drift and stalled releases; " +
+			ConfigResourceURI + " serves the project's ksail.yaml and " + ClustersResourceURI +
+			" lists local clusters. Subscribe to them to be notified when they change.",
 		Logger:    cfg.Logger,
 		KeepAlive: serverKeepAlive,
 		PageSize:  serverPageSize,
+		// Subscriptions are tracked by the SDK; RunServer's watcher notifies
+		// subscribers through Server.ResourceUpdated.
+		SubscribeHandler:   func(context.Context, *mcpsdk.SubscribeRequest) error { return nil },
+		UnsubscribeHandler: func(context.Context, *mcpsdk.UnsubscribeRequest) error { return nil },
 	})
 
 	// Generate tool definitions
@@ -88,26 +108,62 @@ func NewServer(cfg ServerConfig) (*mcpsdk.Server, error) {
 	// Register tools with MCP server
 	toolgen.ToMCPTools(server, toolDefs, opts)
 
-	return server, nil
+	registerResources(server, cfg)
+
+	project := newProjectContext(cfg.WorkingDirectory)
+	registerProjectResources(server, project)
+
+	return server, project
 }
 
 // RunServer creates and runs an MCP server over stdio.
-// This is the main entry point for the MCP server.
+// This is the main entry point for the MCP server. While it runs, ksail.yaml,
+// the local cluster state, and the kubeconfig are watched so the server's
+// resources stay current for long-lived sessions.
 func RunServer(ctx context.Context, cfg ServerConfig) error {
 	// Create server
-	server, err := NewServer(cfg)
-	if err != nil {
-		return fmt.Errorf("creating server: %w", err)
-	}
+	server, project := newServer(cfg)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	startProjectWatcher(ctx, server, project, cfg.Logger)
 
 	// Create stdio transport
 	transport := &mcpsdk.StdioTransport{}
 
 	// Run server - blocks until client disconnects or context is cancelled
-	err = server.Run(ctx, transport)
+	err := server.Run(ctx, transport)
 	if err != nil {
 		return fmt.Errorf("running server: %w", err)
 	}
 
 	return nil
 }
+
+// startProjectWatcher watches the project files in the background until ctx is
+// done. A watcher that cannot start only disables live reload.
+func startProjectWatcher(
+	ctx context.Context,
+	server *mcpsdk.Server,
+	project *projectContext,
+	logger *slog.Logger,
+) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	kubeconfigPath, err := k8s.ResolveKubeconfigPath("")
+	if err != nil {
+		kubeconfigPath = ""
+	}
+
+	watcher, err := newProjectWatcher(server, project, kubeconfigPath, logger)
+	if err != nil {
+		logger.Warn("live config reload disabled", "error", err)
+
+		return
+	}
+
+	go watcher.run(ctx)
+}
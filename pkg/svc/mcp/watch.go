@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/workloadwatch"
+	"github.com/fsnotify/fsnotify"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// reloadDebounce coalesces the burst of events an editor save or a cluster
+// create produces into one refresh per resource.
+const reloadDebounce = 500 * time.Millisecond
+
+// projectWatcher refreshes a projectContext when ksail.yaml, the cluster state
+// registry, or the kubeconfig changes, and notifies subscribers of the
+// resources that changed.
+type projectWatcher struct {
+	server         *mcpsdk.Server
+	project        *projectContext
+	logger         *slog.Logger
+	watcher        *fsnotify.Watcher
+	clustersDir    string
+	kubeconfigPath string
+}
+
+// newProjectWatcher returns a watcher for the files behind the project
+// resources with its watches in place. kubeconfigPath may be empty to skip
+// notifying Flux status changes.
+func newProjectWatcher(
+	server *mcpsdk.Server,
+	project *projectContext,
+	kubeconfigPath string,
+	logger *slog.Logger,
+) (*projectWatcher, error) {
+	clustersDir, err := state.ClustersDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve cluster state directory: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create file watcher: %w", err)
+	}
+
+	projWatcher := &projectWatcher{
+		server:         server,
+		project:        project,
+		logger:         logger,
+		watcher:        watcher,
+		clustersDir:    clustersDir,
+		kubeconfigPath: kubeconfigPath,
+	}
+
+	projWatcher.addWatches()
+
+	return projWatcher, nil
+}
+
+// addWatches watches the config directories, the cluster state registry, and
+// the kubeconfig directory. Directories are watched rather than files because
+// editors and kubeconfig writers replace files instead of writing them in place.
+func (w *projectWatcher) addWatches() {
+	for _, dir := range w.project.configDirs() {
+		_ = w.watcher.Add(dir)
+	}
+
+	w.watchClustersDir()
+
+	if w.kubeconfigPath != "" {
+		_ = w.watcher.Add(filepath.Dir(w.kubeconfigPath))
+	}
+}
+
+// watchClustersDir watches the cluster state registry recursively or, while
+// it does not exist yet, its nearest existing parent so its creation is noticed.
+func (w *projectWatcher) watchClustersDir() {
+	for dir := w.clustersDir; ; dir = filepath.Dir(dir) {
+		info, err := os.Stat(dir)
+		if err == nil && info.IsDir() {
+			if dir == w.clustersDir {
+				_ = workloadwatch.AddRecursive(w.watcher, dir)
+			} else {
+				_ = w.watcher.Add(dir)
+			}
+
+			return
+		}
+
+		if filepath.Dir(dir) == dir {
+			return
+		}
+	}
+}
+
+// run dispatches file events until ctx is done, refreshing and notifying each
+// affected resource once the events have settled, then closes the watcher.
+func (w *projectWatcher) run(ctx context.Context) {
+	defer func() { _ = w.watcher.Close() }()
+
+	pending := map[string]bool{}
+
+	timer := time.NewTimer(reloadDebounce)
+	timer.Stop()
+
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if !workloadwatch.IsRelevantEvent(event) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) {
+				w.watchCreated(event.Name)
+			}
+
+			uri := w.resourceFor(event.Name)
+			if uri == "" {
+				continue
+			}
+
+			pending[uri] = true
+
+			timer.Reset(reloadDebounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			w.logger.Warn("project watcher error", "error", err)
+		case <-timer.C:
+			for uri := range pending {
+				w.refresh(ctx, uri)
+			}
+
+			clear(pending)
+		}
+	}
+}
+
+// watchCreated extends the watches to a created directory inside the cluster
+// state registry, or to the registry itself once a missing parent appears.
+func (w *projectWatcher) watchCreated(path string) {
+	switch {
+	case strings.HasPrefix(path, w.clustersDir+string(filepath.Separator)):
+		workloadwatch.TryAddDirectory(w.watcher, path, io.Discard)
+	case isAncestorOrSelf(path, w.clustersDir):
+		w.watchClustersDir()
+	}
+}
+
+// resourceFor returns the URI of the resource backed by path, or "" when the
+// path is unrelated.
+func (w *projectWatcher) resourceFor(path string) string {
+	switch {
+	case filepath.Base(path) == configFileName && w.isConfigDir(filepath.Dir(path)):
+		return ConfigResourceURI
+	case strings.HasPrefix(path, w.clustersDir+string(filepath.Separator)), isAncestorOrSelf(path, w.clustersDir):
+		return ClustersResourceURI
+	case w.kubeconfigPath != "" && path == w.kubeconfigPath:
+		return FluxStatusResourceURI
+	default:
+		return ""
+	}
+}
+
+// isConfigDir reports whether dir is one of the watched config directories.
+func (w *projectWatcher) isConfigDir(dir string) bool {
+	for _, configDir := range w.project.configDirs() {
+		if configDir == dir {
+			return true
+		}
+	}
+
+	return false
+}
+
+// refresh reloads the cached state behind uri and notifies its subscribers.
+// The Flux status is read live, so a kubeconfig change (such as switching the
+// current context) only needs the notification.
+func (w *projectWatcher) refresh(ctx context.Context, uri string) {
+	switch uri {
+	case ConfigResourceURI:
+		w.project.refreshConfig()
+
+		// A ksail.yaml created in or removed from the working directory moves
+		// the config directory; watching an already watched path is a no-op.
+		for _, dir := range w.project.configDirs() {
+			_ = w.watcher.Add(dir)
+		}
+	case ClustersResourceURI:
+		w.project.refreshClusters()
+	}
+
+	err := w.server.ResourceUpdated(ctx, &mcpsdk.ResourceUpdatedNotificationParams{URI: uri})
+	if err != nil {
+		w.logger.Warn("notify resource update", "uri", uri, "error", err)
+	}
+}
+
+// isAncestorOrSelf reports whether dir is path or one of its parent directories.
+func isAncestorOrSelf(dir, path string) bool {
+	prefix := strings.TrimSuffix(dir, string(filepath.Separator)) + string(filepath.Separator)
+
+	return dir == path || strings.HasPrefix(path, prefix)
+}
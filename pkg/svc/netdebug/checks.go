@@ -0,0 +1,168 @@
+package netdebug
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Check names a kind of network check.
+type Check string
+
+// Checks run by the diagnostic pod.
+const (
+	// CheckClusterDNS resolves the kubernetes Service through cluster DNS.
+	CheckClusterDNS Check = "cluster-dns"
+	// CheckExternalDNS resolves a public host name through cluster DNS.
+	CheckExternalDNS Check = "external-dns"
+	// CheckRegistry requests the /v2/ endpoint of a registry mirror's upstream.
+	CheckRegistry Check = "registry"
+	// CheckAPIServer requests the API server's /livez through the kubernetes Service.
+	CheckAPIServer Check = "api-server"
+	// CheckPodNetwork requests a peer pod on a node by its pod IP.
+	CheckPodNetwork Check = "pod-network"
+)
+
+// resultMarker prefixes every result line the diagnostic script prints.
+const resultMarker = "KSAIL_NETDEBUG"
+
+// detailLimit caps the command output kept per result.
+const detailLimit = 160
+
+// hints are the remediation suggestions shown for failed checks.
+//
+//nolint:gochecknoglobals // Static lookup table.
+var hints = map[Check]string{
+	CheckClusterDNS: "CoreDNS is not answering: check its pods with " +
+		"`ksail workload get pods -n kube-system -l k8s-app=kube-dns` and that the CNI is Ready.",
+	CheckExternalDNS: "CoreDNS cannot resolve public names: check the upstream resolvers it " +
+		"forwards to (the nodes' /etc/resolv.conf) and the host's DNS, VPN or proxy settings.",
+	CheckRegistry: "The registry is unreachable from the cluster network: check egress, proxy " +
+		"and firewall settings, or drop the mirror with --mirror-registry.",
+	CheckAPIServer: "Pods cannot reach the API server through the kubernetes Service: check " +
+		"kube-proxy (or the CNI's kube-proxy replacement) and NetworkPolicies in the namespace.",
+	CheckPodNetwork: "Pod traffic to this node fails: check the CNI pods on both nodes and " +
+		"firewalls between nodes (overlay ports such as VXLAN 8472/udp).",
+}
+
+// Hint returns the remediation suggestion for a failed check.
+func Hint(check Check) string {
+	return hints[check]
+}
+
+// probe is one check the diagnostic script runs: a shell command that exits
+// zero on success.
+type probe struct {
+	check   Check
+	target  string
+	command string
+}
+
+// Peer is a pod on a node that the diagnostic pod requests to check pod
+// connectivity to that node. An empty IP means the peer never started.
+type Peer struct {
+	Node string
+	IP   string
+}
+
+// scriptPrelude defines the helpers the generated probes use. check runs a
+// command and prints one tab-separated result line with its flattened output;
+// registry treats 200 and 401 from /v2/ as reachable.
+const scriptPrelude = `check() {
+  name=$1; target=$2; shift 2
+  if out=$(eval "$1" 2>&1); then status=pass; else status=fail; fi
+  out=$(printf '%%s' "$out" | tr '\t\n' '  ' | cut -c1-` + "%d" + `)
+  printf '` + resultMarker + `\t%%s\t%%s\t%%s\t%%s\n' "$name" "$target" "$status" "$out"
+}
+registry() {
+  code=$(curl -sS --max-time 10 -o /dev/null -w '%%{http_code}' "$1/v2/") || return 1
+  echo "HTTP $code"
+  [ "$code" = 200 ] || [ "$code" = 401 ]
+}
+`
+
+// buildProbes returns the checks for opts against peers, in report order.
+func buildProbes(opts Options, peers []Peer) []probe {
+	probes := []probe{
+		{CheckClusterDNS, "kubernetes.default", "nslookup kubernetes.default"},
+		{CheckExternalDNS, opts.ExternalHost, "nslookup " + shellQuote(opts.ExternalHost)},
+		{
+			CheckAPIServer, "https://kubernetes.default.svc/livez",
+			"curl -fsSk --max-time 10 https://kubernetes.default.svc/livez",
+		},
+	}
+
+	for _, url := range opts.Registries {
+		probes = append(probes, probe{CheckRegistry, url, "registry " + shellQuote(url)})
+	}
+
+	for _, peer := range peers {
+		command := "echo 'peer pod is not running'; false"
+		if peer.IP != "" {
+			command = "curl -fsS --max-time 5 " + shellQuote(peerURL(peer.IP))
+		}
+
+		probes = append(probes, probe{CheckPodNetwork, peer.Node, command})
+	}
+
+	return probes
+}
+
+// BuildScript returns the shell script the diagnostic pod runs. It always exits
+// zero: failures are reported in the printed result lines.
+func BuildScript(opts Options, peers []Peer) string {
+	var script strings.Builder
+
+	_, _ = fmt.Fprintf(&script, scriptPrelude, detailLimit)
+
+	for _, p := range buildProbes(opts, peers) {
+		_, _ = fmt.Fprintf(&script, "check %s %s %s\n",
+			p.check, shellQuote(p.target), shellQuote(p.command))
+	}
+
+	return script.String()
+}
+
+// ParseResults extracts the results the diagnostic script printed in logs and
+// attaches a hint to every failure.
+func ParseResults(logs string) []Result {
+	var results []Result
+
+	for line := range strings.SplitSeq(logs, "\n") {
+		fields := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 5)
+		if len(fields) < 4 || fields[0] != resultMarker {
+			continue
+		}
+
+		result := Result{
+			Check:  Check(fields[1]),
+			Target: fields[2],
+			Passed: fields[3] == "pass",
+		}
+		if len(fields) == 5 {
+			result.Detail = strings.TrimSpace(fields[4])
+		}
+
+		if !result.Passed {
+			result.Hint = Hint(result.Check)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// peerURL returns the netexec endpoint of a peer pod.
+func peerURL(ip string) string {
+	host := ip
+	if strings.Contains(ip, ":") {
+		host = "[" + ip + "]"
+	}
+
+	return fmt.Sprintf("http://%s:%d/hostname", host, peerPort)
+}
+
+// shellQuote quotes value as a single POSIX shell word.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,7 @@
+// Package netdebug diagnoses in-cluster networking for `ksail cluster debug
+// network`. A diagnostic pod running the Kubernetes e2e agnhost image (which
+// ships nslookup, dig and curl) checks cluster DNS, external DNS, registry
+// reachability and API server access, and requests a peer pod on every node to
+// verify cross-node pod connectivity. Each check is reported as a pass/fail
+// result with a remediation hint for failures.
+package netdebug
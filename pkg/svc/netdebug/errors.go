@@ -0,0 +1,13 @@
+package netdebug
+
+import "errors"
+
+var (
+	// ErrNoSchedulableNodes is returned when the cluster has no Ready node to run the
+	// diagnostic pod on.
+	ErrNoSchedulableNodes = errors.New("no ready nodes to run network checks on")
+	// ErrDiagnosticPodFailed is returned when the diagnostic pod does not run its checks.
+	ErrDiagnosticPodFailed = errors.New("network diagnostic pod failed")
+	// ErrChecksFailed reports that at least one network check failed.
+	ErrChecksFailed = errors.New("network checks failed")
+)
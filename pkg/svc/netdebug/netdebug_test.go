@@ -0,0 +1,206 @@
+package netdebug_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/netdebug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// writeStub writes an executable shell script named name into dir.
+func writeStub(t *testing.T, dir, name, body string) {
+	t.Helper()
+
+	err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body+"\n"), 0o700) //nolint:gosec // test stub
+	require.NoError(t, err)
+}
+
+func TestBuildScript_ReportsEveryCheck(t *testing.T) {
+	t.Parallel()
+
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	// Stub the tools the agnhost image ships: name resolution succeeds, the
+	// registry answers 401, the API server and peers refuse connections.
+	bin := t.TempDir()
+	writeStub(t, bin, "nslookup", `echo "Name: $1"`)
+	writeStub(t, bin, "curl", `case "$*" in
+  */v2/*) printf 401 ;;
+  *) echo "curl: (7) Failed to connect"; exit 7 ;;
+esac`)
+
+	script := netdebug.BuildScript(netdebug.Options{
+		ExternalHost: "example.com",
+		Registries:   []string{"https://ghcr.io"},
+	}, []netdebug.Peer{{Node: "worker", IP: "10.244.1.5"}, {Node: "worker2"}})
+
+	cmd := exec.CommandContext(context.Background(), shell, "-c", script)
+	cmd.Env = []string{"PATH=" + bin + string(os.PathListSeparator) + os.Getenv("PATH")}
+
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	results := netdebug.ParseResults(string(out))
+
+	require.Len(t, results, 6)
+	assert.Equal(t, netdebug.Result{
+		Check: netdebug.CheckClusterDNS, Target: "kubernetes.default", Passed: true,
+		Detail: "Name: kubernetes.default",
+	}, results[0])
+	assert.True(t, results[1].Passed)
+	assert.Equal(t, netdebug.CheckAPIServer, results[2].Check)
+	assert.False(t, results[2].Passed)
+	assert.Equal(t, "curl: (7) Failed to connect", results[2].Detail)
+	assert.Equal(t, netdebug.Hint(netdebug.CheckAPIServer), results[2].Hint)
+	assert.Equal(t, netdebug.Result{
+		Check: netdebug.CheckRegistry, Target: "https://ghcr.io", Passed: true, Detail: "HTTP 401",
+	}, results[3])
+	assert.Equal(t, "worker", results[4].Target)
+	assert.False(t, results[4].Passed)
+	assert.Equal(t, "peer pod is not running", results[5].Detail)
+}
+
+func TestBuildPeerPod(t *testing.T) {
+	t.Parallel()
+
+	pod := netdebug.BuildPeerPod(netdebug.Options{Namespace: "ops", Image: "agnhost:test"}, "abcde", 1, "worker")
+
+	assert.Equal(t, "ksail-netdebug-abcde-peer-1", pod.Name)
+	assert.Equal(t, "ops", pod.Namespace)
+	assert.Equal(t, "worker", pod.Spec.NodeName)
+	assert.Equal(t, []string{"netexec", "--http-port=8080"}, pod.Spec.Containers[0].Args)
+	assert.Equal(t, "abcde", pod.Labels[netdebug.LabelInstance])
+	assert.True(t, *pod.Spec.SecurityContext.RunAsNonRoot)
+}
+
+func newNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: ready},
+		}},
+	}
+}
+
+// startPodsOnCreate makes the fake clientset report created peer pods as
+// running and the diagnostic pod as finished on node "worker".
+func startPodsOnCreate(client *fake.Clientset, diagnosticPhase corev1.PodPhase) {
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod, _ := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+
+		if pod.Labels[netdebug.LabelComponent] == "peer" {
+			pod.Status = corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.244.0." + pod.Name[len(pod.Name)-1:]}
+		} else {
+			pod.Spec.NodeName = "worker"
+			pod.Status.Phase = diagnosticPhase
+		}
+
+		return false, nil, nil
+	})
+}
+
+//nolint:paralleltest // Replaces the package-level pod log reader.
+func TestRun(t *testing.T) {
+	client := fake.NewClientset(
+		newNode("control-plane", corev1.ConditionTrue),
+		newNode("worker", corev1.ConditionTrue),
+		newNode("broken", corev1.ConditionFalse),
+	)
+	startPodsOnCreate(client, corev1.PodSucceeded)
+
+	var scripts []string
+
+	restore := netdebug.SetReadPodLogsForTest(
+		func(ctx context.Context, client kubernetes.Interface, namespace, name string) (string, error) {
+			pod, err := client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			require.NoError(t, err)
+
+			scripts = append(scripts, pod.Spec.Containers[0].Env[0].Value)
+
+			return "KSAIL_NETDEBUG\tcluster-dns\tkubernetes.default\tpass\tok\n" +
+				"KSAIL_NETDEBUG\tpod-network\tcontrol-plane\tfail\ttimeout\n", nil
+		},
+	)
+	defer restore()
+
+	var progress bytes.Buffer
+
+	report, err := netdebug.Run(context.Background(), client, netdebug.Options{}, &progress)
+
+	require.NoError(t, err)
+	assert.Equal(t, "worker", report.Node)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, 1, report.Failed())
+	assert.Contains(t, progress.String(), "2 node(s)")
+
+	require.Len(t, scripts, 1)
+	assert.Contains(t, scripts[0], "http://10.244.0.0:8080/hostname")
+	assert.Contains(t, scripts[0], "http://10.244.0.1:8080/hostname")
+
+	pods, err := client.CoreV1().Pods(netdebug.DefaultNamespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items, "all pods are deleted after the run")
+}
+
+//nolint:paralleltest // Replaces the package-level pod log reader.
+func TestRun_DiagnosticPodFailed(t *testing.T) {
+	client := fake.NewClientset(newNode("worker", corev1.ConditionTrue))
+	startPodsOnCreate(client, corev1.PodFailed)
+
+	restore := netdebug.SetReadPodLogsForTest(
+		func(context.Context, kubernetes.Interface, string, string) (string, error) {
+			return "exec: sh: not found\n", nil
+		},
+	)
+	defer restore()
+
+	_, err := netdebug.Run(context.Background(), client, netdebug.Options{}, &bytes.Buffer{})
+
+	require.ErrorIs(t, err, netdebug.ErrDiagnosticPodFailed)
+	assert.Contains(t, err.Error(), "sh: not found")
+}
+
+func TestRun_NoReadyNodes(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset(newNode("broken", corev1.ConditionFalse))
+
+	_, err := netdebug.Run(context.Background(), client, netdebug.Options{}, &bytes.Buffer{})
+
+	require.ErrorIs(t, err, netdebug.ErrNoSchedulableNodes)
+}
+
+func TestWriteMatrix(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	err := netdebug.WriteMatrix(&out, netdebug.Report{Results: []netdebug.Result{
+		{Check: netdebug.CheckClusterDNS, Target: "kubernetes.default", Passed: true},
+		{Check: netdebug.CheckExternalDNS, Target: "example.com", Detail: "SERVFAIL", Hint: "check resolvers"},
+	}})
+
+	require.NoError(t, err)
+
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, "CHECK         TARGET              RESULT", lines[0])
+	assert.Equal(t, "cluster-dns   kubernetes.default  pass", lines[1])
+	assert.Equal(t, "external-dns  example.com         FAIL", lines[2])
+	assert.Contains(t, out.String(), "  external-dns → example.com\n    Output: SERVFAIL\n    Suggested fix: check resolvers\n")
+}
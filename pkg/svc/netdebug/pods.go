@@ -0,0 +1,98 @@
+package netdebug
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// podNamePrefix prefixes the names of all pods a diagnosis creates.
+	podNamePrefix = "ksail-netdebug-"
+	// peerPort is the port the peer pods' netexec server listens on.
+	peerPort = 8080
+	// containerName is the container name of diagnostic and peer pods.
+	containerName = "agnhost"
+	// nonRootUID is the user the pods run as; agnhost runs as root by default.
+	nonRootUID = 65534
+)
+
+// Label keys and values stamped onto the pods a diagnosis creates.
+const (
+	LabelName      = "app.kubernetes.io/name"
+	LabelInstance  = "app.kubernetes.io/instance"
+	LabelComponent = "app.kubernetes.io/component"
+	LabelManagedBy = "app.kubernetes.io/managed-by"
+	nameValue      = "ksail-netdebug"
+	managedByValue = "ksail"
+	componentPeer  = "peer"
+	componentProbe = "diagnostic"
+)
+
+// BuildPeerPod returns the netexec pod pinned to node that the diagnostic pod
+// requests to check pod connectivity to it. It tolerates every taint so
+// control-plane nodes are covered too.
+func BuildPeerPod(opts Options, runID string, index int, node string) *corev1.Pod {
+	pod := newPod(opts, runID, componentPeer)
+	pod.Name = podNamePrefix + runID + "-peer-" + strconv.Itoa(index)
+	pod.Spec.NodeName = node
+	pod.Spec.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+	pod.Spec.Containers[0].Args = []string{"netexec", "--http-port=" + strconv.Itoa(peerPort)}
+	pod.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: "http", ContainerPort: peerPort, Protocol: corev1.ProtocolTCP},
+	}
+
+	return pod
+}
+
+// BuildDiagnosticPod returns the pod that runs the checks of opts against peers
+// and prints their results.
+func BuildDiagnosticPod(opts Options, runID string, peers []Peer) *corev1.Pod {
+	pod := newPod(opts, runID, componentProbe)
+	pod.Name = podNamePrefix + runID
+	pod.Spec.Containers[0].Command = []string{"sh", "-c", `eval "$KSAIL_SCRIPT"`}
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{
+		{Name: "KSAIL_SCRIPT", Value: BuildScript(opts, peers)},
+	}
+
+	return pod
+}
+
+// newPod returns the base of diagnostic and peer pods. They satisfy the
+// restricted Pod Security Standard, so they run in any namespace.
+func newPod(opts Options, runID, component string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: opts.Namespace,
+			Labels: map[string]string{
+				LabelName:      nameValue,
+				LabelInstance:  runID,
+				LabelComponent: component,
+				LabelManagedBy: managedByValue,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			AutomountServiceAccountToken: ptr.To(false),
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: ptr.To(true),
+				RunAsUser:    ptr.To[int64](nonRootUID),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []corev1.Container{{
+				Name:  containerName,
+				Image: opts.Image,
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: ptr.To(false),
+					Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{"ALL"},
+					},
+				},
+			}},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package netdebug
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Result is the outcome of one check.
+type Result struct {
+	Check  Check  `json:"check"`
+	Target string `json:"target"`
+	Passed bool   `json:"passed"`
+	// Detail is the check command's flattened output, truncated.
+	Detail string `json:"detail,omitempty"`
+	// Hint suggests a remediation; set for failed checks only.
+	Hint string `json:"hint,omitempty"`
+}
+
+// Report holds the results of a network diagnosis.
+type Report struct {
+	// Node is the node the diagnostic pod ran on, which pod-network results are
+	// measured from.
+	Node    string   `json:"node"`
+	Results []Result `json:"results"`
+}
+
+// Failed returns the number of failed checks.
+func (r Report) Failed() int {
+	failed := 0
+
+	for _, result := range r.Results {
+		if !result.Passed {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// WriteMatrix writes the report as a pass/fail table followed by the hints of
+// the failed checks.
+func WriteMatrix(writer io.Writer, report Report) error {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "CHECK\tTARGET\tRESULT\n")
+
+	for _, result := range report.Results {
+		status := "pass"
+		if !result.Passed {
+			status = "FAIL"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Check, result.Target, status)
+	}
+
+	err := tw.Flush()
+	if err != nil {
+		return fmt.Errorf("write network check matrix: %w", err)
+	}
+
+	if report.Failed() == 0 {
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(writer, "\nFailed checks:")
+
+	for _, result := range report.Results {
+		if result.Passed {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(writer, "  %s → %s\n", result.Check, result.Target)
+
+		if result.Detail != "" {
+			_, _ = fmt.Fprintf(writer, "    Output: %s\n", result.Detail)
+		}
+
+		if result.Hint != "" {
+			_, _ = fmt.Fprintf(writer, "    Suggested fix: %s\n", result.Hint)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package netdebug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/k8s/readiness"
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultNamespace is where the pods run when no namespace is configured.
+	DefaultNamespace = "default"
+	// DefaultExternalHost is the public name resolved when none is configured.
+	DefaultExternalHost = "example.com"
+	// DefaultTimeout bounds each wait for pods when no timeout is configured.
+	DefaultTimeout = 3 * time.Minute
+
+	// runIDLength is the length of the random suffix shared by a run's pods.
+	runIDLength = 5
+	// failureLogLines is how many trailing log lines a failed pod's error includes.
+	failureLogLines = 10
+)
+
+// Options configures a network diagnosis.
+type Options struct {
+	// Namespace is where the diagnostic and peer pods run; empty means DefaultNamespace.
+	Namespace string
+	// Image is the agnhost image; empty means DefaultImage.
+	Image string
+	// ExternalHost is the public name resolved; empty means DefaultExternalHost.
+	ExternalHost string
+	// Registries are the registry base URLs whose /v2/ endpoints are requested.
+	Registries []string
+	// Timeout bounds each wait for pods to start and finish; 0 means DefaultTimeout.
+	Timeout time.Duration
+}
+
+// withDefaults fills unset options.
+func (o Options) withDefaults() Options {
+	if o.Namespace == "" {
+		o.Namespace = DefaultNamespace
+	}
+
+	if o.Image == "" {
+		o.Image = DefaultImage()
+	}
+
+	if o.ExternalHost == "" {
+		o.ExternalHost = DefaultExternalHost
+	}
+
+	if o.Timeout == 0 {
+		o.Timeout = DefaultTimeout
+	}
+
+	return o
+}
+
+// readPodLogs returns the logs of the agnhost container of a pod; a
+// package-level seam because the fake clientset cannot serve custom logs.
+//
+//nolint:gochecknoglobals // Test seam: lets tests return canned check output.
+var readPodLogs = func(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, pod string,
+) (string, error) {
+	raw, err := client.CoreV1().Pods(namespace).
+		GetLogs(pod, &corev1.PodLogOptions{Container: containerName}).
+		DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("read logs of pod %s/%s: %w", namespace, pod, err)
+	}
+
+	return string(raw), nil
+}
+
+// Run starts a peer pod on every Ready node, runs the diagnostic pod against
+// them and returns its results, reporting progress to writer. All pods are
+// deleted afterwards, also when ctx is cancelled. Peers that do not start in
+// time are reported as failed pod-network checks rather than aborting the run.
+func Run(
+	ctx context.Context,
+	client kubernetes.Interface,
+	opts Options,
+	writer io.Writer,
+) (Report, error) {
+	opts = opts.withDefaults()
+
+	nodes, err := readyNodes(ctx, client)
+	if err != nil {
+		return Report{}, err
+	}
+
+	runID := utilrand.String(runIDLength)
+
+	var created []string
+
+	defer func() { deletePods(context.WithoutCancel(ctx), client, opts.Namespace, created, writer) }()
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "starting network peer pods on %d node(s) in namespace %s",
+		Args:    []any{len(nodes), opts.Namespace},
+		Writer:  writer,
+	})
+
+	for i, node := range nodes {
+		pod, err := createPod(ctx, client, BuildPeerPod(opts, runID, i, node))
+		if err != nil {
+			return Report{}, err
+		}
+
+		created = append(created, pod.Name)
+	}
+
+	peers, err := waitForPeers(ctx, client, opts, created, nodes)
+	if err != nil {
+		return Report{}, err
+	}
+
+	diagnostic, err := createPod(ctx, client, BuildDiagnosticPod(opts, runID, peers))
+	if err != nil {
+		return Report{}, err
+	}
+
+	created = append(created, diagnostic.Name)
+
+	notify.WriteMessage(notify.Message{
+		Type:    notify.ActivityType,
+		Content: "running network checks (pod %s/%s)",
+		Args:    []any{diagnostic.Namespace, diagnostic.Name},
+		Writer:  writer,
+	})
+
+	return runDiagnosticPod(ctx, client, opts, diagnostic)
+}
+
+// runDiagnosticPod waits for the diagnostic pod to finish and parses its results.
+func runDiagnosticPod(
+	ctx context.Context,
+	client kubernetes.Interface,
+	opts Options,
+	pod *corev1.Pod,
+) (Report, error) {
+	finished, err := waitForCompletion(ctx, client, opts, pod.Name)
+	if err != nil {
+		return Report{}, err
+	}
+
+	logs, err := readPodLogs(ctx, client, opts.Namespace, pod.Name)
+	if err != nil {
+		return Report{}, err
+	}
+
+	if finished.Status.Phase != corev1.PodSucceeded {
+		return Report{}, fmt.Errorf("%w: %s/%s\n%s",
+			ErrDiagnosticPodFailed, opts.Namespace, pod.Name, tailLines(logs, failureLogLines))
+	}
+
+	results := ParseResults(logs)
+	if len(results) == 0 {
+		return Report{}, fmt.Errorf("%w: %s/%s printed no results",
+			ErrDiagnosticPodFailed, opts.Namespace, pod.Name)
+	}
+
+	return Report{Node: finished.Spec.NodeName, Results: results}, nil
+}
+
+// readyNodes returns the names of the schedulable nodes whose Ready condition is True.
+func readyNodes(ctx context.Context, client kubernetes.Interface) ([]string, error) {
+	list, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	var nodes []string
+
+	for _, node := range list.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				nodes = append(nodes, node.Name)
+
+				break
+			}
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, ErrNoSchedulableNodes
+	}
+
+	return nodes, nil
+}
+
+// createPod creates pod in its namespace.
+func createPod(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) (*corev1.Pod, error) {
+	created, err := client.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	return created, nil
+}
+
+// waitForPeers waits until every peer pod is running with an IP, or the timeout
+// expires, and returns the peers with the IPs known by then.
+func waitForPeers(
+	ctx context.Context,
+	client kubernetes.Interface,
+	opts Options,
+	names, nodes []string,
+) ([]Peer, error) {
+	peers := make([]Peer, len(names))
+	for i := range names {
+		peers[i].Node = nodes[i]
+	}
+
+	err := readiness.PollForReadiness(ctx, opts.Timeout, func(ctx context.Context) (bool, error) {
+		done := true
+
+		for i, name := range names {
+			if peers[i].IP != "" {
+				continue
+			}
+
+			pod, err := client.CoreV1().Pods(opts.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, fmt.Errorf("get peer pod: %w", err)
+			}
+
+			if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+				peers[i].IP = pod.Status.PodIP
+			} else {
+				done = false
+			}
+		}
+
+		return done, nil
+	})
+	// A timeout leaves the missing peers empty so their checks fail with a hint;
+	// only cancellation and API errors abort the run.
+	if err != nil && (ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded)) {
+		return nil, fmt.Errorf("wait for peer pods: %w", err)
+	}
+
+	return peers, nil
+}
+
+// waitForCompletion waits until the named pod has succeeded or failed and
+// returns it.
+func waitForCompletion(
+	ctx context.Context,
+	client kubernetes.Interface,
+	opts Options,
+	name string,
+) (*corev1.Pod, error) {
+	var finished *corev1.Pod
+
+	err := readiness.PollForReadiness(ctx, opts.Timeout, func(ctx context.Context) (bool, error) {
+		pod, err := client.CoreV1().Pods(opts.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("get diagnostic pod: %w", err)
+		}
+
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			finished = pod
+
+			return true, nil
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wait for diagnostic pod: %w", err)
+	}
+
+	return finished, nil
+}
+
+// deletePods removes the named pods, warning on failure.
+func deletePods(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace string,
+	names []string,
+	writer io.Writer,
+) {
+	for _, name := range names {
+		err := client.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil {
+			notify.WriteMessage(notify.Message{
+				Type:    notify.WarningType,
+				Content: "failed to delete network diagnostic pod %s/%s: %v",
+				Args:    []any{namespace, name, err},
+				Writer:  writer,
+			})
+		}
+	}
+}
+
+// tailLines returns the last n lines of text.
+func tailLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,19 @@
+package netdebug
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Dockerfile
+var dockerfile string
+
+// DefaultImage returns the agnhost image the diagnostic and peer pods run.
+func DefaultImage() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+(registry\.k8s\.io/e2e-test-images/agnhost:[^\s]+)`,
+		"agnhost",
+	)
+}
@@ -0,0 +1,235 @@
+package operatordev
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/kustomize"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s/readiness"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ErrCABundleNotInjected is returned when cert-manager does not inject the CA
+// bundle of a CRD's conversion webhook in time.
+var ErrCABundleNotInjected = errors.New("conversion webhook CA bundle was not injected")
+
+const (
+	// InjectCAFromAnnotation is the cert-manager cainjector annotation naming the
+	// Certificate whose CA is injected into a CRD's conversion webhook.
+	InjectCAFromAnnotation = "cert-manager.io/inject-ca-from"
+
+	// fieldManager is the server-side apply field manager of applied CRDs.
+	fieldManager = "ksail"
+)
+
+// LoadCRDs reads the CustomResourceDefinitions below path: the output of
+// kustomize build when path holds a kustomization, or every YAML file in the
+// directory otherwise. Documents of other kinds are skipped.
+func LoadCRDs(ctx context.Context, path string) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	if hasKustomization(path) {
+		built, err := kustomize.NewClient().Build(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("kustomize build %s: %w", path, err)
+		}
+
+		return parseCRDs(built.Bytes())
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("read CRD directory: %w", err)
+	}
+
+	var crds []apiextensionsv1.CustomResourceDefinition
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(path, entry.Name())) //nolint:gosec // path is the user's project
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", entry.Name(), err)
+		}
+
+		parsed, err := parseCRDs(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", entry.Name(), err)
+		}
+
+		crds = append(crds, parsed...)
+	}
+
+	return crds, nil
+}
+
+// parseCRDs extracts CRD objects from a multi-document YAML stream.
+func parseCRDs(data []byte) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	var crds []apiextensionsv1.CustomResourceDefinition
+
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return crds, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read YAML document: %w", err)
+		}
+
+		doc = bytes.TrimSpace(doc)
+		if len(doc) == 0 {
+			continue
+		}
+
+		jsonData, err := yaml.ToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("convert YAML to JSON: %w", err)
+		}
+
+		var meta metav1.TypeMeta
+
+		err = json.Unmarshal(jsonData, &meta)
+		if err != nil || meta.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		crd := apiextensionsv1.CustomResourceDefinition{}
+
+		err = json.Unmarshal(jsonData, &crd)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal CRD: %w", err)
+		}
+
+		crds = append(crds, crd)
+	}
+}
+
+// ApplyCRDs applies crds with server-side apply. Fields the manifests do not
+// set stay with their current managers, so the CA bundle cert-manager injected
+// into a conversion webhook survives a re-apply of a manifest without one.
+func ApplyCRDs(
+	ctx context.Context,
+	client apiextensionsclient.Interface,
+	crds []apiextensionsv1.CustomResourceDefinition,
+) error {
+	force := true
+
+	for index := range crds {
+		crd := &crds[index]
+
+		data, err := json.Marshal(crd)
+		if err != nil {
+			return fmt.Errorf("marshal CRD %s: %w", crd.Name, err)
+		}
+
+		_, err = client.ApiextensionsV1().
+			CustomResourceDefinitions().
+			Patch(ctx, crd.Name, types.ApplyPatchType, data, metav1.PatchOptions{
+				FieldManager: fieldManager,
+				Force:        &force,
+			})
+		if err != nil {
+			return fmt.Errorf("apply CRD %s: %w", crd.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// WaitForCABundles waits until cert-manager has injected the CA bundle into the
+// conversion webhook of every CRD in crds that converts through a webhook and
+// carries the InjectCAFromAnnotation, so conversion requests to the restarted
+// manager pass TLS verification. CRDs without webhook conversion or without
+// the annotation are not waited for. It returns ErrCABundleNotInjected naming
+// the CRDs still without a bundle when timeout elapses.
+func WaitForCABundles(
+	ctx context.Context,
+	client apiextensionsclient.Interface,
+	crds []apiextensionsv1.CustomResourceDefinition,
+	timeout time.Duration,
+) error {
+	pending := make([]string, 0, len(crds))
+
+	for index := range crds {
+		if injectsConversionCA(&crds[index]) {
+			pending = append(pending, crds[index].Name)
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var getErr error
+
+	err := readiness.PollForReadiness(ctx, timeout, func(ctx context.Context) (bool, error) {
+		remaining := pending[:0]
+
+		for _, name := range pending {
+			live, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				getErr = fmt.Errorf("get CRD %s: %w", name, err)
+
+				return false, getErr
+			}
+
+			if !hasConversionCABundle(live) {
+				remaining = append(remaining, name)
+			}
+		}
+
+		pending = remaining
+
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		if getErr != nil {
+			return getErr
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("wait for conversion CA bundles: %w", ctx.Err())
+		}
+
+		return fmt.Errorf("%w into %s: %w", ErrCABundleNotInjected, strings.Join(pending, ", "), err)
+	}
+
+	return nil
+}
+
+// injectsConversionCA reports whether cert-manager injects the CA bundle of
+// crd's conversion webhook.
+func injectsConversionCA(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	conversion := crd.Spec.Conversion
+
+	return conversion != nil &&
+		conversion.Strategy == apiextensionsv1.WebhookConverter &&
+		crd.Annotations[InjectCAFromAnnotation] != ""
+}
+
+// hasConversionCABundle reports whether crd's conversion webhook has a CA bundle.
+func hasConversionCABundle(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	conversion := crd.Spec.Conversion
+	if conversion == nil || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+		return false
+	}
+
+	return len(bytes.TrimSpace(conversion.Webhook.ClientConfig.CABundle)) > 0
+}
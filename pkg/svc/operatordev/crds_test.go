@@ -0,0 +1,171 @@
+package operatordev_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/operatordev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const guestbookCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: guestbooks.webapp.example.com
+spec:
+  group: webapp.example.com
+  names:
+    kind: Guestbook
+    plural: guestbooks
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+`
+
+func TestLoadCRDsFromDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "webapp.example.com_guestbooks.yaml", guestbookCRD)
+	writeFile(t, dir, "notes.txt", "not a manifest")
+	writeFile(t, dir, "namespace.yaml", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: guestbook-system\n")
+
+	crds, err := operatordev.LoadCRDs(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, crds, 1)
+	assert.Equal(t, "guestbooks.webapp.example.com", crds[0].Name)
+}
+
+func TestLoadCRDsFromKustomization(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "bases/webapp.example.com_guestbooks.yaml", guestbookCRD)
+	writeFile(t, dir, "kustomization.yaml", `resources:
+- bases/webapp.example.com_guestbooks.yaml
+commonAnnotations:
+  cert-manager.io/inject-ca-from: guestbook-system/guestbook-serving-cert
+`)
+
+	crds, err := operatordev.LoadCRDs(context.Background(), dir)
+	require.NoError(t, err)
+	require.Len(t, crds, 1)
+	assert.Equal(t,
+		"guestbook-system/guestbook-serving-cert",
+		crds[0].Annotations[operatordev.InjectCAFromAnnotation],
+	)
+}
+
+// webhookCRD returns a CRD converting through a webhook whose CA cert-manager
+// injects, with caBundle as the injected bundle.
+func webhookCRD(caBundle []byte) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiextensions.k8s.io/v1",
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "guestbooks.webapp.example.com",
+			Annotations: map[string]string{
+				operatordev.InjectCAFromAnnotation: "guestbook-system/guestbook-serving-cert",
+			},
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "webapp.example.com",
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig:             &apiextensionsv1.WebhookClientConfig{CABundle: caBundle},
+					ConversionReviewVersions: []string{"v1"},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyCRDsUsesForcedServerSideApply(t *testing.T) {
+	t.Parallel()
+
+	client := apiextensionsfake.NewClientset()
+
+	var patches []k8stesting.PatchActionImpl
+
+	client.PrependReactor("patch", "customresourcedefinitions",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patchAction, ok := action.(k8stesting.PatchActionImpl)
+			require.True(t, ok)
+
+			patches = append(patches, patchAction)
+
+			return true, &apiextensionsv1.CustomResourceDefinition{}, nil
+		})
+
+	err := operatordev.ApplyCRDs(
+		context.Background(), client, []apiextensionsv1.CustomResourceDefinition{*webhookCRD(nil)},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, patches, 1)
+	assert.Equal(t, "guestbooks.webapp.example.com", patches[0].GetName())
+	assert.Equal(t, types.ApplyPatchType, patches[0].GetPatchType())
+	assert.Equal(t, "ksail", patches[0].PatchOptions.FieldManager)
+	require.NotNil(t, patches[0].PatchOptions.Force)
+	assert.True(t, *patches[0].PatchOptions.Force)
+	assert.Contains(t, string(patches[0].GetPatch()), `"kind":"CustomResourceDefinition"`)
+}
+
+func TestWaitForCABundlesReturnsOnceInjected(t *testing.T) {
+	t.Parallel()
+
+	client := apiextensionsfake.NewClientset(webhookCRD([]byte("injected-ca")))
+
+	err := operatordev.WaitForCABundles(
+		context.Background(), client,
+		[]apiextensionsv1.CustomResourceDefinition{*webhookCRD(nil)},
+		time.Second,
+	)
+	require.NoError(t, err)
+}
+
+func TestWaitForCABundlesTimesOutOnPlaceholderBundle(t *testing.T) {
+	t.Parallel()
+
+	// Older kubebuilder patches ship a "Cg==" (newline) placeholder bundle.
+	client := apiextensionsfake.NewClientset(webhookCRD([]byte("\n")))
+
+	err := operatordev.WaitForCABundles(
+		context.Background(), client,
+		[]apiextensionsv1.CustomResourceDefinition{*webhookCRD(nil)},
+		10*time.Millisecond,
+	)
+	require.ErrorIs(t, err, operatordev.ErrCABundleNotInjected)
+	assert.Contains(t, err.Error(), "guestbooks.webapp.example.com")
+}
+
+func TestWaitForCABundlesSkipsCRDsWithoutInjection(t *testing.T) {
+	t.Parallel()
+
+	crd := webhookCRD(nil)
+	crd.Annotations = nil
+
+	// No live CRD exists: a CRD cert-manager does not inject into is not looked up.
+	err := operatordev.WaitForCABundles(
+		context.Background(), apiextensionsfake.NewClientset(),
+		[]apiextensionsv1.CustomResourceDefinition{*crd},
+		time.Second,
+	)
+	require.NoError(t, err)
+}
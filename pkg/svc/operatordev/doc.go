@@ -0,0 +1,22 @@
+// Package operatordev drives the inner loop of `ksail workload dev --operator`:
+// iterating on a Kubernetes controller scaffolded by kubebuilder or the
+// Operator SDK against a local cluster.
+//
+// A change to the project falls into one of two kinds:
+//
+//   - CRD changes ([ChangeCRDs]) below config/crd, typically regenerated by
+//     `make manifests`. They are re-applied with server-side apply
+//     ([ApplyCRDs]), leaving fields other managers own — such as the
+//     conversion webhook CA bundle cert-manager's cainjector maintains —
+//     in place. [WaitForCABundles] then holds off until cert-manager has
+//     (re)injected the CA of CRDs that convert through a webhook, so the
+//     restarted manager is not served conversion requests that fail TLS
+//     verification.
+//   - Code changes ([ChangeCode]) anywhere else. The caller rebuilds the
+//     manager image and loads it into the cluster, then [RestartManager]
+//     points the manager Deployment at it and rolls it.
+//
+// [DetectProject] reads the project layout and the PROJECT file to default the
+// manager Deployment to the kubebuilder naming convention
+// (<projectName>-controller-manager in <projectName>-system).
+package operatordev
@@ -0,0 +1,197 @@
+package operatordev
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ErrNoDockerfile is returned when the project directory has no Dockerfile to
+// build the manager image from.
+var ErrNoDockerfile = errors.New("controller project has no Dockerfile")
+
+const (
+	// ProjectFile is the kubebuilder and Operator SDK project metadata file.
+	ProjectFile = "PROJECT"
+	// DefaultContainer is the manager container of a kubebuilder Deployment.
+	DefaultContainer = "manager"
+
+	configDir   = "config"
+	crdDir      = "crd"
+	crdBasesDir = "bases"
+	dockerfile  = "Dockerfile"
+)
+
+// ignoredDirs are project directories whose changes never affect the manager:
+// downloaded tool binaries, VCS metadata and editor state.
+//
+//nolint:gochecknoglobals // immutable lookup table
+var ignoredDirs = map[string]bool{
+	"bin":     true,
+	".git":    true,
+	".idea":   true,
+	".vscode": true,
+}
+
+// Project describes a controller project on disk and the manager Deployment it
+// runs as in the cluster.
+type Project struct {
+	// Dir is the project root, the build context of the manager image.
+	Dir string
+	// Name is the projectName of the PROJECT file, or the directory name.
+	Name string
+	// CRDPath is the kustomization or directory the CRDs are applied from, or
+	// empty when the project defines no CRDs.
+	CRDPath string
+	// Namespace is the namespace of the manager Deployment.
+	Namespace string
+	// Deployment is the name of the manager Deployment.
+	Deployment string
+}
+
+// DetectProject reads the controller project in dir. CRDs are applied from the
+// config/crd kustomization when there is one, so the conversion webhook and
+// cert-manager CA injection patches of the project are kept, or from the
+// generated config/crd/bases otherwise.
+func DetectProject(dir string) (*Project, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve project directory %q: %w", dir, err)
+	}
+
+	_, err = os.Stat(filepath.Join(absDir, dockerfile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNoDockerfile, absDir)
+		}
+
+		return nil, fmt.Errorf("access Dockerfile: %w", err)
+	}
+
+	name, err := projectName(absDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Project{
+		Dir:        absDir,
+		Name:       name,
+		CRDPath:    crdPath(absDir),
+		Namespace:  name + "-system",
+		Deployment: name + "-controller-manager",
+	}, nil
+}
+
+// projectName returns the projectName of the PROJECT file in dir, falling back
+// to the directory name when there is no PROJECT file or it sets no name.
+func projectName(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ProjectFile)) //nolint:gosec // path is the user's project
+	if err != nil {
+		if os.IsNotExist(err) {
+			return filepath.Base(dir), nil
+		}
+
+		return "", fmt.Errorf("read %s: %w", ProjectFile, err)
+	}
+
+	var metadata struct {
+		ProjectName string `json:"projectName"`
+	}
+
+	err = yaml.Unmarshal(data, &metadata)
+	if err != nil {
+		return "", fmt.Errorf("parse %s: %w", ProjectFile, err)
+	}
+
+	if metadata.ProjectName == "" {
+		return filepath.Base(dir), nil
+	}
+
+	return metadata.ProjectName, nil
+}
+
+// crdPath returns the path CRDs are applied from, or "" when there is none.
+func crdPath(dir string) string {
+	kustomizeDir := filepath.Join(dir, configDir, crdDir)
+	if hasKustomization(kustomizeDir) {
+		return kustomizeDir
+	}
+
+	basesDir := filepath.Join(kustomizeDir, crdBasesDir)
+
+	info, err := os.Stat(basesDir)
+	if err == nil && info.IsDir() {
+		return basesDir
+	}
+
+	return ""
+}
+
+// hasKustomization reports whether dir holds a kustomization file.
+func hasKustomization(dir string) bool {
+	for _, name := range []string{"kustomization.yaml", "kustomization.yml", "Kustomization"} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Change is the kind of work a changed file calls for. Kinds combine as bits.
+type Change uint8
+
+const (
+	// ChangeCRDs means the CRDs must be re-applied.
+	ChangeCRDs Change = 1 << iota
+	// ChangeCode means the manager image must be rebuilt and restarted.
+	ChangeCode
+)
+
+// ChangeNone means the file does not affect the running controller.
+const ChangeNone Change = 0
+
+// Has reports whether c includes kind.
+func (c Change) Has(kind Change) bool {
+	return c&kind != 0
+}
+
+// Classify returns the work a change to file (absolute or relative to the
+// project root) calls for. Files below config/crd change the CRDs; other
+// manifests below config/ are deployment configuration the loop does not
+// re-apply; test files, tool binaries and VCS or editor state are ignored;
+// anything else is manager source.
+func (p *Project) Classify(file string) Change {
+	rel := file
+	if filepath.IsAbs(file) {
+		var err error
+
+		rel, err = filepath.Rel(p.Dir, file)
+		if err != nil {
+			return ChangeNone
+		}
+	}
+
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return ChangeNone
+	}
+
+	first, _, _ := strings.Cut(rel, "/")
+
+	switch {
+	case ignoredDirs[first], strings.HasSuffix(rel, "_test.go"):
+		return ChangeNone
+	case rel == configDir+"/"+crdDir || strings.HasPrefix(rel, configDir+"/"+crdDir+"/"):
+		return ChangeCRDs
+	case first == configDir:
+		return ChangeNone
+	default:
+		return ChangeCode
+	}
+}
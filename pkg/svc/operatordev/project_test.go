@@ -0,0 +1,106 @@
+package operatordev_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/operatordev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes content to name below dir, creating parent directories.
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestDetectProjectReadsKubebuilderLayout(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "Dockerfile", "FROM scratch\n")
+	writeFile(t, dir, "PROJECT", "domain: example.com\nprojectName: guestbook\nversion: \"3\"\n")
+	writeFile(t, dir, "config/crd/kustomization.yaml", "resources:\n- bases/webapp.example.com_guestbooks.yaml\n")
+
+	project, err := operatordev.DetectProject(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "guestbook", project.Name)
+	assert.Equal(t, "guestbook-system", project.Namespace)
+	assert.Equal(t, "guestbook-controller-manager", project.Deployment)
+	assert.Equal(t, filepath.Join(dir, "config", "crd"), project.CRDPath)
+}
+
+func TestDetectProjectFallsBackToDirectoryNameAndBases(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "memcached-operator")
+	writeFile(t, dir, "Dockerfile", "FROM scratch\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "config", "crd", "bases"), 0o750))
+
+	project, err := operatordev.DetectProject(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "memcached-operator", project.Name)
+	assert.Equal(t, filepath.Join(dir, "config", "crd", "bases"), project.CRDPath)
+}
+
+func TestDetectProjectWithoutCRDs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "Dockerfile", "FROM scratch\n")
+
+	project, err := operatordev.DetectProject(dir)
+	require.NoError(t, err)
+	assert.Empty(t, project.CRDPath)
+}
+
+func TestDetectProjectRequiresDockerfile(t *testing.T) {
+	t.Parallel()
+
+	_, err := operatordev.DetectProject(t.TempDir())
+	require.ErrorIs(t, err, operatordev.ErrNoDockerfile)
+}
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	project := &operatordev.Project{Dir: "/src/guestbook"}
+
+	tests := []struct {
+		file string
+		want operatordev.Change
+	}{
+		{"/src/guestbook/internal/controller/guestbook_controller.go", operatordev.ChangeCode},
+		{"api/v1/guestbook_types.go", operatordev.ChangeCode},
+		{"go.mod", operatordev.ChangeCode},
+		{"/src/guestbook/config/crd/bases/webapp.example.com_guestbooks.yaml", operatordev.ChangeCRDs},
+		{"config/crd/patches/webhook_in_guestbooks.yaml", operatordev.ChangeCRDs},
+		{"config/rbac/role.yaml", operatordev.ChangeNone},
+		{"internal/controller/guestbook_controller_test.go", operatordev.ChangeNone},
+		{"bin/controller-gen", operatordev.ChangeNone},
+		{".git/index", operatordev.ChangeNone},
+		{"/src/other/main.go", operatordev.ChangeNone},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, project.Classify(test.file), test.file)
+	}
+}
+
+func TestChangeHas(t *testing.T) {
+	t.Parallel()
+
+	both := operatordev.ChangeCRDs | operatordev.ChangeCode
+
+	assert.True(t, both.Has(operatordev.ChangeCRDs))
+	assert.True(t, both.Has(operatordev.ChangeCode))
+	assert.False(t, operatordev.ChangeCRDs.Has(operatordev.ChangeCode))
+	assert.False(t, operatordev.ChangeNone.Has(operatordev.ChangeCRDs))
+}
@@ -0,0 +1,68 @@
+package operatordev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrContainerNotFound is returned when the manager Deployment has no container
+// of the requested name.
+var ErrContainerNotFound = errors.New("manager container not found")
+
+// ManagerContainer returns the container of the manager Deployment named
+// container, so the loop can rebuild the image it already runs.
+func ManagerContainer(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, deployment, container string,
+) (*corev1.Container, error) {
+	live, err := client.AppsV1().Deployments(namespace).Get(ctx, deployment, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get manager deployment %s/%s: %w", namespace, deployment, err)
+	}
+
+	containers := live.Spec.Template.Spec.Containers
+	for index := range containers {
+		if containers[index].Name == container {
+			return &containers[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q in %s/%s", ErrContainerNotFound, container, namespace, deployment)
+}
+
+// RestartManager points the container of the manager Deployment at image and
+// rolls the Deployment, the way `kubectl set image` followed by
+// `kubectl rollout restart` does, in a single strategic-merge patch. The
+// restart annotation makes the roll happen even when image is unchanged, so
+// pods pick up an image rebuilt and loaded under the same tag.
+func RestartManager(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, deployment, container, image string,
+) error {
+	patch := fmt.Appendf(nil,
+		`{"spec":{"template":{"metadata":{"annotations":{%q:%q}},"spec":{"containers":[{"name":%q,"image":%q}]}}}}`,
+		k8s.RolloutRestartAnnotation,
+		time.Now().Format(time.RFC3339Nano),
+		container,
+		image,
+	)
+
+	_, err := client.AppsV1().Deployments(namespace).Patch(
+		ctx, deployment, types.StrategicMergePatchType, patch, metav1.PatchOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("restart manager deployment %s/%s: %w", namespace, deployment, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package operatordev_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/operatordev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newManagerDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook-controller-manager", Namespace: "guestbook-system"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "kube-rbac-proxy", Image: "kube-rbac-proxy:v0.16.0"},
+						{Name: "manager", Image: "controller:dev"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestManagerContainer(t *testing.T) {
+	t.Parallel()
+
+	client := k8sfake.NewClientset(newManagerDeployment())
+
+	container, err := operatordev.ManagerContainer(
+		context.Background(), client, "guestbook-system", "guestbook-controller-manager", "manager",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "controller:dev", container.Image)
+
+	_, err = operatordev.ManagerContainer(
+		context.Background(), client, "guestbook-system", "guestbook-controller-manager", "missing",
+	)
+	require.ErrorIs(t, err, operatordev.ErrContainerNotFound)
+}
+
+func TestRestartManagerSetsImageAndRestartAnnotation(t *testing.T) {
+	t.Parallel()
+
+	client := k8sfake.NewClientset(newManagerDeployment())
+
+	err := operatordev.RestartManager(
+		context.Background(), client,
+		"guestbook-system", "guestbook-controller-manager", "manager", "localhost:5000/controller:dev",
+	)
+	require.NoError(t, err)
+
+	live, err := client.AppsV1().Deployments("guestbook-system").Get(
+		context.Background(), "guestbook-controller-manager", metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+
+	containers := live.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2, "containers are merged by name")
+	assert.Equal(t, "kube-rbac-proxy:v0.16.0", containers[0].Image)
+	assert.Equal(t, "localhost:5000/controller:dev", containers[1].Image)
+	assert.NotEmpty(t, live.Spec.Template.Annotations[k8s.RolloutRestartAnnotation])
+}
@@ -0,0 +1,8 @@
+// Package opmetrics exposes the state of long-running KSail commands as
+// Prometheus metrics.
+//
+// A [Recorder] tracks cluster health and control-plane readiness from
+// `ksail cluster status --watch`, and reconcile durations and operation
+// counters from `ksail workload watch`. [Serve] publishes them on /metrics so
+// a shared dev cluster can be scraped by an existing Prometheus stack.
+package opmetrics
@@ -0,0 +1,227 @@
+package opmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterstatus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsPath is the endpoint serving the metrics in the Prometheus text format.
+const MetricsPath = "/metrics"
+
+// Operation results recorded in the result label of ksail_operations_total.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// statusOperation labels the health checks of `cluster status --watch`.
+const statusOperation = "status"
+
+const (
+	namespace            = "ksail"
+	readHeaderTimeout    = 10 * time.Second
+	serveShutdownTimeout = 5 * time.Second
+)
+
+// Recorder collects the metrics of one long-running command. A nil *Recorder
+// is valid and records nothing, so callers need not check whether metrics are
+// enabled.
+type Recorder struct {
+	registry          *prometheus.Registry
+	clusterHealth     *prometheus.GaugeVec
+	componentReady    *prometheus.GaugeVec
+	nodesReady        *prometheus.GaugeVec
+	lastCheck         *prometheus.GaugeVec
+	reconcileDuration *prometheus.HistogramVec
+	operations        *prometheus.CounterVec
+}
+
+// NewRecorder returns a Recorder with its own registry, so only KSail metrics
+// (and the Go and process collectors) are exposed.
+func NewRecorder() *Recorder {
+	recorder := &Recorder{
+		registry: prometheus.NewRegistry(),
+		clusterHealth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "cluster_health",
+			Help:      "1 for the cluster's current health verdict (Healthy, Degraded, Unhealthy), 0 for the others.",
+		}, []string{"cluster", "health"}),
+		componentReady: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "component_ready",
+			Help:      "1 when every pod of a control-plane component is Ready, 0 otherwise.",
+		}, []string{"cluster", "component"}),
+		nodesReady: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "nodes_ready",
+			Help:      "Number of Ready nodes.",
+		}, []string{"cluster"}),
+		lastCheck: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "cluster_last_check_timestamp_seconds",
+			Help:      "Unix time of the last successful health check.",
+		}, []string{"cluster"}),
+		reconcileDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of reconcile operations, such as a watch-triggered apply.",
+			Buckets:   prometheus.ExponentialBuckets(0.25, 2, 10), //nolint:mnd // 0.25s to ~2m
+		}, []string{"operation", "result"}),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "operations_total",
+			Help:      "Number of operations run, by operation and result.",
+		}, []string{"operation", "result"}),
+	}
+
+	recorder.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		recorder.clusterHealth,
+		recorder.componentReady,
+		recorder.nodesReady,
+		recorder.lastCheck,
+		recorder.reconcileDuration,
+		recorder.operations,
+	)
+
+	return recorder
+}
+
+// ObserveStatus records the health verdict, node readiness, and control-plane
+// component readiness of report. Components missing from report are dropped,
+// so a renamed or removed component does not linger as a stale series.
+func (r *Recorder) ObserveStatus(report clusterstatus.Report) {
+	if r == nil {
+		return
+	}
+
+	cluster := report.ClusterName
+
+	for _, health := range []clusterstatus.Health{
+		clusterstatus.HealthHealthy, clusterstatus.HealthDegraded, clusterstatus.HealthUnhealthy,
+	} {
+		r.clusterHealth.WithLabelValues(cluster, string(health)).Set(gaugeValue(report.Health == health))
+	}
+
+	readyNodes := 0
+
+	for _, node := range report.Nodes {
+		if node.Ready {
+			readyNodes++
+		}
+	}
+
+	r.nodesReady.WithLabelValues(cluster).Set(float64(readyNodes))
+
+	r.componentReady.DeletePartialMatch(prometheus.Labels{"cluster": cluster})
+
+	for _, component := range report.ControlPlane {
+		r.componentReady.WithLabelValues(cluster, component.Name).Set(gaugeValue(component.Ready()))
+	}
+
+	r.lastCheck.WithLabelValues(cluster).Set(float64(report.CheckedAt.Unix()))
+	r.operations.WithLabelValues(statusOperation, ResultSuccess).Inc()
+}
+
+// ObserveStatusError counts a health check that could not collect a report.
+func (r *Recorder) ObserveStatusError() {
+	if r == nil {
+		return
+	}
+
+	r.operations.WithLabelValues(statusOperation, ResultFailure).Inc()
+}
+
+// ObserveReconcile records how long a reconcile operation took and counts it
+// under its result.
+func (r *Recorder) ObserveReconcile(operation string, elapsed time.Duration, err error) {
+	if r == nil {
+		return
+	}
+
+	r.reconcileDuration.WithLabelValues(operation, result(err)).Observe(elapsed.Seconds())
+	r.CountOperation(operation, err)
+}
+
+// CountOperation counts one run of operation under the result err implies.
+func (r *Recorder) CountOperation(operation string, err error) {
+	if r == nil {
+		return
+	}
+
+	r.operations.WithLabelValues(operation, result(err)).Inc()
+}
+
+// Handler returns the routes of the metrics listener: GET MetricsPath serves
+// the metrics, and GET /healthz reports liveness.
+func (r *Recorder) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("GET "+MetricsPath, promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("GET /healthz", func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte("ok\n"))
+	})
+
+	return mux
+}
+
+// Listen binds the metrics listener to addr.
+func Listen(ctx context.Context, addr string) (net.Listener, error) {
+	var listenConfig net.ListenConfig
+
+	listener, err := listenConfig.Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	return listener, nil
+}
+
+// Serve runs the recorder's handler on listener until ctx is cancelled.
+func (r *Recorder) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{
+		Handler:           r.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	err := server.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("metrics listener: %w", err)
+	}
+
+	return nil
+}
+
+func result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+
+	return ResultSuccess
+}
+
+func gaugeValue(condition bool) float64 {
+	if condition {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,134 @@
+package opmetrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/clusterstatus"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/opmetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errApply = errors.New("apply failed")
+
+func scrape(t *testing.T, recorder *opmetrics.Recorder) string {
+	t.Helper()
+
+	server := httptest.NewServer(recorder.Handler())
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+opmetrics.MetricsPath, nil)
+	require.NoError(t, err)
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestRecorder_ObserveStatus(t *testing.T) {
+	t.Parallel()
+
+	recorder := opmetrics.NewRecorder()
+	report := clusterstatus.Report{
+		ClusterName: "dev",
+		Health:      clusterstatus.HealthDegraded,
+		CheckedAt:   time.Unix(1700000000, 0),
+		Nodes: []clusterstatus.NodeStatus{
+			{Name: "dev-control-plane", Ready: true},
+			{Name: "dev-worker", Ready: false},
+		},
+		ControlPlane: []clusterstatus.ComponentHealth{
+			{Name: "kube-apiserver", ReadyPods: 1, TotalPods: 1},
+			{Name: "etcd", ReadyPods: 0, TotalPods: 1},
+		},
+	}
+
+	recorder.ObserveStatus(report)
+
+	report.ControlPlane = report.ControlPlane[:1]
+	recorder.ObserveStatus(report)
+	recorder.ObserveStatusError()
+
+	body := scrape(t, recorder)
+
+	assert.Contains(t, body, `ksail_cluster_health{cluster="dev",health="Degraded"} 1`)
+	assert.Contains(t, body, `ksail_cluster_health{cluster="dev",health="Healthy"} 0`)
+	assert.Contains(t, body, `ksail_nodes_ready{cluster="dev"} 1`)
+	assert.Contains(t, body, `ksail_component_ready{cluster="dev",component="kube-apiserver"} 1`)
+	assert.NotContains(t, body, `component="etcd"`, "components missing from the latest report are dropped")
+	assert.Contains(t, body, `ksail_cluster_last_check_timestamp_seconds{cluster="dev"} 1.7e+09`)
+	assert.Contains(t, body, `ksail_operations_total{operation="status",result="success"} 2`)
+	assert.Contains(t, body, `ksail_operations_total{operation="status",result="failure"} 1`)
+}
+
+func TestRecorder_ObserveReconcile(t *testing.T) {
+	t.Parallel()
+
+	recorder := opmetrics.NewRecorder()
+
+	recorder.ObserveReconcile("apply", 2*time.Second, nil)
+	recorder.ObserveReconcile("apply", time.Second, errApply)
+
+	body := scrape(t, recorder)
+
+	assert.Contains(t, body, `ksail_reconcile_duration_seconds_count{operation="apply",result="success"} 1`)
+	assert.Contains(t, body, `ksail_reconcile_duration_seconds_sum{operation="apply",result="failure"} 1`)
+	assert.Contains(t, body, `ksail_operations_total{operation="apply",result="failure"} 1`)
+}
+
+func TestRecorder_NilRecordsNothing(t *testing.T) {
+	t.Parallel()
+
+	var recorder *opmetrics.Recorder
+
+	assert.NotPanics(t, func() {
+		recorder.ObserveStatus(clusterstatus.Report{ClusterName: "dev"})
+		recorder.ObserveStatusError()
+		recorder.ObserveReconcile("apply", time.Second, nil)
+		recorder.CountOperation("apply", errApply)
+	})
+}
+
+func TestRecorder_Serve(t *testing.T) {
+	t.Parallel()
+
+	recorder := opmetrics.NewRecorder()
+
+	listener, err := opmetrics.Listen(t.Context(), "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	done := make(chan error, 1)
+
+	go func() { done <- recorder.Serve(ctx, listener) }()
+
+	req, err := http.NewRequestWithContext(
+		t.Context(), http.MethodGet, "http://"+listener.Addr().String()+"/healthz", nil,
+	)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	require.NoError(t, <-done, "cancelling the context shuts the listener down cleanly")
+}
@@ -287,6 +287,8 @@ func agentPoolInfos(
 			Role:        nodeRoleWorker,
 			State:       derefString(pool.ProvisioningState),
 			ServerType:  derefString(pool.VMSize),
+			Spot: pool.ScaleSetPriority != nil &&
+				*pool.ScaleSetPriority == armcontainerservice.ScaleSetPrioritySpot,
 		})
 	}
 
@@ -202,6 +202,25 @@ func TestListNodesCollapsesAgentPools(t *testing.T) {
 	assert.Equal(t, "Creating", nodes[1].State)
 }
 
+func TestListNodesReportsSpotAgentPools(t *testing.T) {
+	t.Parallel()
+
+	spotPool := agentPool("spot", 3, "Succeeded")
+	spotPool.ScaleSetPriority = new(armcontainerservice.ScaleSetPrioritySpot)
+
+	fake := &fakeClusterClient{clusters: []*armcontainerservice.ManagedCluster{
+		managedCluster(testCluster, agentPool("system", 1, "Succeeded"), spotPool),
+	}}
+	prov := newProvider(t, fake, testResourceGroup)
+
+	nodes, err := prov.ListNodes(context.Background(), testCluster)
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+
+	assert.False(t, nodes[0].Spot)
+	assert.True(t, nodes[1].Spot)
+}
+
 // TestListNodesResolvesResourceGroupWhenUnconfigured pins the ARM-ID
 // resolution: with an empty resource group the provider finds the cluster in
 // a subscription-wide list and targets the resource group parsed from its ID.
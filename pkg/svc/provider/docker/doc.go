@@ -6,6 +6,11 @@
 //   - K3d: Uses container labels "k3d.cluster" and "k3d.role"
 //   - Talos: Uses container labels "talos.cluster.name" and "talos.type"
 //
+// It also manages the HAProxy load balancer container that fronts the Kubernetes API
+// of multi-control-plane clusters whose distribution has no load balancer of its own
+// (Talos). Load balancers carry the "ksail.io/cluster" and "ksail.io/role" labels and
+// are never listed as nodes.
+//
 // This provider is used by provisioners to perform infrastructure operations
 // while the provisioners handle distribution-specific configuration.
 package docker
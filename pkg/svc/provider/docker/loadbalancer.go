@@ -0,0 +1,373 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+// Load balancer label constants. KSail-managed load balancers are identified by
+// labels rather than by a distribution's label scheme, so they are never listed
+// as cluster nodes.
+const (
+	LabelKSailCluster = "ksail.io/cluster"
+	LabelKSailRole    = "ksail.io/role"
+)
+
+const (
+	// roleLoadBalancer is the ksail.io/role value of a control-plane load balancer.
+	roleLoadBalancer = "load-balancer"
+	// loadBalancerPort is the Kubernetes API port the load balancer listens on and
+	// forwards to on every control-plane node.
+	loadBalancerPort = 6443
+	// loadBalancerConfigEnv carries the rendered HAProxy configuration into the
+	// container, which writes it to disk before starting HAProxy.
+	loadBalancerConfigEnv = "HAPROXY_CFG"
+	// loadBalancerHostIP is the host address the Kubernetes API port is published on.
+	loadBalancerHostIP = "127.0.0.1"
+)
+
+var (
+	// ErrNoLoadBalancerBackends is returned when a load balancer is requested without
+	// any control-plane addresses to forward to.
+	ErrNoLoadBalancerBackends = errors.New("load balancer requires at least one control-plane backend")
+	// ErrLoadBalancerNotFound is returned when a cluster has no KSail-managed load balancer.
+	ErrLoadBalancerNotFound = errors.New("load balancer not found")
+	// ErrLoadBalancerPortNotMapped is returned when the load balancer's Kubernetes API
+	// port is not published on the host.
+	ErrLoadBalancerPortNotMapped = errors.New("load balancer API port is not published on the host")
+)
+
+// LoadBalancerSpec describes the HAProxy container that fronts the Kubernetes API of
+// a multi-control-plane cluster.
+type LoadBalancerSpec struct {
+	// ClusterName is the cluster the load balancer belongs to.
+	ClusterName string
+	// Network is the Docker network the control-plane nodes are attached to.
+	Network string
+	// IP is the static address of the load balancer on Network. It must match the
+	// control-plane endpoint baked into the node configs; empty lets Docker assign one.
+	IP string
+	// Backends are the control-plane node addresses on Network.
+	Backends []string
+	// HostPort pins the host port the Kubernetes API is published on. Empty picks a
+	// random port, or keeps the current one when the load balancer is recreated.
+	HostPort string
+}
+
+// LoadBalancerContainerName returns the name of the load balancer container of clusterName.
+func LoadBalancerContainerName(clusterName string) string {
+	return clusterName + "-lb"
+}
+
+// EnsureLoadBalancer creates and starts the load balancer described by spec and returns
+// the host endpoint (127.0.0.1:<port>) its Kubernetes API port is published on. An
+// existing load balancer is started if stopped, and recreated on the same host port when
+// its backends changed, so kubeconfigs pointing at it keep working across scaling.
+func (p *Provider) EnsureLoadBalancer(ctx context.Context, spec LoadBalancerSpec) (string, error) {
+	if p.client == nil {
+		return "", provider.ErrProviderUnavailable
+	}
+
+	if len(spec.Backends) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNoLoadBalancerBackends, spec.ClusterName)
+	}
+
+	config := haproxyConfig(spec.Backends)
+
+	existing, err := p.findLoadBalancer(ctx, spec.ClusterName)
+	if err != nil {
+		return "", err
+	}
+
+	hostPort := spec.HostPort
+
+	if existing != nil {
+		inspect, inspectErr := p.client.ContainerInspect(ctx, existing.ID)
+		if inspectErr != nil {
+			return "", fmt.Errorf("failed to inspect load balancer: %w", inspectErr)
+		}
+
+		if inspect.Config != nil &&
+			slices.Contains(inspect.Config.Env, loadBalancerConfigEnv+"="+config) {
+			return p.startExistingLoadBalancer(ctx, *existing)
+		}
+
+		if hostPort == "" {
+			hostPort = publishedHostPort(inspect)
+		}
+
+		err = p.removeContainer(ctx, existing.ID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = p.createLoadBalancer(ctx, spec, config, hostPort)
+	if err != nil {
+		return "", err
+	}
+
+	return p.LoadBalancerEndpoint(ctx, spec.ClusterName)
+}
+
+// LoadBalancerEndpoint returns the host endpoint (127.0.0.1:<port>) of the Kubernetes
+// API published by the load balancer of clusterName. It returns ErrLoadBalancerNotFound
+// when the cluster has no load balancer.
+func (p *Provider) LoadBalancerEndpoint(ctx context.Context, clusterName string) (string, error) {
+	if p.client == nil {
+		return "", provider.ErrProviderUnavailable
+	}
+
+	existing, err := p.findLoadBalancer(ctx, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	if existing == nil {
+		return "", fmt.Errorf("%w: %s", ErrLoadBalancerNotFound, clusterName)
+	}
+
+	inspect, err := p.client.ContainerInspect(ctx, existing.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect load balancer: %w", err)
+	}
+
+	hostPort := publishedHostPort(inspect)
+	if hostPort == "" {
+		return "", fmt.Errorf("%w: %s", ErrLoadBalancerPortNotMapped, clusterName)
+	}
+
+	return net.JoinHostPort(loadBalancerHostIP, hostPort), nil
+}
+
+// StartLoadBalancer starts the load balancer of clusterName. It is a no-op when the
+// cluster has no load balancer.
+func (p *Provider) StartLoadBalancer(ctx context.Context, clusterName string) error {
+	return p.withLoadBalancer(ctx, clusterName, DefaultStartTimeout, p.startContainer)
+}
+
+// StopLoadBalancer stops the load balancer of clusterName. It is a no-op when the
+// cluster has no load balancer.
+func (p *Provider) StopLoadBalancer(ctx context.Context, clusterName string) error {
+	return p.withLoadBalancer(ctx, clusterName, DefaultStopTimeout, p.stopContainer)
+}
+
+// DeleteLoadBalancer removes the load balancer of clusterName. It is a no-op when the
+// cluster has no load balancer. Cluster deletion calls it before the cluster network
+// is removed, which fails while containers are still attached.
+func (p *Provider) DeleteLoadBalancer(ctx context.Context, clusterName string) error {
+	if p.client == nil {
+		return provider.ErrProviderUnavailable
+	}
+
+	existing, err := p.findLoadBalancer(ctx, clusterName)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	return p.removeContainer(ctx, existing.ID)
+}
+
+// withLoadBalancer runs operation on the load balancer of clusterName, if any.
+func (p *Provider) withLoadBalancer(
+	ctx context.Context,
+	clusterName string,
+	timeout time.Duration,
+	operation nodeOperation,
+) error {
+	if p.client == nil {
+		return provider.ErrProviderUnavailable
+	}
+
+	existing, err := p.findLoadBalancer(ctx, clusterName)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return operation(timeoutCtx, LoadBalancerContainerName(clusterName))
+}
+
+// findLoadBalancer returns the load balancer container of clusterName, or nil if none exists.
+func (p *Provider) findLoadBalancer(
+	ctx context.Context,
+	clusterName string,
+) (*container.Summary, error) {
+	containers, err := p.listContainersByLabels(ctx,
+		filters.Arg("label", LabelKSailCluster+"="+clusterName),
+		filters.Arg("label", LabelKSailRole+"="+roleLoadBalancer),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(containers) == 0 {
+		return nil, nil //nolint:nilnil // absence is not an error for idempotent callers
+	}
+
+	return &containers[0], nil
+}
+
+// startExistingLoadBalancer starts an up-to-date load balancer when it is not running
+// and returns its host endpoint.
+func (p *Provider) startExistingLoadBalancer(
+	ctx context.Context,
+	existing container.Summary,
+) (string, error) {
+	clusterName := existing.Labels[LabelKSailCluster]
+
+	if !strings.EqualFold(existing.State, "running") {
+		err := p.startContainer(ctx, LoadBalancerContainerName(clusterName))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return p.LoadBalancerEndpoint(ctx, clusterName)
+}
+
+// createLoadBalancer pulls the HAProxy image when missing, then creates and starts the
+// load balancer container. An empty hostPort publishes the API port on a random host port.
+func (p *Provider) createLoadBalancer(
+	ctx context.Context,
+	spec LoadBalancerSpec,
+	config, hostPort string,
+) error {
+	imageName := LoadBalancerImage()
+
+	_, err := p.client.ImageInspect(ctx, imageName)
+	if err != nil {
+		err = dockerclient.PullImage(ctx, p.client, imageName)
+		if err != nil {
+			return fmt.Errorf("failed to pull load balancer image: %w", err)
+		}
+	}
+
+	apiPort := nat.Port(strconv.Itoa(loadBalancerPort) + "/tcp")
+
+	containerConfig := &container.Config{
+		Image: imageName,
+		// The official image runs as an unprivileged user, so the config is written
+		// to /tmp rather than the root-owned default location.
+		Cmd: []string{
+			"sh", "-c",
+			`printf '%s' "$` + loadBalancerConfigEnv + `" > /tmp/haproxy.cfg && ` +
+				"exec haproxy -W -db -f /tmp/haproxy.cfg",
+		},
+		Env:          []string{loadBalancerConfigEnv + "=" + config},
+		ExposedPorts: nat.PortSet{apiPort: struct{}{}},
+		Labels: map[string]string{
+			LabelKSailCluster:              spec.ClusterName,
+			LabelKSailRole:                 roleLoadBalancer,
+			"app.kubernetes.io/managed-by": "ksail",
+		},
+	}
+
+	hostConfig := &container.HostConfig{
+		RestartPolicy: container.RestartPolicy{
+			Name: container.RestartPolicyUnlessStopped,
+		},
+		PortBindings: nat.PortMap{
+			apiPort: {{HostIP: loadBalancerHostIP, HostPort: hostPort}},
+		},
+	}
+
+	endpoint := &network.EndpointSettings{}
+	if spec.IP != "" {
+		endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: spec.IP}
+	}
+
+	networkConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{spec.Network: endpoint},
+	}
+
+	resp, err := p.client.ContainerCreate(
+		ctx,
+		containerConfig,
+		hostConfig,
+		networkConfig,
+		nil,
+		LoadBalancerContainerName(spec.ClusterName),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create load balancer container: %w", err)
+	}
+
+	return p.startContainer(ctx, resp.ID)
+}
+
+// removeContainer force-removes a container by ID.
+func (p *Provider) removeContainer(ctx context.Context, id string) error {
+	err := p.client.ContainerRemove(ctx, id, container.RemoveOptions{Force: true})
+	if err != nil {
+		return fmt.Errorf("failed to remove container %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// publishedHostPort returns the host port the load balancer's API port is published
+// on, or "" when it is not published.
+func publishedHostPort(inspect container.InspectResponse) string {
+	if inspect.NetworkSettings == nil {
+		return ""
+	}
+
+	bindings := inspect.NetworkSettings.Ports[nat.Port(strconv.Itoa(loadBalancerPort)+"/tcp")]
+	if len(bindings) == 0 {
+		return ""
+	}
+
+	return bindings[0].HostPort
+}
+
+// haproxyConfig renders a TCP-mode HAProxy configuration that balances the Kubernetes
+// API across backends. Each backend is health-checked, so a control plane whose API
+// server stops accepting connections is taken out of rotation until it recovers.
+func haproxyConfig(backends []string) string {
+	var builder strings.Builder
+
+	port := strconv.Itoa(loadBalancerPort)
+
+	builder.WriteString(`global
+  log stdout format raw local0
+
+defaults
+  log global
+  mode tcp
+  option tcplog
+  timeout connect 5s
+  timeout client 1h
+  timeout server 1h
+
+frontend kube-apiserver
+  bind *:` + port + `
+  default_backend control-planes
+
+backend control-planes
+  balance roundrobin
+  default-server inter 2s fall 3 rise 2
+`)
+
+	for i, backend := range backends {
+		fmt.Fprintf(&builder, "  server control-plane-%d %s check\n",
+			i+1, net.JoinHostPort(backend, port))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,263 @@
+package docker_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLBContainerID = "lb-id"
+	testLBHostPort    = "40123"
+)
+
+// lbInspect returns an inspect response for a load balancer published on hostPort.
+func lbInspect(env []string, hostPort string) container.InspectResponse {
+	return container.InspectResponse{
+		Config: &container.Config{Env: env},
+		NetworkSettings: &container.NetworkSettings{
+			NetworkSettingsBase: container.NetworkSettingsBase{
+				Ports: nat.PortMap{
+					"6443/tcp": {{HostIP: "127.0.0.1", HostPort: hostPort}},
+				},
+			},
+		},
+	}
+}
+
+func testLBSpec() docker.LoadBalancerSpec {
+	return docker.LoadBalancerSpec{
+		ClusterName: testClusterName,
+		Network:     testClusterName,
+		IP:          "10.5.0.254",
+		Backends:    []string{"10.5.0.2", "10.5.0.3", "10.5.0.4"},
+	}
+}
+
+func TestProvider_EnsureLoadBalancer_Creates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := dockerclient.NewMockAPIClient(t)
+
+	lbContainer := container.Summary{ID: testLBContainerID, State: "running"}
+
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return(nil, nil).
+		Once()
+	client.EXPECT().
+		ImageInspect(ctx, docker.LoadBalancerImage()).
+		Return(image.InspectResponse{}, nil)
+	client.EXPECT().
+		ContainerCreate(ctx,
+			mock.MatchedBy(func(cfg *container.Config) bool {
+				return cfg.Labels[docker.LabelKSailCluster] == testClusterName &&
+					cfg.Labels[docker.LabelKSailRole] == "load-balancer" &&
+					slices.ContainsFunc(cfg.Env, func(env string) bool {
+						return strings.Contains(env, "server control-plane-3 10.5.0.4:6443 check")
+					})
+			}),
+			mock.MatchedBy(func(host *container.HostConfig) bool {
+				bindings := host.PortBindings["6443/tcp"]
+
+				return len(bindings) == 1 && bindings[0].HostIP == "127.0.0.1" &&
+					bindings[0].HostPort == ""
+			}),
+			mock.MatchedBy(func(net *network.NetworkingConfig) bool {
+				endpoint := net.EndpointsConfig[testClusterName]
+
+				return endpoint != nil && endpoint.IPAMConfig != nil &&
+					endpoint.IPAMConfig.IPv4Address == "10.5.0.254"
+			}),
+			(*ocispec.Platform)(nil),
+			docker.LoadBalancerContainerName(testClusterName),
+		).
+		Return(container.CreateResponse{ID: testLBContainerID}, nil)
+	client.EXPECT().
+		ContainerStart(mock.Anything, testLBContainerID, container.StartOptions{}).
+		Return(nil)
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return([]container.Summary{lbContainer}, nil).
+		Once()
+	client.EXPECT().
+		ContainerInspect(ctx, testLBContainerID).
+		Return(lbInspect(nil, testLBHostPort), nil)
+
+	prov := docker.NewProvider(client, docker.LabelSchemeTalos)
+
+	endpoint, err := prov.EnsureLoadBalancer(ctx, testLBSpec())
+
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:"+testLBHostPort, endpoint)
+}
+
+func TestProvider_EnsureLoadBalancer_RecreatesOnSameHostPort(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := dockerclient.NewMockAPIClient(t)
+
+	lbContainer := container.Summary{ID: testLBContainerID, State: "running"}
+
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return([]container.Summary{lbContainer}, nil)
+	client.EXPECT().
+		ContainerInspect(ctx, testLBContainerID).
+		Return(lbInspect([]string{"HAPROXY_CFG=stale"}, testLBHostPort), nil)
+	client.EXPECT().
+		ContainerRemove(ctx, testLBContainerID, container.RemoveOptions{Force: true}).
+		Return(nil)
+	client.EXPECT().
+		ImageInspect(ctx, docker.LoadBalancerImage()).
+		Return(image.InspectResponse{}, nil)
+	client.EXPECT().
+		ContainerCreate(ctx, mock.Anything,
+			mock.MatchedBy(func(host *container.HostConfig) bool {
+				bindings := host.PortBindings["6443/tcp"]
+
+				return len(bindings) == 1 && bindings[0].HostPort == testLBHostPort
+			}),
+			mock.Anything, mock.Anything, mock.Anything,
+		).
+		Return(container.CreateResponse{ID: testLBContainerID}, nil)
+	client.EXPECT().
+		ContainerStart(mock.Anything, testLBContainerID, container.StartOptions{}).
+		Return(nil)
+
+	prov := docker.NewProvider(client, docker.LabelSchemeTalos)
+
+	endpoint, err := prov.EnsureLoadBalancer(ctx, testLBSpec())
+
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:"+testLBHostPort, endpoint)
+}
+
+func TestProvider_EnsureLoadBalancer_PinnedHostPort(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := dockerclient.NewMockAPIClient(t)
+
+	const pinnedPort = "16443"
+
+	lbContainer := container.Summary{ID: testLBContainerID, State: "running"}
+
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return(nil, nil).
+		Once()
+	client.EXPECT().
+		ImageInspect(ctx, docker.LoadBalancerImage()).
+		Return(image.InspectResponse{}, nil)
+	client.EXPECT().
+		ContainerCreate(ctx, mock.Anything,
+			mock.MatchedBy(func(host *container.HostConfig) bool {
+				bindings := host.PortBindings["6443/tcp"]
+
+				return len(bindings) == 1 && bindings[0].HostPort == pinnedPort
+			}),
+			mock.Anything, mock.Anything, mock.Anything,
+		).
+		Return(container.CreateResponse{ID: testLBContainerID}, nil)
+	client.EXPECT().
+		ContainerStart(mock.Anything, testLBContainerID, container.StartOptions{}).
+		Return(nil)
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return([]container.Summary{lbContainer}, nil).
+		Once()
+	client.EXPECT().
+		ContainerInspect(ctx, testLBContainerID).
+		Return(lbInspect(nil, pinnedPort), nil)
+
+	prov := docker.NewProvider(client, docker.LabelSchemeTalos)
+
+	spec := testLBSpec()
+	spec.HostPort = pinnedPort
+
+	endpoint, err := prov.EnsureLoadBalancer(ctx, spec)
+
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:"+pinnedPort, endpoint)
+}
+
+func TestProvider_EnsureLoadBalancer_NoBackends(t *testing.T) {
+	t.Parallel()
+
+	client := dockerclient.NewMockAPIClient(t)
+	prov := docker.NewProvider(client, docker.LabelSchemeTalos)
+
+	spec := testLBSpec()
+	spec.Backends = nil
+
+	_, err := prov.EnsureLoadBalancer(context.Background(), spec)
+
+	require.ErrorIs(t, err, docker.ErrNoLoadBalancerBackends)
+}
+
+func TestProvider_LoadBalancerEndpoint_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := dockerclient.NewMockAPIClient(t)
+
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return(nil, nil)
+
+	prov := docker.NewProvider(client, docker.LabelSchemeTalos)
+
+	_, err := prov.LoadBalancerEndpoint(ctx, testClusterName)
+
+	require.ErrorIs(t, err, docker.ErrLoadBalancerNotFound)
+}
+
+func TestProvider_LoadBalancerLifecycle_NoLoadBalancerIsNoop(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := dockerclient.NewMockAPIClient(t)
+
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return(nil, nil)
+
+	prov := docker.NewProvider(client, docker.LabelSchemeTalos)
+
+	require.NoError(t, prov.StartLoadBalancer(ctx, testClusterName))
+	require.NoError(t, prov.StopLoadBalancer(ctx, testClusterName))
+	require.NoError(t, prov.DeleteLoadBalancer(ctx, testClusterName))
+}
+
+func TestProvider_DeleteLoadBalancer_Removes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := dockerclient.NewMockAPIClient(t)
+
+	client.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return([]container.Summary{{ID: testLBContainerID}}, nil)
+	client.EXPECT().
+		ContainerRemove(ctx, testLBContainerID, container.RemoveOptions{Force: true}).
+		Return(nil)
+
+	prov := docker.NewProvider(client, docker.LabelSchemeTalos)
+
+	require.NoError(t, prov.DeleteLoadBalancer(ctx, testClusterName))
+}
@@ -315,12 +315,19 @@ func (p *Provider) containerToNodeInfo(
 
 	role := p.extractRole(ctr)
 
-	return provider.NodeInfo{
+	node := provider.NodeInfo{
 		Name:        name,
 		ClusterName: clusterName,
 		Role:        role,
 		State:       ctr.State,
+		Image:       ctr.Image,
 	}
+
+	if ctr.Created > 0 {
+		node.Created = time.Unix(ctr.Created, 0).UTC()
+	}
+
+	return node
 }
 
 // extractClusterName extracts the cluster name from a container based on the label scheme.
@@ -27,11 +27,13 @@ func newKindContainers(_ string) []container.Summary {
 			ID:    "cp1",
 			Names: []string{"/" + testClusterName + "-control-plane"},
 			State: "running",
+			Image: "kindest/node:v1.35.1",
 		},
 		{
 			ID:    "w1",
 			Names: []string{"/" + testClusterName + "-worker"},
 			State: "running",
+			Image: "kindest/node:v1.35.1",
 		},
 	}
 }
@@ -169,6 +171,7 @@ func TestProvider_ListNodes_Kind(t *testing.T) {
 	assert.Equal(t, "control-plane", nodes[0].Role)
 	assert.Equal(t, testClusterName+"-worker", nodes[1].Name)
 	assert.Equal(t, "worker", nodes[1].Role)
+	assert.Equal(t, "kindest/node:v1.35.1", nodes[1].Image)
 }
 
 func TestProvider_ListNodes_KindIgnoresMirrorHelpers(t *testing.T) {
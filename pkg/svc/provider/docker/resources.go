@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/docker/docker/api/types/container"
+)
+
+// nodeRoles maps the role reported by each label scheme to the spec.cluster.nodes
+// role it belongs to. Roles missing here (e.g. the K3d load balancer) are never
+// customized.
+//
+//nolint:gochecknoglobals // read-only lookup table; avoids per-call allocation.
+var nodeRoles = map[string]v1alpha1.NodeRole{
+	roleControlPlane: v1alpha1.NodeRoleControlPlane,
+	"server":         v1alpha1.NodeRoleControlPlane,
+	"controlplane":   v1alpha1.NodeRoleControlPlane,
+	"worker":         v1alpha1.NodeRoleWorker,
+	"agent":          v1alpha1.NodeRoleWorker,
+}
+
+// SetNodeResources applies the CPU and memory limits of cluster.Nodes to the
+// cluster's running node containers. Nodes are indexed per role in creation
+// order, which for Kind and K3d names is the natural order of the container names
+// (e.g. worker, worker2, ... and agent-0, agent-1, ...). Swap is capped at the
+// memory limit so the limit cannot be bypassed. Nodes without limits are left
+// untouched.
+func (p *Provider) SetNodeResources(
+	ctx context.Context,
+	clusterName string,
+	cluster v1alpha1.ClusterSpec,
+) error {
+	if len(cluster.Nodes) == 0 {
+		return nil
+	}
+
+	nodes, err := provider.EnsureAvailableAndListNodes(ctx, p, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to prepare node resource update: %w", err)
+	}
+
+	slices.SortFunc(nodes, func(a, b provider.NodeInfo) int {
+		return cmp.Or(cmp.Compare(len(a.Name), len(b.Name)), cmp.Compare(a.Name, b.Name))
+	})
+
+	indexes := make(map[v1alpha1.NodeRole]int, len(nodeRoles))
+
+	for _, node := range nodes {
+		role, ok := nodeRoles[node.Role]
+		if !ok {
+			continue
+		}
+
+		resources := cluster.NodeSettingsFor(role, indexes[role]).Resources
+		indexes[role]++
+
+		if resources.IsZero() {
+			continue
+		}
+
+		err := p.updateContainerResources(ctx, node.Name, resources)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateContainerResources sets the CPU and memory limits of a single container.
+func (p *Provider) updateContainerResources(
+	ctx context.Context,
+	name string,
+	resources v1alpha1.NodeResources,
+) error {
+	nanoCPUs, err := resources.NanoCPUs()
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	memory, err := resources.MemoryBytes()
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	update := container.UpdateConfig{
+		Resources: container.Resources{NanoCPUs: nanoCPUs, Memory: memory},
+	}
+	if memory > 0 {
+		update.MemorySwap = memory
+	}
+
+	_, err = p.client.ContainerUpdate(ctx, name, update)
+	if err != nil {
+		return fmt.Errorf("failed to update resources of container %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package docker_test
+
+import (
+	"context"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_SetNodeResources(t *testing.T) {
+	t.Parallel()
+
+	second := int32(1)
+	client := dockerclient.NewMockAPIClient(t)
+	client.EXPECT().ContainerList(mock.Anything, mock.Anything).Return([]container.Summary{
+		{Names: []string{"/" + testClusterName + "-worker2"}},
+		{Names: []string{"/" + testClusterName + "-control-plane"}},
+		{Names: []string{"/" + testClusterName + "-worker"}},
+	}, nil)
+	client.EXPECT().ContainerUpdate(mock.Anything, testClusterName+"-worker2", container.UpdateConfig{
+		Resources: container.Resources{NanoCPUs: 1_500_000_000, Memory: 2 << 30, MemorySwap: 2 << 30},
+	}).Return(container.UpdateResponse{}, nil)
+
+	prov := docker.NewProvider(client, docker.LabelSchemeKind)
+
+	err := prov.SetNodeResources(context.Background(), testClusterName, v1alpha1.ClusterSpec{
+		Nodes: []v1alpha1.NodeSpec{{
+			Role:      v1alpha1.NodeRoleWorker,
+			Index:     &second,
+			Resources: v1alpha1.NodeResources{CPU: "1500m", Memory: "2Gi"},
+		}},
+	})
+	require.NoError(t, err)
+}
+
+func TestProvider_SetNodeResources_NoNodes(t *testing.T) {
+	t.Parallel()
+
+	prov := docker.NewProvider(dockerclient.NewMockAPIClient(t), docker.LabelSchemeKind)
+
+	require.NoError(t, prov.SetNodeResources(context.Background(), testClusterName, v1alpha1.ClusterSpec{}))
+}
@@ -0,0 +1,20 @@
+package docker
+
+import (
+	_ "embed"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/image/parser"
+)
+
+//go:embed Dockerfile
+var dockerfile string
+
+// LoadBalancerImage returns the HAProxy image that fronts the Kubernetes API of
+// multi-control-plane clusters.
+func LoadBalancerImage() string {
+	return parser.ParseImageFromDockerfile(
+		dockerfile,
+		`FROM\s+(docker\.io/library/haproxy:[^\s]+)`,
+		"haproxy",
+	)
+}
@@ -278,6 +278,7 @@ func nodePoolInfos(cluster *containerpb.Cluster, clusterName string) []provider.
 			Role:        nodeRoleWorker,
 			State:       pool.GetStatus().String(),
 			ServerType:  pool.GetConfig().GetMachineType(),
+			Spot:        pool.GetConfig().GetSpot() || pool.GetConfig().GetPreemptible(),
 		})
 	}
 
@@ -202,6 +202,24 @@ func TestListNodesCollapsesNodePools(t *testing.T) {
 	assert.Equal(t, "PROVISIONING", nodes[1].State)
 }
 
+func TestListNodesReportsSpotPools(t *testing.T) {
+	t.Parallel()
+
+	cluster := demoCluster()
+	cluster.NodePools[0].Config.Spot = true
+	cluster.NodePools[1].Config = &containerpb.NodeConfig{Preemptible: true}
+
+	fake := &fakeClusterManager{clusters: []*containerpb.Cluster{cluster}}
+	prov := newProvider(t, fake, "europe-north1")
+
+	nodes, err := prov.ListNodes(t.Context(), "demo")
+
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	assert.True(t, nodes[0].Spot, "spot pools must be reported as spot")
+	assert.True(t, nodes[1].Spot, "legacy preemptible pools must be reported as spot")
+}
+
 func TestListNodesTranslatesNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ package hetzner
 import (
 	"context"
 	"io"
+	"net/http"
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
@@ -105,3 +106,21 @@ func NewSnapshotManagerWithUploaderForTest(
 		logWriter:    logWriter,
 	}
 }
+
+// NewTokenTransportForTest creates a tokenTransport whose token source runs run
+// instead of a command and reads the time from now.
+func NewTokenTransportForTest(
+	run func(ctx context.Context, command []string) ([]byte, error),
+	now func() time.Time,
+	interval time.Duration,
+	base http.RoundTripper,
+) http.RoundTripper {
+	source := newCommandTokenSource([]string{"token-command"}, interval)
+	source.run = run
+	source.now = now
+
+	return &tokenTransport{source: source, base: base}
+}
+
+// RunTokenCommandForTest exports runTokenCommand for testing.
+var RunTokenCommandForTest = runTokenCommand
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
@@ -17,7 +18,10 @@ var ErrTokenRequired = errors.New("hetzner API token not set")
 // NewProviderFromOptions creates a Hetzner provider from the given options.
 // It resolves the API token from the configured token environment variable
 // (falling back to DefaultHetznerTokenEnvVar when unset), then creates an
-// authenticated Hetzner client.
+// authenticated Hetzner client. When a token command is configured, the client
+// instead authenticates every request with a token printed by that command,
+// re-running it once per refresh interval and whenever the API rejects the
+// token, so long operations outlive each short-lived token.
 //
 // Unlike the Omni equivalent, this returns the underlying *hcloud.Client as well
 // so callers that need direct API access — such as the Talos provisioner wiring
@@ -29,6 +33,15 @@ var ErrTokenRequired = errors.New("hetzner API token not set")
 // not yet route through here (tracked separately); they honor only the default
 // env var.
 func NewProviderFromOptions(opts v1alpha1.OptionsHetzner) (*Provider, *hcloud.Client, error) {
+	if len(opts.TokenCommand) > 0 {
+		source := newCommandTokenSource(opts.TokenCommand, opts.EffectiveTokenRefreshInterval())
+		client := hcloud.NewClient(hcloud.WithHTTPClient(&http.Client{
+			Transport: &tokenTransport{source: source, base: http.DefaultTransport},
+		}))
+
+		return NewProvider(client), client, nil
+	}
+
 	tokenEnvVar := opts.TokenEnvVar
 	if tokenEnvVar == "" {
 		tokenEnvVar = v1alpha1.DefaultHetznerTokenEnvVar
@@ -50,7 +63,8 @@ func NewProviderFromOptions(opts v1alpha1.OptionsHetzner) (*Provider, *hcloud.Cl
 
 // ValidateCredentials verifies the configured Hetzner token authenticates by making one cheap
 // authenticated API call (listing locations). It returns ErrTokenRequired when the token is unset,
-// or the API error when the call fails; nil means the credentials work.
+// or the API error when the call fails — including a failing token command; nil means the
+// credentials work.
 func ValidateCredentials(ctx context.Context, opts v1alpha1.OptionsHetzner) error {
 	_, client, err := NewProviderFromOptions(opts)
 	if err != nil {
@@ -139,10 +139,12 @@ func (p *Provider) ensureSubnet(
 // EnsureFirewall ensures a firewall exists for the cluster, creating it if needed.
 // When allowedCIDRs is non-empty, the Kubernetes API and Talos API firewall rules
 // restrict source IPs to the specified CIDR blocks instead of 0.0.0.0/0 and ::/0.
+// When nodePortCIDRs is non-empty, the NodePort range is opened to those CIDR blocks.
 func (p *Provider) EnsureFirewall(
 	ctx context.Context,
 	clusterName string,
 	allowedCIDRs []string,
+	nodePortCIDRs []string,
 ) (*hcloud.Firewall, error) {
 	if p.client == nil {
 		return nil, provider.ErrProviderUnavailable
@@ -164,7 +166,7 @@ func (p *Provider) EnsureFirewall(
 	result, _, err := p.client.Firewall.Create(ctx, hcloud.FirewallCreateOpts{
 		Name:   firewallName,
 		Labels: ResourceLabels(clusterName),
-		Rules:  buildFirewallRules(allowedCIDRs),
+		Rules:  buildFirewallRules(allowedCIDRs, nodePortCIDRs),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create firewall %s: %w", firewallName, err)
@@ -178,10 +180,13 @@ func (p *Provider) EnsureFirewall(
 // on the next `ksail cluster update`.
 // When allowedCIDRs is non-empty, the Kubernetes API and Talos API rules
 // restrict source IPs to the specified CIDR blocks instead of 0.0.0.0/0 and ::/0.
+// Adding, changing, or removing nodePortCIDRs opens, narrows, or closes the
+// NodePort range accordingly.
 func (p *Provider) SyncFirewallRules(
 	ctx context.Context,
 	clusterName string,
 	allowedCIDRs []string,
+	nodePortCIDRs []string,
 ) error {
 	if p.client == nil {
 		return provider.ErrProviderUnavailable
@@ -198,7 +203,7 @@ func (p *Provider) SyncFirewallRules(
 		return nil // No firewall to sync
 	}
 
-	desiredRules := buildFirewallRules(allowedCIDRs)
+	desiredRules := buildFirewallRules(allowedCIDRs, nodePortCIDRs)
 
 	// Skip update if rules already match
 	if firewallRulesMatch(firewall.Rules, desiredRules) {
@@ -388,7 +393,10 @@ func (p *Provider) EnsurePlacementGroup(
 // When allowedCIDRs is non-empty, the Kubernetes API (6443) and Talos API (50000) rules
 // restrict source IPs to those CIDR blocks. ICMP remains open to all.
 // When allowedCIDRs is empty, all rules use 0.0.0.0/0 and ::/0 (open to all).
-func buildFirewallRules(allowedCIDRs []string) []hcloud.FirewallRule {
+//
+// When nodePortCIDRs is non-empty, TCP and UDP rules for the Kubernetes NodePort
+// range (30000-32767) admit those CIDR blocks. When empty, NodePorts stay closed.
+func buildFirewallRules(allowedCIDRs, nodePortCIDRs []string) []hcloud.FirewallRule {
 	anyIP := []net.IPNet{
 		{IP: net.ParseIP("0.0.0.0"), Mask: net.CIDRMask(0, IPv4CIDRBits)},
 		{IP: net.ParseIP("::"), Mask: net.CIDRMask(0, IPv6CIDRBits)},
@@ -406,7 +414,7 @@ func buildFirewallRules(allowedCIDRs []string) []hcloud.FirewallRule {
 	// communication flows through the private Hetzner Cloud Network unfiltered.
 	// See: https://docs.hetzner.com/cloud/firewalls/faq/
 	// #do-firewalls-filter-traffic-between-servers-on-the-same-private-network
-	return []hcloud.FirewallRule{
+	rules := []hcloud.FirewallRule{
 		// Talos API (apid) - required for talosctl
 		{
 			Direction:   hcloud.FirewallRuleDirectionIn,
@@ -431,6 +439,29 @@ func buildFirewallRules(allowedCIDRs []string) []hcloud.FirewallRule {
 			Description: new("ICMP (ping)"),
 		},
 	}
+
+	if len(nodePortCIDRs) == 0 {
+		return rules
+	}
+
+	nodePortSourceIPs := parseCIDRsToIPNets(nodePortCIDRs)
+
+	return append(rules,
+		hcloud.FirewallRule{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolTCP,
+			Port:        new(NodePortRange),
+			SourceIPs:   nodePortSourceIPs,
+			Description: new("Kubernetes NodePorts (TCP)"),
+		},
+		hcloud.FirewallRule{
+			Direction:   hcloud.FirewallRuleDirectionIn,
+			Protocol:    hcloud.FirewallRuleProtocolUDP,
+			Port:        new(NodePortRange),
+			SourceIPs:   nodePortSourceIPs,
+			Description: new("Kubernetes NodePorts (UDP)"),
+		},
+	)
 }
 
 // parseCIDRsToIPNets converts a slice of CIDR strings to net.IPNet values.
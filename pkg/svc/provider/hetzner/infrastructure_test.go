@@ -238,7 +238,7 @@ func TestEnsureFirewallNilClient(t *testing.T) {
 	require.NotNil(t, prov)
 
 	// Should return error when client is nil
-	firewall, err := prov.EnsureFirewall(context.TODO(), "test-cluster", nil)
+	firewall, err := prov.EnsureFirewall(context.TODO(), "test-cluster", nil, nil)
 
 	require.Error(t, err)
 	assert.Nil(t, firewall)
@@ -290,7 +290,7 @@ func TestSyncFirewallRulesNilClient(t *testing.T) {
 	require.NotNil(t, prov)
 
 	// Should return error when client is nil
-	err := prov.SyncFirewallRules(context.TODO(), "test-cluster", nil)
+	err := prov.SyncFirewallRules(context.TODO(), "test-cluster", nil, nil)
 
 	require.Error(t, err)
 	require.ErrorIs(t, err, provider.ErrProviderUnavailable)
@@ -452,7 +452,7 @@ func TestSourceIPsEqual(t *testing.T) {
 func TestBuildFirewallRulesSecureSet(t *testing.T) {
 	t.Parallel()
 
-	rules := hetzner.BuildFirewallRulesForTest(nil)
+	rules := hetzner.BuildFirewallRulesForTest(nil, nil)
 
 	// Only Talos API, Kubernetes API, and ICMP should be exposed publicly.
 	// Cluster-internal ports (etcd 2379-2380, kubelet 10250, trustd 50001)
@@ -495,7 +495,7 @@ func TestBuildFirewallRulesWithAllowedCIDRs(t *testing.T) {
 	t.Parallel()
 
 	allowedCIDRs := []string{"203.0.113.0/24", "198.51.100.0/24"}
-	rules := hetzner.BuildFirewallRulesForTest(allowedCIDRs)
+	rules := hetzner.BuildFirewallRulesForTest(allowedCIDRs, nil)
 
 	require.Len(t, rules, 3, "secure rule set must have exactly 3 rules")
 
@@ -522,6 +522,30 @@ func TestBuildFirewallRulesWithAllowedCIDRs(t *testing.T) {
 	}
 }
 
+func TestBuildFirewallRulesWithNodePortCIDRs(t *testing.T) {
+	t.Parallel()
+
+	rules := hetzner.BuildFirewallRulesForTest(nil, []string{"203.0.113.0/24"})
+
+	require.Len(t, rules, 5, "NodePort CIDRs must add a TCP and a UDP rule")
+
+	nodePortProtocols := map[hcloud.FirewallRuleProtocol]bool{}
+
+	for _, rule := range rules {
+		if rule.Port == nil || *rule.Port != hetzner.NodePortRange {
+			continue
+		}
+
+		nodePortProtocols[rule.Protocol] = true
+
+		require.Len(t, rule.SourceIPs, 1, "NodePort rules must use only the provided CIDRs")
+		assert.Equal(t, "203.0.113.0/24", rule.SourceIPs[0].String())
+	}
+
+	assert.True(t, nodePortProtocols[hcloud.FirewallRuleProtocolTCP], "TCP NodePorts must be open")
+	assert.True(t, nodePortProtocols[hcloud.FirewallRuleProtocolUDP], "UDP NodePorts must be open")
+}
+
 type lbInNetworkTestCase struct {
 	name        string
 	lb          *hcloud.LoadBalancer
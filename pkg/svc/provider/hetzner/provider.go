@@ -30,6 +30,9 @@ const (
 	IPv4CIDRBits = 32
 	// IPv6CIDRBits is the number of bits in an IPv6 CIDR mask for ::/0.
 	IPv6CIDRBits = 128
+	// NodePortRange is the Kubernetes NodePort range opened by the firewall when
+	// NodePort CIDRs are configured.
+	NodePortRange = "30000-32767"
 	// DefaultMaxServerCreateRetries is the number of retry attempts for server creation.
 	DefaultMaxServerCreateRetries = 3
 	// DefaultMaxAvailabilityCheckRetries is the number of attempts for the server-type
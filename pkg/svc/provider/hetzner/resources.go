@@ -0,0 +1,175 @@
+package hetzner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// ResourceKind identifies the type of a Hetzner Cloud resource KSail created for a cluster.
+type ResourceKind string
+
+// Resource kinds returned by ListClusterResources.
+const (
+	// ResourceKindServer is a cluster node.
+	ResourceKindServer ResourceKind = "server"
+	// ResourceKindNetwork is the cluster's private network.
+	ResourceKindNetwork ResourceKind = "network"
+	// ResourceKindSubnet is a subnet of the cluster's private network.
+	ResourceKindSubnet ResourceKind = "subnet"
+	// ResourceKindFirewall is the firewall protecting the nodes' public interfaces.
+	ResourceKindFirewall ResourceKind = "firewall"
+	// ResourceKindPlacementGroup is the spread placement group of the nodes.
+	ResourceKindPlacementGroup ResourceKind = "placement-group"
+	// ResourceKindFloatingIP is the control-plane floating IP.
+	ResourceKindFloatingIP ResourceKind = "floating-ip"
+	// ResourceKindLoadBalancer is a load balancer attached to the cluster network,
+	// typically created by hcloud-ccm for a LoadBalancer Service.
+	ResourceKindLoadBalancer ResourceKind = "load-balancer"
+)
+
+// ClusterResource is one Hetzner Cloud resource that belongs to a cluster.
+type ClusterResource struct {
+	// Kind is the resource type.
+	Kind ResourceKind
+	// Name is the resource name (the IP range for subnets).
+	Name string
+	// ID is the Hetzner Cloud ID; subnets carry their network's ID.
+	ID int64
+}
+
+// ListClusterResources returns the Hetzner Cloud resources of a cluster: its
+// servers, private network and subnets, firewall, placement group, floating
+// IP, and the load balancers attached to its network. Resources are looked up
+// by the same labels and names cluster deletion uses; those that do not exist
+// are omitted.
+func (p *Provider) ListClusterResources(
+	ctx context.Context,
+	clusterName string,
+) ([]ClusterResource, error) {
+	if p.client == nil {
+		return nil, provider.ErrProviderUnavailable
+	}
+
+	labelSelector := fmt.Sprintf("%s=true,%s=%s", LabelOwned, LabelClusterName, clusterName)
+
+	servers, err := p.listServersByLabelSelector(ctx, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ClusterResource, 0, len(servers))
+
+	for _, server := range servers {
+		resources = append(resources, ClusterResource{
+			Kind: ResourceKindServer, Name: server.Name, ID: server.ID,
+		})
+	}
+
+	network, err := p.getNetworkByClusterName(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if network != nil {
+		resources = append(resources, ClusterResource{
+			Kind: ResourceKindNetwork, Name: network.Name, ID: network.ID,
+		})
+
+		for _, subnet := range network.Subnets {
+			if subnet.IPRange == nil {
+				continue
+			}
+
+			resources = append(resources, ClusterResource{
+				Kind: ResourceKindSubnet, Name: subnet.IPRange.String(), ID: network.ID,
+			})
+		}
+	}
+
+	infrastructure, err := p.listClusterInfrastructure(ctx, clusterName, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(resources, infrastructure...), nil
+}
+
+// listClusterInfrastructure returns the firewall, placement group, floating
+// IP, and network load balancers of a cluster.
+func (p *Provider) listClusterInfrastructure(
+	ctx context.Context,
+	clusterName string,
+	network *hcloud.Network,
+) ([]ClusterResource, error) {
+	var resources []ClusterResource
+
+	firewall, _, err := p.client.Firewall.GetByName(ctx, clusterName+FirewallSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall %s: %w", clusterName+FirewallSuffix, err)
+	}
+
+	if firewall != nil {
+		resources = append(resources, ClusterResource{
+			Kind: ResourceKindFirewall, Name: firewall.Name, ID: firewall.ID,
+		})
+	}
+
+	placementGroup, _, err := p.client.PlacementGroup.GetByName(ctx, clusterName+PlacementGroupSuffix)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get placement group %s: %w", clusterName+PlacementGroupSuffix, err,
+		)
+	}
+
+	if placementGroup != nil {
+		resources = append(resources, ClusterResource{
+			Kind: ResourceKindPlacementGroup, Name: placementGroup.Name, ID: placementGroup.ID,
+		})
+	}
+
+	floatingIP, err := p.GetOwnedFloatingIP(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if floatingIP != nil {
+		resources = append(resources, ClusterResource{
+			Kind: ResourceKindFloatingIP, Name: floatingIP.Name, ID: floatingIP.ID,
+		})
+	}
+
+	if network == nil {
+		return resources, nil
+	}
+
+	loadBalancers, err := p.client.LoadBalancer.AllWithOpts(ctx, hcloud.LoadBalancerListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers: %w", err)
+	}
+
+	for _, loadBalancer := range loadBalancers {
+		if lbAttachedToNetwork(loadBalancer, network.ID) {
+			resources = append(resources, ClusterResource{
+				Kind: ResourceKindLoadBalancer, Name: loadBalancer.Name, ID: loadBalancer.ID,
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+// lbAttachedToNetwork reports whether the load balancer has a private
+// interface in the network with the given ID. The list API references the
+// network by ID only, so the ID is matched rather than the name.
+func lbAttachedToNetwork(loadBalancer *hcloud.LoadBalancer, networkID int64) bool {
+	for _, privateNet := range loadBalancer.PrivateNet {
+		if privateNet.Network != nil && privateNet.Network.ID == networkID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package hetzner_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/hetzner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClusterResourcesServer returns an httptest server answering the list
+// calls ListClusterResources makes for the cluster "test-cluster".
+func newClusterResourcesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	responses := map[string]string{
+		"/servers": `{"servers":[{"id":11,"name":"test-cluster-controlplane-1",` +
+			`"labels":{"ksail.owned":"true","ksail.cluster.name":"test-cluster"}}]}`,
+		"/networks": `{"networks":[{"id":21,"name":"test-cluster-network",` +
+			`"ip_range":"10.0.0.0/16","subnets":[{"type":"cloud","ip_range":"10.0.1.0/24",` +
+			`"network_zone":"eu-central","gateway":"10.0.0.1"}]}]}`,
+		"/firewalls":        `{"firewalls":[{"id":31,"name":"test-cluster-firewall"}]}`,
+		"/placement_groups": `{"placement_groups":[]}`,
+		"/floating_ips":     `{"floating_ips":[` + ownedFloatingIPJSON + `]}`,
+		"/load_balancers": `{"load_balancers":[` +
+			`{"id":41,"name":"ccm-lb","private_net":[{"network":21,"ip":"10.0.1.5"}]},` +
+			`{"id":42,"name":"other-lb","private_net":[]}]}`,
+	}
+
+	mux := http.NewServeMux()
+
+	for path, body := range responses {
+		mux.HandleFunc(path, func(responseWriter http.ResponseWriter, _ *http.Request) {
+			responseWriter.Header().Set("Content-Type", "application/json")
+			_, _ = responseWriter.Write([]byte(body))
+		})
+	}
+
+	mux.HandleFunc("/networks/21", func(responseWriter http.ResponseWriter, _ *http.Request) {
+		responseWriter.Header().Set("Content-Type", "application/json")
+		_, _ = responseWriter.Write([]byte(`{"network":{"id":21,"name":"test-cluster-network"}}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestListClusterResources(t *testing.T) {
+	t.Parallel()
+
+	srv := newClusterResourcesServer(t)
+	prov := hetzner.NewProvider(newTestHcloudClient(srv.URL))
+
+	resources, err := prov.ListClusterResources(t.Context(), "test-cluster")
+	require.NoError(t, err)
+
+	assert.Equal(t, []hetzner.ClusterResource{
+		{Kind: hetzner.ResourceKindServer, Name: "test-cluster-controlplane-1", ID: 11},
+		{Kind: hetzner.ResourceKindNetwork, Name: "test-cluster-network", ID: 21},
+		{Kind: hetzner.ResourceKindSubnet, Name: "10.0.1.0/24", ID: 21},
+		{Kind: hetzner.ResourceKindFirewall, Name: "test-cluster-firewall", ID: 31},
+		{Kind: hetzner.ResourceKindFloatingIP, Name: "test-cluster-floating-ip", ID: 7},
+		{Kind: hetzner.ResourceKindLoadBalancer, Name: "ccm-lb", ID: 41},
+	}, resources)
+}
+
+func TestListClusterResources_NoClient(t *testing.T) {
+	t.Parallel()
+
+	prov := hetzner.NewProvider(nil)
+
+	_, err := prov.ListClusterResources(t.Context(), "test-cluster")
+	require.ErrorIs(t, err, provider.ErrProviderUnavailable)
+}
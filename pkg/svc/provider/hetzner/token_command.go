@@ -0,0 +1,163 @@
+package hetzner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenCommandTimeout bounds one run of a token command, so a hung secrets
+// manager CLI fails the API call instead of blocking it forever.
+const tokenCommandTimeout = time.Minute
+
+// ErrTokenCommandFailed indicates that the configured token command failed or
+// printed no token.
+var ErrTokenCommandFailed = errors.New("hetzner token command failed")
+
+// commandTokenSource obtains short-lived Hetzner API tokens by running a
+// command, reusing each token for a fixed interval. It is safe for concurrent
+// use: one API call runs the command while concurrent calls wait for its token.
+type commandTokenSource struct {
+	command  []string
+	interval time.Duration
+	run      func(ctx context.Context, command []string) ([]byte, error)
+	now      func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newCommandTokenSource(command []string, interval time.Duration) *commandTokenSource {
+	return &commandTokenSource{
+		command:  command,
+		interval: interval,
+		run:      runTokenCommand,
+		now:      time.Now,
+	}
+}
+
+// Token returns the cached token, running the command when none is cached or
+// the cached one is older than the refresh interval.
+func (s *commandTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Before(s.expires) {
+		return s.token, nil
+	}
+
+	output, err := s.run(ctx, s.command)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrTokenCommandFailed, s.command[0], err)
+	}
+
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("%w: %s printed no token", ErrTokenCommandFailed, s.command[0])
+	}
+
+	s.token = token
+	s.expires = s.now().Add(s.interval)
+
+	return token, nil
+}
+
+// Invalidate drops token from the cache when it is still the cached one, so
+// the next call runs the command again. A token another caller already
+// replaced is left alone.
+func (s *commandTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// runTokenCommand runs command without a shell and returns its stdout. Stderr
+// is included in the error so a failed login or missing secret is explained.
+func runTokenCommand(ctx context.Context, command []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	//nolint:gosec // G204: the command is the user's own spec.provider.hetzner.tokenCommand.
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+
+		return nil, fmt.Errorf("run token command: %w", err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// tokenTransport authenticates each Hetzner API request with the current token
+// of a commandTokenSource. When the API rejects a token (401), the token is
+// dropped and the request is retried once with a freshly issued one, so a token
+// revoked before its refresh interval ends does not fail a long operation.
+type tokenTransport struct {
+	source *commandTokenSource
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err //nolint:wrapcheck // RoundTrippers must return transport errors unchanged.
+	}
+
+	t.source.Invalidate(token)
+
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	fresh, err := t.source.Token(req.Context())
+	if err != nil || fresh == token {
+		return resp, nil //nolint:nilerr // The 401 response explains the failure better.
+	}
+
+	retry := withBearerToken(req, fresh)
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return resp, nil //nolint:nilerr // The 401 response explains the failure better.
+		}
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return t.base.RoundTrip(retry) //nolint:wrapcheck // RoundTrippers must return transport errors unchanged.
+}
+
+// withBearerToken returns a copy of req carrying token, as RoundTrippers must
+// not modify the request they are given.
+func withBearerToken(req *http.Request, token string) *http.Request {
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token)
+
+	return authorized
+}
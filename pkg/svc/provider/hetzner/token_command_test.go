@@ -0,0 +1,165 @@
+package hetzner_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/hetzner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTokenCommand = errors.New("not logged in")
+
+// tokenCommandFixture issues numbered tokens and counts how often it ran.
+type tokenCommandFixture struct {
+	runs atomic.Int32
+}
+
+func (f *tokenCommandFixture) run(context.Context, []string) ([]byte, error) {
+	run := f.runs.Add(1)
+
+	return []byte("token-" + string(rune('0'+run)) + "\n"), nil
+}
+
+// newAuthServer accepts only the tokens in valid and echoes the request body.
+func newAuthServer(t *testing.T, valid ...string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+		for _, accepted := range valid {
+			if token == accepted {
+				body, _ := io.ReadAll(request.Body)
+				_, _ = writer.Write(append([]byte(token+":"), body...))
+
+				return
+			}
+		}
+
+		writer.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func doRequest(t *testing.T, transport http.RoundTripper, url, body string) (int, string) {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	response, err := (&http.Client{Transport: transport}).Do(request)
+	require.NoError(t, err)
+
+	defer func() { _ = response.Body.Close() }()
+
+	data, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	return response.StatusCode, string(data)
+}
+
+func TestTokenTransport_ReusesTokenUntilRefreshInterval(t *testing.T) {
+	t.Parallel()
+
+	fixture := &tokenCommandFixture{}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := newAuthServer(t, "token-1", "token-2")
+	transport := hetzner.NewTokenTransportForTest(
+		fixture.run, func() time.Time { return now }, 10*time.Minute, http.DefaultTransport,
+	)
+
+	_, body := doRequest(t, transport, server.URL, "a")
+	assert.Equal(t, "token-1:a", body)
+
+	now = now.Add(9 * time.Minute)
+	_, body = doRequest(t, transport, server.URL, "b")
+	assert.Equal(t, "token-1:b", body)
+
+	now = now.Add(2 * time.Minute)
+	_, body = doRequest(t, transport, server.URL, "c")
+	assert.Equal(t, "token-2:c", body)
+	assert.Equal(t, int32(2), fixture.runs.Load())
+}
+
+func TestTokenTransport_RetriesRejectedTokenWithFreshOne(t *testing.T) {
+	t.Parallel()
+
+	fixture := &tokenCommandFixture{}
+	server := newAuthServer(t, "token-2")
+	transport := hetzner.NewTokenTransportForTest(fixture.run, time.Now, time.Hour, http.DefaultTransport)
+
+	status, body := doRequest(t, transport, server.URL, "payload")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "token-2:payload", body, "the retry replays the request body")
+	assert.Equal(t, int32(2), fixture.runs.Load())
+}
+
+func TestTokenTransport_ReturnsUnauthorizedWhenFreshTokenIsRejected(t *testing.T) {
+	t.Parallel()
+
+	fixture := &tokenCommandFixture{}
+	server := newAuthServer(t)
+	transport := hetzner.NewTokenTransportForTest(fixture.run, time.Now, time.Hour, http.DefaultTransport)
+
+	status, _ := doRequest(t, transport, server.URL, "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, int32(2), fixture.runs.Load(), "one retry, no loop")
+}
+
+func TestTokenTransport_ReportsFailingCommand(t *testing.T) {
+	t.Parallel()
+
+	transport := hetzner.NewTokenTransportForTest(
+		func(context.Context, []string) ([]byte, error) { return nil, errTokenCommand },
+		time.Now, time.Hour, http.DefaultTransport,
+	)
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://127.0.0.1", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(request)
+	require.ErrorIs(t, err, hetzner.ErrTokenCommandFailed)
+	require.ErrorIs(t, err, errTokenCommand)
+}
+
+func TestTokenTransport_RejectsEmptyToken(t *testing.T) {
+	t.Parallel()
+
+	transport := hetzner.NewTokenTransportForTest(
+		func(context.Context, []string) ([]byte, error) { return []byte(" \n"), nil },
+		time.Now, time.Hour, http.DefaultTransport,
+	)
+
+	request, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://127.0.0.1", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(request)
+	require.ErrorIs(t, err, hetzner.ErrTokenCommandFailed)
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	output, err := hetzner.RunTokenCommandForTest(t.Context(), []string{"sh", "-c", "echo issued-token"})
+	require.NoError(t, err)
+	assert.Equal(t, "issued-token\n", string(output))
+
+	_, err = hetzner.RunTokenCommandForTest(t.Context(), []string{"sh", "-c", "echo vault sealed >&2; exit 2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vault sealed")
+}
@@ -22,6 +22,10 @@ const (
 
 	// RoleWorker is the label value for worker nodes.
 	RoleWorker = "worker"
+
+	// AnnotationPaused marks a nested cluster's namespace while the cluster is paused
+	// (scaled to zero by `ksail cluster stop`), so discovery can report it as stopped.
+	AnnotationPaused = "ksail.io/paused"
 )
 
 // NamespacePrefix is the prefix for KSail-managed nested cluster namespaces (e.g., "ksail-mycluster").
@@ -99,6 +99,8 @@ func (p *Provider) ListNodes(ctx context.Context, clusterName string) ([]provide
 type ClusterInfo struct {
 	Name         string
 	Distribution string
+	// Paused reports that the cluster's namespace carries AnnotationPaused.
+	Paused bool
 }
 
 // ListAllClusters returns the names of all nested clusters managed by this provider.
@@ -150,7 +152,11 @@ func (p *Provider) ListAllClustersWithDistribution(ctx context.Context) ([]Clust
 		}
 
 		distribution := p.detectDistribution(ctx, nsItem.Name, clusterName)
-		clusters = append(clusters, ClusterInfo{Name: clusterName, Distribution: distribution})
+		clusters = append(clusters, ClusterInfo{
+			Name:         clusterName,
+			Distribution: distribution,
+			Paused:       nsItem.Annotations[AnnotationPaused] == "true",
+		})
 	}
 
 	return clusters, nil
@@ -138,6 +138,39 @@ func TestProvider_ListAllClusters(t *testing.T) {
 	assert.ElementsMatch(t, []string{"cluster-a", "cluster-b"}, clusters)
 }
 
+func TestProvider_ListAllClustersWithDistribution_ReportsPaused(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "vcluster-paused",
+				Labels:      kubeprovider.CommonLabels("paused"),
+				Annotations: map[string]string{kubeprovider.AnnotationPaused: "true"},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "vcluster-running",
+				Labels: kubeprovider.CommonLabels("running"),
+			},
+		},
+	)
+
+	prov, err := kubeprovider.NewProvider(client, v1alpha1.OptionsKubernetes{})
+	require.NoError(t, err)
+
+	infos, err := prov.ListAllClustersWithDistribution(context.Background())
+	require.NoError(t, err)
+
+	paused := map[string]bool{}
+	for _, info := range infos {
+		paused[info.Name] = info.Paused
+	}
+
+	assert.Equal(t, map[string]bool{"paused": true, "running": false}, paused)
+}
+
 func TestProvider_NodesExist(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // NodeInfo contains information about a node managed by a provider.
@@ -22,6 +23,20 @@ type NodeInfo struct {
 	// ServerType is the infrastructure server type (e.g., "cx22", "cx33").
 	// Only populated by cloud providers (Hetzner); empty for Docker/Omni.
 	ServerType string
+
+	// Spot reports whether the node runs on spot (preemptible) capacity the
+	// cloud may reclaim. Populated by the GCP and Azure providers from the
+	// node pool's configuration; false everywhere else.
+	Spot bool
+
+	// Created is when the node was created. Populated by the Docker provider
+	// from the container creation time; zero where the provider does not
+	// report it.
+	Created time.Time
+
+	// Image is the image the node runs (e.g. "kindest/node:v1.35.1"). Populated by
+	// the Docker provider from the container image; empty elsewhere.
+	Image string
 }
 
 // ClusterStatus contains the status of a cluster as reported by its infrastructure provider.
@@ -0,0 +1,43 @@
+package aksprovisioner
+
+import (
+	armcontainerservice "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v7"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// spotMaxPriceOnDemand caps the Spot price at the on-demand price, so VMs are
+// only evicted for capacity and never for price.
+const spotMaxPriceOnDemand float32 = -1
+
+// ApplySpot switches every user agent pool in clusterSpec to Spot priority
+// when spot capacity is requested. The reclaim policy maps onto the scale
+// set eviction policy: Replace deletes evicted nodes so the pool (or the
+// cluster autoscaler) provisions replacements, Deallocate stops them and keeps
+// their disks. System pools — and pools without an explicit mode, which AKS
+// may promote to system — stay on regular priority, because AKS rejects Spot
+// system pools. AKS taints Spot pools with
+// kubernetes.azure.com/scalesetpriority=spot:NoSchedule, so workloads must
+// tolerate it to land there.
+func ApplySpot(clusterSpec *armcontainerservice.ManagedCluster, spot v1alpha1.SpotSpec) {
+	if clusterSpec == nil || clusterSpec.Properties == nil || !spot.Enabled {
+		return
+	}
+
+	evictionPolicy := armcontainerservice.ScaleSetEvictionPolicyDelete
+	if spot.EffectiveReclaimPolicy() == v1alpha1.SpotReclaimPolicyDeallocate {
+		evictionPolicy = armcontainerservice.ScaleSetEvictionPolicyDeallocate
+	}
+
+	for _, pool := range clusterSpec.Properties.AgentPoolProfiles {
+		if pool == nil || pool.Mode == nil || *pool.Mode != armcontainerservice.AgentPoolModeUser {
+			continue
+		}
+
+		pool.ScaleSetPriority = new(armcontainerservice.ScaleSetPrioritySpot)
+		pool.ScaleSetEvictionPolicy = new(evictionPolicy)
+
+		if pool.SpotMaxPrice == nil {
+			pool.SpotMaxPrice = new(spotMaxPriceOnDemand)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package aksprovisioner_test
+
+import (
+	"testing"
+
+	armcontainerservice "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v7"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	aksprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/aks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spotTestCluster returns a spec with a system pool, a user pool, and a pool
+// without an explicit mode.
+func spotTestCluster() *armcontainerservice.ManagedCluster {
+	return &armcontainerservice.ManagedCluster{
+		Properties: &armcontainerservice.ManagedClusterProperties{
+			AgentPoolProfiles: []*armcontainerservice.ManagedClusterAgentPoolProfile{
+				{Name: new("system"), Mode: new(armcontainerservice.AgentPoolModeSystem)},
+				{Name: new("user"), Mode: new(armcontainerservice.AgentPoolModeUser)},
+				{Name: new("unset")},
+			},
+		},
+	}
+}
+
+func TestApplySpotSwitchesOnlyUserPools(t *testing.T) {
+	t.Parallel()
+
+	spec := spotTestCluster()
+
+	aksprovisioner.ApplySpot(spec, v1alpha1.SpotSpec{Enabled: true})
+
+	pools := spec.Properties.AgentPoolProfiles
+	assert.Nil(t, pools[0].ScaleSetPriority, "system pools cannot run on spot")
+	assert.Nil(t, pools[2].ScaleSetPriority, "pools without a mode may become system pools")
+
+	require.NotNil(t, pools[1].ScaleSetPriority)
+	assert.Equal(t, armcontainerservice.ScaleSetPrioritySpot, *pools[1].ScaleSetPriority)
+	require.NotNil(t, pools[1].ScaleSetEvictionPolicy)
+	assert.Equal(t, armcontainerservice.ScaleSetEvictionPolicyDelete, *pools[1].ScaleSetEvictionPolicy)
+	require.NotNil(t, pools[1].SpotMaxPrice)
+	assert.InDelta(t, -1, *pools[1].SpotMaxPrice, 0)
+}
+
+func TestApplySpotDeallocatePolicy(t *testing.T) {
+	t.Parallel()
+
+	spec := spotTestCluster()
+
+	aksprovisioner.ApplySpot(spec, v1alpha1.SpotSpec{
+		Enabled:       true,
+		ReclaimPolicy: v1alpha1.SpotReclaimPolicyDeallocate,
+	})
+
+	pool := spec.Properties.AgentPoolProfiles[1]
+	require.NotNil(t, pool.ScaleSetEvictionPolicy)
+	assert.Equal(t, armcontainerservice.ScaleSetEvictionPolicyDeallocate, *pool.ScaleSetEvictionPolicy)
+}
+
+func TestApplySpotDisabledLeavesSpecUntouched(t *testing.T) {
+	t.Parallel()
+
+	spec := spotTestCluster()
+
+	aksprovisioner.ApplySpot(spec, v1alpha1.SpotSpec{})
+
+	for _, pool := range spec.Properties.AgentPoolProfiles {
+		assert.Nil(t, pool.ScaleSetPriority)
+	}
+
+	aksprovisioner.ApplySpot(nil, v1alpha1.SpotSpec{Enabled: true})
+}
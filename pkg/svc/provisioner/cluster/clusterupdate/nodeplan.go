@@ -0,0 +1,138 @@
+package clusterupdate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// RollingUpgradeReason states what an in-place upgrade does to each node, for
+// plans.
+const RollingUpgradeReason = "cordon, drain, upgrade in place, uncordon"
+
+// SupportsInPlaceNodeUpgrade reports whether distribution upgrades its nodes in
+// place one at a time. Only Talos does, through its upgrade API. Kind and K3d
+// node images carry the cluster state, so they reach a new version only by
+// recreating the cluster.
+func SupportsInPlaceNodeUpgrade(distribution v1alpha1.Distribution) bool {
+	return distribution == v1alpha1.DistributionTalos
+}
+
+// NodeVersion is the running version of one cluster node.
+type NodeVersion struct {
+	// Name is the Kubernetes node name.
+	Name string
+	// ControlPlane reports whether the node runs the control plane.
+	ControlPlane bool
+	// KubeletVersion is the kubelet version the node reports (e.g. "v1.33.1").
+	KubeletVersion string
+	// OSVersion is the distribution (OS) version the node runs (e.g. "v1.11.2"
+	// for Talos), or "" when unknown.
+	OSVersion string
+}
+
+// NodeUpgradeTarget holds the versions an upgrade moves nodes to.
+type NodeUpgradeTarget struct {
+	// KubernetesVersion is the target Kubernetes version; "" is the latest
+	// supported version, resolved later.
+	KubernetesVersion string
+	// OSVersion is the target distribution (OS) version; "" leaves the OS out of
+	// the plan.
+	OSVersion string
+}
+
+// Node roles and version dimensions reported in upgrade plans.
+const (
+	nodeRoleControlPlane = "control-plane"
+	nodeRoleWorker       = "worker"
+	nodeDimensionOS      = "os"
+	nodeDimensionK8s     = "kubernetes"
+)
+
+// PlanNodeUpgrades classifies upgrading nodes in place to target the way
+// DiffConfig classifies configuration changes, so the plan renders like any
+// other diff: one reboot-required change per node and version dimension, in
+// upgrade order. The OS is upgraded first on every node (the runtime must
+// support the target Kubernetes version), then Kubernetes. Within each
+// dimension, control-plane nodes come first (the API server must support the
+// kubelets it serves), then workers, each group by name. Nodes already at a
+// target are left out of that dimension.
+func PlanNodeUpgrades(nodes []NodeVersion, target NodeUpgradeTarget) *UpdateResult {
+	result := NewEmptyUpdateResult()
+
+	ordered := slices.Clone(nodes)
+	slices.SortStableFunc(ordered, func(left, right NodeVersion) int {
+		if left.ControlPlane != right.ControlPlane {
+			if left.ControlPlane {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(left.Name, right.Name)
+	})
+
+	if target.OSVersion != "" {
+		for _, node := range ordered {
+			if sameVersion(node.OSVersion, target.OSVersion) {
+				continue
+			}
+
+			result.RebootRequired = append(result.RebootRequired,
+				nodeUpgradeChange(node, nodeDimensionOS, node.OSVersion, target.OSVersion))
+		}
+	}
+
+	kubernetesLabel := target.KubernetesVersion
+	if kubernetesLabel == "" {
+		kubernetesLabel = "latest supported"
+	}
+
+	for _, node := range ordered {
+		if target.KubernetesVersion != "" && sameVersion(node.KubeletVersion, target.KubernetesVersion) {
+			continue
+		}
+
+		result.RebootRequired = append(result.RebootRequired,
+			nodeUpgradeChange(node, nodeDimensionK8s, node.KubeletVersion, kubernetesLabel))
+	}
+
+	return result
+}
+
+// nodeUpgradeChange is the plan entry upgrading dimension of node from
+// oldValue to newValue.
+func nodeUpgradeChange(node NodeVersion, dimension, oldValue, newValue string) Change {
+	role := nodeRoleWorker
+	if node.ControlPlane {
+		role = nodeRoleControlPlane
+	}
+
+	if oldValue == "" {
+		oldValue = "unknown"
+	}
+
+	return Change{
+		Field:    role + "/" + node.Name + " (" + dimension + ")",
+		OldValue: oldValue,
+		NewValue: newValue,
+		Category: ChangeCategoryRebootRequired,
+		Reason:   RollingUpgradeReason,
+	}
+}
+
+// sameVersion compares two versions, ignoring a "v" prefix and any distribution
+// suffix (e.g. "v1.33.1+k3s1" matches "v1.33.1").
+func sameVersion(left, right string) bool {
+	normalize := func(version string) string {
+		version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+		version, _, _ = strings.Cut(version, "+")
+		version, _, _ = strings.Cut(version, "-")
+
+		return version
+	}
+
+	return normalize(left) == normalize(right)
+}
@@ -0,0 +1,105 @@
+package clusterupdate_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportsInPlaceNodeUpgrade(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, clusterupdate.SupportsInPlaceNodeUpgrade(v1alpha1.DistributionTalos))
+	assert.False(t, clusterupdate.SupportsInPlaceNodeUpgrade(v1alpha1.DistributionVanilla))
+	assert.False(t, clusterupdate.SupportsInPlaceNodeUpgrade(v1alpha1.DistributionK3s))
+}
+
+// planFields returns the fields of the changes in plan, in order.
+func planFields(plan *clusterupdate.UpdateResult) []string {
+	fields := make([]string, 0, len(plan.RebootRequired))
+	for _, change := range plan.RebootRequired {
+		fields = append(fields, change.Field)
+	}
+
+	return fields
+}
+
+func TestPlanNodeUpgrades_OrdersControlPlaneFirst(t *testing.T) {
+	t.Parallel()
+
+	nodes := []clusterupdate.NodeVersion{
+		{Name: "dev-worker-2", KubeletVersion: "v1.32.4"},
+		{Name: "dev-control-plane-2", ControlPlane: true, KubeletVersion: "v1.32.4"},
+		{Name: "dev-worker-1", KubeletVersion: "v1.33.1"},
+		{Name: "dev-control-plane-1", ControlPlane: true, KubeletVersion: "v1.32.4"},
+	}
+
+	plan := clusterupdate.PlanNodeUpgrades(nodes, clusterupdate.NodeUpgradeTarget{KubernetesVersion: "v1.33.1"})
+
+	assert.Equal(t, []string{
+		"control-plane/dev-control-plane-1 (kubernetes)",
+		"control-plane/dev-control-plane-2 (kubernetes)",
+		"worker/dev-worker-2 (kubernetes)",
+	}, planFields(plan), "nodes already at the target are left out")
+	assert.Equal(t, clusterupdate.Change{
+		Field:    "control-plane/dev-control-plane-1 (kubernetes)",
+		OldValue: "v1.32.4",
+		NewValue: "v1.33.1",
+		Category: clusterupdate.ChangeCategoryRebootRequired,
+		Reason:   clusterupdate.RollingUpgradeReason,
+	}, plan.RebootRequired[0])
+	assert.False(t, plan.HasRecreateRequired())
+	assert.Equal(t, "dev-worker-2", nodes[0].Name, "the caller's slice must not be reordered")
+}
+
+func TestPlanNodeUpgrades_UpgradesOSBeforeKubernetes(t *testing.T) {
+	t.Parallel()
+
+	nodes := []clusterupdate.NodeVersion{
+		{Name: "talos-worker-1", KubeletVersion: "v1.33.1", OSVersion: "v1.11.2"},
+		{Name: "talos-control-plane-1", ControlPlane: true, KubeletVersion: "v1.33.1", OSVersion: "v1.12.0"},
+	}
+
+	plan := clusterupdate.PlanNodeUpgrades(nodes, clusterupdate.NodeUpgradeTarget{
+		KubernetesVersion: "v1.34.0",
+		OSVersion:         "v1.12.0",
+	})
+
+	assert.Equal(t, []string{
+		"worker/talos-worker-1 (os)",
+		"control-plane/talos-control-plane-1 (kubernetes)",
+		"worker/talos-worker-1 (kubernetes)",
+	}, planFields(plan))
+	assert.Equal(t, "v1.11.2", plan.RebootRequired[0].OldValue)
+	assert.Equal(t, "v1.12.0", plan.RebootRequired[0].NewValue)
+}
+
+func TestPlanNodeUpgrades_LatestSupportedKubernetes(t *testing.T) {
+	t.Parallel()
+
+	nodes := []clusterupdate.NodeVersion{
+		{Name: "talos-control-plane-1", ControlPlane: true, KubeletVersion: "v1.33.1"},
+	}
+
+	plan := clusterupdate.PlanNodeUpgrades(nodes, clusterupdate.NodeUpgradeTarget{})
+
+	assert.Equal(t, []string{"control-plane/talos-control-plane-1 (kubernetes)"}, planFields(plan),
+		"without an OS target only Kubernetes is planned")
+	assert.Equal(t, "latest supported", plan.RebootRequired[0].NewValue)
+}
+
+func TestPlanNodeUpgrades_AlreadyAtTarget(t *testing.T) {
+	t.Parallel()
+
+	nodes := []clusterupdate.NodeVersion{
+		{Name: "talos-control-plane-1", ControlPlane: true, KubeletVersion: "v1.33.1", OSVersion: "v1.12.0"},
+	}
+
+	plan := clusterupdate.PlanNodeUpgrades(nodes, clusterupdate.NodeUpgradeTarget{
+		KubernetesVersion: "1.33.1",
+		OSVersion:         "v1.12.0",
+	})
+	assert.Zero(t, plan.TotalChanges())
+}
@@ -1,11 +1,13 @@
 package clusterupdate
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"k8s.io/client-go/kubernetes"
 )
 
 // MergePersistedState loads the ClusterSpec previously saved by create/update
@@ -21,6 +23,8 @@ import (
 //     node's containerd config at creation (Kind/K3s baselines otherwise carry the
 //     zero value, so a configured mirrorsDir reads as recreate-required every run;
 //     see kind.DiffConfig and diff/engine.go's documented LocalRegistry limitation).
+//   - Vanilla.NodeImageDockerfile — the snippet the Kind nodes' derived image was
+//     built from; the running nodes only expose the resulting image tag.
 //
 // It is a no-op (returns nil) when no state exists for clusterName
 // (state.ErrStateNotFound). Any other failure (I/O, corrupt JSON, permission)
@@ -39,6 +43,62 @@ func MergePersistedState(spec *v1alpha1.ClusterSpec, clusterName string) error {
 		return fmt.Errorf("load persisted cluster state for %q: %w", clusterName, err)
 	}
 
+	mergeSavedState(spec, saved)
+
+	return nil
+}
+
+// MergePersistedStateWithFallback behaves like MergePersistedState but, when
+// no local state exists for clusterName, falls back to the state KSail keeps
+// in the cluster (state.LoadInClusterState) through client. This lets an
+// update run from a machine other than the one that created the cluster. A
+// nil client, or a cluster without in-cluster state, is a no-op.
+func MergePersistedStateWithFallback(
+	ctx context.Context,
+	spec *v1alpha1.ClusterSpec,
+	clusterName string,
+	client kubernetes.Interface,
+) error {
+	if spec == nil {
+		return nil
+	}
+
+	saved, err := state.LoadClusterSpec(clusterName)
+	if err == nil {
+		mergeSavedState(spec, saved)
+
+		return nil
+	}
+
+	if !errors.Is(err, state.ErrStateNotFound) {
+		return fmt.Errorf("load persisted cluster state for %q: %w", clusterName, err)
+	}
+
+	if client == nil {
+		return nil
+	}
+
+	inCluster, err := state.LoadInClusterState(ctx, client)
+	if err != nil {
+		if errors.Is(err, state.ErrStateNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("load in-cluster state for %q: %w", clusterName, err)
+	}
+
+	mergeSavedState(spec, inCluster.Spec)
+
+	return nil
+}
+
+// mergeSavedState copies the fields that cannot be introspected from a live
+// cluster from saved onto spec.
+func mergeSavedState(spec, saved *v1alpha1.ClusterSpec) {
+	if saved == nil {
+		return
+	}
+
 	// Talos.ISO is a boot-time setting (Hetzner Cloud ISO ID) that cannot be
 	// detected from the running cluster.
 	if saved.Talos.ISO != 0 {
@@ -56,7 +116,11 @@ func MergePersistedState(spec *v1alpha1.ClusterSpec, clusterName string) error {
 		spec.Vanilla.MirrorsDir = saved.Vanilla.MirrorsDir
 	}
 
-	return nil
+	// Vanilla.NodeImageDockerfile only survives as the derived image the Kind
+	// nodes were booted from; the snippet itself cannot be read back.
+	if saved.Vanilla.NodeImageDockerfile != "" {
+		spec.Vanilla.NodeImageDockerfile = saved.Vanilla.NodeImageDockerfile
+	}
 }
 
 // MergePersistedEKSState restores non-introspectable EKS installer inputs from
@@ -1,8 +1,10 @@
 package clusterupdate_test
 
 import (
+	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/devantler-tech/ksail/v7/internal/testutil/homeenv"
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
@@ -10,6 +12,8 @@ import (
 	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 const testEKSComponentAccountID = "123456789012"
@@ -45,6 +49,86 @@ func TestMergePersistedState_NilSpecIsNoOp(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestMergePersistedStateWithFallback_UsesInClusterState verifies that an
+// update run on a machine without local state recovers the non-introspectable
+// fields from the state KSail keeps in the cluster.
+func TestMergePersistedStateWithFallback_UsesInClusterState(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	saved := &v1alpha1.ClusterSpec{
+		Vanilla: v1alpha1.OptionsVanilla{
+			MirrorsDir:          "/custom/mirrors",
+			NodeImageDockerfile: "RUN apt-get install -y nfs-common",
+		},
+		LocalRegistry: v1alpha1.LocalRegistry{Registry: "localhost:5050"},
+	}
+	require.NoError(t, state.SaveInClusterState(
+		context.Background(), client, "created-elsewhere", saved, nil, time.Now(),
+	))
+
+	baseline := clusterupdate.DefaultCurrentSpec(
+		v1alpha1.DistributionVanilla,
+		v1alpha1.ProviderDocker,
+	)
+
+	err := clusterupdate.MergePersistedStateWithFallback(
+		context.Background(), baseline, "created-elsewhere", client,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/custom/mirrors", baseline.Vanilla.MirrorsDir)
+	assert.Equal(t, "RUN apt-get install -y nfs-common", baseline.Vanilla.NodeImageDockerfile)
+	assert.Equal(t, "localhost:5050", baseline.LocalRegistry.Registry)
+}
+
+// TestMergePersistedStateWithFallback_NoStateIsNoOp verifies that neither a
+// nil client nor a cluster without in-cluster state changes the baseline.
+func TestMergePersistedStateWithFallback_NoStateIsNoOp(t *testing.T) {
+	t.Parallel()
+
+	for _, client := range []kubernetes.Interface{nil, fake.NewClientset()} {
+		baseline := clusterupdate.DefaultCurrentSpec(
+			v1alpha1.DistributionK3s,
+			v1alpha1.ProviderDocker,
+		)
+
+		err := clusterupdate.MergePersistedStateWithFallback(
+			context.Background(), baseline, "cluster-that-was-never-saved", client,
+		)
+		require.NoError(t, err)
+		assert.Empty(t, baseline.LocalRegistry.Registry)
+	}
+}
+
+// TestMergePersistedStateWithFallback_PrefersLocalState verifies that local
+// state, when present, wins over the in-cluster copy.
+//
+//nolint:paralleltest // writes/reads a cluster state file under the shared isolated $HOME
+func TestMergePersistedStateWithFallback_PrefersLocalState(t *testing.T) {
+	const clusterName = "fallback-prefers-local"
+
+	require.NoError(t, state.SaveClusterSpec(clusterName, &v1alpha1.ClusterSpec{
+		Vanilla: v1alpha1.OptionsVanilla{MirrorsDir: "/local/mirrors"},
+	}))
+	t.Cleanup(func() { _ = state.DeleteClusterState(clusterName) })
+
+	client := fake.NewClientset()
+	require.NoError(t, state.SaveInClusterState(context.Background(), client, clusterName, &v1alpha1.ClusterSpec{
+		Vanilla: v1alpha1.OptionsVanilla{MirrorsDir: "/cluster/mirrors"},
+	}, nil, time.Now()))
+
+	baseline := clusterupdate.DefaultCurrentSpec(
+		v1alpha1.DistributionVanilla,
+		v1alpha1.ProviderDocker,
+	)
+
+	err := clusterupdate.MergePersistedStateWithFallback(context.Background(), baseline, clusterName, client)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/local/mirrors", baseline.Vanilla.MirrorsDir)
+}
+
 // TestMergePersistedEKSState_NoStateIsNoOp preserves adoption behavior when no
 // exact-region component baseline has been written yet.
 func TestMergePersistedEKSState_NoStateIsNoOp(t *testing.T) {
@@ -97,12 +97,24 @@ func DefaultCurrentSpec(
 		CertManager:   v1alpha1.CertManagerDisabled,
 		PolicyEngine:  v1alpha1.PolicyEngineNone,
 		GitOpsEngine:  v1alpha1.GitOpsEngineNone,
+		SealedSecrets: v1alpha1.SealedSecretsDisabled,
+
+		ExternalSecrets:   v1alpha1.ExternalSecretsDisabled,
+		Backup:            v1alpha1.BackupNone,
+		IngressController: v1alpha1.IngressControllerNone,
+		GatewayAPI:        v1alpha1.GatewayAPINone,
+		ServiceMesh:       v1alpha1.ServiceMeshNone,
+		Knative:           v1alpha1.KnativeDisabled,
+		IdentityProvider:  v1alpha1.IdentityProviderNone,
+		Observability:     v1alpha1.ObservabilitySpec{Logging: v1alpha1.LoggingNone},
 	}
 }
 
 // MarkComponentsUnknown sets each scalar component-enum field that the detector
 // probes from the live cluster (CNI, CSI, MetricsServer, LoadBalancer,
-// CertManager, PolicyEngine, GitOpsEngine) to the UnknownBaselineValue sentinel.
+// CertManager, SealedSecrets, ExternalSecrets, Backup, PolicyEngine,
+// IngressController, GatewayAPI, ServiceMesh, Knative, IdentityProvider,
+// Observability.Logging, GitOpsEngine) to the UnknownBaselineValue sentinel.
 // Callers invoke this when component detection fails (e.g. the Kubernetes API is
 // unreachable) so that the diff engine surfaces these fields as "Unknown" rather
 // than fabricating a confident diff from default values for components that may
@@ -122,13 +134,24 @@ func MarkComponentsUnknown(spec *v1alpha1.ClusterSpec) {
 	spec.MetricsServer = v1alpha1.MetricsServer(UnknownBaselineValue)
 	spec.LoadBalancer = v1alpha1.LoadBalancer(UnknownBaselineValue)
 	spec.CertManager = v1alpha1.CertManager(UnknownBaselineValue)
+	spec.SealedSecrets = v1alpha1.SealedSecrets(UnknownBaselineValue)
+	spec.ExternalSecrets = v1alpha1.ExternalSecrets(UnknownBaselineValue)
+	spec.Backup = v1alpha1.Backup(UnknownBaselineValue)
 	spec.PolicyEngine = v1alpha1.PolicyEngine(UnknownBaselineValue)
+	spec.IngressController = v1alpha1.IngressController(UnknownBaselineValue)
+	spec.GatewayAPI = v1alpha1.GatewayAPI(UnknownBaselineValue)
+	spec.ServiceMesh = v1alpha1.ServiceMesh(UnknownBaselineValue)
+	spec.Knative = v1alpha1.Knative(UnknownBaselineValue)
+	spec.IdentityProvider = v1alpha1.IdentityProvider(UnknownBaselineValue)
+	spec.Observability.Logging = v1alpha1.Logging(UnknownBaselineValue)
 	spec.GitOpsEngine = v1alpha1.GitOpsEngine(UnknownBaselineValue)
 }
 
 // ApplyDetectedComponents copies the detector-derived component fields (CNI,
-// CSI, MetricsServer, LoadBalancer, CertManager, PolicyEngine, GitOpsEngine,
-// and the node autoscaler toggle) from a detected spec onto a baseline spec.
+// CSI, MetricsServer, LoadBalancer, CertManager, SealedSecrets, ExternalSecrets,
+// Backup, PolicyEngine, IngressController, GatewayAPI, ServiceMesh, Istio.Mode,
+// Knative, IdentityProvider, Observability.Logging, GitOpsEngine, and the node
+// autoscaler toggle) from a detected spec onto a baseline spec.
 // The detector (detector.ComponentDetector.DetectComponents) returns its
 // findings as a *v1alpha1.ClusterSpec; this helper centralizes the field list
 // so every baseline-building call site copies the same set — adding a newly
@@ -144,7 +167,17 @@ func ApplyDetectedComponents(dst, detected *v1alpha1.ClusterSpec) {
 	dst.MetricsServer = detected.MetricsServer
 	dst.LoadBalancer = detected.LoadBalancer
 	dst.CertManager = detected.CertManager
+	dst.SealedSecrets = detected.SealedSecrets
+	dst.ExternalSecrets = detected.ExternalSecrets
+	dst.Backup = detected.Backup
 	dst.PolicyEngine = detected.PolicyEngine
+	dst.IngressController = detected.IngressController
+	dst.GatewayAPI = detected.GatewayAPI
+	dst.ServiceMesh = detected.ServiceMesh
+	dst.Istio.Mode = detected.Istio.Mode
+	dst.Knative = detected.Knative
+	dst.IdentityProvider = detected.IdentityProvider
+	dst.Observability.Logging = detected.Observability.Logging
 	dst.GitOpsEngine = detected.GitOpsEngine
 	dst.Autoscaler.Node = detected.Autoscaler.Node
 }
@@ -1,6 +1,7 @@
 package clusterprovisioner
 
 import (
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	kindprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/kind"
 	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
 	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
@@ -40,3 +41,11 @@ func ExportWriteK3dConfigToTempFile(config *k3dv1alpha5.SimpleConfig) (string, e
 func ExportKubeadmInstallVersion() string {
 	return kubeadmInstallVersion()
 }
+
+// ExportWithIngressPortMappings exposes withIngressPortMappings for testing.
+func ExportWithIngressPortMappings(
+	mappings []v1alpha1.PortMapping,
+	controller v1alpha1.IngressController,
+) []v1alpha1.PortMapping {
+	return withIngressPortMappings(mappings, controller)
+}
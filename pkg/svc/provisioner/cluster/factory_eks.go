@@ -101,10 +101,10 @@ func (f DefaultFactory) resolveEKSCredentialOptions(
 		auth = *f.AWSResolution
 	}
 
-	eksctlOptions := credentials.OptionsForAWSChildEnvironment(
+	eksctlOptions := credentials.OptionsForRefreshingAWSChildEnvironment(
 		auth,
 		os.Environ(),
-		eksctlclient.WithEnvironment,
+		eksctlclient.WithEnvironmentSource,
 		eksctlclient.RequireCredentialValues,
 	)
 	providerOptions := credentials.OptionsForFrozenAWSConfig(
@@ -180,6 +180,37 @@ func (f DefaultFactory) createK3dProvisioner(
 		k3dconfigmanager.ApplyAPIServerFeatureGatesArgs(k3dConfig)
 	}
 
+	// Apply the IP family from ksail.yaml so an existing k3d.yaml picks it up.
+	k3dconfigmanager.ApplyIPFamilyArgs(k3dConfig, cluster.Spec.Cluster.Network.IPFamily)
+
+	// Pin the API server host port so the kubeconfig endpoint survives recreates.
+	k3dconfigmanager.ApplyAPIServerPort(k3dConfig, cluster.Spec.Cluster.Connection.APIServerPort)
+
+	// Register per-node labels and taints from spec.cluster.nodes through the kubelet.
+	k3dconfigmanager.ApplyNodeSettings(k3dConfig, cluster.Spec.Cluster)
+
+	// Stamp the spec.cluster.resourceMetadata labels onto the node containers.
+	k3dconfigmanager.ApplyResourceLabels(k3dConfig, cluster.Spec.Cluster.ResourceMetadata.Labels)
+
+	// Map localhost:80/443 onto the ingress controller's fixed node ports.
+	k3dconfigmanager.ApplyIngressPorts(
+		k3dConfig,
+		v1alpha1.IngressPortMappings(cluster.Spec.Cluster.IngressController),
+	)
+
+	// Map localhost:8080 onto Knative's Kourier gateway node port.
+	k3dconfigmanager.ApplyIngressPorts(
+		k3dConfig,
+		v1alpha1.KnativePortMappings(cluster.Spec.Cluster.Knative),
+	)
+
+	// Map Keycloak's HTTPS node port onto the same host port, so the OIDC
+	// issuer URL works on the host and on the nodes.
+	k3dconfigmanager.ApplyIngressPorts(
+		k3dConfig,
+		v1alpha1.KeycloakPortMappings(cluster.Spec.Cluster.IdentityProvider),
+	)
+
 	// Apply containerd image verifier plugin volume mount when image verification is enabled.
 	// This mounts the generated config.toml.tmpl into K3d node containers so K3s uses it
 	// to generate the final containerd config with the image verifier plugin enabled.
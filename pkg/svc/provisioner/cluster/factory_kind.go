@@ -47,6 +47,19 @@ func (f DefaultFactory) createKindProvisioner(
 		kindconfigmanager.ApplyAPIServerFeatureGates(kindConfig)
 	}
 
+	// Apply the IP family from ksail.yaml so it wins over a stale kind.yaml.
+	kindconfigmanager.ApplyIPFamily(kindConfig, cluster.Spec.Cluster.Network.IPFamily)
+
+	// Pin the API server host port so the kubeconfig endpoint survives recreates.
+	kindconfigmanager.ApplyAPIServerPort(kindConfig, cluster.Spec.Cluster.Connection.APIServerPort)
+
+	// Register per-node labels and taints from spec.cluster.nodes through the kubelet.
+	// This must happen AFTER applyKindNodeCounts since node indexes follow the nodes slice.
+	kindconfigmanager.ApplyNodeSettings(kindConfig, cluster.Spec.Cluster)
+
+	// Register the spec.cluster.resourceMetadata labels on every node.
+	kindconfigmanager.ApplyResourceLabels(kindConfig, cluster.Spec.Cluster.ResourceMetadata.Labels)
+
 	// Apply kubelet certificate rotation patches when metrics-server is enabled.
 	// This must happen AFTER applyKindNodeCounts since that function may replace the nodes slice.
 	if cluster.Spec.Cluster.MetricsServer == v1alpha1.MetricsServerEnabled {
@@ -71,6 +84,26 @@ func (f DefaultFactory) createKindProvisioner(
 		return f.createKindKubernetesProvisioner(cluster, kindConfig)
 	}
 
+	// Map localhost:80/443 onto the ingress controller's fixed node ports.
+	// This must happen AFTER applyKindNodeCounts since that function may replace the nodes slice.
+	kindconfigmanager.ApplyIngressPortMappings(
+		kindConfig,
+		v1alpha1.IngressPortMappings(cluster.Spec.Cluster.IngressController),
+	)
+
+	// Map localhost:8080 onto Knative's Kourier gateway node port.
+	kindconfigmanager.ApplyIngressPortMappings(
+		kindConfig,
+		v1alpha1.KnativePortMappings(cluster.Spec.Cluster.Knative),
+	)
+
+	// Map Keycloak's HTTPS node port onto the same host port, so the OIDC
+	// issuer URL works on the host and on the nodes.
+	kindconfigmanager.ApplyIngressPortMappings(
+		kindConfig,
+		v1alpha1.KeycloakPortMappings(cluster.Spec.Cluster.IdentityProvider),
+	)
+
 	provisioner, err := kindprovisioner.CreateProvisioner(
 		kindConfig,
 		cluster.Spec.Cluster.Connection.Kubeconfig,
@@ -83,6 +116,8 @@ func (f DefaultFactory) createKindProvisioner(
 		provisioner.WithComponentDetector(f.ComponentDetector)
 	}
 
+	provisioner.WithNodeImageDockerfile(cluster.Spec.Cluster.Vanilla.NodeImageDockerfile)
+
 	return provisioner, kindConfig, nil
 }
 
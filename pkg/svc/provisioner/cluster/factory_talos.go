@@ -2,6 +2,7 @@ package clusterprovisioner
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	talosprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/talos"
@@ -24,6 +25,33 @@ func talosOptsFromCluster(cluster *v1alpha1.Cluster) v1alpha1.OptionsTalos {
 	return talosOpts
 }
 
+// withIngressPortMappings returns the given port mappings extended with the
+// ingress controller's host port mappings, skipping host ports the user already
+// mapped. The input slice is never modified because it is shared with the
+// cluster spec.
+func withIngressPortMappings(
+	mappings []v1alpha1.PortMapping,
+	controller v1alpha1.IngressController,
+) []v1alpha1.PortMapping {
+	ingressMappings := v1alpha1.IngressPortMappings(controller)
+	if len(ingressMappings) == 0 {
+		return mappings
+	}
+
+	merged := slices.Clone(mappings)
+
+	for _, mapping := range ingressMappings {
+		mapped := slices.ContainsFunc(merged, func(existing v1alpha1.PortMapping) bool {
+			return existing.HostPort == mapping.HostPort
+		})
+		if !mapped {
+			merged = append(merged, mapping)
+		}
+	}
+
+	return merged
+}
+
 func (f DefaultFactory) createTalosProvisioner(
 	cluster *v1alpha1.Cluster,
 ) (Provisioner, any, error) {
@@ -55,6 +83,14 @@ func (f DefaultFactory) createTalosProvisioner(
 	// Overlay cluster-level fields (node counts, Kubernetes version) onto Talos options.
 	talosOpts := talosOptsFromCluster(cluster)
 
+	// Map localhost:80/443 onto the ingress controller's fixed node ports.
+	if cluster.Spec.Cluster.Provider.NeedsLocalDocker() {
+		talosOpts.ExtraPortMappings = withIngressPortMappings(
+			talosOpts.ExtraPortMappings,
+			cluster.Spec.Cluster.IngressController,
+		)
+	}
+
 	// Propagate autoscaler-enabled flag to Hetzner options so the provisioner
 	// can create the cluster-autoscaler-config Secret during bootstrap.
 	hetznerOpts := cluster.Spec.Provider.Hetzner
@@ -91,6 +127,10 @@ func (f DefaultFactory) createTalosProvisioner(
 		return nil, nil, fmt.Errorf("failed to create Talos provisioner: %w", err)
 	}
 
+	provisioner.WithNodes(cluster.Spec.Cluster.Nodes)
+	provisioner.WithResourceLabels(cluster.Spec.Cluster.ResourceMetadata.Labels)
+	provisioner.WithAPIServerPort(cluster.Spec.Cluster.Connection.APIServerPort)
+
 	if f.ComponentDetector != nil {
 		provisioner.WithComponentDetector(f.ComponentDetector)
 	}
@@ -498,3 +498,24 @@ func TestWrapK3kServerArgs(t *testing.T) {
 		}, got)
 	})
 }
+
+func TestWithIngressPortMappings(t *testing.T) {
+	t.Parallel()
+
+	userMappings := []v1alpha1.PortMapping{{ContainerPort: 31000, HostPort: 80, Protocol: "TCP"}}
+
+	merged := clusterprovisioner.ExportWithIngressPortMappings(
+		userMappings,
+		v1alpha1.IngressControllerNginx,
+	)
+
+	assert.Equal(t, []v1alpha1.PortMapping{
+		{ContainerPort: 31000, HostPort: 80, Protocol: "TCP"},
+		{ContainerPort: 30443, HostPort: 443, Protocol: "TCP"},
+	}, merged)
+	assert.Len(t, userMappings, 1, "the input slice must not be modified")
+	assert.Equal(t, userMappings, clusterprovisioner.ExportWithIngressPortMappings(
+		userMappings,
+		v1alpha1.IngressControllerNone,
+	))
+}
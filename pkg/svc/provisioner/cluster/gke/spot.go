@@ -0,0 +1,44 @@
+package gkeprovisioner
+
+import (
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// ApplySpot marks every node pool in clusterSpec as a Spot VM pool when spot
+// capacity is requested. A spec without node pools gets the flag on its
+// cluster-level node config, which GKE uses for the default pool. GKE node
+// pools run on managed instance groups that recreate preempted VMs once Spot
+// capacity returns, so only the Replace reclaim policy applies. Autopilot
+// clusters are left untouched: Autopilot provisions Spot capacity per
+// workload through the cloud.google.com/gke-spot node selector instead.
+func ApplySpot(clusterSpec *containerpb.Cluster, spot v1alpha1.SpotSpec) {
+	if clusterSpec == nil || !spot.Enabled || clusterSpec.GetAutopilot().GetEnabled() {
+		return
+	}
+
+	if len(clusterSpec.GetNodePools()) == 0 {
+		clusterSpec.NodeConfig = spotNodeConfig(clusterSpec.GetNodeConfig())
+
+		return
+	}
+
+	for _, pool := range clusterSpec.GetNodePools() {
+		if pool != nil {
+			pool.Config = spotNodeConfig(pool.GetConfig())
+		}
+	}
+}
+
+// spotNodeConfig returns config (allocating one when nil) with Spot set. The
+// legacy preemptible flag is cleared because GKE rejects pools that set both.
+func spotNodeConfig(config *containerpb.NodeConfig) *containerpb.NodeConfig {
+	if config == nil {
+		config = &containerpb.NodeConfig{}
+	}
+
+	config.Spot = true
+	config.Preemptible = false
+
+	return config
+}
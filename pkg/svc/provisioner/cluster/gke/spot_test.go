@@ -0,0 +1,55 @@
+package gkeprovisioner_test
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	gkeprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/gke"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplySpotMarksEveryNodePool(t *testing.T) {
+	t.Parallel()
+
+	spec := &containerpb.Cluster{
+		NodePools: []*containerpb.NodePool{
+			{Name: "default", Config: &containerpb.NodeConfig{MachineType: "e2-standard-4"}},
+			{Name: "legacy", Config: &containerpb.NodeConfig{Preemptible: true}},
+			{Name: "bare"},
+		},
+	}
+
+	gkeprovisioner.ApplySpot(spec, v1alpha1.SpotSpec{Enabled: true})
+
+	for _, pool := range spec.GetNodePools() {
+		assert.True(t, pool.GetConfig().GetSpot(), pool.GetName())
+		assert.False(t, pool.GetConfig().GetPreemptible(), pool.GetName())
+	}
+
+	assert.Equal(t, "e2-standard-4", spec.GetNodePools()[0].GetConfig().GetMachineType())
+}
+
+func TestApplySpotWithoutPoolsSetsClusterNodeConfig(t *testing.T) {
+	t.Parallel()
+
+	spec := &containerpb.Cluster{InitialNodeCount: 2}
+
+	gkeprovisioner.ApplySpot(spec, v1alpha1.SpotSpec{Enabled: true})
+
+	assert.True(t, spec.GetNodeConfig().GetSpot())
+}
+
+func TestApplySpotLeavesSpecUntouched(t *testing.T) {
+	t.Parallel()
+
+	disabled := &containerpb.Cluster{NodePools: []*containerpb.NodePool{{Name: "default"}}}
+	gkeprovisioner.ApplySpot(disabled, v1alpha1.SpotSpec{})
+	assert.Nil(t, disabled.GetNodePools()[0].GetConfig())
+
+	autopilot := &containerpb.Cluster{Autopilot: &containerpb.Autopilot{Enabled: true}}
+	gkeprovisioner.ApplySpot(autopilot, v1alpha1.SpotSpec{Enabled: true})
+	assert.Nil(t, autopilot.GetNodeConfig())
+
+	gkeprovisioner.ApplySpot(nil, v1alpha1.SpotSpec{Enabled: true})
+}
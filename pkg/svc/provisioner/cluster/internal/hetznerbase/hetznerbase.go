@@ -69,7 +69,7 @@ type Infra interface {
 	EnsureFirewall(
 		ctx context.Context,
 		clusterName string,
-		allowedCIDRs []string,
+		allowedCIDRs, nodePortCIDRs []string,
 	) (*hcloud.Firewall, error)
 	// EnsurePlacementGroup creates (or returns the existing) placement group, or nil
 	// when the strategy disables placement groups.
@@ -273,7 +273,12 @@ func (b *Base) EnsureInfrastructure(
 
 	resolved.NetworkID = idOrZero(network, func(n *hcloud.Network) int64 { return n.ID })
 
-	firewall, err := b.Infra.EnsureFirewall(ctx, clusterName, b.Opts.AllowedCIDRs)
+	firewall, err := b.Infra.EnsureFirewall(
+		ctx,
+		clusterName,
+		b.Opts.AllowedCIDRs,
+		b.Opts.NodePortCIDRs,
+	)
 	if err != nil {
 		return ResolvedInfra{}, fmt.Errorf("ensure firewall: %w", err)
 	}
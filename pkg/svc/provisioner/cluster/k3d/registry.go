@@ -252,6 +252,7 @@ func buildRegistryInfos(
 		port := registry.ExtractRegistryPort(endpoints, usedPorts, &nextPort)
 		upstream := upstreamFromEndpoints(host, endpoints, clusterName)
 		info := registry.BuildRegistryInfo(host, endpoints, port, clusterName, upstream, "", "")
+		info.TLS = servesTLS(host, endpoints, clusterName)
 		registryInfos = append(registryInfos, info)
 	}
 
@@ -268,9 +269,9 @@ func ExtractRegistriesFromConfig(
 }
 
 // upstreamFromEndpoints finds the upstream URL from the endpoint list.
-// The upstream is identified as an HTTPS endpoint (external registry) rather than
-// an HTTP endpoint (local mirror container). Local mirrors use HTTP, while
-// external registries like ghcr.io, docker.io use HTTPS.
+// The upstream is identified as an HTTPS endpoint (external registry) that is not
+// the local mirror container, which serves HTTP unless mirror registry TLS is
+// enabled. External registries like ghcr.io, docker.io use HTTPS.
 func upstreamFromEndpoints(host string, endpoints []string, clusterName string) string {
 	if len(endpoints) == 0 {
 		return ""
@@ -284,31 +285,47 @@ func upstreamFromEndpoints(host string, endpoints []string, clusterName string)
 		}
 
 		// HTTPS endpoints are external upstreams (e.g., https://ghcr.io)
-		if strings.HasPrefix(endpoint, "https://") {
+		if strings.HasPrefix(endpoint, "https://") && !isLocalMirrorEndpoint(host, endpoint, clusterName) {
 			return endpoint
 		}
 	}
 
 	// Fallback: find any endpoint that doesn't match local registry patterns
-	expectedLocal := registry.BuildRegistryName("", host)
-	expectedLocalPrefixed := registry.BuildRegistryName(clusterName, host)
-
 	for _, candidate := range slices.Backward(endpoints) {
 		candidate = strings.TrimSpace(candidate)
 		if candidate == "" {
 			continue
 		}
 
-		extracted := registry.ExtractNameFromEndpoint(candidate)
-		if extracted == "" {
+		if !isLocalMirrorEndpoint(host, candidate, clusterName) {
 			return candidate
 		}
+	}
 
-		// If extracted name matches neither local variant, it's the upstream
-		if extracted != expectedLocal && extracted != expectedLocalPrefixed {
-			return candidate
+	return ""
+}
+
+// isLocalMirrorEndpoint reports whether endpoint points at the local mirror
+// container of host, with or without the cluster name prefix.
+func isLocalMirrorEndpoint(host, endpoint, clusterName string) bool {
+	extracted := registry.ExtractNameFromEndpoint(endpoint)
+	if extracted == "" {
+		return false
+	}
+
+	return extracted == registry.BuildRegistryName("", host) ||
+		extracted == registry.BuildRegistryName(clusterName, host)
+}
+
+// servesTLS reports whether the local mirror container of host is reached over
+// HTTPS, which is the case when mirror registry TLS is enabled.
+func servesTLS(host string, endpoints []string, clusterName string) bool {
+	for _, endpoint := range endpoints {
+		endpoint = strings.TrimSpace(endpoint)
+		if strings.HasPrefix(endpoint, "https://") && isLocalMirrorEndpoint(host, endpoint, clusterName) {
+			return true
 		}
 	}
 
-	return ""
+	return false
 }
@@ -173,6 +173,28 @@ mirrors:
 	assert.Equal(t, "https://registry-1.docker.io", result[0].Upstream)
 }
 
+func TestExtractRegistriesFromConfig_TLSMirrorEndpoint(t *testing.T) {
+	t.Parallel()
+
+	simpleCfg := &k3dv1alpha5.SimpleConfig{
+		Registries: k3dv1alpha5.SimpleConfigRegistries{
+			Config: `
+mirrors:
+  docker.io:
+    endpoint:
+      - https://test-cluster-docker.io:5000
+      - https://registry-1.docker.io
+`,
+		},
+	}
+
+	result := k3dprovisioner.ExtractRegistriesFromConfig(simpleCfg, "test-cluster")
+	require.Len(t, result, 1)
+	// The HTTPS local mirror is served with TLS and is not mistaken for the upstream
+	assert.True(t, result[0].TLS)
+	assert.Equal(t, "https://registry-1.docker.io", result[0].Upstream)
+}
+
 func TestExtractRegistriesFromConfig_OnlyLocalEndpoint(t *testing.T) {
 	t.Parallel()
 
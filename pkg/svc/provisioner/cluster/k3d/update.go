@@ -447,7 +447,9 @@ func (k *Provisioner) GetCurrentConfig(
 		spec = detected
 	}
 
-	err := clusterupdate.MergePersistedState(spec, clusterName)
+	err := clusterupdate.MergePersistedStateWithFallback(
+		ctx, spec, clusterName, k.componentDetector.Clientset(),
+	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("merge persisted state: %w", err)
 	}
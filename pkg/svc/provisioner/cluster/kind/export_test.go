@@ -3,6 +3,7 @@ package kindprovisioner
 import (
 	"context"
 
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 	"sigs.k8s.io/kind/pkg/log"
@@ -44,6 +45,19 @@ func (k *Provisioner) WithWaitForReadyForTest(
 	return k
 }
 
+// WithDockerClientForTest injects the Docker client used to build derived node
+// images.
+func (k *Provisioner) WithDockerClientForTest(dockerClient docker.Client) *Provisioner {
+	k.dockerClient = dockerClient
+
+	return k
+}
+
+// ConfigWithNodeImageForTest exposes configWithNodeImage for unit testing.
+func (k *Provisioner) ConfigWithNodeImageForTest(ctx context.Context) (*v1alpha4.Cluster, error) {
+	return k.configWithNodeImage(ctx)
+}
+
 // NewStreamLoggerForTest creates a streamLogger for testing.
 func NewStreamLoggerForTest(w interface {
 	Write(p []byte) (n int, err error)
@@ -127,6 +127,7 @@ func CreateProvisioner(
 		kindSDKProvider,
 		infraProvider,
 	)
+	provisioner.dockerClient = dockerClient
 
 	return provisioner, nil
 }
@@ -0,0 +1,136 @@
+package kindprovisioner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	kindconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/kind"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+// nodeImageRepository is the local repository derived Kind node images are
+// tagged into.
+const nodeImageRepository = "ksail/kind-node"
+
+// nodeImageHashLength is the number of hex characters of the content hash kept
+// in a derived node image tag.
+const nodeImageHashLength = 12
+
+// DerivedNodeImage returns the tag of the node image built by applying
+// dockerfile on top of baseImage. The tag keeps the base image's version for
+// readability and ends in a hash of the base image and snippet, so an unchanged
+// pair reuses the cached image and any change builds a new one.
+func DerivedNodeImage(baseImage, dockerfile string) string {
+	sum := sha256.Sum256([]byte(baseImage + "\n" + dockerfile))
+	hash := hex.EncodeToString(sum[:])[:nodeImageHashLength]
+
+	return fmt.Sprintf("%s:%s-%s", nodeImageRepository, baseImageTag(baseImage), hash)
+}
+
+// baseImageTag returns the tag of image without its digest, or "latest" when
+// the reference carries none.
+func baseImageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+
+	colon := strings.LastIndex(image, ":")
+	if colon < 0 || colon < strings.LastIndex(image, "/") {
+		return "latest"
+	}
+
+	return image[colon+1:]
+}
+
+// nodeImageBuildFile returns the Dockerfile that derives a node image from
+// baseImage.
+func nodeImageBuildFile(baseImage, dockerfile string) string {
+	return "FROM " + baseImage + "\n" + strings.TrimSpace(dockerfile) + "\n"
+}
+
+// WithNodeImageDockerfile sets the Dockerfile snippet applied on top of the
+// Kind node image. When set, Create builds (or reuses) a derived node image and
+// boots every node from it.
+func (k *Provisioner) WithNodeImageDockerfile(dockerfile string) {
+	k.nodeImageDockerfile = dockerfile
+}
+
+// configWithNodeImage returns the Kind config to create the cluster from. With
+// a node image snippet configured, it is a copy of the config with every node
+// pointing at the derived image, built first when it is not cached yet. The
+// provisioner's own config keeps the base images, which the upgrader reads the
+// running versions from.
+func (k *Provisioner) configWithNodeImage(ctx context.Context) (*v1alpha4.Cluster, error) {
+	if strings.TrimSpace(k.nodeImageDockerfile) == "" {
+		return k.kindConfig, nil
+	}
+
+	config := k.kindConfig.DeepCopy()
+
+	// Kind creates a single control-plane node when none are listed; list it
+	// explicitly so it boots from the derived image too.
+	if len(config.Nodes) == 0 {
+		config.Nodes = []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}}
+	}
+
+	derived := make(map[string]string)
+
+	for index := range config.Nodes {
+		baseImage := config.Nodes[index].Image
+		if baseImage == "" {
+			baseImage = kindconfigmanager.DefaultKindNodeImage
+		}
+
+		image, ok := derived[baseImage]
+		if !ok {
+			var err error
+
+			image, err = k.ensureNodeImage(ctx, baseImage)
+			if err != nil {
+				return nil, err
+			}
+
+			derived[baseImage] = image
+		}
+
+		config.Nodes[index].Image = image
+	}
+
+	return config, nil
+}
+
+// ensureNodeImage returns the derived image for baseImage, building it unless
+// the local Docker image store already has it.
+func (k *Provisioner) ensureNodeImage(ctx context.Context, baseImage string) (string, error) {
+	if k.dockerClient == nil {
+		dockerClient, err := NewDefaultDockerClient()
+		if err != nil {
+			return "", err
+		}
+
+		k.dockerClient = dockerClient
+	}
+
+	image := DerivedNodeImage(baseImage, k.nodeImageDockerfile)
+
+	_, err := k.dockerClient.ImageInspect(ctx, image)
+	if err == nil {
+		return image, nil
+	}
+
+	err = docker.BuildImage(
+		ctx,
+		k.dockerClient,
+		image,
+		nodeImageBuildFile(baseImage, k.nodeImageDockerfile),
+		os.Stdout,
+	)
+	if err != nil {
+		return "", fmt.Errorf("build Kind node image from %s: %w", baseImage, err)
+	}
+
+	return image, nil
+}
@@ -0,0 +1,117 @@
+package kindprovisioner_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	docker "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	kindconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/kind"
+	kindprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/kind"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/image"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+const nfsDockerfile = "RUN apt-get update && apt-get install -y nfs-common"
+
+var errImageNotFound = errors.New("no such image")
+
+func TestDerivedNodeImage(t *testing.T) {
+	t.Parallel()
+
+	tag := kindprovisioner.DerivedNodeImage("kindest/node:v1.35.0", nfsDockerfile)
+
+	assert.True(t, strings.HasPrefix(tag, "ksail/kind-node:v1.35.0-"), tag)
+	assert.Equal(t, tag, kindprovisioner.DerivedNodeImage("kindest/node:v1.35.0", nfsDockerfile),
+		"an unchanged base image and snippet must reuse the cached tag")
+	assert.NotEqual(t, tag, kindprovisioner.DerivedNodeImage("kindest/node:v1.35.0", "RUN true"))
+	assert.NotEqual(t, tag, kindprovisioner.DerivedNodeImage("kindest/node:v1.34.0", nfsDockerfile))
+
+	assert.True(t, strings.HasPrefix(
+		kindprovisioner.DerivedNodeImage("localhost:5000/node@sha256:abc", nfsDockerfile),
+		"ksail/kind-node:latest-",
+	))
+}
+
+func TestConfigWithNodeImage_WithoutDockerfileKeepsConfig(t *testing.T) {
+	t.Parallel()
+
+	provisioner, _, _ := newProvisionerForTest(t)
+
+	config, err := provisioner.ConfigWithNodeImageForTest(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, config.Nodes)
+}
+
+func TestConfigWithNodeImage_BuildsMissingImage(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+	provisioner, _, _ := newProvisionerForTest(t)
+	provisioner.WithDockerClientForTest(mockClient).WithNodeImageDockerfile(nfsDockerfile)
+
+	expected := kindprovisioner.DerivedNodeImage(kindconfigmanager.DefaultKindNodeImage, nfsDockerfile)
+
+	mockClient.EXPECT().
+		ImageInspect(mock.Anything, expected).
+		Return(image.InspectResponse{}, errImageNotFound).
+		Once()
+	mockClient.EXPECT().
+		ImageBuild(mock.Anything, mock.Anything, mock.MatchedBy(func(options build.ImageBuildOptions) bool {
+			return len(options.Tags) == 1 && options.Tags[0] == expected
+		})).
+		Return(build.ImageBuildResponse{Body: io.NopCloser(strings.NewReader(""))}, nil).
+		Once()
+
+	config, err := provisioner.ConfigWithNodeImageForTest(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, config.Nodes, 1, "the implicit control-plane node must boot from the derived image")
+	assert.Equal(t, v1alpha4.ControlPlaneRole, config.Nodes[0].Role)
+	assert.Equal(t, expected, config.Nodes[0].Image)
+}
+
+func TestConfigWithNodeImage_ReusesCachedImage(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+	provisioner := kindprovisioner.NewProvisionerWithRunner(
+		&v1alpha4.Cluster{Nodes: []v1alpha4.Node{
+			{Role: v1alpha4.ControlPlaneRole, Image: "kindest/node:v1.35.0"},
+			{Role: v1alpha4.WorkerRole, Image: "kindest/node:v1.35.0"},
+		}},
+		"",
+		nil,
+		nil,
+		&mockCommandRunner{},
+	).WithDockerClientForTest(mockClient)
+	provisioner.WithNodeImageDockerfile(nfsDockerfile)
+
+	expected := kindprovisioner.DerivedNodeImage("kindest/node:v1.35.0", nfsDockerfile)
+
+	// One inspect per run for both nodes sharing a base image, and no build.
+	mockClient.EXPECT().
+		ImageInspect(mock.Anything, expected).
+		Return(image.InspectResponse{}, nil).
+		Twice()
+
+	config, err := provisioner.ConfigWithNodeImageForTest(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, config.Nodes, 2)
+	assert.Equal(t, expected, config.Nodes[0].Image)
+	assert.Equal(t, expected, config.Nodes[1].Image)
+
+	// The provisioner's own config keeps the base images, so a second run
+	// derives the same tag instead of deriving from the derived image.
+	again, err := provisioner.ConfigWithNodeImageForTest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, again.Nodes[0].Image)
+}
@@ -9,6 +9,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	"github.com/devantler-tech/ksail/v7/pkg/fsutil/marshaller"
 	"github.com/devantler-tech/ksail/v7/pkg/k8s"
 	runner "github.com/devantler-tech/ksail/v7/pkg/runner"
@@ -134,6 +135,12 @@ type Provisioner struct {
 	infraProvider     provider.Provider
 	runner            runner.CommandRunner
 	componentDetector *detector.ComponentDetector
+	// nodeImageDockerfile is the Dockerfile snippet baked into a derived node
+	// image before create (see node_image.go); empty boots the base images.
+	nodeImageDockerfile string
+	// dockerClient builds and inspects derived node images. Created on first
+	// use when the provisioner was not constructed with one.
+	dockerClient docker.Client
 	// waitForReady blocks until the cluster is genuinely ready (API reachable
 	// and a basic authorized read succeeds). It is a seam so tests can run
 	// Start() without a live cluster. Defaults to k8s.WaitForClusterReady.
@@ -199,6 +206,11 @@ func (k *Provisioner) SetComponentDetector(d *detector.ComponentDetector) {
 func (k *Provisioner) Create(ctx context.Context, name string) error {
 	target := setName(name, k.kindConfig.Name)
 
+	kindConfig, err := k.configWithNodeImage(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Serialize config to temp file (required by kind's Cobra command)
 	tmpFile, err := os.CreateTemp("", "kind-config-*.yaml")
 	if err != nil {
@@ -212,7 +224,7 @@ func (k *Provisioner) Create(ctx context.Context, name string) error {
 
 	marshaller := marshaller.NewYAMLMarshaller[*v1alpha4.Cluster]()
 
-	configYAML, err := marshaller.Marshal(k.kindConfig)
+	configYAML, err := marshaller.Marshal(kindConfig)
 	if err != nil {
 		return fmt.Errorf("marshal kind config: %w", err)
 	}
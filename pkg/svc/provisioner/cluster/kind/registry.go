@@ -65,7 +65,7 @@ func getEntriesToInject(
 		clusterName = kindConfig.Name
 	}
 
-	mountedHosts := buildMountedHostsSet(kindConfig)
+	mountedHosts := MountedMirrorHosts(kindConfig)
 	entries := registry.BuildMirrorEntries(mirrorSpecs, clusterName, nil, nil, nil)
 
 	var entriesToInject []registry.MirrorEntry
@@ -102,10 +102,10 @@ func getKindNodesForCluster(
 	return nodes, nil
 }
 
-// buildMountedHostsSet returns a set of registry hosts that have extraMounts configured
+// MountedMirrorHosts returns a set of registry hosts that have extraMounts configured
 // in the Kind config. These hosts have scaffolded hosts.toml files that will be mounted
 // directly into the containers.
-func buildMountedHostsSet(kindConfig *v1alpha4.Cluster) map[string]bool {
+func MountedMirrorHosts(kindConfig *v1alpha4.Cluster) map[string]bool {
 	mountedHosts := make(map[string]bool)
 
 	if kindConfig == nil {
@@ -184,7 +184,7 @@ func SetupRegistries(
 ) error {
 	err := registry.SetupMirrorSpecRegistries(
 		ctx, mirrorSpecs, clusterName, dockerClient,
-		kindconfigmanager.DefaultNetworkName, writer,
+		kindconfigmanager.NetworkName(), writer,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to setup kind registries: %w", err)
@@ -204,7 +204,7 @@ func ConnectRegistriesToNetwork(
 ) error {
 	errConnect := registry.ConnectMirrorSpecsToNetwork(
 		ctx, mirrorSpecs, clusterName,
-		kindconfigmanager.DefaultNetworkName, dockerClient, writer,
+		kindconfigmanager.NetworkName(), dockerClient, writer,
 	)
 	if errConnect != nil {
 		return fmt.Errorf("failed to connect kind registries to network: %w", errConnect)
@@ -223,7 +223,7 @@ func CleanupRegistries(
 ) error {
 	err := registry.CleanupMirrorSpecRegistries(
 		ctx, mirrorSpecs, clusterName, dockerClient,
-		deleteVolumes, kindconfigmanager.DefaultNetworkName,
+		deleteVolumes, kindconfigmanager.NetworkName(),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to cleanup kind registries: %w", err)
@@ -0,0 +1,53 @@
+package kindprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+)
+
+// nodeCACertificatesDir is the directory update-ca-certificates reads extra CA
+// certificates from on the Debian-based Kind node image.
+const nodeCACertificatesDir = "/usr/local/share/ca-certificates"
+
+// InstallTrustBundle adds the PEM bundle to the OS trust store of every node of
+// the Kind cluster and restarts containerd so image pulls trust it immediately.
+// Running pods are unaffected by the containerd restart.
+func InstallTrustBundle(
+	ctx context.Context,
+	dockerClient dockerclient.Client,
+	clusterName string,
+	bundleName string,
+	bundle string,
+) error {
+	nodes, err := listKindNodes(ctx, dockerClient, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to list Kind nodes: %w", err)
+	}
+
+	if len(nodes) == 0 {
+		return fmt.Errorf("%w: %s", ErrNoKindNodes, clusterName)
+	}
+
+	delimiter, err := registry.GenerateRandomDelimiter()
+	if err != nil {
+		return err
+	}
+
+	certPath := registry.EscapeShellArg(nodeCACertificatesDir + "/" + bundleName + ".crt")
+	script := fmt.Sprintf(
+		"mkdir -p %s && cat > %s << '%s'\n%s\n%s\nupdate-ca-certificates && systemctl restart containerd",
+		registry.EscapeShellArg(nodeCACertificatesDir), certPath, delimiter, bundle, delimiter,
+	)
+
+	for _, node := range nodes {
+		err = registry.ExecNodeScript(ctx, dockerClient, node, script)
+		if err != nil {
+			return fmt.Errorf("failed to install trust bundle on node %s: %w", node, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package kindprovisioner_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	docker "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	kindprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/kind"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/docker/docker/api/types/container"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testBundle = "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"
+
+func TestInstallTrustBundle_WritesBundleAndRestartsContainerd(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return([]container.Summary{
+			{
+				Names:  []string{"/dev-control-plane"},
+				Labels: map[string]string{"io.x-k8s.kind.cluster": "dev"},
+			},
+			{
+				Names:  []string{"/other-control-plane"},
+				Labels: map[string]string{"io.x-k8s.kind.cluster": "other"},
+			},
+		}, nil).
+		Once()
+
+	mockClient.EXPECT().
+		ContainerExecCreate(ctx, "dev-control-plane", mock.MatchedBy(func(opts container.ExecOptions) bool {
+			script := opts.Cmd[len(opts.Cmd)-1]
+
+			return strings.Contains(script, "/usr/local/share/ca-certificates/ksail-ca-bundle.crt") &&
+				strings.Contains(script, testBundle) &&
+				strings.HasSuffix(script, "update-ca-certificates && systemctl restart containerd")
+		})).
+		Return(container.ExecCreateResponse{ID: "exec-id"}, nil).
+		Once()
+	mockClient.EXPECT().
+		ContainerExecAttach(ctx, "exec-id", mock.Anything).
+		Return(newMockHijackedResponse(), nil).
+		Once()
+	mockClient.EXPECT().
+		ContainerExecInspect(ctx, "exec-id").
+		Return(container.ExecInspect{ExitCode: 0}, nil).
+		Once()
+
+	err := kindprovisioner.InstallTrustBundle(ctx, mockClient, "dev", "ksail-ca-bundle", testBundle)
+	require.NoError(t, err)
+}
+
+func TestInstallTrustBundle_ExecFailure(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return([]container.Summary{{
+			Names:  []string{"/dev-control-plane"},
+			Labels: map[string]string{"io.x-k8s.kind.cluster": "dev"},
+		}}, nil).
+		Once()
+	mockClient.EXPECT().
+		ContainerExecCreate(ctx, "dev-control-plane", mock.Anything).
+		Return(container.ExecCreateResponse{ID: "exec-id"}, nil).
+		Once()
+	mockClient.EXPECT().
+		ContainerExecAttach(ctx, "exec-id", mock.Anything).
+		Return(newMockHijackedResponse(), nil).
+		Once()
+	mockClient.EXPECT().
+		ContainerExecInspect(ctx, "exec-id").
+		Return(container.ExecInspect{ExitCode: 1}, nil).
+		Once()
+
+	err := kindprovisioner.InstallTrustBundle(ctx, mockClient, "dev", "ksail-ca-bundle", testBundle)
+	require.ErrorIs(t, err, registry.ErrExecFailed)
+	require.ErrorContains(t, err, "dev-control-plane")
+}
+
+func TestInstallTrustBundle_NoNodes(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().
+		ContainerList(ctx, mock.Anything).
+		Return(nil, nil).
+		Once()
+
+	err := kindprovisioner.InstallTrustBundle(ctx, mockClient, "dev", "ksail-ca-bundle", testBundle)
+	require.ErrorIs(t, err, kindprovisioner.ErrNoKindNodes)
+}
@@ -61,6 +61,17 @@ func (k *Provisioner) DiffConfig(
 		})
 	}
 
+	// NodeImageDockerfile change requires recreate (nodes boot from the derived image)
+	if oldSpec.Vanilla.NodeImageDockerfile != newSpec.Vanilla.NodeImageDockerfile {
+		result.RecreateRequired = append(result.RecreateRequired, clusterupdate.Change{
+			Field:    "vanilla.nodeImageDockerfile",
+			OldValue: oldSpec.Vanilla.NodeImageDockerfile,
+			NewValue: newSpec.Vanilla.NodeImageDockerfile,
+			Category: clusterupdate.ChangeCategoryRecreateRequired,
+			Reason:   "Kind nodes boot from the derived node image built at cluster creation",
+		})
+	}
+
 	// Node count changes require recreate
 	// Kind node configuration comes from kind.yaml, not ClusterSpec
 	// Changes to the Kind config (nodes, networking, etc.) require cluster recreation
@@ -100,7 +111,9 @@ func (k *Provisioner) GetCurrentConfig(
 
 	// jscpd:ignore-end
 
-	err := clusterupdate.MergePersistedState(spec, clusterName)
+	err := clusterupdate.MergePersistedStateWithFallback(
+		ctx, spec, clusterName, k.componentDetector.Clientset(),
+	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("merge persisted state: %w", err)
 	}
@@ -488,3 +488,20 @@ func TestCreateProvisioner_KubeconfigEnvListDeduplicates(t *testing.T) {
 	require.NotNil(t, provisioner)
 	assert.Equal(t, second, provisioner.KubeConfigForTest())
 }
+
+func TestProvisioner_DiffConfig_NodeImageDockerfileChange(t *testing.T) {
+	t.Parallel()
+
+	provisioner, _, _ := newProvisionerForTest(t)
+
+	oldSpec := &v1alpha1.ClusterSpec{}
+	newSpec := &v1alpha1.ClusterSpec{
+		Vanilla: v1alpha1.OptionsVanilla{NodeImageDockerfile: "RUN apt-get install -y nfs-common"},
+	}
+
+	result, err := provisioner.DiffConfig(context.Background(), "test-cluster", oldSpec, newSpec)
+
+	require.NoError(t, err)
+	require.Len(t, result.RecreateRequired, 1, "changing the node image snippet requires recreate")
+	assert.Equal(t, "vanilla.nodeImageDockerfile", result.RecreateRequired[0].Field)
+}
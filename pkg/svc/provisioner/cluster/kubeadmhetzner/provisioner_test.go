@@ -63,7 +63,7 @@ func (f *fakeInfra) EnsureNetwork(
 func (f *fakeInfra) EnsureFirewall(
 	_ context.Context,
 	_ string,
-	_ []string,
+	_, _ []string,
 ) (*hcloud.Firewall, error) {
 	return &hcloud.Firewall{}, f.ensureFirewallErr
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -23,6 +24,16 @@ const (
 	defaultTalosAPIRetryBaseWait    = 5 * time.Second
 	defaultTalosAPIRetryMaxWait     = 20 * time.Second
 
+	// ciTalosAPIRetryMaxAttempts and ciHealthCheckGracePeriod are the adaptive
+	// defaults used when KSail runs on a CI machine (CI env var set). Shared,
+	// loaded runners are where machines are slowest to accept configs and
+	// report healthy, so retries and readiness waits get extra headroom there.
+	ciTalosAPIRetryMaxAttempts = 5
+	ciHealthCheckGracePeriod   = 5 * time.Minute
+
+	// ciEnvVar is the environment variable CI systems set to signal a CI run.
+	ciEnvVar = "CI"
+
 	// grpcUnavailable and grpcDeadlineExceeded are the numeric gRPC status
 	// codes for Unavailable (14) and DeadlineExceeded (4). The raw constants
 	// are used because depguard forbids importing google.golang.org/grpc from
@@ -40,6 +51,9 @@ type talosAPIRetryConfig struct {
 	maxAttempts int
 	baseWait    time.Duration
 	maxWait     time.Duration
+	// callTimeout bounds each attempt. Zero leaves attempts bounded only by
+	// the caller's context.
+	callTimeout time.Duration
 }
 
 // defaultTalosAPIRetryConfig returns the default retry configuration for
@@ -52,14 +66,61 @@ func defaultTalosAPIRetryConfig() talosAPIRetryConfig {
 	}
 }
 
+// talosAPIRetryConfigFor returns the retry configuration for options: explicit
+// values from spec.cluster.talos.api win, otherwise the adaptive defaults
+// apply (more attempts on CI machines).
+func talosAPIRetryConfigFor(options *Options) talosAPIRetryConfig {
+	cfg := defaultTalosAPIRetryConfig()
+	if runningInCI() {
+		cfg.maxAttempts = ciTalosAPIRetryMaxAttempts
+	}
+
+	if options == nil {
+		return cfg
+	}
+
+	if options.APIMaxAttempts > 0 {
+		cfg.maxAttempts = options.APIMaxAttempts
+	}
+
+	cfg.callTimeout = options.APICallTimeout
+
+	return cfg
+}
+
+// healthCheckGracePeriod returns the extra time added to cluster and node
+// readiness waits: the configured spec.cluster.talos.api.healthCheckGracePeriod,
+// or ciHealthCheckGracePeriod on CI machines when unset.
+func (p *Provisioner) healthCheckGracePeriod() time.Duration {
+	if p.options != nil && p.options.HealthCheckGracePeriod > 0 {
+		return p.options.HealthCheckGracePeriod
+	}
+
+	if runningInCI() {
+		return ciHealthCheckGracePeriod
+	}
+
+	return 0
+}
+
+// runningInCI reports whether the CI environment variable marks this process
+// as running on a CI machine. "false" and "0" count as unset.
+func runningInCI() bool {
+	value := strings.TrimSpace(os.Getenv(ciEnvVar))
+
+	return value != "" && value != "false" && value != "0"
+}
+
 // retryTransientTalosAPICall runs operation with bounded retries and
 // exponential backoff for transient gRPC failures (Unavailable,
 // DeadlineExceeded). Each attempt must create its own Talos client inside
 // operation so a fresh connection is dialed. Non-transient errors and
 // parent-context cancellation fail
 // immediately; once attempts are exhausted the last error is returned wrapped
-// in errRetriesExhausted. target names the node and description the operation
-// for retry log lines.
+// in errRetriesExhausted. When a per-call timeout is configured each attempt
+// runs under its own deadline, so a hung call is retried instead of consuming
+// the caller's whole budget. target names the node and description the
+// operation for retry log lines.
 func (p *Provisioner) retryTransientTalosAPICall(
 	ctx context.Context,
 	target, description string,
@@ -67,13 +128,15 @@ func (p *Provisioner) retryTransientTalosAPICall(
 ) error {
 	cfg := p.talosAPIRetry
 	if cfg.maxAttempts <= 0 {
-		cfg = defaultTalosAPIRetryConfig()
+		cfg.maxAttempts = defaultTalosAPIRetryMaxAttempts
+		cfg.baseWait = defaultTalosAPIRetryBaseWait
+		cfg.maxWait = defaultTalosAPIRetryMaxWait
 	}
 
 	var lastErr error
 
 	for attempt := 1; attempt <= cfg.maxAttempts; attempt++ {
-		lastErr = operation(ctx)
+		lastErr = runTalosAPIAttempt(ctx, cfg.callTimeout, operation)
 		if lastErr == nil {
 			return nil
 		}
@@ -107,6 +170,23 @@ func (p *Provisioner) retryTransientTalosAPICall(
 	return fmt.Errorf("%w: %w", errRetriesExhausted, lastErr)
 }
 
+// runTalosAPIAttempt runs one attempt of operation, bounded by timeout when it
+// is positive.
+func runTalosAPIAttempt(
+	ctx context.Context,
+	timeout time.Duration,
+	operation func(ctx context.Context) error,
+) error {
+	if timeout <= 0 {
+		return operation(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return operation(attemptCtx)
+}
+
 // sleepWithContext waits for d to elapse, returning ctx.Err() early if the context is cancelled.
 func sleepWithContext(ctx context.Context, d time.Duration) error {
 	timer := time.NewTimer(d)
@@ -2,6 +2,7 @@ package talosprovisioner
 
 import (
 	"context"
+	"time"
 
 	talosclient "github.com/siderolabs/talos/pkg/machinery/client"
 )
@@ -32,3 +33,13 @@ func (p *Provisioner) DialTalosClientWithRetryForTest(
 ) (*talosclient.Client, error) {
 	return p.dialTalosClientWithRetry(ctx, nodeIP, description)
 }
+
+// TalosAPIRetryMaxAttemptsForTest exposes the resolved retry attempt count for unit testing.
+func (p *Provisioner) TalosAPIRetryMaxAttemptsForTest() int {
+	return p.talosAPIRetry.maxAttempts
+}
+
+// HealthCheckGracePeriodForTest exposes healthCheckGracePeriod for unit testing.
+func (p *Provisioner) HealthCheckGracePeriodForTest() time.Duration {
+	return p.healthCheckGracePeriod()
+}
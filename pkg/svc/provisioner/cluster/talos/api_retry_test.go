@@ -285,3 +285,81 @@ func TestRetryTransientTalosAPICall_BackoffInterrupted(t *testing.T) {
 	require.ErrorIs(t, err, context.DeadlineExceeded)
 	assert.Equal(t, 1, calls)
 }
+
+func TestRetryTransientTalosAPICall_CallTimeoutBoundsEachAttempt(t *testing.T) {
+	t.Parallel()
+
+	logBuf := &bytes.Buffer{}
+	options := talosprovisioner.NewOptions().WithAPICallTimeout(10 * time.Millisecond)
+	provisioner := talosprovisioner.NewProvisioner(nil, options).
+		WithLogWriter(logBuf).
+		WithTalosAPIRetryConfig(testRetryMaxAttempts, testRetryBaseWait, testRetryMaxWait)
+
+	calls := 0
+
+	err := provisioner.RetryTransientTalosAPICallForTest(
+		t.Context(), "1.2.3.4", "Config apply",
+		func(ctx context.Context) error {
+			calls++
+
+			<-ctx.Done()
+
+			return fmt.Errorf("apply: %w", ctx.Err())
+		})
+
+	require.ErrorIs(t, err, talosprovisioner.ErrRetriesExhaustedForTest)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, testRetryMaxAttempts, calls)
+}
+
+func TestTalosAPIRetryConfig_AdaptiveDefaults(t *testing.T) {
+	tests := []struct {
+		name         string
+		ci           string
+		options      *talosprovisioner.Options
+		wantAttempts int
+		wantGrace    time.Duration
+	}{
+		{
+			name:         "LocalDefaults",
+			ci:           "",
+			options:      nil,
+			wantAttempts: 3,
+			wantGrace:    0,
+		},
+		{
+			name:         "CIFalseUsesLocalDefaults",
+			ci:           "false",
+			options:      nil,
+			wantAttempts: 3,
+			wantGrace:    0,
+		},
+		{
+			name:         "CIDefaults",
+			ci:           "true",
+			options:      nil,
+			wantAttempts: 5,
+			wantGrace:    5 * time.Minute,
+		},
+		{
+			name: "ExplicitValuesWinOnCI",
+			ci:   "true",
+			options: talosprovisioner.NewOptions().
+				WithAPIMaxAttempts(8).
+				WithHealthCheckGracePeriod(15 * time.Minute),
+			wantAttempts: 8,
+			wantGrace:    15 * time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("CI", test.ci)
+
+			provisioner := talosprovisioner.NewProvisioner(nil, test.options)
+
+			assert.Equal(t, test.wantAttempts, provisioner.TalosAPIRetryMaxAttemptsForTest())
+			assert.Equal(t, test.wantGrace, provisioner.HealthCheckGracePeriodForTest())
+		})
+	}
+}
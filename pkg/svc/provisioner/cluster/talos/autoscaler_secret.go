@@ -9,7 +9,8 @@ import (
 )
 
 // syncHetznerFirewallRules synchronizes the Hetzner Cloud Firewall rules to the
-// hardened set, migrating clusters created with the old insecure rules.
+// hardened set, migrating clusters created with the old insecure rules and
+// applying changes to the configured API and NodePort CIDR allow-lists.
 // No-ops when the provisioner was not initialized with Hetzner opts.
 func (p *Provisioner) syncHetznerFirewallRules(
 	ctx context.Context,
@@ -24,7 +25,12 @@ func (p *Provisioner) syncHetznerFirewallRules(
 		return err
 	}
 
-	syncErr := hzProvider.SyncFirewallRules(ctx, clusterName, p.hetznerOpts.AllowedCIDRs)
+	syncErr := hzProvider.SyncFirewallRules(
+		ctx,
+		clusterName,
+		p.hetznerOpts.AllowedCIDRs,
+		p.hetznerOpts.NodePortCIDRs,
+	)
 	if syncErr != nil {
 		return fmt.Errorf("failed to sync Hetzner firewall rules for %s: %w", clusterName, syncErr)
 	}
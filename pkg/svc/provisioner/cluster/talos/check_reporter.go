@@ -27,12 +27,15 @@ func (r *checkReporter) Update(condition conditions.Condition) {
 
 // runClusterChecks runs CNI-aware readiness checks on a cluster.
 // It selects the appropriate checks based on CNI configuration, logs progress,
-// and waits for all checks to pass within the given timeout.
+// and waits for all checks to pass within the given timeout, extended by the
+// configured health-check grace period.
 func (p *Provisioner) runClusterChecks(
 	ctx context.Context,
 	clusterAccess *access.Adapter,
 	timeout time.Duration,
 ) error {
+	timeout += p.healthCheckGracePeriod()
+
 	checks := p.clusterReadinessChecks()
 
 	if (p.talosConfigs != nil && p.talosConfigs.IsCNIDisabled()) || p.options.SkipCNIChecks {
@@ -28,6 +28,9 @@ var (
 	ErrPrivateNetworkUnreachable = errors.New(
 		"hetzner private network is unreachable from ksail",
 	)
+	// ErrInvalidNetworkCIDR is returned when the Docker network CIDR cannot hold the
+	// control-plane load balancer of a multi-control-plane cluster.
+	ErrInvalidNetworkCIDR = errors.New("invalid network CIDR")
 	// ErrInvalidPatch is returned when a patch file is invalid.
 	ErrInvalidPatch = errors.New("invalid patch file")
 	// ErrStorageHealthTimeout is returned when the opt-in between-node storage-health
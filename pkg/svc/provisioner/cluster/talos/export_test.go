@@ -438,6 +438,11 @@ func NthIPInNetworkForTest(prefix netip.Prefix, offset int) (netip.Addr, error)
 	return nthIPInNetwork(prefix, offset)
 }
 
+// DockerLoadBalancerIPForTest exposes dockerLoadBalancerIP for unit testing.
+func DockerLoadBalancerIPForTest(networkCIDR string) (netip.Addr, error) {
+	return dockerLoadBalancerIP(networkCIDR)
+}
+
 // ExtractTagFromImageForTest exposes extractTagFromImage for unit testing.
 func ExtractTagFromImageForTest(image string) string {
 	return extractTagFromImage(image)
@@ -1086,3 +1091,9 @@ func (p *KubernetesProvisioner) PublishConnectorKubeconfigForTest(
 ) error {
 	return p.publishConnectorKubeconfig(ctx, clusterName, raw)
 }
+
+// FirstControlPlanePortsForTest exposes the host port mappings of the first Docker
+// control-plane node.
+func (p *Provisioner) FirstControlPlanePortsForTest() []string {
+	return p.firstControlPlanePorts()
+}
@@ -89,6 +89,9 @@ func newProvisionerFromOptions(
 		WithKubernetesVersion(opts.KubernetesVersion).
 		WithDrainTimeout(opts.DrainTimeout.Duration).
 		WithStorageHealthTimeout(opts.StorageHealthTimeout.Duration).
+		WithAPIMaxAttempts(int(opts.API.MaxAttempts)).
+		WithAPICallTimeout(opts.API.CallTimeout.Duration).
+		WithHealthCheckGracePeriod(opts.API.HealthCheckGracePeriod.Duration).
 		WithSkipCNIChecks(skipCNIChecks)
 
 	// Override the default Talos container image when a version pin is set.
@@ -81,7 +81,7 @@ func (p *Provisioner) waitForNodeReady(
 	nodeIP string,
 	talosConfig *clientconfig.Config,
 ) error {
-	timeout := clusterReadinessTimeout
+	timeout := clusterReadinessTimeout + p.healthCheckGracePeriod()
 
 	err := retry.Constant(timeout, retry.WithUnits(longRetryInterval)).
 		RetryWithContext(ctx, func(ctx context.Context) error {
@@ -159,7 +159,7 @@ func (p *Provisioner) waitForKubernetesReady(
 	ctx context.Context,
 	talosClient *talosclient.Client,
 ) error {
-	timeout := clusterReadinessTimeout
+	timeout := clusterReadinessTimeout + p.healthCheckGracePeriod()
 
 	err := retry.Constant(timeout, retry.WithUnits(longRetryInterval)).
 		RetryWithContext(ctx, func(ctx context.Context) error {
@@ -573,7 +573,7 @@ func (p *KubernetesProvisioner) setupDinDMirrors(
 	// Use the nested (DinD) MTU so registry/upstream and node↔mirror traffic fits the
 	// DinD pod's path MTU (1500 drops large TLS-handshake packets — see NestedNetworkMTU).
 	err = registry.EnsureNetwork(
-		ctx, dindClient, clusterName, networkCIDR, registry.NestedNetworkMTU, writer,
+		ctx, dindClient, clusterName, networkCIDR, false, registry.NestedNetworkMTU, writer,
 	)
 	if err != nil {
 		return fmt.Errorf("ensure nested cluster network: %w", err)
@@ -0,0 +1,184 @@
+package talosprovisioner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
+)
+
+// ipv4Bits is the address width of an IPv4 prefix.
+const ipv4Bits = 32
+
+// needsDockerLoadBalancer reports whether a new Talos-in-Docker cluster fronts its
+// control planes with a load balancer. A single control plane is reached directly,
+// matching clusters created before load balancers existed.
+func (p *Provisioner) needsDockerLoadBalancer() bool {
+	return p.options.ControlPlaneNodes > 1
+}
+
+// dockerLoadBalancerIP returns the static address reserved for the control-plane load
+// balancer: the last usable address of the cluster network. Node addresses grow upward
+// from the start of the network, so the two ranges only meet on a full network.
+func dockerLoadBalancerIP(networkCIDR string) (netip.Addr, error) {
+	cidr, err := netip.ParsePrefix(networkCIDR)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid network CIDR: %w", err)
+	}
+
+	if !cidr.Addr().Is4() || cidr.Bits() > ipv4Bits-2 {
+		return netip.Addr{}, fmt.Errorf(
+			"%w: %s has no room for a load balancer address",
+			ErrInvalidNetworkCIDR, networkCIDR,
+		)
+	}
+
+	// Skip the broadcast address: the last usable address is size-2 from the base.
+	size := 1 << (ipv4Bits - cidr.Bits())
+
+	return nthIPInNetwork(cidr.Masked(), size-2)
+}
+
+// useDockerLoadBalancerEndpoint points the node configs at the load balancer address,
+// so the API server certificates and every kubelet and control-plane component reach
+// the Kubernetes API through it rather than through the first control plane.
+func (p *Provisioner) useDockerLoadBalancerEndpoint() error {
+	if !p.needsDockerLoadBalancer() || p.talosConfigs == nil {
+		return nil
+	}
+
+	lbIP, err := dockerLoadBalancerIP(p.options.NetworkCIDR)
+	if err != nil {
+		return err
+	}
+
+	configs, err := p.talosConfigs.WithEndpoint(lbIP.String())
+	if err != nil {
+		return fmt.Errorf("failed to point configs at the load balancer: %w", err)
+	}
+
+	// The load balancer only forwards the Kubernetes API, so the talosconfig keeps
+	// addressing the Talos API of every control plane directly.
+	endpoints, err := p.dockerControlPlaneIPs()
+	if err != nil {
+		return err
+	}
+
+	talosConfig := configs.Bundle().TalosConfig()
+	if talosConfig != nil {
+		if talosCtx, ok := talosConfig.Contexts[talosConfig.Context]; ok {
+			talosCtx.Endpoints = endpoints
+		}
+	}
+
+	p.talosConfigs = configs
+
+	return nil
+}
+
+// dockerControlPlaneIPs returns the static addresses buildNodeRequests assigns to the
+// control-plane nodes.
+func (p *Provisioner) dockerControlPlaneIPs() ([]string, error) {
+	cidr, err := netip.ParsePrefix(p.options.NetworkCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network CIDR: %w", err)
+	}
+
+	ips := make([]string, 0, p.options.ControlPlaneNodes)
+
+	for nodeIndex := range p.options.ControlPlaneNodes {
+		nodeIP, ipErr := calculateNodeIP(cidr, RoleControlPlane, nodeIndex, 0)
+		if ipErr != nil {
+			return nil, fmt.Errorf("failed to calculate IP for control-plane-%d: %w", nodeIndex+1, ipErr)
+		}
+
+		ips = append(ips, nodeIP.String())
+	}
+
+	return ips, nil
+}
+
+// ensureDockerLoadBalancer creates or updates the load balancer of clusterName so it
+// forwards to every current control-plane container, and returns the host endpoint
+// the Kubernetes API is published on.
+func (p *Provisioner) ensureDockerLoadBalancer(ctx context.Context, clusterName string) (string, error) {
+	dockerProv, err := p.dockerNodeProvider()
+	if err != nil {
+		return "", err
+	}
+
+	lbIP, err := dockerLoadBalancerIP(p.options.NetworkCIDR)
+	if err != nil {
+		return "", err
+	}
+
+	controlPlanes, err := p.listDockerNodesByRole(ctx, clusterName, RoleControlPlane)
+	if err != nil {
+		return "", fmt.Errorf("failed to list control-plane containers: %w", err)
+	}
+
+	backends := make([]string, 0, len(controlPlanes))
+
+	for _, ctr := range controlPlanes {
+		if ip := containerIP(ctr, clusterName); ip != "" {
+			backends = append(backends, ip)
+		}
+	}
+
+	spec := dockerprovider.LoadBalancerSpec{
+		ClusterName: clusterName,
+		Network:     clusterName,
+		IP:          lbIP.String(),
+		Backends:    backends,
+	}
+
+	if p.options.APIServerPort != 0 {
+		spec.HostPort = strconv.Itoa(int(p.options.APIServerPort))
+	}
+
+	endpoint, err := dockerProv.EnsureLoadBalancer(ctx, spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to ensure control-plane load balancer: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(p.logWriter, "Control-plane load balancer ready at %s (%d backends)\n",
+		endpoint, len(backends))
+
+	return endpoint, nil
+}
+
+// syncDockerLoadBalancer re-targets an existing load balancer at the current control
+// planes after they were scaled. Clusters without a load balancer are left untouched.
+func (p *Provisioner) syncDockerLoadBalancer(ctx context.Context, clusterName string) error {
+	_, err := p.dockerLoadBalancerEndpoint(ctx, clusterName)
+	if errors.Is(err, dockerprovider.ErrLoadBalancerNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = p.ensureDockerLoadBalancer(ctx, clusterName)
+
+	return err
+}
+
+// dockerLoadBalancerEndpoint returns the host endpoint of the load balancer of
+// clusterName, or an error wrapping ErrLoadBalancerNotFound when it has none.
+func (p *Provisioner) dockerLoadBalancerEndpoint(ctx context.Context, clusterName string) (string, error) {
+	dockerProv, err := p.dockerNodeProvider()
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, err := dockerProv.LoadBalancerEndpoint(ctx, clusterName)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve load balancer endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
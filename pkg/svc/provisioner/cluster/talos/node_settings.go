@@ -0,0 +1,142 @@
+package talosprovisioner
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	talosconfig "github.com/siderolabs/talos/pkg/machinery/config"
+	talosv1alpha1 "github.com/siderolabs/talos/pkg/machinery/config/types/v1alpha1"
+	"github.com/siderolabs/talos/pkg/provision"
+)
+
+// applyNodeSettings customizes a Docker node request with the resource labels and
+// the spec.cluster.nodes entries that select the index-th node of role: CPU and
+// memory become container limits, and labels and taints are written into the node's
+// own machine config so Talos registers them with the node.
+func (p *Provisioner) applyNodeSettings(
+	request *provision.NodeRequest,
+	role v1alpha1.NodeRole,
+	index int,
+) error {
+	labeled, err := p.patchResourceLabels(request.Config)
+	if err != nil {
+		return fmt.Errorf("failed to patch machine config of %s: %w", request.Name, err)
+	}
+
+	request.Config = labeled
+
+	settings := v1alpha1.ClusterSpec{Nodes: p.options.Nodes}.NodeSettingsFor(role, index)
+	if settings.IsZero() {
+		return nil
+	}
+
+	nanoCPUs, err := settings.Resources.NanoCPUs()
+	if err != nil {
+		return fmt.Errorf("%s: %w", request.Name, err)
+	}
+
+	memory, err := settings.Resources.MemoryBytes()
+	if err != nil {
+		return fmt.Errorf("%s: %w", request.Name, err)
+	}
+
+	request.NanoCPUs = nanoCPUs
+	request.Memory = memory
+
+	if len(settings.Labels) == 0 && len(settings.Taints) == 0 {
+		return nil
+	}
+
+	patched, err := patchNodeLabelsAndTaints(request.Config, settings)
+	if err != nil {
+		return fmt.Errorf("failed to patch machine config of %s: %w", request.Name, err)
+	}
+
+	request.Config = patched
+
+	return nil
+}
+
+// patchNodeLabelsAndTaints returns a copy of cfg with settings' labels and taints
+// added to machine.nodeLabels and machine.nodeTaints, using the "<value>:<effect>"
+// taint encoding Talos expects.
+func patchNodeLabelsAndTaints(
+	cfg talosconfig.Provider,
+	settings v1alpha1.NodeSettings,
+) (talosconfig.Provider, error) {
+	patched, err := cfg.PatchV1Alpha1(func(machineCfg *talosv1alpha1.Config) error {
+		if machineCfg.MachineConfig == nil {
+			machineCfg.MachineConfig = &talosv1alpha1.MachineConfig{}
+		}
+
+		if len(settings.Labels) > 0 && machineCfg.MachineConfig.MachineNodeLabels == nil {
+			machineCfg.MachineConfig.MachineNodeLabels = map[string]string{}
+		}
+
+		for key, value := range settings.Labels {
+			machineCfg.MachineConfig.MachineNodeLabels[key] = value
+		}
+
+		if len(settings.Taints) > 0 && machineCfg.MachineConfig.MachineNodeTaints == nil {
+			machineCfg.MachineConfig.MachineNodeTaints = map[string]string{}
+		}
+
+		for _, taint := range settings.Taints {
+			machineCfg.MachineConfig.MachineNodeTaints[taint.Key] = taint.Value + ":" + string(taint.Effect)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch node labels and taints: %w", err)
+	}
+
+	return patched, nil
+}
+
+// patchResourceLabels returns a copy of cfg with the resource labels added to
+// machine.nodeLabels. Labels the config already sets keep their value. cfg is
+// returned as is when there are no resource labels.
+func (p *Provisioner) patchResourceLabels(cfg talosconfig.Provider) (talosconfig.Provider, error) {
+	labels := p.options.ResourceLabels
+	if len(labels) == 0 {
+		return cfg, nil
+	}
+
+	patched, err := cfg.PatchV1Alpha1(func(machineCfg *talosv1alpha1.Config) error {
+		if machineCfg.MachineConfig == nil {
+			machineCfg.MachineConfig = &talosv1alpha1.MachineConfig{}
+		}
+
+		if machineCfg.MachineConfig.MachineNodeLabels == nil {
+			machineCfg.MachineConfig.MachineNodeLabels = map[string]string{}
+		}
+
+		for key, value := range labels {
+			if _, ok := machineCfg.MachineConfig.MachineNodeLabels[key]; !ok {
+				machineCfg.MachineConfig.MachineNodeLabels[key] = value
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch resource labels: %w", err)
+	}
+
+	return patched, nil
+}
+
+// containerLabels returns the labels of a Talos node container: the resource labels
+// plus the Talos SDK's own, which take precedence.
+func (p *Provisioner) containerLabels(clusterName, role string) map[string]string {
+	labels := make(map[string]string, len(p.options.ResourceLabels)+3) //nolint:mnd // the Talos labels
+
+	maps.Copy(labels, p.options.ResourceLabels)
+	labels[LabelTalosOwned] = labelValueTrue
+	labels[LabelTalosClusterName] = clusterName
+	labels["talos.type"] = talosTypeFromRole(role)
+
+	return labels
+}
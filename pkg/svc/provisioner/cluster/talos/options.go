@@ -73,12 +73,42 @@ type Options struct {
 	// spec.cluster.talos.drainTimeout (or --drain-timeout).
 	DrainTimeout time.Duration
 
+	// APIMaxAttempts is the number of attempts for per-node Talos API calls that
+	// fail transiently. Zero means use the adaptive default. Sourced from
+	// spec.cluster.talos.api.maxAttempts.
+	APIMaxAttempts int
+
+	// APICallTimeout bounds each Talos API call attempt. Zero leaves attempts
+	// bounded only by the operation. Sourced from spec.cluster.talos.api.callTimeout.
+	APICallTimeout time.Duration
+
+	// HealthCheckGracePeriod is added to cluster and node readiness timeouts.
+	// Zero means use the adaptive default. Sourced from
+	// spec.cluster.talos.api.healthCheckGracePeriod.
+	HealthCheckGracePeriod time.Duration
+
 	// StorageHealthTimeout opts into the between-node storage-health gate during a
 	// rolling reboot. Zero (the default) disables the gate. When positive and a
 	// replicated storage backend (Longhorn) is detected, the roll waits up to this
 	// duration for the just-rebooted node's volumes to recover before draining the
 	// next node. Sourced from spec.cluster.talos.storageHealthTimeout.
 	StorageHealthTimeout time.Duration
+
+	// Nodes customizes individual Docker nodes: container CPU and memory limits,
+	// and labels and taints written into the node's machine config. Sourced from
+	// spec.cluster.nodes.
+	Nodes []v1alpha1.NodeSpec
+
+	// ResourceLabels are stamped onto the Docker node containers KSail creates and
+	// written into every node's machine.nodeLabels, since the Talos SDK creates the
+	// initial containers without them. Sourced from spec.cluster.resourceMetadata.labels.
+	ResourceLabels map[string]string
+
+	// APIServerPort pins the localhost port the Kubernetes API is published on, so the
+	// kubeconfig endpoint survives recreates. Zero keeps the random port picked by the
+	// Talos SDK. Only used with the Docker provider. Sourced from
+	// spec.cluster.connection.apiServerPort.
+	APIServerPort int32
 }
 
 // NewOptions creates new Options with default values.
@@ -177,6 +207,27 @@ func (o *Options) WithExtraPortMappings(ports []string) *Options {
 	return o
 }
 
+// WithNodes sets the per-node customization applied to Docker nodes.
+func (o *Options) WithNodes(nodes []v1alpha1.NodeSpec) *Options {
+	o.Nodes = nodes
+
+	return o
+}
+
+// WithResourceLabels sets the labels stamped onto Docker nodes.
+func (o *Options) WithResourceLabels(labels map[string]string) *Options {
+	o.ResourceLabels = labels
+
+	return o
+}
+
+// WithAPIServerPort sets the pinned localhost port of the Kubernetes API.
+func (o *Options) WithAPIServerPort(port int32) *Options {
+	o.APIServerPort = port
+
+	return o
+}
+
 // WithDrainTimeout sets the per-node drain timeout used during rolling updates.
 // Non-positive values are ignored, so drainNode falls back to defaultDrainTimeout.
 func (o *Options) WithDrainTimeout(timeout time.Duration) *Options {
@@ -187,6 +238,36 @@ func (o *Options) WithDrainTimeout(timeout time.Duration) *Options {
 	return o
 }
 
+// WithAPIMaxAttempts sets the attempt count for transient Talos API failures.
+// Non-positive values are ignored, so the adaptive default applies.
+func (o *Options) WithAPIMaxAttempts(attempts int) *Options {
+	if attempts > 0 {
+		o.APIMaxAttempts = attempts
+	}
+
+	return o
+}
+
+// WithAPICallTimeout sets the per-attempt timeout for Talos API calls.
+// Non-positive values are ignored, leaving attempts unbounded.
+func (o *Options) WithAPICallTimeout(timeout time.Duration) *Options {
+	if timeout > 0 {
+		o.APICallTimeout = timeout
+	}
+
+	return o
+}
+
+// WithHealthCheckGracePeriod sets the extra time allowed for readiness checks.
+// Non-positive values are ignored, so the adaptive default applies.
+func (o *Options) WithHealthCheckGracePeriod(period time.Duration) *Options {
+	if period > 0 {
+		o.HealthCheckGracePeriod = period
+	}
+
+	return o
+}
+
 // WithStorageHealthTimeout opts into the between-node storage-health gate used
 // during rolling updates. Non-positive values leave the gate disabled (the default).
 func (o *Options) WithStorageHealthTimeout(timeout time.Duration) *Options {
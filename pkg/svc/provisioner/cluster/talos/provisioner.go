@@ -224,7 +224,7 @@ func NewProvisioner(
 		kernelModuleLoader: kernelmod.EnsureBrNetfilter,
 		logWriter:          os.Stdout,
 		imagePullRetry:     defaultImagePullRetryConfig(),
-		talosAPIRetry:      defaultTalosAPIRetryConfig(),
+		talosAPIRetry:      talosAPIRetryConfigFor(options),
 	}
 
 	prov.talosClientFactory = func(ctx context.Context, ip string) (kubeconfigFetcher, error) {
@@ -297,6 +297,30 @@ func (p *Provisioner) WithTalosOptions(opts v1alpha1.OptionsTalos) *Provisioner
 	return p
 }
 
+// WithNodes sets the per-node customization (spec.cluster.nodes) applied when
+// creating Docker nodes.
+func (p *Provisioner) WithNodes(nodes []v1alpha1.NodeSpec) *Provisioner {
+	p.options.WithNodes(nodes)
+
+	return p
+}
+
+// WithResourceLabels sets the labels (spec.cluster.resourceMetadata.labels) stamped
+// onto Docker nodes.
+func (p *Provisioner) WithResourceLabels(labels map[string]string) *Provisioner {
+	p.options.WithResourceLabels(labels)
+
+	return p
+}
+
+// WithAPIServerPort sets the pinned localhost port (spec.cluster.connection.apiServerPort)
+// the Kubernetes API of Docker clusters is published on.
+func (p *Provisioner) WithAPIServerPort(port int32) *Provisioner {
+	p.options.WithAPIServerPort(port)
+
+	return p
+}
+
 // WithComponentDetector sets the component detector for querying cluster state.
 func (p *Provisioner) WithComponentDetector(d *detector.ComponentDetector) *Provisioner {
 	p.componentDetector = d
@@ -325,11 +349,9 @@ func (p *Provisioner) WithTalosAPIRetryConfig(
 	maxAttempts int,
 	baseWait, maxWait time.Duration,
 ) *Provisioner {
-	p.talosAPIRetry = talosAPIRetryConfig{
-		maxAttempts: maxAttempts,
-		baseWait:    baseWait,
-		maxWait:     maxWait,
-	}
+	p.talosAPIRetry.maxAttempts = maxAttempts
+	p.talosAPIRetry.baseWait = baseWait
+	p.talosAPIRetry.maxWait = maxWait
 
 	return p
 }
@@ -520,7 +542,7 @@ func (p *Provisioner) Start(ctx context.Context, name string) error {
 	}
 
 	// Wait for cluster to be ready based on provider type
-	switch infraProvider.(type) {
+	switch prov := infraProvider.(type) {
 	case *hetzner.Provider:
 		// Hetzner requires special readiness checks with server discovery
 		err = p.waitForHetznerClusterReadyAfterStart(ctx, clusterName)
@@ -528,6 +550,11 @@ func (p *Provisioner) Start(ctx context.Context, name string) error {
 			return fmt.Errorf("cluster started but not ready: %w", err)
 		}
 	case *dockerprovider.Provider:
+		err = prov.StartLoadBalancer(ctx, clusterName)
+		if err != nil {
+			return fmt.Errorf("failed to start load balancer of cluster %q: %w", clusterName, err)
+		}
+
 		// Docker containers start quickly, but Talos API needs time to initialize
 		err = p.waitForDockerClusterReadyAfterStart(ctx, clusterName)
 		if err != nil {
@@ -554,6 +581,13 @@ func (p *Provisioner) Stop(ctx context.Context, name string) error {
 		return fmt.Errorf("failed to stop cluster %q: %w", clusterName, err)
 	}
 
+	if dockerProv, ok := infraProvider.(*dockerprovider.Provider); ok {
+		err = dockerProv.StopLoadBalancer(ctx, clusterName)
+		if err != nil {
+			return fmt.Errorf("failed to stop load balancer of cluster %q: %w", clusterName, err)
+		}
+	}
+
 	_, _ = fmt.Fprintf(p.logWriter, "Successfully stopped Talos cluster %q\n", clusterName)
 
 	return nil
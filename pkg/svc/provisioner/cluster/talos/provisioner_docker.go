@@ -2,11 +2,15 @@ package talosprovisioner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/netip"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
 	docker "github.com/devantler-tech/ksail/v7/pkg/client/docker"
 	"github.com/devantler-tech/ksail/v7/pkg/client/netretry"
 	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
@@ -47,6 +51,13 @@ func (p *Provisioner) createDockerCluster(ctx context.Context, clusterName strin
 		return fmt.Errorf("%w: %s", ErrClusterAlreadyExists, clusterName)
 	}
 
+	// Multi-control-plane clusters reach the Kubernetes API through a load balancer,
+	// whose address must be the endpoint baked into the node configs.
+	err = p.useDockerLoadBalancerEndpoint()
+	if err != nil {
+		return err
+	}
+
 	// Use the pre-loaded configs (already have all patches applied)
 	configBundle := p.talosConfigs.Bundle()
 
@@ -75,6 +86,15 @@ func (p *Provisioner) createDockerCluster(ctx context.Context, clusterName strin
 	_, _ = fmt.Fprintf(p.logWriter, "SDK cluster creation completed [%s]\n",
 		time.Since(sdkCreateStart).Truncate(time.Second))
 
+	// The load balancer joins the network the SDK just created, before bootstrap
+	// needs the endpoint the node configs point at.
+	if p.needsDockerLoadBalancer() {
+		_, err = p.ensureDockerLoadBalancer(ctx, clusterName)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = p.saveClusterConfigs(ctx, cluster, configBundle)
 	if err != nil {
 		return err
@@ -99,6 +119,18 @@ func (p *Provisioner) deleteDockerCluster(ctx context.Context, clusterName strin
 		return err
 	}
 
+	// Remove the control-plane load balancer first: the SDK cannot remove the cluster
+	// network while it is still attached.
+	dockerProv, err := p.dockerNodeProvider()
+	if err != nil {
+		return err
+	}
+
+	err = dockerProv.DeleteLoadBalancer(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to delete control-plane load balancer: %w", err)
+	}
+
 	// Destroy cluster using Talos SDK
 	err = p.destroyClusterWithProvisioner(ctx, clusterName)
 	if err != nil {
@@ -359,6 +391,18 @@ func (p *Provisioner) setupClusterEndpoints(
 	// The Docker provisioner automatically sets this to the external endpoint
 	// (https://127.0.0.1:<mapped-port>) when the cluster is created.
 	kubernetesEndpoint := cluster.Info().KubernetesEndpoint
+
+	// Multi-control-plane clusters are reached through their load balancer instead, so
+	// the readiness checks and the kubeconfig survive the first control plane going down.
+	if p.needsDockerLoadBalancer() {
+		lbEndpoint, lbErr := p.dockerLoadBalancerEndpoint(ctx, cluster.Info().ClusterName)
+		if lbErr != nil {
+			return nil, "", lbErr
+		}
+
+		kubernetesEndpoint = "https://" + lbEndpoint
+	}
+
 	if kubernetesEndpoint == "" {
 		return nil, "", ErrMissingKubernetesEndpoint
 	}
@@ -652,7 +696,12 @@ func (p *Provisioner) buildNodeRequests(
 
 		// Only the first control-plane node gets port mappings to avoid host port collisions
 		if nodeIndex == 0 {
-			nodeRequest.Ports = p.options.ExtraPortMappings
+			nodeRequest.Ports = p.firstControlPlanePorts()
+		}
+
+		err := p.applyNodeSettings(&nodeRequest, v1alpha1.NodeRoleControlPlane, nodeIndex)
+		if err != nil {
+			return nil, err
 		}
 
 		nodes = append(nodes, nodeRequest)
@@ -669,17 +718,40 @@ func (p *Provisioner) buildNodeRequests(
 			)
 		}
 
-		nodes = append(nodes, provision.NodeRequest{
+		nodeRequest := provision.NodeRequest{
 			Name:   fmt.Sprintf("%s-worker-%d", clusterName, nodeIndex+1),
 			Type:   machine.TypeWorker,
 			IPs:    []netip.Addr{nodeIP},
 			Config: configBundle.Worker(),
-		})
+		}
+
+		err := p.applyNodeSettings(&nodeRequest, v1alpha1.NodeRoleWorker, nodeIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, nodeRequest)
 	}
 
 	return nodes, nil
 }
 
+// firstControlPlanePorts returns the host port mappings of the first control-plane
+// node: the extra port mappings plus, for a pinned API server port on a cluster
+// without a load balancer, the Kubernetes API on 127.0.0.1:<port>. The Talos SDK
+// still publishes the API on a random port as well; getMappedK8sAPIEndpoint
+// prefers the pinned one. Load-balanced clusters publish the pinned port on the
+// load balancer instead.
+func (p *Provisioner) firstControlPlanePorts() []string {
+	if p.options.APIServerPort == 0 || p.needsDockerLoadBalancer() {
+		return p.options.ExtraPortMappings
+	}
+
+	pinned := fmt.Sprintf("127.0.0.1:%d:%d/tcp", p.options.APIServerPort, k8sAPIPort)
+
+	return append(slices.Clone(p.options.ExtraPortMappings), pinned)
+}
+
 // talosAPIPort is the Talos apid service port.
 const talosAPIPort = 50000
 
@@ -720,7 +792,15 @@ func (p *Provisioner) getMappedPortEndpoint(
 		return "", fmt.Errorf("%w for port %d", ErrNoPortMapping, containerPort)
 	}
 
-	return net.JoinHostPort("127.0.0.1", bindings[0].HostPort), nil
+	hostPort := bindings[0].HostPort
+
+	// A pinned API server port is published next to the SDK's random one; use it.
+	if pinned := strconv.Itoa(int(p.options.APIServerPort)); containerPort == k8sAPIPort &&
+		slices.ContainsFunc(bindings, func(b nat.PortBinding) bool { return b.HostPort == pinned }) {
+		hostPort = pinned
+	}
+
+	return net.JoinHostPort("127.0.0.1", hostPort), nil
 }
 
 // getMappedTalosAPIEndpoint returns the host-mapped endpoint for the Talos API (port 50000).
@@ -732,10 +812,20 @@ func (p *Provisioner) getMappedTalosAPIEndpoint(
 }
 
 // getMappedK8sAPIEndpoint returns the host-mapped endpoint for the Kubernetes API (port 6443).
+// Clusters fronted by a control-plane load balancer resolve to the load balancer's port.
 func (p *Provisioner) getMappedK8sAPIEndpoint(
 	ctx context.Context,
 	clusterName string,
 ) (string, error) {
+	endpoint, err := p.dockerLoadBalancerEndpoint(ctx, clusterName)
+	if err == nil {
+		return endpoint, nil
+	}
+
+	if !errors.Is(err, dockerprovider.ErrLoadBalancerNotFound) {
+		return "", err
+	}
+
 	return p.getMappedPortEndpoint(ctx, clusterName, k8sAPIPort)
 }
 
@@ -44,7 +44,12 @@ func (p *Provisioner) ensureHetznerInfra(
 
 	_, _ = fmt.Fprintf(p.logWriter, "  ✓ Network %s created\n", network.Name)
 
-	firewall, err := hzProvider.EnsureFirewall(ctx, clusterName, p.hetznerOpts.AllowedCIDRs)
+	firewall, err := hzProvider.EnsureFirewall(
+		ctx,
+		clusterName,
+		p.hetznerOpts.AllowedCIDRs,
+		p.hetznerOpts.NodePortCIDRs,
+	)
 	if err != nil {
 		return HetznerInfra{}, fmt.Errorf("failed to create firewall: %w", err)
 	}
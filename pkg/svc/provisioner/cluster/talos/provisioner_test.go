@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
@@ -12,6 +13,7 @@ import (
 	configmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager"
 	talosconfigmanager "github.com/devantler-tech/ksail/v7/pkg/fsutil/configmanager/talos"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	dockerprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/docker"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/omni"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clustererr"
 	talosprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/talos"
@@ -33,6 +35,12 @@ var (
 	errRepoNotFound   = errors.New("repository not found: 404 Not Found")
 )
 
+// isLoadBalancerQuery matches the Docker provider's lookup of a cluster's
+// control-plane load balancer.
+func isLoadBalancerQuery(opts container.ListOptions) bool {
+	return slices.Contains(opts.Filters.Get("label"), dockerprovider.LabelKSailRole+"=load-balancer")
+}
+
 // noopKernelModuleLoader is a kernel module loader stub that succeeds without
 // invoking modprobe, so Docker provisioning tests are hermetic.
 func noopKernelModuleLoader(_ context.Context, _ io.Writer) error {
@@ -665,6 +673,10 @@ func TestProvisioner_Delete_Success(t *testing.T) {
 	mockClient.EXPECT().
 		Ping(mock.Anything).
 		Return(types.Ping{}, nil)
+	// No control-plane load balancer to remove
+	mockClient.EXPECT().
+		ContainerList(mock.Anything, mock.MatchedBy(isLoadBalancerQuery)).
+		Return(nil, nil)
 	mockClient.EXPECT().
 		ContainerList(mock.Anything, mock.Anything).
 		Return([]container.Summary{
@@ -358,6 +358,50 @@ func TestNthIPInNetwork(t *testing.T) {
 	}
 }
 
+// --- dockerLoadBalancerIP ---
+
+func TestDockerLoadBalancerIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cidr    string
+		wantIP  string
+		wantErr error
+	}{
+		{name: "default network", cidr: "10.5.0.0/24", wantIP: "10.5.0.254"},
+		{name: "larger network", cidr: "172.20.0.0/16", wantIP: "172.20.255.254"},
+		{name: "unmasked base", cidr: "10.5.0.7/24", wantIP: "10.5.0.254"},
+		{name: "smallest network", cidr: "10.5.0.0/30", wantIP: "10.5.0.2"},
+		{
+			name:    "network without room",
+			cidr:    "10.5.0.0/31",
+			wantErr: talosprovisioner.ErrInvalidNetworkCIDR,
+		},
+		{
+			name:    "IPv6 network",
+			cidr:    "fd00::/64",
+			wantErr: talosprovisioner.ErrInvalidNetworkCIDR,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := talosprovisioner.DockerLoadBalancerIPForTest(testCase.cidr)
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantIP, got.String())
+		})
+	}
+}
+
 // --- rewriteKubeconfigEndpoint ---
 
 //nolint:funlen // Endpoint rewrite scenarios are easier to validate as a table.
@@ -697,3 +741,36 @@ func TestRenameKubeconfigContext_InvalidInput(t *testing.T) {
 	_, err := talosprovisioner.RenameKubeconfigContextForTest([]byte("not: valid: [broken"), "ctx")
 	require.Error(t, err)
 }
+
+func TestFirstControlPlanePorts(t *testing.T) {
+	t.Parallel()
+
+	extra := []string{"8080:80/tcp"}
+
+	tests := []struct {
+		name          string
+		controlPlanes int
+		apiServerPort int32
+		expected      []string
+	}{
+		{"unpinned keeps extra mappings", 1, 0, extra},
+		{"pinned publishes the API port", 1, 16443, []string{"8080:80/tcp", "127.0.0.1:16443:6443/tcp"}},
+		{"load-balanced leaves the pin to the load balancer", 3, 16443, extra},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := talosprovisioner.NewOptions().
+				WithControlPlaneNodes(testCase.controlPlanes).
+				WithExtraPortMappings(extra).
+				WithAPIServerPort(testCase.apiServerPort)
+
+			provisioner := talosprovisioner.NewProvisioner(nil, opts)
+
+			assert.Equal(t, testCase.expected, provisioner.FirstControlPlanePortsForTest())
+			assert.Equal(t, []string{"8080:80/tcp"}, extra, "extra mappings must not be mutated")
+		})
+	}
+}
@@ -198,13 +198,16 @@ func (p *Provisioner) rebootNode(ctx context.Context, nodeIP string) error {
 	return nil
 }
 
-// waitForK8sNodeReady polls until a specific Kubernetes node has condition Ready=True.
+// waitForK8sNodeReady polls until a specific Kubernetes node has condition Ready=True,
+// allowing timeout plus the configured health-check grace period.
 func (p *Provisioner) waitForK8sNodeReady(
 	ctx context.Context,
 	clientset kubernetes.Interface,
 	nodeName string,
 	timeout time.Duration,
 ) error {
+	timeout += p.healthCheckGracePeriod()
+
 	pollErr := readiness.PollForReadiness(ctx, timeout, func(ctx context.Context) (bool, error) {
 		node, err := clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 		if err != nil {
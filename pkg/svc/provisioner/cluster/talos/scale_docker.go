@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/devantler-tech/ksail/v7/pkg/notify"
 	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/clusterupdate"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
@@ -25,17 +26,25 @@ const maxConcurrentContainerOps = 3
 // scaleDockerByRole adjusts the number of Docker nodes for the given role.
 // Scale-up: creates new containers with proper Talos config and static IPs.
 // Scale-down: removes etcd members (for control-plane) then stops and removes containers (highest-index first).
+// Control-plane changes re-target the cluster's load balancer, if it has one.
 func (p *Provisioner) scaleDockerByRole(
 	ctx context.Context,
 	clusterName, role string,
 	delta int,
 	result *clusterupdate.UpdateResult,
 ) error {
+	var err error
 	if delta > 0 {
-		return p.addDockerNodes(ctx, clusterName, role, delta, result)
+		err = p.addDockerNodes(ctx, clusterName, role, delta, result)
+	} else {
+		err = p.removeDockerNodes(ctx, clusterName, role, -delta, result)
 	}
 
-	return p.removeDockerNodes(ctx, clusterName, role, -delta, result)
+	if err != nil || role != RoleControlPlane {
+		return err
+	}
+
+	return p.syncDockerLoadBalancer(ctx, clusterName)
 }
 
 // nodeResult describes a single container operation outcome.
@@ -229,7 +238,8 @@ func preCalculateNodeSpecs(
 }
 
 // createNodesInParallel creates Talos containers for each spec in parallel
-// (up to maxConcurrentContainerOps at a time) and collects the results.
+// (up to maxConcurrentContainerOps at a time, each holding a CPU slot of the
+// notify.Slots carried by ctx) and collects the results.
 func (p *Provisioner) createNodesInParallel(
 	ctx context.Context,
 	clusterName, role string,
@@ -243,7 +253,13 @@ func (p *Provisioner) createNodesInParallel(
 
 	for idx, spec := range specs {
 		group.Go(func() error {
-			createErr := p.createTalosContainer(ctx, clusterName, spec.name, role, spec.ip, config)
+			release, createErr := notify.AcquireSlot(ctx, notify.WeightCPU)
+			if createErr == nil {
+				createErr = p.createTalosContainer(ctx, clusterName, spec.name, role, spec.ip, config)
+
+				release()
+			}
+
 			results[idx] = nodeCreationResult{name: spec.name, ip: spec.ip, err: createErr}
 
 			return nil // errors collected in results; don't cancel sibling goroutines
@@ -380,7 +396,7 @@ func (p *Provisioner) collectResults(
 
 // createTalosContainer creates and starts a Docker container matching the
 // Talos SDK's container spec. This includes: privileged mode, PLATFORM=container
-// env, USERDATA with base64 config, Talos labels, tmpfs mounts, anonymous
+// env, USERDATA with base64 config, Talos and resource labels, tmpfs mounts, anonymous
 // volumes, seccomp:unconfined, and a static IP on the cluster network.
 func (p *Provisioner) createTalosContainer(
 	ctx context.Context,
@@ -388,6 +404,11 @@ func (p *Provisioner) createTalosContainer(
 	nodeIP netip.Addr,
 	config talosconfig.Provider,
 ) error {
+	config, err := p.patchResourceLabels(config)
+	if err != nil {
+		return fmt.Errorf("failed to patch machine config of %s: %w", nodeName, err)
+	}
+
 	cfgStr, err := config.EncodeString()
 	if err != nil {
 		return fmt.Errorf("failed to encode config: %w", err)
@@ -402,11 +423,7 @@ func (p *Provisioner) createTalosContainer(
 		Hostname: nodeName,
 		Image:    p.options.TalosImage,
 		Env:      env,
-		Labels: map[string]string{
-			LabelTalosOwned:       labelValueTrue,
-			LabelTalosClusterName: clusterName,
-			"talos.type":          talosTypeFromRole(role),
-		},
+		Labels:   p.containerLabels(clusterName, role),
 	}
 
 	hostConfig := buildTalosHostConfig()
@@ -2,8 +2,10 @@ package talosprovisioner_test
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/devantler-tech/ksail/v7/pkg/client/docker"
@@ -90,6 +92,63 @@ func TestAddDockerNodes_ControlPlane_CreatesAllNodes(t *testing.T) {
 	assert.Empty(t, result.FailedChanges, "expected no failed changes")
 }
 
+func TestAddDockerNodes_StampsResourceLabels(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+
+	// 1 call for listDockerNodesByRole (existing workers) + 1 call for countDockerRole (CP count)
+	mockClient.On("ContainerList", mock.Anything, mock.Anything).
+		Return([]container.Summary{}, nil).Times(2)
+
+	var created *container.Config
+
+	mockClient.On(
+		"ContainerCreate",
+		mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything,
+		mock.AnythingOfType("string"),
+	).
+		Run(func(args mock.Arguments) {
+			created, _ = args.Get(1).(*container.Config)
+		}).
+		Return(container.CreateResponse{ID: "container-id"}, nil).
+		Once()
+
+	mockClient.On("ContainerStart", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	provisioner := newScaleProvisioner(t, mockClient).
+		WithResourceLabels(map[string]string{"team": "platform", "talos.owned": "false"})
+
+	err := provisioner.AddDockerNodesForTest(
+		context.Background(),
+		"scale-cluster",
+		talosprovisioner.RoleWorker,
+		1,
+		clusterupdate.NewEmptyUpdateResult(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, created)
+
+	assert.Equal(t, "platform", created.Labels["team"])
+	assert.Equal(t, "true", created.Labels["talos.owned"], "Talos labels take precedence")
+	assert.Equal(t, "scale-cluster", created.Labels["talos.cluster.name"])
+
+	var userData string
+
+	for _, env := range created.Env {
+		if encoded, ok := strings.CutPrefix(env, "USERDATA="); ok {
+			decoded, decodeErr := base64.StdEncoding.DecodeString(encoded)
+			require.NoError(t, decodeErr)
+
+			userData = string(decoded)
+		}
+	}
+
+	assert.Contains(t, userData, "team: platform", "the label is registered on the node")
+}
+
 func TestAddDockerNodes_Workers_CreatesAllNodes(t *testing.T) {
 	t.Parallel()
 
@@ -35,3 +35,6 @@ type RemoveNetworkFnForTest = removeNetworkFn
 
 // TestPollInterval is a short poll interval for unit tests to avoid slow test suites.
 const TestPollInterval = time.Millisecond
+
+// MarkPausedForTest exposes markPaused for unit testing.
+var MarkPausedForTest = markPaused
@@ -114,10 +114,7 @@ func (p *KubernetesProvisioner) Create(
 	name string,
 ) error {
 	// sequential setup steps
-	clusterName := p.clusterName
-	if clusterName == "" {
-		clusterName = name
-	}
+	clusterName := p.resolveName(name)
 
 	namespace := NamespacePrefix + clusterName
 
@@ -243,10 +240,7 @@ func (p *KubernetesProvisioner) Create(
 
 // Delete removes the vCluster from the host cluster using the Helm driver.
 func (p *KubernetesProvisioner) Delete(ctx context.Context, name string) error {
-	clusterName := p.clusterName
-	if clusterName == "" {
-		clusterName = name
-	}
+	clusterName := p.resolveName(name)
 
 	namespace := NamespacePrefix + clusterName
 
@@ -283,10 +277,7 @@ func (p *KubernetesProvisioner) Delete(ctx context.Context, name string) error {
 
 // Exists checks whether the vCluster namespace exists on the host cluster.
 func (p *KubernetesProvisioner) Exists(ctx context.Context, name string) (bool, error) {
-	clusterName := p.clusterName
-	if clusterName == "" {
-		clusterName = name
-	}
+	clusterName := p.resolveName(name)
 
 	namespace := NamespacePrefix + clusterName
 
@@ -319,10 +310,7 @@ func (p *KubernetesProvisioner) Exists(ctx context.Context, name string) (bool,
 // satisfies the clusterprovisioner.Connector capability and returns clustererr.ErrKubeconfigNotReady
 // while the vc-<name> Secret has not been published yet.
 func (p *KubernetesProvisioner) Kubeconfig(ctx context.Context, name string) ([]byte, error) {
-	clusterName := p.clusterName
-	if clusterName == "" {
-		clusterName = name
-	}
+	clusterName := p.resolveName(name)
 
 	if clusterName == "" {
 		return nil, fmt.Errorf("%w: vcluster name not set", clustererr.ErrConfigNil)
@@ -355,20 +343,6 @@ func (p *KubernetesProvisioner) Kubeconfig(ctx context.Context, name string) ([]
 	return out, nil
 }
 
-// Start is not supported for Helm-based vClusters (they run as pods).
-func (p *KubernetesProvisioner) Start(_ context.Context, _ string) error {
-	return fmt.Errorf(
-		"start not supported for vCluster on Kubernetes (pods are always running): %w",
-		clustererr.ErrOperationNotSupported,
-	)
-}
-
-// Stop is not supported for Helm-based vClusters (they run as pods).
-func (p *KubernetesProvisioner) Stop(_ context.Context, _ string) error {
-	return fmt.Errorf("stop not supported for vCluster on Kubernetes (pods are always running): %w",
-		clustererr.ErrOperationNotSupported)
-}
-
 // List returns all vCluster instances discovered by the SDK.
 func (p *KubernetesProvisioner) List(ctx context.Context) ([]string, error) {
 	logger := p.newLogger()
@@ -462,6 +436,15 @@ func rewriteVClusterKubeconfig(
 	return out, nil
 }
 
+// resolveName returns the configured cluster name, falling back to name.
+func (p *KubernetesProvisioner) resolveName(name string) string {
+	if p.clusterName != "" {
+		return p.clusterName
+	}
+
+	return name
+}
+
 // newHostGlobalFlags creates global flags configured for the host cluster.
 func (p *KubernetesProvisioner) newHostGlobalFlags(namespace string) *flags.GlobalFlags {
 	configPath, err := cliconfig.DefaultFilePath()
@@ -0,0 +1,102 @@
+package vclusterprovisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	kubernetesprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/kubernetes"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Start resumes a paused vCluster by scaling its control plane back up. A vCluster that is
+// already running is left as is. Either way the namespace's paused marker is cleared.
+func (p *KubernetesProvisioner) Start(ctx context.Context, name string) error {
+	clusterName := p.resolveName(name)
+	namespace := NamespacePrefix + clusterName
+	globalFlags := p.newHostGlobalFlags(namespace)
+	logger := p.newLogger()
+
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, clusterName, namespace, logger)
+	if err != nil {
+		return fmt.Errorf("find vCluster %q: %w", clusterName, err)
+	}
+
+	if vCluster.Status == find.StatusRunning {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ vCluster %q is already running\n", clusterName)
+	} else {
+		_, _ = fmt.Fprintf(os.Stdout, "► resuming vCluster %q in namespace %s\n", clusterName, namespace)
+
+		err = cli.ResumeHelm(ctx, globalFlags, clusterName, logger)
+		if err != nil {
+			return fmt.Errorf("resume vCluster via Helm: %w", err)
+		}
+	}
+
+	return markPaused(ctx, p.hostClientset, namespace, false)
+}
+
+// Stop pauses a vCluster by scaling its control plane to zero and deleting the workloads it
+// synced to the host; the data is kept so Start can resume it. A vCluster that is already
+// paused is left as is. Either way the namespace is marked paused for discovery.
+func (p *KubernetesProvisioner) Stop(ctx context.Context, name string) error {
+	clusterName := p.resolveName(name)
+	namespace := NamespacePrefix + clusterName
+	globalFlags := p.newHostGlobalFlags(namespace)
+	logger := p.newLogger()
+
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, clusterName, namespace, logger)
+	if err != nil {
+		return fmt.Errorf("find vCluster %q: %w", clusterName, err)
+	}
+
+	if vCluster.Status == find.StatusPaused {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ vCluster %q is already paused\n", clusterName)
+	} else {
+		_, _ = fmt.Fprintf(os.Stdout, "► pausing vCluster %q in namespace %s\n", clusterName, namespace)
+
+		err = cli.PauseHelm(ctx, globalFlags, clusterName, logger)
+		if err != nil {
+			return fmt.Errorf("pause vCluster via Helm: %w", err)
+		}
+	}
+
+	return markPaused(ctx, p.hostClientset, namespace, true)
+}
+
+// markPaused sets or clears the kubernetesprovider.AnnotationPaused marker on the vCluster's
+// namespace, which cluster discovery reads to report the cluster as stopped.
+func markPaused(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	namespace string,
+	paused bool,
+) error {
+	var value any
+	if paused {
+		value = "true"
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{kubernetesprovider.AnnotationPaused: value},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("build paused annotation patch: %w", err)
+	}
+
+	_, err = clientset.CoreV1().Namespaces().Patch(
+		ctx, namespace, types.MergePatchType, patch, metav1.PatchOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("record paused state on namespace %s: %w", namespace, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package vclusterprovisioner_test
+
+import (
+	"context"
+	"testing"
+
+	kubernetesprovider "github.com/devantler-tech/ksail/v7/pkg/svc/provider/kubernetes"
+	vclusterprovisioner "github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/cluster/vcluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMarkPaused(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := k8sfake.NewClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "vcluster-dev",
+			Labels:      kubernetesprovider.CommonLabels("dev"),
+			Annotations: map[string]string{"keep": "me"},
+		},
+	})
+
+	annotations := func() map[string]string {
+		t.Helper()
+
+		namespace, err := client.CoreV1().Namespaces().Get(ctx, "vcluster-dev", metav1.GetOptions{})
+		require.NoError(t, err)
+
+		return namespace.Annotations
+	}
+
+	require.NoError(t, vclusterprovisioner.MarkPausedForTest(ctx, client, "vcluster-dev", true))
+	assert.Equal(t, map[string]string{
+		"keep":                              "me",
+		kubernetesprovider.AnnotationPaused: "true",
+	}, annotations())
+
+	require.NoError(t, vclusterprovisioner.MarkPausedForTest(ctx, client, "vcluster-dev", false))
+	assert.Equal(t, map[string]string{"keep": "me"}, annotations())
+}
+
+func TestMarkPaused_MissingNamespace(t *testing.T) {
+	t.Parallel()
+
+	err := vclusterprovisioner.MarkPausedForTest(
+		context.Background(), k8sfake.NewClientset(), "vcluster-gone", true,
+	)
+
+	require.ErrorContains(t, err, "record paused state on namespace vcluster-gone")
+}
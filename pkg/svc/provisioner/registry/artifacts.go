@@ -0,0 +1,247 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+)
+
+// ArtifactBackend inspects and trims the artifact tags of registry containers.
+type ArtifactBackend interface {
+	CacheLister
+	ListRegistryTags(ctx context.Context, name string) ([]dockerclient.RegistryTag, error)
+	DeleteRegistryTags(ctx context.Context, name string, tags []dockerclient.RegistryTag) error
+	GarbageCollectRegistry(ctx context.Context, name string, deleteUntagged bool) error
+}
+
+// RetentionPolicy decides which artifact tags a local registry keeps. The most
+// recently pushed tag of each repository is always kept.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recently pushed tags kept per repository.
+	// Zero keeps any number of tags.
+	KeepLast int
+	// MaxAge deletes tags pushed longer ago than MaxAge. Zero keeps tags of any age.
+	MaxAge time.Duration
+	// Repositories restricts the policy to these repositories. Empty applies it
+	// to every repository.
+	Repositories []string
+	// Protected tags are never deleted, such as the tag a GitOps engine reconciles.
+	Protected []string
+}
+
+// IsZero reports whether the policy keeps every tag.
+func (p RetentionPolicy) IsZero() bool {
+	return p.KeepLast <= 0 && p.MaxAge <= 0
+}
+
+// RetentionPolicyFromSpec converts the retention configured for the local
+// registry in ksail.yaml into a policy applying to every repository.
+func RetentionPolicyFromSpec(retention v1alpha1.RegistryRetention) RetentionPolicy {
+	return RetentionPolicy{KeepLast: int(retention.KeepLast), MaxAge: retention.MaxAge.Duration}
+}
+
+// ArtifactPruneOptions configures PruneArtifacts.
+type ArtifactPruneOptions struct {
+	// Names restricts pruning to these local registry containers. Empty prunes
+	// every running local registry.
+	Names []string
+	// Policy selects the tags to delete.
+	Policy RetentionPolicy
+	// DryRun reports the tags that would be deleted without deleting them.
+	DryRun bool
+}
+
+// ArtifactPruneResult reports what pruning did to one local registry.
+type ArtifactPruneResult struct {
+	// Name is the local registry container name.
+	Name string
+	// DeletedTags are the tags deleted, or that would be deleted on a dry run.
+	DeletedTags []dockerclient.RegistryTag
+	// SizeBefore is the number of bytes the registry stored before pruning.
+	SizeBefore int64
+	// SizeAfter is the number of bytes the registry stores after pruning.
+	SizeAfter int64
+}
+
+// PruneArtifacts deletes the artifact tags of local registries that the
+// retention policy no longer keeps, then garbage-collects the registries so the
+// manifests and blobs only those tags referenced are deleted too. Only local
+// registries are pruned: mirrors hold no pushed artifacts.
+func PruneArtifacts(
+	ctx context.Context,
+	backend ArtifactBackend,
+	opts ArtifactPruneOptions,
+	now time.Time,
+) ([]ArtifactPruneResult, error) {
+	if opts.Policy.IsZero() {
+		return nil, ErrNoRetentionPolicy
+	}
+
+	caches, err := CacheStats(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := selectLocalRegistries(caches, opts.Names)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ArtifactPruneResult, 0, len(targets))
+
+	for _, cache := range targets {
+		result, err := pruneArtifacts(ctx, backend, cache, opts, now)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	if opts.DryRun || len(results) == 0 {
+		return results, nil
+	}
+
+	after, err := CacheStats(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		for _, cache := range after {
+			if cache.Name == results[i].Name {
+				results[i].SizeAfter = cache.Size
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// SelectTagsToDelete returns the tags the policy no longer keeps at now: per
+// repository, every tag but the most recent KeepLast ones and every tag pushed
+// longer ago than MaxAge, sparing the most recent tag and protected tags.
+func SelectTagsToDelete(
+	tags []dockerclient.RegistryTag,
+	policy RetentionPolicy,
+	now time.Time,
+) []dockerclient.RegistryTag {
+	if policy.IsZero() {
+		return nil
+	}
+
+	byRepository := make(map[string][]dockerclient.RegistryTag)
+
+	for _, tag := range tags {
+		if len(policy.Repositories) > 0 && !slices.Contains(policy.Repositories, tag.Repository) {
+			continue
+		}
+
+		byRepository[tag.Repository] = append(byRepository[tag.Repository], tag)
+	}
+
+	var deleted []dockerclient.RegistryTag
+
+	for _, repositoryTags := range byRepository {
+		// Most recently pushed first.
+		slices.SortFunc(repositoryTags, func(a, b dockerclient.RegistryTag) int {
+			return cmp.Or(b.PushedAt.Compare(a.PushedAt), cmp.Compare(a.Tag, b.Tag))
+		})
+
+		for index, tag := range repositoryTags {
+			if index == 0 || slices.Contains(policy.Protected, tag.Tag) {
+				continue
+			}
+
+			beyondCount := policy.KeepLast > 0 && index >= policy.KeepLast
+			beyondAge := policy.MaxAge > 0 && now.Sub(tag.PushedAt) > policy.MaxAge
+
+			if beyondCount || beyondAge {
+				deleted = append(deleted, tag)
+			}
+		}
+	}
+
+	slices.SortFunc(deleted, func(a, b dockerclient.RegistryTag) int {
+		return cmp.Or(cmp.Compare(a.Repository, b.Repository), a.PushedAt.Compare(b.PushedAt))
+	})
+
+	return deleted
+}
+
+// selectLocalRegistries returns the running local registries to prune: the
+// named ones, or every running local registry when no names are given.
+func selectLocalRegistries(
+	caches []dockerclient.RegistryCache,
+	names []string,
+) ([]dockerclient.RegistryCache, error) {
+	if len(names) == 0 {
+		var targets []dockerclient.RegistryCache
+
+		for _, cache := range caches {
+			if cache.Upstream == "" && cache.Running {
+				targets = append(targets, cache)
+			}
+		}
+
+		return targets, nil
+	}
+
+	targets := make([]dockerclient.RegistryCache, 0, len(names))
+
+	for _, name := range names {
+		index := slices.IndexFunc(caches, func(cache dockerclient.RegistryCache) bool {
+			return cache.Name == name && cache.Upstream == ""
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrLocalRegistryNotFound, name)
+		}
+
+		if !caches[index].Running {
+			return nil, fmt.Errorf("%w: %s", ErrLocalRegistryNotRunning, name)
+		}
+
+		targets = append(targets, caches[index])
+	}
+
+	return targets, nil
+}
+
+// pruneArtifacts deletes the tags of one local registry the policy no longer
+// keeps and garbage-collects it, deleting the manifests left untagged.
+func pruneArtifacts(
+	ctx context.Context,
+	backend ArtifactBackend,
+	cache dockerclient.RegistryCache,
+	opts ArtifactPruneOptions,
+	now time.Time,
+) (ArtifactPruneResult, error) {
+	result := ArtifactPruneResult{Name: cache.Name, SizeBefore: cache.Size, SizeAfter: cache.Size}
+
+	tags, err := backend.ListRegistryTags(ctx, cache.Name)
+	if err != nil {
+		return result, fmt.Errorf("list tags of %s: %w", cache.Name, err)
+	}
+
+	result.DeletedTags = SelectTagsToDelete(tags, opts.Policy, now)
+	if len(result.DeletedTags) == 0 || opts.DryRun {
+		return result, nil
+	}
+
+	err = backend.DeleteRegistryTags(ctx, cache.Name, result.DeletedTags)
+	if err != nil {
+		return result, fmt.Errorf("delete tags of %s: %w", cache.Name, err)
+	}
+
+	err = backend.GarbageCollectRegistry(ctx, cache.Name, true)
+	if err != nil {
+		return result, fmt.Errorf("garbage collect %s: %w", cache.Name, err)
+	}
+
+	return result, nil
+}
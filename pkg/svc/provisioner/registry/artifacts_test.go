@@ -0,0 +1,195 @@
+package registry_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeArtifactBackend struct {
+	caches  []dockerclient.RegistryCache
+	tags    map[string][]dockerclient.RegistryTag
+	deleted map[string][]dockerclient.RegistryTag
+	gc      []string
+}
+
+func (f *fakeArtifactBackend) ListRegistryCaches(
+	_ context.Context,
+) ([]dockerclient.RegistryCache, error) {
+	return f.caches, nil
+}
+
+func (f *fakeArtifactBackend) ListRegistryTags(
+	_ context.Context,
+	name string,
+) ([]dockerclient.RegistryTag, error) {
+	return f.tags[name], nil
+}
+
+func (f *fakeArtifactBackend) DeleteRegistryTags(
+	_ context.Context,
+	name string,
+	tags []dockerclient.RegistryTag,
+) error {
+	if f.deleted == nil {
+		f.deleted = map[string][]dockerclient.RegistryTag{}
+	}
+
+	f.deleted[name] = append(f.deleted[name], tags...)
+
+	return nil
+}
+
+func (f *fakeArtifactBackend) GarbageCollectRegistry(
+	_ context.Context,
+	name string,
+	_ bool,
+) error {
+	f.gc = append(f.gc, name)
+
+	return nil
+}
+
+func tag(repository, name string, pushedAt int64) dockerclient.RegistryTag {
+	return dockerclient.RegistryTag{Repository: repository, Tag: name, PushedAt: time.Unix(pushedAt, 0)}
+}
+
+func tagNames(tags []dockerclient.RegistryTag) []string {
+	names := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		names = append(names, tag.Repository+":"+tag.Tag)
+	}
+
+	return names
+}
+
+func TestSelectTagsToDelete(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	tags := []dockerclient.RegistryTag{
+		tag("k8s", "v1", 100),
+		tag("k8s", "v2", 200),
+		tag("k8s", "v3", 300),
+		tag("k8s", "dev", 900),
+		tag("apps", "old", 50),
+	}
+
+	tests := []struct {
+		name   string
+		policy registry.RetentionPolicy
+		want   []string
+	}{
+		{
+			name:   "keeps last tags per repository",
+			policy: registry.RetentionPolicy{KeepLast: 2},
+			want:   []string{"k8s:v1", "k8s:v2"},
+		},
+		{
+			name:   "deletes tags older than max age but the newest",
+			policy: registry.RetentionPolicy{MaxAge: 750 * time.Second},
+			want:   []string{"k8s:v1", "k8s:v2"},
+		},
+		{
+			name:   "spares protected tags",
+			policy: registry.RetentionPolicy{KeepLast: 1, Protected: []string{"v2"}},
+			want:   []string{"k8s:v1", "k8s:v3"},
+		},
+		{
+			name:   "restricts to repositories",
+			policy: registry.RetentionPolicy{KeepLast: 3, Repositories: []string{"k8s"}},
+			want:   []string{"k8s:v1"},
+		},
+		{
+			name:   "zero policy keeps everything",
+			policy: registry.RetentionPolicy{},
+			want:   []string{},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			deleted := registry.SelectTagsToDelete(tags, testCase.policy, now)
+			assert.Equal(t, testCase.want, tagNames(deleted))
+		})
+	}
+}
+
+func TestPruneArtifactsDeletesTagsOfLocalRegistries(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeArtifactBackend{
+		caches: []dockerclient.RegistryCache{
+			{Name: "local-registry", Running: true, Size: 500},
+			{Name: "docker.io", Upstream: "https://registry-1.docker.io", Running: true},
+		},
+		tags: map[string][]dockerclient.RegistryTag{
+			"local-registry": {tag("k8s", "v1", 100), tag("k8s", "v2", 200)},
+			"docker.io":      {tag("library/nginx", "latest", 100)},
+		},
+	}
+
+	results, err := registry.PruneArtifacts(context.Background(), backend, registry.ArtifactPruneOptions{
+		Policy: registry.RetentionPolicy{KeepLast: 1},
+	}, time.Unix(1000, 0))
+	require.NoError(t, err)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "local-registry", results[0].Name)
+	assert.Equal(t, []string{"k8s:v1"}, tagNames(backend.deleted["local-registry"]))
+	assert.Equal(t, []string{"local-registry"}, backend.gc)
+}
+
+func TestPruneArtifactsDryRunDeletesNothing(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeArtifactBackend{
+		caches: []dockerclient.RegistryCache{{Name: "local-registry", Running: true}},
+		tags: map[string][]dockerclient.RegistryTag{
+			"local-registry": {tag("k8s", "v1", 100), tag("k8s", "v2", 200)},
+		},
+	}
+
+	results, err := registry.PruneArtifacts(context.Background(), backend, registry.ArtifactPruneOptions{
+		Policy: registry.RetentionPolicy{KeepLast: 1},
+		DryRun: true,
+	}, time.Unix(1000, 0))
+	require.NoError(t, err)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"k8s:v1"}, tagNames(results[0].DeletedTags))
+	assert.Empty(t, backend.deleted)
+	assert.Empty(t, backend.gc)
+}
+
+func TestPruneArtifactsErrors(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeArtifactBackend{
+		caches: []dockerclient.RegistryCache{
+			{Name: "local-registry"},
+			{Name: "docker.io", Upstream: "https://registry-1.docker.io", Running: true},
+		},
+	}
+	policy := registry.RetentionPolicy{KeepLast: 1}
+
+	_, err := registry.PruneArtifacts(context.Background(), backend,
+		registry.ArtifactPruneOptions{}, time.Now())
+	require.ErrorIs(t, err, registry.ErrNoRetentionPolicy)
+
+	_, err = registry.PruneArtifacts(context.Background(), backend,
+		registry.ArtifactPruneOptions{Names: []string{"docker.io"}, Policy: policy}, time.Now())
+	require.ErrorIs(t, err, registry.ErrLocalRegistryNotFound)
+
+	_, err = registry.PruneArtifacts(context.Background(), backend,
+		registry.ArtifactPruneOptions{Names: []string{"local-registry"}, Policy: policy}, time.Now())
+	require.ErrorIs(t, err, registry.ErrLocalRegistryNotRunning)
+}
@@ -0,0 +1,233 @@
+package registry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+)
+
+// CacheLister lists the storage of registry containers.
+type CacheLister interface {
+	ListRegistryCaches(ctx context.Context) ([]dockerclient.RegistryCache, error)
+}
+
+// CacheBackend inspects and trims the storage of registry containers.
+type CacheBackend interface {
+	CacheLister
+	ListRegistryBlobs(ctx context.Context, name string) ([]dockerclient.RegistryBlob, error)
+	DeleteRegistryBlobs(ctx context.Context, name string, digests []string) error
+	GarbageCollectRegistry(ctx context.Context, name string, deleteUntagged bool) error
+}
+
+// PruneOptions configures PruneCaches.
+type PruneOptions struct {
+	// Names restricts pruning to these mirror registry containers. Empty prunes
+	// every running mirror registry.
+	Names []string
+	// MaxSize is the number of blob bytes each mirror may keep. Least recently
+	// used blobs are deleted until the mirror fits. Zero disables eviction.
+	MaxSize int64
+	// DeleteUntagged lets garbage collection delete manifests no tag points to,
+	// such as images pulled by digest and superseded tags.
+	DeleteUntagged bool
+}
+
+// PruneResult reports what pruning did to one mirror registry.
+type PruneResult struct {
+	// Name is the mirror registry container name.
+	Name string
+	// SizeBefore is the number of bytes the mirror stored before pruning.
+	SizeBefore int64
+	// SizeAfter is the number of bytes the mirror stores after pruning.
+	SizeAfter int64
+	// EvictedBlobs is the number of blobs deleted to fit MaxSize.
+	EvictedBlobs int
+}
+
+// CacheStats returns the storage of every KSail-managed registry, sorted by name.
+func CacheStats(ctx context.Context, backend CacheLister) ([]dockerclient.RegistryCache, error) {
+	caches, err := backend.ListRegistryCaches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list registry caches: %w", err)
+	}
+
+	slices.SortFunc(caches, func(a, b dockerclient.RegistryCache) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return caches, nil
+}
+
+// PruneCaches garbage-collects mirror registries and, when MaxSize is set, evicts
+// their least recently used blobs. Only pull-through mirrors are pruned: their
+// content is refetched from upstream on the next pull, while the local registry
+// holds artifacts that exist nowhere else.
+func PruneCaches(
+	ctx context.Context,
+	backend CacheBackend,
+	opts PruneOptions,
+) ([]PruneResult, error) {
+	caches, err := CacheStats(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := selectPruneTargets(caches, opts.Names)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]PruneResult, 0, len(targets))
+
+	for _, cache := range targets {
+		result, err := pruneCache(ctx, backend, cache, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	after, err := CacheStats(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		for _, cache := range after {
+			if cache.Name == results[i].Name {
+				results[i].SizeAfter = cache.Size
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// SelectBlobsToEvict returns the least recently used blobs to delete so the
+// remaining blobs total at most maxSize bytes. It returns nil when maxSize is
+// not positive or the blobs already fit.
+func SelectBlobsToEvict(
+	blobs []dockerclient.RegistryBlob,
+	maxSize int64,
+) []dockerclient.RegistryBlob {
+	if maxSize <= 0 {
+		return nil
+	}
+
+	var total int64
+	for _, blob := range blobs {
+		total += blob.Size
+	}
+
+	if total <= maxSize {
+		return nil
+	}
+
+	ordered := slices.Clone(blobs)
+	slices.SortFunc(ordered, func(a, b dockerclient.RegistryBlob) int {
+		return cmp.Or(a.LastUsed.Compare(b.LastUsed), cmp.Compare(a.Digest, b.Digest))
+	})
+
+	var evict []dockerclient.RegistryBlob
+
+	for _, blob := range ordered {
+		if total <= maxSize {
+			break
+		}
+
+		evict = append(evict, blob)
+		total -= blob.Size
+	}
+
+	return evict
+}
+
+// selectPruneTargets returns the running mirrors to prune: the named ones, or
+// every running mirror when no names are given.
+func selectPruneTargets(
+	caches []dockerclient.RegistryCache,
+	names []string,
+) ([]dockerclient.RegistryCache, error) {
+	if len(names) == 0 {
+		var targets []dockerclient.RegistryCache
+
+		for _, cache := range caches {
+			if cache.Upstream != "" && cache.Running {
+				targets = append(targets, cache)
+			}
+		}
+
+		return targets, nil
+	}
+
+	targets := make([]dockerclient.RegistryCache, 0, len(names))
+
+	for _, name := range names {
+		index := slices.IndexFunc(caches, func(cache dockerclient.RegistryCache) bool {
+			return cache.Name == name && cache.Upstream != ""
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrRegistryCacheNotFound, name)
+		}
+
+		if !caches[index].Running {
+			return nil, fmt.Errorf("%w: %s", ErrRegistryCacheNotRunning, name)
+		}
+
+		targets = append(targets, caches[index])
+	}
+
+	return targets, nil
+}
+
+// pruneCache garbage-collects one mirror, then evicts blobs down to MaxSize.
+// Garbage collection runs first so eviction never counts unreferenced blobs.
+func pruneCache(
+	ctx context.Context,
+	backend CacheBackend,
+	cache dockerclient.RegistryCache,
+	opts PruneOptions,
+) (PruneResult, error) {
+	result := PruneResult{Name: cache.Name, SizeBefore: cache.Size}
+
+	err := backend.GarbageCollectRegistry(ctx, cache.Name, opts.DeleteUntagged)
+	if err != nil {
+		return result, fmt.Errorf("garbage collect %s: %w", cache.Name, err)
+	}
+
+	if opts.MaxSize <= 0 {
+		return result, nil
+	}
+
+	blobs, err := backend.ListRegistryBlobs(ctx, cache.Name)
+	if err != nil {
+		return result, fmt.Errorf("list blobs of %s: %w", cache.Name, err)
+	}
+
+	evict := SelectBlobsToEvict(blobs, opts.MaxSize)
+	if len(evict) == 0 {
+		return result, nil
+	}
+
+	digests := make([]string, 0, len(evict))
+	for _, blob := range evict {
+		digests = append(digests, blob.Digest)
+	}
+
+	err = backend.DeleteRegistryBlobs(ctx, cache.Name, digests)
+	if err != nil {
+		return result, fmt.Errorf("evict blobs of %s: %w", cache.Name, err)
+	}
+
+	result.EvictedBlobs = len(digests)
+
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package registry_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCacheBackend struct {
+	caches  []dockerclient.RegistryCache
+	blobs   map[string][]dockerclient.RegistryBlob
+	gc      []string
+	deleted map[string][]string
+}
+
+func (f *fakeCacheBackend) ListRegistryCaches(
+	_ context.Context,
+) ([]dockerclient.RegistryCache, error) {
+	caches := make([]dockerclient.RegistryCache, 0, len(f.caches))
+
+	for _, cache := range f.caches {
+		for _, blob := range f.blobs[cache.Name] {
+			if !slices.Contains(f.deleted[cache.Name], blob.Digest) {
+				cache.Size += blob.Size
+			}
+		}
+
+		caches = append(caches, cache)
+	}
+
+	return caches, nil
+}
+
+func (f *fakeCacheBackend) ListRegistryBlobs(
+	_ context.Context,
+	name string,
+) ([]dockerclient.RegistryBlob, error) {
+	return f.blobs[name], nil
+}
+
+func (f *fakeCacheBackend) DeleteRegistryBlobs(
+	_ context.Context,
+	name string,
+	digests []string,
+) error {
+	if f.deleted == nil {
+		f.deleted = map[string][]string{}
+	}
+
+	f.deleted[name] = append(f.deleted[name], digests...)
+
+	return nil
+}
+
+func (f *fakeCacheBackend) GarbageCollectRegistry(
+	_ context.Context,
+	name string,
+	_ bool,
+) error {
+	f.gc = append(f.gc, name)
+
+	return nil
+}
+
+func blob(digest string, size int64, lastUsed int64) dockerclient.RegistryBlob {
+	return dockerclient.RegistryBlob{Digest: digest, Size: size, LastUsed: time.Unix(lastUsed, 0)}
+}
+
+func TestSelectBlobsToEvict(t *testing.T) {
+	t.Parallel()
+
+	blobs := []dockerclient.RegistryBlob{
+		blob("sha256:new", 40, 300),
+		blob("sha256:old", 30, 100),
+		blob("sha256:mid", 30, 200),
+	}
+
+	tests := []struct {
+		name    string
+		maxSize int64
+		want    []string
+	}{
+		{name: "disabled", maxSize: 0, want: nil},
+		{name: "fits", maxSize: 100, want: nil},
+		{name: "evicts oldest", maxSize: 70, want: []string{"sha256:old"}},
+		{name: "evicts until fits", maxSize: 50, want: []string{"sha256:old", "sha256:mid"}},
+		{name: "evicts everything", maxSize: 1, want: []string{"sha256:old", "sha256:mid", "sha256:new"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, evicted := range registry.SelectBlobsToEvict(blobs, test.maxSize) {
+				got = append(got, evicted.Digest)
+			}
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestPruneCaches_PrunesRunningMirrorsOnly(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeCacheBackend{
+		caches: []dockerclient.RegistryCache{
+			{Name: "docker.io", Upstream: "https://registry-1.docker.io", Running: true},
+			{Name: "ghcr.io", Upstream: "https://ghcr.io"},
+			{Name: "local-registry", Running: true},
+		},
+		blobs: map[string][]dockerclient.RegistryBlob{
+			"docker.io": {blob("sha256:a", 60, 100), blob("sha256:b", 60, 200)},
+		},
+	}
+
+	results, err := registry.PruneCaches(context.Background(), backend, registry.PruneOptions{MaxSize: 100})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"docker.io"}, backend.gc)
+	assert.Equal(t, map[string][]string{"docker.io": {"sha256:a"}}, backend.deleted)
+	assert.Equal(t, []registry.PruneResult{
+		{Name: "docker.io", SizeBefore: 120, SizeAfter: 60, EvictedBlobs: 1},
+	}, results)
+}
+
+func TestPruneCaches_NamedRegistries(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeCacheBackend{
+		caches: []dockerclient.RegistryCache{
+			{Name: "ghcr.io", Upstream: "https://ghcr.io"},
+			{Name: "local-registry", Running: true},
+		},
+	}
+
+	_, err := registry.PruneCaches(context.Background(), backend, registry.PruneOptions{
+		Names: []string{"local-registry"},
+	})
+	require.ErrorIs(t, err, registry.ErrRegistryCacheNotFound)
+
+	_, err = registry.PruneCaches(context.Background(), backend, registry.PruneOptions{
+		Names: []string{"ghcr.io"},
+	})
+	require.ErrorIs(t, err, registry.ErrRegistryCacheNotRunning)
+	assert.Empty(t, backend.gc)
+}
@@ -33,6 +33,22 @@ func InjectHostsTomlIntoNodes(
 		hostsTomlContent := GenerateHostsToml(entry)
 
 		for _, node := range nodes {
+			// The CA goes in first so containerd never sees a hosts.toml that
+			// references a missing file.
+			if len(entry.CA) > 0 {
+				err := injectNodeFile(
+					ctx, dockerClient, node, hostsDir(entry.Host), TLSCACertFile, string(entry.CA),
+				)
+				if err != nil {
+					return fmt.Errorf(
+						"failed to inject CA certificate for %s into node %s: %w",
+						entry.Host,
+						node,
+						err,
+					)
+				}
+			}
+
 			err := InjectHostsToml(ctx, dockerClient, node, entry.Host, hostsTomlContent)
 			if err != nil {
 				return fmt.Errorf(
@@ -48,6 +64,17 @@ func InjectHostsTomlIntoNodes(
 	return nil
 }
 
+// HostsCACertPath returns the path inside a node of the CA certificate of the mirror
+// for registryHost, next to its hosts.toml file.
+func HostsCACertPath(registryHost string) string {
+	return hostsDir(registryHost) + "/" + TLSCACertFile
+}
+
+// hostsDir returns the containerd hosts directory of registryHost inside a node.
+func hostsDir(registryHost string) string {
+	return "/etc/containerd/certs.d/" + registryHost
+}
+
 // InjectHostsToml creates the hosts directory and writes the hosts.toml file inside a cluster node
 // using docker exec. It generates a random heredoc delimiter to prevent injection attacks.
 func InjectHostsToml(
@@ -57,22 +84,44 @@ func InjectHostsToml(
 	registryHost string,
 	hostsTomlContent string,
 ) error {
-	certsDir := "/etc/containerd/certs.d/" + registryHost
-	escapedCertsDir := EscapeShellArg(certsDir)
+	return injectNodeFile(
+		ctx, dockerClient, nodeName, hostsDir(registryHost), "hosts.toml", hostsTomlContent,
+	)
+}
+
+// injectNodeFile creates dir and writes content to the file name in it inside a
+// cluster node using docker exec.
+func injectNodeFile(
+	ctx context.Context,
+	dockerClient dockerclient.Client,
+	nodeName string,
+	dir string,
+	name string,
+	content string,
+) error {
+	escapedDir := EscapeShellArg(dir)
 
 	delimiter, err := GenerateRandomDelimiter()
 	if err != nil {
 		return err
 	}
 
-	cmd := []string{
-		"sh", "-c",
-		fmt.Sprintf("mkdir -p %s && cat > %s/hosts.toml << '%s'\n%s\n%s",
-			escapedCertsDir, escapedCertsDir, delimiter, hostsTomlContent, delimiter),
-	}
+	return ExecNodeScript(ctx, dockerClient, nodeName, fmt.Sprintf(
+		"mkdir -p %s && cat > %s/%s << '%s'\n%s\n%s",
+		escapedDir, escapedDir, EscapeShellArg(name), delimiter, content, delimiter,
+	))
+}
 
+// ExecNodeScript runs script with sh -c inside the node container and returns
+// ErrExecFailed with the captured stderr when it exits non-zero.
+func ExecNodeScript(
+	ctx context.Context,
+	dockerClient dockerclient.Client,
+	nodeName string,
+	script string,
+) error {
 	execConfig := container.ExecOptions{
-		Cmd:          cmd,
+		Cmd:          []string{"sh", "-c", script},
 		AttachStdout: true,
 		AttachStderr: true,
 	}
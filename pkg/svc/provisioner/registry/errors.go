@@ -6,4 +6,36 @@ import "errors"
 var (
 	// ErrEmptyBaseDir is returned when the base directory is empty.
 	ErrEmptyBaseDir = errors.New("baseDir cannot be empty")
+
+	// ErrInvalidK3dRegistriesConfig is returned when an existing K3s
+	// registries.yaml document cannot be parsed or re-rendered.
+	ErrInvalidK3dRegistriesConfig = errors.New("invalid k3d registries config")
+
+	// ErrNoDockerConfigCredentials is returned when a mirror references a Docker
+	// config.json that stores no credentials for the mirrored registry.
+	ErrNoDockerConfigCredentials = errors.New("no credentials stored in docker config")
+
+	// ErrInvalidRegistryCA is returned when the stored mirror registry CA
+	// certificate or key is not valid PEM.
+	ErrInvalidRegistryCA = errors.New("invalid registry CA PEM")
+
+	// ErrRegistryCacheNotFound is returned when pruning names a registry that is
+	// not a KSail-managed mirror registry.
+	ErrRegistryCacheNotFound = errors.New("mirror registry not found")
+
+	// ErrRegistryCacheNotRunning is returned when pruning names a mirror registry
+	// whose container is stopped.
+	ErrRegistryCacheNotRunning = errors.New("mirror registry is not running")
+
+	// ErrLocalRegistryNotFound is returned when pruning artifacts names a
+	// registry that is not a KSail-managed local registry.
+	ErrLocalRegistryNotFound = errors.New("local registry not found")
+
+	// ErrLocalRegistryNotRunning is returned when pruning artifacts names a
+	// local registry whose container is stopped.
+	ErrLocalRegistryNotRunning = errors.New("local registry is not running")
+
+	// ErrNoRetentionPolicy is returned when pruning artifacts without a tag
+	// count or age limit.
+	ErrNoRetentionPolicy = errors.New("retention policy needs a tag count or age limit")
 )
@@ -21,6 +21,15 @@ type Info struct {
 	Username string // Optional: username for registry authentication (supports ${ENV_VAR} placeholders)
 
 	Password string
+	// Labels are extra Docker labels stamped onto the registry container.
+	Labels map[string]string
+	// TLS serves the registry over HTTPS with a certificate signed by the CA in
+	// DefaultTLSDir.
+	TLS bool
+	// HostIP is the host address Port is published on. Defaults to 127.0.0.1.
+	HostIP string
+	// Publish publishes the Port of a mirror registry on the host.
+	Publish bool
 }
 
 // Registry Lifecycle Management
@@ -272,6 +281,18 @@ func ensureRegistry(
 		VolumeName:  reg.Volume,
 		Username:    reg.Username,
 		Password:    reg.Password,
+		Labels:      reg.Labels,
+		HostIP:      reg.HostIP,
+		Publish:     reg.Publish,
+	}
+
+	if reg.TLS {
+		certDir, err := ensureServerCertificate(reg.Name)
+		if err != nil {
+			return false, fmt.Errorf("failed to prepare TLS for registry %s: %w", reg.Name, err)
+		}
+
+		config.TLSCertDir = certDir
 	}
 
 	err := registryMgr.CreateRegistry(ctx, config)
@@ -288,6 +309,17 @@ func ensureRegistry(
 	return true, nil
 }
 
+// ensureServerCertificate returns the directory of a serving certificate for the
+// registry container name, signed by the default mirror registry CA.
+func ensureServerCertificate(name string) (string, error) {
+	authority, err := LoadDefaultTLSAuthority()
+	if err != nil {
+		return "", err
+	}
+
+	return authority.EnsureServerCertificate(name)
+}
+
 func cleanupCreatedRegistries(
 	ctx context.Context,
 	registryMgr Backend,
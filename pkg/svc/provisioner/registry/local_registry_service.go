@@ -52,6 +52,10 @@ type CreateOptions struct {
 	Port        int
 	VolumeName  string
 	ClusterName string
+	// Labels are extra Docker labels stamped onto the registry container.
+	Labels map[string]string
+	// HostIP is the host address Port is published on. Defaults to 127.0.0.1.
+	HostIP string
 }
 
 // WithDefaults applies standard defaults for host bindings and storage metadata.
@@ -281,6 +285,8 @@ func (o CreateOptions) toRegistryInfo() Info {
 		Name:   opts.Name,
 		Port:   opts.Port,
 		Volume: opts.VolumeName,
+		Labels: opts.Labels,
+		HostIP: strings.TrimSpace(opts.HostIP),
 	}
 }
 
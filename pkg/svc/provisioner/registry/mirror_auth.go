@@ -0,0 +1,240 @@
+package registry
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	dockerconfig "github.com/docker/cli/cli/config"
+)
+
+// Reserved usernames in the credential segment of a mirror spec. They follow the
+// Docker CLI convention of storing identity tokens under the "<token>" username and
+// cannot collide with real registry usernames, which never contain angle brackets.
+const (
+	tokenCredentialUser        = "<token>"
+	dockerConfigCredentialUser = "<dockerconfig>"
+)
+
+// DockerConfigDefault selects the Docker CLI's own config.json ($DOCKER_CONFIG, or
+// ~/.docker when unset) as a mirror's credential source.
+const DockerConfigDefault = "default"
+
+// dockerHubAuthKey is the key the Docker CLI stores Docker Hub credentials under.
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// MirrorAuth holds resolved credentials for a mirror's upstream registry. Values are
+// literal secrets: they are only ever passed to containers and in-memory cluster
+// configs, never written to scaffolded files.
+type MirrorAuth struct {
+	Username string
+	Password string
+	// Token is an identity token, used instead of a username and password.
+	Token string
+}
+
+// IsZero reports whether no credentials are set.
+func (a MirrorAuth) IsZero() bool {
+	return a.Username == "" && a.Password == "" && a.Token == ""
+}
+
+// authorizationHeader returns the HTTP Authorization header value for the
+// credentials, or "" when none are set.
+func (a MirrorAuth) authorizationHeader() string {
+	if a.Token != "" {
+		return "Bearer " + a.Token
+	}
+
+	if a.Username == "" && a.Password == "" {
+		return ""
+	}
+
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+}
+
+// k3sAuth returns the credentials in the shape of a K3s registries.yaml auth block.
+func (a MirrorAuth) k3sAuth() map[string]any {
+	if a.Token != "" {
+		return map[string]any{"identity_token": a.Token}
+	}
+
+	return map[string]any{"username": a.Username, "password": a.Password}
+}
+
+// EffectiveAuth returns the credentials populated by ResolveMirrorAuth, or else the
+// username, password and token with ${ENV_VAR} placeholders expanded. Docker config
+// references are only honored once ResolveMirrorAuth has run.
+func (m MirrorSpec) EffectiveAuth() MirrorAuth {
+	if m.Auth != nil {
+		return *m.Auth
+	}
+
+	username, password := m.ResolveCredentials()
+
+	return MirrorAuth{Username: username, Password: password, Token: envvar.Expand(m.Token)}
+}
+
+// ResolveAuth resolves the credentials of the spec, reading them from the referenced
+// Docker config.json when DockerConfig is set.
+func (m MirrorSpec) ResolveAuth() (MirrorAuth, error) {
+	if m.DockerConfig == "" {
+		return m.EffectiveAuth(), nil
+	}
+
+	return dockerConfigAuth(m.DockerConfig, m.Host)
+}
+
+// ResolveMirrorAuth returns a copy of specs with the credentials of every spec that
+// has any resolved into Auth, so downstream consumers see literal values without
+// reading Docker configs again. Specs without credentials are returned unchanged.
+func ResolveMirrorAuth(specs []MirrorSpec) ([]MirrorSpec, error) {
+	resolved := make([]MirrorSpec, len(specs))
+
+	for i, spec := range specs {
+		resolved[i] = spec
+
+		if !spec.HasCredentials() {
+			continue
+		}
+
+		auth, err := spec.ResolveAuth()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve credentials for mirror %s: %w", spec.Host, err)
+		}
+
+		resolved[i].Auth = &auth
+	}
+
+	return resolved, nil
+}
+
+// newMirrorSpec builds a spec from a parsed credential pair, recognizing the reserved
+// <token> and <dockerconfig> usernames.
+func newMirrorSpec(host, remote, username, password string) MirrorSpec {
+	spec := MirrorSpec{Host: host, Remote: remote}
+
+	switch username {
+	case tokenCredentialUser:
+		spec.Token = password
+	case dockerConfigCredentialUser:
+		spec.DockerConfig = password
+		if spec.DockerConfig == "" {
+			spec.DockerConfig = DockerConfigDefault
+		}
+	default:
+		spec.Username = username
+		spec.Password = password
+	}
+
+	return spec
+}
+
+// dockerConfigAuth reads the credentials stored for host in the Docker config at
+// configPath, which may name the config.json file or its directory. Credential
+// stores and helpers configured in the file are consulted like the Docker CLI does.
+func dockerConfigAuth(configPath, host string) (MirrorAuth, error) {
+	path, err := dockerConfigPath(configPath)
+	if err != nil {
+		return MirrorAuth{}, err
+	}
+
+	file, err := os.Open(path) //nolint:gosec // user-selected Docker config path
+	if err != nil {
+		return MirrorAuth{}, fmt.Errorf("failed to open docker config: %w", err)
+	}
+	defer file.Close() //nolint:errcheck // read-only file
+
+	cfg, err := dockerconfig.LoadFromReader(file)
+	if err != nil {
+		return MirrorAuth{}, fmt.Errorf("failed to parse docker config %s: %w", path, err)
+	}
+
+	authConfig, err := cfg.GetAuthConfig(dockerConfigAuthKey(host))
+	if err != nil {
+		return MirrorAuth{}, fmt.Errorf("failed to read credentials for %s: %w", host, err)
+	}
+
+	auth := MirrorAuth{
+		Username: authConfig.Username,
+		Password: authConfig.Password,
+		Token:    authConfig.IdentityToken,
+	}
+	if auth.Token != "" {
+		auth.Username, auth.Password = "", ""
+	}
+
+	if auth.IsZero() {
+		return MirrorAuth{}, fmt.Errorf("%w: %s in %s", ErrNoDockerConfigCredentials, host, path)
+	}
+
+	return auth, nil
+}
+
+// dockerConfigPath returns the config.json path a DockerConfig reference points at.
+func dockerConfigPath(configPath string) (string, error) {
+	if configPath == DockerConfigDefault {
+		return filepath.Join(dockerconfig.Dir(), dockerconfig.ConfigFileName), nil
+	}
+
+	path, err := fsutil.ExpandHomePath(envvar.Expand(configPath))
+	if err != nil {
+		return "", fmt.Errorf("invalid docker config path: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() {
+		path = filepath.Join(path, dockerconfig.ConfigFileName)
+	}
+
+	return path, nil
+}
+
+// dockerConfigAuthKey maps a registry host to the key the Docker CLI stores its
+// credentials under.
+func dockerConfigAuthKey(host string) string {
+	switch host {
+	case "docker.io", "index.docker.io", "registry-1.docker.io":
+		return dockerHubAuthKey
+	default:
+		return host
+	}
+}
+
+// upstreamRegistryHost returns the host of the spec's upstream registry, which is
+// what K3s matches its per-registry configs against.
+func upstreamRegistryHost(spec MirrorSpec) string {
+	remote := spec.Remote
+	if remote == "" {
+		remote = GenerateUpstreamURL(spec.Host)
+	}
+
+	parsed, err := url.Parse(remote)
+	if err != nil || parsed.Host == "" {
+		return spec.Host
+	}
+
+	return parsed.Host
+}
+
+// BuildK3dMirrorAuths returns the credentials of specs keyed by upstream registry
+// host, for use with RenderK3dMirrorRuntimeConfig. Specs without credentials are
+// skipped.
+func BuildK3dMirrorAuths(specs []MirrorSpec) map[string]MirrorAuth {
+	auths := make(map[string]MirrorAuth)
+
+	for _, spec := range specs {
+		auth := spec.EffectiveAuth()
+		if auth.IsZero() || strings.TrimSpace(spec.Host) == "" {
+			continue
+		}
+
+		auths[upstreamRegistryHost(spec)] = auth
+	}
+
+	return auths
+}
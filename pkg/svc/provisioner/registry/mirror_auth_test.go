@@ -0,0 +1,152 @@
+package registry_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMirrorSpecs_TokenCredentials(t *testing.T) {
+	t.Parallel()
+
+	specs := registry.ParseMirrorSpecs([]string{"<token>:${GHCR_TOKEN}@ghcr.io"})
+
+	require.Len(t, specs, 1)
+	assert.Equal(t, "ghcr.io", specs[0].Host)
+	assert.Equal(t, "${GHCR_TOKEN}", specs[0].Token)
+	assert.Empty(t, specs[0].Username)
+	assert.Empty(t, specs[0].Password)
+	assert.True(t, specs[0].HasCredentials())
+}
+
+func TestParseMirrorSpecs_DockerConfigCredentials(t *testing.T) {
+	t.Parallel()
+
+	specs := registry.ParseMirrorSpecs([]string{
+		"<dockerconfig>@ghcr.io",
+		"<dockerconfig>:/tmp/docker/config.json@quay.io=https://quay.io",
+	})
+
+	require.Len(t, specs, 2)
+	assert.Equal(t, registry.DockerConfigDefault, specs[0].DockerConfig)
+	assert.Equal(t, "/tmp/docker/config.json", specs[1].DockerConfig)
+	assert.Equal(t, "https://quay.io", specs[1].Remote)
+	assert.Empty(t, specs[1].Username)
+}
+
+func TestMirrorSpec_EffectiveAuth_ExpandsToken(t *testing.T) {
+	t.Setenv("KSAIL_TEST_MIRROR_TOKEN", "secret-token")
+
+	spec := registry.MirrorSpec{Host: "ghcr.io", Token: "${KSAIL_TEST_MIRROR_TOKEN}"}
+
+	assert.Equal(t, registry.MirrorAuth{Token: "secret-token"}, spec.EffectiveAuth())
+}
+
+func writeDockerConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(content), 0o600)
+	require.NoError(t, err)
+
+	return dir
+}
+
+func TestResolveMirrorAuth_DockerConfig(t *testing.T) {
+	t.Parallel()
+
+	// "dXNlcjpwYXNz" is base64("user:pass").
+	dir := writeDockerConfig(t, `{"auths": {
+		"ghcr.io": {"auth": "dXNlcjpwYXNz"},
+		"https://index.docker.io/v1/": {"identitytoken": "hub-token"}
+	}}`)
+
+	specs, err := registry.ResolveMirrorAuth([]registry.MirrorSpec{
+		{Host: "ghcr.io", DockerConfig: dir},
+		{Host: "docker.io", DockerConfig: filepath.Join(dir, "config.json")},
+		{Host: "quay.io"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, specs, 3)
+	assert.Equal(t, &registry.MirrorAuth{Username: "user", Password: "pass"}, specs[0].Auth)
+	assert.Equal(t, &registry.MirrorAuth{Token: "hub-token"}, specs[1].Auth)
+	assert.Nil(t, specs[2].Auth)
+}
+
+func TestResolveMirrorAuth_DockerConfigWithoutHost(t *testing.T) {
+	t.Parallel()
+
+	dir := writeDockerConfig(t, `{"auths": {}}`)
+
+	_, err := registry.ResolveMirrorAuth([]registry.MirrorSpec{
+		{Host: "ghcr.io", DockerConfig: dir},
+	})
+
+	require.ErrorIs(t, err, registry.ErrNoDockerConfigCredentials)
+}
+
+func TestGenerateHostsToml_WithAuth(t *testing.T) {
+	t.Parallel()
+
+	entry := registry.MirrorEntry{
+		Host:     "ghcr.io",
+		Endpoint: "http://kind-ghcr.io:5000",
+		Remote:   "https://ghcr.io",
+		Auth:     registry.MirrorAuth{Username: "user", Password: "pass"},
+	}
+
+	result := registry.GenerateHostsToml(entry)
+
+	assert.Contains(t, result, "[header]\n  Authorization = \"Basic dXNlcjpwYXNz\"\n")
+	assert.Less(t,
+		strings.Index(result, "[header]"),
+		strings.Index(result, `[host."http://kind-ghcr.io:5000"]`),
+		"the header must be a top-level table so it applies to the server only",
+	)
+
+	entry.Auth = registry.MirrorAuth{Token: "tok"}
+	assert.Contains(t, registry.GenerateHostsToml(entry), `Authorization = "Bearer tok"`)
+
+	entry.Auth = registry.MirrorAuth{}
+	assert.NotContains(t, registry.GenerateHostsToml(entry), "[header]")
+}
+
+func TestRenderK3dMirrorRuntimeConfig_Auth(t *testing.T) {
+	t.Parallel()
+
+	base := `configs:
+  registry-1.docker.io:
+    tls:
+      insecure_skip_verify: true
+`
+	specs := []registry.MirrorSpec{
+		{Host: "docker.io", Remote: "https://registry-1.docker.io", Username: "user", Password: "pass"},
+		{Host: "ghcr.io", Token: "tok"},
+		{Host: "quay.io", Remote: "https://quay.io"},
+	}
+
+	result, err := registry.RenderK3dMirrorRuntimeConfig(base, map[string][]string{
+		"docker.io": {"http://k3d-docker.io:5000"},
+	}, registry.BuildK3dMirrorAuths(specs), nil)
+
+	require.NoError(t, err)
+	assert.Contains(t, result, `configs:
+  ghcr.io:
+    auth:
+      identity_token: tok
+  registry-1.docker.io:
+    auth:
+      password: pass
+      username: user
+    tls:
+      insecure_skip_verify: true
+`)
+	assert.NotContains(t, result, "quay.io")
+}
@@ -0,0 +1,55 @@
+package registry
+
+import (
+	"slices"
+	"strings"
+)
+
+// MirrorPreset is a well-known upstream registry that a mirror spec can name
+// instead of spelling out its host and upstream endpoint.
+type MirrorPreset struct {
+	// Name is the preset name accepted in place of a host, e.g. "dockerhub".
+	Name string
+	// Host is the registry the cluster's image pulls are redirected from.
+	Host string
+	// Remote is the endpoint the mirror fetches from on a cache miss.
+	Remote string
+}
+
+// MirrorPresets returns the named mirror presets, sorted by name.
+func MirrorPresets() []MirrorPreset {
+	return []MirrorPreset{
+		{Name: "dockerhub", Host: "docker.io", Remote: "https://registry-1.docker.io"},
+		{Name: "ecr-public", Host: "public.ecr.aws", Remote: "https://public.ecr.aws"},
+		{Name: "gcr", Host: "gcr.io", Remote: "https://gcr.io"},
+		{Name: "ghcr", Host: "ghcr.io", Remote: "https://ghcr.io"},
+		{Name: "k8s-registry", Host: "registry.k8s.io", Remote: "https://registry.k8s.io"},
+		{Name: "quay", Host: "quay.io", Remote: "https://quay.io"},
+	}
+}
+
+// LookupMirrorPreset returns the preset with the given name, ignoring case.
+func LookupMirrorPreset(name string) (MirrorPreset, bool) {
+	presets := MirrorPresets()
+
+	index := slices.IndexFunc(presets, func(preset MirrorPreset) bool {
+		return strings.EqualFold(preset.Name, name)
+	})
+	if index < 0 {
+		return MirrorPreset{}, false
+	}
+
+	return presets[index], true
+}
+
+// MirrorPresetNames returns the names of all mirror presets, sorted.
+func MirrorPresetNames() []string {
+	presets := MirrorPresets()
+	names := make([]string, 0, len(presets))
+
+	for _, preset := range presets {
+		names = append(names, preset.Name)
+	}
+
+	return names
+}
@@ -437,6 +437,47 @@ func TestParseMirrorSpecs_MixedSpecs(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestParseMirrorSpecs_Presets(t *testing.T) {
+	t.Parallel()
+
+	specs := []string{
+		"dockerhub",
+		"user:pass@ghcr",
+		"K8S-Registry",
+		"quay=https://quay-mirror.example.com",
+		"ecr-public",
+		"gcr",
+	}
+	expected := []registry.MirrorSpec{
+		{Host: "docker.io", Remote: "https://registry-1.docker.io"},
+		{Host: "ghcr.io", Remote: "https://ghcr.io", Username: "user", Password: "pass"},
+		{Host: "registry.k8s.io", Remote: "https://registry.k8s.io"},
+		{Host: "quay.io", Remote: "https://quay-mirror.example.com"},
+		{Host: "public.ecr.aws", Remote: "https://public.ecr.aws"},
+		{Host: "gcr.io", Remote: "https://gcr.io"},
+	}
+
+	result := registry.ParseMirrorSpecs(specs)
+	assert.Equal(t, expected, result)
+
+	hostsToml := registry.GenerateScaffoldedHostsToml(result[0])
+	assert.Contains(t, hostsToml, `server = "https://registry-1.docker.io"`)
+	assert.Contains(t, hostsToml, `[host."http://docker.io:5000"]`)
+}
+
+func TestLookupMirrorPreset(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range registry.MirrorPresetNames() {
+		preset, ok := registry.LookupMirrorPreset(name)
+		require.True(t, ok, name)
+		assert.Regexp(t, "^https://", preset.Remote, name)
+	}
+
+	_, ok := registry.LookupMirrorPreset("docker.io")
+	assert.False(t, ok)
+}
+
 func TestParseMirrorSpecs_AtSignInURL(
 	t *testing.T,
 ) {
@@ -518,6 +559,27 @@ func TestBuildRegistryInfosFromSpecs_WithCredentials(t *testing.T) {
 	assert.Empty(t, infos[1].Password)
 }
 
+func TestApplyMirrorBinding_PublishesListedMirrors(t *testing.T) {
+	t.Parallel()
+
+	specs := registry.ApplyMirrorBinding([]registry.MirrorSpec{
+		{Host: "docker.io", Remote: "https://registry-1.docker.io"},
+		{Host: "ghcr.io", Remote: "https://ghcr.io"},
+	}, "0.0.0.0", map[string]int32{"docker.io": 5001})
+
+	infos := registry.BuildRegistryInfosFromSpecs(specs, nil, nil, "test")
+	require.Len(t, infos, 2)
+
+	assert.Equal(t, 5001, infos[0].Port)
+	assert.Equal(t, "0.0.0.0", infos[0].HostIP)
+	assert.True(t, infos[0].Publish)
+
+	// Unlisted mirrors stay on the Docker network
+	assert.Zero(t, infos[1].Port)
+	assert.Empty(t, infos[1].HostIP)
+	assert.False(t, infos[1].Publish)
+}
+
 func TestAllocatePort(t *testing.T) {
 	t.Parallel()
 
@@ -753,13 +815,79 @@ func TestRenderK3dMirrorConfig(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := registry.RenderK3dMirrorConfig(testCase.input)
+			result, err := registry.RenderK3dMirrorConfig("", testCase.input)
 
+			require.NoError(t, err)
 			assert.Equal(t, testCase.expected, result)
 		})
 	}
 }
 
+func TestRenderK3dMirrorConfig_MergesImportedConfig(t *testing.T) {
+	t.Parallel()
+
+	base := `mirrors:
+  docker.io:
+    endpoint:
+      - https://mirror.example.com
+    rewrite:
+      "^library/(.*)": "mirror/library/$1"
+  quay.io:
+    endpoint:
+      - https://quay-mirror.example.com
+configs:
+  mirror.example.com:
+    auth:
+      username: ci
+      password: secret
+    tls:
+      insecure_skip_verify: true
+`
+
+	result, err := registry.RenderK3dMirrorConfig(base, map[string][]string{
+		"docker.io": {"http://k3d-docker.io:5000"},
+		"ghcr.io":   {"http://k3d-ghcr.io:5000"},
+	})
+	require.NoError(t, err)
+
+	expected := "mirrors:\n" +
+		"  \"docker.io\":\n    endpoint:\n      - http://k3d-docker.io:5000\n" +
+		"    rewrite:\n      \"^library/(.*)\": \"mirror/library/$1\"\n" +
+		"  \"ghcr.io\":\n    endpoint:\n      - http://k3d-ghcr.io:5000\n" +
+		"  \"quay.io\":\n    endpoint:\n      - https://quay-mirror.example.com\n" +
+		"configs:\n" +
+		"  mirror.example.com:\n" +
+		"    auth:\n      password: secret\n      username: ci\n" +
+		"    tls:\n      insecure_skip_verify: true\n"
+	assert.Equal(t, expected, result)
+
+	// Rendering is idempotent, so re-running setup on the merged document is stable.
+	again, err := registry.RenderK3dMirrorConfig(result, map[string][]string{
+		"docker.io": {"http://k3d-docker.io:5000"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, result, again)
+}
+
+func TestRenderK3dMirrorConfig_ConfigsOnly(t *testing.T) {
+	t.Parallel()
+
+	result, err := registry.RenderK3dMirrorConfig(
+		"configs:\n  registry.example.com:\n    tls:\n      ca_file: /etc/ca.crt\n", nil,
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, "configs:\n  registry.example.com:\n    tls:\n      ca_file: /etc/ca.crt\n", result)
+}
+
+func TestRenderK3dMirrorConfig_InvalidBase(t *testing.T) {
+	t.Parallel()
+
+	_, err := registry.RenderK3dMirrorConfig("mirrors: [", nil)
+
+	require.ErrorIs(t, err, registry.ErrInvalidK3dRegistriesConfig)
+}
+
 func TestGenerateScaffoldedHostsToml(t *testing.T) {
 	t.Parallel()
 
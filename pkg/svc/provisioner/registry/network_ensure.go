@@ -27,13 +27,15 @@ const NestedNetworkMTU = "1400"
 // This is used to pre-create the cluster network before registry setup, allowing
 // registry containers to be connected and accessible via Docker DNS when nodes start
 // pulling images during boot. The network is created with Talos-compatible labels and
-// CIDR so the Talos SDK recognizes and reuses it when creating the cluster. An empty
-// mtu falls back to DefaultNetworkMTU.
+// CIDR so the Talos SDK recognizes and reuses it when creating the cluster. When
+// enableIPv6 is set, the network also gets an IPv6 subnet allocated by Docker, for
+// IPv6 and dual-stack clusters. An empty mtu falls back to DefaultNetworkMTU.
 func EnsureNetwork(
 	ctx context.Context,
 	dockerClient dockerclient.Client,
 	networkName string,
 	networkCIDR string,
+	enableIPv6 bool,
 	mtu string,
 	writer io.Writer,
 ) error {
@@ -46,7 +48,7 @@ func EnsureNetwork(
 		return nil
 	}
 
-	return createDockerNetwork(ctx, dockerClient, networkName, networkCIDR, mtu, writer)
+	return createDockerNetwork(ctx, dockerClient, networkName, networkCIDR, enableIPv6, mtu, writer)
 }
 
 // networkExists checks if a Docker network with the given name already exists.
@@ -85,6 +87,7 @@ func createDockerNetwork(
 	dockerClient dockerclient.Client,
 	networkName string,
 	networkCIDR string,
+	enableIPv6 bool,
 	mtu string,
 	writer io.Writer,
 ) error {
@@ -95,7 +98,7 @@ func createDockerNetwork(
 		Args:    []any{networkName},
 	})
 
-	createOptions := buildNetworkCreateOptions(networkName, networkCIDR, mtu)
+	createOptions := buildNetworkCreateOptions(networkName, networkCIDR, enableIPv6, mtu)
 
 	_, err := dockerClient.NetworkCreate(ctx, networkName, createOptions)
 	if err != nil {
@@ -108,7 +111,11 @@ func createDockerNetwork(
 // buildNetworkCreateOptions constructs the Docker network creation configuration
 // with Talos-compatible labels, CIDR, and bridge options. An empty mtu defaults to
 // DefaultNetworkMTU.
-func buildNetworkCreateOptions(networkName, networkCIDR, mtu string) network.CreateOptions {
+func buildNetworkCreateOptions(
+	networkName, networkCIDR string,
+	enableIPv6 bool,
+	mtu string,
+) network.CreateOptions {
 	if mtu == "" {
 		mtu = DefaultNetworkMTU
 	}
@@ -130,6 +137,13 @@ func buildNetworkCreateOptions(networkName, networkCIDR, mtu string) network.Cre
 		},
 	}
 
+	// Docker allocates the IPv6 subnet from its default address pools. The IPv4
+	// subnet stays the only IPAM entry, which is the one the Talos SDK compares
+	// when it decides to reuse the network.
+	if enableIPv6 {
+		createOptions.EnableIPv6 = &enableIPv6
+	}
+
 	if networkCIDR != "" {
 		createOptions.IPAM = &network.IPAM{
 			Config: []network.IPAMConfig{
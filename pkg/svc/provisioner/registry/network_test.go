@@ -290,6 +290,7 @@ func TestEnsureNetwork_ExistingNetwork_ShortCircuits(t *testing.T) {
 		mockClient,
 		"existing-net",
 		"10.5.0.0/24",
+		false,
 		registry.DefaultNetworkMTU,
 		&bytes.Buffer{},
 	)
@@ -322,6 +323,7 @@ func TestEnsureNetwork_CreatePath_SetsTalosLabelsOptionsAndSubnet(t *testing.T)
 		mockClient,
 		"new-net",
 		"10.5.0.0/24",
+		false,
 		registry.DefaultNetworkMTU,
 		&bytes.Buffer{},
 	)
@@ -364,6 +366,7 @@ func TestEnsureNetwork_EmptyCIDR_OmitsIPAM(t *testing.T) {
 		mockClient,
 		"no-cidr",
 		"",
+		false,
 		registry.DefaultNetworkMTU,
 		&bytes.Buffer{},
 	)
@@ -387,6 +390,7 @@ func TestEnsureNetwork_NetworkListError_IsWrapped(t *testing.T) {
 		mockClient,
 		"net",
 		"10.5.0.0/24",
+		false,
 		registry.DefaultNetworkMTU,
 		&bytes.Buffer{},
 	)
@@ -414,6 +418,7 @@ func TestEnsureNetwork_NetworkCreateError_IsWrapped(t *testing.T) {
 		mockClient,
 		"net",
 		"10.5.0.0/24",
+		false,
 		registry.DefaultNetworkMTU,
 		&bytes.Buffer{},
 	)
@@ -443,8 +448,47 @@ func TestEnsureNetwork_HonorsCustomMTU(t *testing.T) {
 		Once()
 
 	err := registry.EnsureNetwork(
-		ctx, mockClient, "nested-net", "10.5.0.0/24", registry.NestedNetworkMTU, &bytes.Buffer{},
+		ctx, mockClient, "nested-net", "10.5.0.0/24", false, registry.NestedNetworkMTU, &bytes.Buffer{},
 	)
 	require.NoError(t, err)
 	assert.Equal(t, registry.NestedNetworkMTU, captured.Options["com.docker.network.driver.mtu"])
 }
+
+func TestEnsureNetwork_IPv6_EnablesIPv6AndKeepsIPv4SubnetFirst(t *testing.T) {
+	t.Parallel()
+
+	mockClient := docker.NewMockAPIClient(t)
+	ctx := context.Background()
+
+	mockClient.EXPECT().
+		NetworkList(ctx, mock.Anything).
+		Return([]network.Summary{}, nil).
+		Once()
+
+	var captured network.CreateOptions
+
+	mockClient.EXPECT().
+		NetworkCreate(ctx, "dual-net", mock.Anything).
+		Run(func(_ context.Context, _ string, opts network.CreateOptions) {
+			captured = opts
+		}).
+		Return(network.CreateResponse{}, nil).
+		Once()
+
+	err := registry.EnsureNetwork(
+		ctx,
+		mockClient,
+		"dual-net",
+		"10.5.0.0/24",
+		true,
+		registry.DefaultNetworkMTU,
+		&bytes.Buffer{},
+	)
+	require.NoError(t, err)
+
+	require.NotNil(t, captured.EnableIPv6)
+	assert.True(t, *captured.EnableIPv6)
+	require.NotNil(t, captured.IPAM)
+	require.Len(t, captured.IPAM.Config, 1)
+	assert.Equal(t, "10.5.0.0/24", captured.IPAM.Config[0].Subnet)
+}
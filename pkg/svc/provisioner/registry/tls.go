@@ -0,0 +1,401 @@
+package registry
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+)
+
+const (
+	// tlsDirName is the directory under ~/.ksail holding the mirror registry CA
+	// and the serving certificates it issued.
+	tlsDirName = "registry-tls"
+	// TLSCACertFile is the file name of the CA certificate, both in the TLS
+	// directory and next to the hosts.toml files on the nodes.
+	TLSCACertFile = "ca.crt"
+	// tlsCAKeyFile is the file name of the CA private key.
+	tlsCAKeyFile = "ca.key"
+	// TLSCertFile is the file name of a registry's serving certificate.
+	TLSCertFile = "tls.crt"
+	// TLSKeyFile is the file name of a registry's serving key.
+	TLSKeyFile = "tls.key"
+	// tlsCACommonName is the subject common name of the generated CA.
+	tlsCACommonName = "KSail mirror registry CA"
+	// tlsCAValidity is how long the generated CA stays valid.
+	tlsCAValidity = 10 * 365 * 24 * time.Hour
+	// tlsCertValidity is how long an issued serving certificate stays valid.
+	tlsCertValidity = 365 * 24 * time.Hour
+	// tlsCertRenewBefore is how close to expiry a serving certificate is reissued.
+	tlsCertRenewBefore = 30 * 24 * time.Hour
+	// tlsSerialBits is the bit length of the random certificate serial numbers.
+	tlsSerialBits = 128
+	// tlsBackdate offsets NotBefore into the past to tolerate clock skew between
+	// the host and the node containers.
+	tlsBackdate = time.Minute
+	// tlsDirPerm keeps the CA key directory private to the user.
+	tlsDirPerm = 0o700
+	// tlsKeyPerm is the permission of private key files.
+	tlsKeyPerm = 0o600
+	// tlsCertPerm is the permission of certificate files, which the registry
+	// container reads through a bind mount.
+	tlsCertPerm = 0o644
+	// K3dRegistryCAPath is where the mirror registry CA is mounted in K3d nodes.
+	K3dRegistryCAPath = "/etc/rancher/k3s/ksail-registry-ca.crt"
+)
+
+// TLSAuthority is the self-signed CA KSail signs mirror registry serving
+// certificates with. It is generated once and reused, so nodes of every cluster
+// trust the same CA.
+type TLSAuthority struct {
+	// Dir is the directory holding the CA and the issued certificates.
+	Dir string
+	// CertPEM is the CA certificate in PEM form, distributed to the nodes.
+	CertPEM []byte
+
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// DefaultTLSDir returns the directory the mirror registry CA is kept in
+// (~/.ksail/registry-tls).
+func DefaultTLSDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".ksail", tlsDirName), nil
+}
+
+// LoadDefaultTLSAuthority loads the CA in DefaultTLSDir, generating it on first use.
+func LoadDefaultTLSAuthority() (*TLSAuthority, error) {
+	dir, err := DefaultTLSDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadOrCreateTLSAuthority(dir)
+}
+
+// LoadOrCreateTLSAuthority loads the CA stored in dir, generating and storing a
+// new one when dir holds none.
+func LoadOrCreateTLSAuthority(dir string) (*TLSAuthority, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, TLSCACertFile)) //nolint:gosec // KSail-managed path
+	if errors.Is(err, fs.ErrNotExist) {
+		return createTLSAuthority(dir)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry CA: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(dir, tlsCAKeyFile)) //nolint:gosec // KSail-managed path
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry CA key: %w", err)
+	}
+
+	cert, key, err := parseCertAndKey(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry CA in %s: %w", dir, err)
+	}
+
+	return &TLSAuthority{Dir: dir, CertPEM: certPEM, cert: cert, key: key}, nil
+}
+
+// CACertPath returns the path of the CA certificate file on the host.
+func (a *TLSAuthority) CACertPath() string {
+	return filepath.Join(a.Dir, TLSCACertFile)
+}
+
+// EnsureServerCertificate makes sure a serving certificate for the registry
+// container name exists, is signed by the CA and is not about to expire,
+// issuing a new one otherwise. It returns the directory holding TLSCertFile and
+// TLSKeyFile, which is bind-mounted into the registry container.
+func (a *TLSAuthority) EnsureServerCertificate(name string) (string, error) {
+	certDir := filepath.Join(a.Dir, name)
+
+	if a.hasValidServerCertificate(certDir, name) {
+		return certDir, nil
+	}
+
+	err := os.MkdirAll(certDir, tlsDirPerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to create certificate directory: %w", err)
+	}
+
+	certPEM, keyPEM, err := a.issueServerCertificate(name)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(filepath.Join(certDir, TLSKeyFile), keyPEM, tlsKeyPerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to write serving key: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(certDir, TLSCertFile), certPEM, tlsCertPerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to write serving certificate: %w", err)
+	}
+
+	return certDir, nil
+}
+
+// hasValidServerCertificate reports whether certDir holds a certificate for name
+// that chains to the CA and stays valid beyond the renewal window.
+func (a *TLSAuthority) hasValidServerCertificate(certDir, name string) bool {
+	certPEM, err := os.ReadFile(filepath.Join(certDir, TLSCertFile)) //nolint:gosec // KSail-managed path
+	if err != nil {
+		return false
+	}
+
+	_, err = os.Stat(filepath.Join(certDir, TLSKeyFile))
+	if err != nil {
+		return false
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(a.cert)
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:     name,
+		Roots:       roots,
+		CurrentTime: time.Now().Add(tlsCertRenewBefore),
+	})
+
+	return err == nil
+}
+
+// issueServerCertificate signs a serving certificate for the registry container
+// name, also valid for localhost so the container's health check can verify it.
+func (a *TLSAuthority) issueServerCertificate(name string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate serving key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name, "localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:    now.Add(-tlsBackdate),
+		NotAfter:     now.Add(tlsCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, &key.PublicKey, a.key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign serving certificate: %w", err)
+	}
+
+	keyPEM, err := encodeECKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM, nil
+}
+
+// createTLSAuthority generates a new CA and stores it in dir.
+func createTLSAuthority(dir string) (*TLSAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate registry CA key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: tlsCACommonName},
+		NotBefore:             now.Add(-tlsBackdate),
+		NotAfter:              now.Add(tlsCAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign registry CA: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse registry CA: %w", err)
+	}
+
+	keyPEM, err := encodeECKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	err = os.MkdirAll(dir, tlsDirPerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry TLS directory: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, tlsCAKeyFile), keyPEM, tlsKeyPerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write registry CA key: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, TLSCACertFile), certPEM, tlsCertPerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write registry CA: %w", err)
+	}
+
+	return &TLSAuthority{Dir: dir, CertPEM: certPEM, cert: cert, key: key}, nil
+}
+
+// parseCertAndKey decodes a PEM certificate and its PEM EC private key.
+func parseCertAndKey(certPEM, keyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, nil, ErrInvalidRegistryCA
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, ErrInvalidRegistryCA
+	}
+
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse key: %w", err)
+	}
+
+	return cert, key, nil
+}
+
+// encodeECKey encodes an EC private key as a PEM "EC PRIVATE KEY" block.
+func encodeECKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+// randomSerial returns a random certificate serial number.
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), tlsSerialBits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate serial: %w", err)
+	}
+
+	return serial, nil
+}
+
+// ApplyMirrorTLS returns a copy of specs that serve HTTPS with certificates
+// signed by authority. Like Auth, the authority is runtime-only and never
+// written to scaffolded files.
+func ApplyMirrorTLS(specs []MirrorSpec, authority *TLSAuthority) []MirrorSpec {
+	applied := make([]MirrorSpec, len(specs))
+
+	for i, spec := range specs {
+		applied[i] = spec
+		applied[i].TLS = authority
+	}
+
+	return applied
+}
+
+// mirrorScheme returns the URL scheme the spec's mirror registry is served on.
+func (m MirrorSpec) mirrorScheme() string {
+	if m.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
+
+// caCertPEM returns the PEM CA the spec's mirror registry certificate chains
+// to, or nil when it serves plain HTTP.
+func (m MirrorSpec) caCertPEM() []byte {
+	if m.TLS == nil {
+		return nil
+	}
+
+	return m.TLS.CertPEM
+}
+
+// MirrorTLSAuthority returns the CA of the first spec served over HTTPS, or nil
+// when every mirror serves plain HTTP.
+func MirrorTLSAuthority(specs []MirrorSpec) *TLSAuthority {
+	for _, spec := range specs {
+		if spec.TLS != nil {
+			return spec.TLS
+		}
+	}
+
+	return nil
+}
+
+// BuildK3dMirrorCAFiles returns K3dRegistryCAPath keyed by the container host and
+// port of every mirror served over HTTPS, for use with RenderK3dMirrorRuntimeConfig.
+// K3s matches TLS configs against the endpoint it connects to, not the mirrored host.
+func BuildK3dMirrorCAFiles(specs []MirrorSpec, containerPrefix string) map[string]string {
+	caFiles := make(map[string]string)
+
+	for _, spec := range specs {
+		host := strings.TrimSpace(spec.Host)
+		if spec.TLS == nil || host == "" {
+			continue
+		}
+
+		endpointHost := net.JoinHostPort(
+			BuildRegistryName(containerPrefix, host),
+			strconv.Itoa(dockerclient.DefaultRegistryPort),
+		)
+		caFiles[endpointHost] = K3dRegistryCAPath
+	}
+
+	return caFiles
+}
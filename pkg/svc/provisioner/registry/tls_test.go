@@ -0,0 +1,149 @@
+package registry_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateTLSAuthority_ReusesStoredCA(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	created, err := registry.LoadOrCreateTLSAuthority(dir)
+	require.NoError(t, err)
+	assert.FileExists(t, created.CACertPath())
+
+	loaded, err := registry.LoadOrCreateTLSAuthority(dir)
+	require.NoError(t, err)
+	assert.Equal(t, created.CertPEM, loaded.CertPEM)
+}
+
+func TestLoadOrCreateTLSAuthority_RejectsCorruptCA(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := registry.LoadOrCreateTLSAuthority(dir)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, registry.TLSCACertFile), []byte("garbage"), 0o600)
+	require.NoError(t, err)
+
+	_, err = registry.LoadOrCreateTLSAuthority(dir)
+	require.ErrorIs(t, err, registry.ErrInvalidRegistryCA)
+}
+
+func TestTLSAuthority_EnsureServerCertificate(t *testing.T) {
+	t.Parallel()
+
+	authority, err := registry.LoadOrCreateTLSAuthority(t.TempDir())
+	require.NoError(t, err)
+
+	certDir, err := authority.EnsureServerCertificate("k3d-docker.io")
+	require.NoError(t, err)
+
+	keyPair, err := tls.LoadX509KeyPair(
+		filepath.Join(certDir, registry.TLSCertFile),
+		filepath.Join(certDir, registry.TLSKeyFile),
+	)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(authority.CertPEM))
+
+	for _, name := range []string{"k3d-docker.io", "localhost"} {
+		_, err = leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: roots})
+		require.NoError(t, err, name)
+	}
+
+	issued, err := os.ReadFile(filepath.Join(certDir, registry.TLSCertFile))
+	require.NoError(t, err)
+
+	// A valid certificate is reused rather than reissued.
+	_, err = authority.EnsureServerCertificate("k3d-docker.io")
+	require.NoError(t, err)
+
+	reused, err := os.ReadFile(filepath.Join(certDir, registry.TLSCertFile))
+	require.NoError(t, err)
+	assert.Equal(t, issued, reused)
+}
+
+func TestApplyMirrorTLS_ServesMirrorsOverHTTPS(t *testing.T) {
+	t.Parallel()
+
+	authority, err := registry.LoadOrCreateTLSAuthority(t.TempDir())
+	require.NoError(t, err)
+
+	specs := registry.ApplyMirrorTLS([]registry.MirrorSpec{
+		{Host: "docker.io", Remote: "https://registry-1.docker.io"},
+	}, authority)
+
+	entries := registry.BuildMirrorEntries(specs, "kind", nil, nil, nil)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "https://kind-docker.io:5000", entries[0].Endpoint)
+	assert.Equal(t, authority.CertPEM, entries[0].CA)
+
+	hostsToml := registry.GenerateHostsToml(entries[0])
+	assert.Contains(t, hostsToml, `[host."https://kind-docker.io:5000"]`)
+	assert.Contains(t, hostsToml, `ca = "/etc/containerd/certs.d/docker.io/ca.crt"`)
+
+	infos := registry.BuildRegistryInfosFromSpecs(specs, nil, nil, "kind")
+	require.Len(t, infos, 1)
+	assert.True(t, infos[0].TLS)
+
+	talosMirrors := registry.BuildTalosMirrorRegistries(specs, "talos")
+	require.Len(t, talosMirrors, 1)
+	assert.Equal(t, authority.CertPEM, talosMirrors[0].CA)
+}
+
+func TestBuildHostEndpointMap_ReplacesHTTPEndpointWithHTTPS(t *testing.T) {
+	t.Parallel()
+
+	authority, err := registry.LoadOrCreateTLSAuthority(t.TempDir())
+	require.NoError(t, err)
+
+	specs := registry.ApplyMirrorTLS([]registry.MirrorSpec{{Host: "docker.io"}}, authority)
+
+	endpoints, updated := registry.BuildHostEndpointMap(specs, "k3d-dev", map[string][]string{
+		"docker.io": {"http://k3d-dev-docker.io:5000"},
+	})
+
+	assert.True(t, updated)
+	assert.Contains(t, endpoints["docker.io"], "https://k3d-dev-docker.io:5000")
+	assert.NotContains(t, endpoints["docker.io"], "http://k3d-dev-docker.io:5000")
+}
+
+func TestRenderK3dMirrorRuntimeConfig_CAFiles(t *testing.T) {
+	t.Parallel()
+
+	authority, err := registry.LoadOrCreateTLSAuthority(t.TempDir())
+	require.NoError(t, err)
+
+	specs := registry.ApplyMirrorTLS([]registry.MirrorSpec{{Host: "docker.io"}}, authority)
+	caFiles := registry.BuildK3dMirrorCAFiles(specs, "k3d-dev")
+
+	assert.Equal(t, map[string]string{
+		"k3d-dev-docker.io:5000": registry.K3dRegistryCAPath,
+	}, caFiles)
+
+	result, err := registry.RenderK3dMirrorRuntimeConfig("", map[string][]string{
+		"docker.io": {"https://k3d-dev-docker.io:5000"},
+	}, nil, caFiles)
+
+	require.NoError(t, err)
+	assert.Contains(t, result, `configs:
+  k3d-dev-docker.io:5000:
+    tls:
+      ca_file: `+registry.K3dRegistryCAPath+"\n")
+}
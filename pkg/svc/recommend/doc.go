@@ -0,0 +1,11 @@
+// Package recommend suggests a starting cluster configuration for
+// `ksail project init --recommend`, so first-time users get a distribution,
+// node topology, and component set that fits their machine and their
+// manifests instead of having to learn every flag up front.
+//
+// The engine has three parts: ProbeHost inspects the host (CPU, memory, OS,
+// virtualization, and whether the container engine is Docker or Podman),
+// ScanRepo inspects the project's manifests (Helm charts, HelmReleases,
+// Kustomizations, and the API groups they use), and Recommend turns both into
+// a Recommendation where every suggestion carries the reason it was made.
+package recommend
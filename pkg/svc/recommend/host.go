@@ -0,0 +1,207 @@
+package recommend
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+)
+
+// Container engine names reported in HostInfo.ContainerEngine.
+const (
+	// EngineDocker is a Docker Engine (including Docker Desktop).
+	EngineDocker = "Docker"
+	// EnginePodman is Podman serving the Docker-compatible API.
+	EnginePodman = "Podman"
+)
+
+// HostInfo describes the machine KSail would run the cluster on.
+type HostInfo struct {
+	// OS is the host operating system (runtime.GOOS).
+	OS string `json:"os"`
+	// Arch is the host CPU architecture (runtime.GOARCH).
+	Arch string `json:"arch"`
+	// CPUs is the number of CPUs available to cluster nodes. When the container
+	// engine is reachable this is the engine's count, which is the VM's count
+	// under Docker Desktop or Podman machine.
+	CPUs int `json:"cpus"`
+	// MemoryBytes is the memory available to cluster nodes, or 0 when unknown.
+	MemoryBytes int64 `json:"memoryBytes"`
+	// Hypervisor names the virtualization layer the host runs under (e.g.
+	// "WSL", "Docker Desktop VM", "hypervisor"), or is empty on bare metal.
+	Hypervisor string `json:"hypervisor,omitempty"`
+	// ContainerEngine is EngineDocker, EnginePodman, or empty when no engine
+	// answered.
+	ContainerEngine string `json:"containerEngine,omitempty"`
+}
+
+// EngineInfoClient is the part of the Docker Engine API ProbeHost needs.
+type EngineInfoClient interface {
+	Info(ctx context.Context) (system.Info, error)
+	ServerVersion(ctx context.Context) (types.Version, error)
+}
+
+// HostProbe reads host facts. Its fields default to the live host; tests point
+// ProcRoot at a fixture directory.
+type HostProbe struct {
+	// Engine queries the container engine; nil skips the engine checks.
+	Engine EngineInfoClient
+	// ProcRoot is the procfs mount point; defaults to "/proc".
+	ProcRoot string
+	// GOOS overrides runtime.GOOS.
+	GOOS string
+	// GOARCH overrides runtime.GOARCH.
+	GOARCH string
+	// NumCPU overrides runtime.NumCPU.
+	NumCPU func() int
+}
+
+// ProbeHost inspects the live host, querying engine for the container engine
+// when it is non-nil.
+func ProbeHost(ctx context.Context, engine EngineInfoClient) HostInfo {
+	return HostProbe{Engine: engine}.Probe(ctx)
+}
+
+// Probe gathers HostInfo. Facts that cannot be read are left at their zero
+// value rather than failing the probe, since a recommendation from partial
+// facts is still useful.
+func (p HostProbe) Probe(ctx context.Context) HostInfo {
+	info := HostInfo{
+		OS:   firstNonEmpty(p.GOOS, runtime.GOOS),
+		Arch: firstNonEmpty(p.GOARCH, runtime.GOARCH),
+	}
+
+	numCPU := p.NumCPU
+	if numCPU == nil {
+		numCPU = runtime.NumCPU
+	}
+
+	info.CPUs = numCPU()
+
+	procRoot := firstNonEmpty(p.ProcRoot, "/proc")
+
+	if info.OS == "linux" {
+		info.MemoryBytes = readMemTotal(filepath.Join(procRoot, "meminfo"))
+		info.Hypervisor = detectLinuxHypervisor(procRoot)
+	}
+
+	if p.Engine == nil {
+		return info
+	}
+
+	engineInfo, err := p.Engine.Info(ctx)
+	if err != nil {
+		return info
+	}
+
+	info.ContainerEngine = EngineDocker
+
+	version, err := p.Engine.ServerVersion(ctx)
+	if err == nil && isPodman(version) {
+		info.ContainerEngine = EnginePodman
+	}
+
+	// The engine's view wins: under Docker Desktop and Podman machine the
+	// nodes run inside a VM whose size differs from the host's.
+	if engineInfo.NCPU > 0 {
+		info.CPUs = engineInfo.NCPU
+	}
+
+	if engineInfo.MemTotal > 0 {
+		info.MemoryBytes = engineInfo.MemTotal
+	}
+
+	if info.Hypervisor == "" && info.OS != "linux" && engineInfo.OperatingSystem != "" {
+		info.Hypervisor = engineInfo.OperatingSystem + " VM"
+	}
+
+	return info
+}
+
+// isPodman reports whether the engine behind the Docker-compatible API is
+// Podman, which lists itself as the "Podman Engine" component.
+func isPodman(version types.Version) bool {
+	for _, component := range version.Components {
+		if strings.Contains(strings.ToLower(component.Name), "podman") {
+			return true
+		}
+	}
+
+	return strings.Contains(strings.ToLower(version.Platform.Name), "podman")
+}
+
+// readMemTotal returns MemTotal from a /proc/meminfo file in bytes, or 0.
+func readMemTotal(path string) int64 {
+	data, err := os.ReadFile(path) //nolint:gosec // fixed procfs path
+	if err != nil {
+		return 0
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+
+		kib, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+
+		const bytesPerKiB = 1024
+
+		return kib * bytesPerKiB
+	}
+
+	return 0
+}
+
+// detectLinuxHypervisor reports WSL from the kernel version string and any
+// other virtualization from the "hypervisor" CPU flag.
+func detectLinuxHypervisor(procRoot string) string {
+	version, err := os.ReadFile(filepath.Join(procRoot, "version")) //nolint:gosec // fixed procfs path
+	if err == nil && strings.Contains(strings.ToLower(string(version)), "microsoft") {
+		return "WSL"
+	}
+
+	cpuinfo, err := os.ReadFile(filepath.Join(procRoot, "cpuinfo")) //nolint:gosec // fixed procfs path
+	if err != nil {
+		return ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(cpuinfo))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "flags") {
+			continue
+		}
+
+		for _, flag := range strings.Fields(line) {
+			if flag == "hypervisor" {
+				return "hypervisor"
+			}
+		}
+
+		return ""
+	}
+
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
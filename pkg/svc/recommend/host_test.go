@@ -0,0 +1,121 @@
+package recommend_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/recommend"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errEngineDown = errors.New("engine down")
+
+// fakeEngine returns fixed engine info and version.
+type fakeEngine struct {
+	info    system.Info
+	version types.Version
+	err     error
+}
+
+func (f fakeEngine) Info(context.Context) (system.Info, error) { return f.info, f.err }
+
+func (f fakeEngine) ServerVersion(context.Context) (types.Version, error) { return f.version, f.err }
+
+// writeProc creates a procfs fixture with the given files.
+func writeProc(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o600))
+	}
+
+	return root
+}
+
+func TestHostProbe_ReadsProcfs(t *testing.T) {
+	t.Parallel()
+
+	procRoot := writeProc(t, map[string]string{
+		"meminfo": "MemTotal:       16384000 kB\nMemFree:         1000 kB\n",
+		"cpuinfo": "processor\t: 0\nflags\t\t: fpu vme hypervisor sse\n",
+		"version": "Linux version 6.1.0\n",
+	})
+
+	info := recommend.HostProbe{
+		ProcRoot: procRoot,
+		GOOS:     "linux",
+		GOARCH:   "amd64",
+		NumCPU:   func() int { return 6 },
+	}.Probe(context.Background())
+
+	assert.Equal(t, recommend.HostInfo{
+		OS:          "linux",
+		Arch:        "amd64",
+		CPUs:        6,
+		MemoryBytes: 16384000 * 1024,
+		Hypervisor:  "hypervisor",
+	}, info)
+}
+
+func TestHostProbe_DetectsWSL(t *testing.T) {
+	t.Parallel()
+
+	procRoot := writeProc(t, map[string]string{
+		"version": "Linux version 5.15.153.1-microsoft-standard-WSL2\n",
+	})
+
+	info := recommend.HostProbe{ProcRoot: procRoot, GOOS: "linux"}.Probe(context.Background())
+
+	assert.Equal(t, "WSL", info.Hypervisor)
+}
+
+func TestHostProbe_EngineResourcesWin(t *testing.T) {
+	t.Parallel()
+
+	info := recommend.HostProbe{
+		Engine: fakeEngine{
+			info: system.Info{NCPU: 4, MemTotal: 8 << 30, OperatingSystem: "Docker Desktop"},
+		},
+		GOOS:   "darwin",
+		NumCPU: func() int { return 12 },
+	}.Probe(context.Background())
+
+	assert.Equal(t, 4, info.CPUs)
+	assert.Equal(t, int64(8<<30), info.MemoryBytes)
+	assert.Equal(t, recommend.EngineDocker, info.ContainerEngine)
+	assert.Equal(t, "Docker Desktop VM", info.Hypervisor)
+}
+
+func TestHostProbe_DetectsPodman(t *testing.T) {
+	t.Parallel()
+
+	info := recommend.HostProbe{
+		Engine: fakeEngine{
+			info:    system.Info{NCPU: 2},
+			version: types.Version{Components: []types.ComponentVersion{{Name: "Podman Engine"}}},
+		},
+		GOOS: "linux",
+	}.Probe(context.Background())
+
+	assert.Equal(t, recommend.EnginePodman, info.ContainerEngine)
+}
+
+func TestHostProbe_UnreachableEngine(t *testing.T) {
+	t.Parallel()
+
+	info := recommend.HostProbe{
+		Engine: fakeEngine{err: errEngineDown},
+		GOOS:   "linux",
+		NumCPU: func() int { return 3 },
+	}.Probe(context.Background())
+
+	assert.Empty(t, info.ContainerEngine)
+	assert.Equal(t, 3, info.CPUs)
+}
@@ -0,0 +1,286 @@
+package recommend
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// gib is one gibibyte in bytes.
+const gib = 1 << 30
+
+// Sizing thresholds for the node topology. They are deliberately generous:
+// the container engine, an IDE, and a browser share the same memory.
+const (
+	// minRealClusterMemory is the least memory a real single-node cluster
+	// plus a handful of workloads runs in; below it only KWOK fits.
+	minRealClusterMemory = 2 * gib
+	// minVanillaMemory is the least memory a Kind node plus the add-ons KSail
+	// installs runs comfortably in; below it K3s is the better fit.
+	minVanillaMemory = 6 * gib
+	// minWorkerMemory and minWorkerCPUs gate suggesting dedicated workers.
+	minWorkerMemory = 16 * gib
+	minWorkerCPUs   = 8
+	// suggestedWorkers is the worker count suggested on roomy hosts.
+	suggestedWorkers = 2
+)
+
+// Suggestion is one `ksail project init` flag the engine recommends.
+type Suggestion struct {
+	// Flag is the init flag name without dashes (e.g. "cni").
+	Flag string `json:"flag"`
+	// Value is the flag value (e.g. "Cilium").
+	Value string `json:"value"`
+	// Reason explains why the value was suggested.
+	Reason string `json:"reason"`
+}
+
+// Recommendation is the suggested starting configuration for a project.
+type Recommendation struct {
+	// Distribution is the suggested Kubernetes distribution.
+	Distribution v1alpha1.Distribution `json:"distribution"`
+	// ControlPlanes is the suggested control-plane node count.
+	ControlPlanes int32 `json:"controlPlanes"`
+	// Workers is the suggested worker node count.
+	Workers int32 `json:"workers"`
+	// Reasons explains the distribution and topology choice.
+	Reasons []string `json:"reasons"`
+	// Components lists the suggested component flags.
+	Components []Suggestion `json:"components,omitempty"`
+	// Notes carries caveats about the host that do not change a flag.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// Args returns the `ksail project init` arguments that apply the
+// recommendation.
+func (r Recommendation) Args() []string {
+	args := []string{
+		"--distribution", string(r.Distribution),
+		"--control-planes", strconv.Itoa(int(r.ControlPlanes)),
+		"--workers", strconv.Itoa(int(r.Workers)),
+	}
+
+	for _, component := range r.Components {
+		args = append(args, "--"+component.Flag, component.Value)
+	}
+
+	return args
+}
+
+// Recommend suggests a distribution, node topology, and components for host
+// and repo.
+func Recommend(host HostInfo, repo RepoInfo) Recommendation {
+	rec := recommendTopology(host)
+	rec.Notes = hostNotes(host)
+
+	if rec.Distribution == v1alpha1.DistributionKWOK {
+		// KWOK simulates nodes and runs no pods, so add-ons would never start;
+		// only the GitOps engine is worth suggesting for manifest validation.
+		rec.Components = gitOpsSuggestions(repo)
+
+		return rec
+	}
+
+	rec.Components = append(gitOpsSuggestions(repo), componentSuggestions(repo)...)
+
+	return rec
+}
+
+// recommendTopology picks the distribution and node counts from the memory and
+// CPUs available to cluster nodes.
+func recommendTopology(host HostInfo) Recommendation {
+	memory := formatGiB(host.MemoryBytes)
+
+	switch {
+	case host.MemoryBytes > 0 && host.MemoryBytes < minRealClusterMemory:
+		return Recommendation{
+			Distribution:  v1alpha1.DistributionKWOK,
+			ControlPlanes: 1,
+			Reasons: []string{
+				fmt.Sprintf("only %s of memory is available to containers, too little for a real cluster", memory),
+				"KWOK simulates nodes and pods, so manifests can be applied and reconciled without running them",
+			},
+		}
+	case host.MemoryBytes == 0 || host.MemoryBytes < minVanillaMemory:
+		reason := fmt.Sprintf("%s of memory is available to containers", memory)
+		if host.MemoryBytes == 0 {
+			reason = "the available memory could not be determined"
+		}
+
+		return Recommendation{
+			Distribution:  v1alpha1.DistributionK3s,
+			ControlPlanes: 1,
+			Reasons: []string{
+				reason,
+				"K3s packs the control plane into a single lightweight process and leaves the most room for workloads",
+			},
+		}
+	}
+
+	rec := Recommendation{
+		Distribution:  v1alpha1.DistributionVanilla,
+		ControlPlanes: 1,
+		Reasons: []string{
+			fmt.Sprintf("%s of memory and %d CPUs are available to containers", memory, host.CPUs),
+			"Vanilla (Kind) runs upstream Kubernetes, the closest match to most production clusters",
+		},
+	}
+
+	if host.ContainerEngine == EnginePodman {
+		rec.Reasons = append(rec.Reasons,
+			"Kind has the most mature Podman support of the local distributions")
+	}
+
+	if host.MemoryBytes >= minWorkerMemory && host.CPUs >= minWorkerCPUs {
+		rec.Workers = suggestedWorkers
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf(
+			"there is room for %d workers to exercise scheduling, anti-affinity, and node drains",
+			suggestedWorkers,
+		))
+	}
+
+	return rec
+}
+
+// hostNotes returns caveats about the container engine and virtualization.
+func hostNotes(host HostInfo) []string {
+	var notes []string
+
+	switch host.ContainerEngine {
+	case "":
+		notes = append(notes,
+			"no container engine answered; start Docker (or Podman) before 'ksail cluster create' — "+
+				"sizing is based on the host's own resources")
+	case EnginePodman:
+		notes = append(notes,
+			"Podman is used through its Docker-compatible API; point DOCKER_HOST at the Podman socket")
+	}
+
+	switch host.Hypervisor {
+	case "":
+	case "WSL":
+		notes = append(notes,
+			"running under WSL 2: memory is capped by .wslconfig, raise it there for larger clusters")
+	default:
+		notes = append(notes, fmt.Sprintf(
+			"cluster nodes run inside a virtual machine (%s); resize the VM to grow the cluster",
+			host.Hypervisor,
+		))
+	}
+
+	return notes
+}
+
+// gitOpsSuggestions picks a GitOps engine from the resources the repo already
+// uses, falling back to Flux for plain Kustomize or Helm projects.
+func gitOpsSuggestions(repo RepoInfo) []Suggestion {
+	engine := func(value v1alpha1.GitOpsEngine, reason string) []Suggestion {
+		return []Suggestion{{Flag: "gitops-engine", Value: string(value), Reason: reason}}
+	}
+
+	switch {
+	case repo.UsesGroup("argoproj.io"):
+		return engine(v1alpha1.GitOpsEngineArgoCD, "the manifests declare Argo CD resources")
+	case repo.HelmReleases > 0 || repo.UsesGroup("toolkit.fluxcd.io"):
+		return engine(v1alpha1.GitOpsEngineFlux, "the manifests declare Flux resources such as HelmReleases")
+	case repo.Kustomizations > 0 && repo.HelmCharts > 0:
+		return engine(v1alpha1.GitOpsEngineFlux, "Flux reconciles both the Kustomize overlays and the Helm charts")
+	case repo.Kustomizations > 0:
+		return engine(v1alpha1.GitOpsEngineFlux, "Flux reconciles the Kustomize overlays natively")
+	case repo.HelmCharts > 0:
+		return engine(v1alpha1.GitOpsEngineFlux, "Flux installs the Helm charts as HelmReleases")
+	default:
+		return nil
+	}
+}
+
+// componentRule suggests a component when the repo uses an API group or kind.
+type componentRule struct {
+	group  string
+	kind   string
+	flag   string
+	value  string
+	reason string
+}
+
+// componentRules are evaluated in order; the first rule per flag wins.
+//
+//nolint:gochecknoglobals // read-only rule table
+var componentRules = []componentRule{
+	{group: "cilium.io", flag: "cni", value: string(v1alpha1.CNICilium),
+		reason: "the manifests use Cilium resources such as CiliumNetworkPolicy"},
+	{kind: "NetworkPolicy", flag: "cni", value: string(v1alpha1.CNICalico),
+		reason: "the manifests declare NetworkPolicies, which the default CNI does not enforce"},
+	{group: "cert-manager.io", flag: "cert-manager", value: string(v1alpha1.CertManagerEnabled),
+		reason: "the manifests declare cert-manager Certificates or Issuers"},
+	{group: "gateway.networking.k8s.io", flag: "gateway-api", value: string(v1alpha1.GatewayAPIEnvoy),
+		reason: "the manifests declare Gateway API routes"},
+	{kind: "Ingress", flag: "ingress-controller", value: string(v1alpha1.IngressControllerNginx),
+		reason: "the manifests declare Ingresses"},
+	{group: "kyverno.io", flag: "policy-engine", value: string(v1alpha1.PolicyEngineKyverno),
+		reason: "the manifests declare Kyverno policies"},
+	{group: "gatekeeper.sh", flag: "policy-engine", value: string(v1alpha1.PolicyEngineGatekeeper),
+		reason: "the manifests declare Gatekeeper constraints"},
+	{group: "bitnami.com", flag: "sealed-secrets", value: string(v1alpha1.SealedSecretsEnabled),
+		reason: "the manifests declare SealedSecrets"},
+	{group: "external-secrets.io", flag: "external-secrets", value: string(v1alpha1.ExternalSecretsEnabled),
+		reason: "the manifests declare ExternalSecrets or SecretStores"},
+	{group: "istio.io", flag: "service-mesh", value: string(v1alpha1.ServiceMeshIstio),
+		reason: "the manifests declare Istio resources"},
+	{group: "linkerd.io", flag: "service-mesh", value: string(v1alpha1.ServiceMeshLinkerd),
+		reason: "the manifests declare Linkerd resources"},
+	{group: "knative.dev", flag: "knative", value: string(v1alpha1.KnativeEnabled),
+		reason: "the manifests declare Knative Services"},
+	{group: "velero.io", flag: "backup", value: string(v1alpha1.BackupVelero),
+		reason: "the manifests declare Velero schedules or backups"},
+	{kind: "HorizontalPodAutoscaler", flag: "metrics-server", value: string(v1alpha1.MetricsServerEnabled),
+		reason: "HorizontalPodAutoscalers need the resource metrics API"},
+}
+
+// componentSuggestions applies componentRules to repo.
+func componentSuggestions(repo RepoInfo) []Suggestion {
+	var suggestions []Suggestion
+
+	seen := map[string]bool{}
+
+	for _, rule := range componentRules {
+		if seen[rule.flag] {
+			continue
+		}
+
+		matched := (rule.group != "" && repo.UsesGroup(rule.group)) ||
+			(rule.kind != "" && repo.UsesKind(rule.kind))
+		if !matched {
+			continue
+		}
+
+		seen[rule.flag] = true
+		suggestions = append(suggestions, Suggestion{Flag: rule.flag, Value: rule.value, Reason: rule.reason})
+	}
+
+	// Cilium ships its own Gateway API controller, so prefer it over Envoy.
+	for i, suggestion := range suggestions {
+		if suggestion.Flag == "gateway-api" && cniValue(suggestions) == string(v1alpha1.CNICilium) {
+			suggestions[i].Value = string(v1alpha1.GatewayAPICilium)
+			suggestions[i].Reason += "; Cilium implements them without an extra controller"
+		}
+	}
+
+	return suggestions
+}
+
+func cniValue(suggestions []Suggestion) string {
+	for _, suggestion := range suggestions {
+		if suggestion.Flag == "cni" {
+			return suggestion.Value
+		}
+	}
+
+	return ""
+}
+
+// formatGiB renders bytes as gibibytes with one decimal.
+func formatGiB(bytes int64) string {
+	return fmt.Sprintf("%.1f GiB", float64(bytes)/gib)
+}
@@ -0,0 +1,163 @@
+package recommend_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/recommend"
+	"github.com/stretchr/testify/assert"
+)
+
+const gib = 1 << 30
+
+func TestRecommend_Topology(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		host          recommend.HostInfo
+		distribution  v1alpha1.Distribution
+		workers       int32
+		reasonContain string
+	}{
+		{
+			name:          "tiny host simulates with KWOK",
+			host:          recommend.HostInfo{CPUs: 2, MemoryBytes: 1 * gib},
+			distribution:  v1alpha1.DistributionKWOK,
+			reasonContain: "too little for a real cluster",
+		},
+		{
+			name:          "small host uses K3s",
+			host:          recommend.HostInfo{CPUs: 4, MemoryBytes: 4 * gib},
+			distribution:  v1alpha1.DistributionK3s,
+			reasonContain: "4.0 GiB",
+		},
+		{
+			name:          "unknown memory stays conservative",
+			host:          recommend.HostInfo{CPUs: 4},
+			distribution:  v1alpha1.DistributionK3s,
+			reasonContain: "could not be determined",
+		},
+		{
+			name:          "mid-size host uses Vanilla without workers",
+			host:          recommend.HostInfo{CPUs: 4, MemoryBytes: 8 * gib},
+			distribution:  v1alpha1.DistributionVanilla,
+			reasonContain: "upstream Kubernetes",
+		},
+		{
+			name:          "roomy host adds workers",
+			host:          recommend.HostInfo{CPUs: 12, MemoryBytes: 32 * gib},
+			distribution:  v1alpha1.DistributionVanilla,
+			workers:       2,
+			reasonContain: "room for 2 workers",
+		},
+		{
+			name: "podman prefers Kind",
+			host: recommend.HostInfo{
+				CPUs: 4, MemoryBytes: 8 * gib, ContainerEngine: recommend.EnginePodman,
+			},
+			distribution:  v1alpha1.DistributionVanilla,
+			reasonContain: "Podman support",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := recommend.Recommend(test.host, recommend.RepoInfo{})
+
+			assert.Equal(t, test.distribution, rec.Distribution)
+			assert.Equal(t, int32(1), rec.ControlPlanes)
+			assert.Equal(t, test.workers, rec.Workers)
+			assert.Contains(t, strings.Join(rec.Reasons, "\n"), test.reasonContain)
+		})
+	}
+}
+
+func TestRecommend_ComponentsFromRepo(t *testing.T) {
+	t.Parallel()
+
+	host := recommend.HostInfo{CPUs: 8, MemoryBytes: 8 * gib, ContainerEngine: recommend.EngineDocker}
+	repo := recommend.RepoInfo{
+		HelmReleases: 1,
+		APIGroups: []string{
+			"cert-manager.io", "cilium.io", "gateway.networking.k8s.io",
+			"helm.toolkit.fluxcd.io", "kyverno.io", "networking.istio.io",
+		},
+		Kinds: []string{"HorizontalPodAutoscaler", "NetworkPolicy"},
+	}
+
+	rec := recommend.Recommend(host, repo)
+
+	assert.Equal(t, map[string]string{
+		"gitops-engine":  "Flux",
+		"cni":            "Cilium",
+		"cert-manager":   "Enabled",
+		"gateway-api":    "Cilium",
+		"policy-engine":  "Kyverno",
+		"service-mesh":   "Istio",
+		"metrics-server": "Enabled",
+	}, suggestionValues(rec.Components))
+	assert.Empty(t, rec.Notes)
+}
+
+func TestRecommend_ArgoCDWinsOverKustomize(t *testing.T) {
+	t.Parallel()
+
+	rec := recommend.Recommend(
+		recommend.HostInfo{CPUs: 4, MemoryBytes: 8 * gib, ContainerEngine: recommend.EngineDocker},
+		recommend.RepoInfo{Kustomizations: 2, APIGroups: []string{"argoproj.io"}},
+	)
+
+	assert.Equal(t, map[string]string{"gitops-engine": "ArgoCD"}, suggestionValues(rec.Components))
+}
+
+func TestRecommend_KWOKOnlySuggestsGitOps(t *testing.T) {
+	t.Parallel()
+
+	rec := recommend.Recommend(
+		recommend.HostInfo{CPUs: 1, MemoryBytes: gib},
+		recommend.RepoInfo{Kustomizations: 1, APIGroups: []string{"cert-manager.io"}},
+	)
+
+	assert.Equal(t, map[string]string{"gitops-engine": "Flux"}, suggestionValues(rec.Components))
+}
+
+func TestRecommend_HostNotes(t *testing.T) {
+	t.Parallel()
+
+	rec := recommend.Recommend(
+		recommend.HostInfo{CPUs: 4, MemoryBytes: 8 * gib, Hypervisor: "WSL"},
+		recommend.RepoInfo{},
+	)
+
+	notes := strings.Join(rec.Notes, "\n")
+	assert.Contains(t, notes, "no container engine answered")
+	assert.Contains(t, notes, ".wslconfig")
+}
+
+func TestRecommendation_Args(t *testing.T) {
+	t.Parallel()
+
+	rec := recommend.Recommendation{
+		Distribution:  v1alpha1.DistributionK3s,
+		ControlPlanes: 1,
+		Workers:       2,
+		Components:    []recommend.Suggestion{{Flag: "cni", Value: "Cilium"}},
+	}
+
+	assert.Equal(t, []string{
+		"--distribution", "K3s", "--control-planes", "1", "--workers", "2", "--cni", "Cilium",
+	}, rec.Args())
+}
+
+func suggestionValues(suggestions []recommend.Suggestion) map[string]string {
+	values := map[string]string{}
+	for _, suggestion := range suggestions {
+		values[suggestion.Flag] = suggestion.Value
+	}
+
+	return values
+}
@@ -0,0 +1,158 @@
+package recommend
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"sigs.k8s.io/yaml"
+)
+
+// RepoInfo summarizes the manifests in a project's source directory.
+type RepoInfo struct {
+	// Manifests is the number of Kubernetes resources found.
+	Manifests int `json:"manifests"`
+	// HelmCharts is the number of Chart.yaml files found.
+	HelmCharts int `json:"helmCharts"`
+	// HelmReleases is the number of Flux HelmRelease resources found.
+	HelmReleases int `json:"helmReleases"`
+	// Kustomizations is the number of kustomization.yaml files found.
+	Kustomizations int `json:"kustomizations"`
+	// APIGroups lists the distinct API groups the resources use, sorted. The
+	// core group is omitted.
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Kinds lists the distinct resource kinds, sorted.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// UsesGroup reports whether any resource uses the API group or one of its
+// subgroups (e.g. "istio.io" matches "networking.istio.io").
+func (r RepoInfo) UsesGroup(group string) bool {
+	for _, used := range r.APIGroups {
+		if used == group || strings.HasSuffix(used, "."+group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UsesKind reports whether any resource has the given kind.
+func (r RepoInfo) UsesKind(kind string) bool {
+	return slices.Contains(r.Kinds, kind)
+}
+
+// skippedDirs are never scanned: they hold VCS metadata or vendored packages,
+// not the project's manifests.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var skippedDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true}
+
+// resourceHeader is the minimal shape of a Kubernetes resource document.
+type resourceHeader struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// ScanRepo walks dir and summarizes the manifests it contains. A missing
+// directory yields an empty RepoInfo; unparseable YAML files are skipped.
+func ScanRepo(dir string) (RepoInfo, error) {
+	var info RepoInfo
+
+	_, err := os.Stat(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return info, nil
+	}
+
+	groups := map[string]bool{}
+	kinds := map[string]bool{}
+
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if entry.IsDir() {
+			if path != dir && skippedDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		switch entry.Name() {
+		case "Chart.yaml":
+			info.HelmCharts++
+
+			return nil
+		case "kustomization.yaml", "kustomization.yml", "Kustomization":
+			info.Kustomizations++
+
+			return nil
+		}
+
+		if !fsutil.IsYAMLFile(path) {
+			return nil
+		}
+
+		scanManifestFile(path, &info, groups, kinds)
+
+		return nil
+	})
+	if err != nil {
+		return RepoInfo{}, fmt.Errorf("scanning %s: %w", dir, err)
+	}
+
+	info.APIGroups = sortedKeys(groups)
+	info.Kinds = sortedKeys(kinds)
+
+	return info, nil
+}
+
+// scanManifestFile records the API group and kind of every resource in path.
+func scanManifestFile(path string, info *RepoInfo, groups, kinds map[string]bool) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from walking the source dir
+	if err != nil {
+		return
+	}
+
+	for _, doc := range fsutil.SplitYAMLDocuments(data) {
+		var header resourceHeader
+
+		if yaml.Unmarshal(doc, &header) != nil || header.APIVersion == "" || header.Kind == "" {
+			continue
+		}
+
+		info.Manifests++
+		kinds[header.Kind] = true
+
+		group, _, found := strings.Cut(header.APIVersion, "/")
+		if found {
+			groups[group] = true
+		}
+
+		if header.Kind == "HelmRelease" && group == "helm.toolkit.fluxcd.io" {
+			info.HelmReleases++
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
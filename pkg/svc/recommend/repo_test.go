@@ -0,0 +1,65 @@
+package recommend_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/recommend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestScanRepo(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "k8s", "kustomization.yaml"), "resources:\n  - apps.yaml\n")
+	writeFile(t, filepath.Join(dir, "k8s", "apps.yaml"), `apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: podinfo
+---
+apiVersion: networking.istio.io/v1
+kind: VirtualService
+metadata:
+  name: podinfo
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`)
+	writeFile(t, filepath.Join(dir, "charts", "web", "Chart.yaml"), "apiVersion: v2\nname: web\n")
+	writeFile(t, filepath.Join(dir, "charts", "web", "README.md"), "kind: NotYAML\n")
+	writeFile(t, filepath.Join(dir, "broken.yaml"), "{not: [valid")
+	writeFile(t, filepath.Join(dir, ".git", "config.yaml"), "apiVersion: v1\nkind: Secret\n")
+
+	info, err := recommend.ScanRepo(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, info.Manifests)
+	assert.Equal(t, 1, info.HelmCharts)
+	assert.Equal(t, 1, info.HelmReleases)
+	assert.Equal(t, 1, info.Kustomizations)
+	assert.Equal(t, []string{"helm.toolkit.fluxcd.io", "networking.istio.io"}, info.APIGroups)
+	assert.Equal(t, []string{"ConfigMap", "HelmRelease", "VirtualService"}, info.Kinds)
+	assert.True(t, info.UsesGroup("istio.io"))
+	assert.False(t, info.UsesGroup("kyverno.io"))
+	assert.True(t, info.UsesKind("ConfigMap"))
+}
+
+func TestScanRepo_MissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	info, err := recommend.ScanRepo(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, recommend.RepoInfo{}, info)
+}
@@ -0,0 +1,247 @@
+package registryresolver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/netretry"
+	"github.com/devantler-tech/ksail/v7/pkg/envvar"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// Static errors for artifact copying.
+var (
+	// ErrInvalidCopyLine is returned when a copy list line is not a "<src> <dst>" pair.
+	ErrInvalidCopyLine = errors.New("invalid copy list line: expected '<src> <dst>'")
+	// ErrEmptyCopyList is returned when a copy list contains no pairs.
+	ErrEmptyCopyList = errors.New("copy list contains no '<src> <dst>' pairs")
+)
+
+const (
+	copyMaxAttempts   = 3
+	copyRetryBaseWait = 2 * time.Second
+	copyRetryMaxWait  = 15 * time.Second
+
+	// copyListFields is the number of whitespace-separated fields in a copy list line.
+	copyListFields = 2
+)
+
+// CopyPair is one source → destination reference to copy.
+type CopyPair struct {
+	Source      string
+	Destination string
+}
+
+// CopyOptions configures CopyArtifact.
+type CopyOptions struct {
+	// LocalRegistry is the cluster's configured registry. References on the same
+	// host reuse its credentials when they carry none inline.
+	LocalRegistry v1alpha1.LocalRegistry
+	// Keychain resolves credentials for references without inline or local
+	// registry credentials. Nil uses the Docker config keychain.
+	Keychain authn.Keychain
+}
+
+// CopyResult describes a completed copy.
+type CopyResult struct {
+	Source      string
+	Destination string
+	// Digest is the digest of the copied manifest or index.
+	Digest string
+}
+
+// CopyArtifact copies an image, image index, or OCI artifact between registries
+// without pulling it to the local Docker daemon. References accept the
+// --registry credential form ([user:pass@]host[:port]/repo[:tag|@digest], with
+// ${ENV_VAR} placeholders) and an optional oci:// scheme. Multi-arch indexes
+// are copied whole, and transient registry errors are retried.
+func CopyArtifact(ctx context.Context, src, dst string, opts CopyOptions) (*CopyResult, error) {
+	srcRef, srcAuth, err := parseCopyReference(src, opts)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+
+	dstRef, dstAuth, err := parseCopyReference(dst, opts)
+	if err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+
+	var digest string
+
+	err = netretry.Do(
+		ctx,
+		copyMaxAttempts,
+		copyRetryBaseWait,
+		copyRetryMaxWait,
+		func() error {
+			copied, copyErr := copyDescriptor(ctx, srcRef, dstRef, srcAuth, dstAuth)
+			digest = copied
+
+			return copyErr
+		},
+		netretry.WithCancelError(func(ctxErr error) error {
+			return fmt.Errorf("copy cancelled: %w", ctxErr)
+		}),
+	)
+	if err != nil {
+		if netretry.IsCancelled(err) {
+			return nil, err //nolint:wrapcheck // already context-tagged by WithCancelError
+		}
+
+		return nil, fmt.Errorf("copy %s to %s: %w", srcRef, dstRef, err)
+	}
+
+	return &CopyResult{
+		Source:      srcRef.String(),
+		Destination: dstRef.String(),
+		Digest:      digest,
+	}, nil
+}
+
+// copyDescriptor fetches the source manifest and writes it, with every
+// referenced blob, to the destination. Indexes are written as indexes so all
+// platforms are preserved.
+func copyDescriptor(
+	ctx context.Context,
+	srcRef, dstRef name.Reference,
+	srcAuth, dstAuth remote.Option,
+) (string, error) {
+	desc, err := remote.Get(srcRef, remote.WithContext(ctx), srcAuth)
+	if err != nil {
+		return "", fmt.Errorf("fetch source: %w", err)
+	}
+
+	dstOpts := []remote.Option{remote.WithContext(ctx), dstAuth}
+
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return "", fmt.Errorf("read source index: %w", err)
+		}
+
+		err = remote.WriteIndex(dstRef, index, dstOpts...)
+		if err != nil {
+			return "", fmt.Errorf("write index: %w", err)
+		}
+
+		return desc.Digest.String(), nil
+	}
+
+	image, err := desc.Image()
+	if err != nil {
+		return "", fmt.Errorf("read source manifest: %w", err)
+	}
+
+	err = remote.Write(dstRef, image, dstOpts...)
+	if err != nil {
+		return "", fmt.Errorf("write manifest: %w", err)
+	}
+
+	return desc.Digest.String(), nil
+}
+
+// parseCopyReference splits inline credentials off a reference and resolves
+// the authentication to use for it: inline credentials first, then the local
+// registry's credentials when the host matches, then the keychain.
+func parseCopyReference(raw string, opts CopyOptions) (name.Reference, remote.Option, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(raw), "oci://")
+
+	username, password, refStr := splitInlineCredentials(trimmed)
+
+	ref, err := name.ParseReference(refStr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse reference %q: %w", refStr, err)
+	}
+
+	if username != "" && password != "" {
+		return ref, remote.WithAuth(&authn.Basic{Username: username, Password: password}), nil
+	}
+
+	if matchesLocalRegistry(ref, opts.LocalRegistry) && opts.LocalRegistry.HasCredentials() {
+		username, password = opts.LocalRegistry.ResolveCredentials()
+		if password != "" {
+			return ref, remote.WithAuth(&authn.Basic{Username: username, Password: password}), nil
+		}
+	}
+
+	keychain := opts.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+
+	return ref, remote.WithAuthFromKeychain(keychain), nil
+}
+
+// splitInlineCredentials extracts a user:pass@ prefix. Only an "@" before the
+// first "/" marks credentials, so repo@sha256:... digests are left intact.
+func splitInlineCredentials(ref string) (string, string, string) {
+	atIdx := strings.Index(ref, "@")
+	slashIdx := strings.Index(ref, "/")
+
+	if atIdx <= 0 || (slashIdx >= 0 && atIdx > slashIdx) {
+		return "", "", ref
+	}
+
+	username, password, _ := strings.Cut(ref[:atIdx], ":")
+
+	return envvar.Expand(username), envvar.Expand(password), ref[atIdx+1:]
+}
+
+// matchesLocalRegistry reports whether ref points at the configured registry.
+func matchesLocalRegistry(ref name.Reference, registry v1alpha1.LocalRegistry) bool {
+	if !registry.Enabled() {
+		return false
+	}
+
+	host := registry.ResolvedHost()
+	if port := registry.ResolvedPort(); port > 0 {
+		host += ":" + strconv.Itoa(int(port))
+	}
+
+	return ref.Context().RegistryStr() == host
+}
+
+// ParseCopyList reads "<src> <dst>" pairs, one per line. Blank lines and lines
+// starting with # are ignored.
+func ParseCopyList(reader io.Reader) ([]CopyPair, error) {
+	var pairs []CopyPair
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != copyListFields {
+			return nil, fmt.Errorf("%w (line %d: %q)", ErrInvalidCopyLine, lineNumber, line)
+		}
+
+		pairs = append(pairs, CopyPair{Source: fields[0], Destination: fields[1]})
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read copy list: %w", err)
+	}
+
+	if len(pairs) == 0 {
+		return nil, ErrEmptyCopyList
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,177 @@
+package registryresolver_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/registryresolver"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T) string {
+	t.Helper()
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestCopyArtifact_CopiesImage(t *testing.T) {
+	t.Parallel()
+
+	host := newTestRegistry(t)
+
+	image, err := random.Image(256, 2)
+	require.NoError(t, err)
+
+	srcRef, err := name.ParseReference(host + "/src/app:1.0")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(srcRef, image))
+
+	result, err := registryresolver.CopyArtifact(
+		context.Background(),
+		"oci://"+host+"/src/app:1.0",
+		host+"/mirror/app:1.0",
+		registryresolver.CopyOptions{},
+	)
+	require.NoError(t, err)
+
+	wantDigest, err := image.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, wantDigest.String(), result.Digest)
+
+	dstRef, err := name.ParseReference(host + "/mirror/app:1.0")
+	require.NoError(t, err)
+
+	copied, err := remote.Get(dstRef)
+	require.NoError(t, err)
+	assert.Equal(t, wantDigest, copied.Digest)
+}
+
+func TestCopyArtifact_CopiesIndexWhole(t *testing.T) {
+	t.Parallel()
+
+	host := newTestRegistry(t)
+
+	index, err := random.Index(128, 1, 2)
+	require.NoError(t, err)
+
+	srcRef, err := name.ParseReference(host + "/src/multi:latest")
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteIndex(srcRef, index))
+
+	_, err = registryresolver.CopyArtifact(
+		context.Background(),
+		srcRef.String(),
+		host+"/mirror/multi:latest",
+		registryresolver.CopyOptions{},
+	)
+	require.NoError(t, err)
+
+	dstRef, err := name.ParseReference(host + "/mirror/multi:latest")
+	require.NoError(t, err)
+
+	copied, err := remote.Index(dstRef)
+	require.NoError(t, err)
+
+	manifest, err := copied.IndexManifest()
+	require.NoError(t, err)
+	assert.Len(t, manifest.Manifests, 2)
+}
+
+func TestCopyArtifact_InvalidReference(t *testing.T) {
+	t.Parallel()
+
+	_, err := registryresolver.CopyArtifact(
+		context.Background(),
+		"UPPER CASE",
+		"localhost:5000/app:1.0",
+		registryresolver.CopyOptions{},
+	)
+
+	require.ErrorContains(t, err, "source")
+}
+
+//nolint:paralleltest // t.Setenv is incompatible with t.Parallel.
+func TestSplitInlineCredentials(t *testing.T) {
+	t.Setenv("KSAIL_COPY_TEST_TOKEN", "s3cr3t")
+
+	tests := []struct {
+		name         string
+		ref          string
+		wantUser     string
+		wantPassword string
+		wantRef      string
+	}{
+		{"no credentials", "ghcr.io/org/app:1.0", "", "", "ghcr.io/org/app:1.0"},
+		{
+			"inline credentials with env placeholder",
+			"bot:${KSAIL_COPY_TEST_TOKEN}@ghcr.io/org/app:1.0",
+			"bot", "s3cr3t", "ghcr.io/org/app:1.0",
+		},
+		{
+			"digest is not credentials",
+			"ghcr.io/org/app@sha256:abc",
+			"", "", "ghcr.io/org/app@sha256:abc",
+		},
+	}
+
+	for _, testCase := range tests {
+		user, password, ref := registryresolver.SplitInlineCredentials(testCase.ref)
+
+		assert.Equal(t, testCase.wantUser, user, testCase.name)
+		assert.Equal(t, testCase.wantPassword, password, testCase.name)
+		assert.Equal(t, testCase.wantRef, ref, testCase.name)
+	}
+}
+
+func TestMatchesLocalRegistry(t *testing.T) {
+	t.Parallel()
+
+	ref, err := name.ParseReference("localhost:5050/app:1.0")
+	require.NoError(t, err)
+
+	assert.True(t, registryresolver.MatchesLocalRegistry(
+		ref, v1alpha1.LocalRegistry{Registry: "user:pass@localhost:5050"},
+	))
+	assert.False(t, registryresolver.MatchesLocalRegistry(
+		ref, v1alpha1.LocalRegistry{Registry: "ghcr.io/org"},
+	))
+	assert.False(t, registryresolver.MatchesLocalRegistry(ref, v1alpha1.LocalRegistry{}))
+}
+
+func TestParseCopyList(t *testing.T) {
+	t.Parallel()
+
+	pairs, err := registryresolver.ParseCopyList(strings.NewReader(`
+# seed the mirror
+docker.io/library/nginx:1.27   localhost:5050/library/nginx:1.27
+
+ghcr.io/org/app:1.0 localhost:5050/org/app:1.0
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []registryresolver.CopyPair{
+		{Source: "docker.io/library/nginx:1.27", Destination: "localhost:5050/library/nginx:1.27"},
+		{Source: "ghcr.io/org/app:1.0", Destination: "localhost:5050/org/app:1.0"},
+	}, pairs)
+}
+
+func TestParseCopyList_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := registryresolver.ParseCopyList(strings.NewReader("only-one-field\n"))
+	require.ErrorIs(t, err, registryresolver.ErrInvalidCopyLine)
+	require.ErrorContains(t, err, "line 1")
+
+	_, err = registryresolver.ParseCopyList(strings.NewReader("# nothing\n\n"))
+	require.ErrorIs(t, err, registryresolver.ErrEmptyCopyList)
+}
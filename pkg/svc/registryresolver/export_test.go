@@ -12,6 +12,12 @@ var ParseOCIURL = parseOCIURL
 // ParseRegistryFlag exports parseRegistryFlag for benchmarking.
 var ParseRegistryFlag = parseRegistryFlag
 
+// SplitInlineCredentials exports splitInlineCredentials for testing.
+var SplitInlineCredentials = splitInlineCredentials
+
+// MatchesLocalRegistry exports matchesLocalRegistry for testing.
+var MatchesLocalRegistry = matchesLocalRegistry
+
 // ParseHostPortHost exports parseHostPort for benchmarking, returning the host component.
 func ParseHostPortHost(s string) string {
 	return parseHostPort(s).host
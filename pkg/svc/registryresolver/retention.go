@@ -0,0 +1,97 @@
+package registryresolver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	dockerclient "github.com/devantler-tech/ksail/v7/pkg/client/docker"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provisioner/registry"
+)
+
+// ApplyRetentionOptions describes a pushed artifact and the retention to apply
+// to its repository.
+type ApplyRetentionOptions struct {
+	// Host is the registry host the artifact was pushed to.
+	Host string
+	// Port is the host port of the registry.
+	Port int32
+	// IsExternal reports whether the registry is external. External registries
+	// are never pruned.
+	IsExternal bool
+	// Repository is the repository the artifact was pushed to.
+	Repository string
+	// Tag is the pushed tag, which is never deleted.
+	Tag string
+	// Retention is the retention configured for the local registry.
+	Retention v1alpha1.RegistryRetention
+}
+
+// ApplyRetention deletes the tags of the pushed repository that the retention
+// no longer keeps and garbage-collects the local registry. It returns the
+// deleted tags, and nothing when the retention is zero or the artifact was not
+// pushed to a running KSail-managed local registry.
+func ApplyRetention(ctx context.Context, opts ApplyRetentionOptions) ([]dockerclient.RegistryTag, error) {
+	policy := registry.RetentionPolicyFromSpec(opts.Retention)
+	if policy.IsZero() || opts.IsExternal || opts.Port <= 0 || isExternalHost(opts.Host) {
+		return nil, nil
+	}
+
+	resources, err := dockerclient.NewResources()
+	if err != nil {
+		return nil, fmt.Errorf("create docker resources: %w", err)
+	}
+
+	defer resources.Close()
+
+	name, err := findLocalRegistryByPort(ctx, resources.RegistryManager, opts.Port)
+	if err != nil || name == "" {
+		return nil, err
+	}
+
+	policy.Repositories = []string{opts.Repository}
+	policy.Protected = []string{opts.Tag}
+
+	results, err := registry.PruneArtifacts(ctx, resources.RegistryManager, registry.ArtifactPruneOptions{
+		Names:  []string{name},
+		Policy: policy,
+	}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("prune artifacts of %s: %w", name, err)
+	}
+
+	var deleted []dockerclient.RegistryTag
+
+	for _, result := range results {
+		deleted = append(deleted, result.DeletedTags...)
+	}
+
+	return deleted, nil
+}
+
+// findLocalRegistryByPort returns the name of the running local registry
+// published on port, or an empty name when there is none.
+func findLocalRegistryByPort(
+	ctx context.Context,
+	registryManager *dockerclient.RegistryManager,
+	port int32,
+) (string, error) {
+	caches, err := registryManager.ListRegistryCaches(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list registries: %w", err)
+	}
+
+	for _, cache := range caches {
+		if cache.Upstream != "" || !cache.Running {
+			continue
+		}
+
+		hostPort, err := registryManager.GetContainerPort(ctx, cache.Name, dockerclient.DefaultRegistryPort)
+		if err == nil && int32(hostPort) == port { //nolint:gosec // port validated by Docker API
+			return cache.Name, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,12 @@
+// Package sbom builds a software bill of materials for a KSail cluster: the
+// node images it runs on, the Helm charts KSail installed into it (with their
+// versions), and the container images of those components and of the
+// distribution's own kube-system workloads. Images are recorded with the
+// digest the container runtime resolved, so the inventory describes what is
+// actually running rather than what was requested.
+//
+// The inventory is collected once with [Collect] and rendered with [Write] as
+// SPDX 2.3 or CycloneDX 1.6 JSON, for compliance attestations on environments
+// used in regulated testing. Workloads the user deployed are not part of the
+// inventory: it covers only what KSail put into the cluster.
+package sbom
@@ -0,0 +1,298 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+	"github.com/package-url/packageurl-go"
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	spdx "github.com/spdx/tools-golang/spdx/v2/v2_3"
+)
+
+// Format is an SBOM output format.
+type Format string
+
+const (
+	// FormatSPDX renders SPDX 2.3 JSON.
+	FormatSPDX Format = "spdx"
+	// FormatCycloneDX renders CycloneDX 1.6 JSON.
+	FormatCycloneDX Format = "cyclonedx"
+)
+
+// ErrUnknownFormat is returned for an SBOM format other than FormatSPDX and FormatCycloneDX.
+var ErrUnknownFormat = errors.New("unknown SBOM format")
+
+// Formats lists the supported formats.
+func Formats() []Format {
+	return []Format{FormatSPDX, FormatCycloneDX}
+}
+
+// toolName names KSail as the SBOM producer.
+const toolName = "ksail"
+
+// noAssertion is SPDX's value for information that was not determined.
+const noAssertion = "NOASSERTION"
+
+// Write renders inventory to writer in format.
+func Write(writer io.Writer, inventory *Inventory, format Format) error {
+	switch format {
+	case FormatSPDX:
+		return writeSPDX(writer, inventory)
+	case FormatCycloneDX:
+		return writeCycloneDX(writer, inventory)
+	default:
+		return fmt.Errorf("%w: %q (supported: spdx, cyclonedx)", ErrUnknownFormat, format)
+	}
+}
+
+// writeCycloneDX renders inventory as a CycloneDX 1.6 JSON BOM whose metadata
+// component is the cluster, which depends on every inventory component.
+func writeCycloneDX(writer io.Writer, inventory *Inventory) error {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = "urn:uuid:" + uuid.NewString()
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: inventory.Created.UTC().Format(time.RFC3339),
+		Tools: &cdx.ToolsChoice{
+			Components: &[]cdx.Component{{
+				Type:    cdx.ComponentTypeApplication,
+				Name:    toolName,
+				Version: inventory.Tool,
+			}},
+		},
+		Component: &cdx.Component{
+			BOMRef:     clusterRef,
+			Type:       cdx.ComponentTypePlatform,
+			Name:       inventory.Cluster,
+			Version:    inventory.KubernetesVersion,
+			Properties: properties(clusterProperties(inventory.Metadata)),
+		},
+	}
+
+	components := make([]cdx.Component, 0, len(inventory.Components))
+	refs := make([]string, 0, len(inventory.Components))
+
+	for index, component := range inventory.Components {
+		ref := fmt.Sprintf("%s-%d", component.Type, index+1)
+		refs = append(refs, ref)
+		components = append(components, cycloneDXComponent(ref, component))
+	}
+
+	bom.Components = &components
+	bom.Dependencies = &[]cdx.Dependency{{Ref: clusterRef, Dependencies: &refs}}
+
+	err := cdx.NewBOMEncoder(writer, cdx.BOMFileFormatJSON).
+		SetPretty(true).
+		EncodeVersion(bom, cdx.SpecVersion1_6)
+	if err != nil {
+		return fmt.Errorf("encode CycloneDX SBOM: %w", err)
+	}
+
+	return nil
+}
+
+// clusterRef is the BOM reference (and SPDX identifier) of the cluster itself.
+const clusterRef = "Cluster"
+
+// cycloneDXComponent converts an inventory component.
+func cycloneDXComponent(ref string, component Component) cdx.Component {
+	converted := cdx.Component{
+		BOMRef:     ref,
+		Type:       cycloneDXType(component),
+		Name:       component.Name,
+		Version:    component.Version,
+		PackageURL: packageURL(component),
+		Properties: properties(componentProperties(component)),
+	}
+
+	if hash, ok := sha256Hex(component.Digest); ok {
+		converted.Hashes = &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: hash}}
+	}
+
+	return converted
+}
+
+// cycloneDXType maps an inventory component to a CycloneDX component type.
+func cycloneDXType(component Component) cdx.ComponentType {
+	switch {
+	case component.Type == ComponentChart:
+		return cdx.ComponentTypeApplication
+	case component.Reference == "":
+		return cdx.ComponentTypeOS
+	default:
+		return cdx.ComponentTypeContainer
+	}
+}
+
+// properties converts name/value pairs to CycloneDX properties, skipping empty values.
+func properties(pairs [][2]string) *[]cdx.Property {
+	converted := make([]cdx.Property, 0, len(pairs))
+
+	for _, pair := range pairs {
+		if pair[1] != "" {
+			converted = append(converted, cdx.Property{Name: pair[0], Value: pair[1]})
+		}
+	}
+
+	if len(converted) == 0 {
+		return nil
+	}
+
+	return &converted
+}
+
+// clusterProperties describes the cluster as "ksail:"-prefixed properties.
+func clusterProperties(metadata Metadata) [][2]string {
+	return [][2]string{
+		{"ksail:distribution", string(metadata.Distribution)},
+		{"ksail:provider", string(metadata.Provider)},
+	}
+}
+
+// componentProperties describes a component as "ksail:"-prefixed properties.
+func componentProperties(component Component) [][2]string {
+	return [][2]string{
+		{"ksail:type", string(component.Type)},
+		{"ksail:component", component.Owner},
+		{"ksail:namespace", component.Namespace},
+		{"ksail:reference", component.Reference},
+		{"ksail:appVersion", component.AppVersion},
+	}
+}
+
+// writeSPDX renders inventory as an SPDX 2.3 JSON document that describes the
+// cluster, which contains every inventory component.
+func writeSPDX(writer io.Writer, inventory *Inventory) error {
+	clusterPackage := &spdx.Package{
+		PackageName:               inventory.Cluster,
+		PackageSPDXIdentifier:     clusterRef,
+		PackageVersion:            inventory.KubernetesVersion,
+		PackageDownloadLocation:   noAssertion,
+		IsFilesAnalyzedTagPresent: true,
+		PackageComment:            spdxComment(clusterProperties(inventory.Metadata)),
+		PrimaryPackagePurpose:     "OTHER",
+	}
+
+	document := &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       spdx.DataLicense,
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      "ksail-cluster-" + inventory.Cluster,
+		DocumentNamespace: "https://ksail.devantler.tech/spdx/" + inventory.Cluster + "-" + uuid.NewString(),
+		CreationInfo: &spdx.CreationInfo{
+			Creators: []common.Creator{{CreatorType: "Tool", Creator: toolName + "-" + inventory.Tool}},
+			Created:  inventory.Created.UTC().Format(time.RFC3339),
+		},
+		Packages: []*spdx.Package{clusterPackage},
+		Relationships: []*spdx.Relationship{{
+			RefA:         common.MakeDocElementID("", "DOCUMENT"),
+			RefB:         common.MakeDocElementID("", clusterRef),
+			Relationship: common.TypeRelationshipDescribe,
+		}},
+	}
+
+	for index, component := range inventory.Components {
+		id := common.ElementID(fmt.Sprintf("Package-%d", index+1))
+		document.Packages = append(document.Packages, spdxPackage(id, component))
+		document.Relationships = append(document.Relationships, &spdx.Relationship{
+			RefA:         common.MakeDocElementID("", clusterRef),
+			RefB:         common.MakeDocElementID("", string(id)),
+			Relationship: common.TypeRelationshipContains,
+		})
+	}
+
+	err := spdxjson.Write(document, writer, spdxjson.Indent("  "))
+	if err != nil {
+		return fmt.Errorf("encode SPDX SBOM: %w", err)
+	}
+
+	return nil
+}
+
+// spdxPackage converts an inventory component.
+func spdxPackage(id common.ElementID, component Component) *spdx.Package {
+	pkg := &spdx.Package{
+		PackageName:               component.Name,
+		PackageSPDXIdentifier:     id,
+		PackageVersion:            component.Version,
+		PackageDownloadLocation:   noAssertion,
+		IsFilesAnalyzedTagPresent: true,
+		PackageLicenseConcluded:   noAssertion,
+		PackageLicenseDeclared:    noAssertion,
+		PackageCopyrightText:      noAssertion,
+		PackageComment:            spdxComment(componentProperties(component)),
+		PrimaryPackagePurpose:     spdxPurpose(component),
+	}
+
+	if hash, ok := sha256Hex(component.Digest); ok {
+		pkg.PackageChecksums = []common.Checksum{{Algorithm: common.SHA256, Value: hash}}
+	}
+
+	if purl := packageURL(component); purl != "" {
+		pkg.PackageExternalReferences = []*spdx.PackageExternalReference{{
+			Category: common.CategoryPackageManager,
+			RefType:  common.TypePackageManagerPURL,
+			Locator:  purl,
+		}}
+	}
+
+	return pkg
+}
+
+// spdxPurpose maps an inventory component to an SPDX primary package purpose.
+func spdxPurpose(component Component) string {
+	switch {
+	case component.Type == ComponentChart:
+		return "INSTALL"
+	case component.Reference == "":
+		return "OPERATING-SYSTEM"
+	default:
+		return "CONTAINER"
+	}
+}
+
+// spdxComment joins non-empty name/value pairs into a package comment.
+func spdxComment(pairs [][2]string) string {
+	parts := make([]string, 0, len(pairs))
+
+	for _, pair := range pairs {
+		if pair[1] != "" {
+			parts = append(parts, pair[0]+"="+pair[1])
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// packageURL returns the OCI package URL of an image component, or "" for charts,
+// operating systems, and images whose digest is unknown (an OCI purl is versioned
+// by digest).
+func packageURL(component Component) string {
+	if component.Type == ComponentChart || component.Reference == "" || component.Digest == "" {
+		return ""
+	}
+
+	repository := component.Name
+	qualifiers := packageurl.Qualifiers{{Key: "repository_url", Value: repository}}
+
+	if component.Version != "" {
+		qualifiers = append(qualifiers, packageurl.Qualifier{Key: "tag", Value: component.Version})
+	}
+
+	return packageurl.NewPackageURL(
+		packageurl.TypeOCI, "", repository[strings.LastIndex(repository, "/")+1:],
+		component.Digest, qualifiers, "",
+	).ToString()
+}
+
+// sha256Hex returns the hex part of a "sha256:<hex>" digest.
+func sha256Hex(digest string) (string, bool) {
+	hash, ok := strings.CutPrefix(digest, "sha256:")
+
+	return hash, ok && hash != ""
+}
@@ -0,0 +1,116 @@
+package sbom_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sbom"
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInventory() *sbom.Inventory {
+	return &sbom.Inventory{
+		Metadata: sbom.Metadata{
+			Cluster:           "dev",
+			Distribution:      v1alpha1.DistributionVanilla,
+			Provider:          v1alpha1.ProviderDocker,
+			KubernetesVersion: "v1.35.1",
+			Tool:              "v7.0.0",
+			Created:           time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+		},
+		Components: []sbom.Component{
+			{
+				Type: sbom.ComponentNodeImage, Name: "index.docker.io/kindest/node", Version: "v1.35.1",
+				Reference: "kindest/node:v1.35.1",
+			},
+			{
+				Type: sbom.ComponentChart, Name: "cilium", Version: "1.16.3", AppVersion: "1.16.3",
+				Owner: "Cilium", Namespace: "kube-system",
+			},
+			{
+				Type: sbom.ComponentImage, Name: "quay.io/cilium/cilium", Version: "v1.16.3",
+				Digest: ciliumDigest, Reference: "quay.io/cilium/cilium:v1.16.3",
+				Owner: "Cilium", Namespace: "kube-system",
+			},
+		},
+	}
+}
+
+func TestWrite_CycloneDX(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, sbom.Write(&buf, testInventory(), sbom.FormatCycloneDX))
+
+	bom := new(cdx.BOM)
+	require.NoError(t, cdx.NewBOMDecoder(&buf, cdx.BOMFileFormatJSON).Decode(bom))
+
+	assert.Equal(t, cdx.SpecVersion1_6, bom.SpecVersion)
+	assert.Equal(t, "2026-10-17T12:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "dev", bom.Metadata.Component.Name)
+	assert.Equal(t, "v1.35.1", bom.Metadata.Component.Version)
+
+	components := *bom.Components
+	require.Len(t, components, 3)
+	assert.Equal(t, cdx.ComponentTypeContainer, components[0].Type)
+	assert.Empty(t, components[0].PackageURL, "an image without a digest has no OCI purl")
+	assert.Equal(t, cdx.ComponentTypeApplication, components[1].Type)
+	assert.Equal(t, "cilium", components[1].Name)
+	assert.Equal(t,
+		"pkg:oci/cilium@sha256:1111111111111111111111111111111111111111111111111111111111111111"+
+			"?repository_url=quay.io%2Fcilium%2Fcilium&tag=v1.16.3",
+		components[2].PackageURL)
+	assert.Equal(t, []cdx.Hash{{
+		Algorithm: cdx.HashAlgoSHA256,
+		Value:     "1111111111111111111111111111111111111111111111111111111111111111",
+	}}, *components[2].Hashes)
+
+	dependencies := *bom.Dependencies
+	require.Len(t, dependencies, 1)
+	assert.Len(t, *dependencies[0].Dependencies, 3)
+}
+
+func TestWrite_SPDX(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, sbom.Write(&buf, testInventory(), sbom.FormatSPDX))
+
+	document, err := spdxjson.Read(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, "SPDX-2.3", document.SPDXVersion)
+	assert.Equal(t, "ksail-cluster-dev", document.DocumentName)
+	assert.Equal(t, "2026-10-17T12:00:00Z", document.CreationInfo.Created)
+	require.Len(t, document.Packages, 4)
+	assert.Equal(t, "dev", document.Packages[0].PackageName)
+	assert.Equal(t, "INSTALL", document.Packages[2].PrimaryPackagePurpose)
+	assert.Equal(t, "CONTAINER", document.Packages[3].PrimaryPackagePurpose)
+	assert.Equal(t, "1111111111111111111111111111111111111111111111111111111111111111",
+		document.Packages[3].PackageChecksums[0].Value)
+	require.Len(t, document.Packages[3].PackageExternalReferences, 1)
+	assert.Equal(t, "purl", document.Packages[3].PackageExternalReferences[0].RefType)
+
+	// DESCRIBES the cluster, which CONTAINS each component.
+	require.Len(t, document.Relationships, 4)
+	assert.Equal(t, "DESCRIBES", document.Relationships[0].Relationship)
+
+	for _, relationship := range document.Relationships[1:] {
+		assert.Equal(t, "CONTAINS", relationship.Relationship)
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	err := sbom.Write(&bytes.Buffer{}, testInventory(), "swid")
+
+	require.ErrorIs(t, err, sbom.ErrUnknownFormat)
+}
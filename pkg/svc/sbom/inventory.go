@@ -0,0 +1,383 @@
+package sbom
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ComponentType classifies an inventory component.
+type ComponentType string
+
+const (
+	// ComponentNodeImage is an image (or, where the provider does not expose one,
+	// the operating system) the cluster's nodes run.
+	ComponentNodeImage ComponentType = "node-image"
+	// ComponentChart is a Helm chart KSail installed.
+	ComponentChart ComponentType = "chart"
+	// ComponentImage is a container image run by a KSail-installed component or by
+	// the distribution in kube-system.
+	ComponentImage ComponentType = "image"
+)
+
+// systemNamespace holds the distribution's own workloads (CoreDNS, kube-proxy,
+// the control plane on kubeadm-based distributions), which KSail put there by
+// creating the cluster.
+const systemNamespace = "kube-system"
+
+// instanceLabel is the standard label Helm charts set to the release name.
+const instanceLabel = "app.kubernetes.io/instance"
+
+// Component is one entry of the inventory.
+type Component struct {
+	// Type classifies the component.
+	Type ComponentType `json:"type"`
+	// Name is the image repository (e.g. "quay.io/cilium/cilium"), the chart name,
+	// or the node operating system.
+	Name string `json:"name"`
+	// Version is the image tag or the chart version.
+	Version string `json:"version,omitempty"`
+	// Digest is the image digest the container runtime resolved (e.g. "sha256:…").
+	Digest string `json:"digest,omitempty"`
+	// Reference is the image reference as deployed.
+	Reference string `json:"reference,omitempty"`
+	// AppVersion is the version of the application a chart deploys.
+	AppVersion string `json:"appVersion,omitempty"`
+	// Owner is the KSail component the entry belongs to (e.g. "Cilium").
+	Owner string `json:"owner,omitempty"`
+	// Namespace is the namespace of the Helm release or of the pods running the image.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Metadata describes the cluster an inventory was collected from.
+type Metadata struct {
+	// Cluster is the cluster name.
+	Cluster string `json:"cluster"`
+	// Distribution and Provider are the cluster's distribution and infrastructure provider.
+	Distribution v1alpha1.Distribution `json:"distribution,omitempty"`
+	Provider     v1alpha1.Provider     `json:"provider,omitempty"`
+	// KubernetesVersion is the kubelet version of the cluster's nodes.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Tool is the version of KSail that collected the inventory.
+	Tool string `json:"tool,omitempty"`
+	// Created is when the inventory was collected.
+	Created time.Time `json:"created"`
+}
+
+// Inventory is everything KSail put into a cluster.
+type Inventory struct {
+	Metadata
+
+	Components []Component `json:"components"`
+}
+
+// Sources are the clients an inventory is collected through.
+type Sources struct {
+	// Clientset reads nodes and pods. Required.
+	Clientset kubernetes.Interface
+	// Helm lists the installed releases. Nil skips the charts (and the images of
+	// their namespaces).
+	Helm helm.Interface
+	// Nodes are the cluster's nodes as reported by the infrastructure provider,
+	// whose Image is recorded as the node image. When no node reports an image, the
+	// operating system of the Kubernetes nodes is recorded instead.
+	Nodes []provider.NodeInfo
+}
+
+// Collect builds the inventory of the cluster reachable through sources.
+// metadata is copied into the inventory; its KubernetesVersion is filled from the
+// nodes when empty.
+func Collect(ctx context.Context, metadata Metadata, sources Sources) (*Inventory, error) {
+	nodes, err := sources.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	if metadata.KubernetesVersion == "" {
+		metadata.KubernetesVersion = kubeletVersion(nodes.Items)
+	}
+
+	inventory := &Inventory{Metadata: metadata}
+	inventory.Components = append(inventory.Components, nodeImages(sources.Nodes, nodes.Items)...)
+
+	var releases []detector.ComponentVersion
+
+	if sources.Helm != nil {
+		versions, err := detector.DetectComponentVersions(ctx, sources.Helm)
+		if err != nil {
+			return nil, fmt.Errorf("detect component versions: %w", err)
+		}
+
+		for _, version := range versions {
+			if version.Deployed() {
+				releases = append(releases, version)
+				inventory.Components = append(inventory.Components, chartComponent(version))
+			}
+		}
+	}
+
+	images, err := componentImages(ctx, sources.Clientset, releases)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory.Components = append(inventory.Components, images...)
+	sortComponents(inventory.Components)
+
+	return inventory, nil
+}
+
+// kubeletVersion returns the kubelet version of the first control-plane node, or
+// of the first node when none is labelled control-plane.
+func kubeletVersion(nodes []corev1.Node) string {
+	for _, node := range nodes {
+		if _, ok := node.Labels["node-role.kubernetes.io/control-plane"]; ok {
+			return node.Status.NodeInfo.KubeletVersion
+		}
+	}
+
+	if len(nodes) > 0 {
+		return nodes[0].Status.NodeInfo.KubeletVersion
+	}
+
+	return ""
+}
+
+// nodeImages returns one component per distinct node image reported by the
+// provider, falling back to the distinct operating systems of the Kubernetes nodes.
+func nodeImages(providerNodes []provider.NodeInfo, nodes []corev1.Node) []Component {
+	seen := map[string]bool{}
+
+	var components []Component
+
+	for _, node := range providerNodes {
+		if node.Image == "" || seen[node.Image] {
+			continue
+		}
+
+		seen[node.Image] = true
+
+		component := imageComponent(node.Image, "")
+		component.Type = ComponentNodeImage
+		components = append(components, component)
+	}
+
+	if len(components) > 0 {
+		return components
+	}
+
+	for _, node := range nodes {
+		osImage := node.Status.NodeInfo.OSImage
+		if osImage == "" || seen[osImage] {
+			continue
+		}
+
+		seen[osImage] = true
+
+		components = append(components, Component{
+			Type:    ComponentNodeImage,
+			Name:    osImage,
+			Version: node.Status.NodeInfo.KernelVersion,
+		})
+	}
+
+	return components
+}
+
+// chartComponent converts a Helm release to a chart component.
+func chartComponent(version detector.ComponentVersion) Component {
+	chartName, chartVersion := splitChart(version.Chart)
+
+	return Component{
+		Type:       ComponentChart,
+		Name:       chartName,
+		Version:    chartVersion,
+		AppVersion: version.AppVersion,
+		Owner:      version.Component,
+		Namespace:  version.Namespace,
+	}
+}
+
+// splitChart splits Helm's "<name>-<version>" chart string at the first dash that
+// is followed by a version (chart names may contain dashes, e.g. "ingress-nginx-4.11.3",
+// and versions may too, e.g. "cert-manager-v1.16.0-rc.1").
+func splitChart(chart string) (string, string) {
+	for index := 1; index < len(chart)-1; index++ {
+		if chart[index] != '-' {
+			continue
+		}
+
+		version := strings.TrimPrefix(chart[index+1:], "v")
+		if version != "" && version[0] >= '0' && version[0] <= '9' {
+			return chart[:index], chart[index+1:]
+		}
+	}
+
+	return chart, ""
+}
+
+// componentImages returns the distinct container images run in kube-system and
+// in the namespaces of releases, attributed to the release whose instance label
+// the pod carries, or to the only release in its namespace. kube-system is shared
+// with the distribution, so unlabelled pods there stay unattributed.
+func componentImages(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	releases []detector.ComponentVersion,
+) ([]Component, error) {
+	owners := map[string]string{}
+	namespaceOwners := map[string][]string{}
+
+	for _, release := range releases {
+		owners[release.Namespace+"/"+release.Release] = release.Component
+		namespaceOwners[release.Namespace] = append(namespaceOwners[release.Namespace], release.Component)
+	}
+
+	namespaces := []string{systemNamespace}
+	for namespace := range namespaceOwners {
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	slices.Sort(namespaces)
+
+	seen := map[string]bool{}
+
+	var components []Component
+
+	for _, namespace := range namespaces {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list pods in namespace %s: %w", namespace, err)
+		}
+
+		for _, pod := range pods.Items {
+			owner := owners[namespace+"/"+pod.Labels[instanceLabel]]
+			if owner == "" && namespace != systemNamespace && len(namespaceOwners[namespace]) == 1 {
+				owner = namespaceOwners[namespace][0]
+			}
+
+			for _, component := range podImages(pod, owner) {
+				key := component.Reference + "@" + component.Digest
+				if seen[key] {
+					continue
+				}
+
+				seen[key] = true
+
+				components = append(components, component)
+			}
+		}
+	}
+
+	return components, nil
+}
+
+// podImages returns the images of a pod's init and regular containers, with the
+// digest taken from the container status when the runtime reported one.
+func podImages(pod corev1.Pod, owner string) []Component {
+	imageIDs := map[string]string{}
+
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		imageIDs[status.Name] = status.ImageID
+	}
+
+	containers := slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers)
+	components := make([]Component, 0, len(containers))
+
+	for _, container := range containers {
+		component := imageComponent(container.Image, imageIDs[container.Name])
+		component.Owner = owner
+		component.Namespace = pod.Namespace
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// imageComponent parses an image reference into an image component. imageID is
+// the runtime's image ID (e.g. "docker.io/library/nginx@sha256:…" or
+// "sha256:…"); its digest is preferred over one pinned in the reference.
+func imageComponent(reference, imageID string) Component {
+	component := Component{Type: ComponentImage, Name: reference, Reference: reference}
+
+	parsed, err := name.ParseReference(reference)
+	if err == nil {
+		component.Name = parsed.Context().Name()
+
+		switch ref := parsed.(type) {
+		case name.Tag:
+			component.Version = ref.TagStr()
+		case name.Digest:
+			component.Digest = ref.DigestStr()
+			component.Version = pinnedTag(reference)
+		}
+	}
+
+	if digest := digestOf(imageID); digest != "" {
+		component.Digest = digest
+	}
+
+	return component
+}
+
+// pinnedTag returns the tag of a "repo:tag@digest" reference, or "" when the
+// reference pins only a digest.
+func pinnedTag(reference string) string {
+	repository, _, _ := strings.Cut(reference, "@")
+	lastSegment := repository[strings.LastIndex(repository, "/")+1:]
+
+	_, tag, _ := strings.Cut(lastSegment, ":")
+
+	return tag
+}
+
+// digestOf extracts the "sha256:…" digest from a runtime image ID.
+func digestOf(imageID string) string {
+	if _, digest, ok := strings.Cut(imageID, "@"); ok {
+		return digest
+	}
+
+	if strings.HasPrefix(imageID, "sha256:") {
+		return imageID
+	}
+
+	return ""
+}
+
+// componentTypeOrder orders the inventory: node images, then charts, then images.
+func componentTypeOrder(componentType ComponentType) int {
+	switch componentType {
+	case ComponentNodeImage:
+		return 0
+	case ComponentChart:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// sortComponents orders components by type, name, and version.
+func sortComponents(components []Component) {
+	slices.SortStableFunc(components, func(left, right Component) int {
+		return cmp.Or(
+			cmp.Compare(componentTypeOrder(left.Type), componentTypeOrder(right.Type)),
+			strings.Compare(left.Name, right.Name),
+			strings.Compare(left.Version, right.Version),
+			strings.Compare(left.Digest, right.Digest),
+		)
+	})
+}
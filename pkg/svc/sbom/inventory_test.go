@@ -0,0 +1,168 @@
+package sbom_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/helm"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/detector"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sbom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	ciliumDigest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	corednsID    = "registry.k8s.io/coredns/coredns@sha256:" +
+		"2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func node(name, osImage string, controlPlane bool) *corev1.Node {
+	labels := map[string]string{}
+	if controlPlane {
+		labels["node-role.kubernetes.io/control-plane"] = ""
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+			KubeletVersion: "v1.35.1",
+			OSImage:        osImage,
+			KernelVersion:  "6.8.0",
+		}},
+	}
+}
+
+func pod(namespace, name, instance, image, imageID string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"app.kubernetes.io/instance": instance},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: image}}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "main", ImageID: imageID},
+		}},
+	}
+}
+
+func clusterObjects() []runtime.Object {
+	return []runtime.Object{
+		node("dev-control-plane", "Debian GNU/Linux 12 (bookworm)", true),
+		node("dev-worker", "Debian GNU/Linux 12 (bookworm)", false),
+		pod("kube-system", "cilium-abc", detector.ReleaseCilium,
+			"quay.io/cilium/cilium:v1.16.3", "quay.io/cilium/cilium@"+ciliumDigest),
+		pod("kube-system", "cilium-def", detector.ReleaseCilium,
+			"quay.io/cilium/cilium:v1.16.3", "quay.io/cilium/cilium@"+ciliumDigest),
+		pod("kube-system", "coredns-1", "", "registry.k8s.io/coredns/coredns:v1.12.0", corednsID),
+		pod("cert-manager", "cert-manager-1", "", "quay.io/jetstack/cert-manager-controller:v1.16.2", ""),
+		pod("default", "my-app", "my-app", "nginx:1.27", ""),
+	}
+}
+
+func releases() []helm.ReleaseInfo {
+	return []helm.ReleaseInfo{
+		{
+			Name: detector.ReleaseCilium, Namespace: detector.NamespaceCilium,
+			Revision: 1, Status: "deployed", Chart: "cilium-1.16.3", AppVersion: "1.16.3",
+		},
+		{
+			Name: detector.ReleaseCertManager, Namespace: detector.NamespaceCertManager,
+			Revision: 1, Status: "deployed", Chart: "cert-manager-v1.16.2", AppVersion: "v1.16.2",
+		},
+		{
+			Name: detector.ReleaseVelero, Namespace: detector.NamespaceVelero,
+			Revision: 1, Status: "failed", Chart: "velero-8.0.0", AppVersion: "1.15.0",
+		},
+	}
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	helmClient := helm.NewMockInterface(t)
+	helmClient.On("ListReleases", ctx).Return(releases(), nil).Once()
+
+	created := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	inventory, err := sbom.Collect(ctx, sbom.Metadata{Cluster: "dev", Created: created}, sbom.Sources{
+		Clientset: k8sfake.NewClientset(clusterObjects()...),
+		Helm:      helmClient,
+		Nodes: []provider.NodeInfo{
+			{Name: "dev-control-plane", Image: "kindest/node:v1.35.1"},
+			{Name: "dev-worker", Image: "kindest/node:v1.35.1"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "v1.35.1", inventory.KubernetesVersion)
+	assert.Equal(t, []sbom.Component{
+		{
+			Type: sbom.ComponentNodeImage, Name: "index.docker.io/kindest/node", Version: "v1.35.1",
+			Reference: "kindest/node:v1.35.1",
+		},
+		{
+			Type: sbom.ComponentChart, Name: "cert-manager", Version: "v1.16.2", AppVersion: "v1.16.2",
+			Owner: "cert-manager", Namespace: "cert-manager",
+		},
+		{
+			Type: sbom.ComponentChart, Name: "cilium", Version: "1.16.3", AppVersion: "1.16.3",
+			Owner: "Cilium", Namespace: "kube-system",
+		},
+		{
+			Type: sbom.ComponentImage, Name: "quay.io/cilium/cilium", Version: "v1.16.3",
+			Digest: ciliumDigest, Reference: "quay.io/cilium/cilium:v1.16.3",
+			Owner: "Cilium", Namespace: "kube-system",
+		},
+		{
+			Type: sbom.ComponentImage, Name: "quay.io/jetstack/cert-manager-controller", Version: "v1.16.2",
+			Reference: "quay.io/jetstack/cert-manager-controller:v1.16.2",
+			Owner:     "cert-manager", Namespace: "cert-manager",
+		},
+		{
+			Type: sbom.ComponentImage, Name: "registry.k8s.io/coredns/coredns", Version: "v1.12.0",
+			Digest:    "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+			Reference: "registry.k8s.io/coredns/coredns:v1.12.0", Namespace: "kube-system",
+		},
+	}, inventory.Components)
+}
+
+func TestCollect_FallsBackToNodeOperatingSystem(t *testing.T) {
+	t.Parallel()
+
+	inventory, err := sbom.Collect(context.Background(), sbom.Metadata{Cluster: "dev"}, sbom.Sources{
+		Clientset: k8sfake.NewClientset(
+			node("talos-cp", "Talos (v1.12.4)", true),
+			node("talos-worker", "Talos (v1.12.4)", false),
+		),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []sbom.Component{
+		{Type: sbom.ComponentNodeImage, Name: "Talos (v1.12.4)", Version: "6.8.0"},
+	}, inventory.Components)
+}
+
+func TestCollect_KeepsTagOfDigestPinnedImage(t *testing.T) {
+	t.Parallel()
+
+	reference := "ghcr.io/example/agent:v2@" + ciliumDigest
+
+	inventory, err := sbom.Collect(context.Background(), sbom.Metadata{Cluster: "dev"}, sbom.Sources{
+		Clientset: k8sfake.NewClientset(pod("kube-system", "agent", "", reference, "")),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, inventory.Components, 1)
+	assert.Equal(t, "ghcr.io/example/agent", inventory.Components[0].Name)
+	assert.Equal(t, "v2", inventory.Components[0].Version)
+	assert.Equal(t, ciliumDigest, inventory.Components[0].Digest)
+}
@@ -0,0 +1,15 @@
+// Package sealedsecrets converts Kubernetes Secret manifests into Bitnami
+// SealedSecrets that only the Sealed Secrets controller running in a cluster
+// can decrypt.
+//
+// Sealing is implemented natively and produces ciphertext identical in format
+// to kubeseal's: each value is encrypted with a random AES-256-GCM session key,
+// and the session key is encrypted with the controller's RSA public key using
+// RSA-OAEP (SHA-256). The OAEP label binds the ciphertext to its scope, so a
+// strict-scoped value cannot be replayed under another Secret name or
+// namespace.
+//
+// The controller's public certificate is served by its Service at
+// /v1/cert.pem; [FetchCertificate] reads it through the API server's service
+// proxy so no port-forward is needed.
+package sealedsecrets
@@ -0,0 +1,226 @@
+package sealedsecrets
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	sealedSecretAPIVersion = "bitnami.com/v1alpha1"
+	sealedSecretKind       = "SealedSecret"
+)
+
+var (
+	// ErrSOPSEncrypted is returned when a Secret is SOPS-encrypted; it must be
+	// decrypted before it can be sealed.
+	ErrSOPSEncrypted = errors.New("secret is SOPS-encrypted; decrypt it before sealing")
+	// ErrMissingName is returned when a Secret has no metadata.name.
+	ErrMissingName = errors.New("secret has no metadata.name")
+)
+
+// Sealer converts Secret manifests into SealedSecrets for one controller key.
+type Sealer struct {
+	// PublicKey is the Sealed Secrets controller's public key.
+	PublicKey *rsa.PublicKey
+	// Scope binds the sealed values; the zero value is treated as ScopeStrict.
+	Scope Scope
+	// DefaultNamespace is used for Secrets that declare no namespace. Strict
+	// and namespace-wide values are bound to it, so it must match the
+	// namespace the Secret is eventually applied to.
+	DefaultNamespace string
+	// Rand is the entropy source; nil uses crypto/rand.
+	Rand io.Reader
+}
+
+// objectMeta is the subset of metadata carried over to a SealedSecret.
+type objectMeta struct {
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// secret is the subset of a v1 Secret manifest that is sealed.
+type secret struct {
+	Metadata   objectMeta        `json:"metadata"`
+	Type       string            `json:"type,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	StringData map[string]string `json:"stringData,omitempty"`
+}
+
+// sealedSecret is a bitnami.com/v1alpha1 SealedSecret manifest.
+type sealedSecret struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	Metadata   objectMeta       `json:"metadata"`
+	Spec       sealedSecretSpec `json:"spec"`
+}
+
+type sealedSecretSpec struct {
+	EncryptedData map[string]string `json:"encryptedData"`
+	Template      secretTemplate    `json:"template"`
+}
+
+type secretTemplate struct {
+	Metadata objectMeta `json:"metadata"`
+	Type     string     `json:"type,omitempty"`
+}
+
+// SealManifest replaces every v1 Secret in a (multi-document) YAML manifest
+// with an equivalent SealedSecret and returns the rewritten manifest along
+// with the number of Secrets sealed. Other documents are kept as they are.
+// When no Secret is found the input is returned unchanged.
+func (s *Sealer) SealManifest(data []byte) ([]byte, int, error) {
+	docs := fsutil.SplitYAMLDocuments(data)
+	out := make([][]byte, 0, len(docs))
+	count := 0
+
+	for _, doc := range docs {
+		if !isSecret(doc) {
+			out = append(out, doc)
+
+			continue
+		}
+
+		sealed, err := s.sealDocument(doc)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		out = append(out, sealed)
+		count++
+	}
+
+	if count == 0 {
+		return data, 0, nil
+	}
+
+	return append(bytes.Join(out, []byte("\n---\n")), '\n'), count, nil
+}
+
+// isSecret reports whether doc is a core v1 Secret.
+func isSecret(doc []byte) bool {
+	var header struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+
+	err := yaml.Unmarshal(doc, &header)
+
+	return err == nil && header.APIVersion == "v1" && header.Kind == "Secret"
+}
+
+// sealDocument converts a single Secret document into a SealedSecret.
+func (s *Sealer) sealDocument(doc []byte) ([]byte, error) {
+	var raw map[string]any
+
+	err := yaml.Unmarshal(doc, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse secret: %w", err)
+	}
+
+	var src secret
+
+	err = yaml.Unmarshal(doc, &src)
+	if err != nil {
+		return nil, fmt.Errorf("parse secret: %w", err)
+	}
+
+	if src.Metadata.Name == "" {
+		return nil, ErrMissingName
+	}
+
+	if _, ok := raw["sops"]; ok {
+		return nil, fmt.Errorf("secret %s: %w", src.Metadata.Name, ErrSOPSEncrypted)
+	}
+
+	namespace := src.Metadata.Namespace
+	if namespace == "" {
+		namespace = s.DefaultNamespace
+	}
+
+	plaintext, err := secretValues(src)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := s.Scope
+	if scope == "" {
+		scope = ScopeStrict
+	}
+
+	label := scope.label(namespace, src.Metadata.Name)
+	encrypted := make(map[string]string, len(plaintext))
+
+	for key, value := range plaintext {
+		ciphertext, err := hybridEncrypt(randReader(s.Rand), s.PublicKey, value, label)
+		if err != nil {
+			return nil, fmt.Errorf("seal %s/%s key %q: %w", namespace, src.Metadata.Name, key, err)
+		}
+
+		encrypted[key] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	annotations := maps.Clone(src.Metadata.Annotations)
+	if name := scope.annotation(); name != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[name] = "true"
+	}
+
+	meta := objectMeta{
+		Name:        src.Metadata.Name,
+		Namespace:   src.Metadata.Namespace,
+		Labels:      src.Metadata.Labels,
+		Annotations: annotations,
+	}
+
+	out, err := yaml.Marshal(sealedSecret{
+		APIVersion: sealedSecretAPIVersion,
+		Kind:       sealedSecretKind,
+		Metadata:   meta,
+		Spec: sealedSecretSpec{
+			EncryptedData: encrypted,
+			Template: secretTemplate{
+				Metadata: meta,
+				Type:     src.Type,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal sealed secret: %w", err)
+	}
+
+	return bytes.TrimSpace(out), nil
+}
+
+// secretValues returns the plaintext of every key in the Secret. stringData
+// entries take precedence over data, matching the API server's merge.
+func secretValues(src secret) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(src.Data)+len(src.StringData))
+
+	for key, encoded := range src.Data {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode secret %s key %q: %w", src.Metadata.Name, key, err)
+		}
+
+		values[key] = decoded
+	}
+
+	for key, value := range src.StringData {
+		values[key] = []byte(value)
+	}
+
+	return values, nil
+}
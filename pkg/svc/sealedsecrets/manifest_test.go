@@ -0,0 +1,195 @@
+package sealedsecrets_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sealedsecrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+const secretManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  mode: dev
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: apps
+  labels:
+    app: db
+type: Opaque
+data:
+  password: aHVudGVyMg==
+stringData:
+  user: admin
+`
+
+// sealedManifest is the decoded shape of a sealed document.
+type sealedManifest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		EncryptedData map[string]string `json:"encryptedData"`
+		Template      struct {
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Type string `json:"type"`
+		} `json:"template"`
+	} `json:"spec"`
+}
+
+func sealOne(t *testing.T, sealer *sealedsecrets.Sealer, manifest string) sealedManifest {
+	t.Helper()
+
+	out, count, err := sealer.SealManifest([]byte(manifest))
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	docs := fsutil.SplitYAMLDocuments(out)
+
+	var sealed sealedManifest
+	require.NoError(t, yaml.Unmarshal(docs[len(docs)-1], &sealed))
+
+	return sealed
+}
+
+func TestSealManifest_Strict(t *testing.T) {
+	t.Parallel()
+
+	key := testKey()
+	sealer := &sealedsecrets.Sealer{PublicKey: &key.PublicKey, DefaultNamespace: "default"}
+
+	out, count, err := sealer.SealManifest([]byte(secretManifest))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, string(out), "kind: ConfigMap")
+	assert.NotContains(t, string(out), "aHVudGVyMg==")
+
+	sealed := sealOne(t, sealer, secretManifest)
+	assert.Equal(t, "bitnami.com/v1alpha1", sealed.APIVersion)
+	assert.Equal(t, "SealedSecret", sealed.Kind)
+	assert.Equal(t, "apps", sealed.Metadata.Namespace)
+	assert.Empty(t, sealed.Metadata.Annotations)
+	assert.Equal(t, "Opaque", sealed.Spec.Template.Type)
+	assert.Equal(t, map[string]string{"app": "db"}, sealed.Spec.Template.Metadata.Labels)
+
+	label := []byte("apps/db")
+	assert.Equal(t, "hunter2", unseal(t, key, sealed.Spec.EncryptedData["password"], label))
+	assert.Equal(t, "admin", unseal(t, key, sealed.Spec.EncryptedData["user"], label))
+}
+
+func TestSealManifest_Scopes(t *testing.T) {
+	t.Parallel()
+
+	key := testKey()
+
+	tests := []struct {
+		scope      sealedsecrets.Scope
+		label      []byte
+		annotation string
+	}{
+		{sealedsecrets.ScopeNamespaceWide, []byte("apps"), "sealedsecrets.bitnami.com/namespace-wide"},
+		{sealedsecrets.ScopeClusterWide, nil, "sealedsecrets.bitnami.com/cluster-wide"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(string(testCase.scope), func(t *testing.T) {
+			t.Parallel()
+
+			sealer := &sealedsecrets.Sealer{PublicKey: &key.PublicKey, Scope: testCase.scope}
+			sealed := sealOne(t, sealer, secretManifest)
+
+			assert.Equal(t, "true", sealed.Metadata.Annotations[testCase.annotation])
+			assert.Equal(t, "admin", unseal(t, key, sealed.Spec.EncryptedData["user"], testCase.label))
+		})
+	}
+}
+
+func TestSealManifest_DefaultNamespaceBindsLabel(t *testing.T) {
+	t.Parallel()
+
+	key := testKey()
+	sealer := &sealedsecrets.Sealer{PublicKey: &key.PublicKey, DefaultNamespace: "team-a"}
+
+	sealed := sealOne(t, sealer, `apiVersion: v1
+kind: Secret
+metadata:
+  name: token
+stringData:
+  token: abc
+`)
+
+	assert.Empty(t, sealed.Metadata.Namespace)
+	assert.Equal(t, "abc", unseal(t, key, sealed.Spec.EncryptedData["token"], []byte("team-a/token")))
+}
+
+func TestSealManifest_NoSecretsLeavesInputUntouched(t *testing.T) {
+	t.Parallel()
+
+	key := testKey()
+	sealer := &sealedsecrets.Sealer{PublicKey: &key.PublicKey}
+	input := []byte("# comment\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n")
+
+	out, count, err := sealer.SealManifest(input)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Equal(t, input, out)
+}
+
+func TestSealManifest_Errors(t *testing.T) {
+	t.Parallel()
+
+	key := testKey()
+	sealer := &sealedsecrets.Sealer{PublicKey: &key.PublicKey}
+
+	tests := []struct {
+		name     string
+		manifest string
+		err      error
+	}{
+		{
+			name: "sops encrypted",
+			manifest: `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  password: ENC[AES256_GCM,data:abc]
+sops:
+  version: 3.9.0
+`,
+			err: sealedsecrets.ErrSOPSEncrypted,
+		},
+		{
+			name: "missing name",
+			manifest: `apiVersion: v1
+kind: Secret
+stringData:
+  a: b
+`,
+			err: sealedsecrets.ErrMissingName,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := sealer.SealManifest([]byte(testCase.manifest))
+			require.ErrorIs(t, err, testCase.err)
+		})
+	}
+}
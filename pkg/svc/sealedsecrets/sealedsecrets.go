@@ -0,0 +1,198 @@
+package sealedsecrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ControllerName is the name of the Sealed Secrets controller Service
+	// KSail installs, matching kubeseal's default.
+	ControllerName = "sealed-secrets-controller"
+	// ControllerNamespace is the namespace the Sealed Secrets controller runs in.
+	ControllerNamespace = "kube-system"
+
+	// certificatePath is the controller endpoint serving its public certificate.
+	certificatePath = "/v1/cert.pem"
+	// controllerPortName is the name of the controller Service's HTTP port.
+	controllerPortName = "http"
+	// sessionKeyBytes is the size of the per-value AES-256 session key.
+	sessionKeyBytes = 32
+)
+
+// Scope controls which Secret names and namespaces a sealed value may be
+// decrypted under.
+type Scope string
+
+const (
+	// ScopeStrict binds a value to the Secret's name and namespace.
+	ScopeStrict Scope = "strict"
+	// ScopeNamespaceWide allows the Secret to be renamed within its namespace.
+	ScopeNamespaceWide Scope = "namespace-wide"
+	// ScopeClusterWide allows the Secret to be renamed and moved to any namespace.
+	ScopeClusterWide Scope = "cluster-wide"
+)
+
+var (
+	// ErrInvalidScope is returned when a scope name is not recognised.
+	ErrInvalidScope = errors.New("invalid sealing scope")
+	// ErrNoCertificate is returned when PEM data contains no certificate.
+	ErrNoCertificate = errors.New("no certificate found in PEM data")
+	// ErrNotRSAKey is returned when the certificate does not carry an RSA public key.
+	ErrNotRSAKey = errors.New("certificate public key is not RSA")
+	// ErrSessionKeyTooLong is returned when the RSA ciphertext does not fit the
+	// two-byte length prefix of the sealed format.
+	ErrSessionKeyTooLong = errors.New("encrypted session key exceeds 65535 bytes")
+)
+
+// ValidScopes returns the supported sealing scopes.
+func ValidScopes() []Scope {
+	return []Scope{ScopeStrict, ScopeNamespaceWide, ScopeClusterWide}
+}
+
+// ParseScope returns the Scope named by value.
+func ParseScope(value string) (Scope, error) {
+	scope := Scope(value)
+	if !slices.Contains(ValidScopes(), scope) {
+		return "", fmt.Errorf("%w: %q (valid: strict, namespace-wide, cluster-wide)", ErrInvalidScope, value)
+	}
+
+	return scope, nil
+}
+
+// label returns the OAEP label that binds a sealed value to its scope.
+func (s Scope) label(namespace, name string) []byte {
+	switch s {
+	case ScopeClusterWide:
+		return nil
+	case ScopeNamespaceWide:
+		return []byte(namespace)
+	case ScopeStrict:
+		return []byte(namespace + "/" + name)
+	}
+
+	return []byte(namespace + "/" + name)
+}
+
+// annotation returns the SealedSecret annotation that declares a non-strict
+// scope to the controller, or "" for strict.
+func (s Scope) annotation() string {
+	switch s {
+	case ScopeClusterWide:
+		return "sealedsecrets.bitnami.com/cluster-wide"
+	case ScopeNamespaceWide:
+		return "sealedsecrets.bitnami.com/namespace-wide"
+	case ScopeStrict:
+		return ""
+	}
+
+	return ""
+}
+
+// ParsePublicKey extracts the RSA public key from the first certificate in
+// PEM-encoded data, such as the controller's /v1/cert.pem response.
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, ErrNoCertificate
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate: %w", err)
+		}
+
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, ErrNotRSAKey
+		}
+
+		return key, nil
+	}
+}
+
+// FetchCertificate reads the PEM-encoded public certificate of the Sealed
+// Secrets controller behind the Service name in namespace, via the API
+// server's service proxy.
+func FetchCertificate(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name string,
+) ([]byte, error) {
+	data, err := client.CoreV1().
+		Services(namespace).
+		ProxyGet("http", name, controllerPortName, certificatePath, nil).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"fetch sealed secrets certificate from service %s/%s: %w", namespace, name, err,
+		)
+	}
+
+	return data, nil
+}
+
+// hybridEncrypt seals plaintext in kubeseal's format: a two-byte big-endian
+// length, the RSA-OAEP encrypted session key, then the AES-GCM ciphertext
+// under a zero nonce (safe because every session key is used once).
+func hybridEncrypt(rnd io.Reader, pubKey *rsa.PublicKey, plaintext, label []byte) ([]byte, error) {
+	sessionKey := make([]byte, sessionKeyBytes)
+
+	_, err := io.ReadFull(rnd, sessionKey)
+	if err != nil {
+		return nil, fmt.Errorf("generate session key: %w", err)
+	}
+
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, fmt.Errorf("create session cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create session cipher: %w", err)
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rnd, pubKey, sessionKey, label)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt session key: %w", err)
+	}
+
+	if len(encryptedKey) > 0xFFFF {
+		return nil, ErrSessionKeyTooLong
+	}
+
+	ciphertext := binary.BigEndian.AppendUint16(nil, uint16(len(encryptedKey)))
+	ciphertext = append(ciphertext, encryptedKey...)
+
+	return aead.Seal(ciphertext, make([]byte, aead.NonceSize()), plaintext, nil), nil
+}
+
+// randReader returns rnd, or crypto/rand when rnd is nil.
+func randReader(rnd io.Reader) io.Reader {
+	if rnd == nil {
+		return rand.Reader
+	}
+
+	return rnd
+}
@@ -0,0 +1,145 @@
+package sealedsecrets_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sealedsecrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+//nolint:gochecknoglobals // RSA key generation is slow; share one key across tests.
+var testKey = sync.OnceValue(func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+})
+
+// certificatePEM returns a self-signed PEM certificate for key.
+func certificatePEM(t *testing.T, key *rsa.PrivateKey) []byte {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sealed-secret"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// unseal reverses the sealed format the way the controller does.
+func unseal(t *testing.T, key *rsa.PrivateKey, encoded string, label []byte) string {
+	t.Helper()
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	require.Greater(t, len(ciphertext), 2)
+
+	keyLen := int(binary.BigEndian.Uint16(ciphertext))
+	require.Greater(t, len(ciphertext), 2+keyLen)
+
+	sessionKey, err := rsa.DecryptOAEP(sha256.New(), nil, key, ciphertext[2:2+keyLen], label)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(sessionKey)
+	require.NoError(t, err)
+
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	plaintext, err := aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[2+keyLen:], nil)
+	require.NoError(t, err)
+
+	return string(plaintext)
+}
+
+func TestParseScope(t *testing.T) {
+	t.Parallel()
+
+	for _, scope := range sealedsecrets.ValidScopes() {
+		parsed, err := sealedsecrets.ParseScope(string(scope))
+		require.NoError(t, err)
+		assert.Equal(t, scope, parsed)
+	}
+
+	_, err := sealedsecrets.ParseScope("global")
+	require.ErrorIs(t, err, sealedsecrets.ErrInvalidScope)
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Parallel()
+
+	key := testKey()
+
+	parsed, err := sealedsecrets.ParsePublicKey(certificatePEM(t, key))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(parsed))
+
+	_, err = sealedsecrets.ParsePublicKey([]byte("not a certificate"))
+	require.ErrorIs(t, err, sealedsecrets.ErrNoCertificate)
+}
+
+// proxyResponse serves a fixed body for a fake service proxy request.
+type proxyResponse struct {
+	body []byte
+}
+
+func (r proxyResponse) DoRaw(context.Context) ([]byte, error) { return r.body, nil }
+
+func (r proxyResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(r.body)), nil
+}
+
+func TestFetchCertificate(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	client.PrependProxyReactor("services",
+		func(action k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+			proxy, ok := action.(k8stesting.ProxyGetAction)
+			if !ok || proxy.GetName() != sealedsecrets.ControllerName ||
+				proxy.GetNamespace() != sealedsecrets.ControllerNamespace ||
+				proxy.GetPath() != "/v1/cert.pem" {
+				return false, nil, nil
+			}
+
+			return true, proxyResponse{body: []byte("pem")}, nil
+		},
+	)
+
+	data, err := sealedsecrets.FetchCertificate(
+		context.Background(),
+		client,
+		sealedsecrets.ControllerNamespace,
+		sealedsecrets.ControllerName,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "pem", string(data))
+}
@@ -0,0 +1,345 @@
+// Package secretscan finds secrets committed in plain text to a GitOps workload
+// tree, so `ksail verify` can stop them before they reach a shared branch.
+//
+// Two kinds of findings are reported:
+//
+//   - Kubernetes Secret manifests carrying data or stringData without SOPS
+//     metadata (the repo convention is to commit Secrets SOPS-encrypted). Secrets
+//     whose values are all Flux substitution placeholders (${VAR}) are allowed.
+//   - Well-known credential formats anywhere in a text file: private keys, AWS
+//     access key IDs, GitHub/GitLab/Slack tokens, and Age secret keys.
+//
+// A line containing AllowMarker is never reported, so a deliberate test fixture
+// can opt out inline. Scanning is offline and pattern-based; it is a guard rail
+// against accidents, not a substitute for a dedicated secret scanner.
+package secretscan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// AllowMarker suppresses findings on the line that contains it
+// (e.g. `token: ghp_... # ksail:allow-secret`).
+const AllowMarker = "ksail:allow-secret"
+
+const (
+	// RuleUnencryptedSecret reports a Kubernetes Secret committed without SOPS encryption.
+	RuleUnencryptedSecret = "unencrypted-secret"
+
+	// maxFileBytes skips files larger than this; manifests are far smaller, and
+	// large files are almost always generated or binary artifacts.
+	maxFileBytes = 1 << 20
+	// binarySniffBytes is how much of a file is inspected for NUL bytes to detect binaries.
+	binarySniffBytes = 8000
+)
+
+// Finding is a potential secret found in a file.
+type Finding struct {
+	// Path is the file path relative to the scanned root (slash-separated).
+	Path string
+	// Line is the 1-based line the finding starts on.
+	Line int
+	// Rule identifies the rule that matched (e.g. RuleUnencryptedSecret, "private-key").
+	Rule string
+	// Description explains the finding.
+	Description string
+}
+
+// String formats the finding as "path:line: description (rule)".
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s (%s)", f.Path, f.Line, f.Description, f.Rule)
+}
+
+// patternRule matches a well-known credential format on a single line.
+type patternRule struct {
+	id          string
+	description string
+	pattern     *regexp.Regexp
+}
+
+// patternRules returns the credential formats checked on every line of every text file.
+func patternRules() []patternRule {
+	return []patternRule{
+		{
+			id:          "private-key",
+			description: "private key",
+			pattern:     regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`),
+		},
+		{
+			id:          "aws-access-key-id",
+			description: "AWS access key ID",
+			pattern:     regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+		},
+		{
+			id:          "github-token",
+			description: "GitHub token",
+			pattern:     regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`),
+		},
+		{
+			id:          "gitlab-token",
+			description: "GitLab personal access token",
+			pattern:     regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`),
+		},
+		{
+			id:          "slack-token",
+			description: "Slack token",
+			pattern:     regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+		},
+		{
+			id:          "age-secret-key",
+			description: "Age secret key",
+			pattern:     regexp.MustCompile(`\bAGE-SECRET-KEY-1[0-9A-Z]{58}\b`),
+		},
+	}
+}
+
+// fluxPlaceholder matches a value that is exactly one Flux substitution variable.
+var fluxPlaceholder = regexp.MustCompile(`^\$\{[A-Za-z_][A-Za-z0-9_]*(?::[-=][^}]*)?\}$`)
+
+// Scan scans root — a directory walked recursively (skipping .git) or a single
+// file — and returns the findings sorted by path and line. Binary files and
+// files larger than 1 MiB are skipped.
+func Scan(root string) ([]Finding, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", root, err)
+	}
+
+	if !info.IsDir() {
+		return scanFile(root, filepath.Base(root))
+	}
+
+	var findings []Finding
+
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("resolve relative path of %s: %w", path, err)
+		}
+
+		fileFindings, err := scanFile(path, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+
+		findings = append(findings, fileFindings...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", root, err)
+	}
+
+	slices.SortFunc(findings, func(a, b Finding) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+
+		return a.Line - b.Line
+	})
+
+	return findings, nil
+}
+
+// scanFile scans a single file, reporting findings under displayPath.
+func scanFile(path, displayPath string) ([]Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	if info.Size() > maxFileBytes {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec // path comes from walking the scanned tree
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	if bytes.IndexByte(content[:min(len(content), binarySniffBytes)], 0) >= 0 {
+		return nil, nil
+	}
+
+	findings := scanLines(content, displayPath)
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		findings = append(findings, scanSecretManifests(content, displayPath)...)
+	}
+
+	return findings, nil
+}
+
+// scanLines applies the credential pattern rules to every line.
+func scanLines(content []byte, displayPath string) []Finding {
+	var findings []Finding
+
+	rules := patternRules()
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxFileBytes)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if strings.Contains(line, AllowMarker) {
+			continue
+		}
+
+		for _, rule := range rules {
+			if rule.pattern.MatchString(line) {
+				findings = append(findings, Finding{
+					Path:        displayPath,
+					Line:        lineNumber,
+					Rule:        rule.id,
+					Description: rule.description,
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// yamlDocument is one document of a multi-document YAML file.
+type yamlDocument struct {
+	startLine int
+	content   string
+}
+
+// splitYAMLDocuments splits content on "---" separator lines, recording the
+// 1-based line each document starts on.
+func splitYAMLDocuments(content []byte) []yamlDocument {
+	var (
+		docs    []yamlDocument
+		current strings.Builder
+		start   = 1
+	)
+
+	lines := strings.Split(string(content), "\n")
+	for index, line := range lines {
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(strings.TrimPrefix(line, "---")) == "" {
+			docs = append(docs, yamlDocument{startLine: start, content: current.String()})
+			current.Reset()
+
+			start = index + 2
+
+			continue
+		}
+
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+
+	return append(docs, yamlDocument{startLine: start, content: current.String()})
+}
+
+// scanSecretManifests reports Kubernetes Secrets that carry values but no SOPS
+// metadata. Documents that fail to parse are ignored; manifest validation
+// reports those.
+func scanSecretManifests(content []byte, displayPath string) []Finding {
+	var findings []Finding
+
+	for _, doc := range splitYAMLDocuments(content) {
+		if strings.Contains(doc.content, AllowMarker) {
+			continue
+		}
+
+		var obj map[string]any
+
+		err := yaml.Unmarshal([]byte(doc.content), &obj)
+		if err != nil || obj["kind"] != "Secret" {
+			continue
+		}
+
+		if _, encrypted := obj["sops"]; encrypted {
+			continue
+		}
+
+		if !hasLiteralValues(obj["data"]) && !hasLiteralValues(obj["stringData"]) {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Path:        displayPath,
+			Line:        doc.startLine + leadingBlankLines(doc.content),
+			Rule:        RuleUnencryptedSecret,
+			Description: "Secret " + strconv.Quote(secretName(obj)) + " is not SOPS-encrypted",
+		})
+	}
+
+	return findings
+}
+
+// hasLiteralValues reports whether a Secret data map holds at least one value
+// that is not a Flux substitution placeholder.
+func hasLiteralValues(values any) bool {
+	entries, ok := values.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	for _, value := range entries {
+		str, isString := value.(string)
+		if !isString || !fluxPlaceholder.MatchString(strings.TrimSpace(str)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// secretName returns metadata.name of a Secret, or "<unnamed>".
+func secretName(obj map[string]any) string {
+	metadata, _ := obj["metadata"].(map[string]any)
+
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		return "<unnamed>"
+	}
+
+	return name
+}
+
+// leadingBlankLines counts the blank and comment lines before a document's
+// first content line, so the finding points at the manifest itself.
+func leadingBlankLines(content string) int {
+	count := 0
+
+	for line := range strings.SplitSeq(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return count
+		}
+
+		count++
+	}
+
+	return 0
+}
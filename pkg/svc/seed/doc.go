@@ -0,0 +1,9 @@
+// Package seed creates the ConfigMaps and Secrets declared in spec.seed.
+//
+// [Build] turns each declared resource into a ConfigMap or Secret, reading
+// file references and decrypting SOPS-encrypted files with the local SOPS
+// keys, so a missing file or a missing age key fails before anything is
+// applied. [Apply] creates any missing namespace and server-side applies the
+// resources, so running it again after `ksail cluster update` brings drifted
+// or edited values back to what ksail.yaml declares.
+package seed
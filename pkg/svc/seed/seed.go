@@ -0,0 +1,212 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	sopsclient "github.com/devantler-tech/ksail/v7/pkg/client/sops"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// FieldManager is the server-side apply field manager that owns seeded data.
+const FieldManager = "ksail-seed"
+
+// Resources holds the ConfigMaps and Secrets built from spec.seed.
+type Resources struct {
+	ConfigMaps []*corev1.ConfigMap
+	Secrets    []*corev1.Secret
+}
+
+// Count returns the number of seeded resources.
+func (r Resources) Count() int {
+	return len(r.ConfigMaps) + len(r.Secrets)
+}
+
+// Build reads every file reference in spec and returns the ConfigMaps and
+// Secrets to apply. SOPS-encrypted files are decrypted; ConfigMaps may
+// reference them too, but the decrypted value is then stored in plain text.
+func Build(spec v1alpha1.SeedSpec) (Resources, error) {
+	var resources Resources
+
+	for _, declared := range spec.ConfigMaps {
+		data, err := resolveData(declared)
+		if err != nil {
+			return Resources{}, fmt.Errorf("seed ConfigMap %s: %w", declared.Name, err)
+		}
+
+		resources.ConfigMaps = append(resources.ConfigMaps, &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: objectMeta(declared),
+			Data:       data,
+		})
+	}
+
+	for _, declared := range spec.Secrets {
+		data, err := resolveData(declared)
+		if err != nil {
+			return Resources{}, fmt.Errorf("seed Secret %s: %w", declared.Name, err)
+		}
+
+		resources.Secrets = append(resources.Secrets, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: objectMeta(declared),
+			Type:       corev1.SecretTypeOpaque,
+			Data:       secretData(data),
+		})
+	}
+
+	return resources, nil
+}
+
+// Apply creates any missing namespace and server-side applies resources.
+func Apply(ctx context.Context, clientset kubernetes.Interface, resources Resources) error {
+	for _, configMap := range resources.ConfigMaps {
+		err := ensureNamespace(ctx, clientset, configMap.Namespace)
+		if err != nil {
+			return err
+		}
+
+		err = apply(ctx, clientset.CoreV1().ConfigMaps(configMap.Namespace).Patch, configMap.Name, configMap)
+		if err != nil {
+			return fmt.Errorf("ConfigMap %s/%s: %w", configMap.Namespace, configMap.Name, err)
+		}
+	}
+
+	for _, secret := range resources.Secrets {
+		err := ensureNamespace(ctx, clientset, secret.Namespace)
+		if err != nil {
+			return err
+		}
+
+		err = apply(ctx, clientset.CoreV1().Secrets(secret.Namespace).Patch, secret.Name, secret)
+		if err != nil {
+			return fmt.Errorf("secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func objectMeta(declared v1alpha1.SeedResource) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      declared.Name,
+		Namespace: declared.EffectiveNamespace(),
+		Labels:    map[string]string{"app.kubernetes.io/managed-by": "ksail"},
+	}
+}
+
+// resolveData merges the inline values of declared with the contents of its
+// file references.
+func resolveData(declared v1alpha1.SeedResource) (map[string]string, error) {
+	data := make(map[string]string, len(declared.Data)+len(declared.Files))
+	maps.Copy(data, declared.Data)
+
+	for key, path := range declared.Files {
+		content, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key, err)
+		}
+
+		data[key] = string(content)
+	}
+
+	return data, nil
+}
+
+// secretData converts resolved values to Secret data. Data rather than
+// stringData is applied so that a key removed from ksail.yaml is also removed
+// from the Secret by server-side apply.
+func secretData(data map[string]string) map[string][]byte {
+	converted := make(map[string][]byte, len(data))
+	for key, value := range data {
+		converted[key] = []byte(value)
+	}
+
+	return converted
+}
+
+// readFile returns the content of a file reference, decrypted when it is a
+// SOPS-encrypted YAML or JSON document.
+func readFile(path string) ([]byte, error) {
+	expanded, err := fsutil.ExpandHomePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("expand path %q: %w", path, err)
+	}
+
+	content, err := os.ReadFile(expanded) //nolint:gosec // path comes from the user's own ksail.yaml
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", expanded, err)
+	}
+
+	if !sopsclient.IsEncryptedDocument(content) {
+		return content, nil
+	}
+
+	decrypted, err := sopsclient.DecryptDocument(content)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %q: %w", expanded, err)
+	}
+
+	return decrypted, nil
+}
+
+// ensureNamespace creates namespace when it does not exist. Existing
+// namespaces are left untouched so seeding never takes ownership of them.
+func ensureNamespace(ctx context.Context, clientset kubernetes.Interface, namespace string) error {
+	_, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("get namespace %s: %w", namespace, err)
+	}
+
+	_, err = clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace},
+	}, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("create namespace %s: %w", namespace, err)
+	}
+
+	return nil
+}
+
+// patchFunc is the Patch method of a typed client.
+type patchFunc[T any] func(
+	ctx context.Context,
+	name string,
+	patchType types.PatchType,
+	data []byte,
+	opts metav1.PatchOptions,
+	subresources ...string,
+) (T, error)
+
+// apply server-side applies object under name with patch.
+func apply[T any](ctx context.Context, patch patchFunc[T], name string, object any) error {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+
+	force := true
+
+	_, err = patch(ctx, name, types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		return fmt.Errorf("apply %s: %w", name, err)
+	}
+
+	return nil
+}
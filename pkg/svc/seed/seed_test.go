@@ -0,0 +1,137 @@
+package seed_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	sopsclient "github.com/devantler-tech/ksail/v7/pkg/client/sops"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/seed"
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/stores/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	certPath := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(certPath, []byte("PEM"), 0o600))
+
+	resources, err := seed.Build(v1alpha1.SeedSpec{
+		ConfigMaps: []v1alpha1.SeedResource{{
+			Name:  "app-config",
+			Data:  map[string]string{"LOG_LEVEL": "debug"},
+			Files: map[string]string{"ca.crt": certPath},
+		}},
+		Secrets: []v1alpha1.SeedResource{{
+			Name:      "app-token",
+			Namespace: "apps",
+			Data:      map[string]string{"token": "s3cret"},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, resources.Count())
+
+	configMap := resources.ConfigMaps[0]
+	assert.Equal(t, v1alpha1.DefaultSeedNamespace, configMap.Namespace)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "ca.crt": "PEM"}, configMap.Data)
+	assert.Equal(t, "ksail", configMap.Labels["app.kubernetes.io/managed-by"])
+
+	secret := resources.Secrets[0]
+	assert.Equal(t, "apps", secret.Namespace)
+	assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+	assert.Equal(t, []byte("s3cret"), secret.Data["token"])
+}
+
+func TestBuild_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := seed.Build(v1alpha1.SeedSpec{
+		Secrets: []v1alpha1.SeedResource{{
+			Name:  "app-token",
+			Files: map[string]string{"token": filepath.Join(t.TempDir(), "missing.txt")},
+		}},
+	})
+	require.ErrorContains(t, err, "seed Secret app-token")
+}
+
+//nolint:paralleltest // Sets SOPS_AGE_KEY.
+func TestBuild_DecryptsSOPSFile(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	t.Setenv("SOPS_AGE_KEY", identity.String())
+
+	ageKey, err := sopsage.MasterKeyFromRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "values.yaml")
+	require.NoError(t, os.WriteFile(plainPath, []byte("password: hunter2\n"), 0o600))
+
+	encrypted, err := sopsclient.Encrypt(sopsclient.EncryptOpts{
+		EncryptConfig: sopsclient.EncryptConfig{KeyGroups: []sops.KeyGroup{{ageKey}}},
+		Cipher:        aes.NewCipher(),
+		InputStore:    &yaml.Store{},
+		OutputStore:   &yaml.Store{},
+		InputPath:     plainPath,
+		KeyServices:   []keyservice.KeyServiceClient{keyservice.NewLocalClient()},
+	})
+	require.NoError(t, err)
+
+	encryptedPath := filepath.Join(dir, "values.sops.yaml")
+	require.NoError(t, os.WriteFile(encryptedPath, encrypted, 0o600))
+
+	resources, err := seed.Build(v1alpha1.SeedSpec{
+		Secrets: []v1alpha1.SeedResource{{
+			Name:  "db",
+			Files: map[string]string{"values.yaml": encryptedPath},
+		}},
+	})
+	require.NoError(t, err)
+
+	value := string(resources.Secrets[0].Data["values.yaml"])
+	assert.Contains(t, value, "password: hunter2")
+	assert.NotContains(t, value, "sops:")
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	clientset := fake.NewClientset()
+
+	resources, err := seed.Build(v1alpha1.SeedSpec{
+		ConfigMaps: []v1alpha1.SeedResource{{Name: "app-config", Data: map[string]string{"a": "1"}}},
+		Secrets: []v1alpha1.SeedResource{{
+			Name: "app-token", Namespace: "apps", Data: map[string]string{"token": "first"},
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, seed.Apply(t.Context(), clientset, resources))
+
+	_, err = clientset.CoreV1().Namespaces().Get(t.Context(), "apps", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	configMap, err := clientset.CoreV1().ConfigMaps(v1alpha1.DefaultSeedNamespace).
+		Get(t.Context(), "app-config", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "1", configMap.Data["a"])
+
+	// Reapplying reconciles edited values.
+	resources.Secrets[0].Data["token"] = []byte("second")
+	require.NoError(t, seed.Apply(t.Context(), clientset, resources))
+
+	secret, err := clientset.CoreV1().Secrets("apps").Get(t.Context(), "app-token", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), secret.Data["token"])
+}
@@ -0,0 +1,11 @@
+// Package session records transcripts of interactive node access — the shells
+// and commands opened by `ksail workload debug --host` — so direct access to
+// infrastructure nodes can be reviewed after the fact.
+//
+// A transcript is written to ~/.ksail/sessions/ as a plain-text log with a
+// header describing who opened the session, against which cluster and node,
+// and which command was run, followed by everything the session printed and a
+// footer with the end time and exit status. Every line passes through a
+// [Redactor] before it reaches disk, so credentials that scroll past on the
+// terminal are not persisted in cleartext.
+package session
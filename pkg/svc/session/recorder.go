@@ -0,0 +1,189 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+)
+
+const unknownUser = "unknown"
+
+// Header describes a recorded session. It is written at the top of the
+// transcript.
+type Header struct {
+	User    string
+	Host    string
+	Cluster string
+	Node    string
+	Command []string
+	Started time.Time
+}
+
+// NewHeader returns a header for a session on node of cluster running
+// command, stamped with the current time, the operating system user, and the
+// host name.
+func NewHeader(cluster, node string, command []string) Header {
+	host, _ := os.Hostname()
+
+	return Header{
+		User:    currentUser(),
+		Host:    host,
+		Cluster: cluster,
+		Node:    node,
+		Command: command,
+		Started: time.Now().UTC(),
+	}
+}
+
+// Recorder is an io.Writer that appends redacted session output to a
+// transcript. Output is redacted line by line, so a secret split across two
+// writes is still matched; a trailing partial line is held back until it is
+// completed or the recorder is finished. A PEM private key block is tracked
+// across lines and writes and recorded as a single [Redacted]. Recorder is
+// safe for concurrent use.
+type Recorder struct {
+	mu           sync.Mutex
+	out          io.WriteCloser
+	redactor     *Redactor
+	pending      []byte
+	inPrivateKey bool
+	err          error
+	done         bool
+}
+
+// NewRecorder writes header to out and returns a Recorder that appends
+// session output to it. The recorder owns out and closes it in Finish.
+func NewRecorder(out io.WriteCloser, header Header, redactor *Redactor) (*Recorder, error) {
+	recorder := &Recorder{out: out, redactor: redactor}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# ksail session recording\n")
+	fmt.Fprintf(&buf, "# started: %s\n", header.Started.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&buf, "# user:    %s\n", who(header.User, header.Host))
+	fmt.Fprintf(&buf, "# cluster: %s\n", header.Cluster)
+	fmt.Fprintf(&buf, "# node:    %s\n", header.Node)
+	fmt.Fprintf(&buf, "# command: %s\n", strings.Join(header.Command, " "))
+	fmt.Fprintf(&buf, "#\n")
+
+	_, err := out.Write(redactor.Redact(buf.Bytes()))
+	if err != nil {
+		_ = out.Close()
+
+		return nil, fmt.Errorf("write session header: %w", err)
+	}
+
+	return recorder, nil
+}
+
+// Write records p. It never returns an error to the caller once the session
+// is running: a failing transcript must not break the interactive session,
+// so the first write error is remembered and reported by Finish instead.
+func (r *Recorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.done || r.err != nil {
+		return len(p), nil
+	}
+
+	r.pending = append(r.pending, p...)
+
+	idx := bytes.LastIndexByte(r.pending, '\n')
+	if idx < 0 {
+		return len(p), nil
+	}
+
+	r.writeRedacted(r.pending[:idx+1])
+	r.pending = append(r.pending[:0], r.pending[idx+1:]...)
+
+	return len(p), nil
+}
+
+// Finish flushes any partial line, appends a footer with the end time and
+// the session result, and closes the transcript. It returns the first error
+// encountered while recording.
+func (r *Recorder) Finish(sessionErr error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.done {
+		return r.err
+	}
+
+	r.done = true
+
+	if len(r.pending) > 0 {
+		r.writeRedacted(append(r.pending, '\n'))
+		r.pending = nil
+	}
+
+	// A private key block the session never closed stays redacted; end the
+	// line it was redacted on so the footer is recorded.
+	if r.inPrivateKey {
+		r.inPrivateKey = false
+		r.writeRedacted([]byte("\n"))
+	}
+
+	result := "ok"
+	if sessionErr != nil {
+		result = sessionErr.Error()
+	}
+
+	r.writeRedacted(fmt.Appendf(nil,
+		"#\n# ended:   %s\n# result:  %s\n",
+		time.Now().UTC().Format(time.RFC3339),
+		result,
+	))
+
+	closeErr := r.out.Close()
+	if r.err == nil && closeErr != nil {
+		r.err = fmt.Errorf("close session recording: %w", closeErr)
+	}
+
+	return r.err
+}
+
+// writeRedacted redacts data and writes it to the transcript, remembering the
+// first error. The caller must hold r.mu.
+func (r *Recorder) writeRedacted(data []byte) {
+	if r.err != nil {
+		return
+	}
+
+	data, r.inPrivateKey = r.redactor.redact(data, r.inPrivateKey)
+
+	_, err := r.out.Write(data)
+	if err != nil {
+		r.err = fmt.Errorf("write session recording: %w", err)
+	}
+}
+
+// who returns user@host, or just user when the host is unknown.
+func who(userName, host string) string {
+	if host == "" {
+		return userName
+	}
+
+	return userName + "@" + host
+}
+
+// currentUser returns the operating system user name, falling back to the
+// USER environment variable and finally to "unknown".
+func currentUser() string {
+	usr, err := user.Current()
+	if err == nil && usr.Username != "" {
+		return usr.Username
+	}
+
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+
+	return unknownUser
+}
@@ -0,0 +1,161 @@
+package session_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSessionFailed = errors.New("session failed")
+
+type bufferCloser struct {
+	bytes.Buffer
+
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+
+	return nil
+}
+
+func newTestRecorder(t *testing.T) (*session.Recorder, *bufferCloser) {
+	t.Helper()
+
+	redactor, err := session.NewRedactor(nil)
+	require.NoError(t, err)
+
+	out := &bufferCloser{}
+
+	recorder, err := session.NewRecorder(out, session.Header{
+		User:    "alice",
+		Host:    "laptop",
+		Cluster: "dev",
+		Node:    "dev-control-plane",
+		Command: []string{"/bin/sh", "-c", "env"},
+		Started: time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC),
+	}, redactor)
+	require.NoError(t, err)
+
+	return recorder, out
+}
+
+func TestRecorder_WritesHeaderOutputAndFooter(t *testing.T) {
+	t.Parallel()
+
+	recorder, out := newTestRecorder(t)
+
+	_, err := recorder.Write([]byte("HOME=/root\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Finish(errSessionFailed))
+
+	transcript := out.String()
+
+	assert.Contains(t, transcript, "# started: 2026-10-17T09:30:00Z\n")
+	assert.Contains(t, transcript, "# user:    alice@laptop\n")
+	assert.Contains(t, transcript, "# cluster: dev\n")
+	assert.Contains(t, transcript, "# node:    dev-control-plane\n")
+	assert.Contains(t, transcript, "# command: /bin/sh -c env\n")
+	assert.Contains(t, transcript, "HOME=/root\n")
+	assert.Contains(t, transcript, "# result:  session failed\n")
+	assert.True(t, out.closed)
+}
+
+func TestRecorder_RedactsSecretsSplitAcrossWrites(t *testing.T) {
+	t.Parallel()
+
+	recorder, out := newTestRecorder(t)
+
+	_, err := recorder.Write([]byte("API_KEY=sup"))
+	require.NoError(t, err)
+
+	assert.NotContains(t, out.String(), "sup", "partial lines are held back")
+
+	_, err = recorder.Write([]byte("ersecret\nlast line without newline"))
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Finish(nil))
+
+	transcript := out.String()
+
+	assert.Contains(t, transcript, "API_KEY=[REDACTED]\n")
+	assert.NotContains(t, transcript, "supersecret")
+	assert.Contains(t, transcript, "last line without newline\n")
+	assert.Contains(t, transcript, "# result:  ok\n")
+}
+
+func TestRecorder_RedactsPrivateKeySpanningWrites(t *testing.T) {
+	t.Parallel()
+
+	recorder, out := newTestRecorder(t)
+	key := privateKeyPEM(t)
+
+	_, err := recorder.Write([]byte("$ cat tls.key\n"))
+	require.NoError(t, err)
+
+	// Terminals deliver output in arbitrary chunks; write the key one line at a
+	// time and split the last write mid-line.
+	lines := slices.Collect(strings.Lines(key))
+	for _, line := range lines[:len(lines)-1] {
+		_, err = recorder.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	last := lines[len(lines)-1]
+	_, err = recorder.Write([]byte(last[:5]))
+	require.NoError(t, err)
+	_, err = recorder.Write([]byte(last[5:] + "$ exit\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Finish(nil))
+
+	transcript := out.String()
+
+	assert.Contains(t, transcript, "$ cat tls.key\n[REDACTED]\n$ exit\n")
+	assertNoKeyMaterial(t, key, transcript)
+	assert.Contains(t, transcript, "# result:  ok\n")
+}
+
+func TestRecorder_RedactsUnterminatedPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	recorder, out := newTestRecorder(t)
+	key := privateKeyPEM(t)
+
+	_, err := recorder.Write([]byte(key[:len(key)/2]))
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Finish(errSessionFailed))
+
+	transcript := out.String()
+
+	assertNoKeyMaterial(t, key, transcript)
+	assert.Contains(t, transcript, "[REDACTED]\n#\n# ended:")
+	assert.Contains(t, transcript, "# result:  session failed\n")
+}
+
+func TestRecorder_FinishIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	recorder, out := newTestRecorder(t)
+
+	require.NoError(t, recorder.Finish(nil))
+
+	length := out.Len()
+
+	require.NoError(t, recorder.Finish(nil))
+
+	_, err := recorder.Write([]byte("after finish\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, length, out.Len())
+}
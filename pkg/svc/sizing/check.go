@@ -0,0 +1,164 @@
+package sizing
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// Verdict is the outcome of comparing a Requirement with a Host.
+type Verdict string
+
+const (
+	// VerdictFits means the cluster leaves comfortable headroom on the host.
+	VerdictFits Verdict = "fits"
+	// VerdictTight means the cluster fits but uses most of the host's memory, or more CPU than the
+	// host has; it will run, but slowly and close to swapping.
+	VerdictTight Verdict = "tight"
+	// VerdictInsufficient means the cluster needs more memory than the host has; creating it would
+	// swap the host or get nodes OOM-killed.
+	VerdictInsufficient Verdict = "insufficient"
+	// VerdictUnknown means the host's resources could not be determined, so nothing was compared.
+	VerdictUnknown Verdict = "unknown"
+)
+
+// tightMemoryRatio is the share of host memory above which a cluster is reported as tight: the
+// host OS, the container engine, and the workloads themselves need the rest.
+const tightMemoryRatio = 0.8
+
+// Host is the memory and CPU available to containers.
+type Host struct {
+	// CPUs is the number of CPUs; zero when unknown.
+	CPUs int `json:"cpus"`
+	// MemoryBytes is the total memory; zero when unknown.
+	MemoryBytes int64 `json:"memoryBytes"`
+}
+
+// Result is a sizing check of one cluster against one host.
+type Result struct {
+	Verdict     Verdict     `json:"verdict"`
+	Requirement Requirement `json:"requirement"`
+	Host        Host        `json:"host"`
+	// Reasons explains the verdict.
+	Reasons []string `json:"reasons,omitempty"`
+	// Suggestions lists changes that shrink the cluster, each with the flag to pass and what it saves.
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// Check estimates spec's requirement and compares it with host. Suggestions are only offered when
+// the verdict is tight or insufficient.
+func Check(spec v1alpha1.ClusterSpec, host Host) Result {
+	result := Result{Verdict: VerdictFits, Requirement: Estimate(spec), Host: host}
+	need := result.Requirement.Total
+
+	if host.MemoryBytes <= 0 {
+		result.Verdict = VerdictUnknown
+		result.Reasons = append(result.Reasons, "host memory could not be determined")
+
+		return result
+	}
+
+	switch {
+	case need.MemoryBytes > host.MemoryBytes:
+		result.Verdict = VerdictInsufficient
+		result.Reasons = append(result.Reasons, fmt.Sprintf(
+			"the cluster needs about %s of memory but only %s is available to containers",
+			FormatMemory(need.MemoryBytes), FormatMemory(host.MemoryBytes)))
+	case !withinTightLimit(need, host):
+		result.Verdict = VerdictTight
+		result.Reasons = append(result.Reasons, fmt.Sprintf(
+			"the cluster needs about %s of the %s available to containers, leaving little for "+
+				"workloads and the host",
+			FormatMemory(need.MemoryBytes), FormatMemory(host.MemoryBytes)))
+	}
+
+	if host.CPUs > 0 && need.MilliCPU > int64(host.CPUs)*1000 {
+		if result.Verdict == VerdictFits {
+			result.Verdict = VerdictTight
+		}
+
+		result.Reasons = append(result.Reasons, fmt.Sprintf(
+			"the cluster needs about %s but only %d are available, so it will start slowly",
+			FormatCPU(need.MilliCPU), host.CPUs))
+	}
+
+	if result.Verdict != VerdictFits {
+		result.Suggestions = suggest(spec, host, need)
+	}
+
+	return result
+}
+
+// withinTightLimit reports whether need leaves the headroom tightMemoryRatio asks for.
+func withinTightLimit(need Resources, host Host) bool {
+	return float64(need.MemoryBytes) <= float64(host.MemoryBytes)*tightMemoryRatio
+}
+
+// suggest lists spec changes that shrink the cluster, largest supported lever first.
+func suggest(spec v1alpha1.ClusterSpec, host Host, need Resources) []string {
+	var suggestions []string
+
+	saving := func(changed v1alpha1.ClusterSpec) string {
+		return FormatMemory(need.MemoryBytes - Estimate(changed).Total.MemoryBytes)
+	}
+
+	if spec.Workers > 0 {
+		smaller := spec
+		smaller.Workers = fittingWorkers(spec, host)
+		suggestions = append(suggestions, fmt.Sprintf(
+			"use fewer worker nodes: --workers %d (saves about %s)", smaller.Workers, saving(smaller)))
+	}
+
+	if spec.ControlPlanes > 1 {
+		smaller := spec
+		smaller.ControlPlanes = 1
+		suggestions = append(suggestions, fmt.Sprintf(
+			"use a single control-plane node: --control-planes 1 (saves about %s)", saving(smaller)))
+	}
+
+	if spec.CNI == v1alpha1.CNICilium || spec.CNI == v1alpha1.CNICalico {
+		lighter := spec
+		lighter.CNI = v1alpha1.CNIDefault
+		suggestions = append(suggestions, fmt.Sprintf(
+			"use the distribution's built-in CNI: --cni Default (saves about %s)", saving(lighter)))
+	}
+
+	if spec.Distribution == v1alpha1.DistributionVanilla || spec.Distribution == v1alpha1.DistributionTalos {
+		lighter := spec
+		lighter.Distribution = v1alpha1.DistributionK3s
+		suggestions = append(suggestions, fmt.Sprintf(
+			"use a lighter distribution: --distribution K3s (saves about %s)", saving(lighter)))
+	}
+
+	if spec.GitOpsEngine == v1alpha1.GitOpsEngineArgoCD {
+		lighter := spec
+		lighter.GitOpsEngine = v1alpha1.GitOpsEngineFlux
+		suggestions = append(suggestions, fmt.Sprintf(
+			"use a lighter GitOps engine: --gitops-engine Flux (saves about %s)", saving(lighter)))
+	}
+
+	if spec.ServiceMesh == v1alpha1.ServiceMeshIstio {
+		lighter := spec
+		lighter.ServiceMesh = v1alpha1.ServiceMeshLinkerd
+		suggestions = append(suggestions, fmt.Sprintf(
+			"use a lighter service mesh: --service-mesh Linkerd (saves about %s)", saving(lighter)))
+	}
+
+	return append(suggestions,
+		"give the container engine more memory (e.g. Docker Desktop → Settings → Resources)")
+}
+
+// fittingWorkers returns the largest worker count below spec.Workers whose estimate stays within
+// the tight limit, or zero when none does.
+func fittingWorkers(spec v1alpha1.ClusterSpec, host Host) int32 {
+	candidate := spec
+
+	for workers := spec.Workers - 1; workers > 0; workers-- {
+		candidate.Workers = workers
+		if withinTightLimit(Estimate(candidate).Total, host) {
+			return workers
+		}
+	}
+
+	return 0
+}
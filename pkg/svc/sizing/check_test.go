@@ -0,0 +1,88 @@
+package sizing_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sizing"
+	"github.com/stretchr/testify/assert"
+)
+
+const gib = 1024 * mib
+
+func TestCheck_Verdicts(t *testing.T) {
+	t.Parallel()
+
+	spec := v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionVanilla,
+		Workers:      2,
+		CNI:          v1alpha1.CNICilium,
+		GitOpsEngine: v1alpha1.GitOpsEngineFlux,
+	} // ~2.9 GiB, 1.6 CPUs
+
+	tests := []struct {
+		name    string
+		host    sizing.Host
+		verdict sizing.Verdict
+	}{
+		{name: "roomy host", host: sizing.Host{CPUs: 8, MemoryBytes: 16 * gib}, verdict: sizing.VerdictFits},
+		{name: "most memory used", host: sizing.Host{CPUs: 8, MemoryBytes: 3 * gib}, verdict: sizing.VerdictTight},
+		{name: "too few CPUs", host: sizing.Host{CPUs: 1, MemoryBytes: 16 * gib}, verdict: sizing.VerdictTight},
+		{name: "not enough memory", host: sizing.Host{CPUs: 8, MemoryBytes: 2 * gib}, verdict: sizing.VerdictInsufficient},
+		{name: "unknown host", host: sizing.Host{CPUs: 8}, verdict: sizing.VerdictUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := sizing.Check(spec, test.host)
+
+			assert.Equal(t, test.verdict, result.Verdict)
+
+			if test.verdict == sizing.VerdictFits || test.verdict == sizing.VerdictUnknown {
+				assert.Empty(t, result.Suggestions)
+			} else {
+				assert.NotEmpty(t, result.Reasons)
+				assert.NotEmpty(t, result.Suggestions)
+			}
+		})
+	}
+}
+
+func TestCheck_Suggestions(t *testing.T) {
+	t.Parallel()
+
+	spec := v1alpha1.ClusterSpec{
+		Distribution:  v1alpha1.DistributionTalos,
+		ControlPlanes: 3,
+		Workers:       3,
+		CNI:           v1alpha1.CNICilium,
+		GitOpsEngine:  v1alpha1.GitOpsEngineArgoCD,
+		ServiceMesh:   v1alpha1.ServiceMeshIstio,
+	}
+
+	result := sizing.Check(spec, sizing.Host{CPUs: 4, MemoryBytes: 8 * gib})
+
+	assert.Equal(t, sizing.VerdictInsufficient, result.Verdict)
+	assert.Equal(t, []string{
+		"use fewer worker nodes: --workers 0 (saves about 2.6 GiB)",
+		"use a single control-plane node: --control-planes 1 (saves about 2.9 GiB)",
+		"use the distribution's built-in CNI: --cni Default (saves about 1.9 GiB)",
+		"use a lighter distribution: --distribution K3s (saves about 2.9 GiB)",
+		"use a lighter GitOps engine: --gitops-engine Flux (saves about 0.4 GiB)",
+		"use a lighter service mesh: --service-mesh Linkerd (saves about 0.3 GiB)",
+		"give the container engine more memory (e.g. Docker Desktop → Settings → Resources)",
+	}, result.Suggestions)
+}
+
+func TestCheck_FewerWorkersThatFit(t *testing.T) {
+	t.Parallel()
+
+	spec := v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionK3s, Workers: 10} // ~2.9 GiB
+
+	result := sizing.Check(spec, sizing.Host{CPUs: 8, MemoryBytes: 2 * gib})
+
+	// 2 GiB × 0.8 leaves room for the 550 MiB server plus 4 × 250 MiB agents.
+	assert.Contains(t, result.Suggestions[0], "--workers 4 ")
+}
@@ -0,0 +1,13 @@
+// Package sizing estimates the host memory and CPU a local KSail cluster needs
+// before it is created, so `ksail cluster create` can refuse (or warn about) a
+// cluster that would push the Docker host into swap instead of discovering it
+// minutes later as a hung bootstrap.
+//
+// Estimates are coarse, deliberately conservative baselines: a per-node cost
+// for the distribution's Kubernetes components plus a cost for each selected
+// component (CNI, GitOps engine, policy engine, service mesh, and so on), with
+// per-node components such as CNI agents scaled by the node count. They are
+// compared against the resources the container engine reports as available to
+// containers (Docker info), which on Docker Desktop and Podman machines is the
+// VM's allocation rather than the physical host's.
+package sizing
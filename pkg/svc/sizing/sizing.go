@@ -0,0 +1,175 @@
+package sizing
+
+import (
+	"fmt"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// mebibyte is one MiB in bytes.
+const mebibyte int64 = 1 << 20
+
+// gibibyte is one GiB in bytes.
+const gibibyte = 1024 * mebibyte
+
+// Resources is an amount of memory and CPU.
+type Resources struct {
+	// MemoryBytes is the memory in bytes.
+	MemoryBytes int64 `json:"memoryBytes"`
+	// MilliCPU is the CPU in thousandths of a core.
+	MilliCPU int64 `json:"milliCPU"`
+}
+
+// res builds Resources from MiB and millicores, keeping the baseline tables readable.
+func res(memoryMiB, milliCPU int64) Resources {
+	return Resources{MemoryBytes: memoryMiB * mebibyte, MilliCPU: milliCPU}
+}
+
+// times returns r scaled by n.
+func (r Resources) times(n int32) Resources {
+	return Resources{MemoryBytes: r.MemoryBytes * int64(n), MilliCPU: r.MilliCPU * int64(n)}
+}
+
+// plus returns the sum of r and other.
+func (r Resources) plus(other Resources) Resources {
+	return Resources{
+		MemoryBytes: r.MemoryBytes + other.MemoryBytes,
+		MilliCPU:    r.MilliCPU + other.MilliCPU,
+	}
+}
+
+// LineItem is one contributor to a Requirement.
+type LineItem struct {
+	// Resource describes what consumes the resources (e.g. "control-plane node", "Cilium").
+	Resource string `json:"resource"`
+	// Quantity is the number of units; Resources is the total for all of them.
+	Quantity int32 `json:"quantity"`
+	Resources
+}
+
+// Requirement is the estimated footprint of a cluster on its host.
+type Requirement struct {
+	// Distribution is the distribution the estimate was made for.
+	Distribution v1alpha1.Distribution `json:"distribution"`
+	// Items lists what contributes to the total.
+	Items []LineItem `json:"items"`
+	// Total is the sum of all items.
+	Total Resources `json:"total"`
+}
+
+// add appends item and folds it into the total.
+func (r *Requirement) add(item LineItem) {
+	r.Items = append(r.Items, item)
+	r.Total = r.Total.plus(item.Resources)
+}
+
+// nodeBaseline is the idle footprint of one control-plane and one worker node of a distribution:
+// the node container's Kubernetes components and system pods, before any optional component.
+type nodeBaseline struct {
+	controlPlane Resources
+	worker       Resources
+}
+
+// nodeBaselines holds per-distribution node footprints. Worker nodes of KWOK are simulated and cost
+// next to nothing.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var nodeBaselines = map[v1alpha1.Distribution]nodeBaseline{
+	v1alpha1.DistributionVanilla:  {controlPlane: res(900, 700), worker: res(400, 200)},
+	v1alpha1.DistributionK3s:      {controlPlane: res(550, 400), worker: res(250, 100)},
+	v1alpha1.DistributionTalos:    {controlPlane: res(1200, 800), worker: res(600, 300)},
+	v1alpha1.DistributionVCluster: {controlPlane: res(700, 500), worker: res(300, 150)},
+	v1alpha1.DistributionKWOK:     {controlPlane: res(400, 300), worker: res(0, 0)},
+}
+
+// Estimate returns the host resources a local cluster built from spec is expected to need once its
+// components are installed. Distributions without a local footprint (cloud distributions) yield an
+// empty requirement.
+func Estimate(spec v1alpha1.ClusterSpec) Requirement {
+	req := Requirement{Distribution: spec.Distribution, Items: []LineItem{}}
+
+	baseline, ok := nodeBaselines[spec.Distribution]
+	if !ok {
+		return req
+	}
+
+	controlPlanes := max(spec.ControlPlanes, 1)
+	workers := max(spec.Workers, 0)
+	nodes := controlPlanes + workers
+
+	req.add(LineItem{
+		Resource:  "control-plane node",
+		Quantity:  controlPlanes,
+		Resources: baseline.controlPlane.times(controlPlanes),
+	})
+
+	if workers > 0 && baseline.worker != (Resources{}) {
+		req.add(LineItem{
+			Resource:  "worker node",
+			Quantity:  workers,
+			Resources: baseline.worker.times(workers),
+		})
+	}
+
+	for _, component := range components(spec) {
+		footprint := component.fixed.plus(component.perNode.times(nodes))
+		req.add(LineItem{Resource: component.name, Quantity: 1, Resources: footprint})
+	}
+
+	return req
+}
+
+// component is the footprint of one optional component: a fixed part (controllers, operators) and
+// a per-node part (agents run as DaemonSets).
+type component struct {
+	name    string
+	fixed   Resources
+	perNode Resources
+}
+
+// components returns the optional components spec selects, with their footprints.
+//
+//nolint:cyclop,funlen // flat table of independent component selections
+func components(spec v1alpha1.ClusterSpec) []component {
+	var selected []component
+
+	pick := func(enabled bool, name string, fixed, perNode Resources) {
+		if enabled {
+			selected = append(selected, component{name: name, fixed: fixed, perNode: perNode})
+		}
+	}
+
+	pick(spec.CNI == v1alpha1.CNICilium, "Cilium", res(100, 50), res(300, 100))
+	pick(spec.CNI == v1alpha1.CNICalico, "Calico", res(100, 50), res(150, 100))
+	pick(spec.MetricsServer == v1alpha1.MetricsServerEnabled, "metrics-server", res(60, 50), Resources{})
+	pick(spec.CSI == v1alpha1.CSIEnabled, "CSI", res(50, 20), Resources{})
+	pick(spec.LoadBalancer == v1alpha1.LoadBalancerEnabled, "load balancer", res(60, 20), Resources{})
+	pick(spec.CertManager == v1alpha1.CertManagerEnabled, "cert-manager", res(150, 50), Resources{})
+	pick(spec.PolicyEngine == v1alpha1.PolicyEngineKyverno, "Kyverno", res(500, 200), Resources{})
+	pick(spec.PolicyEngine == v1alpha1.PolicyEngineGatekeeper, "Gatekeeper", res(350, 100), Resources{})
+	pick(spec.IngressController == v1alpha1.IngressControllerNginx, "ingress-nginx", res(150, 100), Resources{})
+	pick(spec.IngressController == v1alpha1.IngressControllerTraefik, "Traefik", res(120, 50), Resources{})
+	pick(spec.IngressController == v1alpha1.IngressControllerContour, "Contour", res(200, 100), Resources{})
+	pick(spec.GatewayAPI == v1alpha1.GatewayAPIEnvoy, "Envoy Gateway", res(200, 100), Resources{})
+	pick(spec.ServiceMesh == v1alpha1.ServiceMeshIstio, "Istio", res(600, 300), Resources{})
+	pick(spec.ServiceMesh == v1alpha1.ServiceMeshLinkerd, "Linkerd", res(300, 150), Resources{})
+	pick(spec.Knative == v1alpha1.KnativeEnabled, "Knative Serving", res(500, 300), Resources{})
+	pick(spec.GitOpsEngine == v1alpha1.GitOpsEngineFlux, "Flux", res(300, 100), Resources{})
+	pick(spec.GitOpsEngine == v1alpha1.GitOpsEngineArgoCD, "Argo CD", res(700, 250), Resources{})
+	pick(spec.SealedSecrets == v1alpha1.SealedSecretsEnabled, "Sealed Secrets", res(50, 10), Resources{})
+	pick(spec.ExternalSecrets == v1alpha1.ExternalSecretsEnabled, "External Secrets", res(150, 50), Resources{})
+
+	return selected
+}
+
+// FormatMemory renders a byte count in GiB with one decimal (e.g. "3.5 GiB").
+func FormatMemory(bytes int64) string {
+	return fmt.Sprintf("%.1f GiB", float64(bytes)/float64(gibibyte))
+}
+
+// FormatCPU renders millicores as cores with one decimal (e.g. "2.5 CPUs").
+func FormatCPU(milliCPU int64) string {
+	const milliPerCore = 1000.0
+
+	return fmt.Sprintf("%.1f CPUs", float64(milliCPU)/milliPerCore)
+}
@@ -0,0 +1,66 @@
+package sizing_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/sizing"
+	"github.com/stretchr/testify/assert"
+)
+
+const mib = 1 << 20
+
+func TestEstimate_NodesAndComponents(t *testing.T) {
+	t.Parallel()
+
+	req := sizing.Estimate(v1alpha1.ClusterSpec{
+		Distribution:  v1alpha1.DistributionVanilla,
+		ControlPlanes: 1,
+		Workers:       2,
+		CNI:           v1alpha1.CNICilium,
+		GitOpsEngine:  v1alpha1.GitOpsEngineFlux,
+	})
+
+	assert.Equal(t, v1alpha1.DistributionVanilla, req.Distribution)
+	assert.Equal(t, []sizing.LineItem{
+		{Resource: "control-plane node", Quantity: 1, Resources: sizing.Resources{MemoryBytes: 900 * mib, MilliCPU: 700}},
+		{Resource: "worker node", Quantity: 2, Resources: sizing.Resources{MemoryBytes: 800 * mib, MilliCPU: 400}},
+		// Cilium: 100 MiB operator + 300 MiB agent on each of the 3 nodes.
+		{Resource: "Cilium", Quantity: 1, Resources: sizing.Resources{MemoryBytes: 1000 * mib, MilliCPU: 350}},
+		{Resource: "Flux", Quantity: 1, Resources: sizing.Resources{MemoryBytes: 300 * mib, MilliCPU: 100}},
+	}, req.Items)
+	assert.Equal(t, sizing.Resources{MemoryBytes: 3000 * mib, MilliCPU: 1550}, req.Total)
+}
+
+func TestEstimate_DefaultsToOneControlPlane(t *testing.T) {
+	t.Parallel()
+
+	req := sizing.Estimate(v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionK3s})
+
+	assert.Equal(t, sizing.Resources{MemoryBytes: 550 * mib, MilliCPU: 400}, req.Total)
+}
+
+func TestEstimate_KWOKWorkersAreFree(t *testing.T) {
+	t.Parallel()
+
+	req := sizing.Estimate(v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionKWOK, Workers: 50})
+
+	assert.Len(t, req.Items, 1)
+	assert.Equal(t, int64(400*mib), req.Total.MemoryBytes)
+}
+
+func TestEstimate_CloudDistributionIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	req := sizing.Estimate(v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionEKS, Workers: 3})
+
+	assert.Empty(t, req.Items)
+	assert.Equal(t, sizing.Resources{}, req.Total)
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "3.5 GiB", sizing.FormatMemory(3584*mib))
+	assert.Equal(t, "1.6 CPUs", sizing.FormatCPU(1550))
+}
@@ -0,0 +1,101 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const aliasFileName = "alias.json"
+
+// ClusterAlias points a cluster name at the cluster that answers to it
+// (~/.ksail/clusters/<name>/alias.json), e.g. a pool spare claimed for it.
+type ClusterAlias struct {
+	// Cluster is the name of the cluster the alias resolves to.
+	Cluster string `json:"cluster"`
+}
+
+// SaveClusterAlias records that lifecycle commands targeting name act on
+// clusterName instead.
+func SaveClusterAlias(name, clusterName string) error {
+	_, err := clusterStateDir(clusterName)
+	if err != nil {
+		return err
+	}
+
+	aliasPath, err := clusterAliasPath(name)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(aliasPath)
+
+	err = os.MkdirAll(dir, dirPermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create state directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(ClusterAlias{Cluster: clusterName}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster alias: %w", err)
+	}
+
+	err = os.WriteFile(aliasPath, data, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to write cluster alias: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveClusterAlias returns the cluster name resolves to. A name with a saved
+// spec of its own, without an alias, or whose alias points at a cluster that no
+// longer has a saved spec resolves to itself.
+func ResolveClusterAlias(name string) string {
+	if hasClusterSpec(name) {
+		return name
+	}
+
+	aliasPath, err := clusterAliasPath(name)
+	if err != nil {
+		return name
+	}
+
+	//nolint:gosec // aliasPath is derived via clusterStateDir(name), which validates name
+	data, err := os.ReadFile(aliasPath)
+	if err != nil {
+		return name
+	}
+
+	var alias ClusterAlias
+
+	err = json.Unmarshal(data, &alias)
+	if err != nil || alias.Cluster == "" || !hasClusterSpec(alias.Cluster) {
+		return name
+	}
+
+	return alias.Cluster
+}
+
+// hasClusterSpec reports whether clusterName has a saved spec.
+func hasClusterSpec(clusterName string) bool {
+	specPath, err := clusterStatePath(clusterName)
+	if err != nil {
+		return false
+	}
+
+	info, err := os.Stat(specPath)
+
+	return err == nil && info.Mode().IsRegular()
+}
+
+// clusterAliasPath returns the path to the alias file for a given cluster name.
+func clusterAliasPath(clusterName string) (string, error) {
+	dir, err := clusterStateDir(clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, aliasFileName), nil
+}
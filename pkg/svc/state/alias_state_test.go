@@ -0,0 +1,42 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveClusterAlias(t *testing.T) {
+	t.Parallel()
+
+	name := "test-alias-" + t.Name()
+	spare := name + "-pool-1"
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(name)
+		_ = state.DeleteClusterState(spare)
+	})
+
+	assert.Equal(t, name, state.ResolveClusterAlias(name), "a name without an alias resolves to itself")
+
+	require.NoError(t, state.SaveClusterAlias(name, spare))
+	assert.Equal(t, name, state.ResolveClusterAlias(name), "an alias to a cluster without state is ignored")
+
+	spec := &v1alpha1.ClusterSpec{Distribution: v1alpha1.DistributionVanilla}
+	require.NoError(t, state.SaveClusterSpec(spare, spec))
+	assert.Equal(t, spare, state.ResolveClusterAlias(name))
+	assert.Equal(t, spare, state.ResolveClusterAlias(spare))
+
+	require.NoError(t, state.SaveClusterSpec(name, spec))
+	assert.Equal(t, name, state.ResolveClusterAlias(name), "a cluster of the same name wins over the alias")
+}
+
+func TestSaveClusterAlias_RejectsUnsafeNames(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, state.SaveClusterAlias("../escape", "spare"), state.ErrInvalidClusterName)
+	require.ErrorIs(t, state.SaveClusterAlias("alias", "../escape"), state.ErrInvalidClusterName)
+}
@@ -0,0 +1,98 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const auditFileName = "audit.jsonl"
+
+// AppendAuditRecord appends one JSON-encoded audit record to the cluster's
+// local audit log (~/.ksail/clusters/<name>/audit.jsonl). The record must be a
+// single line; the log is append-only and read back by LoadAuditRecords.
+func AppendAuditRecord(clusterName string, record []byte) error {
+	auditPath, err := clusterAuditPath(clusterName)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(auditPath)
+
+	err = os.MkdirAll(dir, dirPermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create state directory %s: %w", dir, err)
+	}
+
+	//nolint:gosec // auditPath is derived via clusterStateDir(clusterName), which validates clusterName
+	file, err := os.OpenFile(auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	_, err = file.Write(append(bytes.TrimSpace(record), '\n'))
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+
+	return nil
+}
+
+// LoadAuditRecords returns the records of the cluster's local audit log in
+// the order they were appended. A cluster without a log yields no records.
+func LoadAuditRecords(clusterName string) ([][]byte, error) {
+	auditPath, err := clusterAuditPath(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:gosec // auditPath is derived via clusterStateDir(clusterName), which validates clusterName
+	data, err := os.ReadFile(auditPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	var records [][]byte
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		records = append(records, bytes.Clone(line))
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return records, nil
+}
+
+// clusterAuditPath returns the path to the audit log for a given cluster name.
+func clusterAuditPath(clusterName string) (string, error) {
+	dir, err := clusterStateDir(clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, auditFileName), nil
+}
@@ -0,0 +1,44 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendAndLoadAuditRecords(t *testing.T) {
+	t.Parallel()
+
+	clusterName := "test-audit-" + t.Name()
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(clusterName)
+	})
+
+	require.NoError(t, state.AppendAuditRecord(clusterName, []byte(`{"operation":"create"}`)))
+	require.NoError(t, state.AppendAuditRecord(clusterName, []byte("{\"operation\":\"update\"}\n")))
+
+	records, err := state.LoadAuditRecords(clusterName)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.JSONEq(t, `{"operation":"create"}`, string(records[0]))
+	assert.JSONEq(t, `{"operation":"update"}`, string(records[1]))
+}
+
+func TestLoadAuditRecords_NoLog(t *testing.T) {
+	t.Parallel()
+
+	records, err := state.LoadAuditRecords("test-audit-missing-" + t.Name())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestAppendAuditRecord_InvalidClusterName(t *testing.T) {
+	t.Parallel()
+
+	err := state.AppendAuditRecord("../escape", []byte(`{}`))
+	require.ErrorIs(t, err, state.ErrInvalidClusterName)
+}
@@ -35,9 +35,7 @@ var ErrInvalidClusterName = errors.New(
 // clusterStateDir returns the per-cluster state directory path for a given cluster name.
 // It validates the cluster name to prevent path traversal attacks.
 func clusterStateDir(clusterName string) (string, error) {
-	if strings.Contains(clusterName, "/") ||
-		strings.Contains(clusterName, "\\") ||
-		strings.Contains(clusterName, "..") {
+	if !isSafePathSegment(clusterName) {
 		return "", ErrInvalidClusterName
 	}
 
@@ -49,6 +47,24 @@ func clusterStateDir(clusterName string) (string, error) {
 	return filepath.Join(home, stateDir, clustersSubDir, clusterName), nil
 }
 
+// ClustersDir returns the directory holding the per-cluster state directories.
+func ClustersDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(home, stateDir, clustersSubDir), nil
+}
+
+// isSafePathSegment reports whether name can be used as a single path
+// segment under ~/.ksail without escaping its parent directory.
+func isSafePathSegment(name string) bool {
+	return !strings.Contains(name, "/") &&
+		!strings.Contains(name, "\\") &&
+		!strings.Contains(name, "..")
+}
+
 // clusterStatePath returns the path to the state file for a given cluster name.
 func clusterStatePath(clusterName string) (string, error) {
 	dir, err := clusterStateDir(clusterName)
@@ -4,4 +4,8 @@
 // State is stored as JSON in ~/.ksail/clusters/<name>/spec.json so that the
 // update command can compare the desired configuration against the actual
 // configuration used at creation time, avoiding false-positive diffs.
+//
+// The same spec, together with an inventory of the KSail-managed components,
+// is mirrored into the kube-system/ksail-state ConfigMap of the cluster so
+// that a machine other than the creator's can reconstruct the baseline.
 package state
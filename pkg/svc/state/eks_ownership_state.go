@@ -44,7 +44,7 @@ type EKSOwnershipState struct {
 	ClusterARN  string    `json:"clusterArn"`
 	CreatedAt   time.Time `json:"createdAt"`
 	// AWSOptions stores the complete environment-variable-name mapping used to resolve AWS
-	// credentials. Credential values are never persisted. Records without this mapping predate the
+	// credentials, and the role assumed with them. Credential values are never persisted. Records without this mapping predate the
 	// schema extension and require explicit rebind before state-only lifecycle commands may use them.
 	AWSOptions v1alpha1.OptionsAWS `json:"awsOptions,omitzero"`
 }
@@ -0,0 +1,201 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// InClusterNamespace is the namespace the in-cluster state ConfigMap lives in.
+	InClusterNamespace = "kube-system"
+	// InClusterConfigMapName is the name of the ConfigMap holding the in-cluster state.
+	InClusterConfigMapName = "ksail-state"
+	// inClusterClusterKey holds the name the cluster was created under.
+	inClusterClusterKey = "cluster"
+	// inClusterUpdatedAtKey holds the RFC 3339 time the state was last written.
+	inClusterUpdatedAtKey = "updatedAt"
+	// inClusterComponentsKey holds the JSON component inventory.
+	inClusterComponentsKey = "components.json"
+	// inClusterManagedByLabel marks the state ConfigMap as managed by KSail.
+	inClusterManagedByLabel = "app.kubernetes.io/managed-by"
+)
+
+// InventoryComponent is one KSail-managed component installed in a cluster,
+// as recorded in the in-cluster state.
+type InventoryComponent struct {
+	// Name is the display name of the component (e.g. "Cilium", "Flux").
+	Name string `json:"name"`
+	// Release and Namespace identify the Helm release that installed it.
+	Release   string `json:"release"`
+	Namespace string `json:"namespace"`
+	// Chart is the chart name and version (e.g. "cilium-1.16.3").
+	Chart string `json:"chart,omitempty"`
+	// AppVersion is the version of the application the chart deploys.
+	AppVersion string `json:"appVersion,omitempty"`
+}
+
+// InClusterState is the state KSail keeps inside a cluster so that a machine
+// other than the one that created it can reconstruct the update baseline.
+type InClusterState struct {
+	// ClusterName is the name the cluster was created under.
+	ClusterName string `json:"cluster"`
+	// Spec is the ClusterSpec of the last successful create or update, with
+	// registry credentials redacted.
+	Spec *v1alpha1.ClusterSpec `json:"spec"`
+	// Components is the inventory of KSail-managed components.
+	Components []InventoryComponent `json:"components,omitempty"`
+	// UpdatedAt is when the state was last written.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SaveInClusterState writes the ClusterSpec and component inventory of
+// clusterName to the well-known ConfigMap in the cluster, creating it on first
+// use. The spec is redacted exactly like the local state (see SaveClusterSpec),
+// so no registry credentials end up in the cluster.
+func SaveInClusterState(
+	ctx context.Context,
+	client kubernetes.Interface,
+	clusterName string,
+	spec *v1alpha1.ClusterSpec,
+	components []InventoryComponent,
+	now time.Time,
+) error {
+	data, err := inClusterStateData(clusterName, spec, components, now)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		return writeInClusterState(ctx, client, data)
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"write cluster state to ConfigMap %s/%s: %w",
+			InClusterNamespace, InClusterConfigMapName, err,
+		)
+	}
+
+	return nil
+}
+
+// LoadInClusterState reads the state KSail keeps in the cluster.
+// Returns ErrStateNotFound if the cluster has no state ConfigMap or it holds
+// no spec.
+func LoadInClusterState(ctx context.Context, client kubernetes.Interface) (*InClusterState, error) {
+	configMap, err := client.CoreV1().ConfigMaps(InClusterNamespace).Get(
+		ctx, InClusterConfigMapName, metav1.GetOptions{},
+	)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: ConfigMap %s/%s", ErrStateNotFound, InClusterNamespace, InClusterConfigMapName)
+		}
+
+		return nil, fmt.Errorf(
+			"read cluster state ConfigMap %s/%s: %w",
+			InClusterNamespace, InClusterConfigMapName, err,
+		)
+	}
+
+	specData, ok := configMap.Data[specFileName]
+	if !ok {
+		return nil, fmt.Errorf("%w: ConfigMap %s/%s has no spec", ErrStateNotFound, InClusterNamespace, InClusterConfigMapName)
+	}
+
+	loaded := &InClusterState{ClusterName: configMap.Data[inClusterClusterKey]}
+
+	err = json.Unmarshal([]byte(specData), &loaded.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal in-cluster cluster spec: %w", err)
+	}
+
+	if componentsData := configMap.Data[inClusterComponentsKey]; componentsData != "" {
+		err = json.Unmarshal([]byte(componentsData), &loaded.Components)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal in-cluster component inventory: %w", err)
+		}
+	}
+
+	// A malformed timestamp only loses the informational UpdatedAt.
+	loaded.UpdatedAt, _ = time.Parse(time.RFC3339, configMap.Data[inClusterUpdatedAtKey])
+
+	return loaded, nil
+}
+
+// inClusterStateData serializes the in-cluster state into ConfigMap data.
+func inClusterStateData(
+	clusterName string,
+	spec *v1alpha1.ClusterSpec,
+	components []InventoryComponent,
+	now time.Time,
+) (map[string]string, error) {
+	specData, err := json.MarshalIndent(sanitizeSpecForPersistence(spec), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cluster spec: %w", err)
+	}
+
+	if components == nil {
+		components = []InventoryComponent{}
+	}
+
+	componentsData, err := json.MarshalIndent(components, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal component inventory: %w", err)
+	}
+
+	return map[string]string{
+		inClusterClusterKey:    clusterName,
+		inClusterUpdatedAtKey:  now.UTC().Format(time.RFC3339),
+		specFileName:           string(specData),
+		inClusterComponentsKey: string(componentsData),
+	}, nil
+}
+
+// writeInClusterState replaces the data of the state ConfigMap, creating it
+// when missing.
+func writeInClusterState(ctx context.Context, client kubernetes.Interface, data map[string]string) error {
+	configMaps := client.CoreV1().ConfigMaps(InClusterNamespace)
+
+	configMap, err := configMaps.Get(ctx, InClusterConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, createErr := configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      InClusterConfigMapName,
+				Namespace: InClusterNamespace,
+				Labels:    map[string]string{inClusterManagedByLabel: "ksail"},
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(createErr) {
+			// Another writer created the ConfigMap first; retry as an update.
+			return apierrors.NewConflict(corev1.Resource("configmaps"), InClusterConfigMapName, createErr)
+		}
+
+		if createErr != nil {
+			return fmt.Errorf("create: %w", createErr)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get: %w", err)
+	}
+
+	configMap.Data = data
+
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var errInClusterAPI = errors.New("api unavailable")
+
+func inClusterTestTime() time.Time {
+	return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+}
+
+func TestSaveAndLoadInClusterState(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	spec := &v1alpha1.ClusterSpec{
+		Distribution:  v1alpha1.DistributionK3s,
+		Provider:      v1alpha1.ProviderDocker,
+		CNI:           v1alpha1.CNICilium,
+		Vanilla:       v1alpha1.OptionsVanilla{MirrorsDir: "/custom/mirrors"},
+		LocalRegistry: v1alpha1.LocalRegistry{Registry: "user:secret@ghcr.io/org/repo"},
+	}
+	components := []state.InventoryComponent{{
+		Name: "Cilium", Release: "cilium", Namespace: "kube-system",
+		Chart: "cilium-1.16.3", AppVersion: "1.16.3",
+	}}
+
+	err := state.SaveInClusterState(context.Background(), client, "dev", spec, components, inClusterTestTime())
+	require.NoError(t, err)
+
+	loaded, err := state.LoadInClusterState(context.Background(), client)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dev", loaded.ClusterName)
+	assert.Equal(t, inClusterTestTime(), loaded.UpdatedAt)
+	assert.Equal(t, components, loaded.Components)
+	assert.Equal(t, v1alpha1.CNICilium, loaded.Spec.CNI)
+	assert.Equal(t, "/custom/mirrors", loaded.Spec.Vanilla.MirrorsDir)
+	assert.NotContains(t, loaded.Spec.LocalRegistry.Registry, "secret",
+		"registry credentials must never be written to the cluster")
+	assert.Equal(t, "user:secret@ghcr.io/org/repo", spec.LocalRegistry.Registry,
+		"the caller's spec must be left untouched")
+
+	configMap, err := client.CoreV1().ConfigMaps(state.InClusterNamespace).Get(
+		context.Background(), state.InClusterConfigMapName, metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "ksail", configMap.Labels["app.kubernetes.io/managed-by"])
+}
+
+func TestSaveInClusterState_ReplacesPreviousState(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	ctx := context.Background()
+
+	first := &v1alpha1.ClusterSpec{CNI: v1alpha1.CNICilium}
+	require.NoError(t, state.SaveInClusterState(ctx, client, "dev", first, []state.InventoryComponent{
+		{Name: "Cilium", Release: "cilium", Namespace: "kube-system"},
+	}, inClusterTestTime()))
+
+	second := &v1alpha1.ClusterSpec{CNI: v1alpha1.CNICalico}
+	require.NoError(t, state.SaveInClusterState(ctx, client, "dev", second, nil, inClusterTestTime()))
+
+	loaded, err := state.LoadInClusterState(ctx, client)
+	require.NoError(t, err)
+
+	assert.Equal(t, v1alpha1.CNICalico, loaded.Spec.CNI)
+	assert.Empty(t, loaded.Components)
+}
+
+func TestSaveInClusterState_RetriesConcurrentCreate(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	creates := 0
+
+	client.PrependReactor("create", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		creates++
+		if creates > 1 {
+			return false, nil, nil
+		}
+
+		// Another writer wins the first create; the save must retry as an update.
+		_, err := client.Tracker().Get(corev1.SchemeGroupVersion.WithResource("configmaps"),
+			state.InClusterNamespace, state.InClusterConfigMapName)
+		if apierrors.IsNotFound(err) {
+			addErr := client.Tracker().Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: state.InClusterConfigMapName, Namespace: state.InClusterNamespace,
+			}})
+			require.NoError(t, addErr)
+		}
+
+		return true, nil, apierrors.NewAlreadyExists(corev1.Resource("configmaps"), state.InClusterConfigMapName)
+	})
+
+	err := state.SaveInClusterState(
+		context.Background(), client, "dev", &v1alpha1.ClusterSpec{}, nil, inClusterTestTime(),
+	)
+	require.NoError(t, err)
+
+	loaded, err := state.LoadInClusterState(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", loaded.ClusterName)
+}
+
+func TestLoadInClusterState_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+	}{
+		{name: "no ConfigMap"},
+		{
+			name: "ConfigMap without spec",
+			objects: []runtime.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: state.InClusterConfigMapName, Namespace: state.InClusterNamespace,
+			}}},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := state.LoadInClusterState(context.Background(), fake.NewClientset(testCase.objects...))
+
+			require.ErrorIs(t, err, state.ErrStateNotFound)
+		})
+	}
+}
+
+func TestLoadInClusterState_APIError(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset()
+	client.PrependReactor("get", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errInClusterAPI
+	})
+
+	_, err := state.LoadInClusterState(context.Background(), client)
+
+	require.ErrorIs(t, err, errInClusterAPI)
+	assert.NotErrorIs(t, err, state.ErrStateNotFound)
+}
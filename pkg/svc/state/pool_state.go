@@ -0,0 +1,217 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+const poolFileName = "pool.json"
+
+// ErrPoolMemberClaimed is returned when a pool cluster has already been claimed
+// (or was never a pool member).
+var ErrPoolMemberClaimed = errors.New("pool cluster already claimed")
+
+// PoolMember marks a cluster as a pre-provisioned, stopped spare in a warm
+// standby pool (~/.ksail/clusters/<name>/pool.json).
+type PoolMember struct {
+	// Name is the spare's cluster name (the state directory name).
+	Name string `json:"-"`
+	// Pool is the name of the pool that maintains the spare.
+	Pool string `json:"pool"`
+	// SpecHash identifies the ClusterSpec the spare was created from (see PoolSpecHash).
+	SpecHash string `json:"specHash"`
+	// CreatedAt is the UTC time the spare was provisioned.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SavePoolMember records clusterName as a spare of member.Pool.
+func SavePoolMember(clusterName string, member PoolMember) error {
+	poolPath, err := clusterPoolPath(clusterName)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(poolPath)
+
+	err = os.MkdirAll(dir, dirPermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create state directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(member, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pool member: %w", err)
+	}
+
+	err = os.WriteFile(poolPath, data, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to write pool state: %w", err)
+	}
+
+	return nil
+}
+
+// ListPoolMembers returns every unclaimed spare, oldest first. Unreadable pool
+// records are skipped, and a missing registry yields an empty list.
+func ListPoolMembers() ([]PoolMember, error) {
+	root, err := ClustersDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dirEntries, err := os.ReadDir(root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []PoolMember{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read cluster state registry: %w", err)
+	}
+
+	members := make([]PoolMember, 0, len(dirEntries))
+
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() || !isSafePathSegment(dirEntry.Name()) {
+			continue
+		}
+
+		//nolint:gosec // path is the registry root joined with a validated directory name
+		data, err := os.ReadFile(filepath.Join(root, dirEntry.Name(), poolFileName))
+		if err != nil {
+			continue
+		}
+
+		var member PoolMember
+
+		err = json.Unmarshal(data, &member)
+		if err != nil {
+			continue
+		}
+
+		member.Name = dirEntry.Name()
+		members = append(members, member)
+	}
+
+	slices.SortFunc(members, func(a, b PoolMember) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return members, nil
+}
+
+// ClaimPoolMember removes clusterName from its pool. Removing the pool record is
+// the claim itself, so when several processes race for the same spare exactly
+// one succeeds and the others get ErrPoolMemberClaimed.
+func ClaimPoolMember(clusterName string) error {
+	poolPath, err := clusterPoolPath(clusterName)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(poolPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrPoolMemberClaimed, clusterName)
+		}
+
+		return fmt.Errorf("failed to remove pool state: %w", err)
+	}
+
+	return nil
+}
+
+// PoolSpecHash returns a stable digest of spec for matching spares to a
+// requested cluster. The connection settings are excluded because they are
+// derived from the cluster name, which differs between a spare and the request.
+// The contents of spec.DistributionConfig (e.g. kind.yaml, or every Talos patch
+// under talos/) are included, so editing them retires the spares built from the
+// old ones; a missing distribution config contributes nothing.
+func PoolSpecHash(spec *v1alpha1.ClusterSpec) (string, error) {
+	normalized := sanitizeSpecForPersistence(spec)
+	if normalized == nil {
+		normalized = &v1alpha1.ClusterSpec{}
+	}
+
+	normalized.Connection = v1alpha1.Connection{}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cluster spec: %w", err)
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write(data)
+
+	err = hashDistributionConfig(hash, normalized.DistributionConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// hashDistributionConfig writes the path relative to configPath and the contents
+// of every regular file under configPath, a file or a directory, to hash in
+// lexical order.
+func hashDistributionConfig(hash io.Writer, configPath string) error {
+	if strings.TrimSpace(configPath) == "" {
+		return nil
+	}
+
+	err := filepath.WalkDir(configPath, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(configPath, path)
+		if err != nil {
+			return fmt.Errorf("resolve %s: %w", path, err)
+		}
+
+		//nolint:gosec // path is a file under the configured distribution config
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+
+		_, _ = fmt.Fprintf(hash, "\x00%s\x00%d\x00", filepath.ToSlash(rel), len(content))
+		_, _ = hash.Write(content)
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to hash distribution config: %w", err)
+	}
+
+	return nil
+}
+
+// clusterPoolPath returns the path to the pool file for a given cluster name.
+func clusterPoolPath(clusterName string) (string, error) {
+	dir, err := clusterStateDir(clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, poolFileName), nil
+}
@@ -0,0 +1,143 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func poolMembersOf(t *testing.T, pool string) []state.PoolMember {
+	t.Helper()
+
+	members, err := state.ListPoolMembers()
+	require.NoError(t, err)
+
+	var filtered []state.PoolMember
+
+	for _, member := range members {
+		if member.Pool == pool {
+			filtered = append(filtered, member)
+		}
+	}
+
+	return filtered
+}
+
+func TestSaveAndListPoolMembers(t *testing.T) {
+	t.Parallel()
+
+	pool := "test-pool-list"
+	older := pool + "-pool-2"
+	newer := pool + "-pool-1"
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(older)
+		_ = state.DeleteClusterState(newer)
+	})
+
+	created := time.Now().UTC()
+
+	require.NoError(t, state.SavePoolMember(newer, state.PoolMember{
+		Pool: pool, SpecHash: "abc", CreatedAt: created,
+	}))
+	require.NoError(t, state.SavePoolMember(older, state.PoolMember{
+		Pool: pool, SpecHash: "abc", CreatedAt: created.Add(-time.Hour),
+	}))
+
+	members := poolMembersOf(t, pool)
+	require.Len(t, members, 2)
+	assert.Equal(t, older, members[0].Name)
+	assert.Equal(t, newer, members[1].Name)
+	assert.Equal(t, "abc", members[0].SpecHash)
+}
+
+func TestClaimPoolMember(t *testing.T) {
+	t.Parallel()
+
+	pool := "test-pool-claim"
+	spare := pool + "-pool-1"
+
+	t.Cleanup(func() {
+		_ = state.DeleteClusterState(spare)
+	})
+
+	require.NoError(t, state.SavePoolMember(spare, state.PoolMember{Pool: pool, SpecHash: "abc"}))
+
+	require.NoError(t, state.ClaimPoolMember(spare))
+	assert.Empty(t, poolMembersOf(t, pool))
+
+	err := state.ClaimPoolMember(spare)
+	require.ErrorIs(t, err, state.ErrPoolMemberClaimed)
+}
+
+func TestPoolSpecHash(t *testing.T) {
+	t.Parallel()
+
+	base := v1alpha1.ClusterSpec{
+		Distribution: v1alpha1.DistributionVanilla,
+		Provider:     v1alpha1.ProviderDocker,
+	}
+
+	renamed := base
+	renamed.Connection.Context = "kind-other"
+
+	changed := base
+	changed.Distribution = v1alpha1.DistributionK3s
+
+	baseHash, err := state.PoolSpecHash(&base)
+	require.NoError(t, err)
+
+	renamedHash, err := state.PoolSpecHash(&renamed)
+	require.NoError(t, err)
+
+	changedHash, err := state.PoolSpecHash(&changed)
+	require.NoError(t, err)
+
+	assert.Equal(t, baseHash, renamedHash, "connection settings must not affect the hash")
+	assert.NotEqual(t, baseHash, changedHash)
+}
+
+func TestPoolSpecHash_IncludesDistributionConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	kindConfig := filepath.Join(dir, "kind.yaml")
+	patchesDir := filepath.Join(dir, "talos")
+
+	require.NoError(t, os.WriteFile(kindConfig, []byte("kind: Cluster\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(patchesDir, "cluster"), 0o750))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(patchesDir, "cluster", "mirror.yaml"), []byte("machine: {}\n"), 0o600,
+	))
+
+	hashOf := func(configPath string) string {
+		t.Helper()
+
+		hash, err := state.PoolSpecHash(&v1alpha1.ClusterSpec{
+			Distribution:       v1alpha1.DistributionVanilla,
+			DistributionConfig: configPath,
+		})
+		require.NoError(t, err)
+
+		return hash
+	}
+
+	kindHash := hashOf(kindConfig)
+	patchesHash := hashOf(patchesDir)
+
+	require.NoError(t, os.WriteFile(kindConfig, []byte("kind: Cluster\nnodes: []\n"), 0o600))
+	assert.NotEqual(t, kindHash, hashOf(kindConfig), "editing kind.yaml must change the hash")
+
+	require.NoError(t, os.WriteFile(
+		filepath.Join(patchesDir, "cluster", "mirror.yaml"), []byte("machine: {install: {}}\n"), 0o600,
+	))
+	assert.NotEqual(t, patchesHash, hashOf(patchesDir), "editing a Talos patch must change the hash")
+
+	assert.NotEmpty(t, hashOf(filepath.Join(dir, "missing.yaml")), "a missing distribution config is not an error")
+}
@@ -0,0 +1,79 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+)
+
+// ClusterStateEntry is one cluster recorded in the local state registry
+// (~/.ksail/clusters/<name>/spec.json).
+type ClusterStateEntry struct {
+	// Name is the cluster name (the state directory name).
+	Name string
+	// Spec is the ClusterSpec saved when the cluster was last created or updated.
+	Spec *v1alpha1.ClusterSpec
+	// SavedAt is when the spec was last saved.
+	SavedAt time.Time
+}
+
+// ListClusterStates returns every cluster with a saved spec, sorted by name.
+// State directories without a spec (e.g. one holding only a TTL or audit log)
+// and specs that cannot be read are skipped, so one corrupt entry does not hide
+// the others. A missing registry yields an empty list.
+func ListClusterStates() ([]ClusterStateEntry, error) {
+	root, err := ClustersDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dirEntries, err := os.ReadDir(root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []ClusterStateEntry{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read cluster state registry: %w", err)
+	}
+
+	entries := make([]ClusterStateEntry, 0, len(dirEntries))
+
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() || !isSafePathSegment(dirEntry.Name()) {
+			continue
+		}
+
+		entry, ok := loadClusterStateEntry(root, dirEntry.Name())
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b ClusterStateEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return entries, nil
+}
+
+// loadClusterStateEntry reads the saved spec of one registry directory.
+func loadClusterStateEntry(root, name string) (ClusterStateEntry, bool) {
+	info, err := os.Stat(filepath.Join(root, name, specFileName))
+	if err != nil || !info.Mode().IsRegular() {
+		return ClusterStateEntry{}, false
+	}
+
+	spec, err := LoadClusterSpec(name)
+	if err != nil {
+		return ClusterStateEntry{}, false
+	}
+
+	return ClusterStateEntry{Name: name, Spec: spec, SavedAt: info.ModTime().UTC()}, true
+}
@@ -0,0 +1,102 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+)
+
+func TestListClusterStates(t *testing.T) {
+	t.Parallel()
+
+	withSpec := "test-registry-b-" + t.Name()
+	alsoWithSpec := "test-registry-a-" + t.Name()
+	ttlOnly := "test-registry-ttl-" + t.Name()
+	corrupt := "test-registry-corrupt-" + t.Name()
+
+	t.Cleanup(func() {
+		for _, name := range []string{withSpec, alsoWithSpec, ttlOnly, corrupt} {
+			_ = state.DeleteClusterState(name)
+		}
+	})
+
+	for _, name := range []string{withSpec, alsoWithSpec} {
+		err := state.SaveClusterSpec(name, &v1alpha1.ClusterSpec{
+			Distribution: v1alpha1.DistributionK3s,
+			Workers:      2,
+		})
+		if err != nil {
+			t.Fatalf("SaveClusterSpec failed: %v", err)
+		}
+	}
+
+	err := state.SaveClusterTTL(ttlOnly, time.Hour)
+	if err != nil {
+		t.Fatalf("SaveClusterTTL failed: %v", err)
+	}
+
+	writeCorruptSpec(t, corrupt)
+
+	entries, err := state.ListClusterStates()
+	if err != nil {
+		t.Fatalf("ListClusterStates failed: %v", err)
+	}
+
+	positions := map[string]int{}
+
+	for i, entry := range entries {
+		positions[entry.Name] = i
+
+		if entry.Name == withSpec {
+			if entry.Spec.Distribution != v1alpha1.DistributionK3s || entry.Spec.Workers != 2 {
+				t.Errorf("unexpected spec for %s: %+v", withSpec, entry.Spec)
+			}
+
+			if entry.SavedAt.IsZero() {
+				t.Errorf("SavedAt not set for %s", withSpec)
+			}
+		}
+	}
+
+	for _, name := range []string{withSpec, alsoWithSpec} {
+		if _, ok := positions[name]; !ok {
+			t.Errorf("expected %s in registry listing", name)
+		}
+	}
+
+	for _, name := range []string{ttlOnly, corrupt} {
+		if _, ok := positions[name]; ok {
+			t.Errorf("did not expect %s in registry listing", name)
+		}
+	}
+
+	if positions[alsoWithSpec] > positions[withSpec] {
+		t.Errorf("expected entries sorted by name")
+	}
+}
+
+// writeCorruptSpec writes an unparsable spec.json for clusterName.
+func writeCorruptSpec(t *testing.T, clusterName string) {
+	t.Helper()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("UserHomeDir failed: %v", err)
+	}
+
+	dir := filepath.Join(home, ".ksail", "clusters", clusterName)
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "spec.json"), []byte("{not json"), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
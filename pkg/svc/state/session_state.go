@@ -0,0 +1,78 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// sessionsSubDir holds recorded node access sessions.
+	sessionsSubDir = "sessions"
+	// sessionTimeFormat prefixes session log names so they sort chronologically.
+	sessionTimeFormat = "20060102T150405Z"
+)
+
+// ErrInvalidNodeName is returned when a node name contains path traversal characters.
+var ErrInvalidNodeName = errors.New(
+	"invalid node name: must not contain path separators or '..'",
+)
+
+// CreateSessionLog creates a new, empty session transcript
+// (~/.ksail/sessions/<started>-<cluster>-<node>.log) and returns it opened for
+// writing. Transcripts are never overwritten: a second session started in the
+// same second on the same node gets a numeric suffix.
+func CreateSessionLog(clusterName, nodeName string, started time.Time) (*os.File, error) {
+	if !isSafePathSegment(clusterName) {
+		return nil, ErrInvalidClusterName
+	}
+
+	if !isSafePathSegment(nodeName) {
+		return nil, ErrInvalidNodeName
+	}
+
+	dir, err := SessionsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(dir, dirPermissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sessions directory %s: %w", dir, err)
+	}
+
+	base := fmt.Sprintf("%s-%s-%s", started.UTC().Format(sessionTimeFormat), clusterName, nodeName)
+
+	for attempt := 0; ; attempt++ {
+		name := base + ".log"
+		if attempt > 0 {
+			name = fmt.Sprintf("%s-%d.log", base, attempt)
+		}
+
+		//nolint:gosec // clusterName and nodeName are validated as single path segments above
+		file, openErr := os.OpenFile(
+			filepath.Join(dir, name),
+			os.O_CREATE|os.O_EXCL|os.O_WRONLY,
+			filePermissions,
+		)
+		if openErr == nil {
+			return file, nil
+		}
+
+		if !errors.Is(openErr, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create session log: %w", openErr)
+		}
+	}
+}
+
+// SessionsDir returns the directory session transcripts are written to.
+func SessionsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	return filepath.Join(home, stateDir, sessionsSubDir), nil
+}
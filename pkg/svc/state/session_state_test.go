@@ -0,0 +1,53 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSessionLog(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	node := "node-" + t.Name()
+
+	first, err := state.CreateSessionLog("dev", node, started)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = os.Remove(first.Name())
+	})
+
+	require.NoError(t, first.Close())
+
+	second, err := state.CreateSessionLog("dev", node, started)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = os.Remove(second.Name())
+	})
+
+	require.NoError(t, second.Close())
+
+	dir, err := state.SessionsDir()
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dir, "20261017T093000Z-dev-"+node+".log"), first.Name())
+	assert.Equal(t, filepath.Join(dir, "20261017T093000Z-dev-"+node+"-1.log"), second.Name())
+}
+
+func TestCreateSessionLog_InvalidNames(t *testing.T) {
+	t.Parallel()
+
+	_, err := state.CreateSessionLog("../escape", "node", time.Now())
+	require.ErrorIs(t, err, state.ErrInvalidClusterName)
+
+	_, err = state.CreateSessionLog("dev", "../node", time.Now())
+	require.ErrorIs(t, err, state.ErrInvalidNodeName)
+}
@@ -0,0 +1,9 @@
+// Package tenancy scopes the host-local resources of Docker-provider clusters
+// to a tenant so several users can share one Docker host.
+//
+// A tenant is a prefix, configured in spec.cluster.tenancy or derived from the
+// current OS user, that KSail prepends to cluster names. Container, network,
+// registry, and kubeconfig context names all derive from the cluster name, so
+// scoping the name is enough to keep tenants apart; Kind, whose clusters share
+// one Docker network by default, additionally gets a network per tenant.
+package tenancy
@@ -0,0 +1,121 @@
+package tenancy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/k8s"
+)
+
+// ErrInvalidPrefix is returned when the tenant prefix is not a valid name segment.
+var ErrInvalidPrefix = errors.New("invalid tenant prefix")
+
+// ErrUnknownUser is returned when tenancy is enabled without a prefix and the
+// current OS user cannot be determined.
+var ErrUnknownUser = errors.New("cannot determine the current user for the tenant prefix")
+
+// Prefix returns the tenant prefix for spec: the configured prefix, or the
+// current OS user name sanitized to a DNS label. It returns "" when tenancy is
+// disabled.
+func Prefix(spec v1alpha1.Tenancy) (string, error) {
+	if !spec.Enabled {
+		return "", nil
+	}
+
+	prefix := strings.TrimSpace(spec.Prefix)
+	if prefix == "" {
+		username, err := currentUsername()
+		if err != nil {
+			return "", err
+		}
+
+		prefix = k8s.SanitizeToDNSLabel(username)
+	}
+
+	err := v1alpha1.ValidateClusterName(prefix)
+	if err != nil || prefix == "" {
+		return "", fmt.Errorf(
+			"%w %q: set spec.cluster.tenancy.prefix to a lowercase name starting with a letter",
+			ErrInvalidPrefix, prefix,
+		)
+	}
+
+	return prefix, nil
+}
+
+// Applies reports whether tenant scoping applies to a cluster: tenancy is
+// enabled and the cluster is a Vanilla (Kind) or K3s (K3d) cluster on a local
+// Docker daemon. Other distributions embed the name in generated PKI (Talos)
+// or manage their own naming, and remote providers do not share a host.
+func Applies(spec v1alpha1.ClusterSpec) bool {
+	if !spec.Tenancy.Enabled || !spec.Provider.NeedsLocalDocker() {
+		return false
+	}
+
+	return spec.Distribution == v1alpha1.DistributionVanilla ||
+		spec.Distribution == v1alpha1.DistributionK3s
+}
+
+// ScopeClusterName scopes name to the tenant of clusterCfg when tenancy
+// applies to it, and returns name unchanged otherwise.
+func ScopeClusterName(clusterCfg *v1alpha1.Cluster, name string) (string, error) {
+	if clusterCfg == nil || !Applies(clusterCfg.Spec.Cluster) {
+		return name, nil
+	}
+
+	prefix, err := Prefix(clusterCfg.Spec.Cluster.Tenancy)
+	if err != nil {
+		return "", err
+	}
+
+	return ScopeName(prefix, name), nil
+}
+
+// ScopeName prefixes name with the tenant ("<prefix>-<name>"). Names that are
+// empty or already scoped to the tenant are returned unchanged, so scoping is
+// idempotent across repeated config loads and explicit --name values.
+func ScopeName(prefix, name string) string {
+	if prefix == "" || name == "" || Owns(prefix, name) {
+		return name
+	}
+
+	return prefix + "-" + name
+}
+
+// Owns reports whether name is scoped to the tenant. With no prefix every name
+// is owned.
+func Owns(prefix, name string) bool {
+	if prefix == "" {
+		return true
+	}
+
+	return strings.HasPrefix(name, prefix+"-")
+}
+
+// currentUsername returns the login name of the current user. $SUDO_USER wins
+// so "sudo ksail" stays in the invoking user's tenant, then $USER (or
+// %USERNAME% on Windows), then the account database.
+func currentUsername() (string, error) {
+	for _, key := range []string{"SUDO_USER", "USER", "USERNAME"} {
+		if name := strings.TrimSpace(os.Getenv(key)); name != "" {
+			return name, nil
+		}
+	}
+
+	current, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnknownUser, err)
+	}
+
+	// Windows reports DOMAIN\user.
+	name := current.Username
+	if index := strings.LastIndex(name, `\`); index >= 0 {
+		name = name[index+1:]
+	}
+
+	return name, nil
+}
@@ -0,0 +1,90 @@
+package tenancy_test
+
+import (
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tenancy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Overrides USER.
+func TestPrefix(t *testing.T) {
+	t.Setenv("SUDO_USER", "")
+	t.Setenv("USER", "Alice.Smith")
+
+	prefix, err := tenancy.Prefix(v1alpha1.Tenancy{})
+	require.NoError(t, err)
+	assert.Empty(t, prefix, "tenancy is disabled by default")
+
+	prefix, err = tenancy.Prefix(v1alpha1.Tenancy{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "alice-smith", prefix, "the user name is sanitized to a DNS label")
+
+	prefix, err = tenancy.Prefix(v1alpha1.Tenancy{Enabled: true, Prefix: "team-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", prefix)
+
+	t.Setenv("SUDO_USER", "bob")
+
+	prefix, err = tenancy.Prefix(v1alpha1.Tenancy{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "bob", prefix, "sudo stays in the invoking user's tenant")
+
+	_, err = tenancy.Prefix(v1alpha1.Tenancy{Enabled: true, Prefix: "1team"})
+	require.ErrorIs(t, err, tenancy.ErrInvalidPrefix)
+}
+
+func TestScopeName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "alice-dev", tenancy.ScopeName("alice", "dev"))
+	assert.Equal(t, "alice-dev", tenancy.ScopeName("alice", "alice-dev"), "scoping is idempotent")
+	assert.Equal(t, "dev", tenancy.ScopeName("", "dev"))
+	assert.Empty(t, tenancy.ScopeName("alice", ""))
+}
+
+func TestOwns(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, tenancy.Owns("alice", "alice-dev"))
+	assert.False(t, tenancy.Owns("alice", "bob-dev"))
+	assert.False(t, tenancy.Owns("alice", "alicedev"))
+	assert.True(t, tenancy.Owns("", "bob-dev"))
+}
+
+func TestScopeClusterName(t *testing.T) {
+	t.Parallel()
+
+	cluster := func(distribution v1alpha1.Distribution, provider v1alpha1.Provider) *v1alpha1.Cluster {
+		cfg := &v1alpha1.Cluster{}
+		cfg.Spec.Cluster.Distribution = distribution
+		cfg.Spec.Cluster.Provider = provider
+		cfg.Spec.Cluster.Tenancy = v1alpha1.Tenancy{Enabled: true, Prefix: "alice"}
+
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.Cluster
+		want    string
+	}{
+		{"kind on docker", cluster(v1alpha1.DistributionVanilla, v1alpha1.ProviderDocker), "alice-dev"},
+		{"k3d on docker", cluster(v1alpha1.DistributionK3s, v1alpha1.ProviderDocker), "alice-dev"},
+		{"talos is not scoped", cluster(v1alpha1.DistributionTalos, v1alpha1.ProviderDocker), "dev"},
+		{"remote provider is not scoped", cluster(v1alpha1.DistributionK3s, v1alpha1.ProviderKubernetes), "dev"},
+		{"no config", nil, "dev"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tenancy.ScopeClusterName(test.cluster, "dev")
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
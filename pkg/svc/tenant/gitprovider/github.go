@@ -99,8 +99,12 @@ func (g *gitHubProvider) DeleteRepo(ctx context.Context, owner, name string) err
 
 // GetDefaultBranch returns the default branch name of a repository.
 func (g *gitHubProvider) GetDefaultBranch(ctx context.Context, owner, repo string) (string, error) {
-	r, _, err := g.client.Repositories.Get(ctx, owner, repo)
+	r, resp, err := g.client.Repositories.Get(ctx, owner, repo)
 	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("get repository: %w: %s/%s", ErrRepoNotFound, owner, repo)
+		}
+
 		return "", fmt.Errorf("get repository: %w", err)
 	}
 
@@ -154,6 +158,62 @@ func (g *gitHubProvider) CreatePullRequest(
 	return pullRequest.GetHTMLURL(), nil
 }
 
+// CreateWebhook registers a JSON repository webhook and returns its ID.
+func (g *gitHubProvider) CreateWebhook(
+	ctx context.Context,
+	owner, repo string,
+	opts WebhookOptions,
+) (int64, error) {
+	hook, _, err := g.client.Repositories.CreateHook(ctx, owner, repo, &github.Hook{
+		Config: &github.HookConfig{
+			URL:         new(opts.URL),
+			ContentType: new("json"),
+			Secret:      new(opts.Secret),
+		},
+		Events: opts.Events,
+		Active: new(true),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w during create webhook: %w", ErrGitHubAPI, err)
+	}
+
+	return hook.GetID(), nil
+}
+
+// DeleteWebhook removes a repository webhook.
+func (g *gitHubProvider) DeleteWebhook(ctx context.Context, owner, repo string, id int64) error {
+	_, err := g.client.Repositories.DeleteHook(ctx, owner, repo, id)
+	if err != nil {
+		return fmt.Errorf("%w during delete webhook: %w", ErrGitHubAPI, err)
+	}
+
+	return nil
+}
+
+// CreateDeployKey registers an SSH deploy key on the repository and returns its ID.
+// GitHub rejects a key that is already in use with 422 Unprocessable Entity, which
+// is reported as ErrDeployKeyAlreadyExists so callers can treat it as registered.
+func (g *gitHubProvider) CreateDeployKey(
+	ctx context.Context,
+	owner, repo string,
+	opts DeployKeyOptions,
+) (int64, error) {
+	key, resp, err := g.client.Repositories.CreateKey(ctx, owner, repo, &github.Key{
+		Title:    new(opts.Title),
+		Key:      new(opts.PublicKey),
+		ReadOnly: new(!opts.ReadWrite),
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+			return 0, fmt.Errorf("%w: %s/%s", ErrDeployKeyAlreadyExists, owner, repo)
+		}
+
+		return 0, fmt.Errorf("%w during create deploy key: %w", ErrGitHubAPI, err)
+	}
+
+	return key.GetID(), nil
+}
+
 func (g *gitHubProvider) pushFilesInternal(
 	ctx context.Context,
 	owner, repo, branch string,
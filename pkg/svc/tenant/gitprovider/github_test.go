@@ -413,7 +413,7 @@ func TestGitHubProvider_GetDefaultBranch_Error(t *testing.T) {
 
 	provider := gitprovider.ExportNewGitHubProviderForTest("test-token", srv.Client(), srv.URL)
 	_, err := provider.GetDefaultBranch(context.Background(), "my-org", "no-repo")
-	require.Error(t, err)
+	require.ErrorIs(t, err, gitprovider.ErrRepoNotFound)
 	require.ErrorContains(t, err, "get repository")
 }
 
@@ -601,3 +601,131 @@ func TestGitHubProvider_CreatePullRequest_Error(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorContains(t, err, "create pull request")
 }
+
+func TestGitHubProvider_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPost, request.Method)
+			assert.Equal(t, "/repos/my-org/my-repo/hooks", request.URL.Path)
+
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+			assert.Equal(t, []any{"push"}, body["events"])
+			assert.Equal(t, true, body["active"])
+			assert.Equal(t, map[string]any{
+				"url":          "https://demo.trycloudflare.com/hook/abc",
+				"content_type": "json",
+				"secret":       "s3cr3t",
+			}, body["config"])
+
+			writer.WriteHeader(http.StatusCreated)
+			_, _ = writer.Write([]byte(`{"id":7}`))
+		}),
+	)
+	defer srv.Close()
+
+	provider := gitprovider.ExportNewGitHubProviderForTest("test-token", srv.Client(), srv.URL)
+	hookID, err := provider.CreateWebhook(
+		context.Background(),
+		"my-org",
+		"my-repo",
+		gitprovider.WebhookOptions{
+			URL:    "https://demo.trycloudflare.com/hook/abc",
+			Secret: "s3cr3t",
+			Events: []string{"push"},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), hookID)
+}
+
+func TestGitHubProvider_DeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodDelete, request.Method)
+			assert.Equal(t, "/repos/my-org/my-repo/hooks/7", request.URL.Path)
+
+			writer.WriteHeader(http.StatusNoContent)
+		}),
+	)
+	defer srv.Close()
+
+	provider := gitprovider.ExportNewGitHubProviderForTest("test-token", srv.Client(), srv.URL)
+	err := provider.DeleteWebhook(context.Background(), "my-org", "my-repo", 7)
+	require.NoError(t, err)
+}
+
+func TestGitHubProvider_CreateWebhook_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer srv.Close()
+
+	provider := gitprovider.ExportNewGitHubProviderForTest("test-token", srv.Client(), srv.URL)
+	_, err := provider.CreateWebhook(
+		context.Background(),
+		"my-org",
+		"my-repo",
+		gitprovider.WebhookOptions{URL: "https://example.com", Events: []string{"push"}},
+	)
+	require.ErrorIs(t, err, gitprovider.ErrGitHubAPI)
+}
+
+func TestGitHubProvider_CreateDeployKey(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPost, request.Method)
+			assert.Equal(t, "/repos/my-org/my-repo/keys", request.URL.Path)
+
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+			assert.Equal(t, map[string]any{
+				"title":     "ksail",
+				"key":       "ssh-ed25519 AAAAC3Nz",
+				"read_only": true,
+			}, body)
+
+			writer.WriteHeader(http.StatusCreated)
+			_, _ = writer.Write([]byte(`{"id":9}`))
+		}),
+	)
+	defer srv.Close()
+
+	provider := gitprovider.ExportNewGitHubProviderForTest("test-token", srv.Client(), srv.URL)
+	keyID, err := provider.CreateDeployKey(
+		context.Background(),
+		"my-org",
+		"my-repo",
+		gitprovider.DeployKeyOptions{Title: "ksail", PublicKey: "ssh-ed25519 AAAAC3Nz"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), keyID)
+}
+
+func TestGitHubProvider_CreateDeployKey_AlreadyExists(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = writer.Write([]byte(`{"message":"Validation Failed"}`))
+	}))
+	defer srv.Close()
+
+	provider := gitprovider.ExportNewGitHubProviderForTest("test-token", srv.Client(), srv.URL)
+	_, err := provider.CreateDeployKey(
+		context.Background(),
+		"my-org",
+		"my-repo",
+		gitprovider.DeployKeyOptions{Title: "ksail", PublicKey: "ssh-ed25519 AAAAC3Nz"},
+	)
+	require.ErrorIs(t, err, gitprovider.ErrDeployKeyAlreadyExists)
+}
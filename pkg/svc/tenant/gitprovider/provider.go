@@ -48,6 +48,12 @@ type Provider interface {
 	) error
 	// CreatePullRequest creates a pull request and returns the PR URL.
 	CreatePullRequest(ctx context.Context, owner, repo string, opts PROptions) (string, error)
+	// CreateWebhook registers a repository webhook and returns its ID.
+	CreateWebhook(ctx context.Context, owner, repo string, opts WebhookOptions) (int64, error)
+	// DeleteWebhook removes a repository webhook by ID.
+	DeleteWebhook(ctx context.Context, owner, repo string, id int64) error
+	// CreateDeployKey registers an SSH deploy key on a repository and returns its ID.
+	CreateDeployKey(ctx context.Context, owner, repo string, opts DeployKeyOptions) (int64, error)
 }
 
 // PROptions holds options for creating a pull request.
@@ -62,6 +68,26 @@ type PROptions struct {
 	Base string
 }
 
+// WebhookOptions holds options for registering a repository webhook.
+type WebhookOptions struct {
+	// URL is the payload URL the provider delivers events to.
+	URL string
+	// Secret is the shared secret the provider signs payloads with.
+	Secret string
+	// Events lists the events that trigger a delivery (e.g. push).
+	Events []string
+}
+
+// DeployKeyOptions holds options for registering a repository deploy key.
+type DeployKeyOptions struct {
+	// Title is the name the key is listed under in the repository settings.
+	Title string
+	// PublicKey is the authorized_keys line of the key (e.g. "ssh-ed25519 AAAA...").
+	PublicKey string
+	// ReadWrite grants the key push access; deploy keys are read-only by default.
+	ReadWrite bool
+}
+
 var (
 	// ErrUnsupportedProvider is returned when an unsupported Git provider is specified.
 	ErrUnsupportedProvider = errors.New("unsupported git provider")
@@ -75,10 +101,14 @@ var (
 	ErrGitHubAPI = errors.New("GitHub API error")
 	// ErrRepoAlreadyExists is returned when the repository already exists.
 	ErrRepoAlreadyExists = errors.New("repository already exists")
+	// ErrRepoNotFound is returned when the repository does not exist or the token cannot see it.
+	ErrRepoNotFound = errors.New("repository not found")
 	// ErrOwnerMismatch is returned when the token user does not match the requested owner.
 	ErrOwnerMismatch = errors.New("authenticated user does not match requested owner")
 	// ErrBranchAlreadyExists is returned when the branch already exists.
 	ErrBranchAlreadyExists = errors.New("branch already exists")
+	// ErrDeployKeyAlreadyExists is returned when the repository already has the deploy key.
+	ErrDeployKeyAlreadyExists = errors.New("deploy key already exists")
 )
 
 const (
@@ -186,3 +216,73 @@ func ResolveProviderHost(provider string) string {
 		return provider
 	}
 }
+
+// RepoRef identifies a repository on a Git provider host, as given in a
+// "host/owner/repo" reference such as github.com/org/repo.
+type RepoRef struct {
+	// Provider is the provider name derived from Host (github, gitlab, gitea).
+	Provider string
+	// Host is the provider hostname (e.g. github.com).
+	Host string
+	// Owner is the user or organization owning the repository.
+	Owner string
+	// Name is the repository name.
+	Name string
+}
+
+// ParseRepoRef parses a "host/owner/repo" reference. An https:// scheme and a
+// .git suffix are accepted, so a clone URL can be passed as-is. The host must
+// be one of the hosts returned by ResolveProviderHost.
+func ParseRepoRef(ref string) (RepoRef, error) {
+	trimmed := strings.TrimSpace(ref)
+	trimmed = strings.TrimPrefix(trimmed, "https://")
+	trimmed = strings.TrimSuffix(strings.TrimSuffix(trimmed, "/"), ".git")
+
+	host, ownerRepo, found := strings.Cut(trimmed, "/")
+	if !found || host == "" {
+		return RepoRef{}, fmt.Errorf(
+			"%w: %q (expected host/owner/repo-name)",
+			ErrInvalidRepoFormat,
+			ref,
+		)
+	}
+
+	owner, name, err := ParseOwnerRepo(ownerRepo)
+	if err != nil {
+		return RepoRef{}, fmt.Errorf(
+			"%w: %q (expected host/owner/repo-name)",
+			ErrInvalidRepoFormat,
+			ref,
+		)
+	}
+
+	host = strings.ToLower(host)
+
+	for _, provider := range []string{providerGitHub, providerGitLab, providerGitea} {
+		if ResolveProviderHost(provider) == host {
+			return RepoRef{Provider: provider, Host: host, Owner: owner, Name: name}, nil
+		}
+	}
+
+	return RepoRef{}, fmt.Errorf(
+		"%w: host %s (supported: github.com, gitlab.com, gitea.com)",
+		ErrUnsupportedProvider,
+		host,
+	)
+}
+
+// CloneURL returns the HTTPS clone URL of the repository.
+func (r RepoRef) CloneURL() string {
+	return "https://" + r.Host + "/" + r.Owner + "/" + r.Name + ".git"
+}
+
+// String returns the repository as "host/owner/repo".
+func (r RepoRef) String() string {
+	return r.Host + "/" + r.Owner + "/" + r.Name
+}
+
+// TokenEnvVar returns the conventional environment variable holding the API
+// token for a provider (e.g. GITHUB_TOKEN), as read by ResolveToken.
+func TokenEnvVar(providerName string) string {
+	return strings.ToUpper(providerName) + "_TOKEN"
+}
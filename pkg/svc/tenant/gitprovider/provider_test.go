@@ -143,3 +143,62 @@ func TestNew_CaseInsensitive(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRepoRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected gitprovider.RepoRef
+		wantErr  error
+	}{
+		{
+			name: "host owner repo",
+			ref:  "github.com/org/repo",
+			expected: gitprovider.RepoRef{
+				Provider: "github", Host: "github.com", Owner: "org", Name: "repo",
+			},
+		},
+		{
+			name: "clone URL",
+			ref:  "https://GitLab.com/group/project.git",
+			expected: gitprovider.RepoRef{
+				Provider: "gitlab", Host: "gitlab.com", Owner: "group", Name: "project",
+			},
+		},
+		{name: "missing repo", ref: "github.com/org", wantErr: gitprovider.ErrInvalidRepoFormat},
+		{name: "missing host", ref: "org/repo", wantErr: gitprovider.ErrInvalidRepoFormat},
+		{
+			name:    "unknown host",
+			ref:     "git.example.com/org/repo",
+			wantErr: gitprovider.ErrUnsupportedProvider,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := gitprovider.ParseRepoRef(testCase.ref)
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, ref)
+		})
+	}
+}
+
+func TestRepoRefURLs(t *testing.T) {
+	t.Parallel()
+
+	ref := gitprovider.RepoRef{Provider: "github", Host: "github.com", Owner: "org", Name: "repo"}
+
+	assert.Equal(t, "https://github.com/org/repo.git", ref.CloneURL())
+	assert.Equal(t, "github.com/org/repo", ref.String())
+	assert.Equal(t, "GITHUB_TOKEN", gitprovider.TokenEnvVar(ref.Provider))
+}
@@ -0,0 +1,11 @@
+// Package tfexport hands a KSail-provisioned cloud cluster over to a
+// Terraform or OpenTofu pipeline without recreating it.
+//
+// It renders a module of Terraform import blocks for the cloud resources a
+// cluster is made of — every Hetzner Cloud resource KSail created, or the
+// managed cluster itself on EKS, GKE, and AKS — together with the provider
+// requirements. The module is meant to be completed by
+// `terraform plan -generate-config-out`, which writes the resource
+// configuration from the live infrastructure. Importer runs that flow end to
+// end and applies the import only when the plan changes nothing else.
+package tfexport
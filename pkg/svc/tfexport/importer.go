@@ -0,0 +1,145 @@
+package tfexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// GeneratedConfigFile is where the plan writes the imported resources' configuration.
+	GeneratedConfigFile = "generated.tf"
+	// PlanFile is the saved import plan that is applied.
+	PlanFile = "ksail-import.tfplan"
+)
+
+var (
+	// ErrBinaryNotFound is returned when neither OpenTofu nor Terraform is installed.
+	ErrBinaryNotFound = errors.New("neither tofu nor terraform found in PATH")
+	// ErrUnexpectedChanges is returned when the import plan would change or
+	// destroy infrastructure instead of only importing it.
+	ErrUnexpectedChanges = errors.New("import plan contains changes besides imports")
+)
+
+// Runner runs binary with args in dir and returns its standard output.
+type Runner func(ctx context.Context, dir, binary string, args ...string) ([]byte, error)
+
+// Importer imports a rendered module into Terraform state.
+type Importer struct {
+	// Binary is the tofu or terraform executable.
+	Binary string
+	// Dir is the module directory.
+	Dir string
+	// Run executes the binary; it defaults to running it as a subprocess.
+	Run Runner
+}
+
+// NewImporter returns an Importer running binary in dir.
+func NewImporter(binary, dir string) *Importer {
+	return &Importer{Binary: binary, Dir: dir, Run: execRunner}
+}
+
+// ResolveBinary returns preferred when set, otherwise the first of tofu and
+// terraform found in PATH.
+func ResolveBinary(preferred string) (string, error) {
+	if preferred != "" {
+		return preferred, nil
+	}
+
+	for _, candidate := range []string{"tofu", "terraform"} {
+		path, err := exec.LookPath(candidate)
+		if err == nil {
+			return path, nil
+		}
+	}
+
+	return "", ErrBinaryNotFound
+}
+
+// Import initializes the module, plans the import while generating the
+// resource configuration, and applies the plan. The plan is only applied when
+// every resource change is an import without modifications; otherwise the
+// saved plan is left in place for review.
+func (i *Importer) Import(ctx context.Context) error {
+	_, err := i.Run(ctx, i.Dir, i.Binary, "init", "-input=false")
+	if err != nil {
+		return err
+	}
+
+	_, err = i.Run(ctx, i.Dir, i.Binary, "plan", "-input=false",
+		"-generate-config-out="+GeneratedConfigFile, "-out="+PlanFile)
+	if err != nil {
+		return err
+	}
+
+	planJSON, err := i.Run(ctx, i.Dir, i.Binary, "show", "-json", PlanFile)
+	if err != nil {
+		return err
+	}
+
+	err = checkImportOnly(planJSON)
+	if err != nil {
+		return err
+	}
+
+	_, err = i.Run(ctx, i.Dir, i.Binary, "apply", "-input=false", PlanFile)
+
+	return err
+}
+
+// plan is the subset of the `show -json` plan representation that is checked.
+type plan struct {
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Change  struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// checkImportOnly returns ErrUnexpectedChanges when a resource change in the
+// plan is anything other than a no-op.
+func checkImportOnly(planJSON []byte) error {
+	var parsed plan
+
+	err := json.Unmarshal(planJSON, &parsed)
+	if err != nil {
+		return fmt.Errorf("parse plan: %w", err)
+	}
+
+	var changed []string
+
+	for _, change := range parsed.ResourceChanges {
+		if len(change.Change.Actions) != 1 || change.Change.Actions[0] != "no-op" {
+			changed = append(changed, fmt.Sprintf("%s (%s)",
+				change.Address, strings.Join(change.Change.Actions, ", ")))
+		}
+	}
+
+	if len(changed) > 0 {
+		return fmt.Errorf("%w: %s; review %s and %s",
+			ErrUnexpectedChanges, strings.Join(changed, "; "), GeneratedConfigFile, PlanFile)
+	}
+
+	return nil
+}
+
+// execRunner runs binary as a subprocess and includes its standard error in failures.
+func execRunner(ctx context.Context, dir, binary string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", binary, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
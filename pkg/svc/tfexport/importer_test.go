@@ -0,0 +1,107 @@
+package tfexport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tfexport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errInitFailed = errors.New("init failed")
+
+// fakeRunner records the subcommands it is called with and answers
+// `show -json` with planJSON.
+type fakeRunner struct {
+	calls    []string
+	planJSON string
+	failOn   string
+}
+
+func (f *fakeRunner) run(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args[0])
+
+	if args[0] == f.failOn {
+		return nil, errInitFailed
+	}
+
+	if args[0] == "show" {
+		return []byte(f.planJSON), nil
+	}
+
+	return nil, nil
+}
+
+func TestImporterImport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		planJSON  string
+		failOn    string
+		wantErr   error
+		wantCalls []string
+	}{
+		{
+			name: "applies import-only plan",
+			planJSON: `{"resource_changes":[{"address":"hcloud_server.a",` +
+				`"change":{"actions":["no-op"],"importing":{"id":"11"}}}]}`,
+			wantCalls: []string{"init", "plan", "show", "apply"},
+		},
+		{
+			name: "refuses plan with updates",
+			planJSON: `{"resource_changes":[{"address":"hcloud_server.a",` +
+				`"change":{"actions":["update"],"importing":{"id":"11"}}}]}`,
+			wantErr:   tfexport.ErrUnexpectedChanges,
+			wantCalls: []string{"init", "plan", "show"},
+		},
+		{
+			name:      "stops on failed init",
+			failOn:    "init",
+			wantErr:   errInitFailed,
+			wantCalls: []string{"init"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &fakeRunner{planJSON: testCase.planJSON, failOn: testCase.failOn}
+			importer := tfexport.NewImporter("tofu", t.TempDir())
+			importer.Run = runner.run
+
+			err := importer.Import(t.Context())
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.wantCalls, runner.calls)
+		})
+	}
+}
+
+func TestImporterImport_ReportsChangedAddresses(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{planJSON: `{"resource_changes":[{"address":"hcloud_firewall.fw",` +
+		`"change":{"actions":["delete","create"]}}]}`}
+	importer := tfexport.NewImporter("terraform", t.TempDir())
+	importer.Run = runner.run
+
+	err := importer.Import(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hcloud_firewall.fw (delete, create)")
+}
+
+func TestResolveBinary_Preferred(t *testing.T) {
+	t.Parallel()
+
+	binary, err := tfexport.ResolveBinary("/opt/bin/tofu")
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/bin/tofu", binary)
+}
@@ -0,0 +1,267 @@
+package tfexport
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/hetzner"
+)
+
+const (
+	// VersionsFile holds the terraform block and the provider configuration.
+	VersionsFile = "versions.tf"
+	// ImportsFile holds one import block per cloud resource.
+	ImportsFile = "imports.tf"
+	// dirPermissions is the permission mode for the module directory.
+	dirPermissions = 0o750
+	// filePermissions is the permission mode for the rendered files.
+	filePermissions = 0o600
+)
+
+// ErrFileExists is returned when writing a module would overwrite a file.
+var ErrFileExists = errors.New("file already exists")
+
+// invalidNameChars matches characters that are not allowed in a Terraform resource name.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Provider is the Terraform provider a module's resources belong to.
+type Provider struct {
+	// Name is the provider's local name, e.g. "hcloud".
+	Name string
+	// Source is the provider's registry address, e.g. "hetznercloud/hcloud".
+	Source string
+	// Attributes are the provider block's string arguments, e.g. the AWS region.
+	Attributes map[string]string
+	// Blocks are empty nested blocks the provider requires, e.g. azurerm's "features".
+	Blocks []string
+}
+
+// Resource is one cloud resource to import.
+type Resource struct {
+	// Type is the Terraform resource type, e.g. "hcloud_server".
+	Type string
+	// Name is the resource's name in the cloud, used to derive its Terraform name.
+	Name string
+	// ID is the import ID the Terraform provider expects.
+	ID string
+}
+
+// Module is a Terraform module of import blocks for one cluster.
+type Module struct {
+	// Cluster is the KSail cluster the resources belong to.
+	Cluster string
+	// Provider is the Terraform provider of the resources.
+	Provider Provider
+	// Resources are the cloud resources to import.
+	Resources []Resource
+}
+
+// HetznerModule returns the module importing a Hetzner Cloud cluster's resources.
+func HetznerModule(clusterName string, resources []hetzner.ClusterResource) Module {
+	module := Module{
+		Cluster:  clusterName,
+		Provider: Provider{Name: "hcloud", Source: "hetznercloud/hcloud"},
+	}
+
+	for _, resource := range resources {
+		id := strconv.FormatInt(resource.ID, 10)
+
+		var resourceType string
+
+		switch resource.Kind {
+		case hetzner.ResourceKindServer:
+			resourceType = "hcloud_server"
+		case hetzner.ResourceKindNetwork:
+			resourceType = "hcloud_network"
+		case hetzner.ResourceKindSubnet:
+			resourceType = "hcloud_network_subnet"
+			id += "-" + resource.Name
+		case hetzner.ResourceKindFirewall:
+			resourceType = "hcloud_firewall"
+		case hetzner.ResourceKindPlacementGroup:
+			resourceType = "hcloud_placement_group"
+		case hetzner.ResourceKindFloatingIP:
+			resourceType = "hcloud_floating_ip"
+		case hetzner.ResourceKindLoadBalancer:
+			resourceType = "hcloud_load_balancer"
+		default:
+			continue
+		}
+
+		module.Resources = append(module.Resources, Resource{
+			Type: resourceType, Name: resource.Name, ID: id,
+		})
+	}
+
+	return module
+}
+
+// EKSModule returns the module importing an EKS cluster. Node groups, the VPC,
+// and IAM roles stay in the CloudFormation stacks eksctl manages.
+func EKSModule(clusterName, region string) Module {
+	return Module{
+		Cluster: clusterName,
+		Provider: Provider{
+			Name:       "aws",
+			Source:     "hashicorp/aws",
+			Attributes: map[string]string{"region": region},
+		},
+		Resources: []Resource{{Type: "aws_eks_cluster", Name: clusterName, ID: clusterName}},
+	}
+}
+
+// GKEModule returns the module importing a GKE cluster and, with it, its node pools.
+func GKEModule(clusterName, project, location string) Module {
+	return Module{
+		Cluster: clusterName,
+		Provider: Provider{
+			Name:       "google",
+			Source:     "hashicorp/google",
+			Attributes: map[string]string{"project": project},
+		},
+		Resources: []Resource{{
+			Type: "google_container_cluster",
+			Name: clusterName,
+			ID:   fmt.Sprintf("projects/%s/locations/%s/clusters/%s", project, location, clusterName),
+		}},
+	}
+}
+
+// AKSModule returns the module importing an AKS cluster and its default node pool.
+func AKSModule(clusterName, subscriptionID, resourceGroup string) Module {
+	return Module{
+		Cluster: clusterName,
+		Provider: Provider{
+			Name:       "azurerm",
+			Source:     "hashicorp/azurerm",
+			Attributes: map[string]string{"subscription_id": subscriptionID},
+			Blocks:     []string{"features"},
+		},
+		Resources: []Resource{{
+			Type: "azurerm_kubernetes_cluster",
+			Name: clusterName,
+			ID: fmt.Sprintf(
+				"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerService/managedClusters/%s",
+				subscriptionID, resourceGroup, clusterName,
+			),
+		}},
+	}
+}
+
+// Render returns the module's files keyed by file name.
+func Render(module Module) map[string][]byte {
+	return map[string][]byte{
+		VersionsFile: renderVersions(module),
+		ImportsFile:  renderImports(module),
+	}
+}
+
+// Write renders module into dir, creating it if needed. Existing files are
+// only replaced when force is set.
+func Write(dir string, module Module, force bool) error {
+	files := Render(module)
+
+	if !force {
+		for name := range files {
+			_, err := os.Stat(filepath.Join(dir, name))
+			if err == nil {
+				return fmt.Errorf("%w: %s (pass --force to replace it)", ErrFileExists, filepath.Join(dir, name))
+			}
+		}
+	}
+
+	err := os.MkdirAll(dir, dirPermissions)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+
+	for name, data := range files {
+		err = os.WriteFile(filepath.Join(dir, name), data, filePermissions)
+		if err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// renderVersions renders the terraform block and the provider block.
+func renderVersions(module Module) []byte {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# Generated by KSail for cluster %s.\n\n", module.Cluster)
+	builder.WriteString("terraform {\n  required_version = \">= 1.5.0\"\n\n  required_providers {\n")
+	fmt.Fprintf(&builder, "    %s = {\n      source = %s\n    }\n  }\n}\n\n",
+		module.Provider.Name, hclString(module.Provider.Source))
+	fmt.Fprintf(&builder, "provider %s {\n", hclString(module.Provider.Name))
+
+	keys := make([]string, 0, len(module.Provider.Attributes))
+	for key := range module.Provider.Attributes {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(&builder, "  %s = %s\n", key, hclString(module.Provider.Attributes[key]))
+	}
+
+	for _, block := range module.Provider.Blocks {
+		fmt.Fprintf(&builder, "  %s {}\n", block)
+	}
+
+	builder.WriteString("}\n")
+
+	return []byte(builder.String())
+}
+
+// renderImports renders one import block per resource. Resource names are
+// derived from the cloud names and made unique per resource type.
+func renderImports(module Module) []byte {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# Cloud resources of cluster %s. Generate their configuration with:\n", module.Cluster)
+	builder.WriteString("#   terraform plan -generate-config-out=generated.tf\n")
+
+	used := map[string]bool{}
+
+	for _, resource := range module.Resources {
+		address := resource.Type + "." + uniqueName(resource, used)
+
+		fmt.Fprintf(&builder, "\nimport {\n  to = %s\n  id = %s\n}\n", address, hclString(resource.ID))
+	}
+
+	return []byte(builder.String())
+}
+
+// uniqueName returns a Terraform resource name for resource that is not yet
+// used for its type and records it.
+func uniqueName(resource Resource, used map[string]bool) string {
+	base := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(resource.Name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "r_" + base
+	}
+
+	name := base
+	for index := 2; used[resource.Type+"."+name]; index++ {
+		name = base + "_" + strconv.Itoa(index)
+	}
+
+	used[resource.Type+"."+name] = true
+
+	return name
+}
+
+// hclString quotes value as an HCL string literal, escaping template sequences.
+func hclString(value string) string {
+	quoted := strconv.Quote(value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
@@ -0,0 +1,111 @@
+package tfexport_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/provider/hetzner"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/tfexport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHetznerModule(t *testing.T) {
+	t.Parallel()
+
+	module := tfexport.HetznerModule("dev", []hetzner.ClusterResource{
+		{Kind: hetzner.ResourceKindServer, Name: "dev-controlplane-1", ID: 11},
+		{Kind: hetzner.ResourceKindServer, Name: "dev.controlplane.1", ID: 12},
+		{Kind: hetzner.ResourceKindNetwork, Name: "dev-network", ID: 21},
+		{Kind: hetzner.ResourceKindSubnet, Name: "10.0.1.0/24", ID: 21},
+		{Kind: hetzner.ResourceKindLoadBalancer, Name: "ccm-lb", ID: 41},
+	})
+
+	imports := string(tfexport.Render(module)[tfexport.ImportsFile])
+
+	assert.Contains(t, imports, "to = hcloud_server.dev_controlplane_1\n  id = \"11\"")
+	assert.Contains(t, imports, "to = hcloud_server.dev_controlplane_1_2\n  id = \"12\"")
+	assert.Contains(t, imports, "to = hcloud_network.dev_network\n  id = \"21\"")
+	assert.Contains(t, imports, "to = hcloud_network_subnet.r_10_0_1_0_24\n  id = \"21-10.0.1.0/24\"")
+	assert.Contains(t, imports, "to = hcloud_load_balancer.ccm_lb\n  id = \"41\"")
+}
+
+func TestRenderVersions(t *testing.T) {
+	t.Parallel()
+
+	versions := string(tfexport.Render(tfexport.AKSModule("dev", "sub-1", "rg-dev"))[tfexport.VersionsFile])
+
+	assert.Contains(t, versions, "azurerm = {\n      source = \"hashicorp/azurerm\"")
+	assert.Contains(t, versions, "provider \"azurerm\" {\n  subscription_id = \"sub-1\"\n  features {}\n}")
+}
+
+func TestManagedClusterImportIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		module tfexport.Module
+		want   tfexport.Resource
+	}{
+		{
+			name:   "eks",
+			module: tfexport.EKSModule("dev", "eu-west-1"),
+			want:   tfexport.Resource{Type: "aws_eks_cluster", Name: "dev", ID: "dev"},
+		},
+		{
+			name:   "gke",
+			module: tfexport.GKEModule("dev", "my-project", "europe-west1"),
+			want: tfexport.Resource{
+				Type: "google_container_cluster", Name: "dev",
+				ID: "projects/my-project/locations/europe-west1/clusters/dev",
+			},
+		},
+		{
+			name:   "aks",
+			module: tfexport.AKSModule("dev", "sub-1", "rg-dev"),
+			want: tfexport.Resource{
+				Type: "azurerm_kubernetes_cluster", Name: "dev",
+				ID: "/subscriptions/sub-1/resourceGroups/rg-dev/providers/" +
+					"Microsoft.ContainerService/managedClusters/dev",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, []tfexport.Resource{testCase.want}, testCase.module.Resources)
+		})
+	}
+}
+
+func TestRenderEscapesTemplateSequences(t *testing.T) {
+	t.Parallel()
+
+	module := tfexport.EKSModule("dev", "${var.region}")
+
+	assert.Contains(t, string(tfexport.Render(module)[tfexport.VersionsFile]), `region = "$${var.region}"`)
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "terraform")
+	module := tfexport.EKSModule("dev", "eu-west-1")
+
+	require.NoError(t, tfexport.Write(dir, module, false))
+	assert.FileExists(t, filepath.Join(dir, tfexport.VersionsFile))
+	assert.FileExists(t, filepath.Join(dir, tfexport.ImportsFile))
+
+	err := tfexport.Write(dir, module, false)
+	require.ErrorIs(t, err, tfexport.ErrFileExists)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, tfexport.ImportsFile), []byte("stale"), 0o600))
+	require.NoError(t, tfexport.Write(dir, module, true))
+
+	data, err := os.ReadFile(filepath.Join(dir, tfexport.ImportsFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "aws_eks_cluster.dev")
+}
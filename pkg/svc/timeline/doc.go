@@ -0,0 +1,16 @@
+// Package timeline merges what happened to a cluster into one chronological
+// view for `ksail cluster timeline`, so "what changed in the last hour" can be
+// answered without cross-referencing the audit log, `kubectl get events`, and
+// the GitOps engine's status separately.
+//
+// Entries come from three sources:
+//   - ksail: the operation journal kept by package audit (create, update,
+//     start, stop, restore, ...).
+//   - event: Kubernetes Events. The KSailOperation Events announcing audit
+//     entries are skipped because the journal already carries them.
+//   - gitops: Flux Ready-condition transitions and HelmRelease deployments,
+//     and Argo CD sync history and operation results.
+//
+// Collectors are independent; a [Filter] narrows the merged result by time,
+// source, namespace, object, or severity.
+package timeline
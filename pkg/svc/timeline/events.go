@@ -0,0 +1,71 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ListEvents returns the Kubernetes Events in namespace, or in every namespace
+// when namespace is empty. Events announcing audit entries are skipped; the
+// operation journal already carries them with their full list of changes.
+func ListEvents(ctx context.Context, client kubernetes.Interface, namespace string) ([]Entry, error) {
+	events, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(events.Items))
+
+	for i := range events.Items {
+		event := &events.Items[i]
+		if event.Reason == audit.EventReason {
+			continue
+		}
+
+		message := event.Message
+		if event.Count > 1 {
+			message = fmt.Sprintf("%s (x%d)", message, event.Count)
+		}
+
+		entries = append(entries, Entry{
+			Time:      eventTime(event).UTC(),
+			Source:    SourceEvent,
+			Type:      eventType(event),
+			Namespace: event.InvolvedObject.Namespace,
+			Object:    event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			Reason:    event.Reason,
+			Message:   message,
+		})
+	}
+
+	return entries, nil
+}
+
+// eventTime returns when an Event last occurred.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// eventType returns the Event type, treating anything but Warning as Normal.
+func eventType(event *corev1.Event) string {
+	if event.Type == corev1.EventTypeWarning {
+		return corev1.EventTypeWarning
+	}
+
+	return corev1.EventTypeNormal
+}
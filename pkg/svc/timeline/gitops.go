@@ -0,0 +1,232 @@
+package timeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// readyCondition is the condition Flux sets on every reconciled object.
+const readyCondition = "Ready"
+
+// fluxKinds lists the Flux kinds whose Ready transitions are collected.
+//
+//nolint:gochecknoglobals // Fixed lookup table.
+var fluxKinds = []struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}{
+	{kind: "GitRepository", gvr: flux.GitRepositoryGVR()},
+	{kind: "OCIRepository", gvr: flux.OCIRepositoryGVR()},
+	{kind: "Kustomization", gvr: flux.KustomizationGVR()},
+	{kind: "HelmRelease", gvr: flux.HelmReleaseGVR()},
+}
+
+// ListGitOps returns the reconciliation history of the Flux and Argo CD
+// objects in namespace, or in every namespace when namespace is empty:
+//   - the last Ready transition of Flux sources, Kustomizations, and HelmReleases;
+//   - the Helm release history Flux records on HelmReleases;
+//   - the sync history of Argo CD Applications and their last failed operation.
+//
+// Kinds whose CRDs are not installed are skipped, so a cluster without a
+// GitOps engine yields no entries. Other list failures are returned joined
+// alongside the entries that could be collected.
+func ListGitOps(ctx context.Context, client dynamic.Interface, namespace string) ([]Entry, error) {
+	var (
+		entries []Entry
+		errs    []error
+	)
+
+	for _, fluxKind := range fluxKinds {
+		items, err := listObjects(ctx, client, fluxKind.gvr, namespace)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list %s: %w", fluxKind.gvr.Resource, err))
+
+			continue
+		}
+
+		for i := range items {
+			entries = append(entries, fluxEntries(fluxKind.kind, &items[i])...)
+		}
+	}
+
+	applications, err := listObjects(ctx, client, argocd.ApplicationGVR(), namespace)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("list %s: %w", argocd.ApplicationGVR().Resource, err))
+	}
+
+	for i := range applications {
+		entries = append(entries, applicationEntries(&applications[i])...)
+	}
+
+	return entries, errors.Join(errs...)
+}
+
+// listObjects lists gvr in namespace, treating a missing CRD as an empty list.
+func listObjects(
+	ctx context.Context,
+	client dynamic.Interface,
+	gvr schema.GroupVersionResource,
+	namespace string,
+) ([]unstructured.Unstructured, error) {
+	list, err := client.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+
+		return nil, err //nolint:wrapcheck // wrapped with the resource name by the caller
+	}
+
+	return list.Items, nil
+}
+
+// fluxEntries returns the Ready transition of a Flux object and, for
+// HelmReleases, its release history.
+func fluxEntries(kind string, obj *unstructured.Unstructured) []Entry {
+	var entries []Entry
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]any)
+		if !ok || stringField(condition, "type") != readyCondition {
+			continue
+		}
+
+		transitioned, ok := parseTime(stringField(condition, "lastTransitionTime"))
+		if !ok {
+			continue
+		}
+
+		entryType := corev1.EventTypeNormal
+		if stringField(condition, "status") == string(metav1.ConditionFalse) {
+			entryType = corev1.EventTypeWarning
+		}
+
+		entries = append(entries, gitOpsEntry(obj, kind, transitioned, entryType,
+			stringField(condition, "reason"), stringField(condition, "message")))
+	}
+
+	if kind != "HelmRelease" {
+		return entries
+	}
+
+	history, _, _ := unstructured.NestedSlice(obj.Object, "status", "history")
+	for _, raw := range history {
+		snapshot, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		deployed, ok := parseTime(stringField(snapshot, "lastDeployed"))
+		if !ok {
+			continue
+		}
+
+		status := stringField(snapshot, "status")
+
+		entryType := corev1.EventTypeNormal
+		if status == "failed" {
+			entryType = corev1.EventTypeWarning
+		}
+
+		version, _, _ := unstructured.NestedInt64(snapshot, "version")
+		message := fmt.Sprintf("release v%d %s: %s %s", version, status,
+			stringField(snapshot, "chartName"), stringField(snapshot, "chartVersion"))
+
+		entries = append(entries, gitOpsEntry(obj, kind, deployed, entryType, "HelmRelease", message))
+	}
+
+	return entries
+}
+
+// applicationEntries returns the sync history of an Argo CD Application and
+// its last operation when that operation did not succeed.
+func applicationEntries(app *unstructured.Unstructured) []Entry {
+	var entries []Entry
+
+	history, _, _ := unstructured.NestedSlice(app.Object, "status", "history")
+	for _, raw := range history {
+		record, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		deployed, ok := parseTime(stringField(record, "deployedAt"))
+		if !ok {
+			continue
+		}
+
+		message := "synced"
+		if revision := stringField(record, "revision"); revision != "" {
+			message += " to revision " + revision
+		}
+
+		entries = append(entries, gitOpsEntry(app, "Application", deployed,
+			corev1.EventTypeNormal, "Synced", message))
+	}
+
+	phase, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "phase")
+	if phase == "Failed" || phase == "Error" {
+		finishedAt, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "finishedAt")
+		message, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "message")
+
+		finished, ok := parseTime(finishedAt)
+		if ok {
+			entries = append(entries, gitOpsEntry(app, "Application", finished,
+				corev1.EventTypeWarning, "Sync"+phase, message))
+		}
+	}
+
+	return entries
+}
+
+// gitOpsEntry builds a gitops timeline entry about obj.
+func gitOpsEntry(
+	obj *unstructured.Unstructured,
+	kind string,
+	at time.Time,
+	entryType, reason, message string,
+) Entry {
+	return Entry{
+		Time:      at.UTC(),
+		Source:    SourceGitOps,
+		Type:      entryType,
+		Namespace: obj.GetNamespace(),
+		Object:    kind + "/" + obj.GetName(),
+		Reason:    reason,
+		Message:   message,
+	}
+}
+
+// stringField returns fields[key] when it is a string.
+func stringField(fields map[string]any, key string) string {
+	value, _ := fields[key].(string)
+
+	return value
+}
+
+// parseTime parses an RFC 3339 timestamp as written by the API server.
+func parseTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return parsed, true
+}
@@ -0,0 +1,159 @@
+package timeline
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Source names where a timeline entry came from.
+type Source string
+
+const (
+	// SourceKSail marks entries from the KSail operation journal.
+	SourceKSail Source = "ksail"
+	// SourceEvent marks Kubernetes Events.
+	SourceEvent Source = "event"
+	// SourceGitOps marks Flux and Argo CD reconciliation history.
+	SourceGitOps Source = "gitops"
+)
+
+// ErrUnknownSource is returned when a source name is not one of [Sources].
+var ErrUnknownSource = errors.New("unknown timeline source")
+
+// Sources returns every timeline source in display order.
+func Sources() []Source {
+	return []Source{SourceKSail, SourceEvent, SourceGitOps}
+}
+
+// ParseSources parses source names, ignoring case and surrounding whitespace.
+// An empty list selects every source.
+func ParseSources(names []string) ([]Source, error) {
+	if len(names) == 0 {
+		return Sources(), nil
+	}
+
+	sources := make([]Source, 0, len(names))
+
+	for _, name := range names {
+		source := Source(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(Sources(), source) {
+			return nil, fmt.Errorf("%w %q (valid: ksail, event, gitops)", ErrUnknownSource, name)
+		}
+
+		if !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+
+	return sources, nil
+}
+
+// Entry is one thing that happened to the cluster.
+type Entry struct {
+	// Time is when it happened, in UTC.
+	Time time.Time `json:"time"`
+	// Source is where the entry came from.
+	Source Source `json:"source"`
+	// Type is "Normal" or "Warning", following Kubernetes Event types.
+	Type string `json:"type"`
+	// Namespace is the namespace of Object; empty for cluster-scoped objects
+	// and KSail operations.
+	Namespace string `json:"namespace,omitempty"`
+	// Object identifies what the entry is about as Kind/name.
+	Object string `json:"object"`
+	// Reason is a short machine-readable reason (e.g. "update", "BackOff").
+	Reason string `json:"reason"`
+	// Message is the human-readable detail.
+	Message string `json:"message,omitempty"`
+}
+
+// IsWarning reports whether the entry records a failure or degradation.
+func (e Entry) IsWarning() bool {
+	return e.Type == corev1.EventTypeWarning
+}
+
+// Filter narrows a timeline. The zero value keeps every entry.
+type Filter struct {
+	// Since drops entries older than this time; zero keeps all.
+	Since time.Time
+	// Sources keeps only entries from these sources; empty keeps all.
+	Sources []Source
+	// Namespace keeps only entries in this namespace; empty keeps all.
+	Namespace string
+	// Object keeps only entries whose object contains this text, ignoring case.
+	Object string
+	// WarningsOnly keeps only warning entries.
+	WarningsOnly bool
+}
+
+// Match reports whether entry passes the filter.
+func (f Filter) Match(entry Entry) bool {
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+
+	if len(f.Sources) > 0 && !slices.Contains(f.Sources, entry.Source) {
+		return false
+	}
+
+	if f.Namespace != "" && entry.Namespace != f.Namespace {
+		return false
+	}
+
+	if f.Object != "" &&
+		!strings.Contains(strings.ToLower(entry.Object), strings.ToLower(f.Object)) {
+		return false
+	}
+
+	return !f.WarningsOnly || entry.IsWarning()
+}
+
+// Merge combines groups of entries, drops those that do not pass filter, and
+// returns the rest oldest first. Entries with the same timestamp keep the
+// order of their groups.
+func Merge(filter Filter, groups ...[]Entry) []Entry {
+	var merged []Entry
+
+	for _, group := range groups {
+		for _, entry := range group {
+			if filter.Match(entry) {
+				merged = append(merged, entry)
+			}
+		}
+	}
+
+	slices.SortStableFunc(merged, func(a, b Entry) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return merged
+}
+
+// FromAudit converts operation journal entries to timeline entries.
+func FromAudit(entries []audit.Entry) []Entry {
+	result := make([]Entry, 0, len(entries))
+
+	for _, entry := range entries {
+		eventType := corev1.EventTypeNormal
+		if entry.Error != "" {
+			eventType = corev1.EventTypeWarning
+		}
+
+		result = append(result, Entry{
+			Time:    entry.Time.UTC(),
+			Source:  SourceKSail,
+			Type:    eventType,
+			Object:  "Cluster/" + entry.Cluster,
+			Reason:  string(entry.Operation),
+			Message: entry.Summary(),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,234 @@
+package timeline_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	"github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/audit"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/timeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+//nolint:gochecknoglobals // Shared fixed timestamp for test fixtures.
+var base = time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+func TestParseSources(t *testing.T) {
+	t.Parallel()
+
+	sources, err := timeline.ParseSources(nil)
+	require.NoError(t, err)
+	assert.Equal(t, timeline.Sources(), sources)
+
+	sources, err = timeline.ParseSources([]string{" Event", "gitops", "event"})
+	require.NoError(t, err)
+	assert.Equal(t, []timeline.Source{timeline.SourceEvent, timeline.SourceGitOps}, sources)
+
+	_, err = timeline.ParseSources([]string{"logs"})
+	require.ErrorIs(t, err, timeline.ErrUnknownSource)
+}
+
+func TestMerge_SortsAndFilters(t *testing.T) {
+	t.Parallel()
+
+	journal := []timeline.Entry{
+		{Time: base.Add(20 * time.Minute), Source: timeline.SourceKSail, Type: corev1.EventTypeNormal, Object: "Cluster/dev"},
+	}
+	events := []timeline.Entry{
+		{Time: base.Add(-2 * time.Hour), Source: timeline.SourceEvent, Type: corev1.EventTypeWarning, Object: "Pod/old"},
+		{Time: base.Add(30 * time.Minute), Source: timeline.SourceEvent, Type: corev1.EventTypeWarning, Namespace: "apps", Object: "Pod/web-1"},
+		{Time: base.Add(10 * time.Minute), Source: timeline.SourceEvent, Type: corev1.EventTypeNormal, Namespace: "apps", Object: "Pod/web-0"},
+	}
+
+	merged := timeline.Merge(timeline.Filter{Since: base}, journal, events)
+	require.Len(t, merged, 3)
+	assert.Equal(t, "Pod/web-0", merged[0].Object)
+	assert.Equal(t, "Cluster/dev", merged[1].Object)
+	assert.Equal(t, "Pod/web-1", merged[2].Object)
+
+	tests := []struct {
+		name   string
+		filter timeline.Filter
+		want   []string
+	}{
+		{
+			name:   "source",
+			filter: timeline.Filter{Since: base, Sources: []timeline.Source{timeline.SourceKSail}},
+			want:   []string{"Cluster/dev"},
+		},
+		{
+			name:   "namespace",
+			filter: timeline.Filter{Namespace: "apps"},
+			want:   []string{"Pod/web-0", "Pod/web-1"},
+		},
+		{
+			name:   "object",
+			filter: timeline.Filter{Object: "WEB-1"},
+			want:   []string{"Pod/web-1"},
+		},
+		{
+			name:   "warnings",
+			filter: timeline.Filter{WarningsOnly: true},
+			want:   []string{"Pod/old", "Pod/web-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var objects []string
+			for _, entry := range timeline.Merge(test.filter, journal, events) {
+				objects = append(objects, entry.Object)
+			}
+
+			assert.Equal(t, test.want, objects)
+		})
+	}
+}
+
+func TestFromAudit(t *testing.T) {
+	t.Parallel()
+
+	entries := timeline.FromAudit([]audit.Entry{
+		{Time: base, User: "alice", Host: "laptop", Cluster: "dev", Operation: audit.OperationStop},
+		{Time: base, User: "bob", Cluster: "dev", Operation: audit.OperationUpdate, Error: "timeout"},
+	})
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, timeline.SourceKSail, entries[0].Source)
+	assert.Equal(t, "Cluster/dev", entries[0].Object)
+	assert.Equal(t, "stop", entries[0].Reason)
+	assert.Equal(t, "stop by alice@laptop", entries[0].Message)
+	assert.False(t, entries[0].IsWarning())
+	assert.True(t, entries[1].IsWarning())
+}
+
+func TestListEvents_SkipsAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewClientset(
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web.1", Namespace: "apps"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web", Namespace: "apps"},
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			Type:           corev1.EventTypeWarning,
+			Count:          3,
+			LastTimestamp:  metav1.NewTime(base),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "ksail-audit.1", Namespace: audit.Namespace},
+			InvolvedObject: corev1.ObjectReference{Kind: "ConfigMap", Name: audit.ConfigMapName},
+			Reason:         audit.EventReason,
+			Type:           corev1.EventTypeNormal,
+			LastTimestamp:  metav1.NewTime(base),
+		},
+	)
+
+	entries, err := timeline.ListEvents(context.Background(), client, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, timeline.Entry{
+		Time:      base,
+		Source:    timeline.SourceEvent,
+		Type:      corev1.EventTypeWarning,
+		Namespace: "apps",
+		Object:    "Pod/web",
+		Reason:    "BackOff",
+		Message:   "Back-off restarting failed container (x3)",
+	}, entries[0])
+}
+
+func newGitOpsClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			flux.GitRepositoryGVR(): "GitRepositoryList",
+			flux.OCIRepositoryGVR(): "OCIRepositoryList",
+			flux.KustomizationGVR(): "KustomizationList",
+			flux.HelmReleaseGVR():   "HelmReleaseList",
+			argocd.ApplicationGVR(): "ApplicationList",
+		},
+		objects...,
+	)
+}
+
+func TestListGitOps(t *testing.T) {
+	t.Parallel()
+
+	kustomization := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]any{"name": "apps", "namespace": "flux-system"},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{
+				"type":               "Ready",
+				"status":             "False",
+				"reason":             "BuildFailed",
+				"message":            "kustomize build failed",
+				"lastTransitionTime": base.Format(time.RFC3339),
+			},
+		}},
+	}}
+	helmRelease := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2",
+		"kind":       "HelmRelease",
+		"metadata":   map[string]any{"name": "podinfo", "namespace": "apps"},
+		"status": map[string]any{"history": []any{
+			map[string]any{
+				"chartName":    "podinfo",
+				"chartVersion": "6.7.0",
+				"version":      int64(2),
+				"status":       "deployed",
+				"lastDeployed": base.Add(time.Minute).Format(time.RFC3339),
+			},
+		}},
+	}}
+	application := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]any{"name": "ksail", "namespace": "argocd"},
+		"status": map[string]any{
+			"history": []any{
+				map[string]any{"revision": "abc123", "deployedAt": base.Add(2 * time.Minute).Format(time.RFC3339)},
+			},
+			"operationState": map[string]any{
+				"phase":      "Failed",
+				"message":    "one or more objects failed to apply",
+				"finishedAt": base.Add(3 * time.Minute).Format(time.RFC3339),
+			},
+		},
+	}}
+
+	entries, err := timeline.ListGitOps(context.Background(),
+		newGitOpsClient(kustomization, helmRelease, application), "")
+	require.NoError(t, err)
+
+	merged := timeline.Merge(timeline.Filter{}, entries)
+	require.Len(t, merged, 4)
+
+	assert.Equal(t, "Kustomization/apps", merged[0].Object)
+	assert.Equal(t, "BuildFailed", merged[0].Reason)
+	assert.True(t, merged[0].IsWarning())
+
+	assert.Equal(t, "HelmRelease/podinfo", merged[1].Object)
+	assert.Equal(t, "release v2 deployed: podinfo 6.7.0", merged[1].Message)
+
+	assert.Equal(t, "Application/ksail", merged[2].Object)
+	assert.Equal(t, "synced to revision abc123", merged[2].Message)
+
+	assert.Equal(t, "SyncFailed", merged[3].Reason)
+	assert.True(t, merged[3].IsWarning())
+}
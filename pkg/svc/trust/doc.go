@@ -0,0 +1,10 @@
+// Package trust loads the extra CA certificates configured in
+// spec.trust.caBundles and renders them into the single PEM bundle KSail
+// distributes to node OS trust stores and to workloads.
+//
+// Each configured entry is either a path to a PEM file or inline PEM content.
+// [LoadBundle] reads every entry, rejects anything that is not a parseable
+// X.509 certificate, drops duplicates, and concatenates the rest in order, so
+// a mistyped path or a truncated certificate fails `ksail cluster create`
+// before any node is touched.
+package trust
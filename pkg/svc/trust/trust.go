@@ -0,0 +1,119 @@
+package trust
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/fsutil"
+)
+
+const (
+	// BundleName names the bundle everywhere KSail publishes it: the node trust
+	// store file, the Talos TrustedRootsConfig document, and the trust-manager
+	// Bundle and the ConfigMap it distributes.
+	BundleName = "ksail-ca-bundle"
+	// BundleKey is the ConfigMap key holding the PEM bundle, matching the file
+	// name most TLS libraries look for.
+	BundleKey = "ca-certificates.crt"
+
+	certificateBlockType = "CERTIFICATE"
+)
+
+var (
+	// ErrNoCertificates is returned when a CA bundle entry holds no PEM certificate.
+	ErrNoCertificates = errors.New("no PEM certificates found")
+	// ErrUnexpectedPEMBlock is returned when a CA bundle entry holds a PEM block
+	// other than a certificate, such as a private key.
+	ErrUnexpectedPEMBlock = errors.New("unexpected PEM block")
+)
+
+// LoadBundle reads every spec.trust.caBundles entry and returns the
+// certificates as one PEM bundle, in configuration order with duplicates
+// removed. It returns an empty string when no bundles are configured.
+func LoadBundle(spec v1alpha1.TrustSpec) (string, error) {
+	var (
+		bundle bytes.Buffer
+		seen   = make(map[string]bool)
+	)
+
+	for _, entry := range spec.CABundles {
+		content, source, err := readEntry(entry)
+		if err != nil {
+			return "", err
+		}
+
+		certs, err := parseCertificates(content)
+		if err != nil {
+			return "", fmt.Errorf("CA bundle %s: %w", source, err)
+		}
+
+		for _, cert := range certs {
+			if seen[string(cert.Raw)] {
+				continue
+			}
+
+			seen[string(cert.Raw)] = true
+
+			_ = pem.Encode(&bundle, &pem.Block{Type: certificateBlockType, Bytes: cert.Raw})
+		}
+	}
+
+	return bundle.String(), nil
+}
+
+// readEntry returns the PEM content of a CA bundle entry and a description of
+// where it came from for error messages.
+func readEntry(entry string) ([]byte, string, error) {
+	if v1alpha1.IsInlinePEM(entry) {
+		return []byte(entry), "(inline)", nil
+	}
+
+	path, err := fsutil.ExpandHomePath(entry)
+	if err != nil {
+		return nil, entry, fmt.Errorf("expand CA bundle path %q: %w", entry, err)
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec // path comes from the user's own ksail.yaml
+	if err != nil {
+		return nil, path, fmt.Errorf("read CA bundle %q: %w", path, err)
+	}
+
+	return content, path, nil
+}
+
+// parseCertificates decodes every PEM block in content as an X.509
+// certificate, failing on other block types or malformed certificates.
+func parseCertificates(content []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	for rest := content; ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != certificateBlockType {
+			return nil, fmt.Errorf("%w %q", ErrUnexpectedPEMBlock, block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, ErrNoCertificates
+	}
+
+	return certs, nil
+}
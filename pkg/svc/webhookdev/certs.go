@@ -0,0 +1,167 @@
+package webhookdev
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// CertFile is the serving certificate file name controller-runtime's webhook
+	// server reads from its certificate directory.
+	CertFile = "tls.crt"
+	// KeyFile is the serving key file name controller-runtime's webhook server
+	// reads from its certificate directory.
+	KeyFile = "tls.key"
+	// CACertFile is the file name of the CA certificate written next to the
+	// serving certificate, for clients that call the local server directly.
+	CACertFile = "ca.crt"
+
+	// certValidity is how long the generated CA and serving certificate stay
+	// valid. A session generates fresh ones, so it only has to outlast one.
+	certValidity = 7 * 24 * time.Hour
+	// certBackdate offsets NotBefore into the past to tolerate clock skew
+	// between the host and the API server.
+	certBackdate = time.Minute
+	// serialBits is the bit length of the random certificate serial numbers.
+	serialBits = 128
+	// certDirPerm keeps the certificate directory private to the user.
+	certDirPerm = 0o700
+	// keyPerm is the permission of the serving key.
+	keyPerm = 0o600
+	// certPerm is the permission of the certificates.
+	certPerm = 0o644
+)
+
+// Certificates are the serving certificate of the local webhook server and the
+// CA that signed it, in PEM form.
+type Certificates struct {
+	// CACertPEM is the CA certificate, set as the webhooks' CA bundle.
+	CACertPEM []byte
+	// CertPEM is the serving certificate.
+	CertPEM []byte
+	// KeyPEM is the serving private key.
+	KeyPEM []byte
+}
+
+// GenerateCertificates issues a new CA and a serving certificate signed by it
+// for host, an IP address or DNS name, that is also valid for localhost so the
+// local server can be called directly.
+func GenerateCertificates(host string) (*Certificates, error) {
+	now := time.Now()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate CA key: %w", err)
+	}
+
+	caTemplate, err := newTemplate(now, pkix.Name{CommonName: "KSail webhook dev CA"})
+	if err != nil {
+		return nil, err
+	}
+
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("create CA certificate: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate serving key: %w", err)
+	}
+
+	template, err := newTemplate(now, pkix.Name{CommonName: host})
+	if err != nil {
+		return nil, err
+	}
+
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	template.DNSNames = []string{"localhost"}
+	template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = append(template.IPAddresses, ip)
+	} else {
+		template.DNSNames = append(template.DNSNames, host)
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA certificate: %w", err)
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("create serving certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encode serving key: %w", err)
+	}
+
+	return &Certificates{
+		CACertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		CertPEM:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		KeyPEM:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// Write stores the certificates in dir as CertFile, KeyFile and CACertFile,
+// creating dir when needed, so a controller-runtime webhook server pointed at
+// dir serves them.
+func (c *Certificates) Write(dir string) error {
+	err := os.MkdirAll(dir, certDirPerm)
+	if err != nil {
+		return fmt.Errorf("create certificate directory: %w", err)
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+		perm    os.FileMode
+	}{
+		{name: KeyFile, content: c.KeyPEM, perm: keyPerm},
+		{name: CertFile, content: c.CertPEM, perm: certPerm},
+		{name: CACertFile, content: c.CACertPEM, perm: certPerm},
+	}
+
+	for _, file := range files {
+		err = os.WriteFile(filepath.Join(dir, file.name), file.content, file.perm)
+		if err != nil {
+			return fmt.Errorf("write %s: %w", file.name, err)
+		}
+	}
+
+	return nil
+}
+
+// newTemplate returns a certificate template for subject with a random serial
+// number, valid from now (backdated for clock skew) for certValidity.
+func newTemplate(now time.Time, subject pkix.Name) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+	if err != nil {
+		return nil, fmt.Errorf("generate serial number: %w", err)
+	}
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    now.Add(-certBackdate),
+		NotAfter:     now.Add(certValidity),
+	}, nil
+}
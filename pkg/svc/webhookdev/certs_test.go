@@ -0,0 +1,75 @@
+package webhookdev_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhookdev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCertificatesServesHostSignedByCA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		host string
+	}{
+		{name: "IP address", host: "172.18.0.1"},
+		{name: "DNS name", host: "host.docker.internal"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			certs, err := webhookdev.GenerateCertificates(testCase.host)
+			require.NoError(t, err)
+
+			_, err = tls.X509KeyPair(certs.CertPEM, certs.KeyPEM)
+			require.NoError(t, err)
+
+			roots := x509.NewCertPool()
+			require.True(t, roots.AppendCertsFromPEM(certs.CACertPEM))
+
+			block, _ := pem.Decode(certs.CertPEM)
+			require.NotNil(t, block)
+
+			cert, err := x509.ParseCertificate(block.Bytes)
+			require.NoError(t, err)
+
+			for _, name := range []string{testCase.host, "localhost", "127.0.0.1"} {
+				_, err = cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots})
+				require.NoError(t, err, name)
+			}
+		})
+	}
+}
+
+func TestCertificatesWrite(t *testing.T) {
+	t.Parallel()
+
+	certs, err := webhookdev.GenerateCertificates("172.18.0.1")
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "k8s-webhook-server", "serving-certs")
+	require.NoError(t, certs.Write(dir))
+
+	_, err = tls.LoadX509KeyPair(
+		filepath.Join(dir, webhookdev.CertFile), filepath.Join(dir, webhookdev.KeyFile),
+	)
+	require.NoError(t, err)
+
+	caCert, err := os.ReadFile(filepath.Join(dir, webhookdev.CACertFile))
+	require.NoError(t, err)
+	assert.Equal(t, certs.CACertPEM, caCert)
+
+	info, err := os.Stat(filepath.Join(dir, webhookdev.KeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
@@ -0,0 +1,14 @@
+// Package webhookdev drives `ksail workload dev --webhook`: serving the
+// admission webhooks of a controller from a process on the developer's host, so
+// webhook code can be run under a local debugger instead of in the cluster.
+//
+// [GenerateCertificates] issues a throwaway CA and a serving certificate for the
+// address the cluster reaches the host on — the gateway of the cluster's Docker
+// network — which the local process serves with. [Redirect] then points every
+// webhook of the Validating- and MutatingWebhookConfigurations that calls the
+// controller's webhook Service at that address instead, trusting the CA. It
+// records the original client configs in an annotation on each configuration
+// and suspends cert-manager's CA injection, which would otherwise overwrite
+// the CA bundle; [Restore] puts both back, also after a session that did not
+// exit cleanly.
+package webhookdev
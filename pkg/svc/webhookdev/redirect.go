@@ -0,0 +1,304 @@
+package webhookdev
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// ErrInvalidService is returned when a webhook Service reference is not of the
+// form <namespace>/<name>.
+var ErrInvalidService = errors.New("webhook service must be <namespace>/<name>")
+
+const (
+	// OriginalAnnotation records, on a redirected webhook configuration, the
+	// client configs and cert-manager annotation Restore puts back.
+	OriginalAnnotation = "ksail.io/webhook-dev-original"
+	// CAInjectAnnotation is the cert-manager annotation that makes cainjector
+	// maintain the CA bundle of a webhook configuration. It is suspended while
+	// the webhooks trust the local CA instead.
+	CAInjectAnnotation = "cert-manager.io/inject-ca-from"
+)
+
+// Service is the Service a controller's webhooks are served through.
+type Service struct {
+	Namespace string
+	Name      string
+}
+
+// ParseService parses a <namespace>/<name> Service reference.
+func ParseService(ref string) (Service, error) {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return Service{}, fmt.Errorf("%w: %q", ErrInvalidService, ref)
+	}
+
+	return Service{Namespace: namespace, Name: name}, nil
+}
+
+// String returns the <namespace>/<name> form of the Service.
+func (s Service) String() string {
+	return s.Namespace + "/" + s.Name
+}
+
+// original is what Redirect changed on a webhook configuration, stored as JSON
+// in OriginalAnnotation.
+type original struct {
+	// CAInjectFrom is the value of CAInjectAnnotation, or empty when the
+	// configuration had none.
+	CAInjectFrom string `json:"caInjectFrom,omitempty"`
+	// ClientConfigs are the original client configs of the redirected webhooks,
+	// keyed by webhook name.
+	ClientConfigs map[string]admissionregistrationv1.WebhookClientConfig `json:"clientConfigs"`
+}
+
+// webhookRef is the name and client config of one webhook of a Validating- or
+// MutatingWebhookConfiguration, which do not share a webhook type.
+type webhookRef struct {
+	name         string
+	clientConfig *admissionregistrationv1.WebhookClientConfig
+}
+
+// Redirect points every webhook served by service at baseURL, keeping the path
+// each one calls, and makes it trust caBundle. It returns the number of webhooks
+// redirected. Configurations that are already redirected are left alone; call
+// Restore first to recover from a session that did not exit cleanly.
+func Redirect(
+	ctx context.Context,
+	client kubernetes.Interface,
+	service Service,
+	baseURL string,
+	caBundle []byte,
+) (int, error) {
+	redirect := func(meta *metav1.ObjectMeta, webhooks []webhookRef) (int, bool, error) {
+		return redirectWebhooks(meta, webhooks, service, baseURL, caBundle)
+	}
+
+	validating, err := updateValidating(ctx, client, redirect)
+	if err != nil {
+		return validating, err
+	}
+
+	mutating, err := updateMutating(ctx, client, redirect)
+
+	return validating + mutating, err
+}
+
+// Restore puts back the webhooks Redirect changed, on every configuration that
+// carries OriginalAnnotation, and resumes cert-manager's CA injection. It
+// returns the number of webhooks restored.
+func Restore(ctx context.Context, client kubernetes.Interface) (int, error) {
+	validating, err := updateValidating(ctx, client, restoreWebhooks)
+	if err != nil {
+		return validating, err
+	}
+
+	mutating, err := updateMutating(ctx, client, restoreWebhooks)
+
+	return validating + mutating, err
+}
+
+// redirectWebhooks redirects the webhooks calling service, recording their
+// original client configs on meta. It returns how many it redirected and
+// whether it changed the configuration.
+func redirectWebhooks(
+	meta *metav1.ObjectMeta,
+	webhooks []webhookRef,
+	service Service,
+	baseURL string,
+	caBundle []byte,
+) (int, bool, error) {
+	if _, ok := meta.Annotations[OriginalAnnotation]; ok {
+		return 0, false, nil
+	}
+
+	saved := original{
+		CAInjectFrom:  meta.Annotations[CAInjectAnnotation],
+		ClientConfigs: map[string]admissionregistrationv1.WebhookClientConfig{},
+	}
+
+	for _, webhook := range webhooks {
+		ref := webhook.clientConfig.Service
+		if ref == nil || ref.Namespace != service.Namespace || ref.Name != service.Name {
+			continue
+		}
+
+		path := "/"
+		if ref.Path != nil {
+			path = *ref.Path
+		}
+
+		url := strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+
+		saved.ClientConfigs[webhook.name] = *webhook.clientConfig.DeepCopy()
+		*webhook.clientConfig = admissionregistrationv1.WebhookClientConfig{
+			URL:      &url,
+			CABundle: caBundle,
+		}
+	}
+
+	if len(saved.ClientConfigs) == 0 {
+		return 0, false, nil
+	}
+
+	encoded, err := json.Marshal(saved)
+	if err != nil {
+		return 0, false, fmt.Errorf("encode original client configs: %w", err)
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+
+	delete(meta.Annotations, CAInjectAnnotation)
+	meta.Annotations[OriginalAnnotation] = string(encoded)
+
+	return len(saved.ClientConfigs), true, nil
+}
+
+// restoreWebhooks restores the webhooks recorded on meta by redirectWebhooks.
+// It returns how many it restored and whether it changed the configuration.
+func restoreWebhooks(meta *metav1.ObjectMeta, webhooks []webhookRef) (int, bool, error) {
+	encoded, ok := meta.Annotations[OriginalAnnotation]
+	if !ok {
+		return 0, false, nil
+	}
+
+	var saved original
+
+	err := json.Unmarshal([]byte(encoded), &saved)
+	if err != nil {
+		return 0, false, fmt.Errorf("decode %s of %s: %w", OriginalAnnotation, meta.Name, err)
+	}
+
+	restored := 0
+
+	for _, webhook := range webhooks {
+		clientConfig, ok := saved.ClientConfigs[webhook.name]
+		if !ok {
+			continue
+		}
+
+		*webhook.clientConfig = clientConfig
+		restored++
+	}
+
+	delete(meta.Annotations, OriginalAnnotation)
+
+	if saved.CAInjectFrom != "" {
+		meta.Annotations[CAInjectAnnotation] = saved.CAInjectFrom
+	}
+
+	return restored, true, nil
+}
+
+// mutateFunc changes the webhooks of a configuration. It returns how many
+// webhooks it changed and whether the configuration needs to be stored.
+type mutateFunc func(meta *metav1.ObjectMeta, webhooks []webhookRef) (int, bool, error)
+
+// updateValidating applies mutate to every ValidatingWebhookConfiguration and
+// stores the ones it changed.
+func updateValidating(ctx context.Context, client kubernetes.Interface, mutate mutateFunc) (int, error) {
+	api := client.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+
+	list, err := api.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("list validating webhook configurations: %w", err)
+	}
+
+	total := 0
+
+	for _, item := range list.Items {
+		var changed int
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			live, opErr := api.Get(ctx, item.Name, metav1.GetOptions{})
+			if opErr != nil {
+				return fmt.Errorf("get validating webhook configuration %s: %w", item.Name, opErr)
+			}
+
+			webhooks := make([]webhookRef, 0, len(live.Webhooks))
+			for index := range live.Webhooks {
+				webhooks = append(webhooks, webhookRef{
+					name:         live.Webhooks[index].Name,
+					clientConfig: &live.Webhooks[index].ClientConfig,
+				})
+			}
+
+			var modified bool
+
+			changed, modified, opErr = mutate(&live.ObjectMeta, webhooks)
+			if opErr != nil || !modified {
+				return opErr
+			}
+
+			_, opErr = api.Update(ctx, live, metav1.UpdateOptions{})
+
+			return opErr //nolint:wrapcheck // wrapped below, RetryOnConflict needs the raw conflict
+		})
+		if err != nil {
+			return total, fmt.Errorf("update validating webhook configuration %s: %w", item.Name, err)
+		}
+
+		total += changed
+	}
+
+	return total, nil
+}
+
+// updateMutating applies mutate to every MutatingWebhookConfiguration and
+// stores the ones it changed.
+func updateMutating(ctx context.Context, client kubernetes.Interface, mutate mutateFunc) (int, error) {
+	api := client.AdmissionregistrationV1().MutatingWebhookConfigurations()
+
+	list, err := api.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("list mutating webhook configurations: %w", err)
+	}
+
+	total := 0
+
+	for _, item := range list.Items {
+		var changed int
+
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			live, opErr := api.Get(ctx, item.Name, metav1.GetOptions{})
+			if opErr != nil {
+				return fmt.Errorf("get mutating webhook configuration %s: %w", item.Name, opErr)
+			}
+
+			webhooks := make([]webhookRef, 0, len(live.Webhooks))
+			for index := range live.Webhooks {
+				webhooks = append(webhooks, webhookRef{
+					name:         live.Webhooks[index].Name,
+					clientConfig: &live.Webhooks[index].ClientConfig,
+				})
+			}
+
+			var modified bool
+
+			changed, modified, opErr = mutate(&live.ObjectMeta, webhooks)
+			if opErr != nil || !modified {
+				return opErr
+			}
+
+			_, opErr = api.Update(ctx, live, metav1.UpdateOptions{})
+
+			return opErr //nolint:wrapcheck // wrapped below, RetryOnConflict needs the raw conflict
+		})
+		if err != nil {
+			return total, fmt.Errorf("update mutating webhook configuration %s: %w", item.Name, err)
+		}
+
+		total += changed
+	}
+
+	return total, nil
+}
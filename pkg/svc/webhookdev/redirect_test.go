@@ -0,0 +1,162 @@
+package webhookdev_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhookdev"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	baseURL  = "https://172.18.0.1:9443"
+	injectCA = "guestbook-system/guestbook-serving-cert"
+)
+
+func serviceClientConfig(name, path string) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: "guestbook-system",
+			Name:      name,
+			Path:      ptr.To(path),
+			Port:      ptr.To[int32](443),
+		},
+		CABundle: []byte("cluster-ca"),
+	}
+}
+
+func newWebhookConfigurations() (
+	*admissionregistrationv1.ValidatingWebhookConfiguration,
+	*admissionregistrationv1.MutatingWebhookConfiguration,
+) {
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "guestbook-validating-webhook-configuration",
+			Annotations: map[string]string{webhookdev.CAInjectAnnotation: injectCA},
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name:         "vguestbook.kb.io",
+				ClientConfig: serviceClientConfig("guestbook-webhook-service", "/validate-guestbook"),
+			},
+			{
+				Name:         "vother.example.com",
+				ClientConfig: serviceClientConfig("other-webhook-service", "/validate-other"),
+			},
+		},
+	}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook-mutating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name:         "mguestbook.kb.io",
+				ClientConfig: serviceClientConfig("guestbook-webhook-service", "/mutate-guestbook"),
+			},
+		},
+	}
+
+	return validating, mutating
+}
+
+func TestParseService(t *testing.T) {
+	t.Parallel()
+
+	service, err := webhookdev.ParseService("guestbook-system/guestbook-webhook-service")
+	require.NoError(t, err)
+	assert.Equal(t, webhookdev.Service{
+		Namespace: "guestbook-system",
+		Name:      "guestbook-webhook-service",
+	}, service)
+	assert.Equal(t, "guestbook-system/guestbook-webhook-service", service.String())
+
+	for _, ref := range []string{"guestbook-webhook-service", "/name", "namespace/", "a/b/c"} {
+		_, err = webhookdev.ParseService(ref)
+		require.ErrorIs(t, err, webhookdev.ErrInvalidService, ref)
+	}
+}
+
+//nolint:funlen // Covers the full redirect and restore round trip.
+func TestRedirectAndRestore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	validating, mutating := newWebhookConfigurations()
+	client := k8sfake.NewClientset(validating, mutating)
+	service := webhookdev.Service{Namespace: "guestbook-system", Name: "guestbook-webhook-service"}
+
+	redirected, err := webhookdev.Redirect(ctx, client, service, baseURL, []byte("local-ca"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, redirected)
+
+	liveValidating, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(
+		ctx, validating.Name, metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, admissionregistrationv1.WebhookClientConfig{
+		URL:      ptr.To(baseURL + "/validate-guestbook"),
+		CABundle: []byte("local-ca"),
+	}, liveValidating.Webhooks[0].ClientConfig)
+	assert.Equal(t, validating.Webhooks[1].ClientConfig, liveValidating.Webhooks[1].ClientConfig,
+		"webhooks of other services are left alone")
+	assert.NotContains(t, liveValidating.Annotations, webhookdev.CAInjectAnnotation)
+	assert.Contains(t, liveValidating.Annotations, webhookdev.OriginalAnnotation)
+
+	liveMutating, err := client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(
+		ctx, mutating.Name, metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To(baseURL+"/mutate-guestbook"), liveMutating.Webhooks[0].ClientConfig.URL)
+
+	redirected, err = webhookdev.Redirect(ctx, client, service, "https://10.0.0.1:9443", []byte("other-ca"))
+	require.NoError(t, err)
+	assert.Zero(t, redirected, "redirected configurations are left alone")
+
+	restored, err := webhookdev.Restore(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+
+	liveValidating, err = client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(
+		ctx, validating.Name, metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, validating.Webhooks, liveValidating.Webhooks)
+	assert.Equal(t, validating.Annotations, liveValidating.Annotations)
+
+	liveMutating, err = client.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(
+		ctx, mutating.Name, metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, mutating.Webhooks, liveMutating.Webhooks)
+	assert.Empty(t, liveMutating.Annotations)
+
+	restored, err = webhookdev.Restore(ctx, client)
+	require.NoError(t, err)
+	assert.Zero(t, restored)
+}
+
+func TestRedirectWithoutMatchingWebhooks(t *testing.T) {
+	t.Parallel()
+
+	validating, mutating := newWebhookConfigurations()
+	client := k8sfake.NewClientset(validating, mutating)
+
+	redirected, err := webhookdev.Redirect(
+		context.Background(), client,
+		webhookdev.Service{Namespace: "guestbook-system", Name: "missing"},
+		baseURL, []byte("local-ca"),
+	)
+	require.NoError(t, err)
+	assert.Zero(t, redirected)
+
+	live, err := client.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(
+		context.Background(), validating.Name, metav1.GetOptions{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, validating.Annotations, live.Annotations)
+}
@@ -0,0 +1,18 @@
+// Package webhooktunnel exposes the GitOps engine's in-cluster webhook endpoint
+// on a public URL, so a push to the tracked Git repository triggers immediate
+// reconciliation of a local cluster instead of waiting for the next poll.
+//
+// It has two halves:
+//
+//   - The receiver side ([EnsureReceiver]) prepares the engine to accept
+//     signed GitHub push events: a Flux notification Receiver for the
+//     flux-system GitRepository, or the webhook secret in argocd-secret for
+//     Argo CD's built-in /api/webhook endpoint. Both are idempotent and reuse
+//     an existing secret, so re-running keeps an already-registered webhook
+//     valid.
+//   - The tunnel side ([Tunnel]) runs a cloudflared quick tunnel as an
+//     in-cluster Deployment pointed at the receiver Service and reads the
+//     public trycloudflare.com URL from its logs. Running cloudflared inside
+//     the cluster keeps KSail free of a locally installed tunnel binary, in
+//     line with its in-process, no-shelled-binaries rule.
+package webhooktunnel
@@ -0,0 +1,17 @@
+package webhooktunnel
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// SetReadPodLogsForTest replaces the pod log reader and returns a restore function.
+func SetReadPodLogsForTest(
+	fn func(ctx context.Context, client kubernetes.Interface, namespace, pod string) (string, error),
+) func() {
+	original := readPodLogs
+	readPodLogs = fn
+
+	return func() { readPodLogs = original }
+}
@@ -0,0 +1,291 @@
+package webhooktunnel
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/client/argocd"
+	fluxclient "github.com/devantler-tech/ksail/v7/pkg/client/flux"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrUnsupportedEngine is returned when the GitOps engine has no webhook receiver.
+var ErrUnsupportedEngine = errors.New("webhook receiver requires the Flux or ArgoCD GitOps engine")
+
+const (
+	// ReceiverName is the name of the Flux Receiver KSail manages.
+	ReceiverName = "ksail-webhook"
+	// TokenSecretName is the name of the Secret holding the Flux Receiver token.
+	//nolint:gosec // not credentials, just a secret name constant
+	TokenSecretName = "ksail-webhook-token"
+
+	// fluxSourceName is the GitRepository the FluxInstance sync creates.
+	fluxSourceName = "flux-system"
+	// fluxReceiverServiceURL is the notification-controller webhook Service.
+	fluxReceiverServiceURL = "http://webhook-receiver." + fluxclient.DefaultNamespace + ".svc.cluster.local"
+
+	// argoCDSecretName is the Secret Argo CD reads its webhook secrets from.
+	//nolint:gosec // not credentials, just a secret name constant
+	argoCDSecretName = "argocd-secret"
+	// argoCDWebhookSecretKey is the argocd-secret key for GitHub webhook signatures.
+	//nolint:gosec // not credentials, just a key name constant
+	argoCDWebhookSecretKey = "webhook.github.secret"
+	// argoCDServerURL is the argocd-server Service, which serves /api/webhook over
+	// a self-signed certificate by default.
+	argoCDServerURL   = "https://argocd-server." + argocd.DefaultNamespace + ".svc.cluster.local"
+	argoCDWebhookPath = "/api/webhook"
+
+	secretBytes = 32
+)
+
+// Target describes the in-cluster webhook endpoint a tunnel forwards to and the
+// secret the Git provider must sign payloads with.
+type Target struct {
+	// Namespace is where the engine, and therefore the tunnel, runs.
+	Namespace string
+	// ServiceURL is the in-cluster URL of the receiver Service.
+	ServiceURL string
+	// Path is the webhook path appended to the public URL.
+	Path string
+	// Secret is the shared secret used to sign webhook payloads.
+	Secret string
+	// NoTLSVerify is set when ServiceURL serves a self-signed certificate.
+	NoTLSVerify bool
+}
+
+// PayloadURL joins the tunnel's public URL with the webhook path.
+func (t Target) PayloadURL(publicURL string) string {
+	return strings.TrimSuffix(publicURL, "/") + t.Path
+}
+
+// ReceiverGVR returns the GroupVersionResource for Flux Receivers.
+func ReceiverGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    "notification.toolkit.fluxcd.io",
+		Version:  "v1",
+		Resource: "receivers",
+	}
+}
+
+// EnsureReceiver prepares the GitOps engine to accept GitHub push webhooks and
+// returns the endpoint the tunnel should expose.
+func EnsureReceiver(
+	ctx context.Context,
+	engine v1alpha1.GitOpsEngine,
+	client kubernetes.Interface,
+	dyn dynamic.Interface,
+) (Target, error) {
+	switch engine {
+	case v1alpha1.GitOpsEngineFlux:
+		return ensureFluxReceiver(ctx, client, dyn)
+	case v1alpha1.GitOpsEngineArgoCD:
+		return ensureArgoCDWebhook(ctx, client)
+	case v1alpha1.GitOpsEngineNone:
+	}
+
+	return Target{}, fmt.Errorf("%w: got %q", ErrUnsupportedEngine, engine)
+}
+
+// ensureFluxReceiver creates the Receiver token Secret (keeping an existing
+// token) and a github-type Receiver that reconciles the flux-system
+// GitRepository on push.
+func ensureFluxReceiver(
+	ctx context.Context,
+	client kubernetes.Interface,
+	dyn dynamic.Interface,
+) (Target, error) {
+	namespace := fluxclient.DefaultNamespace
+
+	token, err := ensureTokenSecret(ctx, client, namespace)
+	if err != nil {
+		return Target{}, err
+	}
+
+	err = upsertReceiver(ctx, dyn, buildReceiver(namespace))
+	if err != nil {
+		return Target{}, err
+	}
+
+	return Target{
+		Namespace:  namespace,
+		ServiceURL: fluxReceiverServiceURL,
+		Path:       ReceiverWebhookPath(token, ReceiverName, namespace),
+		Secret:     token,
+	}, nil
+}
+
+// ReceiverWebhookPath returns the path notification-controller serves a
+// Receiver on: /hook/ followed by the SHA-256 of token, name, and namespace.
+// Computing it avoids waiting for the Receiver to report status.webhookPath.
+func ReceiverWebhookPath(token, name, namespace string) string {
+	digest := sha256.Sum256([]byte(token + name + namespace))
+
+	return "/hook/" + hex.EncodeToString(digest[:])
+}
+
+func ensureTokenSecret(ctx context.Context, client kubernetes.Interface, namespace string) (string, error) {
+	secrets := client.CoreV1().Secrets(namespace)
+
+	existing, err := secrets.Get(ctx, TokenSecretName, metav1.GetOptions{})
+
+	found := err == nil
+	if found && len(existing.Data["token"]) > 0 {
+		return string(existing.Data["token"]), nil
+	}
+
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("get webhook token secret: %w", err)
+	}
+
+	token, err := generateSecret()
+	if err != nil {
+		return "", err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TokenSecretName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "ksail",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{"token": []byte(token)},
+	}
+
+	if found {
+		secret.ResourceVersion = existing.ResourceVersion
+
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	} else {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("write webhook token secret: %w", err)
+	}
+
+	return token, nil
+}
+
+func buildReceiver(namespace string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "notification.toolkit.fluxcd.io/v1",
+			"kind":       "Receiver",
+			"metadata": map[string]any{
+				"name":      ReceiverName,
+				"namespace": namespace,
+				"labels": map[string]any{
+					"app.kubernetes.io/managed-by": "ksail",
+				},
+			},
+			"spec": map[string]any{
+				"type":   "github",
+				"events": []any{"ping", "push"},
+				"secretRef": map[string]any{
+					"name": TokenSecretName,
+				},
+				"resources": []any{
+					map[string]any{
+						"apiVersion": "source.toolkit.fluxcd.io/v1",
+						"kind":       "GitRepository",
+						"name":       fluxSourceName,
+					},
+				},
+			},
+		},
+	}
+}
+
+func upsertReceiver(ctx context.Context, dyn dynamic.Interface, desired *unstructured.Unstructured) error {
+	receivers := dyn.Resource(ReceiverGVR()).Namespace(desired.GetNamespace())
+
+	existing, err := receivers.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			_, err = receivers.Create(ctx, desired, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("create Flux Receiver: %w", err)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("get Flux Receiver: %w", err)
+	}
+
+	desired.SetResourceVersion(existing.GetResourceVersion())
+
+	_, err = receivers.Update(ctx, desired, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update Flux Receiver: %w", err)
+	}
+
+	return nil
+}
+
+// ensureArgoCDWebhook sets the GitHub webhook secret in argocd-secret (keeping
+// an existing one) so argocd-server verifies payloads on /api/webhook.
+func ensureArgoCDWebhook(ctx context.Context, client kubernetes.Interface) (Target, error) {
+	namespace := argocd.DefaultNamespace
+	secrets := client.CoreV1().Secrets(namespace)
+
+	existing, err := secrets.Get(ctx, argoCDSecretName, metav1.GetOptions{})
+	if err != nil {
+		return Target{}, fmt.Errorf("get %s: %w", argoCDSecretName, err)
+	}
+
+	secret := string(existing.Data[argoCDWebhookSecretKey])
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return Target{}, err
+		}
+
+		patch := fmt.Sprintf(
+			`{"data":{%q:%q}}`,
+			argoCDWebhookSecretKey,
+			base64.StdEncoding.EncodeToString([]byte(secret)),
+		)
+
+		_, err = secrets.Patch(ctx, argoCDSecretName, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		if err != nil {
+			return Target{}, fmt.Errorf("patch %s: %w", argoCDSecretName, err)
+		}
+	}
+
+	return Target{
+		Namespace:   namespace,
+		ServiceURL:  argoCDServerURL,
+		Path:        argoCDWebhookPath,
+		Secret:      secret,
+		NoTLSVerify: true,
+	}, nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, secretBytes)
+
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
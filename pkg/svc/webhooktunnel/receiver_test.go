@@ -0,0 +1,107 @@
+package webhooktunnel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/apis/cluster/v1alpha1"
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhooktunnel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newDynamicClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{webhooktunnel.ReceiverGVR(): "ReceiverList"},
+	)
+}
+
+func TestEnsureReceiver_FluxCreatesReceiverAndKeepsToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := k8sfake.NewClientset()
+	dyn := newDynamicClient()
+
+	first, err := webhooktunnel.EnsureReceiver(ctx, v1alpha1.GitOpsEngineFlux, client, dyn)
+	require.NoError(t, err)
+
+	assert.Equal(t, "flux-system", first.Namespace)
+	assert.Equal(t, "http://webhook-receiver.flux-system.svc.cluster.local", first.ServiceURL)
+	assert.Equal(t,
+		webhooktunnel.ReceiverWebhookPath(first.Secret, webhooktunnel.ReceiverName, "flux-system"),
+		first.Path,
+	)
+	assert.False(t, first.NoTLSVerify)
+
+	receiver, err := dyn.Resource(webhooktunnel.ReceiverGVR()).
+		Namespace("flux-system").
+		Get(ctx, webhooktunnel.ReceiverName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	receiverType, _, _ := unstructured.NestedString(receiver.Object, "spec", "type")
+	assert.Equal(t, "github", receiverType)
+
+	resources, _, _ := unstructured.NestedSlice(receiver.Object, "spec", "resources")
+	require.Len(t, resources, 1)
+	assert.Equal(t, "GitRepository", resources[0].(map[string]any)["kind"])
+
+	second, err := webhooktunnel.EnsureReceiver(ctx, v1alpha1.GitOpsEngineFlux, client, dyn)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "re-running must keep the registered secret valid")
+}
+
+func TestEnsureReceiver_ArgoCDSetsWebhookSecretOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := k8sfake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-secret", Namespace: "argocd"},
+		Data:       map[string][]byte{"server.secretkey": []byte("x")},
+	})
+
+	target, err := webhooktunnel.EnsureReceiver(ctx, v1alpha1.GitOpsEngineArgoCD, client, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/webhook", target.Path)
+	assert.True(t, target.NoTLSVerify)
+	assert.NotEmpty(t, target.Secret)
+
+	secret, err := client.CoreV1().Secrets("argocd").Get(ctx, "argocd-secret", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, target.Secret, string(secret.Data["webhook.github.secret"]))
+	assert.Equal(t, "x", string(secret.Data["server.secretkey"]))
+
+	again, err := webhooktunnel.EnsureReceiver(ctx, v1alpha1.GitOpsEngineArgoCD, client, nil)
+	require.NoError(t, err)
+	assert.Equal(t, target.Secret, again.Secret)
+}
+
+func TestEnsureReceiver_NoEngine(t *testing.T) {
+	t.Parallel()
+
+	_, err := webhooktunnel.EnsureReceiver(
+		context.Background(), v1alpha1.GitOpsEngineNone, k8sfake.NewClientset(), nil,
+	)
+
+	require.ErrorIs(t, err, webhooktunnel.ErrUnsupportedEngine)
+}
+
+func TestTargetPayloadURL(t *testing.T) {
+	t.Parallel()
+
+	target := webhooktunnel.Target{Path: "/api/webhook"}
+
+	assert.Equal(t,
+		"https://demo.trycloudflare.com/api/webhook",
+		target.PayloadURL("https://demo.trycloudflare.com/"),
+	)
+}
@@ -0,0 +1,189 @@
+package webhooktunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrPublicURLNotFound is returned when cloudflared does not report a public
+// URL before the wait timeout expires.
+var ErrPublicURLNotFound = errors.New("tunnel did not report a public URL")
+
+const (
+	// DefaultImage is the cloudflared image the tunnel Deployment runs.
+	DefaultImage = "docker.io/cloudflare/cloudflared:2025.9.1"
+	// DeploymentName is the name of the tunnel Deployment.
+	DeploymentName = "ksail-webhook-tunnel"
+
+	tunnelAppLabel   = "app.kubernetes.io/name"
+	publicURLPoll    = 2 * time.Second
+	tunnelLogTailLen = int64(200)
+)
+
+// publicURLPattern matches the quick-tunnel hostname cloudflared prints once
+// the tunnel is registered.
+var publicURLPattern = regexp.MustCompile(`https://[a-z0-9-]+\.trycloudflare\.com`)
+
+// readPodLogs returns the recent logs of a pod. It is a package-level seam
+// because the fake clientset cannot serve real log streams.
+//
+//nolint:gochecknoglobals // Test seam: lets tests supply cloudflared log output without a live cluster.
+var readPodLogs = func(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, pod string,
+) (string, error) {
+	tail := tunnelLogTailLen
+
+	raw, err := client.CoreV1().Pods(namespace).
+		GetLogs(pod, &corev1.PodLogOptions{TailLines: &tail}).
+		DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("read logs of pod %s: %w", pod, err)
+	}
+
+	return string(raw), nil
+}
+
+// Tunnel manages a cloudflared quick tunnel running as a Deployment next to
+// the GitOps engine.
+type Tunnel struct {
+	client    kubernetes.Interface
+	namespace string
+	image     string
+}
+
+// NewTunnel creates a Tunnel that runs image in namespace. An empty image uses
+// DefaultImage.
+func NewTunnel(client kubernetes.Interface, namespace, image string) *Tunnel {
+	if image == "" {
+		image = DefaultImage
+	}
+
+	return &Tunnel{client: client, namespace: namespace, image: image}
+}
+
+// Start creates (or replaces) the tunnel Deployment forwarding to the target's
+// Service. Replacing it restarts cloudflared, which yields a fresh public URL.
+func (t *Tunnel) Start(ctx context.Context, target Target) error {
+	deployments := t.client.AppsV1().Deployments(t.namespace)
+
+	err := deployments.Delete(ctx, DeploymentName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete previous tunnel deployment: %w", err)
+	}
+
+	_, err = deployments.Create(ctx, t.buildDeployment(target), metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("create tunnel deployment: %w", err)
+	}
+
+	return nil
+}
+
+// PublicURL waits until a tunnel pod logs its trycloudflare.com URL.
+func (t *Tunnel) PublicURL(ctx context.Context, timeout time.Duration) (string, error) {
+	var publicURL string
+
+	err := wait.PollUntilContextTimeout(ctx, publicURLPoll, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			pods, err := t.client.CoreV1().Pods(t.namespace).List(ctx, metav1.ListOptions{
+				LabelSelector: tunnelAppLabel + "=" + DeploymentName,
+			})
+			if err != nil {
+				return false, nil //nolint:nilerr // transient API errors are retried until timeout
+			}
+
+			for _, pod := range pods.Items {
+				if pod.Status.Phase != corev1.PodRunning {
+					continue
+				}
+
+				logs, err := readPodLogs(ctx, t.client, t.namespace, pod.Name)
+				if err != nil {
+					continue
+				}
+
+				if match := publicURLPattern.FindString(logs); match != "" {
+					publicURL = match
+
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	if err != nil {
+		return "", fmt.Errorf("%w within %s: %w", ErrPublicURLNotFound, timeout, err)
+	}
+
+	return publicURL, nil
+}
+
+// Stop deletes the tunnel Deployment, tolerating one that is already gone.
+func (t *Tunnel) Stop(ctx context.Context) error {
+	err := t.client.AppsV1().Deployments(t.namespace).
+		Delete(ctx, DeploymentName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete tunnel deployment: %w", err)
+	}
+
+	return nil
+}
+
+func (t *Tunnel) buildDeployment(target Target) *appsv1.Deployment {
+	labels := map[string]string{
+		tunnelAppLabel:                 DeploymentName,
+		"app.kubernetes.io/managed-by": "ksail",
+	}
+
+	args := []string{"tunnel", "--no-autoupdate", "--url", target.ServiceURL}
+	if target.NoTLSVerify {
+		args = append(args, "--no-tls-verify")
+	}
+
+	replicas := int32(1)
+	nonRoot := true
+	noEscalation := false
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeploymentName,
+			Namespace: t.namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{tunnelAppLabel: DeploymentName},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "cloudflared",
+						Image: t.image,
+						Args:  args,
+						SecurityContext: &corev1.SecurityContext{
+							RunAsNonRoot:             &nonRoot,
+							AllowPrivilegeEscalation: &noEscalation,
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"ALL"},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
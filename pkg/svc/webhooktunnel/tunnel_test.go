@@ -0,0 +1,94 @@
+package webhooktunnel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/webhooktunnel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func runningTunnelPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ksail-webhook-tunnel-abc",
+			Namespace: "flux-system",
+			Labels:    map[string]string{"app.kubernetes.io/name": webhooktunnel.DeploymentName},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestTunnelStart_CreatesCloudflaredDeployment(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := k8sfake.NewClientset()
+	tunnel := webhooktunnel.NewTunnel(client, "argocd", "")
+
+	target := webhooktunnel.Target{
+		ServiceURL:  "https://argocd-server.argocd.svc.cluster.local",
+		NoTLSVerify: true,
+	}
+
+	require.NoError(t, tunnel.Start(ctx, target))
+	require.NoError(t, tunnel.Start(ctx, target), "a second start replaces the deployment")
+
+	deployment, err := client.AppsV1().Deployments("argocd").
+		Get(ctx, webhooktunnel.DeploymentName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, webhooktunnel.DefaultImage, container.Image)
+	assert.Equal(t, []string{
+		"tunnel", "--no-autoupdate",
+		"--url", "https://argocd-server.argocd.svc.cluster.local",
+		"--no-tls-verify",
+	}, container.Args)
+
+	require.NoError(t, tunnel.Stop(ctx))
+	require.NoError(t, tunnel.Stop(ctx), "stop tolerates a missing deployment")
+
+	_, err = client.AppsV1().Deployments("argocd").
+		Get(ctx, webhooktunnel.DeploymentName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+//nolint:paralleltest // mutates package-level readPodLogs
+func TestTunnelPublicURL_ParsesCloudflaredLogs(t *testing.T) {
+	restore := webhooktunnel.SetReadPodLogsForTest(
+		func(context.Context, kubernetes.Interface, string, string) (string, error) {
+			return "INF Requesting new quick Tunnel on trycloudflare.com...\n" +
+				"INF |  https://fancy-demo-words.trycloudflare.com  |\n", nil
+		},
+	)
+	defer restore()
+
+	tunnel := webhooktunnel.NewTunnel(k8sfake.NewClientset(runningTunnelPod()), "flux-system", "")
+
+	publicURL, err := tunnel.PublicURL(context.Background(), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "https://fancy-demo-words.trycloudflare.com", publicURL)
+}
+
+//nolint:paralleltest // mutates package-level readPodLogs
+func TestTunnelPublicURL_TimesOut(t *testing.T) {
+	restore := webhooktunnel.SetReadPodLogsForTest(
+		func(context.Context, kubernetes.Interface, string, string) (string, error) {
+			return "INF Starting tunnel\n", nil
+		},
+	)
+	defer restore()
+
+	tunnel := webhooktunnel.NewTunnel(k8sfake.NewClientset(runningTunnelPod()), "flux-system", "")
+
+	_, err := tunnel.PublicURL(context.Background(), 50*time.Millisecond)
+	require.ErrorIs(t, err, webhooktunnel.ErrPublicURLNotFound)
+}
@@ -0,0 +1,84 @@
+package workloadwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultServeAddress is the address the webhook listener binds to when
+// `--serve` is given without a value. It is loopback-only so the unauthenticated
+// endpoint is not exposed beyond the local machine by default.
+const DefaultServeAddress = "127.0.0.1:9292"
+
+// ReconcilePath is the endpoint that accepts reconcile requests.
+const ReconcilePath = "/reconcile"
+
+const (
+	serveReadHeaderTimeout = 10 * time.Second
+	serveShutdownTimeout   = 5 * time.Second
+)
+
+// NewReconcileHandler returns the webhook listener's routes: POST
+// ReconcilePath enqueues a reconcile request on requests and answers 202
+// Accepted, and GET /healthz reports liveness. The send never blocks: while a
+// request is already pending, further requests are coalesced into it, so a
+// burst of pushes triggers a single reconciliation.
+func NewReconcileHandler(requests chan<- struct{}) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST "+ReconcilePath, func(writer http.ResponseWriter, _ *http.Request) {
+		select {
+		case requests <- struct{}{}:
+		default:
+		}
+
+		writer.WriteHeader(http.StatusAccepted)
+		_, _ = writer.Write([]byte("reconcile requested\n"))
+	})
+
+	mux.HandleFunc("GET /healthz", func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte("ok\n"))
+	})
+
+	return mux
+}
+
+// Listen binds the webhook listener to addr.
+func Listen(ctx context.Context, addr string) (net.Listener, error) {
+	var listenConfig net.ListenConfig
+
+	listener, err := listenConfig.Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	return listener, nil
+}
+
+// Serve runs handler on listener until ctx is cancelled.
+func Serve(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	err := server.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("webhook listener: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package workloadwatch_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/svc/workloadwatch"
+)
+
+func TestReconcileHandlerCoalescesRequests(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan struct{}, 1)
+	handler := workloadwatch.NewReconcileHandler(requests)
+
+	for range 3 {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequestWithContext(
+			context.Background(), http.MethodPost, workloadwatch.ReconcilePath, nil,
+		))
+
+		if recorder.Code != http.StatusAccepted {
+			t.Fatalf("expected status %d, got %d", http.StatusAccepted, recorder.Code)
+		}
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 pending request, got %d", len(requests))
+	}
+}
+
+func TestReconcileHandlerRejectsGet(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan struct{}, 1)
+	recorder := httptest.NewRecorder()
+
+	workloadwatch.NewReconcileHandler(requests).ServeHTTP(recorder, httptest.NewRequestWithContext(
+		context.Background(), http.MethodGet, workloadwatch.ReconcilePath, nil,
+	))
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected status %d, got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+
+	if len(requests) != 0 {
+		t.Fatal("expected no reconcile request for GET")
+	}
+}
+
+func TestServeStopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	listener, err := workloadwatch.Listen(ctx, "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- workloadwatch.Serve(ctx, listener, workloadwatch.NewReconcileHandler(make(chan struct{}, 1)))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+listener.Addr().String()+"/healthz", nil)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("healthz: %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	cancel()
+
+	err = <-done
+	if err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+}
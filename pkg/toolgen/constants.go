@@ -20,6 +20,8 @@ const (
 	flagTypeStringSlice = "stringSlice"
 	flagTypeStringArray = "stringArray"
 	flagTypeIntSlice    = "intSlice"
+	dryRunFlagName      = "dry-run"
+	dryRunServer        = "server"
 )
 
 // Default value strings to skip when adding defaults to schema.
@@ -1,6 +1,9 @@
 package toolgen
 
-import "slices"
+import (
+	"maps"
+	"slices"
+)
 
 // ToolDefinition is an SDK-agnostic representation of a tool generated from a Cobra command.
 // It contains all the metadata needed to create SDK-specific tools (Copilot, MCP, etc.).
@@ -49,6 +52,12 @@ type ToolDefinition struct {
 	// tools; consolidated tools resolve confirm flags per-subcommand via
 	// Subcommands[*].Flags[*].ConfirmFlag.
 	ConfirmFlags []string
+
+	// DryRunFlagType is the JSON schema type of the tool's --dry-run flag
+	// ("boolean", or "string" for kubectl's none|client|server flag), or ""
+	// when the tool has none. Only populated for non-consolidated tools;
+	// consolidated tools resolve it per-subcommand via Subcommands[*].Flags.
+	DryRunFlagType string
 }
 
 // ConfirmFlagsFor returns the sorted names of confirmation-skip flags
@@ -85,6 +94,47 @@ func (t ToolDefinition) ConfirmFlagsFor(params map[string]any) []string {
 	return confirmFlags
 }
 
+// DryRunParams returns a copy of params with the invocation's --dry-run flag
+// set, so the command previews its changes instead of applying them. Boolean
+// flags are set to true; kubectl's string flag is set to "server", which sends
+// the request through admission and validation without persisting it. For
+// consolidated tools the flag is resolved per-subcommand. Returns false when
+// the invoked command has no dry-run flag.
+func (t ToolDefinition) DryRunParams(params map[string]any) (map[string]any, bool) {
+	flagType := t.DryRunFlagType
+
+	if t.IsConsolidated {
+		flagType = ""
+
+		subcommandName, _ := params[t.SubcommandParam].(string)
+		if subcommandDef, exists := t.Subcommands[subcommandName]; exists {
+			if flagDef, hasFlag := subcommandDef.Flags[dryRunFlagName]; hasFlag {
+				flagType = flagDef.Type
+			}
+		}
+	}
+
+	var value any
+
+	switch flagType {
+	case jsonSchemaTypeBoolean:
+		value = true
+	case jsonSchemaTypeString:
+		value = dryRunServer
+	default:
+		return nil, false
+	}
+
+	dryRunParams := maps.Clone(params)
+	if dryRunParams == nil {
+		dryRunParams = make(map[string]any, 1)
+	}
+
+	dryRunParams[dryRunFlagName] = value
+
+	return dryRunParams, true
+}
+
 // ToolAnnotationHints contains SDK-agnostic behavioral hints for a tool.
 // These map to MCP ToolAnnotations and inform client behavior.
 // KSail always sets all fields explicitly so there is no ambiguity.
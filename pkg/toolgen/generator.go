@@ -179,6 +179,7 @@ func commandToToolDefinition(cmd *cobra.Command, excludeFlags []string) ToolDefi
 		RequiresPermission: requiresPermission,
 		Annotations:        buildAnnotationHints(requiresPermission),
 		ConfirmFlags:       confirmFlagNames(cmd),
+		DryRunFlagType:     dryRunFlagType(cmd),
 	}
 }
 
@@ -115,6 +115,8 @@ func DefaultOptions() ToolOptions {
 			"ksail",            // Root command, not a tool
 		},
 		ExcludeFlags: []string{
+			// IDE progress protocol; an AI tool call always wants the human-readable output.
+			"client-mode",
 			// kubectl global config flags rarely used by LLMs.
 			// These are still accepted at runtime — just hidden from the AI schema.
 			"cache-dir",
@@ -328,6 +328,17 @@ func confirmFlagNames(cmd *cobra.Command) []string {
 	return names
 }
 
+// dryRunFlagType returns the JSON schema type of cmd's --dry-run flag, or ""
+// when it has none.
+func dryRunFlagType(cmd *cobra.Command) string {
+	flag := cmd.Flags().Lookup(dryRunFlagName)
+	if flag == nil {
+		return ""
+	}
+
+	return mapFlagTypeToJSONType(flag.Value.Type())
+}
+
 // buildConsolidatedParameterSchema builds a dynamic JSON schema for consolidated tools.
 // excludeFlags lists flags to omit from the schema (they still work at runtime).
 func buildConsolidatedParameterSchema(
@@ -122,6 +122,61 @@ func TestGenerateTools_ConfirmFlagAnnotation_NonConsolidated(t *testing.T) {
 	assert.Equal(t, []string{safetyForceFlag}, tools[0].ConfirmFlagsFor(map[string]any{}))
 }
 
+// TestDryRunParams verifies that sandboxed invocations get the --dry-run flag
+// of the invoked command: true for boolean flags, "server" for kubectl's
+// string flag, and no rewrite when the command cannot dry-run.
+func TestDryRunParams(t *testing.T) {
+	t.Parallel()
+
+	root := &cobra.Command{Use: safetyRootUse}
+	parent := &cobra.Command{
+		Use: "workload",
+		Annotations: map[string]string{
+			annotations.AnnotationConsolidate: safetySubcmdParam,
+			annotations.AnnotationPermission:  safetyWritePerm,
+		},
+	}
+
+	applyCmd := &cobra.Command{Use: "apply", Run: func(_ *cobra.Command, _ []string) {}}
+	applyCmd.Flags().String("dry-run", "none", "Must be none, server, or client")
+
+	execCmd := &cobra.Command{Use: "exec", Run: func(_ *cobra.Command, _ []string) {}}
+
+	updateCmd := &cobra.Command{
+		Use:         "update",
+		Run:         func(_ *cobra.Command, _ []string) {},
+		Annotations: map[string]string{annotations.AnnotationPermission: safetyWritePerm},
+	}
+	updateCmd.Flags().Bool("dry-run", false, "Preview changes")
+
+	parent.AddCommand(applyCmd, execCmd)
+	root.AddCommand(parent, updateCmd)
+
+	tools := toolgen.GenerateTools(root, toolgen.ToolOptions{})
+	require.Len(t, tools, 2)
+
+	byName := map[string]toolgen.ToolDefinition{}
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+
+	workload := byName["workload"]
+
+	params := map[string]any{safetySubcmdParam: "apply", "filename": "k8s"}
+	dryRun, ok := workload.DryRunParams(params)
+	require.True(t, ok)
+	assert.Equal(t, "server", dryRun["dry-run"])
+	assert.Equal(t, "k8s", dryRun["filename"])
+	assert.NotContains(t, params, "dry-run", "the caller's params must not be modified")
+
+	_, ok = workload.DryRunParams(map[string]any{safetySubcmdParam: "exec"})
+	assert.False(t, ok)
+
+	dryRun, ok = byName["update"].DryRunParams(nil)
+	require.True(t, ok)
+	assert.Equal(t, true, dryRun["dry-run"])
+}
+
 // TestNormalizeHomePath verifies home-dir prefixes in schema defaults are
 // rewritten to the portable "~" form.
 func TestNormalizeHomePath(t *testing.T) {
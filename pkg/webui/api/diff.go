@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DiffResult reports how applying one manifest document would change the live cluster.
+type DiffResult struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Status is "created" when the object does not exist yet, "changed" when applying it would modify
+	// the live object, "unchanged" when it would not, or "error".
+	Status string `json:"status"`
+	// Diff is the unified diff between the live object and the result of applying the document. Empty
+	// when Status is "unchanged" or "error".
+	Diff string `json:"diff,omitempty"`
+	// Error carries the failure detail when Status is "error".
+	Error string `json:"error,omitempty"`
+}
+
+// DiffService is an optional interface a ClusterService may implement to preview how raw Kubernetes
+// manifests (multi-document YAML) would change a target cluster, without persisting anything. When
+// the serving ClusterService implements it, the server registers
+// POST /api/v1/clusters/{ns}/{name}/diff and advertises capabilities.manifestDiff=true. The POST only
+// carries the manifests, so the read-only guard lets it through. Each document is diffed
+// independently and reported separately, like ApplyService.
+type DiffService interface {
+	DiffManifests(
+		ctx context.Context,
+		namespace, name string,
+		manifests []byte,
+	) ([]DiffResult, error)
+}
+
+// diffPathSuffix ends the manifest diff route; readOnlyGuard uses it to exempt the non-mutating POST.
+const diffPathSuffix = "/diff"
+
+// diffPathSegments is the segment count of /api/v1/clusters/{namespace}/{name}/diff once the leading
+// slash is trimmed.
+const diffPathSegments = 6
+
+// isDiffRequest reports whether request targets the manifest diff route. It matches the exact route
+// shape rather than the suffix alone, so a mutating route whose last segment happens to be "diff"
+// (e.g. a plugin named "diff") is not exempted from the read-only guard.
+func isDiffRequest(request *http.Request) bool {
+	if request.Method != http.MethodPost ||
+		!strings.HasPrefix(request.URL.Path, "/api/v1/clusters/") ||
+		!strings.HasSuffix(request.URL.Path, diffPathSuffix) {
+		return false
+	}
+
+	return len(strings.Split(strings.TrimPrefix(request.URL.Path, "/"), "/")) == diffPathSegments
+}
+
+func (s *Server) handleDiff(writer http.ResponseWriter, request *http.Request) {
+	svc, ok := s.Service.(DiffService)
+	if !ok {
+		writeClientError(writer, ErrNotSupported)
+
+		return
+	}
+
+	limited := http.MaxBytesReader(writer, request.Body, maxRequestBodyBytes)
+
+	manifests, err := io.ReadAll(limited)
+	if err != nil {
+		writeDecodeError(writer, fmt.Errorf("read manifests: %w", err))
+
+		return
+	}
+
+	results, err := svc.DiffManifests(
+		request.Context(),
+		request.PathValue("namespace"),
+		request.PathValue("name"),
+		manifests,
+	)
+	if err != nil {
+		writeClientError(writer, err)
+
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, map[string]any{"results": results})
+}
@@ -0,0 +1,141 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/devantler-tech/ksail/v7/pkg/webui/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// diffStub implements api.DiffService, recording what it received.
+type diffStub struct {
+	stubClusterService
+
+	gotManifests string
+}
+
+func (d *diffStub) DiffManifests(
+	_ context.Context, _, _ string, manifests []byte,
+) ([]api.DiffResult, error) {
+	d.gotManifests = string(manifests)
+
+	return []api.DiffResult{
+		{Kind: "ConfigMap", Name: "cm1", Status: "changed", Diff: "-a\n+b\n"},
+	}, nil
+}
+
+func TestConfigReportsManifestDiff(t *testing.T) {
+	t.Parallel()
+
+	server := &api.Server{Service: &diffStub{}}
+
+	recorder := doRequest(server.Handler(), http.MethodGet, "/api/v1/config", "")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"manifestDiff":true`)
+}
+
+func TestDiffEndpoint(t *testing.T) {
+	t.Parallel()
+
+	stub := &diffStub{}
+	server := &api.Server{Service: stub}
+
+	recorder := doRequest(
+		server.Handler(),
+		http.MethodPost,
+		"/api/v1/clusters/default/c1/diff",
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\n",
+	)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"status":"changed"`)
+	assert.Contains(t, stub.gotManifests, "kind: ConfigMap")
+}
+
+func TestDiffAllowedWhenReadOnly(t *testing.T) {
+	t.Parallel()
+
+	stub := &diffStub{}
+	server := &api.Server{Service: stub, ReadOnly: true}
+
+	recorder := doRequest(
+		server.Handler(),
+		http.MethodPost,
+		"/api/v1/clusters/default/c1/diff",
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\n",
+	)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, stub.gotManifests, "kind: ConfigMap")
+}
+
+func TestReadOnlyStillBlocksOtherRoutesEndingInDiff(t *testing.T) {
+	t.Parallel()
+
+	server := &api.Server{Service: &diffStub{}, ReadOnly: true}
+
+	recorder := doRequest(server.Handler(), http.MethodDelete, "/api/v1/plugins/diff", "")
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestTokenGuardsAPI(t *testing.T) {
+	t.Parallel()
+
+	server := &api.Server{Service: stubClusterService{}, Token: "s3cret"}
+	handler := server.Handler()
+
+	tests := []struct {
+		name          string
+		target        string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "missing token", target: "/api/v1/clusters", wantStatus: http.StatusUnauthorized},
+		{
+			name:          "wrong token",
+			target:        "/api/v1/clusters",
+			authorization: "Bearer nope",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong scheme",
+			target:        "/api/v1/clusters",
+			authorization: "Basic s3cret",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "valid token",
+			target:        "/api/v1/clusters",
+			authorization: "Bearer s3cret",
+			wantStatus:    http.StatusOK,
+		},
+		{name: "open health path", target: "/healthz", wantStatus: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequestWithContext(
+				context.Background(),
+				http.MethodGet,
+				test.target,
+				strings.NewReader(""),
+			)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.wantStatus, recorder.Code)
+		})
+	}
+}
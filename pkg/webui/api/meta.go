@@ -81,6 +81,11 @@ func clusterMeta() metaResponse {
 		component("loadBalancer", new(v1alpha1.LoadBalancer)),
 		component("certManager", new(v1alpha1.CertManager)),
 		component("policyEngine", new(v1alpha1.PolicyEngine)),
+		component("ingressController", new(v1alpha1.IngressController)),
+		component("gatewayAPI", new(v1alpha1.GatewayAPI)),
+		component("serviceMesh", new(v1alpha1.ServiceMesh)),
+		component("knative", new(v1alpha1.Knative)),
+		component("identityProvider", new(v1alpha1.IdentityProvider)),
 		component("gitOpsEngine", new(v1alpha1.GitOpsEngine)),
 	}
 
@@ -12,6 +12,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -57,6 +58,10 @@ type Server struct {
 	BindAddress string
 	// OIDC configures app-driven OIDC authentication; empty IssuerURL disables it.
 	OIDC OIDCConfig
+	// Token, when non-empty, requires every guarded API request to carry it as a bearer token
+	// (Authorization: Bearer <token>). `ksail serve` sets it so programmatic clients authenticate
+	// without a browser session; it applies in addition to OIDC when both are configured.
+	Token string
 
 	// Distributions lists the distributions the create form should offer. When empty the SPA falls
 	// back to its built-in default (VCluster), so the operator can leave it unset.
@@ -323,6 +328,11 @@ func (s *Server) registerCapabilityRoutes(mux *http.ServeMux) {
 		mux.HandleFunc("POST /api/v1/clusters/{namespace}/{name}/apply", s.handleApply)
 	}
 
+	// Manifest diff (DiffService). A POST that only previews changes, so the read-only guard exempts it.
+	if _, ok := s.Service.(DiffService); ok {
+		mux.HandleFunc("POST /api/v1/clusters/{namespace}/{name}"+diffPathSuffix, s.handleDiff)
+	}
+
 	// SOPS secret cipher with local age keys (CipherService). Cluster-independent local crypto.
 	// encrypt/decrypt are POST, so the read-only guard rejects them in read-only mode. This is
 	// intentional: a read-only deployment locks down secret operations entirely — including decrypt,
@@ -473,12 +483,28 @@ func spaFileServer(fsys fs.FS) http.Handler {
 	})
 }
 
-// authGuard requires a valid session for cluster endpoints when OIDC is enabled. Health checks,
-// the config endpoint, and the auth flow itself remain open so the SPA can detect the mode and log
-// in. When OIDC is disabled the guard is a pass-through.
+// authGuard requires a valid bearer token (when Token is set) and a valid session (when OIDC is
+// enabled) for cluster endpoints. Health checks, the config endpoint, and the auth flow itself remain
+// open so the SPA can detect the mode and log in. With neither configured the guard is a
+// pass-through.
 func (s *Server) authGuard(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-		if s.auth == nil || isOpenPath(request.URL.Path) {
+		if isOpenPath(request.URL.Path) {
+			next.ServeHTTP(writer, request)
+
+			return
+		}
+
+		if s.Token != "" && !hasBearerToken(request, s.Token) {
+			writer.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(writer, http.StatusUnauthorized, map[string]any{
+				errorJSONKey: "valid bearer token required",
+			})
+
+			return
+		}
+
+		if s.auth == nil {
 			next.ServeHTTP(writer, request)
 
 			return
@@ -498,6 +524,17 @@ func (s *Server) authGuard(next http.Handler) http.Handler {
 	})
 }
 
+// hasBearerToken reports whether request carries token in its Authorization header. The comparison
+// is constant-time so response timing does not leak the token.
+func hasBearerToken(request *http.Request, token string) bool {
+	presented, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
 // isOpenPath reports whether a path is reachable without an authenticated session. Cluster API
 // paths stay guarded; everything else (health, config, meta, the auth flow, and the SPA's static
 // assets/client routes) is open so the login screen and its assets load before a session exists —
@@ -536,11 +573,12 @@ func writeReadOnlyError(writer http.ResponseWriter) {
 
 // readOnlyGuard rejects mutating cluster requests with 403 when the server is in read-only mode.
 // Auth endpoints (e.g. POST /api/v1/auth/logout) are exempt: read-only constrains cluster
-// mutations, not session management, so users must still be able to log out.
+// mutations, not session management, so users must still be able to log out. The manifest diff POST
+// is exempt too: it only previews changes.
 func (s *Server) readOnlyGuard(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		isAuthPath := strings.HasPrefix(request.URL.Path, "/api/v1/auth/")
-		if s.ReadOnly && isMutating(request.Method) && !isAuthPath {
+		if s.ReadOnly && isMutating(request.Method) && !isAuthPath && !isDiffRequest(request) {
 			writeReadOnlyError(writer)
 
 			return
@@ -578,6 +616,7 @@ func (s *Server) handleConfig(writer http.ResponseWriter, request *http.Request)
 	_, capabilities.WorkloadWrite = s.Service.(ResourceWriter)
 	_, capabilities.KubeconfigDownload = s.Service.(KubeconfigProvider)
 	_, capabilities.ApplyManifests = s.Service.(ApplyService)
+	_, capabilities.ManifestDiff = s.Service.(DiffService)
 	_, capabilities.SecretsCipher = s.Service.(CipherService)
 	_, capabilities.WorkloadLogs = s.Service.(LogService)
 	_, capabilities.WorkloadExec = s.Service.(ExecService)
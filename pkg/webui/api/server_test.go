@@ -71,7 +71,8 @@ func TestConfigReportsReadOnly(t *testing.T) {
 		`{"readOnly":true,"authEnabled":false,`+
 			`"capabilities":{"clusterUpdate":true,"workloadRead":false,`+
 			`"workloadWrite":false,"kubeconfigDownload":false,`+
-			`"applyManifests":false,"secretsCipher":false,"workloadLogs":false,"workloadExec":false,`+
+			`"applyManifests":false,"manifestDiff":false,"secretsCipher":false,"workloadLogs":false,`+
+			`"workloadExec":false,`+
 			`"clusterStartStop":false,"componentsInstall":true,"plugins":false,`+
 			`"aiChat":false,"kubeProxy":false,"pluginInstall":false,"aiChatWrite":false,"pluginCatalog":false,`+
 			`"kubeWatch":false,"wsMultiplexer":false}}`,
@@ -377,7 +378,8 @@ func TestConfigDefaultsWritable(t *testing.T) {
 		`{"readOnly":false,"authEnabled":false,`+
 			`"capabilities":{"clusterUpdate":true,"workloadRead":false,`+
 			`"workloadWrite":false,"kubeconfigDownload":false,`+
-			`"applyManifests":false,"secretsCipher":false,"workloadLogs":false,"workloadExec":false,`+
+			`"applyManifests":false,"manifestDiff":false,"secretsCipher":false,"workloadLogs":false,`+
+			`"workloadExec":false,`+
 			`"clusterStartStop":false,"componentsInstall":true,"plugins":false,`+
 			`"aiChat":false,"kubeProxy":false,"pluginInstall":false,"aiChatWrite":false,"pluginCatalog":false,`+
 			`"kubeWatch":false,"wsMultiplexer":false}}`,
@@ -401,7 +403,8 @@ func TestConfigReportsNoClusterUpdateWithoutUpdater(t *testing.T) {
 		recorder.Body.String(),
 		`"capabilities":{"clusterUpdate":false,"workloadRead":false,`+
 			`"workloadWrite":false,"kubeconfigDownload":false,`+
-			`"applyManifests":false,"secretsCipher":false,"workloadLogs":false,"workloadExec":false,`+
+			`"applyManifests":false,"manifestDiff":false,"secretsCipher":false,"workloadLogs":false,`+
+			`"workloadExec":false,`+
 			`"clusterStartStop":false,"componentsInstall":false,"plugins":false,`+
 			`"aiChat":false,"kubeProxy":false,"pluginInstall":false,"aiChatWrite":false,"pluginCatalog":false,`+
 			`"kubeWatch":false,"wsMultiplexer":false}`,
@@ -423,7 +426,8 @@ func TestConfigReportsClusterUpdateWithUpdater(t *testing.T) {
 		recorder.Body.String(),
 		`"capabilities":{"clusterUpdate":true,"workloadRead":false,`+
 			`"workloadWrite":false,"kubeconfigDownload":false,`+
-			`"applyManifests":false,"secretsCipher":false,"workloadLogs":false,"workloadExec":false,`+
+			`"applyManifests":false,"manifestDiff":false,"secretsCipher":false,"workloadLogs":false,`+
+			`"workloadExec":false,`+
 			`"clusterStartStop":false,"componentsInstall":false,"plugins":false,`+
 			`"aiChat":false,"kubeProxy":false,"pluginInstall":false,"aiChatWrite":false,"pluginCatalog":false,`+
 			`"kubeWatch":false,"wsMultiplexer":false}`,
@@ -445,7 +449,8 @@ func TestConfigReportsClusterUpdateForOperatorBackend(t *testing.T) {
 		recorder.Body.String(),
 		`"capabilities":{"clusterUpdate":true,"workloadRead":false,`+
 			`"workloadWrite":false,"kubeconfigDownload":false,`+
-			`"applyManifests":false,"secretsCipher":false,"workloadLogs":false,"workloadExec":false,`+
+			`"applyManifests":false,"manifestDiff":false,"secretsCipher":false,"workloadLogs":false,`+
+			`"workloadExec":false,`+
 			`"clusterStartStop":false,"componentsInstall":true,"plugins":false,`+
 			`"aiChat":false,"kubeProxy":false,"pluginInstall":false,"aiChatWrite":false,"pluginCatalog":false,`+
 			`"kubeWatch":false,"wsMultiplexer":false}`,
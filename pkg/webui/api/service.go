@@ -130,6 +130,10 @@ type Capabilities struct {
 	// true exactly when the serving ClusterService implements ApplyService. Combined with !readOnly
 	// before the SPA shows the apply affordance.
 	ApplyManifests bool `json:"applyManifests"`
+	// ManifestDiff reports whether the backend can preview how raw manifests would change a cluster —
+	// true exactly when the serving ClusterService implements DiffService. A preview does not mutate,
+	// so it is not combined with !readOnly.
+	ManifestDiff bool `json:"manifestDiff"`
 	// SecretsCipher reports whether the backend can encrypt/decrypt secrets with SOPS using the local
 	// age keys — true exactly when the serving ClusterService implements CipherService. The SPA shows
 	// the Secrets view only then (local backend only; the operator has no local keys).
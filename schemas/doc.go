@@ -1,8 +1,10 @@
 // Package schemas contains the KSail JSON schema and its generator.
 //
 // The schema file (ksail-config.schema.json) is generated by gen_schema.go
-// which reflects on the v1alpha1.Cluster type to produce a JSON Schema.
-// Run `go generate ./schemas/...` to regenerate.
+// which reflects on the v1alpha1.Cluster type to produce a JSON Schema. The
+// schemas of the scaffolded distribution configs (kind-config.schema.json,
+// k3d-config.schema.json and talos-config.schema.json) are generated alongside
+// it by gen_schema_distributions.go. Run `go generate ./schemas/...` to regenerate.
 //
-//go:generate go run gen_schema.go
+//go:generate go run gen_schema.go gen_schema_distributions.go
 package schemas
@@ -9,7 +9,10 @@
 //
 // Usage:
 //
-//	go run gen_schema.go [output-path]
+//	go run gen_schema.go gen_schema_distributions.go [output-path]
+//
+// The Kind, K3d and Talos schemas (see gen_schema_distributions.go) are written
+// next to the KSail schema.
 package main
 
 import (
@@ -42,6 +45,10 @@ const (
 	// path, so "<module>/schemas" + "../pkg/apis/cluster/v1alpha1" cleans to the
 	// v1alpha1 package's real import path.
 	commentImportBase = "github.com/devantler-tech/ksail/v7/schemas"
+
+	// specDefinition is the $defs key of the spec schema, shared by spec and
+	// every profile.
+	specDefinition = "Spec"
 )
 
 // errSchemaPathMissing reports that a schema customization targeted a property
@@ -93,7 +100,7 @@ func run(args []string) error {
 
 	fmt.Printf("gen_schema: wrote %s (%d bytes)\n", outputPath, len(schemaJSON))
 
-	return nil
+	return writeDistributionSchemas(filepath.Dir(outputPath))
 }
 
 // customizeSchema applies all schema customizations.
@@ -144,7 +151,58 @@ func customizeSchema(schema *jsonschema.Schema) error {
 		return err
 	}
 
-	return addDistributionProviderConstraints(schema)
+	if err := addDistributionProviderConstraints(schema); err != nil {
+		return err
+	}
+
+	if err := addProfiles(schema); err != nil {
+		return err
+	}
+
+	addExtends(schema)
+
+	return nil
+}
+
+// addProfiles adds the top-level profiles section: named overlays of spec that
+// the config manager merges onto the base spec when selected with --profile or
+// KSAIL_PROFILE (see applyProfile in pkg/fsutil/configmanager/ksail). Profiles
+// only exist in ksail.yaml, so they are not part of the Cluster type or the CRD.
+// The spec schema moves to $defs so spec and every profile reference one copy.
+func addProfiles(schema *jsonschema.Schema) error {
+	spec := propertyAt(schema, "spec")
+	if spec == nil {
+		return fmt.Errorf("%w: spec", errSchemaPathMissing)
+	}
+
+	if schema.Definitions == nil {
+		schema.Definitions = jsonschema.Definitions{}
+	}
+
+	schema.Definitions[specDefinition] = spec
+	specRef := "#/$defs/" + specDefinition
+
+	schema.Properties.Set("spec", &jsonschema.Schema{Ref: specRef})
+	schema.Properties.Set("profiles", &jsonschema.Schema{
+		Type: "object",
+		Description: "Named overlays of spec (e.g. minimal, full, ci), selected with --profile or " +
+			"KSAIL_PROFILE. Mappings merge key by key onto the base spec; lists and scalars replace it.",
+		AdditionalProperties: &jsonschema.Schema{Ref: specRef},
+	})
+
+	return nil
+}
+
+// addExtends adds the top-level extends key: a shared base ksail.yaml the config
+// manager fetches and merges under the local file (see applyExtends in
+// pkg/fsutil/configmanager/ksail). Like profiles, it only exists in ksail.yaml.
+func addExtends(schema *jsonschema.Schema) {
+	schema.Properties.Set("extends", &jsonschema.Schema{
+		Type:    "string",
+		Pattern: "^(oci|https)://",
+		Description: "Shared base ksail.yaml to inherit from, as an OCI artifact (oci://) or HTTPS URL. " +
+			"The base is merged under this file, so local values win.",
+	})
 }
 
 // propertyAt returns the schema node at the given property path, or nil when
@@ -0,0 +1,181 @@
+// Copyright (c) KSail contributors. All rights reserved.
+// Licensed under the PolyForm Shield License 1.0.0. See LICENSE in the project root.
+
+//go:build ignore
+
+// gen_schema_distributions.go generates the JSON schemas of the distribution
+// configuration files KSail scaffolds next to ksail.yaml (kind.yaml, k3d.yaml and
+// the Talos patches), so `ksail project init --schema` can point editors at them.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	k3dv1alpha5 "github.com/k3d-io/k3d/v5/pkg/config/v1alpha5"
+	kindv1alpha4 "sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+)
+
+const (
+	// kindSchemaFile is the schema of kind.yaml, reflected from the Kind v1alpha4 Cluster type.
+	kindSchemaFile = "kind-config.schema.json"
+	// k3dSchemaFile is the schema of k3d.yaml, as published by k3d for its v1alpha5 SimpleConfig.
+	k3dSchemaFile = "k3d-config.schema.json"
+	// talosSchemaFile is the schema of the Talos patches, as published by Talos for its
+	// machine configuration documents.
+	talosSchemaFile = "talos-config.schema.json"
+
+	kindModule   = "sigs.k8s.io/kind"
+	kindTypesDir = "pkg/apis/config/v1alpha4"
+
+	talosMachineryModule = "github.com/siderolabs/talos/pkg/machinery"
+	talosSchemaPath      = "config/schemas/config.schema.json"
+)
+
+// writeDistributionSchemas writes the Kind, K3d and Talos schemas to dir. The
+// versions follow go.mod, so the schemas match the configuration KSail reads.
+func writeDistributionSchemas(dir string) error {
+	kindSchema, err := kindSchemaJSON()
+	if err != nil {
+		return err
+	}
+
+	talosSchema, err := talosSchemaJSON()
+	if err != nil {
+		return err
+	}
+
+	schemas := []struct {
+		name    string
+		content []byte
+	}{
+		{name: kindSchemaFile, content: kindSchema},
+		{name: k3dSchemaFile, content: []byte(k3dv1alpha5.JSONSchema)},
+		{name: talosSchemaFile, content: talosSchema},
+	}
+
+	for _, schema := range schemas {
+		outputPath := filepath.Join(dir, schema.name)
+
+		if err := os.WriteFile(outputPath, schema.content, filePermissions); err != nil {
+			return fmt.Errorf("write schema to %s: %w", outputPath, err)
+		}
+
+		fmt.Printf("gen_schema: wrote %s (%d bytes)\n", outputPath, len(schema.content))
+	}
+
+	return nil
+}
+
+// kindSchemaJSON reflects the Kind v1alpha4 Cluster type, taking descriptions
+// from the Go doc comments of the Kind module in the module cache.
+func kindSchemaJSON() ([]byte, error) {
+	reflector := &jsonschema.Reflector{
+		AllowAdditionalProperties: false,
+		DoNotReference:            true,
+	}
+
+	moduleDir, err := moduleDir(kindModule)
+	if err != nil {
+		return nil, err
+	}
+
+	// The comment extractor derives import paths from base joined with each file's
+	// directory relative to the working directory, so extract from the module root.
+	err = inDir(moduleDir, func() error {
+		return reflector.AddGoComments(kindModule, kindTypesDir)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("extract Go comments from %s: %w", kindModule, err)
+	}
+
+	schema := reflector.Reflect(&kindv1alpha4.Cluster{})
+	schema.ID = ""
+	schema.Title = "Kind Cluster Configuration"
+	schema.Description = "JSON schema for the Kind cluster configuration KSail scaffolds (kind.yaml)"
+
+	// Every Kind field is optional and defaulted by Kind itself.
+	walkSchema(schema, func(s *jsonschema.Schema) {
+		s.Required = nil
+	})
+
+	if p, ok := schema.Properties.Get("kind"); ok && p != nil {
+		p.Enum = []any{"Cluster"}
+	}
+
+	if p, ok := schema.Properties.Get("apiVersion"); ok && p != nil {
+		p.Enum = []any{"kind.x-k8s.io/v1alpha4"}
+	}
+
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal kind schema: %w", err)
+	}
+
+	return schemaJSON, nil
+}
+
+// talosSchemaJSON reads the machine configuration schema shipped with the Talos
+// machinery module. Its oneOf over the config documents accepts the strategic
+// merge patches and multi-document resources KSail scaffolds.
+func talosSchemaJSON() ([]byte, error) {
+	moduleDir, err := moduleDir(talosMachineryModule)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaPath := filepath.Join(moduleDir, talosSchemaPath)
+
+	content, err := os.ReadFile(schemaPath) //nolint:gosec // module cache path from go list
+	if err != nil {
+		return nil, fmt.Errorf("read talos schema %s: %w", schemaPath, err)
+	}
+
+	return content, nil
+}
+
+// moduleDir returns the directory of a go.mod dependency in the module cache.
+func moduleDir(module string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", module)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("locate module %s: %w: %s", module, err, stderr.String())
+	}
+
+	dir := strings.TrimSpace(stdout.String())
+	if dir == "" {
+		return "", fmt.Errorf("locate module %s: not downloaded (run go mod download)", module)
+	}
+
+	return dir, nil
+}
+
+// inDir runs fn with dir as the working directory, restoring the previous one afterwards.
+func inDir(dir string, fn func() error) error {
+	previous, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		return fmt.Errorf("change to %s: %w", dir, err)
+	}
+
+	fnErr := fn()
+
+	if err := os.Chdir(previous); err != nil {
+		return fmt.Errorf("change back to %s: %w", previous, err)
+	}
+
+	return fnErr
+}
@@ -57,6 +57,7 @@ func generateSchemaFile(t *testing.T) string {
 		"go",
 		"run",
 		"gen_schema.go",
+		"gen_schema_distributions.go",
 		outPath,
 	)
 
@@ -125,6 +126,7 @@ func TestGeneratedSchema(t *testing.T) {
 	testMetadataNameConstraints(t, schema)
 	testDeprecatedAliases(t, schema)
 	testDistributionProviderConstraints(t, schema)
+	testProfilesReferenceSpec(t, schema)
 }
 
 // testClusterEnums asserts the distribution and provider enum surfaces carry
@@ -362,6 +364,18 @@ func schemaValidationCases() []schemaValidationCase {
 				`"spec":{"cluster":{"provider":"AWS"}}}`,
 			wantValid: true,
 		},
+		{
+			name: "profile overlaying the spec",
+			instance: `{"apiVersion":"ksail.io/v1alpha1","kind":"Cluster",` +
+				`"profiles":{"ci":{"cluster":{"workers":0}}}}`,
+			wantValid: true,
+		},
+		{
+			name: "profile with unknown spec field",
+			instance: `{"apiVersion":"ksail.io/v1alpha1","kind":"Cluster",` +
+				`"profiles":{"ci":{"clusters":{}}}}`,
+			wantValid: false,
+		},
 		{
 			name: "cluster name violating pattern",
 			instance: `{"apiVersion":"ksail.io/v1alpha1","kind":"Cluster",` +
@@ -391,12 +405,29 @@ func testNoRequiredFields(t *testing.T, schema map[string]any) {
 	})
 }
 
+func testProfilesReferenceSpec(t *testing.T, schema map[string]any) {
+	t.Helper()
+
+	t.Run("spec defined once and referenced by profiles", func(t *testing.T) {
+		t.Parallel()
+
+		props := mustMap(t, schema["properties"], "properties")
+		specRef := mustMap(t, props[specKey], specKey)["$ref"]
+		profileRef := mustMap(t, mustProp(t, schema, "profiles")["additionalProperties"],
+			"profiles.additionalProperties")["$ref"]
+
+		if specRef != "#/$defs/Spec" || profileRef != specRef {
+			t.Errorf("spec $ref = %v, profiles $ref = %v, want both #/$defs/Spec", specRef, profileRef)
+		}
+	})
+}
+
 func mustProp(t *testing.T, schema map[string]any, key string) map[string]any {
 	t.Helper()
 
 	props := mustMap(t, schema["properties"], "properties")
 
-	return mustMap(t, props[key], key)
+	return resolveRef(t, schema, mustMap(t, props[key], key))
 }
 
 func mustNestedProp(t *testing.T, schema map[string]any, keys ...string) map[string]any {
@@ -405,12 +436,30 @@ func mustNestedProp(t *testing.T, schema map[string]any, keys ...string) map[str
 	current := schema
 	for _, key := range keys {
 		props := mustMap(t, current["properties"], "properties")
-		current = mustMap(t, props[key], key)
+		current = resolveRef(t, schema, mustMap(t, props[key], key))
 	}
 
 	return current
 }
 
+// resolveRef returns the $defs entry of root that node references, or node
+// itself when it is not a reference.
+func resolveRef(t *testing.T, root, node map[string]any) map[string]any {
+	t.Helper()
+
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node
+	}
+
+	name, found := strings.CutPrefix(ref, "#/$defs/")
+	if !found {
+		t.Fatalf("unsupported $ref %q", ref)
+	}
+
+	return mustMap(t, mustMap(t, root["$defs"], "$defs")[name], ref)
+}
+
 func mustMap(t *testing.T, v any, path string) map[string]any {
 	t.Helper()
 
@@ -490,3 +539,103 @@ func forEachDescription(node any, visit func(string)) {
 		}
 	}
 }
+
+// TestDistributionSchemaValidation compiles the Kind, K3d and Talos schemas written
+// next to the KSail schema and exercises them with documents shaped like the files
+// `ksail project init` scaffolds.
+func TestDistributionSchemaValidation(t *testing.T) {
+	t.Parallel()
+
+	schemaDir := filepath.Dir(generateSchemaFile(t))
+
+	for _, testCase := range distributionSchemaValidationCases() {
+		t.Run(testCase.schemaFile+"/"+testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			sch, err := jsonschema.NewCompiler().Compile(filepath.Join(schemaDir, testCase.schemaFile))
+			if err != nil {
+				t.Fatalf("compile %s: %v", testCase.schemaFile, err)
+			}
+
+			instance, err := jsonschema.UnmarshalJSON(strings.NewReader(testCase.instance))
+			if err != nil {
+				t.Fatalf("unmarshal instance: %v", err)
+			}
+
+			err = sch.Validate(instance)
+			if testCase.wantValid && err != nil {
+				t.Errorf("expected instance to validate, got: %v", err)
+			}
+
+			if !testCase.wantValid && err == nil {
+				t.Error("expected a validation error, got none")
+			}
+		})
+	}
+}
+
+type distributionSchemaValidationCase struct {
+	schemaValidationCase
+
+	schemaFile string
+}
+
+func distributionSchemaValidationCases() []distributionSchemaValidationCase {
+	const (
+		kindSchema  = "kind-config.schema.json"
+		k3dSchema   = "k3d-config.schema.json"
+		talosSchema = "talos-config.schema.json"
+	)
+
+	return []distributionSchemaValidationCase{
+		{
+			schemaFile: kindSchema,
+			schemaValidationCase: schemaValidationCase{
+				name: "scaffolded config",
+				instance: `{"kind":"Cluster","apiVersion":"kind.x-k8s.io/v1alpha4","name":"kind",` +
+					`"nodes":[{"role":"control-plane"}],"networking":{"disableDefaultCNI":true}}`,
+				wantValid: true,
+			},
+		},
+		{
+			schemaFile: kindSchema,
+			schemaValidationCase: schemaValidationCase{
+				name:      "unknown field",
+				instance:  `{"kind":"Cluster","apiVersion":"kind.x-k8s.io/v1alpha4","workers":2}`,
+				wantValid: false,
+			},
+		},
+		{
+			schemaFile: k3dSchema,
+			schemaValidationCase: schemaValidationCase{
+				name:      "scaffolded config",
+				instance:  `{"apiVersion":"k3d.io/v1alpha5","kind":"Simple","metadata":{"name":"k3d-default"}}`,
+				wantValid: true,
+			},
+		},
+		{
+			schemaFile: k3dSchema,
+			schemaValidationCase: schemaValidationCase{
+				name:      "wrong api version",
+				instance:  `{"apiVersion":"k3d.io/v1alpha4","kind":"Simple"}`,
+				wantValid: false,
+			},
+		},
+		{
+			schemaFile: talosSchema,
+			schemaValidationCase: schemaValidationCase{
+				name:      "strategic merge patch",
+				instance:  `{"cluster":{"allowSchedulingOnControlPlanes":true}}`,
+				wantValid: true,
+			},
+		},
+		{
+			schemaFile: talosSchema,
+			schemaValidationCase: schemaValidationCase{
+				name:      "unknown field",
+				instance:  `{"cluster":{"allowSchedulingOnControlPlane":true}}`,
+				wantValid: false,
+			},
+		},
+	}
+}
@@ -138,6 +138,9 @@ export interface Capabilities {
   // applyManifests is true when the backend can server-side-apply raw manifests. The SPA combines it
   // with !readOnly before showing the Apply YAML action.
   applyManifests: boolean;
+  // manifestDiff is true when the backend can preview how raw manifests would change a cluster. A
+  // preview does not mutate, so the SPA does not combine it with !readOnly.
+  manifestDiff: boolean;
   // secretsCipher is true when the backend can encrypt/decrypt secrets with SOPS via local age keys
   // (the local backend). The SPA shows the Secrets view only then.
   secretsCipher: boolean;
@@ -201,6 +204,7 @@ export const fullCapabilities: Capabilities = {
   workloadWrite: false,
   kubeconfigDownload: false,
   applyManifests: false,
+  manifestDiff: false,
   secretsCipher: false,
   workloadLogs: false,
   workloadExec: false,
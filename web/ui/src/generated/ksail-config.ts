@@ -71,6 +71,30 @@ export interface KSailClusterConfiguration {
        * distribution's built-in CNI; Cilium or Calico install that CNI instead.
        */
       cni?: "Default" | "Cilium" | "Calico";
+      /**
+       * Cilium holds options for the Cilium CNI (Hubble observability and
+       * kube-proxy replacement). Ignored unless cni is Cilium.
+       */
+      cilium?: {
+        /**
+         * Hubble flow observability. Default keeps the Cilium chart defaults; Enabled deploys Hubble and Hubble Relay (used by 'ksail workload network'); Disabled turns Hubble off.
+         */
+        hubble?: "Default" | "Enabled" | "Disabled";
+        /**
+         * Cilium eBPF kube-proxy replacement. Default enables it on Talos (which runs without kube-proxy) and keeps the chart default elsewhere; Enabled or Disabled override it.
+         */
+        kubeProxyReplacement?: "Default" | "Enabled" | "Disabled";
+      };
+      /**
+       * Network configures the pod and service networks, such as IPv6 or
+       * dual-stack addressing.
+       */
+      network?: {
+        /**
+         * IP family of pods and services: ipv4 (default), ipv6, or dual (dual-stack). ipv6 and dual are supported by Vanilla (Kind), K3s (K3d), and Talos on the Docker provider, whose Docker network is then created with IPv6 enabled.
+         */
+        ipFamily?: "ipv4" | "ipv6" | "dual";
+      };
       /**
        * CSI controls Container Storage Interface support. Default keeps the
        * distribution's behavior; Enabled installs a CSI driver
@@ -104,6 +128,71 @@ export interface KSailClusterConfiguration {
        * PolicyEngine selects the policy engine to install: None, Kyverno, or Gatekeeper.
        */
       policyEngine?: "None" | "Kyverno" | "Gatekeeper";
+      /**
+       * VulnerabilityPolicy scaffolds a Kyverno policy that blocks images whose
+       * vulnerability scan attestation reports findings at or above a severity,
+       * except allowlisted IDs.
+       */
+      vulnerabilityPolicy?: {
+        /**
+         * Severity is the lowest severity that blocks an image: None (default, no
+         * policy), Low, Medium, High, or Critical. Requires policyEngine: Kyverno.
+         */
+        severity?: "None" | "Low" | "Medium" | "High" | "Critical";
+        /**
+         * Allowlist lists vulnerability IDs (e.g. CVE-2024-3094 or GHSA-xxxx-xxxx-xxxx)
+         * that never block an image, for findings that are accepted or not exploitable.
+         */
+        allowlist?: string[];
+        /**
+         * Images lists the image reference patterns the policy applies to, such as
+         * "ghcr.io/my-org/*". Defaults to every image.
+         */
+        images?: string[];
+      };
+      /**
+       * IngressController selects the ingress controller to install: None, Nginx
+       * (ingress-nginx), Traefik, or Contour. On the Docker provider KSail maps host
+       * ports 80 and 443 onto the controller so ingresses answer on localhost.
+       */
+      ingressController?: "None" | "Nginx" | "Traefik" | "Contour";
+      /**
+       * GatewayAPI selects Gateway API support: None, CRDs (CRDs only), Cilium (the
+       * Cilium CNI's built-in controller), or Envoy (Envoy Gateway). When a
+       * controller is selected, the scaffolder emits an example Gateway and HTTPRoute.
+       */
+      gatewayAPI?: "None" | "CRDs" | "Cilium" | "Envoy";
+      /**
+       * ServiceMesh selects the service mesh to install: None, Istio, or Linkerd.
+       * KSail waits for the control plane to become ready, and the scaffolder
+       * enrolls the workload namespace in the mesh.
+       */
+      serviceMesh?: "None" | "Istio" | "Linkerd";
+      /**
+       * Istio holds options for the Istio service mesh (when serviceMesh is Istio).
+       */
+      istio?: {
+        /**
+         * Istio data-plane mode. Sidecar (default) injects an Envoy proxy into each pod; Ambient installs the Istio CNI node agent and ztunnel instead of sidecars.
+         */
+        mode?: "Sidecar" | "Ambient";
+      };
+      /**
+       * Knative controls whether Knative Serving is installed (Enabled or Disabled),
+       * networked by Kourier. On the Docker provider Kourier is mapped to host port
+       * 8080 and services get sslip.io magic DNS names that resolve to localhost.
+       */
+      knative?: "Enabled" | "Disabled";
+      /**
+       * Observability configures the observability stacks KSail installs, such as
+       * cluster-wide log aggregation.
+       */
+      observability?: {
+        /**
+         * Cluster-wide log aggregation stack. None (default) installs nothing; Loki installs Grafana Loki in single-binary mode plus Promtail as a DaemonSet shipping every container's logs to it.
+         */
+        logging?: "None" | "Loki";
+      };
       /**
        * LocalRegistry configures the host-local OCI registry (or an external
        * registry for cloud providers) used by GitOps workflows.
@@ -143,6 +232,46 @@ export interface KSailClusterConfiguration {
            */
           clusterTokenEnvVar?: string;
         };
+        /**
+         * Retention limits the artifact tags the local registry keeps. It is applied to
+         * the pushed repository after every `ksail workload push` and by
+         * `ksail registry prune-artifacts`. External registries are never pruned.
+         */
+        retention?: {
+          /**
+           * KeepLast is the number of most recently pushed tags kept per repository.
+           */
+          keepLast?: number;
+          /**
+           * MaxAge deletes tags pushed longer ago than this duration. Example: "168h".
+           */
+          maxAge?: string;
+        };
+      };
+      /**
+       * MirrorRegistryTLS controls whether the local mirror registries (pull-through
+       * caches created by --mirror-registry) serve HTTPS (Enabled or Disabled). When
+       * Enabled, KSail signs their certificates with a CA it generates under
+       * ~/.ksail/registry-tls and adds that CA to the nodes' containerd trust.
+       * Docker provider only.
+       */
+      mirrorRegistryTLS?: "Enabled" | "Disabled";
+      /**
+       * RegistryBinding controls the host address and ports the local and mirror
+       * registry containers are published on, so registries can be reached from
+       * other machines than the Docker host. Docker provider only.
+       */
+      registryBinding?: {
+        /**
+         * Host address the local and mirror registry ports are published on. Defaults to 127.0.0.1; use 0.0.0.0 or the address of a host interface to reach the registries from other machines, such as sibling VMs.
+         */
+        hostIP?: string;
+        /**
+         * Host ports to publish mirror registries on, keyed by the registry host they mirror (e.g. docker.io: 5001). Mirrors not listed are only reachable from the cluster's Docker network.
+         */
+        mirrorPorts?: {
+          [k: string]: number;
+        };
       };
       /**
        * GitOpsEngine selects the GitOps engine KSail bootstraps: None, Flux, or ArgoCD.
@@ -193,6 +322,56 @@ export interface KSailClusterConfiguration {
           publicKeys?: string[];
         };
       };
+      /**
+       * SealedSecrets controls whether the Bitnami Sealed Secrets controller is
+       * installed (Enabled or Disabled), so SealedSecret manifests sealed with
+       * `ksail workload cipher seal` can be committed and decrypted in-cluster.
+       */
+      sealedSecrets?: "Enabled" | "Disabled";
+      /**
+       * ExternalSecrets controls whether the External Secrets Operator is installed
+       * (Enabled or Disabled). When enabled, the scaffolder emits a ClusterSecretStore
+       * backed by ESO's fake provider so ExternalSecret manifests work locally.
+       */
+      externalSecrets?: "Enabled" | "Disabled";
+      /**
+       * Backup selects the in-cluster backup system: None or Velero. Velero is
+       * installed with a MinIO container on the cluster's Docker network as its
+       * S3 target and is driven by `ksail cluster backup --velero` and
+       * `ksail cluster restore-backup`.
+       */
+      backup?: "None" | "Velero";
+      /**
+       * ActionsRunner installs GitHub Actions Runner Controller with a runner scale
+       * set registered to a repository or organization, for testing CI jobs that
+       * need in-cluster runners.
+       */
+      actionsRunner?: {
+        /**
+         * Install GitHub Actions Runner Controller with a runner scale set registered to githubConfigURL. The token is read from the KSail credential store (github.token), falling back to GITHUB_TOKEN.
+         */
+        enabled?: boolean;
+        /**
+         * Repository (https://github.com/org/repo) or organization (https://github.com/org) URL the runners register with.
+         */
+        githubConfigURL?: string;
+        /**
+         * Runner scale set name that workflow jobs target with runs-on (default: ksail). Must be a DNS-1123 label.
+         */
+        name?: string;
+        /**
+         * Additional runs-on labels of the runner scale set.
+         */
+        labels?: string[];
+        /**
+         * Number of idle runners kept ready (default: 0).
+         */
+        minRunners?: number;
+        /**
+         * Maximum number of concurrent runners (default: unbounded).
+         */
+        maxRunners?: number;
+      };
       /**
        * @deprecated
        * Deprecated. Use autoscaler.node.enabled instead. Do not set both nodeAutoscaling and autoscaler.
@@ -320,6 +499,74 @@ export interface KSailClusterConfiguration {
        * Number of worker nodes to create for the cluster (provider/distribution-agnostic)
        */
       workers?: number;
+      /**
+       * Per-node customization selected by role (ControlPlane or Worker) and optional index: container CPU and memory limits, and kubelet-registered labels and taints. Supported by Vanilla (Kind), K3s (K3d), and Talos on the Docker provider.
+       */
+      nodes?: {
+        /**
+         * Role selects the nodes this entry applies to: ControlPlane or Worker.
+         */
+        role?: "ControlPlane" | "Worker";
+        /**
+         * Zero-based index of the node of the given role this entry applies to, in creation order. When unset the entry applies to every node of the role.
+         */
+        index?: number;
+        /**
+         * Resources limits the CPU and memory of the node container.
+         */
+        resources?: {
+          /**
+           * Number of CPUs the node container may use, as a Kubernetes quantity (e.g. 2 or 1500m). Empty leaves CPU unlimited.
+           */
+          cpu?: string;
+          /**
+           * Memory limit of the node container, as a Kubernetes quantity (e.g. 4Gi). Empty leaves memory unlimited.
+           */
+          memory?: string;
+        };
+        /**
+         * Kubernetes node labels registered by the kubelet of the selected nodes.
+         */
+        labels?: {
+          [k: string]: string;
+        };
+        /**
+         * Kubernetes node taints registered by the kubelet of the selected nodes. Control-plane nodes keep their default control-plane taint.
+         */
+        taints?: {
+          /**
+           * Key is the taint key. Must be a valid Kubernetes label key (an optional
+           * DNS-subdomain prefix followed by a name segment).
+           */
+          key?: string;
+          /**
+           * Value is the optional taint value.
+           */
+          value?: string;
+          /**
+           * Effect is the scheduling effect: NoSchedule, PreferNoSchedule, or NoExecute.
+           */
+          effect?: "NoSchedule" | "PreferNoSchedule" | "NoExecute";
+        }[];
+        /**
+         * NVIDIA GPUs of the Docker host passed through to the selected node containers: all, or a count of GPUs taken in device order. Requires the NVIDIA Container Toolkit on the host. Only Vanilla (Kind) and K3s (K3d) support it; K3d applies one request to every node.
+         */
+        gpus?: string;
+      }[];
+      /**
+       * Spot requests spot (preemptible) capacity for the worker node pools of
+       * cloud distributions (EKS, GKE, AKS), with a policy for reclaimed instances.
+       */
+      spot?: {
+        /**
+         * Run the worker node pools of EKS, GKE, and AKS clusters on spot (preemptible) capacity. Applied to gke.yaml node pools and aks.yaml user agent pools at create time; scaffolded into the eks.yaml managed node group. AKS system pools stay on-demand.
+         */
+        enabled?: boolean;
+        /**
+         * What happens when the cloud reclaims a spot instance. Replace (default) deletes it so the node pool provisions a replacement as soon as capacity returns (EKS capacity rebalancing, GKE managed instance groups, AKS eviction policy Delete). Deallocate stops the VM and keeps its disk for a later restart; AKS only.
+         */
+        reclaimPolicy?: "Replace" | "Deallocate";
+      };
       /**
        * Kubernetes version to deploy. When set: cluster create/update reconcile toward it. When unset: cluster update follows the latest stable version and new clusters use a default compatible with the pinned Talos version.
        */
@@ -361,6 +608,45 @@ export interface KSailClusterConfiguration {
          */
         caFile?: string;
       };
+      /**
+       * IdentityProvider selects a development identity provider to install: None
+       * or Keycloak. Keycloak is imported with a realm of test users, groups and an
+       * OIDC client, and the oidc options default to it, so OIDC login and RBAC
+       * group mapping can be tested locally. Vanilla and K3s on Docker only.
+       */
+      identityProvider?: "None" | "Keycloak";
+      /**
+       * Labels and annotations (e.g. team, environment, cost-center) KSail stamps onto every resource it creates: registry containers, namespaces, Helm releases, and scaffolded kustomizations.
+       */
+      resourceMetadata?: {
+        /**
+         * Labels KSail stamps onto every resource it creates (Docker containers, namespaces, Helm releases, scaffolded kustomizations). Keys and values must satisfy Kubernetes label syntax.
+         */
+        labels?: {
+          [k: string]: string;
+        };
+        /**
+         * Annotations KSail stamps onto namespaces it creates and scaffolded kustomizations. Keys must be Kubernetes qualified names.
+         */
+        annotations?: {
+          [k: string]: string;
+        };
+      };
+      /**
+       * Tenancy scopes the cluster's host-local resources (node containers, Docker network,
+       * registry containers, kubeconfig context) to a tenant so several users can share a
+       * Docker host. CLI-only; ignored by the operator.
+       */
+      tenancy?: {
+        /**
+         * Prefix the cluster name, node containers, Docker network, registry containers, and kubeconfig context with the tenant so several users can share a Docker host.
+         */
+        enabled?: boolean;
+        /**
+         * Tenant name prepended to resource names (lowercase RFC 1123 label). Defaults to the current OS user name.
+         */
+        prefix?: string;
+      };
       /**
        * Vanilla holds options specific to the Vanilla (Kind) distribution.
        */
@@ -370,6 +656,15 @@ export interface KSailClusterConfiguration {
          * Defaults to "kind/mirrors" if not specified.
          */
         mirrorsDir?: string;
+        /**
+         * NodeImageDockerfile is a Dockerfile snippet applied on top of the Kind node
+         * image, e.g. "RUN apt-get update && apt-get install -y nfs-common".
+         * When set, KSail builds a derived node image before creating the cluster,
+         * caches it in the local Docker image store, and reuses it while the base
+         * image and snippet are unchanged. The build context is empty, so fetch extra
+         * binaries with RUN or ADD <url> rather than COPY.
+         */
+        nodeImageDockerfile?: string;
       };
       /**
        * Talos holds options specific to the Talos distribution.
@@ -482,6 +777,31 @@ export interface KSailClusterConfiguration {
          * (naming the stuck volumes) rather than hanging. Example: "10m".
          */
         storageHealthTimeout?: string;
+        /**
+         * API tunes how KSail retries and waits on the Talos API. Unset fields use
+         * adaptive defaults that allow more headroom on CI machines (CI env var set).
+         */
+        api?: {
+          /**
+           * MaxAttempts is how many times a per-node Talos API call (config apply,
+           * reboot, upgrade, etcd operations) is attempted when it fails transiently,
+           * e.g. when a loaded machine is slow to accept its config. When unset, KSail
+           * uses 3, or 5 on CI machines.
+           */
+          maxAttempts?: number;
+          /**
+           * CallTimeout bounds each attempt of a per-node Talos API call, so a hung
+           * call is retried instead of consuming the whole operation budget. When
+           * unset, attempts are bounded only by the operation. Example: "2m".
+           */
+          callTimeout?: string;
+          /**
+           * HealthCheckGracePeriod is added to the cluster and node readiness timeouts
+           * (20m for Talos on cloud providers, 10m on Docker and per node during
+           * updates). When unset, KSail adds nothing, or 5m on CI machines. Example: "10m".
+           */
+          healthCheckGracePeriod?: string;
+        };
       };
       /**
        * EKS holds options specific to the EKS distribution.
@@ -547,6 +867,20 @@ export interface KSailClusterConfiguration {
          * Defaults to "HCLOUD_TOKEN".
          */
         tokenEnvVar?: string;
+        /**
+         * TokenCommand is a program and its arguments, run without a shell, that prints a
+         * short-lived Hetzner API token on stdout (for example a token exchange against a
+         * secrets manager). When set, KSail's own Hetzner API calls use it instead of
+         * TokenEnvVar, re-running it every TokenRefreshInterval and whenever the API
+         * rejects the token. In-cluster components (CCM, CSI, floating IP VIP) still
+         * receive the token from TokenEnvVar, as they outlive any single KSail run.
+         */
+        tokenCommand?: string[];
+        /**
+         * TokenRefreshInterval is how long a token printed by TokenCommand is reused
+         * before the command runs again. Defaults to 10m.
+         */
+        tokenRefreshInterval?: string;
         /**
          * PlacementGroupStrategy controls whether and how placement groups are used.
          * "Spread" (default) distributes servers across different physical hosts for HA.
@@ -595,6 +929,10 @@ export interface KSailClusterConfiguration {
          * CIDR blocks allowed to access the Kubernetes API and Talos API on control-plane nodes. When empty defaults to 0.0.0.0/0 and ::/0 (open to all IPv4 and IPv6).
          */
         allowedCidrs?: string[];
+        /**
+         * CIDR blocks allowed to reach the Kubernetes NodePort range (30000-32767, TCP and UDP) on every node. When empty NodePorts are not exposed publicly.
+         */
+        nodePortCidrs?: string[];
         /**
          * Assign a public IPv4 to worker nodes. Defaults to true. Set false for IPv4-less workers reached over the private network (requires private-network reachability and NAT egress).
          */
@@ -707,6 +1045,19 @@ export interface KSailClusterConfiguration {
          * Defaults to "AWS_SESSION_TOKEN".
          */
         sessionTokenEnvVar?: string;
+        /**
+         * AssumeRoleARN is an IAM role KSail assumes through AWS STS with the resolved
+         * credentials, so eksctl and the AWS SDK only ever receive short-lived session
+         * credentials. SDK calls refresh the session before it expires during long
+         * operations; each eksctl run receives a session valid for AssumeRoleDuration.
+         * Empty uses the resolved credentials directly.
+         */
+        assumeRoleArn?: string;
+        /**
+         * AssumeRoleDuration is the lifetime of each assumed-role session, between 15m
+         * and 12h and at most the role's maximum session duration. Defaults to 1h.
+         */
+        assumeRoleDuration?: string;
       };
       /**
        * GCP holds options for the Google Cloud provider used by the GKE distribution.
@@ -871,6 +1222,93 @@ export interface KSailClusterConfiguration {
           }[];
         };
       };
+      /**
+       * Argo CD bootstrap configuration: AppProject guardrails (source repos, destinations, sync windows) applied to the Application KSail generates. Empty keeps the unrestricted default project.
+       */
+      argoCD?: {
+        /**
+         * AppProject guardrails (source repos, destinations, sync windows) KSail reconciles as the 'ksail' AppProject and assigns to its Application. Empty keeps the unrestricted default project.
+         */
+        project?: {
+          /**
+           * Repository URLs (glob patterns allowed) the Application may sync from. Empty allows any repository. Must cover the repository KSail syncs from, or Argo CD rejects the sync.
+           */
+          sourceRepos?: string[];
+          /**
+           * Clusters and namespaces the Application may deploy to. Empty allows any destination.
+           */
+          destinations?: {
+            /**
+             * Cluster API server URL (glob patterns allowed, e.g. https://kubernetes.default.svc).
+             */
+            server?: string;
+            /**
+             * Cluster name as registered in Argo CD (glob patterns allowed). Alternative to server.
+             */
+            name?: string;
+            /**
+             * Target namespace (glob patterns allowed, e.g. team-*). Empty allows any namespace.
+             */
+            namespace?: string;
+          }[];
+          /**
+           * Time windows during which syncs are allowed or denied, evaluated by Argo CD exactly as in production.
+           */
+          syncWindows?: {
+            /**
+             * Whether syncs are allowed (allow) or blocked (deny) while the window is active.
+             */
+            kind?: "allow" | "deny";
+            /**
+             * Cron expression (five fields or a descriptor such as @daily) at which the window opens.
+             */
+            schedule?: string;
+            /**
+             * How long the window stays open after each scheduled start (Go duration, e.g. 1h or 30m).
+             */
+            duration?: string;
+            /**
+             * Application names (glob patterns allowed) the window applies to. Empty with no namespaces or clusters matches every application.
+             */
+            applications?: string[];
+            /**
+             * Destination namespaces (glob patterns allowed) the window applies to.
+             */
+            namespaces?: string[];
+            /**
+             * Destination clusters (glob patterns allowed) the window applies to.
+             */
+            clusters?: string[];
+            /**
+             * Allow manual syncs while a deny window is active.
+             */
+            manualSync?: boolean;
+            /**
+             * IANA time zone the schedule is evaluated in (e.g. Europe/Copenhagen). Empty uses UTC.
+             */
+            timeZone?: string;
+          }[];
+        };
+        /**
+         * Application (default) syncs the source directory with a single Application. ApplicationSet adds an ApplicationSet next to it that generates an Application per app directory or per registered cluster.
+         */
+        layout?: "Application" | "ApplicationSet";
+        /**
+         * Generator of the ApplicationSet KSail creates when layout is ApplicationSet.
+         */
+        applicationSet?: {
+          /**
+           * ApplicationSet generator. Directory (default) generates an Application per subdirectory of <sourceDirectory>/apps and requires spec.workload.git; Cluster generates an Application per Argo CD cluster matching clusterSelector.
+           */
+          generator?: "Directory" | "Cluster";
+          /**
+           * Labels of the Argo CD cluster secrets the Cluster generator deploys to. Required for the Cluster generator; the local cluster is synced by the root Application.
+           */
+          clusterSelector?: {
+            [k: string]: string;
+          };
+        };
+      };
       /**
        * Configuration for the workload watch command (pre-apply hooks, etc.)
        */
@@ -918,6 +1356,48 @@ export interface KSailClusterConfiguration {
          */
         complianceThreshold?: number;
       };
+      /**
+       * Git repository the GitOps engine tracks instead of the OCI artifact KSail pushes. Set by 'ksail init --push-to'; empty keeps the OCI source.
+       */
+      git?: {
+        /**
+         * HTTPS clone URL of the Git repository the GitOps engine tracks instead of the OCI artifact (e.g. https://github.com/org/repo.git). Set by 'ksail init --push-to'.
+         */
+        url?: string;
+        /**
+         * Branch the GitOps engine tracks. Defaults to main.
+         */
+        branch?: string;
+        /**
+         * Environment variable holding an access token for a private repository. When set and non-empty, KSail stores it in-cluster as the GitOps engine's repository credentials.
+         */
+        tokenEnvVar?: string;
+      };
+      /**
+       * Variables substituted into manifests by 'ksail workload apply' and 'ksail workload render' (Flux postBuild-style ${VAR} references), so manifests can reference cluster-specific values without per-developer overlays.
+       */
+      substitution?: {
+        /**
+         * Variables substituted for ${NAME} references. Overridden by the vars file, environment variables, and --var.
+         */
+        vars?: {
+          [k: string]: string;
+        };
+        /**
+         * Path to a dotenv-style file (KEY=VALUE per line) of variables, overriding vars. A relative path resolves against the ksail.yaml directory.
+         */
+        varsFile?: string;
+      };
+    };
+    /**
+     * Trust configures extra CA certificates installed into node trust stores and published to
+     * workloads, for networks behind a TLS-intercepting proxy.
+     */
+    trust?: {
+      /**
+       * Extra CA certificates to trust on nodes and in workloads. Each entry is a path to a PEM file (relative to ksail.yaml) or inline PEM content.
+       */
+      caBundles?: string[];
     };
     /**
      * Chat configures the KSail AI chat assistant.
@@ -933,5 +1413,23 @@ export interface KSailClusterConfiguration {
        */
       reasoningEffort?: "low" | "medium" | "high";
     };
+    /**
+     * Notify configures extra sinks (log files, syslog, a webhook) that keep a record of KSail's output.
+     * CLI-only; ignored by the operator (the Cluster CRD shares this type but never reads it).
+     */
+    notify?: {
+      /**
+       * Directory receiving one timestamped log file per operation (relative to ksail.yaml)
+       */
+      logDir?: string;
+      /**
+       * Send every message to the local syslog daemon (not supported on Windows)
+       */
+      syslog?: boolean;
+      /**
+       * URL receiving the messages of each operation as one JSON document when it finishes
+       */
+      webhook?: string;
+    };
   };
 }